@@ -8,10 +8,13 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/opencontainers/go-digest"
+
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/blob/compression"
 	"ocm.software/open-component-model/bindings/go/blob/direct"
@@ -19,15 +22,31 @@ import (
 
 // DirOptions contains options for creating a blob from a path.
 type DirOptions struct {
-	MediaType       string   // Media type of the resulting blob. If empty, defaults are used.
-	Compress        bool     // Compress resulting blob using gzip.
-	PreserveDir     bool     // Add parent directory to the tar archive.
-	Reproducible    bool     // Create a reproducible tar archive (fixed timestamps, uid/gid etc).
-	ExcludePatterns []string // Patterns to exclude (glob patterns). Applies to files and directories.
-	IncludePatterns []string // Patterns to include (glob patterns). Applies to files and directories.
-	WorkingDir      string   // Working directory to ensure the path is within and avoid path traversal.
+	MediaType       string        // Media type of the resulting blob. If empty, defaults are used.
+	Compress        bool          // Compress resulting blob using gzip.
+	PreserveDir     bool          // Add parent directory to the tar archive.
+	Reproducible    bool          // Create a reproducible tar archive (fixed timestamps, uid/gid etc).
+	ExcludePatterns []string      // Patterns to exclude (glob patterns). Applies to files and directories.
+	IncludePatterns []string      // Patterns to include (glob patterns). Applies to files and directories.
+	WorkingDir      string        // Working directory to ensure the path is within and avoid path traversal.
+	IgnoreFile      string        // Name of an ignore file in gitignore syntax in the directory. If empty, no ignore file is read.
+	Symlinks        SymlinkPolicy // Handling of symbolic links. Defaults to SymlinkPolicyReject.
 }
 
+// SymlinkPolicy defines how symbolic links are handled when adding directories.
+type SymlinkPolicy string
+
+const (
+	// SymlinkPolicyReject fails on symbolic links.
+	SymlinkPolicyReject SymlinkPolicy = ""
+	// SymlinkPolicySkip leaves out symbolic links.
+	SymlinkPolicySkip SymlinkPolicy = "skip"
+	// SymlinkPolicyFollow adds the content of the target of symbolic links under the name of the link.
+	// Targets have to be located below the root of the added directory. Links to a parent directory
+	// of the link are rejected, as they would result in an endless archive.
+	SymlinkPolicyFollow SymlinkPolicy = "follow"
+)
+
 // DefaultTarMediaType is used as blob media type for directories, if not set in the DirOptions.
 const DefaultTarMediaType = "application/x-tar"
 
@@ -48,7 +67,16 @@ const DefaultFileMediaType = "application/octet-stream"
 //
 // Paths and patterns are normalized to use forward slashes (`/`) as separators for matching
 // and have any leading `./` or `/` removed.
-// Symlinks are not supported so far and will result in an error.
+//
+// If an IgnoreFile is set and exists in the directory, its patterns (gitignore syntax, relative to
+// the directory) exclude files and directories in addition to the exclude patterns.
+// Only the ignore file in the directory itself is read, ignore files in subdirectories are added as regular files.
+//
+// Symlinks are handled according to the SymlinkPolicy and result in an error by default.
+//
+// Entries are added in lexical order, so the archive only depends on the content of the directory.
+// If Reproducible is set, the archive is byte-stable: timestamps and owners are reset and permissions
+// are normalized to 0755 for directories and executable files and 0644 for all other files.
 func GetBlobFromPath(ctx context.Context, path string, opt DirOptions) (blob.ReadOnlyBlob, error) {
 	// Validate the input path
 	if path == "" {
@@ -87,19 +115,9 @@ func GetBlobFromPath(ctx context.Context, path string, opt DirOptions) (blob.Rea
 // If requested it compresses the resulting blob using gzip.
 // It uses a virtual filesystem to read the directory contents and streams the TAR data using a pipe.
 func createDirBlob(ctx context.Context, path string, opt DirOptions) (blob.ReadOnlyBlob, error) {
-	// Determine filesystem root and start dir walk based on PreserveDir
-	baseFSPath := path
-	subPath := "."
-	if opt.PreserveDir {
-		// Root the virtual FS at the parent, and start walking at the preserved base directory name.
-		baseFSPath = filepath.Dir(path)
-		subPath = filepath.Base(path)
-	}
-
-	// Create a virtual filesystem rooted at baseFSPath
-	fileSystem, err := NewFS(baseFSPath, os.O_RDONLY)
+	fileSystem, subPath, err := openDirRoot(path, opt)
 	if err != nil {
-		return nil, fmt.Errorf("error creating virtual filesystem for path %q: %w", baseFSPath, err)
+		return nil, err
 	}
 
 	// Create TAR stream using pipe
@@ -146,6 +164,86 @@ func createDirBlob(ctx context.Context, path string, opt DirOptions) (blob.ReadO
 	return tarBlob, nil
 }
 
+// openDirRoot creates the virtual filesystem to read a directory from and returns it
+// together with the path to start the walk at.
+func openDirRoot(path string, opt DirOptions) (FileSystem, string, error) {
+	// Determine filesystem root and start dir walk based on PreserveDir
+	baseFSPath := path
+	subPath := "."
+	if opt.PreserveDir {
+		// Root the virtual FS at the parent, and start walking at the preserved base directory name.
+		baseFSPath = filepath.Dir(path)
+		subPath = filepath.Base(path)
+	}
+
+	// Create a virtual filesystem rooted at baseFSPath
+	fileSystem, err := NewFS(baseFSPath, os.O_RDONLY)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating virtual filesystem for path %q: %w", baseFSPath, err)
+	}
+	return fileSystem, subPath, nil
+}
+
+// FileEntry describes a file of a directory blob, see ListFiles.
+type FileEntry struct {
+	// Name is the name of the file in the TAR archive.
+	Name string `json:"name"`
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+	// Digest is the digest of the content of the file.
+	Digest string `json:"digest"`
+}
+
+// ListFiles lists the files GetBlobFromPath adds to the TAR archive of the directory at path with
+// the same options, in the order of the archive. Directory entries are not listed.
+// The options affecting only the resulting blob (MediaType, Compress, Reproducible) are ignored.
+func ListFiles(ctx context.Context, path string, opt DirOptions) ([]FileEntry, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path must not be empty")
+	}
+	if opt.WorkingDir != "" {
+		if _, err := ensurePathInWorkingDirectory(path, opt.WorkingDir); err != nil {
+			return nil, fmt.Errorf("error ensuring path %q in working directory %q: %w", path, opt.WorkingDir, err)
+		}
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error accessing path %q: %w", path, err)
+	}
+	if !fi.IsDir() {
+		return nil, fmt.Errorf("path %q is not a directory", path)
+	}
+
+	fileSystem, subPath, err := openDirRoot(path, opt)
+	if err != nil {
+		return nil, err
+	}
+	var entries []FileEntry
+	err = walkDir(ctx, fileSystem, subPath, opt, func(path string, fi fs.FileInfo) error {
+		if fi.IsDir() {
+			return nil
+		}
+		file, err := fileSystem.Open(path)
+		if err != nil {
+			return fmt.Errorf("error opening file %q for reading: %w", path, err)
+		}
+		dgst, err := digest.Canonical.FromReader(file)
+		if err = errors.Join(err, file.Close()); err != nil {
+			return fmt.Errorf("error calculating digest of file %q: %w", path, err)
+		}
+		entries = append(entries, FileEntry{
+			Name:   filepath.ToSlash(path),
+			Size:   fi.Size(),
+			Digest: dgst.String(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 // createSingleFileBlob creates a blob from the specified single file.
 func createSingleFileBlob(path string, opt DirOptions) (blob.ReadOnlyBlob, error) {
 	// Validate that include/exclude patterns are not used for single files
@@ -176,18 +274,60 @@ func createSingleFileBlob(path string, opt DirOptions) (blob.ReadOnlyBlob, error
 
 // createTarFromDir creates a TAR archive from the filesystem.
 // Uses the virtual filesystem to read the directory contents.
-// Uses fs.WalkDir to traverse the directory structure in a deterministic order.
+// Uses walkDir to traverse the directory structure in a deterministic order.
 // This is required to ensure reproducible TAR archives.
 func createTarFromDir(ctx context.Context, fileSystem FileSystem, subPath string, opt DirOptions, tw *tar.Writer) error {
-	return fs.WalkDir(fileSystem, subPath, func(path string, d fs.DirEntry, err error) error {
+	return walkDir(ctx, fileSystem, subPath, opt, func(path string, fi fs.FileInfo) error {
+		if fi.IsDir() {
+			return writeDirectory(path, fi, opt, tw)
+		}
+		return writeFile(path, fi, fileSystem, opt, tw)
+	})
+}
+
+// walkFunc is called by walkDir for every entry added to the blob.
+// For followed symlinks, fi describes the target of the link.
+type walkFunc func(path string, fi fs.FileInfo) error
+
+// dirWalker walks a directory and filters its entries by the options of the blob.
+type dirWalker struct {
+	ctx        context.Context
+	fileSystem FileSystem
+	subPath    string
+	opt        DirOptions
+	ignore     *ignoreMatcher
+	fn         walkFunc
+}
+
+// walkDir walks the directory at subPath in lexical order and calls fn for every entry that is
+// not excluded by the ignore file or the include and exclude patterns.
+// Symlinks are handled according to the SymlinkPolicy of opt.
+func walkDir(ctx context.Context, fileSystem FileSystem, subPath string, opt DirOptions, fn walkFunc) error {
+	ignore, err := loadIgnoreFile(fileSystem, subPath, opt.IgnoreFile)
+	if err != nil {
+		return err
+	}
+	w := &dirWalker{
+		ctx:        ctx,
+		fileSystem: fileSystem,
+		subPath:    filepath.ToSlash(subPath),
+		opt:        opt,
+		ignore:     ignore,
+		fn:         fn,
+	}
+	return w.walk(w.subPath)
+}
+
+func (w *dirWalker) walk(root string) error {
+	return fs.WalkDir(w.fileSystem, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("error walking path %q: %w", path, err)
 		}
 
 		// Check context before processing each entry
 		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context cancelled while processing %q: %w", path, ctx.Err())
+		case <-w.ctx.Done():
+			return fmt.Errorf("context cancelled while processing %q: %w", path, w.ctx.Err())
 		default:
 		}
 
@@ -197,25 +337,42 @@ func createTarFromDir(ctx context.Context, fileSystem FileSystem, subPath string
 			return fmt.Errorf("error getting file info for %q: %w", path, err)
 		}
 
-		// Reject symlinks
 		if (fi.Mode() & fs.ModeSymlink) != 0 {
-			return fmt.Errorf("symlinks are not supported yet: found symlink %q", path)
+			switch w.opt.Symlinks {
+			case SymlinkPolicySkip:
+				return nil
+			case SymlinkPolicyFollow:
+				if fi, err = w.fileSystem.Stat(path); err != nil {
+					return fmt.Errorf("error following symlink %q: %w", path, err)
+				}
+				if fi.IsDir() {
+					// the walk of the target reports the link itself as its root directory.
+					if err := w.checkLoop(path, fi); err != nil {
+						return err
+					}
+					return w.walk(path)
+				}
+			case SymlinkPolicyReject:
+				return fmt.Errorf("symlinks are not supported without a symlink policy: found symlink %q", path)
+			default:
+				return fmt.Errorf("unknown symlink policy %q", w.opt.Symlinks)
+			}
 		}
 
 		// Process directory or file
 		if fi.IsDir() {
-			return processDirectory(path, fi, opt, tw)
+			return w.visitDirectory(path, fi)
 		}
-		return processFile(path, fi, fileSystem, opt, tw)
+		return w.visitFile(path, fi)
 	})
 }
 
-// processDirectory handles directory entries during DirWalk
-// Prune on exclude, optionally write header if included, always traverse
-func processDirectory(path string, fi fs.FileInfo, opt DirOptions, tw *tar.Writer) error {
+// visitDirectory handles directory entries during the walk.
+// Prune on exclude, optionally report the directory if included, always traverse.
+func (w *dirWalker) visitDirectory(path string, fi fs.FileInfo) error {
 	// Exclude precedence: if directory matches any exclude pattern -> prune subtree by skipping dir
-	if len(opt.ExcludePatterns) > 0 {
-		inc, err := isPathIncluded(path, nil, opt.ExcludePatterns)
+	if len(w.opt.ExcludePatterns) > 0 {
+		inc, err := isPathIncluded(path, nil, w.opt.ExcludePatterns)
 		if err != nil {
 			return fmt.Errorf("error checking exclusion of directory %q: %w", path, err)
 		}
@@ -223,41 +380,81 @@ func processDirectory(path string, fi fs.FileInfo, opt DirOptions, tw *tar.Write
 			return fs.SkipDir
 		}
 	}
+	if w.ignore.Ignored(w.relative(path), true) {
+		return fs.SkipDir
+	}
 
-	// Write directory header only if the directory path itself is included
-	inc, err := isPathIncluded(path, opt.IncludePatterns, opt.ExcludePatterns)
+	// Report directory only if the directory path itself is included
+	inc, err := isPathIncluded(path, w.opt.IncludePatterns, w.opt.ExcludePatterns)
 	if err != nil {
 		return fmt.Errorf("error checking include/exclude pattern for directory %q: %w", path, err)
 	}
 	if inc {
-		header, err := createTarHeader(fi, "", opt.Reproducible)
-		if err != nil {
-			return fmt.Errorf("error creating tar header for directory %q: %w", path, err)
-		}
-		name := filepath.ToSlash(path)
-		if !strings.HasSuffix(name, "/") {
-			name += "/"
-		}
-		header.Name = name
-		if err := tw.WriteHeader(header); err != nil {
-			return fmt.Errorf("error writing tar header for directory %q: %w", path, err)
-		}
+		return w.fn(path, fi)
 	}
 	// continue walking into directory in any case
 	return nil
 }
 
-// processFile handles file entries during DirWalk
-// Write only if included (exclude precedence handled in isPathIncluded)
-func processFile(path string, fi fs.FileInfo, fileSystem FileSystem, opt DirOptions, tw *tar.Writer) error {
-	inc, err := isPathIncluded(path, opt.IncludePatterns, opt.ExcludePatterns)
+// visitFile handles file entries during the walk.
+// Report only if included (exclude precedence handled in isPathIncluded)
+func (w *dirWalker) visitFile(path string, fi fs.FileInfo) error {
+	if w.ignore.Ignored(w.relative(path), false) {
+		return nil
+	}
+	inc, err := isPathIncluded(path, w.opt.IncludePatterns, w.opt.ExcludePatterns)
 	if err != nil {
 		return fmt.Errorf("error checking include/exclude pattern for file %q: %w", path, err)
 	}
 	if !inc {
 		return nil
 	}
+	return w.fn(path, fi)
+}
+
+// relative returns path relative to the walked directory, which is the base of the ignore file.
+func (w *dirWalker) relative(path string) string {
+	if w.subPath == "." {
+		return path
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(path, w.subPath), "/")
+}
+
+// checkLoop rejects following a symlink to the directory target if the target is a parent of the link.
+func (w *dirWalker) checkLoop(link string, target fs.FileInfo) error {
+	for dir := path.Dir(link); ; dir = path.Dir(dir) {
+		fi, err := w.fileSystem.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("error checking symlink %q for loops: %w", link, err)
+		}
+		if os.SameFile(fi, target) {
+			return fmt.Errorf("symlink %q points to its parent directory %q", link, dir)
+		}
+		if dir == "." {
+			return nil
+		}
+	}
+}
 
+// writeDirectory writes the header of a directory entry.
+func writeDirectory(path string, fi fs.FileInfo, opt DirOptions, tw *tar.Writer) error {
+	header, err := createTarHeader(fi, "", opt.Reproducible)
+	if err != nil {
+		return fmt.Errorf("error creating tar header for directory %q: %w", path, err)
+	}
+	name := filepath.ToSlash(path)
+	if !strings.HasSuffix(name, "/") {
+		name += "/"
+	}
+	header.Name = name
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("error writing tar header for directory %q: %w", path, err)
+	}
+	return nil
+}
+
+// writeFile writes the header and content of a file entry.
+func writeFile(path string, fi fs.FileInfo, fileSystem FileSystem, opt DirOptions, tw *tar.Writer) error {
 	header, err := createTarHeader(fi, "", opt.Reproducible)
 	if err != nil {
 		return fmt.Errorf("error creating tar header for %q: %w", path, err)
@@ -335,7 +532,7 @@ func createTarHeader(fi fs.FileInfo, linkTarget string, reproducible bool) (*tar
 		h.ChangeTime = time.Unix(0, 0)
 		h.Uid, h.Gid = 0, 0
 		h.Uname, h.Gname = "", ""
-		h.Mode &= 0o777 // remove all but permission bits
+		h.Mode = normalizedMode(fi)
 	}
 	return h, nil
 }
@@ -348,3 +545,12 @@ func normalizePathOrPattern(s string) string {
 	s = strings.TrimPrefix(s, "/")
 	return s
 }
+
+// normalizedMode returns the permissions of a reproducible TAR entry: 0755 for directories
+// and files executable by anyone, 0644 for all other files.
+func normalizedMode(fi fs.FileInfo) int64 {
+	if fi.IsDir() || fi.Mode().Perm()&0o111 != 0 {
+		return 0o755
+	}
+	return 0o644
+}
@@ -2,6 +2,7 @@ package filesystem_test
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
 	"io"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/require"
 
 	"ocm.software/open-component-model/bindings/go/blob"
@@ -459,6 +461,158 @@ func TestGetBlobFromPath_PatternNormalization(t *testing.T) {
 	}
 }
 
+func TestGetBlobFromPath_IgnoreFile(t *testing.T) {
+	r := require.New(t)
+
+	tmpDir := t.TempDir()
+	createTestFile(t, tmpDir, ".ocmignore", "# build output\nbuild/\n*.log\n!keep.log\n/top.txt\ndocs/**/*.md\n")
+	createTestFile(t, tmpDir, "main.go", "package main")
+	createTestFile(t, tmpDir, "top.txt", "ignored at the root")
+	createTestFile(t, tmpDir, "sub/top.txt", "not anchored to sub")
+	createTestFile(t, tmpDir, "debug.log", "ignored")
+	createTestFile(t, tmpDir, "sub/trace.log", "ignored at any depth")
+	createTestFile(t, tmpDir, "keep.log", "re-included")
+	createTestFile(t, tmpDir, "build/out.bin", "ignored directory")
+	createTestFile(t, tmpDir, "docs/a/b/readme.md", "ignored by double star")
+	createTestFile(t, tmpDir, "docs/index.md", "ignored by double star")
+	createTestFile(t, tmpDir, "docs/logo.png", "not markdown")
+
+	opt := filesystem.DirOptions{IgnoreFile: ".ocmignore", Reproducible: true}
+	b, err := filesystem.GetBlobFromPath(t.Context(), tmpDir, opt)
+	r.NoError(err)
+	r.ElementsMatch([]string{".ocmignore", "docs/logo.png", "keep.log", "main.go", "sub/top.txt"}, extractTarContents(t, b))
+
+	t.Run("relative to the preserved directory", func(t *testing.T) {
+		r := require.New(t)
+		opt := filesystem.DirOptions{IgnoreFile: ".ocmignore", Reproducible: true, PreserveDir: true}
+		b, err := filesystem.GetBlobFromPath(t.Context(), tmpDir, opt)
+		r.NoError(err)
+		base := filepath.Base(tmpDir)
+		r.Contains(extractTarContents(t, b), base+"/sub/top.txt")
+		r.NotContains(extractTarContents(t, b), base+"/top.txt")
+	})
+
+	t.Run("without ignore file", func(t *testing.T) {
+		r := require.New(t)
+		b, err := filesystem.GetBlobFromPath(t.Context(), tmpDir, filesystem.DirOptions{Reproducible: true})
+		r.NoError(err)
+		r.Len(extractTarContents(t, b), 11)
+	})
+}
+
+func TestGetBlobFromPath_SymlinkPolicies(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, tmpDir, "target.txt", "target content")
+	createTestFile(t, tmpDir, "dir/nested.txt", "nested content")
+	if err := os.Symlink("target.txt", filepath.Join(tmpDir, "link.txt")); err != nil {
+		t.Skipf("symlink creation failed (may not be supported on this system): %v", err)
+	}
+	require.NoError(t, os.Symlink("dir", filepath.Join(tmpDir, "linkdir")))
+
+	t.Run("skip", func(t *testing.T) {
+		r := require.New(t)
+		b, err := filesystem.GetBlobFromPath(t.Context(), tmpDir, filesystem.DirOptions{Symlinks: filesystem.SymlinkPolicySkip})
+		r.NoError(err)
+		r.ElementsMatch([]string{"dir/nested.txt", "target.txt"}, extractTarContents(t, b))
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		r := require.New(t)
+		b, err := filesystem.GetBlobFromPath(t.Context(), tmpDir, filesystem.DirOptions{Symlinks: filesystem.SymlinkPolicyFollow})
+		r.NoError(err)
+		r.ElementsMatch([]string{"dir/nested.txt", "link.txt", "linkdir/nested.txt", "target.txt"}, extractTarContents(t, b))
+	})
+
+	t.Run("follow rejects loops", func(t *testing.T) {
+		r := require.New(t)
+		loopDir := t.TempDir()
+		createTestFile(t, loopDir, "sub/file.txt", "content")
+		r.NoError(os.Symlink("..", filepath.Join(loopDir, "sub", "parent")))
+		b, err := filesystem.GetBlobFromPath(t.Context(), loopDir, filesystem.DirOptions{Symlinks: filesystem.SymlinkPolicyFollow})
+		r.NoError(err)
+		_, err = readAllFromBlob(b)
+		r.ErrorContains(err, "points to its parent directory")
+	})
+
+	t.Run("follow rejects targets outside of the directory", func(t *testing.T) {
+		r := require.New(t)
+		outsideDir := t.TempDir()
+		r.NoError(os.Symlink(filepath.Join(tmpDir, "target.txt"), filepath.Join(outsideDir, "link.txt")))
+		b, err := filesystem.GetBlobFromPath(t.Context(), outsideDir, filesystem.DirOptions{Symlinks: filesystem.SymlinkPolicyFollow})
+		r.NoError(err)
+		_, err = readAllFromBlob(b)
+		r.ErrorContains(err, "error following symlink")
+	})
+}
+
+func TestGetBlobFromPath_ReproducibleDirectory(t *testing.T) {
+	r := require.New(t)
+
+	create := func() string {
+		dir := t.TempDir()
+		createTestFile(t, dir, "b.txt", "b")
+		createTestFile(t, dir, "a/script.sh", "#!/bin/sh")
+		return dir
+	}
+	dir1, dir2 := create(), create()
+	r.NoError(os.Chmod(filepath.Join(dir1, "b.txt"), 0o600))
+	r.NoError(os.Chmod(filepath.Join(dir1, "a", "script.sh"), 0o700))
+	r.NoError(os.Chmod(filepath.Join(dir2, "a", "script.sh"), 0o755))
+	later := time.Now().Add(time.Hour)
+	r.NoError(os.Chtimes(filepath.Join(dir2, "b.txt"), later, later))
+
+	opt := filesystem.DirOptions{Reproducible: true}
+	b1, err := filesystem.GetBlobFromPath(t.Context(), dir1, opt)
+	r.NoError(err)
+	data1, err := readAllFromBlob(b1)
+	r.NoError(err)
+	b2, err := filesystem.GetBlobFromPath(t.Context(), dir2, opt)
+	r.NoError(err)
+	data2, err := readAllFromBlob(b2)
+	r.NoError(err)
+	r.Equal(data1, data2, "permissions and timestamps must be normalized")
+
+	tr := tar.NewReader(bytes.NewReader(data1))
+	modes := map[string]int64{}
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		r.NoError(err)
+		names = append(names, header.Name)
+		modes[header.Name] = header.Mode
+	}
+	r.Equal([]string{"./", "a/", "a/script.sh", "b.txt"}, names, "entries must be sorted")
+	r.Equal(map[string]int64{"./": 0o755, "a/": 0o755, "a/script.sh": 0o755, "b.txt": 0o644}, modes)
+}
+
+func TestListFiles(t *testing.T) {
+	r := require.New(t)
+
+	tmpDir := t.TempDir()
+	createTestFile(t, tmpDir, ".ocmignore", "*.log\n")
+	createTestFile(t, tmpDir, "sub/b.txt", "b")
+	createTestFile(t, tmpDir, "a.txt", "a")
+	createTestFile(t, tmpDir, "debug.log", "ignored")
+
+	opt := filesystem.DirOptions{IgnoreFile: ".ocmignore", ExcludePatterns: []string{".ocmignore"}}
+	entries, err := filesystem.ListFiles(t.Context(), tmpDir, opt)
+	r.NoError(err)
+	r.Equal([]filesystem.FileEntry{
+		{Name: "a.txt", Size: 1, Digest: digest.FromString("a").String()},
+		{Name: "sub/b.txt", Size: 1, Digest: digest.FromString("b").String()},
+	}, entries)
+
+	b, err := filesystem.GetBlobFromPath(t.Context(), tmpDir, opt)
+	r.NoError(err)
+	r.Equal([]string{"a.txt", "sub/b.txt"}, extractTarContents(t, b), "listed files must match the archive")
+
+	_, err = filesystem.ListFiles(t.Context(), filepath.Join(tmpDir, "a.txt"), opt)
+	r.ErrorContains(err, "is not a directory")
+}
+
 // HELPERS
 func readAllFromBlob(b blob.ReadOnlyBlob) ([]byte, error) {
 	rc, err := b.ReadCloser()
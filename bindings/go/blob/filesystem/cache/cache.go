@@ -0,0 +1,271 @@
+// Package cache provides a content-addressed cache for blobs on disk.
+//
+// Blobs are stored in a directory keyed by their digest. The total size of the cached blobs is
+// bounded: once it exceeds the configured maximum, the least recently used blobs are evicted.
+// The cache survives restarts of the process, the recency of blobs is approximated by the
+// modification time of their files when the cache is opened again.
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// ErrTooLarge is returned by Put for blobs that are larger than the maximum size of the cache.
+var ErrTooLarge = errors.New("blob is larger than the cache")
+
+// Stats describes the usage of a Cache.
+type Stats struct {
+	// Hits is the number of Get calls that found the blob.
+	Hits int64 `json:"hits"`
+	// Misses is the number of Get calls that did not find the blob.
+	Misses int64 `json:"misses"`
+	// Evictions is the number of blobs removed to stay within the maximum size.
+	Evictions int64 `json:"evictions"`
+	// Entries is the number of cached blobs.
+	Entries int `json:"entries"`
+	// Size is the total size of the cached blobs in bytes.
+	Size int64 `json:"size"`
+}
+
+// Cache is a content-addressed blob cache on disk with least recently used eviction.
+// It is safe for concurrent use, but a directory must not be shared by multiple caches.
+type Cache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	entries map[digest.Digest]*list.Element
+	// lru orders the entries from the most to the least recently used.
+	lru  *list.List
+	size int64
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
+}
+
+type entry struct {
+	digest digest.Digest
+	size   int64
+}
+
+// New opens the cache in dir, which is created if it does not exist.
+// Blobs already present in dir are kept as long as they fit into maxSize.
+// maxSize must be greater than zero.
+func New(dir string, maxSize int64) (*Cache, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("maximum size of the blob cache must be greater than zero")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create blob cache directory: %w", err)
+	}
+	c := &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[digest.Digest]*list.Element),
+		lru:     list.New(),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load indexes the blobs in the directory of the cache, removing leftovers of interrupted writes.
+func (c *Cache) load() error {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read blob cache directory: %w", err)
+	}
+	type cached struct {
+		entry
+		modified time.Time
+	}
+	var blobs []cached
+	for _, file := range files {
+		if !file.Type().IsRegular() {
+			continue
+		}
+		path := filepath.Join(c.dir, file.Name())
+		dig, ok := digestFromFileName(file.Name())
+		if !ok {
+			if strings.HasPrefix(file.Name(), ".") {
+				_ = os.Remove(path)
+			}
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat cached blob %s: %w", dig, err)
+		}
+		blobs = append(blobs, cached{entry: entry{digest: dig, size: info.Size()}, modified: info.ModTime()})
+	}
+	slices.SortFunc(blobs, func(a, b cached) int {
+		return b.modified.Compare(a.modified)
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, blob := range blobs {
+		c.entries[blob.digest] = c.lru.PushBack(blob.entry)
+		c.size += blob.size
+	}
+	c.evict()
+	return nil
+}
+
+// Get opens the cached blob with the digest and marks it as recently used.
+// It returns false if the blob is not cached.
+// The blob stays readable until the returned reader is closed, even if it is evicted in the meantime.
+func (c *Cache) Get(dig string) (io.ReadCloser, bool) {
+	d := digest.Digest(dig)
+	c.mu.Lock()
+	element, ok := c.entries[d]
+	if ok {
+		c.lru.MoveToFront(element)
+	}
+	c.mu.Unlock()
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	path := c.path(d)
+	file, err := os.Open(path)
+	if err != nil {
+		// the file was removed externally, forget about it.
+		c.remove(d)
+		c.misses.Add(1)
+		return nil, false
+	}
+	// the modification time persists the recency across restarts, failing to update it is not critical.
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	c.hits.Add(1)
+	return file, true
+}
+
+// Put stores the content of r under the digest, evicting the least recently used blobs
+// if the cache would exceed its maximum size. size may be negative if it is not known in advance.
+// If the blob is larger than the cache, ErrTooLarge is returned, without reading r if the size is known.
+// The content is verified against the digest, content that does not match is not stored.
+func (c *Cache) Put(dig string, size int64, r io.Reader) (err error) {
+	d := digest.Digest(dig)
+	if err := d.Validate(); err != nil {
+		return fmt.Errorf("invalid digest %q: %w", dig, err)
+	}
+	if size > c.maxSize {
+		return ErrTooLarge
+	}
+	c.mu.Lock()
+	_, exists := c.entries[d]
+	c.mu.Unlock()
+	if exists {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".put-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	verifier := d.Verifier()
+	// read one byte more than allowed to detect content exceeding the cache.
+	written, err := io.Copy(tmp, io.TeeReader(io.LimitReader(r, c.maxSize+1), verifier))
+	if err = errors.Join(err, tmp.Close()); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	switch {
+	case written > c.maxSize:
+		return ErrTooLarge
+	case size >= 0 && written != size:
+		return fmt.Errorf("expected %d bytes for blob %s but got %d", size, d, written)
+	case !verifier.Verified():
+		return fmt.Errorf("content of blob does not match digest %s", d)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path(d)); err != nil {
+		return fmt.Errorf("failed to store cache file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[d]; ok {
+		// stored concurrently, the file was replaced with identical content.
+		c.lru.MoveToFront(element)
+		return nil
+	}
+	c.entries[d] = c.lru.PushFront(entry{digest: d, size: written})
+	c.size += written
+	c.evict()
+	return nil
+}
+
+// Stats returns the current usage of the cache.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Entries:   len(c.entries),
+		Size:      c.size,
+	}
+}
+
+// evict removes the least recently used blobs until the cache fits into its maximum size.
+// c.mu must be held.
+func (c *Cache) evict() {
+	for c.size > c.maxSize {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			return
+		}
+		e := c.lru.Remove(oldest).(entry)
+		delete(c.entries, e.digest)
+		c.size -= e.size
+		c.evictions.Add(1)
+		_ = os.Remove(c.path(e.digest))
+	}
+}
+
+func (c *Cache) remove(d digest.Digest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[d]; ok {
+		e := c.lru.Remove(element).(entry)
+		delete(c.entries, d)
+		c.size -= e.size
+	}
+}
+
+func (c *Cache) path(d digest.Digest) string {
+	return filepath.Join(c.dir, d.Algorithm().String()+"-"+d.Encoded())
+}
+
+func digestFromFileName(name string) (digest.Digest, bool) {
+	algorithm, encoded, ok := strings.Cut(name, "-")
+	if !ok {
+		return "", false
+	}
+	d := digest.NewDigestFromEncoded(digest.Algorithm(algorithm), encoded)
+	return d, d.Validate() == nil
+}
@@ -0,0 +1,62 @@
+package cache_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/filesystem/cache"
+)
+
+func read(t *testing.T, c *cache.Cache, dig digest.Digest) (string, bool) {
+	t.Helper()
+	data, ok := c.Get(dig.String())
+	if !ok {
+		return "", false
+	}
+	defer func() {
+		require.NoError(t, data.Close())
+	}()
+	content, err := io.ReadAll(data)
+	require.NoError(t, err)
+	return string(content), true
+}
+
+func TestCache(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	c, err := cache.New(dir, 10)
+	r.NoError(err)
+
+	a, b, d := digest.FromString("aaaa"), digest.FromString("bbbb"), digest.FromString("dddd")
+	_, ok := read(t, c, a)
+	r.False(ok)
+
+	r.NoError(c.Put(a.String(), 4, strings.NewReader("aaaa")))
+	r.NoError(c.Put(b.String(), -1, strings.NewReader("bbbb")))
+	content, ok := read(t, c, a)
+	r.True(ok)
+	r.Equal("aaaa", content)
+
+	// b is the least recently used blob and is evicted.
+	r.NoError(c.Put(d.String(), 4, strings.NewReader("dddd")))
+	_, ok = read(t, c, b)
+	r.False(ok)
+	r.Equal(cache.Stats{Hits: 1, Misses: 2, Evictions: 1, Entries: 2, Size: 8}, c.Stats())
+
+	r.ErrorIs(c.Put(digest.FromString("too large").String(), 11, nil), cache.ErrTooLarge)
+	r.ErrorIs(c.Put(digest.FromString("too large").String(), -1, strings.NewReader("01234567890")), cache.ErrTooLarge)
+	r.ErrorContains(c.Put(b.String(), -1, bytes.NewReader([]byte("corrupt"))), "does not match digest")
+
+	// the cache is restored from its directory.
+	c, err = cache.New(dir, 10)
+	r.NoError(err)
+	content, ok = read(t, c, d)
+	r.True(ok)
+	r.Equal("dddd", content)
+	r.Equal(2, c.Stats().Entries)
+}
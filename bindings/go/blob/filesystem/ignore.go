@@ -0,0 +1,145 @@
+package filesystem
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// ignorePattern is a single pattern of an ignore file.
+type ignorePattern struct {
+	// segments are the slash separated elements of the pattern, "**" matches any number of elements.
+	segments []string
+	// negate re-includes paths matched by a previous pattern ("!pattern").
+	negate bool
+	// dirOnly restricts the pattern to directories ("pattern/").
+	dirOnly bool
+}
+
+// ignoreMatcher matches paths against the patterns of an ignore file in gitignore syntax.
+//
+// Supported are comments ("#"), negation ("!"), directory-only patterns (trailing "/"),
+// anchored patterns (containing a "/" other than a trailing one) and "**" for any number
+// of path elements. Patterns without a "/" match the name of an entry at any depth.
+// All other elements are matched with path.Match.
+// As in git, the last matching pattern decides, and a path inside an ignored directory
+// cannot be re-included as the directory is not walked at all.
+type ignoreMatcher struct {
+	patterns []ignorePattern
+}
+
+// loadIgnoreFile reads the ignore file with the given name from dir.
+// It returns nil if name is empty or the file does not exist.
+func loadIgnoreFile(fileSystem fs.FS, dir, name string) (*ignoreMatcher, error) {
+	if name == "" {
+		return nil, nil
+	}
+	file, err := fileSystem.Open(path.Join(dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening ignore file %q: %w", name, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	matcher, err := parseIgnorePatterns(file)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ignore file %q: %w", name, err)
+	}
+	return matcher, nil
+}
+
+// parseIgnorePatterns parses patterns in gitignore syntax, one per line.
+func parseIgnorePatterns(r io.Reader) (*ignoreMatcher, error) {
+	matcher := &ignoreMatcher{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		raw := strings.TrimSuffix(scanner.Text(), "\r")
+		pattern := strings.TrimRight(raw, " \t")
+		// a trailing space escaped with a backslash is kept
+		if strings.HasSuffix(pattern, `\`) && len(pattern) < len(raw) {
+			pattern += " "
+		}
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		var p ignorePattern
+		if p.negate = strings.HasPrefix(pattern, "!"); p.negate {
+			pattern = pattern[1:]
+		}
+		if p.dirOnly = strings.HasSuffix(pattern, "/"); p.dirOnly {
+			pattern = strings.TrimRight(pattern, "/")
+		}
+		if strings.Contains(pattern, "/") {
+			pattern = strings.TrimPrefix(pattern, "/")
+		} else {
+			pattern = "**/" + pattern
+		}
+		if pattern == "" || pattern == "**/" {
+			continue
+		}
+
+		p.segments = strings.Split(pattern, "/")
+		for _, segment := range p.segments {
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in line %d: %w", raw, line, err)
+			}
+		}
+		matcher.patterns = append(matcher.patterns, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return matcher, nil
+}
+
+// Ignored reports whether the slash separated path relative to the directory of the ignore file is ignored.
+func (m *ignoreMatcher) Ignored(name string, isDir bool) bool {
+	if m == nil || name == "" {
+		return false
+	}
+	elems := strings.Split(name, "/")
+	ignored := false
+	for _, p := range m.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if matchSegments(p.segments, elems) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+// matchSegments matches path elements against pattern segments.
+// "**" matches zero or more elements, or one or more if it is the last segment.
+func matchSegments(segments, elems []string) bool {
+	for len(segments) > 0 {
+		if segments[0] == "**" {
+			if len(segments) == 1 {
+				return len(elems) > 0
+			}
+			for i := range len(elems) + 1 {
+				if matchSegments(segments[1:], elems[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(elems) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(segments[0], elems[0]); !ok {
+			return false
+		}
+		segments, elems = segments[1:], elems[1:]
+	}
+	return len(elems) == 0
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"runtime"
 	"sync"
 	"sync/atomic"
 
 	"github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/blob/quota"
 )
 
 // New forwards a given [io.Reader] to be able to be used as a ReadOnlyBlob.
@@ -36,6 +39,8 @@ type Blob struct {
 	digest    atomic.Pointer[digest.Digest] // digest of the blob, if loaded or set in advance
 	mediaType atomic.Pointer[string]        // media type of the blob, if set in advance
 
+	quota *quota.Quota // quota the data is charged against while the blob is reachable, if set
+
 	load func() error
 }
 
@@ -122,7 +127,7 @@ func newMemoryBlobFromUnknownSource() *Blob {
 	return b
 }
 
-func storeSourceInBlob(b *Blob, source io.Reader) error {
+func storeSourceInBlob(b *Blob, source io.Reader) (err error) {
 	// Either compute a new digest or verify against an existing one.
 	var reader io.Reader
 	var digester digest.Digester
@@ -138,16 +143,29 @@ func storeSourceInBlob(b *Blob, source io.Reader) error {
 		reader = io.TeeReader(source, verifier)
 	}
 
+	// the data is charged against the quota until the blob is garbage collected.
+	// with a pre-set size the allocation is rejected before reading, otherwise while reading.
+	reservation, err := b.quota.Reserve(max(b.size.Load(), 0))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			reservation.Release()
+		} else if reservation != nil {
+			runtime.AddCleanup(b, (*quota.Reservation).Release, reservation)
+		}
+	}()
+
 	// either read the data into a pre-set size or read all data
 	// if the size is set, we can use io.ReadFull to limit the read.
 	// if the size is not set, we can use io.ReadAll to read all data with buffering
-	var err error
 	if size := b.size.Load(); size > 0 {
 		b.data = make([]byte, size)
 		// if we have a pre-set size, we can use io.CopyN to limit the read.
 		_, err = io.ReadFull(reader, b.data)
 	} else {
-		b.data, err = io.ReadAll(reader)
+		b.data, err = io.ReadAll(quota.NewReader(reader, reservation))
 		b.size.Store(int64(len(b.data)))
 	}
 	if err != nil {
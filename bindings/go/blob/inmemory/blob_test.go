@@ -12,6 +12,7 @@ import (
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	. "ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/blob/quota"
 )
 
 func Test_ReadCloserReturnsReader(t *testing.T) {
@@ -358,6 +359,23 @@ func TestMemoryBlobOptions(t *testing.T) {
 
 }
 
+func TestMemoryBlobQuota(t *testing.T) {
+	r := require.New(t)
+	q := quota.New(quota.Limits{MaxBlobBytes: 8})
+
+	b := New(strings.NewReader("12345678"), WithQuota(q))
+	r.NoError(b.Load())
+	r.Equal(int64(8), q.Stats().Used)
+
+	b = New(strings.NewReader("123456789"), WithQuota(q))
+	r.ErrorIs(b.Load(), quota.ErrExceeded)
+
+	b = New(strings.NewReader("123456789"), WithQuota(q), WithSize(9))
+	r.ErrorIs(b.Load(), quota.ErrExceeded, "a known size is rejected before reading")
+	r.Equal(int64(8), q.Stats().Used)
+	r.Equal(int64(2), q.Stats().Rejections)
+}
+
 func TestConcurrentAndSerialReads(t *testing.T) {
 	data := "test data for concurrent and serial reads"
 	blob := New(strings.NewReader(data))
@@ -1,5 +1,7 @@
 package inmemory
 
+import "ocm.software/open-component-model/bindings/go/blob/quota"
+
 type MemoryBlobOption interface {
 	ApplyToMemoryBlob(*Blob)
 }
@@ -30,3 +32,18 @@ type WithDigest string
 func (w WithDigest) ApplyToMemoryBlob(b *Blob) {
 	b.SetPrecalculatedDigest(string(w))
 }
+
+// WithQuota is a MemoryBlobOption that charges the data of the Blob against a quota.
+// The data is charged when it is loaded, and released once the Blob is garbage collected.
+// If loading the data exceeds the quota, Load fails with a [*quota.ExceededError].
+func WithQuota(q *quota.Quota) MemoryBlobOption {
+	return quotaOption{quota: q}
+}
+
+type quotaOption struct {
+	quota *quota.Quota
+}
+
+func (w quotaOption) ApplyToMemoryBlob(b *Blob) {
+	b.quota = w.quota
+}
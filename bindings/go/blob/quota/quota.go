@@ -0,0 +1,267 @@
+// Package quota bounds the memory and disk space allocated for blob content, e.g. when blobs
+// are loaded into memory or buffered in temporary files.
+//
+// Services that process untrusted component content (such as the controller or a resolution service)
+// create a global Quota and derive per-operation quotas from it. Allocations are charged against the
+// quota of the operation and all its parents, and fail with an [*ExceededError] if any limit is exceeded:
+//
+//	global := quota.New(quota.Limits{MaxTotalBytes: 4 << 30})
+//	ctx = quota.WithQuota(ctx, global.Operation(quota.Limits{MaxTotalBytes: 1 << 30, MaxBlobBytes: 512 << 20}))
+//
+// All methods of a nil *Quota and a nil *Reservation are no-ops, so allocations can be charged unconditionally.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrExceeded is returned (wrapped in an [*ExceededError]) if an allocation exceeds a quota.
+var ErrExceeded = errors.New("quota exceeded")
+
+// Limits are the limits of a Quota. A limit of zero or less is unlimited.
+type Limits struct {
+	// MaxTotalBytes is the maximum number of bytes allocated at the same time.
+	MaxTotalBytes int64 `json:"maxTotalBytes,omitempty"`
+	// MaxBlobBytes is the maximum size of a single allocation, e.g. a single blob.
+	MaxBlobBytes int64 `json:"maxBlobBytes,omitempty"`
+}
+
+// Stats describes the allocations charged against a Quota.
+type Stats struct {
+	// Used is the number of bytes currently allocated.
+	Used int64 `json:"used"`
+	// Peak is the highest number of bytes allocated at the same time.
+	Peak int64 `json:"peak"`
+	// Allocations is the number of reservations made.
+	Allocations int64 `json:"allocations"`
+	// Rejections is the number of allocations rejected because a limit of the quota was exceeded.
+	Rejections int64 `json:"rejections"`
+}
+
+// Quota accounts the bytes allocated for blob content and enforces its Limits. It is safe for concurrent use.
+type Quota struct {
+	limits Limits
+	parent *Quota
+
+	used        atomic.Int64
+	peak        atomic.Int64
+	allocations atomic.Int64
+	rejections  atomic.Int64
+}
+
+// New creates a Quota with the given limits.
+func New(limits Limits) *Quota {
+	return &Quota{limits: limits}
+}
+
+// Operation creates a Quota for a single operation. Allocations charged against it
+// are charged against q as well, and are bounded by the limits of both.
+// If q is nil, the returned Quota has no parent.
+func (q *Quota) Operation(limits Limits) *Quota {
+	return &Quota{limits: limits, parent: q}
+}
+
+// Limits returns the limits of the quota.
+func (q *Quota) Limits() Limits {
+	if q == nil {
+		return Limits{}
+	}
+	return q.limits
+}
+
+// Stats returns the current accounting of the quota.
+func (q *Quota) Stats() Stats {
+	if q == nil {
+		return Stats{}
+	}
+	return Stats{
+		Used:        q.used.Load(),
+		Peak:        q.peak.Load(),
+		Allocations: q.allocations.Load(),
+		Rejections:  q.rejections.Load(),
+	}
+}
+
+// Reserve reserves size bytes for a single allocation. The reservation can grow if the size of the
+// allocation is not known in advance, and must be released once the allocation is freed.
+// If q is nil, a nil Reservation is returned.
+func (q *Quota) Reserve(size int64) (*Reservation, error) {
+	if q == nil {
+		return nil, nil
+	}
+	for level := q; level != nil; level = level.parent {
+		level.allocations.Add(1)
+	}
+	r := &Reservation{quota: q}
+	if err := r.Grow(size); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// charge charges n more bytes of an allocation that then has size bytes against q and all its parents.
+// If a limit is exceeded, nothing is charged.
+func (q *Quota) charge(size, n int64) error {
+	for level := q; level != nil; level = level.parent {
+		if err := level.add(size, n); err != nil {
+			level.rejections.Add(1)
+			for charged := q; charged != level; charged = charged.parent {
+				charged.used.Add(-n)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *Quota) add(size, n int64) error {
+	if q.limits.MaxBlobBytes > 0 && size > q.limits.MaxBlobBytes {
+		return &ExceededError{Limit: LimitBlob, Max: q.limits.MaxBlobBytes, Requested: size}
+	}
+	for {
+		used := q.used.Load()
+		if q.limits.MaxTotalBytes > 0 && used+n > q.limits.MaxTotalBytes {
+			return &ExceededError{Limit: LimitTotal, Max: q.limits.MaxTotalBytes, Requested: n, Used: used}
+		}
+		if q.used.CompareAndSwap(used, used+n) {
+			q.updatePeak(used + n)
+			return nil
+		}
+	}
+}
+
+func (q *Quota) updatePeak(used int64) {
+	for {
+		peak := q.peak.Load()
+		if used <= peak || q.peak.CompareAndSwap(peak, used) {
+			return
+		}
+	}
+}
+
+// Reservation is the part of a Quota charged by a single allocation.
+type Reservation struct {
+	quota *Quota
+
+	mu       sync.Mutex
+	size     int64
+	released bool
+}
+
+// Grow charges n more bytes for the allocation.
+// If a limit is exceeded, an [*ExceededError] is returned and the reservation keeps its size.
+func (r *Reservation) Grow(n int64) error {
+	if r == nil || n <= 0 {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.released {
+		return fmt.Errorf("reservation was already released")
+	}
+	if err := r.quota.charge(r.size+n, n); err != nil {
+		return err
+	}
+	r.size += n
+	return nil
+}
+
+// Size returns the number of bytes reserved.
+func (r *Reservation) Size() int64 {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.size
+}
+
+// Release frees the reserved bytes. Releasing a reservation more than once has no effect.
+func (r *Reservation) Release() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.released {
+		return
+	}
+	r.released = true
+	for level := r.quota; level != nil; level = level.parent {
+		level.used.Add(-r.size)
+	}
+}
+
+// NewReader returns a reader that grows the reservation by the bytes read from reader.
+// It fails with the [*ExceededError] of the reservation once a limit is exceeded.
+func NewReader(reader io.Reader, reservation *Reservation) io.Reader {
+	if reservation == nil {
+		return reader
+	}
+	return &quotaReader{reader: reader, reservation: reservation}
+}
+
+type quotaReader struct {
+	reader      io.Reader
+	reservation *Reservation
+}
+
+func (r *quotaReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if growErr := r.reservation.Grow(int64(n)); growErr != nil {
+		return 0, growErr
+	}
+	return n, err
+}
+
+// Limit identifies the limit of a Quota that was exceeded.
+type Limit string
+
+const (
+	// LimitTotal is Limits.MaxTotalBytes.
+	LimitTotal Limit = "total"
+	// LimitBlob is Limits.MaxBlobBytes.
+	LimitBlob Limit = "blob"
+)
+
+// ExceededError is returned if an allocation exceeds a limit of a Quota.
+// It matches ErrExceeded with [errors.Is].
+type ExceededError struct {
+	// Limit is the exceeded limit.
+	Limit Limit
+	// Max is the value of the exceeded limit.
+	Max int64
+	// Requested is the size of the allocation for LimitBlob, and the number of additionally requested bytes for LimitTotal.
+	Requested int64
+	// Used is the number of bytes allocated when the allocation was requested. It is only set for LimitTotal.
+	Used int64
+}
+
+func (e *ExceededError) Error() string {
+	if e.Limit == LimitBlob {
+		return fmt.Sprintf("%s: allocation of %d bytes exceeds the maximum blob size of %d bytes", ErrExceeded, e.Requested, e.Max)
+	}
+	return fmt.Sprintf("%s: allocating %d more bytes exceeds the maximum of %d bytes, %d bytes are in use", ErrExceeded, e.Requested, e.Max, e.Used)
+}
+
+func (e *ExceededError) Is(target error) bool {
+	return target == ErrExceeded
+}
+
+type contextKey struct{}
+
+// WithQuota returns a context that carries q, for allocations that do not take the Quota as option.
+func WithQuota(ctx context.Context, q *Quota) context.Context {
+	return context.WithValue(ctx, contextKey{}, q)
+}
+
+// FromContext returns the Quota carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Quota {
+	q, _ := ctx.Value(contextKey{}).(*Quota)
+	return q
+}
@@ -0,0 +1,78 @@
+package quota_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/quota"
+)
+
+func TestQuota(t *testing.T) {
+	r := require.New(t)
+	global := quota.New(quota.Limits{MaxTotalBytes: 100})
+	operation := global.Operation(quota.Limits{MaxTotalBytes: 80, MaxBlobBytes: 50})
+
+	first, err := operation.Reserve(40)
+	r.NoError(err)
+	_, err = operation.Reserve(60)
+	var exceeded *quota.ExceededError
+	r.ErrorAs(err, &exceeded)
+	r.Equal(quota.LimitBlob, exceeded.Limit)
+	r.ErrorIs(err, quota.ErrExceeded)
+
+	second, err := operation.Reserve(30)
+	r.NoError(err)
+	err = second.Grow(20)
+	r.ErrorAs(err, &exceeded)
+	r.Equal(quota.LimitTotal, exceeded.Limit)
+	r.Equal(int64(80), exceeded.Max)
+	r.Equal(int64(30), second.Size(), "a rejected grow does not change the reservation")
+
+	// the global quota bounds all operations.
+	_, err = global.Operation(quota.Limits{}).Reserve(40)
+	r.ErrorAs(err, &exceeded)
+	r.Equal(int64(100), exceeded.Max)
+
+	r.Equal(quota.Stats{Used: 70, Peak: 70, Allocations: 3, Rejections: 2}, operation.Stats())
+	r.Equal(quota.Stats{Used: 70, Peak: 70, Allocations: 4, Rejections: 1}, global.Stats())
+
+	first.Release()
+	first.Release()
+	second.Release()
+	r.Equal(int64(0), global.Stats().Used)
+	r.Equal(int64(70), global.Stats().Peak)
+}
+
+func TestNewReader(t *testing.T) {
+	r := require.New(t)
+	q := quota.New(quota.Limits{MaxBlobBytes: 10})
+
+	reservation, err := q.Reserve(0)
+	r.NoError(err)
+	data, err := io.ReadAll(quota.NewReader(bytes.NewReader([]byte("0123456789")), reservation))
+	r.NoError(err)
+	r.Len(data, 10)
+	r.Equal(int64(10), q.Stats().Used)
+
+	reservation, err = q.Reserve(0)
+	r.NoError(err)
+	_, err = io.ReadAll(quota.NewReader(bytes.NewReader([]byte("0123456789a")), reservation))
+	r.ErrorIs(err, quota.ErrExceeded)
+}
+
+func TestNilQuota(t *testing.T) {
+	r := require.New(t)
+	var q *quota.Quota
+	reservation, err := q.Reserve(1 << 40)
+	r.NoError(err)
+	r.NoError(reservation.Grow(1))
+	reservation.Release()
+	r.Equal(quota.Stats{}, q.Stats())
+	r.Nil(quota.FromContext(t.Context()))
+
+	q = quota.New(quota.Limits{})
+	r.Same(q, quota.FromContext(quota.WithQuota(t.Context(), q)))
+}
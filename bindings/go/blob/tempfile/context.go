@@ -0,0 +1,57 @@
+package tempfile
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+)
+
+type managerKey struct{}
+
+// defaultManager is used by FromContext if no manager was attached to the context.
+var defaultManager = sync.OnceValue(func() *Manager {
+	return NewManager()
+})
+
+// Default returns the process-wide default manager, which creates its artifacts in [os.TempDir].
+func Default() *Manager {
+	return defaultManager()
+}
+
+// WithManager returns a copy of ctx carrying the given manager.
+func WithManager(ctx context.Context, m *Manager) context.Context {
+	return context.WithValue(ctx, managerKey{}, m)
+}
+
+// FromContext returns the manager carried by ctx, or the default manager if there is none.
+func FromContext(ctx context.Context) *Manager {
+	if m, ok := ctx.Value(managerKey{}).(*Manager); ok && m != nil {
+		return m
+	}
+	return Default()
+}
+
+// CreateTemp creates a new temporary file with the manager carried by ctx.
+// See [Manager.CreateTemp].
+func CreateTemp(ctx context.Context, dir, pattern string) (*os.File, error) {
+	return FromContext(ctx).CreateTemp(ctx, dir, pattern)
+}
+
+// WriteTemp creates a new temporary file with the content of r with the manager carried by ctx.
+// See [Manager.WriteTemp].
+func WriteTemp(ctx context.Context, dir, pattern string, r io.Reader) (string, error) {
+	return FromContext(ctx).WriteTemp(ctx, dir, pattern, r)
+}
+
+// MkdirTemp creates a new temporary directory with the manager carried by ctx.
+// See [Manager.MkdirTemp].
+func MkdirTemp(ctx context.Context, dir, pattern string) (string, error) {
+	return FromContext(ctx).MkdirTemp(ctx, dir, pattern)
+}
+
+// Release removes a path tracked by the manager carried by ctx.
+// See [Manager.Release].
+func Release(ctx context.Context, path string) error {
+	return FromContext(ctx).Release(path)
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package tempfile
+
+import "os"
+
+// createExclusive creates a new file that must not exist yet.
+func createExclusive(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package tempfile
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// createExclusive creates a new file that must not exist yet. Unlike [os.OpenFile], the file is
+// opened with FILE_SHARE_DELETE, so that it can be renamed while it is open and locked.
+func createExclusive(path string) (*os.File, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	handle, err := windows.CreateFile(name,
+		windows.GENERIC_READ|windows.GENERIC_WRITE,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.CREATE_NEW, windows.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	return os.NewFile(uintptr(handle), path), nil
+}
@@ -0,0 +1,19 @@
+// Package tempfile manages temporary files and directories so that they do not outlive the
+// operation that created them.
+//
+// A Manager owns all temporary artifacts of one session. Every session has a random ID that is
+// embedded into the names of the artifacts it creates ("ocm-<session>-<pattern>"). Artifacts can be
+// bound to a context when they are created or registered: once the context is done, they are
+// removed, even if the operation that created them was interrupted mid-way. Cleanup removes all
+// artifacts still tracked by the manager.
+//
+// Artifacts of sessions that never reached Cleanup (for example because the process crashed or was
+// killed) are found by SweepOrphans: while a session owns artifacts, it holds an exclusive lock on a
+// session file in the manager directory. The operating system releases the lock when the process exits,
+// so SweepOrphans removes the artifacts of all sessions whose session file is no longer locked. It is
+// meant to be called once on startup.
+//
+// Code that creates temporary files should obtain the manager through FromContext, so that the caller
+// can scope the artifacts with WithManager. Without a manager in the context, a process-wide default
+// manager in the default temporary directory is used.
+package tempfile
@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package tempfile
+
+import (
+	"errors"
+	"os"
+)
+
+// tryLock is not supported on this platform, so sessions are never considered dead.
+func tryLock(*os.File) error {
+	return errors.ErrUnsupported
+}
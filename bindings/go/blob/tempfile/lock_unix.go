@@ -0,0 +1,22 @@
+//go:build unix
+
+package tempfile
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// tryLock acquires an exclusive lock on file without blocking. The lock is held until the file is closed
+// and released by the operating system if the process exits.
+func tryLock(file *os.File) error {
+	if err := unix.Flock(int(file.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return errLocked
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package tempfile
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// tryLock acquires an exclusive lock on file without blocking. The lock is held until the file is closed
+// and released by the operating system if the process exits.
+func tryLock(file *os.File) error {
+	err := windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0, 1, 0, new(windows.Overlapped))
+	if err != nil {
+		if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+			return errLocked
+		}
+		return err
+	}
+	return nil
+}
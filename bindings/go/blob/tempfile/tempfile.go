@@ -0,0 +1,403 @@
+package tempfile
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"ocm.software/open-component-model/bindings/go/blob/quota"
+)
+
+const (
+	// namePrefix is the prefix of all artifacts and session files created by a Manager.
+	namePrefix = "ocm-"
+	// sessionFileSuffix is the suffix of the session file that is locked while a session is alive.
+	sessionFileSuffix = ".session"
+)
+
+// errLocked is returned by tryLock if the file is locked by another open file.
+var errLocked = errors.New("file is locked")
+
+// Manager tracks the temporary files and directories of a single session.
+// It is safe for concurrent use.
+type Manager struct {
+	dir string
+	id  string
+
+	mu sync.Mutex
+	// paths maps every tracked artifact to the function detaching it from its context.
+	paths map[string]func() bool
+	// sessionFile is the path of the session file, empty if it has not been written yet.
+	sessionFile string
+	// sessionLock is the session file, kept open to hold the lock on it as long as the session is alive.
+	sessionLock *os.File
+
+	// quota is the quota files written with WriteTemp are charged against if the context carries none.
+	quota *quota.Quota
+	// reservations maps the files written with WriteTemp to their reservation of the quota.
+	reservations map[string]*quota.Reservation
+}
+
+// Option configures a Manager.
+type Option func(*Manager)
+
+// WithDir sets the directory that artifacts are created in if no directory is passed on creation.
+// The session file of the manager is kept in this directory as well.
+// Defaults to [os.TempDir].
+func WithDir(dir string) Option {
+	return func(m *Manager) {
+		m.dir = dir
+	}
+}
+
+// WithSessionID sets the ID of the session instead of generating a random one.
+// The ID must not contain path separators or dashes.
+func WithSessionID(id string) Option {
+	return func(m *Manager) {
+		m.id = id
+	}
+}
+
+// WithQuota sets the quota that files written with WriteTemp are charged against,
+// unless the context carries a quota (see [quota.WithQuota]).
+func WithQuota(q *quota.Quota) Option {
+	return func(m *Manager) {
+		m.quota = q
+	}
+}
+
+// NewManager creates a new Manager for a new session.
+// No files are written until the first artifact is created or registered.
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
+		paths:        make(map[string]func() bool),
+		reservations: make(map[string]*quota.Reservation),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.dir == "" {
+		m.dir = os.TempDir()
+	}
+	if m.id == "" {
+		id := make([]byte, 8)
+		_, _ = rand.Read(id)
+		m.id = hex.EncodeToString(id)
+	}
+	return m
+}
+
+// SessionID returns the ID of the session owned by the manager.
+func (m *Manager) SessionID() string {
+	return m.id
+}
+
+// Dir returns the directory artifacts are created in by default.
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+// CreateTemp creates a new temporary file like [os.CreateTemp] and tracks it.
+// If dir is empty, the file is created in the directory of the manager.
+// The file is removed once ctx is done, on Release or on Cleanup, whatever comes first.
+// Use [context.WithoutCancel] to track a file that should outlive ctx until Cleanup.
+func (m *Manager) CreateTemp(ctx context.Context, dir, pattern string) (*os.File, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := m.writeSessionFile(); err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		dir = m.dir
+	}
+	file, err := os.CreateTemp(dir, m.prefix()+pattern)
+	if err != nil {
+		return nil, err
+	}
+	m.track(ctx, file.Name())
+	return file, nil
+}
+
+// WriteTemp creates a new temporary file like CreateTemp and writes the content of r to it.
+// The content is charged against the quota carried by ctx, or the quota of the manager,
+// until the file is removed. If the quota is exceeded, the file is removed and
+// a [*quota.ExceededError] is returned.
+func (m *Manager) WriteTemp(ctx context.Context, dir, pattern string, r io.Reader) (_ string, err error) {
+	q := quota.FromContext(ctx)
+	if q == nil {
+		q = m.quota
+	}
+	reservation, err := q.Reserve(0)
+	if err != nil {
+		return "", err
+	}
+	file, err := m.CreateTemp(ctx, dir, pattern)
+	if err != nil {
+		reservation.Release()
+		return "", err
+	}
+	path := file.Name()
+	if reservation != nil {
+		m.mu.Lock()
+		m.reservations[path] = reservation
+		m.mu.Unlock()
+	}
+
+	_, err = io.Copy(file, quota.NewReader(r, reservation))
+	if err = errors.Join(err, file.Close()); err != nil {
+		return "", errors.Join(err, m.Release(path))
+	}
+	return path, nil
+}
+
+// MkdirTemp creates a new temporary directory like [os.MkdirTemp] and tracks it.
+// If dir is empty, the directory is created in the directory of the manager.
+// The directory and its contents are removed once ctx is done, on Release or on Cleanup,
+// whatever comes first.
+func (m *Manager) MkdirTemp(ctx context.Context, dir, pattern string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if err := m.writeSessionFile(); err != nil {
+		return "", err
+	}
+	if dir == "" {
+		dir = m.dir
+	}
+	path, err := os.MkdirTemp(dir, m.prefix()+pattern)
+	if err != nil {
+		return "", err
+	}
+	m.track(ctx, path)
+	return path, nil
+}
+
+// Register tracks an existing path that was not created by the manager.
+// The path is removed once ctx is done, on Release or on Cleanup, whatever comes first.
+// As the name of the path is not bound to the session, it is not found by SweepOrphans.
+func (m *Manager) Register(ctx context.Context, path string) {
+	m.track(ctx, path)
+}
+
+// Release removes a tracked path and stops tracking it.
+// Removing a path that does not exist anymore is not an error.
+func (m *Manager) Release(path string) error {
+	m.mu.Lock()
+	stop, ok := m.paths[path]
+	delete(m.paths, path)
+	reservation := m.reservations[path]
+	delete(m.reservations, path)
+	m.mu.Unlock()
+	defer reservation.Release()
+
+	if ok {
+		stop()
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to remove temporary path %q: %w", path, err)
+	}
+	return nil
+}
+
+// Keep stops tracking a path without removing it, handing its ownership over to the caller.
+// The path is not charged against the quota anymore.
+func (m *Manager) Keep(path string) {
+	m.mu.Lock()
+	stop, ok := m.paths[path]
+	delete(m.paths, path)
+	reservation := m.reservations[path]
+	delete(m.reservations, path)
+	m.mu.Unlock()
+	reservation.Release()
+
+	if ok {
+		stop()
+	}
+}
+
+// Cleanup removes all tracked paths and the session file.
+// The manager can still be used afterward.
+func (m *Manager) Cleanup() error {
+	m.mu.Lock()
+	paths := make([]string, 0, len(m.paths))
+	for path := range m.paths {
+		paths = append(paths, path)
+	}
+	sessionFile, sessionLock := m.sessionFile, m.sessionLock
+	m.sessionFile, m.sessionLock = "", nil
+	m.mu.Unlock()
+
+	var errs []error
+	for _, path := range paths {
+		errs = append(errs, m.Release(path))
+	}
+	if sessionFile != "" {
+		// the lock is released first, files that are open cannot be removed on all platforms.
+		_ = sessionLock.Close()
+		if err := os.Remove(sessionFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to remove session file: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SweepOrphans removes the artifacts of all sessions whose process is no longer running.
+// Sessions are looked up by their session files in the directory of the manager. A session is
+// alive as long as its session file is locked, the lock is released by the operating system once
+// the process owning the session exits. On platforms without file locks, no session is swept. Artifacts are
+// removed from the directory of the manager and from all additional dirs. Sessions of other
+// managers are only recognized if they share the same directory.
+// The paths of the removed artifacts are returned.
+func (m *Manager) SweepOrphans(dirs ...string) ([]string, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read temporary directory: %w", err)
+	}
+
+	var (
+		errs         []error
+		orphans      []string
+		sessionFiles []string
+	)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, namePrefix) || !strings.HasSuffix(name, sessionFileSuffix) {
+			continue
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(name, namePrefix), sessionFileSuffix)
+		if id == m.id {
+			continue
+		}
+		path := filepath.Join(m.dir, name)
+		alive, err := sessionAlive(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !alive {
+			orphans = append(orphans, id)
+			sessionFiles = append(sessionFiles, path)
+		}
+	}
+	if len(orphans) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	var removed []string
+	for _, dir := range append([]string{m.dir}, dirs...) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read temporary directory: %w", err))
+			continue
+		}
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), sessionFileSuffix) {
+				continue
+			}
+			for _, id := range orphans {
+				if !strings.HasPrefix(entry.Name(), namePrefix+id+"-") {
+					continue
+				}
+				path := filepath.Join(dir, entry.Name())
+				if err := os.RemoveAll(path); err != nil {
+					errs = append(errs, fmt.Errorf("failed to remove orphaned path %q: %w", path, err))
+					continue
+				}
+				removed = append(removed, path)
+			}
+		}
+	}
+
+	// session files are removed last so that a failed sweep can be repeated.
+	if len(errs) == 0 {
+		for _, path := range sessionFiles {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("failed to remove session file: %w", err))
+			}
+		}
+	}
+
+	return removed, errors.Join(errs...)
+}
+
+func (m *Manager) prefix() string {
+	return namePrefix + m.id + "-"
+}
+
+func (m *Manager) track(ctx context.Context, path string) {
+	stop := context.AfterFunc(ctx, func() {
+		_ = m.Release(path)
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if previous, ok := m.paths[path]; ok {
+		previous()
+	}
+	m.paths[path] = stop
+}
+
+// writeSessionFile creates and locks the session file if it does not exist yet. The lock is held until Cleanup.
+// The session file is created, locked and filled with the PID of the process under a temporary name and then
+// renamed into place, so that a sweep never finds the session file of a live session unlocked.
+func (m *Manager) writeSessionFile() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessionFile != "" {
+		return nil
+	}
+	path := filepath.Join(m.dir, namePrefix+m.id+sessionFileSuffix)
+	if _, err := os.Lstat(path); !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not create session file: session %q already exists", m.id)
+	}
+	// the temporary name is an artifact name of the session, not a session file.
+	pending := filepath.Join(m.dir, m.prefix()+"session")
+	file, err := createExclusive(pending)
+	if err != nil {
+		return fmt.Errorf("could not create session file: %w", err)
+	}
+	if err := tryLock(file); err != nil && !errors.Is(err, errors.ErrUnsupported) {
+		return errors.Join(fmt.Errorf("could not lock session file: %w", err), file.Close(), os.Remove(pending))
+	}
+	if _, err := file.WriteString(strconv.Itoa(os.Getpid())); err != nil {
+		return errors.Join(fmt.Errorf("could not write session file: %w", err), file.Close(), os.Remove(pending))
+	}
+	if err := os.Rename(pending, path); err != nil {
+		return errors.Join(fmt.Errorf("could not create session file: %w", err), file.Close(), os.Remove(pending))
+	}
+	m.sessionFile = path
+	m.sessionLock = file
+	return nil
+}
+
+// sessionAlive reports whether the session of the session file is still alive, which is the case
+// as long as its process holds the lock on the session file.
+func sessionAlive(sessionFile string) (bool, error) {
+	file, err := os.OpenFile(sessionFile, os.O_RDWR, 0)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// the session was cleaned up concurrently.
+			return true, nil
+		}
+		return false, fmt.Errorf("could not open session file: %w", err)
+	}
+	defer file.Close()
+
+	switch err := tryLock(file); {
+	case errors.Is(err, errLocked), errors.Is(err, errors.ErrUnsupported):
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("could not lock session file: %w", err)
+	}
+	// session files are only put in place once locked, so the session is dead if the lock was acquired.
+	return false, nil
+}
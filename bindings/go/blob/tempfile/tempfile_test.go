@@ -0,0 +1,194 @@
+package tempfile_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/quota"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
+)
+
+func TestManager_CleanupOnContextDone(t *testing.T) {
+	r := require.New(t)
+	m := tempfile.NewManager(tempfile.WithDir(t.TempDir()))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	file, err := m.CreateTemp(ctx, "", "buffer-*")
+	r.NoError(err)
+	r.NoError(file.Close())
+	dir, err := m.MkdirTemp(ctx, "", "download-*")
+	r.NoError(err)
+	r.NoError(os.WriteFile(filepath.Join(dir, "content"), []byte("data"), 0o600))
+
+	kept, err := m.CreateTemp(context.WithoutCancel(ctx), "", "output-*")
+	r.NoError(err)
+	r.NoError(kept.Close())
+
+	r.Contains(filepath.Base(file.Name()), m.SessionID())
+	r.FileExists(file.Name())
+	r.DirExists(dir)
+	sessionFile, err := os.Stat(filepath.Join(m.Dir(), "ocm-"+m.SessionID()+".session"))
+	r.NoError(err)
+	r.Equal(int64(len(strconv.Itoa(os.Getpid()))), sessionFile.Size(), "the session file is put in place with the PID")
+	r.NoFileExists(filepath.Join(m.Dir(), "ocm-"+m.SessionID()+"-session"))
+
+	cancel()
+	r.Eventually(func() bool {
+		_, fileErr := os.Stat(file.Name())
+		_, dirErr := os.Stat(dir)
+		return os.IsNotExist(fileErr) && os.IsNotExist(dirErr)
+	}, time.Second, 10*time.Millisecond)
+	r.FileExists(kept.Name())
+
+	_, err = m.CreateTemp(ctx, "", "late-*")
+	r.ErrorIs(err, context.Canceled)
+
+	r.NoError(m.Cleanup())
+	r.NoFileExists(kept.Name())
+	entries, err := os.ReadDir(m.Dir())
+	r.NoError(err)
+	r.Empty(entries, "cleanup should remove all artifacts and the session file")
+}
+
+func TestManager_ReleaseAndKeep(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	m := tempfile.NewManager(tempfile.WithDir(t.TempDir()))
+
+	released, err := m.CreateTemp(ctx, "", "released-*")
+	r.NoError(err)
+	r.NoError(released.Close())
+	r.NoError(m.Release(released.Name()))
+	r.NoFileExists(released.Name())
+	r.NoError(m.Release(released.Name()), "releasing a removed path should succeed")
+
+	kept, err := m.CreateTemp(ctx, "", "kept-*")
+	r.NoError(err)
+	r.NoError(kept.Close())
+	m.Keep(kept.Name())
+
+	registered := filepath.Join(t.TempDir(), "registered")
+	r.NoError(os.WriteFile(registered, []byte("data"), 0o600))
+	m.Register(ctx, registered)
+
+	r.NoError(m.Cleanup())
+	r.FileExists(kept.Name())
+	r.NoFileExists(registered)
+}
+
+func TestManager_WriteTempQuota(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	q := quota.New(quota.Limits{MaxTotalBytes: 10})
+	m := tempfile.NewManager(tempfile.WithDir(t.TempDir()), tempfile.WithQuota(q))
+
+	path, err := m.WriteTemp(ctx, "", "content-*", strings.NewReader("012345"))
+	r.NoError(err)
+	content, err := os.ReadFile(path)
+	r.NoError(err)
+	r.Equal("012345", string(content))
+	r.Equal(int64(6), q.Stats().Used)
+
+	_, err = m.WriteTemp(ctx, "", "exceeding-*", strings.NewReader("012345"))
+	r.ErrorIs(err, quota.ErrExceeded)
+	matches, err := filepath.Glob(filepath.Join(m.Dir(), "*exceeding-*"))
+	r.NoError(err)
+	r.Empty(matches, "the file exceeding the quota must be removed")
+	r.Equal(int64(6), q.Stats().Used)
+
+	operation := quota.New(quota.Limits{MaxBlobBytes: 2})
+	_, err = m.WriteTemp(quota.WithQuota(ctx, operation), "", "operation-*", strings.NewReader("012"))
+	r.ErrorIs(err, quota.ErrExceeded, "the quota of the context takes precedence")
+
+	r.NoError(m.Release(path))
+	r.Equal(int64(0), q.Stats().Used)
+}
+
+func TestManager_SweepOrphans(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	dir, extraDir := t.TempDir(), t.TempDir()
+
+	// a session of a process that exited without cleaning up.
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperOrphanedSession$")
+	cmd.Env = append(os.Environ(), "OCM_TEMPFILE_HELPER_DIRS="+dir+string(os.PathListSeparator)+extraDir)
+	out, err := cmd.Output()
+	r.NoError(err)
+	var orphanedFile, orphanedDir string
+	for _, line := range strings.Split(string(out), "\n") {
+		if path, ok := strings.CutPrefix(line, "file="); ok {
+			orphanedFile = path
+		}
+		if path, ok := strings.CutPrefix(line, "dir="); ok {
+			orphanedDir = path
+		}
+	}
+	r.FileExists(orphanedFile)
+	r.DirExists(orphanedDir)
+
+	// a session of a running process.
+	alive := tempfile.NewManager(tempfile.WithDir(dir))
+	aliveFile, err := alive.CreateTemp(ctx, "", "buffer-*")
+	r.NoError(err)
+	r.NoError(aliveFile.Close())
+
+	unrelated := filepath.Join(dir, "unrelated")
+	r.NoError(os.WriteFile(unrelated, []byte("data"), 0o600))
+
+	m := tempfile.NewManager(tempfile.WithDir(dir))
+	removed, err := m.SweepOrphans(extraDir)
+	r.NoError(err)
+	r.ElementsMatch([]string{orphanedFile, orphanedDir}, removed)
+	r.NoFileExists(orphanedFile)
+	r.NoDirExists(orphanedDir)
+	r.NoFileExists(filepath.Join(dir, "ocm-orphaned.session"))
+	r.FileExists(aliveFile.Name())
+	r.FileExists(unrelated)
+
+	removed, err = m.SweepOrphans(extraDir)
+	r.NoError(err)
+	r.Empty(removed)
+}
+
+// TestHelperOrphanedSession is not a test, it creates the artifacts of a session for TestManager_SweepOrphans
+// and exits without cleaning them up.
+func TestHelperOrphanedSession(t *testing.T) {
+	dirs := filepath.SplitList(os.Getenv("OCM_TEMPFILE_HELPER_DIRS"))
+	if len(dirs) != 2 {
+		t.Skip("only run as helper process of TestManager_SweepOrphans")
+	}
+	r := require.New(t)
+	m := tempfile.NewManager(tempfile.WithDir(dirs[0]), tempfile.WithSessionID("orphaned"))
+	file, err := m.CreateTemp(context.Background(), "", "buffer-*")
+	r.NoError(err)
+	r.NoError(file.Close())
+	dir, err := m.MkdirTemp(context.Background(), dirs[1], "download-*")
+	r.NoError(err)
+	fmt.Printf("file=%s\ndir=%s\n", file.Name(), dir)
+}
+
+func TestFromContext(t *testing.T) {
+	r := require.New(t)
+	m := tempfile.NewManager(tempfile.WithDir(t.TempDir()))
+
+	r.Same(tempfile.Default(), tempfile.FromContext(t.Context()))
+
+	ctx := tempfile.WithManager(t.Context(), m)
+	r.Same(m, tempfile.FromContext(ctx))
+
+	file, err := tempfile.CreateTemp(ctx, "", "buffer-*")
+	r.NoError(err)
+	r.NoError(file.Close())
+	r.Equal(m.Dir(), filepath.Dir(file.Name()))
+	r.NoError(tempfile.Release(ctx, file.Name()))
+	r.NoFileExists(file.Name())
+}
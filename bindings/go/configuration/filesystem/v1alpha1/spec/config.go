@@ -2,7 +2,6 @@ package spec
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 	"time"
 
@@ -18,7 +17,10 @@ const (
 var Scheme = runtime.NewScheme()
 
 func init() {
-	Scheme.MustRegisterWithAlias(&Config{}, runtime.NewVersionedType(ConfigType, Version))
+	Scheme.MustRegisterWithAlias(&Config{},
+		runtime.NewVersionedType(ConfigType, Version),
+		runtime.NewUnversionedType(ConfigType),
+	)
 }
 
 // Config represents the top-level configuration for the plugin manager.
@@ -41,6 +43,11 @@ type Config struct {
 	// to resolve relative paths for file operations.
 	// If not defined, the current working directory is used as a default for file operations.
 	WorkingDirectory string `json:"workingDirectory,omitempty"`
+
+	// ResumableDownloadFolder defines where downloads of OCI artifacts keep their partial state,
+	// so that interrupted downloads of large artifacts are resumed by later runs instead of restarted.
+	// State that is not modified for a day is removed. If not defined, downloads are not resumable.
+	ResumableDownloadFolder string `json:"resumableDownloadFolder,omitempty"`
 }
 
 type Duration time.Duration
@@ -56,39 +63,17 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 }
 
 // LookupConfig creates a new filesystem configuration from a central V1 config.
+// The configurations are validated against the JSON schema of Config, and the defaults are applied to the merged
+// configuration, see genericv1.Lookup.
 func LookupConfig(cfg *genericv1.Config) (*Config, error) {
-	var merged *Config
-	if cfg != nil {
-		cfg, err := genericv1.Filter(cfg, &genericv1.FilterOptions{
-			ConfigTypes: []runtime.Type{
-				runtime.NewVersionedType(ConfigType, Version),
-				runtime.NewUnversionedType(ConfigType),
-			},
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to filter config: %w", err)
-		}
-		cfgs := make([]*Config, 0, len(cfg.Configurations))
-		for _, entry := range cfg.Configurations {
-			var config Config
-			if err := Scheme.Convert(entry, &config); err != nil {
-				return nil, fmt.Errorf("failed to decode credential config: %w", err)
-			}
-			cfgs = append(cfgs, &config)
-		}
-		merged = Merge(cfgs...)
-		if merged == nil {
-			merged = &Config{}
-		}
-	} else {
-		merged = new(Config)
-	}
+	return genericv1.Lookup(Scheme, cfg, Merge)
+}
 
-	if len(merged.TempFolder) == 0 {
-		merged.TempFolder = os.TempDir()
+// Default sets the TempFolder to os.TempDir if it is not defined.
+func (c *Config) Default() {
+	if len(c.TempFolder) == 0 {
+		c.TempFolder = os.TempDir()
 	}
-
-	return merged, nil
 }
 
 // Merge merges the provided configs into a single config.
@@ -107,6 +92,9 @@ func Merge(configs ...*Config) *Config {
 		if config.WorkingDirectory != merged.WorkingDirectory {
 			merged.WorkingDirectory = config.WorkingDirectory
 		}
+		if config.ResumableDownloadFolder != merged.ResumableDownloadFolder {
+			merged.ResumableDownloadFolder = config.ResumableDownloadFolder
+		}
 	}
 
 	return merged
@@ -25,4 +25,13 @@
 //				 type: DockerConfig/v1
 //				 dockerConfigFile: "~/.docker/config.json"
 //				 propagateConsumerIdentity: true
+//
+// Configuration files decoded with Decode remember the file and line of every configuration.
+// Configuration types look up their configuration with Lookup, which validates every configuration
+// against the JSON schema of the type, merges them and applies the defaults of the type. Invalid
+// configurations are reported together in a ValidationError that points to their file and line:
+//
+//	func LookupConfig(cfg *genericv1.Config) (*Config, error) {
+//		return genericv1.Lookup(Scheme, cfg, Merge)
+//	}
 package spec
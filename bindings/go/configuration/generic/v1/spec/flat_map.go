@@ -27,6 +27,7 @@ import (
 // Configuration types are decoded the least effort, and if they are not yet decoded,
 // they will only be loaded in if they are of type ConfigType.
 // All other types will be left as is and taken over.
+// Nested configurations without a recorded Source inherit the Source of their parent, see SourceOf.
 func FlatMap(configs ...*Config) *Config {
 	merged := new(Config)
 	merged.Configurations = make([]*runtime.Raw, 0)
@@ -37,6 +38,13 @@ func FlatMap(configs ...*Config) *Config {
 			if err := Scheme.Convert(config, &cfg); err != nil {
 				merged.Configurations = append(merged.Configurations, config)
 			} else {
+				if source, ok := SourceOf(config); ok {
+					for _, nested := range cfg.Configurations {
+						if _, ok := SourceOf(nested); !ok {
+							SetSource(nested, source)
+						}
+					}
+				}
 				flattenCandidates = append(flattenCandidates, &cfg)
 			}
 		}
@@ -0,0 +1,205 @@
+package spec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ErrInvalidConfiguration is matched by ValidationError.
+var ErrInvalidConfiguration = errors.New("invalid configuration")
+
+// Defaulter is implemented by configuration types that set defaults for the fields left empty.
+// Lookup applies the defaults to the merged configuration.
+type Defaulter interface {
+	Default()
+}
+
+// Violation describes an invalid configuration.
+type Violation struct {
+	// Type is the type of the configuration.
+	Type runtime.Type
+	// Source is the location the configuration was loaded from. Its File is empty if unknown.
+	Source Source
+	// Err describes why the configuration is invalid.
+	Err error
+}
+
+func (v Violation) Error() string {
+	if v.Source.File == "" {
+		return fmt.Sprintf("%s: %v", v.Type, v.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", v.Source, v.Type, v.Err)
+}
+
+// ValidationError is returned by Lookup if configurations are invalid. It lists the violations of all
+// configurations of the looked up type, so that they can be fixed at once.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString(ErrInvalidConfiguration.Error())
+	for _, violation := range e.Violations {
+		b.WriteString("\n- ")
+		b.WriteString(violation.Error())
+	}
+	return b.String()
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidConfiguration
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		errs = append(errs, violation.Err)
+	}
+	return errs
+}
+
+// Lookup returns the configuration of type T from the central configuration, so that configuration
+// types do not have to validate and default their configurations themselves:
+//
+//  1. cfg is filtered for the types T is registered with in the scheme.
+//  2. Every configuration is validated against the JSON schema of T, if T implements
+//     runtime.JSONSchemaIntrospectable (as generated by jsonschemagen), and decoded.
+//     Configurations larger than runtime.DefaultMaxRawSize are rejected.
+//  3. The configurations are merged with merge in ascending priority.
+//  4. The defaults of T are applied to the merged configuration, if T implements Defaulter.
+//
+// If cfg is nil or contains no configuration of type T, the defaulted empty configuration is returned.
+// Invalid configurations are reported together in a *ValidationError, with the file and line they were loaded
+// from if known, see Decode.
+func Lookup[T runtime.Typed](scheme *runtime.Scheme, cfg *Config, merge func(...T) T) (T, error) {
+	var zero T
+	typ, err := scheme.TypeForPrototype(zero)
+	if err != nil {
+		return zero, fmt.Errorf("failed to get type for prototype of type %T: %w", zero, err)
+	}
+
+	var configs []T
+	if cfg != nil {
+		if configs, err = decodeAndValidate[T](scheme, typ, cfg); err != nil {
+			return zero, err
+		}
+	}
+
+	var merged T
+	if len(configs) > 0 {
+		merged = merge(configs...)
+	} else {
+		obj, err := scheme.NewObject(typ)
+		if err != nil {
+			return zero, fmt.Errorf("failed to create object for type %s: %w", typ, err)
+		}
+		merged = obj.(T)
+	}
+	if defaulter, ok := any(merged).(Defaulter); ok {
+		defaulter.Default()
+	}
+	return merged, nil
+}
+
+func decodeAndValidate[T runtime.Typed](scheme *runtime.Scheme, typ runtime.Type, cfg *Config) ([]T, error) {
+	types := append(scheme.GetTypes()[typ], typ) //nolint:gocritic // appendAssign to new variable should be safe here
+	filtered, err := Filter(cfg, &FilterOptions{ConfigTypes: types})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter for types %v: %w", types, err)
+	}
+
+	prototype, err := scheme.NewObject(typ)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object for type %s: %w", typ, err)
+	}
+	var schema *jsonschema.Schema
+	if introspectable, ok := prototype.(runtime.JSONSchemaIntrospectable); ok {
+		if schema, err = compileSchema(typ, introspectable.JSONSchema()); err != nil {
+			return nil, err
+		}
+	}
+
+	configs := make([]T, 0, len(filtered.Configurations))
+	var violations []Violation
+	for _, entry := range filtered.Configurations {
+		source, _ := SourceOf(entry)
+		// the size is checked before the configuration is parsed for validation.
+		if err := runtime.CheckRawSize(entry.Data, runtime.DefaultMaxRawSize); err != nil {
+			violations = append(violations, Violation{Type: entry.GetType(), Source: source, Err: err})
+			continue
+		}
+		if schema != nil {
+			if err := validate(schema, entry); err != nil {
+				violations = append(violations, Violation{Type: entry.GetType(), Source: source, Err: err})
+				continue
+			}
+		}
+		obj, err := scheme.NewObject(typ)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create object for type %s: %w", typ, err)
+		}
+		if err := scheme.Convert(entry, obj); err != nil {
+			violations = append(violations, Violation{Type: entry.GetType(), Source: source, Err: err})
+			continue
+		}
+		configs = append(configs, obj.(T))
+	}
+	if len(violations) > 0 {
+		return nil, &ValidationError{Violations: violations}
+	}
+	return configs, nil
+}
+
+// schemas caches the compiled JSON schemas by type.
+var schemas sync.Map
+
+func compileSchema(typ runtime.Type, data []byte) (*jsonschema.Schema, error) {
+	if schema, ok := schemas.Load(typ.String()); ok {
+		return schema.(*jsonschema.Schema), nil
+	}
+	resource, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON schema of %s: %w", typ, err)
+	}
+	location := typ.String() + ".schema.json"
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(location, resource); err != nil {
+		return nil, fmt.Errorf("failed to add JSON schema of %s: %w", typ, err)
+	}
+	schema, err := compiler.Compile(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile JSON schema of %s: %w", typ, err)
+	}
+	schemas.Store(typ.String(), schema)
+	return schema, nil
+}
+
+func validate(schema *jsonschema.Schema, entry *runtime.Raw) error {
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(entry.Data))
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal configuration: %w", err)
+	}
+	err = schema.Validate(instance)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	// the first line only names the schema, the following lines list the violated constraints.
+	lines := strings.Split(validationErr.Error(), "\n")
+	if len(lines) < 2 {
+		return err
+	}
+	details := make([]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		details = append(details, strings.TrimPrefix(strings.TrimSpace(line), "- "))
+	}
+	return errors.New(strings.Join(details, "; "))
+}
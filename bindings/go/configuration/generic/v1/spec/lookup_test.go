@@ -0,0 +1,134 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const lookupTestType = "lookup.config.ocm.software"
+
+type lookupTestConfig struct {
+	Type      runtime.Type `json:"type"`
+	Name      string       `json:"name,omitempty"`
+	Retries   int          `json:"retries,omitempty"`
+	Defaulted bool         `json:"-"`
+}
+
+func (c *lookupTestConfig) GetType() runtime.Type    { return c.Type }
+func (c *lookupTestConfig) SetType(typ runtime.Type) { c.Type = typ }
+func (c *lookupTestConfig) DeepCopyTyped() runtime.Typed {
+	cp := *c
+	return &cp
+}
+
+func (c *lookupTestConfig) JSONSchema() []byte {
+	return []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "type": {"type": "string"},
+    "name": {"type": "string"},
+    "retries": {"type": "integer", "minimum": 0}
+  },
+  "required": ["type"],
+  "additionalProperties": false
+}`)
+}
+
+func (c *lookupTestConfig) Default() {
+	c.Defaulted = true
+	if c.Retries == 0 {
+		c.Retries = 3
+	}
+}
+
+func mergeLookupTestConfigs(configs ...*lookupTestConfig) *lookupTestConfig {
+	merged := &lookupTestConfig{Type: runtime.NewVersionedType(lookupTestType, "v1")}
+	for _, config := range configs {
+		if config.Name != "" {
+			merged.Name = config.Name
+		}
+		if config.Retries != 0 {
+			merged.Retries = config.Retries
+		}
+	}
+	return merged
+}
+
+func TestLookup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterWithAlias(&lookupTestConfig{},
+		runtime.NewVersionedType(lookupTestType, "v1"),
+		runtime.NewUnversionedType(lookupTestType),
+	)
+
+	t.Run("merges and defaults valid configurations", func(t *testing.T) {
+		r := require.New(t)
+		cfg, err := Decode([]byte(`type: generic.config.ocm.software/v1
+configurations:
+- type: lookup.config.ocm.software/v1
+  name: first
+- type: other.config.ocm.software
+- type: lookup.config.ocm.software
+  name: second
+`), "config.yaml")
+		r.NoError(err)
+
+		config, err := Lookup(scheme, cfg, mergeLookupTestConfigs)
+		r.NoError(err)
+		r.Equal("second", config.Name)
+		r.Equal(3, config.Retries)
+		r.True(config.Defaulted)
+	})
+
+	t.Run("defaults without configurations", func(t *testing.T) {
+		r := require.New(t)
+		config, err := Lookup(scheme, nil, mergeLookupTestConfigs)
+		r.NoError(err)
+		r.Equal(3, config.Retries)
+		r.True(config.Defaulted)
+	})
+
+	t.Run("reports all invalid configurations with their source", func(t *testing.T) {
+		r := require.New(t)
+		cfg, err := Decode([]byte(`type: generic.config.ocm.software/v1
+configurations:
+- type: lookup.config.ocm.software/v1
+  retries: -1
+- type: lookup.config.ocm.software/v1
+  name: valid
+- type: generic.config.ocm.software/v1
+  configurations:
+  - type: lookup.config.ocm.software/v1
+    unknown: true
+`), "config.yaml")
+		r.NoError(err)
+
+		_, err = Lookup(scheme, FlatMap(cfg), mergeLookupTestConfigs)
+		r.ErrorIs(err, ErrInvalidConfiguration)
+		var validationErr *ValidationError
+		r.ErrorAs(err, &validationErr)
+		r.Len(validationErr.Violations, 2)
+		r.Equal(Source{File: "config.yaml", Line: 3}, validationErr.Violations[0].Source)
+		r.ErrorContains(validationErr.Violations[0].Err, "minimum")
+		// nested configurations have the source of their parent.
+		r.Equal(Source{File: "config.yaml", Line: 7}, validationErr.Violations[1].Source)
+		r.ErrorContains(validationErr.Violations[1].Err, "unknown")
+		r.Contains(err.Error(), "- config.yaml:3: lookup.config.ocm.software/v1: ")
+	})
+	t.Run("rejects oversized configurations", func(t *testing.T) {
+		r := require.New(t)
+		cfg := &Config{Configurations: []*runtime.Raw{{
+			Type: runtime.NewVersionedType(lookupTestType, "v1"),
+			Data: []byte(`{"type":"lookup.config.ocm.software/v1","name":"` + strings.Repeat("x", runtime.DefaultMaxRawSize) + `"}`),
+		}}}
+
+		_, err := Lookup(scheme, cfg, mergeLookupTestConfigs)
+		r.ErrorIs(err, ErrInvalidConfiguration)
+		r.ErrorIs(err, runtime.ErrRawSizeLimitExceeded)
+	})
+}
@@ -0,0 +1,96 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	goruntime "runtime"
+	"sync"
+	"weak"
+
+	"go.yaml.in/yaml/v3"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Source is the location a configuration was loaded from.
+type Source struct {
+	// File is the path of the configuration file.
+	File string
+	// Line is the line of the configuration in the file, starting at 1. It is 0 if unknown.
+	Line int
+}
+
+func (s Source) String() string {
+	if s.Line == 0 {
+		return s.File
+	}
+	return fmt.Sprintf("%s:%d", s.File, s.Line)
+}
+
+// sources maps configurations (weak.Pointer[runtime.Raw]) to the Source they were loaded from.
+// Entries are removed once their configuration is garbage collected.
+var sources sync.Map
+
+// SetSource records the location the configuration was loaded from, see SourceOf.
+func SetSource(entry *runtime.Raw, source Source) {
+	key := weak.Make(entry)
+	if _, loaded := sources.Swap(key, source); !loaded {
+		goruntime.AddCleanup(entry, func(key weak.Pointer[runtime.Raw]) {
+			sources.Delete(key)
+		}, key)
+	}
+}
+
+// SourceOf returns the location the configuration was loaded from, if it was recorded by Decode or SetSource.
+// Configurations nested in generic configurations have the source of their parent after FlatMap.
+func SourceOf(entry *runtime.Raw) (Source, bool) {
+	source, ok := sources.Load(weak.Make(entry))
+	if !ok {
+		return Source{}, false
+	}
+	return source.(Source), true
+}
+
+// Decode decodes a generic configuration from the YAML or JSON data of the given file and records the file and
+// line of every configuration in it, so that errors about the configurations can point to their source.
+func Decode(data []byte, file string) (*Config, error) {
+	var config Config
+	if err := Scheme.Decode(bytes.NewReader(data), &config); err != nil {
+		return nil, err
+	}
+
+	lines := configurationLines(data)
+	for i, entry := range config.Configurations {
+		source := Source{File: file}
+		if i < len(lines) {
+			source.Line = lines[i]
+		}
+		SetSource(entry, source)
+	}
+	return &config, nil
+}
+
+// configurationLines returns the lines of the entries of the configurations list in data.
+// It returns nil if the lines cannot be determined.
+func configurationLines(data []byte) []int {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil || len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "configurations" || root.Content[i+1].Kind != yaml.SequenceNode {
+			continue
+		}
+		entries := root.Content[i+1].Content
+		lines := make([]int, len(entries))
+		for j, entry := range entries {
+			lines[j] = entry.Line
+		}
+		return lines
+	}
+	return nil
+}
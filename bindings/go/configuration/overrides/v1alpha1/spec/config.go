@@ -0,0 +1,143 @@
+package spec
+
+import (
+	"fmt"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	ConfigType = "overrides.config.ocm.software"
+	Version    = "v1alpha1"
+)
+
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&Config{},
+		runtime.NewVersionedType(ConfigType, Version),
+		runtime.NewUnversionedType(ConfigType),
+	)
+}
+
+// Config is the OCM configuration type for overriding component references at consumption time.
+//
+//	type: overrides.config.ocm.software/v1alpha1
+//	overrides:
+//	- component: ocm.software/base
+//	  version: 1.0.0
+//	  substitute:
+//	    version: 1.0.1-patched
+//	  reason: CVE-2026-0001
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Config struct {
+	// +ocm:jsonschema-gen:enum=overrides.config.ocm.software/v1alpha1
+	// +ocm:jsonschema-gen:enum:deprecated=overrides.config.ocm.software
+	Type runtime.Type `json:"type"`
+
+	// Overrides remap component references to substitute component versions.
+	// An override for a specific version takes precedence over an override for all versions
+	// of the same component. Otherwise, the first matching override applies.
+	Overrides []Override `json:"overrides,omitempty"`
+}
+
+// Override substitutes the target of matching component references.
+//
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type Override struct {
+	// Component is the name of the referenced component to override.
+	Component string `json:"component"`
+
+	// Version limits the override to references of this version.
+	// If empty, references of all versions of the component are overridden.
+	Version string `json:"version,omitempty"`
+
+	// Substitute is the component version used instead of the referenced one.
+	Substitute Substitute `json:"substitute"`
+
+	// Reason documents why the override is needed. It is recorded with the resulting deviation.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Substitute is the component version that replaces an overridden component reference.
+//
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type Substitute struct {
+	// Component is the name of the substitute component. If empty, the referenced name is kept.
+	Component string `json:"component,omitempty"`
+
+	// Version is the version of the substitute component. If empty, the referenced version is kept.
+	Version string `json:"version,omitempty"`
+}
+
+// Validate rejects overrides without component or substitute.
+func (cfg *Config) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+	for i, override := range cfg.Overrides {
+		if override.Component == "" {
+			return fmt.Errorf("override %d: component is required", i)
+		}
+		if override.Substitute == (Substitute{}) {
+			return fmt.Errorf("override %d for component %s: substitute component or version is required", i, override.Component)
+		}
+	}
+	return nil
+}
+
+// Lookup creates a new Config from a central V1 config.
+// All entries of type [ConfigType] are decoded, validated and merged via [Merge].
+// Returns nil if cfg is nil or contains no override entries.
+func Lookup(cfg *genericv1.Config) (*Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	cfg, err := genericv1.Filter(cfg, &genericv1.FilterOptions{
+		ConfigTypes: []runtime.Type{
+			runtime.NewVersionedType(ConfigType, Version),
+			runtime.NewUnversionedType(ConfigType),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter config: %w", err)
+	}
+	cfgs := make([]*Config, 0, len(cfg.Configurations))
+	for _, entry := range cfg.Configurations {
+		var config Config
+		if err := Scheme.Convert(entry, &config); err != nil {
+			return nil, fmt.Errorf("failed to decode override config: %w", err)
+		}
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid override config: %w", err)
+		}
+		cfgs = append(cfgs, &config)
+	}
+	return Merge(cfgs...), nil
+}
+
+// Merge merges the provided configs into a single config by appending their overrides.
+func Merge(configs ...*Config) *Config {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	merged := new(Config)
+	_, _ = Scheme.DefaultType(merged)
+
+	for _, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		merged.Overrides = append(merged.Overrides, cfg.Overrides...)
+	}
+
+	return merged
+}
@@ -0,0 +1,60 @@
+package spec_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	overridespec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func makeGenericConfig(t *testing.T, entries ...string) *genericv1.Config {
+	t.Helper()
+	cfg := &genericv1.Config{
+		Type:           runtime.NewVersionedType(genericv1.ConfigType, genericv1.ConfigTypeV1),
+		Configurations: make([]*runtime.Raw, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		raw := &runtime.Raw{}
+		require.NoError(t, json.Unmarshal([]byte(entry), raw))
+		cfg.Configurations = append(cfg.Configurations, raw)
+	}
+	return cfg
+}
+
+func TestLookup(t *testing.T) {
+	t.Run("merges overrides of all entries", func(t *testing.T) {
+		r := require.New(t)
+		cfg, err := overridespec.Lookup(makeGenericConfig(t, `{
+			"type": "overrides.config.ocm.software/v1alpha1",
+			"overrides": [{"component": "ocm.software/base", "version": "1.0.0", "substitute": {"version": "1.0.1"}, "reason": "CVE-2026-0001"}]
+		}`, `{
+			"type": "overrides.config.ocm.software",
+			"overrides": [{"component": "ocm.software/db", "substitute": {"component": "acme.org/db"}}]
+		}`))
+		r.NoError(err)
+		r.Equal([]overridespec.Override{
+			{Component: "ocm.software/base", Version: "1.0.0", Substitute: overridespec.Substitute{Version: "1.0.1"}, Reason: "CVE-2026-0001"},
+			{Component: "ocm.software/db", Substitute: overridespec.Substitute{Component: "acme.org/db"}},
+		}, cfg.Overrides)
+	})
+
+	t.Run("no override entries", func(t *testing.T) {
+		r := require.New(t)
+		cfg, err := overridespec.Lookup(makeGenericConfig(t))
+		r.NoError(err)
+		r.Nil(cfg)
+	})
+
+	t.Run("override without substitute is rejected", func(t *testing.T) {
+		r := require.New(t)
+		_, err := overridespec.Lookup(makeGenericConfig(t, `{
+			"type": "overrides.config.ocm.software/v1alpha1",
+			"overrides": [{"component": "ocm.software/base", "substitute": {}}]
+		}`))
+		r.ErrorContains(err, "substitute component or version is required")
+	})
+}
@@ -0,0 +1,7 @@
+// Package spec implements the specification for the consumer-side component reference
+// override configuration type "overrides.config.ocm.software".
+//
+// Overrides remap component references to a different component name or version while
+// component versions are resolved or transferred, e.g. to substitute a patched child
+// component without rebuilding its parents.
+package spec
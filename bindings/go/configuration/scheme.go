@@ -5,6 +5,7 @@ import (
 	filesystemv1alpha1 "ocm.software/open-component-model/bindings/go/configuration/filesystem/v1alpha1/spec"
 	genericspecv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
 	ocmv1 "ocm.software/open-component-model/bindings/go/configuration/ocm/v1/spec"
+	overridesv1alpha1 "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -22,5 +23,6 @@ func Register(scheme *runtime.Scheme) error {
 		filesystemv1alpha1.Scheme,
 		extractv1alpha1.Scheme,
 		ocmv1.Scheme,
+		overridesv1alpha1.Scheme,
 	)
 }
@@ -0,0 +1,27 @@
+// Package sops decrypts OCM configuration files that are encrypted at rest in the
+// SOPS format (https://getsops.io), so that configuration files containing
+// credentials can be committed to version control and are decrypted transparently
+// when they are loaded.
+//
+// A SOPS encrypted file is a regular YAML or JSON document in which every value is
+// replaced by an authenticated AES256-GCM ciphertext of the form
+//
+//	password: ENC[AES256_GCM,data:...,iv:...,tag:...,type:str]
+//
+// and which carries a top-level «sops» entry with the metadata needed to decrypt
+// it: the data key encrypted for one or more master keys, and a message
+// authentication code over all values of the document. Only the values are
+// encrypted, so the structure of the configuration stays readable and diffable.
+//
+// Such files are created with the sops tool, for example for an age recipient:
+//
+//	sops encrypt --age age1... --input-type yaml --output-type yaml .ocmconfig > .ocmconfig.enc
+//
+// The [Decryptor] decrypts documents with the upstream sops library
+// (github.com/getsops/sops/v3). It recovers the data key with the first [KeySource]
+// that can decrypt it with one of the master keys of the file, decrypts all values
+// and verifies the message authentication code, so that tampering with any value of
+// the file is detected. Key sources are available for age identities, read from a
+// file or the environment following the conventions of the sops tool, and for AWS
+// KMS and GCP KMS keys, see [KeySourcesFromEnvironment].
+package sops
@@ -0,0 +1,217 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sopsage "github.com/getsops/sops/v3/age"
+	sopsgcpkms "github.com/getsops/sops/v3/gcpkms"
+	"github.com/getsops/sops/v3/keyservice"
+	sopskms "github.com/getsops/sops/v3/kms"
+)
+
+// ErrNoMatchingKey is returned if a [KeySource] cannot decrypt the data key of a file with any of
+// the master keys it is encrypted for.
+var ErrNoMatchingKey = errors.New("no matching master key")
+
+// Environment variables and the default key file location used by the sops tool to look up age identities.
+const (
+	// AgeKeyEnv contains age identities, one per line.
+	AgeKeyEnv = "SOPS_AGE_KEY"
+	// AgeKeyFileEnv contains the path of a file with age identities.
+	AgeKeyFileEnv = "SOPS_AGE_KEY_FILE"
+	// DefaultAgeKeyFile is the path of the age identities file relative to the user configuration directory.
+	DefaultAgeKeyFile = "sops/age/keys.txt"
+)
+
+// KeySource decrypts the data key of an encrypted document with one kind of master key.
+type KeySource interface {
+	// DecryptDataKey decrypts the data key encrypted with the master key.
+	// It returns an error wrapping ErrNoMatchingKey if the source cannot decrypt data keys of the master key.
+	DecryptDataKey(ctx context.Context, key *keyservice.Key, encryptedDataKey []byte) ([]byte, error)
+}
+
+// AgeKeySource is a [KeySource] decrypting data keys with age identities.
+type AgeKeySource struct {
+	identities []age.Identity
+}
+
+var _ KeySource = (*AgeKeySource)(nil)
+
+// NewAgeKeySource creates a key source for the given age identities.
+func NewAgeKeySource(identities ...age.Identity) *AgeKeySource {
+	return &AgeKeySource{identities: identities}
+}
+
+// ParseAgeKeySource creates a key source from age identities in the format of an age identity file,
+// one identity per line.
+func ParseAgeKeySource(r io.Reader) (*AgeKeySource, error) {
+	identities, err := age.ParseIdentities(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse age identities: %w", err)
+	}
+	return NewAgeKeySource(identities...), nil
+}
+
+// AgeKeySourceFromFile creates a key source from the age identity file at the given path.
+func AgeKeySourceFromFile(path string) (_ *AgeKeySource, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	source, err := ParseAgeKeySource(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read age identity file %q: %w", path, err)
+	}
+	return source, nil
+}
+
+func (s *AgeKeySource) DecryptDataKey(_ context.Context, key *keyservice.Key, encryptedDataKey []byte) ([]byte, error) {
+	ageKey := key.GetAgeKey()
+	if ageKey == nil {
+		return nil, fmt.Errorf("%w: not an age recipient", ErrNoMatchingKey)
+	}
+
+	if len(s.identities) == 0 {
+		return nil, fmt.Errorf("%w: no age identities", ErrNoMatchingKey)
+	}
+	masterKey := &sopsage.MasterKey{Recipient: ageKey.GetRecipient(), EncryptedKey: string(encryptedDataKey)}
+	// applying the identities keeps the master key from loading identities from the environment.
+	sopsage.ParsedIdentities(s.identities).ApplyToMasterKey(masterKey)
+	dataKey, err := masterKey.Decrypt()
+	if err != nil {
+		if _, ok := errors.AsType[*age.NoIdentityMatchError](err); ok {
+			return nil, fmt.Errorf("%w: none of the age identities matches recipient %q", ErrNoMatchingKey, ageKey.GetRecipient())
+		}
+		return nil, fmt.Errorf("failed to decrypt data key for age recipient %q: %w", ageKey.GetRecipient(), err)
+	}
+	return dataKey, nil
+}
+
+// AWSKMSKeySource is a [KeySource] decrypting data keys with AWS KMS keys.
+type AWSKMSKeySource struct {
+	credentials aws.CredentialsProvider
+}
+
+var _ KeySource = (*AWSKMSKeySource)(nil)
+
+// NewAWSKMSKeySource creates a key source decrypting data keys with AWS KMS using the given credentials.
+// If credentials is nil, the credentials are resolved like the AWS CLI does, from the environment,
+// the shared configuration and credentials files (respecting the profile recorded for the key),
+// or the role of the workload.
+func NewAWSKMSKeySource(credentials aws.CredentialsProvider) *AWSKMSKeySource {
+	return &AWSKMSKeySource{credentials: credentials}
+}
+
+func (s *AWSKMSKeySource) DecryptDataKey(ctx context.Context, key *keyservice.Key, encryptedDataKey []byte) ([]byte, error) {
+	kmsKey := key.GetKmsKey()
+	if kmsKey == nil {
+		return nil, fmt.Errorf("%w: not an aws kms key", ErrNoMatchingKey)
+	}
+
+	encryptionContext := make(map[string]*string, len(kmsKey.GetContext()))
+	for k, v := range kmsKey.GetContext() {
+		encryptionContext[k] = &v
+	}
+	masterKey := sopskms.NewMasterKeyWithProfile(kmsKey.GetArn(), kmsKey.GetRole(), encryptionContext, kmsKey.GetAwsProfile())
+	masterKey.EncryptedKey = string(encryptedDataKey)
+	if s.credentials != nil {
+		sopskms.NewCredentialsProvider(s.credentials).ApplyToMasterKey(masterKey)
+	}
+	dataKey, err := masterKey.DecryptContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key with aws kms key %q: %w", kmsKey.GetArn(), err)
+	}
+	return dataKey, nil
+}
+
+// GCPKMSKeySource is a [KeySource] decrypting data keys with GCP KMS keys.
+type GCPKMSKeySource struct {
+	credentialsJSON []byte
+}
+
+var _ KeySource = (*GCPKMSKeySource)(nil)
+
+// NewGCPKMSKeySource creates a key source decrypting data keys with GCP KMS using the given
+// service account credentials in JSON format. If credentialsJSON is empty, the application
+// default credentials are used.
+func NewGCPKMSKeySource(credentialsJSON []byte) *GCPKMSKeySource {
+	return &GCPKMSKeySource{credentialsJSON: credentialsJSON}
+}
+
+func (s *GCPKMSKeySource) DecryptDataKey(ctx context.Context, key *keyservice.Key, encryptedDataKey []byte) ([]byte, error) {
+	gcpKey := key.GetGcpKmsKey()
+	if gcpKey == nil {
+		return nil, fmt.Errorf("%w: not a gcp kms key", ErrNoMatchingKey)
+	}
+
+	masterKey := sopsgcpkms.NewMasterKeyFromResourceID(gcpKey.GetResourceId())
+	masterKey.EncryptedKey = string(encryptedDataKey)
+	if len(s.credentialsJSON) > 0 {
+		sopsgcpkms.CredentialJSON(s.credentialsJSON).ApplyToMasterKey(masterKey)
+	}
+	dataKey, err := masterKey.DecryptContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key with gcp kms key %q: %w", gcpKey.GetResourceId(), err)
+	}
+	return dataKey, nil
+}
+
+// KeySourcesFromEnvironment returns the key sources configured in the environment, following the
+// conventions of the sops tool:
+//   - the age identities in the environment variable SOPS_AGE_KEY,
+//   - the age identity file referenced by the environment variable SOPS_AGE_KEY_FILE, and
+//   - the age identity file sops/age/keys.txt in the user configuration directory, if it exists, and
+//   - AWS KMS and GCP KMS with the credentials of the environment, see NewAWSKMSKeySource and NewGCPKMSKeySource.
+//
+// The user configuration directory is $XDG_CONFIG_HOME if set, or the result of userConfigDir otherwise.
+func KeySourcesFromEnvironment(getenv func(string) string, userConfigDir func() (string, error)) ([]KeySource, error) {
+	var sources []KeySource
+
+	if keys := getenv(AgeKeyEnv); keys != "" {
+		source, err := ParseAgeKeySource(strings.NewReader(keys))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", AgeKeyEnv, err)
+		}
+		sources = append(sources, source)
+	}
+
+	if path := getenv(AgeKeyFileEnv); path != "" {
+		source, err := AgeKeySourceFromFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", AgeKeyFileEnv, err)
+		}
+		sources = append(sources, source)
+	}
+
+	configDir := getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		if dir, err := userConfigDir(); err == nil {
+			configDir = dir
+		}
+	}
+	if configDir != "" {
+		source, err := AgeKeySourceFromFile(filepath.Join(configDir, DefaultAgeKeyFile))
+		switch {
+		case err == nil:
+			sources = append(sources, source)
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+	}
+
+	sources = append(sources, NewAWSKMSKeySource(nil), NewGCPKMSKeySource(nil))
+
+	return sources, nil
+}
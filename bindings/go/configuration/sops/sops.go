@@ -0,0 +1,153 @@
+package sops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sopsv3 "github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/keyservice"
+	sopsjson "github.com/getsops/sops/v3/stores/json"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"go.yaml.in/yaml/v3"
+)
+
+// MetadataKey is the top-level key of an encrypted document holding the sops metadata.
+const MetadataKey = "sops"
+
+var (
+	// ErrNotEncrypted is returned when decrypting a document that is not encrypted with sops.
+	ErrNotEncrypted = errors.New("document is not encrypted with sops")
+	// ErrMACMismatch is returned if the message authentication code of a document does not match its values.
+	// This happens if the values of the document were modified after encryption.
+	ErrMACMismatch = errors.New("message authentication code mismatch")
+)
+
+// IsEncrypted reports whether the YAML or JSON document is encrypted with sops.
+func IsEncrypted(data []byte) bool {
+	var document struct {
+		Metadata *struct {
+			MAC string `yaml:"mac"`
+		} `yaml:"sops"`
+	}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return false
+	}
+	return document.Metadata != nil && document.Metadata.MAC != ""
+}
+
+// store loads and emits documents in one of the formats supported by sops.
+type store interface {
+	LoadEncryptedFile(in []byte) (sopsv3.Tree, error)
+	EmitPlainFile(branches sopsv3.TreeBranches) ([]byte, error)
+}
+
+// storeFor returns the store for the format of the document, JSON for documents that are a JSON object,
+// and YAML otherwise.
+func storeFor(data []byte) store {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return sopsjson.NewStore(&config.JSONStoreConfig{})
+	}
+	return sopsyaml.NewStore(&config.YAMLStoreConfig{})
+}
+
+// Decryptor decrypts documents encrypted with sops.
+type Decryptor struct {
+	sources []KeySource
+}
+
+// NewDecryptor creates a decryptor recovering data keys with the given key sources.
+// The key sources are tried in order until one of them decrypts the data key.
+func NewDecryptor(sources ...KeySource) *Decryptor {
+	return &Decryptor{sources: sources}
+}
+
+// Decrypt decrypts the YAML or JSON document encrypted with sops and returns the plaintext
+// document in its original format, without the sops metadata.
+// It returns ErrNotEncrypted if the document is not encrypted with sops, and ErrMACMismatch
+// if the values of the document do not match its message authentication code.
+func (d *Decryptor) Decrypt(ctx context.Context, data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, ErrNotEncrypted
+	}
+
+	store := storeFor(data)
+	tree, err := store.LoadEncryptedFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encrypted document: %w", err)
+	}
+
+	service := &keyService{ctx: ctx, sources: d.sources}
+	dataKey, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{keyservice.NewCustomLocalClient(service)}, nil)
+	if err != nil {
+		return nil, service.err(err)
+	}
+
+	cipher := aes.NewCipher()
+	mac, err := tree.Decrypt(dataKey, cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt document: %w", err)
+	}
+	originalMAC, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt message authentication code: %w", err)
+	}
+	if originalMAC != mac {
+		return nil, ErrMACMismatch
+	}
+
+	plaintext, err := store.EmitPlainFile(tree.Branches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode decrypted document: %w", err)
+	}
+	return plaintext, nil
+}
+
+// keyService decrypts data keys for sops with the key sources of a Decryptor.
+// It records the errors of the key sources, so that they can be reported if no key source
+// decrypted the data key.
+type keyService struct {
+	keyservice.UnimplementedKeyServiceServer
+	ctx     context.Context
+	sources []KeySource
+
+	mu   sync.Mutex
+	errs []error
+}
+
+var _ keyservice.KeyServiceServer = (*keyService)(nil)
+
+func (s *keyService) Decrypt(_ context.Context, req *keyservice.DecryptRequest) (*keyservice.DecryptResponse, error) {
+	var errs []error
+	for _, source := range s.sources {
+		dataKey, err := source.DecryptDataKey(s.ctx, req.GetKey(), req.GetCiphertext())
+		if err == nil {
+			return &keyservice.DecryptResponse{Plaintext: dataKey}, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		errs = append(errs, fmt.Errorf("%w: no key source configured", ErrNoMatchingKey))
+	}
+
+	err := errors.Join(errs...)
+	s.mu.Lock()
+	s.errs = append(s.errs, err)
+	s.mu.Unlock()
+	return nil, err
+}
+
+// err returns the error of a failed data key decryption, including the errors of the key sources.
+func (s *keyService) err(err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.errs) == 0 {
+		return fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	return fmt.Errorf("failed to decrypt data key: %w", errors.Join(s.errs...))
+}
@@ -0,0 +1,252 @@
+package sops_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	sopsv3 "github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/keyservice"
+	sopsjson "github.com/getsops/sops/v3/stores/json"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/configuration/sops"
+)
+
+const plaintextConfig = `type: generic.config.ocm.software/v1
+configurations:
+  - type: credentials.config.ocm.software
+    consumers:
+      - identity:
+          type: OCIRegistry
+          hostname: ghcr.io
+        credentials:
+          - type: Credentials
+            properties:
+              username: open-component-model
+              password: some-token
+              insecure: true
+              retries: 3
+`
+
+func TestDecrypt(t *testing.T) {
+	r := require.New(t)
+
+	identity, err := age.GenerateX25519Identity()
+	r.NoError(err)
+	encrypted := encrypt(t, plaintextConfig, identity.Recipient())
+	r.True(sops.IsEncrypted(encrypted))
+	r.NotContains(string(encrypted), "some-token")
+
+	decrypted, err := sops.NewDecryptor(sops.NewAgeKeySource(identity)).Decrypt(t.Context(), encrypted)
+	r.NoError(err)
+	r.False(sops.IsEncrypted(decrypted))
+
+	var expected, actual any
+	r.NoError(yaml.Unmarshal([]byte(plaintextConfig), &expected))
+	r.NoError(yaml.Unmarshal(decrypted, &actual))
+	r.Equal(expected, actual)
+
+	var cfg genericv1.Config
+	r.NoError(genericv1.Scheme.Decode(bytes.NewReader(decrypted), &cfg))
+	r.Len(cfg.Configurations, 1)
+}
+
+func TestDecrypt_JSON(t *testing.T) {
+	r := require.New(t)
+
+	identity, err := age.GenerateX25519Identity()
+	r.NoError(err)
+	plaintext := `{"type":"generic.config.ocm.software/v1","configurations":[{"type":"credentials.config.ocm.software","password":"some-token"}]}`
+	encrypted := encryptWith(t, sopsjson.NewStore(&config.JSONStoreConfig{}), plaintext, identity.Recipient(), "")
+	r.True(sops.IsEncrypted(encrypted))
+	r.NotContains(string(encrypted), "some-token")
+
+	decrypted, err := sops.NewDecryptor(sops.NewAgeKeySource(identity)).Decrypt(t.Context(), encrypted)
+	r.NoError(err)
+	r.JSONEq(plaintext, string(decrypted))
+}
+
+func TestDecrypt_Errors(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	encrypted := encrypt(t, plaintextConfig, identity.Recipient())
+
+	t.Run("not encrypted", func(t *testing.T) {
+		r := require.New(t)
+		r.False(sops.IsEncrypted([]byte(plaintextConfig)))
+		_, err := sops.NewDecryptor(sops.NewAgeKeySource(identity)).Decrypt(t.Context(), []byte(plaintextConfig))
+		r.ErrorIs(err, sops.ErrNotEncrypted)
+	})
+
+	t.Run("no matching key", func(t *testing.T) {
+		r := require.New(t)
+		other, err := age.GenerateX25519Identity()
+		r.NoError(err)
+		_, err = sops.NewDecryptor(sops.NewAgeKeySource(other)).Decrypt(t.Context(), encrypted)
+		r.ErrorIs(err, sops.ErrNoMatchingKey)
+		_, err = sops.NewDecryptor().Decrypt(t.Context(), encrypted)
+		r.ErrorIs(err, sops.ErrNoMatchingKey)
+	})
+
+	t.Run("moved value", func(t *testing.T) {
+		r := require.New(t)
+		// swapping two ciphertexts keeps each of them valid, but their position is authenticated.
+		var document yaml.Node
+		r.NoError(yaml.Unmarshal(encrypted, &document))
+		username := findValue(t, &document, "username")
+		password := findValue(t, &document, "password")
+		username.Value, password.Value = password.Value, username.Value
+		tampered, err := yaml.Marshal(&document)
+		r.NoError(err)
+
+		_, err = sops.NewDecryptor(sops.NewAgeKeySource(identity)).Decrypt(t.Context(), tampered)
+		r.ErrorContains(err, "failed to decrypt document")
+	})
+
+	t.Run("modified plaintext value", func(t *testing.T) {
+		r := require.New(t)
+		encrypted := encryptWith(t, sopsyaml.NewStore(&config.YAMLStoreConfig{}), plaintextConfig, identity.Recipient(), "^hostname$")
+		tampered := bytes.Replace(encrypted, []byte("ghcr.io"), []byte("evil.example.com"), 1)
+
+		_, err := sops.NewDecryptor(sops.NewAgeKeySource(identity)).Decrypt(t.Context(), encrypted)
+		r.NoError(err)
+		_, err = sops.NewDecryptor(sops.NewAgeKeySource(identity)).Decrypt(t.Context(), tampered)
+		r.ErrorIs(err, sops.ErrMACMismatch)
+	})
+}
+
+func TestKeySourcesFromEnvironment(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	encrypted := encrypt(t, plaintextConfig, identity.Recipient())
+	keys := "# created: 2026-01-02T03:04:05Z\n" + identity.String() + "\n"
+
+	decrypt := func(t *testing.T, env map[string]string, configDir string) error {
+		t.Helper()
+		sources, err := sops.KeySourcesFromEnvironment(
+			func(key string) string { return env[key] },
+			func() (string, error) { return configDir, nil },
+		)
+		if err != nil {
+			return err
+		}
+		_, err = sops.NewDecryptor(sources...).Decrypt(t.Context(), encrypted)
+		return err
+	}
+
+	t.Run("key in environment", func(t *testing.T) {
+		require.NoError(t, decrypt(t, map[string]string{sops.AgeKeyEnv: keys}, t.TempDir()))
+	})
+
+	t.Run("key file in environment", func(t *testing.T) {
+		r := require.New(t)
+		path := filepath.Join(t.TempDir(), "keys.txt")
+		r.NoError(os.WriteFile(path, []byte(keys), 0o600))
+		r.NoError(decrypt(t, map[string]string{sops.AgeKeyFileEnv: path}, t.TempDir()))
+
+		err := decrypt(t, map[string]string{sops.AgeKeyFileEnv: filepath.Join(t.TempDir(), "missing")}, t.TempDir())
+		r.ErrorIs(err, os.ErrNotExist)
+	})
+
+	t.Run("default key file", func(t *testing.T) {
+		r := require.New(t)
+		configDir := t.TempDir()
+		r.ErrorIs(decrypt(t, nil, configDir), sops.ErrNoMatchingKey)
+
+		path := filepath.Join(configDir, sops.DefaultAgeKeyFile)
+		r.NoError(os.MkdirAll(filepath.Dir(path), 0o700))
+		r.NoError(os.WriteFile(path, []byte(keys), 0o600))
+		r.NoError(decrypt(t, nil, configDir))
+		r.NoError(decrypt(t, map[string]string{"XDG_CONFIG_HOME": configDir}, t.TempDir()))
+	})
+}
+
+func TestKMSKeySources(t *testing.T) {
+	ageKey := &keyservice.Key{KeyType: &keyservice.Key_AgeKey{AgeKey: &keyservice.AgeKey{Recipient: "age1..."}}}
+
+	for name, source := range map[string]sops.KeySource{
+		"aws": sops.NewAWSKMSKeySource(nil),
+		"gcp": sops.NewGCPKMSKeySource(nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			_, err := source.DecryptDataKey(t.Context(), ageKey, []byte("wrapped"))
+			r.ErrorIs(err, sops.ErrNoMatchingKey, "kms key sources only decrypt data keys of their kms")
+		})
+	}
+
+	t.Run("age", func(t *testing.T) {
+		r := require.New(t)
+		identity, err := age.GenerateX25519Identity()
+		r.NoError(err)
+		gcpKey := &keyservice.Key{KeyType: &keyservice.Key_GcpKmsKey{GcpKmsKey: &keyservice.GcpKmsKey{ResourceId: "projects/p/locations/global/keyRings/r/cryptoKeys/k"}}}
+		_, err = sops.NewAgeKeySource(identity).DecryptDataKey(t.Context(), gcpKey, []byte("wrapped"))
+		r.ErrorIs(err, sops.ErrNoMatchingKey)
+	})
+}
+
+// encrypt encrypts the YAML document with sops for the given age recipient.
+func encrypt(t *testing.T, document string, recipient *age.X25519Recipient) []byte {
+	t.Helper()
+	return encryptWith(t, sopsyaml.NewStore(&config.YAMLStoreConfig{}), document, recipient, "")
+}
+
+// encryptWith encrypts the document with sops for the given age recipient like the sops tool does.
+// Values of keys matching unencryptedRegex are left in plaintext, but are part of the message authentication code.
+func encryptWith(t *testing.T, store sopsv3.Store, document string, recipient *age.X25519Recipient, unencryptedRegex string) []byte {
+	t.Helper()
+	r := require.New(t)
+
+	branches, err := store.LoadPlainFile([]byte(document))
+	r.NoError(err)
+	key, err := sopsage.MasterKeyFromRecipient(recipient.String())
+	r.NoError(err)
+	tree := sopsv3.Tree{
+		Branches: branches,
+		Metadata: sopsv3.Metadata{
+			KeyGroups:        []sopsv3.KeyGroup{{key}},
+			UnencryptedRegex: unencryptedRegex,
+			Version:          "3.13.3",
+		},
+	}
+	dataKey, errs := tree.GenerateDataKey()
+	r.Empty(errs)
+
+	cipher := aes.NewCipher()
+	mac, err := tree.Encrypt(dataKey, cipher)
+	r.NoError(err)
+	tree.Metadata.LastModified = time.Now().UTC()
+	tree.Metadata.MessageAuthenticationCode, err = cipher.Encrypt(mac, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	r.NoError(err)
+
+	out, err := store.EmitEncryptedFile(tree)
+	r.NoError(err)
+	return out
+}
+
+func findValue(t *testing.T, node *yaml.Node, key string) *yaml.Node {
+	t.Helper()
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1]
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if found := findValue(t, child, key); found != nil {
+			return found
+		}
+	}
+	return nil
+}
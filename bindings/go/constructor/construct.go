@@ -13,6 +13,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	"ocm.software/open-component-model/bindings/go/constructor/internal/log"
 	constructor "ocm.software/open-component-model/bindings/go/constructor/runtime"
 	"ocm.software/open-component-model/bindings/go/credentials"
@@ -77,10 +78,19 @@ type ConstructorOrExternalComponent struct {
 	ExternalComponent    *DescriptorWithLocalBlobs
 }
 
-func (c *DefaultConstructor) Construct(ctx context.Context) error {
+func (c *DefaultConstructor) Construct(ctx context.Context) (err error) {
 	c.constructMutex.Lock()
 	defer c.constructMutex.Unlock()
 
+	tempFiles := c.opts.TempFiles
+	if tempFiles == nil {
+		tempFiles = tempfile.NewManager()
+		defer func() {
+			err = errors.Join(err, tempFiles.Cleanup())
+		}()
+	}
+	ctx = tempfile.WithManager(ctx, tempFiles)
+
 	if err := c.discoverer.Graph().WithReadLock(func(d *dag.DirectedAcyclicGraph[string]) error {
 		if len(d.Vertices) > 0 {
 			return fmt.Errorf("component constructor graph has already been constructed")
@@ -90,6 +100,10 @@ func (c *DefaultConstructor) Construct(ctx context.Context) error {
 		return err
 	}
 
+	if c.opts.Locked && c.opts.Lockfile == nil {
+		return fmt.Errorf("a locked construction requires a lockfile")
+	}
+
 	logger := log.Base().With("operation", "constructComponent")
 
 	if c.opts.ResourceInputMethodProvider == nil {
@@ -105,7 +119,13 @@ func (c *DefaultConstructor) Construct(ctx context.Context) error {
 		return nil
 	}
 
-	err := c.discover(ctx)
+	if c.opts.ValidateReferenceClosure {
+		if err := c.validateReferenceClosure(ctx); err != nil {
+			return fmt.Errorf("failed to validate component reference closure: %w", err)
+		}
+	}
+
+	err = c.discover(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to discover component constructor graph: %w", err)
 	}
@@ -297,7 +317,18 @@ func (c *DefaultConstructor) processDescriptor(
 					return fmt.Errorf("error starting resource construction for %q: %w", resource.ToIdentity(), err)
 				}
 			}
+			step, err := c.startJournalStep(JournalKindResource, elementJournalID(component, resource.ToIdentity()))
+			if err != nil {
+				return err
+			}
 			res, err := c.processResource(egctx, targetRepo, &resource, component.Name, component.Version)
+			var digest *descriptor.Digest
+			if res != nil {
+				digest = res.Digest
+			}
+			if err := step.end(err, digest); err != nil {
+				return err
+			}
 			if c.opts.OnEndResourceConstruct != nil {
 				if err := c.opts.OnEndResourceConstruct(egctx, res, err); err != nil {
 					return fmt.Errorf("error ending resource construction for %q: %w", resource.ToIdentity(), err)
@@ -306,6 +337,9 @@ func (c *DefaultConstructor) processDescriptor(
 			if err != nil {
 				return fmt.Errorf("error processing resource %q at index %d: %w", resource.ToIdentity(), i, err)
 			}
+			if err := c.lock(component, JournalKindResource, resource.ToIdentity(), res.Digest, res.Access); err != nil {
+				return err
+			}
 			descLock.Lock()
 			defer descLock.Unlock()
 			desc.Component.Resources[i] = *res
@@ -324,7 +358,14 @@ func (c *DefaultConstructor) processDescriptor(
 					return fmt.Errorf("error starting source construction for %q: %w", source.ToIdentity(), err)
 				}
 			}
+			step, err := c.startJournalStep(JournalKindSource, elementJournalID(component, source.ToIdentity()))
+			if err != nil {
+				return err
+			}
 			src, err := c.processSource(egctx, targetRepo, &source, component.Name, component.Version)
+			if err := step.end(err, nil); err != nil {
+				return err
+			}
 			if c.opts.OnEndSourceConstruct != nil {
 				if err := c.opts.OnEndSourceConstruct(egctx, src, err); err != nil {
 					return fmt.Errorf("error ending source construction for %q: %w", source.ToIdentity(), err)
@@ -333,6 +374,9 @@ func (c *DefaultConstructor) processDescriptor(
 			if err != nil {
 				return fmt.Errorf("error processing source %q at index %d: %w", source.ToIdentity(), i, err)
 			}
+			if err := c.lock(component, JournalKindSource, source.ToIdentity(), nil, src.Access); err != nil {
+				return err
+			}
 			descLock.Lock()
 			defer descLock.Unlock()
 			desc.Component.Sources[i] = *src
@@ -352,7 +396,18 @@ func (c *DefaultConstructor) processDescriptor(
 				}
 			}
 			referencedComponent := referencedComponents[reference.ToIdentity().String()]
+			step, err := c.startJournalStep(JournalKindReference, elementJournalID(component, reference.ToIdentity()))
+			if err != nil {
+				return err
+			}
 			ref, err := c.processReference(egctx, &reference, referencedComponent)
+			var digest *descriptor.Digest
+			if ref != nil {
+				digest = &ref.Digest
+			}
+			if err := step.end(err, digest); err != nil {
+				return err
+			}
 			if c.opts.OnEndReferenceConstruct != nil {
 				if hookErr := c.opts.OnEndReferenceConstruct(egctx, ref, err); hookErr != nil {
 					return fmt.Errorf("error ending reference construction for %q: %w", reference.ToIdentity(), errors.Join(hookErr, err))
@@ -361,6 +416,9 @@ func (c *DefaultConstructor) processDescriptor(
 			if err != nil {
 				return fmt.Errorf("error processing reference %q at index %d: %w", reference.ToIdentity(), i, err)
 			}
+			if err := c.lock(component, JournalKindReference, reference.ToIdentity(), &ref.Digest, nil); err != nil {
+				return err
+			}
 			descLock.Lock()
 			defer descLock.Unlock()
 			desc.Component.References[i] = *ref
@@ -512,6 +570,9 @@ func (c *DefaultConstructor) processSource(ctx context.Context, targetRepo Targe
 // It looks up the appropriate input method from the registry and processes the source
 // using the found method.
 func (c *DefaultConstructor) processSourceWithInput(ctx context.Context, targetRepo TargetRepository, src *constructor.Source, component, version string) (*descriptor.Source, error) {
+	if err := migrateInput(ctx, c.opts.SourceInputMethodProvider, &src.Input); err != nil {
+		return nil, fmt.Errorf("error migrating input specification of source %q: %w", src.ToIdentity(), err)
+	}
 	method, err := c.opts.GetSourceInputMethod(ctx, src)
 	if err != nil {
 		return nil, fmt.Errorf("no input method resolvable for input specification of type %q: %w", src.Input.GetType(), err)
@@ -553,6 +614,9 @@ func (c *DefaultConstructor) processSourceWithInput(ctx context.Context, targetR
 // It looks up the appropriate input method from the registry and processes the resource
 // using the found method.
 func (c *DefaultConstructor) processResourceWithInput(ctx context.Context, targetRepo TargetRepository, resource *constructor.Resource, component, version string) (*descriptor.Resource, error) {
+	if err := migrateInput(ctx, c.opts.ResourceInputMethodProvider, &resource.Input); err != nil {
+		return nil, fmt.Errorf("error migrating input specification of resource %q: %w", resource.ToIdentity(), err)
+	}
 	method, err := c.opts.GetResourceInputMethod(ctx, resource)
 	if err != nil {
 		return nil, fmt.Errorf("no input method resolvable for input specification of type %q: %w", resource.Input.GetType(), err)
@@ -814,3 +878,20 @@ func resolveCredentials(ctx context.Context, provider credentials.Resolver, cons
 
 	return creds, err
 }
+
+// migrateInput migrates the input specification in place if the provider of its input method is an InputMigrator.
+func migrateInput(ctx context.Context, provider any, input *ocmruntime.Typed) error {
+	migrator, ok := provider.(InputMigrator)
+	if !ok {
+		return nil
+	}
+	migrated, ok, err := migrator.MigrateInput(ctx, *input)
+	if err != nil {
+		return err
+	}
+	if ok {
+		log.Base().DebugContext(ctx, "migrated deprecated input specification", "from", (*input).GetType(), "to", migrated.GetType())
+		*input = migrated
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package constructor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+)
+
+type flakyInputMethod struct {
+	mockInputMethod
+	fail map[string]bool
+}
+
+func (m *flakyInputMethod) ProcessResource(ctx context.Context, resource *constructorruntime.Resource, creds runtime.Typed) (*ResourceInputMethodResult, error) {
+	if m.fail[resource.Name] {
+		return nil, errors.New("transient failure")
+	}
+	res := m.processedResource.DeepCopy()
+	res.Name = resource.Name
+	return &ResourceInputMethodResult{ProcessedResource: res}, nil
+}
+
+func TestConstructWithJournal_Resume(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "construct.journal")
+
+	component := func(name string) constructorruntime.Component {
+		return constructorruntime.Component{
+			ComponentMeta: constructorruntime.ComponentMeta{
+				ObjectMeta: constructorruntime.ObjectMeta{
+					Name:    name,
+					Version: "v1.0.0",
+				},
+			},
+			Provider: constructorruntime.Provider{Name: "ocm.software"},
+			Resources: []constructorruntime.Resource{
+				{
+					ElementMeta: constructorruntime.ElementMeta{
+						ObjectMeta: constructorruntime.ObjectMeta{
+							Name:    name + "-resource",
+							Version: "v1.0.0",
+						},
+					},
+					Type:     "test",
+					Relation: constructorruntime.LocalRelation,
+					AccessOrInput: constructorruntime.AccessOrInput{
+						Input: &mockInputType{Type: runtime.NewVersionedType("mock", "v1")},
+					},
+				},
+			},
+		}
+	}
+	spec := &constructorruntime.ComponentConstructor{
+		Components: []constructorruntime.Component{component("ocm.software/a"), component("ocm.software/b")},
+	}
+
+	repo := newMockTargetRepository()
+	input := &flakyInputMethod{
+		mockInputMethod: mockInputMethod{
+			processedResource: &descriptor.Resource{
+				ElementMeta: descriptor.ElementMeta{
+					ObjectMeta: descriptor.ObjectMeta{Version: "v1.0.0"},
+				},
+				Type:     "test",
+				Relation: descriptor.LocalRelation,
+				Access:   &descriptor.LocalBlob{LocalReference: "sha256:abc", MediaType: "application/octet-stream"},
+			},
+		},
+		fail: map[string]bool{"ocm.software/b-resource": true},
+	}
+	var statusOutput bytes.Buffer
+	statusWriter := status.NewWriter(&statusOutput)
+	construct := func() error {
+		j, err := journal.Open(path)
+		r.NoError(err)
+		defer func() { r.NoError(j.Close()) }()
+		return NewDefaultConstructor(spec, Options{
+			TargetRepositoryProvider: &mockTargetRepositoryProvider{repo: repo},
+			ResourceInputMethodProvider: &mockInputMethodProvider{
+				methods: map[runtime.Type]ResourceInputMethod{runtime.NewVersionedType("mock", "v1"): input},
+			},
+			Journal: j,
+			Status:  statusWriter,
+		}).Construct(ctx)
+	}
+
+	r.ErrorContains(construct(), "transient failure")
+	r.Len(repo.addedVersions, 1)
+	r.Equal("ocm.software/a", repo.addedVersions[0].Component.Name)
+
+	input.fail = nil
+	r.NoError(construct())
+	r.Len(repo.addedVersions, 2, "only the failed component should have been constructed again")
+	r.Equal("ocm.software/b", repo.addedVersions[1].Component.Name)
+
+	j, err := journal.Open(path)
+	r.NoError(err)
+	defer func() { r.NoError(j.Close()) }()
+
+	skipped := j.Query(journal.Filter{Kind: JournalKindComponent, Outcome: journal.OutcomeSkipped})
+	r.Len(skipped, 1)
+	r.Equal(spec.Components[0].ToIdentity().String(), skipped[0].ID)
+
+	failed := j.Query(journal.Filter{Kind: JournalKindResource, Outcome: journal.OutcomeFailed})
+	r.Len(failed, 1)
+	r.Contains(failed[0].Error, "transient failure")
+
+	entry, ok := j.Completed(JournalKindResource, elementJournalID(&spec.Components[1], spec.Components[1].Resources[0].ToIdentity()))
+	r.True(ok)
+	r.Equal(2, entry.Attempt)
+
+	results := map[string][]status.Result{}
+	scanner := bufio.NewScanner(&statusOutput)
+	for scanner.Scan() {
+		var record status.Record
+		r.NoError(json.Unmarshal(scanner.Bytes(), &record))
+		r.Equal(StatusOperation, record.Operation)
+		results[record.Step+" "+record.Item] = append(results[record.Step+" "+record.Item], record.Result)
+	}
+	r.Equal([]status.Result{status.ResultStarted, status.ResultSucceeded, status.ResultSkipped},
+		results[JournalKindComponent+" "+spec.Components[0].ToIdentity().String()])
+	r.Equal([]status.Result{status.ResultStarted, status.ResultFailed, status.ResultStarted, status.ResultSucceeded},
+		results[JournalKindResource+" "+elementJournalID(&spec.Components[1], spec.Components[1].Resources[0].ToIdentity())])
+}
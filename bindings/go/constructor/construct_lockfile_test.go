@@ -0,0 +1,125 @@
+package constructor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func TestConstructWithLockfile(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	image := func(tag string) *runtime.Raw {
+		return &runtime.Raw{
+			Type: runtime.NewVersionedType("ociArtifact", "v1"),
+			Data: []byte(`{"type":"ociArtifact/v1","imageReference":"ghcr.io/acme/app:` + tag + `"}`),
+		}
+	}
+	spec := func(tag string) *constructorruntime.ComponentConstructor {
+		return &constructorruntime.ComponentConstructor{
+			Components: []constructorruntime.Component{{
+				ComponentMeta: constructorruntime.ComponentMeta{
+					ObjectMeta: constructorruntime.ObjectMeta{Name: "ocm.software/app", Version: "v1.0.0"},
+				},
+				Provider: constructorruntime.Provider{Name: "ocm.software"},
+				Resources: []constructorruntime.Resource{
+					{
+						ElementMeta: constructorruntime.ElementMeta{
+							ObjectMeta: constructorruntime.ObjectMeta{Name: "chart", Version: "v1.0.0"},
+						},
+						Type:     "helmChart",
+						Relation: constructorruntime.LocalRelation,
+						AccessOrInput: constructorruntime.AccessOrInput{
+							Input: &mockInputType{Type: runtime.NewVersionedType("mock", "v1")},
+						},
+					},
+					{
+						ElementMeta: constructorruntime.ElementMeta{
+							ObjectMeta: constructorruntime.ObjectMeta{Name: "image", Version: "v1.0.0"},
+						},
+						Type:     "ociImage",
+						Relation: constructorruntime.ExternalRelation,
+						AccessOrInput: constructorruntime.AccessOrInput{
+							Access: image(tag),
+						},
+					},
+				},
+			}},
+		}
+	}
+	input := &mockInputMethod{
+		processedResource: &descriptor.Resource{
+			ElementMeta: descriptor.ElementMeta{
+				ObjectMeta: descriptor.ObjectMeta{Name: "chart", Version: "v1.0.0"},
+			},
+			Type:     "helmChart",
+			Relation: descriptor.LocalRelation,
+			Access:   &descriptor.LocalBlob{LocalReference: "sha256:abc", MediaType: "application/octet-stream"},
+		},
+	}
+	construct := func(spec *constructorruntime.ComponentConstructor, lockfile *Lockfile, locked bool) (*mockTargetRepository, error) {
+		repo := newMockTargetRepository()
+		return repo, NewDefaultConstructor(spec, Options{
+			TargetRepositoryProvider: &mockTargetRepositoryProvider{repo: repo},
+			ResourceInputMethodProvider: &mockInputMethodProvider{
+				methods: map[runtime.Type]ResourceInputMethod{runtime.NewVersionedType("mock", "v1"): input},
+			},
+			Lockfile: lockfile,
+			Locked:   locked,
+		}).Construct(ctx)
+	}
+
+	recorded := &Lockfile{}
+	_, err := construct(spec("1.0"), recorded, false)
+	r.NoError(err)
+
+	entries := recorded.Entries()
+	r.Len(entries, 2)
+	r.Equal(runtime.Identity{"name": "chart", "version": "v1.0.0"}, entries[0].Identity)
+	r.Equal("sha256:abc", entries[0].LocalReference)
+	r.Nil(entries[0].Access)
+	r.Equal(runtime.Identity{"name": "image", "version": "v1.0.0"}, entries[1].Identity)
+	r.JSONEq(string(image("1.0").Data), string(entries[1].Access.Data))
+
+	data, err := json.Marshal(recorded)
+	r.NoError(err)
+	lockfile := &Lockfile{}
+	r.NoError(json.Unmarshal(data, lockfile))
+	again, err := json.Marshal(lockfile)
+	r.NoError(err)
+	r.JSONEq(string(data), string(again))
+
+	t.Run("unchanged resolution", func(t *testing.T) {
+		repo, err := construct(spec("1.0"), lockfile, true)
+		require.NoError(t, err)
+		require.Len(t, repo.addedVersions, 1)
+	})
+
+	t.Run("changed access", func(t *testing.T) {
+		repo, err := construct(spec("1.1"), lockfile, true)
+		require.ErrorIs(t, err, ErrLockMismatch)
+		require.ErrorContains(t, err, "resource name=image,version=v1.0.0")
+		require.Empty(t, repo.addedVersions)
+	})
+
+	t.Run("changed input content", func(t *testing.T) {
+		input.processedResource.Access = &descriptor.LocalBlob{LocalReference: "sha256:def", MediaType: "application/octet-stream"}
+		t.Cleanup(func() {
+			input.processedResource.Access = &descriptor.LocalBlob{LocalReference: "sha256:abc", MediaType: "application/octet-stream"}
+		})
+		_, err := construct(spec("1.0"), lockfile, true)
+		require.ErrorIs(t, err, ErrLockMismatch)
+		require.ErrorContains(t, err, `has content "sha256:def" but "sha256:abc" is locked`)
+	})
+
+	t.Run("locked without lockfile", func(t *testing.T) {
+		_, err := construct(spec("1.0"), nil, true)
+		require.ErrorContains(t, err, "requires a lockfile")
+	})
+}
@@ -0,0 +1,166 @@
+package constructor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	constructorv1 "ocm.software/open-component-model/bindings/go/constructor/spec/v1"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocirepository "ocm.software/open-component-model/bindings/go/oci/repository"
+	"ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func newReferenceClosureDescriptor(name string, references ...descriptor.Reference) *descriptor.Descriptor {
+	return &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{
+				ObjectMeta: descriptor.ObjectMeta{Name: name, Version: "1.0.0"},
+			},
+			Provider:   descriptor.Provider{Name: "ocm.software"},
+			References: references,
+		},
+	}
+}
+
+func TestConstructWithReferenceClosureValidation(t *testing.T) {
+	t.Parallel()
+
+	present := newReferenceClosureDescriptor("ocm.software/closure/present")
+	presentDigest, err := calculateDigest(present)
+	require.NoError(t, err)
+	parent := newReferenceClosureDescriptor("ocm.software/closure/parent", descriptor.Reference{
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "missing", Version: "1.0.0"}},
+		Component:   "ocm.software/closure/transitive-missing",
+	})
+	externalOnly := newReferenceClosureDescriptor("ocm.software/closure/external-only")
+
+	reference := func(name, component, digest string) string {
+		ref := `
+      - name: ` + name + `
+        version: 1.0.0
+        componentName: ` + component
+		if digest != "" {
+			ref += `
+        digest:
+          hashAlgorithm: ` + presentDigest.HashAlgorithm + `
+          normalisationAlgorithm: ` + presentDigest.NormalisationAlgorithm + `
+          value: "` + digest + `"`
+		}
+		return ref
+	}
+	constructorYAML := func(references ...string) *constructorruntime.ComponentConstructor {
+		yamlData := `
+components:
+  - name: ocm.software/closure/root
+    version: 1.0.0
+    provider:
+      name: ocm.software
+    componentReferences:`
+		for _, ref := range references {
+			yamlData += ref
+		}
+		var spec constructorv1.ComponentConstructor
+		require.NoError(t, yaml.Unmarshal([]byte(yamlData), &spec))
+		return constructorruntime.ConvertToRuntimeConstructor(&spec)
+	}
+
+	externalRepo, err := ocirepository.NewFromCTFRepoV1(t.Context(), &ctf.Repository{
+		FilePath:   t.TempDir(),
+		AccessMode: ctf.AccessModeReadWrite,
+	})
+	require.NoError(t, err)
+	require.NoError(t, externalRepo.AddComponentVersion(t.Context(), present))
+	require.NoError(t, externalRepo.AddComponentVersion(t.Context(), externalOnly))
+
+	newTargetRepo := func() *mockTargetRepository {
+		repo := newMockTargetRepository()
+		repo.components[present.Component.Name+":1.0.0"] = present
+		repo.components[parent.Component.Name+":1.0.0"] = parent
+		return repo
+	}
+
+	brokenReferences := constructorYAML(
+		reference("present", present.Component.Name, presentDigest.Value),
+		reference("missing", "ocm.software/closure/missing", ""),
+		reference("mismatch", present.Component.Name, "0000"),
+		reference("parent", parent.Component.Name, ""),
+		reference("external-only", externalOnly.Component.Name, ""),
+	)
+
+	tests := []struct {
+		name       string
+		spec       *constructorruntime.ComponentConstructor
+		copyPolicy ExternalComponentVersionCopyPolicy
+		wantBroken []string
+	}{
+		{
+			name:       "resolvable references",
+			spec:       constructorYAML(reference("present", present.Component.Name, presentDigest.Value)),
+			copyPolicy: ExternalComponentVersionCopyPolicySkip,
+		},
+		{
+			name:       "broken references without copy",
+			spec:       brokenReferences,
+			copyPolicy: ExternalComponentVersionCopyPolicySkip,
+			wantBroken: []string{
+				"ocm.software/closure/parent/missing",
+				"ocm.software/closure/root/external-only",
+				"ocm.software/closure/root/mismatch",
+				"ocm.software/closure/root/missing",
+			},
+		},
+		{
+			name:       "broken references with copy",
+			spec:       brokenReferences,
+			copyPolicy: ExternalComponentVersionCopyPolicyCopyOrFail,
+			wantBroken: []string{
+				"ocm.software/closure/parent/missing",
+				"ocm.software/closure/root/mismatch",
+				"ocm.software/closure/root/missing",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			targetRepo := newTargetRepo()
+			constructorInstance := NewDefaultConstructor(tt.spec, Options{
+				TargetRepositoryProvider:            &mockTargetRepositoryProvider{repo: targetRepo},
+				ExternalComponentRepositoryProvider: RepositoryAsExternalComponentVersionRepositoryProvider(externalRepo),
+				ExternalComponentVersionCopyPolicy:  tt.copyPolicy,
+				ValidateReferenceClosure:            true,
+			})
+
+			err := constructorInstance.Construct(t.Context())
+			if len(tt.wantBroken) == 0 {
+				r.NoError(err)
+				return
+			}
+			r.ErrorIs(err, ErrBrokenReference)
+			r.ErrorIs(err, ErrDigestMismatch)
+			r.ErrorIs(err, repository.ErrNotFound)
+
+			var closureErr *ReferenceClosureError
+			r.True(errors.As(err, &closureErr))
+			var broken []string
+			for _, b := range closureErr.Broken {
+				component, err := runtime.ParseIdentity(b.Component)
+				r.NoError(err)
+				reference, err := runtime.ParseIdentity(b.Reference)
+				r.NoError(err)
+				broken = append(broken, component[descriptor.IdentityAttributeName]+"/"+reference[descriptor.IdentityAttributeName])
+			}
+			r.Equal(tt.wantBroken, broken)
+
+			// nothing is constructed if the closure is broken.
+			r.Empty(targetRepo.addedVersions)
+		})
+	}
+}
@@ -810,3 +810,63 @@ func TestDefaultConstructor_OwnershipAttachment(t *testing.T) {
 		})
 	}
 }
+
+type mockInputSpec struct {
+	Type runtime.Type `json:"type"`
+	Path string       `json:"path"`
+}
+
+func (m *mockInputSpec) GetType() runtime.Type        { return m.Type }
+func (m *mockInputSpec) SetType(typ runtime.Type)     { m.Type = typ }
+func (m *mockInputSpec) DeepCopyTyped() runtime.Typed { c := *m; return &c }
+
+// capturingInputMethod records the input specification passed to it.
+type capturingInputMethod struct {
+	mockInputMethod
+	capturedInput runtime.Typed
+}
+
+func (m *capturingInputMethod) ProcessResource(ctx context.Context, resource *constructorruntime.Resource, creds runtime.Typed) (*ResourceInputMethodResult, error) {
+	m.capturedInput = resource.Input
+	return m.mockInputMethod.ProcessResource(ctx, resource, creds)
+}
+
+func TestConstructWithDeprecatedInputType(t *testing.T) {
+	r := require.New(t)
+	current := runtime.NewVersionedType("mock", "v1")
+	legacy := runtime.NewUnversionedType("legacyMock")
+
+	var warned []runtime.Type
+	scheme := runtime.NewScheme(runtime.WithDeprecationHandler(func(typ runtime.Type, _ runtime.Deprecation) {
+		warned = append(warned, typ)
+	}))
+	scheme.MustRegisterWithAlias(&mockInputSpec{}, current, legacy)
+	scheme.MustDeprecate(legacy, runtime.Deprecation{Replacement: current})
+
+	method := &capturingInputMethod{mockInputMethod: mockInputMethod{
+		processedResource: &descriptor.Resource{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "test-resource", Version: "v1.0.0"}},
+			Access:      &v2.LocalBlob{MediaType: "application/octet-stream"},
+		},
+	}}
+	registry := New(scheme)
+	registry.MustRegisterResourceInputMethod(&mockInputSpec{}, method)
+
+	constructor := setupTestComponent(t, `
+      - name: test-resource
+        version: v1.0.0
+        relation: local
+        type: blob
+        input:
+          type: legacyMock
+          path: /data
+`)
+	opts := Options{
+		ResourceInputMethodProvider: registry,
+		TargetRepositoryProvider:    &mockTargetRepositoryProvider{repo: newMockTargetRepository()},
+	}
+	r.NoError(NewDefaultConstructor(constructor, opts).Construct(t.Context()))
+
+	r.Equal(&mockInputSpec{Type: current, Path: "/data"}, method.capturedInput)
+	r.Equal([]runtime.Type{legacy}, warned)
+}
@@ -0,0 +1,86 @@
+package constructor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+type tempFileInputMethod struct {
+	mockInputMethod
+	created []string
+}
+
+func (m *tempFileInputMethod) ProcessResource(ctx context.Context, resource *constructorruntime.Resource, creds runtime.Typed) (*ResourceInputMethodResult, error) {
+	file, err := tempfile.CreateTemp(context.WithoutCancel(ctx), "", "input-*")
+	if err != nil {
+		return nil, err
+	}
+	m.created = append(m.created, file.Name())
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+	return m.mockInputMethod.ProcessResource(ctx, resource, creds)
+}
+
+func TestConstruct_TempFiles(t *testing.T) {
+	spec := &constructorruntime.ComponentConstructor{
+		Components: []constructorruntime.Component{{
+			ComponentMeta: constructorruntime.ComponentMeta{
+				ObjectMeta: constructorruntime.ObjectMeta{Name: "ocm.software/test", Version: "v1.0.0"},
+			},
+			Provider: constructorruntime.Provider{Name: "ocm.software"},
+			Resources: []constructorruntime.Resource{{
+				ElementMeta: constructorruntime.ElementMeta{
+					ObjectMeta: constructorruntime.ObjectMeta{Name: "test-resource", Version: "v1.0.0"},
+				},
+				Type:     "test",
+				Relation: constructorruntime.LocalRelation,
+				AccessOrInput: constructorruntime.AccessOrInput{
+					Input: &mockInputType{Type: runtime.NewVersionedType("mock", "v1")},
+				},
+			}},
+		}},
+	}
+	construct := func(t *testing.T, tempFiles *tempfile.Manager) *tempFileInputMethod {
+		input := &tempFileInputMethod{mockInputMethod: mockInputMethod{
+			processedResource: &descriptor.Resource{
+				ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "test-resource", Version: "v1.0.0"}},
+				Type:        "test",
+				Relation:    descriptor.LocalRelation,
+				Access:      &descriptor.LocalBlob{LocalReference: "sha256:abc", MediaType: "application/octet-stream"},
+			},
+		}}
+		require.NoError(t, NewDefaultConstructor(spec, Options{
+			TargetRepositoryProvider: &mockTargetRepositoryProvider{repo: newMockTargetRepository()},
+			ResourceInputMethodProvider: &mockInputMethodProvider{
+				methods: map[runtime.Type]ResourceInputMethod{runtime.NewVersionedType("mock", "v1"): input},
+			},
+			TempFiles: tempFiles,
+		}).Construct(t.Context()))
+		require.Len(t, input.created, 1)
+		return input
+	}
+
+	t.Run("temp files are removed after construction", func(t *testing.T) {
+		input := construct(t, nil)
+		require.NoFileExists(t, input.created[0])
+	})
+
+	t.Run("temp files of a provided manager are left to the caller", func(t *testing.T) {
+		r := require.New(t)
+		tempFiles := tempfile.NewManager(tempfile.WithDir(t.TempDir()))
+		input := construct(t, tempFiles)
+		r.Equal(tempFiles.Dir(), filepath.Dir(input.created[0]))
+		r.FileExists(input.created[0])
+		r.NoError(tempFiles.Cleanup())
+		r.NoFileExists(input.created[0])
+	})
+}
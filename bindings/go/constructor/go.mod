@@ -16,6 +16,7 @@ require (
 	ocm.software/open-component-model/bindings/go/oci v0.0.48
 	ocm.software/open-component-model/bindings/go/repository v0.0.10
 	ocm.software/open-component-model/bindings/go/runtime v0.0.8
+	ocm.software/open-component-model/bindings/go/transform v0.0.0-20260716142305-3b46fe9f481f
 	sigs.k8s.io/yaml v1.6.0
 )
 
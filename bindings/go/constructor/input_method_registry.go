@@ -11,6 +11,7 @@ import (
 var (
 	_ ResourceInputMethodProvider = (*InputMethodRegistry)(nil)
 	_ SourceInputMethodProvider   = (*InputMethodRegistry)(nil)
+	_ InputMigrator               = (*InputMethodRegistry)(nil)
 )
 
 // InputMethodRegistry manages resource input resourceMethods for different types
@@ -51,6 +52,12 @@ func (r *InputMethodRegistry) MustRegisterResourceInputMethod(prototype runtime.
 	}
 }
 
+// MigrateInput migrates an input specification of a type deprecated in the scheme of the registry
+// to its replacement, see runtime.Scheme.Migrate.
+func (r *InputMethodRegistry) MigrateInput(_ context.Context, input runtime.Typed) (runtime.Typed, bool, error) {
+	return r.scheme.Migrate(input)
+}
+
 func (r *InputMethodRegistry) typeInsideRegistry(input runtime.Typed) (runtime.Type, error) {
 	inputType := input.GetType()
 
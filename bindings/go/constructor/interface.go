@@ -100,6 +100,13 @@ type SourceInputMethodProvider interface {
 	GetSourceInputMethod(ctx context.Context, src *constructor.Source) (SourceInputMethod, error)
 }
 
+// InputMigrator MAY be implemented by a ResourceInputMethodProvider or SourceInputMethodProvider
+// to migrate input specifications of deprecated types to their replacement before the input method is looked up.
+type InputMigrator interface {
+	// MigrateInput returns the migrated input specification and whether a migration took place.
+	MigrateInput(ctx context.Context, input runtime.Typed) (runtime.Typed, bool, error)
+}
+
 type ResourceDigestProcessor interface {
 	// GetResourceDigestProcessorCredentialConsumerIdentity resolves the identity of the given resource to use for credential resolution
 	// for the digest processor. The identity returned MAY be used to resolve credentials for the digest processor.
@@ -0,0 +1,103 @@
+package constructor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ocm.software/open-component-model/bindings/go/constructor/internal/log"
+	constructor "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+)
+
+// StatusOperation is the operation of the status records written to Options.Status.
+const StatusOperation = "construct"
+
+// Kinds of the steps recorded in the journal configured with Options.Journal.
+// They are used as step of the status records written to Options.Status as well.
+const (
+	JournalKindComponent = "component"
+	JournalKindResource  = "resource"
+	JournalKindSource    = "source"
+	JournalKindReference = "reference"
+)
+
+// journalStep is a started step in the configured journal and status output.
+// It is a no-op if neither is configured.
+type journalStep struct {
+	step      *journal.Step
+	endStatus func(err error)
+}
+
+func (s journalStep) end(err error, digest *descriptor.Digest) error {
+	if s.endStatus != nil {
+		s.endStatus(err)
+	}
+	if s.step == nil {
+		return nil
+	}
+	var result journal.Result
+	if digest != nil {
+		result.Digest = digest.Value
+	}
+	if jerr := s.step.End(err, result); jerr != nil {
+		return fmt.Errorf("error recording journal entry: %w", jerr)
+	}
+	return nil
+}
+
+func (c *DefaultConstructor) startJournalStep(kind, id string) (journalStep, error) {
+	s := journalStep{endStatus: c.opts.Status.Start(StatusOperation, kind, id)}
+	if c.opts.Journal == nil {
+		return s, nil
+	}
+	step, err := c.opts.Journal.Start(kind, id)
+	if err != nil {
+		s.endStatus(err)
+		return journalStep{}, fmt.Errorf("error recording journal entry: %w", err)
+	}
+	s.step = step
+	return s, nil
+}
+
+// elementJournalID identifies a resource, source or reference of a component version in the journal.
+func elementJournalID(component *constructor.Component, element runtime.Identity) string {
+	return component.ToIdentity().String() + "/" + element.String()
+}
+
+// resumeComponent returns the descriptor of a component that was already constructed
+// in a previous run recorded in the journal. It returns nil if the journal does not record
+// a successful construction or the component version is no longer present in the target repository.
+func (c *DefaultConstructor) resumeComponent(ctx context.Context, component *constructor.Component) (*descriptor.Descriptor, error) {
+	if c.opts.Journal == nil {
+		return nil, nil
+	}
+	id := component.ToIdentity().String()
+	if _, ok := c.opts.Journal.Completed(JournalKindComponent, id); !ok {
+		return nil, nil
+	}
+
+	logger := log.Base().With("component", component.Name, "version", component.Version)
+	repo, err := c.opts.GetTargetRepository(ctx, component)
+	if err != nil {
+		return nil, fmt.Errorf("error getting target repository for component %q: %w", component.Name, err)
+	}
+	desc, err := repo.GetComponentVersion(ctx, component.Name, component.Version)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		logger.WarnContext(ctx, "component version recorded as constructed in journal is missing in target repository, constructing again")
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("error resuming component version from target repository: %w", err)
+	}
+
+	if err := c.opts.Journal.Skip(JournalKindComponent, id); err != nil {
+		return nil, fmt.Errorf("error recording journal entry: %w", err)
+	}
+	c.opts.Status.Skip(StatusOperation, JournalKindComponent, id)
+	logger.InfoContext(ctx, "skipping construction of component version already constructed in a previous run")
+	return desc, nil
+}
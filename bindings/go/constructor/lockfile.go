@@ -0,0 +1,216 @@
+package constructor
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	constructor "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// LockfileVersion is the version of the lockfile format written by the constructor.
+const LockfileVersion = "v1"
+
+// ErrLockMismatch is returned by a locked construction if the resolution of a resource, source or reference
+// differs from the lockfile or the lockfile has no entry for it.
+var ErrLockMismatch = errors.New("resolution differs from lockfile")
+
+// Lockfile records how the resources, sources and references of constructed component versions were resolved,
+// e.g. the digest of a chart or image, the access of a resource that is added by reference or the digest
+// of the content produced by an input method.
+//
+// A Lockfile is recorded by a construction with Options.Lockfile. A construction with Options.Locked verifies
+// the resolution against a previously recorded Lockfile instead, which makes builds reproducible.
+// It is safe for concurrent use and always marshals its entries in a deterministic order.
+type Lockfile struct {
+	mu      sync.Mutex
+	entries []LockEntry
+}
+
+// LockEntry is the recorded resolution of a resource, source or reference of a component version.
+type LockEntry struct {
+	// Component and Version identify the component version.
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	// Kind is one of JournalKindResource, JournalKindSource or JournalKindReference.
+	Kind string `json:"kind"`
+	// Identity is the identity of the element within the component version.
+	Identity runtime.Identity `json:"identity"`
+	// Digest is the digest of the element, if it has one.
+	Digest *v2.Digest `json:"digest,omitempty"`
+	// Access is the access of the element, unless its content is stored as local blob.
+	Access *runtime.Raw `json:"access,omitempty"`
+	// LocalReference is the reference of the local blob produced by an input method.
+	LocalReference string `json:"localReference,omitempty"`
+}
+
+type lockfileJSON struct {
+	Version string      `json:"version"`
+	Entries []LockEntry `json:"entries"`
+}
+
+// Entries returns the entries of the lockfile, sorted by component, version, kind and identity.
+func (l *Lockfile) Entries() []LockEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := slices.Clone(l.entries)
+	slices.SortFunc(entries, func(a, b LockEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Component, b.Component),
+			cmp.Compare(a.Version, b.Version),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Identity.String(), b.Identity.String()),
+		)
+	})
+	return entries
+}
+
+func (l *Lockfile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lockfileJSON{Version: LockfileVersion, Entries: l.Entries()})
+}
+
+func (l *Lockfile) UnmarshalJSON(data []byte) error {
+	var content lockfileJSON
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	if content.Version != LockfileVersion {
+		return fmt.Errorf("unsupported lockfile version %q, expected %q", content.Version, LockfileVersion)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = content.Entries
+	return nil
+}
+
+// find returns the index of the entry of the element, or -1.
+func (l *Lockfile) find(component, version, kind string, identity runtime.Identity) int {
+	return slices.IndexFunc(l.entries, func(e LockEntry) bool {
+		return e.Component == component && e.Version == version && e.Kind == kind && e.Identity.Equal(identity)
+	})
+}
+
+// record replaces the entry of the element.
+func (l *Lockfile) record(entry LockEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if idx := l.find(entry.Component, entry.Version, entry.Kind, entry.Identity); idx >= 0 {
+		l.entries[idx] = entry
+		return
+	}
+	l.entries = append(l.entries, entry)
+}
+
+// verify checks that the entry of the element matches the given resolution.
+func (l *Lockfile) verify(entry LockEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := fmt.Sprintf("%s %s of component version %s:%s", entry.Kind, entry.Identity, entry.Component, entry.Version)
+	idx := l.find(entry.Component, entry.Version, entry.Kind, entry.Identity)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s is not locked", ErrLockMismatch, id)
+	}
+	locked := l.entries[idx]
+	if !reflect.DeepEqual(locked.Digest, entry.Digest) {
+		return fmt.Errorf("%w: %s has digest %s but %s is locked", ErrLockMismatch, id, formatLockedDigest(entry.Digest), formatLockedDigest(locked.Digest))
+	}
+	if locked.LocalReference != entry.LocalReference {
+		return fmt.Errorf("%w: %s has content %q but %q is locked", ErrLockMismatch, id, entry.LocalReference, locked.LocalReference)
+	}
+	equal, err := equalAccess(locked.Access, entry.Access)
+	if err != nil {
+		return fmt.Errorf("error comparing access of %s: %w", id, err)
+	}
+	if !equal {
+		return fmt.Errorf("%w: %s has access %s but %s is locked", ErrLockMismatch, id, formatLockedAccess(entry.Access), formatLockedAccess(locked.Access))
+	}
+	return nil
+}
+
+// lock records the resolution of an element in the configured lockfile, or verifies it against the lockfile
+// for a locked construction. It is a no-op if no lockfile is configured.
+func (c *DefaultConstructor) lock(component *constructor.Component, kind string, identity runtime.Identity, digest *descriptor.Digest, access runtime.Typed) error {
+	if c.opts.Lockfile == nil {
+		return nil
+	}
+
+	entry := LockEntry{
+		Component: component.Name,
+		Version:   component.Version,
+		Kind:      kind,
+		Identity:  identity,
+		Digest:    descriptor.ConvertToV2Digest(digest),
+	}
+	// the location of local blobs depends on the target repository, only their content is locked.
+	switch access := access.(type) {
+	case nil:
+	case *descriptor.LocalBlob:
+		entry.LocalReference = access.LocalReference
+	case *v2.LocalBlob:
+		entry.LocalReference = access.LocalReference
+	default:
+		raw := &runtime.Raw{}
+		if err := convertToRaw(access, raw); err != nil {
+			return fmt.Errorf("error recording access of %s %s in lockfile: %w", kind, identity, err)
+		}
+		switch raw.GetType().Name {
+		case descriptor.LocalBlobAccessType, descriptor.LegacyLocalBlobAccessType:
+			var localBlob v2.LocalBlob
+			if err := json.Unmarshal(raw.Data, &localBlob); err != nil {
+				return fmt.Errorf("error recording local blob of %s %s in lockfile: %w", kind, identity, err)
+			}
+			entry.LocalReference = localBlob.LocalReference
+		default:
+			entry.Access = raw
+		}
+	}
+
+	if c.opts.Locked {
+		return c.opts.Lockfile.verify(entry)
+	}
+	c.opts.Lockfile.record(entry)
+	return nil
+}
+
+func convertToRaw(typed runtime.Typed, raw *runtime.Raw) error {
+	data, err := json.Marshal(typed)
+	if err != nil {
+		return err
+	}
+	return raw.UnmarshalJSON(data)
+}
+
+func equalAccess(a, b *runtime.Raw) (bool, error) {
+	if a == nil || b == nil {
+		return a == b, nil
+	}
+	var av, bv any
+	if err := json.Unmarshal(a.Data, &av); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b.Data, &bv); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(av, bv), nil
+}
+
+func formatLockedDigest(digest *v2.Digest) string {
+	if digest == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%s:%s", digest.HashAlgorithm, digest.Value)
+}
+
+func formatLockedAccess(access *runtime.Raw) string {
+	if access == nil {
+		return "<none>"
+	}
+	return string(access.Data)
+}
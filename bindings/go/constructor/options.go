@@ -3,9 +3,12 @@ package constructor
 import (
 	"context"
 
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	constructor "ocm.software/open-component-model/bindings/go/constructor/runtime"
 	"ocm.software/open-component-model/bindings/go/credentials"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
 )
 
 // Options are the options for construction based on a *constructor.Constructor.
@@ -60,9 +63,51 @@ type Options struct {
 	// external references to component versions not located within the constructor or target repository itself.
 	ExternalComponentVersionCopyPolicy
 
+	// While constructing a component version, the constructor library will resolve all component references
+	// that point outside of the constructor specification (including their transitive references) before any
+	// component is constructed, and verify that the referenced component versions exist in the target repository
+	// and match their declared digests. All broken references are reported at once with a *ReferenceClosureError.
+	// Component versions that are copied with ExternalComponentVersionCopyPolicyCopyOrFail may instead exist
+	// in the external repository.
+	// ValidateReferenceClosure is OPTIONAL, if not set, broken references are only detected when they are resolved.
+	ValidateReferenceClosure bool
+
 	// While constructing a component version, the constructor library will use the given callbacks to notify about
 	// the construction process. This can be used to implement custom logging or other actions such as progress trackers.
 	ComponentConstructionCallbacks
+
+	// While constructing a component version, the constructor library will record every component, resource, source
+	// and reference it processes (including outcome, duration, digest and attempt) in the given journal.
+	// Components that the journal records as successfully constructed in a previous run are not constructed again,
+	// but loaded from the target repository, which allows resuming a failed construction.
+	// The Journal is OPTIONAL, if not provided, no journal is recorded.
+	Journal *journal.Journal
+
+	// While constructing a component version, the constructor library will write a status record for the start and
+	// end of every component, resource, source and reference it processes to the given writer, as newline-delimited
+	// JSON with the StatusOperation and the JournalKind of the element as step. CI systems can parse these records
+	// instead of the human-readable logs.
+	// The Status writer is OPTIONAL, if not provided, no status records are written.
+	Status *status.Writer
+
+	// While constructing a component version, the constructor library will record the resolution of every resource,
+	// source and reference it processes (their digests, accesses and the content produced by input methods) in the
+	// given lockfile. Entries of elements that are not processed are kept.
+	// The Lockfile is OPTIONAL, if not provided, no lockfile is recorded.
+	Lockfile *Lockfile
+
+	// Locked verifies the resolution of every resource, source and reference against the entries of the Lockfile
+	// instead of recording them. Construction fails with ErrLockMismatch before the component version is added to
+	// the target repository if a resolution differs or is not locked.
+	// Locked is OPTIONAL and requires a Lockfile.
+	Locked bool
+
+	// TempFiles is the manager that temporary files created during the construction (for example by input methods,
+	// plugins or when uploading to the target repository) are created with. It is passed on through the context.
+	// The TempFiles manager is OPTIONAL, if not provided, a new manager is used for every construction and all
+	// temporary files that are left over are removed once the construction returns.
+	// If provided, cleaning up the manager is the responsibility of the caller.
+	TempFiles *tempfile.Manager
 }
 
 type ComponentConstructionCallbacks struct {
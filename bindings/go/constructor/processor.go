@@ -119,12 +119,29 @@ func (p *processor) processConstructorComponent(ctx context.Context, component *
 		// identities).
 		referencedComponents[ref.ToIdentity().String()] = refDescriptor
 	}
+	resumed, err := p.constructor.resumeComponent(ctx, component)
+	if err != nil {
+		return fmt.Errorf("error resuming component %q: %w", component.ToIdentity(), err)
+	}
+	if resumed != nil {
+		if err := p.processedDescriptors.store(ctx, resumed); err != nil {
+			return fmt.Errorf("failed to store processed descriptor: %w", err)
+		}
+		return nil
+	}
 	if p.constructor.opts.OnStartComponentConstruct != nil {
 		if err := p.constructor.opts.OnStartComponentConstruct(ctx, component); err != nil {
 			return fmt.Errorf("error starting component construction for %q: %w", component.ToIdentity(), err)
 		}
 	}
+	step, err := p.constructor.startJournalStep(JournalKindComponent, component.ToIdentity().String())
+	if err != nil {
+		return err
+	}
 	desc, err := p.constructor.constructComponent(ctx, component, referencedComponents)
+	if err := step.end(err, nil); err != nil {
+		return err
+	}
 	if p.constructor.opts.OnEndComponentConstruct != nil {
 		if err := p.constructor.opts.OnEndComponentConstruct(ctx, desc, err); err != nil {
 			return fmt.Errorf("error ending component construction for %q: %w", component.ToIdentity(), err)
@@ -0,0 +1,241 @@
+package constructor
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	constructor "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ErrBrokenReference is the error every ReferenceClosureError matches with errors.Is.
+var ErrBrokenReference = errors.New("broken component reference")
+
+// BrokenReference describes a component reference that failed validation of the reference closure.
+type BrokenReference struct {
+	// Component is the identity of the referencing component version.
+	Component string
+	// Reference is the identity of the reference within the referencing component version.
+	Reference string
+	// Err is the reason the reference is broken, e.g. wrapping repository.ErrNotFound or ErrDigestMismatch.
+	Err error
+}
+
+// ReferenceClosureError is returned by Construct if Options.ValidateReferenceClosure is set
+// and at least one component reference is broken. It reports all broken references at once.
+type ReferenceClosureError struct {
+	Broken []BrokenReference
+}
+
+func (e *ReferenceClosureError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d broken component reference(s):", len(e.Broken))
+	for _, broken := range e.Broken {
+		fmt.Fprintf(&sb, "\n- component %s: reference %s: %v", broken.Component, broken.Reference, broken.Err)
+	}
+	return sb.String()
+}
+
+func (e *ReferenceClosureError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Broken)+1)
+	errs = append(errs, ErrBrokenReference)
+	for _, broken := range e.Broken {
+		errs = append(errs, broken.Err)
+	}
+	return errs
+}
+
+// referenceCheck is a single reference to validate.
+type referenceCheck struct {
+	referrer string
+	// reference is the identity of the reference within the referrer.
+	reference string
+	// component is the identity of the referenced component version.
+	component ocmruntime.Identity
+	// digest is the expected digest of the referenced component version, if any.
+	digest *descriptor.Digest
+	repo   TargetRepository
+}
+
+type resolvedComponent struct {
+	desc *descriptor.Descriptor
+	err  error
+}
+
+// validateReferenceClosure resolves all component references that point outside of the constructor,
+// including the references of the referenced component versions, and verifies that they exist and
+// match their declared digests.
+//
+// A referenced component version has to exist in the target repository of the referencing component.
+// With ExternalComponentVersionCopyPolicyCopyOrFail it may instead exist in the external repository,
+// as it is copied to the target repository during construction.
+// References between components of the constructor are not validated here, as the referenced
+// component versions do not exist before construction and their digests are verified while
+// processing the reference.
+func (c *DefaultConstructor) validateReferenceClosure(ctx context.Context) error {
+	inConstructor := make(map[string]struct{}, len(c.constructor.Components))
+	for _, component := range c.constructor.Components {
+		inConstructor[component.ToIdentity().String()] = struct{}{}
+	}
+
+	var pending []referenceCheck
+	for _, component := range c.constructor.Components {
+		if len(component.References) == 0 {
+			continue
+		}
+		repo, err := c.opts.GetTargetRepository(ctx, &component)
+		if err != nil {
+			return fmt.Errorf("error getting target repository for component %q: %w", component.ToIdentity(), err)
+		}
+		for _, reference := range component.References {
+			if _, ok := inConstructor[reference.ToComponentIdentity().String()]; ok {
+				continue
+			}
+			pending = append(pending, referenceCheck{
+				referrer:  component.ToIdentity().String(),
+				reference: reference.ToIdentity().String(),
+				component: reference.ToComponentIdentity(),
+				digest:    constructorDigest(reference.Digest),
+				repo:      repo,
+			})
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		resolved = make(map[string]resolvedComponent)
+		expanded = make(map[string]struct{})
+		broken   []BrokenReference
+	)
+	for len(pending) > 0 {
+		eg, egctx := newConcurrencyGroup(ctx, c.opts.ConcurrencyLimit)
+		scheduled := make(map[string]struct{})
+		for _, check := range pending {
+			id := check.component.String()
+			if _, ok := resolved[id]; ok {
+				continue
+			}
+			if _, ok := scheduled[id]; ok {
+				continue
+			}
+			scheduled[id] = struct{}{}
+			eg.Go(func() error {
+				desc, err := c.resolveReferencedComponent(egctx, check.repo, check.component)
+				mu.Lock()
+				defer mu.Unlock()
+				resolved[id] = resolvedComponent{desc: desc, err: err}
+				return nil
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var next []referenceCheck
+		for _, check := range pending {
+			id := check.component.String()
+			result := resolved[id]
+			if result.err == nil {
+				result.err = verifyReferenceDigest(check.digest, result.desc)
+			}
+			if result.err != nil {
+				broken = append(broken, BrokenReference{Component: check.referrer, Reference: check.reference, Err: result.err})
+				continue
+			}
+			if _, ok := expanded[id]; ok {
+				continue
+			}
+			expanded[id] = struct{}{}
+			for _, reference := range result.desc.Component.References {
+				if _, ok := inConstructor[reference.ToComponentIdentity().String()]; ok {
+					continue
+				}
+				var digest *descriptor.Digest
+				if reference.Digest.Value != "" {
+					digest = &reference.Digest
+				}
+				next = append(next, referenceCheck{
+					referrer:  id,
+					reference: reference.ToIdentity().String(),
+					component: reference.ToComponentIdentity(),
+					digest:    digest,
+					repo:      check.repo,
+				})
+			}
+		}
+		pending = next
+	}
+
+	if len(broken) == 0 {
+		slog.DebugContext(ctx, "component reference closure validated successfully", "components", len(resolved))
+		return nil
+	}
+	slices.SortFunc(broken, func(a, b BrokenReference) int {
+		return cmp.Or(cmp.Compare(a.Component, b.Component), cmp.Compare(a.Reference, b.Reference))
+	})
+	return &ReferenceClosureError{Broken: broken}
+}
+
+// resolveReferencedComponent gets a referenced component version from the target repository, or if it is
+// copied during construction, from the external repository.
+func (c *DefaultConstructor) resolveReferencedComponent(ctx context.Context, repo TargetRepository, identity ocmruntime.Identity) (*descriptor.Descriptor, error) {
+	name, version := identity[descriptor.IdentityAttributeName], identity[descriptor.IdentityAttributeVersion]
+	desc, err := repo.GetComponentVersion(ctx, name, version)
+	if err == nil {
+		return desc, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) ||
+		c.opts.ExternalComponentVersionCopyPolicy != ExternalComponentVersionCopyPolicyCopyOrFail ||
+		c.opts.ExternalComponentRepositoryProvider == nil {
+		return nil, fmt.Errorf("error getting component version %q from target repository: %w", identity, err)
+	}
+	externalRepo, err := c.opts.GetExternalRepository(ctx, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("error getting external repository for component %q: %w", identity, err)
+	}
+	if desc, err = externalRepo.GetComponentVersion(ctx, name, version); err != nil {
+		return nil, fmt.Errorf("error getting component version %q from external repository: %w", identity, err)
+	}
+	return desc, nil
+}
+
+func verifyReferenceDigest(expected *descriptor.Digest, desc *descriptor.Descriptor) error {
+	if expected == nil {
+		return nil
+	}
+	actual, err := calculateDigest(desc)
+	if err != nil {
+		return err
+	}
+	if expected.HashAlgorithm != actual.HashAlgorithm ||
+		expected.NormalisationAlgorithm != actual.NormalisationAlgorithm ||
+		expected.Value != actual.Value {
+		return fmt.Errorf("%w: expected digest (hashAlgorithm=%s, normalisationAlgorithm=%s, value=%s) but calculated digest is (hashAlgorithm=%s, normalisationAlgorithm=%s, value=%s)",
+			ErrDigestMismatch,
+			expected.HashAlgorithm, expected.NormalisationAlgorithm, expected.Value,
+			actual.HashAlgorithm, actual.NormalisationAlgorithm, actual.Value)
+	}
+	return nil
+}
+
+func constructorDigest(digest *constructor.Digest) *descriptor.Digest {
+	if digest == nil {
+		return nil
+	}
+	return &descriptor.Digest{
+		HashAlgorithm:          digest.HashAlgorithm,
+		NormalisationAlgorithm: digest.NormalisationAlgorithm,
+		Value:                  digest.Value,
+	}
+}
@@ -15,7 +15,7 @@
 //   - Support for repository-specific credential handling
 //   - Thread-safe operations with synchronized DAG implementation
 //   - Flexible identity-based credential lookup with wildcard support
-//   - Caching of resolved credentials for improved performance
+//   - Caching of resolved credentials for improved performance, with expiry of short-lived credentials
 //   - Concurrent resolution of repository credentials
 //
 // # Core Concept
@@ -206,6 +206,9 @@
 //	  type: DockerConfig/v1
 //	  dockerConfigFile: "~/.docker/config.json"
 //
+// Secrets stored in HashiCorp Vault can be offered in the same way with the HashiCorpVault/v1alpha1
+// repository of the [ocm.software/open-component-model/bindings/go/credentials/vault] package.
+//
 // Note that repositories are always consulted AFTER no direct credentials are found.
 // They act as a store that is independent from the graph.
 //
@@ -216,6 +219,23 @@
 // - The credentials required for a credential plugin CANNOT be resolved via a repository plugin
 // - A repository plugin may be called for ANY consumer identity (currently there is no filtering implemented, but this may change)
 //
+// # Expiry of Resolved Credentials
+//
+// Credentials resolved by plugins are cached in the graph. Short-lived credentials, such as tokens issued
+// by Vault or an STS, report when they expire, either by implementing [ExpiringCredentials] or, for
+// [ocm.software/open-component-model/bindings/go/credentials/spec/config/v1.DirectCredentials], with the
+// [ExpiresAtProperty]. Once they expired, the next resolution asks the plugins again.
+// [Options.TTL] additionally bounds how long any resolved credentials are cached:
+//
+//	graph, err := ToGraph(ctx, config, Options{
+//	    TTL: 15 * time.Minute,
+//	    OnRefresh: func(ctx context.Context, identity runtime.Identity, credentials runtime.Typed) {
+//	        // e.g. replace the credentials of long-lived clients for the identity
+//	    },
+//	})
+//
+// Credentials that are configured directly never expire.
+//
 // # Usage
 //
 // Basic usage with typed credential resolution:
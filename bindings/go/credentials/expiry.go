@@ -0,0 +1,69 @@
+package credentials
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ExpiresAtProperty is the property of [v1.DirectCredentials] that carries the time, formatted as RFC 3339,
+// after which the credentials are no longer valid. Plugins returning short-lived credentials such as
+// tokens of Vault or an STS set it so that the graph resolves them again once they expired.
+const ExpiresAtProperty = "expiresAt"
+
+// ExpiringCredentials is implemented by typed credentials that are only valid for a limited time.
+// The graph resolves them again once ExpiresAt has passed. A zero time means that they do not expire.
+type ExpiringCredentials interface {
+	ExpiresAt() time.Time
+}
+
+// RefreshFunc is called after credentials of an identity were resolved again because the cached
+// credentials expired, e.g. to replace the credentials of long-lived clients.
+type RefreshFunc func(ctx context.Context, identity runtime.Identity, credentials runtime.Typed)
+
+// expiry returns the time after which credentials resolved at now must be resolved again.
+// It is the earliest expiry of the credentials, bounded by the TTL of the graph.
+// The zero time is returned if the credentials do not expire.
+func (g *Graph) expiry(now time.Time, credentials ...runtime.Typed) time.Time {
+	var expiresAt time.Time
+	if g.ttl > 0 {
+		expiresAt = now.Add(g.ttl)
+	}
+	for _, c := range credentials {
+		if at := expiresAtOf(c); !at.IsZero() && (expiresAt.IsZero() || at.Before(expiresAt)) {
+			expiresAt = at
+		}
+	}
+	return expiresAt
+}
+
+// refreshed notifies the OnRefresh callback about credentials that were resolved again after they expired.
+func (g *Graph) refreshed(ctx context.Context, identity runtime.Identity, credentials runtime.Typed) {
+	slog.DebugContext(ctx, "refreshed expired credentials", "identity", identity.String())
+	if g.onRefresh != nil {
+		g.onRefresh(ctx, identity, credentials)
+	}
+}
+
+func expiresAtOf(credentials runtime.Typed) time.Time {
+	switch c := credentials.(type) {
+	case ExpiringCredentials:
+		return c.ExpiresAt()
+	case *v1.DirectCredentials:
+		value, ok := c.Properties[ExpiresAtProperty]
+		if !ok {
+			return time.Time{}
+		}
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			slog.Warn("ignoring invalid expiry of credentials", "property", ExpiresAtProperty, "error", err)
+			return time.Time{}
+		}
+		return at
+	default:
+		return time.Time{}
+	}
+}
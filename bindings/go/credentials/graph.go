@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	cfgRuntime "ocm.software/open-component-model/bindings/go/credentials/spec/config/runtime"
 	v1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
@@ -25,6 +26,15 @@ type Options struct {
 	CredentialRepositoryTypeScheme *runtime.Scheme
 	// CredentialTypeSchemeProvider provides access to known credential types (e.g. HelmHTTPCredentials/v1).
 	CredentialTypeSchemeProvider CredentialTypeSchemeProvider
+	// Limiter is consulted before credential and repository plugins resolve credentials for an identity
+	// with a hostname. It is optional — when nil, plugin calls are not throttled.
+	Limiter Limiter
+	// TTL bounds how long credentials resolved by plugins are cached before they are resolved again.
+	// Credentials that expire earlier on their own (see [ExpiringCredentials] and [ExpiresAtProperty])
+	// are resolved again once they expired. It is optional — when zero, only expiring credentials are resolved again.
+	TTL time.Duration
+	// OnRefresh is called after expired credentials were resolved again. It is optional.
+	OnRefresh RefreshFunc
 }
 
 // ToGraph creates a new credential graph from the provided configuration and options.
@@ -35,6 +45,10 @@ func ToGraph(ctx context.Context, config *cfgRuntime.Config, opts Options) (*Gra
 		credentialPluginProvider:     opts.CredentialPluginProvider,
 		repositoryPluginProvider:     opts.RepositoryPluginProvider,
 		credentialTypeSchemeProvider: opts.CredentialTypeSchemeProvider,
+		limiter:                      opts.Limiter,
+		ttl:                          opts.TTL,
+		onRefresh:                    opts.OnRefresh,
+		now:                          time.Now,
 	}
 
 	if err := ingest(ctx, g, config, opts.CredentialRepositoryTypeScheme); err != nil {
@@ -56,6 +70,23 @@ type Graph struct {
 	repositoryPluginProvider     RepositoryPluginProvider     // injection for resolving custom repository types
 	credentialPluginProvider     CredentialPluginProvider     // injection for resolving custom credential types
 	credentialTypeSchemeProvider CredentialTypeSchemeProvider // optional: enables typed credential ingestion
+	limiter                      Limiter                      // optional: throttles plugin calls per host
+	ttl                          time.Duration                // optional: bounds caching of resolved credentials
+	onRefresh                    RefreshFunc                  // optional: notified about refreshed credentials
+	now                          func() time.Time             // clock for the expiry of resolved credentials
+}
+
+// wait blocks until the limiter allows a plugin call for the hostname of the identity.
+// Identities without a hostname are not throttled.
+func (g *Graph) wait(ctx context.Context, identity runtime.Identity) error {
+	if g.limiter == nil {
+		return nil
+	}
+	host, ok := identity[runtime.IdentityAttributeHostname]
+	if !ok || host == "" {
+		return nil
+	}
+	return g.limiter.Wait(ctx, host)
 }
 
 // credentialTypeScheme returns the underlying scheme from the credential type
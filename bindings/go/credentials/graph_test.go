@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -654,6 +655,45 @@ consumers:
 	}
 }
 
+func TestGraphShape(t *testing.T) {
+	r := require.New(t)
+	resolver, err := GetGraph(t, `
+type: credentials.config.ocm.software
+consumers:
+  - identity:
+      type: RecursionTest
+      path: "recursive/path/abc"
+    credentials:
+      - type: RecursionTest
+        path: "recursive/path/a"
+  - identity:
+      type: RecursionTest
+      path: "recursive/path/a"
+    credentials:
+      - type: Credentials/v1
+        properties:
+          username: "user"
+          password: "secret"
+`)
+	r.NoError(err)
+	graph, ok := resolver.(*credentials.Graph)
+	r.True(ok)
+
+	shape := graph.Shape()
+	r.Len(shape.Nodes, 2)
+	a, abc := shape.Nodes[0], shape.Nodes[1]
+	r.Equal("path=recursive/path/a,type=RecursionTest", a.ID)
+	r.Equal(runtime.Identity{"type": "RecursionTest", "path": "recursive/path/a"}, a.Identity)
+	r.Equal("Credentials/v1", a.CredentialType)
+	r.Equal([]string{"password", "username"}, a.CredentialProperties)
+	r.Empty(abc.CredentialType)
+	r.Equal([]credentials.EdgeShape{{To: a.ID, Kind: "resolution-relevant"}}, abc.Edges)
+
+	data, err := json.Marshal(shape)
+	r.NoError(err)
+	r.NotContains(string(data), "secret")
+}
+
 func TestResolutionErrors(t *testing.T) {
 	id := runtime.Identity{
 		"type": "not exists",
@@ -686,3 +726,141 @@ func TestResolutionErrors(t *testing.T) {
 	r.ErrorContains(err, fmt.Sprintf("failed to resolve credentials for identity %q: credentials not found", id.String()))
 	r.ErrorContains(err, "no indirect credentials found in graph")
 }
+
+type recordingLimiter struct {
+	hosts []string
+}
+
+func (l *recordingLimiter) Wait(_ context.Context, host string) error {
+	l.hosts = append(l.hosts, host)
+	return nil
+}
+
+func TestResolveWithLimiter(t *testing.T) {
+	r := require.New(t)
+	scheme := runtime.NewScheme()
+	v1.MustRegister(scheme)
+
+	var configv1 v1.Config
+	r.NoError(scheme.Decode(strings.NewReader(`
+type: credentials.config.ocm.software
+consumers:
+  - identity:
+      type: OCIRegistry
+      hostname: registry.example.com
+    credentials:
+      - type: Token
+        token: abc
+`), &configv1))
+
+	limiter := &recordingLimiter{}
+	graph, err := credentials.ToGraph(t.Context(), credentialruntime.ConvertFromV1(&configv1), credentials.Options{
+		CredentialPluginProvider: credentials.GetCredentialPluginFn(func(_ context.Context, _ runtime.Typed) (credentials.CredentialPlugin, error) {
+			return CredentialPlugin{
+				ConsumerIdentityTypeAttributes: map[runtime.Type]map[string]func(v any) (string, string){
+					runtime.NewUnversionedType("Token"): {
+						"token": func(v any) (string, string) { return "token", v.(string) },
+					},
+				},
+				CredentialFunc: func(_ context.Context, _ runtime.Identity, _ runtime.Typed) (runtime.Typed, error) {
+					return &v1.DirectCredentials{
+						Type:       runtime.NewVersionedType(v1.CredentialsType, v1.Version),
+						Properties: map[string]string{"token": "abc"},
+					}, nil
+				},
+			}, nil
+		}),
+		CredentialRepositoryTypeScheme: runtime.NewScheme(runtime.WithAllowUnknown()),
+		Limiter:                        limiter,
+	})
+	r.NoError(err)
+
+	identity := runtime.Identity{"type": "OCIRegistry", "hostname": "registry.example.com"}
+	_, err = graph.Resolve(t.Context(), identity)
+	r.NoError(err)
+	r.Equal([]string{"registry.example.com"}, limiter.hosts)
+
+	// resolved credentials are cached, so the plugin and the limiter are not consulted again.
+	_, err = graph.Resolve(t.Context(), identity)
+	r.NoError(err)
+	r.Len(limiter.hosts, 1)
+}
+
+func TestResolveWithExpiry(t *testing.T) {
+	scheme := runtime.NewScheme()
+	v1.MustRegister(scheme)
+
+	var configv1 v1.Config
+	require.NoError(t, scheme.Decode(strings.NewReader(`
+type: credentials.config.ocm.software
+consumers:
+  - identity:
+      type: OCIRegistry
+      hostname: registry.example.com
+    credentials:
+      - type: Token
+        token: abc
+`), &configv1))
+	identity := runtime.Identity{"type": "OCIRegistry", "hostname": "registry.example.com"}
+
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		expiresAt string
+		resolved  int
+	}{
+		{name: "without expiry", resolved: 1},
+		{name: "not yet expired", expiresAt: time.Now().Add(time.Hour).Format(time.RFC3339), resolved: 1},
+		{name: "expired", expiresAt: time.Now().Add(-time.Hour).Format(time.RFC3339), resolved: 3},
+		{name: "ttl", ttl: time.Nanosecond, resolved: 3},
+		{name: "expires before ttl", ttl: time.Hour, expiresAt: time.Now().Add(-time.Hour).Format(time.RFC3339), resolved: 3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			var resolved int
+			var refreshed []runtime.Typed
+			graph, err := credentials.ToGraph(t.Context(), credentialruntime.ConvertFromV1(&configv1), credentials.Options{
+				CredentialPluginProvider: credentials.GetCredentialPluginFn(func(_ context.Context, _ runtime.Typed) (credentials.CredentialPlugin, error) {
+					return CredentialPlugin{
+						ConsumerIdentityTypeAttributes: map[runtime.Type]map[string]func(v any) (string, string){
+							runtime.NewUnversionedType("Token"): {
+								"token": func(v any) (string, string) { return "token", v.(string) },
+							},
+						},
+						CredentialFunc: func(_ context.Context, _ runtime.Identity, _ runtime.Typed) (runtime.Typed, error) {
+							resolved++
+							properties := map[string]string{"token": fmt.Sprintf("token-%d", resolved)}
+							if tc.expiresAt != "" {
+								properties[credentials.ExpiresAtProperty] = tc.expiresAt
+							}
+							return &v1.DirectCredentials{
+								Type:       runtime.NewVersionedType(v1.CredentialsType, v1.Version),
+								Properties: properties,
+							}, nil
+						},
+					}, nil
+				}),
+				CredentialRepositoryTypeScheme: runtime.NewScheme(runtime.WithAllowUnknown()),
+				TTL:                            tc.ttl,
+				OnRefresh: func(_ context.Context, id runtime.Identity, creds runtime.Typed) {
+					r.Equal(identity.String(), id.String())
+					refreshed = append(refreshed, creds)
+				},
+			})
+			r.NoError(err)
+
+			var last runtime.Typed
+			for range 3 {
+				last, err = graph.Resolve(t.Context(), identity)
+				r.NoError(err)
+			}
+			r.Equal(tc.resolved, resolved)
+			r.Equal(fmt.Sprintf("token-%d", resolved), last.(*v1.DirectCredentials).Properties["token"])
+			r.Len(refreshed, tc.resolved-1)
+			if len(refreshed) > 0 {
+				r.Equal(last, refreshed[len(refreshed)-1])
+			}
+		})
+	}
+}
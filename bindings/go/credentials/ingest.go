@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
+	"time"
 
 	cfgRuntime "ocm.software/open-component-model/bindings/go/credentials/spec/config/runtime"
 	v1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
@@ -94,7 +95,8 @@ func processDirectCredentials(ctx context.Context, g *Graph, config *cfgRuntime.
 	}
 
 	for node, typed := range typedPerIdentity {
-		g.setCredentials(node, typed)
+		// configured credentials never expire, only credentials resolved from them do.
+		g.setCredentials(node, typed, time.Time{})
 	}
 
 	return consumers, nil
@@ -138,9 +140,7 @@ func processConsumerCredential(ctx context.Context, g *Graph, credential runtime
 	}
 
 	credentialNode := credentialIdentity.String()
-	if err := g.addEdge(node, credentialNode, map[string]any{
-		"kind": "resolution-relevant",
-	}); err != nil {
+	if err := g.addEdge(node, credentialNode, edgeKindResolutionRelevant); err != nil {
 		return fmt.Errorf("could not add edge from consumer identity %q to credential identity %q: %w", identity, credentialIdentity, err)
 	}
 	return nil
@@ -5,10 +5,11 @@ import (
 	"errors"
 
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // ErrNotFound is returned when no credentials could be found for the given identity.
-var ErrNotFound = errors.New("credentials not found")
+var ErrNotFound = ocmerrors.NotFound(errors.New("credentials not found"))
 
 // ErrUnknown is a generic error indicating an unknown failure during credential resolution.
 var ErrUnknown = errors.New("unknown error occurred")
@@ -24,6 +25,13 @@ type Resolver interface {
 	Resolve(ctx context.Context, identity runtime.Identity) (runtime.Typed, error)
 }
 
+// Limiter throttles credential plugin calls for a host, e.g. the Throttle of the http bindings that is
+// shared with the HTTP clients talking to the same hosts, so resolving credentials does not add to the
+// load on a struggling upstream. Wait blocks until a call for the host may be made or the context is done.
+type Limiter interface {
+	Wait(ctx context.Context, host string) error
+}
+
 // CredentialTypeSchemeProvider provides read access to a runtime.Scheme of known credential types.
 // The credential graph uses this during ingestion to deserialize typed credentials and resolve
 // type aliases. It is optional — when nil, the graph falls back to *v1.DirectCredentials.
@@ -25,8 +25,9 @@ func (g *Graph) resolveFromGraph(ctx context.Context, identity runtime.Identity)
 		return nil, err
 	}
 
-	// Leaf node: return the credentials directly.
-	creds, cached := g.getCredentials(vertex.ID)
+	// Leaf node or cached credentials: return the credentials directly.
+	now := g.now()
+	creds, cached, expired := g.getCredentials(vertex.ID, now)
 	if cached {
 		return creds, nil
 	}
@@ -50,6 +51,9 @@ func (g *Graph) resolveFromGraph(ctx context.Context, identity runtime.Identity)
 		}
 
 		// Let the plugin resolve the child's credentials.
+		if err := g.wait(ctx, identity); err != nil {
+			return nil, err
+		}
 		credentials, err := plugin.Resolve(ctx, identity, childCredentials)
 		if err != nil {
 			return nil, fmt.Errorf("no credentials for node %q resolved from plugin: %w", edgeID, err)
@@ -65,7 +69,10 @@ func (g *Graph) resolveFromGraph(ctx context.Context, identity runtime.Identity)
 		return nil, fmt.Errorf("merging credentials for node %q: %w", node, err)
 	}
 
-	g.setCredentials(node, merged)
+	g.setCredentials(node, merged, g.expiry(now, resolved...))
+	if expired {
+		g.refreshed(ctx, identity, merged)
+	}
 
 	return merged, nil
 }
@@ -16,11 +16,13 @@ import (
 var ErrNoIndirectCredentials = errors.New("no indirect credentials found in graph")
 
 // resolveFromRepository is invoked when the DAG does not yield direct credentials.
-// The method ensures that successful resolutions are cached for subsequent calls.
+// The method ensures that successful resolutions are cached for subsequent calls until they expire.
 func (g *Graph) resolveFromRepository(ctx context.Context, identity runtime.Identity) (runtime.Typed, error) {
 	node := identity.String()
 
-	if credentials, cached := g.getCredentials(node); cached {
+	now := g.now()
+	credentials, cached, expired := g.getCredentials(node, now)
+	if cached {
 		return credentials, nil
 	}
 
@@ -67,7 +69,10 @@ func (g *Graph) resolveFromRepository(ctx context.Context, identity runtime.Iden
 			}
 		}
 		slog.DebugContext(ctx, "Resolving credentials via repository", "identity", identity, "config", cfg)
-		credentials, err := plugin.Resolve(ctx, cfg, identity, credentials)
+		err := g.wait(ctx, identity)
+		if err == nil {
+			credentials, err = plugin.Resolve(ctx, cfg, identity, credentials)
+		}
 
 		mu.Lock()
 		defer mu.Unlock()
@@ -76,6 +81,8 @@ func (g *Graph) resolveFromRepository(ctx context.Context, identity runtime.Iden
 		case err != nil:
 			slog.DebugContext(ctx, "repository plugin failed to resolve credentials", slog.Any("identity", identity), slog.Any("config", cfg.GetType()), slog.Any("error", err))
 			errs = append(errs, err)
+		case credentials == nil:
+			// the repository has no credentials for the identity, the other repositories are still asked.
 		case resolved == nil:
 			resolved = credentials
 			cancel()
@@ -105,7 +112,10 @@ func (g *Graph) resolveFromRepository(ctx context.Context, identity runtime.Iden
 		return nil, errors.Join(ErrNoIndirectCredentials, fmt.Errorf("no repository plugin could resolve credentials for identity %q", node))
 	}
 
-	g.setCredentials(node, resolved)
+	g.setCredentials(node, resolved, g.expiry(now, resolved))
+	if expired {
+		g.refreshed(ctx, identity, resolved)
+	}
 
 	return resolved, nil
 }
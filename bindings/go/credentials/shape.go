@@ -0,0 +1,75 @@
+package credentials
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// GraphShape describes the structure of a credential graph for diagnostics.
+// It contains no credential values, only their types and, for direct credentials, the names
+// of their properties.
+type GraphShape struct {
+	// Nodes are the consumer identities of the graph, sorted by ID.
+	Nodes []NodeShape `json:"nodes"`
+	// Repositories are the types of the configured credential repositories.
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// NodeShape describes a consumer identity in a credential graph.
+type NodeShape struct {
+	ID       string           `json:"id"`
+	Identity runtime.Identity `json:"identity"`
+	// CredentialType is the type of the credentials directly attached to the identity, if any.
+	CredentialType string `json:"credentialType,omitempty"`
+	// CredentialProperties are the names of the properties of directly attached DirectCredentials.
+	CredentialProperties []string `json:"credentialProperties,omitempty"`
+	// Edges are the IDs of the nodes this node resolves credentials from, with the kind of the edge.
+	Edges []EdgeShape `json:"edges,omitempty"`
+}
+
+// EdgeShape describes an edge between two nodes of a credential graph.
+type EdgeShape struct {
+	To   string `json:"to"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// Shape returns the redacted structure of the graph.
+func (g *Graph) Shape() GraphShape {
+	var shape GraphShape
+
+	g.dagMu.RLock()
+	for id, vertex := range g.dag.Vertices {
+		node := NodeShape{ID: id}
+		if identity := vertex.Attributes.identity; identity != nil {
+			node.Identity = maps.Clone(identity)
+		}
+		if credentials := vertex.Attributes.credentials; credentials != nil {
+			node.CredentialType = credentials.GetType().String()
+			if direct, ok := credentials.(*v1.DirectCredentials); ok {
+				node.CredentialProperties = slices.Sorted(maps.Keys(direct.Properties))
+			}
+		}
+		for to, kind := range vertex.Edges {
+			node.Edges = append(node.Edges, EdgeShape{To: to, Kind: string(kind)})
+		}
+		slices.SortFunc(node.Edges, func(a, b EdgeShape) int {
+			return strings.Compare(a.To, b.To)
+		})
+		shape.Nodes = append(shape.Nodes, node)
+	}
+	g.dagMu.RUnlock()
+	slices.SortFunc(shape.Nodes, func(a, b NodeShape) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	g.repositoryConfigurationsMu.RLock()
+	defer g.repositoryConfigurationsMu.RUnlock()
+	for _, repository := range g.repositoryConfigurations {
+		shape.Repositories = append(shape.Repositories, repository.GetType().String())
+	}
+	return shape
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Version is the version of the HashiCorp Vault credential repository types.
+const Version = "v1alpha1"
+
+const (
+	// RepositoryType is the type name of the HashiCorp Vault credential repository configuration.
+	RepositoryType = "HashiCorpVault"
+	// ConsumerIdentityType is the type of the consumer identity of a HashiCorp Vault server.
+	// Credentials for this identity are used to authenticate against the server.
+	ConsumerIdentityType = "HashiCorpVault"
+)
+
+var (
+	RepositoryVersionedType       = runtime.NewVersionedType(RepositoryType, Version)
+	ConsumerIdentityVersionedType = runtime.NewVersionedType(ConsumerIdentityType, Version)
+)
+
+// DefaultMountPath is the mount path of the KV v2 secrets engine that is used if none is configured.
+const DefaultMountPath = "secret"
+
+// Repository is a credential repository backed by the KV v2 secrets engine of a HashiCorp Vault server.
+//
+// Every secret of the repository describes the credentials of a consumer. The consumer is given by the
+// property consumerId of the secret, a JSON encoded consumer identity, all other properties of the
+// secret are the credentials. Secrets without consumerId are ignored.
+//
+// The credentials to access the server are resolved for the consumer identity of type
+// HashiCorpVault/v1alpha1 with the hostname, port and scheme of the server. They either offer a
+// token or the role_id and secret_id of an AppRole.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Repository struct {
+	// +ocm:jsonschema-gen:enum=HashiCorpVault/v1alpha1
+	Type runtime.Type `json:"type"`
+	// ServerURL is the URL of the Vault server, e.g. https://myvault.example.com:8200.
+	ServerURL string `json:"serverURL"`
+	// Namespace is the Vault Enterprise namespace of the secrets engine.
+	Namespace string `json:"namespace,omitempty"`
+	// MountPath is the mount path of the KV v2 secrets engine. Defaults to DefaultMountPath.
+	MountPath string `json:"mountPath,omitempty"`
+	// Path is the path of the secrets within the secrets engine.
+	Path string `json:"path,omitempty"`
+	// Secrets are the names of the secrets below Path to consider.
+	// If empty, all secrets directly below Path are considered.
+	Secrets []string `json:"secrets,omitempty"`
+}
+
+// MustAddToScheme registers the HashiCorp Vault credential repository configuration in the scheme.
+func MustAddToScheme(scheme *runtime.Scheme) {
+	scheme.MustRegisterWithAlias(&Repository{},
+		RepositoryVersionedType,
+		runtime.NewUnversionedType(RepositoryType),
+	)
+}
@@ -4,17 +4,36 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"ocm.software/open-component-model/bindings/go/dag"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
+// edgeKind describes why an edge between two identities exists.
+type edgeKind string
+
 const (
-	attributeIdentity = "attributes.ocm.software/identity"
-	//nolint:gosec // gosec thinks this is a hardcoded credential, but it's not.
-	attributeCredentials = "attributes.ocm.software/credentials"
+	// edgeKindResolutionRelevant edges point from a consumer identity to the identity of
+	// its credentials and are followed during resolution.
+	edgeKindResolutionRelevant edgeKind = "resolution-relevant"
+	// edgeKindCyclicOnly edges connect identities matching each other. They are only used
+	// to discover cycles between identities.
+	edgeKindCyclicOnly edgeKind = "cyclic-only"
 )
 
+// vertexAttributes are the attributes of an identity in the graph.
+type vertexAttributes struct {
+	identity runtime.Identity
+	// credentials are the credentials attached to or resolved for the identity, if any.
+	credentials runtime.Typed
+	// expiresAt is the time after which resolved credentials must be resolved again.
+	// It is zero for configured credentials and resolved credentials that do not expire.
+	expiresAt time.Time
+}
+
+type vertex = dag.TypedVertex[string, vertexAttributes, edgeKind]
+
 // ErrNoDirectCredentials is returned when a node in the graph does not have any directly
 // attached credentials. There might still be credentials available through
 // plugins which can be resolved at runtime.
@@ -22,16 +41,16 @@ var ErrNoDirectCredentials = errors.New("no direct credentials found in graph")
 
 func newSyncedDag() *syncedDag {
 	return &syncedDag{
-		dag: dag.NewDirectedAcyclicGraph[string](),
+		dag: dag.NewTypedDirectedAcyclicGraph[string, vertexAttributes, edgeKind](),
 	}
 }
 
 type syncedDag struct {
 	dagMu sync.RWMutex
-	dag   *dag.DirectedAcyclicGraph[string]
+	dag   *dag.TypedDirectedAcyclicGraph[string, vertexAttributes, edgeKind]
 }
 
-func (g *syncedDag) getVertex(id string) (v *dag.Vertex[string], ok bool) {
+func (g *syncedDag) getVertex(id string) (v *vertex, ok bool) {
 	g.dagMu.RLock()
 	defer g.dagMu.RUnlock()
 	v, ok = g.dag.Vertices[id]
@@ -43,40 +62,47 @@ func (g *syncedDag) getIdentity(id string) (runtime.Identity, bool) {
 	if !ok {
 		return nil, false
 	}
-	identity, ok := v.Attributes[attributeIdentity].(runtime.Identity)
-	return identity, ok
+	return v.Attributes.identity, v.Attributes.identity != nil
 }
 
-func (g *syncedDag) getCredentials(id string) (runtime.Typed, bool) {
-	v, ok := g.getVertex(id)
-	if !ok {
-		return nil, false
+// getCredentials returns the credentials of the identity if they have not expired at now.
+// expired reports whether credentials were available but have expired.
+func (g *syncedDag) getCredentials(id string, now time.Time) (credentials runtime.Typed, ok, expired bool) {
+	g.dagMu.RLock()
+	defer g.dagMu.RUnlock()
+	v, ok := g.dag.Vertices[id]
+	if !ok || v.Attributes.credentials == nil {
+		return nil, false, false
+	}
+	if expiresAt := v.Attributes.expiresAt; !expiresAt.IsZero() && !now.Before(expiresAt) {
+		return nil, false, true
 	}
-	credentials, ok := v.Attributes[attributeCredentials].(runtime.Typed)
-	return credentials, ok
+	return v.Attributes.credentials, true, false
 }
 
-func (g *syncedDag) setCredentials(id string, credentials runtime.Typed) {
+// setCredentials stores the credentials of the identity until expiresAt, a zero expiresAt never expires.
+func (g *syncedDag) setCredentials(id string, credentials runtime.Typed, expiresAt time.Time) {
 	g.dagMu.Lock()
 	defer g.dagMu.Unlock()
 	v, ok := g.dag.Vertices[id]
 	if !ok {
 		return
 	}
-	v.Attributes[attributeCredentials] = credentials
+	v.Attributes.credentials = credentials
+	v.Attributes.expiresAt = expiresAt
 }
 
-func (g *syncedDag) addEdge(from, to string, attributes ...map[string]any) error {
+func (g *syncedDag) addEdge(from, to string, kind edgeKind) error {
 	g.dagMu.Lock()
 	defer g.dagMu.Unlock()
-	return g.dag.AddEdge(from, to, attributes...)
+	return g.dag.AddEdge(from, to, kind)
 }
 
 // matchAnyNode attempts to locate the graph vertex corresponding to the provided node ID.
 // If an exact match is not found, it falls back to a wildcard search by comparing identities
 // using the Identity.Match method.
 // This wildcard search is the reason there can be undiscovered cycles at runtime.
-func (g *syncedDag) matchAnyNode(identity runtime.Identity) (*dag.Vertex[string], error) {
+func (g *syncedDag) matchAnyNode(identity runtime.Identity) (*vertex, error) {
 	g.dagMu.RLock()
 	defer g.dagMu.RUnlock()
 	node := identity.String()
@@ -84,8 +110,8 @@ func (g *syncedDag) matchAnyNode(identity runtime.Identity) (*dag.Vertex[string]
 		return vertex, nil
 	}
 	for _, vertex := range g.dag.Vertices {
-		existing, ok := vertex.Attributes[attributeIdentity].(runtime.Identity)
-		if !ok {
+		existing := vertex.Attributes.identity
+		if existing == nil {
 			continue
 		}
 		if identity.Match(existing) {
@@ -105,30 +131,24 @@ func (g *syncedDag) addIdentity(identity runtime.Identity) error {
 	if g.dag.Contains(node) {
 		return nil
 	}
-	if err := g.dag.AddVertex(node, map[string]any{
-		attributeIdentity: identity,
-	}); err != nil {
+	if err := g.dag.AddVertex(node, vertexAttributes{identity: identity}); err != nil {
 		return err
 	}
 	for _, vertex := range g.dag.Vertices {
 		if vertex.ID == node {
 			continue
 		}
-		existing, ok := vertex.Attributes[attributeIdentity].(runtime.Identity)
-		if !ok {
+		existing := vertex.Attributes.identity
+		if existing == nil {
 			continue
 		}
 		if identity.Match(existing) {
-			if err := g.dag.AddEdge(vertex.ID, node, map[string]any{
-				"kind": "cyclic-only",
-			}); err != nil {
+			if err := g.dag.AddEdge(vertex.ID, node, edgeKindCyclicOnly); err != nil {
 				return err
 			}
 		}
 		if existing.Match(identity) {
-			if err := g.dag.AddEdge(node, vertex.ID, map[string]any{
-				"kind": "cyclic-only",
-			}); err != nil {
+			if err := g.dag.AddEdge(node, vertex.ID, edgeKindCyclicOnly); err != nil {
 				return err
 			}
 		}
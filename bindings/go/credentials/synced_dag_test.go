@@ -2,10 +2,12 @@ package credentials
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	v1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -56,3 +58,27 @@ func Test_addIdentity_Idempotent(t *testing.T) {
 	require.NoError(t, dag.addIdentity(id)) // second add is a no-op
 	assert.Len(t, dag.dag.Vertices, 1)
 }
+
+func Test_getCredentials_Expiry(t *testing.T) {
+	dag := newSyncedDag()
+	id := runtime.Identity{"type": "OCIRegistry", "hostname": "docker.io"}
+	require.NoError(t, dag.addIdentity(id))
+	creds := &v1.DirectCredentials{Properties: map[string]string{"username": "foo"}}
+	now := time.Now()
+
+	dag.setCredentials(id.String(), creds, now.Add(time.Minute))
+	got, ok, expired := dag.getCredentials(id.String(), now)
+	assert.True(t, ok)
+	assert.False(t, expired)
+	assert.Same(t, creds, got)
+
+	got, ok, expired = dag.getCredentials(id.String(), now.Add(time.Minute))
+	assert.False(t, ok)
+	assert.True(t, expired)
+	assert.Nil(t, got)
+
+	dag.setCredentials(id.String(), creds, time.Time{})
+	_, ok, expired = dag.getCredentials(id.String(), now.Add(time.Hour))
+	assert.True(t, ok)
+	assert.False(t, expired)
+}
@@ -0,0 +1,112 @@
+package vault
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	credentialsv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/credentials/spec/vault/v1alpha1"
+)
+
+// cache holds the tokens of AppRole logins and the secrets read from repositories.
+// Its keys are hashes, so that the credentials they are derived from are not kept in the cache.
+type cache struct {
+	mu           sync.Mutex
+	tokens       map[string]cacheEntry[string]
+	repositories map[string]cacheEntry[[]secret]
+}
+
+// cacheEntry is a cached value that is valid until expiresAt. A zero expiresAt never expires.
+type cacheEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+func (e cacheEntry[T]) valid(now time.Time) bool {
+	return e.expiresAt.IsZero() || now.Before(e.expiresAt)
+}
+
+func (c *cache) token(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.tokens[key]
+	if !ok || !entry.valid(time.Now()) {
+		delete(c.tokens, key)
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (c *cache) putToken(key, token string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens == nil {
+		c.tokens = make(map[string]cacheEntry[string])
+	}
+	c.tokens[key] = cacheEntry[string]{value: token, expiresAt: expiresAt}
+}
+
+func (c *cache) dropToken(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.tokens, key)
+}
+
+func (c *cache) secrets(key string) ([]secret, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.repositories[key]
+	if !ok || !entry.valid(time.Now()) {
+		delete(c.repositories, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *cache) putSecrets(key string, secrets []secret, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.repositories == nil {
+		c.repositories = make(map[string]cacheEntry[[]secret])
+	}
+	c.repositories[key] = cacheEntry[[]secret]{value: secrets, expiresAt: expiresAt}
+}
+
+// tokenExpiry returns the time after which the token of an AppRole login with the lease duration in seconds
+// is no longer reused. Tokens are renewed after 90% of their lease, tokens without lease never expire.
+func tokenExpiry(now time.Time, leaseDuration int64) time.Time {
+	if leaseDuration <= 0 {
+		return time.Time{}
+	}
+	return now.Add(time.Duration(leaseDuration) * time.Second * 9 / 10)
+}
+
+// tokenKey returns the cache key of the token of an AppRole login against the server of the repository.
+func tokenKey(repository *v1alpha1.Repository, roleID, secretID string) string {
+	return hash(repository.ServerURL, repository.Namespace, roleID, secretID)
+}
+
+// secretsKey returns the cache key of the secrets of the repository read with the credentials.
+func secretsKey(repository *v1alpha1.Repository, credentials *credentialsv1.DirectCredentials) (string, error) {
+	data, err := json.Marshal(struct {
+		Repository  *v1alpha1.Repository `json:"repository"`
+		Credentials map[string]string    `json:"credentials"`
+	}{repository, credentials.Properties})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute cache key of vault repository: %w", err)
+	}
+	return hash(string(data)), nil
+}
+
+func hash(values ...string) string {
+	h := sha256.New()
+	for _, value := range values {
+		// the length prefix keeps the boundaries of the values unambiguous.
+		_, _ = fmt.Fprintf(h, "%d:%s", len(value), value)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
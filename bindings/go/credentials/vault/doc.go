@@ -0,0 +1,43 @@
+// Package vault provides a credential repository plugin that resolves credentials from the
+// KV v2 secrets engine of a HashiCorp Vault server.
+//
+// The repository is configured with a [v1alpha1.Repository]:
+//
+//	type: credentials.config.ocm.software
+//	repositories:
+//	- repository:
+//	    type: HashiCorpVault/v1alpha1
+//	    serverURL: "https://myvault.example.com/"
+//	    mountPath: "my-engine"
+//	    path: "ocm/registries"
+//	consumers:
+//	- identity:
+//	    type: HashiCorpVault/v1alpha1
+//	    hostname: myvault.example.com
+//	  credentials:
+//	  - type: Credentials/v1
+//	    properties:
+//	      role_id: "repository.vault.com-role"
+//	      secret_id: "repository.vault.com-secret"
+//
+// The credentials for the Vault server itself are resolved from the credential graph with the consumer
+// identity returned by [CredentialRepository.ConsumerIdentityForConfig]. They offer either a token
+// or the role_id and secret_id of an AppRole.
+//
+// Each secret maps to a consumer identity with its consumerId property, a JSON encoded identity such as
+//
+//	{"type":"OCIRegistry","hostname":"quay.io"}
+//
+// The remaining properties of the first secret matching a requested identity are returned as
+// [ocm.software/open-component-model/bindings/go/credentials/spec/config/v1.DirectCredentials].
+//
+// The secrets read from a repository are cached for [CredentialRepository.CacheTTL], so that resolving
+// the credentials of further consumers does not read them again. Tokens of AppRole logins are reused
+// until 90% of their lease passed, a token revoked before that is replaced by logging in again.
+//
+// The type of the identity has to be the one requested by the consumer, e.g. OCIRegistry for OCI registries.
+//
+// The OCM CLI and the controller register the plugin as built-in credential repository for OCI registries, next to
+// the DockerConfig repository. Other hosts register it for the consumer identity types it should serve, e.g. with
+// RegisterInternalCredentialRepositoryPlugin of the credential repository registry of the plugin manager.
+package vault
@@ -0,0 +1,422 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	credentialsv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/credentials/spec/vault/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// CredentialKeyToken is the property of the Vault credentials holding a Vault token.
+	//nolint:gosec // not a credential
+	CredentialKeyToken = "token"
+	// CredentialKeyRoleID is the property of the Vault credentials holding the role_id of an AppRole.
+	CredentialKeyRoleID = "role_id"
+	// CredentialKeySecretID is the property of the Vault credentials holding the secret_id of an AppRole.
+	//nolint:gosec // not a credential
+	CredentialKeySecretID = "secret_id"
+
+	// SecretKeyConsumerID is the property of a secret holding the JSON encoded consumer identity
+	// the secret offers credentials for.
+	SecretKeyConsumerID = "consumerId"
+)
+
+// ErrMissingCredentials is returned if no credentials to access the Vault server are available.
+var ErrMissingCredentials = errors.New("credentials for the vault server are required")
+
+// Scheme contains the HashiCorp Vault credential repository configuration.
+var Scheme = runtime.NewScheme()
+
+func init() {
+	v1alpha1.MustAddToScheme(Scheme)
+}
+
+// DefaultCacheTTL is the time the secrets read from a Vault server are reused by default.
+const DefaultCacheTTL = 5 * time.Minute
+
+// CredentialRepository implements the RepositoryPlugin Credential Graph interface for HashiCorp Vault.
+// It resolves credentials from the secrets of a KV v2 secrets engine, see v1alpha1.Repository.
+//
+// The secrets read from a repository are cached, so that the resolution of further identities
+// does not read them again, and so are the tokens of AppRole logins until their lease ends.
+type CredentialRepository struct {
+	// Client is used for requests to the Vault server. If nil, http.DefaultClient is used.
+	Client *http.Client
+	// CacheTTL is the time the secrets read from a repository are reused.
+	// If zero, DefaultCacheTTL is used. If negative, secrets are read for every resolution.
+	CacheTTL time.Duration
+
+	cache cache
+}
+
+var _ credentials.RepositoryPlugin = (*CredentialRepository)(nil)
+
+func (r *CredentialRepository) GetCredentialRepositoryScheme() *runtime.Scheme {
+	return Scheme
+}
+
+// ConsumerIdentityForConfig returns the identity of the Vault server of the configuration.
+// The credentials of this identity are used to authenticate against the server.
+func (r *CredentialRepository) ConsumerIdentityForConfig(_ context.Context, cfg runtime.Typed) (runtime.Identity, error) {
+	repository, err := r.convert(cfg)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := runtime.ParseURLToIdentity(repository.ServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse vault server url %q: %w", repository.ServerURL, err)
+	}
+	delete(identity, runtime.IdentityAttributePath)
+	identity.SetType(v1alpha1.ConsumerIdentityVersionedType)
+	return identity, nil
+}
+
+// Resolve returns the credentials of the first secret of the repository whose consumerId matches the identity.
+// It returns nil if no secret matches. credentials are the credentials to access the Vault server.
+func (r *CredentialRepository) Resolve(ctx context.Context, cfg runtime.Typed, identity runtime.Identity, credentials runtime.Typed) (runtime.Typed, error) {
+	repository, err := r.convert(cfg)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := r.secrets(ctx, repository, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range secrets {
+		consumer, err := consumerIdentity(secret.data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid consumer identity in vault secret %q: %w", secret.name, err)
+		}
+		if consumer == nil {
+			slog.DebugContext(ctx, "ignoring vault secret without consumer identity", "secret", secret.name)
+			continue
+		}
+		if !identity.Match(consumer) {
+			continue
+		}
+		properties, err := secretProperties(secret.data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vault secret %q: %w", secret.name, err)
+		}
+		return &credentialsv1.DirectCredentials{
+			Type:       runtime.NewVersionedType(credentialsv1.CredentialsType, credentialsv1.Version),
+			Properties: properties,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (r *CredentialRepository) convert(cfg runtime.Typed) (*v1alpha1.Repository, error) {
+	repository := &v1alpha1.Repository{}
+	if err := Scheme.Convert(cfg, repository); err != nil {
+		return nil, fmt.Errorf("config could not be interpreted as vault repository: %w", err)
+	}
+	if repository.ServerURL == "" {
+		return nil, fmt.Errorf("vault repository requires a server url")
+	}
+	return repository, nil
+}
+
+// secrets returns the secrets of the repository. Secrets read with the same credentials within the
+// cache TTL are reused.
+func (r *CredentialRepository) secrets(ctx context.Context, repository *v1alpha1.Repository, credentials runtime.Typed) ([]secret, error) {
+	direct, err := directCredentials(credentials)
+	if err != nil {
+		return nil, err
+	}
+	if repository.MountPath == "" {
+		repository.MountPath = v1alpha1.DefaultMountPath
+	}
+	key, err := secretsKey(repository, direct)
+	if err != nil {
+		return nil, err
+	}
+	if secrets, ok := r.cache.secrets(key); ok {
+		return secrets, nil
+	}
+
+	c, err := r.login(ctx, repository, direct)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := c.readAll(ctx)
+	if errors.Is(err, errPermissionDenied) && c.cachedToken {
+		// the cached AppRole token was revoked before its lease ended, log in again.
+		r.cache.dropToken(c.tokenKey)
+		if c, err = r.login(ctx, repository, direct); err != nil {
+			return nil, err
+		}
+		secrets, err = c.readAll(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl := r.cacheTTL(); ttl > 0 {
+		r.cache.putSecrets(key, secrets, time.Now().Add(ttl))
+	}
+	return secrets, nil
+}
+
+func (r *CredentialRepository) cacheTTL() time.Duration {
+	if r.CacheTTL == 0 {
+		return DefaultCacheTTL
+	}
+	return r.CacheTTL
+}
+
+func directCredentials(credentials runtime.Typed) (*credentialsv1.DirectCredentials, error) {
+	if credentials == nil {
+		return nil, ErrMissingCredentials
+	}
+	direct, ok := credentials.(*credentialsv1.DirectCredentials)
+	if !ok {
+		return nil, fmt.Errorf("unsupported credentials of type %s for vault server", credentials.GetType())
+	}
+	return direct, nil
+}
+
+// login authenticates against the Vault server of the repository with a token or an AppRole.
+// The tokens of AppRole logins are reused until their lease ends.
+func (r *CredentialRepository) login(ctx context.Context, repository *v1alpha1.Repository, credentials *credentialsv1.DirectCredentials) (*client, error) {
+	httpClient := r.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	c := &client{http: httpClient, repository: repository}
+
+	if token := credentials.Properties[CredentialKeyToken]; token != "" {
+		c.token = token
+		return c, nil
+	}
+	roleID, secretID := credentials.Properties[CredentialKeyRoleID], credentials.Properties[CredentialKeySecretID]
+	if roleID == "" || secretID == "" {
+		return nil, fmt.Errorf("%w: expected %q or %q and %q", ErrMissingCredentials, CredentialKeyToken, CredentialKeyRoleID, CredentialKeySecretID)
+	}
+
+	c.tokenKey = tokenKey(repository, roleID, secretID)
+	if token, ok := r.cache.token(c.tokenKey); ok {
+		c.token, c.cachedToken = token, true
+		return c, nil
+	}
+
+	var response struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := c.do(ctx, http.MethodPost, []string{"auth", "approle", "login"}, map[string]string{
+		CredentialKeyRoleID:   roleID,
+		CredentialKeySecretID: secretID,
+	}, &response); err != nil {
+		return nil, fmt.Errorf("failed to log in to vault with approle: %w", err)
+	}
+	if response.Auth.ClientToken == "" {
+		return nil, fmt.Errorf("failed to log in to vault with approle: no token returned")
+	}
+	c.token = response.Auth.ClientToken
+	r.cache.putToken(c.tokenKey, c.token, tokenExpiry(time.Now(), response.Auth.LeaseDuration))
+	return c, nil
+}
+
+// client talks to the KV v2 secrets engine of a Vault server with an authenticated token.
+type client struct {
+	http       *http.Client
+	repository *v1alpha1.Repository
+	token      string
+
+	// tokenKey is the cache key of the token of an AppRole login,
+	// cachedToken reports whether the token was taken from the cache.
+	tokenKey    string
+	cachedToken bool
+}
+
+// secret is a secret of the repository with its name.
+type secret struct {
+	name string
+	data map[string]any
+}
+
+// readAll reads the secrets of the repository, either the configured ones or all secrets directly below its path.
+func (c *client) readAll(ctx context.Context) ([]secret, error) {
+	names := c.repository.Secrets
+	if len(names) == 0 {
+		var err error
+		if names, err = c.list(ctx); err != nil {
+			return nil, err
+		}
+	}
+	secrets := make([]secret, 0, len(names))
+	for _, name := range names {
+		data, err := c.read(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret{name: name, data: data})
+	}
+	return secrets, nil
+}
+
+// list returns the names of the secrets directly below the path of the repository.
+func (c *client) list(ctx context.Context) ([]string, error) {
+	var response struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	err := c.do(ctx, methodList, []string{c.repository.MountPath, "metadata", c.repository.Path}, nil, &response)
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vault secrets: %w", err)
+	}
+
+	names := make([]string, 0, len(response.Data.Keys))
+	for _, key := range response.Data.Keys {
+		// keys ending with a slash are folders.
+		if !strings.HasSuffix(key, "/") {
+			names = append(names, key)
+		}
+	}
+	return names, nil
+}
+
+// read returns the data of the latest version of a secret below the path of the repository.
+func (c *client) read(ctx context.Context, name string) (map[string]any, error) {
+	var response struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, []string{c.repository.MountPath, "data", c.repository.Path, name}, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %q: %w", name, err)
+	}
+	return response.Data.Data, nil
+}
+
+var (
+	errNotFound         = errors.New("not found")
+	errPermissionDenied = errors.New("permission denied")
+)
+
+// methodList is the http method Vault uses to list keys.
+const methodList = "LIST"
+
+func (c *client) do(ctx context.Context, method string, path []string, body any, into any) error {
+	elems := []string{"v1"}
+	for _, p := range path {
+		if p = strings.Trim(p, "/"); p != "" {
+			elems = append(elems, p)
+		}
+	}
+	u, err := url.JoinPath(c.repository.ServerURL, elems...)
+	if err != nil {
+		return fmt.Errorf("invalid vault url: %w", err)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+	if c.repository.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.repository.Namespace)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		var response struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&response)
+		err := fmt.Errorf("vault responded with status %d: %s", resp.StatusCode, strings.Join(response.Errors, ", "))
+		if resp.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("%w: %w", errPermissionDenied, err)
+		}
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return nil
+}
+
+// consumerIdentity returns the consumer identity of a secret, which is either
+// a JSON encoded identity or a JSON object.
+func consumerIdentity(secret map[string]any) (runtime.Identity, error) {
+	value, ok := secret[SecretKeyConsumerID]
+	if !ok {
+		return nil, nil
+	}
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	var identity runtime.Identity
+	if err := json.Unmarshal(data, &identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// secretProperties returns all properties of a secret except its consumer identity.
+// Values that are not strings are JSON encoded.
+func secretProperties(secret map[string]any) (map[string]string, error) {
+	properties := make(map[string]string, len(secret))
+	for key, value := range secret {
+		if key == SecretKeyConsumerID {
+			continue
+		}
+		if s, ok := value.(string); ok {
+			properties[key] = s
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode property %q: %w", key, err)
+		}
+		properties[key] = string(data)
+	}
+	return properties, nil
+}
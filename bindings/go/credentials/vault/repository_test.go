@@ -0,0 +1,212 @@
+package vault_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	credentialsv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/credentials/spec/vault/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/credentials/vault"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// fakeVault serves the AppRole login and the KV v2 endpoints of a Vault server for a single mount.
+type fakeVault struct {
+	*httptest.Server
+	// logins and reads count the AppRole logins and the reads of secrets.
+	logins, reads atomic.Int32
+	// token is the only token accepted by the server, it is issued by AppRole logins.
+	token atomic.Pointer[string]
+}
+
+func newFakeVault(t *testing.T, secrets map[string]map[string]any) *fakeVault {
+	fake := &fakeVault{}
+	fake.revoke("client-token")
+	fake.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		write := func(status int, body any) {
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(body)
+		}
+		token := *fake.token.Load()
+		if req.URL.Path == "/v1/auth/approle/login" {
+			var login map[string]string
+			if err := json.NewDecoder(req.Body).Decode(&login); err != nil || login["role_id"] != "role" || login["secret_id"] != "secret" {
+				write(http.StatusBadRequest, map[string]any{"errors": []string{"invalid role or secret id"}})
+				return
+			}
+			fake.logins.Add(1)
+			write(http.StatusOK, map[string]any{"auth": map[string]any{"client_token": token, "lease_duration": 3600}})
+			return
+		}
+		if req.Header.Get("X-Vault-Token") != token {
+			write(http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		switch {
+		case req.Method == "LIST" && req.URL.Path == "/v1/engine/metadata/ocm":
+			keys := []string{"folder/"}
+			for name := range secrets {
+				keys = append(keys, name)
+			}
+			write(http.StatusOK, map[string]any{"data": map[string]any{"keys": keys}})
+		case req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, "/v1/engine/data/ocm/"):
+			fake.reads.Add(1)
+			secret, ok := secrets[strings.TrimPrefix(req.URL.Path, "/v1/engine/data/ocm/")]
+			if !ok {
+				write(http.StatusNotFound, map[string]any{"errors": []string{}})
+				return
+			}
+			write(http.StatusOK, map[string]any{"data": map[string]any{"data": secret}})
+		default:
+			write(http.StatusNotFound, map[string]any{"errors": []string{}})
+		}
+	}))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+// revoke makes the server accept only the given token from now on.
+func (f *fakeVault) revoke(token string) {
+	f.token.Store(&token)
+}
+
+func TestCredentialRepository(t *testing.T) {
+	srv := newFakeVault(t, map[string]map[string]any{
+		"quay": {
+			"consumerId": `{"type":"OCIRegistry/v1","hostname":"quay.io"}`,
+			"username":   "quay-user",
+			"password":   "quay-password",
+		},
+		"docker": {
+			"consumerId": map[string]any{"type": "OCIRegistry/v1", "hostname": "docker.io"},
+			"username":   "docker-user",
+		},
+		"unmapped": {
+			"username": "other",
+		},
+	})
+	repo := &vault.CredentialRepository{}
+	config := &v1alpha1.Repository{
+		Type:      v1alpha1.RepositoryVersionedType,
+		ServerURL: srv.URL,
+		MountPath: "engine",
+		Path:      "ocm",
+	}
+	appRole := &credentialsv1.DirectCredentials{Properties: map[string]string{
+		vault.CredentialKeyRoleID:   "role",
+		vault.CredentialKeySecretID: "secret",
+	}}
+	quay := runtime.Identity{"type": "OCIRegistry/v1", "hostname": "quay.io"}
+
+	t.Run("consumer identity", func(t *testing.T) {
+		r := require.New(t)
+		identity, err := repo.ConsumerIdentityForConfig(t.Context(), config)
+		r.NoError(err)
+		r.Equal(v1alpha1.ConsumerIdentityVersionedType, identity.GetType())
+		r.Equal("127.0.0.1", identity[runtime.IdentityAttributeHostname])
+	})
+
+	t.Run("approle", func(t *testing.T) {
+		r := require.New(t)
+		resolved, err := repo.Resolve(t.Context(), config, quay, appRole)
+		r.NoError(err)
+		r.Equal(map[string]string{"username": "quay-user", "password": "quay-password"}, resolved.(*credentialsv1.DirectCredentials).Properties)
+	})
+
+	t.Run("token with selected secrets", func(t *testing.T) {
+		r := require.New(t)
+		config := config.DeepCopy()
+		config.Secrets = []string{"docker"}
+		token := &credentialsv1.DirectCredentials{Properties: map[string]string{vault.CredentialKeyToken: "client-token"}}
+
+		resolved, err := repo.Resolve(t.Context(), config, runtime.Identity{"type": "OCIRegistry/v1", "hostname": "docker.io"}, token)
+		r.NoError(err)
+		r.Equal(map[string]string{"username": "docker-user"}, resolved.(*credentialsv1.DirectCredentials).Properties)
+
+		resolved, err = repo.Resolve(t.Context(), config, quay, token)
+		r.NoError(err)
+		r.Nil(resolved)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		r := require.New(t)
+		_, err := repo.Resolve(t.Context(), config, quay, nil)
+		r.ErrorIs(err, vault.ErrMissingCredentials)
+
+		_, err = repo.Resolve(t.Context(), config, quay, &credentialsv1.DirectCredentials{Properties: map[string]string{
+			vault.CredentialKeyRoleID:   "role",
+			vault.CredentialKeySecretID: "wrong",
+		}})
+		r.ErrorContains(err, "invalid role or secret id")
+	})
+}
+
+func TestCredentialRepository_Cache(t *testing.T) {
+	secrets := map[string]map[string]any{
+		"quay": {
+			"consumerId": `{"type":"OCIRegistry/v1","hostname":"quay.io"}`,
+			"username":   "quay-user",
+		},
+		"docker": {
+			"consumerId": `{"type":"OCIRegistry/v1","hostname":"docker.io"}`,
+			"username":   "docker-user",
+		},
+	}
+	appRole := &credentialsv1.DirectCredentials{Properties: map[string]string{
+		vault.CredentialKeyRoleID:   "role",
+		vault.CredentialKeySecretID: "secret",
+	}}
+	quay := runtime.Identity{"type": "OCIRegistry/v1", "hostname": "quay.io"}
+	docker := runtime.Identity{"type": "OCIRegistry/v1", "hostname": "docker.io"}
+	configFor := func(srv *fakeVault) *v1alpha1.Repository {
+		return &v1alpha1.Repository{
+			Type:      v1alpha1.RepositoryVersionedType,
+			ServerURL: srv.URL,
+			MountPath: "engine",
+			Path:      "ocm",
+		}
+	}
+
+	t.Run("secrets and approle token are reused", func(t *testing.T) {
+		r := require.New(t)
+		srv := newFakeVault(t, secrets)
+		repo := &vault.CredentialRepository{}
+		config := configFor(srv)
+
+		for _, identity := range []runtime.Identity{quay, docker, quay} {
+			resolved, err := repo.Resolve(t.Context(), config, identity, appRole)
+			r.NoError(err)
+			r.NotNil(resolved)
+		}
+		r.EqualValues(1, srv.logins.Load())
+		r.EqualValues(2, srv.reads.Load(), "every secret is read once")
+
+		config.Secrets = []string{"quay"}
+		_, err := repo.Resolve(t.Context(), config, quay, appRole)
+		r.NoError(err)
+		r.EqualValues(1, srv.logins.Load(), "the token is reused for other repositories of the server")
+		r.EqualValues(3, srv.reads.Load(), "the secrets of other repositories are read")
+	})
+
+	t.Run("revoked approle token logs in again", func(t *testing.T) {
+		r := require.New(t)
+		srv := newFakeVault(t, secrets)
+		repo := &vault.CredentialRepository{CacheTTL: -1}
+		config := configFor(srv)
+
+		_, err := repo.Resolve(t.Context(), config, quay, appRole)
+		r.NoError(err)
+		srv.revoke("another-token")
+		resolved, err := repo.Resolve(t.Context(), config, quay, appRole)
+		r.NoError(err)
+		r.Equal("quay-user", resolved.(*credentialsv1.DirectCredentials).Properties["username"])
+		r.EqualValues(2, srv.logins.Load())
+		r.EqualValues(4, srv.reads.Load(), "secrets are not cached with a negative TTL")
+	})
+}
@@ -1,10 +1,12 @@
 package ctf
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -12,6 +14,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
 	"ocm.software/open-component-model/bindings/go/ctf/index/v1"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // FileFormat represents the format of a CTF.
@@ -19,7 +22,7 @@ import (
 // The zero value of FileFormat is FormatDirectory, and also the default.
 type FileFormat int
 
-var ErrUnsupportedFormat = fmt.Errorf("unsupported format")
+var ErrUnsupportedFormat = ocmerrors.Unsupported(errors.New("unsupported format"))
 
 const (
 	// FormatUnknown represents an unknown format.
@@ -30,10 +33,12 @@ const (
 	FormatTAR FileFormat = iota
 	// FormatTGZ represents a CTF stored as a Tape (TAR) archive compressed with GZip with arbitrary compression.
 	FormatTGZ FileFormat = iota
+	// FormatZIP represents a CTF stored as a ZIP archive.
+	FormatZIP FileFormat = iota
 )
 
 // formats is a list of all supported formats corresponding to the FileFormat constants.
-var formats = [4]string{"unknown", "directory", "tar", "tgz"}
+var formats = [5]string{"unknown", "directory", "tar", "tgz", "zip"}
 
 func (f FileFormat) String() string {
 	return formats[f]
@@ -107,7 +112,7 @@ type ReadOnlyBlobStore interface {
 }
 
 // OpenCTF opens a CTF using the provided options.
-// The CTF may be backed by a temporary directory if the format is FormatTAR or FormatTGZ.
+// The CTF may be backed by a temporary directory if the format is FormatTAR, FormatTGZ or FormatZIP.
 // In this case, the temporary directory is used to extract the archive before returning access on that path.
 func OpenCTF(ctx context.Context, opts OpenCTFOptions) (CTF, error) {
 	switch opts.Format {
@@ -117,7 +122,7 @@ func OpenCTF(ctx context.Context, opts OpenCTFOptions) (CTF, error) {
 			return nil, fmt.Errorf("unable to open filesystem ctf: %w", err)
 		}
 		return ctf, nil
-	case FormatTAR, FormatTGZ:
+	case FormatTAR, FormatTGZ, FormatZIP:
 		hash := fnv.New32a()
 		if _, err := hash.Write([]byte(opts.Path)); err != nil {
 			return nil, fmt.Errorf("unable to hash path to determine temporary ctf: %w", err)
@@ -138,7 +143,12 @@ func OpenCTF(ctx context.Context, opts OpenCTFOptions) (CTF, error) {
 		}
 		slog.Debug("ctf is automatically extracted and will need to be rearchived to persist", slog.String("path", tmp))
 
-		ctf, err := ExtractTAR(ctx, tmp, opts.Path, opts.Format, opts.Flag)
+		var ctf *FileSystemCTF
+		if opts.Format == FormatZIP {
+			ctf, err = ExtractZIP(ctx, tmp, opts.Path, opts.Flag)
+		} else {
+			ctf, err = ExtractTAR(ctx, tmp, opts.Path, opts.Format, opts.Flag)
+		}
 		if errors.Is(err, os.ErrNotExist) && opts.Flag&O_CREATE != 0 {
 			return OpenCTFFromOSPath(tmp, opts.Flag)
 		}
@@ -162,6 +172,7 @@ func OpenCTFFromOSPath(path string, flag int) (*FileSystemCTF, error) {
 // For FormatDirectory, the path is treated as a directory, otherwise the path is interpreted as a file with
 // an extension that determines its behavior.
 // For more information on how a flag behaves for FormatTAR (and FormatTGZ), see ExtractTAR.
+// To detect the format from the content of the file, use Open.
 func OpenCTFByFileExtension(ctx context.Context, opts OpenCTFOptions) (CTF, FileFormat, error) {
 	discovered := DiscoverCTFFormatFromPath(opts.Path)
 
@@ -175,6 +186,8 @@ func OpenCTFByFileExtension(ctx context.Context, opts OpenCTFOptions) (CTF, File
 	return archive, discovered, nil
 }
 
+// DiscoverCTFFormatFromPath determines the format of a CTF from the file extension of the path.
+// Paths without a known archive extension are treated as FormatDirectory.
 func DiscoverCTFFormatFromPath(path string) FileFormat {
 	ext := filepath.Ext(path)
 	// check if the extension is in the form of ".tar.gz" in which case the extension is ".tar" and ".gz"
@@ -188,20 +201,99 @@ func DiscoverCTFFormatFromPath(path string) FileFormat {
 		discovered = FormatTGZ
 	case ".tar":
 		discovered = FormatTAR
+	case ".zip":
+		discovered = FormatZIP
 	default:
 		discovered = FormatDirectory
 	}
 	return discovered
 }
 
+// Open opens the CTF at the path of the options in any FileFormat.
+// If no format is set in the options, it is detected with DetectCTFFormat, so that
+// archives are recognized by their content even if their file extension does not match,
+// as is the case for archives produced by older versions of the OCM CLI.
+// It returns the format the CTF was opened with, which can be passed to Save to write it back.
+func Open(ctx context.Context, opts OpenCTFOptions) (CTF, FileFormat, error) {
+	if opts.Format == FormatUnknown {
+		detected, err := DetectCTFFormat(opts.Path)
+		if err != nil {
+			return nil, FormatUnknown, fmt.Errorf("failed to detect format of CTF %q: %w", opts.Path, err)
+		}
+		opts.Format = detected
+	}
+	archive, err := OpenCTF(ctx, opts)
+	if err != nil {
+		return nil, FormatUnknown, fmt.Errorf("failed to open CTF: %w", err)
+	}
+	return archive, opts.Format, nil
+}
+
+// Save writes the CTF to the path in the given format.
+// If the format is FormatUnknown, it is determined from the file extension of the path with DiscoverCTFFormatFromPath.
+// See Archive for details.
+func Save(ctx context.Context, ctf CTF, path string, format FileFormat) error {
+	if format == FormatUnknown {
+		format = DiscoverCTFFormatFromPath(path)
+	}
+	return Archive(ctx, ctf, path, format)
+}
+
+// DetectCTFFormat determines the format of the CTF at the path.
+// Directories are FormatDirectory, the format of files is detected from their leading bytes.
+// If the path does not exist (yet) or the content is not recognized, the format is determined
+// from the file extension with DiscoverCTFFormatFromPath.
+// Files that are neither recognized by content nor extension result in ErrUnsupportedFormat.
+func DetectCTFFormat(path string) (_ FileFormat, err error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DiscoverCTFFormatFromPath(path), nil
+	}
+	if err != nil {
+		return FormatUnknown, err
+	}
+	if info.IsDir() {
+		return FormatDirectory, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return FormatUnknown, err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return FormatUnknown, fmt.Errorf("unable to read header: %w", err)
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return FormatTGZ, nil
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")), bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return FormatZIP, nil
+	case len(header) >= 262 && bytes.Equal(header[257:262], []byte("ustar")):
+		return FormatTAR, nil
+	}
+
+	if discovered := DiscoverCTFFormatFromPath(path); discovered != FormatDirectory {
+		return discovered, nil
+	}
+	return FormatUnknown, fmt.Errorf("%w: %q is neither a directory nor a known archive", ErrUnsupportedFormat, path)
+}
+
 // WorkWithinCTF opens a CTF using the provided options and calls the work function with the CTF.
-// If the CTF is backed by a TAR or TGZ archive, the CTF is archived into its originally discovered
+// The format is detected with Open unless it is set in the options.
+// If the CTF is backed by a TAR, TGZ or ZIP archive, the CTF is archived into its originally discovered
 // format after the work function is called.
-// If an error occurs during the work function, the CTF is not archived if the format is FormatTAR or FormatTGZ
+// If an error occurs during the work function, the CTF is not archived if the format is an archive format.
 // However, if the format is FormatDirectory, the CTF is edited in place, which can lead to non-atomic failures.
 // To avoid this, by default (flag not set to O_RDWR), the CTF is not rearchived and opened in read-only mode.
 func WorkWithinCTF(ctx context.Context, opts OpenCTFOptions, work func(ctx context.Context, ctf CTF) error) error {
-	archive, format, err := OpenCTFByFileExtension(ctx, opts)
+	archive, format, err := Open(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("failed to open CTF %q: %w", opts.Path, err)
 	}
@@ -210,7 +302,7 @@ func WorkWithinCTF(ctx context.Context, opts OpenCTFOptions, work func(ctx conte
 		return fmt.Errorf("failed to work within CTF at %q: %w", opts.Path, err)
 	}
 
-	if opts.Flag&O_RDWR != 0 && (format == FormatTAR || format == FormatTGZ) {
+	if opts.Flag&O_RDWR != 0 && format != FormatDirectory {
 		slog.Debug(
 			"work within ctf has concluded and format and mode indicates it needs to be rearchived, this might take a while",
 			slog.String("path", opts.Path),
@@ -21,6 +21,7 @@ func Test_CTF_ReadWrite(t *testing.T) {
 		ctf.FormatDirectory,
 		ctf.FormatTAR,
 		ctf.FormatTGZ,
+		ctf.FormatZIP,
 	} {
 		t.Run(format.String(), func(t *testing.T) {
 			ctx := t.Context()
@@ -29,6 +30,7 @@ func Test_CTF_ReadWrite(t *testing.T) {
 				ctf.FormatDirectory: "",
 				ctf.FormatTAR:       ".tar",
 				ctf.FormatTGZ:       ".tar.gz",
+				ctf.FormatZIP:       ".zip",
 			}[format]
 			path := filepath.Join(t.TempDir(), name)
 
@@ -30,8 +30,9 @@
 //
 // The FileFormat of a CTF can differ: as directory of an
 // operating system file system or a virtual file system (FormatDirectory) or as content of
-// a TAR archive (unzipped - FormatTAR or zipped - FormatTGZ).
+// a TAR archive (unzipped - FormatTAR or zipped - FormatTGZ) or a ZIP archive (FormatZIP).
 // The descriptor SHOULD be the first file if stored in an archive.
+// Open and Save read and write a CTF in any of these formats, detecting the format if it is not given.
 //
 // This package also offers a legacy compatibility layer access for the ArtifactSet, a now no longer recommended
 // artifact format that was used in the past by OCM CLI to package local blobs. We now instead recommend packaging
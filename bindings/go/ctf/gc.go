@@ -0,0 +1,238 @@
+package ctf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/ctf/index/v1"
+)
+
+// maxManifestSize is the maximum size of a blob that is inspected for references during garbage collection.
+// Larger blobs are considered to be layers and are not parsed.
+const maxManifestSize = 4 * 1024 * 1024
+
+// GarbageCollectOptions contains options for GarbageCollect.
+type GarbageCollectOptions struct {
+	// Compact rewrites the index without dead entries before collecting blobs:
+	//   - entries that are duplicates of another entry for the same repository and digest
+	//   - untagged entries (e.g. left behind by a retag) that are neither referenced by a tagged entry,
+	//     for example as a child manifest of an index, nor refer to a kept subject.
+	// Without Compact, every index entry is kept and keeps its blobs alive.
+	Compact bool
+	// DryRun only reports what would be removed without modifying the CTF.
+	DryRun bool
+}
+
+// GarbageCollectResult describes the outcome of GarbageCollect.
+type GarbageCollectResult struct {
+	// RemovedBlobs are the digests of the blobs that were (or, on DryRun, would be) removed.
+	RemovedBlobs []string
+	// RemovedBytes is the total size of RemovedBlobs, as far as it is known.
+	RemovedBytes int64
+	// RemovedArtifacts are the index entries that were (or, on DryRun, would be) removed by Compact.
+	RemovedArtifacts []v1.ArtifactMetadata
+}
+
+// GarbageCollect removes all blobs from the CTF that are not referenced, directly or indirectly,
+// by an artifact in the index. Starting from the manifests in the index, it follows the config,
+// layers, child manifests and subject of every OCI manifest and index it finds.
+// Blobs that are missing from the CTF are ignored.
+//
+// GarbageCollect must not run concurrently with writes to the same CTF, as blobs
+// saved before the index is updated would be considered unreferenced.
+//
+// For CTFs in FormatTAR or FormatTGZ, run GarbageCollect within WorkWithinCTF with O_RDWR,
+// which rewrites the archive with the remaining blobs only:
+//
+//	err := ctf.WorkWithinCTF(ctx, ctf.OpenCTFOptions{Path: "archive.tgz", Flag: ctf.O_RDWR}, func(ctx context.Context, archive ctf.CTF) error {
+//		_, err := ctf.GarbageCollect(ctx, archive, ctf.GarbageCollectOptions{Compact: true})
+//		return err
+//	})
+func GarbageCollect(ctx context.Context, ctf CTF, opts GarbageCollectOptions) (*GarbageCollectResult, error) {
+	idx, err := ctf.GetIndex(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get index: %w", err)
+	}
+	artifacts := idx.GetArtifacts()
+
+	result := &GarbageCollectResult{}
+	reachable := map[string]struct{}{}
+
+	roots := artifacts
+	if opts.Compact {
+		roots = slices.DeleteFunc(slices.Clone(artifacts), func(a v1.ArtifactMetadata) bool {
+			return a.Tag == ""
+		})
+	}
+	for _, artifact := range roots {
+		if err := markReachable(ctx, ctf, artifact.Digest, reachable); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Compact {
+		// referrers point to their subject, but not the other way around, so untagged
+		// entries are kept alive by their subject until no more referrers are found.
+		for found := true; found; {
+			found = false
+			for _, artifact := range artifacts {
+				if _, ok := reachable[artifact.Digest]; ok || artifact.Tag != "" {
+					continue
+				}
+				refs, err := readReferences(ctx, ctf, artifact.Digest)
+				if err != nil {
+					return nil, err
+				}
+				if refs == nil || refs.Subject == nil {
+					continue
+				}
+				if _, ok := reachable[refs.Subject.Digest.String()]; !ok {
+					continue
+				}
+				if err := markReachable(ctx, ctf, artifact.Digest, reachable); err != nil {
+					return nil, err
+				}
+				found = true
+			}
+		}
+
+		compacted := v1.NewIndex()
+		seen := map[v1.ArtifactMetadata]struct{}{}
+		for _, artifact := range artifacts {
+			key := v1.ArtifactMetadata{Repository: artifact.Repository, Digest: artifact.Digest}
+			_, duplicate := seen[key]
+			_, referenced := reachable[artifact.Digest]
+			switch {
+			case artifact.Tag != "":
+				compacted.AddArtifact(artifact)
+			case !duplicate && !hasTaggedEntry(artifacts, key) && referenced:
+				compacted.AddArtifact(artifact)
+			default:
+				result.RemovedArtifacts = append(result.RemovedArtifacts, artifact)
+			}
+			seen[key] = struct{}{}
+		}
+		if len(result.RemovedArtifacts) > 0 && !opts.DryRun {
+			if err := ctf.SetIndex(ctx, compacted); err != nil {
+				return nil, fmt.Errorf("unable to write compacted index: %w", err)
+			}
+		}
+	}
+
+	digests, err := ctf.ListBlobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list blobs: %w", err)
+	}
+	slices.Sort(digests)
+	for _, dig := range digests {
+		if _, ok := reachable[dig]; ok {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if b, err := ctf.GetBlob(ctx, dig); err == nil {
+			if sizeAware, ok := b.(blob.SizeAware); ok && sizeAware.Size() != blob.SizeUnknown {
+				result.RemovedBytes += sizeAware.Size()
+			}
+		}
+		if !opts.DryRun {
+			if err := ctf.DeleteBlob(ctx, dig); err != nil {
+				return nil, fmt.Errorf("unable to delete unreferenced blob %s: %w", dig, err)
+			}
+		}
+		result.RemovedBlobs = append(result.RemovedBlobs, dig)
+	}
+
+	return result, nil
+}
+
+// hasTaggedEntry reports whether the artifacts contain a tagged entry for the repository and digest of key.
+func hasTaggedEntry(artifacts []v1.ArtifactMetadata, key v1.ArtifactMetadata) bool {
+	return slices.ContainsFunc(artifacts, func(a v1.ArtifactMetadata) bool {
+		return a.Tag != "" && a.Repository == key.Repository && a.Digest == key.Digest
+	})
+}
+
+// manifestReferences is the union of the fields of OCI image manifests and indexes that reference other blobs.
+type manifestReferences struct {
+	Config    *ociImageSpecV1.Descriptor  `json:"config,omitempty"`
+	Layers    []ociImageSpecV1.Descriptor `json:"layers,omitempty"`
+	Manifests []ociImageSpecV1.Descriptor `json:"manifests,omitempty"`
+	Subject   *ociImageSpecV1.Descriptor  `json:"subject,omitempty"`
+}
+
+// markReachable marks the blob with the given digest and all blobs it references as reachable.
+func markReachable(ctx context.Context, ctf CTF, dig string, reachable map[string]struct{}) error {
+	pending := []string{dig}
+	for len(pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		dig, pending = pending[len(pending)-1], pending[:len(pending)-1]
+		if _, ok := reachable[dig]; ok || dig == "" {
+			continue
+		}
+		reachable[dig] = struct{}{}
+
+		refs, err := readReferences(ctx, ctf, dig)
+		if err != nil {
+			return err
+		}
+		if refs == nil {
+			continue
+		}
+		if refs.Config != nil {
+			pending = append(pending, refs.Config.Digest.String())
+		}
+		for _, layer := range refs.Layers {
+			pending = append(pending, layer.Digest.String())
+		}
+		for _, manifest := range refs.Manifests {
+			pending = append(pending, manifest.Digest.String())
+		}
+		if refs.Subject != nil {
+			pending = append(pending, refs.Subject.Digest.String())
+		}
+	}
+	return nil
+}
+
+// readReferences parses the blob as OCI manifest or index.
+// It returns nil if the blob is missing, too large or not a JSON object.
+func readReferences(ctx context.Context, ctf CTF, dig string) (_ *manifestReferences, err error) {
+	b, err := ctf.GetBlob(ctx, dig)
+	if err != nil {
+		// a missing blob cannot reference anything
+		return nil, nil //nolint:nilerr // missing blobs are not the concern of garbage collection
+	}
+	if sizeAware, ok := b.(blob.SizeAware); ok && sizeAware.Size() > maxManifestSize {
+		return nil, nil
+	}
+	data, err := b.ReadCloser()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read blob %s: %w", dig, err)
+	}
+	defer func() {
+		err = errors.Join(err, data.Close())
+	}()
+	raw, err := io.ReadAll(io.LimitReader(data, maxManifestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read blob %s: %w", dig, err)
+	}
+	if len(raw) > maxManifestSize {
+		return nil, nil
+	}
+	var refs manifestReferences
+	if err := json.Unmarshal(raw, &refs); err != nil {
+		return nil, nil //nolint:nilerr // blobs that are not JSON objects do not reference other blobs
+	}
+	return &refs, nil
+}
@@ -0,0 +1,126 @@
+package ctf_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ociimagespecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/ctf"
+	v1 "ocm.software/open-component-model/bindings/go/ctf/index/v1"
+)
+
+func saveBlob(t *testing.T, archive ctf.CTF, data []byte) ociimagespecv1.Descriptor {
+	t.Helper()
+	require.NoError(t, archive.SaveBlob(t.Context(), inmemory.New(bytes.NewReader(data))))
+	return ociimagespecv1.Descriptor{Digest: digest.FromBytes(data), Size: int64(len(data))}
+}
+
+func saveManifest(t *testing.T, archive ctf.CTF, config ociimagespecv1.Descriptor, subject *ociimagespecv1.Descriptor, layers ...ociimagespecv1.Descriptor) ociimagespecv1.Descriptor {
+	t.Helper()
+	data, err := json.Marshal(ociimagespecv1.Manifest{
+		MediaType: ociimagespecv1.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    layers,
+		Subject:   subject,
+	})
+	require.NoError(t, err)
+	desc := saveBlob(t, archive, data)
+	desc.MediaType = ociimagespecv1.MediaTypeImageManifest
+	return desc
+}
+
+func Test_GarbageCollect(t *testing.T) {
+	setup := func(t *testing.T) (ctf.CTF, map[string]ociimagespecv1.Descriptor) {
+		t.Helper()
+		r := require.New(t)
+		archive, err := ctf.OpenCTFFromOSPath(t.TempDir(), ctf.O_RDWR|ctf.O_CREATE)
+		r.NoError(err)
+
+		config := saveBlob(t, archive, []byte("{}"))
+		descs := map[string]ociimagespecv1.Descriptor{
+			"config": config,
+			"v1":     saveBlob(t, archive, []byte("layer v1")),
+			"v2":     saveBlob(t, archive, []byte("layer v2")),
+			"orphan": saveBlob(t, archive, []byte("orphan")),
+		}
+		descs["manifest v1"] = saveManifest(t, archive, config, nil, descs["v1"])
+		descs["manifest v2"] = saveManifest(t, archive, config, nil, descs["v2"])
+		descs["signature"] = saveBlob(t, archive, []byte("signature"))
+		subject := descs["manifest v2"]
+		descs["referrer"] = saveManifest(t, archive, config, &subject, descs["signature"])
+
+		idx := v1.NewIndex()
+		idx.AddArtifact(v1.ArtifactMetadata{Repository: "app", Tag: "1.0.0", Digest: descs["manifest v1"].Digest.String()})
+		// retag 1.0.0, leaving an untagged entry for manifest v1
+		idx.AddArtifact(v1.ArtifactMetadata{Repository: "app", Tag: "1.0.0", Digest: descs["manifest v2"].Digest.String()})
+		idx.AddArtifact(v1.ArtifactMetadata{Repository: "app", Digest: descs["referrer"].Digest.String()})
+		r.NoError(archive.SetIndex(t.Context(), idx))
+		return archive, descs
+	}
+
+	listBlobs := func(t *testing.T, archive ctf.CTF) []string {
+		t.Helper()
+		blobs, err := archive.ListBlobs(t.Context())
+		require.NoError(t, err)
+		return blobs
+	}
+
+	t.Run("removes unreferenced blobs", func(t *testing.T) {
+		r := require.New(t)
+		archive, descs := setup(t)
+
+		result, err := ctf.GarbageCollect(t.Context(), archive, ctf.GarbageCollectOptions{})
+		r.NoError(err)
+		r.Equal([]string{descs["orphan"].Digest.String()}, result.RemovedBlobs)
+		r.Equal(descs["orphan"].Size, result.RemovedBytes)
+		r.Empty(result.RemovedArtifacts)
+		r.Len(listBlobs(t, archive), 7)
+	})
+
+	t.Run("compacts the index", func(t *testing.T) {
+		r := require.New(t)
+		archive, descs := setup(t)
+
+		result, err := ctf.GarbageCollect(t.Context(), archive, ctf.GarbageCollectOptions{Compact: true})
+		r.NoError(err)
+		r.ElementsMatch([]string{
+			descs["orphan"].Digest.String(),
+			descs["v1"].Digest.String(),
+			descs["manifest v1"].Digest.String(),
+		}, result.RemovedBlobs)
+		r.Equal([]v1.ArtifactMetadata{{Repository: "app", Digest: descs["manifest v1"].Digest.String()}}, result.RemovedArtifacts)
+
+		idx, err := archive.GetIndex(t.Context())
+		r.NoError(err)
+		r.Equal([]v1.ArtifactMetadata{
+			{Repository: "app", Tag: "1.0.0", Digest: descs["manifest v2"].Digest.String()},
+			{Repository: "app", Digest: descs["referrer"].Digest.String()},
+		}, idx.GetArtifacts(), "referrers of tagged artifacts are kept")
+		r.ElementsMatch([]string{
+			descs["config"].Digest.String(),
+			descs["v2"].Digest.String(),
+			descs["manifest v2"].Digest.String(),
+			descs["signature"].Digest.String(),
+			descs["referrer"].Digest.String(),
+		}, listBlobs(t, archive))
+	})
+
+	t.Run("dry run does not modify the ctf", func(t *testing.T) {
+		r := require.New(t)
+		archive, _ := setup(t)
+
+		result, err := ctf.GarbageCollect(t.Context(), archive, ctf.GarbageCollectOptions{Compact: true, DryRun: true})
+		r.NoError(err)
+		r.Len(result.RemovedBlobs, 3)
+		r.Len(result.RemovedArtifacts, 1)
+		r.Len(listBlobs(t, archive), 8)
+		idx, err := archive.GetIndex(t.Context())
+		r.NoError(err)
+		r.Len(idx.GetArtifacts(), 3)
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"slices"
 	"sync"
+
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 const (
@@ -16,7 +18,7 @@ const (
 
 var (
 	ErrSchemaVersionMismatch = fmt.Errorf("schema version mismatch, only %v is supported", SchemaVersion)
-	ErrArtifactNotFound      = errors.New("artifact not found in index")
+	ErrArtifactNotFound      = ocmerrors.NotFound(errors.New("artifact not found in index"))
 )
 
 // Index is a collection of artifacts that can be serialized to disk.
@@ -40,6 +42,10 @@ type Index interface {
 	// No blobs are deleted: the manifest and all blobs it references (layers, config)
 	// remain in the CTF until an explicit GC pass compacts the archive.
 	RemoveTag(repository, tag string) error
+	// RemoveArtifact removes all index entries with the given digest from the given repository,
+	// tagged or not. Returns ErrArtifactNotFound if no matching entry exists.
+	// Like RemoveTag, no blobs are deleted.
+	RemoveArtifact(repository, digest string) error
 }
 
 type index struct {
@@ -154,3 +160,16 @@ func (i *index) RemoveTag(repository, tag string) error {
 	i.Artifacts = slices.Delete(i.Artifacts, idx, idx+1)
 	return nil
 }
+
+func (i *index) RemoveArtifact(repository, digest string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	remaining := slices.DeleteFunc(slices.Clone(i.Artifacts), func(a ArtifactMetadata) bool {
+		return a.Repository == repository && a.Digest == digest
+	})
+	if len(remaining) == len(i.Artifacts) {
+		return ErrArtifactNotFound
+	}
+	i.Artifacts = remaining
+	return nil
+}
@@ -341,3 +341,29 @@ func TestRemoveTag_OnlyMatchingTag(t *testing.T) {
 		}
 	}
 }
+
+func TestRemoveArtifact(t *testing.T) {
+	idx := NewIndex()
+	idx.AddArtifact(ArtifactMetadata{Repository: "repo1", Tag: "v1", Digest: "sha256:abc"})
+	idx.AddArtifact(ArtifactMetadata{Repository: "repo1", Tag: "latest", Digest: "sha256:abc"})
+	idx.AddArtifact(ArtifactMetadata{Repository: "repo1", Digest: "sha256:abc"})
+	idx.AddArtifact(ArtifactMetadata{Repository: "repo1", Tag: "v2", Digest: "sha256:def"})
+	idx.AddArtifact(ArtifactMetadata{Repository: "repo2", Tag: "v1", Digest: "sha256:abc"})
+
+	if err := idx.RemoveArtifact("repo1", "sha256:abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	arts := idx.GetArtifacts()
+	if len(arts) != 2 {
+		t.Fatalf("expected 2 artifacts after removal, got %d", len(arts))
+	}
+	for _, a := range arts {
+		if a.Repository == "repo1" && a.Digest == "sha256:abc" {
+			t.Errorf("artifact %+v should have been removed", a)
+		}
+	}
+
+	if err := idx.RemoveArtifact("repo1", "sha256:abc"); !errors.Is(err, ErrArtifactNotFound) {
+		t.Errorf("expected ErrArtifactNotFound, got %v", err)
+	}
+}
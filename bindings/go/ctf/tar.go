@@ -28,7 +28,7 @@ import (
 // If the flag O_RDONLY is set, the extracted CTF will be read-only as well, however
 // the CTF will be first opened as O_RDWR to copy the data from the TAR into the new FileSystemCTF.
 func ExtractTAR(ctx context.Context, base, path string, format FileFormat, flag int) (extracted *FileSystemCTF, err error) {
-	if format == FormatDirectory {
+	if format != FormatTAR && format != FormatTGZ {
 		return nil, ErrUnsupportedFormat
 	}
 
@@ -109,7 +109,7 @@ func extractTARToFilesystemCTF(reader *tar.Reader, ctf *FileSystemCTF) (err erro
 
 // Archive creates an archive from the provided CTF and writes it to the specified path.
 // The format of the archive is determined by the format parameter.
-// Supported formats are FormatTAR, FormatTGZ, and FormatDirectory.
+// Supported formats are FormatTAR, FormatTGZ, FormatZIP, and FormatDirectory.
 // If the format is FormatDirectory, the filesystem is copied to the specified path.
 func Archive(ctx context.Context, ctf CTF, path string, format FileFormat) error {
 	switch format {
@@ -117,6 +117,8 @@ func Archive(ctx context.Context, ctf CTF, path string, format FileFormat) error
 		return ArchiveDirectory(ctx, ctf, path)
 	case FormatTAR, FormatTGZ:
 		return ArchiveTAR(ctx, ctf, path, format)
+	case FormatZIP:
+		return ArchiveZIP(ctx, ctf, path)
 	default:
 		return ErrUnsupportedFormat
 	}
@@ -197,7 +199,7 @@ func ArchiveTAR(ctx context.Context, ctf CTF, path string, format FileFormat) (e
 // The blobs are written in the order they are returned by ListBlobs.
 // The index is written to the index file as first entry.
 func ArchiveTARToWriter(ctx context.Context, ctf CTF, writer io.Writer, format FileFormat) (err error) {
-	if format == FormatDirectory {
+	if format != FormatTAR && format != FormatTGZ {
 		return ErrUnsupportedFormat
 	}
 
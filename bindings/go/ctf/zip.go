@@ -0,0 +1,179 @@
+package ctf
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/ctf/index/v1"
+)
+
+// ExtractZIP extracts a CTF from a ZIP file at the given path and writes it to the given base directory.
+// The base directory must exist and will form the parent directory of the extracted CTF.
+// The extracted CTF is not modified and only read from after extraction,
+// and the ZIP itself is not modified.
+// If the flag O_RDONLY is set, the extracted CTF will be read-only as well, however
+// the CTF will be first opened as O_RDWR to copy the data from the ZIP into the new FileSystemCTF.
+func ExtractZIP(ctx context.Context, base, path string, flag int) (extracted *FileSystemCTF, err error) {
+	zipFile, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open zip file: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, zipFile.Close())
+	}()
+
+	// for the extracted version we will first open the CTF with O_RDWR
+	ctf, err := OpenCTFFromOSPath(base, O_RDWR)
+	if err != nil {
+		return nil, fmt.Errorf("unable to setup file system ctf: %w", err)
+	}
+
+	if err := extractZIPToFilesystemCTF(ctx, &zipFile.Reader, ctf); err != nil {
+		return nil, fmt.Errorf("unable to extract zip to filesystem ctf: %w", err)
+	}
+
+	// see ExtractTAR, the extracted CTF respects the original flag.
+	if flag&O_RDONLY != 0 || (flag&os.O_WRONLY == 0 && flag&os.O_RDWR == 0) {
+		if roFS, ok := ctf.FS().(filesystem.ReadOnlyFS); ok {
+			roFS.ForceReadOnly()
+		}
+	}
+
+	return ctf, nil
+}
+
+func extractZIPToFilesystemCTF(ctx context.Context, reader *zip.Reader, ctf *FileSystemCTF) error {
+	for _, file := range reader.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if strings.Contains(file.Name, "..") {
+			return fmt.Errorf("invalid zip entry, contains %q: %s", "..", file.Name)
+		}
+		if !file.Mode().IsRegular() {
+			// directories are created implicitly when writing files
+			continue
+		}
+		if err := extractZIPEntry(file, ctf); err != nil {
+			return fmt.Errorf("unable to write file: %w", err)
+		}
+	}
+	return nil
+}
+
+func extractZIPEntry(file *zip.File, ctf *FileSystemCTF) (err error) {
+	data, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, data.Close())
+	}()
+	return ctf.writeFile(file.Name, data, int64(file.UncompressedSize64))
+}
+
+// ArchiveZIP archives the CTF to the specified path in FormatZIP.
+// The CTF is not modified and only read from.
+// The file is created if it does not exist.
+//
+// see ArchiveZIPToWriter for more details.
+func ArchiveZIP(ctx context.Context, ctf CTF, path string) (err error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to open file for writing ctf archive: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	return ArchiveZIPToWriter(ctx, ctf, file)
+}
+
+// ArchiveZIPToWriter archives the CTF to the specified writer in FormatZIP.
+//
+// The index is written to the index file as first entry and compressed with deflate.
+// The blobs are written to the blobs directory sequentially in the order they are returned by ListBlobs.
+// They are stored without compression, as blobs of OCI artifacts are usually compressed already.
+func ArchiveZIPToWriter(ctx context.Context, ctf CTF, writer io.Writer) (err error) {
+	zipWriter := zip.NewWriter(writer)
+	defer func() {
+		err = errors.Join(err, zipWriter.Close())
+	}()
+
+	idx, err := ctf.GetIndex(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get index: %w", err)
+	}
+	rawIdx, err := v1.Encode(idx)
+	if err != nil {
+		return fmt.Errorf("unable to encode index: %w", err)
+	}
+	idxWriter, err := zipWriter.CreateHeader(zipHeader(v1.ArtifactIndexFileName, zip.Deflate))
+	if err != nil {
+		return fmt.Errorf("unable to write index header: %w", err)
+	}
+	if _, err := idxWriter.Write(rawIdx); err != nil {
+		return fmt.Errorf("unable to write index: %w", err)
+	}
+
+	blobs, err := ctf.ListBlobs(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list blobs: %w", err)
+	}
+
+	copyBuffer := make([]byte, blob.DefaultArchiveBlobBufferSize) // shared buffer for all data to avoid allocs.
+
+	for _, digest := range blobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		file, err := ToBlobFileName(digest)
+		if err != nil {
+			return err
+		}
+		if err := archiveZIPBlob(ctx, ctf, zipWriter, filepath.Join(BlobsDirectoryName, file), digest, copyBuffer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func archiveZIPBlob(ctx context.Context, ctf CTF, zipWriter *zip.Writer, name, digest string, buf []byte) (err error) {
+	b, err := ctf.GetBlob(ctx, digest)
+	if err != nil {
+		return fmt.Errorf("unable to get blob %s: %w", digest, err)
+	}
+	entry, err := zipWriter.CreateHeader(zipHeader(filepath.ToSlash(name), zip.Store))
+	if err != nil {
+		return fmt.Errorf("unable to write blob header: %w", err)
+	}
+	data, err := b.ReadCloser()
+	if err != nil {
+		return fmt.Errorf("unable to read blob %s: %w", digest, err)
+	}
+	defer func() {
+		err = errors.Join(err, data.Close())
+	}()
+	if _, err := io.CopyBuffer(entry, data, buf); err != nil {
+		return fmt.Errorf("unable to write blob: %w", err)
+	}
+	return nil
+}
+
+func zipHeader(name string, method uint16) *zip.FileHeader {
+	header := &zip.FileHeader{
+		Name:   name,
+		Method: method,
+	}
+	header.SetMode(0o644)
+	return header
+}
@@ -0,0 +1,82 @@
+package ctf_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/ctf"
+	v1 "ocm.software/open-component-model/bindings/go/ctf/index/v1"
+)
+
+func Test_OpenAndSave(t *testing.T) {
+	ctx := t.Context()
+	r := require.New(t)
+
+	source, err := ctf.OpenCTFFromOSPath(t.TempDir(), ctf.O_RDWR|ctf.O_CREATE)
+	r.NoError(err)
+	testBlob := inmemory.New(bytes.NewReader([]byte("test")))
+	digest, _ := testBlob.Digest()
+	r.NoError(source.SaveBlob(ctx, testBlob))
+	idx := v1.NewIndex()
+	idx.AddArtifact(v1.ArtifactMetadata{Repository: "test-repo", Tag: "latest", Digest: digest})
+	r.NoError(source.SetIndex(ctx, idx))
+
+	for _, format := range []ctf.FileFormat{
+		ctf.FormatDirectory,
+		ctf.FormatTAR,
+		ctf.FormatTGZ,
+		ctf.FormatZIP,
+	} {
+		t.Run(format.String(), func(t *testing.T) {
+			r := require.New(t)
+			// archives of the legacy OCM CLI do not necessarily carry a file extension
+			path := filepath.Join(t.TempDir(), "transport")
+			r.NoError(ctf.Save(ctx, source, path, format))
+
+			detected, err := ctf.DetectCTFFormat(path)
+			r.NoError(err)
+			r.Equal(format, detected)
+
+			archive, opened, err := ctf.Open(ctx, ctf.OpenCTFOptions{Path: path, Flag: ctf.O_RDONLY, TempDir: t.TempDir()})
+			r.NoError(err)
+			r.Equal(format, opened)
+
+			idx, err := archive.GetIndex(ctx)
+			r.NoError(err)
+			r.Equal(digest, idx.GetArtifacts()[0].Digest)
+			b, err := archive.GetBlob(ctx, digest)
+			r.NoError(err)
+			data, err := b.ReadCloser()
+			r.NoError(err)
+			t.Cleanup(func() {
+				r.NoError(data.Close())
+			})
+			content, err := io.ReadAll(data)
+			r.NoError(err)
+			r.Equal("test", string(content))
+		})
+	}
+
+	t.Run("format from extension", func(t *testing.T) {
+		r := require.New(t)
+		path := filepath.Join(t.TempDir(), "transport.zip")
+		r.NoError(ctf.Save(ctx, source, path, ctf.FormatUnknown))
+		detected, err := ctf.DetectCTFFormat(path)
+		r.NoError(err)
+		r.Equal(ctf.FormatZIP, detected)
+	})
+
+	t.Run("unknown file", func(t *testing.T) {
+		r := require.New(t)
+		path := filepath.Join(t.TempDir(), "transport")
+		r.NoError(os.WriteFile(path, []byte("not a ctf"), 0o644))
+		_, _, err := ctf.Open(ctx, ctf.OpenCTFOptions{Path: path, Flag: ctf.O_RDONLY})
+		r.ErrorIs(err, ctf.ErrUnsupportedFormat)
+	})
+}
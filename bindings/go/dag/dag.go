@@ -22,6 +22,7 @@ package dag
 import (
 	"cmp"
 	"fmt"
+	"iter"
 	"maps"
 	"slices"
 	"sort"
@@ -281,6 +282,14 @@ func (d *DirectedAcyclicGraph[T]) GetEdges() [][2]T {
 }
 
 func (d *DirectedAcyclicGraph[T]) HasCycle() (bool, []string) {
+	return hasCycle(maps.Keys(d.Vertices), func(node T) iter.Seq[T] {
+		return maps.Keys(d.Vertices[node].Edges)
+	})
+}
+
+// hasCycle detects a cycle in the graph given by its nodes and a function returning the
+// neighbors of a node. It is shared by all graph implementations of this package.
+func hasCycle[T cmp.Ordered](nodes iter.Seq[T], neighbors func(T) iter.Seq[T]) (bool, []string) {
 	visited := make(map[T]bool)
 	recStack := make(map[T]bool)
 	var cyclePath []string
@@ -291,7 +300,7 @@ func (d *DirectedAcyclicGraph[T]) HasCycle() (bool, []string) {
 		recStack[node] = true
 		cyclePath = append(cyclePath, fmt.Sprintf("%v", node))
 
-		for neighbor := range d.Vertices[node].Edges {
+		for neighbor := range neighbors(node) {
 			if !visited[neighbor] {
 				if dfs(neighbor) {
 					return true
@@ -308,7 +317,7 @@ func (d *DirectedAcyclicGraph[T]) HasCycle() (bool, []string) {
 		return false
 	}
 
-	for node := range d.Vertices {
+	for node := range nodes {
 		if !visited[node] {
 			cyclePath = []string{}
 			if dfs(node) {
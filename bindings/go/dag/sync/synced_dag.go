@@ -2,6 +2,7 @@ package sync
 
 import (
 	"cmp"
+	"iter"
 	"sync"
 
 	"ocm.software/open-component-model/bindings/go/dag"
@@ -35,3 +36,12 @@ func (g *SyncedDirectedAcyclicGraph[T]) WithWriteLock(fn func(d *dag.DirectedAcy
 	defer g.dagMu.Unlock()
 	return fn(g.dag)
 }
+
+// TopologicalOrder returns an iterator over the vertices in topological order, see
+// dag.DirectedAcyclicGraph.TopologicalOrder. The order is computed under the read lock,
+// so the graph can be modified while iterating.
+func (g *SyncedDirectedAcyclicGraph[T]) TopologicalOrder() iter.Seq2[T, error] {
+	g.dagMu.RLock()
+	defer g.dagMu.RUnlock()
+	return g.dag.TopologicalOrder()
+}
@@ -0,0 +1,101 @@
+package dag
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// TopologicalOrder returns an iterator over the vertices in topological order: every vertex is
+// yielded before the vertices its edges point to. Vertices of the same rank, i.e. with the same
+// longest distance from a root, are yielded in sorted order, so that the order is deterministic.
+//
+// If the graph contains a cycle, no vertex is yielded. Instead, the iterator yields a single error
+// wrapping a *CycleError with the vertices of one cycle in the order of its edges.
+// The order is computed when TopologicalOrder is called, later modifications of the graph do not
+// affect the iterator. Callers that need dependencies first (e.g. referenced components before the components referencing
+// them) iterate the graph returned by Reverse.
+func (d *DirectedAcyclicGraph[T]) TopologicalOrder() iter.Seq2[T, error] {
+	order, err := topologicalOrder(d.GetVertices(), func(node T) iter.Seq[T] {
+		return maps.Keys(d.Vertices[node].Edges)
+	})
+	return yieldOrder(order, err)
+}
+
+// TopologicalOrder returns an iterator over the vertices in topological order.
+// See DirectedAcyclicGraph.TopologicalOrder for the ordering and cycle semantics.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) TopologicalOrder() iter.Seq2[T, error] {
+	order, err := topologicalOrder(d.GetVertices(), func(node T) iter.Seq[T] {
+		return maps.Keys(d.Vertices[node].Edges)
+	})
+	return yieldOrder(order, err)
+}
+
+func yieldOrder[T cmp.Ordered](order []T, err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for _, node := range order {
+			if !yield(node, nil) {
+				return
+			}
+		}
+	}
+}
+
+// topologicalOrder orders the sorted nodes rank by rank with Kahn's algorithm.
+// It is shared by all graph implementations of this package.
+func topologicalOrder[T cmp.Ordered](nodes []T, neighbors func(T) iter.Seq[T]) ([]T, error) {
+	inDegree := make(map[T]int, len(nodes))
+	for _, node := range nodes {
+		for neighbor := range neighbors(node) {
+			inDegree[neighbor]++
+		}
+	}
+
+	order := make([]T, 0, len(nodes))
+	var rank []T
+	for _, node := range nodes {
+		if inDegree[node] == 0 {
+			rank = append(rank, node)
+		}
+	}
+	for len(rank) > 0 {
+		order = append(order, rank...)
+		var next []T
+		for _, node := range rank {
+			for neighbor := range neighbors(node) {
+				if inDegree[neighbor]--; inDegree[neighbor] == 0 {
+					next = append(next, neighbor)
+				}
+			}
+		}
+		slices.Sort(next)
+		rank = next
+	}
+
+	if len(order) == len(nodes) {
+		return order, nil
+	}
+
+	// the nodes that could not be ordered are part of or reachable from a cycle.
+	// Searching them in sorted order keeps the reported cycle deterministic.
+	remaining := slices.DeleteFunc(slices.Clone(nodes), func(node T) bool {
+		return inDegree[node] == 0
+	})
+	_, cycle := hasCycle(slices.Values(remaining), func(node T) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			for _, neighbor := range slices.Sorted(neighbors(node)) {
+				if inDegree[neighbor] > 0 && !yield(neighbor) {
+					return
+				}
+			}
+		}
+	})
+	return nil, fmt.Errorf("%d of %d vertices cannot be ordered topologically: %w", len(remaining), len(nodes), &CycleError{Cycle: cycle})
+}
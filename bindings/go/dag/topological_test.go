@@ -0,0 +1,104 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func collectOrder[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var order []T
+	for node, err := range seq {
+		if err != nil {
+			return order, err
+		}
+		order = append(order, node)
+	}
+	return order, nil
+}
+
+func TestDAGTopologicalOrder(t *testing.T) {
+	grid := []struct {
+		Nodes string
+		Edges string
+		Want  string
+	}{
+		{Nodes: "A,B", Want: "A,B"},
+		{Nodes: "A,B", Edges: "A->B", Want: "A,B"},
+		{Nodes: "A,B", Edges: "B->A", Want: "B,A"},
+		{Nodes: "A,B,C,D,E,F", Edges: "D->C", Want: "A,B,D,E,F,C"},
+		{Nodes: "A,B,C,D,E,F", Edges: "F->A,F->B,B->A", Want: "C,D,E,F,B,A"},
+		{Nodes: "A,B,C,D,E,F", Edges: "B->A,C->A,D->B,D->C,F->E,A->E", Want: "D,F,B,C,A,E"},
+		// vertices of the same rank are sorted, regardless of the order of their parents.
+		{Nodes: "A,B,X,Y,Z", Edges: "B->X,A->Z,A->Y", Want: "A,B,X,Y,Z"},
+	}
+
+	for i, g := range grid {
+		t.Run(fmt.Sprintf("[%d] nodes=%s,edges=%s", i, g.Nodes, g.Edges), func(t *testing.T) {
+			r := require.New(t)
+			d := NewDirectedAcyclicGraph[string]()
+			for _, node := range strings.Split(g.Nodes, ",") {
+				r.NoError(d.AddVertex(node))
+			}
+			if g.Edges != "" {
+				for _, edge := range strings.Split(g.Edges, ",") {
+					tokens := strings.SplitN(edge, "->", 2)
+					r.NoError(d.AddEdge(tokens[0], tokens[1]))
+				}
+			}
+
+			order, err := collectOrder(d.TopologicalOrder())
+			r.NoError(err)
+			r.Equal(strings.Split(g.Want, ","), order)
+		})
+	}
+}
+
+func TestDAGTopologicalOrder_Cycle(t *testing.T) {
+	r := require.New(t)
+	d := NewDirectedAcyclicGraph[string]()
+	for _, node := range []string{"A", "B", "C", "D", "E"} {
+		r.NoError(d.AddVertex(node))
+	}
+	r.NoError(d.AddEdge("A", "B"))
+	r.NoError(d.AddEdge("B", "C"))
+	r.NoError(d.AddEdge("C", "D"))
+	r.NoError(d.AddEdge("D", "E"))
+	// AddEdge rejects cycles, so the cycle is introduced by modifying the vertices directly.
+	d.Vertices["D"].Edges["B"] = map[string]any{}
+
+	for range 10 {
+		order, err := collectOrder(d.TopologicalOrder())
+		r.Empty(order, "no vertex is yielded for a cyclic graph")
+		var cycleErr *CycleError
+		r.True(errors.As(err, &cycleErr))
+		r.Equal([]string{"B", "C", "D", "B"}, cycleErr.Cycle)
+		r.ErrorContains(err, "4 of 5 vertices cannot be ordered topologically")
+	}
+}
+
+func TestTypedDAGTopologicalOrder(t *testing.T) {
+	r := require.New(t)
+	d := NewTypedDirectedAcyclicGraph[int, string, struct{}]()
+	for i := range 4 {
+		r.NoError(d.AddVertex(i, fmt.Sprint(i)))
+	}
+	r.NoError(d.AddEdge(3, 1, struct{}{}))
+	r.NoError(d.AddEdge(1, 0, struct{}{}))
+	r.NoError(d.AddEdge(2, 0, struct{}{}))
+
+	order, err := collectOrder(d.TopologicalOrder())
+	r.NoError(err)
+	r.Equal([]int{2, 3, 1, 0}, order)
+
+	var stopped []int
+	for node := range d.TopologicalOrder() {
+		stopped = append(stopped, node)
+		break
+	}
+	r.Equal([]int{2}, stopped)
+}
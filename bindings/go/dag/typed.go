@@ -0,0 +1,179 @@
+package dag
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// TypedVertex is a vertex of a TypedDirectedAcyclicGraph.
+// In contrast to Vertex, its attributes are a value of type V and the attributes of its edges
+// are values of type E, so that they do not need a map allocation per vertex and edge and
+// can be accessed without type assertions.
+type TypedVertex[T cmp.Ordered, V, E any] struct {
+	// ID is a unique identifier for the node
+	ID T
+	// Attributes stores the attributes of the node.
+	Attributes V
+	// Edges stores the IDs of the nodes that this node has an outgoing edge to,
+	// together with the attributes of the edge.
+	Edges map[T]E
+
+	InDegree, OutDegree int
+}
+
+// TypedDirectedAcyclicGraph is a directed acyclic graph with typed vertex and edge attributes.
+// It offers the same semantics as DirectedAcyclicGraph and is preferable for large graphs
+// whose attributes are known at compile time.
+type TypedDirectedAcyclicGraph[T cmp.Ordered, V, E any] struct {
+	// Vertices stores the nodes in the graph
+	Vertices map[T]*TypedVertex[T, V, E]
+}
+
+// NewTypedDirectedAcyclicGraph creates a new directed acyclic graph with typed attributes.
+func NewTypedDirectedAcyclicGraph[T cmp.Ordered, V, E any]() *TypedDirectedAcyclicGraph[T, V, E] {
+	return &TypedDirectedAcyclicGraph[T, V, E]{
+		Vertices: make(map[T]*TypedVertex[T, V, E]),
+	}
+}
+
+// AddVertex adds a new node with the given attributes to the graph.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) AddVertex(id T, attributes V) error {
+	if _, exists := d.Vertices[id]; exists {
+		return fmt.Errorf("node %v already exists", id)
+	}
+	d.Vertices[id] = &TypedVertex[T, V, E]{
+		ID:         id,
+		Attributes: attributes,
+		Edges:      make(map[T]E),
+	}
+	return nil
+}
+
+// AddEdge adds a directed edge from one node to another.
+// If the edge already exists, its attributes are replaced.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) AddEdge(from, to T, attributes E) error {
+	fromNode, fromExists := d.Vertices[from]
+	toNode, toExists := d.Vertices[to]
+	if !fromExists {
+		return fmt.Errorf("node %v does not exist", from)
+	}
+	if !toExists {
+		return fmt.Errorf("node %v does not exist", to)
+	}
+	if from == to {
+		return ErrSelfReference
+	}
+
+	if _, exists := fromNode.Edges[to]; exists {
+		fromNode.Edges[to] = attributes
+		return nil
+	}
+
+	fromNode.Edges[to] = attributes
+	fromNode.OutDegree++
+	toNode.InDegree++
+
+	// the graph was acyclic before, so a cycle has to pass the new edge. Searching a path
+	// back from the new edge is much cheaper than checking the whole graph on every edge.
+	if path := d.path(to, from); path != nil {
+		delete(fromNode.Edges, to)
+		fromNode.OutDegree--
+		toNode.InDegree--
+
+		cycle := make([]string, 0, len(path)+1)
+		for _, node := range path {
+			cycle = append(cycle, fmt.Sprintf("%v", node))
+		}
+		cycle = append(cycle, fmt.Sprintf("%v", to))
+		return fmt.Errorf("adding an edge from %v to %v would create a cycle: %w", from, to, &CycleError{
+			Cycle: cycle,
+		})
+	}
+
+	return nil
+}
+
+// path returns the nodes of a path from one node to another, or nil if there is none.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) path(from, to T) []T {
+	visited := make(map[T]struct{})
+	var path []T
+
+	var dfs func(T) bool
+	dfs = func(node T) bool {
+		visited[node] = struct{}{}
+		path = append(path, node)
+		if node == to {
+			return true
+		}
+		for neighbor := range d.Vertices[node].Edges {
+			if _, ok := visited[neighbor]; !ok && dfs(neighbor) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if dfs(from) {
+		return path
+	}
+	return nil
+}
+
+// DeleteEdge removes the edge from one node to another.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) DeleteEdge(from, to T) error {
+	fromNode, fromExists := d.Vertices[from]
+	toNode, toExists := d.Vertices[to]
+	if !fromExists {
+		return fmt.Errorf("node %v does not exist", from)
+	}
+	if !toExists {
+		return fmt.Errorf("node %v does not exist", to)
+	}
+	if _, exists := fromNode.Edges[to]; !exists {
+		return fmt.Errorf("edge from %v to %v does not exist", from, to)
+	}
+
+	delete(fromNode.Edges, to)
+	fromNode.OutDegree--
+	toNode.InDegree--
+
+	return nil
+}
+
+// DeleteVertex removes a node and its outgoing edges from the graph.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) DeleteVertex(id T) error {
+	vertex, exists := d.Vertices[id]
+	if !exists {
+		return fmt.Errorf("node %v does not exist", id)
+	}
+	for edge := range vertex.Edges {
+		if err := d.DeleteEdge(id, edge); err != nil {
+			return err
+		}
+	}
+
+	delete(d.Vertices, id)
+	return nil
+}
+
+// Contains reports whether the graph contains the node.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) Contains(id T) bool {
+	_, ok := d.Vertices[id]
+	return ok
+}
+
+// GetVertices returns the nodes in the graph in sorted order.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) GetVertices() []T {
+	return slices.Sorted(maps.Keys(d.Vertices))
+}
+
+// HasCycle reports whether the graph contains a cycle and returns it.
+func (d *TypedDirectedAcyclicGraph[T, V, E]) HasCycle() (bool, []string) {
+	return hasCycle(maps.Keys(d.Vertices), func(node T) iter.Seq[T] {
+		return maps.Keys(d.Vertices[node].Edges)
+	})
+}
@@ -0,0 +1,97 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testAttributes struct {
+	value string
+}
+
+func TestTypedDAG(t *testing.T) {
+	r := require.New(t)
+	d := NewTypedDirectedAcyclicGraph[string, testAttributes, string]()
+
+	r.NoError(d.AddVertex("A", testAttributes{value: "a"}))
+	r.NoError(d.AddVertex("B", testAttributes{value: "b"}))
+	r.NoError(d.AddVertex("C", testAttributes{value: "c"}))
+	r.Error(d.AddVertex("A", testAttributes{}), "duplicate node ids are forbidden")
+	r.Equal("a", d.Vertices["A"].Attributes.value)
+
+	r.NoError(d.AddEdge("A", "B", "first"))
+	r.NoError(d.AddEdge("A", "B", "second"))
+	r.Equal("second", d.Vertices["A"].Edges["B"])
+	r.Equal(1, d.Vertices["A"].OutDegree)
+	r.Equal(1, d.Vertices["B"].InDegree)
+
+	r.NoError(d.AddEdge("B", "C", ""))
+	err := d.AddEdge("C", "A", "")
+	var cycleErr *CycleError
+	r.True(errors.As(err, &cycleErr))
+	r.Equal([]string{"A", "B", "C", "A"}, cycleErr.Cycle)
+	r.NotContains(d.Vertices["C"].Edges, "A")
+	r.ErrorIs(d.AddEdge("A", "A", ""), ErrSelfReference)
+	r.Error(d.AddEdge("A", "D", ""))
+
+	r.NoError(d.DeleteVertex("B"))
+	r.False(d.Contains("B"))
+	r.Equal(0, d.Vertices["C"].InDegree)
+	r.Equal([]string{"A", "C"}, d.GetVertices())
+	r.Error(d.DeleteEdge("A", "C"))
+}
+
+func BenchmarkDAG(b *testing.B) {
+	const size = 500
+	ids := make([]string, size)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("node-%d", i)
+	}
+
+	b.Run("map attributes", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			d := NewDirectedAcyclicGraph[string]()
+			for i, id := range ids {
+				if err := d.AddVertex(id, map[string]any{"value": id}); err != nil {
+					b.Fatal(err)
+				}
+				if i > 0 {
+					if err := d.AddEdge(ids[i-1], id, map[string]any{"kind": "next"}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			for _, id := range ids {
+				if _, ok := d.Vertices[id].Attributes["value"].(string); !ok {
+					b.Fatal("missing attribute")
+				}
+			}
+		}
+	})
+
+	b.Run("typed attributes", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			d := NewTypedDirectedAcyclicGraph[string, testAttributes, string]()
+			for i, id := range ids {
+				if err := d.AddVertex(id, testAttributes{value: id}); err != nil {
+					b.Fatal(err)
+				}
+				if i > 0 {
+					if err := d.AddEdge(ids[i-1], id, "next"); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			for _, id := range ids {
+				if d.Vertices[id].Attributes.value == "" {
+					b.Fatal("missing attribute")
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"ocm.software/open-component-model/bindings/go/deploytarget"
+	"ocm.software/open-component-model/bindings/go/deploytarget/filesystem"
+	"ocm.software/open-component-model/bindings/go/deploytarget/kubectl"
+	"ocm.software/open-component-model/bindings/go/deploytarget/spec"
+	v1 "ocm.software/open-component-model/bindings/go/deploytarget/spec/v1"
+)
+
+// NewRegistry creates a registry with the providers of all built-in deploy targets.
+func NewRegistry() *deploytarget.Registry {
+	registry := deploytarget.NewRegistry(spec.Scheme)
+	Register(registry)
+	return registry
+}
+
+// Register registers the providers of all built-in deploy targets. The registry has to resolve types
+// with a scheme containing spec.Scheme.
+func Register(registry *deploytarget.Registry) {
+	registry.MustRegisterProvider(&v1.Filesystem{}, filesystem.NewProvider())
+	registry.MustRegisterProvider(&v1.Kubectl{}, kubectl.NewProvider())
+}
@@ -0,0 +1,28 @@
+// Package deploytarget deploys resources packaged as Kubernetes manifests to environments outside of the
+// cluster controller, for example from the CLI.
+//
+// A [Target] deploys [Manifest]s. Targets are described by typed specifications and created by the [Provider]
+// registered for the type of the specification in a [Registry]:
+//
+//	registry := builtin.NewRegistry()
+//	target, err := registry.GetTarget(ctx, &v1.Kubectl{
+//		Type:       runtime.NewVersionedType(v1.KubectlType, v1.Version),
+//		Kubeconfig: "/home/user/.kube/staging",
+//		ServerSide: true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := target.Deploy(ctx, []deploytarget.Manifest{{Name: "app", Data: manifestBlob}})
+//
+// The built-in targets, registered by the builtin package, are:
+//
+//   - Filesystem/v1 (see the filesystem package) exports the manifests as files into a directory,
+//     for example to commit them to a repository watched by a GitOps tool.
+//   - Kubectl/v1 (see the kubectl package) applies the manifests to a cluster reached with a kubeconfig
+//     by running kubectl apply, so that no cluster controller is required.
+//
+// Further targets can be added by registering providers for their specifications. The Deployer of the
+// cluster controller is expected to move onto this abstraction with a target applying manifests with its
+// own client.
+package deploytarget
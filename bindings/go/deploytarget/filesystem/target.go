@@ -0,0 +1,109 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ocm.software/open-component-model/bindings/go/deploytarget"
+	v1 "ocm.software/open-component-model/bindings/go/deploytarget/spec/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// NewProvider creates a provider of targets that export manifests into a directory, see v1.Filesystem.
+func NewProvider() deploytarget.Provider {
+	return deploytarget.ProviderFunc(newTarget)
+}
+
+func newTarget(_ context.Context, spec runtime.Typed) (deploytarget.Target, error) {
+	fs, ok := spec.(*v1.Filesystem)
+	if !ok {
+		return nil, fmt.Errorf("expected specification of type %T, got %T", &v1.Filesystem{}, spec)
+	}
+	if fs.Path == "" {
+		return nil, errors.New("path of filesystem deploy target is required")
+	}
+	return &Target{Path: fs.Path}, nil
+}
+
+// Target exports manifests as files into a directory.
+type Target struct {
+	// Path is the directory the manifests are written to.
+	Path string
+}
+
+var _ deploytarget.Target = (*Target)(nil)
+
+// Deploy writes every manifest to the file in Path named after the manifest, with the extension .yaml
+// unless the name has an extension. Existing files are replaced atomically, so that tools watching the
+// directory never see partially written manifests.
+func (t *Target) Deploy(ctx context.Context, manifests []deploytarget.Manifest) (*deploytarget.Result, error) {
+	if err := os.MkdirAll(t.Path, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %q: %w", t.Path, err)
+	}
+	result := &deploytarget.Result{}
+	for _, manifest := range manifests {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		path, err := t.pathOf(manifest)
+		if err != nil {
+			return result, err
+		}
+		if err := writeFile(path, manifest); err != nil {
+			return result, fmt.Errorf("failed to export manifest %q: %w", manifest.Name, err)
+		}
+		result.Deployed = append(result.Deployed, path)
+	}
+	return result, nil
+}
+
+func (t *Target) pathOf(manifest deploytarget.Manifest) (string, error) {
+	name := manifest.Name
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid manifest name %q, names must be non-empty and must not contain path separators", name)
+	}
+	if filepath.Ext(name) == "" {
+		name += ".yaml"
+	}
+	return filepath.Join(t.Path, name), nil
+}
+
+func writeFile(path string, manifest deploytarget.Manifest) (err error) {
+	if manifest.Data == nil {
+		return errors.New("manifest has no data")
+	}
+	data, err := manifest.Data.ReadCloser()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, data.Close())
+	}()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, err := io.Copy(tmp, data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,52 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/deploytarget"
+	"ocm.software/open-component-model/bindings/go/deploytarget/filesystem"
+	v1 "ocm.software/open-component-model/bindings/go/deploytarget/spec/v1"
+)
+
+func manifest(name, data string) deploytarget.Manifest {
+	return deploytarget.Manifest{Name: name, Data: inmemory.New(strings.NewReader(data))}
+}
+
+func TestTarget(t *testing.T) {
+	r := require.New(t)
+	dir := filepath.Join(t.TempDir(), "export")
+
+	target, err := filesystem.NewProvider().NewTarget(t.Context(), &v1.Filesystem{Path: dir})
+	r.NoError(err)
+
+	result, err := target.Deploy(t.Context(), []deploytarget.Manifest{
+		manifest("deployment", "kind: Deployment\n"),
+		manifest("service.yml", "kind: Service\n"),
+	})
+	r.NoError(err)
+	r.Equal([]string{filepath.Join(dir, "deployment.yaml"), filepath.Join(dir, "service.yml")}, result.Deployed)
+
+	// deploying again replaces the existing files.
+	_, err = target.Deploy(t.Context(), []deploytarget.Manifest{manifest("deployment", "kind: Deployment\nreplicas: 2\n")})
+	r.NoError(err)
+	data, err := os.ReadFile(filepath.Join(dir, "deployment.yaml"))
+	r.NoError(err)
+	r.Equal("kind: Deployment\nreplicas: 2\n", string(data))
+	entries, err := os.ReadDir(dir)
+	r.NoError(err)
+	r.Len(entries, 2, "no temporary files are left behind")
+
+	for _, name := range []string{"", "..", "../escape", "nested/name"} {
+		_, err = target.Deploy(t.Context(), []deploytarget.Manifest{manifest(name, "kind: Secret\n")})
+		r.ErrorContains(err, "invalid manifest name", "name %q", name)
+	}
+
+	_, err = filesystem.NewProvider().NewTarget(t.Context(), &v1.Filesystem{})
+	r.ErrorContains(err, "path of filesystem deploy target is required")
+}
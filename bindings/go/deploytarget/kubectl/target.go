@@ -0,0 +1,140 @@
+package kubectl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"ocm.software/open-component-model/bindings/go/deploytarget"
+	v1 "ocm.software/open-component-model/bindings/go/deploytarget/spec/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Provider creates targets that apply manifests to a cluster with the kubectl binary, see v1.Kubectl.
+type Provider struct {
+	// LookPath locates the kubectl binary on PATH.
+	LookPath func(file string) (string, error)
+	// ExecKubectl runs kubectl with the given arguments and input and returns its output.
+	ExecKubectl func(ctx context.Context, binaryPath string, args []string, stdin io.Reader) ([]byte, error)
+}
+
+var _ deploytarget.Provider = (*Provider)(nil)
+
+func NewProvider() *Provider {
+	return &Provider{
+		LookPath:    exec.LookPath,
+		ExecKubectl: execKubectl,
+	}
+}
+
+// NewTarget creates a target for the given *v1.Kubectl specification. The kubectl binary is located
+// when the target is created, so that a missing binary is reported before any manifest is read.
+func (p *Provider) NewTarget(_ context.Context, spec runtime.Typed) (deploytarget.Target, error) {
+	kubectl, ok := spec.(*v1.Kubectl)
+	if !ok {
+		return nil, fmt.Errorf("expected specification of type %T, got %T", &v1.Kubectl{}, spec)
+	}
+	path, err := p.LookPath("kubectl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate kubectl binary: %w", err)
+	}
+	return &Target{
+		spec:        kubectl.DeepCopy(),
+		binaryPath:  path,
+		execKubectl: p.ExecKubectl,
+	}, nil
+}
+
+// Target applies manifests to a cluster with kubectl apply.
+type Target struct {
+	spec        *v1.Kubectl
+	binaryPath  string
+	execKubectl func(ctx context.Context, binaryPath string, args []string, stdin io.Reader) ([]byte, error)
+}
+
+var _ deploytarget.Target = (*Target)(nil)
+
+// Deploy applies all manifests with a single kubectl apply, so that the objects of all manifests are
+// applied in the order kubectl determines, for example namespaces before the objects in them.
+// The result lists the applied objects as reported by kubectl, e.g. deployment.apps/nginx.
+func (t *Target) Deploy(ctx context.Context, manifests []deploytarget.Manifest) (*deploytarget.Result, error) {
+	input, err := concatenate(manifests)
+	if err != nil {
+		return nil, err
+	}
+	output, err := t.execKubectl(ctx, t.binaryPath, t.args(), input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply manifests: %w", err)
+	}
+	result := &deploytarget.Result{}
+	for line := range strings.Lines(string(output)) {
+		if line = strings.TrimSpace(line); line != "" {
+			result.Deployed = append(result.Deployed, line)
+		}
+	}
+	return result, nil
+}
+
+func (t *Target) args() []string {
+	args := []string{"apply", "--filename", "-", "--output", "name"}
+	if t.spec.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", t.spec.Kubeconfig)
+	}
+	if t.spec.Context != "" {
+		args = append(args, "--context", t.spec.Context)
+	}
+	if t.spec.Namespace != "" {
+		args = append(args, "--namespace", t.spec.Namespace)
+	}
+	if t.spec.ServerSide {
+		fieldManager := t.spec.FieldManager
+		if fieldManager == "" {
+			fieldManager = v1.DefaultFieldManager
+		}
+		args = append(args, "--server-side", "--field-manager", fieldManager)
+	}
+	return args
+}
+
+// concatenate joins the manifests into a single stream of YAML documents.
+func concatenate(manifests []deploytarget.Manifest) (io.Reader, error) {
+	var buf bytes.Buffer
+	for _, manifest := range manifests {
+		if manifest.Data == nil {
+			return nil, fmt.Errorf("manifest %q has no data", manifest.Name)
+		}
+		data, err := manifest.Data.ReadCloser()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest %q: %w", manifest.Name, err)
+		}
+		buf.WriteString("---\n")
+		_, err = io.Copy(&buf, data)
+		err = errors.Join(err, data.Close())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest %q: %w", manifest.Name, err)
+		}
+		if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	return &buf, nil
+}
+
+func execKubectl(ctx context.Context, binaryPath string, args []string, stdin io.Reader) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binaryPath, args...) //nolint:gosec // G204 the binary and arguments are controlled by the target
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,78 @@
+package kubectl_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/deploytarget"
+	"ocm.software/open-component-model/bindings/go/deploytarget/kubectl"
+	v1 "ocm.software/open-component-model/bindings/go/deploytarget/spec/v1"
+)
+
+func TestTarget(t *testing.T) {
+	var args []string
+	var input string
+	provider := &kubectl.Provider{
+		LookPath: func(file string) (string, error) {
+			return "/usr/local/bin/" + file, nil
+		},
+		ExecKubectl: func(_ context.Context, binaryPath string, a []string, stdin io.Reader) ([]byte, error) {
+			if binaryPath != "/usr/local/bin/kubectl" {
+				return nil, errors.New("unexpected binary " + binaryPath)
+			}
+			args = a
+			data, err := io.ReadAll(stdin)
+			input = string(data)
+			return []byte("namespace/app\ndeployment.apps/app\n"), err
+		},
+	}
+	manifests := []deploytarget.Manifest{
+		{Name: "namespace", Data: inmemory.New(strings.NewReader("kind: Namespace"))},
+		{Name: "deployment", Data: inmemory.New(strings.NewReader("kind: Deployment\n"))},
+	}
+
+	t.Run("client-side apply with default kubeconfig", func(t *testing.T) {
+		r := require.New(t)
+		target, err := provider.NewTarget(t.Context(), &v1.Kubectl{})
+		r.NoError(err)
+		result, err := target.Deploy(t.Context(), manifests)
+		r.NoError(err)
+		r.Equal([]string{"namespace/app", "deployment.apps/app"}, result.Deployed)
+		r.Equal([]string{"apply", "--filename", "-", "--output", "name"}, args)
+		r.Equal("---\nkind: Namespace\n---\nkind: Deployment\n", input)
+	})
+
+	t.Run("server-side apply with kubeconfig", func(t *testing.T) {
+		r := require.New(t)
+		target, err := provider.NewTarget(t.Context(), &v1.Kubectl{
+			Kubeconfig: "/home/user/.kube/staging",
+			Context:    "staging",
+			Namespace:  "apps",
+			ServerSide: true,
+		})
+		r.NoError(err)
+		_, err = target.Deploy(t.Context(), manifests[1:])
+		r.NoError(err)
+		r.Equal([]string{
+			"apply", "--filename", "-", "--output", "name",
+			"--kubeconfig", "/home/user/.kube/staging",
+			"--context", "staging",
+			"--namespace", "apps",
+			"--server-side", "--field-manager", v1.DefaultFieldManager,
+		}, args)
+	})
+
+	t.Run("missing kubectl binary", func(t *testing.T) {
+		r := require.New(t)
+		_, err := (&kubectl.Provider{LookPath: func(string) (string, error) {
+			return "", errors.New("not found")
+		}}).NewTarget(t.Context(), &v1.Kubectl{})
+		r.ErrorContains(err, "failed to locate kubectl binary")
+	})
+}
@@ -0,0 +1,84 @@
+package deploytarget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ErrUnsupportedTarget is returned by Registry.GetTarget if no provider is registered for the type of a specification.
+var ErrUnsupportedTarget = errors.New("unsupported deploy target")
+
+// Registry manages the providers of deploy targets for the types of their specifications.
+type Registry struct {
+	mu        sync.RWMutex
+	scheme    *runtime.Scheme
+	providers map[runtime.Type]Provider
+}
+
+// NewRegistry creates a new Registry that resolves the types of specifications with the given scheme.
+func NewRegistry(scheme *runtime.Scheme) *Registry {
+	return &Registry{
+		scheme:    scheme,
+		providers: make(map[runtime.Type]Provider),
+	}
+}
+
+// RegisterProvider registers a provider for the type of the given prototype and all its aliases in the scheme.
+// Returns an error if the prototype is not registered in the scheme or if a provider is already registered for its type.
+func (r *Registry) RegisterProvider(prototype runtime.Typed, provider Provider) error {
+	typ, err := r.scheme.TypeForPrototype(prototype)
+	if err != nil {
+		return fmt.Errorf("failed to get type for prototype: %w", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.providers[typ]; exists {
+		return fmt.Errorf("deploy target provider for type %q is already registered", typ)
+	}
+	r.providers[typ] = provider
+	return nil
+}
+
+// MustRegisterProvider registers a provider for the type of the given prototype.
+// Panics if the registration fails or if the type is already registered.
+func (r *Registry) MustRegisterProvider(prototype runtime.Typed, provider Provider) {
+	if err := r.RegisterProvider(prototype, provider); err != nil {
+		panic(err)
+	}
+}
+
+// GetTarget creates the target described by spec with the provider registered for its type.
+// spec may be a *runtime.Raw, which is converted to the type registered in the scheme before it is passed to the provider.
+func (r *Registry) GetTarget(ctx context.Context, spec runtime.Typed) (Target, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("%w: no specification given", ErrUnsupportedTarget)
+	}
+	typ, ok := r.scheme.ResolveCanonicalType(spec.GetType())
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrUnsupportedTarget, spec.GetType())
+	}
+	r.mu.RLock()
+	provider, ok := r.providers[typ]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: no provider registered for type %q", ErrUnsupportedTarget, typ)
+	}
+
+	typed, err := r.scheme.NewObject(typ)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create specification of type %q: %w", typ, err)
+	}
+	if err := r.scheme.Convert(spec, typed); err != nil {
+		return nil, fmt.Errorf("failed to convert specification of type %q: %w", spec.GetType(), err)
+	}
+
+	target, err := provider.NewTarget(ctx, typed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deploy target of type %q: %w", typ, err)
+	}
+	return target, nil
+}
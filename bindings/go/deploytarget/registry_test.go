@@ -0,0 +1,67 @@
+package deploytarget_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/deploytarget"
+	"ocm.software/open-component-model/bindings/go/deploytarget/spec"
+	v1 "ocm.software/open-component-model/bindings/go/deploytarget/spec/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+type recordingTarget struct {
+	spec runtime.Typed
+}
+
+func (t *recordingTarget) Deploy(context.Context, []deploytarget.Manifest) (*deploytarget.Result, error) {
+	return &deploytarget.Result{}, nil
+}
+
+func TestRegistry(t *testing.T) {
+	r := require.New(t)
+	registry := deploytarget.NewRegistry(spec.Scheme)
+	registry.MustRegisterProvider(&v1.Filesystem{}, deploytarget.ProviderFunc(func(_ context.Context, spec runtime.Typed) (deploytarget.Target, error) {
+		return &recordingTarget{spec: spec}, nil
+	}))
+	r.ErrorContains(registry.RegisterProvider(&v1.Filesystem{}, nil), "already registered")
+
+	t.Run("raw specification of an alias type", func(t *testing.T) {
+		r := require.New(t)
+		target, err := registry.GetTarget(t.Context(), &runtime.Raw{
+			Type: runtime.NewUnversionedType(v1.FilesystemType),
+			Data: []byte(`{"type":"Filesystem","path":"manifests"}`),
+		})
+		r.NoError(err)
+		r.IsType(&recordingTarget{}, target)
+		r.Equal("manifests", target.(*recordingTarget).spec.(*v1.Filesystem).Path)
+	})
+
+	t.Run("typed specification", func(t *testing.T) {
+		r := require.New(t)
+		target, err := registry.GetTarget(t.Context(), &v1.Filesystem{
+			Type: runtime.NewVersionedType(v1.FilesystemType, v1.Version),
+			Path: "manifests",
+		})
+		r.NoError(err)
+		r.Equal("manifests", target.(*recordingTarget).spec.(*v1.Filesystem).Path)
+	})
+
+	t.Run("no provider registered", func(t *testing.T) {
+		r := require.New(t)
+		_, err := registry.GetTarget(t.Context(), &v1.Kubectl{Type: runtime.NewVersionedType(v1.KubectlType, v1.Version)})
+		r.ErrorIs(err, deploytarget.ErrUnsupportedTarget)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		r := require.New(t)
+		_, err := registry.GetTarget(t.Context(), &runtime.Raw{Type: runtime.NewVersionedType("Unknown", "v1")})
+		r.ErrorIs(err, deploytarget.ErrUnsupportedTarget)
+	})
+
+	r.Panics(func() {
+		deploytarget.NewRegistry(runtime.NewScheme()).MustRegisterProvider(&v1.Filesystem{}, nil)
+	})
+}
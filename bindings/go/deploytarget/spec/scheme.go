@@ -0,0 +1,20 @@
+package spec
+
+import (
+	v1 "ocm.software/open-component-model/bindings/go/deploytarget/spec/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Scheme contains the specifications of the built-in deploy targets.
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&v1.Filesystem{},
+		runtime.NewVersionedType(v1.FilesystemType, v1.Version),
+		runtime.NewUnversionedType(v1.FilesystemType),
+	)
+	Scheme.MustRegisterWithAlias(&v1.Kubectl{},
+		runtime.NewVersionedType(v1.KubectlType, v1.Version),
+		runtime.NewUnversionedType(v1.KubectlType),
+	)
+}
@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	Version        = "v1"
+	FilesystemType = "Filesystem"
+)
+
+// Filesystem describes a deploy target that exports manifests as files into a directory,
+// for example to commit them to a repository watched by a GitOps tool.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Filesystem struct {
+	// +ocm:jsonschema-gen:enum=Filesystem/v1
+	// +ocm:jsonschema-gen:enum:deprecated=Filesystem
+	Type runtime.Type `json:"type"`
+
+	// Path is the directory the manifests are written to. It is created if it does not exist.
+	// Every manifest is written to a file named after the manifest, existing files are replaced.
+	Path string `json:"path"`
+}
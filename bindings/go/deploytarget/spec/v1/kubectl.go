@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const KubectlType = "Kubectl"
+
+// DefaultFieldManager is the field manager of server-side applies if none is specified.
+const DefaultFieldManager = "ocm"
+
+// Kubectl describes a deploy target that applies manifests to a Kubernetes cluster with kubectl apply,
+// for deployments from outside the cluster that do not run the controller.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Kubectl struct {
+	// +ocm:jsonschema-gen:enum=Kubectl/v1
+	// +ocm:jsonschema-gen:enum:deprecated=Kubectl
+	Type runtime.Type `json:"type"`
+
+	// Kubeconfig is the path of the kubeconfig file to connect to the cluster with.
+	// Defaults to the kubeconfig kubectl uses by default, i.e. $KUBECONFIG or ~/.kube/config.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+
+	// Context is the context of the kubeconfig to use. Defaults to the current context of the kubeconfig.
+	Context string `json:"context,omitempty"`
+
+	// Namespace is the namespace of objects in the manifests that do not specify one.
+	// Defaults to the namespace of the context.
+	Namespace string `json:"namespace,omitempty"`
+
+	// ServerSide applies the manifests with server-side apply instead of client-side apply.
+	ServerSide bool `json:"serverSide,omitempty"`
+
+	// FieldManager is the name of the manager of the fields set by server-side apply. Defaults to ocm.
+	FieldManager string `json:"fieldManager,omitempty"`
+}
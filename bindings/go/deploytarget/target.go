@@ -0,0 +1,47 @@
+package deploytarget
+
+import (
+	"context"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Manifest is a file with one or more Kubernetes objects in YAML or JSON, as packaged in a resource
+// of a component version.
+type Manifest struct {
+	// Name identifies the manifest, for example the name of the resource it was packaged in.
+	// Targets that store manifests as files use it as file name, so it must not contain path separators.
+	Name string
+	// Data is the content of the manifest.
+	Data blob.ReadOnlyBlob
+}
+
+// Result describes the outcome of a deployment.
+type Result struct {
+	// Deployed lists what the manifests were deployed as, in the notation of the target,
+	// for example the paths of the written files or the names of the applied objects (deployment.apps/nginx).
+	Deployed []string
+}
+
+// Target deploys manifests to an environment outside of the cluster controller, for example a directory
+// or a cluster reached with a kubeconfig.
+type Target interface {
+	// Deploy deploys all manifests. Deploying the same manifests again must not fail, so that a deployment
+	// can be repeated after it failed or the manifests changed.
+	Deploy(ctx context.Context, manifests []Manifest) (*Result, error)
+}
+
+// Provider creates the targets described by a specification of the type the provider is registered for.
+type Provider interface {
+	// NewTarget creates the target described by spec. spec is of the type of the prototype the provider was
+	// registered with.
+	NewTarget(ctx context.Context, spec runtime.Typed) (Target, error)
+}
+
+// ProviderFunc is a function implementing Provider.
+type ProviderFunc func(ctx context.Context, spec runtime.Typed) (Target, error)
+
+func (f ProviderFunc) NewTarget(ctx context.Context, spec runtime.Typed) (Target, error) {
+	return f(ctx, spec)
+}
@@ -0,0 +1,192 @@
+// Package compattest provides a golden-file harness asserting that component descriptors are
+// encoded and normalised byte-stable across releases.
+//
+// Signatures of component versions are computed over the normalised descriptor. Any change in how
+// a descriptor is decoded, converted to its runtime representation, encoded again or normalised
+// therefore invalidates existing signatures, even if the change is semantically irrelevant.
+// The harness round-trips a corpus of descriptors through decode → runtime → encode and through all
+// registered normalisation algorithms and compares the results byte by byte with golden files.
+//
+// A corpus is a file system with one directory per case:
+//
+//	<case>/
+//	  README.md                    optional description of the case, e.g. where the descriptor was taken from
+//	  descriptor.yaml              the descriptor as found in the wild, in YAML or JSON
+//	  encoded.json                 golden v2 encoding after decode → runtime → encode
+//	  normalised/<algorithm>.json  golden normalisation per algorithm, with "/" in the algorithm name replaced by "-"
+//
+// The corpus maintained with this package is available through Corpus. Consumers can run it against
+// their builds, or maintain their own corpus in the same layout:
+//
+//	func TestDescriptorCompatibility(t *testing.T) {
+//		compattest.Run(t, compattest.Corpus())
+//	}
+//
+// Golden files must only be regenerated with WithUpdate if a change of the output is intended,
+// as it breaks the verification of all descriptors signed before.
+package compattest
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation"
+	_ "ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	_ "ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v5alpha1"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Files of a corpus case.
+const (
+	ReadmeFile    = "README.md"
+	InputFile     = "descriptor.yaml"
+	EncodedFile   = "encoded.json"
+	NormalisedDir = "normalised"
+)
+
+//go:embed corpus
+var corpus embed.FS
+
+// Corpus returns the corpus of real-world descriptors maintained with this package.
+func Corpus() fs.FS {
+	sub, err := fs.Sub(corpus, "corpus")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Options configure Run.
+type Options struct {
+	// Algorithms are the normalisation algorithms to check.
+	// Defaults to all algorithms registered in normalisation.Normalisations.
+	Algorithms []string
+	// UpdateDir is the directory the corpus is stored in on disk.
+	// If set, golden files are written to it instead of being compared.
+	UpdateDir string
+}
+
+// Option is a function that modifies Options.
+type Option func(*Options)
+
+// WithAlgorithms restricts the checked normalisation algorithms.
+func WithAlgorithms(algorithms ...string) Option {
+	return func(o *Options) {
+		o.Algorithms = algorithms
+	}
+}
+
+// WithUpdate writes the golden files of all cases into dir, the directory the corpus is stored in,
+// instead of comparing against them.
+func WithUpdate(dir string) Option {
+	return func(o *Options) {
+		o.UpdateDir = dir
+	}
+}
+
+// Run runs every case of the corpus as a subtest of t.
+func Run(t *testing.T, corpus fs.FS, opts ...Option) {
+	t.Helper()
+	options := Options{Algorithms: normalisation.Normalisations.Names()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	entries, err := fs.ReadDir(corpus, ".")
+	require.NoError(t, err, "failed to read corpus")
+	var cases int
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		cases++
+		t.Run(entry.Name(), func(t *testing.T) {
+			runCase(t, corpus, entry.Name(), options)
+		})
+	}
+	require.NotZero(t, cases, "corpus contains no cases")
+}
+
+func runCase(t *testing.T, corpus fs.FS, name string, options Options) {
+	r := require.New(t)
+	if readme, err := fs.ReadFile(corpus, path.Join(name, ReadmeFile)); err == nil {
+		t.Log(string(readme))
+	}
+
+	input, err := fs.ReadFile(corpus, path.Join(name, InputFile))
+	r.NoError(err, "failed to read input descriptor")
+
+	encoded, desc, err := RoundTrip(input)
+	r.NoError(err, "failed to round-trip input descriptor")
+	reencoded, redecoded, err := RoundTrip(encoded)
+	r.NoError(err, "failed to round-trip encoded descriptor")
+	r.Equal(string(encoded), string(reencoded), "encoding is not stable when decoding its own output")
+	golden(t, corpus, name, EncodedFile, encoded, options)
+
+	for _, algorithm := range options.Algorithms {
+		normalised, err := normalisation.Normalise(desc, algorithm)
+		r.NoError(err, "failed to normalise descriptor with %s", algorithm)
+		renormalised, err := normalisation.Normalise(redecoded, algorithm)
+		r.NoError(err, "failed to normalise encoded descriptor with %s", algorithm)
+		r.Equal(string(normalised), string(renormalised), "%s normalisation changes after encoding the descriptor", algorithm)
+		golden(t, corpus, name, path.Join(NormalisedDir, GoldenFileName(algorithm)), normalised, options)
+	}
+}
+
+// golden compares got with the golden file of the case, or writes it in update mode.
+func golden(t *testing.T, corpus fs.FS, name, file string, got []byte, options Options) {
+	t.Helper()
+	r := require.New(t)
+	if options.UpdateDir != "" {
+		target := filepath.Join(options.UpdateDir, name, filepath.FromSlash(file))
+		r.NoError(os.MkdirAll(filepath.Dir(target), 0o755))
+		r.NoError(os.WriteFile(target, got, 0o644))
+		return
+	}
+	expected, err := fs.ReadFile(corpus, path.Join(name, file))
+	if errors.Is(err, fs.ErrNotExist) {
+		r.Failf("missing golden file", "%s of case %s does not exist, generate it in update mode", file, name)
+	}
+	r.NoError(err, "failed to read golden file %s", file)
+	r.Equal(string(expected), string(got), "%s changed, descriptors signed before will no longer verify", file)
+}
+
+// GoldenFileName returns the name of the golden file of a normalisation algorithm.
+func GoldenFileName(algorithm string) string {
+	return strings.ReplaceAll(algorithm, "/", "-") + ".json"
+}
+
+// RoundTrip decodes a v2 descriptor in YAML or JSON, converts it to its runtime representation
+// and encodes it as v2 JSON again. It returns the encoding and the runtime descriptor.
+func RoundTrip(data []byte) ([]byte, *descruntime.Descriptor, error) {
+	var decoded v2.Descriptor
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode descriptor: %w", err)
+	}
+	desc, err := descruntime.ConvertFromV2(&decoded)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert descriptor to runtime: %w", err)
+	}
+	encodable, err := descruntime.ConvertToV2(runtime.NewScheme(runtime.WithAllowUnknown()), desc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert descriptor to v2: %w", err)
+	}
+	encoded, err := json.Marshal(encodable)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode descriptor: %w", err)
+	}
+	return encoded, desc, nil
+}
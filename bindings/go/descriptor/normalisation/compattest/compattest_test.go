@@ -0,0 +1,18 @@
+package compattest_test
+
+import (
+	"flag"
+	"testing"
+
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/compattest"
+)
+
+var update = flag.Bool("update", false, "regenerate the golden files of the corpus")
+
+func TestCorpus(t *testing.T) {
+	var opts []compattest.Option
+	if *update {
+		opts = append(opts, compattest.WithUpdate("corpus"))
+	}
+	compattest.Run(t, compattest.Corpus(), opts...)
+}
@@ -0,0 +1,15 @@
+// Package v5alpha1 provides a JSON normalisation algorithm for Open Component Model descriptors
+// whose field exclusions can be declared.
+//
+// The algorithm builds on the rules of v4alpha1: access specifications, repository contexts,
+// signatures and labels that are not signing relevant never contribute to the normalised form.
+// In addition, an Exclusions specification declares
+//   - label names (patterns) that are excluded wherever they appear, even if signing relevant,
+//     which allows labels that are rewritten during transport (volatile labels),
+//   - arbitrary fields of the v2 serialization of the descriptor by path.
+//
+// As signatures only record the name of the normalisation algorithm, every set of exclusions has to be
+// registered under its own algorithm name with Register. The algorithm registered as Algorithm uses
+// DefaultExclusions. Verifiers need to register the same exclusions under the same name to reproduce
+// the normalised form, which is therefore stable across transports that only touch excluded fields.
+package v5alpha1
@@ -0,0 +1,85 @@
+package v5alpha1
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	ExclusionsType    = "NormalisationExclusions"
+	ExclusionsVersion = "v1alpha1"
+)
+
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&Exclusions{},
+		runtime.NewVersionedType(ExclusionsType, ExclusionsVersion),
+		runtime.NewUnversionedType(ExclusionsType),
+	)
+}
+
+// Exclusions declares the fields of a component descriptor that are excluded from the normalised form
+// in addition to the fields always excluded by the algorithm.
+//
+// Example:
+//
+//	type: NormalisationExclusions/v1alpha1
+//	labels:
+//	  - acme.org/build/*
+//	fields:
+//	  - component.resources.*.extraIdentity.architecture
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+type Exclusions struct {
+	Type runtime.Type `json:"type"`
+
+	// Labels are patterns of label names, as supported by path.Match.
+	// Matching labels of the component, its provider, resources, sources and references are
+	// excluded, regardless of whether they are signing relevant.
+	Labels []string `json:"labels,omitempty"`
+
+	// Fields are paths of fields in the v2 serialization of the component descriptor.
+	// The elements of a path are separated by dots, "*" matches all entries of a list or map.
+	// The name and version of the component cannot be excluded.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// DefaultExclusions are the exclusions of the algorithm registered as Algorithm.
+// It excludes no fields in addition to the ones always excluded.
+var DefaultExclusions = Exclusions{
+	Type: runtime.NewVersionedType(ExclusionsType, ExclusionsVersion),
+}
+
+// protectedFields are the fields identifying the component, which cannot be excluded.
+var protectedFields = []string{"component", "component.name", "component.version"}
+
+// Validate checks that the label patterns and field paths are well-formed.
+func (e *Exclusions) Validate() error {
+	for _, pattern := range e.Labels {
+		if pattern == "" {
+			return fmt.Errorf("label pattern must not be empty")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid label pattern %q: %w", pattern, err)
+		}
+	}
+	for _, field := range e.Fields {
+		elems := strings.Split(field, ".")
+		if slices.Contains(elems, "") {
+			return fmt.Errorf("invalid field path %q: empty path element", field)
+		}
+		if slices.Contains(protectedFields, field) ||
+			elems[0] == "*" ||
+			len(elems) == 2 && elems[0] == "component" && elems[1] == "*" {
+			return fmt.Errorf("field path %q would exclude the identity of the component", field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package v5alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	norms "ocm.software/open-component-model/bindings/go/descriptor/normalisation"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/engine/jcs"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Algorithm is the registered name of this normalisation algorithm with DefaultExclusions.
+const Algorithm = "jsonNormalisation/v5alpha1"
+
+// labelLists are the paths of all label lists in the v2 serialization of a component descriptor,
+// except for the labels of the provider, which is serialized as a JSON string.
+var labelLists = []string{
+	"component.labels",
+	"component.resources.*.labels",
+	"component.sources.*.labels",
+	"component.references.*.labels",
+}
+
+// init registers the normalisation algorithm with DefaultExclusions on package initialization.
+func init() {
+	norms.Normalisations.Register(Algorithm, algo{exclusions: DefaultExclusions})
+}
+
+// New returns the normalisation with the given exclusions.
+func New(exclusions *Exclusions) (norms.Normalisation, error) {
+	if err := exclusions.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid normalisation exclusions: %w", err)
+	}
+	return algo{exclusions: *exclusions.DeepCopy()}, nil
+}
+
+// Register registers the normalisation with the given exclusions under name in the normalisation
+// registry, so that it can be referenced as normalisation algorithm of digests and signatures.
+// The name must differ from the names of all other algorithms, as the exclusions are not recorded
+// in signatures.
+func Register(name string, exclusions *Exclusions) error {
+	if name == "" {
+		return fmt.Errorf("normalisation algorithm name must not be empty")
+	}
+	if norms.Normalisations.Get(name) != nil {
+		return fmt.Errorf("normalisation algorithm %q is already registered", name)
+	}
+	normalisation, err := New(exclusions)
+	if err != nil {
+		return err
+	}
+	norms.Normalisations.Register(name, normalisation)
+	return nil
+}
+
+// algo implements the normalisation interface for a set of exclusions.
+type algo struct {
+	exclusions Exclusions
+}
+
+// Normalise converts the descriptor to its v2 serialization, applies default values and the
+// exclusions, and normalises the result with the JCS algorithm and the rules of v4alpha1.
+func (a algo) Normalise(cd *descruntime.Descriptor) ([]byte, error) {
+	scheme := runtime.NewScheme(runtime.WithAllowUnknown())
+	desc, err := descruntime.ConvertToV2(scheme, cd)
+	if err != nil {
+		return nil, err
+	}
+	v4alpha1.DefaultComponent(desc)
+
+	data, err := json.Marshal(desc)
+	if err != nil {
+		return nil, err
+	}
+	var generic map[string]any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	if len(a.exclusions.Labels) > 0 {
+		for _, labels := range labelLists {
+			apply(generic, strings.Split(labels, "."), a.excludeLabels)
+		}
+		apply(generic, []string{"component", "provider"}, a.excludeProviderLabels)
+	}
+	for _, field := range a.exclusions.Fields {
+		apply(generic, strings.Split(field, "."), func(any) (any, bool) {
+			return nil, false
+		})
+	}
+
+	return jcs.Normalise(generic, v4alpha1.ExclusionRules)
+}
+
+// excludeLabels removes the labels with excluded names from a list of labels.
+func (a algo) excludeLabels(v any) (any, bool) {
+	labels, ok := v.([]any)
+	if !ok {
+		return v, true
+	}
+	return slices.DeleteFunc(labels, func(label any) bool {
+		m, ok := label.(map[string]any)
+		if !ok {
+			return false
+		}
+		name, _ := m["name"].(string)
+		return slices.ContainsFunc(a.exclusions.Labels, func(pattern string) bool {
+			matched, _ := path.Match(pattern, name)
+			return matched
+		})
+	}), true
+}
+
+// excludeProviderLabels removes the labels with excluded names from a provider serialized as JSON string.
+// A provider that is only a name is kept as is.
+func (a algo) excludeProviderLabels(v any) (any, bool) {
+	raw, ok := v.(string)
+	if !ok {
+		return v, true
+	}
+	var provider map[string]any
+	if err := json.Unmarshal([]byte(raw), &provider); err != nil {
+		return v, true
+	}
+	if labels, ok := provider["labels"]; ok {
+		provider["labels"], _ = a.excludeLabels(labels)
+	}
+	data, err := json.Marshal(provider)
+	if err != nil {
+		return v, true
+	}
+	return string(data), true
+}
+
+// apply calls fn for all values addressed by elems in v and replaces them with the result of fn.
+// If fn does not keep a value, it is removed from its map or list.
+// "*" addresses all entries of a map or list, any other element addresses the entry of a map with that key.
+func apply(v any, elems []string, fn func(any) (any, bool)) any {
+	visit := func(value any) (any, bool) {
+		if len(elems) == 1 {
+			return fn(value)
+		}
+		return apply(value, elems[1:], fn), true
+	}
+	switch typed := v.(type) {
+	case map[string]any:
+		for key, value := range typed {
+			if elems[0] != "*" && elems[0] != key {
+				continue
+			}
+			if mapped, keep := visit(value); keep {
+				typed[key] = mapped
+			} else {
+				delete(typed, key)
+			}
+		}
+		return typed
+	case []any:
+		if elems[0] != "*" {
+			return typed
+		}
+		result := make([]any, 0, len(typed))
+		for _, value := range typed {
+			if mapped, keep := visit(value); keep {
+				result = append(result, mapped)
+			}
+		}
+		return result
+	default:
+		return v
+	}
+}
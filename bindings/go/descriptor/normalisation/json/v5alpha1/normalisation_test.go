@@ -0,0 +1,130 @@
+package v5alpha1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v5alpha1"
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+)
+
+const descriptorYAML = `
+meta:
+  schemaVersion: v2
+component:
+  name: acme.org/test
+  version: 1.0.0
+  provider: '{"name":"acme.org","labels":[{"name":"acme.org/build/host","value":"a","signing":true}]}'
+  labels:
+  - name: acme.org/build/id
+    value: "1"
+    signing: true
+  - name: acme.org/owner
+    value: team
+    signing: true
+  resources:
+  - name: data
+    version: 1.0.0
+    type: blob
+    relation: local
+    extraIdentity:
+      architecture: amd64
+    labels:
+    - name: acme.org/build/id
+      value: "1"
+      signing: true
+    access:
+      type: localBlob/v1
+      localReference: sha256:abc
+      mediaType: application/octet-stream
+    digest:
+      hashAlgorithm: SHA-256
+      normalisationAlgorithm: genericBlobDigest/v1
+      value: abc
+`
+
+func parseDescriptor(t *testing.T, data string) *runtime.Descriptor {
+	t.Helper()
+	var desc descriptorv2.Descriptor
+	require.NoError(t, yaml.Unmarshal([]byte(data), &desc))
+	converted, err := runtime.ConvertFromV2(&desc)
+	require.NoError(t, err)
+	return converted
+}
+
+func TestNormalise_DefaultExclusions(t *testing.T) {
+	r := require.New(t)
+	desc := parseDescriptor(t, descriptorYAML)
+
+	v5, err := normalisation.Normalise(desc, v5alpha1.Algorithm)
+	r.NoError(err)
+	v4, err := normalisation.Normalise(desc, v4alpha1.Algorithm)
+	r.NoError(err)
+	r.Equal(string(v4), string(v5), "without declared exclusions the normalised form must equal v4alpha1")
+}
+
+func TestNormalise_Exclusions(t *testing.T) {
+	r := require.New(t)
+	name := v5alpha1.Algorithm + "+" + t.Name()
+	r.NoError(v5alpha1.Register(name, &v5alpha1.Exclusions{
+		Labels: []string{"acme.org/build/*"},
+		Fields: []string{"component.resources.*.extraIdentity.architecture"},
+	}))
+	r.ErrorContains(v5alpha1.Register(name, &v5alpha1.Exclusions{}), "already registered")
+
+	desc := parseDescriptor(t, descriptorYAML)
+	normalised, err := normalisation.Normalise(desc, name)
+	r.NoError(err)
+	r.NotContains(string(normalised), "acme.org/build")
+	r.NotContains(string(normalised), "architecture")
+	r.Contains(string(normalised), "acme.org/owner")
+
+	t.Run("stable if excluded fields are rewritten", func(t *testing.T) {
+		r := require.New(t)
+		rewritten := parseDescriptor(t, descriptorYAML)
+		rewritten.Component.Labels[0].Value = []byte(`"2"`)
+		rewritten.Component.Resources[0].ExtraIdentity["architecture"] = "arm64"
+		rewritten.Component.Resources[0].Labels = nil
+		rewrittenNormalised, err := normalisation.Normalise(rewritten, name)
+		r.NoError(err)
+		r.Equal(string(normalised), string(rewrittenNormalised))
+	})
+
+	t.Run("changes if other fields are rewritten", func(t *testing.T) {
+		r := require.New(t)
+		rewritten := parseDescriptor(t, descriptorYAML)
+		rewritten.Component.Labels[1].Value = []byte(`"other"`)
+		rewrittenNormalised, err := normalisation.Normalise(rewritten, name)
+		r.NoError(err)
+		r.NotEqual(string(normalised), string(rewrittenNormalised))
+	})
+}
+
+func TestExclusions_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		exclusions v5alpha1.Exclusions
+		err        string
+	}{
+		{name: "valid", exclusions: v5alpha1.Exclusions{Labels: []string{"acme.org/*"}, Fields: []string{"component.resources.*.access"}}},
+		{name: "empty label pattern", exclusions: v5alpha1.Exclusions{Labels: []string{""}}, err: "must not be empty"},
+		{name: "invalid label pattern", exclusions: v5alpha1.Exclusions{Labels: []string{"["}}, err: "invalid label pattern"},
+		{name: "empty path element", exclusions: v5alpha1.Exclusions{Fields: []string{"component..labels"}}, err: "empty path element"},
+		{name: "component name", exclusions: v5alpha1.Exclusions{Fields: []string{"component.name"}}, err: "identity of the component"},
+		{name: "whole component", exclusions: v5alpha1.Exclusions{Fields: []string{"component.*"}}, err: "identity of the component"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.exclusions.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"maps"
 	"strings"
+	"time"
 
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	"ocm.software/open-component-model/bindings/go/runtime"
@@ -222,20 +223,31 @@ func ConvertFromV2Sources(sources []v2.Source) []Source {
 	}
 	n := make([]Source, len(sources))
 	for i := range sources {
-		n[i].ElementMeta = ElementMeta{
+		n[i] = *ConvertFromV2Source(&sources[i])
+	}
+	return n
+}
+
+// ConvertFromV2Source converts a v2 source to internal representation.
+func ConvertFromV2Source(src *v2.Source) *Source {
+	if src == nil {
+		return nil
+	}
+	source := Source{
+		ElementMeta: ElementMeta{
 			ObjectMeta: ObjectMeta{
-				Name:    sources[i].Name,
-				Version: sources[i].Version,
-				Labels:  ConvertFromV2Labels(sources[i].Labels),
+				Name:    src.Name,
+				Version: src.Version,
+				Labels:  ConvertFromV2Labels(src.Labels),
 			},
-			ExtraIdentity: sources[i].ExtraIdentity.DeepCopy(),
-		}
-		if sources[i].Access != nil {
-			n[i].Access = sources[i].Access.DeepCopy()
-		}
-		n[i].Type = sources[i].Type
+			ExtraIdentity: src.ExtraIdentity.DeepCopy(),
+		},
+		Type: src.Type,
 	}
-	return n
+	if src.Access != nil {
+		source.Access = src.Access.DeepCopy()
+	}
+	return &source
 }
 
 // ConvertFromV2References converts v2 references to internal references.
@@ -245,16 +257,26 @@ func ConvertFromV2References(references []v2.Reference) []Reference {
 	}
 	n := make([]Reference, len(references))
 	for i := range references {
-		n[i].Name = references[i].Name
-		n[i].Version = references[i].Version
-		n[i].Labels = ConvertFromV2Labels(references[i].Labels)
-		n[i].ExtraIdentity = references[i].ExtraIdentity.DeepCopy()
-		n[i].Component = references[i].Component
-		n[i].Digest = *ConvertFromV2Digest(&references[i].Digest)
+		n[i] = *ConvertFromV2Reference(&references[i])
 	}
 	return n
 }
 
+// ConvertFromV2Reference converts a v2 reference to internal representation.
+func ConvertFromV2Reference(ref *v2.Reference) *Reference {
+	if ref == nil {
+		return nil
+	}
+	var reference Reference
+	reference.Name = ref.Name
+	reference.Version = ref.Version
+	reference.Labels = ConvertFromV2Labels(ref.Labels)
+	reference.ExtraIdentity = ref.ExtraIdentity.DeepCopy()
+	reference.Component = ref.Component
+	reference.Digest = *ConvertFromV2Digest(&ref.Digest)
+	return &reference
+}
+
 // ConvertFromV2Signatures converts v2 signatures to internal format.
 func ConvertFromV2Signatures(signatures []v2.Signature) []Signature {
 	if signatures == nil {
@@ -275,9 +297,23 @@ func ConvertFromV2Signature(signature *v2.Signature) *Signature {
 		Name:      signature.Name,
 		Digest:    *ConvertFromV2Digest(&signature.Digest),
 		Signature: *ConvertFromV2SignatureInfo(&signature.Signature),
+		Timestamp: ConvertFromV2Timestamp(signature.Timestamp),
 	}
 }
 
+func ConvertFromV2Timestamp(timestamp *v2.TimestampSpec) *TimestampSpec {
+	if timestamp == nil {
+		return nil
+	}
+	n := &TimestampSpec{
+		Value: timestamp.Value,
+	}
+	if timestamp.Time != nil {
+		n.Time = timestamp.Time.Time.Time
+	}
+	return n
+}
+
 func ConvertFromV2SignatureInfo(signature *v2.SignatureInfo) *SignatureInfo {
 	if signature == nil {
 		return nil
@@ -487,9 +523,23 @@ func ConvertToV2Signature(sig *Signature) *v2.Signature {
 		Name:      sig.Name,
 		Digest:    *ConvertToV2Digest(&sig.Digest),
 		Signature: *ConvertToV2SignatureInfo(&sig.Signature),
+		Timestamp: ConvertToV2Timestamp(sig.Timestamp),
 	}
 }
 
+func ConvertToV2Timestamp(timestamp *TimestampSpec) *v2.TimestampSpec {
+	if timestamp == nil {
+		return nil
+	}
+	n := &v2.TimestampSpec{
+		Value: timestamp.Value,
+	}
+	if !timestamp.Time.IsZero() {
+		n.Time = &v2.Timestamp{Time: v2.NewTime(timestamp.Time.UTC().Round(time.Second))}
+	}
+	return n
+}
+
 func ConvertToV2SignatureInfo(sig *SignatureInfo) *v2.SignatureInfo {
 	if sig == nil {
 		return nil
@@ -516,6 +566,9 @@ func ConvertFromV2LocalBlob(scheme *runtime.Scheme, blob *v2.LocalBlob) (*LocalB
 	if blob.GlobalAccess != nil {
 		result.GlobalAccess = blob.GlobalAccess.DeepCopy()
 	}
+	if blob.Encryption != nil {
+		result.Encryption = (*LocalBlobEncryption)(blob.Encryption.DeepCopy())
+	}
 	return result, nil
 }
 
@@ -536,5 +589,8 @@ func ConvertToV2LocalBlob(scheme *runtime.Scheme, blob *LocalBlob) (*v2.LocalBlo
 			return nil, fmt.Errorf("could not convert global access: %w", err)
 		}
 	}
+	if blob.Encryption != nil {
+		result.Encryption = (*v2.LocalBlobEncryption)(blob.Encryption.DeepCopy())
+	}
 	return result, nil
 }
@@ -3,6 +3,7 @@ package runtime_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -506,12 +507,38 @@ func TestConvertFromV2Signatures(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with timestamp",
+			signatures: []v2.Signature{
+				{
+					Name:      "test",
+					Signature: v2.SignatureInfo{Value: "test-value"},
+					Timestamp: &v2.TimestampSpec{
+						Value: "test-token",
+						Time:  &v2.Timestamp{Time: v2.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))},
+					},
+				},
+			},
+			want: []descriptorRuntime.Signature{
+				{
+					Name:      "test",
+					Signature: descriptorRuntime.SignatureInfo{Value: "test-value"},
+					Timestamp: &descriptorRuntime.TimestampSpec{
+						Value: "test-token",
+						Time:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := descriptorRuntime.ConvertFromV2Signatures(tt.signatures)
 			assert.Equal(t, tt.want, got)
+			if got != nil {
+				assert.Equal(t, tt.signatures, descriptorRuntime.ConvertToV2Signatures(got))
+			}
 		})
 	}
 }
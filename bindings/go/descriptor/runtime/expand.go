@@ -0,0 +1,219 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrReferenceCycle is returned by ExpandedDescriptor.Walk if a component version references itself, directly or
+// through other component versions.
+var ErrReferenceCycle = errors.New("reference cycle detected")
+
+// ComponentVersionGetter gets the descriptor of a component version.
+// It is implemented by component version repositories.
+type ComponentVersionGetter interface {
+	GetComponentVersion(ctx context.Context, component, version string) (*Descriptor, error)
+}
+
+// ExpandedDescriptor is a component descriptor together with the resolved descriptors of its references.
+type ExpandedDescriptor struct {
+	*Descriptor
+	// References contains the references of the component in the order of Component.References.
+	// It is empty if the expansion depth was reached.
+	References []*ExpandedReference
+}
+
+// ExpandedReference is a reference of a component together with the descriptor of the referenced component version.
+// The descriptor is fetched on the first call to Resolve.
+type ExpandedReference struct {
+	Reference
+
+	cache  *referenceCache
+	parent *Descriptor
+	depth  int
+
+	mu       sync.Mutex
+	expanded *ExpandedDescriptor
+}
+
+// ExpandReferences returns an expanded view of the descriptor, in which every reference carries the descriptor of the
+// referenced component version, up to the given depth of nested references.
+// With a depth of 1 only the direct references are expanded, with a negative depth all references are expanded.
+//
+// Referenced descriptors are fetched from the getter lazily when a reference is resolved. They are cached by the
+// digest of the reference, or by component name and version for references without digest, so a component version
+// referenced several times in the graph is fetched only once.
+// With WithDigestVerifier, every reference is verified against the fetched descriptor before the descriptor is
+// cached or returned for it, like in ResolveReferences. The other options of ResolveReferences do not apply.
+func ExpandReferences(ctx context.Context, getter ComponentVersionGetter, desc *Descriptor, depth int, opts ...ResolveOption) (*ExpandedDescriptor, error) {
+	if desc == nil {
+		return nil, errors.New("descriptor must not be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var options ResolveOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return newReferenceCache(getter, options.VerifyDigest).expand(desc, depth), nil
+}
+
+// Resolve returns the expanded descriptor of the referenced component version.
+// The descriptor is fetched on the first successful call, later calls return the same result.
+func (r *ExpandedReference) Resolve(ctx context.Context) (*ExpandedDescriptor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.expanded != nil {
+		return r.expanded, nil
+	}
+	desc, err := r.cache.get(ctx, r.parent, &r.Reference)
+	if err != nil {
+		return nil, err
+	}
+	r.expanded = r.cache.expand(desc, r.depth)
+	return r.expanded, nil
+}
+
+// Walk calls fn for the descriptor and then for all expanded references depth first, resolving them on the way.
+// The path contains the references leading from the root to the current descriptor and is empty for the root.
+// If fn returns an error, the walk is stopped and the error is returned.
+// Walk returns an error matching ErrReferenceCycle if a component version references itself.
+func (d *ExpandedDescriptor) Walk(ctx context.Context, fn func(ctx context.Context, path []*Reference, desc *Descriptor) error) error {
+	return d.walk(ctx, nil, map[string]bool{}, fn)
+}
+
+func (d *ExpandedDescriptor) walk(ctx context.Context, path []*Reference, visiting map[string]bool, fn func(ctx context.Context, path []*Reference, desc *Descriptor) error) error {
+	key := d.Component.Name + ":" + d.Component.Version
+	if visiting[key] {
+		return fmt.Errorf("component version %s: %w", key, ErrReferenceCycle)
+	}
+	visiting[key] = true
+	defer delete(visiting, key)
+
+	if err := fn(ctx, path, d.Descriptor); err != nil {
+		return err
+	}
+
+	for _, ref := range d.References {
+		resolved, err := ref.Resolve(ctx)
+		if err != nil {
+			return err
+		}
+		if err := resolved.walk(ctx, append(path[:len(path):len(path)], &ref.Reference), visiting, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// referenceCache fetches and caches the descriptors of references, see referenceCacheKey.
+// It is shared by ExpandReferences and ResolveReferences.
+//
+// If a DigestVerifier is set, a descriptor is verified once for the digest it is cached by, and a
+// descriptor fetched for a reference it does not match is not cached.
+type referenceCache struct {
+	getter       ComponentVersionGetter
+	verifyDigest DigestVerifier
+
+	mu      sync.Mutex
+	entries map[string]*referenceCacheEntry
+}
+
+// referenceCacheEntry holds a fetched descriptor. Failed fetches are not cached, so they are retried.
+type referenceCacheEntry struct {
+	mu   sync.Mutex
+	desc *Descriptor
+	// verified is set once desc was verified against the digest of the entry.
+	verified bool
+}
+
+func newReferenceCache(getter ComponentVersionGetter, verifyDigest DigestVerifier) *referenceCache {
+	return &referenceCache{
+		getter:       getter,
+		verifyDigest: verifyDigest,
+		entries:      make(map[string]*referenceCacheEntry),
+	}
+}
+
+func (c *referenceCache) expand(desc *Descriptor, depth int) *ExpandedDescriptor {
+	expanded := &ExpandedDescriptor{Descriptor: desc}
+	if depth == 0 {
+		return expanded
+	}
+	expanded.References = make([]*ExpandedReference, len(desc.Component.References))
+	for i, ref := range desc.Component.References {
+		expanded.References[i] = &ExpandedReference{
+			Reference: ref,
+			cache:     c,
+			parent:    desc,
+			depth:     depth - 1,
+		}
+	}
+	return expanded
+}
+
+// referenceCacheKey returns the key of the descriptor a reference points to.
+// References with digest are keyed by the digest, so references pinning different descriptors of the
+// same component version do not share a descriptor. References without digest, and the root of a graph,
+// are keyed by component name and version.
+func referenceCacheKey(component, version string, digest Digest) string {
+	key := component + ":" + version
+	if digest.Value != "" {
+		key += "@" + digest.HashAlgorithm + ":" + digest.NormalisationAlgorithm + ":" + digest.Value
+	}
+	return key
+}
+
+// entry returns the cache entry of the key, creating it if necessary.
+func (c *referenceCache) entry(key string) *referenceCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &referenceCacheEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+// add caches a descriptor that is known without reference, e.g. the root of a graph.
+func (c *referenceCache) add(desc *Descriptor) {
+	entry := c.entry(referenceCacheKey(desc.Component.Name, desc.Component.Version, Digest{}))
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	entry.desc = desc
+}
+
+// get returns the descriptor of the component version the reference of parent points to.
+// The descriptor is fetched once and verified against the reference if a DigestVerifier is set.
+func (c *referenceCache) get(ctx context.Context, parent *Descriptor, ref *Reference) (*Descriptor, error) {
+	entry := c.entry(referenceCacheKey(ref.Component, ref.Version, ref.Digest))
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	desc := entry.desc
+	if desc == nil {
+		fetched, err := c.getter.GetComponentVersion(ctx, ref.Component, ref.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get component version %s:%s of reference %s: %w", ref.Component, ref.Version, ref.Name, err)
+		}
+		if fetched == nil {
+			return nil, fmt.Errorf("component version %s:%s of reference %s not found", ref.Component, ref.Version, ref.Name)
+		}
+		desc = fetched
+	}
+
+	if c.verifyDigest != nil && !entry.verified {
+		if err := c.verifyDigest(ctx, ref, desc); err != nil {
+			return nil, fmt.Errorf("digest verification of reference %s of component version %s failed: %w",
+				ref.Name, componentVersionKey(parent), err)
+		}
+		entry.verified = true
+	}
+
+	entry.desc = desc
+	return desc, nil
+}
@@ -0,0 +1,174 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+)
+
+type countingGetter struct {
+	descriptors map[string]*descriptor.Descriptor
+	calls       map[string]int
+}
+
+func (g *countingGetter) GetComponentVersion(_ context.Context, component, version string) (*descriptor.Descriptor, error) {
+	key := component + ":" + version
+	g.calls[key]++
+	desc, ok := g.descriptors[key]
+	if !ok {
+		return nil, fmt.Errorf("component version %s not found", key)
+	}
+	return desc, nil
+}
+
+func newComponent(name, version string, refs ...string) *descriptor.Descriptor {
+	desc := &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: name, Version: version}},
+		},
+	}
+	for _, ref := range refs {
+		desc.Component.References = append(desc.Component.References, descriptor.Reference{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: ref, Version: "1.0.0"}},
+			Component:   "ocm.software/" + ref,
+			Digest:      descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v4alpha1", Value: ref},
+		})
+	}
+	return desc
+}
+
+func TestExpandReferences(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	getter := &countingGetter{
+		descriptors: map[string]*descriptor.Descriptor{
+			"ocm.software/frontend:1.0.0": newComponent("ocm.software/frontend", "1.0.0", "lib"),
+			"ocm.software/backend:1.0.0":  newComponent("ocm.software/backend", "1.0.0", "lib"),
+			"ocm.software/lib:1.0.0":      newComponent("ocm.software/lib", "1.0.0"),
+		},
+		calls: map[string]int{},
+	}
+	root := newComponent("ocm.software/app", "1.0.0", "frontend", "backend")
+
+	expanded, err := descriptor.ExpandReferences(ctx, getter, root, -1)
+	r.NoError(err)
+	r.Len(expanded.References, 2)
+	r.Empty(getter.calls, "references must be fetched lazily")
+
+	var visited []string
+	r.NoError(expanded.Walk(ctx, func(_ context.Context, path []*descriptor.Reference, desc *descriptor.Descriptor) error {
+		visited = append(visited, fmt.Sprintf("%d:%s", len(path), desc.Component.Name))
+		return nil
+	}))
+	r.Equal([]string{
+		"0:ocm.software/app",
+		"1:ocm.software/frontend",
+		"2:ocm.software/lib",
+		"1:ocm.software/backend",
+		"2:ocm.software/lib",
+	}, visited)
+	r.Equal(1, getter.calls["ocm.software/lib:1.0.0"], "references to the same component version must be fetched once")
+
+	shallow, err := descriptor.ExpandReferences(ctx, getter, root, 1)
+	r.NoError(err)
+	frontend, err := shallow.References[0].Resolve(ctx)
+	r.NoError(err)
+	r.Equal("ocm.software/frontend", frontend.Component.Name)
+	r.Empty(frontend.References, "references beyond the depth must not be expanded")
+}
+
+func TestExpandReferences_Errors(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	getter := &countingGetter{
+		descriptors: map[string]*descriptor.Descriptor{
+			"ocm.software/a:1.0.0": newComponent("ocm.software/a", "1.0.0", "b"),
+			"ocm.software/b:1.0.0": newComponent("ocm.software/b", "1.0.0", "a"),
+		},
+		calls: map[string]int{},
+	}
+
+	expanded, err := descriptor.ExpandReferences(ctx, getter, newComponent("ocm.software/a", "1.0.0", "b"), -1)
+	r.NoError(err)
+	err = expanded.Walk(ctx, func(context.Context, []*descriptor.Reference, *descriptor.Descriptor) error { return nil })
+	r.ErrorIs(err, descriptor.ErrReferenceCycle)
+
+	expanded, err = descriptor.ExpandReferences(ctx, getter, newComponent("ocm.software/c", "1.0.0", "missing"), -1)
+	r.NoError(err)
+	_, err = expanded.References[0].Resolve(ctx)
+	r.ErrorContains(err, "failed to get component version ocm.software/missing:1.0.0 of reference missing")
+	_, err = expanded.References[0].Resolve(ctx)
+	r.Error(err)
+	r.Equal(2, getter.calls["ocm.software/missing:1.0.0"], "failed fetches must not be cached")
+}
+
+func TestExpandReferences_DigestVerification(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	getter := &countingGetter{
+		descriptors: map[string]*descriptor.Descriptor{
+			// the registry serves a descriptor that does not match the digest of the reference.
+			"ocm.software/lib:1.0.0": newComponent("ocm.software/tampered", "1.0.0"),
+		},
+		calls: map[string]int{},
+	}
+	verifications := 0
+	verifier := func(_ context.Context, ref *descriptor.Reference, desc *descriptor.Descriptor) error {
+		verifications++
+		if desc.Component.Name != "ocm.software/"+ref.Digest.Value {
+			return errors.New("digest mismatch")
+		}
+		return nil
+	}
+	root := newComponent("ocm.software/app", "1.0.0", "lib", "lib")
+
+	expanded, err := descriptor.ExpandReferences(ctx, getter, root, -1, descriptor.WithDigestVerifier(verifier))
+	r.NoError(err)
+	_, err = expanded.References[0].Resolve(ctx)
+	r.ErrorContains(err, "digest verification of reference lib of component version ocm.software/app:1.0.0 failed: digest mismatch")
+
+	getter.descriptors["ocm.software/lib:1.0.0"] = newComponent("ocm.software/lib", "1.0.0")
+	lib, err := expanded.References[1].Resolve(ctx)
+	r.NoError(err)
+	r.Equal("ocm.software/lib", lib.Component.Name)
+	r.Equal(2, getter.calls["ocm.software/lib:1.0.0"], "descriptors failing verification must not be cached")
+
+	_, err = expanded.References[0].Resolve(ctx)
+	r.NoError(err)
+	r.Equal(2, getter.calls["ocm.software/lib:1.0.0"])
+	r.Equal(2, verifications, "a descriptor is verified once per reference digest")
+}
+
+func TestExpandReferences_CacheKey(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	getter := &countingGetter{
+		descriptors: map[string]*descriptor.Descriptor{
+			"ocm.software/lib:1.0.0": newComponent("ocm.software/lib", "1.0.0"),
+		},
+		calls: map[string]int{},
+	}
+	root := newComponent("ocm.software/app", "1.0.0", "lib", "lib", "lib", "lib")
+	refs := root.Component.References
+	refs[1].Digest.Value = "other"
+	refs[2].Digest = descriptor.Digest{}
+	refs[3].Digest = descriptor.Digest{}
+
+	expanded, err := descriptor.ExpandReferences(ctx, getter, root, -1)
+	r.NoError(err)
+	for _, ref := range expanded.References {
+		_, err := ref.Resolve(ctx)
+		r.NoError(err)
+	}
+	r.Equal(3, getter.calls["ocm.software/lib:1.0.0"], "references must be cached by digest, or by component version without digest")
+}
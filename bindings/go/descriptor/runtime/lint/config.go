@@ -0,0 +1,31 @@
+package lint
+
+import (
+	"fmt"
+)
+
+// DefaultMaxLabelSize is the default maximum size of a label value in bytes.
+const DefaultMaxLabelSize = 4 * 1024
+
+// Config configures the built-in rules of a Linter.
+type Config struct {
+	// Severities overrides the severity of rules by name. SeverityOff disables a rule.
+	Severities map[string]Severity `json:"severities,omitempty"`
+	// RequiredLabels are the names of labels every component must have, e.g. because of organisational policies.
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+	// MaxLabelSize is the maximum size of a label value in bytes. Defaults to DefaultMaxLabelSize.
+	MaxLabelSize int `json:"maxLabelSize,omitempty"`
+}
+
+// Validate checks that the configured severities and sizes are valid.
+func (c *Config) Validate() error {
+	for name, severity := range c.Severities {
+		if err := severity.Validate(); err != nil {
+			return fmt.Errorf("invalid severity for lint rule %q: %w", name, err)
+		}
+	}
+	if c.MaxLabelSize < 0 {
+		return fmt.Errorf("maxLabelSize must not be negative")
+	}
+	return nil
+}
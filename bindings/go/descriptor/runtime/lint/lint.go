@@ -0,0 +1,343 @@
+// Package lint checks component descriptors against rules that go beyond schema validation,
+// such as organisational label policies, descriptor size or insecure accesses.
+//
+// A [Linter] runs a set of [Rule]s over a descriptor and reports [Finding]s with a [Severity].
+// The built-in rules are configured with a [Config], which is meant to be loaded from a
+// configuration file, and can be extended with custom rules created with [NewRule] or [NewElementRule].
+//
+// Rules implementing [ElementRule] check the resources, sources and references of a descriptor one at a
+// time, which allows [Linter.LintStream] to lint very large descriptors without decoding them completely.
+package lint
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Severity is the severity of a finding.
+type Severity string
+
+const (
+	// SeverityOff disables a rule.
+	SeverityOff Severity = "off"
+	// SeverityInfo marks findings that are informational only.
+	SeverityInfo Severity = "info"
+	// SeverityWarning marks findings that should be fixed.
+	SeverityWarning Severity = "warning"
+	// SeverityError marks findings that must be fixed.
+	SeverityError Severity = "error"
+)
+
+var severityLevels = []Severity{SeverityOff, SeverityInfo, SeverityWarning, SeverityError}
+
+// Validate checks that the severity is known.
+func (s Severity) Validate() error {
+	if !slices.Contains(severityLevels, s) {
+		return fmt.Errorf("unknown severity %q, expected one of %v", s, severityLevels)
+	}
+	return nil
+}
+
+// AtLeast reports whether the severity is at least as severe as other.
+func (s Severity) AtLeast(other Severity) bool {
+	return slices.Index(severityLevels, s) >= slices.Index(severityLevels, other)
+}
+
+// Finding is a violation of a rule.
+type Finding struct {
+	// Rule is the name of the violated rule.
+	Rule string `json:"rule"`
+	// Severity is the severity of the violated rule.
+	Severity Severity `json:"severity"`
+	// Path locates the violating element in the descriptor, e.g. component.resources[1].labels[0].
+	Path string `json:"path"`
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", f.Severity, f.Path, f.Message, f.Rule)
+}
+
+// Rule checks a descriptor. Rules only report the Path and Message of their findings,
+// the Linter fills in the rule name and severity.
+type Rule interface {
+	// Name is the unique name of the rule, used to configure its severity.
+	Name() string
+	// DefaultSeverity is the severity of the rule unless configured otherwise.
+	DefaultSeverity() Severity
+	// Check returns the findings of the rule for the descriptor.
+	Check(desc *descruntime.Descriptor) []Finding
+}
+
+type ruleFunc struct {
+	name     string
+	severity Severity
+	check    func(desc *descruntime.Descriptor) []Finding
+}
+
+func (r *ruleFunc) Name() string                                 { return r.name }
+func (r *ruleFunc) DefaultSeverity() Severity                    { return r.severity }
+func (r *ruleFunc) Check(desc *descruntime.Descriptor) []Finding { return r.check(desc) }
+
+// NewRule creates a rule from a check function.
+func NewRule(name string, severity Severity, check func(desc *descruntime.Descriptor) []Finding) Rule {
+	return &ruleFunc{name: name, severity: severity, check: check}
+}
+
+// ElementKind is the kind of an Element.
+type ElementKind string
+
+const (
+	ElementKindResource  ElementKind = "resource"
+	ElementKindSource    ElementKind = "source"
+	ElementKindReference ElementKind = "reference"
+)
+
+// Element is a resource, source or reference of a descriptor.
+type Element struct {
+	Kind ElementKind
+	// Path locates the element in the descriptor, e.g. component.resources[1].
+	Path string
+	Meta *descruntime.ElementMeta
+	// Access is the access of a resource or source, nil for references.
+	Access runtime.Typed
+}
+
+// ElementCheck checks a single descriptor one element at a time.
+type ElementCheck interface {
+	// CheckElement checks a resource, source or reference. Elements of the same kind are passed in order.
+	CheckElement(elem Element) []Finding
+	// CheckComponent is called once after all elements. When linting a stream, the descriptor does not
+	// contain resources, sources and references.
+	CheckComponent(desc *descruntime.Descriptor) []Finding
+}
+
+// ElementRule is a Rule that checks the elements of a descriptor one at a time.
+// Linter.LintStream only streams a descriptor if all enabled rules are ElementRules.
+type ElementRule interface {
+	Rule
+	// NewElementCheck returns the check of the rule for a single descriptor.
+	NewElementCheck() ElementCheck
+}
+
+type elementRule struct {
+	name     string
+	severity Severity
+	newCheck func() ElementCheck
+}
+
+func (r *elementRule) Name() string                  { return r.name }
+func (r *elementRule) DefaultSeverity() Severity     { return r.severity }
+func (r *elementRule) NewElementCheck() ElementCheck { return r.newCheck() }
+
+// Check runs a new check over all elements of the descriptor. Findings of the component come first,
+// followed by the findings of the resources, sources and references.
+func (r *elementRule) Check(desc *descruntime.Descriptor) []Finding {
+	check := r.newCheck()
+	var findings []Finding
+	for _, elem := range elements(desc) {
+		findings = append(findings, check.CheckElement(elem)...)
+	}
+	return append(check.CheckComponent(desc), findings...)
+}
+
+// NewElementRule creates an ElementRule creating a new check with newCheck for every descriptor.
+func NewElementRule(name string, severity Severity, newCheck func() ElementCheck) ElementRule {
+	return &elementRule{name: name, severity: severity, newCheck: newCheck}
+}
+
+// checkFuncs is an ElementCheck made of optional functions.
+type checkFuncs struct {
+	component func(desc *descruntime.Descriptor) []Finding
+	element   func(elem Element) []Finding
+}
+
+func (c *checkFuncs) CheckComponent(desc *descruntime.Descriptor) []Finding {
+	if c.component == nil {
+		return nil
+	}
+	return c.component(desc)
+}
+
+func (c *checkFuncs) CheckElement(elem Element) []Finding {
+	if c.element == nil {
+		return nil
+	}
+	return c.element(elem)
+}
+
+// componentOnly creates checks that only check the component.
+func componentOnly(check func(desc *descruntime.Descriptor) []Finding) func() ElementCheck {
+	return func() ElementCheck {
+		return &checkFuncs{component: check}
+	}
+}
+
+func resourceElement(index int, res *descruntime.Resource) Element {
+	return Element{ElementKindResource, fmt.Sprintf("component.resources[%d]", index), &res.ElementMeta, res.Access}
+}
+
+func sourceElement(index int, src *descruntime.Source) Element {
+	return Element{ElementKindSource, fmt.Sprintf("component.sources[%d]", index), &src.ElementMeta, src.Access}
+}
+
+func referenceElement(index int, ref *descruntime.Reference) Element {
+	return Element{ElementKindReference, fmt.Sprintf("component.references[%d]", index), &ref.ElementMeta, nil}
+}
+
+// elements returns the resources, sources and references of the descriptor in this order.
+func elements(desc *descruntime.Descriptor) []Element {
+	elems := make([]Element, 0, len(desc.Component.Resources)+len(desc.Component.Sources)+len(desc.Component.References))
+	for i := range desc.Component.Resources {
+		elems = append(elems, resourceElement(i, &desc.Component.Resources[i]))
+	}
+	for i := range desc.Component.Sources {
+		elems = append(elems, sourceElement(i, &desc.Component.Sources[i]))
+	}
+	for i := range desc.Component.References {
+		elems = append(elems, referenceElement(i, &desc.Component.References[i]))
+	}
+	return elems
+}
+
+// Result are the findings of a lint run, ordered by rule.
+type Result []Finding
+
+// HasFindings reports whether the result contains findings of at least the given severity.
+func (r Result) HasFindings(severity Severity) bool {
+	return slices.ContainsFunc(r, func(f Finding) bool {
+		return f.Severity.AtLeast(severity)
+	})
+}
+
+// Linter runs rules over descriptors.
+type Linter struct {
+	rules      []Rule
+	severities map[string]Severity
+}
+
+// New creates a Linter running the built-in rules configured by cfg, followed by the given custom rules.
+func New(cfg Config, rules ...Rule) (*Linter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	all := append(DefaultRules(cfg), rules...)
+	names := make(map[string]struct{}, len(all))
+	for _, rule := range all {
+		if _, ok := names[rule.Name()]; ok {
+			return nil, fmt.Errorf("duplicate lint rule %q", rule.Name())
+		}
+		names[rule.Name()] = struct{}{}
+	}
+	for name := range cfg.Severities {
+		if _, ok := names[name]; !ok {
+			return nil, fmt.Errorf("severity configured for unknown lint rule %q", name)
+		}
+	}
+	return &Linter{rules: all, severities: cfg.Severities}, nil
+}
+
+// Rules returns the rules of the linter with their effective severity.
+func (l *Linter) Rules() map[string]Severity {
+	rules := make(map[string]Severity, len(l.rules))
+	for _, rule := range l.rules {
+		rules[rule.Name()] = l.severity(rule)
+	}
+	return rules
+}
+
+// Lint runs all enabled rules over the descriptor.
+func (l *Linter) Lint(desc *descruntime.Descriptor) Result {
+	var result Result
+	for _, rule := range l.rules {
+		severity := l.severity(rule)
+		if severity == SeverityOff {
+			continue
+		}
+		result = appendFindings(result, rule, severity, rule.Check(desc))
+	}
+	return result
+}
+
+// LintStream runs all enabled rules over the JSON encoded v2 descriptor read from r and returns the same
+// result as Lint. If all enabled rules are ElementRules, the resources, sources and references are checked
+// while the descriptor is decoded and are never held in memory at once. Otherwise, the descriptor is
+// decoded completely.
+func (l *Linter) LintStream(r io.Reader) (Result, error) {
+	type streamCheck struct {
+		rule     Rule
+		severity Severity
+		check    ElementCheck
+		findings map[ElementKind][]Finding
+	}
+	var checks []*streamCheck
+	for _, rule := range l.rules {
+		severity := l.severity(rule)
+		if severity == SeverityOff {
+			continue
+		}
+		elementRule, ok := rule.(ElementRule)
+		if !ok {
+			desc, err := descruntime.DecodeStream(r, descruntime.StreamHandlers{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Lint(desc), nil
+		}
+		checks = append(checks, &streamCheck{rule, severity, elementRule.NewElementCheck(), make(map[ElementKind][]Finding)})
+	}
+
+	checkElement := func(elem Element) error {
+		for _, c := range checks {
+			c.findings[elem.Kind] = append(c.findings[elem.Kind], c.check.CheckElement(elem)...)
+		}
+		return nil
+	}
+	desc, err := descruntime.DecodeStream(r, descruntime.StreamHandlers{
+		Resource: func(index int, res *descruntime.Resource) error {
+			return checkElement(resourceElement(index, res))
+		},
+		Source: func(index int, src *descruntime.Source) error {
+			return checkElement(sourceElement(index, src))
+		},
+		Reference: func(index int, ref *descruntime.Reference) error {
+			return checkElement(referenceElement(index, ref))
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result Result
+	for _, c := range checks {
+		findings := slices.Concat(
+			c.check.CheckComponent(desc),
+			c.findings[ElementKindResource],
+			c.findings[ElementKindSource],
+			c.findings[ElementKindReference],
+		)
+		result = appendFindings(result, c.rule, c.severity, findings)
+	}
+	return result, nil
+}
+
+func appendFindings(result Result, rule Rule, severity Severity, findings []Finding) Result {
+	for _, finding := range findings {
+		finding.Rule = rule.Name()
+		finding.Severity = severity
+		result = append(result, finding)
+	}
+	return result
+}
+
+func (l *Linter) severity(rule Rule) Severity {
+	if severity, ok := l.severities[rule.Name()]; ok {
+		return severity
+	}
+	return rule.DefaultSeverity()
+}
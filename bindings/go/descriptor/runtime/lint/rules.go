@@ -0,0 +1,180 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+)
+
+// Names of the built-in rules.
+const (
+	// RuleRequiredLabels reports components missing labels configured in Config.RequiredLabels.
+	RuleRequiredLabels = "required-labels"
+	// RuleLabelSize reports label values larger than Config.MaxLabelSize, which bloat descriptors
+	// and should be stored as resources instead.
+	RuleLabelSize = "label-size"
+	// RuleSemverVersion reports component and element versions that are not MAJOR.MINOR.PATCH semantic
+	// versions (with optional leading v), which breaks version ordering and constraints.
+	RuleSemverVersion = "semver-version"
+	// RuleDuplicateIdentity reports resources, sources and references with the same identity.
+	RuleDuplicateIdentity = "duplicate-identity"
+	// RuleInsecureAccess reports accesses of resources and sources using plain http URLs.
+	RuleInsecureAccess = "insecure-access"
+)
+
+// DefaultRules returns the built-in rules configured by cfg.
+func DefaultRules(cfg Config) []Rule {
+	maxLabelSize := cfg.MaxLabelSize
+	if maxLabelSize == 0 {
+		maxLabelSize = DefaultMaxLabelSize
+	}
+	return []Rule{
+		NewElementRule(RuleRequiredLabels, SeverityError, componentOnly(requiredLabels(cfg.RequiredLabels))),
+		NewElementRule(RuleLabelSize, SeverityWarning, labelSize(maxLabelSize)),
+		NewElementRule(RuleSemverVersion, SeverityWarning, semverVersions),
+		NewElementRule(RuleDuplicateIdentity, SeverityError, duplicateIdentities),
+		NewElementRule(RuleInsecureAccess, SeverityWarning, insecureAccesses),
+	}
+}
+
+func requiredLabels(names []string) func(desc *descruntime.Descriptor) []Finding {
+	return func(desc *descruntime.Descriptor) []Finding {
+		var findings []Finding
+		for _, name := range names {
+			if !slices.ContainsFunc(desc.Component.Labels, func(l descruntime.Label) bool { return l.Name == name }) {
+				findings = append(findings, Finding{
+					Path:    "component.labels",
+					Message: fmt.Sprintf("component %s is missing required label %q", desc.Component.Name, name),
+				})
+			}
+		}
+		return findings
+	}
+}
+
+func labelSize(maxSize int) func() ElementCheck {
+	check := func(path string, labels []descruntime.Label) []Finding {
+		var findings []Finding
+		for i, label := range labels {
+			if len(label.Value) > maxSize {
+				findings = append(findings, Finding{
+					Path:    fmt.Sprintf("%s.labels[%d]", path, i),
+					Message: fmt.Sprintf("value of label %q has %d bytes, more than the maximum of %d bytes", label.Name, len(label.Value), maxSize),
+				})
+			}
+		}
+		return findings
+	}
+	return func() ElementCheck {
+		return &checkFuncs{
+			component: func(desc *descruntime.Descriptor) []Finding {
+				return append(check("component", desc.Component.Labels), check("component.provider", desc.Component.Provider.Labels)...)
+			},
+			element: func(elem Element) []Finding {
+				return check(elem.Path, elem.Meta.Labels)
+			},
+		}
+	}
+}
+
+// semverRegex matches MAJOR.MINOR.PATCH semantic versions with optional pre-release, build metadata and leading v.
+var semverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+func checkSemver(path, name, version string) []Finding {
+	if version == "" || semverRegex.MatchString(version) {
+		return nil
+	}
+	return []Finding{{
+		Path:    path + ".version",
+		Message: fmt.Sprintf("version %q of %s is not a semantic version", version, name),
+	}}
+}
+
+func semverVersions() ElementCheck {
+	return &checkFuncs{
+		component: func(desc *descruntime.Descriptor) []Finding {
+			return checkSemver("component", desc.Component.Name, desc.Component.Version)
+		},
+		element: func(elem Element) []Finding {
+			return checkSemver(elem.Path, elem.Meta.Name, elem.Meta.Version)
+		},
+	}
+}
+
+// duplicateIdentities only keeps the hashes of the identities seen so far, so it stays cheap for large descriptors.
+func duplicateIdentities() ElementCheck {
+	first := make(map[ElementKind]map[uint64]string)
+	return &checkFuncs{
+		element: func(elem Element) []Finding {
+			seen, ok := first[elem.Kind]
+			if !ok {
+				seen = make(map[uint64]string)
+				first[elem.Kind] = seen
+			}
+			identity := elem.Meta.ToIdentity()
+			hash := identity.CanonicalHashV1()
+			if path, ok := seen[hash]; ok {
+				return []Finding{{
+					Path:    elem.Path,
+					Message: fmt.Sprintf("%s identity %s duplicates the identity of %s", elem.Kind, identity.String(), path),
+				}}
+			}
+			seen[hash] = elem.Path
+			return nil
+		},
+	}
+}
+
+func insecureAccesses() ElementCheck {
+	return &checkFuncs{
+		element: func(elem Element) []Finding {
+			if elem.Access == nil {
+				return nil
+			}
+			data, err := json.Marshal(elem.Access)
+			if err != nil {
+				return nil
+			}
+			var access any
+			if err := json.Unmarshal(data, &access); err != nil {
+				return nil
+			}
+			var findings []Finding
+			walkStrings(access, elem.Path+".access", func(path, value string) {
+				u, err := url.Parse(value)
+				if err != nil || !strings.EqualFold(u.Scheme, "http") || u.Host == "" {
+					return
+				}
+				findings = append(findings, Finding{
+					Path:    path,
+					Message: fmt.Sprintf("access of %s uses insecure http URL to host %s", elem.Meta.Name, u.Host),
+				})
+			})
+			return findings
+		},
+	}
+}
+
+// walkStrings calls fn for all strings in a decoded JSON value in a deterministic order.
+func walkStrings(value any, path string, fn func(path, value string)) {
+	switch v := value.(type) {
+	case string:
+		fn(path, v)
+	case []any:
+		for i, item := range v {
+			walkStrings(item, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			walkStrings(v[key], path+"."+key, fn)
+		}
+	}
+}
@@ -46,4 +46,26 @@ type LocalBlob struct {
 	// The value is typically an OCI repository name optionally
 	// followed by a colon ':' and a tag
 	ReferenceName string `json:"referenceName,omitempty"`
+	// Encryption is an optional field describing the envelope encryption
+	// of the blob. If given, the blob is stored encrypted and has to be
+	// decrypted before it represents an object of the given MediaType.
+	Encryption *LocalBlobEncryption `json:"encryption,omitempty"`
+}
+
+// LocalBlobEncryption describes the envelope encryption of a local blob.
+// The blob is encrypted with a data key that is stored wrapped
+// (encrypted) with a key encryption key, for example a public key or a key
+// managed by a key management service.
+// +k8s:deepcopy-gen=true
+type LocalBlobEncryption struct {
+	// Algorithm is the algorithm the blob is encrypted with, e.g. AES-256-GCM.
+	Algorithm string `json:"algorithm"`
+	// KeyWrapAlgorithm is the algorithm the data key is wrapped with, e.g. RSA-OAEP-256.
+	KeyWrapAlgorithm string `json:"keyWrapAlgorithm"`
+	// KeyID optionally identifies the key encryption key the data key is wrapped with.
+	KeyID string `json:"keyID,omitempty"`
+	// WrappedKey is the base64 encoded wrapped data key.
+	WrappedKey string `json:"wrappedKey"`
+	// Nonce is the base64 encoded nonce the blob is encrypted with.
+	Nonce string `json:"nonce"`
 }
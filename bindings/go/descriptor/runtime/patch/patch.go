@@ -0,0 +1,513 @@
+// Package patch applies JSON patches (RFC 6902) and JSON merge patches (RFC 7386) to component descriptors.
+//
+// Patches are applied to the v2 serialization of a descriptor. Positional patches of the elements of lists such
+// as resources, sources, references or labels break as soon as an element is added or removed, so elements of
+// lists can also be addressed by their identity: a JSON pointer token in the format of [runtime.Identity.String]
+// (e.g. "name=image,version=1.0.0") addresses the only element of a list whose identity, i.e. its name, version
+// and extra identity, contains all the attributes of the token.
+//
+//	[
+//	  {"op": "replace", "path": "/component/resources/name=image/access/imageReference", "value": "ghcr.io/acme/image:1.0.0"},
+//	  {"op": "add", "path": "/component/resources/name=image,architecture=arm64/labels/-", "value": {"name": "team", "value": "acme"}}
+//	]
+//
+// Merge patches merge lists of elements with identities by identity instead of replacing them, see [MergePatch].
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+var (
+	// ErrPathNotFound is returned if a path or from pointer of an operation addresses no value.
+	ErrPathNotFound = errors.New("path not found")
+	// ErrAmbiguousIdentity is returned if an identity token matches more than one element of a list.
+	ErrAmbiguousIdentity = errors.New("identity matches more than one element")
+	// ErrTestFailed is returned if a test operation fails.
+	ErrTestFailed = errors.New("test operation failed")
+)
+
+// Operation is a single operation of a JSON patch.
+type Operation struct {
+	// Op is one of add, remove, replace, move, copy or test.
+	Op string `json:"op"`
+	// Path is the JSON pointer to the value the operation applies to.
+	Path string `json:"path"`
+	// From is the JSON pointer to the value moved or copied by move and copy operations.
+	From string `json:"from,omitempty"`
+	// Value is the value of add, replace and test operations.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// IdentityToken returns the JSON pointer token addressing the element of a list with the given identity.
+func IdentityToken(identity runtime.Identity) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(identity.String())
+}
+
+// ApplyJSONPatch applies the JSON patch to the v2 serialization of the descriptor and returns the patched descriptor.
+func ApplyJSONPatch(scheme *runtime.Scheme, desc *descruntime.Descriptor, patch []byte) (*descruntime.Descriptor, error) {
+	return apply(scheme, desc, patch, JSONPatch)
+}
+
+// ApplyMergePatch applies the merge patch to the v2 serialization of the descriptor and returns the patched
+// descriptor.
+func ApplyMergePatch(scheme *runtime.Scheme, desc *descruntime.Descriptor, patch []byte) (*descruntime.Descriptor, error) {
+	return apply(scheme, desc, patch, MergePatch)
+}
+
+func apply(scheme *runtime.Scheme, desc *descruntime.Descriptor, patch []byte, fn func(doc, patch []byte) ([]byte, error)) (*descruntime.Descriptor, error) {
+	v2desc, err := descruntime.ConvertToV2(scheme, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert descriptor to v2: %w", err)
+	}
+	doc, err := json.Marshal(v2desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal descriptor: %w", err)
+	}
+
+	patched, err := fn(doc, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var patchedV2 v2.Descriptor
+	if err := json.Unmarshal(patched, &patchedV2); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal patched descriptor: %w", err)
+	}
+	patchedDesc, err := descruntime.ConvertFromV2(&patchedV2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert patched descriptor from v2: %w", err)
+	}
+
+	return patchedDesc, nil
+}
+
+// JSONPatch applies the JSON patch to the JSON document doc. Elements of lists can be addressed by their identity,
+// an identity token is resolved to the index of the element it matches, so that the operation then behaves as if
+// the index was given. Operations are applied in order, if one fails, an error is returned and no change applies.
+func JSONPatch(doc, patch []byte) ([]byte, error) {
+	var operations []Operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON patch: %w", err)
+	}
+	node, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document: %w", err)
+	}
+
+	for i, op := range operations {
+		if node, err = applyOperation(node, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s) failed: %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(node)
+}
+
+// MergePatch applies the merge patch to the JSON document doc as defined by RFC 7386, except for lists of
+// elements with identities: if all elements of a list of the patch have a name, each of them is merged into the
+// element of the list of the document that its identity addresses, or appended if there is none. Use JSONPatch
+// to remove elements of such lists.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	node, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document: %w", err)
+	}
+	patchNode, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal merge patch: %w", err)
+	}
+
+	merged, err := mergePatch(node, patchNode)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merged)
+}
+
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep numbers as they are, so that patching does not change their representation.
+	decoder.UseNumber()
+	var node any
+	if err := decoder.Decode(&node); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func applyOperation(node any, op Operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal value: %w", err)
+		}
+		switch op.Op {
+		case "add":
+			return add(node, path, value)
+		case "replace":
+			return replace(node, path, value)
+		default:
+			current, err := get(node, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return node, nil
+		}
+	case "remove":
+		node, _, err = remove(node, path)
+		return node, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+		if op.Op == "copy" {
+			value, err := get(node, from)
+			if err != nil {
+				return nil, fmt.Errorf("from: %w", err)
+			}
+			return add(node, path, deepCopy(value))
+		}
+		if strings.HasPrefix(op.Path+"/", op.From+"/") && op.Path != op.From {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		node, value, err := remove(node, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		return add(node, path, value)
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+}
+
+// parsePointer returns the unescaped tokens of the JSON pointer.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
+
+func get(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: no field %q", ErrPathNotFound, token)
+			}
+			node = child
+		case []any:
+			i, err := index(n, token, false)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: %q addresses a child of a scalar", ErrPathNotFound, token)
+		}
+	}
+
+	return node, nil
+}
+
+// update replaces the container addressed by all but the last token of path with the result of fn, which is
+// called with the container and the last token.
+func update(node any, path []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: no field %q", ErrPathNotFound, path[0])
+		}
+		updated, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = updated
+		return n, nil
+	case []any:
+		i, err := index(n, path[0], false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := update(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%w: %q addresses a child of a scalar", ErrPathNotFound, path[0])
+	}
+}
+
+func add(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(node, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			c[token] = value
+			return c, nil
+		case []any:
+			i, err := index(c, token, true)
+			if err != nil {
+				return nil, err
+			}
+			return append(c[:i], append([]any{value}, c[i:]...)...), nil
+		default:
+			return nil, fmt.Errorf("%w: %q addresses a child of a scalar", ErrPathNotFound, token)
+		}
+	})
+}
+
+func replace(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(node, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			if _, ok := c[token]; !ok {
+				return nil, fmt.Errorf("%w: no field %q", ErrPathNotFound, token)
+			}
+			c[token] = value
+			return c, nil
+		case []any:
+			i, err := index(c, token, false)
+			if err != nil {
+				return nil, err
+			}
+			c[i] = value
+			return c, nil
+		default:
+			return nil, fmt.Errorf("%w: %q addresses a child of a scalar", ErrPathNotFound, token)
+		}
+	})
+}
+
+// remove removes the value addressed by path and returns it.
+func remove(node any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the document")
+	}
+
+	var removed any
+	node, err := update(node, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			value, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: no field %q", ErrPathNotFound, token)
+			}
+			removed = value
+			delete(c, token)
+			return c, nil
+		case []any:
+			i, err := index(c, token, false)
+			if err != nil {
+				return nil, err
+			}
+			removed = c[i]
+			return append(c[:i], c[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: %q addresses a child of a scalar", ErrPathNotFound, token)
+		}
+	})
+
+	return node, removed, err
+}
+
+// index resolves the token to an index of the list. Tokens are either indices, identities or, if end is true,
+// "-" for the end of the list. If end is true, the length of the list is a valid index.
+func index(list []any, token string, end bool) (int, error) {
+	last := len(list) - 1
+	if end {
+		last = len(list)
+		if token == "-" {
+			return last, nil
+		}
+	}
+
+	if strings.Contains(token, "=") {
+		identity, err := runtime.ParseIdentity(token)
+		if err != nil {
+			return 0, fmt.Errorf("invalid identity %q: %w", token, err)
+		}
+		return indexOfIdentity(list, identity)
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid list index %q", token)
+	}
+	if i > last {
+		return 0, fmt.Errorf("%w: index %d is out of bounds", ErrPathNotFound, i)
+	}
+
+	return i, nil
+}
+
+// indexOfIdentity returns the index of the only element of the list whose identity contains the identity.
+func indexOfIdentity(list []any, identity runtime.Identity) (int, error) {
+	found := -1
+	for i, element := range list {
+		if !runtime.IdentitySubset(identity, elementIdentity(element)) {
+			continue
+		}
+		if found >= 0 {
+			return 0, fmt.Errorf("%w: %s", ErrAmbiguousIdentity, identity)
+		}
+		found = i
+	}
+	if found < 0 {
+		return 0, fmt.Errorf("%w: no element with identity %s", ErrPathNotFound, identity)
+	}
+
+	return found, nil
+}
+
+// elementIdentity returns the identity of an element of a list, i.e. its name, version and extra identity, or nil
+// if the element has no name.
+func elementIdentity(element any) runtime.Identity {
+	obj, ok := element.(map[string]any)
+	if !ok {
+		return nil
+	}
+	name, ok := obj[descruntime.IdentityAttributeName].(string)
+	if !ok {
+		return nil
+	}
+
+	identity := runtime.Identity{descruntime.IdentityAttributeName: name}
+	if version, ok := obj[descruntime.IdentityAttributeVersion].(string); ok && version != "" {
+		identity[descruntime.IdentityAttributeVersion] = version
+	}
+	if extra, ok := obj["extraIdentity"].(map[string]any); ok {
+		for key, value := range extra {
+			if s, ok := value.(string); ok {
+				identity[key] = s
+			}
+		}
+	}
+
+	return identity
+}
+
+func mergePatch(node, patch any) (any, error) {
+	switch p := patch.(type) {
+	case map[string]any:
+		obj, ok := node.(map[string]any)
+		if !ok {
+			obj = map[string]any{}
+		}
+		for key, value := range p {
+			if value == nil {
+				delete(obj, key)
+				continue
+			}
+			merged, err := mergePatch(obj[key], value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			obj[key] = merged
+		}
+		return obj, nil
+	case []any:
+		list, ok := node.([]any)
+		if !ok || !hasIdentities(p) {
+			return deepCopy(p), nil
+		}
+		for _, element := range p {
+			i, err := indexOfIdentity(list, elementIdentity(element))
+			if errors.Is(err, ErrPathNotFound) {
+				merged, err := mergePatch(nil, element)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, merged)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if list[i], err = mergePatch(list[i], element); err != nil {
+				return nil, fmt.Errorf("%s: %w", elementIdentity(element), err)
+			}
+		}
+		return list, nil
+	default:
+		return patch, nil
+	}
+}
+
+func hasIdentities(list []any) bool {
+	for _, element := range list {
+		if elementIdentity(element) == nil {
+			return false
+		}
+	}
+
+	return len(list) > 0
+}
+
+func deepCopy(node any) any {
+	switch n := node.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(n))
+		for key, value := range n {
+			c[key] = deepCopy(value)
+		}
+		return c
+	case []any:
+		c := make([]any, len(n))
+		for i, value := range n {
+			c[i] = deepCopy(value)
+		}
+		return c
+	default:
+		return node
+	}
+}
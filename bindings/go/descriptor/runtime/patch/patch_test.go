@@ -0,0 +1,174 @@
+package patch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime/patch"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const doc = `{
+  "resources": [
+    {"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "amd64"}, "labels": [{"name": "team", "value": "a"}]},
+    {"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "arm64"}},
+    {"name": "chart", "version": "1.0.0", "replicas": 1}
+  ]
+}`
+
+func TestJSONPatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+		err   error
+		msg   string
+	}{
+		{
+			name:  "replace by identity",
+			patch: `[{"op": "replace", "path": "/resources/name=chart/replicas", "value": 3}]`,
+			want:  `{"resources": [{"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "amd64"}, "labels": [{"name": "team", "value": "a"}]}, {"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "arm64"}}, {"name": "chart", "version": "1.0.0", "replicas": 3}]}`,
+		},
+		{
+			name: "extra identity and nested identity",
+			patch: `[
+				{"op": "replace", "path": "/resources/name=image,architecture=amd64/labels/name=team/value", "value": "b"},
+				{"op": "add", "path": "/resources/architecture=arm64,name=image/labels", "value": [{"name": "team", "value": "c"}]}
+			]`,
+			want: `{"resources": [{"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "amd64"}, "labels": [{"name": "team", "value": "b"}]}, {"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "arm64"}, "labels": [{"name": "team", "value": "c"}]}, {"name": "chart", "version": "1.0.0", "replicas": 1}]}`,
+		},
+		{
+			name: "remove, move and copy",
+			patch: `[
+				{"op": "test", "path": "/resources/name=chart/version", "value": "1.0.0"},
+				{"op": "remove", "path": "/resources/name=image,architecture=arm64"},
+				{"op": "copy", "from": "/resources/name=image/labels", "path": "/resources/name=chart/labels"},
+				{"op": "move", "from": "/resources/name=chart", "path": "/resources/0"}
+			]`,
+			want: `{"resources": [{"name": "chart", "version": "1.0.0", "replicas": 1, "labels": [{"name": "team", "value": "a"}]}, {"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "amd64"}, "labels": [{"name": "team", "value": "a"}]}]}`,
+		},
+		{
+			name:  "ambiguous identity",
+			patch: `[{"op": "remove", "path": "/resources/name=image"}]`,
+			err:   patch.ErrAmbiguousIdentity,
+		},
+		{
+			name:  "unknown identity",
+			patch: `[{"op": "remove", "path": "/resources/name=db"}]`,
+			err:   patch.ErrPathNotFound,
+		},
+		{
+			name:  "failed test",
+			patch: `[{"op": "test", "path": "/resources/name=chart/replicas", "value": 2}]`,
+			err:   patch.ErrTestFailed,
+		},
+		{
+			name:  "index out of bounds",
+			patch: `[{"op": "replace", "path": "/resources/3", "value": {}}]`,
+			err:   patch.ErrPathNotFound,
+		},
+		{
+			name:  "unsupported operation",
+			patch: `[{"op": "rename", "path": "/resources"}]`,
+			msg:   `unsupported operation "rename"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			patched, err := patch.JSONPatch([]byte(doc), []byte(tt.patch))
+			switch {
+			case tt.err != nil:
+				r.ErrorIs(err, tt.err)
+			case tt.msg != "":
+				r.ErrorContains(err, tt.msg)
+			default:
+				r.NoError(err)
+				r.JSONEq(tt.want, string(patched))
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	r := require.New(t)
+
+	patched, err := patch.MergePatch([]byte(doc), []byte(`{
+		"resources": [
+			{"name": "image", "extraIdentity": {"architecture": "arm64"}, "labels": [{"name": "team", "value": "c"}]},
+			{"name": "chart", "replicas": null, "labels": [{"name": "team", "value": "d"}]},
+			{"name": "db", "version": "2.0.0", "extra": null}
+		]
+	}`))
+	r.NoError(err)
+	r.JSONEq(`{"resources": [
+		{"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "amd64"}, "labels": [{"name": "team", "value": "a"}]},
+		{"name": "image", "version": "1.0.0", "extraIdentity": {"architecture": "arm64"}, "labels": [{"name": "team", "value": "c"}]},
+		{"name": "chart", "version": "1.0.0", "labels": [{"name": "team", "value": "d"}]},
+		{"name": "db", "version": "2.0.0"}
+	]}`, string(patched))
+
+	_, err = patch.MergePatch([]byte(doc), []byte(`{"resources": [{"name": "image", "labels": []}]}`))
+	r.ErrorIs(err, patch.ErrAmbiguousIdentity)
+
+	patched, err = patch.MergePatch([]byte(`{"list": [1, 2], "keep": true}`), []byte(`{"list": [3]}`))
+	r.NoError(err)
+	r.JSONEq(`{"list": [3], "keep": true}`, string(patched), "lists without identities are replaced")
+}
+
+func TestIdentityToken(t *testing.T) {
+	r := require.New(t)
+
+	token := patch.IdentityToken(runtime.Identity{"name": "image", "path": "a/b~c"})
+	r.Equal("name=image,path=a~1b~0c", token)
+
+	patched, err := patch.JSONPatch([]byte(`{"list": [{"name": "image", "extraIdentity": {"path": "a/b~c"}}]}`),
+		[]byte(`[{"op": "add", "path": "/list/`+token+`/value", "value": 1}]`))
+	r.NoError(err)
+	r.JSONEq(`{"list": [{"name": "image", "extraIdentity": {"path": "a/b~c"}, "value": 1}]}`, string(patched))
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	r := require.New(t)
+
+	var desc v2.Descriptor
+	r.NoError(json.Unmarshal([]byte(`{
+		"meta": {"schemaVersion": "v2"},
+		"component": {
+			"name": "acme.org/app",
+			"version": "1.0.0",
+			"provider": "acme",
+			"repositoryContexts": [],
+			"resources": [
+				{"name": "image", "version": "1.0.0", "type": "ociImage", "relation": "external",
+				 "access": {"type": "ociArtifact", "imageReference": "ghcr.io/acme/image:1.0.0"}}
+			],
+			"sources": [],
+			"componentReferences": []
+		}
+	}`), &desc))
+	runtimeDesc, err := descruntime.ConvertFromV2(&desc)
+	r.NoError(err)
+
+	scheme := runtime.NewScheme()
+	patched, err := patch.ApplyJSONPatch(scheme, runtimeDesc, []byte(`[
+		{"op": "replace", "path": "/component/resources/name=image/access/imageReference", "value": "registry.acme.org/image:1.0.0"},
+		{"op": "add", "path": "/component/resources/name=image/labels", "value": [{"name": "relocated", "value": true}]}
+	]`))
+	r.NoError(err)
+	r.Len(patched.Component.Resources, 1)
+	r.Equal("relocated", patched.Component.Resources[0].Labels[0].Name)
+	r.JSONEq(`{"type": "ociArtifact", "imageReference": "registry.acme.org/image:1.0.0"}`,
+		string(patched.Component.Resources[0].Access.(*runtime.Raw).Data))
+	r.Empty(runtimeDesc.Component.Resources[0].Labels, "the original descriptor is not modified")
+
+	patched, err = patch.ApplyMergePatch(scheme, runtimeDesc, []byte(`{"component": {"resources": [{"name": "image", "labels": [{"name": "team", "value": "acme"}]}]}}`))
+	r.NoError(err)
+	r.Equal("team", patched.Component.Resources[0].Labels[0].Name)
+}
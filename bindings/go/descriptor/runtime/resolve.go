@@ -0,0 +1,207 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrMaxDepthExceeded is returned by ResolveReferences if the reference graph is nested deeper than the maximum depth.
+var ErrMaxDepthExceeded = errors.New("maximum reference depth exceeded")
+
+const (
+	// DefaultMaxReferenceDepth is the maximum depth of nested references resolved by ResolveReferences by default.
+	DefaultMaxReferenceDepth = 32
+	// DefaultResolveConcurrency is the number of component versions ResolveReferences fetches concurrently by default.
+	DefaultResolveConcurrency = 8
+)
+
+// DigestVerifier verifies that desc matches the digest of the reference ref pointing to it.
+// It is typically implemented by normalising desc with the normalisation algorithm of the reference digest,
+// see signing.VerifyReferenceDigest.
+type DigestVerifier func(ctx context.Context, ref *Reference, desc *Descriptor) error
+
+// ResolveOptions configures ResolveReferences.
+type ResolveOptions struct {
+	// MaxDepth is the maximum depth of nested references, 1 allows only direct references.
+	// A negative depth allows references of any depth.
+	MaxDepth int
+	// Concurrency is the number of component versions fetched concurrently.
+	Concurrency int
+	// VerifyDigest verifies every reference against the descriptor it points to.
+	// If nil, the digests of references are not verified.
+	VerifyDigest DigestVerifier
+}
+
+// ResolveOption configures ResolveReferences.
+type ResolveOption func(*ResolveOptions)
+
+// WithMaxDepth sets the maximum depth of nested references, see ResolveOptions.MaxDepth.
+func WithMaxDepth(depth int) ResolveOption {
+	return func(o *ResolveOptions) {
+		o.MaxDepth = depth
+	}
+}
+
+// WithConcurrency sets the number of component versions fetched concurrently.
+func WithConcurrency(concurrency int) ResolveOption {
+	return func(o *ResolveOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// WithDigestVerifier verifies every reference in the graph against the descriptor it points to, so that the
+// closure is bound to the digests along the chain from desc.
+func WithDigestVerifier(verifier DigestVerifier) ResolveOption {
+	return func(o *ResolveOptions) {
+		o.VerifyDigest = verifier
+	}
+}
+
+// ResolveReferences returns the closure of desc over its references: desc and the descriptors of all component
+// versions it references directly or transitively, each once, in breadth-first order.
+//
+// The graph is resolved level by level, fetching the component versions of a level concurrently from the getter.
+// ResolveReferences fails with an error matching
+//   - ErrMaxDepthExceeded if a reference is nested deeper than the maximum depth (DefaultMaxReferenceDepth),
+//   - ErrReferenceCycle if a component version references itself, directly or through other component versions,
+//   - the error of the DigestVerifier if a reference does not match the descriptor it points to.
+//
+// Unlike ExpandReferences, all descriptors are fetched eagerly. Both share the fetching, caching and digest
+// verification of referenced component versions.
+func ResolveReferences(ctx context.Context, getter ComponentVersionGetter, desc *Descriptor, opts ...ResolveOption) ([]*Descriptor, error) {
+	if desc == nil {
+		return nil, errors.New("descriptor must not be nil")
+	}
+	options := ResolveOptions{
+		MaxDepth:    DefaultMaxReferenceDepth,
+		Concurrency: DefaultResolveConcurrency,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+
+	cache := newReferenceCache(getter, options.VerifyDigest)
+	cache.add(desc)
+	nodes := map[string]*Descriptor{componentVersionKey(desc): desc}
+	closure := []*Descriptor{desc}
+	level := []*Descriptor{desc}
+	for depth := 1; len(level) > 0; depth++ {
+		var edges []referenceEdge
+		for _, parent := range level {
+			for i := range parent.Component.References {
+				edges = append(edges, referenceEdge{parent: parent, ref: &parent.Component.References[i]})
+			}
+		}
+		if len(edges) == 0 {
+			break
+		}
+		if options.MaxDepth >= 0 && depth > options.MaxDepth {
+			edge := edges[0]
+			return nil, fmt.Errorf("reference %s of component version %s at depth %d: %w",
+				edge.ref.Name, componentVersionKey(edge.parent), depth, ErrMaxDepthExceeded)
+		}
+
+		// component versions referenced several times are fetched once and verified against every reference.
+		resolved := make([]*Descriptor, len(edges))
+		if err := forEach(ctx, len(edges), options.Concurrency, func(ctx context.Context, i int) error {
+			desc, err := cache.get(ctx, edges[i].parent, edges[i].ref)
+			if err != nil {
+				return err
+			}
+			resolved[i] = desc
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		level = nil
+		for i, edge := range edges {
+			key := edge.ref.Component + ":" + edge.ref.Version
+			if nodes[key] == nil {
+				nodes[key] = resolved[i]
+				closure = append(closure, resolved[i])
+				level = append(level, resolved[i])
+			}
+		}
+	}
+
+	if err := detectCycle(desc, nodes, nil, map[string]bool{}, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	return closure, nil
+}
+
+type referenceEdge struct {
+	parent *Descriptor
+	ref    *Reference
+}
+
+func componentVersionKey(desc *Descriptor) string {
+	return desc.Component.Name + ":" + desc.Component.Version
+}
+
+// detectCycle searches the resolved graph depth first for a component version on its own path.
+func detectCycle(desc *Descriptor, nodes map[string]*Descriptor, path []string, visiting, done map[string]bool) error {
+	key := componentVersionKey(desc)
+	if visiting[key] {
+		return fmt.Errorf("component version %s: %w", strings.Join(append(path[:len(path):len(path)], key), " -> "), ErrReferenceCycle)
+	}
+	if done[key] {
+		return nil
+	}
+	visiting[key] = true
+	for _, ref := range desc.Component.References {
+		if err := detectCycle(nodes[ref.Component+":"+ref.Version], nodes, append(path[:len(path):len(path)], key), visiting, done); err != nil {
+			return err
+		}
+	}
+	delete(visiting, key)
+	done[key] = true
+	return nil
+}
+
+// forEach calls fn for the indices 0 to n-1 with at most concurrency calls in flight.
+// It returns the first error and cancels the context passed to the remaining calls.
+func forEach(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) error {
+	fnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-fnCtx.Done():
+		}
+		if fnCtx.Err() != nil {
+			break
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			if err := fn(fnCtx, i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				cancel()
+			}
+		})
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,142 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+)
+
+// concurrentGetter is safe for concurrent use and records the highest number of concurrent calls.
+type concurrentGetter struct {
+	descriptors map[string]*descriptor.Descriptor
+
+	mu    sync.Mutex
+	calls map[string]int
+
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (g *concurrentGetter) GetComponentVersion(_ context.Context, component, version string) (*descriptor.Descriptor, error) {
+	n := g.inFlight.Add(1)
+	defer g.inFlight.Add(-1)
+	for {
+		peak := g.maxInFlight.Load()
+		if n <= peak || g.maxInFlight.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	key := component + ":" + version
+	g.mu.Lock()
+	g.calls[key]++
+	g.mu.Unlock()
+	desc, ok := g.descriptors[key]
+	if !ok {
+		return nil, fmt.Errorf("component version %s not found", key)
+	}
+	return desc, nil
+}
+
+func newGetter(descs ...*descriptor.Descriptor) *concurrentGetter {
+	g := &concurrentGetter{descriptors: map[string]*descriptor.Descriptor{}, calls: map[string]int{}}
+	for _, desc := range descs {
+		g.descriptors[desc.Component.Name+":"+desc.Component.Version] = desc
+	}
+	return g
+}
+
+func names(descs []*descriptor.Descriptor) []string {
+	var result []string
+	for _, desc := range descs {
+		result = append(result, desc.Component.Name)
+	}
+	return result
+}
+
+func TestResolveReferences(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	// a -> b, c; b -> d; c -> d
+	root := newComponent("ocm.software/a", "1.0.0", "b", "c")
+	getter := newGetter(
+		newComponent("ocm.software/b", "1.0.0", "d"),
+		newComponent("ocm.software/c", "1.0.0", "d"),
+		newComponent("ocm.software/d", "1.0.0"),
+	)
+
+	closure, err := descriptor.ResolveReferences(ctx, getter, root, descriptor.WithConcurrency(2))
+	r.NoError(err)
+	r.Equal([]string{"ocm.software/a", "ocm.software/b", "ocm.software/c", "ocm.software/d"}, names(closure))
+	r.Same(root, closure[0])
+	r.Equal(1, getter.calls["ocm.software/d:1.0.0"], "component versions referenced several times must be fetched once")
+	r.LessOrEqual(getter.maxInFlight.Load(), int32(2))
+
+	t.Run("max depth", func(t *testing.T) {
+		r := require.New(t)
+		_, err := descriptor.ResolveReferences(ctx, getter, root, descriptor.WithMaxDepth(1))
+		r.ErrorIs(err, descriptor.ErrMaxDepthExceeded)
+		r.ErrorContains(err, "reference d of component version ocm.software/b:1.0.0 at depth 2")
+
+		closure, err := descriptor.ResolveReferences(ctx, getter, root, descriptor.WithMaxDepth(2))
+		r.NoError(err)
+		r.Len(closure, 4)
+	})
+
+	t.Run("digest verification", func(t *testing.T) {
+		r := require.New(t)
+		var verified sync.Map
+		verifier := func(_ context.Context, ref *descriptor.Reference, desc *descriptor.Descriptor) error {
+			verified.Store(ref.Name, desc.Component.Name)
+			if ref.Digest.Value != ref.Name {
+				return errors.New("digest mismatch")
+			}
+			return nil
+		}
+		_, err := descriptor.ResolveReferences(ctx, getter, root, descriptor.WithDigestVerifier(verifier))
+		r.NoError(err)
+		for _, ref := range []string{"b", "c", "d"} {
+			component, ok := verified.Load(ref)
+			r.True(ok, "reference %s must be verified", ref)
+			r.Equal("ocm.software/"+ref, component)
+		}
+
+		tampered := newComponent("ocm.software/c", "1.0.0", "d")
+		tampered.Component.References[0].Digest.Value = "other"
+		_, err = descriptor.ResolveReferences(ctx, newGetter(getter.descriptors["ocm.software/b:1.0.0"], tampered, getter.descriptors["ocm.software/d:1.0.0"]), root, descriptor.WithDigestVerifier(verifier))
+		r.ErrorContains(err, "digest verification of reference d of component version ocm.software/c:1.0.0 failed: digest mismatch")
+	})
+}
+
+func TestResolveReferences_Errors(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("cycle", func(t *testing.T) {
+		r := require.New(t)
+		root := newComponent("ocm.software/a", "1.0.0", "b")
+		getter := newGetter(root, newComponent("ocm.software/b", "1.0.0", "c"), newComponent("ocm.software/c", "1.0.0", "b"))
+		_, err := descriptor.ResolveReferences(ctx, getter, root, descriptor.WithMaxDepth(-1))
+		r.ErrorIs(err, descriptor.ErrReferenceCycle)
+		r.ErrorContains(err, "ocm.software/a:1.0.0 -> ocm.software/b:1.0.0 -> ocm.software/c:1.0.0 -> ocm.software/b:1.0.0")
+	})
+
+	t.Run("missing component version", func(t *testing.T) {
+		r := require.New(t)
+		_, err := descriptor.ResolveReferences(ctx, newGetter(), newComponent("ocm.software/a", "1.0.0", "missing"))
+		r.ErrorContains(err, "failed to get component version ocm.software/missing:1.0.0 of reference missing")
+	})
+
+	t.Run("nil descriptor", func(t *testing.T) {
+		r := require.New(t)
+		_, err := descriptor.ResolveReferences(ctx, newGetter(), nil)
+		r.Error(err)
+	})
+}
@@ -0,0 +1,379 @@
+// Package selector defines a typed filter to select resources of component versions.
+//
+// The same [ResourceFilter] is meant to be embedded in every specification that selects resources,
+// such as transfer and replication configurations, retention policies or SBOM generation, so that
+// a filter selects the same resources wherever it is used. Consumers evaluate it with
+// [ResourceFilter.Matches] instead of implementing their own semantics.
+//
+//	include:
+//	  - types: [ociImage, helmChart]
+//	    matchLabels:
+//	      ocm.software/release: stable
+//	exclude:
+//	  - accessTypes: [localBlob]
+//	    minSize: 104857600
+package selector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ErrUnknownSize is returned if a selector with size thresholds is evaluated for a resource
+// whose size cannot be determined.
+var ErrUnknownSize = errors.New("resource size is unknown")
+
+// LabelSelectorOperator represents the operator of a label selector requirement.
+type LabelSelectorOperator string
+
+const (
+	// LabelSelectorOpIn - the label value is in the set of values
+	LabelSelectorOpIn LabelSelectorOperator = "In"
+	// LabelSelectorOpNotIn - the label is not set or its value is not in the set of values
+	LabelSelectorOpNotIn LabelSelectorOperator = "NotIn"
+	// LabelSelectorOpExists - the label is set
+	LabelSelectorOpExists LabelSelectorOperator = "Exists"
+	// LabelSelectorOpDoesNotExist - the label is not set
+	LabelSelectorOpDoesNotExist LabelSelectorOperator = "DoesNotExist"
+)
+
+// LabelSelectorRequirement represents a single requirement on the labels of a resource.
+// +k8s:deepcopy-gen=true
+type LabelSelectorRequirement struct {
+	// Key is the name of the label the requirement applies to.
+	Key string `json:"key"`
+	// Operator represents the relationship between the label and values.
+	Operator LabelSelectorOperator `json:"operator"`
+	// Values is an array of label values. If the operator is In or NotIn,
+	// the value array must be non-empty. If the operator is Exists or DoesNotExist,
+	// the value array must be empty.
+	// Label values that are JSON strings are compared by their string, all other
+	// label values by their compact JSON representation.
+	Values []string `json:"values,omitempty"`
+}
+
+// ResourceSelector selects resources by their attributes. All configured criteria must be
+// satisfied (they are ANDed), while the entries of a list criterion are alternatives (they are ORed).
+// An empty selector matches every resource.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type ResourceSelector struct {
+	// Types are the resource types to select, e.g. ociImage or helmChart.
+	Types []string `json:"types,omitempty"`
+	// AccessTypes are the access types to select, e.g. localBlob or OCIImage/v1.
+	// An access type without version matches all versions. Type names are compared case-insensitively,
+	// other aliases of an access type have to be listed explicitly.
+	AccessTypes []string `json:"accessTypes,omitempty"`
+	// MediaTypes are the media types of the resource content to select, e.g. application/vnd.oci.image.manifest.v1+json.
+	// The media type is taken from the mediaType attribute of the access, e.g. of a localBlob. Media types are
+	// compared case-insensitively and without parameters. Resources whose access has no media type are not selected.
+	MediaTypes []string `json:"mediaTypes,omitempty"`
+	// ExtraIdentity is a map of extra identity attributes the resource must have.
+	ExtraIdentity map[string]string `json:"extraIdentity,omitempty"`
+	// MatchLabels is a map of {name,value} pairs of labels the resource must have. A single pair
+	// is equivalent to an element of matchLabelExpressions, whose key field is the name,
+	// the operator is "In", and the value array contains only the value.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// MatchLabelExpressions is a list of label selector requirements. The requirements are ANDed.
+	MatchLabelExpressions []LabelSelectorRequirement `json:"matchLabelExpressions,omitempty"`
+	// MinSize is the minimum size in bytes of the resource content.
+	MinSize *int64 `json:"minSize,omitempty"`
+	// MaxSize is the maximum size in bytes of the resource content.
+	MaxSize *int64 `json:"maxSize,omitempty"`
+}
+
+// ResourceFilter selects the resources matching any of the Include selectors, except
+// those matching any of the Exclude selectors. Without Include selectors, all resources
+// not excluded are selected.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type ResourceFilter struct {
+	// Include are the selectors of the resources to select. The selectors are ORed.
+	Include []ResourceSelector `json:"include,omitempty"`
+	// Exclude are the selectors of the resources not to select, even if they are included.
+	// The selectors are ORed.
+	Exclude []ResourceSelector `json:"exclude,omitempty"`
+}
+
+// SizeFunc returns the size in bytes of the content of a resource, or -1 if it is unknown.
+// It is only called for selectors with size thresholds.
+type SizeFunc func(ctx context.Context, resource *descruntime.Resource) (int64, error)
+
+// Validate checks the selectors of the filter.
+func (f *ResourceFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	for i := range f.Include {
+		if err := f.Include[i].Validate(); err != nil {
+			return fmt.Errorf("invalid include selector %d: %w", i, err)
+		}
+	}
+	for i := range f.Exclude {
+		if err := f.Exclude[i].Validate(); err != nil {
+			return fmt.Errorf("invalid exclude selector %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the filter selects the resource. A nil filter selects every resource.
+// The size of the resource is determined with size, which may be nil if no selector has size thresholds.
+func (f *ResourceFilter) Matches(ctx context.Context, resource *descruntime.Resource, size SizeFunc) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	eval := &evaluation{resource: resource, sizeFunc: size}
+	for i := range f.Exclude {
+		matches, err := f.Exclude[i].matches(ctx, eval)
+		if err != nil || matches {
+			return false, err
+		}
+	}
+	if len(f.Include) == 0 {
+		return true, nil
+	}
+	for i := range f.Include {
+		matches, err := f.Include[i].matches(ctx, eval)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+// Validate checks the access types, label selector requirements and size thresholds of the selector.
+func (s *ResourceSelector) Validate() error {
+	if s == nil {
+		return nil
+	}
+	for _, typ := range s.AccessTypes {
+		if _, err := runtime.TypeFromString(typ); err != nil {
+			return fmt.Errorf("invalid access type: %w", err)
+		}
+	}
+	for _, expr := range s.MatchLabelExpressions {
+		if expr.Key == "" {
+			return errors.New("label selector requirement without key")
+		}
+		switch expr.Operator {
+		case LabelSelectorOpIn, LabelSelectorOpNotIn:
+			if len(expr.Values) == 0 {
+				return fmt.Errorf("label selector requirement for %q with operator %s requires values", expr.Key, expr.Operator)
+			}
+		case LabelSelectorOpExists, LabelSelectorOpDoesNotExist:
+			if len(expr.Values) > 0 {
+				return fmt.Errorf("label selector requirement for %q with operator %s must not have values", expr.Key, expr.Operator)
+			}
+		default:
+			return fmt.Errorf("label selector requirement for %q has invalid operator %q (must be one of %q, %q, %q, %q)",
+				expr.Key, expr.Operator, LabelSelectorOpIn, LabelSelectorOpNotIn, LabelSelectorOpExists, LabelSelectorOpDoesNotExist)
+		}
+	}
+	if s.MinSize != nil && *s.MinSize < 0 {
+		return fmt.Errorf("invalid minSize %d (must not be negative)", *s.MinSize)
+	}
+	if s.MaxSize != nil && *s.MaxSize < 0 {
+		return fmt.Errorf("invalid maxSize %d (must not be negative)", *s.MaxSize)
+	}
+	if s.MinSize != nil && s.MaxSize != nil && *s.MinSize > *s.MaxSize {
+		return fmt.Errorf("minSize %d is greater than maxSize %d", *s.MinSize, *s.MaxSize)
+	}
+	return nil
+}
+
+// Matches returns true if the selector matches the resource. A nil selector matches every resource.
+// The size of the resource is determined with size, which may be nil if the selector has no size thresholds.
+func (s *ResourceSelector) Matches(ctx context.Context, resource *descruntime.Resource, size SizeFunc) (bool, error) {
+	return s.matches(ctx, &evaluation{resource: resource, sizeFunc: size})
+}
+
+// evaluation holds a resource while it is evaluated against multiple selectors,
+// so that its size is only determined once.
+type evaluation struct {
+	resource *descruntime.Resource
+	sizeFunc SizeFunc
+	size     *int64
+}
+
+func (e *evaluation) getSize(ctx context.Context) (int64, error) {
+	if e.size != nil {
+		return *e.size, nil
+	}
+	if e.sizeFunc == nil {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownSize, e.resource.ToIdentity())
+	}
+	size, err := e.sizeFunc(ctx, e.resource)
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine size of resource %s: %w", e.resource.ToIdentity(), err)
+	}
+	if size < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownSize, e.resource.ToIdentity())
+	}
+	e.size = &size
+	return size, nil
+}
+
+func (s *ResourceSelector) matches(ctx context.Context, eval *evaluation) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	resource := eval.resource
+
+	if len(s.Types) > 0 && !slices.Contains(s.Types, resource.Type) {
+		return false, nil
+	}
+	if len(s.AccessTypes) > 0 && !matchesAccessType(s.AccessTypes, resource.Access) {
+		return false, nil
+	}
+	if len(s.MediaTypes) > 0 {
+		matches, err := matchesMediaType(s.MediaTypes, resource.Access)
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate media type of resource %s: %w", resource.ToIdentity(), err)
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+	for key, value := range s.ExtraIdentity {
+		if actual, ok := resource.ExtraIdentity[key]; !ok || actual != value {
+			return false, nil
+		}
+	}
+
+	labels, err := labelValues(resource.Labels)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate labels of resource %s: %w", resource.ToIdentity(), err)
+	}
+	for name, value := range s.MatchLabels {
+		if actual, ok := labels[name]; !ok || actual != value {
+			return false, nil
+		}
+	}
+	for _, expr := range s.MatchLabelExpressions {
+		if !matchesExpression(expr, labels) {
+			return false, nil
+		}
+	}
+
+	if s.MinSize != nil || s.MaxSize != nil {
+		size, err := eval.getSize(ctx)
+		if err != nil {
+			return false, err
+		}
+		if s.MinSize != nil && size < *s.MinSize {
+			return false, nil
+		}
+		if s.MaxSize != nil && size > *s.MaxSize {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesAccessType checks if the type of the access is one of the given types.
+func matchesAccessType(types []string, access runtime.Typed) bool {
+	if access == nil {
+		return false
+	}
+	actual := access.GetType()
+	for _, typ := range types {
+		expected, err := runtime.TypeFromString(typ)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(expected.Name, actual.Name) && (expected.Version == "" || expected.Version == actual.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMediaType checks if the media type of the access is one of the given media types.
+func matchesMediaType(mediaTypes []string, access runtime.Typed) (bool, error) {
+	if access == nil {
+		return false, nil
+	}
+	var data []byte
+	if raw, ok := access.(*runtime.Raw); ok {
+		data = raw.Data
+	} else {
+		var err error
+		if data, err = json.Marshal(access); err != nil {
+			return false, err
+		}
+	}
+	if len(data) == 0 {
+		return false, nil
+	}
+	var fields struct {
+		MediaType string `json:"mediaType"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false, err
+	}
+	if fields.MediaType == "" {
+		return false, nil
+	}
+	actual := normalizeMediaType(fields.MediaType)
+	return slices.ContainsFunc(mediaTypes, func(mediaType string) bool {
+		return normalizeMediaType(mediaType) == actual
+	}), nil
+}
+
+// normalizeMediaType returns the media type in lower case and without parameters.
+func normalizeMediaType(mediaType string) string {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return parsed
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// matchesExpression checks if a single label selector requirement is satisfied.
+func matchesExpression(expr LabelSelectorRequirement, labels map[string]string) bool {
+	actual, exists := labels[expr.Key]
+	switch expr.Operator {
+	case LabelSelectorOpExists:
+		return exists
+	case LabelSelectorOpDoesNotExist:
+		return !exists
+	case LabelSelectorOpIn:
+		return exists && slices.Contains(expr.Values, actual)
+	case LabelSelectorOpNotIn:
+		return !exists || !slices.Contains(expr.Values, actual)
+	default:
+		return false
+	}
+}
+
+// labelValues returns the values of the labels by name. JSON string values are unquoted,
+// all other values are returned in their compact JSON representation.
+func labelValues(labels []descruntime.Label) (map[string]string, error) {
+	values := make(map[string]string, len(labels))
+	for _, label := range labels {
+		if len(label.Value) == 0 {
+			values[label.Name] = ""
+			continue
+		}
+		var str string
+		if err := json.Unmarshal(label.Value, &str); err == nil {
+			values[label.Name] = str
+			continue
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, label.Value); err != nil {
+			return nil, fmt.Errorf("invalid value of label %q: %w", label.Name, err)
+		}
+		values[label.Name] = compact.String()
+	}
+	return values, nil
+}
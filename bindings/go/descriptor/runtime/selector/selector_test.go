@@ -0,0 +1,191 @@
+package selector_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime/selector"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func resource(name, typ string, access runtime.Type, labels map[string]string, extraIdentity runtime.Identity) *descruntime.Resource {
+	res := &descruntime.Resource{
+		Type:   typ,
+		Access: &runtime.Raw{Type: access},
+	}
+	res.Name = name
+	res.Version = "1.0.0"
+	res.ExtraIdentity = extraIdentity
+	for name, value := range labels {
+		res.Labels = append(res.Labels, descruntime.Label{Name: name, Value: json.RawMessage(value)})
+	}
+	return res
+}
+
+func TestResourceFilter(t *testing.T) {
+	image := resource("image", "ociImage", runtime.NewVersionedType("OCIImage", "v1"),
+		map[string]string{"release": `"stable"`, "platforms": `["linux", "darwin"]`},
+		runtime.Identity{"architecture": "amd64"})
+	chart := resource("chart", "helmChart", runtime.NewVersionedType("localBlob", "v1"),
+		map[string]string{"release": `"beta"`}, nil)
+	chart.Access.(*runtime.Raw).Data = []byte(`{"type": "localBlob/v1", "mediaType": "application/vnd.cncf.helm.chart.content.v1.tar+gzip; version=3"}`)
+	sizes := map[string]int64{"image": 1000, "chart": 10}
+	size := func(_ context.Context, res *descruntime.Resource) (int64, error) {
+		return sizes[res.Name], nil
+	}
+	ptr := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name   string
+		filter *selector.ResourceFilter
+		want   []string
+	}{
+		{name: "nil filter", filter: nil, want: []string{"image", "chart"}},
+		{name: "empty filter", filter: &selector.ResourceFilter{}, want: []string{"image", "chart"}},
+		{
+			name:   "types",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{Types: []string{"helmChart", "blob"}}}},
+			want:   []string{"chart"},
+		},
+		{
+			name:   "access type names are case-insensitive and match all versions",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{AccessTypes: []string{"LocalBlob"}}}},
+			want:   []string{"chart"},
+		},
+		{
+			name:   "access type versions",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{AccessTypes: []string{"OCIImage/v2", "localBlob/v1"}}}},
+			want:   []string{"chart"},
+		},
+		{
+			name:   "media types are case-insensitive and compared without parameters",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{MediaTypes: []string{"application/vnd.cncf.helm.chart.content.v1.TAR+gzip"}}}},
+			want:   []string{"chart"},
+		},
+		{
+			name:   "accesses without media type do not match media types",
+			filter: &selector.ResourceFilter{Exclude: []selector.ResourceSelector{{MediaTypes: []string{"application/vnd.cncf.helm.chart.content.v1.tar+gzip"}}}},
+			want:   []string{"image"},
+		},
+		{
+			name:   "extra identity",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{ExtraIdentity: map[string]string{"architecture": "amd64"}}}},
+			want:   []string{"image"},
+		},
+		{
+			name:   "match labels",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{MatchLabels: map[string]string{"release": "beta"}}}},
+			want:   []string{"chart"},
+		},
+		{
+			name: "label expressions compare non-string values as compact json",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{MatchLabelExpressions: []selector.LabelSelectorRequirement{
+				{Key: "platforms", Operator: selector.LabelSelectorOpIn, Values: []string{`["linux","darwin"]`}},
+			}}}},
+			want: []string{"image"},
+		},
+		{
+			name: "label expressions are ANDed",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{MatchLabelExpressions: []selector.LabelSelectorRequirement{
+				{Key: "release", Operator: selector.LabelSelectorOpNotIn, Values: []string{"stable"}},
+				{Key: "platforms", Operator: selector.LabelSelectorOpDoesNotExist},
+			}}}},
+			want: []string{"chart"},
+		},
+		{
+			name:   "size thresholds",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{{MinSize: ptr(10), MaxSize: ptr(100)}}},
+			want:   []string{"chart"},
+		},
+		{
+			name: "include selectors are ORed",
+			filter: &selector.ResourceFilter{Include: []selector.ResourceSelector{
+				{Types: []string{"helmChart"}},
+				{MatchLabels: map[string]string{"release": "stable"}},
+			}},
+			want: []string{"image", "chart"},
+		},
+		{
+			name: "exclude wins over include",
+			filter: &selector.ResourceFilter{
+				Include: []selector.ResourceSelector{{MatchLabelExpressions: []selector.LabelSelectorRequirement{
+					{Key: "release", Operator: selector.LabelSelectorOpExists},
+				}}},
+				Exclude: []selector.ResourceSelector{{MinSize: ptr(100)}},
+			},
+			want: []string{"chart"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			r.NoError(tt.filter.Validate())
+			var selected []string
+			for _, res := range []*descruntime.Resource{image, chart} {
+				ok, err := tt.filter.Matches(t.Context(), res, size)
+				r.NoError(err)
+				if ok {
+					selected = append(selected, res.Name)
+				}
+			}
+			r.ElementsMatch(tt.want, selected)
+		})
+	}
+
+	t.Run("size thresholds require the size", func(t *testing.T) {
+		r := require.New(t)
+		filter := &selector.ResourceFilter{Include: []selector.ResourceSelector{{Types: []string{"helmChart"}, MaxSize: ptr(100)}}}
+
+		ok, err := filter.Matches(t.Context(), image, nil)
+		r.NoError(err, "the size is not needed if other criteria do not match")
+		r.False(ok)
+
+		_, err = filter.Matches(t.Context(), chart, nil)
+		r.ErrorIs(err, selector.ErrUnknownSize)
+
+		_, err = filter.Matches(t.Context(), chart, func(context.Context, *descruntime.Resource) (int64, error) { return -1, nil })
+		r.ErrorIs(err, selector.ErrUnknownSize)
+
+		_, err = filter.Matches(t.Context(), chart, func(context.Context, *descruntime.Resource) (int64, error) {
+			return 0, errors.New("unreachable")
+		})
+		r.ErrorContains(err, "unreachable")
+	})
+}
+
+func TestResourceFilterValidate(t *testing.T) {
+	r := require.New(t)
+	ptr := func(v int64) *int64 { return &v }
+
+	var filter selector.ResourceFilter
+	r.NoError(yaml.Unmarshal([]byte(`
+include:
+  - types: [ociImage]
+    accessTypes: [OCIImage/v1]
+    matchLabelExpressions:
+      - key: release
+        operator: In
+        values: [stable]
+exclude:
+  - maxSize: 1024
+`), &filter))
+	r.NoError(filter.Validate())
+
+	for name, invalid := range map[string]selector.ResourceSelector{
+		"access type":      {AccessTypes: []string{"a/b/c"}},
+		"missing key":      {MatchLabelExpressions: []selector.LabelSelectorRequirement{{Operator: selector.LabelSelectorOpExists}}},
+		"missing values":   {MatchLabelExpressions: []selector.LabelSelectorRequirement{{Key: "a", Operator: selector.LabelSelectorOpIn}}},
+		"unexpected value": {MatchLabelExpressions: []selector.LabelSelectorRequirement{{Key: "a", Operator: selector.LabelSelectorOpExists, Values: []string{"b"}}}},
+		"operator":         {MatchLabelExpressions: []selector.LabelSelectorRequirement{{Key: "a", Operator: "Equals", Values: []string{"b"}}}},
+		"negative size":    {MinSize: ptr(-1)},
+		"size range":       {MinSize: ptr(2), MaxSize: ptr(1)},
+	} {
+		r.Error((&selector.ResourceFilter{Exclude: []selector.ResourceSelector{invalid}}).Validate(), name)
+	}
+}
@@ -0,0 +1,42 @@
+package runtime
+
+import (
+	"io"
+
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+)
+
+// StreamHandlers receive the converted resources, sources and references of a descriptor decoded with
+// DecodeStream, one element at a time. Elements of a kind without a handler are kept in the returned descriptor.
+type StreamHandlers struct {
+	Resource  func(index int, resource *Resource) error
+	Source    func(index int, source *Source) error
+	Reference func(index int, reference *Reference) error
+}
+
+// DecodeStream decodes a JSON encoded v2 descriptor with v2.DecodeStream and converts it to the internal format.
+// Elements with a handler are converted and passed to the handler one at a time instead of being kept in the
+// returned descriptor, which keeps memory usage low for descriptors with many resources or sources.
+func DecodeStream(r io.Reader, handlers StreamHandlers) (*Descriptor, error) {
+	var v2handlers v2.StreamHandlers
+	if handlers.Resource != nil {
+		v2handlers.Resource = func(index int, resource *v2.Resource) error {
+			return handlers.Resource(index, ConvertFromV2Resource(resource))
+		}
+	}
+	if handlers.Source != nil {
+		v2handlers.Source = func(index int, source *v2.Source) error {
+			return handlers.Source(index, ConvertFromV2Source(source))
+		}
+	}
+	if handlers.Reference != nil {
+		v2handlers.Reference = func(index int, reference *v2.Reference) error {
+			return handlers.Reference(index, ConvertFromV2Reference(reference))
+		}
+	}
+	desc, err := v2.DecodeStream(r, v2handlers)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertFromV2(desc)
+}
@@ -96,6 +96,11 @@ func (in *LocalBlob) DeepCopyInto(out *LocalBlob) {
 	if in.GlobalAccess != nil {
 		out.GlobalAccess = in.GlobalAccess.DeepCopyTyped()
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(LocalBlobEncryption)
+		**out = **in
+	}
 	return
 }
 
@@ -117,6 +122,22 @@ func (in *LocalBlob) DeepCopyTyped() goruntime.Typed {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalBlobEncryption) DeepCopyInto(out *LocalBlobEncryption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalBlobEncryption.
+func (in *LocalBlobEncryption) DeepCopy() *LocalBlobEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalBlobEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Meta) DeepCopyInto(out *Meta) {
 	*out = *in
@@ -212,6 +233,11 @@ func (in *Signature) DeepCopyInto(out *Signature) {
 	*out = *in
 	out.Digest = in.Digest
 	out.Signature = in.Signature
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = new(TimestampSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -290,3 +316,20 @@ func (in *SourceRef) DeepCopy() *SourceRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimestampSpec) DeepCopyInto(out *TimestampSpec) {
+	*out = *in
+	out.Time = in.Time
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimestampSpec.
+func (in *TimestampSpec) DeepCopy() *TimestampSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TimestampSpec)
+	in.DeepCopyInto(out)
+	return out
+}
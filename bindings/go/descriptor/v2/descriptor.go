@@ -318,6 +318,26 @@ type Signature struct {
 	// Signature is the metadata and cryptographic payload proving the authenticity
 	// of the digest. It includes details on the algorithm, encoding, and issuer.
 	Signature SignatureInfo `json:"signature"`
+
+	// Timestamp optionally countersigns the signature with an RFC 3161 timestamp,
+	// proving that the signature existed at the time stated by a time-stamp authority.
+	Timestamp *TimestampSpec `json:"timestamp,omitempty"`
+}
+
+// TimestampSpec is an RFC 3161 timestamp of a signature.
+//
+// See RFC 3161:
+//   - https://datatracker.ietf.org/doc/html/rfc3161
+//
+// +k8s:deepcopy-gen=true
+type TimestampSpec struct {
+	// Value is the base64 encoded DER of the timestamp token, which is
+	// a CMS SignedData structure of the time-stamp authority.
+	Value string `json:"value"`
+
+	// Time is the time stated by the time-stamp authority.
+	// It is informational, verifiers MUST use the time of the timestamp token.
+	Time *Timestamp `json:"time,omitempty"`
 }
 
 // SignatureInfo provides the metadata and cryptographic material for a signature.
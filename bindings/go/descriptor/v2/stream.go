@@ -0,0 +1,142 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamHandlers receive the resources, sources and references of a descriptor decoded with DecodeStream,
+// one element at a time. Elements of a kind without a handler are decoded into the returned descriptor.
+type StreamHandlers struct {
+	// Resource is called for every entry of component.resources in order.
+	Resource func(index int, resource *Resource) error
+	// Source is called for every entry of component.sources in order.
+	Source func(index int, source *Source) error
+	// Reference is called for every entry of component.componentReferences in order.
+	Reference func(index int, reference *Reference) error
+}
+
+// DecodeStream decodes a JSON encoded descriptor from r without materializing all of its elements at once.
+// Resources, sources and references with a handler are passed to the handler and not kept in the
+// returned descriptor, so memory usage of descriptors with many elements does not grow with their number.
+// Handlers are called in document order, which means that the component name and version are not yet known
+// when they are called if they appear after the elements in the document.
+//
+// An error returned by a handler stops decoding and is returned.
+// YAML encoded descriptors are not supported, as they cannot be decoded incrementally.
+func DecodeStream(r io.Reader, handlers StreamHandlers) (*Descriptor, error) {
+	dec := json.NewDecoder(r)
+
+	var component map[string]json.RawMessage
+	fields, err := decodeObject(dec, func(key string) (bool, error) {
+		if key != "component" {
+			return false, nil
+		}
+		var err error
+		component, err = decodeObject(dec, func(key string) (bool, error) {
+			switch {
+			case key == "resources" && handlers.Resource != nil:
+				return true, decodeArray(dec, key, handlers.Resource)
+			case key == "sources" && handlers.Source != nil:
+				return true, decodeArray(dec, key, handlers.Source)
+			case key == "componentReferences" && handlers.Reference != nil:
+				return true, decodeArray(dec, key, handlers.Reference)
+			default:
+				return false, nil
+			}
+		})
+		return true, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode descriptor: %w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode descriptor: unexpected data after descriptor")
+	}
+
+	// everything except the streamed elements is small enough to be decoded as usual.
+	if component != nil {
+		if fields["component"], err = json.Marshal(component); err != nil {
+			return nil, fmt.Errorf("failed to decode component: %w", err)
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode descriptor: %w", err)
+	}
+	var desc Descriptor
+	if err := json.Unmarshal(data, &desc); err != nil {
+		return nil, fmt.Errorf("failed to decode descriptor: %w", err)
+	}
+	return &desc, nil
+}
+
+// decodeObject decodes the next JSON object field by field. Fields consumed by stream are skipped,
+// all others are returned as raw messages. A null object is returned as nil.
+func decodeObject(dec *json.Decoder, stream func(key string) (bool, error)) (map[string]json.RawMessage, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected object but got %v", tok)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected object key but got %v", tok)
+		}
+		streamed, err := stream(key)
+		if err != nil {
+			return nil, err
+		}
+		if streamed {
+			continue
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("failed to decode %q: %w", key, err)
+		}
+		fields[key] = raw
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// decodeArray decodes the next JSON array and passes its entries to handle one at a time.
+func decodeArray[T any](dec *json.Decoder, key string, handle func(index int, elem *T) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array for %q but got %v", key, tok)
+	}
+	for index := 0; dec.More(); index++ {
+		var elem T
+		if err := dec.Decode(&elem); err != nil {
+			return fmt.Errorf("failed to decode %s[%d]: %w", key, index, err)
+		}
+		if err := handle(index, &elem); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
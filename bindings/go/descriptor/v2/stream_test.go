@@ -0,0 +1,89 @@
+package v2_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+)
+
+func TestDecodeStream(t *testing.T) {
+	r := require.New(t)
+
+	var expected descriptorv2.Descriptor
+	r.NoError(json.Unmarshal([]byte(jsonData), &expected))
+	r.NotEmpty(expected.Component.Resources)
+
+	t.Run("without handlers", func(t *testing.T) {
+		r := require.New(t)
+		desc, err := descriptorv2.DecodeStream(strings.NewReader(jsonData), descriptorv2.StreamHandlers{})
+		r.NoError(err)
+		r.Equal(&expected, desc)
+	})
+
+	t.Run("with handlers", func(t *testing.T) {
+		r := require.New(t)
+		var (
+			resources  []descriptorv2.Resource
+			sources    []descriptorv2.Source
+			references []descriptorv2.Reference
+		)
+		desc, err := descriptorv2.DecodeStream(strings.NewReader(jsonData), descriptorv2.StreamHandlers{
+			Resource: func(index int, resource *descriptorv2.Resource) error {
+				r.Len(resources, index)
+				resources = append(resources, *resource)
+				return nil
+			},
+			Source: func(index int, source *descriptorv2.Source) error {
+				r.Len(sources, index)
+				sources = append(sources, *source)
+				return nil
+			},
+			Reference: func(index int, reference *descriptorv2.Reference) error {
+				r.Len(references, index)
+				references = append(references, *reference)
+				return nil
+			},
+		})
+		r.NoError(err)
+		r.Equal(expected.Component.Resources, resources)
+		r.Equal(expected.Component.Sources, sources)
+		r.Equal(expected.Component.References, references)
+
+		r.Nil(desc.Component.Resources)
+		r.Nil(desc.Component.Sources)
+		r.Nil(desc.Component.References)
+		desc.Component.Resources = resources
+		desc.Component.Sources = sources
+		desc.Component.References = references
+		r.Equal(&expected, desc)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		r := require.New(t)
+		stop := errors.New("stop")
+		_, err := descriptorv2.DecodeStream(strings.NewReader(jsonData), descriptorv2.StreamHandlers{
+			Resource: func(int, *descriptorv2.Resource) error { return stop },
+		})
+		r.ErrorIs(err, stop)
+	})
+
+	t.Run("invalid documents", func(t *testing.T) {
+		for _, data := range []string{
+			`[]`,
+			`{"component": {"resources": {}}}`,
+			`{"component": {"resources": [{"name": 1}]}}`,
+			`{"meta": {"schemaVersion": "v2"}} {}`,
+			`{"meta": `,
+		} {
+			_, err := descriptorv2.DecodeStream(strings.NewReader(data), descriptorv2.StreamHandlers{
+				Resource: func(int, *descriptorv2.Resource) error { return nil },
+			})
+			require.Error(t, err, data)
+		}
+	})
+}
@@ -177,6 +177,11 @@ func (in *LocalBlob) DeepCopyInto(out *LocalBlob) {
 		*out = new(runtime.Raw)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(LocalBlobEncryption)
+		**out = **in
+	}
 	return
 }
 
@@ -198,6 +203,22 @@ func (in *LocalBlob) DeepCopyTyped() runtime.Typed {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalBlobEncryption) DeepCopyInto(out *LocalBlobEncryption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalBlobEncryption.
+func (in *LocalBlobEncryption) DeepCopy() *LocalBlobEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalBlobEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Meta) DeepCopyInto(out *Meta) {
 	*out = *in
@@ -350,6 +371,11 @@ func (in *Signature) DeepCopyInto(out *Signature) {
 	*out = *in
 	out.Digest = in.Digest
 	out.Signature = in.Signature
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = new(TimestampSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -447,3 +473,24 @@ func (in *Timestamp) DeepCopy() *Timestamp {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimestampSpec) DeepCopyInto(out *TimestampSpec) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = new(Timestamp)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimestampSpec.
+func (in *TimestampSpec) DeepCopy() *TimestampSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TimestampSpec)
+	in.DeepCopyInto(out)
+	return out
+}
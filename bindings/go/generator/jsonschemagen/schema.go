@@ -396,6 +396,10 @@ func (g *generation) collectReachableQueue(root *universe.TypeInfo) []*universe.
 
 		if ti.Struct != nil {
 			for _, f := range ti.Struct.Fields.List {
+				// fields that are not serialized do not contribute definitions.
+				if name, _ := parseJSONTag(f); name == "-" {
+					continue
+				}
 				if ref, ok := g.U.ResolveExpr(ti.Pkg.TypesInfo, ti.Key.PkgPath, f.Type); ok {
 					walk(ref)
 				}
@@ -411,6 +411,30 @@ func TestGenerate_FieldWithJSONDashExcludedFromRequired(t *testing.T) {
 	require.NotContains(t, s.Required, "-")
 }
 
+func TestGenerate_FieldWithJSONDashExcludedFromDefs(t *testing.T) {
+	u := universe.New()
+	// A -> *cache (json:"-")
+	fieldCache := &ast.Field{
+		Names: []*ast.Ident{{Name: "cache"}},
+		Type:  &ast.StarExpr{X: &ast.Ident{Name: "cache"}},
+		Tag:   &ast.BasicLit{Value: "`json:\"-\"`"},
+	}
+	stA := &ast.StructType{Fields: &ast.FieldList{List: []*ast.Field{fieldCache}}}
+	A := mkTypeInfo("example.com/pkg", "A", nil, stA)
+
+	stCache := &ast.StructType{Fields: &ast.FieldList{}}
+	cache := mkTypeInfo("example.com/pkg", "cache", nil, stCache)
+
+	u.Types[A.Key] = A
+	u.Types[cache.Key] = cache
+
+	g := jsonschemagen.New(u)
+	s := g.GenerateJSONSchemaDraft202012(A)
+
+	require.NotContains(t, s.Properties, "cache")
+	require.NotContains(t, s.Defs, universe.Definition(cache.Key))
+}
+
 func TestGenerate_StructInlineFlattensPropertiesAndRequired(t *testing.T) {
 	u := universe.New()
 
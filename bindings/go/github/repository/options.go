@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"net/http"
+)
+
+// DefaultMaxDownloadSize is the default maximum size of a downloaded release asset (100 MiB).
+const DefaultMaxDownloadSize int64 = 100 * 1024 * 1024
+
+// Options holds configuration options for the GitHub release resource repository.
+type Options struct {
+	Client          *http.Client
+	MaxDownloadSize *int64
+}
+
+// Option is a function that configures Options.
+type Option func(*Options)
+
+// WithHTTPClient sets the HTTP client to use for requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		o.Client = client
+	}
+}
+
+// WithMaxDownloadSize sets the maximum number of bytes of a release asset to download.
+// Defaults to DefaultMaxDownloadSize (100 MiB) when not set.
+// Pass 0 to allow unlimited download size (not recommended for untrusted sources).
+func WithMaxDownloadSize(size int64) Option {
+	return func(o *Options) {
+		o.MaxDownloadSize = &size
+	}
+}
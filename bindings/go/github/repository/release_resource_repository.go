@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+
+	godigest "github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	accessspec "ocm.software/open-component-model/bindings/go/github/spec/access"
+	v1 "ocm.software/open-component-model/bindings/go/github/spec/access/v1"
+	credv1 "ocm.software/open-component-model/bindings/go/github/spec/credentials/v1"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// hashAlgorithmSHA256 is the hash algorithm used for release asset digests.
+	hashAlgorithmSHA256 = "SHA-256"
+	// genericBlobDigestV1 is the normalisation algorithm for a plain downloaded blob.
+	genericBlobDigestV1 = "genericBlobDigest/v1"
+
+	defaultHostname    = "github.com"
+	defaultAPIHostname = "api.github.com"
+	apiVersion         = "2022-11-28"
+)
+
+var (
+	// ErrAssetNotFound is returned if the release or the asset of an access does not exist.
+	ErrAssetNotFound = errors.New("release asset not found")
+	// ErrDigestMismatch is returned if a downloaded release asset does not match its expected digest.
+	ErrDigestMismatch = errors.New("release asset digest mismatch")
+)
+
+var _ repository.ResourceRepository = (*ReleaseResourceRepository)(nil)
+
+// ReleaseResourceRepository implements the ResourceRepository interface for GitHub release asset access types.
+type ReleaseResourceRepository struct {
+	client          *http.Client
+	maxDownloadSize int64
+}
+
+// NewReleaseResourceRepository creates a new GitHub release resource repository.
+func NewReleaseResourceRepository(opts ...Option) *ReleaseResourceRepository {
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	client := options.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	maxSize := DefaultMaxDownloadSize
+	if options.MaxDownloadSize != nil {
+		maxSize = *options.MaxDownloadSize
+	}
+	return &ReleaseResourceRepository{
+		client:          client,
+		maxDownloadSize: maxSize,
+	}
+}
+
+// GetResourceRepositoryScheme returns the scheme used by the GitHub release resource repository.
+func (r *ReleaseResourceRepository) GetResourceRepositoryScheme() *runtime.Scheme {
+	return accessspec.ReleaseScheme
+}
+
+// GetResourceCredentialConsumerIdentity resolves the credential consumer identity for the given resource.
+// The identity is a GitHubRepository identity with the hostname of the GitHub instance and the path owner/repository,
+// so tokens configured for the repository are used.
+func (r *ReleaseResourceRepository) GetResourceCredentialConsumerIdentity(ctx context.Context, resource *descriptor.Resource) (runtime.Identity, error) {
+	release, err := r.convert(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname := release.APIHostname
+	if hostname == "" || hostname == defaultAPIHostname {
+		hostname = defaultHostname
+	}
+	identity := runtime.Identity{
+		runtime.IdentityAttributeHostname: hostname,
+		runtime.IdentityAttributePath:     path.Join(release.Owner, release.Repository),
+	}
+	identity.SetType(runtime.NewUnversionedType(accessspec.GitHubRepositoryConsumerType))
+
+	return identity, nil
+}
+
+// DownloadResource downloads the release asset specified in the access spec. The asset is verified
+// against the digest of the access spec and the digest reported by GitHub, if any.
+func (r *ReleaseResourceRepository) DownloadResource(ctx context.Context, resource *descriptor.Resource, credentials runtime.Typed) (blob.ReadOnlyBlob, error) {
+	release, err := r.convert(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var token string
+	if credentials != nil {
+		creds, err := credv1.ConvertToGitHubCredentials(credentials)
+		if err != nil {
+			return nil, fmt.Errorf("error converting credentials: %w", err)
+		}
+		token = creds.Token
+	}
+
+	asset, err := r.getAsset(ctx, release, token)
+	if err != nil {
+		return nil, err
+	}
+	if r.maxDownloadSize > 0 && asset.Size > r.maxDownloadSize {
+		return nil, fmt.Errorf("release asset %q has %d bytes and exceeds maximum allowed size of %d bytes", asset.Name, asset.Size, r.maxDownloadSize)
+	}
+
+	data, err := r.download(ctx, asset, token)
+	if err != nil {
+		return nil, err
+	}
+
+	dig := godigest.FromBytes(data)
+	for _, expected := range []string{release.Digest, asset.Digest} {
+		if expected != "" && expected != dig.String() {
+			return nil, fmt.Errorf("%w: release asset %q: expected %s, got %s", ErrDigestMismatch, asset.Name, expected, dig)
+		}
+	}
+
+	mediaType := release.MediaType
+	if mediaType == "" {
+		mediaType = asset.ContentType
+	}
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+
+	return inmemory.New(bytes.NewReader(data),
+		inmemory.WithMediaType(mediaType),
+		inmemory.WithSize(int64(len(data))),
+		inmemory.WithDigest(dig.String()),
+	), nil
+}
+
+// UploadResource is not supported for GitHub release access types.
+func (r *ReleaseResourceRepository) UploadResource(ctx context.Context, res *descriptor.Resource, content blob.ReadOnlyBlob, credentials runtime.Typed) (*descriptor.Resource, error) {
+	return nil, fmt.Errorf("upload is not supported for GitHub release access type")
+}
+
+// GetResourceDigestProcessorCredentialConsumerIdentity resolves the credential consumer
+// identity used when downloading the resource to compute its digest.
+func (r *ReleaseResourceRepository) GetResourceDigestProcessorCredentialConsumerIdentity(ctx context.Context, resource *descriptor.Resource) (runtime.Identity, error) {
+	return r.GetResourceCredentialConsumerIdentity(ctx, resource)
+}
+
+// ProcessResourceDigest computes the digest of a release asset by downloading it.
+// When the resource already carries a digest, the computed value is verified against it.
+func (r *ReleaseResourceRepository) ProcessResourceDigest(ctx context.Context, resource *descriptor.Resource, credentials runtime.Typed) (*descriptor.Resource, error) {
+	data, err := r.DownloadResource(ctx, resource, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading resource for digest processing: %w", err)
+	}
+
+	rc, err := data.ReadCloser()
+	if err != nil {
+		return nil, fmt.Errorf("error opening downloaded resource: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	dig, err := godigest.FromReader(rc)
+	if err != nil {
+		return nil, fmt.Errorf("error computing resource digest: %w", err)
+	}
+
+	resource = resource.DeepCopy()
+	if resource.Digest == nil {
+		resource.Digest = &descriptor.Digest{
+			HashAlgorithm:          hashAlgorithmSHA256,
+			NormalisationAlgorithm: genericBlobDigestV1,
+			Value:                  dig.Encoded(),
+		}
+		return resource, nil
+	}
+
+	if resource.Digest.HashAlgorithm != hashAlgorithmSHA256 {
+		return nil, fmt.Errorf("unsupported hash algorithm: expected %s, got %s", hashAlgorithmSHA256, resource.Digest.HashAlgorithm)
+	}
+	if resource.Digest.NormalisationAlgorithm != genericBlobDigestV1 {
+		return nil, fmt.Errorf("unsupported normalisation algorithm: expected %s, got %s", genericBlobDigestV1, resource.Digest.NormalisationAlgorithm)
+	}
+	if resource.Digest.Value != dig.Encoded() {
+		return nil, fmt.Errorf("digest mismatch: expected %s, got %s", resource.Digest.Value, dig.Encoded())
+	}
+
+	return resource, nil
+}
+
+func (r *ReleaseResourceRepository) convert(resource *descriptor.Resource) (*v1.GitHubRelease, error) {
+	if resource == nil {
+		return nil, fmt.Errorf("resource is required")
+	}
+	if resource.Access == nil {
+		return nil, fmt.Errorf("resource access is required")
+	}
+
+	release := &v1.GitHubRelease{}
+	if err := accessspec.ReleaseScheme.Convert(resource.Access, release); err != nil {
+		return nil, fmt.Errorf("error converting resource access spec: %w", err)
+	}
+	if err := release.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid GitHub release access: %w", err)
+	}
+	return release, nil
+}
+
+// releaseAsset is the subset of a release asset returned by the GitHub REST API that is needed for a download.
+type releaseAsset struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+	Digest      string `json:"digest"`
+}
+
+// getAsset looks up the asset of the release by its name.
+func (r *ReleaseResourceRepository) getAsset(ctx context.Context, release *v1.GitHubRelease, token string) (*releaseAsset, error) {
+	releaseURL := apiBaseURL(release.APIHostname).JoinPath("repos", release.Owner, release.Repository, "releases", "tags", release.Tag)
+	resp, err := r.do(ctx, releaseURL.String(), "application/vnd.github+json", token)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close(ctx, resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: release %s of %s/%s does not exist", ErrAssetNotFound, release.Tag, release.Owner, release.Repository)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP request to %s returned status %d", releaseURL, resp.StatusCode)
+	}
+
+	var info struct {
+		Assets []releaseAsset `json:"assets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("error decoding release %s of %s/%s: %w", release.Tag, release.Owner, release.Repository, err)
+	}
+	for _, asset := range info.Assets {
+		if asset.Name == release.Asset {
+			return &asset, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: release %s of %s/%s has no asset %q", ErrAssetNotFound, release.Tag, release.Owner, release.Repository, release.Asset)
+}
+
+// download downloads the content of the asset. GitHub redirects asset downloads to a storage host;
+// the HTTP client does not forward the Authorization header to it.
+func (r *ReleaseResourceRepository) download(ctx context.Context, asset *releaseAsset, token string) ([]byte, error) {
+	resp, err := r.do(ctx, asset.URL, "application/octet-stream", token)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download of release asset %q returned status %d", asset.Name, resp.StatusCode)
+	}
+
+	body := io.Reader(resp.Body)
+	if r.maxDownloadSize > 0 {
+		body = io.LimitReader(resp.Body, r.maxDownloadSize+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading release asset %q: %w", asset.Name, err)
+	}
+	if r.maxDownloadSize > 0 && int64(len(data)) > r.maxDownloadSize {
+		return nil, fmt.Errorf("release asset %q exceeds maximum allowed size of %d bytes", asset.Name, r.maxDownloadSize)
+	}
+	return data, nil
+}
+
+func (r *ReleaseResourceRepository) do(ctx context.Context, rawURL, accept, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing HTTP request to %s: %w", rawURL, err)
+	}
+	return resp, nil
+}
+
+func (r *ReleaseResourceRepository) close(ctx context.Context, resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		slog.WarnContext(ctx, "failed to close HTTP response body", "error", err)
+	}
+}
+
+// apiBaseURL returns the base URL of the GitHub REST API. GitHub Enterprise serves the API under /api/v3.
+func apiBaseURL(hostname string) *url.URL {
+	if hostname == "" || hostname == defaultHostname || hostname == defaultAPIHostname {
+		return &url.URL{Scheme: "https", Host: defaultAPIHostname}
+	}
+	return &url.URL{Scheme: "https", Host: hostname, Path: "/api/v3"}
+}
@@ -0,0 +1,214 @@
+package repository_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	godigest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/github/repository"
+	v1 "ocm.software/open-component-model/bindings/go/github/spec/access/v1"
+	credv1 "ocm.software/open-component-model/bindings/go/github/spec/credentials/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const assetContent = "ocm binary"
+
+// fakeGitHub serves the release v1.0.0 of open-component-model/ocm with a single asset,
+// whose download is redirected to a storage path like on github.com.
+type fakeGitHub struct {
+	server        *httptest.Server
+	digest        string
+	authorization []string
+}
+
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	t.Helper()
+	gh := &fakeGitHub{digest: godigest.FromString(assetContent).String()}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/open-component-model/ocm/releases/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		gh.authorization = append(gh.authorization, r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"tag_name": "v1.0.0",
+			"assets": []map[string]any{{
+				"name":         "ocm-linux-amd64",
+				"url":          gh.server.URL + "/api/v3/repos/open-component-model/ocm/releases/assets/1",
+				"size":         len(assetContent),
+				"content_type": "application/x-executable",
+				"digest":       gh.digest,
+			}},
+		})
+	})
+	mux.HandleFunc("GET /api/v3/repos/open-component-model/ocm/releases/assets/1", func(w http.ResponseWriter, r *http.Request) {
+		gh.authorization = append(gh.authorization, r.Header.Get("Authorization"))
+		if r.Header.Get("Accept") != "application/octet-stream" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		http.Redirect(w, r, "/storage/ocm-linux-amd64", http.StatusFound)
+	})
+	mux.HandleFunc("GET /storage/ocm-linux-amd64", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, assetContent)
+	})
+	gh.server = httptest.NewTLSServer(mux)
+	t.Cleanup(gh.server.Close)
+	return gh
+}
+
+func (gh *fakeGitHub) resource(t *testing.T, modify func(*v1.GitHubRelease)) *descruntime.Resource {
+	t.Helper()
+	u, err := url.Parse(gh.server.URL)
+	require.NoError(t, err)
+	spec := &v1.GitHubRelease{
+		Type:        runtime.NewVersionedType(v1.CamelReleaseType, v1.Version),
+		Owner:       "open-component-model",
+		Repository:  "ocm",
+		Tag:         "v1.0.0",
+		Asset:       "ocm-linux-amd64",
+		APIHostname: u.Host,
+	}
+	if modify != nil {
+		modify(spec)
+	}
+
+	res := &descruntime.Resource{}
+	res.Name = "ocm"
+	res.Version = "1.0.0"
+	res.Type = "executable"
+	res.Access = spec
+	return res
+}
+
+func TestReleaseResourceRepository_DownloadResource(t *testing.T) {
+	token := &credv1.GitHubCredentials{Type: credv1.GitHubCredentialsVersionedType, Token: "secret"}
+
+	t.Run("downloads and verifies asset", func(t *testing.T) {
+		r := require.New(t)
+		gh := newFakeGitHub(t)
+		repo := repository.NewReleaseResourceRepository(repository.WithHTTPClient(gh.server.Client()))
+
+		b, err := repo.DownloadResource(t.Context(), gh.resource(t, func(spec *v1.GitHubRelease) {
+			spec.Digest = gh.digest
+		}), token)
+		r.NoError(err)
+		rc, err := b.ReadCloser()
+		r.NoError(err)
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		r.NoError(err)
+		r.Equal(assetContent, string(data))
+		r.Equal([]string{"Bearer secret", "Bearer secret"}, gh.authorization)
+	})
+
+	t.Run("media type of the spec takes precedence", func(t *testing.T) {
+		r := require.New(t)
+		gh := newFakeGitHub(t)
+		repo := repository.NewReleaseResourceRepository(repository.WithHTTPClient(gh.server.Client()))
+
+		b, err := repo.DownloadResource(t.Context(), gh.resource(t, nil), nil)
+		r.NoError(err)
+		mediaType, _ := b.(interface{ MediaType() (string, bool) }).MediaType()
+		r.Equal("application/x-executable", mediaType)
+
+		b, err = repo.DownloadResource(t.Context(), gh.resource(t, func(spec *v1.GitHubRelease) {
+			spec.MediaType = "application/octet-stream"
+		}), nil)
+		r.NoError(err)
+		mediaType, _ = b.(interface{ MediaType() (string, bool) }).MediaType()
+		r.Equal("application/octet-stream", mediaType)
+		r.Equal([]string{"", ""}, gh.authorization[:2], "no authorization is sent without credentials")
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		gh := newFakeGitHub(t)
+		repo := repository.NewReleaseResourceRepository(repository.WithHTTPClient(gh.server.Client()))
+
+		_, err := repo.DownloadResource(t.Context(), gh.resource(t, func(spec *v1.GitHubRelease) {
+			spec.Digest = godigest.FromString("other").String()
+		}), nil)
+		require.ErrorIs(t, err, repository.ErrDigestMismatch)
+
+		gh.digest = godigest.FromString("tampered").String()
+		_, err = repo.DownloadResource(t.Context(), gh.resource(t, nil), nil)
+		require.ErrorIs(t, err, repository.ErrDigestMismatch, "digest reported by GitHub must be verified")
+	})
+
+	t.Run("unknown release or asset", func(t *testing.T) {
+		gh := newFakeGitHub(t)
+		repo := repository.NewReleaseResourceRepository(repository.WithHTTPClient(gh.server.Client()))
+
+		_, err := repo.DownloadResource(t.Context(), gh.resource(t, func(spec *v1.GitHubRelease) {
+			spec.Tag = "v2.0.0"
+		}), nil)
+		require.ErrorIs(t, err, repository.ErrAssetNotFound)
+
+		_, err = repo.DownloadResource(t.Context(), gh.resource(t, func(spec *v1.GitHubRelease) {
+			spec.Asset = "ocm-darwin-arm64"
+		}), nil)
+		require.ErrorIs(t, err, repository.ErrAssetNotFound)
+	})
+
+	t.Run("exceeds maximum download size", func(t *testing.T) {
+		gh := newFakeGitHub(t)
+		repo := repository.NewReleaseResourceRepository(
+			repository.WithHTTPClient(gh.server.Client()),
+			repository.WithMaxDownloadSize(4),
+		)
+
+		_, err := repo.DownloadResource(t.Context(), gh.resource(t, nil), nil)
+		require.ErrorContains(t, err, "exceeds maximum allowed size")
+	})
+
+	t.Run("invalid access", func(t *testing.T) {
+		gh := newFakeGitHub(t)
+		repo := repository.NewReleaseResourceRepository(repository.WithHTTPClient(gh.server.Client()))
+
+		_, err := repo.DownloadResource(t.Context(), gh.resource(t, func(spec *v1.GitHubRelease) {
+			spec.Asset = ""
+		}), nil)
+		require.ErrorContains(t, err, "asset must not be empty")
+	})
+}
+
+func TestReleaseResourceRepository_GetResourceCredentialConsumerIdentity(t *testing.T) {
+	r := require.New(t)
+	gh := newFakeGitHub(t)
+	repo := repository.NewReleaseResourceRepository()
+
+	identity, err := repo.GetResourceCredentialConsumerIdentity(t.Context(), gh.resource(t, func(spec *v1.GitHubRelease) {
+		spec.APIHostname = ""
+	}))
+	r.NoError(err)
+	r.Equal(runtime.Identity{
+		runtime.IdentityAttributeType:     "GitHubRepository",
+		runtime.IdentityAttributeHostname: "github.com",
+		runtime.IdentityAttributePath:     "open-component-model/ocm",
+	}, identity)
+}
+
+func TestReleaseResourceRepository_ProcessResourceDigest(t *testing.T) {
+	r := require.New(t)
+	gh := newFakeGitHub(t)
+	repo := repository.NewReleaseResourceRepository(repository.WithHTTPClient(gh.server.Client()))
+
+	res, err := repo.ProcessResourceDigest(t.Context(), gh.resource(t, nil), nil)
+	r.NoError(err)
+	r.Equal(&descruntime.Digest{
+		HashAlgorithm:          "SHA-256",
+		NormalisationAlgorithm: "genericBlobDigest/v1",
+		Value:                  godigest.FromString(assetContent).Encoded(),
+	}, res.Digest)
+
+	_, err = repo.ProcessResourceDigest(t.Context(), res, nil)
+	r.NoError(err, "a matching digest must be accepted")
+
+	res.Digest.Value = godigest.FromString("other").Encoded()
+	_, err = repo.ProcessResourceDigest(t.Context(), res, nil)
+	r.ErrorContains(err, "digest mismatch")
+}
@@ -10,10 +10,15 @@ import (
 // property holding a GitHub (Enterprise) access token.
 const GitHubRepositoryConsumerType = "GitHubRepository"
 
+// Scheme holds all GitHub access types.
 var Scheme = runtime.NewScheme()
 
+// ReleaseScheme only holds the GitHub release asset access type.
+var ReleaseScheme = runtime.NewScheme()
+
 func init() {
 	MustAddToScheme(Scheme)
+	MustAddReleaseToScheme(ReleaseScheme)
 }
 
 func MustAddToScheme(scheme *runtime.Scheme) {
@@ -26,4 +31,15 @@ func MustAddToScheme(scheme *runtime.Scheme) {
 		runtime.NewVersionedType(v1.CamelLegacyType, v1.Version),
 		runtime.NewUnversionedType(v1.CamelLegacyType),
 	)
+	MustAddReleaseToScheme(scheme)
+}
+
+// MustAddReleaseToScheme registers the GitHub release asset access type.
+func MustAddReleaseToScheme(scheme *runtime.Scheme) {
+	scheme.MustRegisterWithAlias(&v1.GitHubRelease{},
+		runtime.NewVersionedType(v1.ReleaseType, v1.Version),
+		runtime.NewUnversionedType(v1.ReleaseType),
+		runtime.NewVersionedType(v1.CamelReleaseType, v1.Version),
+		runtime.NewUnversionedType(v1.CamelReleaseType),
+	)
 }
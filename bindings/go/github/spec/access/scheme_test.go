@@ -42,3 +42,24 @@ func TestScheme_DecodeLegacyType(t *testing.T) {
 	assert.Equal(t, "refs/heads/main", gh.Ref)
 	assert.NoError(t, gh.Validate())
 }
+
+func TestScheme_ReleaseTypeAliases(t *testing.T) {
+	types := []runtime.Type{
+		runtime.NewVersionedType(v1.ReleaseType, v1.Version),      // GitHubRelease/v1
+		runtime.NewUnversionedType(v1.ReleaseType),                // GitHubRelease
+		runtime.NewVersionedType(v1.CamelReleaseType, v1.Version), // gitHubRelease/v1
+		runtime.NewUnversionedType(v1.CamelReleaseType),           // gitHubRelease
+	}
+	for _, typ := range types {
+		t.Run(typ.String(), func(t *testing.T) {
+			for _, scheme := range []*runtime.Scheme{access.Scheme, access.ReleaseScheme} {
+				obj, err := scheme.NewObject(typ)
+				require.NoError(t, err)
+				assert.IsType(t, &v1.GitHubRelease{}, obj)
+			}
+		})
+	}
+
+	_, err := access.ReleaseScheme.NewObject(runtime.NewVersionedType(v1.Type, v1.Version))
+	assert.Error(t, err, "the release scheme must not contain the commit access type")
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// ReleaseType is the canonical release asset access type, using the GitHub brand casing.
+	ReleaseType = "GitHubRelease"
+	// CamelReleaseType is the camelCase spelling following the naming of the OCM spec access types.
+	CamelReleaseType = "gitHubRelease"
+)
+
+// GitHubRelease describes the access to an asset of a GitHub release, downloadable
+// via the GitHub REST API. Many third-party tools are distributed only as release assets,
+// so this access allows packaging them without mirroring them to another location first.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type GitHubRelease struct {
+	// +ocm:jsonschema-gen:enum=GitHubRelease/v1,GitHubRelease,gitHubRelease/v1,gitHubRelease
+	Type runtime.Type `json:"type"`
+
+	// Owner is the user or organization owning the repository (e.g. open-component-model).
+	Owner string `json:"owner"`
+
+	// Repository is the name of the repository (e.g. ocm).
+	Repository string `json:"repository"`
+
+	// Tag is the tag of the release (e.g. v0.27.0).
+	Tag string `json:"tag"`
+
+	// Asset is the file name of the release asset (e.g. ocm-0.27.0-linux-amd64.tar.gz).
+	Asset string `json:"asset"`
+
+	// APIHostname overrides the GitHub REST API hostname for GitHub Enterprise.
+	APIHostname string `json:"apiHostname,omitempty"`
+
+	// MediaType is the media type of the asset. Defaults to the content type reported by GitHub.
+	MediaType string `json:"mediaType,omitempty"`
+
+	// Digest is the expected digest of the asset (e.g. sha256:...).
+	// The download is verified against it and against the digest reported by GitHub, if any.
+	Digest string `json:"digest,omitempty"`
+}
+
+// Validate checks that the access spec identifies a single release asset
+// and that a set Digest is well-formed.
+func (g *GitHubRelease) Validate() error {
+	switch {
+	case g.Owner == "":
+		return fmt.Errorf("owner must not be empty")
+	case g.Repository == "":
+		return fmt.Errorf("repository must not be empty")
+	case g.Tag == "":
+		return fmt.Errorf("tag must not be empty")
+	case g.Asset == "":
+		return fmt.Errorf("asset must not be empty")
+	}
+	if g.Digest != "" {
+		if err := digest.Digest(g.Digest).Validate(); err != nil {
+			return fmt.Errorf("invalid digest %q: %w", g.Digest, err)
+		}
+	}
+	return nil
+}
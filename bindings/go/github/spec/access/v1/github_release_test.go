@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubRelease_Validate(t *testing.T) {
+	valid := GitHubRelease{
+		Owner:      "open-component-model",
+		Repository: "ocm",
+		Tag:        "v0.27.0",
+		Asset:      "ocm-0.27.0-linux-amd64.tar.gz",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*GitHubRelease)
+		wantErr string
+	}{
+		{name: "valid"},
+		{
+			name: "valid with digest",
+			modify: func(g *GitHubRelease) {
+				g.Digest = "sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+			},
+		},
+		{name: "missing owner", modify: func(g *GitHubRelease) { g.Owner = "" }, wantErr: "owner"},
+		{name: "missing repository", modify: func(g *GitHubRelease) { g.Repository = "" }, wantErr: "repository"},
+		{name: "missing tag", modify: func(g *GitHubRelease) { g.Tag = "" }, wantErr: "tag"},
+		{name: "missing asset", modify: func(g *GitHubRelease) { g.Asset = "" }, wantErr: "asset"},
+		{name: "invalid digest", modify: func(g *GitHubRelease) { g.Digest = "sha256:abc" }, wantErr: "invalid digest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := valid
+			if tt.modify != nil {
+				tt.modify(&release)
+			}
+			err := release.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
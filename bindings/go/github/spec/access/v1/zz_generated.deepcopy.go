@@ -33,3 +33,28 @@ func (in *GitHub) DeepCopyTyped() runtime.Typed {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubRelease) DeepCopyInto(out *GitHubRelease) {
+	*out = *in
+	out.Type = in.Type
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubRelease.
+func (in *GitHubRelease) DeepCopy() *GitHubRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyTyped is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Typed.
+func (in *GitHubRelease) DeepCopyTyped() runtime.Typed {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -12,7 +12,15 @@ import (
 //go:embed schemas/GitHub.schema.json
 var schemaGitHub []byte
 
+//go:embed schemas/GitHubRelease.schema.json
+var schemaGitHubRelease []byte
+
 // JSONSchema returns the JSON Schema for GitHub.
 func (GitHub) JSONSchema() []byte {
 	return schemaGitHub
 }
+
+// JSONSchema returns the JSON Schema for GitHubRelease.
+func (GitHubRelease) JSONSchema() []byte {
+	return schemaGitHubRelease
+}
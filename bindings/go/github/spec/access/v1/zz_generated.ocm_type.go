@@ -16,3 +16,13 @@ func (t *GitHub) SetType(typ runtime.Type) {
 func (t *GitHub) GetType() runtime.Type {
 	return t.Type
 }
+
+// SetType is an autogenerated setter function, useful for type inference and defaulting.
+func (t *GitHubRelease) SetType(typ runtime.Type) {
+	t.Type = typ
+}
+
+// GetType is an autogenerated getter function, useful for type inference and defaulting.
+func (t *GitHubRelease) GetType() runtime.Type {
+	return t.Type
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"fmt"
+
+	directcredsv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const credentialKeyToken = "token"
+
+var convertScheme = runtime.NewScheme()
+
+func init() {
+	MustRegisterCredentialType(convertScheme)
+	directcredsv1.MustRegister(convertScheme)
+}
+
+// ConvertToGitHubCredentials converts runtime.Typed into GitHubCredentials.
+// Direct conversion as well as converting from DirectCredentials/v1 with a "token" property is supported.
+func ConvertToGitHubCredentials(creds runtime.Typed) (*GitHubCredentials, error) {
+	typed, err := convertScheme.NewObject(creds.GetType())
+	if err != nil {
+		return nil, fmt.Errorf("error converting credential type: %w", err)
+	}
+
+	if err = convertScheme.Convert(creds, typed); err != nil {
+		return nil, fmt.Errorf("error converting credential type: %w", err)
+	}
+
+	switch t := typed.(type) {
+	case *directcredsv1.DirectCredentials:
+		return &GitHubCredentials{
+			Type:  GitHubCredentialsVersionedType,
+			Token: t.Properties[credentialKeyToken],
+		}, nil
+	case *GitHubCredentials:
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("unsupported credential type %v", typed.GetType())
+}
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	credv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func TestConvertToGitHubCredentials(t *testing.T) {
+	t.Run("GitHubCredentials passthrough", func(t *testing.T) {
+		creds, err := ConvertToGitHubCredentials(&GitHubCredentials{Type: GitHubCredentialsVersionedType, Token: "token"})
+		require.NoError(t, err)
+		require.Equal(t, "token", creds.Token)
+	})
+
+	t.Run("DirectCredentials token property", func(t *testing.T) {
+		creds, err := ConvertToGitHubCredentials(&credv1.DirectCredentials{
+			Type:       runtime.NewVersionedType(credv1.CredentialsType, credv1.Version),
+			Properties: map[string]string{credentialKeyToken: "token"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &GitHubCredentials{Type: GitHubCredentialsVersionedType, Token: "token"}, creds)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := ConvertToGitHubCredentials(&runtime.Raw{Type: runtime.NewVersionedType("Unknown", "v1")})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,26 @@
+package v1
+
+import "ocm.software/open-component-model/bindings/go/runtime"
+
+var GitHubCredentialsVersionedType = runtime.NewVersionedType(GitHubCredentialsType, Version)
+
+// MustRegisterCredentialType registers GitHubCredentials/v1 (and its unversioned alias) in the given scheme.
+func MustRegisterCredentialType(scheme *runtime.Scheme) {
+	scheme.MustRegisterWithAlias(&GitHubCredentials{},
+		GitHubCredentialsVersionedType,
+		runtime.NewUnversionedType(GitHubCredentialsType),
+	)
+}
+
+// GitHubCredentials represents typed credentials for accessing the GitHub REST API.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+type GitHubCredentials struct {
+	// +ocm:jsonschema-gen:enum=GitHubCredentials/v1
+	// +ocm:jsonschema-gen:enum:deprecated=GitHubCredentials
+	Type runtime.Type `json:"type"`
+	// Token is a GitHub (Enterprise) access token sent as "Authorization: Bearer <token>".
+	Token string `json:"token,omitempty"`
+}
@@ -0,0 +1,6 @@
+package v1
+
+const (
+	Version               = "v1"
+	GitHubCredentialsType = "GitHubCredentials"
+)
@@ -16,6 +16,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/helm/internal/oci"
 	helmcredsv1 "ocm.software/open-component-model/bindings/go/helm/spec/credentials/v1"
 	"ocm.software/open-component-model/bindings/go/helm/spec/input/v1"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
 	ocicredsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
 )
@@ -57,10 +58,18 @@ func WithHTTPConfig(cfg *httpv1alpha1.Config) Option {
 	}
 }
 
+// WithThrottle sets the Throttle consulted by chart downloads and OCI registry access.
+func WithThrottle(throttle *ocmhttp.Throttle) Option {
+	return func(options *Options) {
+		options.Throttle = throttle
+	}
+}
+
 type Options struct {
 	Credentials    *helmcredsv1.HelmHTTPCredentials
 	OCICredentials *ocicredsv1.OCICredentials
 	HTTPConfig     *httpv1alpha1.Config
+	Throttle       *ocmhttp.Throttle
 }
 
 // GetV1HelmBlob creates a ReadOnlyBlob from a v1.Helm specification.
@@ -87,7 +96,7 @@ func GetV1HelmBlob(ctx context.Context, helmSpec v1.Helm, tmpDir string, opts ..
 			return nil, nil, fmt.Errorf("error loading local helm chart %q: %w", helmSpec.Path, err)
 		}
 	case helmSpec.HelmRepository != "":
-		chart, err = newReadOnlyChartFromRemote(ctx, helmSpec, tmpDir, options.Credentials, options.OCICredentials, options.HTTPConfig)
+		chart, err = newReadOnlyChartFromRemote(ctx, helmSpec, tmpDir, options)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error loading remote helm chart from %q: %w", helmSpec.HelmRepository, err)
 		}
@@ -177,13 +186,11 @@ func newReadOnlyChartFromRemote(
 	ctx context.Context,
 	helmSpec v1.Helm,
 	tmpDirBase string,
-	credentials *helmcredsv1.HelmHTTPCredentials,
-	ociCredentials *ocicredsv1.OCICredentials,
-	httpConfig *httpv1alpha1.Config,
+	options *Options,
 ) (result *ReadOnlyChart, err error) {
 	opts := []dlinternal.Option{
-		dlinternal.WithCredentials(credentials),
-		dlinternal.WithOCICredentials(ociCredentials),
+		dlinternal.WithCredentials(options.Credentials),
+		dlinternal.WithOCICredentials(options.OCICredentials),
 		//nolint:staticcheck // downward compatibility for helm input
 		dlinternal.WithVersion(helmSpec.Version),
 		//nolint:staticcheck // downward compatibility for helm input
@@ -191,8 +198,11 @@ func newReadOnlyChartFromRemote(
 		//nolint:staticcheck // downward compatibility for helm input
 		dlinternal.WithCACertFile(helmSpec.CACertFile),
 	}
-	if httpConfig != nil {
-		opts = append(opts, dlinternal.WithHTTPConfig(httpConfig))
+	if options.HTTPConfig != nil {
+		opts = append(opts, dlinternal.WithHTTPConfig(options.HTTPConfig))
+	}
+	if options.Throttle != nil {
+		opts = append(opts, dlinternal.WithThrottle(options.Throttle))
 	}
 	resultChart, err := dlinternal.NewReadOnlyChartFromRemote(ctx, helmSpec.HelmRepository, tmpDirBase, opts...)
 	if err != nil {
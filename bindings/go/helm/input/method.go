@@ -13,6 +13,7 @@ import (
 	credsv1 "ocm.software/open-component-model/bindings/go/helm/spec/credentials/v1"
 	"ocm.software/open-component-model/bindings/go/helm/spec/input"
 	"ocm.software/open-component-model/bindings/go/helm/spec/input/v1"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/oci/looseref"
 	access "ocm.software/open-component-model/bindings/go/oci/spec/access"
@@ -37,6 +38,8 @@ var _ interface {
 type InputMethod struct {
 	TempFolder string
 	HTTPConfig *httpv1alpha1.Config
+	// Throttle is consulted for the request rate and retry budget of remote chart downloads.
+	Throttle *ocmhttp.Throttle
 }
 
 // LegacyHelmChartConsumerType is the type of the identity for remote helm repositories.
@@ -103,6 +106,9 @@ func (i *InputMethod) ProcessResource(ctx context.Context, resource *constructor
 	if i.HTTPConfig != nil {
 		credOpts = append(credOpts, WithHTTPConfig(i.HTTPConfig))
 	}
+	if i.Throttle != nil {
+		credOpts = append(credOpts, WithThrottle(i.Throttle))
+	}
 
 	helmBlob, chart, err := GetV1HelmBlob(ctx, helm, i.TempFolder, credOpts...)
 	if err != nil {
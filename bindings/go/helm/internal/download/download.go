@@ -108,11 +108,11 @@ func NewReadOnlyChartFromRemote(ctx context.Context, helmRepo, targetDir string,
 
 	var regClientOpts []registry.ClientOption
 	var httpClient *http.Client
-	if opt.HTTPConfig != nil {
+	if opt.HTTPConfig != nil || opt.Throttle != nil {
 		// Build a single client from the full config (includes per-host routing).
 		// It is shared between the OCI registry client and the HTTP/S getter so
-		// both paths use the same timeout and per-host override behaviour.
-		httpClient = ocmhttp.New(ocmhttp.WithConfig(opt.HTTPConfig))
+		// both paths use the same timeout, per-host override and throttling behaviour.
+		httpClient = ocmhttp.New(ocmhttp.WithConfig(opt.HTTPConfig), ocmhttp.WithThrottle(opt.Throttle))
 		regClientOpts = append(regClientOpts, registry.ClientOptHTTPClient(httpClient))
 	}
 	regClient, err := registry.NewClient(regClientOpts...)
@@ -6,6 +6,7 @@ import (
 	"helm.sh/helm/v4/pkg/getter"
 
 	helmcredsv1 "ocm.software/open-component-model/bindings/go/helm/spec/credentials/v1"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
 	ocicredsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
 )
@@ -59,6 +60,10 @@ type option struct {
 	// Accepts the serialisable config type so that external plugins can round-trip it over
 	// the wire and reconstruct an equivalent client.
 	HTTPConfig *httpv1alpha1.Config
+
+	// Throttle is consulted for the per-host request rate and the retry budget of chart downloads
+	// and OCI registry access. It is shared with other subsystems talking to the same upstreams.
+	Throttle *ocmhttp.Throttle
 }
 
 // Option configures the behavior of [NewReadOnlyChartFromRemote].
@@ -125,3 +130,11 @@ func WithHTTPConfig(cfg *httpv1alpha1.Config) Option {
 		t.HTTPConfig = cfg
 	}
 }
+
+// WithThrottle sets the Throttle consulted by chart downloads and OCI registry access.
+// Setting it builds an internal client even without [WithHTTPConfig].
+func WithThrottle(throttle *ocmhttp.Throttle) Option {
+	return func(t *option) {
+		t.Throttle = throttle
+	}
+}
@@ -17,6 +17,7 @@ import (
 	helmaccess "ocm.software/open-component-model/bindings/go/helm/spec/access"
 	"ocm.software/open-component-model/bindings/go/helm/spec/access/v1"
 	helmcredsv1 "ocm.software/open-component-model/bindings/go/helm/spec/credentials/v1"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
@@ -28,6 +29,7 @@ import (
 type ResourceRepository struct {
 	filesystemConfig *filesystemv1alpha1.Config
 	httpConfig       *httpv1alpha1.Config
+	throttle         *ocmhttp.Throttle
 }
 
 // Option configures a ResourceRepository.
@@ -43,6 +45,14 @@ func WithHTTPConfig(cfg *httpv1alpha1.Config) Option {
 	}
 }
 
+// WithThrottle sets the Throttle consulted by chart downloads and OCI registry access, shared with
+// other subsystems talking to the same upstreams. When nil, requests are not throttled.
+func WithThrottle(throttle *ocmhttp.Throttle) Option {
+	return func(r *ResourceRepository) {
+		r.throttle = throttle
+	}
+}
+
 var _ repository.ResourceRepository = (*ResourceRepository)(nil)
 
 // NewResourceRepository creates a ResourceRepository. If filesystemConfig is non-nil,
@@ -139,6 +149,9 @@ func (r *ResourceRepository) DownloadResource(ctx context.Context, resource *des
 	if r.httpConfig != nil {
 		opts = append(opts, helmdownload.WithHTTPConfig(r.httpConfig))
 	}
+	if r.throttle != nil {
+		opts = append(opts, helmdownload.WithThrottle(r.throttle))
+	}
 
 	result, err := helmdownload.NewReadOnlyChartFromRemote(ctx, helmURL, downloadDir, opts...)
 	if err != nil {
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Bandwidth limits and meters the bytes an operation sends and receives over HTTP.
+// In contrast to a Throttle, which is shared by all requests to a host, a Bandwidth is scoped to
+// an operation (e.g. a single transfer) by attaching it to the context of its requests with
+// ContextWithBandwidth. Clients built with New and WithContextBandwidth honor it for request
+// and response bodies.
+//
+// A Bandwidth is safe for concurrent use, concurrent requests of an operation share its limit.
+type Bandwidth struct {
+	bytesPerSecond float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	transferred atomic.Int64
+
+	now func() time.Time
+}
+
+// NewBandwidth creates a Bandwidth that limits the transferred bytes to bytesPerSecond.
+// Up to one second worth of bytes is transferred without delay.
+// A bytesPerSecond of 0 or less does not limit, but only meters the transferred bytes.
+func NewBandwidth(bytesPerSecond int64) *Bandwidth {
+	b := &Bandwidth{
+		bytesPerSecond: float64(max(bytesPerSecond, 0)),
+		now:            time.Now,
+	}
+	b.tokens = b.bytesPerSecond
+	b.last = b.now()
+	return b
+}
+
+// Transferred returns the number of bytes sent and received so far.
+func (b *Bandwidth) Transferred() int64 {
+	return b.transferred.Load()
+}
+
+// wait records n transferred bytes and blocks until they fit into the limit or the context is done.
+func (b *Bandwidth) wait(ctx context.Context, n int) error {
+	b.transferred.Add(int64(n))
+	if b.bytesPerSecond <= 0 || n <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := b.now()
+	b.tokens = min(b.bytesPerSecond, b.tokens+now.Sub(b.last).Seconds()*b.bytesPerSecond)
+	b.last = now
+	// the bytes are reserved right away, so concurrent transfers queue up behind each other.
+	b.tokens -= float64(n)
+	tokens := b.tokens
+	b.mu.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(-tokens / b.bytesPerSecond * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for bandwidth limit: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+type bandwidthKey struct{}
+
+// ContextWithBandwidth returns a context whose requests are limited and metered by the given Bandwidth.
+func ContextWithBandwidth(ctx context.Context, bandwidth *Bandwidth) context.Context {
+	return context.WithValue(ctx, bandwidthKey{}, bandwidth)
+}
+
+// BandwidthFromContext returns the Bandwidth of the context, or nil if there is none.
+func BandwidthFromContext(ctx context.Context) *Bandwidth {
+	bandwidth, _ := ctx.Value(bandwidthKey{}).(*Bandwidth)
+	return bandwidth
+}
+
+// bandwidthTransport limits and meters the bodies of requests whose context carries a Bandwidth.
+// It sits below retry.Transport, so the bodies of retries are accounted for as well.
+type bandwidthTransport struct {
+	base nethttp.RoundTripper
+}
+
+func (t *bandwidthTransport) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	bandwidth := BandwidthFromContext(req.Context())
+	if bandwidth == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	if req.Body != nil && req.Body != nethttp.NoBody {
+		req = req.Clone(req.Context())
+		req.Body = &bandwidthReader{ctx: req.Context(), base: req.Body, bandwidth: bandwidth}
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &bandwidthReader{ctx: req.Context(), base: resp.Body, bandwidth: bandwidth}
+	return resp, nil
+}
+
+// bandwidthReader waits for the Bandwidth after every read.
+type bandwidthReader struct {
+	ctx       context.Context
+	base      io.ReadCloser
+	bandwidth *Bandwidth
+}
+
+func (r *bandwidthReader) Read(p []byte) (int, error) {
+	// large reads are split, so a single read does not exceed the burst by far.
+	if limit := int(r.bandwidth.bytesPerSecond); limit > 0 && len(p) > limit {
+		p = p[:limit]
+	}
+	n, err := r.base.Read(p)
+	if werr := r.bandwidth.wait(r.ctx, n); werr != nil && err == nil {
+		err = werr
+	}
+	return n, err
+}
+
+func (r *bandwidthReader) Close() error {
+	return r.base.Close()
+}
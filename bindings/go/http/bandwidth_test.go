@@ -0,0 +1,53 @@
+package http_test
+
+import (
+	"bytes"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
+)
+
+func TestBandwidth(t *testing.T) {
+	r := require.New(t)
+
+	const size = 150 << 10
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, req *nethttp.Request) {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_, _ = w.Write(make([]byte, size))
+	}))
+	t.Cleanup(server.Close)
+	client := ocmhttp.New(ocmhttp.WithContextBandwidth())
+
+	do := func(req *nethttp.Request) {
+		resp, err := client.Do(req)
+		r.NoError(err)
+		_, err = io.Copy(io.Discard, resp.Body)
+		r.NoError(err)
+		r.NoError(resp.Body.Close())
+	}
+
+	// without a bandwidth in the context, requests are neither limited nor metered.
+	req, err := nethttp.NewRequestWithContext(t.Context(), nethttp.MethodGet, server.URL, nil)
+	r.NoError(err)
+	start := time.Now()
+	do(req)
+	r.Less(time.Since(start), 200*time.Millisecond)
+
+	bandwidth := ocmhttp.NewBandwidth(100 << 10)
+	ctx := ocmhttp.ContextWithBandwidth(t.Context(), bandwidth)
+	r.Same(bandwidth, ocmhttp.BandwidthFromContext(ctx))
+
+	req, err = nethttp.NewRequestWithContext(ctx, nethttp.MethodPut, server.URL, bytes.NewReader(make([]byte, 10<<10)))
+	r.NoError(err)
+	start = time.Now()
+	do(req)
+	// the first 100 KiB are the burst, the remaining 60 KiB take 0.6s.
+	r.GreaterOrEqual(time.Since(start), 500*time.Millisecond, "transfers beyond the burst should wait for the limit")
+	r.EqualValues(size+10<<10, bandwidth.Transferred())
+}
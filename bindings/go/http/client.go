@@ -12,6 +12,9 @@ import (
 type Options struct {
 	config    *httpv1alpha1.Config
 	userAgent string
+	throttle  *Throttle
+
+	contextBandwidth bool
 }
 
 // Option is a functional option for New.
@@ -34,6 +37,24 @@ func WithUserAgent(userAgent string) Option {
 	}
 }
 
+// WithThrottle makes the client consult the given Throttle: every request, including retries,
+// waits for the rate limit of its host, and retries are only sent while the retry budget allows.
+// Share one Throttle between all clients talking to the same upstreams.
+func WithThrottle(throttle *Throttle) Option {
+	return func(o *Options) {
+		o.throttle = throttle
+	}
+}
+
+// WithContextBandwidth makes the client limit and meter request and response bodies
+// with the Bandwidth attached to the request context by ContextWithBandwidth.
+// Requests without a Bandwidth in their context are not affected.
+func WithContextBandwidth() Option {
+	return func(o *Options) {
+		o.contextBandwidth = true
+	}
+}
+
 // userAgentTransport wraps an http.RoundTripper and injects a User-Agent header.
 type userAgentTransport struct {
 	base      nethttp.RoundTripper
@@ -74,7 +95,7 @@ func retryPolicyFromConfig(rc *httpv1alpha1.RetryConfig) retry.Policy {
 //
 // Transport chain (outermost first):
 //
-//		http.Client → [userAgentTransport] → [hostRouter] → [insecureWarnTransport] → retry.Transport → http.Transport
+//		http.Client → [userAgentTransport] → [hostRouter] → [insecureWarnTransport] → retry.Transport → [throttleTransport] → bandwidthTransport → http.Transport
 //
 //	 1. userAgentTransport sets the User-Agent header (only when WithUserAgent
 //	    is given).
@@ -86,7 +107,13 @@ func retryPolicyFromConfig(rc *httpv1alpha1.RetryConfig) retry.Policy {
 //	 4. retry.Transport retries transient failures using the default retry
 //	    policy. One instance exists per host (plus one for the global fallback)
 //	    so retry attempts share the per-host context deadline.
-//	 5. http.Transport carries the configured TCP/TLS/idle timeouts, merged
+//	 5. throttleTransport (only when WithThrottle is given) waits for the
+//	    per-host rate limit of the Throttle before every attempt. The retry
+//	    budget of the Throttle is consulted by retry.Transport.
+//	 6. bandwidthTransport limits and meters request and response bodies with
+//	    the Bandwidth of the request context (only when WithContextBandwidth
+//	    is given, see ContextWithBandwidth).
+//	 7. http.Transport carries the configured TCP/TLS/idle timeouts, merged
 //	    from the global config and the matching per-host overrides.
 //
 // Without per-host entries, the overall Timeout is applied as
@@ -103,10 +130,21 @@ func New(opts ...Option) *nethttp.Client {
 	}
 
 	build := func(tc *httpv1alpha1.TimeoutConfig, rc *httpv1alpha1.RetryConfig, tlsc *httpv1alpha1.TLSConfig) nethttp.RoundTripper {
-		rt := nethttp.RoundTripper(retry.NewTransport(NewTransportWithTLS(tc, tlsc)))
+		base := nethttp.RoundTripper(NewTransportWithTLS(tc, tlsc))
+		if options.contextBandwidth {
+			base = &bandwidthTransport{base: base}
+		}
+		if options.throttle != nil {
+			base = &throttleTransport{base: base, throttle: options.throttle}
+		}
+		retryTransport := retry.NewTransport(base)
 		if p := retryPolicyFromConfig(rc); p != nil {
-			rt.(*retry.Transport).Policy = func() retry.Policy { return p }
+			retryTransport.Policy = func() retry.Policy { return p }
+		}
+		if options.throttle != nil {
+			retryTransport.Budget = options.throttle
 		}
+		rt := nethttp.RoundTripper(retryTransport)
 		if tlsc != nil && tlsc.InsecureSkipVerify != nil && *tlsc.InsecureSkipVerify {
 			rt = &insecureWarnTransport{base: rt}
 		}
@@ -53,6 +53,28 @@
 // context deadline (covering headers + body), so a per-host value can exceed
 // the global. http.Client.Timeout is left zero in this case.
 //
+// # Throttling
+//
+// Clients of independent subsystems retry on their own. To keep them from
+// multiplying the load on a struggling upstream, share a single Throttle
+// between them. It limits the request rate per host and the share of retried
+// requests across all clients it is passed to:
+//
+//	throttle := ocmhttp.NewThrottle(
+//		ocmhttp.WithHostRate(50, 10),      // 50 requests/s per host, bursts of 10
+//		ocmhttp.WithRetryBudget(0.1, 10), // retry at most 10% of requests
+//	)
+//	ociClient := ocmhttp.New(ocmhttp.WithConfig(cfg), ocmhttp.WithThrottle(throttle))
+//	helmClient := ocmhttp.New(ocmhttp.WithConfig(cfg), ocmhttp.WithThrottle(throttle))
+//
+// To limit the bandwidth of a single operation instead, attach a Bandwidth to
+// the context of its requests. It also meters the transferred bytes:
+//
+//	client := ocmhttp.New(ocmhttp.WithConfig(cfg), ocmhttp.WithContextBandwidth())
+//	bandwidth := ocmhttp.NewBandwidth(10 << 20) // 10 MiB/s
+//	err := transfer(ocmhttp.ContextWithBandwidth(ctx, bandwidth))
+//	fmt.Println(bandwidth.Transferred())
+//
 // # Lower-level constructors
 //
 // Skip the retry layer with NewClient, or get just the transport:
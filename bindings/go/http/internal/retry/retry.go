@@ -151,6 +151,16 @@ type Transport struct {
 
 	// Policy returns the retry Policy for a request. If nil, DefaultGenericPolicy is used.
 	Policy func() Policy
+
+	// Budget limits the retries across all transports sharing it. If nil, retries are only limited by the Policy.
+	Budget Budget
+}
+
+// Budget is a retry budget shared between transports. Every request is recorded with RecordRequest,
+// every retry has to be allowed by AllowRetry, so retries stay bounded relative to the overall traffic.
+type Budget interface {
+	RecordRequest()
+	AllowRetry() bool
 }
 
 // DefaultClient is a client with the default retry policy.
@@ -170,6 +180,9 @@ func NewTransport(base http.RoundTripper) *Transport {
 func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	ctx := req.Context()
 	policy := t.policy()
+	if t.Budget != nil {
+		t.Budget.RecordRequest()
+	}
 	attempt := 0
 	for {
 		resp, respErr := t.roundTrip(req)
@@ -183,6 +196,10 @@ func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		if duration < 0 {
 			return resp, respErr
 		}
+		if t.Budget != nil && !t.Budget.AllowRetry() {
+			// the retry budget is exhausted, the last response is returned as if no retries were configured.
+			return resp, respErr
+		}
 
 		if req.Body != nil {
 			if req.GetBody == nil {
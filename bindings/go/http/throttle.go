@@ -0,0 +1,160 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math"
+	nethttp "net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRetryBudgetRatio is the share of requests that may be retried by default.
+	DefaultRetryBudgetRatio = 0.1
+	// DefaultRetryBudgetReserve is the number of retries that are allowed by default before any
+	// request earned retries via DefaultRetryBudgetRatio.
+	DefaultRetryBudgetReserve = 10
+)
+
+// Throttle is client-side throttling shared by independent subsystems that talk to the same upstreams.
+// It combines a token bucket per host, which limits the rate of requests (including retries) sent to a
+// host, with a global retry budget, which limits retries to a share of the overall requests.
+// Without the shared budget, every subsystem retries on its own, so a struggling upstream receives
+// a multiple of the original load exactly when it can handle it the least.
+//
+// A Throttle is safe for concurrent use. Pass the same instance to all clients that should share it,
+// e.g. with WithThrottle for clients built with New.
+type Throttle struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+
+	budgetMu      sync.Mutex
+	budgetRatio   float64
+	budgetReserve float64
+	budgetLimit   float64
+	budget        float64
+
+	now func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// ThrottleOption is a functional option for NewThrottle.
+type ThrottleOption func(*Throttle)
+
+// WithHostRate limits the requests per second sent to a single host. Up to burst requests are
+// sent without delay. A rate of 0 (the default) does not limit requests.
+func WithHostRate(perSecond float64, burst int) ThrottleOption {
+	return func(t *Throttle) {
+		t.rate = perSecond
+		t.burst = float64(max(burst, 1))
+	}
+}
+
+// WithRetryBudget configures the retry budget: ratio is the share of requests that may be retried,
+// reserve is the number of retries that may be spent before enough requests earned retries,
+// which is also the maximum number of retries that can be saved up (at least one with a positive ratio).
+// A ratio and reserve of 0 disable retries for all clients sharing the Throttle.
+func WithRetryBudget(ratio float64, reserve int) ThrottleOption {
+	return func(t *Throttle) {
+		t.budgetRatio = ratio
+		t.budgetReserve = float64(reserve)
+	}
+}
+
+// NewThrottle creates a Throttle. By default, requests are not rate limited and the retry budget
+// is DefaultRetryBudgetRatio with a reserve of DefaultRetryBudgetReserve.
+func NewThrottle(opts ...ThrottleOption) *Throttle {
+	t := &Throttle{
+		buckets:       make(map[string]*bucket),
+		budgetRatio:   DefaultRetryBudgetRatio,
+		budgetReserve: DefaultRetryBudgetReserve,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.budget = t.budgetReserve
+	t.budgetLimit = t.budgetReserve
+	if t.budgetRatio > 0 {
+		t.budgetLimit = math.Max(t.budgetLimit, 1)
+	}
+	return t
+}
+
+// Wait blocks until a request may be sent to host or the context is done.
+// Hosts are compared by name, requests to different ports of a host share a bucket.
+func (t *Throttle) Wait(ctx context.Context, host string) error {
+	if t.rate <= 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := t.now()
+	b, ok := t.buckets[host]
+	if !ok {
+		b = &bucket{tokens: t.burst, last: now}
+		t.buckets[host] = b
+	}
+	b.tokens = math.Min(t.burst, b.tokens+now.Sub(b.last).Seconds()*t.rate)
+	b.last = now
+	// the token is reserved right away, so concurrent waiters queue up behind each other.
+	b.tokens--
+	tokens := b.tokens
+	t.mu.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(-tokens / t.rate * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		t.mu.Lock()
+		b.tokens++
+		t.mu.Unlock()
+		return fmt.Errorf("waiting for request rate limit of host %q: %w", host, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RecordRequest records a request, which earns the retry budget a share of a retry.
+func (t *Throttle) RecordRequest() {
+	t.budgetMu.Lock()
+	defer t.budgetMu.Unlock()
+	t.budget = math.Min(t.budgetLimit, t.budget+t.budgetRatio)
+}
+
+// AllowRetry reports whether a retry may be sent and spends it from the retry budget if so.
+func (t *Throttle) AllowRetry() bool {
+	t.budgetMu.Lock()
+	defer t.budgetMu.Unlock()
+	if t.budget < 1 {
+		return false
+	}
+	t.budget--
+	return true
+}
+
+// throttleTransport waits for the host rate limit of the Throttle before every request.
+// It sits below retry.Transport, so retries are rate limited as well.
+type throttleTransport struct {
+	base     nethttp.RoundTripper
+	throttle *Throttle
+}
+
+func (t *throttleTransport) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	if err := t.throttle.Wait(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
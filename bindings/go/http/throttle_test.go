@@ -0,0 +1,83 @@
+package http_test
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
+	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+)
+
+func TestThrottle_RetryBudget(t *testing.T) {
+	r := require.New(t)
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		attempts.Add(1)
+		w.WriteHeader(nethttp.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	throttle := ocmhttp.NewThrottle(ocmhttp.WithRetryBudget(0, 3))
+	maxRetries := 5
+	cfg := &httpv1alpha1.Config{Retry: &httpv1alpha1.RetryConfig{
+		MaxRetries: &maxRetries,
+		MinWait:    httpv1alpha1.NewTimeout(time.Millisecond),
+		MaxWait:    httpv1alpha1.NewTimeout(time.Millisecond),
+	}}
+	// two independent clients share the budget of three retries.
+	for range 2 {
+		client := ocmhttp.New(ocmhttp.WithConfig(cfg), ocmhttp.WithThrottle(throttle))
+		req, err := nethttp.NewRequestWithContext(t.Context(), nethttp.MethodGet, server.URL, nil)
+		r.NoError(err)
+		resp, err := client.Do(req)
+		r.NoError(err)
+		r.NoError(resp.Body.Close())
+		r.Equal(nethttp.StatusServiceUnavailable, resp.StatusCode)
+	}
+	// the first client spends the whole budget, the second one may not retry at all.
+	r.EqualValues(2+3, attempts.Load())
+	r.False(throttle.AllowRetry())
+}
+
+func TestThrottle_RetryBudgetRatio(t *testing.T) {
+	r := require.New(t)
+
+	throttle := ocmhttp.NewThrottle(ocmhttp.WithRetryBudget(0.5, 0))
+	r.False(throttle.AllowRetry())
+	throttle.RecordRequest()
+	r.False(throttle.AllowRetry())
+	throttle.RecordRequest()
+	r.True(throttle.AllowRetry())
+	r.False(throttle.AllowRetry())
+}
+
+func TestThrottle_HostRate(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	throttle := ocmhttp.NewThrottle(ocmhttp.WithHostRate(20, 2))
+
+	start := time.Now()
+	for range 2 {
+		r.NoError(throttle.Wait(ctx, "a.example.com"))
+	}
+	r.Less(time.Since(start), 50*time.Millisecond, "burst should not be delayed")
+
+	// other hosts have their own bucket.
+	r.NoError(throttle.Wait(ctx, "b.example.com"))
+	r.Less(time.Since(start), 50*time.Millisecond, "other hosts should not be delayed")
+
+	r.NoError(throttle.Wait(ctx, "a.example.com"))
+	r.GreaterOrEqual(time.Since(start), 40*time.Millisecond, "requests beyond the burst should wait for the rate")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	r.ErrorIs(throttle.Wait(cancelled, "a.example.com"), context.Canceled)
+}
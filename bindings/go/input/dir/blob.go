@@ -2,6 +2,7 @@ package dir
 
 import (
 	"context"
+	"fmt"
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
@@ -13,16 +14,35 @@ import (
 // The function returns an error if the file path is empty or if there are issues reading the directory
 // contents from the filesystem.
 //
-// The function is not able to handle symbolic links yet.
+// Symbolic links are rejected unless the specification follows or skips them.
 //
 // The function performs the following steps:
 //  1. Validates that the directory path is not empty
 //  2. Ensures that the directory path is within the working directory
 //     (this is to prevent directory traversal attacks and ensure security)
-//  3. Reads the directory contents using an instance of the virtual FileSystem
+//  3. Reads the directory contents using an instance of the virtual FileSystem,
+//     honoring the patterns of the v1.IgnoreFile in the directory
 //  4. Packs the directory contents into a tar archive
 //  5. Applies different configuration options of the v1.Dir specification
 func GetV1DirBlob(ctx context.Context, dir v1.Dir, workingDirectory string) (blob.ReadOnlyBlob, error) {
+	opts, err := dirOptions(dir, workingDirectory)
+	if err != nil {
+		return nil, err
+	}
+	return filesystem.GetBlobFromPath(ctx, dir.Path, opts)
+}
+
+// GetV1DirManifest lists the files included in the blob created by GetV1DirBlob for the same
+// v1.Dir specification, together with their size and digest.
+func GetV1DirManifest(ctx context.Context, dir v1.Dir, workingDirectory string) ([]filesystem.FileEntry, error) {
+	opts, err := dirOptions(dir, workingDirectory)
+	if err != nil {
+		return nil, err
+	}
+	return filesystem.ListFiles(ctx, dir.Path, opts)
+}
+
+func dirOptions(dir v1.Dir, workingDirectory string) (filesystem.DirOptions, error) {
 	opts := filesystem.DirOptions{
 		MediaType:       dir.MediaType,
 		Compress:        dir.Compress,
@@ -31,6 +51,15 @@ func GetV1DirBlob(ctx context.Context, dir v1.Dir, workingDirectory string) (blo
 		IncludePatterns: dir.IncludeFiles,
 		ExcludePatterns: dir.ExcludeFiles,
 		WorkingDir:      workingDirectory,
+		IgnoreFile:      v1.IgnoreFile,
 	}
-	return filesystem.GetBlobFromPath(ctx, dir.Path, opts)
+	switch {
+	case dir.FollowSymlinks && dir.SkipSymlinks:
+		return filesystem.DirOptions{}, fmt.Errorf("followSymlinks and skipSymlinks cannot be combined")
+	case dir.FollowSymlinks:
+		opts.Symlinks = filesystem.SymlinkPolicyFollow
+	case dir.SkipSymlinks:
+		opts.Symlinks = filesystem.SymlinkPolicySkip
+	}
+	return opts, nil
 }
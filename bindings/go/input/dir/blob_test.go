@@ -58,10 +58,62 @@ func TestGetV1DirBlob_Symlinks(t *testing.T) {
 	r.NoError(err)
 	r.NotNil(reader)
 
-	// Expect an error on read, as symlinks are not supported yet.
+	// Expect an error on read, as symlinks are rejected without a policy.
 	_, err = io.ReadAll(reader)
 	r.Error(err)
-	r.Contains(err.Error(), "symlinks are not supported yet")
+	r.Contains(err.Error(), "symlinks are not supported")
+
+	t.Run("skip symlinks", func(t *testing.T) {
+		r := require.New(t)
+		spec := dirSpec
+		spec.SkipSymlinks = true
+		b, err := dir.GetV1DirBlob(ctx, spec, tempDir)
+		r.NoError(err)
+		data, err := readAll(b)
+		r.NoError(err)
+		_, err = extractFileFromTar(data, "hello.link")
+		r.Error(err)
+	})
+
+	t.Run("follow symlinks", func(t *testing.T) {
+		r := require.New(t)
+		spec := dirSpec
+		spec.FollowSymlinks = true
+		b, err := dir.GetV1DirBlob(ctx, spec, tempDir)
+		r.NoError(err)
+		data, err := readAll(b)
+		r.NoError(err)
+		content, err := extractFileFromTar(data, "hello.link")
+		r.NoError(err)
+		r.Equal("This file is a symlink target", string(content))
+	})
+
+	t.Run("conflicting symlink options", func(t *testing.T) {
+		spec := dirSpec
+		spec.FollowSymlinks, spec.SkipSymlinks = true, true
+		_, err := dir.GetV1DirBlob(ctx, spec, tempDir)
+		require.ErrorContains(t, err, "cannot be combined")
+	})
+}
+
+func TestGetV1DirBlob_IgnoreFile(t *testing.T) {
+	r := require.New(t)
+
+	dirAbs := t.TempDir()
+	r.NoError(os.WriteFile(filepath.Join(dirAbs, v1.IgnoreFile), []byte("*.tmp\n"), 0o644))
+	r.NoError(os.WriteFile(filepath.Join(dirAbs, "keep.txt"), []byte("keep"), 0o644))
+	r.NoError(os.WriteFile(filepath.Join(dirAbs, "scratch.tmp"), []byte("ignored"), 0o644))
+
+	b, err := dir.GetV1DirBlob(t.Context(), v1.Dir{Type: runtime.NewUnversionedType(v1.Type), Path: dirAbs}, dirAbs)
+	r.NoError(err)
+	data, err := readAll(b)
+	r.NoError(err)
+
+	content, err := extractFileFromTar(data, "keep.txt")
+	r.NoError(err)
+	r.Equal("keep", string(content))
+	_, err = extractFileFromTar(data, "scratch.tmp")
+	r.Error(err)
 }
 
 func TestGetV1DirBlob_Reproducibility(t *testing.T) {
@@ -495,6 +547,18 @@ func extractFileFromTar(tarData []byte, fileName string) ([]byte, error) {
 	return nil, fmt.Errorf("file '%s' not found in tar archive", fileName)
 }
 
+// readAll reads the whole content of the blob.
+func readAll(b blob.ReadOnlyBlob) ([]byte, error) {
+	reader, err := b.ReadCloser()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	return io.ReadAll(reader)
+}
+
 func TestScheme_ResolvesAllDirInputAliases(t *testing.T) {
 	tests := []struct {
 		name string
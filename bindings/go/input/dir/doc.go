@@ -8,7 +8,10 @@
 //   - File-based resource and source input processing
 //   - Optional gzip compression support
 //   - Support for inclusion or exclusion based on file naming patterns
-//   - Support for symbolic links with an option to include their content
+//   - Support for an .ocmignore file (gitignore syntax) in the directory
+//   - Support for symbolic links with options to include their content or skip them
+//   - Byte-stable tars with sorted entries and normalized attributes if reproducible
+//   - Optional manifest label listing the included files with their digests
 //   - Integration with the OCM blob system for efficient data handling
 //   - No credential requirements (files are accessed directly from the filesystem)
 //
@@ -28,6 +31,9 @@
 //   - Compress: Boolean flag to enable gzip compression
 //   - PreserveDir: Boolean flag to include top-level directory
 //   - FollowSymlinks: Boolean flag to follow symbolic links and include respective content
+//   - SkipSymlinks: Boolean flag to leave out symbolic links
 //   - ExcludeFiles: a string array of name patterns to explicitly exclude (overrides IncludeFiles)
 //   - IncludeFiles: a string array of name patterns to explicitly include (only these files will be included)
+//   - Reproducible: Boolean flag to normalize timestamps, owners and permissions of the tar entries
+//   - Manifest: Boolean flag to record the included files in the ManifestLabelName label
 package dir
@@ -2,9 +2,11 @@ package dir
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
+	"ocm.software/open-component-model/bindings/go/blob/filesystem"
 	"ocm.software/open-component-model/bindings/go/constructor"
 	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
 	v1 "ocm.software/open-component-model/bindings/go/input/dir/spec/v1"
@@ -16,6 +18,16 @@ import (
 // require authentication or authorization.
 var ErrDirsDoNotRequireCredentials = fmt.Errorf("directories do not require credentials")
 
+// ManifestLabelName is the name of the label the InputMethod sets on a resource or source
+// to record the files included in the blob, if requested by v1.Dir.Manifest.
+const ManifestLabelName = "ocm.software/dirManifest"
+
+// ManifestLabel is the value of the label with the name [ManifestLabelName].
+type ManifestLabel struct {
+	// Files are the files included in the blob in the order of the archive.
+	Files []filesystem.FileEntry `json:"files"`
+}
+
 var _ interface {
 	constructor.ResourceInputMethod
 	constructor.SourceInputMethod
@@ -97,6 +109,11 @@ func (i *InputMethod) ProcessResource(ctx context.Context, resource *constructor
 	if err != nil {
 		return nil, fmt.Errorf("error getting dir blob based on resource input specification: %w", err)
 	}
+	if dir.Manifest {
+		if err := i.setManifestLabel(ctx, dir, &resource.Labels); err != nil {
+			return nil, err
+		}
+	}
 
 	return &constructor.ResourceInputMethodResult{
 		ProcessedBlobData: dirBlob,
@@ -129,8 +146,35 @@ func (i *InputMethod) ProcessSource(ctx context.Context, src *constructorruntime
 	if err != nil {
 		return nil, fmt.Errorf("error getting dir blob based on source input specification: %w", err)
 	}
+	if dir.Manifest {
+		if err := i.setManifestLabel(ctx, dir, &src.Labels); err != nil {
+			return nil, err
+		}
+	}
 
 	return &constructor.SourceInputMethodResult{
 		ProcessedBlobData: fileBlob,
 	}, nil
 }
+
+// setManifestLabel sets the ManifestLabelName label listing the files of the blob of dir.
+// An existing label with the same name is replaced.
+func (i *InputMethod) setManifestLabel(ctx context.Context, dir v1.Dir, labels *[]constructorruntime.Label) error {
+	files, err := GetV1DirManifest(ctx, dir, i.WorkingDirectory)
+	if err != nil {
+		return fmt.Errorf("error listing files of dir %q: %w", dir.Path, err)
+	}
+	raw, err := json.Marshal(ManifestLabel{Files: files})
+	if err != nil {
+		return fmt.Errorf("error marshalling label %q: %w", ManifestLabelName, err)
+	}
+	label := constructorruntime.Label{Name: ManifestLabelName, Value: raw}
+	for idx := range *labels {
+		if (*labels)[idx].Name == ManifestLabelName {
+			(*labels)[idx] = label
+			return nil
+		}
+	}
+	*labels = append(*labels, label)
+	return nil
+}
@@ -0,0 +1,64 @@
+package dir_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	"ocm.software/open-component-model/bindings/go/input/dir"
+	v1 "ocm.software/open-component-model/bindings/go/input/dir/spec/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func TestInputMethod_ManifestLabel(t *testing.T) {
+	dirAbs := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dirAbs, v1.IgnoreFile), []byte("*.tmp\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dirAbs, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dirAbs, "sub", "b.txt"), []byte("b"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dirAbs, "scratch.tmp"), []byte("ignored"), 0o644))
+
+	newResource := func(t *testing.T, spec v1.Dir) *constructorruntime.Resource {
+		raw, err := json.Marshal(spec)
+		require.NoError(t, err)
+		resource := &constructorruntime.Resource{}
+		resource.Name = "dir"
+		resource.Input = &runtime.Raw{Type: runtime.NewVersionedType(v1.Type, v1.Version), Data: raw}
+		return resource
+	}
+
+	method, err := dir.NewInputMethod(dirAbs)
+	require.NoError(t, err)
+
+	t.Run("label is set on request", func(t *testing.T) {
+		r := require.New(t)
+		resource := newResource(t, v1.Dir{Type: runtime.NewVersionedType(v1.Type, v1.Version), Path: dirAbs, Manifest: true})
+		resource.Labels = []constructorruntime.Label{{Name: dir.ManifestLabelName, Value: []byte(`{}`)}}
+
+		result, err := method.ProcessResource(t.Context(), resource, nil)
+		r.NoError(err)
+		r.NotNil(result.ProcessedBlobData)
+
+		r.Len(resource.Labels, 1, "an existing manifest label must be replaced")
+		var label dir.ManifestLabel
+		r.NoError(json.Unmarshal(resource.Labels[0].Value, &label))
+		r.Equal([]filesystem.FileEntry{
+			{Name: v1.IgnoreFile, Size: 6, Digest: digest.FromString("*.tmp\n").String()},
+			{Name: "sub/b.txt", Size: 1, Digest: digest.FromString("b").String()},
+		}, label.Files)
+	})
+
+	t.Run("no label by default", func(t *testing.T) {
+		r := require.New(t)
+		resource := newResource(t, v1.Dir{Type: runtime.NewVersionedType(v1.Type, v1.Version), Path: dirAbs})
+
+		_, err := method.ProcessResource(t.Context(), resource, nil)
+		r.NoError(err)
+		r.Empty(resource.Labels)
+	})
+}
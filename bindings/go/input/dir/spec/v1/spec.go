@@ -5,6 +5,8 @@ import (
 )
 
 // Dir describes an input sourced by a directory.
+// Files and directories matching the patterns of an .ocmignore file (gitignore syntax)
+// in the directory are not included in the resulting blob.
 //
 // +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
 // +k8s:deepcopy-gen=true
@@ -32,9 +34,14 @@ type Dir struct {
 	PreserveDir bool `json:"preserveDir,omitempty"`
 
 	// FollowSymlinks will include the content of the encountered symbolic links to the resulting blob.
-	// Support for this option is not implemented yet. The field is included for compatibility with previous OCM version.
+	// The targets of the links have to be located inside the directory.
+	// If neither FollowSymlinks nor SkipSymlinks is set, symbolic links are rejected.
 	FollowSymlinks bool `json:"followSymlinks,omitempty"`
 
+	// SkipSymlinks will leave out encountered symbolic links from the resulting blob.
+	// It cannot be combined with FollowSymlinks.
+	SkipSymlinks bool `json:"skipSymlinks,omitempty"`
+
 	// ExcludeFiles is a list of file name patterns to exclude from addition to the resulting blob.
 	// Excluded files always override included files.
 	ExcludeFiles []string `json:"excludeFiles,omitempty"`
@@ -48,6 +55,10 @@ type Dir struct {
 	// to get fully byte-equivalent blobs despite different file modification time, permission bits, etc.,
 	// these attributes will be set to fixed values while creating the blob.
 	Reproducible bool `json:"reproducible,omitempty"`
+
+	// Manifest defines that the files included in the resulting blob are recorded with their size and digest
+	// in the label "ocm.software/dirManifest" of the resource or source for traceability.
+	Manifest bool `json:"manifest,omitempty"`
 }
 
 func (t *Dir) String() string {
@@ -59,3 +70,7 @@ const (
 	Type       = "Dir"
 	LegacyType = "dir"
 )
+
+// IgnoreFile is the name of the file in the directory whose patterns (gitignore syntax)
+// exclude files and directories from the resulting blob.
+const IgnoreFile = ".ocmignore"
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// writeArchive writes the files of the tree as gzip compressed tar to w. The entries are written
+// in the order of the tree with normalized timestamps, owners and permissions, so that the same tree
+// always results in the same archive. Submodules are not included.
+func writeArchive(w io.Writer, tree *object.Tree) (err error) {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	defer func() {
+		err = errors.Join(err, tw.Close(), gw.Close())
+	}()
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error walking tree: %w", err)
+		}
+
+		header := &tar.Header{
+			Name:       name,
+			ModTime:    time.Unix(0, 0),
+			AccessTime: time.Unix(0, 0),
+			ChangeTime: time.Unix(0, 0),
+		}
+		switch entry.Mode {
+		case filemode.Dir:
+			header.Typeflag = tar.TypeDir
+			header.Name += "/"
+			header.Mode = 0o755
+			if err := tw.WriteHeader(header); err != nil {
+				return fmt.Errorf("error writing tar header for %q: %w", name, err)
+			}
+		case filemode.Regular, filemode.Deprecated, filemode.Executable:
+			file, err := tree.TreeEntryFile(&entry)
+			if err == nil {
+				err = writeFile(tw, header, file, entry.Mode == filemode.Executable)
+			}
+			if err != nil {
+				return fmt.Errorf("error archiving file %q: %w", name, err)
+			}
+		case filemode.Symlink:
+			file, err := tree.TreeEntryFile(&entry)
+			if err != nil {
+				return fmt.Errorf("error reading symbolic link %q: %w", name, err)
+			}
+			target, err := file.Contents()
+			if err != nil {
+				return fmt.Errorf("error reading symbolic link %q: %w", name, err)
+			}
+			header.Typeflag = tar.TypeSymlink
+			header.Linkname = target
+			header.Mode = 0o777
+			if err := tw.WriteHeader(header); err != nil {
+				return fmt.Errorf("error writing tar header for %q: %w", name, err)
+			}
+		case filemode.Submodule:
+			// the content of submodules is stored in other repositories.
+		default:
+			return fmt.Errorf("unsupported file mode %s of %q", entry.Mode, name)
+		}
+	}
+}
+
+func writeFile(tw *tar.Writer, header *tar.Header, file *object.File, executable bool) error {
+	header.Typeflag = tar.TypeReg
+	header.Size = file.Size
+	header.Mode = 0o644
+	if executable {
+		header.Mode = 0o755
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	reader, err := file.Reader()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, reader)
+	return errors.Join(err, reader.Close())
+}
@@ -0,0 +1,33 @@
+// Package git provides functionality for handling git repository inputs in the Open Component Model (OCM) constructor.
+//
+// This package implements input methods for both resources and sources that are backed by the files of a
+// commit in a git repository. The repository is cloned into memory, the configured branch, tag or commit is
+// resolved and the files of the commit are packed into a tar+gzip blob.
+//
+// Key Features:
+//   - Git-based resource and source input processing
+//   - Resolution of branches, tags and full reference names
+//   - Commit pinning, the pinned commit has to be reachable from the ref if both are set
+//   - Optional restriction to a directory of the repository
+//   - Byte-stable archives with normalized timestamps, owners and permissions
+//   - The commit SHA recorded as "commit" extra identity attribute of the resource or source
+//   - HTTP basic authentication with credentials of the GitRepository consumer identity
+//
+// Example:
+//
+//	resources:
+//	  - name: chart-sources
+//	    type: directoryTree
+//	    input:
+//	      type: git/v1
+//	      repository: https://github.com/open-component-model/ocm.git
+//	      ref: main
+//	      commit: 0123456789abcdef0123456789abcdef01234567
+//	      path: charts
+//
+// The package can use the v1.Git specification which includes:
+//   - Repository: The URL of the git repository
+//   - Ref: Optional branch, tag or reference name (defaults to the default branch)
+//   - Commit: Optional full SHA of the commit to pin the input to
+//   - Path: Optional directory of the repository to pack
+package git
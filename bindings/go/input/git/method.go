@@ -0,0 +1,308 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/constructor"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	credv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	identityv1 "ocm.software/open-component-model/bindings/go/input/git/spec/identity/v1"
+	v1 "ocm.software/open-component-model/bindings/go/input/git/spec/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// MediaTypeTarGzip is the media type of the blobs created by the InputMethod.
+const MediaTypeTarGzip = "application/x-tar+gzip"
+
+// CommitIdentityAttribute is the extra identity attribute the InputMethod sets on a resource or source
+// to record the SHA of the commit the blob was created from.
+const CommitIdentityAttribute = "commit"
+
+// ErrCommitNotReachable is returned if the pinned commit is not reachable from the configured ref.
+var ErrCommitNotReachable = errors.New("commit is not reachable from ref")
+
+// commitPattern matches full SHA-1 commit hashes.
+var commitPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+var _ interface {
+	constructor.ResourceInputMethod
+	constructor.SourceInputMethod
+} = (*InputMethod)(nil)
+
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&v1.Git{},
+		runtime.NewVersionedType(v1.Type, v1.Version),
+		runtime.NewUnversionedType(v1.Type),
+	)
+}
+
+var credentialScheme = runtime.NewScheme()
+
+func init() {
+	credv1.MustRegister(credentialScheme)
+}
+
+// InputMethod implements the ResourceInputMethod and SourceInputMethod interfaces
+// for git-based inputs. It clones a repository into memory, resolves the configured
+// ref or commit and packs the files of the commit as tar+gzip blob.
+//
+// The SHA of the commit is recorded in the CommitIdentityAttribute extra identity
+// attribute of the resource or source, so that the element identifies the exact content.
+//
+// Credentials are resolved for the repository with the GitRepository consumer identity.
+// The properties username and password, or token are used for HTTP basic authentication.
+type InputMethod struct{}
+
+func (i *InputMethod) GetInputMethodScheme() *runtime.Scheme {
+	return Scheme
+}
+
+// GetResourceCredentialConsumerIdentity returns the GitRepository credential consumer identity
+// of the repository of the git input.
+func (i *InputMethod) GetResourceCredentialConsumerIdentity(_ context.Context, resource *constructorruntime.Resource) (runtime.Identity, error) {
+	spec, err := i.convert(resource.Input)
+	if err != nil {
+		return nil, err
+	}
+	return consumerIdentity(spec)
+}
+
+// ProcessResource clones the repository of the git input and returns the files of the resolved commit
+// as local blob data. The commit SHA is set as extra identity of the resource.
+func (i *InputMethod) ProcessResource(ctx context.Context, resource *constructorruntime.Resource, credentials runtime.Typed) (*constructor.ResourceInputMethodResult, error) {
+	spec, err := i.convert(resource.Input)
+	if err != nil {
+		return nil, err
+	}
+	data, commit, err := GetV1GitBlob(ctx, spec, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("error getting git blob based on resource input specification: %w", err)
+	}
+	setCommitIdentity(&resource.ElementMeta, commit)
+	return &constructor.ResourceInputMethodResult{
+		ProcessedBlobData: data,
+	}, nil
+}
+
+// GetSourceCredentialConsumerIdentity returns the GitRepository credential consumer identity
+// of the repository of the git input.
+func (i *InputMethod) GetSourceCredentialConsumerIdentity(_ context.Context, src *constructorruntime.Source) (runtime.Identity, error) {
+	spec, err := i.convert(src.Input)
+	if err != nil {
+		return nil, err
+	}
+	return consumerIdentity(spec)
+}
+
+// ProcessSource clones the repository of the git input and returns the files of the resolved commit
+// as local blob data. The commit SHA is set as extra identity of the source.
+func (i *InputMethod) ProcessSource(ctx context.Context, src *constructorruntime.Source, credentials runtime.Typed) (*constructor.SourceInputMethodResult, error) {
+	spec, err := i.convert(src.Input)
+	if err != nil {
+		return nil, err
+	}
+	data, commit, err := GetV1GitBlob(ctx, spec, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("error getting git blob based on source input specification: %w", err)
+	}
+	setCommitIdentity(&src.ElementMeta, commit)
+	return &constructor.SourceInputMethodResult{
+		ProcessedBlobData: data,
+	}, nil
+}
+
+func (i *InputMethod) convert(input runtime.Typed) (*v1.Git, error) {
+	spec := v1.Git{}
+	if err := i.GetInputMethodScheme().Convert(input, &spec); err != nil {
+		return nil, fmt.Errorf("error converting input spec: %w", err)
+	}
+	if spec.Repository == "" {
+		return nil, fmt.Errorf("repository is required in git input spec")
+	}
+	if spec.Commit != "" && !commitPattern.MatchString(spec.Commit) {
+		return nil, fmt.Errorf("commit %q in git input spec is not a full lowercase commit SHA", spec.Commit)
+	}
+	if spec.Path != "" {
+		cleaned := path.Clean(strings.TrimPrefix(spec.Path, "./"))
+		if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return nil, fmt.Errorf("path %q in git input spec must be relative to the repository root", spec.Path)
+		}
+		if cleaned == "." {
+			cleaned = ""
+		}
+		spec.Path = cleaned
+	}
+	return &spec, nil
+}
+
+// GetV1GitBlob clones the repository of the spec into memory, resolves the commit and returns the files
+// of the commit, restricted to spec.Path, as tar+gzip blob together with the SHA of the commit.
+func GetV1GitBlob(ctx context.Context, spec *v1.Git, credentials runtime.Typed) (blob.ReadOnlyBlob, string, error) {
+	auth, err := basicAuth(credentials)
+	if err != nil {
+		return nil, "", err
+	}
+
+	repo, err := gogit.CloneContext(ctx, memory.NewStorage(), nil, &gogit.CloneOptions{
+		URL:  spec.Repository,
+		Auth: auth,
+		Tags: gogit.AllTags,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("error cloning git repository %s: %w", spec.Repository, err)
+	}
+
+	commit, err := resolveCommit(repo, spec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading tree of commit %s: %w", commit.Hash, err)
+	}
+	if spec.Path != "" {
+		if tree, err = tree.Tree(spec.Path); err != nil {
+			return nil, "", fmt.Errorf("error reading directory %q of commit %s: %w", spec.Path, commit.Hash, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, tree); err != nil {
+		return nil, "", fmt.Errorf("error archiving commit %s: %w", commit.Hash, err)
+	}
+	data := buf.Bytes()
+
+	return inmemory.New(bytes.NewReader(data),
+		inmemory.WithMediaType(MediaTypeTarGzip),
+		inmemory.WithSize(int64(len(data))),
+		inmemory.WithDigest(digest.FromBytes(data).String()),
+	), commit.Hash.String(), nil
+}
+
+// resolveCommit returns the commit pinned by the spec, or the commit the ref of the spec points to.
+// If both are set, the pinned commit has to be reachable from the ref.
+func resolveCommit(repo *gogit.Repository, spec *v1.Git) (*object.Commit, error) {
+	var refCommit *object.Commit
+	if spec.Ref != "" || spec.Commit == "" {
+		hash, err := resolveRef(repo, spec.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if refCommit, err = commitOf(repo, *hash); err != nil {
+			return nil, fmt.Errorf("error resolving commit of ref %q: %w", spec.Ref, err)
+		}
+	}
+	if spec.Commit == "" {
+		return refCommit, nil
+	}
+
+	pinned, err := repo.CommitObject(plumbing.NewHash(spec.Commit))
+	if err != nil {
+		return nil, fmt.Errorf("error resolving commit %s: %w", spec.Commit, err)
+	}
+	if refCommit != nil && pinned.Hash != refCommit.Hash {
+		reachable, err := pinned.IsAncestor(refCommit)
+		if err != nil {
+			return nil, fmt.Errorf("error checking reachability of commit %s: %w", spec.Commit, err)
+		}
+		if !reachable {
+			return nil, fmt.Errorf("%w: %s is not in the history of %s", ErrCommitNotReachable, spec.Commit, spec.Ref)
+		}
+	}
+	return pinned, nil
+}
+
+// resolveRef resolves a branch, tag or full reference name. An empty ref resolves to HEAD.
+func resolveRef(repo *gogit.Repository, ref string) (*plumbing.Hash, error) {
+	if ref == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return nil, fmt.Errorf("error resolving HEAD: %w", err)
+		}
+		hash := head.Hash()
+		return &hash, nil
+	}
+	for _, name := range []plumbing.ReferenceName{
+		plumbing.ReferenceName(ref),
+		plumbing.NewBranchReferenceName(ref),
+		plumbing.NewTagReferenceName(ref),
+	} {
+		resolved, err := repo.Reference(name, true)
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error resolving ref %q: %w", ref, err)
+		}
+		hash := resolved.Hash()
+		return &hash, nil
+	}
+	return nil, fmt.Errorf("error resolving ref %q: %w", ref, plumbing.ErrReferenceNotFound)
+}
+
+// commitOf returns the commit of the hash, peeling annotated tags.
+func commitOf(repo *gogit.Repository, hash plumbing.Hash) (*object.Commit, error) {
+	if tag, err := repo.TagObject(hash); err == nil {
+		return tag.Commit()
+	}
+	return repo.CommitObject(hash)
+}
+
+func consumerIdentity(spec *v1.Git) (runtime.Identity, error) {
+	identity, err := runtime.ParseURLToIdentity(spec.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing git repository URL to identity: %w", err)
+	}
+	identity.SetType(identityv1.Type)
+	return identity, nil
+}
+
+// basicAuth converts direct credentials with the properties username and password, or token
+// into HTTP basic authentication. It returns nil for nil credentials.
+func basicAuth(credentials runtime.Typed) (transport.AuthMethod, error) {
+	if credentials == nil || credentials.GetType().IsEmpty() {
+		return nil, nil
+	}
+	var direct credv1.DirectCredentials
+	if err := credentialScheme.Convert(credentials, &direct); err != nil {
+		return nil, fmt.Errorf("unsupported credential type for git repositories: %w", err)
+	}
+	if token := direct.Properties["token"]; token != "" {
+		// git servers ignore the username when authenticating with a token, but require one to be set.
+		username := direct.Properties["username"]
+		if username == "" {
+			username = "git"
+		}
+		return &http.BasicAuth{Username: username, Password: token}, nil
+	}
+	if direct.Properties["username"] == "" {
+		return nil, nil
+	}
+	return &http.BasicAuth{Username: direct.Properties["username"], Password: direct.Properties["password"]}, nil
+}
+
+func setCommitIdentity(meta *constructorruntime.ElementMeta, commit string) {
+	if meta.ExtraIdentity == nil {
+		meta.ExtraIdentity = runtime.Identity{}
+	}
+	meta.ExtraIdentity[CommitIdentityAttribute] = commit
+}
@@ -0,0 +1,186 @@
+package git_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	"ocm.software/open-component-model/bindings/go/input/git"
+	v1 "ocm.software/open-component-model/bindings/go/input/git/spec/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// testRepository creates a repository with two commits on the default branch and a tag v1.0.0
+// on the first commit. It returns the path of the repository and the SHAs of both commits.
+func testRepository(t *testing.T) (string, string, string) {
+	t.Helper()
+	r := require.New(t)
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	r.NoError(err)
+	worktree, err := repo.Worktree()
+	r.NoError(err)
+
+	commit := func(files map[string]string, message string) plumbing.Hash {
+		for name, content := range files {
+			r.NoError(os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+			r.NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			_, err := worktree.Add(name)
+			r.NoError(err)
+		}
+		hash, err := worktree.Commit(message, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "ocm", Email: "ocm@ocm.software", When: time.Unix(1700000000, 0)},
+		})
+		r.NoError(err)
+		return hash
+	}
+
+	first := commit(map[string]string{"README.md": "v1", "charts/app/Chart.yaml": "name: app"}, "first")
+	_, err = repo.CreateTag("v1.0.0", first, nil)
+	r.NoError(err)
+	second := commit(map[string]string{"README.md": "v2"}, "second")
+	return dir, first.String(), second.String()
+}
+
+func gitInputResource(t *testing.T, spec map[string]any) *constructorruntime.Resource {
+	t.Helper()
+	raw, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	r := &constructorruntime.Resource{}
+	r.Name = "sources"
+	r.Version = "1.0.0"
+	r.Type = "directoryTree"
+	r.Input = &runtime.Raw{
+		Type: runtime.NewVersionedType(v1.Type, v1.Version),
+		Data: raw,
+	}
+	return r
+}
+
+// readArchive returns the content of the regular files of a tar+gzip blob by name.
+func readArchive(t *testing.T, b blob.ReadOnlyBlob) map[string]string {
+	t.Helper()
+	r := require.New(t)
+	rc, err := b.ReadCloser()
+	r.NoError(err)
+	defer func() { r.NoError(rc.Close()) }()
+	gz, err := gzip.NewReader(rc)
+	r.NoError(err)
+
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		r.NoError(err)
+		r.Equal(time.Unix(0, 0), header.ModTime)
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		r.NoError(err)
+		files[header.Name] = string(data)
+	}
+}
+
+func TestProcessResource(t *testing.T) {
+	repo, first, second := testRepository(t)
+	method := &git.InputMethod{}
+
+	t.Run("packs the default branch", func(t *testing.T) {
+		r := require.New(t)
+		resource := gitInputResource(t, map[string]any{"type": "git/v1", "repository": repo})
+		result, err := method.ProcessResource(t.Context(), resource, nil)
+		r.NoError(err)
+		r.Equal(map[string]string{"README.md": "v2", "charts/app/Chart.yaml": "name: app"}, readArchive(t, result.ProcessedBlobData))
+		r.Equal(second, resource.ExtraIdentity[git.CommitIdentityAttribute])
+		mediaType, _ := result.ProcessedBlobData.(blob.MediaTypeAware).MediaType()
+		r.Equal(git.MediaTypeTarGzip, mediaType)
+	})
+
+	t.Run("pins the commit and filters by path", func(t *testing.T) {
+		r := require.New(t)
+		resource := gitInputResource(t, map[string]any{
+			"type":       "git",
+			"repository": repo,
+			"ref":        "master",
+			"commit":     first,
+			"path":       "charts",
+		})
+		result, err := method.ProcessResource(t.Context(), resource, nil)
+		r.NoError(err)
+		r.Equal(map[string]string{"app/Chart.yaml": "name: app"}, readArchive(t, result.ProcessedBlobData))
+		r.Equal(first, resource.ExtraIdentity[git.CommitIdentityAttribute])
+	})
+
+	t.Run("resolves tags reproducibly", func(t *testing.T) {
+		r := require.New(t)
+		var digests []string
+		for range 2 {
+			resource := gitInputResource(t, map[string]any{"type": "git/v1", "repository": repo, "ref": "v1.0.0"})
+			result, err := method.ProcessResource(t.Context(), resource, nil)
+			r.NoError(err)
+			r.Equal(first, resource.ExtraIdentity[git.CommitIdentityAttribute])
+			dig, _ := result.ProcessedBlobData.(blob.DigestAware).Digest()
+			digests = append(digests, dig)
+		}
+		r.Equal(digests[0], digests[1])
+	})
+
+	t.Run("rejects commits not reachable from ref", func(t *testing.T) {
+		r := require.New(t)
+		_, err := method.ProcessResource(t.Context(), gitInputResource(t, map[string]any{
+			"type":       "git/v1",
+			"repository": repo,
+			"ref":        "v1.0.0",
+			"commit":     second,
+		}), nil)
+		r.ErrorIs(err, git.ErrCommitNotReachable)
+	})
+
+	t.Run("rejects invalid specs", func(t *testing.T) {
+		r := require.New(t)
+		_, err := method.ProcessResource(t.Context(), gitInputResource(t, map[string]any{
+			"type": "git/v1", "repository": repo, "commit": "abc",
+		}), nil)
+		r.ErrorContains(err, "not a full lowercase commit SHA")
+
+		_, err = method.ProcessResource(t.Context(), gitInputResource(t, map[string]any{
+			"type": "git/v1", "repository": repo, "path": "../outside",
+		}), nil)
+		r.ErrorContains(err, "must be relative to the repository root")
+
+		_, err = method.ProcessResource(t.Context(), gitInputResource(t, map[string]any{
+			"type": "git/v1", "repository": repo, "ref": "unknown",
+		}), nil)
+		r.ErrorIs(err, plumbing.ErrReferenceNotFound)
+	})
+}
+
+func TestGetResourceCredentialConsumerIdentity(t *testing.T) {
+	r := require.New(t)
+	identity, err := (&git.InputMethod{}).GetResourceCredentialConsumerIdentity(t.Context(), gitInputResource(t, map[string]any{
+		"type":       "git/v1",
+		"repository": "https://github.com/open-component-model/ocm.git",
+	}))
+	r.NoError(err)
+	r.Equal("GitRepository", identity[runtime.IdentityAttributeType])
+	r.Equal("github.com", identity[runtime.IdentityAttributeHostname])
+	r.Equal("open-component-model/ocm.git", identity[runtime.IdentityAttributePath])
+}
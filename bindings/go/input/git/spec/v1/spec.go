@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Git describes an input sourced by a git repository. The files of a commit are packed
+// into a tar+gzip archive with normalized attributes, so the same commit always results
+// in the same blob.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Git struct {
+	// +ocm:jsonschema-gen:enum=git/v1
+	// +ocm:jsonschema-gen:enum:deprecated=git
+	Type runtime.Type `json:"type"`
+
+	// Repository is the URL of the git repository, e.g. https://github.com/open-component-model/ocm.git.
+	Repository string `json:"repository"`
+
+	// Ref is the branch or tag to take the files from, e.g. main, v1.0.0 or refs/heads/main.
+	// If neither Ref nor Commit is set, the default branch of the repository is used.
+	Ref string `json:"ref,omitempty"`
+
+	// Commit is the full SHA of the commit to take the files from. It pins the input to a commit,
+	// so that moving the Ref does not change the resulting blob. If Ref is set as well,
+	// the commit must be reachable from the Ref.
+	Commit string `json:"commit,omitempty"`
+
+	// Path is the directory of the repository whose files are packed, relative to the root
+	// of the repository. If not set, all files of the repository are packed.
+	Path string `json:"path,omitempty"`
+}
+
+func (t *Git) String() string {
+	base := t.Repository
+	if t.Ref != "" {
+		base += "@" + t.Ref
+	}
+	if t.Commit != "" {
+		base += "#" + t.Commit
+	}
+	return base
+}
+
+const (
+	Version = "v1"
+	Type    = "git"
+)
@@ -0,0 +1,26 @@
+// Package npm provides the "npm" component-constructor input method, which packages
+// a version of a package published to an npm registry as a local blob.
+//
+// [ocm.software/open-component-model/bindings/go/npm/input.InputMethod] resolves a
+// [ocm.software/open-component-model/bindings/go/npm/spec/input/v1.NPM] input spec
+// in the metadata of the package in the registry. The version is either an exact
+// version or a dist-tag such as latest. The tarball of the version is only accepted
+// if it matches the integrity published by the registry, and it is stored with the
+// media type application/x-tgz and its sha256 digest:
+//
+//	resources:
+//	- name: tool
+//	  type: npmPackage
+//	  input:
+//	    type: npm/v1
+//	    registry: https://registry.npmjs.org
+//	    package: "@angular/core"
+//	    version: 17.3.0
+//
+// Credentials are resolved for the NPMRegistry consumer identity derived from the
+// registry URL (see the spec/identity/v1 package). They are accepted as
+// [ocm.software/open-component-model/bindings/go/npm/spec/credentials/v1.NPMCredentials]
+// or as Credentials/v1 with the token, username and password properties. A token is
+// sent as bearer token and takes precedence over basic authentication. Credentials
+// are never sent to tarball hosts other than the host of the registry.
+package npm
@@ -0,0 +1,307 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // sha1 shasums are only used for packages published without integrity
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/constructor"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	httpclient "ocm.software/open-component-model/bindings/go/http"
+	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	credsv1 "ocm.software/open-component-model/bindings/go/npm/spec/credentials/v1"
+	identityv1 "ocm.software/open-component-model/bindings/go/npm/spec/identity/v1"
+	"ocm.software/open-component-model/bindings/go/npm/spec/input"
+	v1 "ocm.software/open-component-model/bindings/go/npm/spec/input/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// MediaTypeNPMPackage is the media type of npm package tarballs.
+const MediaTypeNPMPackage = "application/x-tgz"
+
+// DefaultMaxDownloadSize is the maximum size (100 MiB) of package metadata and tarballs if
+// InputMethod.MaxDownloadSize is not set.
+const DefaultMaxDownloadSize int64 = 100 * 1024 * 1024
+
+// ErrVersionNotFound is returned if the registry knows neither a version nor a dist-tag with the requested name.
+var ErrVersionNotFound = errors.New("package version not found")
+
+// packageNamePattern matches unscoped and scoped npm package names.
+var packageNamePattern = regexp.MustCompile(`^(@[a-z0-9][a-z0-9._~-]*/)?[a-z0-9][a-z0-9._~-]*$`)
+
+// integrityAlgorithms are the algorithms of subresource integrity strings, strongest first.
+var integrityAlgorithms = []struct {
+	name string
+	hash func() hash.Hash
+}{
+	{name: "sha512", hash: sha512.New},
+	{name: "sha384", hash: sha512.New384},
+	{name: "sha256", hash: sha256.New},
+}
+
+var _ interface {
+	constructor.ResourceInputMethod
+} = (*InputMethod)(nil)
+
+// InputMethod implements the ResourceInputMethod interface for npm-based inputs.
+// It resolves a package version in an npm registry, fetches its tarball, verifies the tarball
+// against the integrity published by the registry and returns it as a local blob.
+//
+// Credentials are resolved for the registry with the NPMRegistry consumer identity.
+// They are only sent to the host of the registry, also if the registry serves tarballs from another host.
+type InputMethod struct {
+	// HTTPConfig configures the HTTP client (timeouts, retries, TLS, routing) used for
+	// requests to the registry. When nil, a default client is used.
+	HTTPConfig *httpv1alpha1.Config
+	// MaxDownloadSize limits the number of bytes read from a response. When zero,
+	// DefaultMaxDownloadSize is used. A negative value disables the limit.
+	MaxDownloadSize int64
+}
+
+func (i *InputMethod) GetInputMethodScheme() *runtime.Scheme {
+	return input.Scheme
+}
+
+// GetResourceCredentialConsumerIdentity returns the NPMRegistry credential consumer identity of the registry
+// of the npm input.
+func (i *InputMethod) GetResourceCredentialConsumerIdentity(_ context.Context, resource *constructorruntime.Resource) (runtime.Identity, error) {
+	spec, err := i.convert(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := runtime.ParseURLToIdentity(spec.Registry)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing npm registry URL to identity: %w", err)
+	}
+	identity.SetType(identityv1.Type)
+
+	return identity, nil
+}
+
+// ProcessResource fetches the tarball of the package version described by the npm input specification
+// and returns it as local blob data to be stored in the component version.
+func (i *InputMethod) ProcessResource(ctx context.Context, resource *constructorruntime.Resource, credentials runtime.Typed) (*constructor.ResourceInputMethodResult, error) {
+	spec, err := i.convert(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := credsv1.ConvertToNPMCredentials(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("error converting credentials: %w", err)
+	}
+
+	registryURL, err := url.Parse(spec.Registry)
+	if err != nil {
+		return nil, fmt.Errorf("npm registry is not a valid url: %w", err)
+	}
+	if registryURL.Scheme != "http" && registryURL.Scheme != "https" {
+		return nil, fmt.Errorf("npm registry must use http or https scheme, got %q", registryURL.Scheme)
+	}
+
+	client := http.DefaultClient
+	if i.HTTPConfig != nil {
+		client = httpclient.New(httpclient.WithConfig(i.HTTPConfig))
+	}
+
+	// scoped packages are addressed with an escaped slash, e.g. @scope%2fname.
+	metadataURL := strings.TrimSuffix(registryURL.String(), "/") + "/" + url.PathEscape(spec.Package)
+	metadata, err := i.get(ctx, client, metadataURL, registryURL, creds, "application/vnd.npm.install-v1+json; q=1.0, application/json; q=0.8")
+	if err != nil {
+		return nil, fmt.Errorf("error fetching metadata of npm package %s: %w", spec.Package, err)
+	}
+
+	version, dist, err := resolveVersion(metadata, spec.Version)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving npm package %s: %w", spec, err)
+	}
+	if dist.Tarball == "" {
+		return nil, fmt.Errorf("registry publishes no tarball for npm package %s@%s", spec.Package, version)
+	}
+
+	tarball, err := i.get(ctx, client, dist.Tarball, registryURL, creds, "")
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tarball of npm package %s@%s: %w", spec.Package, version, err)
+	}
+
+	if err := dist.verify(tarball); err != nil {
+		return nil, fmt.Errorf("error verifying tarball of npm package %s@%s: %w", spec.Package, version, err)
+	}
+
+	return &constructor.ResourceInputMethodResult{
+		ProcessedBlobData: inmemory.New(bytes.NewReader(tarball),
+			inmemory.WithMediaType(MediaTypeNPMPackage),
+			inmemory.WithSize(int64(len(tarball))),
+			inmemory.WithDigest(digest.FromBytes(tarball).String()),
+		),
+	}, nil
+}
+
+func (i *InputMethod) convert(resource *constructorruntime.Resource) (*v1.NPM, error) {
+	spec := v1.NPM{}
+	if err := i.GetInputMethodScheme().Convert(resource.Input, &spec); err != nil {
+		return nil, fmt.Errorf("error converting resource input spec: %w", err)
+	}
+	if spec.Package == "" {
+		return nil, fmt.Errorf("package is required in npm input spec")
+	}
+	if !packageNamePattern.MatchString(spec.Package) {
+		return nil, fmt.Errorf("invalid package name %q in npm input spec", spec.Package)
+	}
+	if spec.Version == "" {
+		return nil, fmt.Errorf("version is required in npm input spec")
+	}
+	if spec.Registry == "" {
+		spec.Registry = v1.DefaultRegistry
+	}
+	return &spec, nil
+}
+
+// get fetches the content at location. The credentials are only sent if location is served by the host
+// of the registry.
+func (i *InputMethod) get(ctx context.Context, client *http.Client, location string, registryURL *url.URL, creds *credsv1.NPMCredentials, accept string) (_ []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url scheme %q: only http and https are allowed", req.URL.Scheme)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if creds != nil && strings.EqualFold(req.URL.Host, registryURL.Host) {
+		switch {
+		case creds.Token != "":
+			req.Header.Set("Authorization", "Bearer "+creds.Token)
+		case creds.Username != "":
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.WarnContext(ctx, "failed to close npm registry response body", "error", err)
+		}
+	}()
+
+	// the location is sanitized, because tarball urls may contain presigned query parameters.
+	safeURL := *req.URL
+	safeURL.User = nil
+	safeURL.RawQuery = ""
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("request to %s returned status %d", safeURL.String(), resp.StatusCode)
+	}
+
+	maxDownloadSize := DefaultMaxDownloadSize
+	if i.MaxDownloadSize != 0 {
+		maxDownloadSize = i.MaxDownloadSize
+	}
+	body := io.Reader(resp.Body)
+	if maxDownloadSize > 0 {
+		body = io.LimitReader(resp.Body, maxDownloadSize+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from %s: %w", safeURL.String(), err)
+	}
+	if maxDownloadSize > 0 && int64(len(data)) > maxDownloadSize {
+		return nil, fmt.Errorf("response from %s exceeds maximum allowed size of %d bytes", safeURL.String(), maxDownloadSize)
+	}
+	return data, nil
+}
+
+// packageMetadata is the part of the metadata of a package (the packument) the input method uses.
+type packageMetadata struct {
+	DistTags map[string]string `json:"dist-tags"`
+	Versions map[string]struct {
+		Dist distribution `json:"dist"`
+	} `json:"versions"`
+}
+
+// distribution describes the tarball of a package version.
+type distribution struct {
+	// Tarball is the URL of the tarball.
+	Tarball string `json:"tarball"`
+	// Integrity is the subresource integrity string of the tarball, e.g. sha512-<base64>.
+	Integrity string `json:"integrity"`
+	// Shasum is the hex encoded sha1 checksum of the tarball, published for all packages.
+	Shasum string `json:"shasum"`
+}
+
+// resolveVersion returns the version the given version or dist-tag refers to, together with its distribution.
+func resolveVersion(metadata []byte, version string) (string, distribution, error) {
+	var pkg packageMetadata
+	if err := json.Unmarshal(metadata, &pkg); err != nil {
+		return "", distribution{}, fmt.Errorf("error decoding package metadata: %w", err)
+	}
+	if v, ok := pkg.Versions[version]; ok {
+		return version, v.Dist, nil
+	}
+	if tagged, ok := pkg.DistTags[version]; ok {
+		if v, ok := pkg.Versions[tagged]; ok {
+			return tagged, v.Dist, nil
+		}
+	}
+	return "", distribution{}, fmt.Errorf("%w: %s", ErrVersionNotFound, version)
+}
+
+// verify checks the tarball against the strongest integrity hash of the distribution. The sha1 shasum is only
+// used for packages published without integrity.
+func (d distribution) verify(tarball []byte) error {
+	hashes := make(map[string]string)
+	for _, entry := range strings.Fields(d.Integrity) {
+		algorithm, value, ok := strings.Cut(entry, "-")
+		if !ok {
+			continue
+		}
+		// options are appended with a question mark, see https://www.w3.org/TR/SRI/#the-integrity-attribute.
+		value, _, _ = strings.Cut(value, "?")
+		hashes[algorithm] = value
+	}
+
+	for _, algorithm := range integrityAlgorithms {
+		expected, ok := hashes[algorithm.name]
+		if !ok {
+			continue
+		}
+		h := algorithm.hash()
+		h.Write(tarball)
+		if actual := base64.StdEncoding.EncodeToString(h.Sum(nil)); actual != expected {
+			return fmt.Errorf("%s integrity mismatch: expected %s, got %s", algorithm.name, expected, actual)
+		}
+		return nil
+	}
+
+	if d.Shasum != "" {
+		sum := sha1.Sum(tarball) //nolint:gosec // see import
+		if actual := hex.EncodeToString(sum[:]); actual != strings.ToLower(d.Shasum) {
+			return fmt.Errorf("sha1 shasum mismatch: expected %s, got %s", d.Shasum, actual)
+		}
+		return nil
+	}
+
+	return errors.New("registry publishes neither an integrity nor a shasum")
+}
@@ -0,0 +1,230 @@
+package input_test
+
+import (
+	"crypto/sha1" //nolint:gosec // npm registries publish sha1 shasums
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	"ocm.software/open-component-model/bindings/go/npm/input"
+	credsv1 "ocm.software/open-component-model/bindings/go/npm/spec/credentials/v1"
+	v1 "ocm.software/open-component-model/bindings/go/npm/spec/input/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func npmInputResource(t *testing.T, spec map[string]any) *constructorruntime.Resource {
+	t.Helper()
+	raw, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	r := &constructorruntime.Resource{}
+	r.Name = "package"
+	r.Version = "1.0.0"
+	r.Type = "npmPackage"
+	r.Input = &runtime.Raw{
+		Type: runtime.NewVersionedType(v1.Type, v1.Version),
+		Data: raw,
+	}
+	return r
+}
+
+func readBlob(t *testing.T, b blob.ReadOnlyBlob) []byte {
+	t.Helper()
+	rc, err := b.ReadCloser()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, rc.Close()) }()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return data
+}
+
+func integrity(data []byte) string {
+	sum := sha512.Sum512(data)
+	return "sha512-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// newRegistry serves the metadata of the package @ocm/tool with the given distributions by version
+// and the tarballs by path. Tarball paths in the distributions are served as absolute URLs of the registry.
+// Requests without the token are rejected if token is set.
+func newRegistry(t *testing.T, token string, dists map[string]map[string]string, tarballs map[string][]byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.EscapedPath() == "/@ocm%2Ftool" {
+			versions := map[string]any{}
+			for version, dist := range dists {
+				absolute := map[string]string{}
+				for key, value := range dist {
+					absolute[key] = value
+				}
+				absolute["tarball"] = "http://" + r.Host + dist["tarball"]
+				versions[version] = map[string]any{"dist": absolute}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name":      "@ocm/tool",
+				"dist-tags": map[string]string{"latest": "1.1.0"},
+				"versions":  versions,
+			})
+			return
+		}
+		data, ok := tarballs[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProcessResource(t *testing.T) {
+	t.Parallel()
+	tarball := []byte("package tarball")
+
+	t.Run("fetches scoped package with integrity", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newRegistry(t, "", map[string]map[string]string{
+			"1.0.0": {"tarball": "/tool-1.0.0.tgz", "integrity": integrity(tarball)},
+		}, map[string][]byte{"/tool-1.0.0.tgz": tarball})
+
+		result, err := (&input.InputMethod{}).ProcessResource(t.Context(), npmInputResource(t, map[string]any{
+			"type":     "npm/v1",
+			"registry": server.URL,
+			"package":  "@ocm/tool",
+			"version":  "1.0.0",
+		}), nil)
+		r.NoError(err)
+		r.Equal(tarball, readBlob(t, result.ProcessedBlobData))
+		mediaType, _ := result.ProcessedBlobData.(blob.MediaTypeAware).MediaType()
+		r.Equal(input.MediaTypeNPMPackage, mediaType)
+		dig, _ := result.ProcessedBlobData.(blob.DigestAware).Digest()
+		r.Equal(digest.FromBytes(tarball).String(), dig)
+	})
+
+	t.Run("resolves dist-tags and falls back to the shasum", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		sum := sha1.Sum(tarball) //nolint:gosec // npm registries publish sha1 shasums
+		server := newRegistry(t, "", map[string]map[string]string{
+			"1.1.0": {"tarball": "/tool-1.1.0.tgz", "shasum": hex.EncodeToString(sum[:])},
+		}, map[string][]byte{"/tool-1.1.0.tgz": tarball})
+
+		result, err := (&input.InputMethod{}).ProcessResource(t.Context(), npmInputResource(t, map[string]any{
+			"type":     "npm",
+			"registry": server.URL,
+			"package":  "@ocm/tool",
+			"version":  "latest",
+		}), nil)
+		r.NoError(err)
+		r.Equal(tarball, readBlob(t, result.ProcessedBlobData))
+	})
+
+	t.Run("sends the token of the credentials", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newRegistry(t, "secret", map[string]map[string]string{
+			"1.0.0": {"tarball": "/tool-1.0.0.tgz", "integrity": integrity(tarball)},
+		}, map[string][]byte{"/tool-1.0.0.tgz": tarball})
+
+		method := &input.InputMethod{}
+		resource := npmInputResource(t, map[string]any{
+			"type":     "npm/v1",
+			"registry": server.URL,
+			"package":  "@ocm/tool",
+			"version":  "1.0.0",
+		})
+		_, err := method.ProcessResource(t.Context(), resource, nil)
+		r.ErrorContains(err, "status 401")
+
+		result, err := method.ProcessResource(t.Context(), resource, &runtime.Raw{
+			Type: runtime.NewVersionedType("Credentials", "v1"),
+			Data: []byte(`{"type":"Credentials/v1","properties":{"token":"secret"}}`),
+		})
+		r.NoError(err)
+		r.Equal(tarball, readBlob(t, result.ProcessedBlobData))
+
+		result, err = method.ProcessResource(t.Context(), resource, &credsv1.NPMCredentials{
+			Type:  credsv1.NPMCredentialsVersionedType,
+			Token: "secret",
+		})
+		r.NoError(err)
+		r.Equal(tarball, readBlob(t, result.ProcessedBlobData))
+	})
+
+	t.Run("rejects tarball with mismatching integrity", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newRegistry(t, "", map[string]map[string]string{
+			"1.0.0": {"tarball": "/tool-1.0.0.tgz", "integrity": integrity([]byte("other"))},
+		}, map[string][]byte{"/tool-1.0.0.tgz": tarball})
+
+		_, err := (&input.InputMethod{}).ProcessResource(t.Context(), npmInputResource(t, map[string]any{
+			"type":     "npm/v1",
+			"registry": server.URL,
+			"package":  "@ocm/tool",
+			"version":  "1.0.0",
+		}), nil)
+		r.ErrorContains(err, "sha512 integrity mismatch")
+	})
+
+	t.Run("rejects unknown versions and invalid package names", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newRegistry(t, "", nil, nil)
+
+		_, err := (&input.InputMethod{}).ProcessResource(t.Context(), npmInputResource(t, map[string]any{
+			"type":     "npm/v1",
+			"registry": server.URL,
+			"package":  "@ocm/tool",
+			"version":  "2.0.0",
+		}), nil)
+		r.ErrorIs(err, input.ErrVersionNotFound)
+
+		_, err = (&input.InputMethod{}).ProcessResource(t.Context(), npmInputResource(t, map[string]any{
+			"type":    "npm/v1",
+			"package": "../tool",
+			"version": "1.0.0",
+		}), nil)
+		r.ErrorContains(err, "invalid package name")
+	})
+}
+
+func TestGetResourceCredentialConsumerIdentity(t *testing.T) {
+	r := require.New(t)
+	method := &input.InputMethod{}
+
+	identity, err := method.GetResourceCredentialConsumerIdentity(t.Context(), npmInputResource(t, map[string]any{
+		"type":    "npm/v1",
+		"package": "lodash",
+		"version": "4.17.21",
+	}))
+	r.NoError(err)
+	r.Equal("NPMRegistry", identity[runtime.IdentityAttributeType])
+	r.Equal("registry.npmjs.org", identity[runtime.IdentityAttributeHostname])
+
+	identity, err = method.GetResourceCredentialConsumerIdentity(t.Context(), npmInputResource(t, map[string]any{
+		"type":     "npm/v1",
+		"registry": "https://npm.example.com:8443/api/npm",
+		"package":  "lodash",
+		"version":  "4.17.21",
+	}))
+	r.NoError(err)
+	r.Equal("npm.example.com", identity[runtime.IdentityAttributeHostname])
+	r.Equal("8443", identity[runtime.IdentityAttributePort])
+	r.Equal("api/npm", identity[runtime.IdentityAttributePath])
+}
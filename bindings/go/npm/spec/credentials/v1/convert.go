@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"fmt"
+
+	credv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	credentialKeyToken    = "token"
+	credentialKeyUsername = "username"
+	credentialKeyPassword = "password"
+)
+
+var convertScheme = runtime.NewScheme()
+
+func init() {
+	MustRegisterCredentialType(convertScheme)
+	credv1.MustRegister(convertScheme)
+}
+
+// ConvertToNPMCredentials converts runtime.Typed credentials into *NPMCredentials.
+// DirectCredentials are mapped using the npm-relevant fields (token, username, password).
+// Returns nil, nil for nil input or input with an empty type.
+func ConvertToNPMCredentials(creds runtime.Typed) (*NPMCredentials, error) {
+	if creds == nil || creds.GetType().String() == "" {
+		return nil, nil
+	}
+	typed, err := convertScheme.NewObject(creds.GetType())
+	if err != nil {
+		return nil, fmt.Errorf("error converting credential type: %w", err)
+	}
+	if err = convertScheme.Convert(creds, typed); err != nil {
+		return nil, fmt.Errorf("error converting credential type: %w", err)
+	}
+	switch t := typed.(type) {
+	case *credv1.DirectCredentials:
+		return &NPMCredentials{
+			Type:     NPMCredentialsVersionedType,
+			Token:    t.Properties[credentialKeyToken],
+			Username: t.Properties[credentialKeyUsername],
+			Password: t.Properties[credentialKeyPassword],
+		}, nil
+	case *NPMCredentials:
+		return t, nil
+	}
+	return nil, fmt.Errorf("unsupported credential type for npm registries: %v", typed.GetType())
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	//nolint:gosec // G101: This is a type name, not a credential.
+	NPMCredentialsType = "NPMCredentials"
+	Version            = "v1"
+)
+
+// NPMCredentialsVersionedType is the versioned type of NPMCredentials.
+var NPMCredentialsVersionedType = runtime.NewVersionedType(NPMCredentialsType, Version)
+
+// NPMCredentials represents typed credentials for npm registries.
+// Token is sent as bearer token, as configured with _authToken in an .npmrc.
+// Username and Password are sent with HTTP Basic Authentication and are ignored if Token is set.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type NPMCredentials struct {
+	// +ocm:jsonschema-gen:enum=NPMCredentials/v1
+	// +ocm:jsonschema-gen:enum:deprecated=NPMCredentials
+	Type     runtime.Type `json:"type"`
+	Token    string       `json:"token,omitempty"`
+	Username string       `json:"username,omitempty"`
+	Password string       `json:"password,omitempty"`
+}
+
+// MustRegisterCredentialType registers NPMCredentials/v1 (and its unversioned alias) in the given scheme.
+func MustRegisterCredentialType(scheme *runtime.Scheme) {
+	scheme.MustRegisterWithAlias(&NPMCredentials{},
+		NPMCredentialsVersionedType,
+		runtime.NewUnversionedType(NPMCredentialsType),
+	)
+}
@@ -0,0 +1,17 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	NPMRegistryIdentityType = "NPMRegistry"
+	Version                 = "v1"
+)
+
+// Type is the unversioned consumer identity type for npm registries.
+// The identity carries the hostname, scheme, port and path of the registry URL.
+var Type = runtime.NewUnversionedType(NPMRegistryIdentityType)
+
+// VersionedType is the versioned consumer identity type.
+var VersionedType = runtime.NewVersionedType(NPMRegistryIdentityType, Version)
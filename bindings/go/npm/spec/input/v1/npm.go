@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	Version = "v1"
+	Type    = "npm"
+)
+
+// DefaultRegistry is the registry packages are fetched from if no registry is specified.
+const DefaultRegistry = "https://registry.npmjs.org"
+
+// NPM describes an input sourced by fetching the tarball of a package version from an npm registry
+// during component construction. The tarball is stored as a local blob in the component version.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type NPM struct {
+	// +ocm:jsonschema-gen:enum=npm/v1
+	// +ocm:jsonschema-gen:enum:deprecated=npm
+	Type runtime.Type `json:"type"`
+
+	// Registry is the base URL of the npm registry. Defaults to https://registry.npmjs.org.
+	Registry string `json:"registry,omitempty"`
+
+	// Package is the name of the package, optionally scoped, for example lodash or @angular/core.
+	Package string `json:"package"`
+
+	// Version is the exact version of the package, for example 1.2.3, or a dist-tag such as latest,
+	// which is resolved to the version it points to at construction time.
+	Version string `json:"version"`
+}
+
+func (t *NPM) String() string {
+	return t.Package + "@" + t.Version
+}
@@ -13,27 +13,52 @@ import (
 // OCICredentialRepository implements the RepositoryPlugin Credential Graph interface to handle OCI/Docker credential
 // configurations. It provides functionality to:
 // - Resolve credentials from Docker config files or inline configurations
+// - Resolve credentials from Kubernetes Secrets, if a SecretProvider is set
 // - Support Docker credential repository configuration types
 // - Map repository configurations to consumer identities
 //
 // The repository supports various credential types including:
 // - Username/password authentication
 // - Token-based authentication (access tokens and refresh tokens)
-type OCICredentialRepository struct{}
+type OCICredentialRepository struct {
+	// SecretProvider reads the secrets of KubernetesSecrets configurations.
+	// If nil, KubernetesSecrets configurations cannot be resolved.
+	SecretProvider SecretProvider
+}
 
 func (p *OCICredentialRepository) GetCredentialRepositoryScheme() *runtime.Scheme {
 	return ocicredentials.Scheme
 }
 
 // Resolve resolves credentials and returns them as typed *credentialsv1.OCICredentials.
-// The credentials parameter is unused: docker configs are read from the host and do not require
-// authentication themselves.
+// The credentials parameter is unused: docker configs are read from the host and Kubernetes Secrets
+// are read with the cluster access of the SecretProvider, so neither requires authentication itself.
 func (p *OCICredentialRepository) Resolve(ctx context.Context, cfg runtime.Typed, identity runtime.Identity, _ runtime.Typed) (runtime.Typed, error) {
-	dockerConfig := credentialsv1.DockerConfig{}
-	if err := p.GetCredentialRepositoryScheme().Convert(cfg, &dockerConfig); err != nil {
-		return nil, fmt.Errorf("failed to resolve credentials because config could not be interpreted as docker config: %w", err)
+	scheme := p.GetCredentialRepositoryScheme()
+	typ := cfg.GetType()
+	if typ.IsEmpty() {
+		var err error
+		if typ, err = scheme.TypeForPrototype(cfg); err != nil {
+			return nil, fmt.Errorf("failed to resolve credentials because config type could not be determined: %w", err)
+		}
+	}
+	obj, err := scheme.NewObject(typ)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials because config type %q is not supported: %w", typ, err)
+	}
+	if err := scheme.Convert(cfg, obj); err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials because config could not be interpreted as %s: %w", typ, err)
+	}
+
+	var resolved *credentialsv1.OCICredentials
+	switch config := obj.(type) {
+	case *credentialsv1.DockerConfig:
+		resolved, err = ResolveV1DockerConfigCredentials(ctx, *config, identity)
+	case *credentialsv1.KubernetesSecrets:
+		resolved, err = ResolveV1KubernetesSecretsCredentials(ctx, p.SecretProvider, config, identity)
+	default:
+		return nil, fmt.Errorf("failed to resolve credentials because config type %T is not supported", obj)
 	}
-	resolved, err := ResolveV1DockerConfigCredentials(ctx, dockerConfig, identity)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,63 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	credentialsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// DockerConfigJSONKey is the key of the docker config in the data of a Kubernetes Secret
+// of type kubernetes.io/dockerconfigjson.
+const DockerConfigJSONKey = ".dockerconfigjson"
+
+// ErrNoSecretProvider is returned when credentials are resolved from a KubernetesSecrets
+// configuration without a SecretProvider.
+var ErrNoSecretProvider = errors.New("no secret provider configured to read kubernetes secrets")
+
+// SecretProvider reads the data of Kubernetes Secrets.
+// It is implemented by environments with cluster access such as the controller and the CLI,
+// so that this package does not depend on a Kubernetes client.
+type SecretProvider interface {
+	// GetSecretData returns the data of the secret with the given name, accessed with the cluster
+	// configuration and in the namespace of cfg.
+	GetSecretData(ctx context.Context, cfg *credentialsv1.KubernetesSecrets, name string) (map[string][]byte, error)
+}
+
+// ResolveV1KubernetesSecretsCredentials resolves credentials for a given identity from the
+// docker configs stored in Kubernetes Secrets of type kubernetes.io/dockerconfigjson.
+//
+// The secrets are consulted in the order of cfg.SecretNames and the credentials of the first
+// secret matching the identity are returned. If no secret matches, nil is returned.
+func ResolveV1KubernetesSecretsCredentials(ctx context.Context, provider SecretProvider, cfg *credentialsv1.KubernetesSecrets, identity runtime.Identity) (*credentialsv1.OCICredentials, error) {
+	if provider == nil {
+		return nil, ErrNoSecretProvider
+	}
+
+	for _, name := range cfg.SecretNames {
+		data, err := provider.GetSecretData(ctx, cfg, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret %q: %w", name, err)
+		}
+		dockerConfig, ok := data[DockerConfigJSONKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %q does not contain a docker config under key %q", name, DockerConfigJSONKey)
+		}
+		resolved, err := ResolveV1DockerConfigCredentials(ctx, credentialsv1.DockerConfig{
+			Type:         credentialsv1.DockerConfigVersionedType,
+			DockerConfig: string(dockerConfig),
+		}, identity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve credentials from secret %q: %w", name, err)
+		}
+		if resolved != nil {
+			return resolved, nil
+		}
+		slog.DebugContext(ctx, "no credentials found in secret", "secret", name)
+	}
+
+	return nil, nil
+}
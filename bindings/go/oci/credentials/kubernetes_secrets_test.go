@@ -0,0 +1,84 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	credentialsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
+	identityv1 "ocm.software/open-component-model/bindings/go/oci/spec/identity/v1"
+)
+
+type fakeSecretProvider map[string]map[string][]byte
+
+func (f fakeSecretProvider) GetSecretData(_ context.Context, cfg *credentialsv1.KubernetesSecrets, name string) (map[string][]byte, error) {
+	data, ok := f[cfg.Namespace+"/"+name]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s not found", cfg.Namespace, name)
+	}
+	return data, nil
+}
+
+func TestOCICredentialRepository_ResolveKubernetesSecrets(t *testing.T) {
+	provider := fakeSecretProvider{
+		"ocm-system/other": {
+			DockerConfigJSONKey: []byte(`{"auths":{"other.example.com":{"username":"other","password":"other"}}}`),
+		},
+		"ocm-system/registry": {
+			DockerConfigJSONKey: []byte(`{"auths":{"registry.example.com":{"username":"testuser","password":"testpass"}}}`),
+		},
+		"ocm-system/opaque": {
+			"token": []byte("value"),
+		},
+	}
+	identity := identityv1.ToIdentity(&identityv1.OCIRegistryIdentity{Hostname: "registry.example.com"})
+	config := func(names ...string) *credentialsv1.KubernetesSecrets {
+		return &credentialsv1.KubernetesSecrets{
+			Type:        credentialsv1.KubernetesSecretsVersionedType,
+			Namespace:   "ocm-system",
+			SecretNames: names,
+		}
+	}
+
+	t.Run("first matching secret", func(t *testing.T) {
+		r := require.New(t)
+		repo := &OCICredentialRepository{SecretProvider: provider}
+		resolved, err := repo.Resolve(t.Context(), config("other", "registry"), identity, nil)
+		r.NoError(err)
+		creds, ok := resolved.(*credentialsv1.OCICredentials)
+		r.True(ok)
+		r.Equal("testuser", creds.Username)
+		r.Equal("testpass", creds.Password)
+	})
+
+	t.Run("no matching secret", func(t *testing.T) {
+		r := require.New(t)
+		repo := &OCICredentialRepository{SecretProvider: provider}
+		resolved, err := repo.Resolve(t.Context(), config("other"), identity, nil)
+		r.NoError(err)
+		r.Nil(resolved)
+	})
+
+	t.Run("secret without docker config", func(t *testing.T) {
+		r := require.New(t)
+		repo := &OCICredentialRepository{SecretProvider: provider}
+		_, err := repo.Resolve(t.Context(), config("opaque"), identity, nil)
+		r.ErrorContains(err, DockerConfigJSONKey)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		r := require.New(t)
+		repo := &OCICredentialRepository{SecretProvider: provider}
+		_, err := repo.Resolve(t.Context(), config("missing"), identity, nil)
+		r.ErrorContains(err, "not found")
+	})
+
+	t.Run("without secret provider", func(t *testing.T) {
+		r := require.New(t)
+		repo := &OCICredentialRepository{}
+		_, err := repo.Resolve(t.Context(), config("registry"), identity, nil)
+		r.ErrorIs(err, ErrNoSecretProvider)
+	})
+}
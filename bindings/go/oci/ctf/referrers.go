@@ -9,6 +9,7 @@ import (
 	"io"
 	"io/fs"
 	"log/slog"
+	"slices"
 
 	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/image-spec/specs-go"
@@ -127,17 +128,38 @@ func (s *repository) referrersFromArtifactIndex(ctx context.Context, idx v1.Inde
 // References:
 //   - https://github.com/opencontainers/distribution-spec/blob/v1.1.1/spec.md#pushing-manifests-with-subject
 //
-// CTF does not implement content.Deleter and does not implement the
-// SkipReferrerGC negotiation; this is the inline equivalent. Each push that
-// changes the index writes a new index blob, retags the referrers tag onto
-// it, and best-effort deletes the prior index blob. Failures during cleanup
-// are logged but do not fail the push: stale blobs are harmless dead weight,
-// not correctness bugs. The new index is intentionally not tagged by digest —
-// referrers indexes are bookkeeping, never resolved by digest, and a digest
-// tag would leave a dangling index entry behind on the next push.
-//
 // The caller MUST hold a write lock and MUST persist the index after the call.
 func (s *repository) updateReferrersIndex(ctx context.Context, idx v1.Index, subject, referrer ociImageSpecV1.Descriptor) error {
+	return s.applyReferrersIndexChange(ctx, idx, subject, func(referrers []ociImageSpecV1.Descriptor) ([]ociImageSpecV1.Descriptor, bool) {
+		return addReferrer(referrers, referrer)
+	})
+}
+
+// removeFromReferrersIndex removes desc referencing subject from the referrers
+// index on manifest deletion.
+// References:
+//   - https://github.com/opencontainers/distribution-spec/blob/v1.1.1/spec.md#deleting-manifests
+//
+// The caller MUST hold a write lock and MUST persist the index after the call.
+func (s *repository) removeFromReferrersIndex(ctx context.Context, idx v1.Index, subject, referrer ociImageSpecV1.Descriptor) error {
+	return s.applyReferrersIndexChange(ctx, idx, subject, func(referrers []ociImageSpecV1.Descriptor) ([]ociImageSpecV1.Descriptor, bool) {
+		return removeReferrer(referrers, referrer)
+	})
+}
+
+// applyReferrersIndexChange applies change to the referrers index of subject.
+//
+// CTF does not implement the SkipReferrerGC negotiation; this is the inline
+// equivalent. Each change writes a new index blob, retags the referrers tag
+// onto it, and best-effort deletes the prior index blob. Failures during
+// cleanup are logged but do not fail the operation: stale blobs are harmless
+// dead weight, not correctness bugs. The new index is intentionally not tagged
+// by digest — referrers indexes are bookkeeping, never resolved by digest, and
+// a digest tag would leave a dangling index entry behind on the next change.
+// An index without referrers is removed instead of being rewritten.
+//
+// The caller MUST hold a write lock and MUST persist the index after the call.
+func (s *repository) applyReferrersIndexChange(ctx context.Context, idx v1.Index, subject ociImageSpecV1.Descriptor, change func([]ociImageSpecV1.Descriptor) ([]ociImageSpecV1.Descriptor, bool)) error {
 	referrersTag, err := buildReferrersTag(subject)
 	if err != nil {
 		return err
@@ -148,13 +170,25 @@ func (s *repository) updateReferrersIndex(ctx context.Context, idx v1.Index, sub
 		return err
 	}
 
-	updated, changed := addReferrer(oldReferrers, referrer)
+	updated, changed := change(oldReferrers)
 	if !changed {
-		// the referrer is already indexed and the stored index is clean;
+		// the referrers are already up to date and the stored index is clean;
 		// skip the write entirely, making referrer re-pushes idempotent.
 		return nil
 	}
 
+	hadPriorIndex := !content.Equal(oldIndexDesc, ociImageSpecV1.Descriptor{})
+
+	if len(updated) == 0 {
+		if hadPriorIndex {
+			if err := idx.RemoveTag(s.repo, referrersTag); err != nil && !errors.Is(err, v1.ErrArtifactNotFound) {
+				return fmt.Errorf("unable to remove referrers tag %q: %w", referrersTag, err)
+			}
+			s.deleteStaleReferrersIndex(ctx, oldIndexDesc, referrersTag)
+		}
+		return nil
+	}
+
 	newIndexDesc, newIndexJSON, err := generateIndex(updated)
 	if err != nil {
 		return err
@@ -163,8 +197,6 @@ func (s *repository) updateReferrersIndex(ctx context.Context, idx v1.Index, sub
 		return fmt.Errorf("unable to save referrers index for referrers tag %q: %w", referrersTag, err)
 	}
 
-	hadPriorIndex := !content.Equal(oldIndexDesc, ociImageSpecV1.Descriptor{})
-
 	// Clearing the old tag before creating the new one is kind of a hack. This
 	// allows garbage collection of the old index blobs without having to
 	// extend index API.
@@ -179,17 +211,22 @@ func (s *repository) updateReferrersIndex(ctx context.Context, idx v1.Index, sub
 		return fmt.Errorf("unable to retag referrers index for referrers tag %q: %w", referrersTag, err)
 	}
 
-	// best-effort GC of the prior referrers index blob. The RemoveTag above
-	// dropped the only index entry; nothing else points at this blob.
 	if hadPriorIndex {
-		if err := s.archive.DeleteBlob(ctx, oldIndexDesc.Digest.String()); err != nil {
-			slog.DebugContext(ctx, "failed to delete stale referrers index blob",
-				"digest", oldIndexDesc.Digest.String(), "referrersTag", referrersTag, "error", err)
-		}
+		s.deleteStaleReferrersIndex(ctx, oldIndexDesc, referrersTag)
 	}
 	return nil
 }
 
+// deleteStaleReferrersIndex is a best-effort GC of a prior referrers index
+// blob. The caller removed the only index entry; nothing else points at this
+// blob.
+func (s *repository) deleteStaleReferrersIndex(ctx context.Context, indexDesc ociImageSpecV1.Descriptor, referrersTag string) {
+	if err := s.archive.DeleteBlob(ctx, indexDesc.Digest.String()); err != nil {
+		slog.DebugContext(ctx, "failed to delete stale referrers index blob",
+			"digest", indexDesc.Digest.String(), "referrersTag", referrersTag, "error", err)
+	}
+}
+
 // referrerFromManifest inspects a pushed manifest for a subject field and, if
 // present, returns the subject together with the referrer descriptor enriched
 // the way the Referrers API response requires: artifactType set (falling back
@@ -280,6 +317,19 @@ func addReferrer(referrers []ociImageSpecV1.Descriptor, referrer ociImageSpecV1.
 	return updated, changed
 }
 
+// removeReferrer removes a referrer from a list of referrers.
+// Returns the updated referrers list and a boolean indicating if the list
+// was changed.
+//
+// Inspired by oras remote.applyReferrerChanges.
+func removeReferrer(referrers []ociImageSpecV1.Descriptor, referrer ociImageSpecV1.Descriptor) ([]ociImageSpecV1.Descriptor, bool) {
+	key := referrerKeyOf(referrer)
+	updated := slices.DeleteFunc(slices.Clone(referrers), func(r ociImageSpecV1.Descriptor) bool {
+		return referrerKeyOf(r) == key || content.Equal(r, ociImageSpecV1.Descriptor{})
+	})
+	return updated, len(updated) != len(referrers)
+}
+
 // generateIndex generates an image index containing the given manifests list.
 //
 // Copied from oras remote.generateIndex.
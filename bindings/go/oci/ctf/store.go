@@ -100,6 +100,8 @@ type repository struct {
 	mu      *sync.RWMutex
 }
 
+var _ content.Deleter = (*repository)(nil)
+
 // Fetch retrieves a blob from the CTF archive based on its descriptor.
 // Returns an io.ReadCloser for the blob content or an error if the blob cannot be found.
 // Uses LockedReader to maintain read lock during async streaming operations. The io.ReadCloser must be closed.
@@ -488,6 +490,60 @@ func (s *repository) Untag(ctx context.Context, reference string) error {
 	return s.untag(ctx, reference)
 }
 
+// Delete removes the manifest described by target from the CTF archive's index:
+// all entries of the manifest in the repository, tagged or not, and its entry in
+// the referrers index of its subject.
+// Like Untag, no blobs are deleted. They remain in the CTF until an explicit GC
+// pass compacts the archive.
+func (s *repository) Delete(ctx context.Context, target ociImageSpecV1.Descriptor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.archive.GetIndex(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get index: %w", err)
+	}
+	if err := idx.RemoveArtifact(s.repo, target.Digest.String()); err != nil {
+		if errors.Is(err, v1.ErrArtifactNotFound) {
+			return errdef.ErrNotFound
+		}
+		return fmt.Errorf("unable to remove %s from index: %w", target.Digest, err)
+	}
+
+	if introspection.IsOCICompliantManifest(target) {
+		b, err := s.archive.GetBlob(ctx, target.Digest.String())
+		if err != nil {
+			return fmt.Errorf("unable to get manifest %s: %w", target.Digest, err)
+		}
+		manifestJSON, err := readBlob(b)
+		if err != nil {
+			return fmt.Errorf("unable to read manifest %s: %w", target.Digest, err)
+		}
+		// undecodable manifests cannot carry a subject, see pushManifest.
+		if referrer, subject, err := referrerFromManifest(target, manifestJSON); err == nil && subject != nil {
+			if err := s.removeFromReferrersIndex(ctx, idx, *subject, referrer); err != nil {
+				return fmt.Errorf("unable to remove referrer %s for subject %s: %w", target.Digest, subject.Digest, err)
+			}
+		}
+	}
+
+	if err := s.archive.SetIndex(ctx, idx); err != nil {
+		return fmt.Errorf("unable to persist index after deletion: %w", err)
+	}
+	return nil
+}
+
+func readBlob(b blob.ReadOnlyBlob) (_ []byte, err error) {
+	rc, err := b.ReadCloser()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, rc.Close())
+	}()
+	return io.ReadAll(rc)
+}
+
 func (s *repository) untag(ctx context.Context, reference string) error {
 	tag := reference
 	if ref, err := looseref.ParseReference(reference); err == nil && ref.Tag != "" {
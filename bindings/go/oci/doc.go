@@ -28,6 +28,8 @@
 //     - access/v1: Provides version 1 of the OCI image access specification
 //     - digest/v1: Handles content addressing and digest operations
 //     - tar/: Manages TAR archive operations for OCI layouts
+//     - resumable/: Resumes interrupted blob downloads from persisted partial state and defines
+//       the tokens to resume interrupted chunked uploads (see resolver/url.WithChunkedUpload)
 //     - ctf/: Common Transport Format Store implementation that can be used to work with CTFs as if they were OCI registires
 //     - integration/: Integration tests
 //
@@ -104,6 +106,8 @@
 //   - WithCreator: Component version creator identification
 //   - WithReferrerTrackingPolicy: OCI referrer tracking policy
 //   - WithGlobalAccessPolicy: Global access policy for local blobs
+//   - WithRepositoryMode / WithMaintenanceMessage: Read-only or maintenance mode.
+//     Write operations in these modes fail with a *WriteDeniedError matching ErrWriteDenied.
 //
 // Media Types:
 //
@@ -118,6 +122,10 @@
 // The package uses specific annotations for OCI manifests:
 //   - AnnotationOCMComponentVersion: Identifies the component version
 //   - AnnotationOCMCreator: Identifies the creator of the OCM component
+//   - AnnotationOCMSignatures: Lists the names of the signatures of signed component versions
+//
+// Repository.ListComponentVersionsMatching filters the listed versions by these annotations
+// without downloading the component descriptors.
 //
 // Dependencies:
 //
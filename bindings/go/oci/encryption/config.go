@@ -0,0 +1,46 @@
+package encryption
+
+import (
+	"fmt"
+	"os"
+
+	"ocm.software/open-component-model/bindings/go/oci/encryption/spec/v1alpha1"
+)
+
+// LoadRepositoryKeys loads the RSA keys configured for a repository, see v1alpha1.RepositoryEncryption.
+// The returned wrapper is nil if no public key is configured.
+func LoadRepositoryKeys(config *v1alpha1.RepositoryEncryption) (KeyWrapper, []KeyUnwrapper, error) {
+	var wrapper KeyWrapper
+	if config.PublicKeyFile != "" {
+		data, err := os.ReadFile(config.PublicKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read public key file: %w", err)
+		}
+		key, err := ParseRSAPublicKeyPEM(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid public key file %s: %w", config.PublicKeyFile, err)
+		}
+		if wrapper, err = NewRSAKeyWrapper(key); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	unwrappers := make([]KeyUnwrapper, 0, len(config.PrivateKeyFiles))
+	for _, file := range config.PrivateKeyFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read private key file: %w", err)
+		}
+		key, err := ParseRSAPrivateKeyPEM(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid private key file %s: %w", file, err)
+		}
+		unwrapper, err := NewRSAKeyUnwrapper(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		unwrappers = append(unwrappers, unwrapper)
+	}
+
+	return wrapper, unwrappers, nil
+}
@@ -0,0 +1,161 @@
+// Package encryption provides envelope encryption of local blobs at rest.
+//
+// A blob is encrypted with a random AES-256 data key in GCM mode. The data key is wrapped with a
+// key encryption key by a [KeyWrapper], for example an RSA public key or a key managed by a key
+// management service, and recorded together with the nonce in the encryption metadata of the
+// local blob access. Consumers holding a matching [KeyUnwrapper] unwrap the data key and decrypt
+// the blob. Everybody else only sees the ciphertext, so sensitive configuration can be stored in
+// shared registries.
+//
+// The ciphertext can be bound to its context with additional authenticated data, e.g. the identity
+// of the resource the blob belongs to. Decryption then fails if the ciphertext is presented in another
+// context, so that encrypted blobs cannot be swapped between resources unnoticed.
+//
+// The keys are configured per repository with the configuration type encryption.config.ocm.software,
+// see the spec/v1alpha1 package, which the OCI repository provider applies to the repositories it creates.
+//
+// Blobs are encrypted and decrypted in memory, which makes envelope encryption suitable for
+// configuration sized blobs rather than large artifacts.
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+const (
+	// AlgorithmAES256GCM is the algorithm blobs are encrypted with.
+	AlgorithmAES256GCM = "AES-256-GCM"
+
+	// MediaTypeEncryptedBlob is the media type of the OCI layer storing an encrypted blob.
+	// The media type of the decrypted blob is kept in the local blob access.
+	MediaTypeEncryptedBlob = "application/vnd.ocm.software.encrypted.v1"
+
+	dataKeySize = 32
+)
+
+var (
+	// ErrKeyMismatch is returned by a [KeyUnwrapper] for data keys that were not wrapped with its key.
+	ErrKeyMismatch = errors.New("data key was not wrapped with this key")
+	// ErrNoDecryptionKey is returned when no configured [KeyUnwrapper] can unwrap the data key of a blob.
+	ErrNoDecryptionKey = errors.New("no key to decrypt blob")
+	// ErrUnsupportedAlgorithm is returned for blobs encrypted with an unknown algorithm.
+	ErrUnsupportedAlgorithm = errors.New("unsupported encryption algorithm")
+)
+
+// KeyWrapper wraps data keys with a key encryption key.
+type KeyWrapper interface {
+	// KeyWrapAlgorithm is the algorithm data keys are wrapped with, e.g. RSA-OAEP-256.
+	KeyWrapAlgorithm() string
+	// KeyID identifies the key encryption key. It may be empty.
+	KeyID() string
+	// WrapKey wraps the data key.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+}
+
+// KeyUnwrapper unwraps data keys wrapped by a [KeyWrapper].
+type KeyUnwrapper interface {
+	// UnwrapKey unwraps a data key wrapped with the given algorithm and key encryption key.
+	// It returns [ErrKeyMismatch] if the data key was not wrapped with the key of the unwrapper.
+	UnwrapKey(ctx context.Context, algorithm, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// Encrypt encrypts the plaintext with a new data key wrapped by the wrapper and authenticates it together
+// with the additional data, which has to be passed to Decrypt unchanged.
+// It returns the ciphertext and the encryption metadata needed to decrypt it.
+func Encrypt(ctx context.Context, wrapper KeyWrapper, plaintext, additionalData []byte) ([]byte, *descriptor.LocalBlobEncryption, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	wrappedKey, err := wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return aead.Seal(nil, nonce, plaintext, additionalData), &descriptor.LocalBlobEncryption{
+		Algorithm:        AlgorithmAES256GCM,
+		KeyWrapAlgorithm: wrapper.KeyWrapAlgorithm(),
+		KeyID:            wrapper.KeyID(),
+		WrappedKey:       base64.StdEncoding.EncodeToString(wrappedKey),
+		Nonce:            base64.StdEncoding.EncodeToString(nonce),
+	}, nil
+}
+
+// Decrypt decrypts the ciphertext described by the encryption metadata with the data key
+// unwrapped by the first of the unwrappers that holds the key encryption key.
+// If none of them does, it returns [ErrNoDecryptionKey], classified as unauthorized.
+// Decryption fails if the additional data differs from the one passed to Encrypt.
+func Decrypt(ctx context.Context, unwrappers []KeyUnwrapper, encryption *descriptor.LocalBlobEncryption, ciphertext, additionalData []byte) ([]byte, error) {
+	if encryption.Algorithm != AlgorithmAES256GCM {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, encryption.Algorithm)
+	}
+	wrappedKey, err := base64.StdEncoding.DecodeString(encryption.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode wrapped data key: %w", err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(encryption.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nonce: %w", err)
+	}
+
+	dataKey, err := unwrapKey(ctx, unwrappers, encryption, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce size %d, expected %d", len(nonce), aead.NonceSize())
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt blob: %w", err)
+	}
+	return plaintext, nil
+}
+
+func unwrapKey(ctx context.Context, unwrappers []KeyUnwrapper, encryption *descriptor.LocalBlobEncryption, wrappedKey []byte) ([]byte, error) {
+	for _, unwrapper := range unwrappers {
+		dataKey, err := unwrapper.UnwrapKey(ctx, encryption.KeyWrapAlgorithm, encryption.KeyID, wrappedKey)
+		if errors.Is(err, ErrKeyMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+		}
+		return dataKey, nil
+	}
+	return nil, ocmerrors.Unauthorized(fmt.Errorf("%w: data key is wrapped with %s by key %q",
+		ErrNoDecryptionKey, encryption.KeyWrapAlgorithm, encryption.KeyID))
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != dataKeySize {
+		return nil, fmt.Errorf("invalid data key size %d, expected %d", len(dataKey), dataKeySize)
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,98 @@
+package encryption_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/oci/encryption"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+func newRSAKeys(t *testing.T) (*encryption.RSAKeyWrapper, *encryption.RSAKeyUnwrapper) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	wrapper, err := encryption.NewRSAKeyWrapper(&key.PublicKey)
+	require.NoError(t, err)
+	unwrapper, err := encryption.NewRSAKeyUnwrapper(key)
+	require.NoError(t, err)
+	return wrapper, unwrapper
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	r := require.New(t)
+	wrapper, unwrapper := newRSAKeys(t)
+	_, otherUnwrapper := newRSAKeys(t)
+	plaintext := []byte("sensitive configuration")
+	additionalData := []byte("name=config,version=1.0.0")
+
+	ciphertext, metadata, err := encryption.Encrypt(t.Context(), wrapper, plaintext, additionalData)
+	r.NoError(err)
+	r.NotContains(string(ciphertext), string(plaintext))
+	r.Equal(encryption.AlgorithmAES256GCM, metadata.Algorithm)
+	r.Equal(encryption.KeyWrapAlgorithmRSAOAEP256, metadata.KeyWrapAlgorithm)
+	r.Equal(wrapper.KeyID(), metadata.KeyID)
+
+	decrypted, err := encryption.Decrypt(t.Context(), []encryption.KeyUnwrapper{otherUnwrapper, unwrapper}, metadata, ciphertext, additionalData)
+	r.NoError(err)
+	r.Equal(plaintext, decrypted)
+
+	// the ciphertext is bound to the additional data.
+	_, err = encryption.Decrypt(t.Context(), []encryption.KeyUnwrapper{unwrapper}, metadata, ciphertext, []byte("name=other,version=1.0.0"))
+	r.ErrorContains(err, "failed to decrypt blob")
+
+	// every encryption uses a new data key and nonce.
+	again, otherMetadata, err := encryption.Encrypt(t.Context(), wrapper, plaintext, additionalData)
+	r.NoError(err)
+	r.NotEqual(ciphertext, again)
+	r.NotEqual(metadata.WrappedKey, otherMetadata.WrappedKey)
+}
+
+func TestDecrypt_NoMatchingKey(t *testing.T) {
+	r := require.New(t)
+	wrapper, _ := newRSAKeys(t)
+	_, otherUnwrapper := newRSAKeys(t)
+
+	ciphertext, metadata, err := encryption.Encrypt(t.Context(), wrapper, []byte("data"), nil)
+	r.NoError(err)
+
+	for name, unwrappers := range map[string][]encryption.KeyUnwrapper{
+		"no keys":   nil,
+		"other key": {otherUnwrapper},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := encryption.Decrypt(t.Context(), unwrappers, metadata, ciphertext, nil)
+			require.ErrorIs(t, err, encryption.ErrNoDecryptionKey)
+			require.True(t, ocmerrors.IsUnauthorized(err))
+		})
+	}
+
+	// without a key id, the unwrapper can only detect a foreign key by failing to unwrap it.
+	metadata.KeyID = ""
+	_, err = encryption.Decrypt(t.Context(), []encryption.KeyUnwrapper{otherUnwrapper}, metadata, ciphertext, nil)
+	r.ErrorIs(err, encryption.ErrNoDecryptionKey)
+}
+
+func TestDecrypt_Tampered(t *testing.T) {
+	r := require.New(t)
+	wrapper, unwrapper := newRSAKeys(t)
+
+	ciphertext, metadata, err := encryption.Encrypt(t.Context(), wrapper, []byte("data"), nil)
+	r.NoError(err)
+	ciphertext[0] ^= 0xff
+	_, err = encryption.Decrypt(t.Context(), []encryption.KeyUnwrapper{unwrapper}, metadata, ciphertext, nil)
+	r.ErrorContains(err, "failed to decrypt blob")
+
+	ciphertext[0] ^= 0xff
+	metadata.Nonce = base64.StdEncoding.EncodeToString(make([]byte, 12))
+	_, err = encryption.Decrypt(t.Context(), []encryption.KeyUnwrapper{unwrapper}, metadata, ciphertext, nil)
+	r.ErrorContains(err, "failed to decrypt blob")
+
+	metadata.Algorithm = "AES-128-CBC"
+	_, err = encryption.Decrypt(t.Context(), []encryption.KeyUnwrapper{unwrapper}, metadata, ciphertext, nil)
+	r.ErrorIs(err, encryption.ErrUnsupportedAlgorithm)
+}
@@ -0,0 +1,134 @@
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// KeyWrapAlgorithmRSAOAEP256 wraps data keys with RSA-OAEP using SHA-256.
+const KeyWrapAlgorithmRSAOAEP256 = "RSA-OAEP-256"
+
+// RSAKeyID returns the default key id of an RSA key, the SHA-256 fingerprint of its public key.
+func RSAKeyID(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// RSAKeyWrapper wraps data keys with an RSA public key.
+type RSAKeyWrapper struct {
+	key *rsa.PublicKey
+	id  string
+}
+
+var _ KeyWrapper = (*RSAKeyWrapper)(nil)
+
+// NewRSAKeyWrapper creates a [KeyWrapper] wrapping data keys with the public key.
+// The key is identified by [RSAKeyID].
+func NewRSAKeyWrapper(key *rsa.PublicKey) (*RSAKeyWrapper, error) {
+	id, err := RSAKeyID(key)
+	if err != nil {
+		return nil, err
+	}
+	return &RSAKeyWrapper{key: key, id: id}, nil
+}
+
+func (w *RSAKeyWrapper) KeyWrapAlgorithm() string {
+	return KeyWrapAlgorithmRSAOAEP256
+}
+
+func (w *RSAKeyWrapper) KeyID() string {
+	return w.id
+}
+
+func (w *RSAKeyWrapper) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	return rsa.EncryptOAEP(sha256.New(), rand.Reader, w.key, dataKey, nil)
+}
+
+// RSAKeyUnwrapper unwraps data keys wrapped by an [RSAKeyWrapper] with an RSA private key.
+type RSAKeyUnwrapper struct {
+	key *rsa.PrivateKey
+	id  string
+}
+
+var _ KeyUnwrapper = (*RSAKeyUnwrapper)(nil)
+
+// NewRSAKeyUnwrapper creates a [KeyUnwrapper] unwrapping data keys with the private key.
+func NewRSAKeyUnwrapper(key *rsa.PrivateKey) (*RSAKeyUnwrapper, error) {
+	id, err := RSAKeyID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &RSAKeyUnwrapper{key: key, id: id}, nil
+}
+
+func (u *RSAKeyUnwrapper) UnwrapKey(_ context.Context, algorithm, keyID string, wrappedKey []byte) ([]byte, error) {
+	if algorithm != KeyWrapAlgorithmRSAOAEP256 || keyID != "" && keyID != u.id {
+		return nil, ErrKeyMismatch
+	}
+	dataKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, u.key, wrappedKey, nil)
+	if err != nil {
+		if keyID == "" {
+			// without a key id, a failing decryption is the only hint that another key was used.
+			return nil, fmt.Errorf("%w: %w", ErrKeyMismatch, err)
+		}
+		return nil, err
+	}
+	return dataKey, nil
+}
+
+// ParseRSAPublicKeyPEM returns the first RSA public key in the PEM data. It supports PKIX ("PUBLIC KEY")
+// and PKCS#1 ("RSA PUBLIC KEY") public keys as well as X.509 certificates.
+func ParseRSAPublicKeyPEM(data []byte) (*rsa.PublicKey, error) {
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		switch block.Type {
+		case "PUBLIC KEY":
+			if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+				if key, ok := key.(*rsa.PublicKey); ok {
+					return key, nil
+				}
+			}
+		case "RSA PUBLIC KEY":
+			if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+				return key, nil
+			}
+		case "CERTIFICATE":
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				if key, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+					return key, nil
+				}
+			}
+		}
+	}
+	return nil, errors.New("no RSA public key found in PEM data")
+}
+
+// ParseRSAPrivateKeyPEM returns the first RSA private key in the PEM data. It supports PKCS#1
+// ("RSA PRIVATE KEY") and PKCS#8 ("PRIVATE KEY") private keys.
+func ParseRSAPrivateKeyPEM(data []byte) (*rsa.PrivateKey, error) {
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+				return key, nil
+			}
+		case "PRIVATE KEY":
+			if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+				if key, ok := key.(*rsa.PrivateKey); ok {
+					return key, nil
+				}
+			}
+		}
+	}
+	return nil, errors.New("no RSA private key found in PEM data")
+}
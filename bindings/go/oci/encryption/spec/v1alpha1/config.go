@@ -0,0 +1,91 @@
+package v1alpha1
+
+import (
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// ConfigType defines the type identifier for local blob encryption configurations.
+	ConfigType = "encryption.config.ocm.software"
+)
+
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&Config{},
+		runtime.NewVersionedType(ConfigType, Version),
+		runtime.NewUnversionedType(ConfigType),
+	)
+}
+
+// Config configures the envelope encryption of local resource blobs per repository.
+// Local resource blobs added to a configured repository are encrypted with its public key,
+// and encrypted local resource blobs read from it are decrypted with its private keys.
+//
+//	type: generic.config.ocm.software/v1
+//	configurations:
+//	  - type: encryption.config.ocm.software/v1alpha1
+//	    repositories:
+//	      - repository:
+//	          type: OCIRepository/v1
+//	          baseUrl: ghcr.io
+//	          subPath: acme/confidential
+//	        publicKeyFile: /etc/ocm/encryption.pub
+//	        privateKeyFiles:
+//	          - /etc/ocm/encryption.key
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Config struct {
+	// +ocm:jsonschema-gen:enum=encryption.config.ocm.software/v1alpha1
+	// +ocm:jsonschema-gen:enum:deprecated=encryption.config.ocm.software
+	Type runtime.Type `json:"type"`
+
+	// Repositories configure the keys of the repositories local resource blobs are encrypted in.
+	// Local resource blobs of all other repositories are stored unencrypted.
+	Repositories []RepositoryEncryption `json:"repositories,omitempty"`
+}
+
+// RepositoryEncryption configures the keys local resource blobs of a repository are encrypted with.
+//
+// +k8s:deepcopy-gen=true
+type RepositoryEncryption struct {
+	// Repository is the specification of the repository, e.g. an OCIRepository with its base URL
+	// and sub path, or a CommonTransportFormat with its file path.
+	Repository *runtime.Raw `json:"repository"`
+
+	// PublicKeyFile is the path of a PEM file holding the RSA public key or certificate the data keys
+	// of local resource blobs added to the repository are wrapped with.
+	// Local resource blobs are added unencrypted if not defined.
+	PublicKeyFile string `json:"publicKeyFile,omitempty"`
+
+	// PrivateKeyFiles are the paths of PEM files holding the RSA private keys the data keys of encrypted
+	// local resource blobs read from the repository are unwrapped with.
+	// Getting an encrypted local resource without a matching key fails.
+	PrivateKeyFiles []string `json:"privateKeyFiles,omitempty"`
+}
+
+// LookupConfig creates a local blob encryption configuration from a central V1 config.
+// The repositories of all encryption configurations are combined.
+func LookupConfig(cfg *genericv1.Config) (*Config, error) {
+	return genericv1.Lookup(Scheme, cfg, Merge)
+}
+
+// Merge merges the provided configs into a single config by combining their repositories.
+func Merge(configs ...*Config) *Config {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	merged := new(Config)
+	_, _ = Scheme.DefaultType(merged)
+
+	for _, config := range configs {
+		merged.Repositories = append(merged.Repositories, config.Repositories...)
+	}
+
+	return merged
+}
@@ -0,0 +1,5 @@
+// Package v1alpha1 defines the local blob encryption configuration type encryption.config.ocm.software/v1alpha1.
+//
+// See the parent package ocm.software/open-component-model/bindings/go/oci/encryption
+// for the envelope encryption of local blobs with the configured keys.
+package v1alpha1
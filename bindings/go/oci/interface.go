@@ -19,6 +19,10 @@ type LocalBlob fetch.LocalBlob
 type ComponentVersionRepository interface {
 	repository.ComponentVersionRepository
 	AliasComponentVersionRepository
+	ComponentVersionSignatureRepository
+	CosignSignatureRepository
+	repository.ComponentVersionMetadataRepository
+	repository.ComponentVersionDeleter
 	repository.HealthCheckable
 	ResourceDigestProcessor
 }
@@ -50,6 +54,33 @@ type ResourceDigestProcessor interface {
 	ProcessResourceDigest(ctx context.Context, res *descriptor.Resource) (*descriptor.Resource, error)
 }
 
+// ComponentVersionSignatureRepository defines the interface for attaching signatures to component versions
+// that were already published. The signatures are stored as OCI referrers of the component version manifest,
+// so neither the component descriptor nor its manifest are rewritten.
+type ComponentVersionSignatureRepository interface {
+	// AddComponentVersionSignature attaches a signature of the component descriptor to an existing component version.
+	// Signature names are unique within a component version: it fails with ErrSignatureExists if the component
+	// descriptor or an attached signature already has a signature with the same name.
+	// It fails with repository.ErrNotFound if the component version does not exist.
+	AddComponentVersionSignature(ctx context.Context, component, version string, signature descriptor.Signature) error
+	// ListComponentVersionSignatures returns the signatures attached with AddComponentVersionSignature, ordered by name.
+	// Signatures that are part of the component descriptor are not included, see MergeSignatures.
+	// It fails with repository.ErrNotFound if the component version does not exist.
+	ListComponentVersionSignatures(ctx context.Context, component, version string) ([]descriptor.Signature, error)
+}
+
+// CosignSignatureRepository defines the interface for reading signatures that container tooling such as cosign
+// attached to the manifest of a component version, instead of signing the component descriptor.
+type CosignSignatureRepository interface {
+	// GetComponentVersionCosignSignatures returns the component descriptor of the component version together with
+	// the Sigstore bundles attached to its manifest as OCI referrers, converted to signatures named cosign-<digest>.
+	// The digest of the signatures is the digest of the manifest (see ManifestDigestNormalisation) that holds
+	// the returned descriptor. To verify them, e.g. with signing.VerifyWithPolicy, merge them into the descriptor
+	// with MergeSignatures and record the manifest digest with signing.WithArtifactDigest.
+	// It fails with repository.ErrNotFound if the component version does not exist.
+	GetComponentVersionCosignSignatures(ctx context.Context, component, version string) (*CosignSignatures, error)
+}
+
 // Resolver defines the interface for resolving references to OCI stores.
 type Resolver interface {
 	// StoreForReference resolves a reference to a Store.
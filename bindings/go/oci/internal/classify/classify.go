@@ -0,0 +1,48 @@
+// Package classify classifies errors of OCI stores and registries with the reasons of the
+// shared error taxonomy.
+package classify
+
+import (
+	"errors"
+	"io"
+	"net"
+	"syscall"
+
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+// Error classifies err with the reason matching the oras error definitions, the HTTP status
+// of registry error responses or transient network failures.
+// Errors that are already classified or have no matching reason are returned unchanged.
+func Error(err error) error {
+	if err == nil || ocmerrors.Reason(err) != nil {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, errdef.ErrNotFound):
+		return ocmerrors.NotFound(err)
+	case errors.Is(err, errdef.ErrAlreadyExists):
+		return ocmerrors.AlreadyExists(err)
+	case errors.Is(err, errdef.ErrUnsupported):
+		return ocmerrors.Unsupported(err)
+	}
+
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) {
+		return ocmerrors.FromHTTPStatus(errResp.StatusCode, err)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return ocmerrors.Temporary(err)
+	}
+
+	return err
+}
@@ -0,0 +1,53 @@
+package classify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+func TestError(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err    error
+		reason error
+	}{
+		"nil":            {err: nil, reason: nil},
+		"unclassified":   {err: errors.New("invalid manifest"), reason: nil},
+		"not found":      {err: fmt.Errorf("resolve: %w", errdef.ErrNotFound), reason: ocmerrors.ErrNotFound},
+		"already exists": {err: errdef.ErrAlreadyExists, reason: ocmerrors.ErrAlreadyExists},
+		"unsupported":    {err: errdef.ErrUnsupported, reason: ocmerrors.ErrUnsupported},
+		"unauthorized": {
+			err:    &errcode.ErrorResponse{Method: http.MethodGet, StatusCode: http.StatusUnauthorized},
+			reason: ocmerrors.ErrUnauthorized,
+		},
+		"rate limited": {
+			err:    &errcode.ErrorResponse{Method: http.MethodGet, StatusCode: http.StatusTooManyRequests},
+			reason: ocmerrors.ErrTemporary,
+		},
+		"connection reset": {err: fmt.Errorf("read: %w", syscall.ECONNRESET), reason: ocmerrors.ErrTemporary},
+		"context canceled": {err: context.Canceled, reason: nil},
+		"already classified": {
+			err:    ocmerrors.PreconditionFailed(errdef.ErrNotFound),
+			reason: ocmerrors.ErrPreconditionFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			classified := Error(tc.err)
+			r.Equal(tc.reason, ocmerrors.Reason(classified))
+			if tc.err != nil {
+				r.ErrorIs(classified, tc.err)
+				r.Equal(tc.err.Error(), classified.Error())
+			}
+		})
+	}
+}
@@ -22,7 +22,9 @@ import (
 // The annotation format is expected to be: "annotations.DefaultComponentDescriptorPath/<component>:<version>".
 // The input format can be either annotations.DefaultComponentDescriptorPath/<component> or simply <component>.
 // Returns lister.ErrSkip if the annotation is not present or not in the correct format.
-func ReferrerAnnotationVersionResolver(component string) lister.ReferrerVersionResolver {
+// Referrers whose annotations do not match all filters are skipped as well.
+func ReferrerAnnotationVersionResolver(component string, filters ...annotations.Filter) lister.ReferrerVersionResolver {
+	filter := annotations.MatchAll(filters...)
 	referrerResolver := func(ctx context.Context, descriptor ociImageSpecV1.Descriptor) (string, error) {
 		if descriptor.Annotations == nil {
 			return "", lister.ErrSkip
@@ -45,6 +47,10 @@ func ReferrerAnnotationVersionResolver(component string) lister.ReferrerVersionR
 			return "", fmt.Errorf("component %q from annotation does not match %q: %w", candidate, component, lister.ErrSkip)
 		}
 
+		if !filter(descriptor.Annotations) {
+			return "", lister.ErrSkip
+		}
+
 		return version, nil
 	}
 	return referrerResolver
@@ -58,12 +64,14 @@ func ReferrerAnnotationVersionResolver(component string) lister.ReferrerVersionR
 //   - Parse the provided reference
 //   - Resolve the tag to a descriptor
 //   - Validate the descriptor's media type
+//   - Skip the tag if the manifest annotations do not match all filters
 //   - Return the tag if valid, or an error if invalid
 func ReferenceTagVersionResolver(component string, store interface {
 	content.Resolver
 	content.Fetcher
-},
+}, filters ...annotations.Filter,
 ) lister.TagVersionResolver {
+	filter := annotations.MatchAll(filters...)
 	tagResolver := func(ctx context.Context, tag string) (string, error) {
 		// This resolve call's descriptor is a virtual descriptor. It will never have the annotation
 		// we are looking for, and that's why we do a second Fetch inside the switch to get the actual descriptor that
@@ -73,13 +81,16 @@ func ReferenceTagVersionResolver(component string, store interface {
 			return "", fmt.Errorf("failed to resolve tag %q: %w", tag, err)
 		}
 
-		version, err := validate.ComponentVersionDescriptor(ctx, store, desc, component, tag)
+		version, manifestAnnotations, err := validate.ComponentVersionAnnotations(ctx, store, desc, component, tag)
 		if err != nil {
 			if errors.Is(err, validate.ErrInvalidComponentVersion) {
 				return "", errors.Join(err, lister.ErrSkip)
 			}
 			return "", err
 		}
+		if !filter(manifestAnnotations) {
+			return "", fmt.Errorf("annotations of tag %q do not match: %w", tag, lister.ErrSkip)
+		}
 		return version, nil
 	}
 	return tagResolver
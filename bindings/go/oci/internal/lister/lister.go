@@ -3,6 +3,9 @@
 // 1. Referrer-based listing: Finds versions by examining referrers to a base component (requires OCI 1.1 compatible referrer subjects)
 // 2. Tag-based listing: Finds versions by examining repository tags and filtering by descriptor (this is the legacy behavior in old OCM)
 //
+// If both strategies fail or find no versions, the versions can be read from a component version index
+// as a last resort, which also works on registries that support neither referrers nor tag listing.
+//
 // The package supports different lookup and sorting policies to customize the version discovery process.
 package lister
 
@@ -58,6 +61,7 @@ type Options struct {
 
 	TagListerOptions
 	ReferrerListerOptions
+	VersionIndexListerOptions
 }
 
 // ReferrerListerOptions configures referrer-based version listing.
@@ -82,6 +86,15 @@ type TagListerOptions struct {
 	VersionResolver TagVersionResolver
 }
 
+// VersionIndexListerOptions configures listing from a component version index.
+type VersionIndexListerOptions struct {
+	// Manifests returns the manifests listed in the component version index.
+	// It returns nil without error if there is no index. If Manifests is nil, no index is used.
+	Manifests func(ctx context.Context) ([]ociImageSpecV1.Descriptor, error)
+	// VersionResolver converts a manifest descriptor of the index to a version string
+	VersionResolver ReferrerVersionResolver
+}
+
 // TagVersionResolver converts a tag to a version string.
 // Returns ErrSkip to exclude a tag from the results.
 type TagVersionResolver func(ctx context.Context, tag string) (string, error)
@@ -125,8 +138,29 @@ func (lister *Lister) List(ctx context.Context, opts Options) ([]string, error)
 }
 
 // listUnsorted discovers component versions without applying sorting.
-// The lookup strategy is determined by the LookupPolicy in opts.
+// The lookup strategy is determined by the LookupPolicy in opts. If it fails or finds no versions,
+// the component version index is consulted if configured.
 func (lister *Lister) listUnsorted(ctx context.Context, opts Options) ([]string, error) {
+	versions, err := lister.listUnsortedByPolicy(ctx, opts)
+	if (err == nil && len(versions) > 0) || opts.VersionIndexListerOptions.Manifests == nil {
+		return versions, err
+	}
+
+	indexed, found, ierr := listViaVersionIndex(ctx, opts.VersionIndexListerOptions)
+	switch {
+	case ierr != nil && err != nil:
+		return nil, errors.Join(err, ierr)
+	case ierr != nil:
+		slogcontext.FromCtx(ctx).With(slog.String("realm", "oci")).Log(ctx, slog.LevelDebug, "could not list versions via component version index", slog.String("error", ierr.Error()))
+		return versions, nil
+	case !found:
+		return versions, err
+	default:
+		return indexed, nil
+	}
+}
+
+func (lister *Lister) listUnsortedByPolicy(ctx context.Context, opts Options) ([]string, error) {
 	switch opts.LookupPolicy {
 	case LookupPolicyReferrerWithTagFallback:
 		tags, err := listViaReferrrers(ctx, lister.referrerLister, opts.ReferrerListerOptions)
@@ -275,3 +309,29 @@ func listViaTags(ctx context.Context, lister registry.TagLister, opts TagListerO
 
 	return versions, nil
 }
+
+// listViaVersionIndex discovers versions by examining the manifests of the component version index.
+// It reports whether an index was found.
+func listViaVersionIndex(ctx context.Context, opts VersionIndexListerOptions) (versions []string, found bool, err error) {
+	manifests, err := opts.Manifests(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read component version index: %w", err)
+	}
+	if manifests == nil {
+		return nil, false, nil
+	}
+	slogcontext.FromCtx(ctx).With(slog.String("realm", "oci")).Log(ctx, slog.LevelDebug, "listing component version index", slog.Int("count", len(manifests)))
+
+	for _, manifest := range manifests {
+		ver, err := opts.VersionResolver(ctx, manifest)
+		if errors.Is(err, ErrSkip) {
+			continue
+		}
+		if err != nil {
+			return nil, true, fmt.Errorf("error resolving version from component version index: %w", err)
+		}
+		versions = append(versions, ver)
+	}
+
+	return versions, true, nil
+}
@@ -292,4 +292,43 @@ func TestList(t *testing.T) {
 		assert.Contains(t, logOutput, "realm")
 		assert.Contains(t, logOutput, "oci")
 	})
+
+	t.Run("list via version index when tags and referrers fail", func(t *testing.T) {
+		store := &mockTagStore{err: errors.New("tag listing disabled")}
+
+		lister, err := New(store)
+		require.NoError(t, err)
+
+		resolver := func(ctx context.Context, desc v1.Descriptor) (string, error) {
+			if ver, ok := desc.Annotations["version"]; ok {
+				return ver, nil
+			}
+			return "", ErrSkip
+		}
+		opts := Options{
+			LookupPolicy: LookupPolicyReferrerWithTagFallback,
+			SortPolicy:   SortPolicyLooseSemverDescending,
+			VersionIndexListerOptions: VersionIndexListerOptions{
+				Manifests: func(ctx context.Context) ([]v1.Descriptor, error) {
+					return []v1.Descriptor{
+						{Digest: "sha256:abc", Annotations: map[string]string{"version": "v1.0.0"}},
+						{Digest: "sha256:def", Annotations: map[string]string{"version": "v2.0.0"}},
+						{Digest: "sha256:ghi"},
+					}, nil
+				},
+				VersionResolver: resolver,
+			},
+		}
+
+		versions, err := lister.List(t.Context(), opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v2.0.0", "v1.0.0"}, versions)
+
+		// without an index, the original error is returned.
+		opts.VersionIndexListerOptions.Manifests = func(ctx context.Context) ([]v1.Descriptor, error) {
+			return nil, nil
+		}
+		_, err = lister.List(t.Context(), opts)
+		assert.ErrorContains(t, err, "tag listing disabled")
+	})
 }
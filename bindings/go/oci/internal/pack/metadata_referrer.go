@@ -0,0 +1,57 @@
+package pack
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+	"ocm.software/open-component-model/bindings/go/repository"
+)
+
+// MetadataReferrer builds a metadata referrer - an OCI manifest with a subject
+// pointing at the manifest of a component version and a single layer holding
+// the metadata. It returns the manifest and the layer together with their
+// content. The manifest references [ociImageSpecV1.DescriptorEmptyJSON] as
+// config. The caller must push that blob and the layer before the manifest.
+func MetadataReferrer(subject ociImageSpecV1.Descriptor, component, version string, metadata repository.ComponentVersionMetadata) (manifestDesc ociImageSpecV1.Descriptor, manifestBody []byte, layerDesc ociImageSpecV1.Descriptor, layerBody []byte, err error) {
+	layerBody, err = json.Marshal(metadata)
+	if err != nil {
+		return manifestDesc, nil, layerDesc, nil, fmt.Errorf("failed to marshal component version metadata: %w", err)
+	}
+	layerDesc = ociImageSpecV1.Descriptor{
+		MediaType: annotations.MetadataMediaType,
+		Digest:    digest.FromBytes(layerBody),
+		Size:      int64(len(layerBody)),
+	}
+
+	manifest := ociImageSpecV1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ociImageSpecV1.MediaTypeImageManifest,
+		ArtifactType: annotations.MetadataArtifactType,
+		Config:       ociImageSpecV1.DescriptorEmptyJSON,
+		Layers:       []ociImageSpecV1.Descriptor{layerDesc},
+		Subject:      &subject,
+		Annotations: map[string]string{
+			annotations.OCMComponentVersion:  annotations.NewComponentVersionAnnotation(component, version),
+			annotations.MetadataName:         metadata.Name,
+			ociImageSpecV1.AnnotationCreated: metadata.CreatedAt.UTC().Format(time.RFC3339),
+		},
+	}
+	manifestBody, err = json.Marshal(manifest)
+	if err != nil {
+		return manifestDesc, nil, layerDesc, nil, fmt.Errorf("failed to marshal component version metadata referrer manifest: %w", err)
+	}
+
+	manifestDesc = ociImageSpecV1.Descriptor{
+		MediaType:    ociImageSpecV1.MediaTypeImageManifest,
+		ArtifactType: annotations.MetadataArtifactType,
+		Digest:       digest.FromBytes(manifestBody),
+		Size:         int64(len(manifestBody)),
+	}
+	return manifestDesc, manifestBody, layerDesc, layerBody, nil
+}
@@ -0,0 +1,56 @@
+package pack
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+)
+
+// SignatureReferrer builds a signature referrer - an OCI manifest with a subject
+// pointing at the manifest of a component version and a single layer holding
+// the signature in its v2 serialization. It returns the manifest and the layer
+// together with their content. The same signature always results in the same
+// manifest. The manifest references [ociImageSpecV1.DescriptorEmptyJSON] as
+// config. The caller must push that blob and the layer before the manifest.
+func SignatureReferrer(subject ociImageSpecV1.Descriptor, component, version string, signature descriptor.Signature) (manifestDesc ociImageSpecV1.Descriptor, manifestBody []byte, layerDesc ociImageSpecV1.Descriptor, layerBody []byte, err error) {
+	layerBody, err = json.Marshal(descriptor.ConvertToV2Signature(&signature))
+	if err != nil {
+		return manifestDesc, nil, layerDesc, nil, fmt.Errorf("failed to marshal component version signature: %w", err)
+	}
+	layerDesc = ociImageSpecV1.Descriptor{
+		MediaType: annotations.SignatureMediaType,
+		Digest:    digest.FromBytes(layerBody),
+		Size:      int64(len(layerBody)),
+	}
+
+	manifest := ociImageSpecV1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ociImageSpecV1.MediaTypeImageManifest,
+		ArtifactType: annotations.SignatureArtifactType,
+		Config:       ociImageSpecV1.DescriptorEmptyJSON,
+		Layers:       []ociImageSpecV1.Descriptor{layerDesc},
+		Subject:      &subject,
+		Annotations: map[string]string{
+			annotations.OCMComponentVersion: annotations.NewComponentVersionAnnotation(component, version),
+			annotations.SignatureName:       signature.Name,
+		},
+	}
+	manifestBody, err = json.Marshal(manifest)
+	if err != nil {
+		return manifestDesc, nil, layerDesc, nil, fmt.Errorf("failed to marshal component version signature referrer manifest: %w", err)
+	}
+
+	manifestDesc = ociImageSpecV1.Descriptor{
+		MediaType:    ociImageSpecV1.MediaTypeImageManifest,
+		ArtifactType: annotations.SignatureArtifactType,
+		Digest:       digest.FromBytes(manifestBody),
+		Size:         int64(len(manifestBody)),
+	}
+	return manifestDesc, manifestBody, layerDesc, layerBody, nil
+}
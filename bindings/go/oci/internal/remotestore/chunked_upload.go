@@ -0,0 +1,301 @@
+package remotestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	slogcontext "github.com/veqryn/slog-context"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"ocm.software/open-component-model/bindings/go/oci/resumable"
+)
+
+// DefaultChunkAttempts is the number of attempts to upload a single chunk of a chunked upload.
+// Between attempts, the upload session is queried for the acknowledged offset, so a retry
+// only sends the part of the chunk the registry did not receive yet.
+const DefaultChunkAttempts = 5
+
+// errUploadUnknown is returned if the registry does not know an upload session (anymore).
+var errUploadUnknown = errors.New("upload session unknown to registry")
+
+// manifestMediaTypes are never uploaded in chunks, as manifests cannot be pushed as blobs.
+var manifestMediaTypes = []string{
+	ociImageSpecV1.MediaTypeImageManifest,
+	ociImageSpecV1.MediaTypeImageIndex,
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+// Push pushes content to the repository.
+// If a ChunkSize is set, blobs of at least ChunkSize bytes are uploaded with the chunked upload
+// API of the OCI distribution specification, see pushChunked. All other content is pushed by
+// the wrapped repository.
+func (r *RemoteStore) Push(ctx context.Context, expected ociImageSpecV1.Descriptor, content io.Reader) error {
+	if r.ChunkSize <= 0 || expected.Size < r.ChunkSize || slices.Contains(manifestMediaTypes, expected.MediaType) {
+		return r.Repository.Push(ctx, expected, content)
+	}
+	return r.pushChunked(ctx, expected, content)
+}
+
+// pushChunked uploads a blob in chunks of ChunkSize bytes.
+// A chunk that fails is retried from the offset acknowledged by the registry. If the upload
+// cannot be completed, a *resumable.UploadInterruptedError with a token of the upload session
+// is returned. If that token is attached to the context of a later push of the same blob,
+// the session is continued at the acknowledged offset instead of uploading the blob again.
+func (r *RemoteStore) pushChunked(ctx context.Context, expected ociImageSpecV1.Descriptor, content io.Reader) error {
+	ctx = auth.AppendRepositoryScope(ctx, r.Reference, auth.ActionPull, auth.ActionPush)
+
+	session := r.resumeUpload(ctx, expected.Digest)
+	if session == nil {
+		var err error
+		if session, err = r.startUpload(ctx, expected.Digest); err != nil {
+			return fmt.Errorf("failed to start chunked upload of %s: %w", expected.Digest, err)
+		}
+	} else if err := skip(content, session.offset); err != nil {
+		return fmt.Errorf("failed to skip %d acknowledged bytes of %s: %w", session.offset, expected.Digest, err)
+	}
+
+	chunk := make([]byte, min(r.ChunkSize, expected.Size))
+	for session.offset < expected.Size {
+		n, err := io.ReadFull(content, chunk[:min(r.ChunkSize, expected.Size-session.offset)])
+		if err != nil {
+			return session.interrupted(fmt.Errorf("failed to read content: %w", err))
+		}
+		if err := session.uploadChunk(ctx, chunk[:n]); err != nil {
+			return session.interrupted(err)
+		}
+	}
+	if err := session.complete(ctx); err != nil {
+		return session.interrupted(err)
+	}
+	return nil
+}
+
+// resumeUpload returns the upload session of the upload token in the context, or nil if there is
+// none or the registry does not know the session anymore.
+func (r *RemoteStore) resumeUpload(ctx context.Context, dgst digest.Digest) *uploadSession {
+	token, ok := resumable.UploadTokenFromContext(ctx, dgst)
+	if !ok {
+		return nil
+	}
+	location, err := url.Parse(token.Location)
+	if err != nil {
+		slogcontext.Log(ctx, slog.LevelWarn, "ignoring invalid upload token", slog.String("digest", dgst.String()), slog.Any("error", err))
+		return nil
+	}
+	session := &uploadSession{store: r, digest: dgst, location: location}
+	if err := session.status(ctx); err != nil {
+		slogcontext.Log(ctx, slog.LevelInfo, "cannot resume chunked upload, restarting",
+			slog.String("digest", dgst.String()), slog.Any("error", err))
+		return nil
+	}
+	slogcontext.Log(ctx, slog.LevelInfo, "resuming chunked upload",
+		slog.String("digest", dgst.String()), slog.Int64("offset", session.offset))
+	return session
+}
+
+// startUpload starts a new upload session.
+func (r *RemoteStore) startUpload(ctx context.Context, dgst digest.Digest) (*uploadSession, error) {
+	endpoint := r.endpoint("blobs", "uploads")
+	endpoint.Path += "/"
+	session := &uploadSession{store: r, digest: dgst, location: endpoint}
+	resp, err := session.do(ctx, http.MethodPost, session.location, nil, nil, http.StatusAccepted)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.update(resp, 0); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// endpoint returns the URL of the given path elements below the API endpoint of the repository.
+func (r *RemoteStore) endpoint(elem ...string) *url.URL {
+	scheme := "https"
+	if r.PlainHTTP {
+		scheme = "http"
+	}
+	return &url.URL{
+		Scheme: scheme,
+		Host:   r.Reference.Host(),
+		Path:   path.Join(append([]string{"/v2", r.Reference.Repository}, elem...)...),
+	}
+}
+
+func (r *RemoteStore) client() remote.Client {
+	if r.Client == nil {
+		return auth.DefaultClient
+	}
+	return r.Client
+}
+
+// uploadSession is an upload session of a blob in the registry.
+type uploadSession struct {
+	store    *RemoteStore
+	digest   digest.Digest
+	location *url.URL
+	// offset is the number of bytes acknowledged by the registry.
+	offset int64
+}
+
+// uploadChunk uploads chunk, which has to start at the current offset of the session.
+func (s *uploadSession) uploadChunk(ctx context.Context, chunk []byte) error {
+	start, end := s.offset, s.offset+int64(len(chunk))
+	for attempt := 1; ; attempt++ {
+		data := chunk[s.offset-start:]
+		header := http.Header{
+			"Content-Type":  []string{"application/octet-stream"},
+			"Content-Range": []string{fmt.Sprintf("%d-%d", s.offset, end-1)},
+		}
+		resp, err := s.do(ctx, http.MethodPatch, s.location, data, header, http.StatusAccepted)
+		if err == nil {
+			return s.update(resp, end)
+		}
+		if attempt >= DefaultChunkAttempts || ctx.Err() != nil {
+			return err
+		}
+		slogcontext.Log(ctx, slog.LevelWarn, "chunk upload interrupted",
+			slog.String("digest", s.digest.String()),
+			slog.Int64("offset", s.offset),
+			slog.Int("attempt", attempt),
+			slog.Any("error", err))
+		if serr := s.status(ctx); serr != nil {
+			return errors.Join(err, serr)
+		}
+		if s.offset < start || s.offset > end {
+			return fmt.Errorf("registry acknowledged offset %d outside of the chunk %d-%d", s.offset, start, end-1)
+		}
+		if s.offset == end {
+			return nil
+		}
+	}
+}
+
+// status updates the session from the upload status reported by the registry.
+func (s *uploadSession) status(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodGet, s.location, nil, nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	return s.update(resp, 0)
+}
+
+// complete finishes the upload session.
+func (s *uploadSession) complete(ctx context.Context) error {
+	location := *s.location
+	query := location.Query()
+	query.Set("digest", s.digest.String())
+	location.RawQuery = query.Encode()
+	_, err := s.do(ctx, http.MethodPut, &location, nil, nil, http.StatusCreated)
+	return err
+}
+
+// update updates the location and offset of the session from a response of the registry.
+// If the response carries no or an ambiguous Range header, the offset is set to fallback.
+func (s *uploadSession) update(resp *http.Response, fallback int64) error {
+	if location := resp.Header.Get("Location"); location != "" {
+		next, err := s.location.Parse(location)
+		if err != nil {
+			return fmt.Errorf("invalid upload location %q: %w", location, err)
+		}
+		s.location = next
+	}
+	s.offset = fallback
+	if rng := resp.Header.Get("Range"); rng != "" {
+		offset, err := parseRange(rng)
+		if err != nil {
+			return err
+		}
+		if offset > 0 {
+			s.offset = offset
+		}
+	}
+	return nil
+}
+
+// do sends a request to location and expects the given status code.
+// The body of the response is discarded.
+func (s *uploadSession) do(ctx context.Context, method string, location *url.URL, body []byte, header http.Header, expected int) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, location.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := s.store.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	switch resp.StatusCode {
+	case expected:
+		return resp, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s %s: %w", method, location.Redacted(), errUploadUnknown)
+	default:
+		return nil, responseError(resp)
+	}
+}
+
+// interrupted returns an error carrying a token to resume the upload session.
+func (s *uploadSession) interrupted(err error) error {
+	return &resumable.UploadInterruptedError{
+		Token: resumable.UploadToken{
+			Digest:   s.digest,
+			Location: s.location.String(),
+			Offset:   s.offset,
+		},
+		Err: err,
+	}
+}
+
+// parseRange parses the Range header of an upload session ("0-<last byte>") into the acknowledged offset.
+// As registries report "0-0" both for sessions without content and with a single byte, 0 is returned for it.
+func parseRange(rng string) (int64, error) {
+	_, last, ok := strings.Cut(strings.TrimPrefix(rng, "bytes="), "-")
+	if !ok {
+		return 0, fmt.Errorf("invalid upload range %q", rng)
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid upload range %q: %w", rng, err)
+	}
+	if end <= 0 {
+		return 0, nil
+	}
+	return end + 1, nil
+}
+
+// skip discards the first n bytes of content.
+func skip(content io.Reader, n int64) error {
+	if n == 0 {
+		return nil
+	}
+	if seeker, ok := content.(io.Seeker); ok {
+		_, err := seeker.Seek(n, io.SeekCurrent)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, content, n)
+	return err
+}
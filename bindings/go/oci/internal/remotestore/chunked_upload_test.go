@@ -0,0 +1,191 @@
+package remotestore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"ocm.software/open-component-model/bindings/go/oci/resumable"
+)
+
+const uploadsPath = "/v2/test-repo/blobs/uploads/"
+
+// chunkedRegistry implements the blob upload API of the OCI distribution specification for a single repository.
+type chunkedRegistry struct {
+	mu       sync.Mutex
+	sessions map[string][]byte
+	blobs    map[digest.Digest][]byte
+	// failPatches is the number of upcoming PATCH requests that fail after storing half of their content.
+	failPatches int
+	// patched records the bytes received by PATCH requests.
+	patched int
+}
+
+func newChunkedRegistry(t *testing.T) (*chunkedRegistry, *RemoteStore) {
+	reg := &chunkedRegistry{
+		sessions: map[string][]byte{},
+		blobs:    map[digest.Digest][]byte{},
+	}
+	srv := httptest.NewServer(reg)
+	t.Cleanup(srv.Close)
+
+	repo, err := remote.NewRepository(srv.Listener.Addr().String() + "/test-repo")
+	require.NoError(t, err)
+	repo.PlainHTTP = true
+	repo.Client = &http.Client{}
+	return reg, &RemoteStore{Repository: repo}
+}
+
+func (reg *chunkedRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if req.Method == http.MethodPost && req.URL.Path == uploadsPath {
+		id := fmt.Sprint(len(reg.sessions))
+		reg.sessions[id] = nil
+		w.Header().Set("Location", uploadsPath+id)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	id := strings.TrimPrefix(req.URL.Path, uploadsPath)
+	data, ok := reg.sessions[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeStatus := func(status int) {
+		w.Header().Set("Location", uploadsPath+id)
+		w.Header().Set("Range", fmt.Sprintf("0-%d", max(len(reg.sessions[id])-1, 0)))
+		w.WriteHeader(status)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		writeStatus(http.StatusNoContent)
+	case http.MethodPatch:
+		if req.Header.Get("Content-Range") != fmt.Sprintf("%d-%d", len(data), len(data)+len(body)-1) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if reg.failPatches > 0 {
+			reg.failPatches--
+			body = body[:len(body)/2]
+			reg.sessions[id] = append(data, body...)
+			reg.patched += len(body)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		reg.sessions[id] = append(data, body...)
+		reg.patched += len(body)
+		writeStatus(http.StatusAccepted)
+	case http.MethodPut:
+		data = append(data, body...)
+		dgst := digest.Digest(req.URL.Query().Get("digest"))
+		if dgst != digest.FromBytes(data) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reg.blobs[dgst] = data
+		delete(reg.sessions, id)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newUploadBlob(t *testing.T, size int) ([]byte, ociImageSpecV1.Descriptor) {
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	return data, content.NewDescriptorFromBytes("application/octet-stream", data)
+}
+
+func TestRemoteStore_PushChunked(t *testing.T) {
+	t.Run("uploads large blobs in chunks", func(t *testing.T) {
+		r := require.New(t)
+		reg, store := newChunkedRegistry(t)
+		store.ChunkSize = 4096
+		data, desc := newUploadBlob(t, 10000)
+
+		r.NoError(store.Push(t.Context(), desc, bytes.NewReader(data)))
+		r.Equal(data, reg.blobs[desc.Digest])
+		r.Equal(len(data), reg.patched)
+	})
+
+	t.Run("uploads small blobs in a single request", func(t *testing.T) {
+		r := require.New(t)
+		reg, store := newChunkedRegistry(t)
+		store.ChunkSize = 4096
+		data, desc := newUploadBlob(t, 1000)
+
+		r.NoError(store.Push(t.Context(), desc, bytes.NewReader(data)))
+		r.Equal(data, reg.blobs[desc.Digest])
+		r.Zero(reg.patched)
+	})
+
+	t.Run("retries chunks from the acknowledged offset", func(t *testing.T) {
+		r := require.New(t)
+		reg, store := newChunkedRegistry(t)
+		store.ChunkSize = 4096
+		reg.failPatches = 2
+		data, desc := newUploadBlob(t, 10000)
+
+		r.NoError(store.Push(t.Context(), desc, bytes.NewReader(data)))
+		r.Equal(data, reg.blobs[desc.Digest])
+		r.Equal(len(data), reg.patched, "no byte should be sent twice")
+	})
+
+	t.Run("resumes interrupted uploads with the upload token", func(t *testing.T) {
+		r := require.New(t)
+		reg, store := newChunkedRegistry(t)
+		store.ChunkSize = 4096
+		reg.failPatches = DefaultChunkAttempts
+		data, desc := newUploadBlob(t, 10000)
+
+		err := store.Push(t.Context(), desc, bytes.NewReader(data))
+		var interrupted *resumable.UploadInterruptedError
+		r.ErrorAs(err, &interrupted)
+		r.Equal(desc.Digest, interrupted.Token.Digest)
+		r.Positive(interrupted.Token.Offset)
+		r.LessOrEqual(interrupted.Token.Offset, int64(reg.patched))
+		r.Empty(reg.blobs)
+
+		ctx := resumable.ContextWithUploadToken(t.Context(), interrupted.Token)
+		r.NoError(store.Push(ctx, desc, bytes.NewReader(data)))
+		r.Equal(data, reg.blobs[desc.Digest])
+		r.Equal(len(data), reg.patched, "acknowledged bytes should not be sent again")
+	})
+
+	t.Run("restarts uploads with an unknown upload token", func(t *testing.T) {
+		r := require.New(t)
+		reg, store := newChunkedRegistry(t)
+		store.ChunkSize = 4096
+		data, desc := newUploadBlob(t, 10000)
+
+		ctx := resumable.ContextWithUploadToken(t.Context(), resumable.UploadToken{
+			Digest:   desc.Digest,
+			Location: "http://" + store.Reference.Host() + uploadsPath + "unknown",
+			Offset:   4096,
+		})
+		r.NoError(store.Push(ctx, desc, bytes.NewReader(data)))
+		r.Equal(data, reg.blobs[desc.Digest])
+		r.Equal(len(data), reg.patched)
+	})
+}
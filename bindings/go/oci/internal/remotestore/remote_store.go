@@ -3,11 +3,10 @@ package remotestore
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
-	"net/url"
-	"path"
 
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/errdef"
@@ -16,12 +15,13 @@ import (
 	"oras.land/oras-go/v2/registry/remote/errcode"
 
 	"ocm.software/open-component-model/bindings/go/oci/spec"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // ErrTagDeletionDisabled is returned when the registry responds with 405 Method Not Allowed
 // to a tag-deletion request, indicating that the registry does not support tag deletion
 // (e.g. REGISTRY_STORAGE_DELETE_ENABLED is not set).
-var ErrTagDeletionDisabled = fmt.Errorf("registry does not support tag deletion (405 Method Not Allowed)")
+var ErrTagDeletionDisabled = ocmerrors.Unsupported(errors.New("registry does not support tag deletion (405 Method Not Allowed)"))
 
 // RemoteStore wraps *remote.Repository and adds content.Untagger support.
 //
@@ -29,8 +29,15 @@ var ErrTagDeletionDisabled = fmt.Errorf("registry does not support tag deletion
 // (content/oci.Store). The remote registry client (registry/remote.Repository)
 // intentionally omits it: the OCI Distribution Spec treats
 // DELETE /v2/<name>/manifests/<tag> as optional, and not all registries honor it.
+//
+// RemoteStore can also upload large blobs with the chunked upload API, see ChunkSize.
 type RemoteStore struct {
 	*remote.Repository
+
+	// ChunkSize is the size of the chunks in which blobs of at least ChunkSize bytes are uploaded.
+	// Chunked uploads can be resumed after an interruption, see RemoteStore.Push.
+	// If ChunkSize is 0, blobs are uploaded in a single request.
+	ChunkSize int64
 }
 
 var (
@@ -48,26 +55,14 @@ func (r *RemoteStore) Untag(ctx context.Context, reference string) error {
 	}
 	ctx = auth.AppendRepositoryScope(ctx, ref, auth.ActionDelete)
 
-	scheme := "https"
-	if r.PlainHTTP {
-		scheme = "http"
-	}
-	endpoint := &url.URL{
-		Scheme: scheme,
-		Host:   ref.Host(),
-		Path:   path.Join("/v2", ref.Repository, "manifests", reference),
-	}
+	endpoint := r.endpoint("manifests", reference)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint.String(), http.NoBody)
 	if err != nil {
 		return fmt.Errorf("failed to build delete request for alias %q: %w", reference, err)
 	}
 
-	client := r.Client
-	if client == nil {
-		client = auth.DefaultClient
-	}
-	resp, err := client.Do(req)
+	resp, err := r.client().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to delete alias %q: %w", reference, err)
 	}
@@ -85,17 +80,22 @@ func (r *RemoteStore) Untag(ctx context.Context, reference string) error {
 	case http.StatusMethodNotAllowed:
 		return ErrTagDeletionDisabled
 	default:
-		errResp := &errcode.ErrorResponse{
-			Method:     resp.Request.Method,
-			URL:        resp.Request.URL,
-			StatusCode: resp.StatusCode,
-		}
-		var body struct {
-			Errors errcode.Errors `json:"errors"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&body); err == nil {
-			errResp.Errors = body.Errors
-		}
-		return errResp
+		return responseError(resp)
+	}
+}
+
+// responseError returns the error of an unexpected response of the registry.
+func responseError(resp *http.Response) error {
+	errResp := &errcode.ErrorResponse{
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL,
+		StatusCode: resp.StatusCode,
+	}
+	var body struct {
+		Errors errcode.Errors `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil {
+		errResp.Errors = body.Errors
 	}
+	return errResp
 }
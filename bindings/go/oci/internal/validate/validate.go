@@ -39,6 +39,20 @@ func ComponentVersionDescriptor(
 	component,
 	ref string,
 ) (string, error) {
+	version, _, err := ComponentVersionAnnotations(ctx, fetcher, desc, component, ref)
+	return version, err
+}
+
+// ComponentVersionAnnotations is like [ComponentVersionDescriptor], but additionally returns the
+// annotations of the manifest or index, so they can be inspected without fetching the descriptor again.
+// Legacy manifests are returned without annotations.
+func ComponentVersionAnnotations(
+	ctx context.Context,
+	fetcher content.Fetcher,
+	desc ociImageSpecV1.Descriptor,
+	component,
+	ref string,
+) (string, map[string]string, error) {
 	var (
 		manifestAnnotations    map[string]string
 		data                   io.ReadCloser
@@ -49,11 +63,11 @@ func ComponentVersionDescriptor(
 	case ociImageSpecV1.MediaTypeImageManifest:
 		data, err = fetcher.Fetch(ctx, desc)
 		if err != nil {
-			return "", fmt.Errorf("failed to fetch descriptor for reference %q: %w", ref, err)
+			return "", nil, fmt.Errorf("failed to fetch descriptor for reference %q: %w", ref, err)
 		}
 		var manifest ociImageSpecV1.Manifest
 		if err := json.NewDecoder(data).Decode(&manifest); err != nil {
-			return "", errors.Join(fmt.Errorf("failed to decode manifest for reference %q: %w", ref, err), data.Close())
+			return "", nil, errors.Join(fmt.Errorf("failed to decode manifest for reference %q: %w", ref, err), data.Close())
 		}
 		manifestAnnotations = manifest.Annotations
 		// Checks for old component versions pre-2024 which didn't have an annotation.
@@ -67,32 +81,32 @@ func ComponentVersionDescriptor(
 	case ociImageSpecV1.MediaTypeImageIndex:
 		data, err = fetcher.Fetch(ctx, desc)
 		if err != nil {
-			return "", fmt.Errorf("failed to fetch descriptor for reference %q: %w", ref, err)
+			return "", nil, fmt.Errorf("failed to fetch descriptor for reference %q: %w", ref, err)
 		}
 		var index ociImageSpecV1.Index
 		if err := json.NewDecoder(data).Decode(&index); err != nil {
-			return "", errors.Join(fmt.Errorf("failed to decode index for reference %q: %w", ref, err), data.Close())
+			return "", nil, errors.Join(fmt.Errorf("failed to decode index for reference %q: %w", ref, err), data.Close())
 		}
 		manifestAnnotations = index.Annotations
 
 	default:
-		return "", fmt.Errorf("unsupported media type %q for reference %q: %w", desc.MediaType, ref, ErrInvalidComponentVersion)
+		return "", nil, fmt.Errorf("unsupported media type %q for reference %q: %w", desc.MediaType, ref, ErrInvalidComponentVersion)
 	}
 	if err = data.Close(); err != nil {
-		return "", fmt.Errorf("failed to close descriptor reader for reference %q: %w", ref, err)
+		return "", nil, fmt.Errorf("failed to close descriptor reader for reference %q: %w", ref, err)
 	}
 	annotation, ok := manifestAnnotations[annotations.OCMComponentVersion]
 	if !ok {
 		if oldOCMComponentVersion {
-			return ref, nil
+			return ref, nil, nil
 		}
 
-		return "", fmt.Errorf("failed to find %q annotation for tag %q: %w", annotations.OCMComponentVersion, ref, ErrInvalidComponentVersion)
+		return "", nil, fmt.Errorf("failed to find %q annotation for tag %q: %w", annotations.OCMComponentVersion, ref, ErrInvalidComponentVersion)
 	}
 
 	candidate, version, err := annotations.ParseComponentVersionAnnotation(annotation)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse component version annotation: %w", err)
+		return "", nil, fmt.Errorf("failed to parse component version annotation: %w", err)
 	}
 
 	if strings.HasPrefix(component, path.DefaultComponentDescriptorPath+"/") {
@@ -100,8 +114,8 @@ func ComponentVersionDescriptor(
 	}
 
 	if candidate != component {
-		return "", fmt.Errorf("component %q from annotation does not match %q: %w", candidate, component, ErrInvalidComponentVersion)
+		return "", nil, fmt.Errorf("component %q from annotation does not match %q: %w", candidate, component, ErrInvalidComponentVersion)
 	}
 
-	return version, nil
+	return version, manifestAnnotations, nil
 }
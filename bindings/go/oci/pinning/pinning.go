@@ -0,0 +1,306 @@
+// Package pinning enforces that OCI artifact accesses reference their content by digest.
+//
+// An ociArtifact access that only references a tag, e.g. "ghcr.io/acme/app:1.0.0", is mutable: the tag can be
+// moved to a different artifact after the component version was signed, and the resource digest is the only
+// thing binding the access to the signed content. A [Policy] decides how consumers treat such accesses:
+//
+//   - [PolicyEnforce] refuses them with an [UnpinnedError],
+//   - [PolicyResolve] resolves their tag against the registry with a [Resolver] and pins them to the digest of
+//     the artifact, e.g. "ghcr.io/acme/app:1.0.0@sha256:...". The original reference is recorded in the
+//     non-signing resource label [LabelName].
+//
+// Accesses and non-signing labels are not part of the normalised descriptor, so pinning an access does not
+// invalidate the signatures of the component version. The resource digest is covered by the signatures though:
+// an access that resolves or is pinned to a different digest than the one of the resource is refused, see [Verify].
+package pinning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	internaldigest "ocm.software/open-component-model/bindings/go/oci/internal/digest"
+	"ocm.software/open-component-model/bindings/go/oci/looseref"
+	ociaccess "ocm.software/open-component-model/bindings/go/oci/spec/access"
+	accessv1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+const (
+	// LabelName is the name of the resource label recording the original reference of a pinned access.
+	LabelName = "ocm.software/oci/pinned-digest"
+	// LabelVersion is the version of the value of the label.
+	LabelVersion = "v1"
+)
+
+var (
+	// ErrUnpinned is returned (wrapped in an [*UnpinnedError]) if an OCI image reference is not pinned to a digest.
+	ErrUnpinned = errors.New("OCI image reference is not pinned to a digest")
+	// ErrDigestMismatch is returned if an OCI image access is pinned to a different digest than the one of its resource.
+	ErrDigestMismatch = errors.New("pinned digest does not match the resource digest")
+)
+
+// Resolver resolves the tag of an OCI image reference to the digest of the artifact it currently references
+// in its registry.
+type Resolver interface {
+	ResolveDigest(ctx context.Context, imageReference string) (digest.Digest, error)
+}
+
+// ResolverFunc adapts a function to the [Resolver] interface.
+type ResolverFunc func(ctx context.Context, imageReference string) (digest.Digest, error)
+
+// ResolveDigest calls the underlying function.
+func (f ResolverFunc) ResolveDigest(ctx context.Context, imageReference string) (digest.Digest, error) {
+	return f(ctx, imageReference)
+}
+
+// Policy defines how OCI image accesses that are not pinned to a digest are treated.
+type Policy int
+
+const (
+	// PolicyNone accepts accesses that are not pinned to a digest.
+	// This is the default (zero value).
+	PolicyNone Policy = iota
+	// PolicyEnforce refuses accesses that are not pinned to a digest.
+	PolicyEnforce
+	// PolicyResolve pins accesses that are not pinned to a digest to the digest their tag resolves to
+	// in the registry and records the original reference in the label LabelName.
+	PolicyResolve
+)
+
+func (p Policy) String() string {
+	switch p {
+	case PolicyNone:
+		return "none"
+	case PolicyEnforce:
+		return "enforce"
+	case PolicyResolve:
+		return "resolve"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(p))
+	}
+}
+
+// UnpinnedError is returned if an OCI image reference is not pinned to a digest.
+// It matches ErrUnpinned and runtime/errors.ErrPreconditionFailed with [errors.Is].
+type UnpinnedError struct {
+	// ImageReference is the reference that is not pinned.
+	ImageReference string
+	// Reason explains why the reference could not be pinned, if it was attempted.
+	Reason string
+}
+
+func (e *UnpinnedError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("image reference %q is not pinned to a digest: %s", e.ImageReference, e.Reason)
+	}
+	return fmt.Sprintf("image reference %q is not pinned to a digest", e.ImageReference)
+}
+
+func (e *UnpinnedError) Is(target error) bool {
+	return target == ErrUnpinned || target == ocmerrors.ErrPreconditionFailed
+}
+
+// Record is the original reference of a pinned access, kept in the label [LabelName] of the resource.
+type Record struct {
+	// ImageReference is the image reference of the access before it was pinned.
+	ImageReference string `json:"imageReference"`
+	// Digest is the digest the access was pinned to.
+	Digest string `json:"digest"`
+}
+
+// Label returns the label recording r.
+func (r Record) Label() (descriptor.Label, error) {
+	value, err := json.Marshal(r)
+	if err != nil {
+		return descriptor.Label{}, fmt.Errorf("failed to encode label %s: %w", LabelName, err)
+	}
+	return descriptor.Label{Name: LabelName, Value: value, Version: LabelVersion}, nil
+}
+
+// GetRecord returns the record kept in the label [LabelName], or nil if the labels do not contain it.
+func GetRecord(labels []descriptor.Label) (*Record, error) {
+	for _, label := range labels {
+		if label.Name != LabelName {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(label.Value, &record); err != nil {
+			return nil, fmt.Errorf("failed to decode label %s: %w", LabelName, err)
+		}
+		return &record, nil
+	}
+	return nil, nil
+}
+
+// Digest returns the digest imageReference is pinned to, or an empty digest if it only references a tag.
+func Digest(imageReference string) (digest.Digest, error) {
+	ref, err := looseref.ParseReference(imageReference)
+	if err != nil {
+		return "", fmt.Errorf("invalid OCI image reference %q: %w", imageReference, err)
+	}
+	if ref.ValidateReferenceAsDigest() != nil {
+		return "", nil
+	}
+	return ref.Digest()
+}
+
+// Check returns an [*UnpinnedError] if imageReference is not pinned to a digest.
+func Check(imageReference string) error {
+	dig, err := Digest(imageReference)
+	if err != nil {
+		return err
+	}
+	if dig == "" {
+		return &UnpinnedError{ImageReference: imageReference}
+	}
+	return nil
+}
+
+// Pin returns imageReference pinned to dig. The tag of the reference is kept,
+// e.g. "ghcr.io/acme/app:1.0.0" becomes "ghcr.io/acme/app:1.0.0@sha256:...".
+// It fails if imageReference is already pinned to a different digest.
+func Pin(imageReference string, dig digest.Digest) (string, error) {
+	if err := dig.Validate(); err != nil {
+		return "", fmt.Errorf("invalid digest %q: %w", dig, err)
+	}
+	ref, err := looseref.ParseReference(imageReference)
+	if err != nil {
+		return "", fmt.Errorf("invalid OCI image reference %q: %w", imageReference, err)
+	}
+	if ref.ValidateReferenceAsDigest() == nil && ref.Reference.Reference != dig.String() {
+		return "", fmt.Errorf("image reference %q is already pinned to a different digest than %s", imageReference, dig)
+	}
+	ref.Reference.Reference = dig.String()
+	return ref.String(), nil
+}
+
+// ResourceDigest returns the digest of the OCI artifact described by the digest of res, which is the
+// manifest digest for OCI artifacts. It returns an empty digest if the resource has no digest of an OCI artifact.
+func ResourceDigest(res *descriptor.Resource) digest.Digest {
+	if res.Digest == nil || res.Digest.Value == "" || res.Digest.NormalisationAlgorithm != "genericBlobDigest/v1" {
+		return ""
+	}
+	algorithm, ok := internaldigest.SHAMapping[res.Digest.HashAlgorithm]
+	if !ok {
+		return ""
+	}
+	return digest.NewDigestFromEncoded(algorithm, res.Digest.Value)
+}
+
+// Apply applies policy to res if it has an OCI image access and returns the resulting resource.
+// Resources with other accesses are returned unchanged.
+//
+// With [PolicyEnforce], an access that is not pinned is refused with an [*UnpinnedError].
+// With [PolicyResolve], the tag of such an access is resolved against the registry with resolver, and the access
+// of the returned copy of res is pinned to the resolved digest. The original reference is recorded in the label
+// [LabelName]. If the tag no longer references the artifact described by the resource digest, e.g. because it was
+// moved after the component version was signed, the access is refused with an [*UnpinnedError].
+// With both policies, accesses that are pinned to a different digest than the one of the resource are refused,
+// see [Verify].
+func Apply(ctx context.Context, res *descriptor.Resource, policy Policy, resolver Resolver) (*descriptor.Resource, error) {
+	if policy == PolicyNone {
+		return res, nil
+	}
+	access, err := imageAccess(res)
+	if err != nil || access == nil {
+		return res, err
+	}
+
+	dig, err := Digest(access.ImageReference)
+	if err != nil {
+		return nil, err
+	}
+	if dig != "" {
+		return res, Verify(res)
+	}
+	switch policy {
+	case PolicyEnforce:
+		return nil, &UnpinnedError{ImageReference: access.ImageReference}
+	case PolicyResolve:
+		if resolver == nil {
+			return nil, &UnpinnedError{ImageReference: access.ImageReference, Reason: "no resolver for its tag is configured"}
+		}
+		if dig, err = resolver.ResolveDigest(ctx, access.ImageReference); err != nil {
+			return nil, fmt.Errorf("failed to resolve the tag of image reference %q: %w", access.ImageReference, err)
+		}
+		if expected := ResourceDigest(res); expected != "" && expected != dig {
+			return nil, &UnpinnedError{ImageReference: access.ImageReference,
+				Reason: fmt.Sprintf("its tag references %s, but the digest of resource %s is %s", dig, res.ToIdentity(), expected)}
+		}
+		return PinResource(res, access, dig)
+	default:
+		return nil, fmt.Errorf("unknown digest pinning policy %s", policy)
+	}
+}
+
+// Verify checks that the OCI image access of res references the artifact described by the resource digest,
+// which is covered by the signatures of the component version. An access pinned to a digest has to be pinned
+// to the digest of the resource, and the digest recorded in the label [LabelName] has to be the one the access
+// is pinned to. Accesses that only reference a tag, resources with other accesses and resources without a
+// digest of an OCI artifact are not checked.
+func Verify(res *descriptor.Resource) error {
+	access, err := imageAccess(res)
+	if err != nil || access == nil {
+		return err
+	}
+	dig, err := Digest(access.ImageReference)
+	if err != nil || dig == "" {
+		return err
+	}
+	if expected := ResourceDigest(res); expected != "" && expected != dig {
+		return fmt.Errorf("%w: image reference %q of resource %s, resource digest %s", ErrDigestMismatch, access.ImageReference, res.ToIdentity(), expected)
+	}
+	record, err := GetRecord(res.Labels)
+	if err != nil {
+		return err
+	}
+	if record != nil && record.Digest != dig.String() {
+		return fmt.Errorf("%w: image reference %q of resource %s was recorded as pinned to %s", ErrDigestMismatch, access.ImageReference, res.ToIdentity(), record.Digest)
+	}
+	return nil
+}
+
+// imageAccess returns the OCI image access of res, or nil if res has a different access.
+func imageAccess(res *descriptor.Resource) (*accessv1.OCIImage, error) {
+	if res.Access == nil || !ociaccess.Scheme.IsRegistered(res.Access.GetType()) {
+		return nil, nil
+	}
+	typed, err := ociaccess.Scheme.NewObjectFrom(res.Access)
+	if err != nil {
+		return nil, fmt.Errorf("error converting access: %w", err)
+	}
+	// OCI image layers are referenced by digest anyway.
+	access, _ := typed.(*accessv1.OCIImage)
+	return access, nil
+}
+
+// PinResource returns a copy of res with its access pinned to dig and the original reference of the access recorded
+// in the label [LabelName].
+func PinResource(res *descriptor.Resource, access *accessv1.OCIImage, dig digest.Digest) (*descriptor.Resource, error) {
+	pinned, err := Pin(access.ImageReference, dig)
+	if err != nil {
+		return nil, err
+	}
+	label, err := Record{ImageReference: access.ImageReference, Digest: dig.String()}.Label()
+	if err != nil {
+		return nil, err
+	}
+
+	res = res.DeepCopy()
+	access = access.DeepCopy()
+	access.ImageReference = pinned
+	res.Access = access
+	for i := range res.Labels {
+		if res.Labels[i].Name == LabelName {
+			res.Labels[i] = label
+			return res, nil
+		}
+	}
+	res.Labels = append(res.Labels, label)
+	return res, nil
+}
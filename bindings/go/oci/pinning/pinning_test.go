@@ -0,0 +1,172 @@
+package pinning_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
+	accessv1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+var (
+	dig   = digest.FromString("signed")
+	other = digest.FromString("other")
+)
+
+func TestPin(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		expected  string
+		err       string
+	}{
+		{name: "tag", reference: "ghcr.io/acme/app:1.0.0", expected: "ghcr.io/acme/app:1.0.0@" + dig.String()},
+		{name: "same digest", reference: "ghcr.io/acme/app:1.0.0@" + dig.String(), expected: "ghcr.io/acme/app:1.0.0@" + dig.String()},
+		{name: "different digest", reference: "ghcr.io/acme/app@" + other.String(), err: "already pinned to a different digest"},
+		{name: "invalid reference", reference: "ghcr.io/acme/APP:1.0.0", err: "invalid OCI image reference"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			pinned, err := pinning.Pin(tt.reference, dig)
+			if tt.err != "" {
+				r.ErrorContains(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.expected, pinned)
+			r.NoError(pinning.Check(pinned))
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	r := require.New(t)
+	err := pinning.Check("ghcr.io/acme/app:1.0.0")
+	r.ErrorIs(err, pinning.ErrUnpinned)
+	r.ErrorIs(err, ocmerrors.ErrPreconditionFailed)
+	r.NoError(pinning.Check("ghcr.io/acme/app@" + dig.String()))
+}
+
+func TestApply(t *testing.T) {
+	resource := func(access runtime.Typed, withDigest bool) *descriptor.Resource {
+		res := &descriptor.Resource{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "app", Version: "1.0.0"}},
+			Type:        "ociArtifact",
+			Access:      access,
+		}
+		if withDigest {
+			res.Digest = &descriptor.Digest{
+				HashAlgorithm:          "SHA-256",
+				NormalisationAlgorithm: "genericBlobDigest/v1",
+				Value:                  dig.Encoded(),
+			}
+		}
+		return res
+	}
+	image := func(reference string) *accessv1.OCIImage {
+		return &accessv1.OCIImage{
+			Type:           runtime.NewVersionedType(accessv1.LegacyType, accessv1.LegacyTypeVersion),
+			ImageReference: reference,
+		}
+	}
+
+	t.Run("none accepts tags", func(t *testing.T) {
+		r := require.New(t)
+		res := resource(image("ghcr.io/acme/app:1.0.0"), true)
+		applied, err := pinning.Apply(t.Context(), res, pinning.PolicyNone, nil)
+		r.NoError(err)
+		r.Same(res, applied)
+	})
+
+	t.Run("enforce refuses tags", func(t *testing.T) {
+		r := require.New(t)
+		_, err := pinning.Apply(t.Context(), resource(image("ghcr.io/acme/app:1.0.0"), true), pinning.PolicyEnforce, nil)
+		r.ErrorIs(err, pinning.ErrUnpinned)
+
+		res := resource(image("ghcr.io/acme/app:1.0.0@"+dig.String()), true)
+		applied, err := pinning.Apply(t.Context(), res, pinning.PolicyEnforce, nil)
+		r.NoError(err)
+		r.Same(res, applied)
+	})
+
+	t.Run("enforce ignores other accesses", func(t *testing.T) {
+		r := require.New(t)
+		res := resource(&v2.LocalBlob{Type: runtime.NewVersionedType(v2.LocalBlobAccessType, v2.LocalBlobAccessTypeVersion), LocalReference: dig.String()}, false)
+		applied, err := pinning.Apply(t.Context(), res, pinning.PolicyEnforce, nil)
+		r.NoError(err)
+		r.Same(res, applied)
+	})
+
+	t.Run("enforce refuses accesses pinned to a different digest", func(t *testing.T) {
+		r := require.New(t)
+		_, err := pinning.Apply(t.Context(), resource(image("ghcr.io/acme/app:1.0.0@"+other.String()), true), pinning.PolicyEnforce, nil)
+		r.ErrorIs(err, pinning.ErrDigestMismatch)
+	})
+
+	resolver := func(resolved digest.Digest) pinning.Resolver {
+		return pinning.ResolverFunc(func(_ context.Context, imageReference string) (digest.Digest, error) {
+			if imageReference != "ghcr.io/acme/app:1.0.0" {
+				return "", errors.New("unexpected reference")
+			}
+			return resolved, nil
+		})
+	}
+
+	t.Run("resolve pins to the digest of the tag", func(t *testing.T) {
+		r := require.New(t)
+		res := resource(image("ghcr.io/acme/app:1.0.0"), true)
+		applied, err := pinning.Apply(t.Context(), res, pinning.PolicyResolve, resolver(dig))
+		r.NoError(err)
+		r.Equal("ghcr.io/acme/app:1.0.0@"+dig.String(), applied.Access.(*accessv1.OCIImage).ImageReference)
+		r.Equal("ghcr.io/acme/app:1.0.0", res.Access.(*accessv1.OCIImage).ImageReference, "the resource must not be modified")
+
+		record, err := pinning.GetRecord(applied.Labels)
+		r.NoError(err)
+		r.Equal(&pinning.Record{ImageReference: "ghcr.io/acme/app:1.0.0", Digest: dig.String()}, record)
+		r.False(applied.Labels[0].Signing)
+		r.NoError(pinning.Verify(applied))
+	})
+
+	t.Run("resolve pins resources without digest", func(t *testing.T) {
+		r := require.New(t)
+		applied, err := pinning.Apply(t.Context(), resource(image("ghcr.io/acme/app:1.0.0"), false), pinning.PolicyResolve, resolver(other))
+		r.NoError(err)
+		r.Equal("ghcr.io/acme/app:1.0.0@"+other.String(), applied.Access.(*accessv1.OCIImage).ImageReference)
+	})
+
+	t.Run("resolve refuses moved tags", func(t *testing.T) {
+		r := require.New(t)
+		_, err := pinning.Apply(t.Context(), resource(image("ghcr.io/acme/app:1.0.0"), true), pinning.PolicyResolve, resolver(other))
+		r.ErrorIs(err, pinning.ErrUnpinned)
+		r.ErrorContains(err, "its tag references "+other.String())
+	})
+
+	t.Run("resolve requires a resolver", func(t *testing.T) {
+		r := require.New(t)
+		_, err := pinning.Apply(t.Context(), resource(image("ghcr.io/acme/app:1.0.0"), true), pinning.PolicyResolve, nil)
+		r.ErrorIs(err, pinning.ErrUnpinned)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		r := require.New(t)
+		r.NoError(pinning.Verify(resource(image("ghcr.io/acme/app:1.0.0"), true)))
+		r.NoError(pinning.Verify(resource(image("ghcr.io/acme/app@"+dig.String()), true)))
+		r.NoError(pinning.Verify(resource(image("ghcr.io/acme/app@"+other.String()), false)))
+		r.ErrorIs(pinning.Verify(resource(image("ghcr.io/acme/app@"+other.String()), true)), pinning.ErrDigestMismatch)
+
+		res := resource(image("ghcr.io/acme/app:1.0.0@"+dig.String()), true)
+		label, err := pinning.Record{ImageReference: "ghcr.io/acme/app:1.0.0", Digest: other.String()}.Label()
+		r.NoError(err)
+		res.Labels = append(res.Labels, label)
+		r.ErrorIs(pinning.Verify(res), pinning.ErrDigestMismatch)
+	})
+}
@@ -10,8 +10,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"regexp"
+	"sync"
 
 	"github.com/opencontainers/go-digest"
 	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
@@ -23,10 +25,14 @@ import (
 	"oras.land/oras-go/v2/registry"
 
 	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/filesystem/cache"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	ociblob "ocm.software/open-component-model/bindings/go/oci/blob"
 	"ocm.software/open-component-model/bindings/go/oci/compref"
+	"ocm.software/open-component-model/bindings/go/oci/encryption"
+	"ocm.software/open-component-model/bindings/go/oci/internal/classify"
 	internaldigest "ocm.software/open-component-model/bindings/go/oci/internal/digest"
 	"ocm.software/open-component-model/bindings/go/oci/internal/fetch"
 	"ocm.software/open-component-model/bindings/go/oci/internal/identity"
@@ -37,6 +43,8 @@ import (
 	"ocm.software/open-component-model/bindings/go/oci/internal/pack"
 	"ocm.software/open-component-model/bindings/go/oci/internal/validate"
 	"ocm.software/open-component-model/bindings/go/oci/looseref"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
+	"ocm.software/open-component-model/bindings/go/oci/resumable"
 	"ocm.software/open-component-model/bindings/go/oci/spec"
 	accessv1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
 	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
@@ -46,6 +54,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/oci/tar"
 	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 var (
@@ -97,6 +106,34 @@ type Repository struct {
 	// globalAccessPolicy controls whether global access references are added to local blobs.
 	// Default (zero value) is Never, suppressing global access to discourage reliance on it.
 	globalAccessPolicy GlobalAccessPolicy
+
+	// mode restricts the operations allowed on the repository.
+	mode RepositoryMode
+
+	// maintenanceMessage is reported to callers of write operations rejected because of mode.
+	maintenanceMessage string
+
+	// resumableDownloadDir is the directory keeping the partial state of resumable downloads.
+	// If empty, downloads are not resumable.
+	resumableDownloadDir string
+
+	// localBlobEncryptionKey wraps the data keys local resource blobs are encrypted with.
+	// If nil, local resource blobs are stored unencrypted.
+	localBlobEncryptionKey encryption.KeyWrapper
+
+	// localBlobDecryptionKeys unwrap the data keys of encrypted local resource blobs.
+	localBlobDecryptionKeys []encryption.KeyUnwrapper
+
+	// deleteReferrers makes DeleteComponentVersion delete the referrers of a component version as well.
+	deleteReferrers bool
+	// maintainVersionIndex makes the repository maintain the component version index on publish and delete.
+	maintainVersionIndex bool
+
+	// blobCache caches local blobs by digest. If nil, local blobs are always fetched from the store.
+	blobCache *cache.Cache
+
+	// digestPinningPolicy defines how OCI image accesses that are not pinned to a digest are treated.
+	digestPinningPolicy DigestPinningPolicy
 }
 
 // SetGlobalAccessPolicy overrides the global access policy for this repository.
@@ -106,18 +143,31 @@ func (repo *Repository) SetGlobalAccessPolicy(policy GlobalAccessPolicy) {
 
 // AddComponentVersion adds a new component version to the repository.
 func (repo *Repository) AddComponentVersion(ctx context.Context, descriptor *descriptor.Descriptor) (err error) {
+	return repo.addComponentVersion(ctx, descriptor, repository.AddComponentVersionPrecondition{})
+}
+
+func (repo *Repository) addComponentVersion(ctx context.Context, descriptor *descriptor.Descriptor, precondition repository.AddComponentVersionPrecondition) (err error) {
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
 	component, version := descriptor.Component.Name, descriptor.Component.Version
 	done := log.Operation(ctx, "add component version", slog.String("component", component), slog.String("version", version))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
+	if err := repo.checkWritable("add component version"); err != nil {
+		return err
+	}
+
 	reference, store, err := repo.getStore(ctx, component, version)
 	if err != nil {
 		return err
 	}
 
+	if err := checkTagPrecondition(ctx, store, reference, component, version, precondition); err != nil {
+		return err
+	}
+
 	// Scan component descriptor for local blob references
 	localBlobs := scanLocalBlobs(descriptor)
 
@@ -139,18 +189,43 @@ func (repo *Repository) AddComponentVersion(ctx context.Context, descriptor *des
 		return fmt.Errorf("failed to add descriptor to store: %w", err)
 	}
 
+	// check again right before moving the tag, the descriptor may have been pushed concurrently in the meantime.
+	if err := checkTagPrecondition(ctx, store, reference, component, version, precondition); err != nil {
+		return err
+	}
 	if err := store.Tag(ctx, *manifest, reference); err != nil {
 		return fmt.Errorf("failed to tag manifest: %w", err)
 	}
 
+	if repo.maintainVersionIndex {
+		if err := repo.updateVersionIndex(ctx, component, func(index *ociImageSpecV1.Index) bool {
+			indexv1.AddVersion(index, *manifest)
+			return true
+		}); err != nil {
+			return fmt.Errorf("failed to add component version to version index: %w", err)
+		}
+	}
+
 	return nil
 }
 
 func (repo *Repository) ListComponentVersions(ctx context.Context, component string) (_ []string, err error) {
+	return repo.ListComponentVersionsMatching(ctx, component)
+}
+
+// ListComponentVersionsMatching lists the versions of a component whose manifest annotations match all filters,
+// for example only versions created by a given pipeline ([annotations.MatchCreator]) or only signed versions
+// ([annotations.MatchSigned]). The filters are evaluated on the annotations of the referrers of the component
+// or of the tagged manifests, so no component descriptor is downloaded for filtering.
+// Legacy component versions without annotations never match a filter.
+// Without filters, it behaves like ListComponentVersions.
+func (repo *Repository) ListComponentVersionsMatching(ctx context.Context, component string, filters ...annotations.Filter) (_ []string, err error) {
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
 	done := log.Operation(ctx, "list component versions",
-		slog.String("component", component))
+		slog.String("component", component),
+		slog.Int("filters", len(filters)))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
@@ -167,12 +242,18 @@ func (repo *Repository) ListComponentVersions(ctx context.Context, component str
 	opts := lister.Options{
 		SortPolicy: lister.SortPolicyLooseSemverDescending,
 		TagListerOptions: lister.TagListerOptions{
-			VersionResolver: complister.ReferenceTagVersionResolver(component, store),
+			VersionResolver: complister.ReferenceTagVersionResolver(component, store, filters...),
 		},
 		ReferrerListerOptions: lister.ReferrerListerOptions{
 			ArtifactType:    descriptor2.MediaTypeComponentDescriptorV2,
 			Subject:         indexv1.Descriptor,
-			VersionResolver: complister.ReferrerAnnotationVersionResolver(component),
+			VersionResolver: complister.ReferrerAnnotationVersionResolver(component, filters...),
+		},
+		VersionIndexListerOptions: lister.VersionIndexListerOptions{
+			Manifests: func(ctx context.Context) ([]ociImageSpecV1.Descriptor, error) {
+				return repo.versionIndexManifests(ctx, component)
+			},
+			VersionResolver: complister.ReferrerAnnotationVersionResolver(component, filters...),
 		},
 	}
 
@@ -198,6 +279,7 @@ func (repo *Repository) GetComponentVersion(ctx context.Context, component, vers
 		slog.String("component", component),
 		slog.String("version", version))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
@@ -226,15 +308,31 @@ func (repo *Repository) AddLocalResource(
 		slog.String("version", version),
 		log.IdentityLogAttr("resource", resource.ToIdentity()))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
+	if err := repo.checkWritable("add local resource"); err != nil {
+		return nil, err
+	}
+
 	resource = resource.DeepCopy()
 
+	b, encrypted, mediaType, err := repo.encryptLocalResource(ctx, resource, b)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := repo.uploadAndUpdateLocalArtifact(ctx, component, version, resource, b); err != nil {
 		return nil, err
 	}
 
+	if encrypted != nil {
+		if err := repo.recordLocalResourceEncryption(resource, encrypted, mediaType); err != nil {
+			return nil, err
+		}
+	}
+
 	return resource, nil
 }
 
@@ -245,9 +343,14 @@ func (repo *Repository) AddLocalSource(ctx context.Context, component, version s
 		slog.String("version", version),
 		log.IdentityLogAttr("source", source.ToIdentity()))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
+	if err := repo.checkWritable("add local source"); err != nil {
+		return nil, err
+	}
+
 	source = source.DeepCopy()
 
 	if err := repo.uploadAndUpdateLocalArtifact(ctx, component, version, source, content); err != nil {
@@ -262,6 +365,7 @@ func (repo *Repository) ProcessResourceDigest(ctx context.Context, res *descript
 	done := log.Operation(ctx, "process resource digest",
 		log.IdentityLogAttr("resource", res.ToIdentity()))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 	res = res.DeepCopy()
@@ -270,11 +374,8 @@ func (repo *Repository) ProcessResourceDigest(ctx context.Context, res *descript
 		if typed.GlobalAccess == nil {
 			return nil, fmt.Errorf("local blob access does not have a global access and cannot be used")
 		}
-		globalAccess, err := repo.scheme.NewObject(typed.GlobalAccess.GetType())
+		globalAccess, err := repo.scheme.NewObjectFrom(typed.GlobalAccess)
 		if err != nil {
-			return nil, fmt.Errorf("error creating typed global blob access with help of scheme: %w", err)
-		}
-		if err := repo.scheme.Convert(typed.GlobalAccess, globalAccess); err != nil {
 			return nil, fmt.Errorf("error converting global blob access: %w", err)
 		}
 		res.Access = globalAccess
@@ -301,6 +402,9 @@ func (repo *Repository) processOCIImageDigest(ctx context.Context, res *descript
 	if dig, err := resolved.Digest(); err == nil {
 		pinnedDigest = dig
 	}
+	if pinnedDigest == "" && repo.digestPinningPolicy == DigestPinningPolicyEnforce {
+		return nil, &pinning.UnpinnedError{ImageReference: typed.ImageReference}
+	}
 
 	var desc ociImageSpecV1.Descriptor
 	if pinnedDigest.String() == "" {
@@ -328,6 +432,11 @@ func (repo *Repository) processOCIImageDigest(ctx context.Context, res *descript
 		return nil, fmt.Errorf("expected pinned digest %q (derived from %q) but got %q", pinnedDigest, resolved, desc.Digest)
 	}
 
+	if pinnedDigest == "" && repo.digestPinningPolicy == DigestPinningPolicyResolve {
+		// record the tag the access was resolved from.
+		return pinning.PinResource(res, typed, desc.Digest)
+	}
+
 	resolved.Reference.Reference = desc.Digest.String()
 
 	// in any case, after successful processing, we can pin the access
@@ -469,14 +578,14 @@ func (repo *Repository) uploadAndUpdateLocalArtifact(
 }
 
 // GetLocalResource retrieves a local resource from the repository.
-func (repo *Repository) GetLocalResource(ctx context.Context, component, version string, identity runtime.Identity) (blob.ReadOnlyBlob, *descriptor.Resource, error) {
+func (repo *Repository) GetLocalResource(ctx context.Context, component, version string, identity runtime.Identity) (_ blob.ReadOnlyBlob, _ *descriptor.Resource, err error) {
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
-	var err error
 	done := log.Operation(ctx, "get local resource",
 		slog.String("component", component),
 		slog.String("version", version),
 		log.IdentityLogAttr("resource", identity))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
@@ -488,17 +597,22 @@ func (repo *Repository) GetLocalResource(ctx context.Context, component, version
 		}
 		return nil, nil, err
 	}
-	return b, artifact.(*descriptor.Resource), nil
+	resource := artifact.(*descriptor.Resource)
+	decrypted, err := repo.decryptLocalResource(ctx, resource, b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decrypted, resource, nil
 }
 
-func (repo *Repository) GetLocalSource(ctx context.Context, component, version string, identity runtime.Identity) (blob.ReadOnlyBlob, *descriptor.Source, error) {
+func (repo *Repository) GetLocalSource(ctx context.Context, component, version string, identity runtime.Identity) (_ blob.ReadOnlyBlob, _ *descriptor.Source, err error) {
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
-	var err error
 	done := log.Operation(ctx, "get local source",
 		slog.String("component", component),
 		slog.String("version", version),
 		log.IdentityLogAttr("resource", identity))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
@@ -550,11 +664,8 @@ func (repo *Repository) localArtifact(ctx context.Context, component, version st
 	slogcontext.Debug(ctx, "found artifact in descriptor", "artifact", meta.ToIdentity())
 
 	access := artifact.GetAccess()
-	typed, err := repo.scheme.NewObject(access.GetType())
+	typed, err := repo.scheme.NewObjectFrom(access)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error creating resource access: %w", err)
-	}
-	if err := repo.scheme.Convert(access, typed); err != nil {
 		return nil, nil, fmt.Errorf("error converting resource access: %w", err)
 	}
 
@@ -601,7 +712,7 @@ func (repo *Repository) getLocalBlobFromIndexOrManifest(
 		})
 	}
 
-	data, err := store.Fetch(ctx, artifact)
+	data, err := repo.fetchLocalBlob(ctx, store, artifact)
 	if err != nil {
 		return nil, fmt.Errorf("fetch layer: %w", err)
 	}
@@ -613,6 +724,37 @@ func (repo *Repository) getLocalBlobFromIndexOrManifest(
 	return b, nil
 }
 
+// fetchLocalBlob fetches the content of a local blob, from the blob cache if possible.
+// Blobs fetched from the store are added to the cache before they are returned.
+func (repo *Repository) fetchLocalBlob(ctx context.Context, store spec.Store, artifact ociImageSpecV1.Descriptor) (io.ReadCloser, error) {
+	if repo.blobCache == nil {
+		return store.Fetch(ctx, artifact)
+	}
+	if data, ok := repo.blobCache.Get(artifact.Digest.String()); ok {
+		slogcontext.Debug(ctx, "serving local blob from cache", log.DescriptorLogAttr(artifact))
+		return data, nil
+	}
+
+	data, err := store.Fetch(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	err = repo.blobCache.Put(artifact.Digest.String(), artifact.Size, data)
+	if errors.Is(err, cache.ErrTooLarge) && artifact.Size >= 0 {
+		// the content was not read, so it can be used directly.
+		return data, nil
+	}
+	if err := errors.Join(err, data.Close()); err != nil {
+		slogcontext.Warn(ctx, "failed to cache local blob, fetching it again", log.DescriptorLogAttr(artifact), slog.String("error", err.Error()))
+		return store.Fetch(ctx, artifact)
+	}
+	if cached, ok := repo.blobCache.Get(artifact.Digest.String()); ok {
+		return cached, nil
+	}
+	// evicted right away by concurrent additions to the cache.
+	return store.Fetch(ctx, artifact)
+}
+
 func (repo *Repository) getStore(ctx context.Context, component string, version string) (ref string, store spec.Store, err error) {
 	reference := repo.resolver.ComponentVersionReference(ctx, component, version)
 	if store, err = repo.resolver.StoreForReference(ctx, reference); err != nil {
@@ -626,9 +768,14 @@ func (repo *Repository) UploadResource(ctx context.Context, res *descriptor.Reso
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
 	done := log.Operation(ctx, "upload resource", log.IdentityLogAttr("resource", res.ToIdentity()))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
+	if err := repo.checkWritable("upload resource"); err != nil {
+		return nil, err
+	}
+
 	res = res.DeepCopy()
 
 	desc, access, err := repo.uploadOCIImage(ctx, res.Access, b)
@@ -652,9 +799,14 @@ func (repo *Repository) UploadSource(ctx context.Context, src *descriptor.Source
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
 	done := log.Operation(ctx, "upload source", log.IdentityLogAttr("source", src.ToIdentity()))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
+	if err := repo.checkWritable("upload source"); err != nil {
+		return nil, err
+	}
+
 	src = src.DeepCopy()
 
 	_, access, err := repo.uploadOCIImage(ctx, src.Access, b)
@@ -724,9 +876,14 @@ func (repo *Repository) AddOwnership(ctx context.Context, component, version str
 		slog.String("version", version),
 		log.IdentityLogAttr("resource", resource.ToIdentity()))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
+	if err := repo.checkWritable("add ownership referrer"); err != nil {
+		return err
+	}
+
 	store, subject, err := repo.resolveOwnershipSubject(ctx, component, version, resource)
 	if err != nil {
 		return err
@@ -735,11 +892,8 @@ func (repo *Repository) AddOwnership(ctx context.Context, component, version str
 }
 
 func (repo *Repository) resolveOwnershipSubject(ctx context.Context, component, version string, resource *descriptor.Resource) (spec.Store, ociImageSpecV1.Descriptor, error) {
-	typed, err := repo.scheme.NewObject(resource.Access.GetType())
+	typed, err := repo.scheme.NewObjectFrom(resource.Access)
 	if err != nil {
-		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("error creating resource access for ownership referrer: %w", err)
-	}
-	if err := repo.scheme.Convert(resource.Access, typed); err != nil {
 		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("error converting resource access for ownership referrer: %w", err)
 	}
 
@@ -830,6 +984,7 @@ func (repo *Repository) DownloadResource(ctx context.Context, res *descriptor.Re
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
 	done := log.Operation(ctx, "download resource", log.IdentityLogAttr("resource", res.ToIdentity()))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
@@ -840,7 +995,7 @@ func (repo *Repository) DownloadResource(ctx context.Context, res *descriptor.Re
 	if err != nil {
 		return nil, err
 	}
-	return stream.Materialize(ctx)
+	return repo.materialize(ctx, stream)
 }
 
 // DownloadSource downloads a [*descriptor.Source] from the repository.
@@ -848,6 +1003,7 @@ func (repo *Repository) DownloadSource(ctx context.Context, src *descriptor.Sour
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
 	done := log.Operation(ctx, "download source", log.IdentityLogAttr("resource", src.ToIdentity()))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
@@ -864,7 +1020,60 @@ func (repo *Repository) download(ctx context.Context, access runtime.Typed) (dat
 	if err != nil {
 		return nil, err
 	}
-	return stream.Materialize(ctx)
+	return repo.materialize(ctx, stream)
+}
+
+// materialize materializes the stream into a blob. If resumable downloads are configured,
+// the blobs of OCI artifacts are downloaded through the resumable download directory and
+// removed from it once the materialized blob has been read completely. The state of downloads
+// that fail is kept for the next attempt and pruned once it exceeds resumable.DefaultMaxAge.
+func (repo *Repository) materialize(ctx context.Context, stream ocistream.ResourceStream) (blob.ReadOnlyBlob, error) {
+	ociStream, ok := stream.(*ocistream.OCIResourceStream)
+	if !ok || repo.resumableDownloadDir == "" {
+		return stream.Materialize(ctx)
+	}
+
+	storage, err := resumable.New(ociStream.ReadOnlyGraphStorage, repo.resumableDownloadDir)
+	if err != nil {
+		return nil, err
+	}
+	resumableStream := *ociStream
+	resumableStream.ReadOnlyGraphStorage = storage
+
+	materialized, err := resumableStream.Materialize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := materialized.ReadCloser()
+	if err != nil {
+		return nil, err
+	}
+	opts := []inmemory.MemoryBlobOption{}
+	if mediaTypeAware, ok := materialized.(blob.MediaTypeAware); ok {
+		if mediaType, known := mediaTypeAware.MediaType(); known {
+			opts = append(opts, inmemory.WithMediaType(mediaType))
+		}
+	}
+	return inmemory.New(&cleanupOnEOFReader{ReadCloser: data, cleanup: storage.Cleanup}, opts...), nil
+}
+
+// cleanupOnEOFReader calls cleanup once the underlying reader has been read completely.
+type cleanupOnEOFReader struct {
+	io.ReadCloser
+	cleanup func() error
+	once    sync.Once
+}
+
+func (r *cleanupOnEOFReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.once.Do(func() {
+			if cleanupErr := r.cleanup(); cleanupErr != nil {
+				err = cleanupErr
+			}
+		})
+	}
+	return n, err
 }
 
 // getDescriptorOCIImageManifest retrieves the manifest for a given reference from the store.
@@ -952,9 +1161,14 @@ func (repo *Repository) AddComponentVersionAlias(ctx context.Context, component,
 		slog.String("versionOrAlias", versionOrAlias),
 		slog.String("alias", alias))
 	defer func() {
+		err = classify.Error(err)
 		done(err)
 	}()
 
+	if err := repo.checkWritable("add component version alias"); err != nil {
+		return err
+	}
+
 	if versionRegex.MatchString(alias) {
 		return fmt.Errorf("alias %q uses semantic version format and cannot be used as an alias (use non-semver names like 'edge' or 'latest' instead)", alias)
 	}
@@ -990,7 +1204,14 @@ func (repo *Repository) RemoveComponentVersionAlias(ctx context.Context, compone
 	done := log.Operation(ctx, "remove component version alias",
 		slog.String("component", component),
 		slog.String("alias", alias))
-	defer func() { done(err) }()
+	defer func() {
+		err = classify.Error(err)
+		done(err)
+	}()
+
+	if err := repo.checkWritable("remove component version alias"); err != nil {
+		return err
+	}
 
 	if versionRegex.MatchString(alias) {
 		return fmt.Errorf("%q is a semantic version (component version identifier), not an alias; RemoveComponentVersionAlias only removes floating alias tags such as 'edge' or 'latest'", alias)
@@ -1030,6 +1251,71 @@ func (repo *Repository) RemoveComponentVersionAlias(ctx context.Context, compone
 	return nil
 }
 
+// DeleteComponentVersion removes a component version from the repository.
+// If the store supports it, the manifest of the component version is deleted, which removes all tags and aliases
+// pointing to it as well as its entry in the referrers of the component index. Otherwise, only the version tag
+// is removed, and the component version may still be listed by referrer based lookups.
+// The referrers of the component version are only deleted with WithDeleteReferrers.
+// With WithMaintainVersionIndex, the component version is removed from the component version index.
+// Local blobs of the component version are kept until the store is garbage collected.
+func (repo *Repository) DeleteComponentVersion(ctx context.Context, component, version string) (err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "delete component version",
+		slog.String("component", component),
+		slog.String("version", version))
+	defer func() {
+		err = classify.Error(err)
+		done(err)
+	}()
+
+	if err := repo.checkWritable("delete component version"); err != nil {
+		return err
+	}
+
+	store, manifest, err := repo.resolveComponentVersionManifest(ctx, component, version)
+	if err != nil {
+		return err
+	}
+
+	deleter, canDelete := store.(content.Deleter)
+	if !canDelete {
+		untagger, ok := store.(content.Untagger)
+		if !ok {
+			return ocmerrors.Unsupported(fmt.Errorf("store does not support deletion of component version %s/%s", component, version))
+		}
+		reference, _, err := repo.getStore(ctx, component, version)
+		if err != nil {
+			return err
+		}
+		if err := untagger.Untag(ctx, reference); err != nil {
+			return fmt.Errorf("failed to remove tag of component version %s/%s: %w", component, version, err)
+		}
+		return repo.removeFromVersionIndex(ctx, component, version)
+	}
+
+	if repo.deleteReferrers {
+		referrers, err := registry.Referrers(ctx, store, manifest, "")
+		if err != nil {
+			return fmt.Errorf("failed to list referrers of component version %s/%s: %w", component, version, err)
+		}
+		for _, referrer := range referrers {
+			if err := deleter.Delete(ctx, referrer); err != nil && !errors.Is(err, errdef.ErrNotFound) {
+				return fmt.Errorf("failed to delete referrer %s of component version %s/%s: %w", referrer.Digest, component, version, err)
+			}
+		}
+	}
+
+	if err := deleter.Delete(ctx, manifest); err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return errors.Join(repository.ErrNotFound,
+				fmt.Errorf("component version %s/%s not found: %w", component, version, err))
+		}
+		return fmt.Errorf("failed to delete component version %s/%s: %w", component, version, err)
+	}
+
+	return repo.removeFromVersionIndex(ctx, component, version)
+}
+
 // DownloadResourceStream returns a lazy ResourceStream for the given resource.
 // No data is downloaded — content streams on demand via Fetch calls.
 func (repo *Repository) DownloadResourceStream(ctx context.Context, res *descriptor.Resource) (ocistream.ResourceStream, error) {
@@ -1037,15 +1323,16 @@ func (repo *Repository) DownloadResourceStream(ctx context.Context, res *descrip
 	if res.Access.GetType().IsEmpty() {
 		return nil, fmt.Errorf("resource access type is empty")
 	}
+	res, err := repo.pinResource(ctx, res)
+	if err != nil {
+		return nil, err
+	}
 	return repo.downloadStream(ctx, res.Access)
 }
 
 func (repo *Repository) downloadStream(ctx context.Context, access runtime.Typed) (ocistream.ResourceStream, error) {
-	typed, err := repo.scheme.NewObject(access.GetType())
+	typed, err := repo.scheme.NewObjectFrom(access)
 	if err != nil {
-		return nil, fmt.Errorf("error creating resource access: %w", err)
-	}
-	if err := repo.scheme.Convert(access, typed); err != nil {
 		return nil, fmt.Errorf("error converting resource access: %w", err)
 	}
 
@@ -1054,11 +1341,8 @@ func (repo *Repository) downloadStream(ctx context.Context, access runtime.Typed
 		if typed.GlobalAccess == nil {
 			return nil, fmt.Errorf("local blob access does not have a global access and cannot be used")
 		}
-		globalAccess, err := repo.scheme.NewObject(typed.GlobalAccess.GetType())
+		globalAccess, err := repo.scheme.NewObjectFrom(typed.GlobalAccess)
 		if err != nil {
-			return nil, fmt.Errorf("error creating typed global blob access with help of scheme: %w", err)
-		}
-		if err := repo.scheme.Convert(typed.GlobalAccess, globalAccess); err != nil {
 			return nil, fmt.Errorf("error converting global blob access: %w", err)
 		}
 		return repo.downloadStream(ctx, globalAccess)
@@ -1110,6 +1394,10 @@ func (repo *Repository) downloadStream(ctx context.Context, access runtime.Typed
 func (repo *Repository) UploadResourceStream(ctx context.Context, res *descriptor.Resource, rs ocistream.ResourceStream) (*descriptor.Resource, error) {
 	ctx = slogcontext.NewCtx(ctx, repo.logger)
 
+	if err := repo.checkWritable("upload resource stream"); err != nil {
+		return nil, err
+	}
+
 	var access accessv1.OCIImage
 	if err := repo.scheme.Convert(res.Access, &access); err != nil {
 		return nil, fmt.Errorf("error converting resource target to OCI image: %w", err)
@@ -1120,6 +1408,11 @@ func (repo *Repository) UploadResourceStream(ctx context.Context, res *descripto
 		return nil, fmt.Errorf("failed to parse target access image reference %q: %w", access.ImageReference, err)
 	}
 
+	// a reference pinned to a digest only accepts exactly that artifact, e.g. the one signed with the component version.
+	if ref.ValidateReferenceAsDigest() == nil && ref.Reference.Reference != rs.Root().Digest.String() {
+		return nil, fmt.Errorf("artifact %s does not match the digest of the target access image reference %q", rs.Root().Digest, access.ImageReference)
+	}
+
 	store, err := repo.resolver.StoreForReference(ctx, access.ImageReference)
 	if err != nil {
 		return nil, err
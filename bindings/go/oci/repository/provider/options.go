@@ -1,7 +1,11 @@
 package provider
 
 import (
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	encryptionv1alpha1 "ocm.software/open-component-model/bindings/go/oci/encryption/spec/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/oci/resolver/anonymous"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -30,6 +34,26 @@ type Options struct {
 	// Accepts the serialisable config type so that external plugins can
 	// round-trip it over the wire and reconstruct an equivalent client.
 	HTTPConfig *httpv1alpha1.Config
+
+	// Throttle is consulted by the provider's internal HTTP client for the
+	// per-host request rate and the retry budget. Share it with other
+	// subsystems talking to the same registries. When nil, requests are not
+	// throttled.
+	Throttle *ocmhttp.Throttle
+
+	// AnonymousFallback decides for which registry hosts pulls are retried
+	// anonymously if they are rejected and no credentials were passed for the
+	// repository. When nil, pulls are never retried anonymously.
+	AnonymousFallback *anonymous.Policy
+
+	// EncryptionConfig configures the keys local resource blobs are encrypted with per repository.
+	// When nil, local resource blobs are stored unencrypted and encrypted ones cannot be read.
+	EncryptionConfig *encryptionv1alpha1.Config
+
+	// RegistryConfig configures the registry hosts that are accessed over plain HTTP or without
+	// TLS verification. When nil, all registries are accessed over HTTPS with TLS verification,
+	// unless the base URL of a repository has the http scheme.
+	RegistryConfig *registriesv1alpha1.Config
 }
 
 type Option func(*Options)
@@ -67,3 +91,36 @@ func WithHTTPConfig(cfg *httpv1alpha1.Config) Option {
 		o.HTTPConfig = cfg
 	}
 }
+
+// WithThrottle sets the Throttle shared with other subsystems that the
+// provider's internal HTTP client consults before sending requests and retries.
+func WithThrottle(throttle *ocmhttp.Throttle) Option {
+	return func(o *Options) {
+		o.Throttle = throttle
+	}
+}
+
+// WithAnonymousFallback retries pulls anonymously for the hosts allowed by policy
+// if the pulls are rejected and no credentials were passed for the repository.
+// The pulls are counted in the PullStats of the provider.
+func WithAnonymousFallback(policy *anonymous.Policy) Option {
+	return func(o *Options) {
+		o.AnonymousFallback = policy
+	}
+}
+
+// WithEncryptionConfig encrypts the local resource blobs of the repositories configured in cfg
+// with their keys, see the encryption package.
+func WithEncryptionConfig(cfg *encryptionv1alpha1.Config) Option {
+	return func(o *Options) {
+		o.EncryptionConfig = cfg
+	}
+}
+
+// WithRegistryConfig configures the registry hosts that are accessed over plain HTTP or without
+// TLS verification, see urlresolver.WithRegistryConfig.
+func WithRegistryConfig(cfg *registriesv1alpha1.Config) Option {
+	return func(o *Options) {
+		o.RegistryConfig = cfg
+	}
+}
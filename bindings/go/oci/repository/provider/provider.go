@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
+	"path/filepath"
 
 	"oras.land/oras-go/v2/registry/remote/auth"
 
@@ -12,7 +14,12 @@ import (
 	"ocm.software/open-component-model/bindings/go/oci"
 	"ocm.software/open-component-model/bindings/go/oci/credentials"
 	ocictf "ocm.software/open-component-model/bindings/go/oci/ctf"
+	"ocm.software/open-component-model/bindings/go/oci/encryption"
+	encryptionv1alpha1 "ocm.software/open-component-model/bindings/go/oci/encryption/spec/v1alpha1"
 	ocirepository "ocm.software/open-component-model/bindings/go/oci/repository"
+	"ocm.software/open-component-model/bindings/go/oci/resolver/anonymous"
+	urlresolver "ocm.software/open-component-model/bindings/go/oci/resolver/url"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 	v2 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
 	"ocm.software/open-component-model/bindings/go/oci/spec/identity/v1"
 	repoSpec "ocm.software/open-component-model/bindings/go/oci/spec/repository"
@@ -59,6 +66,19 @@ type CachingComponentVersionRepositoryProvider struct {
 	// (such as the extracted directory representation of a tar
 	// or tar.gz ctf archive).
 	tempDir string
+
+	// anonymousFallback is the policy for retrying rejected pulls anonymously.
+	// If nil, pulls are not retried.
+	anonymousFallback *anonymous.Policy
+
+	// pullMetrics counts the authenticated and anonymous pulls of all repositories provided.
+	pullMetrics *anonymous.Metrics
+
+	// encryptionConfig configures the keys local resource blobs are encrypted with per repository.
+	encryptionConfig *encryptionv1alpha1.Config
+
+	// registryConfig configures the registry hosts that are accessed over plain HTTP or without TLS verification.
+	registryConfig *registriesv1alpha1.Config
 }
 
 var _ repository.ComponentVersionRepositoryProvider = (*CachingComponentVersionRepositoryProvider)(nil)
@@ -86,8 +106,14 @@ func NewComponentVersionRepositoryProvider(opts ...Option) *CachingComponentVers
 		httpClient: ocmhttp.New(
 			ocmhttp.WithConfig(options.HTTPConfig),
 			ocmhttp.WithUserAgent(options.UserAgent),
+			ocmhttp.WithThrottle(options.Throttle),
+			ocmhttp.WithContextBandwidth(),
 		),
-		tempDir: options.TempDir,
+		tempDir:           options.TempDir,
+		anonymousFallback: options.AnonymousFallback,
+		pullMetrics:       &anonymous.Metrics{},
+		encryptionConfig:  options.EncryptionConfig,
+		registryConfig:    options.RegistryConfig,
 	}
 
 	return provider
@@ -143,6 +169,11 @@ func (b *CachingComponentVersionRepositoryProvider) GetComponentVersionRepositor
 		oci.WithTempDir(b.tempDir),
 		oci.WithCreator(b.creator),
 	}
+	encryptionOpts, err := b.encryptionOptions(obj)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, encryptionOpts...)
 
 	switch obj := obj.(type) {
 	case *ocirepospecv1.Repository:
@@ -159,14 +190,16 @@ func (b *CachingComponentVersionRepositoryProvider) GetComponentVersionRepositor
 			}
 		}
 
-		return ocirepository.NewFromOCIRepoV1(ctx, obj, &auth.Client{
+		client := &auth.Client{
 			Client:     b.httpClient,
 			Cache:      auth.NewCache(),
 			Credential: credentials.CredentialFunc(identity, ociCredentials),
 			Header: map[string][]string{
 				"User-Agent": {b.creator},
 			},
-		}, opts...)
+		}
+		return ocirepository.NewFromOCIRepoV1WithResolverOptions(ctx, obj, anonymous.NewClient(client, b.anonymousFallback, b.pullMetrics),
+			[]urlresolver.Option{urlresolver.WithRegistryConfig(b.registryConfig)}, opts...)
 	case *ctfrepospecv1.Repository:
 		loadFunc := func(path string) (*ocictf.Store, error) {
 			return ocirepository.NewStoreFromCTFRepoV1(ctx, obj, opts...)
@@ -191,6 +224,11 @@ func (b *CachingComponentVersionRepositoryProvider) GetComponentVersionRepositor
 	}
 }
 
+// Stats returns the usage statistics of the cache of CTF stores.
+func (b *CachingComponentVersionRepositoryProvider) Stats() CacheStats {
+	return b.storeCache.stats()
+}
+
 // getConvertedTypedSpec is a helper function that converts any runtime.Typed specification
 // to its corresponding object type in the scheme. It ensures that the type is set correctly
 func getConvertedTypedSpec(scheme *runtime.Scheme, repositorySpecification runtime.Typed) (runtime.Typed, error) {
@@ -205,3 +243,54 @@ func getConvertedTypedSpec(scheme *runtime.Scheme, repositorySpecification runti
 	}
 	return obj, nil
 }
+
+// encryptionOptions returns the options encrypting local resource blobs with the keys of the first
+// entry of the encryption configuration that matches the repository specification.
+func (b *CachingComponentVersionRepositoryProvider) encryptionOptions(spec runtime.Typed) ([]oci.RepositoryOption, error) {
+	if b.encryptionConfig == nil {
+		return nil, nil
+	}
+	for _, entry := range b.encryptionConfig.Repositories {
+		if entry.Repository == nil {
+			continue
+		}
+		configured, err := getConvertedTypedSpec(b.scheme, entry.Repository)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository in encryption configuration: %w", err)
+		}
+		if !sameRepository(spec, configured) {
+			continue
+		}
+		wrapper, unwrappers, err := encryption.LoadRepositoryKeys(&entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load encryption keys of repository: %w", err)
+		}
+		opts := []oci.RepositoryOption{oci.WithLocalBlobDecryptionKeys(unwrappers...)}
+		if wrapper != nil {
+			opts = append(opts, oci.WithLocalBlobEncryptionKey(wrapper))
+		}
+		return opts, nil
+	}
+	return nil, nil
+}
+
+// sameRepository reports whether both specifications describe the same repository.
+// OCI repositories are compared by their location, so that a sub path may be given separately or as part
+// of the base URL.
+func sameRepository(a, b runtime.Typed) bool {
+	switch a := a.(type) {
+	case *ocirepospecv1.Repository:
+		b, ok := b.(*ocirepospecv1.Repository)
+		return ok && path.Join(a.BaseUrl, a.SubPath) == path.Join(b.BaseUrl, b.SubPath)
+	case *ctfrepospecv1.Repository:
+		b, ok := b.(*ctfrepospecv1.Repository)
+		return ok && filepath.Clean(a.FilePath) == filepath.Clean(b.FilePath)
+	default:
+		return false
+	}
+}
+
+// PullStats returns the number of authenticated and anonymous pulls of the OCI repositories provided.
+func (b *CachingComponentVersionRepositoryProvider) PullStats() anonymous.PullStats {
+	return b.pullMetrics.Stats()
+}
@@ -1,20 +1,36 @@
 package provider_test
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/oci/encryption"
+	encryptionv1alpha1 "ocm.software/open-component-model/bindings/go/oci/encryption/spec/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/oci/repository/provider"
+	urlresolver "ocm.software/open-component-model/bindings/go/oci/resolver/url"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 	ctfrepospecv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
 	ocirepospecv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
 	"ocm.software/open-component-model/bindings/go/runtime"
@@ -208,6 +224,11 @@ func TestWithHTTPConfig_NilFallsBackToDefault(t *testing.T) {
 	repo, err := prov.GetComponentVersionRepository(t.Context(), repoSpec, nil)
 	require.NoError(t, err)
 	require.NotNil(t, repo)
+
+	// the store of the ctf is cached and reused.
+	_, err = prov.GetComponentVersionRepository(t.Context(), repoSpec, nil)
+	require.NoError(t, err)
+	require.Equal(t, provider.CacheStats{Entries: 1, Hits: 1, Misses: 1}, prov.Stats())
 }
 
 // TestWithHTTPConfig_ShortTimeoutCausesError starts a server that hangs and
@@ -237,3 +258,100 @@ func TestWithHTTPConfig_ShortTimeoutCausesError(t *testing.T) {
 	_, err = repo.ListComponentVersions(t.Context(), "example.org/component")
 	require.Error(t, err)
 }
+
+func TestWithEncryptionConfig(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	r.NoError(err)
+	keyDir := t.TempDir()
+	publicKeyFile, privateKeyFile := filepath.Join(keyDir, "key.pub"), filepath.Join(keyDir, "key")
+	r.NoError(os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}), 0o600))
+	r.NoError(os.WriteFile(privateKeyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+
+	ctfDir := t.TempDir()
+	repoSpec := &ctfrepospecv1.Repository{FilePath: ctfDir, AccessMode: ctfrepospecv1.AccessModeReadWrite}
+	configured := &runtime.Raw{}
+	r.NoError(json.Unmarshal(fmt.Appendf(nil, `{"type":"CommonTransportFormat/v1","filePath":%q}`, ctfDir+"/"), configured))
+	cfg := &encryptionv1alpha1.Config{
+		Repositories: []encryptionv1alpha1.RepositoryEncryption{{
+			Repository:      configured,
+			PublicKeyFile:   publicKeyFile,
+			PrivateKeyFiles: []string{privateKeyFile},
+		}},
+	}
+
+	repo, err := provider.NewComponentVersionRepositoryProvider(provider.WithEncryptionConfig(cfg)).GetComponentVersionRepository(ctx, repoSpec, nil)
+	r.NoError(err)
+
+	secret := []byte(`{"password":"s3cr3t"}`)
+	res, err := repo.AddLocalResource(ctx, "ocm.software/test", "1.0.0", &descriptor.Resource{
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "config", Version: "1.0.0"}},
+		Type:        "plainData",
+		Relation:    descriptor.LocalRelation,
+		Access:      &v2.LocalBlob{LocalReference: digest.FromBytes(secret).String(), MediaType: "application/json"},
+	}, inmemory.New(bytes.NewReader(secret)))
+	r.NoError(err)
+	access := &v2.LocalBlob{}
+	r.NoError(runtime.NewScheme(runtime.WithAllowUnknown()).Convert(res.Access, access))
+	r.NotNil(access.Encryption, "local blobs of configured repositories must be encrypted")
+
+	desc := &descriptor.Descriptor{Meta: descriptor.Meta{Version: "v2"}}
+	desc.Component.Name, desc.Component.Version = "ocm.software/test", "1.0.0"
+	desc.Component.Provider.Name = "ocm.software"
+	desc.Component.Resources = []descriptor.Resource{*res}
+	r.NoError(repo.AddComponentVersion(ctx, desc))
+
+	b, _, err := repo.GetLocalResource(ctx, "ocm.software/test", "1.0.0", runtime.Identity{"name": "config", "version": "1.0.0"})
+	r.NoError(err)
+	rc, err := b.ReadCloser()
+	r.NoError(err)
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	r.NoError(err)
+	r.Equal(secret, data)
+
+	unconfigured, err := provider.NewComponentVersionRepositoryProvider().GetComponentVersionRepository(ctx, repoSpec, nil)
+	r.NoError(err)
+	_, _, err = unconfigured.GetLocalResource(ctx, "ocm.software/test", "1.0.0", runtime.Identity{"name": "config", "version": "1.0.0"})
+	r.ErrorIs(err, encryption.ErrNoDecryptionKey)
+
+	cfg.Repositories[0].PrivateKeyFiles = []string{filepath.Join(keyDir, "missing")}
+	_, err = provider.NewComponentVersionRepositoryProvider(provider.WithEncryptionConfig(cfg)).GetComponentVersionRepository(ctx, repoSpec, nil)
+	r.ErrorContains(err, "failed to load encryption keys of repository")
+}
+
+func TestWithRegistryConfig(t *testing.T) {
+	r := require.New(t)
+	var serverHit bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverHit = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	// the base URL has no scheme, so only the registry configuration makes the registry accessed over plain HTTP.
+	repoSpec := &ocirepospecv1.Repository{
+		BaseUrl: host,
+		SubPath: "test/repo",
+	}
+	cfg := &registriesv1alpha1.Config{
+		Registries: []registriesv1alpha1.Registry{{Host: host, PlainHTTP: true}},
+	}
+
+	prov := provider.NewComponentVersionRepositoryProvider(provider.WithRegistryConfig(cfg))
+	repo, err := prov.GetComponentVersionRepository(t.Context(), repoSpec, nil)
+	r.NoError(err)
+	_, err = repo.ListComponentVersions(t.Context(), "example.org/component")
+	r.ErrorIs(err, urlresolver.ErrInsecureRegistryNotAllowed)
+	r.False(serverHit)
+
+	cfg.AllowInsecureRegistries = true
+	prov = provider.NewComponentVersionRepositoryProvider(provider.WithRegistryConfig(cfg))
+	repo, err = prov.GetComponentVersionRepository(t.Context(), repoSpec, nil)
+	r.NoError(err)
+	_, _ = repo.ListComponentVersions(t.Context(), "example.org/component")
+	r.True(serverHit, "expected HTTP request to reach test server over plain HTTP")
+}
@@ -8,8 +8,10 @@ import (
 )
 
 type storeCache struct {
-	mu    sync.Mutex
-	store map[string]*ocictf.Store
+	mu     sync.Mutex
+	store  map[string]*ocictf.Store
+	hits   int64
+	misses int64
 }
 
 // loadOrStore returns the existing oci store for the path, if present.
@@ -20,8 +22,10 @@ func (c *storeCache) loadOrStore(_ context.Context, path string, load func(path
 	defer c.mu.Unlock()
 
 	if store, ok := c.store[path]; ok {
+		c.hits++
 		return store, nil
 	}
+	c.misses++
 	store, err := load(path)
 	if err != nil {
 		return nil, err
@@ -30,3 +34,19 @@ func (c *storeCache) loadOrStore(_ context.Context, path string, load func(path
 
 	return store, nil
 }
+
+// CacheStats describes the usage of a cache.
+type CacheStats struct {
+	// Entries is the number of cached entries.
+	Entries int `json:"entries"`
+	// Hits is the number of lookups served from the cache.
+	Hits int64 `json:"hits"`
+	// Misses is the number of lookups that had to load the entry.
+	Misses int64 `json:"misses"`
+}
+
+func (c *storeCache) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Entries: len(c.store), Hits: c.hits, Misses: c.misses}
+}
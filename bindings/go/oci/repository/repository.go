@@ -46,7 +46,7 @@ func NewStoreFromCTFRepoV1(ctx context.Context, repository *ctfrepospecv1.Reposi
 	mask := repository.AccessMode.ToAccessBitmask()
 
 	format := ctf.DiscoverCTFFormatFromPath(path)
-	if mask&ctf.O_RDWR != 0 && (format == ctf.FormatTAR || format == ctf.FormatTGZ) {
+	if mask&ctf.O_RDWR != 0 && format != ctf.FormatDirectory {
 		return nil, fmt.Errorf("readwrite access is not supported for archive formats such as %s", format.String())
 	}
 
@@ -84,16 +84,51 @@ func NewStoreFromCTFRepoV1(ctx context.Context, repository *ctfrepospecv1.Reposi
 //     https://github.com/open-component-model/ocm/blob/2b819e6/api/oci/extensions/repositories/ocireg/type.go#L138
 //
 // New OCM: Explicit BaseUrl + SubPath fields, consistent parsing, auto-extraction support
-func NewFromOCIRepoV1(_ context.Context, repository *ocirepospecv1.Repository, client remote.Client, options ...oci.RepositoryOption) (*oci.Repository, error) {
-	resolver, err := buildResolver(client, repository)
+func NewFromOCIRepoV1(ctx context.Context, repository *ocirepospecv1.Repository, client remote.Client, options ...oci.RepositoryOption) (*oci.Repository, error) {
+	return NewFromOCIRepoV1WithResolverOptions(ctx, repository, client, nil, options...)
+}
+
+// NewFromOCIRepoV1WithResolverOptions is like NewFromOCIRepoV1, but additionally configures the resolver
+// of the repository with resolverOptions, e.g. with urlresolver.WithRegistryConfig.
+func NewFromOCIRepoV1WithResolverOptions(_ context.Context, repository *ocirepospecv1.Repository, client remote.Client, resolverOptions []urlresolver.Option, options ...oci.RepositoryOption) (*oci.Repository, error) {
+	resolver, err := buildResolver(client, repository, resolverOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("could not create OCI resolver for OCI repository %q: %w", repository.BaseUrl, err)
 	}
 
+	modeOptions, err := repositoryModeOptions(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mode for OCI repository %q: %w", repository.BaseUrl, err)
+	}
+	options = append(options, modeOptions...)
+
 	return oci.NewRepository(append(options, oci.WithResolver(resolver))...)
 }
 
-func buildResolver(client remote.Client, repository *ocirepospecv1.Repository) (*urlresolver.CachingResolver, error) {
+// repositoryModeOptions translates the mode of an OCI repository v1 specification
+// into the corresponding [oci.RepositoryOption]s.
+func repositoryModeOptions(repository *ocirepospecv1.Repository) ([]oci.RepositoryOption, error) {
+	var mode oci.RepositoryMode
+	switch repository.Mode {
+	case ocirepospecv1.ModeReadWrite:
+		if repository.MaintenanceMessage != "" {
+			return nil, fmt.Errorf("a maintenance message can only be set in mode %q", ocirepospecv1.ModeMaintenance)
+		}
+		return nil, nil
+	case ocirepospecv1.ModeReadOnly:
+		mode = oci.RepositoryModeReadOnly
+	case ocirepospecv1.ModeMaintenance:
+		mode = oci.RepositoryModeMaintenance
+	default:
+		return nil, fmt.Errorf("unsupported repository mode %q", repository.Mode)
+	}
+	return []oci.RepositoryOption{
+		oci.WithRepositoryMode(mode),
+		oci.WithMaintenanceMessage(repository.MaintenanceMessage),
+	}, nil
+}
+
+func buildResolver(client remote.Client, repository *ocirepospecv1.Repository, resolverOptions ...urlresolver.Option) (*urlresolver.CachingResolver, error) {
 	if repository.BaseUrl == "" {
 		return nil, fmt.Errorf("a base url is required")
 	}
@@ -128,6 +163,7 @@ func buildResolver(client remote.Client, repository *ocirepospecv1.Repository) (
 	}
 
 	opts = append(opts, urlresolver.WithBaseClient(client))
+	opts = append(opts, resolverOptions...)
 
 	resolver, err := urlresolver.New(opts...)
 	if err != nil {
@@ -122,6 +122,41 @@ func TestNewFromOCIRepoV1(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "read-only repository",
+			repository: &ocirepospecv1.Repository{
+				BaseUrl: "https://registry.example.com",
+				Mode:    ocirepospecv1.ModeReadOnly,
+			},
+			wantErr: false,
+		},
+		{
+			name: "repository in maintenance mode",
+			repository: &ocirepospecv1.Repository{
+				BaseUrl:            "https://registry.example.com",
+				Mode:               ocirepospecv1.ModeMaintenance,
+				MaintenanceMessage: "migration in progress",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown mode",
+			repository: &ocirepospecv1.Repository{
+				BaseUrl: "https://registry.example.com",
+				Mode:    "frozen",
+			},
+			wantErr:     true,
+			errContains: "unsupported repository mode",
+		},
+		{
+			name: "maintenance message without maintenance mode",
+			repository: &ocirepospecv1.Repository{
+				BaseUrl:            "https://registry.example.com",
+				MaintenanceMessage: "migration in progress",
+			},
+			wantErr:     true,
+			errContains: "a maintenance message can only be set",
+		},
 	}
 
 	for _, tt := range tests {
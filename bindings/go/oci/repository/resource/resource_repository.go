@@ -5,16 +5,18 @@ import (
 	"fmt"
 
 	"oras.land/oras-go/v2/registry/remote/auth"
-	"oras.land/oras-go/v2/registry/remote/retry"
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	filesystemv1alpha1 "ocm.software/open-component-model/bindings/go/configuration/filesystem/v1alpha1/spec"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
 	"ocm.software/open-component-model/bindings/go/oci"
 	ocicredentials "ocm.software/open-component-model/bindings/go/oci/credentials"
 	"ocm.software/open-component-model/bindings/go/oci/looseref"
 	"ocm.software/open-component-model/bindings/go/oci/repository/provider"
+	"ocm.software/open-component-model/bindings/go/oci/resolver/anonymous"
 	urlresolver "ocm.software/open-component-model/bindings/go/oci/resolver/url"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 	ociaccess "ocm.software/open-component-model/bindings/go/oci/spec/access"
 	v1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
 	ocicredsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
@@ -30,6 +32,21 @@ type Options struct {
 	// UserAgent is the User-Agent string to be used in HTTP requests by all the
 	// repositories provided by the provider.
 	UserAgent string
+
+	// ResumableDownloadDir is the directory in which downloads keep their partial state,
+	// so that interrupted downloads of large artifacts are resumed instead of restarted.
+	// If empty, the ResumableDownloadFolder of the filesystem configuration is used.
+	// If both are empty, downloads are not resumable.
+	ResumableDownloadDir string
+
+	// AnonymousFallback decides for which registry hosts pulls are retried anonymously
+	// if they are rejected and no credentials were passed for the resource.
+	// If nil, pulls are never retried anonymously.
+	AnonymousFallback *anonymous.Policy
+
+	// RegistryConfig configures the registry hosts that are accessed over plain HTTP or without
+	// TLS verification. If nil, all registries are accessed over HTTPS with TLS verification.
+	RegistryConfig *registriesv1alpha1.Config
 }
 
 type Option func(*Options)
@@ -41,9 +58,36 @@ func WithUserAgent(userAgent string) Option {
 	}
 }
 
+// WithResumableDownloadDir makes downloads resumable by keeping their partial state in the given directory.
+func WithResumableDownloadDir(dir string) Option {
+	return func(o *Options) {
+		o.ResumableDownloadDir = dir
+	}
+}
+
+// WithAnonymousFallback retries pulls anonymously for the hosts allowed by policy
+// if the pulls are rejected and no credentials were passed for the resource.
+func WithAnonymousFallback(policy *anonymous.Policy) Option {
+	return func(o *Options) {
+		o.AnonymousFallback = policy
+	}
+}
+
+// WithRegistryConfig configures the registry hosts that are accessed over plain HTTP or without
+// TLS verification, see urlresolver.WithRegistryConfig.
+func WithRegistryConfig(cfg *registriesv1alpha1.Config) Option {
+	return func(o *Options) {
+		o.RegistryConfig = cfg
+	}
+}
+
 type ResourceRepository struct {
-	filesystemConfig *filesystemv1alpha1.Config
-	userAgent        string
+	filesystemConfig     *filesystemv1alpha1.Config
+	userAgent            string
+	resumableDownloadDir string
+	anonymousFallback    *anonymous.Policy
+	pullMetrics          *anonymous.Metrics
+	registryConfig       *registriesv1alpha1.Config
 }
 
 // make sure that ResourceRepository implements the oci ResourceRepository interface
@@ -61,13 +105,25 @@ func NewResourceRepository(filesystemConfig *filesystemv1alpha1.Config, opts ...
 	if options.UserAgent == "" {
 		options.UserAgent = provider.DefaultCreator
 	}
+	if options.ResumableDownloadDir == "" && filesystemConfig != nil {
+		options.ResumableDownloadDir = filesystemConfig.ResumableDownloadFolder
+	}
 
 	return &ResourceRepository{
-		filesystemConfig: filesystemConfig,
-		userAgent:        options.UserAgent,
+		filesystemConfig:     filesystemConfig,
+		userAgent:            options.UserAgent,
+		resumableDownloadDir: options.ResumableDownloadDir,
+		anonymousFallback:    options.AnonymousFallback,
+		pullMetrics:          &anonymous.Metrics{},
+		registryConfig:       options.RegistryConfig,
 	}
 }
 
+// PullStats returns the number of authenticated and anonymous pulls of the resource repository.
+func (p *ResourceRepository) PullStats() anonymous.PullStats {
+	return p.pullMetrics.Stats()
+}
+
 func (p *ResourceRepository) GetResourceRepositoryScheme() *runtime.Scheme {
 	return ociaccess.Scheme
 }
@@ -188,7 +244,11 @@ func (p *ResourceRepository) UploadResource(ctx context.Context, resource *descr
 }
 
 func (p *ResourceRepository) getRepository(spec *ociv1.Repository, credentials *ocicredsv1.OCICredentials) (*oci.Repository, error) {
-	repo, err := createRepository(spec, credentials, p.filesystemConfig, p.userAgent)
+	var opts []oci.RepositoryOption
+	if p.resumableDownloadDir != "" {
+		opts = append(opts, oci.WithResumableDownloadDir(p.resumableDownloadDir))
+	}
+	repo, err := createRepository(spec, credentials, p.filesystemConfig, p.userAgent, p.anonymousFallback, p.pullMetrics, p.registryConfig, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating repository: %w", err)
 	}
@@ -205,11 +265,19 @@ func ociImageAccessToBaseURL(access *v1.OCIImage) (string, error) {
 	return baseURL, nil
 }
 
+// httpClient is shared by all repositories of the resource repository.
+// It honors the bandwidth of the context of requests, see ocmhttp.ContextWithBandwidth.
+var httpClient = ocmhttp.New(ocmhttp.WithContextBandwidth())
+
 func createRepository(
 	spec *ociv1.Repository,
 	credentials *ocicredsv1.OCICredentials,
 	filesystemConfig *filesystemv1alpha1.Config,
 	userAgent string,
+	anonymousFallback *anonymous.Policy,
+	pullMetrics *anonymous.Metrics,
+	registryConfig *registriesv1alpha1.Config,
+	opts ...oci.RepositoryOption,
 ) (*oci.Repository, error) {
 	url, err := runtime.ParseURLAndAllowNoScheme(spec.BaseUrl)
 	if err != nil {
@@ -219,13 +287,14 @@ func createRepository(
 
 	urlResolver, err := urlresolver.New(
 		urlresolver.WithBaseURL(urlString),
-		urlresolver.WithBaseClient(&auth.Client{
-			Client: retry.DefaultClient,
+		urlresolver.WithBaseClient(anonymous.NewClient(&auth.Client{
+			Client: httpClient,
 			Header: map[string][]string{
 				"User-Agent": {userAgent},
 			},
 			Credential: auth.StaticCredential(url.Host, ocicredentials.MapCredentials(credentials)),
-		}))
+		}, anonymousFallback, pullMetrics)),
+		urlresolver.WithRegistryConfig(registryConfig))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create URL resolver: %w", err)
 	}
@@ -238,6 +307,7 @@ func createRepository(
 		oci.WithCreator(userAgent),
 		oci.WithTempDir(tempDir), // the filesystem config being empty is a valid config
 	}
+	options = append(options, opts...)
 
 	repo, err := oci.NewRepository(options...)
 	return repo, err
@@ -99,7 +99,7 @@ func TestCreateRepositoryWithFilesystemConfig(t *testing.T) {
 			}
 			credentials := ocicredsv1.OCICredentials{}
 
-			repo, err := createRepository(spec, &credentials, tt.filesystemConfig, "test")
+			repo, err := createRepository(spec, &credentials, tt.filesystemConfig, "test", nil, nil, nil)
 
 			if tt.expectError {
 				r.Error(err, "expected error")
@@ -111,3 +111,16 @@ func TestCreateRepositoryWithFilesystemConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewResourceRepository_ResumableDownloadDir(t *testing.T) {
+	r := require.New(t)
+
+	repo := NewResourceRepository(&filesystemv1alpha1.Config{ResumableDownloadFolder: "/var/cache/ocm"})
+	r.Equal("/var/cache/ocm", repo.resumableDownloadDir, "the folder of the filesystem configuration should be used by default")
+
+	repo = NewResourceRepository(&filesystemv1alpha1.Config{ResumableDownloadFolder: "/var/cache/ocm"}, WithResumableDownloadDir("/data/downloads"))
+	r.Equal("/data/downloads", repo.resumableDownloadDir, "the option should take precedence over the filesystem configuration")
+
+	repo = NewResourceRepository(nil)
+	r.Empty(repo.resumableDownloadDir)
+}
@@ -0,0 +1,64 @@
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	slogcontext "github.com/veqryn/slog-context"
+	"oras.land/oras-go/v2/errdef"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/internal/classify"
+	"ocm.software/open-component-model/bindings/go/oci/internal/log"
+	"ocm.software/open-component-model/bindings/go/oci/spec"
+	"ocm.software/open-component-model/bindings/go/repository"
+)
+
+var _ repository.ConditionalComponentVersionRepository = (*Repository)(nil)
+
+// GetComponentVersionDigest returns the digest of the manifest the tag of the component version points to.
+func (repo *Repository) GetComponentVersionDigest(ctx context.Context, component, version string) (_ string, err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "get component version digest",
+		slog.String("component", component),
+		slog.String("version", version))
+	defer func() {
+		err = classify.Error(err)
+		done(err)
+	}()
+
+	_, manifest, err := repo.resolveComponentVersionManifest(ctx, component, version)
+	if err != nil {
+		return "", err
+	}
+	return manifest.Digest.String(), nil
+}
+
+// AddComponentVersionIf adds the component version like AddComponentVersion if the tag of the component
+// version points to the manifest digest expected by the precondition.
+//
+// OCI registries do not support conditional tag updates, so the precondition is checked when the
+// publication starts and again right before the tag is moved. This detects concurrent publishers
+// unless their tag updates fall exactly between the final check and the tag update.
+func (repo *Repository) AddComponentVersionIf(ctx context.Context, descriptor *descriptor.Descriptor, precondition repository.AddComponentVersionPrecondition) error {
+	return repo.addComponentVersion(ctx, descriptor, precondition)
+}
+
+// checkTagPrecondition checks the precondition against the manifest the reference currently resolves to.
+func checkTagPrecondition(ctx context.Context, store spec.Store, reference, component, version string, precondition repository.AddComponentVersionPrecondition) error {
+	if precondition == (repository.AddComponentVersionPrecondition{}) {
+		return nil
+	}
+	var current string
+	manifest, err := store.Resolve(ctx, reference)
+	switch {
+	case errors.Is(err, errdef.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to resolve component version %s/%s to check precondition: %w", component, version, err)
+	default:
+		current = manifest.Digest.String()
+	}
+	return precondition.Check(component, version, current)
+}
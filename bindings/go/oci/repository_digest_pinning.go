@@ -0,0 +1,45 @@
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/looseref"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
+)
+
+var _ pinning.Resolver = (*Repository)(nil)
+
+// DigestPinningPolicy returns the digest pinning policy of the repository.
+func (repo *Repository) DigestPinningPolicy() DigestPinningPolicy {
+	return repo.digestPinningPolicy
+}
+
+// ResolveDigest resolves the tag of the image reference against its registry and returns the digest
+// of the artifact it currently references.
+func (repo *Repository) ResolveDigest(ctx context.Context, imageReference string) (digest.Digest, error) {
+	src, err := repo.resolver.StoreForReference(ctx, imageReference)
+	if err != nil {
+		return "", err
+	}
+	ref, err := looseref.ParseReference(imageReference)
+	if err != nil {
+		return "", fmt.Errorf("error parsing image reference %q: %w", imageReference, err)
+	}
+	desc, err := src.Resolve(ctx, ref.ReferenceOrTag())
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve reference %q to a digest: %w", imageReference, err)
+	}
+	return desc.Digest, nil
+}
+
+// pinResource applies the digest pinning policy of the repository to a resource before it is downloaded.
+// With DigestPinningPolicyResolve, the tag of the access is resolved against the registry and has to reference
+// the artifact described by the resource digest, so that a tag that was moved after the component version was
+// signed is not followed.
+func (repo *Repository) pinResource(ctx context.Context, res *descriptor.Resource) (*descriptor.Resource, error) {
+	return pinning.Apply(ctx, res, repo.digestPinningPolicy, repo)
+}
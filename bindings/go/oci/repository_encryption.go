@@ -0,0 +1,133 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	ociblob "ocm.software/open-component-model/bindings/go/oci/blob"
+	"ocm.software/open-component-model/bindings/go/oci/encryption"
+)
+
+// encryptLocalResource prepares a local resource and its blob for upload.
+//
+// A resource that was read from an encrypted local blob is added with its decrypted blob, so
+// its encryption metadata and digest no longer describe the blob and are dropped.
+// If the repository encrypts local blobs, the blob is encrypted and returned together with
+// the encryption metadata and the media type of the plain blob, which are recorded in the
+// access with recordLocalResourceEncryption after upload.
+func (repo *Repository) encryptLocalResource(
+	ctx context.Context,
+	resource *descriptor.Resource,
+	b blob.ReadOnlyBlob,
+) (blob.ReadOnlyBlob, *descriptor.LocalBlobEncryption, string, error) {
+	access := &v2.LocalBlob{}
+	if resource.Access == nil || repo.scheme.Convert(resource.Access, access) != nil {
+		// not a local blob, packing reports the invalid access.
+		return b, nil, "", nil
+	}
+	if access.Encryption != nil {
+		access.Encryption = nil
+		resource.Access = access
+		resource.Digest = nil
+	}
+	if repo.localBlobEncryptionKey == nil {
+		return b, nil, "", nil
+	}
+
+	// the digest of the resource describes the plain blob, the stored blob is described by the
+	// digest of the ciphertext set during upload.
+	if resource.Digest != nil {
+		if _, err := ociblob.NewArtifactBlob(resource, b); err != nil {
+			return nil, nil, "", err
+		}
+		resource.Digest = nil
+	}
+
+	mediaType := access.MediaType
+	if mediaType == "" {
+		if mediaTypeAware, ok := b.(blob.MediaTypeAware); ok {
+			mediaType, _ = mediaTypeAware.MediaType()
+		}
+	}
+	if mediaType == "" {
+		return nil, nil, "", errors.New("blob media type is unknown and cannot be encrypted")
+	}
+
+	plaintext, err := readAll(b)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to read blob to encrypt: %w", err)
+	}
+	ciphertext, metadata, err := encryption.Encrypt(ctx, repo.localBlobEncryptionKey, plaintext, resourceAdditionalData(resource))
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to encrypt blob: %w", err)
+	}
+
+	// the ciphertext is stored as an opaque layer, independent of the media type of the plain blob.
+	access.MediaType = encryption.MediaTypeEncryptedBlob
+	resource.Access = access
+
+	return inmemory.New(bytes.NewReader(ciphertext), inmemory.WithMediaType(encryption.MediaTypeEncryptedBlob)), metadata, mediaType, nil
+}
+
+// recordLocalResourceEncryption records the encryption metadata and the media type of the plain
+// blob in the local blob access of an uploaded resource.
+// A global access is removed, as it would serve the ciphertext to consumers bypassing decryption.
+func (repo *Repository) recordLocalResourceEncryption(resource *descriptor.Resource, metadata *descriptor.LocalBlobEncryption, mediaType string) error {
+	access := &v2.LocalBlob{}
+	if err := repo.scheme.Convert(resource.Access, access); err != nil {
+		return fmt.Errorf("failed to convert resource access to local blob: %w", err)
+	}
+	access.MediaType = mediaType
+	access.GlobalAccess = nil
+	access.Encryption = (*v2.LocalBlobEncryption)(metadata)
+	resource.Access = access
+	return nil
+}
+
+// decryptLocalResource decrypts the blob of a resource if its local blob access is encrypted.
+// The resource is returned as stored, so its access keeps the encryption metadata and its digest
+// describes the encrypted blob.
+func (repo *Repository) decryptLocalResource(ctx context.Context, resource *descriptor.Resource, b blob.ReadOnlyBlob) (blob.ReadOnlyBlob, error) {
+	access := &v2.LocalBlob{}
+	if err := repo.scheme.Convert(resource.Access, access); err != nil {
+		return nil, fmt.Errorf("error converting resource access: %w", err)
+	}
+	if access.Encryption == nil {
+		return b, nil
+	}
+
+	ciphertext, err := readAll(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted blob: %w", err)
+	}
+	plaintext, err := encryption.Decrypt(ctx, repo.localBlobDecryptionKeys, (*descriptor.LocalBlobEncryption)(access.Encryption), ciphertext, resourceAdditionalData(resource))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt resource %s: %w", resource.ToIdentity(), err)
+	}
+	return inmemory.New(bytes.NewReader(plaintext), inmemory.WithMediaType(access.MediaType)), nil
+}
+
+// resourceAdditionalData returns the additional authenticated data local resource blobs are encrypted with.
+// It binds the ciphertext to the identity of the resource, so that the encrypted blob of one resource
+// cannot be passed off as the blob of another one.
+func resourceAdditionalData(resource *descriptor.Resource) []byte {
+	return []byte(resource.ToIdentity().String())
+}
+
+func readAll(b blob.ReadOnlyBlob) (_ []byte, err error) {
+	rc, err := b.ReadCloser()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, rc.Close())
+	}()
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,170 @@
+package oci
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	slogcontext "github.com/veqryn/slog-context"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+
+	"ocm.software/open-component-model/bindings/go/oci/internal/classify"
+	"ocm.software/open-component-model/bindings/go/oci/internal/log"
+	"ocm.software/open-component-model/bindings/go/oci/internal/pack"
+	"ocm.software/open-component-model/bindings/go/oci/internal/validate"
+	"ocm.software/open-component-model/bindings/go/oci/spec"
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+	"ocm.software/open-component-model/bindings/go/repository"
+)
+
+var _ repository.ComponentVersionMetadataRepository = (*Repository)(nil)
+
+// AppendComponentVersionMetadata attaches metadata to an existing component version.
+// The metadata is pushed as a referrer manifest whose subject is the component version manifest,
+// so neither the component descriptor nor the manifest of the component version are changed.
+func (repo *Repository) AppendComponentVersionMetadata(ctx context.Context, component, version string, metadata repository.ComponentVersionMetadata) (err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "append component version metadata",
+		slog.String("component", component),
+		slog.String("version", version),
+		slog.String("metadata", metadata.Name))
+	defer func() {
+		err = classify.Error(err)
+		done(err)
+	}()
+
+	if err := repo.checkWritable("append component version metadata"); err != nil {
+		return err
+	}
+
+	if metadata.Name == "" {
+		return fmt.Errorf("component version metadata requires a name")
+	}
+	if !json.Valid(metadata.Value) {
+		return fmt.Errorf("value of component version metadata %q is not valid json", metadata.Name)
+	}
+	if metadata.CreatedAt.IsZero() {
+		metadata.CreatedAt = time.Now().UTC()
+	}
+
+	store, subject, err := repo.resolveComponentVersionManifest(ctx, component, version)
+	if err != nil {
+		return err
+	}
+
+	manifestDesc, manifestBody, layerDesc, layerBody, err := pack.MetadataReferrer(subject, component, version, metadata)
+	if err != nil {
+		return fmt.Errorf("failed to build component version metadata referrer: %w", err)
+	}
+	// OCI registries reject a manifest that references blobs not yet present
+	// (MANIFEST_BLOB_UNKNOWN), so push the config and layer before the manifest itself.
+	empty := ociImageSpecV1.DescriptorEmptyJSON
+	if err := store.Push(ctx, empty, bytes.NewReader(empty.Data)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push component version metadata empty blob %s: %w", empty.Digest, err)
+	}
+	if err := store.Push(ctx, layerDesc, bytes.NewReader(layerBody)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push component version metadata %s: %w", layerDesc.Digest, err)
+	}
+	if err := store.Push(ctx, manifestDesc, bytes.NewReader(manifestBody)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push component version metadata referrer %s: %w", manifestDesc.Digest, err)
+	}
+
+	return nil
+}
+
+// ListComponentVersionMetadata returns the metadata attached to a component version with
+// AppendComponentVersionMetadata, ordered by creation time.
+func (repo *Repository) ListComponentVersionMetadata(ctx context.Context, component, version string) (_ []repository.ComponentVersionMetadata, err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "list component version metadata",
+		slog.String("component", component),
+		slog.String("version", version))
+	defer func() {
+		err = classify.Error(err)
+		done(err)
+	}()
+
+	store, subject, err := repo.resolveComponentVersionManifest(ctx, component, version)
+	if err != nil {
+		return nil, err
+	}
+
+	referrers, err := registry.Referrers(ctx, store, subject, annotations.MetadataArtifactType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component version metadata referrers: %w", err)
+	}
+
+	result := make([]repository.ComponentVersionMetadata, 0, len(referrers))
+	for _, referrer := range referrers {
+		metadata, err := fetchComponentVersionMetadata(ctx, store, referrer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch component version metadata referrer %s: %w", referrer.Digest, err)
+		}
+		result = append(result, metadata)
+	}
+	// the order of referrers is not defined, entries created at the same time are ordered by name.
+	slices.SortFunc(result, func(a, b repository.ComponentVersionMetadata) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.Name, b.Name))
+	})
+
+	return result, nil
+}
+
+// resolveComponentVersionManifest resolves the manifest of a component version and validates
+// that it belongs to the component.
+func (repo *Repository) resolveComponentVersionManifest(ctx context.Context, component, version string) (spec.Store, ociImageSpecV1.Descriptor, error) {
+	reference, store, err := repo.getStore(ctx, component, version)
+	if err != nil {
+		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to get store for component version %s/%s: %w", component, version, err)
+	}
+
+	subject, err := store.Resolve(ctx, reference)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return nil, ociImageSpecV1.Descriptor{}, errors.Join(repository.ErrNotFound,
+				fmt.Errorf("component version %s/%s not found: %w", component, version, err))
+		}
+		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to resolve component version %s/%s: %w", component, version, err)
+	}
+
+	if _, err := validate.ComponentVersionDescriptor(ctx, store, subject, component, reference); err != nil {
+		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("reference %q does not point to a valid OCM component version: %w", reference, err)
+	}
+
+	return store, subject, nil
+}
+
+func fetchComponentVersionMetadata(ctx context.Context, store spec.Store, referrer ociImageSpecV1.Descriptor) (repository.ComponentVersionMetadata, error) {
+	var metadata repository.ComponentVersionMetadata
+
+	manifestBody, err := content.FetchAll(ctx, store, referrer)
+	if err != nil {
+		return metadata, err
+	}
+	var manifest ociImageSpecV1.Manifest
+	if err := json.Unmarshal(manifestBody, &manifest); err != nil {
+		return metadata, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != annotations.MetadataMediaType {
+		return metadata, fmt.Errorf("expected a single layer of media type %s", annotations.MetadataMediaType)
+	}
+
+	layerBody, err := content.FetchAll(ctx, store, manifest.Layers[0])
+	if err != nil {
+		return metadata, err
+	}
+	if err := json.Unmarshal(layerBody, &metadata); err != nil {
+		return metadata, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	return metadata, nil
+}
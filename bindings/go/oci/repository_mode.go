@@ -0,0 +1,100 @@
+package oci
+
+import (
+	"errors"
+	"fmt"
+
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+// ErrWriteDenied is returned (wrapped in a [*WriteDeniedError]) by all write operations
+// of a Repository that is not in RepositoryModeReadWrite.
+var ErrWriteDenied = errors.New("write operation denied")
+
+// RepositoryMode defines which operations are allowed on a Repository.
+type RepositoryMode int
+
+const (
+	// RepositoryModeReadWrite allows all operations on the repository.
+	// This is the default (zero value).
+	RepositoryModeReadWrite RepositoryMode = iota
+	// RepositoryModeReadOnly rejects all write operations on the repository.
+	RepositoryModeReadOnly
+	// RepositoryModeMaintenance rejects all write operations on the repository
+	// and reports the configured maintenance message to the caller.
+	// It is intended to freeze a registry temporarily, e.g. during a migration.
+	RepositoryModeMaintenance
+)
+
+func (m RepositoryMode) String() string {
+	switch m {
+	case RepositoryModeReadWrite:
+		return "readwrite"
+	case RepositoryModeReadOnly:
+		return "readonly"
+	case RepositoryModeMaintenance:
+		return "maintenance"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(m))
+	}
+}
+
+// WriteDeniedError is returned by write operations (such as AddComponentVersion or UploadResource)
+// if the repository mode does not allow writes.
+// It matches ErrWriteDenied with [errors.Is], and is classified as temporary in maintenance mode
+// and as a failed precondition otherwise.
+type WriteDeniedError struct {
+	// Operation is the rejected operation, e.g. "add component version".
+	Operation string
+	// Mode is the mode of the repository that rejected the operation.
+	Mode RepositoryMode
+	// Message is the maintenance message configured for the repository, if any.
+	Message string
+}
+
+func (e *WriteDeniedError) Error() string {
+	var reason string
+	switch e.Mode {
+	case RepositoryModeMaintenance:
+		reason = "repository is in maintenance mode"
+	case RepositoryModeReadOnly:
+		reason = "repository is read-only"
+	default:
+		reason = fmt.Sprintf("repository mode %s does not allow writes", e.Mode)
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("%s denied: %s: %s", e.Operation, reason, e.Message)
+	}
+	return fmt.Sprintf("%s denied: %s", e.Operation, reason)
+}
+
+func (e *WriteDeniedError) Is(target error) bool {
+	switch target {
+	case ErrWriteDenied:
+		return true
+	case ocmerrors.ErrTemporary:
+		return e.Mode == RepositoryModeMaintenance
+	case ocmerrors.ErrPreconditionFailed:
+		return e.Mode != RepositoryModeMaintenance
+	default:
+		return false
+	}
+}
+
+// Mode returns the mode of the repository.
+func (repo *Repository) Mode() RepositoryMode {
+	return repo.mode
+}
+
+// checkWritable returns a [*WriteDeniedError] for the given operation
+// if the repository mode does not allow writes.
+func (repo *Repository) checkWritable(operation string) error {
+	if repo.mode == RepositoryModeReadWrite {
+		return nil
+	}
+	return &WriteDeniedError{
+		Operation: operation,
+		Mode:      repo.mode,
+		Message:   repo.maintenanceMessage,
+	}
+}
@@ -9,15 +9,20 @@ import (
 	slogcontext "github.com/veqryn/slog-context"
 	"oras.land/oras-go/v2"
 
+	"ocm.software/open-component-model/bindings/go/blob/filesystem/cache"
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci/encryption"
 	"ocm.software/open-component-model/bindings/go/oci/internal/log"
 	"ocm.software/open-component-model/bindings/go/oci/internal/policy"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
 	ocmoci "ocm.software/open-component-model/bindings/go/oci/spec/access"
 	"ocm.software/open-component-model/bindings/go/oci/spec/descriptor"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
-var DefaultRepositoryScheme = runtime.NewScheme()
+// DefaultRepositoryScheme decodes the access specifications of descriptors read from registries,
+// which are rejected if they are larger than runtime.DefaultMaxRawSize.
+var DefaultRepositoryScheme = runtime.NewScheme(runtime.WithMaxRawSize(runtime.DefaultMaxRawSize))
 
 func init() {
 	ocmoci.MustAddToScheme(DefaultRepositoryScheme)
@@ -40,6 +45,11 @@ type RepositoryOptions struct {
 	// CopyOptions are the options for copying resources between sources and targets
 	ResourceCopyOptions *oras.CopyOptions
 
+	// Concurrency is the number of blobs that are pushed and pulled in parallel when copying
+	// OCI artifacts, e.g. the layers of an OCI image layout added with AddLocalResource.
+	// If set, it overrides the concurrency of ResourceCopyOptions. Defaults to DefaultConcurrency.
+	Concurrency int
+
 	// ReferrerTrackingPolicy defines how OCI referrers are used to track component versions.
 	ReferrerTrackingPolicy ReferrerTrackingPolicy
 
@@ -62,8 +72,53 @@ type RepositoryOptions struct {
 	// to discourage reliance on global access references.
 	// Set to GlobalAccessPolicyAuto to auto-detect based on the storage backend.
 	GlobalAccessPolicy GlobalAccessPolicy
+
+	// Mode restricts the operations allowed on the repository.
+	// By default (zero value), all operations are allowed.
+	Mode RepositoryMode
+
+	// MaintenanceMessage is reported to callers of write operations
+	// that are rejected because of Mode.
+	MaintenanceMessage string
+
+	// ResumableDownloadDir is the directory in which downloads of resources and sources keep
+	// their partial state, so that interrupted downloads are resumed instead of restarted.
+	// If empty, downloads are not resumable. See the resumable package for details.
+	ResumableDownloadDir string
+
+	// LocalBlobEncryptionKey wraps the data keys local resource blobs are encrypted with at rest.
+	// If not provided, local resource blobs are stored unencrypted. See the encryption package for details.
+	LocalBlobEncryptionKey encryption.KeyWrapper
+
+	// LocalBlobDecryptionKeys unwrap the data keys of encrypted local resource blobs, which are then
+	// decrypted transparently. Getting an encrypted local resource without a matching key fails.
+	LocalBlobDecryptionKeys []encryption.KeyUnwrapper
+
+	// DeleteReferrers makes DeleteComponentVersion delete the referrers of a component version
+	// (e.g. appended metadata or signatures of other tools) together with the component version.
+	// By default, referrers are kept and only lose their subject.
+	DeleteReferrers bool
+
+	// MaintainVersionIndex makes AddComponentVersion and DeleteComponentVersion maintain a component
+	// version index (see indexv1.VersionIndexTag) next to the component versions.
+	// ListComponentVersions falls back to this index on registries that neither support
+	// the Referrers API nor tag listing.
+	MaintainVersionIndex bool
+
+	// BlobCache caches the local blobs fetched by GetLocalResource and GetLocalSource on disk, so that
+	// repeated calls for the same digest are served without fetching the blob from the store again.
+	// Local blobs that are OCI artifacts themselves are not cached. If not provided, blobs are not cached.
+	BlobCache *cache.Cache
+
+	// DigestPinningPolicy defines how OCI image accesses of resources that reference a tag without
+	// a digest are treated when the resources are downloaded or their digest is processed.
+	// By default (zero value), such accesses are accepted. See the pinning package for details.
+	DigestPinningPolicy DigestPinningPolicy
 }
 
+// DefaultConcurrency is the default number of blobs pushed and pulled in parallel, see RepositoryOptions.Concurrency.
+const DefaultConcurrency = 8
+
 // ReferrerTrackingPolicy defines how OCI referrers are used in the repository.
 // see https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
 type ReferrerTrackingPolicy int
@@ -99,6 +154,20 @@ const (
 	GlobalAccessPolicyAuto = policy.GlobalAccessPolicyAuto
 )
 
+// DigestPinningPolicy is an alias for [pinning.Policy].
+type DigestPinningPolicy = pinning.Policy
+
+const (
+	// DigestPinningPolicyNone accepts OCI image accesses that are not pinned to a digest.
+	// This is the default (zero value).
+	DigestPinningPolicyNone = pinning.PolicyNone
+	// DigestPinningPolicyEnforce refuses OCI image accesses that are not pinned to a digest.
+	DigestPinningPolicyEnforce = pinning.PolicyEnforce
+	// DigestPinningPolicyResolve pins OCI image accesses that are not pinned to a digest to the
+	// digest their tag resolves to in the registry, which has to match the digest of the resource.
+	DigestPinningPolicyResolve = pinning.PolicyResolve
+)
+
 // RepositoryOption is a function that modifies RepositoryOptions.
 type RepositoryOption func(*RepositoryOptions)
 
@@ -167,6 +236,79 @@ func WithGlobalAccessPolicy(policy GlobalAccessPolicy) RepositoryOption {
 	}
 }
 
+// WithRepositoryMode sets the mode of the repository.
+// Write operations on a repository that is not in RepositoryModeReadWrite fail with a [*WriteDeniedError].
+func WithRepositoryMode(mode RepositoryMode) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.Mode = mode
+	}
+}
+
+// WithMaintenanceMessage sets the message reported to callers of rejected write operations.
+func WithMaintenanceMessage(message string) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.MaintenanceMessage = message
+	}
+}
+
+// WithResumableDownloadDir makes downloads of resources and sources resumable by keeping
+// their partial state in the given directory.
+func WithResumableDownloadDir(dir string) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.ResumableDownloadDir = dir
+	}
+}
+
+// WithLocalBlobEncryptionKey encrypts local resource blobs at rest with data keys wrapped by the key.
+func WithLocalBlobEncryptionKey(key encryption.KeyWrapper) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.LocalBlobEncryptionKey = key
+	}
+}
+
+// WithLocalBlobDecryptionKeys sets the keys encrypted local resource blobs are decrypted with.
+func WithLocalBlobDecryptionKeys(keys ...encryption.KeyUnwrapper) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.LocalBlobDecryptionKeys = append(o.LocalBlobDecryptionKeys, keys...)
+	}
+}
+
+// WithDeleteReferrers makes DeleteComponentVersion delete the referrers of a component version as well.
+func WithDeleteReferrers(deleteReferrers bool) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.DeleteReferrers = deleteReferrers
+	}
+}
+
+// WithMaintainVersionIndex makes the repository maintain a component version index on publish and delete.
+func WithMaintainVersionIndex(maintain bool) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.MaintainVersionIndex = maintain
+	}
+}
+
+// WithConcurrency sets the number of blobs that are pushed and pulled in parallel when copying OCI artifacts.
+func WithConcurrency(concurrency int) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// WithBlobCache caches the local blobs fetched from the repository in the given cache.
+// The cache can be shared by multiple repositories.
+func WithBlobCache(c *cache.Cache) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.BlobCache = c
+	}
+}
+
+// WithDigestPinningPolicy sets the digest pinning policy for OCI image accesses of the repository.
+func WithDigestPinningPolicy(policy DigestPinningPolicy) RepositoryOption {
+	return func(o *RepositoryOptions) {
+		o.DigestPinningPolicy = policy
+	}
+}
+
 // NewRepository creates a new Repository instance with the given options.
 func NewRepository(opts ...RepositoryOption) (*Repository, error) {
 	options := &RepositoryOptions{}
@@ -201,7 +343,7 @@ func NewRepository(opts ...RepositoryOption) (*Repository, error) {
 	if options.ResourceCopyOptions == nil {
 		options.ResourceCopyOptions = &oras.CopyOptions{
 			CopyGraphOptions: oras.CopyGraphOptions{
-				Concurrency: 8,
+				Concurrency: DefaultConcurrency,
 				PreCopy: func(ctx context.Context, desc ociImageSpecV1.Descriptor) error {
 					slogcontext.FromCtx(ctx).DebugContext(ctx, "copying", log.DescriptorLogAttr(desc))
 					return nil
@@ -218,16 +360,33 @@ func NewRepository(opts ...RepositoryOption) (*Repository, error) {
 		}
 	}
 
+	resourceCopyOptions := *options.ResourceCopyOptions
+	switch {
+	case options.Concurrency > 0:
+		resourceCopyOptions.Concurrency = options.Concurrency
+	case resourceCopyOptions.Concurrency <= 0:
+		resourceCopyOptions.Concurrency = DefaultConcurrency
+	}
+
 	return &Repository{
 		scheme:                      options.Scheme,
 		resolver:                    options.Resolver,
 		creatorAnnotation:           options.Creator,
-		resourceCopyOptions:         *options.ResourceCopyOptions,
+		resourceCopyOptions:         resourceCopyOptions,
 		referrerTrackingPolicy:      options.ReferrerTrackingPolicy,
 		descriptorEncodingMediaType: options.DescriptorEncodingMediaType,
 		logger:                      options.Logger,
 		unmarshalDescriptorFunc:     options.DescriptorUnmarshalFunc,
 		tempDir:                     options.TempDir,
 		globalAccessPolicy:          options.GlobalAccessPolicy,
+		mode:                        options.Mode,
+		maintenanceMessage:          options.MaintenanceMessage,
+		resumableDownloadDir:        options.ResumableDownloadDir,
+		localBlobEncryptionKey:      options.LocalBlobEncryptionKey,
+		localBlobDecryptionKeys:     options.LocalBlobDecryptionKeys,
+		deleteReferrers:             options.DeleteReferrers,
+		maintainVersionIndex:        options.MaintainVersionIndex,
+		blobCache:                   options.BlobCache,
+		digestPinningPolicy:         options.DigestPinningPolicy,
 	}, nil
 }
@@ -0,0 +1,193 @@
+package oci
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	slogcontext "github.com/veqryn/slog-context"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci/internal/classify"
+	"ocm.software/open-component-model/bindings/go/oci/internal/log"
+	"ocm.software/open-component-model/bindings/go/oci/internal/pack"
+	"ocm.software/open-component-model/bindings/go/oci/spec"
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+)
+
+// ErrSignatureExists is returned if a signature is attached to a component version
+// that already has a signature with the same name.
+var ErrSignatureExists = errors.New("signature already exists")
+
+var _ ComponentVersionSignatureRepository = (*Repository)(nil)
+
+// AddComponentVersionSignature attaches a signature to an existing component version.
+// The signature is pushed as a referrer manifest whose subject is the component version manifest,
+// so neither the component descriptor nor the manifest of the component version are changed.
+func (repo *Repository) AddComponentVersionSignature(ctx context.Context, component, version string, signature descriptor.Signature) (err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "add component version signature",
+		slog.String("component", component),
+		slog.String("version", version),
+		slog.String("signature", signature.Name))
+	defer func() {
+		err = classify.Error(err)
+		done(err)
+	}()
+
+	if err := repo.checkWritable("add component version signature"); err != nil {
+		return err
+	}
+	if err := validateSignature(signature); err != nil {
+		return err
+	}
+
+	desc, err := repo.GetComponentVersion(ctx, component, version)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(desc.Signatures, func(s descriptor.Signature) bool { return s.Name == signature.Name }) {
+		return fmt.Errorf("%w: %q is part of the component descriptor of %s/%s", ErrSignatureExists, signature.Name, component, version)
+	}
+
+	store, subject, err := repo.resolveComponentVersionManifest(ctx, component, version)
+	if err != nil {
+		return err
+	}
+	attached, err := listSignatures(ctx, store, subject)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(attached, func(s descriptor.Signature) bool { return s.Name == signature.Name }) {
+		return fmt.Errorf("%w: %q is already attached to %s/%s", ErrSignatureExists, signature.Name, component, version)
+	}
+
+	manifestDesc, manifestBody, layerDesc, layerBody, err := pack.SignatureReferrer(subject, component, version, signature)
+	if err != nil {
+		return fmt.Errorf("failed to build component version signature referrer: %w", err)
+	}
+	// OCI registries reject a manifest that references blobs not yet present
+	// (MANIFEST_BLOB_UNKNOWN), so push the config and layer before the manifest itself.
+	empty := ociImageSpecV1.DescriptorEmptyJSON
+	if err := store.Push(ctx, empty, bytes.NewReader(empty.Data)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push component version signature empty blob %s: %w", empty.Digest, err)
+	}
+	if err := store.Push(ctx, layerDesc, bytes.NewReader(layerBody)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push component version signature %s: %w", layerDesc.Digest, err)
+	}
+	if err := store.Push(ctx, manifestDesc, bytes.NewReader(manifestBody)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push component version signature referrer %s: %w", manifestDesc.Digest, err)
+	}
+
+	return nil
+}
+
+// ListComponentVersionSignatures returns the signatures attached to a component version with
+// AddComponentVersionSignature, ordered by name.
+func (repo *Repository) ListComponentVersionSignatures(ctx context.Context, component, version string) (_ []descriptor.Signature, err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "list component version signatures",
+		slog.String("component", component),
+		slog.String("version", version))
+	defer func() {
+		err = classify.Error(err)
+		done(err)
+	}()
+
+	store, subject, err := repo.resolveComponentVersionManifest(ctx, component, version)
+	if err != nil {
+		return nil, err
+	}
+	return listSignatures(ctx, store, subject)
+}
+
+// MergeSignatures returns a view of desc with the attached signatures appended to the signatures
+// of the component descriptor. Signatures of the descriptor take precedence over attached signatures
+// with the same name.
+//
+// desc is not modified. The view shares all content with desc except for the signatures.
+func MergeSignatures(desc *descriptor.Descriptor, signatures []descriptor.Signature) *descriptor.Descriptor {
+	if len(signatures) == 0 {
+		return desc
+	}
+	view := *desc
+	view.Signatures = slices.Clone(desc.Signatures)
+	for _, signature := range signatures {
+		if slices.ContainsFunc(view.Signatures, func(s descriptor.Signature) bool { return s.Name == signature.Name }) {
+			continue
+		}
+		view.Signatures = append(view.Signatures, signature)
+	}
+	return &view
+}
+
+func validateSignature(signature descriptor.Signature) error {
+	var errs []error
+	if signature.Name == "" {
+		errs = append(errs, errors.New("signature requires a name"))
+	}
+	if signature.Digest.HashAlgorithm == "" || signature.Digest.NormalisationAlgorithm == "" || signature.Digest.Value == "" {
+		errs = append(errs, fmt.Errorf("signature %q requires a digest with hash algorithm, normalisation algorithm and value", signature.Name))
+	}
+	if signature.Signature.Algorithm == "" || signature.Signature.Value == "" {
+		errs = append(errs, fmt.Errorf("signature %q requires an algorithm and a value", signature.Name))
+	}
+	return errors.Join(errs...)
+}
+
+func listSignatures(ctx context.Context, store spec.Store, subject ociImageSpecV1.Descriptor) ([]descriptor.Signature, error) {
+	referrers, err := registry.Referrers(ctx, store, subject, annotations.SignatureArtifactType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component version signature referrers: %w", err)
+	}
+
+	result := make([]descriptor.Signature, 0, len(referrers))
+	for _, referrer := range referrers {
+		signature, err := fetchComponentVersionSignature(ctx, store, referrer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch component version signature referrer %s: %w", referrer.Digest, err)
+		}
+		result = append(result, signature)
+	}
+	// the order of referrers is not defined.
+	slices.SortFunc(result, func(a, b descriptor.Signature) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+func fetchComponentVersionSignature(ctx context.Context, store spec.Store, referrer ociImageSpecV1.Descriptor) (descriptor.Signature, error) {
+	manifestBody, err := content.FetchAll(ctx, store, referrer)
+	if err != nil {
+		return descriptor.Signature{}, err
+	}
+	var manifest ociImageSpecV1.Manifest
+	if err := json.Unmarshal(manifestBody, &manifest); err != nil {
+		return descriptor.Signature{}, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != annotations.SignatureMediaType {
+		return descriptor.Signature{}, fmt.Errorf("expected a single layer of media type %s", annotations.SignatureMediaType)
+	}
+
+	layerBody, err := content.FetchAll(ctx, store, manifest.Layers[0])
+	if err != nil {
+		return descriptor.Signature{}, err
+	}
+	var signature v2.Signature
+	if err := json.Unmarshal(layerBody, &signature); err != nil {
+		return descriptor.Signature{}, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	return *descriptor.ConvertFromV2Signature(&signature), nil
+}
@@ -0,0 +1,148 @@
+package oci
+
+import (
+	"cmp"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/opencontainers/go-digest"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	slogcontext "github.com/veqryn/slog-context"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/internal/classify"
+	"ocm.software/open-component-model/bindings/go/oci/internal/log"
+	"ocm.software/open-component-model/bindings/go/oci/spec"
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+)
+
+const (
+	// ManifestDigestNormalisation is the normalisation algorithm of the digest of cosign signatures.
+	// Their digest is the digest of the component version manifest, which references the component
+	// descriptor by digest, instead of the digest of the normalised component descriptor.
+	// It equals signing.ArtifactDigestNormalisation, so that the signatures are verified against
+	// CosignSignatures.ManifestDigest recorded with signing.WithArtifactDigest.
+	ManifestDigestNormalisation = "ociArtifactDigest/v1"
+	// CosignSignatureAlgorithm is the algorithm of cosign signatures. It is the algorithm of the
+	// sigstore signing handler, which verifies Sigstore bundles.
+	CosignSignatureAlgorithm = "Sigstore/v1alpha1"
+	// cosignSignatureNamePrefix prefixes the names of cosign signatures, which carry no name of their own.
+	cosignSignatureNamePrefix = "cosign-"
+)
+
+var _ CosignSignatureRepository = (*Repository)(nil)
+
+// CosignSignatures are the cosign signatures of a component version, see CosignSignatureRepository.
+type CosignSignatures struct {
+	// Descriptor is the component descriptor held by the signed manifest.
+	Descriptor *descriptor.Descriptor
+	// ManifestDigest is the digest of the signed manifest with the normalisation algorithm
+	// ManifestDigestNormalisation. The signatures are verified against it.
+	ManifestDigest descriptor.Digest
+	// Signatures are the Sigstore bundles attached to the manifest, ordered by name.
+	Signatures []descriptor.Signature
+}
+
+// GetComponentVersionCosignSignatures returns the component descriptor of the component version together with
+// the Sigstore bundles that cosign attached to its manifest as OCI referrers.
+//
+// Each bundle is returned as a signature named after the digest of its referrer manifest. Its digest is the
+// digest of the component version manifest the bundle was attached to, and the descriptor is read from exactly
+// this manifest, so the signatures cover the returned descriptor even if the version is updated concurrently.
+func (repo *Repository) GetComponentVersionCosignSignatures(ctx context.Context, component, version string) (_ *CosignSignatures, err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "get component version cosign signatures",
+		slog.String("component", component),
+		slog.String("version", version))
+	defer func() {
+		err = classify.Error(err)
+		done(err)
+	}()
+
+	store, subject, err := repo.resolveComponentVersionManifest(ctx, component, version)
+	if err != nil {
+		return nil, err
+	}
+	desc, _, _, err := getDescriptorFromStore(ctx, store, subject.Digest.String(), repo.unmarshalDescriptorFunc)
+	if err != nil {
+		return nil, err
+	}
+	hashAlgorithm, err := hashAlgorithmOf(subject.Digest)
+	if err != nil {
+		return nil, err
+	}
+	manifestDigest := descriptor.Digest{
+		HashAlgorithm:          hashAlgorithm,
+		NormalisationAlgorithm: ManifestDigestNormalisation,
+		Value:                  subject.Digest.Encoded(),
+	}
+	signatures, err := listCosignSignatures(ctx, store, subject, manifestDigest)
+	if err != nil {
+		return nil, err
+	}
+	return &CosignSignatures{Descriptor: desc, ManifestDigest: manifestDigest, Signatures: signatures}, nil
+}
+
+func listCosignSignatures(ctx context.Context, store spec.Store, subject ociImageSpecV1.Descriptor, manifestDigest descriptor.Digest) ([]descriptor.Signature, error) {
+	referrers, err := registry.Referrers(ctx, store, subject, annotations.CosignSignatureArtifactType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cosign signature referrers: %w", err)
+	}
+
+	result := make([]descriptor.Signature, 0, len(referrers))
+	for _, referrer := range referrers {
+		bundle, err := fetchCosignBundle(ctx, store, referrer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch cosign signature referrer %s: %w", referrer.Digest, err)
+		}
+		result = append(result, descriptor.Signature{
+			Name:   cosignSignatureNamePrefix + referrer.Digest.Encoded()[:12],
+			Digest: manifestDigest,
+			Signature: descriptor.SignatureInfo{
+				Algorithm: CosignSignatureAlgorithm,
+				MediaType: annotations.CosignSignatureArtifactType,
+				Value:     base64.StdEncoding.EncodeToString(bundle),
+			},
+		})
+	}
+	// the order of referrers is not defined.
+	slices.SortFunc(result, func(a, b descriptor.Signature) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+func fetchCosignBundle(ctx context.Context, store spec.Store, referrer ociImageSpecV1.Descriptor) ([]byte, error) {
+	manifestBody, err := content.FetchAll(ctx, store, referrer)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ociImageSpecV1.Manifest
+	if err := json.Unmarshal(manifestBody, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != annotations.CosignSignatureArtifactType {
+		return nil, fmt.Errorf("expected a single layer of media type %s", annotations.CosignSignatureArtifactType)
+	}
+	return content.FetchAll(ctx, store, manifest.Layers[0])
+}
+
+// hashAlgorithmOf returns the OCM hash algorithm of an OCI digest.
+func hashAlgorithmOf(dig digest.Digest) (string, error) {
+	switch dig.Algorithm() {
+	case digest.SHA256:
+		return crypto.SHA256.String(), nil
+	case digest.SHA512:
+		return crypto.SHA512.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm %q of component version manifest", dig.Algorithm())
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/image-spec/specs-go"
@@ -23,24 +28,29 @@ import (
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/filesystem/cache"
 	"ocm.software/open-component-model/bindings/go/blob/inmemory"
 	"ocm.software/open-component-model/bindings/go/ctf"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	"ocm.software/open-component-model/bindings/go/oci"
 	ocictf "ocm.software/open-component-model/bindings/go/oci/ctf"
+	"ocm.software/open-component-model/bindings/go/oci/encryption"
 	"ocm.software/open-component-model/bindings/go/oci/internal/identity"
 	"ocm.software/open-component-model/bindings/go/oci/internal/pack"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
 	"ocm.software/open-component-model/bindings/go/oci/resolver/url"
 	"ocm.software/open-component-model/bindings/go/oci/spec"
 	access "ocm.software/open-component-model/bindings/go/oci/spec/access"
 	v1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
 	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+	indexv1 "ocm.software/open-component-model/bindings/go/oci/spec/index/component/v1"
 	"ocm.software/open-component-model/bindings/go/oci/spec/layout"
 	ocistream "ocm.software/open-component-model/bindings/go/oci/stream"
 	"ocm.software/open-component-model/bindings/go/oci/tar"
 	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 var testScheme = runtime.NewScheme()
@@ -84,6 +94,7 @@ func TestRepository_AddComponentVersion(t *testing.T) {
 	_, err = repo.GetComponentVersion(ctx, desc.Component.Name, desc.Component.Version)
 	r.Error(err)
 	r.ErrorIs(err, repository.ErrNotFound)
+	r.True(ocmerrors.IsNotFound(err))
 
 	// Test adding component version
 	err = repo.AddComponentVersion(ctx, desc)
@@ -1053,6 +1064,56 @@ func TestRepository_ListComponentVersions(t *testing.T) {
 	r.Equal(expectedOrder, versions, "Versions should be sorted in descending order")
 }
 
+func TestRepository_ListComponentVersionsMatching(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	pipeline := Repository(t, ocictf.WithCTF(store), oci.WithCreator("pipeline"))
+	manual := Repository(t, ocictf.WithCTF(store), oci.WithCreator("manual"))
+
+	add := func(repo *oci.Repository, version string, signatures ...string) {
+		desc := &descriptor.Descriptor{
+			Meta: descriptor.Meta{Version: "v2"},
+			Component: descriptor.Component{
+				Provider:      descriptor.Provider{Name: "test-provider"},
+				ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: "ocm.software/test-component", Version: version}},
+			},
+		}
+		for _, name := range signatures {
+			desc.Signatures = append(desc.Signatures, descriptor.Signature{Name: name})
+		}
+		r.NoError(repo.AddComponentVersion(ctx, desc), "Failed to add component version %s", version)
+	}
+	add(pipeline, "1.0.0")
+	add(pipeline, "1.1.0", "release")
+	add(manual, "2.0.0", "release", "audit")
+	add(manual, "2.1.0")
+
+	versions, err := pipeline.ListComponentVersionsMatching(ctx, "ocm.software/test-component")
+	r.NoError(err)
+	r.Equal([]string{"2.1.0", "2.0.0", "1.1.0", "1.0.0"}, versions, "No filter should return all versions")
+
+	versions, err = pipeline.ListComponentVersionsMatching(ctx, "ocm.software/test-component", annotations.MatchCreator("pipeline"))
+	r.NoError(err)
+	r.Equal([]string{"1.1.0", "1.0.0"}, versions)
+
+	versions, err = pipeline.ListComponentVersionsMatching(ctx, "ocm.software/test-component", annotations.MatchSigned())
+	r.NoError(err)
+	r.Equal([]string{"2.0.0", "1.1.0"}, versions)
+
+	versions, err = pipeline.ListComponentVersionsMatching(ctx, "ocm.software/test-component", annotations.MatchSigned("release", "audit"))
+	r.NoError(err)
+	r.Equal([]string{"2.0.0"}, versions)
+
+	versions, err = pipeline.ListComponentVersionsMatching(ctx, "ocm.software/test-component",
+		annotations.MatchCreator("manual"), annotations.MatchSigned())
+	r.NoError(err)
+	r.Equal([]string{"2.0.0"}, versions)
+}
+
 func setupLegacyComponentVersion(t *testing.T, store *ocictf.Store, ctx context.Context, content []byte, resource *descriptor.Resource) {
 	r := require.New(t)
 	// Get a repository store for the component
@@ -2253,6 +2314,14 @@ func TestRepository_UploadResourceStream(t *testing.T) {
 				r.NotNil(res.Digest)
 			},
 		},
+		{
+			name: "reference pinned to a different digest is rejected",
+			refFunc: func(ociImageSpecV1.Descriptor) string {
+				return "test-repo:v1.0.0@sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+			},
+			wantErr:    true,
+			wantErrMsg: "does not match the digest of the target access image reference",
+		},
 	}
 
 	for _, tc := range tests {
@@ -2551,6 +2620,146 @@ func TestRepository_AddOwnershipByReference(t *testing.T) {
 	r.Equal(resolved, resolvedAfter, "the subject must be unchanged by re-running the attach")
 }
 
+// TestRepository_DownloadResource_Resumable verifies that resources downloaded through a
+// resumable download directory are complete and that the directory is cleaned up after
+// the downloaded blob has been read.
+func TestRepository_DownloadResource_Resumable(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	const imageRef = "ghcr.io/acme/large:1.0.0"
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	downloadDir := t.TempDir()
+	repo := Repository(t, ocictf.WithCTF(store), oci.WithResumableDownloadDir(downloadDir))
+
+	imgStore, err := store.StoreForReference(ctx, imageRef)
+	r.NoError(err)
+	layerData := bytes.Repeat([]byte("layer"), 1<<19)
+	layer := content.NewDescriptorFromBytes(ociImageSpecV1.MediaTypeImageLayer, layerData)
+	r.NoError(imgStore.Push(ctx, layer, bytes.NewReader(layerData)))
+	manifest, err := oras.PackManifest(ctx, imgStore, oras.PackManifestVersion1_1, "application/vnd.test.artifact", oras.PackManifestOptions{
+		Layers: []ociImageSpecV1.Descriptor{layer},
+	})
+	r.NoError(err)
+	r.NoError(imgStore.Tag(ctx, manifest, "1.0.0"))
+
+	downloaded, err := repo.DownloadResource(ctx, &descriptor.Resource{
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "large", Version: "1.0.0"}},
+		Type:        "ociArtifact",
+		Access:      &v1.OCIImage{Type: runtime.NewVersionedType(v1.OCIImageType, v1.Version), ImageReference: imageRef},
+	})
+	r.NoError(err)
+
+	imageLayout, err := tar.ReadOCILayout(ctx, downloaded)
+	r.NoError(err)
+	t.Cleanup(func() {
+		r.NoError(imageLayout.Close())
+	})
+	layerRaw, err := imageLayout.Fetch(ctx, layer)
+	r.NoError(err)
+	t.Cleanup(func() {
+		r.NoError(layerRaw.Close())
+	})
+	data, err := io.ReadAll(layerRaw)
+	r.NoError(err)
+	r.Equal(layerData, data)
+
+	r.NoFileExists(filepath.Join(downloadDir, layer.Digest.Algorithm().String(), layer.Digest.Encoded()),
+		"completed downloads must be removed once the resource has been read")
+}
+
+func TestRepository_DigestPinningPolicy(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	const imageRef = "ghcr.io/acme/app:1.0.0"
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	imgStore, err := store.StoreForReference(ctx, imageRef)
+	r.NoError(err)
+
+	push := func(data string) ociImageSpecV1.Descriptor {
+		layer := content.NewDescriptorFromBytes(ociImageSpecV1.MediaTypeImageLayer, []byte(data))
+		r.NoError(imgStore.Push(ctx, layer, bytes.NewReader([]byte(data))))
+		manifest, err := oras.PackManifest(ctx, imgStore, oras.PackManifestVersion1_1, "application/vnd.test.artifact", oras.PackManifestOptions{
+			Layers: []ociImageSpecV1.Descriptor{layer},
+		})
+		r.NoError(err)
+		r.NoError(imgStore.Tag(ctx, manifest, "1.0.0"))
+		return manifest
+	}
+	// the tag is moved to a different artifact after the resource digest was recorded.
+	signed := push("signed")
+	moved := push("moved")
+
+	resource := func(dig *ociImageSpecV1.Descriptor) *descriptor.Resource {
+		res := &descriptor.Resource{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "app", Version: "1.0.0"}},
+			Type:        "ociArtifact",
+			Access:      &v1.OCIImage{Type: runtime.NewVersionedType(v1.OCIImageType, v1.Version), ImageReference: imageRef},
+		}
+		if dig != nil {
+			res.Digest = &descriptor.Digest{
+				HashAlgorithm:          "SHA-256",
+				NormalisationAlgorithm: "genericBlobDigest/v1",
+				Value:                  dig.Digest.Encoded(),
+			}
+		}
+		return res
+	}
+	downloaded := func(repo *oci.Repository, res *descriptor.Resource) digest.Digest {
+		stream, err := repo.DownloadResourceStream(ctx, res)
+		r.NoError(err)
+		ociStream, ok := stream.(*ocistream.OCIResourceStream)
+		r.True(ok)
+		return ociStream.Descriptor.Digest
+	}
+
+	t.Run("none follows the tag", func(t *testing.T) {
+		repo := Repository(t, ocictf.WithCTF(store))
+		require.Equal(t, moved.Digest, downloaded(repo, resource(&signed)))
+	})
+
+	t.Run("enforce refuses tags", func(t *testing.T) {
+		r := require.New(t)
+		repo := Repository(t, ocictf.WithCTF(store), oci.WithDigestPinningPolicy(oci.DigestPinningPolicyEnforce))
+		_, err := repo.DownloadResource(ctx, resource(&signed))
+		r.ErrorIs(err, pinning.ErrUnpinned)
+		r.ErrorIs(err, ocmerrors.ErrPreconditionFailed)
+		_, err = repo.ProcessResourceDigest(ctx, resource(nil))
+		r.ErrorIs(err, pinning.ErrUnpinned)
+
+		pinned := resource(&signed)
+		pinned.Access.(*v1.OCIImage).ImageReference = imageRef + "@" + signed.Digest.String()
+		r.Equal(signed.Digest, downloaded(repo, pinned))
+	})
+
+	t.Run("resolve pins to the digest of the tag", func(t *testing.T) {
+		r := require.New(t)
+		repo := Repository(t, ocictf.WithCTF(store), oci.WithDigestPinningPolicy(oci.DigestPinningPolicyResolve))
+		r.Equal(moved.Digest, downloaded(repo, resource(&moved)))
+		r.Equal(moved.Digest, downloaded(repo, resource(nil)), "without resource digest the tag is resolved")
+
+		_, err := repo.DownloadResourceStream(ctx, resource(&signed))
+		r.ErrorIs(err, pinning.ErrUnpinned, "the tag was moved after the resource digest was recorded")
+		r.ErrorContains(err, "its tag references "+moved.Digest.String())
+
+		dig, err := repo.ResolveDigest(ctx, imageRef)
+		r.NoError(err)
+		r.Equal(moved.Digest, dig)
+
+		processed, err := repo.ProcessResourceDigest(ctx, resource(nil))
+		r.NoError(err)
+		r.Equal(imageRef+"@"+moved.Digest.String(), processed.Access.(*v1.OCIImage).ImageReference)
+		record, err := pinning.GetRecord(processed.Labels)
+		r.NoError(err)
+		r.Equal(&pinning.Record{ImageReference: imageRef, Digest: moved.Digest.String()}, record)
+	})
+}
+
 func TestRepository_AddOwnership_ResolveErrors(t *testing.T) {
 	const (
 		component = "ocm.software/test-component"
@@ -2798,3 +3007,734 @@ func TestRepository_AddOwnership_RawBlobSubjectSkipped(t *testing.T) {
 	r.NoError(err)
 	r.Nil(body, "a raw-blob subject must yield no ownership referrer")
 }
+
+func TestRepository_ComponentVersionMetadata(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	repo := Repository(t, ocictf.WithCTF(store))
+
+	componentName := "ocm.software/test-component"
+	desc := &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			Provider:      descriptor.Provider{Name: "test-provider"},
+			ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: componentName, Version: "1.0.0", Labels: []descriptor.Label{{Name: "signed", Value: []byte(`"original"`), Signing: true}}}},
+		},
+	}
+	r.NoError(repo.AddComponentVersion(ctx, desc))
+	before, err := repo.GetComponentVersion(ctx, componentName, "1.0.0")
+	r.NoError(err)
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.NoError(repo.AppendComponentVersionMetadata(ctx, componentName, "1.0.0", repository.ComponentVersionMetadata{
+		Name: "ocm.software/scan-result", Value: []byte(`{"critical":1}`), CreatedAt: created,
+	}))
+	r.NoError(repo.AppendComponentVersionMetadata(ctx, componentName, "1.0.0", repository.ComponentVersionMetadata{
+		Name: "ocm.software/scan-result", Value: []byte(`{"critical":0}`), CreatedAt: created.Add(time.Hour),
+	}))
+	r.NoError(repo.AppendComponentVersionMetadata(ctx, componentName, "1.0.0", repository.ComponentVersionMetadata{
+		Name: "signed", Value: []byte(`"shadowed"`),
+	}))
+
+	metadata, err := repo.ListComponentVersionMetadata(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.Len(metadata, 3)
+	r.JSONEq(`{"critical":1}`, string(metadata[0].Value))
+	r.JSONEq(`{"critical":0}`, string(metadata[1].Value))
+	r.Equal("signed", metadata[2].Name)
+	r.False(metadata[2].CreatedAt.IsZero())
+
+	// the component descriptor is not changed by appended metadata.
+	after, err := repo.GetComponentVersion(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.Equal(before, after)
+
+	view := repository.MergeComponentVersionMetadata(after, metadata)
+	r.Len(view.Component.Labels, 2)
+	r.Equal("signed", view.Component.Labels[0].Name)
+	r.JSONEq(`"original"`, string(view.Component.Labels[0].Value))
+	r.Equal(descriptor.Label{Name: "ocm.software/scan-result", Value: []byte(`{"critical":0}`)}, view.Component.Labels[1])
+	r.Len(after.Component.Labels, 1)
+
+	_, err = repo.ListComponentVersionMetadata(ctx, componentName, "2.0.0")
+	r.ErrorIs(err, repository.ErrNotFound)
+	err = repo.AppendComponentVersionMetadata(ctx, componentName, "1.0.0", repository.ComponentVersionMetadata{Name: "invalid", Value: []byte(`{`)})
+	r.Error(err)
+}
+
+func TestRepository_ComponentVersionSignatures(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	repo := Repository(t, ocictf.WithCTF(store))
+
+	signature := func(name string) descriptor.Signature {
+		return descriptor.Signature{
+			Name:      name,
+			Digest:    descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v4alpha1", Value: "abc"},
+			Signature: descriptor.SignatureInfo{Algorithm: "RSASSA-PSS", Value: "c2lnbmF0dXJl", MediaType: "application/vnd.ocm.signature.rsa"},
+		}
+	}
+
+	componentName := "ocm.software/test-component"
+	desc := &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			Provider:      descriptor.Provider{Name: "test-provider"},
+			ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: componentName, Version: "1.0.0"}},
+		},
+		Signatures: []descriptor.Signature{signature("build")},
+	}
+	r.NoError(repo.AddComponentVersion(ctx, desc))
+	before, err := repo.GetComponentVersion(ctx, componentName, "1.0.0")
+	r.NoError(err)
+
+	r.NoError(repo.AddComponentVersionSignature(ctx, componentName, "1.0.0", signature("release")))
+	r.NoError(repo.AddComponentVersionSignature(ctx, componentName, "1.0.0", signature("approval")))
+	r.ErrorIs(repo.AddComponentVersionSignature(ctx, componentName, "1.0.0", signature("release")), oci.ErrSignatureExists)
+	r.ErrorIs(repo.AddComponentVersionSignature(ctx, componentName, "1.0.0", signature("build")), oci.ErrSignatureExists)
+	r.Error(repo.AddComponentVersionSignature(ctx, componentName, "1.0.0", descriptor.Signature{Name: "invalid"}))
+
+	signatures, err := repo.ListComponentVersionSignatures(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.Equal([]descriptor.Signature{signature("approval"), signature("release")}, signatures)
+
+	// the component descriptor is not changed by attached signatures.
+	after, err := repo.GetComponentVersion(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.Equal(before, after)
+
+	view := oci.MergeSignatures(after, signatures)
+	r.Equal([]string{"build", "approval", "release"}, []string{view.Signatures[0].Name, view.Signatures[1].Name, view.Signatures[2].Name})
+	r.Len(after.Signatures, 1)
+
+	_, err = repo.ListComponentVersionSignatures(ctx, componentName, "2.0.0")
+	r.ErrorIs(err, repository.ErrNotFound)
+	r.ErrorIs(repo.AddComponentVersionSignature(ctx, componentName, "2.0.0", signature("release")), repository.ErrNotFound)
+}
+
+func TestRepository_ComponentVersionCosignSignatures(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	repo := Repository(t, ocictf.WithCTF(store))
+
+	componentName := "ocm.software/test-component"
+	r.NoError(repo.AddComponentVersion(ctx, &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			Provider:      descriptor.Provider{Name: "test-provider"},
+			ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: componentName, Version: "1.0.0"}},
+		},
+	}))
+	// an OCM signature referrer is not a cosign signature.
+	r.NoError(repo.AddComponentVersionSignature(ctx, componentName, "1.0.0", descriptor.Signature{
+		Name:      "release",
+		Digest:    descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v4alpha1", Value: "abc"},
+		Signature: descriptor.SignatureInfo{Algorithm: "RSASSA-PSS", Value: "c2lnbmF0dXJl", MediaType: "application/vnd.ocm.signature.rsa"},
+	}))
+
+	cosign, err := repo.GetComponentVersionCosignSignatures(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.Equal(componentName, cosign.Descriptor.Component.Name)
+	r.Empty(cosign.Signatures)
+
+	componentStore, err := store.StoreForReference(ctx, store.ComponentVersionReference(ctx, componentName, "1.0.0"))
+	r.NoError(err)
+	subject, err := componentStore.Resolve(ctx, store.ComponentVersionReference(ctx, componentName, "1.0.0"))
+	r.NoError(err)
+
+	// attach a Sigstore bundle the way cosign does with the OCI 1.1 referrers API.
+	bundle := []byte(`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json","dsseEnvelope":{}}`)
+	layer := ociImageSpecV1.Descriptor{MediaType: annotations.CosignSignatureArtifactType, Digest: digest.FromBytes(bundle), Size: int64(len(bundle))}
+	manifestBody, err := json.Marshal(ociImageSpecV1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ociImageSpecV1.MediaTypeImageManifest,
+		ArtifactType: annotations.CosignSignatureArtifactType,
+		Config:       ociImageSpecV1.DescriptorEmptyJSON,
+		Layers:       []ociImageSpecV1.Descriptor{layer},
+		Subject:      &subject,
+	})
+	r.NoError(err)
+	manifest := ociImageSpecV1.Descriptor{
+		MediaType:    ociImageSpecV1.MediaTypeImageManifest,
+		ArtifactType: annotations.CosignSignatureArtifactType,
+		Digest:       digest.FromBytes(manifestBody),
+		Size:         int64(len(manifestBody)),
+	}
+	empty := ociImageSpecV1.DescriptorEmptyJSON
+	r.NoError(componentStore.Push(ctx, empty, bytes.NewReader(empty.Data)))
+	r.NoError(componentStore.Push(ctx, layer, bytes.NewReader(bundle)))
+	r.NoError(componentStore.Push(ctx, manifest, bytes.NewReader(manifestBody)))
+
+	cosign, err = repo.GetComponentVersionCosignSignatures(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.Equal(componentName, cosign.Descriptor.Component.Name)
+	manifestDigest := descriptor.Digest{
+		HashAlgorithm:          "SHA-256",
+		NormalisationAlgorithm: oci.ManifestDigestNormalisation,
+		Value:                  subject.Digest.Encoded(),
+	}
+	r.Equal(manifestDigest, cosign.ManifestDigest)
+	r.Equal([]descriptor.Signature{{
+		Name:   "cosign-" + manifest.Digest.Encoded()[:12],
+		Digest: manifestDigest,
+		Signature: descriptor.SignatureInfo{
+			Algorithm: oci.CosignSignatureAlgorithm,
+			MediaType: annotations.CosignSignatureArtifactType,
+			Value:     base64.StdEncoding.EncodeToString(bundle),
+		},
+	}}, cosign.Signatures)
+
+	_, err = repo.GetComponentVersionCosignSignatures(ctx, componentName, "2.0.0")
+	r.ErrorIs(err, repository.ErrNotFound)
+}
+
+func TestRepository_DeleteComponentVersion(t *testing.T) {
+	for name, policy := range map[string]oci.ReferrerTrackingPolicy{
+		"tags":      oci.ReferrerTrackingPolicyNone,
+		"referrers": oci.ReferrerTrackingPolicyByIndexAndSubject,
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			ctx := t.Context()
+
+			fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+			r.NoError(err)
+			store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+			repo := Repository(t, ocictf.WithCTF(store), oci.WithReferrerTrackingPolicy(policy), oci.WithDeleteReferrers(true))
+
+			componentName := "ocm.software/test-component"
+			for _, version := range []string{"1.0.0", "2.0.0"} {
+				r.NoError(repo.AddComponentVersion(ctx, &descriptor.Descriptor{
+					Meta: descriptor.Meta{Version: "v2"},
+					Component: descriptor.Component{
+						Provider:      descriptor.Provider{Name: "test-provider"},
+						ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: componentName, Version: version}},
+					},
+				}))
+			}
+			r.NoError(repo.AddComponentVersionAlias(ctx, componentName, "1.0.0", "latest"))
+			r.NoError(repo.AppendComponentVersionMetadata(ctx, componentName, "1.0.0", repository.ComponentVersionMetadata{
+				Name: "ocm.software/scan-result", Value: []byte(`{}`),
+			}))
+
+			r.NoError(repo.DeleteComponentVersion(ctx, componentName, "1.0.0"))
+
+			_, err = repo.GetComponentVersion(ctx, componentName, "1.0.0")
+			r.ErrorIs(err, repository.ErrNotFound)
+			_, err = repo.GetComponentVersion(ctx, componentName, "latest")
+			r.ErrorIs(err, repository.ErrNotFound, "aliases of a deleted component version must be removed")
+			versions, err := repo.ListComponentVersions(ctx, componentName)
+			r.NoError(err)
+			r.Equal([]string{"2.0.0"}, versions)
+			got, err := repo.GetComponentVersion(ctx, componentName, "2.0.0")
+			r.NoError(err)
+			r.Equal("2.0.0", got.Component.Version)
+
+			r.ErrorIs(repo.DeleteComponentVersion(ctx, componentName, "1.0.0"), repository.ErrNotFound)
+		})
+	}
+}
+
+// tagListingDisabledResolver returns stores that reject tag listing and do not support referrers,
+// like registries that lock down the tag list API.
+type tagListingDisabledResolver struct {
+	oci.Resolver
+}
+
+func (r tagListingDisabledResolver) StoreForReference(ctx context.Context, reference string) (spec.Store, error) {
+	s, err := r.Resolver.StoreForReference(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	return tagListingDisabledStore{Store: s}, nil
+}
+
+type tagListingDisabledStore struct {
+	spec.Store
+}
+
+func (tagListingDisabledStore) Tags(context.Context, string, func(tags []string) error) error {
+	return errors.New("tag listing is disabled")
+}
+
+type fetchCountingResolver struct {
+	oci.Resolver
+	fetched map[digest.Digest]int
+}
+
+func (r fetchCountingResolver) StoreForReference(ctx context.Context, reference string) (spec.Store, error) {
+	s, err := r.Resolver.StoreForReference(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	return fetchCountingStore{Store: s, fetched: r.fetched}, nil
+}
+
+type fetchCountingStore struct {
+	spec.Store
+	fetched map[digest.Digest]int
+}
+
+func (s fetchCountingStore) Fetch(ctx context.Context, target ociImageSpecV1.Descriptor) (io.ReadCloser, error) {
+	s.fetched[target.Digest]++
+	return s.Store.Fetch(ctx, target)
+}
+
+func TestRepository_BlobCache(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	resolver := fetchCountingResolver{Resolver: ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs)), fetched: map[digest.Digest]int{}}
+	blobCache, err := cache.New(t.TempDir(), 1024)
+	r.NoError(err)
+	repo, err := oci.NewRepository(oci.WithResolver(resolver), oci.WithTempDir(t.TempDir()), oci.WithBlobCache(blobCache))
+	r.NoError(err)
+
+	componentName := "ocm.software/test-component"
+	resourceContent := []byte("cached resource content")
+	contentDigest := digest.FromBytes(resourceContent)
+	resource := &descriptor.Resource{
+		Relation:    descriptor.LocalRelation,
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "my-resource", Version: "1.0.0"}},
+		Type:        "plainData",
+		Access: &v2.LocalBlob{
+			LocalReference: contentDigest.String(),
+			MediaType:      "application/octet-stream",
+		},
+	}
+	newRes, err := repo.AddLocalResource(ctx, componentName, "1.0.0", resource, inmemory.New(bytes.NewReader(resourceContent)))
+	r.NoError(err)
+	r.NoError(repo.AddComponentVersion(ctx, &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			Provider:      descriptor.Provider{Name: "test-provider"},
+			ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: componentName, Version: "1.0.0"}},
+			Resources:     []descriptor.Resource{*newRes},
+		},
+	}))
+
+	for range 3 {
+		b, _, err := repo.GetLocalResource(ctx, componentName, "1.0.0", map[string]string{"name": "my-resource"})
+		r.NoError(err)
+		data, err := b.ReadCloser()
+		r.NoError(err)
+		content, err := io.ReadAll(data)
+		r.NoError(err)
+		r.NoError(data.Close())
+		r.Equal(resourceContent, content)
+	}
+	r.Equal(1, resolver.fetched[contentDigest], "the local blob must only be fetched once")
+	stats := blobCache.Stats()
+	r.Equal(int64(1), stats.Misses)
+	r.Equal(1, stats.Entries)
+	r.Equal(int64(len(resourceContent)), stats.Size)
+}
+
+func TestRepository_MaintainVersionIndex(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	repo := Repository(t, ocictf.WithCTF(store), oci.WithMaintainVersionIndex(true))
+
+	componentName := "ocm.software/test-component"
+	for _, version := range []string{"1.0.0", "2.0.0", "1.1.0"} {
+		r.NoError(repo.AddComponentVersion(ctx, &descriptor.Descriptor{
+			Meta: descriptor.Meta{Version: "v2"},
+			Component: descriptor.Component{
+				Provider:      descriptor.Provider{Name: "test-provider"},
+				ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: componentName, Version: version}},
+			},
+		}))
+	}
+	r.NoError(repo.DeleteComponentVersion(ctx, componentName, "1.1.0"))
+
+	versions, err := repo.ListComponentVersions(ctx, componentName)
+	r.NoError(err)
+	r.Equal([]string{"2.0.0", "1.0.0"}, versions, "the version index tag must not be listed as a version")
+
+	repoStore, err := store.StoreForReference(ctx, store.ComponentVersionReference(ctx, componentName, indexv1.VersionIndexTag))
+	r.NoError(err)
+	indexDesc, err := repoStore.Resolve(ctx, store.ComponentVersionReference(ctx, componentName, indexv1.VersionIndexTag))
+	r.NoError(err)
+	r.Equal(ociImageSpecV1.MediaTypeImageIndex, indexDesc.MediaType)
+
+	locked, err := oci.NewRepository(oci.WithResolver(tagListingDisabledResolver{Resolver: store}), oci.WithTempDir(t.TempDir()))
+	r.NoError(err)
+	versions, err = locked.ListComponentVersions(ctx, componentName)
+	r.NoError(err)
+	r.Equal([]string{"2.0.0", "1.0.0"}, versions, "versions must be listed from the version index")
+
+	_, err = locked.ListComponentVersions(ctx, "ocm.software/other-component")
+	r.ErrorContains(err, "tag listing is disabled", "without a version index the listing error must be returned")
+}
+
+func TestRepository_AddComponentVersionIf(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	repo := Repository(t, ocictf.WithCTF(ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))))
+
+	componentName := "ocm.software/test-component"
+	newDescriptor := func(provider string) *descriptor.Descriptor {
+		return &descriptor.Descriptor{
+			Meta: descriptor.Meta{Version: "v2"},
+			Component: descriptor.Component{
+				Provider:      descriptor.Provider{Name: provider},
+				ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: componentName, Version: "1.0.0"}},
+			},
+		}
+	}
+
+	_, err = repo.GetComponentVersionDigest(ctx, componentName, "1.0.0")
+	r.ErrorIs(err, repository.ErrNotFound)
+
+	mustNotExist := repository.AddComponentVersionPrecondition{MustNotExist: true}
+	r.NoError(repo.AddComponentVersionIf(ctx, newDescriptor("first"), mustNotExist))
+	first, err := repo.GetComponentVersionDigest(ctx, componentName, "1.0.0")
+	r.NoError(err)
+
+	err = repo.AddComponentVersionIf(ctx, newDescriptor("second"), mustNotExist)
+	var conflict *repository.ConflictError
+	r.ErrorAs(err, &conflict)
+	r.Equal(first, conflict.Actual)
+	r.ErrorIs(err, ocmerrors.ErrPreconditionFailed)
+
+	// a concurrent publisher updates the component version
+	r.NoError(repo.AddComponentVersion(ctx, newDescriptor("concurrent")))
+	err = repo.AddComponentVersionIf(ctx, newDescriptor("second"), repository.AddComponentVersionPrecondition{ExpectedDigest: first})
+	r.ErrorIs(err, repository.ErrConflict)
+	desc, err := repo.GetComponentVersion(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.Equal("concurrent", desc.Component.Provider.Name, "the conflicting write must not be applied")
+
+	current, err := repo.GetComponentVersionDigest(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.NotEqual(first, current)
+	r.NoError(repo.AddComponentVersionIf(ctx, newDescriptor("second"), repository.AddComponentVersionPrecondition{ExpectedDigest: current}))
+	desc, err = repo.GetComponentVersion(ctx, componentName, "1.0.0")
+	r.NoError(err)
+	r.Equal("second", desc.Component.Provider.Name)
+}
+
+func TestRepository_WriteDeniedByMode(t *testing.T) {
+	desc := &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			Provider: descriptor.Provider{
+				Name: "test-provider",
+			},
+			ComponentMeta: descriptor.ComponentMeta{
+				ObjectMeta: descriptor.ObjectMeta{
+					Name:    "ocm.software/test-component",
+					Version: "1.0.0",
+				},
+			},
+		},
+	}
+	resource := &descriptor.Resource{
+		ElementMeta: descriptor.ElementMeta{
+			ObjectMeta: descriptor.ObjectMeta{
+				Name:    "test-resource",
+				Version: "1.0.0",
+			},
+		},
+		Type:     "test-type",
+		Relation: descriptor.LocalRelation,
+		Access: &runtime.Raw{
+			Type: runtime.Type{
+				Name:    v2.LocalBlobAccessType,
+				Version: v2.LocalBlobAccessTypeVersion,
+			},
+			Data: []byte(`{"type":"localBlob/v1","localReference":"sha256:1234","mediaType":"application/octet-stream"}`),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		options []oci.RepositoryOption
+		message string
+		reason  error
+	}{
+		{
+			name:    "read-only",
+			options: []oci.RepositoryOption{oci.WithRepositoryMode(oci.RepositoryModeReadOnly)},
+			message: "add component version denied: repository is read-only",
+			reason:  ocmerrors.ErrPreconditionFailed,
+		},
+		{
+			name: "maintenance",
+			options: []oci.RepositoryOption{
+				oci.WithRepositoryMode(oci.RepositoryModeMaintenance),
+				oci.WithMaintenanceMessage("registry migration in progress"),
+			},
+			message: "add component version denied: repository is in maintenance mode: registry migration in progress",
+			reason:  ocmerrors.ErrTemporary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			ctx := t.Context()
+
+			fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+			r.NoError(err)
+			store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+
+			writable := Repository(t, ocictf.WithCTF(store))
+			r.NoError(writable.AddComponentVersion(ctx, desc))
+
+			repo := Repository(t, append(tt.options, ocictf.WithCTF(store))...)
+
+			err = repo.AddComponentVersion(ctx, desc)
+			r.ErrorIs(err, oci.ErrWriteDenied)
+			var denied *oci.WriteDeniedError
+			r.ErrorAs(err, &denied)
+			r.Equal(repo.Mode(), denied.Mode)
+			r.EqualError(err, tt.message)
+			r.Equal(tt.reason, ocmerrors.Reason(err))
+
+			_, err = repo.AddLocalResource(ctx, desc.Component.Name, desc.Component.Version, resource, inmemory.New(bytes.NewReader([]byte("test"))))
+			r.ErrorIs(err, oci.ErrWriteDenied)
+
+			err = repo.AddComponentVersionAlias(ctx, desc.Component.Name, desc.Component.Version, "latest")
+			r.ErrorIs(err, oci.ErrWriteDenied)
+
+			err = repo.RemoveComponentVersionAlias(ctx, desc.Component.Name, "latest")
+			r.ErrorIs(err, oci.ErrWriteDenied)
+
+			err = repo.DeleteComponentVersion(ctx, desc.Component.Name, desc.Component.Version)
+			r.ErrorIs(err, oci.ErrWriteDenied)
+
+			// read operations are still allowed
+			got, err := repo.GetComponentVersion(ctx, desc.Component.Name, desc.Component.Version)
+			r.NoError(err)
+			r.Equal(desc.Component.Name, got.Component.Name)
+
+			versions, err := repo.ListComponentVersions(ctx, desc.Component.Name)
+			r.NoError(err)
+			r.Equal([]string{desc.Component.Version}, versions)
+		})
+	}
+}
+
+func TestRepository_LocalResourceEncryption(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	r.NoError(err)
+	encryptionKey, err := encryption.NewRSAKeyWrapper(&privateKey.PublicKey)
+	r.NoError(err)
+	decryptionKey, err := encryption.NewRSAKeyUnwrapper(privateKey)
+	r.NoError(err)
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	repo := Repository(t, ocictf.WithCTF(store),
+		oci.WithLocalBlobEncryptionKey(encryptionKey),
+		oci.WithLocalBlobDecryptionKeys(decryptionKey),
+	)
+
+	componentName := "ocm.software/test-component"
+	secret := []byte(`{"password":"s3cr3t"}`)
+	resource := &descriptor.Resource{
+		Relation: descriptor.LocalRelation,
+		ElementMeta: descriptor.ElementMeta{
+			ObjectMeta: descriptor.ObjectMeta{Name: "config", Version: "1.0.0"},
+		},
+		Type: "plainData",
+		Access: &v2.LocalBlob{
+			LocalReference: digest.FromBytes(secret).String(),
+			MediaType:      "application/json",
+		},
+	}
+	newRes, err := repo.AddLocalResource(ctx, componentName, "1.0.0", resource, inmemory.New(bytes.NewReader(secret)))
+	r.NoError(err)
+
+	stored := &v2.LocalBlob{}
+	r.NoError(oci.DefaultRepositoryScheme.Convert(newRes.Access, stored))
+	r.Equal("application/json", stored.MediaType, "the access must keep the media type of the plain blob")
+	r.NotNil(stored.Encryption)
+	r.Equal(encryption.AlgorithmAES256GCM, stored.Encryption.Algorithm)
+	r.Equal(encryption.KeyWrapAlgorithmRSAOAEP256, stored.Encryption.KeyWrapAlgorithm)
+	r.Equal(encryptionKey.KeyID(), stored.Encryption.KeyID)
+	r.NotEqual(digest.FromBytes(secret).String(), stored.LocalReference, "the stored blob must be encrypted")
+
+	// a resource pretending to own the encrypted blob of another resource.
+	swapped := newRes.DeepCopy()
+	swapped.Name = "swapped"
+
+	desc := &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			Provider:      descriptor.Provider{Name: "test-provider"},
+			ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: componentName, Version: "1.0.0"}},
+			Resources:     []descriptor.Resource{*newRes, *swapped},
+		},
+	}
+	r.NoError(repo.AddComponentVersion(ctx, desc))
+
+	identity := runtime.Identity{"name": "config", "version": "1.0.0"}
+
+	t.Run("authorized consumers get the plain blob", func(t *testing.T) {
+		r := require.New(t)
+		b, res, err := repo.GetLocalResource(ctx, componentName, "1.0.0", identity)
+		r.NoError(err)
+		data, err := io.ReadAll(mustReadCloser(t, b))
+		r.NoError(err)
+		r.Equal(secret, data)
+		mediaType, _ := b.(blob.MediaTypeAware).MediaType()
+		r.Equal("application/json", mediaType)
+
+		// adding the decrypted resource to an unencrypted repository stores the plain blob.
+		plainRepo := Repository(t, ocictf.WithCTF(ocictf.NewFromCTF(ctf.NewFileSystemCTF(mustFS(t)))))
+		copied, err := plainRepo.AddLocalResource(ctx, componentName, "1.0.0", res, b)
+		r.NoError(err)
+		copiedAccess := &v2.LocalBlob{}
+		r.NoError(oci.DefaultRepositoryScheme.Convert(copied.Access, copiedAccess))
+		r.Nil(copiedAccess.Encryption)
+		r.Equal(digest.FromBytes(secret).String(), copiedAccess.LocalReference)
+	})
+
+	t.Run("encrypted blobs are bound to their resource", func(t *testing.T) {
+		_, _, err := repo.GetLocalResource(ctx, componentName, "1.0.0", runtime.Identity{"name": "swapped", "version": "1.0.0"})
+		require.ErrorContains(t, err, "failed to decrypt blob")
+	})
+
+	t.Run("consumers without a matching key are unauthorized", func(t *testing.T) {
+		r := require.New(t)
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		r.NoError(err)
+		otherDecryptionKey, err := encryption.NewRSAKeyUnwrapper(otherKey)
+		r.NoError(err)
+
+		for _, consumer := range []*oci.Repository{
+			Repository(t, ocictf.WithCTF(store)),
+			Repository(t, ocictf.WithCTF(store), oci.WithLocalBlobDecryptionKeys(otherDecryptionKey)),
+		} {
+			_, _, err := consumer.GetLocalResource(ctx, componentName, "1.0.0", identity)
+			r.ErrorIs(err, encryption.ErrNoDecryptionKey)
+			r.True(ocmerrors.IsUnauthorized(err))
+		}
+	})
+}
+
+func mustReadCloser(t *testing.T, b blob.ReadOnlyBlob) io.ReadCloser {
+	t.Helper()
+	rc, err := b.ReadCloser()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, rc.Close())
+	})
+	return rc
+}
+
+func mustFS(t *testing.T) *filesystem.RootFileSystem {
+	t.Helper()
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	require.NoError(t, err)
+	return fs
+}
+
+// latencyResolver returns stores that delay every push, like a remote registry would.
+type latencyResolver struct {
+	oci.Resolver
+	latency time.Duration
+}
+
+func (r latencyResolver) StoreForReference(ctx context.Context, reference string) (spec.Store, error) {
+	s, err := r.Resolver.StoreForReference(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	return latencyStore{Store: s, latency: r.latency}, nil
+}
+
+type latencyStore struct {
+	spec.Store
+	latency time.Duration
+}
+
+func (s latencyStore) Push(ctx context.Context, expected ociImageSpecV1.Descriptor, content io.Reader) error {
+	time.Sleep(s.latency)
+	return s.Store.Push(ctx, expected, content)
+}
+
+// BenchmarkRepository_AddLocalResource_Concurrency adds an OCI image layout with many layers
+// to a store with push latency for different WithConcurrency settings.
+func BenchmarkRepository_AddLocalResource_Concurrency(b *testing.B) {
+	const (
+		layers    = 32
+		layerSize = 1 << 20
+	)
+
+	var buf bytes.Buffer
+	w, err := tar.NewOCILayoutWriterWithTempFile(&buf, b.TempDir())
+	require.NoError(b, err)
+	descs := make([]ociImageSpecV1.Descriptor, 0, layers)
+	for i := range layers {
+		data := make([]byte, layerSize)
+		_, err := rand.Read(data)
+		require.NoError(b, err)
+		desc := content.NewDescriptorFromBytes(ociImageSpecV1.MediaTypeImageLayer, data)
+		require.NoError(b, w.Push(b.Context(), desc, bytes.NewReader(data)), "layer %d", i)
+		descs = append(descs, desc)
+	}
+	manifest, err := oras.PackManifest(b.Context(), w, oras.PackManifestVersion1_1, ociImageSpecV1.MediaTypeImageLayer, oras.PackManifestOptions{
+		Layers: descs,
+	})
+	require.NoError(b, err)
+	require.NoError(b, w.Tag(b.Context(), manifest, "large-image:1.0.0"))
+	require.NoError(b, w.Close())
+	data := buf.Bytes()
+
+	for _, concurrency := range []int{1, 4, oci.DefaultConcurrency, 16} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for b.Loop() {
+				fs, err := filesystem.NewFS(b.TempDir(), os.O_RDWR)
+				require.NoError(b, err)
+				store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+				repo, err := oci.NewRepository(
+					oci.WithResolver(latencyResolver{Resolver: store, latency: 5 * time.Millisecond}),
+					oci.WithTempDir(b.TempDir()),
+					oci.WithConcurrency(concurrency),
+				)
+				require.NoError(b, err)
+
+				resource := &descriptor.Resource{
+					Relation:    descriptor.LocalRelation,
+					ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "large-image", Version: "1.0.0"}},
+					Type:        "ociImage",
+					Access: &v2.LocalBlob{
+						LocalReference: digest.FromBytes(data).String(),
+						MediaType:      layout.MediaTypeOCIImageLayoutV1 + "+tar",
+					},
+				}
+				_, err = repo.AddLocalResource(b.Context(), "ocm.software/benchmark", "1.0.0", resource, inmemory.New(bytes.NewReader(data)))
+				require.NoError(b, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+
+	"ocm.software/open-component-model/bindings/go/oci/spec"
+	indexv1 "ocm.software/open-component-model/bindings/go/oci/spec/index/component/v1"
+)
+
+// versionIndexManifests returns the manifests of the component version index of a component,
+// or nil if the component has no version index.
+func (repo *Repository) versionIndexManifests(ctx context.Context, component string) ([]ociImageSpecV1.Descriptor, error) {
+	reference, store, err := repo.getStore(ctx, component, indexv1.VersionIndexTag)
+	if err != nil {
+		return nil, err
+	}
+	index, err := fetchVersionIndex(ctx, store, reference)
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		return nil, nil
+	}
+	return index.Manifests, nil
+}
+
+// updateVersionIndex applies update to the component version index of a component and pushes the
+// result if update reports a change. A missing index is created.
+// The index is updated without locking, so concurrent updates of the same component may lose entries.
+func (repo *Repository) updateVersionIndex(ctx context.Context, component string, update func(index *ociImageSpecV1.Index) bool) error {
+	reference, store, err := repo.getStore(ctx, component, indexv1.VersionIndexTag)
+	if err != nil {
+		return err
+	}
+	index, err := fetchVersionIndex(ctx, store, reference)
+	if err != nil {
+		return err
+	}
+	if index == nil {
+		index = indexv1.NewVersionIndex()
+	}
+	if !update(index) {
+		return nil
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal component version index: %w", err)
+	}
+	desc := content.NewDescriptorFromBytes(index.MediaType, data)
+	desc.ArtifactType = index.ArtifactType
+	if err := store.Push(ctx, desc, content.NewVerifyReader(bytes.NewReader(data), desc)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push component version index: %w", err)
+	}
+	if err := store.Tag(ctx, desc, reference); err != nil {
+		return fmt.Errorf("failed to tag component version index: %w", err)
+	}
+	return nil
+}
+
+// removeFromVersionIndex removes a component version from the component version index
+// if the repository maintains one.
+func (repo *Repository) removeFromVersionIndex(ctx context.Context, component, version string) error {
+	if !repo.maintainVersionIndex {
+		return nil
+	}
+	if err := repo.updateVersionIndex(ctx, component, func(index *ociImageSpecV1.Index) bool {
+		return indexv1.RemoveVersion(index, version)
+	}); err != nil {
+		return fmt.Errorf("failed to remove component version from version index: %w", err)
+	}
+	return nil
+}
+
+// fetchVersionIndex resolves and fetches the component version index behind reference.
+// It returns a nil index if the reference does not exist.
+func fetchVersionIndex(ctx context.Context, store spec.Store, reference string) (*ociImageSpecV1.Index, error) {
+	desc, err := store.Resolve(ctx, reference)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve component version index: %w", err)
+	}
+	data, err := content.FetchAll(ctx, store, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch component version index: %w", err)
+	}
+	var index ociImageSpecV1.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal component version index: %w", err)
+	}
+	if index.ArtifactType != indexv1.VersionIndexArtifactType {
+		return nil, fmt.Errorf("tag %q does not point to a component version index but to an artifact of type %q", indexv1.VersionIndexTag, index.ArtifactType)
+	}
+	return &index, nil
+}
@@ -0,0 +1,219 @@
+// Package anonymous provides an OCI registry client that falls back to anonymous pulls
+// for hosts without matching credentials.
+//
+// Public images can be pulled without credentials, but pulls fail when the credentials
+// for the registry host cannot be resolved, for example because a credential helper is
+// not installed, or when a registry rejects the request. The Client retries such pulls
+// unauthenticated if the Policy allows anonymous access to the host:
+//
+//	client := anonymous.NewClient(&auth.Client{...}, &anonymous.Policy{
+//		AllowHosts: []string{"ghcr.io", "*.docker.io"},
+//	}, &anonymous.Metrics{})
+//
+// Pushes and pulls authenticated with credentials of a matched consumer are never retried.
+package anonymous
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"path"
+	"sync"
+	"sync/atomic"
+
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// Policy decides for which registry hosts pulls may fall back to anonymous access.
+// Patterns are matched with path.Match against the host with and without port,
+// for example "ghcr.io", "*.docker.io" or "localhost:5000".
+type Policy struct {
+	// AllowHosts are the hosts anonymous pulls are allowed for.
+	// If empty, anonymous pulls are allowed for all hosts that are not denied.
+	AllowHosts []string `json:"allowHosts,omitempty"`
+	// DenyHosts are the hosts anonymous pulls are never attempted for.
+	// DenyHosts take precedence over AllowHosts.
+	DenyHosts []string `json:"denyHosts,omitempty"`
+}
+
+// Allows reports whether anonymous pulls may be attempted for the host.
+// A nil policy does not allow anonymous pulls for any host.
+func (p *Policy) Allows(hostport string) bool {
+	if p == nil {
+		return false
+	}
+	if matchesAny(p.DenyHosts, hostport) {
+		return false
+	}
+	return len(p.AllowHosts) == 0 || matchesAny(p.AllowHosts, hostport)
+}
+
+// Validate checks that all host patterns are well-formed.
+func (p *Policy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	var errs []error
+	for _, pattern := range append(append([]string{}, p.AllowHosts...), p.DenyHosts...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid host pattern %q: %w", pattern, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func matchesAny(patterns []string, hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, hostport); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PullStats describes the pulls sent by a Client.
+type PullStats struct {
+	// Authenticated is the number of pulls sent with the credentials of a matched consumer.
+	Authenticated int64 `json:"authenticated"`
+	// Anonymous is the number of pulls sent without credentials, including fallbacks.
+	Anonymous int64 `json:"anonymous"`
+	// Fallbacks is the number of pulls that were retried anonymously after authentication failed.
+	Fallbacks int64 `json:"fallbacks"`
+}
+
+// Metrics counts the pulls of one or more clients. The zero value is ready to use.
+type Metrics struct {
+	authenticated atomic.Int64
+	anonymous     atomic.Int64
+	fallbacks     atomic.Int64
+}
+
+// Stats returns the current pull counts.
+func (m *Metrics) Stats() PullStats {
+	return PullStats{
+		Authenticated: m.authenticated.Load(),
+		Anonymous:     m.anonymous.Load(),
+		Fallbacks:     m.fallbacks.Load(),
+	}
+}
+
+// Client is a remote.Client that retries unauthorized pulls anonymously
+// if no credentials matched the registry host and the Policy allows it.
+type Client struct {
+	authenticated *auth.Client
+	anonymous     *auth.Client
+	policy        *Policy
+	metrics       *Metrics
+
+	// matched remembers per host whether the last credential lookup returned credentials.
+	// It attributes pulls served with cached tokens, for which no lookup is done.
+	matched sync.Map
+}
+
+var _ remote.Client = (*Client)(nil)
+
+// NewClient wraps client with an anonymous fallback for pulls governed by policy.
+// The pulls are counted in metrics, which may be nil.
+// The credential function of client is used for lookups only,
+// the anonymous fallback shares the HTTP client and headers of client.
+func NewClient(client *auth.Client, policy *Policy, metrics *Metrics) *Client {
+	if metrics == nil {
+		metrics = &Metrics{}
+	}
+	c := &Client{
+		policy:  policy,
+		metrics: metrics,
+		anonymous: &auth.Client{
+			Client:   client.Client,
+			Header:   client.Header,
+			Cache:    auth.NewCache(),
+			ClientID: client.ClientID,
+		},
+	}
+	authenticated := *client
+	authenticated.Credential = c.recordingCredential(client.Credential)
+	c.authenticated = &authenticated
+	return c
+}
+
+// lookup records the outcome of the credential lookups during one request.
+type lookup struct {
+	mu      sync.Mutex
+	done    bool
+	matched bool
+	err     error
+}
+
+type lookupKey struct{}
+
+func (c *Client) recordingCredential(credential auth.CredentialFunc) auth.CredentialFunc {
+	return func(ctx context.Context, hostport string) (auth.Credential, error) {
+		cred, err := auth.EmptyCredential, error(nil)
+		if credential != nil {
+			cred, err = credential(ctx, hostport)
+		}
+		matched := err == nil && cred != auth.EmptyCredential
+		c.matched.Store(hostport, matched)
+		if l, ok := ctx.Value(lookupKey{}).(*lookup); ok {
+			l.mu.Lock()
+			l.done, l.matched, l.err = true, matched, err
+			l.mu.Unlock()
+		}
+		return cred, err
+	}
+}
+
+// Do sends the request with the wrapped client. Unauthorized pulls for which no credentials
+// matched are retried anonymously if the policy allows anonymous access to the host.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return c.authenticated.Do(req)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	l := &lookup{}
+	resp, err := c.authenticated.Do(req.WithContext(context.WithValue(req.Context(), lookupKey{}, l)))
+
+	l.mu.Lock()
+	matched, lookupErr := l.matched, l.err
+	if !l.done {
+		value, _ := c.matched.Load(host)
+		matched, _ = value.(bool)
+	}
+	l.mu.Unlock()
+
+	if matched {
+		c.metrics.authenticated.Add(1)
+		return resp, err
+	}
+	c.metrics.anonymous.Add(1)
+
+	unauthorized := lookupErr != nil || (err == nil && resp.StatusCode == http.StatusUnauthorized)
+	if !unauthorized || !c.policy.Allows(host) {
+		return resp, err
+	}
+
+	if resp != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+	slog.DebugContext(req.Context(), "retrying pull anonymously", "host", host, "path", req.URL.Path, "error", err)
+	c.metrics.fallbacks.Add(1)
+	return c.anonymous.Do(req)
+}
@@ -0,0 +1,151 @@
+package anonymous_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"ocm.software/open-component-model/bindings/go/oci/resolver/anonymous"
+)
+
+// registry returns a server that requires bearer tokens for manifests. The token endpoint
+// issues a token for the user "user" and anonymous tokens.
+func registry(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(nil)
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			token := "anonymous"
+			if user, password, ok := r.BasicAuth(); ok {
+				if user != "user" || password != "password" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				token = "user"
+			}
+			_, _ = fmt.Fprintf(w, `{"token": %q}`, token)
+		default:
+			switch r.Header.Get("Authorization") {
+			case "Bearer anonymous", "Bearer user":
+				w.WriteHeader(http.StatusOK)
+			default:
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:app:pull"`, srv.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func do(t *testing.T, client *anonymous.Client, method, url string) (int, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), method, url+"/v2/app/manifests/1.0.0", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode, nil
+}
+
+func TestClient(t *testing.T) {
+	srv := registry(t)
+	lookupFailed := errors.New("credential helper not found")
+	failingLookup := func(context.Context, string) (auth.Credential, error) {
+		return auth.EmptyCredential, lookupFailed
+	}
+
+	t.Run("falls back to anonymous pulls for allowed hosts", func(t *testing.T) {
+		r := require.New(t)
+		metrics := &anonymous.Metrics{}
+		client := anonymous.NewClient(&auth.Client{Client: srv.Client(), Credential: failingLookup}, &anonymous.Policy{}, metrics)
+
+		status, err := do(t, client, http.MethodGet, srv.URL)
+		r.NoError(err)
+		r.Equal(http.StatusOK, status)
+		r.Equal(anonymous.PullStats{Anonymous: 1, Fallbacks: 1}, metrics.Stats())
+	})
+
+	t.Run("does not fall back for denied hosts", func(t *testing.T) {
+		r := require.New(t)
+		metrics := &anonymous.Metrics{}
+		client := anonymous.NewClient(&auth.Client{Client: srv.Client(), Credential: failingLookup}, &anonymous.Policy{
+			DenyHosts: []string{"127.0.0.1"},
+		}, metrics)
+
+		_, err := do(t, client, http.MethodGet, srv.URL)
+		r.ErrorIs(err, lookupFailed)
+		r.Equal(anonymous.PullStats{Anonymous: 1}, metrics.Stats())
+	})
+
+	t.Run("does not fall back without policy or for pushes", func(t *testing.T) {
+		r := require.New(t)
+		client := anonymous.NewClient(&auth.Client{Client: srv.Client(), Credential: failingLookup}, nil, nil)
+		_, err := do(t, client, http.MethodGet, srv.URL)
+		r.ErrorIs(err, lookupFailed)
+
+		client = anonymous.NewClient(&auth.Client{Client: srv.Client(), Credential: failingLookup}, &anonymous.Policy{}, nil)
+		_, err = do(t, client, http.MethodPut, srv.URL)
+		r.ErrorIs(err, lookupFailed)
+	})
+
+	t.Run("counts authenticated pulls", func(t *testing.T) {
+		r := require.New(t)
+		metrics := &anonymous.Metrics{}
+		client := anonymous.NewClient(&auth.Client{
+			Client:     srv.Client(),
+			Cache:      auth.NewCache(),
+			Credential: auth.StaticCredential(srv.Listener.Addr().String(), auth.Credential{Username: "user", Password: "password"}),
+		}, &anonymous.Policy{}, metrics)
+
+		for range 2 {
+			status, err := do(t, client, http.MethodGet, srv.URL)
+			r.NoError(err)
+			r.Equal(http.StatusOK, status)
+		}
+		r.Equal(anonymous.PullStats{Authenticated: 2}, metrics.Stats(), "pulls with cached tokens are attributed to the credentials")
+	})
+
+	t.Run("does not fall back for rejected credentials", func(t *testing.T) {
+		r := require.New(t)
+		metrics := &anonymous.Metrics{}
+		client := anonymous.NewClient(&auth.Client{
+			Client:     srv.Client(),
+			Credential: auth.StaticCredential(srv.Listener.Addr().String(), auth.Credential{Username: "user", Password: "wrong"}),
+		}, &anonymous.Policy{}, metrics)
+
+		_, err := do(t, client, http.MethodGet, srv.URL)
+		r.Error(err)
+		r.Equal(anonymous.PullStats{Authenticated: 1}, metrics.Stats())
+	})
+}
+
+func TestPolicy(t *testing.T) {
+	r := require.New(t)
+	policy := &anonymous.Policy{
+		AllowHosts: []string{"ghcr.io", "*.docker.io", "localhost:5000"},
+		DenyHosts:  []string{"private.docker.io"},
+	}
+	r.NoError(policy.Validate())
+
+	r.True(policy.Allows("ghcr.io"))
+	r.True(policy.Allows("ghcr.io:443"))
+	r.True(policy.Allows("registry-1.docker.io"))
+	r.True(policy.Allows("localhost:5000"))
+	r.False(policy.Allows("localhost:5001"))
+	r.False(policy.Allows("private.docker.io"))
+	r.False(policy.Allows("quay.io"))
+
+	r.True((&anonymous.Policy{}).Allows("quay.io"))
+	r.False((*anonymous.Policy)(nil).Allows("quay.io"))
+	r.Error((&anonymous.Policy{DenyHosts: []string{"["}}).Validate())
+}
@@ -2,6 +2,8 @@ package url
 
 import (
 	"oras.land/oras-go/v2/registry/remote"
+
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 )
 
 // Option is an interface for configuring the CachingResolver.
@@ -32,13 +34,55 @@ func WithBaseClient(client remote.Client) Option {
 	})
 }
 
-// WithPlainHTTP sets whether to use plain HTTP instead of HTTPS for any repository clients
+// WithPlainHTTP sets whether to use plain HTTP instead of HTTPS for any repository clients.
+// Use WithRegistries to only access single registry hosts over plain HTTP.
 func WithPlainHTTP(plainHTTP bool) Option {
 	return OptionFunc(func(resolver *CachingResolver) {
 		resolver.plainHTTP = plainHTTP
 	})
 }
 
+// WithRegistries sets the per-host configuration of the registries accessed by the resolver.
+// Registries configured to be accessed over plain HTTP or without TLS verification are refused
+// with ErrInsecureRegistryNotAllowed unless they are allowed with WithAllowInsecureRegistries.
+func WithRegistries(registries Registries) Option {
+	return OptionFunc(func(resolver *CachingResolver) {
+		resolver.registries = registries
+	})
+}
+
+// WithAllowInsecureRegistries explicitly allows accessing the registries configured with WithRegistries
+// over plain HTTP or without TLS verification. Every store created for such a registry logs a warning.
+func WithAllowInsecureRegistries(allow bool) Option {
+	return OptionFunc(func(resolver *CachingResolver) {
+		resolver.allowInsecureRegistries = allow
+	})
+}
+
+// WithRegistryConfig sets the per-host configuration of the registries accessed by the resolver
+// and whether insecure registries are allowed from the serialisable registry configuration,
+// see WithRegistries and WithAllowInsecureRegistries. A nil config leaves the resolver unchanged.
+func WithRegistryConfig(cfg *registriesv1alpha1.Config) Option {
+	return OptionFunc(func(resolver *CachingResolver) {
+		if cfg == nil {
+			return
+		}
+		resolver.registries = RegistriesFromConfig(cfg)
+		resolver.allowInsecureRegistries = cfg.AllowInsecureRegistries
+	})
+}
+
+// WithChunkedUpload makes stores of the resolver upload blobs of at least chunkSize bytes
+// in chunks of chunkSize bytes with the chunked upload API of the OCI distribution specification.
+// Interrupted chunked uploads return a *resumable.UploadInterruptedError, whose token resumes the
+// upload if it is attached to the context of the next push with resumable.ContextWithUploadToken.
+// Registries have to support chunked uploads, which not all registries do.
+func WithChunkedUpload(chunkSize int64) Option {
+	return OptionFunc(func(resolver *CachingResolver) {
+		resolver.chunkSize = chunkSize
+	})
+}
+
 // WithSubPath sets the repository prefix path used for the OCM repository.
 // The OCM based artifacts will use this path as a repository prefix.
 func WithSubPath(subPath string) Option {
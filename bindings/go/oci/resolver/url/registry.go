@@ -0,0 +1,138 @@
+package url
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
+)
+
+// ErrInsecureRegistryNotAllowed is returned if a registry is configured to be accessed
+// over plain HTTP or without TLS verification, but insecure registries are not allowed
+// with WithAllowInsecureRegistries.
+var ErrInsecureRegistryNotAllowed = errors.New("insecure registry not allowed")
+
+// RegistryConfig configures how the resolver connects to a single registry host.
+//
+// WARNING: Both settings expose the traffic to the registry, including credentials,
+// to anyone on the network path. They are meant for development and edge environments
+// only and are refused unless insecure registries are allowed with WithAllowInsecureRegistries.
+type RegistryConfig struct {
+	// PlainHTTP connects to the registry over plain HTTP instead of HTTPS.
+	PlainHTTP bool
+	// Insecure connects to the registry over HTTPS without verifying its TLS certificate.
+	Insecure bool
+}
+
+// IsInsecure reports whether the registry is accessed over plain HTTP or without TLS verification.
+func (c RegistryConfig) IsInsecure() bool {
+	return c.PlainHTTP || c.Insecure
+}
+
+// Registries maps registry hosts to their configuration.
+// Keys are either "hostname" or "hostname:port", an entry with a port wins over the bare hostname.
+type Registries map[string]RegistryConfig
+
+// RegistriesFromConfig returns the registries of the registry configuration.
+// If a host is configured more than once, the last entry wins.
+func RegistriesFromConfig(cfg *registriesv1alpha1.Config) Registries {
+	if cfg == nil || len(cfg.Registries) == 0 {
+		return nil
+	}
+	registries := make(Registries, len(cfg.Registries))
+	for _, registry := range cfg.Registries {
+		registries[registry.Host] = RegistryConfig{
+			PlainHTTP: registry.PlainHTTP,
+			Insecure:  registry.Insecure,
+		}
+	}
+	return registries
+}
+
+// lookup returns the configuration of the registry host (hostname[:port]).
+func (r Registries) lookup(host string) (RegistryConfig, bool) {
+	if cfg, ok := r[host]; ok {
+		return cfg, true
+	}
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		if cfg, ok := r[name]; ok {
+			return cfg, true
+		}
+	}
+	return RegistryConfig{}, false
+}
+
+// registryConfig returns the configuration of the registry host and refuses
+// insecure registries unless they are explicitly allowed.
+func (resolver *CachingResolver) registryConfig(ctx context.Context, host string) (RegistryConfig, error) {
+	cfg, ok := resolver.registries.lookup(host)
+	if !ok || !cfg.IsInsecure() {
+		return cfg, nil
+	}
+	if !resolver.allowInsecureRegistries {
+		return RegistryConfig{}, fmt.Errorf("%w: registry %q is configured with plainHTTP=%t and insecure=%t, "+
+			"insecure registries have to be allowed explicitly", ErrInsecureRegistryNotAllowed, host, cfg.PlainHTTP, cfg.Insecure)
+	}
+	if cfg.PlainHTTP {
+		slog.WarnContext(ctx, "connecting to registry over plain HTTP — traffic and credentials are sent unencrypted",
+			"host", host)
+	}
+	if cfg.Insecure {
+		slog.WarnContext(ctx, "TLS certificate verification of registry disabled — connections are vulnerable to MITM attacks",
+			"host", host)
+	}
+	return cfg, nil
+}
+
+// insecureClient returns a copy of client that does not verify the TLS certificate of the registry.
+// Only clients based on an [http.Transport] can be copied, a nil client is based on [auth.DefaultClient].
+func insecureClient(client remote.Client) (remote.Client, error) {
+	switch c := client.(type) {
+	case nil:
+		return insecureClient(auth.DefaultClient)
+	case *auth.Client:
+		httpClient, err := insecureHTTPClient(c.Client)
+		if err != nil {
+			return nil, err
+		}
+		insecure := *c
+		insecure.Client = httpClient
+		return &insecure, nil
+	case *http.Client:
+		return insecureHTTPClient(c)
+	default:
+		return nil, fmt.Errorf("cannot disable TLS verification for client of type %T", client)
+	}
+}
+
+func insecureHTTPClient(client *http.Client) (*http.Client, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("cannot disable TLS verification for transport of type %T", base)
+	}
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	//nolint:gosec // explicitly requested per registry and only allowed with WithAllowInsecureRegistries
+	transport.TLSClientConfig.InsecureSkipVerify = true
+
+	insecure := *client
+	insecure.Transport = transport
+	return &insecure, nil
+}
@@ -0,0 +1,84 @@
+// Package registrytest provides registry test servers that are accessed over plain HTTP or with
+// an untrusted TLS certificate, together with the resolver options that connect to them.
+//
+// Conformance and integration tests of dev and edge setups use them to exercise the explicit
+// opt-in to insecure registries of the URL resolver:
+//
+//	reg := registrytest.NewPlainHTTP(t, handler)
+//	resolver, err := url.New(reg.Options()...)
+package registrytest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ocm.software/open-component-model/bindings/go/oci/resolver/url"
+)
+
+// Registry is a registry test server with the configuration the resolver needs to connect to it.
+type Registry struct {
+	*httptest.Server
+
+	// Host is the hostname:port of the registry.
+	Host string
+	// Config is the configuration of the registry host, see url.WithRegistries.
+	Config url.RegistryConfig
+}
+
+// NewPlainHTTP starts a registry test server serving handler over plain HTTP.
+// A nil handler serves PingHandler. The server is closed when the test ends.
+func NewPlainHTTP(t testing.TB, handler http.Handler) *Registry {
+	t.Helper()
+	server := httptest.NewServer(orPing(handler))
+	t.Cleanup(server.Close)
+	return &Registry{
+		Server: server,
+		Host:   strings.TrimPrefix(server.URL, "http://"),
+		Config: url.RegistryConfig{PlainHTTP: true},
+	}
+}
+
+// NewInsecureTLS starts a registry test server serving handler over HTTPS with a self-signed
+// certificate that is not trusted by any client. A nil handler serves PingHandler.
+// The server is closed when the test ends.
+func NewInsecureTLS(t testing.TB, handler http.Handler) *Registry {
+	t.Helper()
+	server := httptest.NewTLSServer(orPing(handler))
+	t.Cleanup(server.Close)
+	return &Registry{
+		Server: server,
+		Host:   strings.TrimPrefix(server.URL, "https://"),
+		Config: url.RegistryConfig{Insecure: true},
+	}
+}
+
+// Options returns the resolver options that connect to the registry as base URL,
+// including the explicit opt-in to insecure registries.
+func (r *Registry) Options() []url.Option {
+	return []url.Option{
+		url.WithBaseURL(r.Host),
+		url.WithRegistries(url.Registries{r.Host: r.Config}),
+		url.WithAllowInsecureRegistries(true),
+	}
+}
+
+// PingHandler answers the base endpoint "/v2/" of the OCI distribution specification
+// with 200 OK and all other requests with 404 Not Found.
+func PingHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+func orPing(handler http.Handler) http.Handler {
+	if handler == nil {
+		return PingHandler()
+	}
+	return handler
+}
@@ -39,6 +39,10 @@ type CachingResolver struct {
 	subPath    string
 	baseClient remote.Client
 	plainHTTP  bool
+	chunkSize  int64
+
+	registries              Registries
+	allowInsecureRegistries bool
 
 	DisableCacheProxy bool
 
@@ -78,9 +82,13 @@ func (resolver *CachingResolver) Ping(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create registry client: %w", err)
 	}
-	r.PlainHTTP = resolver.plainHTTP
-	if resolver.baseClient != nil {
-		r.Client = resolver.baseClient
+	cfg, err := resolver.registryConfig(ctx, parsedURL.Host)
+	if err != nil {
+		return err
+	}
+	r.PlainHTTP = resolver.plainHTTP || cfg.PlainHTTP
+	if r.Client, err = resolver.clientFor(cfg); err != nil {
+		return fmt.Errorf("failed to create client for registry %q: %w", parsedURL.Host, err)
 	}
 	if err := r.Ping(ctx); err != nil {
 		errResp := &errcode.ErrorResponse{}
@@ -101,7 +109,9 @@ func (resolver *CachingResolver) Ping(ctx context.Context) error {
 	return nil
 }
 
-func (resolver *CachingResolver) StoreForReference(_ context.Context, reference string) (spec.Store, error) {
+// StoreForReference returns the store for the repository of the reference. The store is created
+// once per repository and connects to the registry as configured with WithRegistries.
+func (resolver *CachingResolver) StoreForReference(ctx context.Context, reference string) (spec.Store, error) {
 	ref, err := looseref.ParseReference(reference)
 	if err != nil {
 		return nil, err
@@ -128,20 +138,34 @@ func (resolver *CachingResolver) StoreForReference(_ context.Context, reference
 		SkipReferrersGC: true,
 	}
 
-	if resolver.plainHTTP || ref.Scheme == "http" {
+	cfg, err := resolver.registryConfig(ctx, ref.Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolver.plainHTTP || cfg.PlainHTTP || ref.Scheme == "http" {
 		repo.PlainHTTP = true
 	}
 
-	if resolver.baseClient != nil {
-		repo.Client = resolver.baseClient
+	if repo.Client, err = resolver.clientFor(cfg); err != nil {
+		return nil, fmt.Errorf("failed to create client for registry %q: %w", ref.Registry, err)
 	}
 
-	store := &remotestore.RemoteStore{Repository: repo}
+	store := &remotestore.RemoteStore{Repository: repo, ChunkSize: resolver.chunkSize}
 	resolver.addToCache(key, store)
 
 	return store, nil
 }
 
+// clientFor returns the client to access a registry with the given configuration.
+// A nil client makes oras fall back to its default client.
+func (resolver *CachingResolver) clientFor(cfg RegistryConfig) (remote.Client, error) {
+	if cfg.Insecure {
+		return insecureClient(resolver.baseClient)
+	}
+	return resolver.baseClient, nil
+}
+
 func (resolver *CachingResolver) addToCache(reference string, store spec.Store) {
 	resolver.cacheMu.Lock()
 	defer resolver.cacheMu.Unlock()
@@ -9,10 +9,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 
 	"ocm.software/open-component-model/bindings/go/oci/resolver/url"
+	"ocm.software/open-component-model/bindings/go/oci/resolver/url/registrytest"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 )
 
 // Custom transport to verify the custom client is being used
@@ -477,3 +480,89 @@ func TestURLPathResolver_Ping(t *testing.T) {
 		assert.True(t, authUsed, "Expected bearer token to be used in request")
 	})
 }
+
+func TestURLPathResolver_InsecureRegistries(t *testing.T) {
+	t.Run("plain HTTP registry is refused unless allowed", func(t *testing.T) {
+		r := require.New(t)
+		reg := registrytest.NewPlainHTTP(t, nil)
+
+		resolver, err := url.New(url.WithBaseURL(reg.Host), url.WithRegistries(url.Registries{reg.Host: reg.Config}))
+		r.NoError(err)
+		_, err = resolver.StoreForReference(t.Context(), reg.Host+"/test:v1.0.0")
+		r.ErrorIs(err, url.ErrInsecureRegistryNotAllowed)
+		r.ErrorIs(resolver.Ping(t.Context()), url.ErrInsecureRegistryNotAllowed)
+	})
+
+	t.Run("plain HTTP registry is used if allowed", func(t *testing.T) {
+		r := require.New(t)
+		reg := registrytest.NewPlainHTTP(t, nil)
+
+		resolver, err := url.New(reg.Options()...)
+		r.NoError(err)
+		r.NoError(resolver.Ping(t.Context()))
+		store, err := resolver.StoreForReference(t.Context(), reg.Host+"/test:v1.0.0")
+		r.NoError(err)
+		_, err = store.Resolve(t.Context(), reg.Host+"/test:v1.0.0")
+		r.ErrorIs(err, errdef.ErrNotFound, "the registry answers over plain HTTP")
+	})
+
+	t.Run("registry with untrusted certificate is used if allowed", func(t *testing.T) {
+		r := require.New(t)
+		reg := registrytest.NewInsecureTLS(t, nil)
+
+		resolver, err := url.New(url.WithBaseURL(reg.Host))
+		r.NoError(err)
+		r.ErrorContains(resolver.Ping(t.Context()), "certificate", "an untrusted certificate fails without configuration")
+
+		resolver, err = url.New(append(reg.Options(), url.WithBaseClient(&auth.Client{Client: &http.Client{}}))...)
+		r.NoError(err)
+		r.NoError(resolver.Ping(t.Context()))
+	})
+
+	t.Run("registry without port matches by hostname", func(t *testing.T) {
+		r := require.New(t)
+		resolver, err := url.New(url.WithBaseURL("localhost:5000"), url.WithRegistries(url.Registries{"localhost": {PlainHTTP: true}}))
+		r.NoError(err)
+		_, err = resolver.StoreForReference(t.Context(), "localhost:5000/test:v1.0.0")
+		r.ErrorIs(err, url.ErrInsecureRegistryNotAllowed)
+		_, err = resolver.StoreForReference(t.Context(), "example.com/test:v1.0.0")
+		r.NoError(err, "other registries are not affected")
+	})
+
+	t.Run("plain HTTP registry is used with registry config", func(t *testing.T) {
+		r := require.New(t)
+		reg := registrytest.NewPlainHTTP(t, nil)
+		cfg := &registriesv1alpha1.Config{
+			Registries: []registriesv1alpha1.Registry{
+				{Host: reg.Host},
+				{Host: reg.Host, PlainHTTP: true},
+			},
+		}
+
+		resolver, err := url.New(url.WithBaseURL(reg.Host), url.WithRegistryConfig(cfg))
+		r.NoError(err)
+		_, err = resolver.StoreForReference(t.Context(), reg.Host+"/test:v1.0.0")
+		r.ErrorIs(err, url.ErrInsecureRegistryNotAllowed, "the last entry of a host wins")
+
+		cfg.AllowInsecureRegistries = true
+		resolver, err = url.New(url.WithBaseURL(reg.Host), url.WithRegistryConfig(cfg))
+		r.NoError(err)
+		store, err := resolver.StoreForReference(t.Context(), reg.Host+"/test:v1.0.0")
+		r.NoError(err)
+		_, err = store.Resolve(t.Context(), reg.Host+"/test:v1.0.0")
+		r.ErrorIs(err, errdef.ErrNotFound, "the registry answers over plain HTTP")
+	})
+
+	t.Run("custom transport cannot skip TLS verification", func(t *testing.T) {
+		r := require.New(t)
+		resolver, err := url.New(
+			url.WithBaseURL("example.com"),
+			url.WithBaseClient(&http.Client{Transport: &customRoundTripper{transport: http.DefaultTransport}}),
+			url.WithRegistries(url.Registries{"example.com": {Insecure: true}}),
+			url.WithAllowInsecureRegistries(true),
+		)
+		r.NoError(err)
+		_, err = resolver.StoreForReference(t.Context(), "example.com/test:v1.0.0")
+		r.ErrorContains(err, "cannot disable TLS verification")
+	})
+}
@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// ConfigType defines the type identifier for registry configurations.
+	ConfigType = "registries.config.ocm.software"
+)
+
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&Config{},
+		runtime.NewVersionedType(ConfigType, Version),
+		runtime.NewUnversionedType(ConfigType),
+	)
+}
+
+// Config configures how single OCI registry hosts are connected to.
+//
+// WARNING: Registries accessed over plain HTTP or without TLS verification expose their traffic,
+// including credentials, to anyone on the network path. They are meant for development and edge
+// environments only and are refused unless AllowInsecureRegistries is set.
+//
+//	type: generic.config.ocm.software/v1
+//	configurations:
+//	  - type: registries.config.ocm.software/v1alpha1
+//	    allowInsecureRegistries: true
+//	    registries:
+//	      - host: localhost:5000
+//	        plainHTTP: true
+//	      - host: registry.edge.local
+//	        insecure: true
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Config struct {
+	// +ocm:jsonschema-gen:enum=registries.config.ocm.software/v1alpha1
+	// +ocm:jsonschema-gen:enum:deprecated=registries.config.ocm.software
+	Type runtime.Type `json:"type"`
+
+	// Registries configure the registry hosts that are not accessed over HTTPS with TLS verification.
+	// If a host is configured more than once, the last entry wins.
+	Registries []Registry `json:"registries,omitempty"`
+
+	// AllowInsecureRegistries explicitly allows accessing registries over plain HTTP or without TLS verification.
+	// Without it, accessing a registry configured with plainHTTP or insecure fails.
+	AllowInsecureRegistries bool `json:"allowInsecureRegistries,omitempty"`
+}
+
+// Registry configures how a single registry host is connected to.
+//
+// +k8s:deepcopy-gen=true
+type Registry struct {
+	// Host is the registry host, either "hostname" or "hostname:port".
+	// An entry with a port wins over an entry with the bare hostname.
+	Host string `json:"host"`
+
+	// PlainHTTP connects to the registry over plain HTTP instead of HTTPS.
+	PlainHTTP bool `json:"plainHTTP,omitempty"`
+
+	// Insecure connects to the registry over HTTPS without verifying its TLS certificate.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// LookupConfig creates a registry configuration from a central V1 config.
+// The registries of all registry configurations are combined.
+func LookupConfig(cfg *genericv1.Config) (*Config, error) {
+	return genericv1.Lookup(Scheme, cfg, Merge)
+}
+
+// Merge merges the provided configs into a single config by combining their registries.
+// Insecure registries are allowed if any of the configs allows them.
+func Merge(configs ...*Config) *Config {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	merged := new(Config)
+	_, _ = Scheme.DefaultType(merged)
+
+	for _, config := range configs {
+		merged.Registries = append(merged.Registries, config.Registries...)
+		merged.AllowInsecureRegistries = merged.AllowInsecureRegistries || config.AllowInsecureRegistries
+	}
+
+	return merged
+}
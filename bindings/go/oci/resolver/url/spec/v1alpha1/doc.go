@@ -0,0 +1,5 @@
+// Package v1alpha1 defines the registry configuration type registries.config.ocm.software/v1alpha1.
+//
+// See the parent package ocm.software/open-component-model/bindings/go/oci/resolver/url
+// for how the resolver connects to the configured registries.
+package v1alpha1
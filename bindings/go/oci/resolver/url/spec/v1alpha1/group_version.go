@@ -0,0 +1,5 @@
+package v1alpha1
+
+const (
+	Version = "v1alpha1"
+)
@@ -0,0 +1,389 @@
+// Package resumable provides a content storage that downloads blobs into a state directory so that
+// interrupted downloads continue where they stopped instead of restarting from zero.
+//
+// Blobs are written to <dir>/<algorithm>/<encoded>.partial while they are downloaded. If the
+// download is interrupted, the partial file is kept and the next attempt, within the same
+// [Storage.Fetch] call or in a later process using the same directory, continues at its size.
+// Continuation requires the reader returned by the source store to implement [io.Seeker], which
+// remote registries do for blobs served with "Accept-Ranges: bytes" by issuing HTTP range requests.
+// For all other stores the download restarts from zero.
+//
+// Completed downloads are verified against the digest of the descriptor every time they are served
+// and are kept in <dir>/<algorithm>/<encoded> until [Storage.Cleanup] is called, so that a copy
+// of a multi-blob artifact that fails after some blobs completed does not download them again.
+// Completed files that no longer match their digest are downloaded again.
+//
+// State that is not cleaned up, e.g. of downloads that failed and were never retried, is removed
+// once it was not modified for the maximum age, see [WithMaxAge] and [Prune].
+//
+// For uploads, the package defines [UploadToken]s, which identify interrupted chunked upload
+// sessions in a registry. Registry stores configured for chunked uploads return them as part of
+// an [UploadInterruptedError] and continue the session if the token is attached to the context
+// of the next push with [ContextWithUploadToken].
+package resumable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	slogcontext "github.com/veqryn/slog-context"
+	"oras.land/oras-go/v2/content"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of attempts to download a single blob.
+	DefaultMaxAttempts = 5
+	// DefaultMinSize is the default minimum size of a blob to be downloaded resumably.
+	// Smaller blobs such as manifests are fetched directly from the source.
+	DefaultMinSize = 1 << 20
+	// DefaultMaxAge is the default age after which unmodified state is removed from the directory.
+	DefaultMaxAge = 24 * time.Hour
+
+	partialSuffix = ".partial"
+)
+
+// ErrDigestMismatch is returned if a completed download does not match the digest of its descriptor.
+var ErrDigestMismatch = errors.New("downloaded content does not match digest")
+
+// Options configure a [Storage].
+type Options struct {
+	// MaxAttempts is the number of attempts to download a single blob before giving up.
+	MaxAttempts int
+	// MinSize is the minimum size of a blob to be downloaded through the state directory.
+	MinSize int64
+	// MaxAge is the age after which partial and completed downloads that were not modified are
+	// removed from the state directory. A negative value keeps them until they are cleaned up.
+	MaxAge time.Duration
+}
+
+// Option is a function that modifies Options.
+type Option func(*Options)
+
+// WithMaxAttempts sets the number of attempts to download a single blob.
+func WithMaxAttempts(attempts int) Option {
+	return func(o *Options) {
+		o.MaxAttempts = attempts
+	}
+}
+
+// WithMinSize sets the minimum size of a blob to be downloaded through the state directory.
+func WithMinSize(size int64) Option {
+	return func(o *Options) {
+		o.MinSize = size
+	}
+}
+
+// WithMaxAge sets the age after which unmodified partial and completed downloads are removed
+// from the state directory. A negative age keeps them until they are cleaned up.
+func WithMaxAge(age time.Duration) Option {
+	return func(o *Options) {
+		o.MaxAge = age
+	}
+}
+
+// Storage is a [content.ReadOnlyGraphStorage] that downloads blobs of the wrapped storage
+// resumably into a state directory.
+type Storage struct {
+	content.ReadOnlyGraphStorage
+
+	dir     string
+	options Options
+
+	mu        sync.Mutex
+	completed map[string]struct{}
+}
+
+var _ content.ReadOnlyGraphStorage = (*Storage)(nil)
+
+// New creates a [Storage] downloading blobs of src into dir.
+// The directory is created if it does not exist. It can be shared between storages and
+// processes, and should be on persistent storage to resume downloads across restarts.
+// State older than the maximum age is pruned from the directory, at most once per maximum
+// age and directory within the process.
+func New(src content.ReadOnlyGraphStorage, dir string, opts ...Option) (*Storage, error) {
+	options := Options{
+		MaxAttempts: DefaultMaxAttempts,
+		MinSize:     DefaultMinSize,
+		MaxAge:      DefaultMaxAge,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create resumable download directory: %w", err)
+	}
+	if options.MaxAge >= 0 && pruneDue(dir, options.MaxAge) {
+		if err := Prune(dir, options.MaxAge); err != nil {
+			return nil, fmt.Errorf("failed to prune resumable download directory: %w", err)
+		}
+	}
+	return &Storage{
+		ReadOnlyGraphStorage: src,
+		dir:                  dir,
+		options:              options,
+		completed:            make(map[string]struct{}),
+	}, nil
+}
+
+// Fetch downloads the blob into the state directory, continuing a previous partial download
+// if one exists, and returns a reader over the verified content.
+func (s *Storage) Fetch(ctx context.Context, target ociImageSpecV1.Descriptor) (io.ReadCloser, error) {
+	if target.Size < s.options.MinSize {
+		return s.ReadOnlyGraphStorage.Fetch(ctx, target)
+	}
+	if err := target.Digest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid digest of %s: %w", target.Digest, err)
+	}
+
+	path := s.path(target.Digest)
+	unlock := lockPath(path)
+	defer unlock()
+
+	if _, err := os.Stat(path); err == nil {
+		// the file may have been modified or truncated since it was downloaded.
+		if err := verify(path, target); err != nil {
+			if !errors.Is(err, ErrDigestMismatch) {
+				return nil, err
+			}
+			slogcontext.Log(ctx, slog.LevelWarn, "completed blob download does not match its digest, downloading again",
+				slog.String("digest", target.Digest.String()))
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+			if err := s.download(ctx, target, path); err != nil {
+				return nil, err
+			}
+		}
+	} else if errors.Is(err, os.ErrNotExist) {
+		if err := s.download(ctx, target, path); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.completed[path] = struct{}{}
+	s.mu.Unlock()
+	return file, nil
+}
+
+// Cleanup removes all completed downloads served by the storage from the state directory.
+// Partial downloads of other blobs are kept.
+func (s *Storage) Cleanup() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for path := range s.completed {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+		delete(s.completed, path)
+	}
+	return errors.Join(errs...)
+}
+
+// Prune removes partial and completed downloads from the state directory that were not modified
+// for longer than maxAge. Downloads in progress within the process are kept.
+func Prune(dir string, maxAge time.Duration) error {
+	threshold := time.Now().Add(-maxAge)
+	var errs []error
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			return nil
+		}
+		if info.ModTime().After(threshold) {
+			return nil
+		}
+		unlock, ok := tryLockPath(strings.TrimSuffix(path, partialSuffix))
+		if !ok {
+			return nil
+		}
+		defer unlock()
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+		return nil
+	})
+	return errors.Join(append(errs, err)...)
+}
+
+// lastPrunes records when the state directories were last pruned within the process.
+var lastPrunes sync.Map
+
+// pruneDue reports whether the directory was not pruned within the last maxAge and records
+// the prune if so.
+func pruneDue(dir string, maxAge time.Duration) bool {
+	now := time.Now()
+	last, loaded := lastPrunes.LoadOrStore(dir, now)
+	if !loaded {
+		return true
+	}
+	if now.Sub(last.(time.Time)) < maxAge {
+		return false
+	}
+	return lastPrunes.CompareAndSwap(dir, last, now)
+}
+
+func (s *Storage) path(dgst digest.Digest) string {
+	return filepath.Join(s.dir, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// download downloads the blob into path, retrying interrupted attempts from the size of
+// the partial file, and verifies the digest of the completed download.
+func (s *Storage) download(ctx context.Context, target ociImageSpecV1.Descriptor, path string) error {
+	partial := path + partialSuffix
+	if err := os.MkdirAll(filepath.Dir(partial), 0o755); err != nil {
+		return err
+	}
+
+	var err error
+	for attempt := 1; attempt <= s.options.MaxAttempts; attempt++ {
+		if err = s.attempt(ctx, target, partial); err == nil {
+			break
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.Join(err, ctxErr)
+		}
+		slogcontext.Log(ctx, slog.LevelWarn, "blob download interrupted",
+			slog.String("digest", target.Digest.String()),
+			slog.Int("attempt", attempt),
+			slog.Any("error", err))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download %s after %d attempts: %w", target.Digest, s.options.MaxAttempts, err)
+	}
+
+	if err := verify(partial, target); err != nil {
+		return errors.Join(err, os.Remove(partial))
+	}
+	return os.Rename(partial, path)
+}
+
+// attempt appends the remaining content of the blob to the partial file.
+func (s *Storage) attempt(ctx context.Context, target ociImageSpecV1.Descriptor, partial string) (err error) {
+	file, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	offset := info.Size()
+	if offset > target.Size {
+		offset = 0
+	}
+	if offset == target.Size {
+		return nil
+	}
+
+	rc, err := s.ReadOnlyGraphStorage.Fetch(ctx, target)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, rc.Close())
+	}()
+
+	if offset > 0 {
+		if seeker, ok := rc.(io.Seeker); ok {
+			if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				slogcontext.Log(ctx, slog.LevelDebug, "cannot resume blob download, restarting",
+					slog.String("digest", target.Digest.String()), slog.Any("error", err))
+				offset = 0
+			} else {
+				slogcontext.Log(ctx, slog.LevelInfo, "resuming blob download",
+					slog.String("digest", target.Digest.String()), slog.Int64("offset", offset))
+			}
+		} else {
+			offset = 0
+		}
+	}
+	if err := file.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	written, err := io.Copy(file, io.LimitReader(rc, target.Size-offset))
+	if err != nil {
+		return err
+	}
+	if offset+written != target.Size {
+		return fmt.Errorf("unexpected end of content after %d of %d bytes: %w", offset+written, target.Size, io.ErrUnexpectedEOF)
+	}
+	return nil
+}
+
+// verify checks that the content of the file matches the digest of the descriptor.
+func verify(path string, target ociImageSpecV1.Descriptor) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	verifier := target.Digest.Verifier()
+	if _, err := io.Copy(verifier, file); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("%w %s", ErrDigestMismatch, target.Digest)
+	}
+	return nil
+}
+
+// pathLocks serializes downloads of the same blob into the same directory within the process.
+var pathLocks sync.Map
+
+func lockPath(path string) (unlock func()) {
+	mu, _ := pathLocks.LoadOrStore(path, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+func tryLockPath(path string) (unlock func(), ok bool) {
+	mu, _ := pathLocks.LoadOrStore(path, &sync.Mutex{})
+	if !mu.(*sync.Mutex).TryLock() {
+		return nil, false
+	}
+	return mu.(*sync.Mutex).Unlock, true
+}
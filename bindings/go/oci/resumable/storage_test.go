@@ -0,0 +1,253 @@
+package resumable_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+
+	"ocm.software/open-component-model/bindings/go/oci/resumable"
+)
+
+var errConnectionReset = errors.New("connection reset")
+
+// flakyStorage serves blobs that break after failAfter bytes for the first failures fetches.
+type flakyStorage struct {
+	content.ReadOnlyGraphStorage
+	data      []byte
+	seekable  bool
+	failures  int
+	failAfter int64
+
+	fetches int
+	offsets []int64
+}
+
+func (s *flakyStorage) Fetch(_ context.Context, _ ociImageSpecV1.Descriptor) (io.ReadCloser, error) {
+	s.fetches++
+	r := &flakyReader{storage: s, reader: bytes.NewReader(s.data), limit: -1}
+	if s.failures > 0 {
+		s.failures--
+		r.limit = s.failAfter
+	}
+	if s.seekable {
+		return r, nil
+	}
+	return struct{ io.ReadCloser }{r}, nil
+}
+
+type flakyReader struct {
+	storage *flakyStorage
+	reader  *bytes.Reader
+	limit   int64
+	read    int64
+}
+
+func (r *flakyReader) Read(p []byte) (int, error) {
+	if r.limit >= 0 {
+		if r.read >= r.limit {
+			return 0, errConnectionReset
+		}
+		p = p[:min(int64(len(p)), r.limit-r.read)]
+	}
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	return n, err
+}
+
+func (r *flakyReader) Seek(offset int64, whence int) (int64, error) {
+	r.storage.offsets = append(r.storage.offsets, offset)
+	return r.reader.Seek(offset, whence)
+}
+
+func (r *flakyReader) Close() error {
+	return nil
+}
+
+func newBlob(t *testing.T, size int) ([]byte, ociImageSpecV1.Descriptor) {
+	t.Helper()
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	return data, ociImageSpecV1.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(data),
+		Size:      int64(size),
+	}
+}
+
+func fetchAll(t *testing.T, storage *resumable.Storage, desc ociImageSpecV1.Descriptor) ([]byte, error) {
+	t.Helper()
+	rc, err := storage.Fetch(t.Context(), desc)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		require.NoError(t, rc.Close())
+	}()
+	return io.ReadAll(rc)
+}
+
+func TestFetch_ResumesInterruptedDownload(t *testing.T) {
+	r := require.New(t)
+	data, desc := newBlob(t, 4096)
+	src := &flakyStorage{ReadOnlyGraphStorage: memory.New(), data: data, seekable: true, failures: 2, failAfter: 1000}
+
+	storage, err := resumable.New(src, t.TempDir(), resumable.WithMinSize(0))
+	r.NoError(err)
+
+	fetched, err := fetchAll(t, storage, desc)
+	r.NoError(err)
+	r.Equal(data, fetched)
+	r.Equal(3, src.fetches)
+	r.Equal([]int64{1000, 2000}, src.offsets, "every retry should continue at the size of the partial download")
+}
+
+func TestFetch_ResumesAcrossStorages(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	data, desc := newBlob(t, 4096)
+	src := &flakyStorage{ReadOnlyGraphStorage: memory.New(), data: data, seekable: true, failures: 1, failAfter: 3000}
+
+	storage, err := resumable.New(src, dir, resumable.WithMinSize(0), resumable.WithMaxAttempts(1))
+	r.NoError(err)
+	_, err = fetchAll(t, storage, desc)
+	r.ErrorIs(err, errConnectionReset)
+
+	partial, err := os.Stat(filepath.Join(dir, desc.Digest.Algorithm().String(), desc.Digest.Encoded()+".partial"))
+	r.NoError(err)
+	r.EqualValues(3000, partial.Size())
+
+	storage, err = resumable.New(src, dir, resumable.WithMinSize(0), resumable.WithMaxAttempts(1))
+	r.NoError(err)
+	fetched, err := fetchAll(t, storage, desc)
+	r.NoError(err)
+	r.Equal(data, fetched)
+	r.Equal([]int64{3000}, src.offsets)
+}
+
+func TestFetch_RestartsWithoutSeekableSource(t *testing.T) {
+	r := require.New(t)
+	data, desc := newBlob(t, 4096)
+	src := &flakyStorage{ReadOnlyGraphStorage: memory.New(), data: data, failures: 1, failAfter: 1000}
+
+	storage, err := resumable.New(src, t.TempDir(), resumable.WithMinSize(0))
+	r.NoError(err)
+
+	fetched, err := fetchAll(t, storage, desc)
+	r.NoError(err)
+	r.Equal(data, fetched)
+	r.Equal(2, src.fetches)
+	r.Empty(src.offsets)
+}
+
+func TestFetch_DigestMismatch(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	data, desc := newBlob(t, 4096)
+	corrupted := bytes.Clone(data)
+	corrupted[42] ^= 0xff
+	src := &flakyStorage{ReadOnlyGraphStorage: memory.New(), data: corrupted, seekable: true}
+
+	storage, err := resumable.New(src, dir, resumable.WithMinSize(0))
+	r.NoError(err)
+
+	_, err = fetchAll(t, storage, desc)
+	r.ErrorIs(err, resumable.ErrDigestMismatch)
+	entries, err := os.ReadDir(filepath.Join(dir, desc.Digest.Algorithm().String()))
+	r.NoError(err)
+	r.Empty(entries, "corrupted downloads must not be resumed")
+}
+
+func TestFetch_VerifiesCompletedDownloads(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	data, desc := newBlob(t, 4096)
+	src := &flakyStorage{ReadOnlyGraphStorage: memory.New(), data: data}
+
+	storage, err := resumable.New(src, dir, resumable.WithMinSize(0))
+	r.NoError(err)
+	_, err = fetchAll(t, storage, desc)
+	r.NoError(err)
+
+	completed := filepath.Join(dir, desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+	r.NoError(os.WriteFile(completed, []byte("tampered"), 0o644))
+
+	fetched, err := fetchAll(t, storage, desc)
+	r.NoError(err)
+	r.Equal(data, fetched)
+	r.Equal(2, src.fetches, "a completed download not matching its digest should be downloaded again")
+}
+
+func TestFetch_SmallBlobsAreFetchedDirectly(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	data, desc := newBlob(t, 128)
+	src := &flakyStorage{ReadOnlyGraphStorage: memory.New(), data: data}
+
+	storage, err := resumable.New(src, dir, resumable.WithMinSize(1024))
+	r.NoError(err)
+
+	fetched, err := fetchAll(t, storage, desc)
+	r.NoError(err)
+	r.Equal(data, fetched)
+	entries, err := os.ReadDir(dir)
+	r.NoError(err)
+	r.Empty(entries)
+}
+
+func TestCleanup(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	data, desc := newBlob(t, 4096)
+	src := &flakyStorage{ReadOnlyGraphStorage: memory.New(), data: data}
+
+	storage, err := resumable.New(src, dir, resumable.WithMinSize(0))
+	r.NoError(err)
+	_, err = fetchAll(t, storage, desc)
+	r.NoError(err)
+
+	// completed downloads are served from the directory until they are cleaned up.
+	_, err = fetchAll(t, storage, desc)
+	r.NoError(err)
+	r.Equal(1, src.fetches)
+	completed := filepath.Join(dir, desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+	r.FileExists(completed)
+
+	r.NoError(storage.Cleanup())
+	r.NoFileExists(completed)
+}
+
+func TestPrune(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	data, desc := newBlob(t, 4096)
+	src := &flakyStorage{ReadOnlyGraphStorage: memory.New(), data: data, seekable: true, failures: 1, failAfter: 1000}
+
+	storage, err := resumable.New(src, dir, resumable.WithMinSize(0), resumable.WithMaxAttempts(1))
+	r.NoError(err)
+	_, err = fetchAll(t, storage, desc)
+	r.ErrorIs(err, errConnectionReset)
+
+	partial := filepath.Join(dir, desc.Digest.Algorithm().String(), desc.Digest.Encoded()+".partial")
+	r.FileExists(partial)
+
+	r.NoError(resumable.Prune(dir, time.Hour))
+	r.FileExists(partial, "recently modified state should be kept")
+
+	old := time.Now().Add(-2 * time.Hour)
+	r.NoError(os.Chtimes(partial, old, old))
+	r.NoError(resumable.Prune(dir, time.Hour))
+	r.NoFileExists(partial, "state older than the maximum age should be removed")
+}
@@ -0,0 +1,64 @@
+package resumable
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// UploadToken identifies an interrupted chunked blob upload session in a registry.
+//
+// Registries implementing the chunked upload API of the OCI distribution specification keep
+// upload sessions open for some time after a chunk was acknowledged. An UploadToken can be
+// attached to the context of a later push of the same blob with [ContextWithUploadToken]
+// to continue the upload at Offset instead of restarting it.
+type UploadToken struct {
+	// Digest is the digest of the blob being uploaded.
+	Digest digest.Digest `json:"digest"`
+	// Location is the URL of the upload session.
+	Location string `json:"location"`
+	// Offset is the number of bytes acknowledged by the registry.
+	Offset int64 `json:"offset"`
+}
+
+// UploadInterruptedError is returned by chunked blob uploads that were interrupted after the
+// upload session was started. Its Token allows to resume the upload, see [ContextWithUploadToken].
+type UploadInterruptedError struct {
+	Token UploadToken
+	Err   error
+}
+
+func (e *UploadInterruptedError) Error() string {
+	return fmt.Sprintf("upload of %s interrupted after %d acknowledged bytes: %v", e.Token.Digest, e.Token.Offset, e.Err)
+}
+
+func (e *UploadInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+type uploadTokensKey struct{}
+
+// ContextWithUploadToken returns a context in which pushes of the blob of the token continue
+// the upload session of the token. If the session expired, the upload restarts from zero.
+// Tokens of multiple blobs can be attached by calling ContextWithUploadToken repeatedly.
+func ContextWithUploadToken(ctx context.Context, token UploadToken) context.Context {
+	tokens := maps.Clone(uploadTokens(ctx))
+	if tokens == nil {
+		tokens = map[digest.Digest]UploadToken{}
+	}
+	tokens[token.Digest] = token
+	return context.WithValue(ctx, uploadTokensKey{}, tokens)
+}
+
+// UploadTokenFromContext returns the token attached to the context for the blob with the given digest.
+func UploadTokenFromContext(ctx context.Context, dgst digest.Digest) (UploadToken, bool) {
+	token, ok := uploadTokens(ctx)[dgst]
+	return token, ok
+}
+
+func uploadTokens(ctx context.Context) map[digest.Digest]UploadToken {
+	tokens, _ := ctx.Value(uploadTokensKey{}).(map[digest.Digest]UploadToken)
+	return tokens
+}
@@ -20,6 +20,14 @@ const (
 	// process or user agent. as such it CAN be correlated to a user agent header in http.
 	OCMCreator = "software.ocm.creator"
 
+	// OCMSignatures is an annotation that lists the names of the signatures of the component version,
+	// separated by OCMSignaturesSeparator. It is only set if the component version is signed.
+	// It allows to find signed component versions without downloading their component descriptors.
+	// The annotation does not prove the validity of the signatures, which still have to be verified.
+	OCMSignatures = "software.ocm.signatures"
+
+	OCMSignaturesSeparator = ","
+
 	OCMComponentVersionAnnotationSeparator = ":"
 )
 
@@ -27,6 +35,19 @@ func NewComponentVersionAnnotation(component, version string) string {
 	return fmt.Sprintf("%s:%s", component, version)
 }
 
+// NewSignaturesAnnotation creates the value of the OCMSignatures annotation from the names of the signatures.
+func NewSignaturesAnnotation(names []string) string {
+	return strings.Join(names, OCMSignaturesSeparator)
+}
+
+// ParseSignaturesAnnotation returns the names of the signatures in the OCMSignatures annotation.
+func ParseSignaturesAnnotation(annotation string) []string {
+	if annotation == "" {
+		return nil
+	}
+	return strings.Split(annotation, OCMSignaturesSeparator)
+}
+
 // ParseComponentVersionAnnotation parses the component version annotation and returns the component name and version.
 // It can identify a possible prefix of the annotation, which is the default component descriptor path and exclude
 // it from the component name. (this can be present in CTFs.)
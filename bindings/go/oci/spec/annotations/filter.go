@@ -0,0 +1,55 @@
+package annotations
+
+import (
+	"slices"
+)
+
+// Filter reports whether the annotations of a component version manifest (or index) match.
+// Filters are evaluated on the annotations that are available from the referrers of a component
+// or from the component version manifest itself, so the component descriptor does not have to be downloaded.
+// Legacy component versions without annotations never match a Filter.
+type Filter func(annotations map[string]string) bool
+
+// MatchAll returns a Filter that matches if all given filters match.
+// Without filters, it matches everything.
+func MatchAll(filters ...Filter) Filter {
+	return func(annotations map[string]string) bool {
+		for _, filter := range filters {
+			if filter != nil && !filter(annotations) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchAnnotation returns a Filter that matches if the annotation key has the given value.
+func MatchAnnotation(key, value string) Filter {
+	return func(annotations map[string]string) bool {
+		actual, ok := annotations[key]
+		return ok && actual == value
+	}
+}
+
+// MatchCreator returns a Filter that matches component versions created by the given creator,
+// e.g. a dedicated pipeline. See OCMCreator.
+func MatchCreator(creator string) Filter {
+	return MatchAnnotation(OCMCreator, creator)
+}
+
+// MatchSigned returns a Filter that matches signed component versions. If names are given,
+// the component version must carry signatures with all of these names. See OCMSignatures.
+func MatchSigned(names ...string) Filter {
+	return func(annotations map[string]string) bool {
+		signatures := ParseSignaturesAnnotation(annotations[OCMSignatures])
+		if len(signatures) == 0 {
+			return false
+		}
+		for _, name := range names {
+			if !slices.Contains(signatures, name) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,33 @@
+package annotations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilters(t *testing.T) {
+	signed := map[string]string{
+		OCMCreator:    "pipeline",
+		OCMSignatures: NewSignaturesAnnotation([]string{"release", "audit"}),
+	}
+	unsigned := map[string]string{OCMCreator: "manual"}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []bool // signed, unsigned, legacy (nil annotations)
+	}{
+		{name: "match all without filters", filter: MatchAll(), expected: []bool{true, true, true}},
+		{name: "creator", filter: MatchCreator("pipeline"), expected: []bool{true, false, false}},
+		{name: "signed", filter: MatchSigned(), expected: []bool{true, false, false}},
+		{name: "signed by name", filter: MatchSigned("audit"), expected: []bool{true, false, false}},
+		{name: "signed by unknown name", filter: MatchSigned("release", "other"), expected: []bool{false, false, false}},
+		{name: "combined", filter: MatchAll(MatchCreator("manual"), nil, MatchSigned()), expected: []bool{false, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, []bool{tt.filter(signed), tt.filter(unsigned), tt.filter(nil)})
+		})
+	}
+}
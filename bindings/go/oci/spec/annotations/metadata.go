@@ -0,0 +1,22 @@
+package annotations
+
+// Component version metadata referrer annotation keys.
+//
+// A metadata referrer is an OCI manifest whose subject points to a component
+// version manifest and whose single layer holds metadata appended after the
+// component version was published. It never changes the component descriptor.
+const (
+	// MetadataName is an annotation that records the name of the metadata on a
+	// metadata referrer manifest, so that referrers can be selected by name
+	// without fetching their layer.
+	MetadataName = "software.ocm.metadata.name"
+)
+
+// MetadataArtifactType is the OCI artifactType set on component version
+// metadata referrer manifests. It enables filtering via the Referrers API
+// (GET /v2/<name>/referrers/<digest>?artifactType=...).
+const MetadataArtifactType = "application/vnd.ocm.software.component-version-metadata.v1+json"
+
+// MetadataMediaType is the media type of the layer holding the metadata of a
+// metadata referrer.
+const MetadataMediaType = "application/vnd.ocm.software.component-version-metadata.v1+json"
@@ -0,0 +1,28 @@
+package annotations
+
+// Component version signature referrer annotation keys.
+//
+// A signature referrer is an OCI manifest whose subject points to a component
+// version manifest and whose single layer holds a signature of the component
+// descriptor that was added after the component version was published.
+// It never changes the component descriptor or its manifest.
+const (
+	// SignatureName is an annotation that records the name of the signature on a
+	// signature referrer manifest, so that referrers can be selected by name
+	// without fetching their layer.
+	SignatureName = "software.ocm.signature.name"
+)
+
+// SignatureArtifactType is the OCI artifactType set on component version
+// signature referrer manifests. It enables filtering via the Referrers API
+// (GET /v2/<name>/referrers/<digest>?artifactType=...).
+const SignatureArtifactType = "application/vnd.ocm.software.component-version-signature.v1+json"
+
+// SignatureMediaType is the media type of the layer holding the signature of a
+// signature referrer.
+const SignatureMediaType = "application/vnd.ocm.software.component-version-signature.v1+json"
+
+// CosignSignatureArtifactType is the OCI artifactType of the referrer manifests that cosign attaches
+// to a signed manifest ("cosign sign --registry-referrers-mode=oci-1-1" with the Sigstore bundle format).
+// It is also the media type of their single layer, which holds the Sigstore bundle.
+const CosignSignatureArtifactType = "application/vnd.dev.sigstore.bundle.v0.3+json"
@@ -9,8 +9,12 @@ import (
 // DockerConfig credential repository configuration.
 var CredentialRepositoryConfigType = runtime.NewVersionedType("DockerConfig", "v1")
 
+// KubernetesSecretsConfigType is the canonical versioned type for the
+// KubernetesSecrets credential repository configuration.
+var KubernetesSecretsConfigType = runtime.NewVersionedType(v1.KubernetesSecretsType, v1.Version)
+
 // Scheme contains the credential repository configuration types provided by
-// this package (currently DockerConfig).
+// this package (currently DockerConfig and KubernetesSecrets).
 //
 // It does NOT contain credential payload types such as OCICredentials.
 // Credential payloads describe authentication material consumed by repositories,
@@ -35,7 +39,7 @@ func init() {
 }
 
 // MustAddToScheme registers credential repository configuration types provided
-// by this package (DockerConfig) into the given scheme.
+// by this package (DockerConfig and KubernetesSecrets) into the given scheme.
 //
 // This MUST NOT be used to register credential payload types such as
 // OCICredentials — see Scheme docs for the rationale.
@@ -45,4 +49,9 @@ func MustAddToScheme(scheme *runtime.Scheme) {
 		CredentialRepositoryConfigType,
 		runtime.NewUnversionedType(v1.DockerConfigType),
 	)
+	kubernetesSecrets := &v1.KubernetesSecrets{}
+	scheme.MustRegisterWithAlias(kubernetesSecrets,
+		KubernetesSecretsConfigType,
+		runtime.NewUnversionedType(v1.KubernetesSecretsType),
+	)
 }
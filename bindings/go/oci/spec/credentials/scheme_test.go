@@ -12,7 +12,7 @@ import (
 
 // TestSchemeContainsOnlyRepositoryConfigTypes verifies that the package-level
 // Scheme exposes the credential repository configuration types
-// (DockerConfig) and does NOT contain credential payload types
+// (DockerConfig, KubernetesSecrets) and does NOT contain credential payload types
 // (OCICredentials).
 //
 // Mixing the two would cause OCICredentials specs to be wired to the
@@ -27,6 +27,8 @@ func TestSchemeContainsOnlyRepositoryConfigTypes(t *testing.T) {
 		"DockerConfig must be registered in the credential repository scheme")
 	assert.True(t, credentials.Scheme.IsRegistered(runtime.NewUnversionedType(credentialsv1.DockerConfigType)),
 		"unversioned DockerConfig must be registered in the credential repository scheme")
+	assert.True(t, credentials.Scheme.IsRegistered(runtime.NewVersionedType(credentialsv1.KubernetesSecretsType, credentialsv1.Version)),
+		"KubernetesSecrets must be registered in the credential repository scheme")
 
 	assert.False(t, credentials.Scheme.IsRegistered(ociCredentialsType),
 		"OCICredentials is a credential payload, not a repository config, and must not be in Scheme")
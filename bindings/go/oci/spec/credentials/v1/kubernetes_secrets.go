@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"fmt"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// KubernetesSecretsType is the type name for KubernetesSecrets credentials.
+	KubernetesSecretsType = "KubernetesSecrets"
+)
+
+var KubernetesSecretsVersionedType = runtime.NewVersionedType(KubernetesSecretsType, Version)
+
+// KubernetesSecrets is a type that represents a credential repository backed by Kubernetes Secrets
+// of type kubernetes.io/dockerconfigjson.
+//
+// The secrets are consulted in the given order, the first secret offering credentials
+// for a registry wins. Reading the secrets requires a SecretProvider that has access to the cluster,
+// the controller and the CLI offer one based on their cluster configuration.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type KubernetesSecrets struct {
+	// +ocm:jsonschema-gen:enum=KubernetesSecrets/v1
+	Type runtime.Type `json:"type"`
+	// Kubeconfig is the reference path to the kubeconfig used to access the cluster.
+	// If empty, the default cluster configuration of the environment is used.
+	// The controller does not support a kubeconfig and only reads secrets from its own cluster.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Namespace of the secrets. If empty, the CLI uses the namespace of the current kubeconfig context
+	// and the controller the namespace of the object the configuration is referenced by.
+	// The controller rejects all other namespaces.
+	Namespace string `json:"namespace,omitempty"`
+	// SecretNames are the names of the secrets to read credentials from.
+	SecretNames []string `json:"secretNames"`
+}
+
+func (c *KubernetesSecrets) String() string {
+	base := c.GetType().String()
+	if c.Namespace != "" {
+		base += fmt.Sprintf("(%s)", c.Namespace)
+	}
+	return base + fmt.Sprintf("%v", c.SecretNames)
+}
@@ -34,6 +34,36 @@ func (in *DockerConfig) DeepCopyTyped() runtime.Typed {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesSecrets) DeepCopyInto(out *KubernetesSecrets) {
+	*out = *in
+	out.Type = in.Type
+	if in.SecretNames != nil {
+		in, out := &in.SecretNames, &out.SecretNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSecrets.
+func (in *KubernetesSecrets) DeepCopy() *KubernetesSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyTyped is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Typed.
+func (in *KubernetesSecrets) DeepCopyTyped() runtime.Typed {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCICredentials) DeepCopyInto(out *OCICredentials) {
 	*out = *in
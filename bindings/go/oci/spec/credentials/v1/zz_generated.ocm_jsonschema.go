@@ -12,6 +12,9 @@ import (
 //go:embed schemas/DockerConfig.schema.json
 var schemaDockerConfig []byte
 
+//go:embed schemas/KubernetesSecrets.schema.json
+var schemaKubernetesSecrets []byte
+
 //go:embed schemas/OCICredentials.schema.json
 var schemaOCICredentials []byte
 
@@ -20,6 +23,11 @@ func (DockerConfig) JSONSchema() []byte {
 	return schemaDockerConfig
 }
 
+// JSONSchema returns the JSON Schema for KubernetesSecrets.
+func (KubernetesSecrets) JSONSchema() []byte {
+	return schemaKubernetesSecrets
+}
+
 // JSONSchema returns the JSON Schema for OCICredentials.
 func (OCICredentials) JSONSchema() []byte {
 	return schemaOCICredentials
@@ -17,6 +17,16 @@ func (t *DockerConfig) GetType() runtime.Type {
 	return t.Type
 }
 
+// SetType is an autogenerated setter function, useful for type inference and defaulting.
+func (t *KubernetesSecrets) SetType(typ runtime.Type) {
+	t.Type = typ
+}
+
+// GetType is an autogenerated getter function, useful for type inference and defaulting.
+func (t *KubernetesSecrets) GetType() runtime.Type {
+	return t.Type
+}
+
 // SetType is an autogenerated setter function, useful for type inference and defaulting.
 func (t *OCICredentials) SetType(typ runtime.Type) {
 	t.Type = typ
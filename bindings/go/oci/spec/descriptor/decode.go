@@ -27,7 +27,7 @@ func SingleFileDecodeDescriptor(raw io.Reader, mediaType string, unmarshal Unmar
 		if err != nil {
 			return nil, fmt.Errorf("unable to get component descriptor stream from tar: %w", err)
 		}
-		descriptorYAML, err := io.ReadAll(descriptorStream)
+		descriptorYAML, err := readDescriptor(descriptorStream)
 		if err != nil {
 			return nil, fmt.Errorf("unable to read component descriptor stream from tar: %w", err)
 		}
@@ -41,7 +41,7 @@ func SingleFileDecodeDescriptor(raw io.Reader, mediaType string, unmarshal Unmar
 		}
 		return desc, nil
 	default:
-		descriptorYAML, err := io.ReadAll(raw)
+		descriptorYAML, err := readDescriptor(raw)
 		if err != nil {
 			return nil, fmt.Errorf("unable to read component descriptor stream from descriptor with format %q: %w", mediaType, err)
 		}
@@ -57,7 +57,20 @@ func SingleFileDecodeDescriptor(raw io.Reader, mediaType string, unmarshal Unmar
 	}
 }
 
-const maxDescriptorSize = 1 ^ 1024*1024*1024 // 1 GB
+const maxDescriptorSize = 1 << 30 // 1 GiB
+
+// readDescriptor reads a component descriptor of at most maxDescriptorSize bytes, so that descriptors
+// received from untrusted registries cannot exhaust the memory before they are decoded.
+func readDescriptor(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxDescriptorSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDescriptorSize {
+		return nil, fmt.Errorf("component descriptor is larger than %d bytes", maxDescriptorSize)
+	}
+	return data, nil
+}
 
 // descriptorFileFromTar reads the component descriptor from a tar.
 // The component is expected to be inside the tar in a file called LegacyComponentDescriptorTarFileName.
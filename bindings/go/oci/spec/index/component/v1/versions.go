@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"slices"
+
+	"github.com/opencontainers/image-spec/specs-go"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+)
+
+// VersionIndexTag is the tag of the component version index in the repository of a component.
+//
+// The component version index lists the manifests of all component versions of a component.
+// In contrast to the Component Index, which only serves as subject for the Referrers API,
+// it can be read with a single manifest request. This allows listing component versions on
+// registries that neither support the Referrers API nor tag listing.
+const VersionIndexTag = "ocm-component-version-index"
+
+// VersionIndexArtifactType is the artifact type of the component version index.
+const VersionIndexArtifactType = "application/vnd.ocm.software.component-version-index.v1+json"
+
+// versionIndexAnnotations are the annotations of component version manifests kept in the
+// component version index. They are sufficient to list and filter component versions.
+var versionIndexAnnotations = []string{
+	annotations.OCMComponentVersion,
+	annotations.OCMCreator,
+	annotations.OCMSignatures,
+}
+
+// NewVersionIndex creates an empty component version index.
+func NewVersionIndex() *ociImageSpecV1.Index {
+	return &ociImageSpecV1.Index{
+		Versioned: specs.Versioned{
+			SchemaVersion: 2,
+		},
+		MediaType:    ociImageSpecV1.MediaTypeImageIndex,
+		ArtifactType: VersionIndexArtifactType,
+		Manifests:    []ociImageSpecV1.Descriptor{},
+	}
+}
+
+// AddVersion adds the manifest of a component version to the component version index.
+// An existing entry for the same component version is replaced.
+// The manifest has to carry the annotations.OCMComponentVersion annotation.
+func AddVersion(index *ociImageSpecV1.Index, manifest ociImageSpecV1.Descriptor) {
+	entry := ociImageSpecV1.Descriptor{
+		MediaType:    manifest.MediaType,
+		ArtifactType: manifest.ArtifactType,
+		Digest:       manifest.Digest,
+		Size:         manifest.Size,
+		Annotations:  make(map[string]string, len(versionIndexAnnotations)),
+	}
+	for _, key := range versionIndexAnnotations {
+		if value, ok := manifest.Annotations[key]; ok {
+			entry.Annotations[key] = value
+		}
+	}
+
+	version := entry.Annotations[annotations.OCMComponentVersion]
+	if i := slices.IndexFunc(index.Manifests, func(existing ociImageSpecV1.Descriptor) bool {
+		return existing.Annotations[annotations.OCMComponentVersion] == version
+	}); i >= 0 {
+		index.Manifests[i] = entry
+		return
+	}
+	index.Manifests = append(index.Manifests, entry)
+}
+
+// RemoveVersion removes the given version from the component version index.
+// It reports whether an entry was removed.
+func RemoveVersion(index *ociImageSpecV1.Index, version string) bool {
+	n := len(index.Manifests)
+	index.Manifests = slices.DeleteFunc(index.Manifests, func(existing ociImageSpecV1.Descriptor) bool {
+		_, existingVersion, err := annotations.ParseComponentVersionAnnotation(existing.Annotations[annotations.OCMComponentVersion])
+		return err == nil && existingVersion == version
+	})
+	return len(index.Manifests) != n
+}
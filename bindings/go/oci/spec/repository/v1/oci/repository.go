@@ -57,6 +57,20 @@ type Repository struct {
 	//     BaseUrl="ghcr.io/open-component-model/ocm" + SubPath=""
 	//     → Auto-extracts to: BaseUrl="ghcr.io", SubPath="open-component-model/ocm"
 	SubPath string `json:"subPath,omitempty"`
+
+	// Mode restricts the operations that are allowed on the repository.
+	// If not specified, the repository is writable.
+	//
+	// Examples:
+	//   - "readonly": all write operations are rejected
+	//   - "maintenance": all write operations are rejected and MaintenanceMessage is reported to clients
+	Mode Mode `json:"mode,omitempty"`
+	// MaintenanceMessage is the message reported to clients when a write operation is rejected
+	// because the repository is in maintenance mode.
+	//
+	// Examples:
+	//   - "registry migration in progress until 2026-01-01, contact #platform"
+	MaintenanceMessage string `json:"maintenanceMessage,omitempty"`
 }
 
 func (spec *Repository) String() string {
@@ -80,3 +94,17 @@ const (
 	// Its future availability is being evaluated by the community.
 	GlobalAccessPolicyAuto GlobalAccessPolicy = "auto"
 )
+
+// Mode defines which operations are allowed on a repository.
+// The zero value (empty string) allows all operations.
+type Mode string
+
+const (
+	// ModeReadWrite allows all operations on the repository. This is the default (zero value).
+	ModeReadWrite Mode = ""
+	// ModeReadOnly rejects all write operations on the repository.
+	ModeReadOnly Mode = "readonly"
+	// ModeMaintenance rejects all write operations on the repository and reports
+	// the configured maintenance message to clients.
+	ModeMaintenance Mode = "maintenance"
+)
@@ -124,6 +124,7 @@ It is used to store the component descriptor in an OCI registry and can be refer
 		},
 		Layers: append([]ociImageSpecV1.Descriptor{descriptorOCIDescriptor}, opts.AdditionalLayers...),
 	}
+	addSignaturesAnnotation(manifest.Annotations, descriptor)
 	if opts.ReferrerTrackingPolicy == ReferrerTrackingPolicyByIndexAndSubject {
 		manifest.Subject = &indexv1.Descriptor
 	}
@@ -174,6 +175,7 @@ It is used to store the component descriptor manifest and other related blob man
 			ociImageSpecV1.AnnotationVersion:       version,
 		},
 	}
+	addSignaturesAnnotation(idx.Annotations, descriptor)
 	if opts.ReferrerTrackingPolicy == ReferrerTrackingPolicyByIndexAndSubject {
 		idx.Subject = &indexv1.Descriptor
 	}
@@ -232,3 +234,16 @@ func getDescriptorFromStore(ctx context.Context, store spec.Store, reference str
 
 	return desc, &manifest, index, nil
 }
+
+// addSignaturesAnnotation records the names of the signatures of a signed descriptor,
+// so that signed component versions can be listed without fetching their descriptors.
+func addSignaturesAnnotation(manifestAnnotations map[string]string, descriptor *descriptor.Descriptor) {
+	if len(descriptor.Signatures) == 0 {
+		return
+	}
+	names := make([]string, 0, len(descriptor.Signatures))
+	for _, signature := range descriptor.Signatures {
+		names = append(names, signature.Name)
+	}
+	manifestAnnotations[annotations.OCMSignatures] = annotations.NewSignaturesAnnotation(names)
+}
@@ -57,7 +57,7 @@ func copyToOCILayoutInMemoryAsync(ctx context.Context, src content.ReadOnlyGraph
 	}()
 
 	// Create an OCI layout writer over the gzip stream.
-	target, targetErr := NewOCILayoutWriterWithTempFile(zippedBuf, opts.TempDir)
+	target, targetErr := NewOCILayoutWriterWithTempFileContext(ctx, zippedBuf, opts.TempDir)
 	if targetErr != nil {
 		err = targetErr
 		return
@@ -0,0 +1,229 @@
+package tar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+
+	"ocm.software/open-component-model/bindings/go/oci/internal/introspection"
+)
+
+var (
+	// ErrInvalidPlatform is returned for platforms without operating system or architecture.
+	ErrInvalidPlatform = errors.New("invalid platform")
+	// ErrDuplicatePlatform is returned when a manifest is added for a platform that already has one.
+	ErrDuplicatePlatform = errors.New("duplicate platform")
+)
+
+// ParsePlatform parses a platform in the form os/architecture[/variant], e.g. "linux/arm64/v8".
+func ParsePlatform(platform string) (ociImageSpecV1.Platform, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return ociImageSpecV1.Platform{}, fmt.Errorf("%w %q: expected os/architecture[/variant]", ErrInvalidPlatform, platform)
+	}
+	p := ociImageSpecV1.Platform{
+		OS:           parts[0],
+		Architecture: parts[1],
+	}
+	if len(parts) == 3 {
+		p.Variant = parts[2]
+	}
+	if err := validatePlatform(p); err != nil {
+		return ociImageSpecV1.Platform{}, err
+	}
+	return p, nil
+}
+
+// FormatPlatform formats a platform in the form os/architecture[/variant] understood by [ParsePlatform].
+func FormatPlatform(platform ociImageSpecV1.Platform) string {
+	s := platform.OS + "/" + platform.Architecture
+	if platform.Variant != "" {
+		s += "/" + platform.Variant
+	}
+	return s
+}
+
+func validatePlatform(platform ociImageSpecV1.Platform) error {
+	if platform.OS == "" || platform.Architecture == "" {
+		return fmt.Errorf("%w %q: os and architecture are required", ErrInvalidPlatform, FormatPlatform(platform))
+	}
+	return nil
+}
+
+// IndexBuilder authors a multi-platform image index in an [OCILayoutWriter], so that constructors
+// and inputs producing multi-arch images do not need to hand-craft manifest and index JSON.
+//
+// Per-platform manifests are added with [IndexBuilder.AddManifest] or authored from pushed config
+// and layer blobs with [IndexBuilder.PushManifest]. [IndexBuilder.Push] computes the index and
+// pushes it to the writer. All methods are safe for concurrent use, and the computed index does not
+// depend on the order in which manifests were added.
+type IndexBuilder struct {
+	writer *OCILayoutWriter
+
+	mu          sync.Mutex
+	manifests   []ociImageSpecV1.Descriptor
+	annotations map[string]string
+}
+
+// NewIndexBuilder creates an [IndexBuilder] authoring an index in the writer.
+func NewIndexBuilder(writer *OCILayoutWriter) *IndexBuilder {
+	return &IndexBuilder{writer: writer}
+}
+
+// SetAnnotations sets the annotations of the index.
+func (b *IndexBuilder) SetAnnotations(annotations map[string]string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.annotations = maps.Clone(annotations)
+}
+
+// AddManifest adds a manifest that has been pushed to the writer as the manifest of the platform.
+// It returns [ErrDuplicatePlatform] if the index already contains a manifest for the platform.
+func (b *IndexBuilder) AddManifest(ctx context.Context, platform ociImageSpecV1.Platform, manifest ociImageSpecV1.Descriptor) error {
+	if err := validatePlatform(platform); err != nil {
+		return err
+	}
+	if !introspection.IsOCICompliantManifest(manifest) {
+		return fmt.Errorf("cannot add %s with media type %q to index: %w", manifest.Digest, manifest.MediaType, errdef.ErrUnsupported)
+	}
+	exists, err := b.writer.Exists(ctx, manifest)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("manifest %s for platform %s was not pushed: %w", manifest.Digest, FormatPlatform(platform), errdef.ErrNotFound)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, existing := range b.manifests {
+		if samePlatform(*existing.Platform, platform) {
+			return fmt.Errorf("%w: index already contains manifest %s for platform %s", ErrDuplicatePlatform, existing.Digest, FormatPlatform(platform))
+		}
+	}
+	manifest.Platform = &platform
+	b.manifests = append(b.manifests, manifest)
+	return nil
+}
+
+// PushManifest authors an image manifest from a config and layers that have been pushed to the writer,
+// pushes it and adds it as the manifest of the platform. It returns the descriptor of the manifest.
+func (b *IndexBuilder) PushManifest(
+	ctx context.Context,
+	platform ociImageSpecV1.Platform,
+	config ociImageSpecV1.Descriptor,
+	layers []ociImageSpecV1.Descriptor,
+	annotations map[string]string,
+) (ociImageSpecV1.Descriptor, error) {
+	if err := validatePlatform(platform); err != nil {
+		return ociImageSpecV1.Descriptor{}, err
+	}
+	for _, blob := range append([]ociImageSpecV1.Descriptor{config}, layers...) {
+		exists, err := b.writer.Exists(ctx, blob)
+		if err != nil {
+			return ociImageSpecV1.Descriptor{}, err
+		}
+		if !exists {
+			return ociImageSpecV1.Descriptor{}, fmt.Errorf("blob %s of manifest for platform %s was not pushed: %w",
+				blob.Digest, FormatPlatform(platform), errdef.ErrNotFound)
+		}
+	}
+	if layers == nil {
+		layers = []ociImageSpecV1.Descriptor{}
+	}
+
+	manifest := ociImageSpecV1.Manifest{
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   ociImageSpecV1.MediaTypeImageManifest,
+		Config:      config,
+		Layers:      layers,
+		Annotations: annotations,
+	}
+	desc, err := b.push(ctx, ociImageSpecV1.MediaTypeImageManifest, manifest)
+	if err != nil {
+		return ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to push manifest for platform %s: %w", FormatPlatform(platform), err)
+	}
+	if err := b.AddManifest(ctx, platform, desc); err != nil {
+		return ociImageSpecV1.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// Index computes the index from the added manifests, ordered by platform.
+func (b *IndexBuilder) Index() (*ociImageSpecV1.Index, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.manifests) == 0 {
+		return nil, errors.New("index contains no manifests")
+	}
+
+	manifests := slices.Clone(b.manifests)
+	slices.SortFunc(manifests, func(a, b ociImageSpecV1.Descriptor) int {
+		return strings.Compare(platformSortKey(*a.Platform), platformSortKey(*b.Platform))
+	})
+	return &ociImageSpecV1.Index{
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   ociImageSpecV1.MediaTypeImageIndex,
+		Manifests:   manifests,
+		Annotations: maps.Clone(b.annotations),
+	}, nil
+}
+
+// Push computes the index and pushes it to the writer. If reference is not empty,
+// the index is tagged with it. It returns the descriptor of the index.
+func (b *IndexBuilder) Push(ctx context.Context, reference string) (ociImageSpecV1.Descriptor, error) {
+	index, err := b.Index()
+	if err != nil {
+		return ociImageSpecV1.Descriptor{}, err
+	}
+	desc, err := b.push(ctx, ociImageSpecV1.MediaTypeImageIndex, index)
+	if err != nil {
+		return ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to push index: %w", err)
+	}
+	if reference != "" {
+		if err := b.writer.Tag(ctx, desc, reference); err != nil {
+			return ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to tag index: %w", err)
+		}
+	}
+	return desc, nil
+}
+
+func (b *IndexBuilder) push(ctx context.Context, mediaType string, v any) (ociImageSpecV1.Descriptor, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ociImageSpecV1.Descriptor{}, err
+	}
+	desc := ociImageSpecV1.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	if err := b.writer.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+		return ociImageSpecV1.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+func samePlatform(a, b ociImageSpecV1.Platform) bool {
+	return platformSortKey(a) == platformSortKey(b)
+}
+
+// platformSortKey identifies a platform including the fields not represented by [FormatPlatform].
+func platformSortKey(platform ociImageSpecV1.Platform) string {
+	features := slices.Clone(platform.OSFeatures)
+	slices.Sort(features)
+	return strings.Join([]string{
+		platform.OS, platform.Architecture, platform.Variant, platform.OSVersion, strings.Join(features, ","),
+	}, "/")
+}
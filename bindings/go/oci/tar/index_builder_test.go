@@ -0,0 +1,160 @@
+package tar
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		platform string
+		expected ociImageSpecV1.Platform
+		err      bool
+	}{
+		{platform: "linux/amd64", expected: ociImageSpecV1.Platform{OS: "linux", Architecture: "amd64"}},
+		{platform: "linux/arm64/v8", expected: ociImageSpecV1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}},
+		{platform: "linux", err: true},
+		{platform: "/amd64", err: true},
+		{platform: "linux/arm/v7/extra", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			r := require.New(t)
+			platform, err := ParsePlatform(tt.platform)
+			if tt.err {
+				r.ErrorIs(err, ErrInvalidPlatform)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.expected, platform)
+			r.Equal(tt.platform, FormatPlatform(platform))
+		})
+	}
+}
+
+func TestIndexBuilder(t *testing.T) {
+	r := require.New(t)
+	platforms := []string{"linux/arm64/v8", "linux/amd64", "windows/amd64"}
+
+	var index ociImageSpecV1.Descriptor
+	store := readLayout(t, func(w *OCILayoutWriter) {
+		builder := NewIndexBuilder(w)
+		builder.SetAnnotations(map[string]string{ociImageSpecV1.AnnotationTitle: "multi-arch"})
+		for _, p := range platforms {
+			platform, err := ParsePlatform(p)
+			r.NoError(err)
+			config := pushBlob(t, w, ociImageSpecV1.MediaTypeImageConfig, `{"os":"`+platform.OS+`","architecture":"`+platform.Architecture+`"}`)
+			layer := pushBlob(t, w, ociImageSpecV1.MediaTypeImageLayer, "layer for "+p)
+			_, err = builder.PushManifest(t.Context(), platform, config, []ociImageSpecV1.Descriptor{layer}, nil)
+			r.NoError(err)
+		}
+
+		var err error
+		index, err = builder.Push(t.Context(), "v1.0.0")
+		r.NoError(err)
+		r.Equal(ociImageSpecV1.MediaTypeImageIndex, index.MediaType)
+	})
+
+	main := store.MainArtifacts(t.Context())
+	r.Len(main, 1, "platform manifests must be contained by the index")
+	r.Equal(index.Digest, main[0].Digest)
+	resolved, err := store.Resolve(t.Context(), "v1.0.0")
+	r.NoError(err)
+	r.Equal(index.Digest, resolved.Digest)
+
+	data, err := content.FetchAll(t.Context(), store, index)
+	r.NoError(err)
+	var idx ociImageSpecV1.Index
+	r.NoError(json.Unmarshal(data, &idx))
+	r.Equal(2, idx.SchemaVersion)
+	r.Equal("multi-arch", idx.Annotations[ociImageSpecV1.AnnotationTitle])
+	r.Len(idx.Manifests, len(platforms))
+	var got []string
+	for _, manifest := range idx.Manifests {
+		r.Equal(ociImageSpecV1.MediaTypeImageManifest, manifest.MediaType)
+		r.NotNil(manifest.Platform)
+		got = append(got, FormatPlatform(*manifest.Platform))
+		exists, err := store.Exists(t.Context(), manifest)
+		r.NoError(err)
+		r.True(exists)
+	}
+	r.Equal([]string{"linux/amd64", "linux/arm64/v8", "windows/amd64"}, got, "manifests must be ordered by platform")
+}
+
+func TestIndexBuilder_Deterministic(t *testing.T) {
+	r := require.New(t)
+	build := func(platforms ...string) ociImageSpecV1.Descriptor {
+		w, err := NewOCILayoutWriterWithTempFile(io.Discard, t.TempDir())
+		r.NoError(err)
+		defer func() { r.NoError(w.Close()) }()
+		builder := NewIndexBuilder(w)
+		config := pushBlob(t, w, ociImageSpecV1.MediaTypeImageConfig, "{}")
+		for _, p := range platforms {
+			platform, err := ParsePlatform(p)
+			r.NoError(err)
+			_, err = builder.PushManifest(t.Context(), platform, config, nil, map[string]string{"platform": p})
+			r.NoError(err)
+		}
+		index, err := builder.Push(t.Context(), "")
+		r.NoError(err)
+		return index
+	}
+	r.Equal(build("linux/amd64", "linux/arm64"), build("linux/arm64", "linux/amd64"))
+}
+
+func TestIndexBuilder_Errors(t *testing.T) {
+	w, err := NewOCILayoutWriterWithTempFile(io.Discard, t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, w.Close()) })
+	amd64 := ociImageSpecV1.Platform{OS: "linux", Architecture: "amd64"}
+	config := pushBlob(t, w, ociImageSpecV1.MediaTypeImageConfig, "{}")
+
+	t.Run("empty index", func(t *testing.T) {
+		_, err := NewIndexBuilder(w).Push(t.Context(), "")
+		require.ErrorContains(t, err, "no manifests")
+	})
+
+	t.Run("invalid platform", func(t *testing.T) {
+		_, err := NewIndexBuilder(w).PushManifest(t.Context(), ociImageSpecV1.Platform{OS: "linux"}, config, nil, nil)
+		require.ErrorIs(t, err, ErrInvalidPlatform)
+	})
+
+	t.Run("missing blob", func(t *testing.T) {
+		layer := ociImageSpecV1.Descriptor{MediaType: ociImageSpecV1.MediaTypeImageLayer, Digest: digest.FromString("missing"), Size: 7}
+		_, err := NewIndexBuilder(w).PushManifest(t.Context(), amd64, config, []ociImageSpecV1.Descriptor{layer}, nil)
+		require.ErrorIs(t, err, errdef.ErrNotFound)
+	})
+
+	t.Run("no manifest", func(t *testing.T) {
+		err := NewIndexBuilder(w).AddManifest(t.Context(), amd64, config)
+		require.ErrorIs(t, err, errdef.ErrUnsupported)
+	})
+
+	t.Run("duplicate platform", func(t *testing.T) {
+		r := require.New(t)
+		builder := NewIndexBuilder(w)
+		manifest, err := builder.PushManifest(t.Context(), amd64, config, nil, nil)
+		r.NoError(err)
+		r.ErrorIs(builder.AddManifest(t.Context(), amd64, manifest), ErrDuplicatePlatform)
+		r.NoError(builder.AddManifest(t.Context(), ociImageSpecV1.Platform{OS: "linux", Architecture: "arm64"}, manifest))
+	})
+}
+
+func pushBlob(t *testing.T, w *OCILayoutWriter, mediaType, data string) ociImageSpecV1.Descriptor {
+	t.Helper()
+	desc := ociImageSpecV1.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromString(data),
+		Size:      int64(len(data)),
+	}
+	require.NoError(t, w.Push(t.Context(), desc, bytes.NewReader([]byte(data))))
+	return desc
+}
@@ -20,6 +20,7 @@ import (
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/errdef"
 
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	"ocm.software/open-component-model/bindings/go/oci/internal/introspection"
 )
 
@@ -82,10 +83,27 @@ func NewOCILayoutWriterWithTempFile(output io.Writer, dir string) (*OCILayoutWri
 	return NewOCILayoutWriter(output, &removingCloser{File: tmpFile}), nil
 }
 
+// NewOCILayoutWriterWithTempFileContext is like [NewOCILayoutWriterWithTempFile], but creates
+// the temp file with the [tempfile.Manager] carried by ctx.
+// Besides being removed on [OCILayoutWriter.Close], the temp file is removed as soon as ctx is done,
+// so it does not leak if the write is interrupted, and it is found by [tempfile.Manager.SweepOrphans]
+// if the process dies before.
+func NewOCILayoutWriterWithTempFileContext(ctx context.Context, output io.Writer, dir string) (*OCILayoutWriter, error) {
+	manager := tempfile.FromContext(ctx)
+	tmpFile, err := manager.CreateTemp(ctx, dir, "oci-layout-buffer-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file for OCI layout tar: %w", err)
+	}
+
+	return NewOCILayoutWriter(output, &removingCloser{File: tmpFile, remove: manager.Release}), nil
+}
+
 // removingCloser wraps an [*os.File] so that [removingCloser.Close] both closes the
 // file descriptor and removes the file from disk.
 type removingCloser struct {
 	*os.File
+	// remove removes the file from disk, defaults to [os.Remove].
+	remove func(name string) error
 }
 
 // Close closes the underlying file and removes it from disk. Both errors (if any)
@@ -93,7 +111,11 @@ type removingCloser struct {
 func (rc *removingCloser) Close() error {
 	name := rc.Name()
 	err := rc.File.Close()
-	return errors.Join(err, os.Remove(name))
+	remove := rc.remove
+	if remove == nil {
+		remove = os.Remove
+	}
+	return errors.Join(err, remove(name))
 }
 
 // OCILayoutWriter writes an OCI image layout as a tar archive with support for concurrent blob pushes.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	"ocm.software/open-component-model/bindings/go/credentials"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/oci/spec/transformation/v1alpha1"
@@ -97,14 +98,14 @@ func (t *GetLocalResource) Transform(ctx context.Context, step runtime.Typed) (r
 	}
 
 	// Determine output path
-	if outputPath, err = DetermineOutputPath(outputPath, "resource"); err != nil {
+	if outputPath, err = DetermineOutputPath(ctx, outputPath, "resource"); err != nil {
 		return nil, fmt.Errorf("failed determining output path: %w", err)
 	}
 
 	// Buffer blob to file
 	fileSpec, err := filesystem.BlobToSpec(blobContent, outputPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed buffering blob to file: %w", err)
+		return nil, errors.Join(fmt.Errorf("failed buffering blob to file: %w", err), tempfile.Release(ctx, outputPath))
 	}
 
 	// Convert resource to v2 format
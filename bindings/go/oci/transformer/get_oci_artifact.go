@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	"ocm.software/open-component-model/bindings/go/credentials"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
@@ -65,14 +66,14 @@ func (t *GetOCIArtifact) Transform(ctx context.Context, step runtime.Typed) (run
 	}
 
 	// Determine output path
-	if outputPath, err = DetermineOutputPath(outputPath, "oci-artifact"); err != nil {
+	if outputPath, err = DetermineOutputPath(ctx, outputPath, "oci-artifact"); err != nil {
 		return nil, fmt.Errorf("failed determining output path: %w", err)
 	}
 
 	// Buffer blob to file
 	fileSpec, err := filesystem.BlobToSpec(blobContent, outputPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed buffering blob to file: %w", err)
+		return nil, errors.Join(fmt.Errorf("failed buffering blob to file: %w", err), tempfile.Release(ctx, outputPath))
 	}
 
 	// Convert resource to v2 format
@@ -1,20 +1,24 @@
 package transformer
 
 import (
+	"context"
 	"fmt"
 	"os"
+
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 )
 
 // DetermineOutputPath determines the output path for buffering the blob content.
-// If the outputPath is empty, it creates a temporary file in the default temp directory.
+// If the outputPath is empty, it creates a temporary file with the [tempfile.Manager] carried by ctx.
+// The file outlives ctx, but is removed once the manager is cleaned up.
 // If the outputPath is provided, it checks if the path exists:
 //   - If the outputPath does not exist, it returns an error.
 //   - If the outputPath exists and is a file, it returns an error.
 //   - If the outputPath exists and is a directory, it creates a temporary file in that directory with the filePrefix as a prefix.
-func DetermineOutputPath(outputPath string, filePrefix string) (string, error) {
+func DetermineOutputPath(ctx context.Context, outputPath string, filePrefix string) (string, error) {
 	if outputPath == "" {
-		// Create a temporary file in the default temp directory
-		tempFile, err := os.CreateTemp("", filePrefix+"-*")
+		// Create a temporary file tracked by the session, the output is consumed after the transformation returned
+		tempFile, err := tempfile.CreateTemp(context.WithoutCancel(ctx), "", filePrefix+"-*")
 		if err != nil {
 			return "", fmt.Errorf("failed creating temporary file: %w", err)
 		}
@@ -13,7 +13,7 @@ import (
 
 func Test_DetermineOutputPath_EmptyPath(t *testing.T) {
 	prefix := "test-prefix"
-	outputPath, err := transformer.DetermineOutputPath("", prefix)
+	outputPath, err := transformer.DetermineOutputPath(t.Context(), "", prefix)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, outputPath)
@@ -29,7 +29,7 @@ func Test_DetermineOutputPath_DirectoryPath(t *testing.T) {
 	tempDir := t.TempDir()
 	prefix := "test-prefix"
 
-	outputPath, err := transformer.DetermineOutputPath(tempDir, prefix)
+	outputPath, err := transformer.DetermineOutputPath(t.Context(), tempDir, prefix)
 
 	require.NoError(t, err)
 	assert.Contains(t, filepath.Base(outputPath), prefix)
@@ -41,7 +41,7 @@ func Test_DetermineOutputPath_DirectoryPath(t *testing.T) {
 }
 
 func Test_DetermineOutputPath_NonExistentPath(t *testing.T) {
-	_, err := transformer.DetermineOutputPath("/nonexistent/path", "test-prefix")
+	_, err := transformer.DetermineOutputPath(t.Context(), "/nonexistent/path", "test-prefix")
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "output path does not exist")
@@ -52,7 +52,7 @@ func Test_DetermineOutputPath_ExistingFilePath(t *testing.T) {
 	filePath := filepath.Join(tempDir, "existing-file.tar.gz")
 	require.NoError(t, os.WriteFile(filePath, []byte("content"), 0o644))
 
-	_, err := transformer.DetermineOutputPath(filePath, "test-prefix")
+	_, err := transformer.DetermineOutputPath(t.Context(), filePath, "test-prefix")
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "is a file, not a directory")
@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	credentialrefreshv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialrefresh/v1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+type credentialRefresherKey struct{}
+
+// credentialRefresher requests refreshed credentials through the credential refresh channel of the plugin manager.
+type credentialRefresher struct {
+	typ      types.ConnectionType
+	location string
+	token    string
+}
+
+var _ credentialrefreshv1.CredentialRefresher = (*credentialRefresher)(nil)
+
+func (r *credentialRefresher) RefreshCredentials(ctx context.Context, identity runtime.Identity) (runtime.Typed, error) {
+	return plugins.RefreshCredentials(ctx, r.typ, r.location, r.token, identity)
+}
+
+func withCredentialRefresher(ctx context.Context, refresher credentialrefreshv1.CredentialRefresher) context.Context {
+	return context.WithValue(ctx, credentialRefresherKey{}, refresher)
+}
+
+// CredentialRefresherFromContext returns the credential refresher carried by the context of every request
+// served by a Plugin. Handlers can pass it on to clients that need to refresh expired credentials during
+// long-running operations.
+func CredentialRefresherFromContext(ctx context.Context) (credentialrefreshv1.CredentialRefresher, bool) {
+	refresher, ok := ctx.Value(credentialRefresherKey{}).(credentialrefreshv1.CredentialRefresher)
+	return refresher, ok
+}
+
+// RefreshCredentials requests refreshed credentials for the consumer identity from the plugin manager.
+// The credentials passed with a call might expire before a long-running operation, such as the transfer
+// of a large artifact, is done. Handlers call RefreshCredentials with the request context to continue
+// with new credentials instead of failing.
+// It returns an error wrapping credentialrefreshv1.ErrRefreshUnavailable if the plugin manager does not
+// offer credential refresh.
+func RefreshCredentials(ctx context.Context, identity runtime.Identity) (runtime.Typed, error) {
+	refresher, ok := CredentialRefresherFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: context is not the context of a plugin request", credentialrefreshv1.ErrRefreshUnavailable)
+	}
+	return refresher.RefreshCredentials(ctx, identity)
+}
@@ -7,6 +7,23 @@
 //   - determine listening address ( for tcp: get a free port and listen on it; unix: create a name of the socket )
 //
 // GracefulShutdown will handle interrupts and will clean up any created unix domain sockets if any were created.
+// Temporary files of handlers should be created with tempfile.CreateTemp using the request context, so that
+// they are cleaned up when the request is cancelled, when the plugin shuts down, or on the next start of the
+// plugin if it was killed.
+// Handlers returning blobs should write them with BlobLocation, which uses memory-mapped files handed off to the
+// manager if it enabled shared memory blobs, avoiding copies of large blobs.
+// Handlers of long-running operations can request refreshed credentials for a consumer identity from the manager
+// with RefreshCredentials using the request context, in case the credentials passed with the call expire.
+// If the manager selects the gRPC transport (see types.Config.Transport), the handlers are served through a single
+// multiplexed gRPC connection instead of HTTP/1.1. Handlers are identical for both transports, the SDK advertises
+// both in the capabilities of the plugin.
+// Unix domain sockets are created in the directory of the socket policy of the manager (see types.Config.Socket),
+// and their permissions, owner and SELinux label are set according to the policy before the manager is told
+// where to connect.
+// Every plugin exposes metrics in the Prometheus text format on MetricsEndpoint: the number and latency of the
+// requests per handler, the requests in flight and the idle timeout as well as the time the plugin has been idle.
+// Plugins can register their own collectors with the registry returned by Metrics. The plugin manager scrapes and
+// aggregates the metrics of all plugins, see manager.PluginManager.MetricsCollector.
 // The following code is an example on how to use this package:
 // First, call the appropriate endpoint builder to get the right handlers and config that needs to be sent back to
 // the manager:
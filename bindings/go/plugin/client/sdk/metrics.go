@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsEndpoint is the endpoint on which every plugin exposes its metrics in the Prometheus text format.
+// Requests to it do not count as work of the plugin, so scraping the metrics does not keep an idle plugin alive.
+const MetricsEndpoint = "/metrics"
+
+// metrics are the metrics of the handlers and the idle timeout of a plugin.
+type metrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	// lastActivity is the time in unix nanoseconds the plugin last finished work.
+	lastActivity atomic.Int64
+}
+
+func newMetrics(p *Plugin) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ocm_plugin_requests_total",
+			Help: "Number of requests handled by the plugin, by handler and status code.",
+		}, []string{"handler", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ocm_plugin_request_duration_seconds",
+			Help:    "Latency of the requests handled by the plugin, by handler.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"handler"}),
+	}
+	m.lastActivity.Store(time.Now().UnixNano())
+
+	idleTimeout := time.Hour
+	if p.Config.IdleTimeout != nil {
+		idleTimeout = *p.Config.IdleTimeout
+	}
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "ocm_plugin_in_flight_requests",
+			Help: "Number of requests the plugin is currently working on.",
+		}, func() float64 {
+			return float64(p.workerCounter.Load())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "ocm_plugin_idle_timeout_seconds",
+			Help: "Time after which the plugin shuts down if it does not work on any request.",
+		}, func() float64 {
+			return idleTimeout.Seconds()
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "ocm_plugin_idle_seconds",
+			Help: "Time since the plugin finished its last request, 0 while it works on requests.",
+		}, func() float64 {
+			if p.workerCounter.Load() > 0 {
+				return 0
+			}
+			return time.Since(time.Unix(0, m.lastActivity.Load())).Seconds()
+		}),
+	)
+	return m
+}
+
+// instrument records the number and the latency of the requests of the handler at the given location.
+func (m *metrics) instrument(location string, h http.HandlerFunc) http.HandlerFunc {
+	labels := prometheus.Labels{"handler": location}
+	return promhttp.InstrumentHandlerDuration(m.duration.MustCurryWith(labels),
+		promhttp.InstrumentHandlerCounter(m.requests.MustCurryWith(labels), h))
+}
+
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Metrics returns the registry of the metrics the plugin exposes on MetricsEndpoint.
+// Plugins can register their own collectors with it.
+func (p *Plugin) Metrics() *prometheus.Registry {
+	return p.metrics.registry
+}
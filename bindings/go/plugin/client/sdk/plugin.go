@@ -10,12 +10,20 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
 	"sync/atomic"
 	"syscall"
 	"time"
 
+	"google.golang.org/grpc"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
+	credentialrefreshv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialrefresh/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/endpoints"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/blobs"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 )
@@ -28,13 +36,20 @@ import (
 type Plugin struct {
 	Config types.Config
 
-	handlers      []endpoints.Handler
-	server        *http.Server
+	handlers []endpoints.Handler
+	server   *http.Server
+	// grpcServer serves the handlers instead of server if the manager selected types.TransportGRPC.
+	grpcServer    *grpc.Server
 	interrupt     chan bool
 	workerCounter atomic.Int64
 	location      string
 	output        io.Writer
 	baseCtx       context.Context
+	tempFiles     *tempfile.Manager
+	// credentialRefresher requests refreshed credentials from the manager during requests.
+	credentialRefresher *credentialRefresher
+	// metrics are exposed on MetricsEndpoint.
+	metrics *metrics
 	// this should be a logger using stderr instead of default logger.
 	logger slog.Logger
 }
@@ -47,13 +62,72 @@ type Plugin struct {
 // TODO(Skarlso): Provide documentation for secure data flow with local certificate
 // setup and certificate generation. At least start a document / issue.
 func NewPlugin(ctx context.Context, logger *slog.Logger, conf types.Config, output io.Writer) *Plugin {
-	return &Plugin{
+	p := &Plugin{
 		Config:    conf,
 		interrupt: make(chan bool, 1), // to not block any new work coming in
 		output:    output,
 		baseCtx:   ctx, // base context is used for graceful shutdown operation to finish properly
-		logger:    *logger,
+		tempFiles: tempfile.NewManager(),
+		credentialRefresher: &credentialRefresher{
+			typ:      conf.Type,
+			location: conf.CredentialRefreshLocation,
+			token:    os.Getenv(credentialrefreshv1.TokenEnv),
+		},
+		logger: *logger,
+	}
+	p.metrics = newMetrics(p)
+	return p
+}
+
+// TempFiles returns the manager of the plugin's temporary files. It is also carried by the
+// context of every request, so handlers should create their temporary files with
+// [tempfile.CreateTemp]. Files bound to the request context are removed once the request is done,
+// all remaining files are removed on GracefulShutdown. Files of previous runs of the plugin that
+// did not shut down gracefully are removed on Start.
+func (p *Plugin) TempFiles() *tempfile.Manager {
+	return p.tempFiles
+}
+
+// BlobLocation writes b to a location that handlers can return to the manager.
+// If the manager accepts shared memory blobs (see types.Config.SharedMemoryBlobs), b is written into a
+// memory-mapped file whose ownership is handed off to the manager, which removes it once it consumed it.
+// Shared memory files that the manager did not consume are removed on GracefulShutdown.
+// Otherwise, b is written to a local file that is left to the manager.
+func (p *Plugin) BlobLocation(b blob.ReadOnlyBlob) (_ types.Location, err error) {
+	dir := ""
+	if p.Config.SharedMemoryBlobs {
+		dir = blobs.SharedMemoryDir()
+	}
+	file, err := p.tempFiles.CreateTemp(p.baseCtx, dir, "blob-*")
+	if err != nil {
+		return types.Location{}, fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, p.tempFiles.Release(file.Name()))
+		}
+	}()
+
+	if p.Config.SharedMemoryBlobs {
+		location, err := blobs.WriteSharedMemory(file, b)
+		return location, errors.Join(err, file.Close())
+	}
+
+	if err := file.Close(); err != nil {
+		return types.Location{}, err
+	}
+	if err := filesystem.CopyBlobToOSPath(b, file.Name()); err != nil {
+		return types.Location{}, fmt.Errorf("failed to write blob file: %w", err)
 	}
+	p.tempFiles.Keep(file.Name())
+	location := types.Location{
+		LocationType: types.LocationTypeLocalFile,
+		Value:        file.Name(),
+	}
+	if mediaTypeAware, ok := b.(blob.MediaTypeAware); ok {
+		location.MediaType, _ = mediaTypeAware.MediaType()
+	}
+	return location, nil
 }
 
 func (p *Plugin) startIdleChecker(ctx context.Context) {
@@ -88,14 +162,17 @@ func (p *Plugin) startIdleChecker(ctx context.Context) {
 	}
 }
 
+// The worker counter is updated before notifying the idle checker, so that the checker
+// sees the finished work and restarts the idle timeout once the last request is done.
 func (p *Plugin) StartWork() {
-	p.interrupt <- true
 	p.workerCounter.Add(1)
+	p.interrupt <- true
 }
 
 func (p *Plugin) StopWork() {
-	p.interrupt <- false
+	p.metrics.lastActivity.Store(time.Now().UnixNano())
 	p.workerCounter.Add(-1)
+	p.interrupt <- false
 }
 
 // Start starts the plugin and sets up a graceful shutdown catch for interrupts.
@@ -115,12 +192,18 @@ func (p *Plugin) Start(ctx context.Context) error {
 		if err := p.GracefulShutdown(ctx); err != nil {
 			p.logger.ErrorContext(ctx, "Error shutting down plugin", "error", err)
 		}
-		if err := p.server.Close(); err != nil {
+		if err := p.closeServer(); err != nil {
 			p.logger.ErrorContext(ctx, "failed to close server", "error", err)
 		}
 		p.logger.InfoContext(ctx, "Plugin shutdown complete", "id", p.Config.ID)
 	}()
 
+	if removed, err := p.tempFiles.SweepOrphans(); err != nil {
+		p.logger.WarnContext(ctx, "failed to remove orphaned temporary files", "error", err)
+	} else if len(removed) > 0 {
+		p.logger.InfoContext(ctx, "removed orphaned temporary files", "count", len(removed))
+	}
+
 	err := p.listen(ctx)
 
 	if errors.Is(err, http.ErrServerClosed) {
@@ -160,6 +243,13 @@ func (p *Plugin) listen(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to socket from client: %w", err)
 	}
 
+	if p.Config.Type == types.Socket {
+		// the policy is applied before the location is written, so the manager never connects to the socket before.
+		if err := plugins.ApplySocketPolicy(ctx, loc, p.Config.Socket); err != nil {
+			return errors.Join(fmt.Errorf("failed to apply socket policy: %w", err), conn.Close())
+		}
+	}
+
 	m := http.NewServeMux()
 	for _, h := range p.handlers {
 		m.HandleFunc(h.Location, p.panicRecovery(h.Handler))
@@ -167,27 +257,42 @@ func (p *Plugin) listen(ctx context.Context) error {
 
 	m.HandleFunc("/shutdown", p.Shutdown)
 	m.HandleFunc("/healthz", p.Healthz)
+	m.Handle(MetricsEndpoint, p.metrics.handler())
 
-	server := &http.Server{
-		Handler:           m,
-		ReadHeaderTimeout: 5 * time.Second,
-		ReadTimeout:       15 * time.Second,
-		BaseContext: func(listener net.Listener) context.Context {
-			return ctx
-		},
+	requestContext := func(ctx context.Context) context.Context {
+		return withCredentialRefresher(tempfile.WithManager(ctx, p.tempFiles), p.credentialRefresher)
+	}
+
+	switch p.Config.Transport {
+	case "", types.TransportHTTP:
+		p.server = &http.Server{
+			Handler:           m,
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			BaseContext: func(listener net.Listener) context.Context {
+				return requestContext(ctx)
+			},
+		}
+	case types.TransportGRPC:
+		// the requests are canceled with their stream, so the request context is derived from the stream.
+		p.grpcServer = plugins.NewGRPCServer(m, requestContext)
+	default:
+		return errors.Join(fmt.Errorf("unsupported transport: %s", p.Config.Transport), conn.Close())
 	}
 
 	// start idle checker.
 	go p.startIdleChecker(ctx)
 
-	p.server = server
-
 	// output the location before starting the server
 	var schemedLocation string
-	switch p.Config.Type {
-	case types.TCP:
+	switch {
+	case p.Config.Type == types.TCP && p.grpcServer != nil:
+		schemedLocation = "grpc://" + loc
+	case p.Config.Type == types.TCP:
 		schemedLocation = loc // http is already included in tcp output
-	case types.Socket:
+	case p.grpcServer != nil:
+		schemedLocation = "grpc+unix://" + loc
+	default:
 		schemedLocation = "http+unix://" + loc
 	}
 
@@ -195,7 +300,39 @@ func (p *Plugin) listen(ctx context.Context) error {
 		return fmt.Errorf("failed to write location to output writer: %w", err)
 	}
 
-	return server.Serve(conn)
+	if p.grpcServer != nil {
+		return p.grpcServer.Serve(conn)
+	}
+	return p.server.Serve(conn)
+}
+
+// shutdownServer stops the server serving the handlers, waiting for in-flight requests until ctx is done.
+func (p *Plugin) shutdownServer(ctx context.Context) error {
+	if p.grpcServer == nil {
+		return p.server.Shutdown(ctx)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		p.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		p.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
+
+// closeServer stops the server serving the handlers immediately.
+func (p *Plugin) closeServer() error {
+	if p.grpcServer != nil {
+		p.grpcServer.Stop()
+		return nil
+	}
+	return p.server.Close()
 }
 
 func (p *Plugin) panicRecovery(f func(w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
@@ -217,7 +354,11 @@ func (p *Plugin) panicRecovery(f func(w http.ResponseWriter, r *http.Request)) h
 func (p *Plugin) determineLocation(ctx context.Context) (_ string, err error) {
 	switch p.Config.Type {
 	case types.Socket:
-		loc := "/tmp/" + p.Config.ID + "-plugin.socket"
+		dir := "/tmp"
+		if p.Config.Socket != nil && p.Config.Socket.Directory != "" {
+			dir = p.Config.Socket.Directory
+		}
+		loc := filepath.Join(dir, p.Config.ID+"-plugin.socket")
 		if _, err := os.Stat(loc); err == nil {
 			if cleanupErr := p.performCleanUp(loc); cleanupErr != nil {
 				return "", fmt.Errorf("could not cleanup socket: %w", cleanupErr)
@@ -251,11 +392,12 @@ func (p *Plugin) determineLocation(ctx context.Context) (_ string, err error) {
 }
 
 // GracefulShutdown will stop the server and do cleanup if necessary.
-// In the case of sockets, it will remove the created socket.
+// In the case of sockets, it will remove the created socket. Temporary files
+// created with the manager of the plugin are removed as well.
 func (p *Plugin) GracefulShutdown(ctx context.Context) error {
 	p.logger.InfoContext(ctx, "gracefully shutting down plugin", "id", p.Config.ID)
 	// We ignore server closed errors because server closing might race with the listener.
-	if err := p.server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+	if err := p.shutdownServer(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return fmt.Errorf("failed to shutdown server: %w", err)
 	}
 
@@ -273,6 +415,10 @@ func (p *Plugin) GracefulShutdown(ctx context.Context) error {
 		// empty case for now
 	}
 
+	if err := p.tempFiles.Cleanup(); err != nil {
+		return fmt.Errorf("failed to remove temporary files: %w", err)
+	}
+
 	p.logger.InfoContext(ctx, "plugin shutdown complete", "id", p.Config.ID)
 	return nil
 }
@@ -283,7 +429,7 @@ func (p *Plugin) RegisterHandlers(handlers ...endpoints.Handler) error {
 			return fmt.Errorf("handler for %s is required", h.Location)
 		}
 
-		h.Handler = p.workerHandler(h.Handler)
+		h.Handler = p.workerHandler(p.metrics.instrument(h.Location, h.Handler))
 		p.handlers = append(p.handlers, h)
 	}
 
@@ -311,9 +457,17 @@ func (p *Plugin) workerHandler(h http.HandlerFunc) http.HandlerFunc {
 func (p *Plugin) Shutdown(w http.ResponseWriter, _ *http.Request) {
 	p.logger.InfoContext(p.baseCtx, "Shutting down plugin", "id", p.Config.ID)
 	w.WriteHeader(http.StatusOK)
-	if err := p.GracefulShutdown(p.baseCtx); err != nil {
-		p.logger.ErrorContext(p.baseCtx, "Error shutting down plugin", "error", err)
+	shutdown := func() {
+		if err := p.GracefulShutdown(p.baseCtx); err != nil {
+			p.logger.ErrorContext(p.baseCtx, "Error shutting down plugin", "error", err)
+		}
+	}
+	if p.grpcServer != nil {
+		// the gRPC server waits for this request before it stops, so the shutdown must not be awaited.
+		go shutdown()
+		return
 	}
+	shutdown()
 }
 
 // performCleanUp looks for a lock file that contains the pid of the process using the corresponding socket file.
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -16,8 +17,14 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
+	credentialrefreshv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialrefresh/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/endpoints"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/blobs"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
 const testPluginType = "test-plugin-type"
@@ -129,6 +136,54 @@ func TestPluginSDKForceShutdownContext(t *testing.T) {
 	}, 10*time.Second, 5*time.Millisecond)
 }
 
+func TestPluginSDKGRPCTransport(t *testing.T) {
+	r := require.New(t)
+
+	output, outputWriter := io.Pipe()
+	location := "/tmp/test-plugin-grpc-plugin.socket"
+	ctx := context.Background()
+	p := NewPlugin(ctx, slog.Default(), types.Config{
+		ID:         "test-plugin-grpc",
+		Type:       types.Socket,
+		PluginType: testPluginType,
+		Transport:  types.TransportGRPC,
+	}, outputWriter)
+
+	t.Cleanup(func() {
+		r.NoError(os.RemoveAll(location))
+	})
+
+	r.NoError(p.RegisterHandlers(endpoints.Handler{
+		Handler: func(writer http.ResponseWriter, request *http.Request) {
+			_, _ = writer.Write([]byte("hello"))
+		},
+		Location: "/test-location",
+	}))
+
+	go func() {
+		_ = p.Start(ctx)
+	}()
+
+	plugin := &types.Plugin{ID: p.Config.ID, Config: p.Config, Stdout: output}
+	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+	r.NoError(err)
+	r.Equal(location, loc)
+
+	resp, err := plugins.CallStream(ctx, client, types.Socket, loc, "test-location", http.MethodGet)
+	r.NoError(err)
+	content, err := io.ReadAll(resp.Body)
+	r.NoError(err)
+	r.NoError(resp.Body.Close())
+	r.Equal("hello", string(content))
+
+	// the shutdown endpoint must not wait for the gRPC server, which waits for the request.
+	r.NoError(plugins.Call(ctx, client, types.Socket, loc, "shutdown", http.MethodGet))
+	r.Eventually(func() bool {
+		_, err := os.Stat(location)
+		return os.IsNotExist(err)
+	}, 10*time.Second, 5*time.Millisecond)
+}
+
 func TestIdleChecker(t *testing.T) {
 	r := require.New(t)
 	location := "/tmp/test-plugin-idle-plugin.socket"
@@ -245,6 +300,145 @@ func TestPanicRecovery(t *testing.T) {
 	r.NoError(p.GracefulShutdown(ctx))
 }
 
+func TestTempFileCleanup(t *testing.T) {
+	r := require.New(t)
+	location := "/tmp/test-plugin-tempfile-plugin.socket"
+	output := bytes.NewBuffer(nil)
+	ctx := context.Background()
+	p := NewPlugin(ctx, slog.Default(), types.Config{
+		ID:         "test-plugin-tempfile",
+		Type:       types.Socket,
+		PluginType: testPluginType,
+	}, output)
+
+	t.Cleanup(func() {
+		r.NoError(os.RemoveAll(location))
+	})
+
+	r.NoError(p.RegisterHandlers(endpoints.Handler{
+		Handler: func(writer http.ResponseWriter, request *http.Request) {
+			requestBound, err := tempfile.CreateTemp(request.Context(), "", "request-*")
+			if err != nil {
+				writer.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = requestBound.Close()
+
+			sessionBound, err := tempfile.CreateTemp(context.WithoutCancel(request.Context()), "", "session-*")
+			if err != nil {
+				writer.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = sessionBound.Close()
+
+			_, _ = writer.Write([]byte(requestBound.Name() + "\n" + sessionBound.Name()))
+		},
+		Location: "/tempfile-endpoint",
+	}))
+
+	go func() {
+		_ = p.Start(ctx)
+	}()
+
+	httpClient := createHttpClient(location)
+	waitForPlugin(r, httpClient)
+
+	resp, err := httpClient.Get("http://unix/tempfile-endpoint")
+	r.NoError(err)
+	r.Equal(http.StatusOK, resp.StatusCode)
+	content, err := io.ReadAll(resp.Body)
+	r.NoError(err)
+	r.NoError(resp.Body.Close())
+	requestFile, sessionFile, ok := strings.Cut(string(content), "\n")
+	r.True(ok)
+	r.Contains(sessionFile, p.TempFiles().SessionID())
+
+	// the request file is bound to the request and removed once it is done.
+	r.Eventually(func() bool {
+		_, err := os.Stat(requestFile)
+		return os.IsNotExist(err)
+	}, 5*time.Second, 20*time.Millisecond)
+	r.FileExists(sessionFile)
+
+	r.NoError(p.GracefulShutdown(ctx))
+	r.NoFileExists(sessionFile)
+}
+
+// refreshResolver resolves credentials that tell how often they were refreshed.
+type refreshResolver struct {
+	refreshes int
+}
+
+func (r *refreshResolver) Resolve(_ context.Context, identity runtime.Identity) (runtime.Typed, error) {
+	r.refreshes++
+	raw := &runtime.Raw{}
+	err := raw.UnmarshalJSON([]byte(`{"type":"Credentials/v1","properties":{"hostname":"` + identity["hostname"] + `","refresh":"` + strconv.Itoa(r.refreshes) + `"}}`))
+	return raw, err
+}
+
+func TestCredentialRefresh(t *testing.T) {
+	r := require.New(t)
+	location := "/tmp/test-plugin-credential-refresh-plugin.socket"
+	output := bytes.NewBuffer(nil)
+	ctx := context.Background()
+
+	server, err := plugins.NewCredentialRefreshServer(t.Context(), types.Socket)
+	r.NoError(err)
+	t.Cleanup(func() {
+		r.NoError(server.Close(ctx))
+	})
+	server.SetResolver(&refreshResolver{})
+	grant, err := server.Grant()
+	r.NoError(err)
+	grant.Allow(runtime.Identity{"type": "OCIRegistry", "hostname": "ghcr.io"})
+	t.Setenv(credentialrefreshv1.TokenEnv, grant.Token())
+
+	p := NewPlugin(ctx, slog.Default(), types.Config{
+		ID:                        "test-plugin-credential-refresh",
+		Type:                      types.Socket,
+		PluginType:                testPluginType,
+		CredentialRefreshLocation: server.Location(),
+	}, output)
+
+	t.Cleanup(func() {
+		r.NoError(os.RemoveAll(location))
+	})
+
+	r.NoError(p.RegisterHandlers(endpoints.Handler{
+		Handler: func(writer http.ResponseWriter, request *http.Request) {
+			creds, err := RefreshCredentials(request.Context(), runtime.Identity{"type": "OCIRegistry", "hostname": "ghcr.io"})
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			_, _ = writer.Write(creds.(*runtime.Raw).Data)
+		},
+		Location: "/refresh-endpoint",
+	}))
+
+	go func() {
+		_ = p.Start(ctx)
+	}()
+
+	httpClient := createHttpClient(location)
+	waitForPlugin(r, httpClient)
+
+	for _, refresh := range []string{"1", "2"} {
+		resp, err := httpClient.Get("http://unix/refresh-endpoint")
+		r.NoError(err)
+		content, err := io.ReadAll(resp.Body)
+		r.NoError(err)
+		r.NoError(resp.Body.Close())
+		r.Equal(http.StatusOK, resp.StatusCode, string(content))
+		r.JSONEq(`{"type":"Credentials/v1","properties":{"hostname":"ghcr.io","refresh":"`+refresh+`"}}`, string(content))
+	}
+
+	_, err = RefreshCredentials(ctx, runtime.Identity{"type": "OCIRegistry"})
+	r.ErrorIs(err, credentialrefreshv1.ErrRefreshUnavailable, "outside of requests no refresher is available")
+
+	r.NoError(p.GracefulShutdown(ctx))
+}
+
 func waitForPlugin(r *require.Assertions, httpClient *http.Client) {
 	r.Eventually(func() bool {
 		resp, err := httpClient.Get("http://unix/healthz")
@@ -405,3 +599,101 @@ func createHttpClient(location string) *http.Client {
 	}
 	return httpClient
 }
+
+func TestBlobLocation(t *testing.T) {
+	content := []byte("blob content")
+
+	t.Run("shared memory", func(t *testing.T) {
+		r := require.New(t)
+		p := NewPlugin(t.Context(), slog.Default(), types.Config{ID: "test-blob-location", SharedMemoryBlobs: true}, io.Discard)
+
+		location, err := p.BlobLocation(inmemory.New(bytes.NewReader(content)))
+		r.NoError(err)
+		r.Equal(types.LocationTypeSharedMemory, location.LocationType)
+		r.Equal(blobs.SharedMemoryDir(), filepath.Dir(location.Value))
+
+		b, err := blobs.CreateBlobData(location)
+		r.NoError(err)
+		r.NoFileExists(location.Value)
+		rc, err := b.ReadCloser()
+		r.NoError(err)
+		data, err := io.ReadAll(rc)
+		r.NoError(err)
+		r.Equal(content, data)
+	})
+
+	t.Run("local file", func(t *testing.T) {
+		r := require.New(t)
+		p := NewPlugin(t.Context(), slog.Default(), types.Config{ID: "test-blob-location"}, io.Discard)
+
+		location, err := p.BlobLocation(inmemory.New(bytes.NewReader(content)))
+		r.NoError(err)
+		r.Equal(types.LocationTypeLocalFile, location.LocationType)
+		t.Cleanup(func() {
+			_ = os.Remove(location.Value)
+		})
+
+		// local files are left to the manager.
+		r.NoError(p.TempFiles().Cleanup())
+		data, err := os.ReadFile(location.Value)
+		r.NoError(err)
+		r.Equal(content, data)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	r := require.New(t)
+	location := "/tmp/test-plugin-metrics-plugin.socket"
+	output := bytes.NewBuffer(nil)
+	timeout := time.Minute
+	ctx := context.Background()
+	p := NewPlugin(ctx, slog.Default(), types.Config{
+		ID:          "test-plugin-metrics",
+		Type:        types.Socket,
+		PluginType:  testPluginType,
+		IdleTimeout: &timeout,
+	}, output)
+
+	t.Cleanup(func() {
+		r.NoError(p.GracefulShutdown(ctx))
+		r.NoError(os.RemoveAll(location))
+	})
+
+	r.NoError(p.RegisterHandlers(endpoints.Handler{
+		Handler: func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.Query().Get("fail") != "" {
+				writer.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = writer.Write([]byte("hello"))
+		},
+		Location: "/test-location",
+	}))
+
+	go func() {
+		_ = p.Start(ctx)
+	}()
+
+	httpClient := createHttpClient(location)
+	waitForPlugin(r, httpClient)
+
+	for _, query := range []string{"", "", "?fail=true"} {
+		resp, err := httpClient.Get("http://unix/test-location" + query)
+		r.NoError(err)
+		r.NoError(resp.Body.Close())
+	}
+
+	resp, err := httpClient.Get("http://unix" + MetricsEndpoint)
+	r.NoError(err)
+	defer resp.Body.Close()
+	r.Equal(http.StatusOK, resp.StatusCode)
+	content, err := io.ReadAll(resp.Body)
+	r.NoError(err)
+	metrics := string(content)
+	r.Contains(metrics, `ocm_plugin_requests_total{code="200",handler="/test-location"} 2`)
+	r.Contains(metrics, `ocm_plugin_requests_total{code="400",handler="/test-location"} 1`)
+	r.Contains(metrics, `ocm_plugin_request_duration_seconds_count{handler="/test-location"} 3`)
+	r.Contains(metrics, "ocm_plugin_in_flight_requests 0")
+	r.Contains(metrics, "ocm_plugin_idle_timeout_seconds 60")
+	r.Contains(metrics, "ocm_plugin_idle_seconds ")
+}
@@ -0,0 +1,115 @@
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	blobtransformerv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/blobtransformer/v1"
+	componentlisterv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/componentlister/v1"
+	credentialpluginv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialplugin/v1"
+	credentialrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentials/v1"
+	digestprocessorv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/digestprocessor/v1"
+	inputv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/input/v1"
+	ocmrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
+	resourcev1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/resource/v1"
+	signinghandlerv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/signing/v1"
+	mtypes "ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	pluginruntime "ocm.software/open-component-model/bindings/go/plugin/manager/types/runtime"
+)
+
+// ErrIncompatibleContract is matched by ContractVersionError.
+var ErrIncompatibleContract = errors.New("incompatible plugin contract")
+
+// ContractVersionError is returned when registering a plugin that serves one of its capabilities
+// with no version of the contract the manager supports.
+type ContractVersionError struct {
+	// PluginID is the ID of the plugin.
+	PluginID string
+	// Capability is the type of the capability.
+	Capability mtypes.PluginType
+	// Version is the version the plugin chose, if it chose one the manager does not support.
+	Version string
+	// Offered are the versions of the contract the manager supports.
+	Offered []string
+	// Supported are the versions of the contract the plugin supports.
+	Supported []string
+}
+
+func (e *ContractVersionError) Error() string {
+	if e.Version != "" {
+		return fmt.Sprintf("plugin %s chose unsupported contract version %s for capability %s, supported versions are: %s",
+			e.PluginID, e.Version, e.Capability, strings.Join(e.Offered, ", "))
+	}
+	return fmt.Sprintf("plugin %s supports contract versions %s for capability %s, but the plugin manager only supports %s",
+		e.PluginID, strings.Join(e.Supported, ", "), e.Capability, strings.Join(e.Offered, ", "))
+}
+
+func (e *ContractVersionError) Is(target error) bool {
+	return target == ErrIncompatibleContract
+}
+
+// contractVersions are the versions of the contracts of all capabilities the manager supports, in the order of
+// preference. When a new revision of a contract ships, it is added in front of the previous versions, which keep
+// being supported for plugins that were built against them.
+// ATTENTION: keep in sync with the capability types registered in the scheme.
+var contractVersions = mtypes.ContractVersions{
+	ocmrepositoryv1.ComponentVersionRepositoryPluginType:  {mtypes.DefaultContractVersion},
+	blobtransformerv1.BlobTransformerPluginType:           {mtypes.DefaultContractVersion},
+	credentialrepositoryv1.CredentialRepositoryPluginType: {mtypes.DefaultContractVersion},
+	credentialpluginv1.CredentialPluginType:               {mtypes.DefaultContractVersion},
+	componentlisterv1.ComponentListerPluginType:           {mtypes.DefaultContractVersion},
+	digestprocessorv1.DigestProcessorPluginType:           {mtypes.DefaultContractVersion},
+	inputv1.InputPluginType:                               {mtypes.DefaultContractVersion},
+	resourcev1.ResourceRepositoryPluginType:               {mtypes.DefaultContractVersion},
+	signinghandlerv1.SigningHandlerPluginType:             {mtypes.DefaultContractVersion},
+}
+
+// contractVersionsEnv returns the environment variable with which the contract versions are advertised
+// to the capabilities command of plugins.
+func contractVersionsEnv() (string, error) {
+	encoded, err := json.Marshal(contractVersions)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode contract versions: %w", err)
+	}
+	return mtypes.ContractVersionsEnv + "=" + string(encoded), nil
+}
+
+// negotiatedContractVersions returns the contract versions the plugin serves its capabilities with.
+// Capabilities the plugin reports no contract for are served with mtypes.DefaultContractVersion,
+// as the plugin predates the negotiation of contract versions.
+func negotiatedContractVersions(id string, pluginSpec *pluginruntime.PluginSpec) (map[mtypes.PluginType]string, error) {
+	negotiated := make(map[mtypes.PluginType]string, len(pluginSpec.CapabilitySpecs))
+	var errs []error
+	for _, capability := range pluginSpec.CapabilitySpecs {
+		typ := mtypes.PluginType(capability.GetType().Name)
+		if _, ok := negotiated[typ]; ok {
+			continue
+		}
+		offered := contractVersions[typ]
+		var contract mtypes.Contract
+		if i := slices.IndexFunc(pluginSpec.Contracts, func(c mtypes.Contract) bool { return c.Capability == typ }); i >= 0 {
+			contract = pluginSpec.Contracts[i]
+		} else {
+			contract = mtypes.Contract{Capability: typ, Supported: []string{mtypes.DefaultContractVersion}}
+			contract.Version, _ = mtypes.Negotiate(offered, contract.Supported)
+		}
+		if contract.Version == "" || !slices.Contains(offered, contract.Version) {
+			errs = append(errs, &ContractVersionError{
+				PluginID:   id,
+				Capability: typ,
+				Version:    contract.Version,
+				Offered:    offered,
+				Supported:  contract.Supported,
+			})
+			continue
+		}
+		negotiated[typ] = contract.Version
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return negotiated, nil
+}
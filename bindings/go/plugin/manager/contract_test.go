@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	ocmrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	pluginruntime "ocm.software/open-component-model/bindings/go/plugin/manager/types/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func TestNegotiatedContractVersions(t *testing.T) {
+	capability := &ocmrepositoryv1.CapabilitySpec{
+		Type: runtime.NewUnversionedType(string(ocmrepositoryv1.ComponentVersionRepositoryPluginType)),
+	}
+	tests := []struct {
+		name      string
+		contracts []types.Contract
+		want      string
+		wantErr   string
+	}{
+		{
+			name: "plugin without negotiation serves the default version",
+			want: types.DefaultContractVersion,
+		},
+		{
+			name: "negotiated version",
+			contracts: []types.Contract{
+				{Capability: ocmrepositoryv1.ComponentVersionRepositoryPluginType, Version: "v1", Supported: []string{"v2", "v1"}},
+			},
+			want: "v1",
+		},
+		{
+			name: "no version in common",
+			contracts: []types.Contract{
+				{Capability: ocmrepositoryv1.ComponentVersionRepositoryPluginType, Supported: []string{"v2"}},
+			},
+			wantErr: "plugin test supports contract versions v2 for capability componentVersionRepository, but the plugin manager only supports v1",
+		},
+		{
+			name: "chosen version not offered",
+			contracts: []types.Contract{
+				{Capability: ocmrepositoryv1.ComponentVersionRepositoryPluginType, Version: "v3", Supported: []string{"v3"}},
+			},
+			wantErr: "plugin test chose unsupported contract version v3 for capability componentVersionRepository, supported versions are: v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			negotiated, err := negotiatedContractVersions("test", &pluginruntime.PluginSpec{
+				CapabilitySpecs: []runtime.Typed{capability},
+				Contracts:       tt.contracts,
+			})
+			if tt.wantErr != "" {
+				r.ErrorIs(err, ErrIncompatibleContract)
+				r.EqualError(err, tt.wantErr)
+				return
+			}
+			r.NoError(err)
+			r.Equal(map[types.PluginType]string{ocmrepositoryv1.ComponentVersionRepositoryPluginType: tt.want}, negotiated)
+		})
+	}
+}
+
+func TestPluginManagerContractVersions(t *testing.T) {
+	r := require.New(t)
+	config := &genericv1.Config{
+		Type: runtime.Type{
+			Name:    "custom.config",
+			Version: "v1",
+		},
+		Configurations: []*runtime.Raw{
+			{
+				Type: runtime.Type{
+					Name:    "custom.config",
+					Version: "v1",
+				},
+				Data: []byte(`{}`),
+			},
+		},
+	}
+	ctx := t.Context()
+
+	pm := NewPluginManager(context.Background())
+	r.NoError(pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"), WithConfiguration(config)))
+	r.NoError(pm.Shutdown(context.Background()))
+	diagnostics := pm.Diagnostics()
+	r.NotEmpty(diagnostics)
+	for _, d := range diagnostics {
+		r.NotEmpty(d.ContractVersions, "plugin %s has no negotiated contract versions", d.ID)
+		if d.ID == "test-plugin-component-version" {
+			r.Equal(map[types.PluginType]string{ocmrepositoryv1.ComponentVersionRepositoryPluginType: "v1"}, d.ContractVersions)
+		}
+	}
+
+	// a manager that dropped the version the plugin was built against refuses to load it.
+	previous := contractVersions[ocmrepositoryv1.ComponentVersionRepositoryPluginType]
+	contractVersions[ocmrepositoryv1.ComponentVersionRepositoryPluginType] = []string{"v2"}
+	t.Cleanup(func() {
+		contractVersions[ocmrepositoryv1.ComponentVersionRepositoryPluginType] = previous
+	})
+
+	pm = NewPluginManager(context.Background())
+	t.Cleanup(func() {
+		r.NoError(pm.Shutdown(context.Background()))
+	})
+	err := pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"), WithConfiguration(config))
+	r.ErrorIs(err, ErrIncompatibleContract)
+	var contractErr *ContractVersionError
+	r.True(errors.As(err, &contractErr))
+	r.Equal(ocmrepositoryv1.ComponentVersionRepositoryPluginType, contractErr.Capability)
+	r.Equal([]string{"v1"}, contractErr.Supported)
+}
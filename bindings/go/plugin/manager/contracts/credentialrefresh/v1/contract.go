@@ -0,0 +1,18 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ErrRefreshUnavailable is returned if credentials cannot be refreshed, because the plugin manager
+// does not offer a credential refresh channel or no credential resolver is configured.
+var ErrRefreshUnavailable = errors.New("credential refresh is not available")
+
+// CredentialRefresher resolves refreshed credentials for a consumer identity.
+type CredentialRefresher interface {
+	// RefreshCredentials resolves the credentials for the consumer identity again.
+	RefreshCredentials(ctx context.Context, identity runtime.Identity) (runtime.Typed, error)
+}
@@ -0,0 +1,20 @@
+// Package v1 contains the contract and types of the credential refresh callback channel.
+//
+// Credentials are resolved by the plugin manager and passed to a plugin together with a call.
+// Long-running operations, such as transfers of large artifacts, can outlive short-lived
+// credentials like access tokens. Through the callback channel, a plugin can request refreshed
+// credentials for a consumer identity from the plugin manager while it is processing a call.
+//
+// The plugin manager serves the channel at the location in the plugin configuration
+// (types.Config.CredentialRefreshLocation). Requests are authenticated with a token that the plugin
+// receives in the environment variable TokenEnv and sends in the TokenHeader. Every plugin process
+// receives its own token, which is invalidated once the process exited. With it, a plugin can only refresh
+// the credentials of identities whose credentials the plugin manager passed to the plugin before.
+// Sandboxed plugins whose environment allowlist does not include TokenEnv do not receive a token.
+//
+// The contracts are categorized based on their functionality:
+//
+//   - CredentialRefresher: Resolves refreshed credentials for a consumer identity.
+//
+// The types define the request and response structures used by the channel.
+package v1
@@ -0,0 +1,24 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// Endpoint is the endpoint of the credential refresh channel served by the plugin manager.
+	Endpoint = "/credentials/refresh"
+	// TokenEnv is the environment variable in which a plugin receives the token for the channel.
+	TokenEnv = "OCM_PLUGIN_CREDENTIAL_REFRESH_TOKEN"
+	// TokenHeader is the header in which a plugin sends the token to the channel.
+	TokenHeader = "Ocm-Credential-Refresh-Token"
+)
+
+// RefreshRequest requests refreshed credentials for a consumer identity.
+type RefreshRequest struct {
+	Identity runtime.Identity `json:"identity"`
+}
+
+// RefreshResponse contains the refreshed credentials.
+type RefreshResponse struct {
+	Credentials *runtime.Raw `json:"credentials"`
+}
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"slices"
+
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
@@ -25,4 +27,29 @@ type CapabilitySpec struct {
 	//  currently, it uses the general types.Type, but we might want to tailor this
 	//  to ocm repository specifically.
 	SupportedRepositorySpecTypes []types.Type `json:"supportedRepositorySpecTypes"`
+	// MaintenanceOperations are the optional maintenance operations the plugin supports.
+	MaintenanceOperations []MaintenanceOperation `json:"maintenanceOperations,omitempty"`
+	// StreamingBlobTransfer is set by plugins implementing StreamingBlobTransfer.
+	StreamingBlobTransfer bool `json:"streamingBlobTransfer,omitempty"`
+	// VerificationMetadata is set by plugins implementing VerifiedComponentVersionGetter.
+	VerificationMetadata bool `json:"verificationMetadata,omitempty"`
+	// ConsumerIdentityListing is set by plugins implementing ConsumerIdentityLister.
+	ConsumerIdentityListing bool `json:"consumerIdentityListing,omitempty"`
+}
+
+// MaintenanceOperation is an optional maintenance operation of a component version repository plugin.
+type MaintenanceOperation string
+
+const (
+	// MaintenanceOperationGarbageCollect is advertised by plugins implementing GarbageCollector.
+	MaintenanceOperationGarbageCollect MaintenanceOperation = "garbageCollect"
+	// MaintenanceOperationVerifyIntegrity is advertised by plugins implementing IntegrityVerifier.
+	MaintenanceOperationVerifyIntegrity MaintenanceOperation = "verifyIntegrity"
+	// MaintenanceOperationStats is advertised by plugins implementing StatsProvider.
+	MaintenanceOperationStats MaintenanceOperation = "stats"
+)
+
+// Supports reports whether the plugin advertises the maintenance operation.
+func (c *CapabilitySpec) Supports(operation MaintenanceOperation) bool {
+	return slices.Contains(c.MaintenanceOperations, operation)
 }
@@ -2,9 +2,11 @@ package v1
 
 import (
 	"context"
+	"io"
 
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/contracts"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -47,3 +49,51 @@ type ReadWriteOCMRepositoryPluginContract[T runtime.Typed] interface {
 type HealthCheckable[T runtime.Typed] interface {
 	CheckHealth(ctx context.Context, request PostCheckHealthRequest[T], credentials runtime.Typed) error
 }
+
+// GarbageCollector is an optional interface that can be implemented by a component version
+// repository plugin to remove content that is no longer referenced.
+type GarbageCollector[T runtime.Typed] interface {
+	GarbageCollect(ctx context.Context, request GarbageCollectRequest[T], credentials runtime.Typed) (*repository.GarbageCollectResult, error)
+}
+
+// IntegrityVerifier is an optional interface that can be implemented by a component version
+// repository plugin to verify the consistency of the stored content.
+type IntegrityVerifier[T runtime.Typed] interface {
+	VerifyIntegrity(ctx context.Context, request VerifyIntegrityRequest[T], credentials runtime.Typed) (*repository.IntegrityReport, error)
+}
+
+// StatsProvider is an optional interface that can be implemented by a component version
+// repository plugin to report statistics about the stored content.
+type StatsProvider[T runtime.Typed] interface {
+	GetStats(ctx context.Context, request GetStatsRequest[T], credentials runtime.Typed) (*repository.Stats, error)
+}
+
+// StreamingBlobTransfer is an optional interface that can be implemented by a component version
+// repository plugin to exchange local resources and sources as a stream instead of a file location.
+// The content is transferred in the body of the plugin call, so large blobs are neither materialized
+// on disk by the manager nor by the plugin.
+type StreamingBlobTransfer[T runtime.Typed] interface {
+	// AddLocalResourceStream adds a local resource with the content read from content.
+	AddLocalResourceStream(ctx context.Context, request PostLocalResourceStreamRequest[T], content io.Reader, credentials runtime.Typed) (*descriptor.Resource, error)
+	// GetLocalResourceStream returns the local resource with its content. The caller must close the content.
+	GetLocalResourceStream(ctx context.Context, request GetLocalResourceRequest[T], credentials runtime.Typed) (*GetLocalResourceStreamResponse, error)
+	// AddLocalSourceStream adds a local source with the content read from content.
+	AddLocalSourceStream(ctx context.Context, request PostLocalSourceStreamRequest[T], content io.Reader, credentials runtime.Typed) (*descriptor.Source, error)
+	// GetLocalSourceStream returns the local source with its content. The caller must close the content.
+	GetLocalSourceStream(ctx context.Context, request GetLocalSourceRequest[T], credentials runtime.Typed) (*GetLocalSourceStreamResponse, error)
+}
+
+// VerifiedComponentVersionGetter is an optional interface that can be implemented by a component version
+// repository plugin that verifies component versions on retrieval. It returns the verification metadata
+// together with the descriptor, see repository.VerifiedComponentVersionGetter.
+type VerifiedComponentVersionGetter[T runtime.Typed] interface {
+	GetVerifiedComponentVersion(ctx context.Context, request GetComponentVersionRequest[T], credentials runtime.Typed) (*descriptor.Descriptor, *repository.Verification, error)
+}
+
+// ConsumerIdentityLister is an optional interface that can be implemented by a component version
+// repository plugin to list all consumer identities it may resolve credentials for while working on a
+// repository. Unlike IdentityProvider, which maps a repository specification to a single identity, it
+// covers every identity of every operation, so missing credentials can be reported before an operation starts.
+type ConsumerIdentityLister[T runtime.Typed] interface {
+	ListConsumerIdentities(ctx context.Context, request ListConsumerIdentitiesRequest[T]) (*ListConsumerIdentitiesResponse, error)
+}
@@ -9,6 +9,14 @@
 //   - ReadOCMRepositoryPluginContract: Defines methods for reading component versions and local resources from an OCM repository.
 //   - WriteOCMRepositoryPluginContract: Defines methods for adding component versions and local resources to an OCM repository.
 //   - ReadWriteOCMRepositoryPluginContract: Combines the read and write functionalities for OCM repositories.
+//   - GarbageCollector, IntegrityVerifier, StatsProvider: Optional maintenance operations of a repository.
+//     Plugins implementing them advertise them in CapabilitySpec.MaintenanceOperations.
+//   - StreamingBlobTransfer: Optional exchange of local resources and sources as a stream in the body of the
+//     plugin call instead of a file location. Plugins implementing it set CapabilitySpec.StreamingBlobTransfer.
+//   - VerifiedComponentVersionGetter: Optional retrieval of component versions with the metadata of their verification.
+//     Plugins implementing it set CapabilitySpec.VerificationMetadata.
+//   - ConsumerIdentityLister: Optional listing of all consumer identities a plugin may require credentials for,
+//     per operation. Plugins implementing it set CapabilitySpec.ConsumerIdentityListing.
 //
 // The types define the request and response structures used by these contracts.
 package v1
@@ -1,8 +1,12 @@
 package v1
 
 import (
+	"io"
+	"slices"
+
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -15,6 +19,13 @@ type GetComponentVersionRequest[T runtime.Typed] struct {
 	Version string `json:"version"`
 }
 
+type GetVerifiedComponentVersionResponse struct {
+	// The Descriptor of the Component Version
+	Descriptor *v2.Descriptor `json:"descriptor"`
+	// The Verification the Component Version passed, if it was verified
+	Verification *repository.Verification `json:"verification,omitempty"`
+}
+
 type ListComponentVersionsRequest[T runtime.Typed] struct {
 	// The Location of the Component Version
 	Repository T `json:"repository"`
@@ -89,11 +100,108 @@ type PostLocalSourceRequest[T runtime.Typed] struct {
 	Source         *v2.Source     `json:"source"`
 }
 
+type PostLocalResourceStreamRequest[T runtime.Typed] struct {
+	// The Repository Specification where the Component Version should be stored
+	Repository T `json:"repository"`
+	// The Component Name
+	Name string `json:"name"`
+	// The Component Version
+	Version string `json:"version"`
+
+	Resource *v2.Resource `json:"resource"`
+}
+
+type PostLocalSourceStreamRequest[T runtime.Typed] struct {
+	// The Repository Specification where the Component Version should be stored
+	Repository T `json:"repository"`
+	// The Component Name
+	Name string `json:"name"`
+	// The Component Version
+	Version string `json:"version"`
+
+	Source *v2.Source `json:"source"`
+}
+
+type GetLocalResourceStreamResponse struct {
+	Resource *v2.Resource `json:"resource"`
+	// MediaType of the content, if known.
+	MediaType string `json:"mediaType,omitempty"`
+	// Size of the content in bytes. It is unknown if not positive.
+	Size int64 `json:"size"`
+	// Content of the local resource, transferred as the body of the response.
+	Content io.ReadCloser `json:"-"`
+}
+
+type GetLocalSourceStreamResponse struct {
+	Source *v2.Source `json:"source"`
+	// MediaType of the content, if known.
+	MediaType string `json:"mediaType,omitempty"`
+	// Size of the content in bytes. It is unknown if not positive.
+	Size int64 `json:"size"`
+	// Content of the local source, transferred as the body of the response.
+	Content io.ReadCloser `json:"-"`
+}
+
 type PostCheckHealthRequest[T runtime.Typed] struct {
 	// The Repository Specification where the Component Version should be stored
 	Repository T `json:"repository"`
 }
 
+type GarbageCollectRequest[T runtime.Typed] struct {
+	// The Repository Specification of the repository to collect garbage in
+	Repository T `json:"repository"`
+	// The Options of the garbage collection
+	Options repository.GarbageCollectOptions `json:"options"`
+}
+
+type VerifyIntegrityRequest[T runtime.Typed] struct {
+	// The Repository Specification of the repository to verify
+	Repository T `json:"repository"`
+	// The Options of the verification
+	Options repository.VerifyIntegrityOptions `json:"options"`
+}
+
+type GetStatsRequest[T runtime.Typed] struct {
+	// The Repository Specification of the repository to get statistics for
+	Repository T `json:"repository"`
+}
+
+type ListConsumerIdentitiesRequest[T runtime.Typed] struct {
+	// The Repository Specification of the repository to list the consumer identities for
+	Repository T `json:"repository"`
+}
+
+type ListConsumerIdentitiesResponse struct {
+	// The Consumer Identities the plugin may require credentials for
+	Identities []ConsumerIdentity `json:"identities"`
+}
+
+// RepositoryOperation is an operation on a component version repository that may require credentials.
+type RepositoryOperation string
+
+const (
+	// RepositoryOperationRead covers getting and listing component versions, local resources and sources.
+	RepositoryOperationRead RepositoryOperation = "read"
+	// RepositoryOperationWrite covers adding component versions, local resources and sources.
+	RepositoryOperationWrite RepositoryOperation = "write"
+)
+
+// ConsumerIdentity is a consumer identity a plugin may resolve credentials for while working on a repository.
+type ConsumerIdentity struct {
+	// Identity is the consumer identity, including its type, credentials are resolved for.
+	Identity runtime.Identity `json:"identity"`
+	// Operations are the operations that use credentials for the identity. They are used by all operations
+	// if none are given.
+	Operations []RepositoryOperation `json:"operations,omitempty"`
+	// Optional is set if the operations also succeed without credentials for the identity, e.g. anonymous reads.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// UsedFor reports whether the operation uses credentials for the identity.
+func (c ConsumerIdentity) UsedFor(operation RepositoryOperation) bool {
+	return len(c.Operations) == 0 || slices.Contains(c.Operations, operation)
+}
+
 type GetIdentityRequest[T runtime.Typed] struct {
 	Typ T `json:"type"`
 }
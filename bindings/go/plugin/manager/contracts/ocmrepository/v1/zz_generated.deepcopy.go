@@ -21,6 +21,11 @@ func (in *CapabilitySpec) DeepCopyInto(out *CapabilitySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaintenanceOperations != nil {
+		in, out := &in.MaintenanceOperations, &out.MaintenanceOperations
+		*out = make([]MaintenanceOperation, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
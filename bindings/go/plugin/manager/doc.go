@@ -92,6 +92,49 @@
 // the type of the plugin constructed by one of the above Register* functions that can be used. This process is described
 // in the `endpoints` package documentation.
 //
+// The contracts of the capabilities are versioned. The manager advertises the contract versions it supports to the
+// `capabilities` command in the OCM_PLUGIN_CONTRACT_VERSIONS environment variable, and the plugin reports the version
+// it chose for every capability (see endpoints.EndpointBuilder.SetContractVersions). Plugins that do not report a
+// version predate the negotiation and serve types.DefaultContractVersion. Plugins without a version in common with
+// the manager are refused with a ContractVersionError, so older plugins keep loading as long as the manager still
+// supports the contract versions they were built against.
+//
+// Registration does not start any plugin. A plugin is started by its registry only once one of its capabilities is
+// first used. Plugins shut themselves down once they have been idle for the time configured with WithIdleTimeout,
+// and the registry starts them again on their next use. Plugins that are expected to be used anyway can be started
+// right away with WithPreWarm:
+//
+//	err := pm.RegisterPlugins(ctx, "/path/to/plugins",
+//	    manager.WithIdleTimeout(5*time.Minute),
+//	    manager.WithPreWarm("ocm-oci-plugin"),
+//	)
+//
+// The manager records how often each capability of a plugin is called, how much data is exchanged with it, how many
+// calls fail and when it was last used. Usage returns these statistics for all plugins, including registered plugins
+// that were never used. With SetUsageFile, the statistics are persisted across restarts, for example to identify
+// plugins that can be removed:
+//
+//	if err := pm.SetUsageFile(filepath.Join(configDir, "plugin-usage.json")); err != nil {
+//	    return err
+//	}
+//	defer pm.Shutdown(ctx) // saves the usage statistics
+//
+// The metrics plugins built with the SDK expose, e.g. the number and latency of their requests, are scraped and
+// aggregated by the collector returned by MetricsCollector, labeled with the ID of the plugin and the capability:
+//
+//	prometheus.MustRegister(pm.MetricsCollector())
+//
+// Plugins can be sandboxed to protect the host from runaway plugins. WithSandbox limits the memory, CPU, CPU time and
+// open files of all plugin processes and restricts the environment variables passed to them to an allowlist, so that
+// plugins do not see secrets of the host process. WithPluginSandbox configures a different sandbox for a single plugin.
+// On Linux, memory and CPU can only be limited with a cgroup below a delegated cgroup v2 directory, elsewhere memory
+// is limited with the data segment size limit of the process:
+//
+//	err := pm.RegisterPlugins(ctx, dir,
+//		manager.WithSandbox(types.Sandbox{OpenFiles: 1024, Environment: []string{"PATH", "HOME", "OCM_*"}}),
+//		manager.WithPluginSandbox("helm", types.Sandbox{Memory: 2 << 30, CPU: 1, CgroupParent: "/sys/fs/cgroup/ocm"}),
+//	)
+//
 // Once the registration is successful, in order to get a plugin, call the appropriate function that gets back the
 // right plugin. For example, for OCMComponentVersionRepository plugins the `Get*` function would be:
 // `GetReadWriteComponentVersionRepositoryPluginForType`. Usage:
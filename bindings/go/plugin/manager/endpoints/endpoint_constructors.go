@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	pluginruntime "ocm.software/open-component-model/bindings/go/plugin/manager/types/runtime"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
@@ -25,18 +27,33 @@ type EndpointBuilder struct {
 	PluginSpec pluginruntime.PluginSpec
 	Handlers   []Handler
 	Scheme     *runtime.Scheme
+
+	// contractVersions are the contract versions the plugin supports per capability, see SetContractVersions.
+	contractVersions types.ContractVersions
 }
 
 // NewEndpoints constructs a new builder for registering capabilities for the given plugin type.
+// The builder advertises all transports that plugins serving their handlers with the plugin SDK support.
 func NewEndpoints(scheme *runtime.Scheme) *EndpointBuilder {
 	return &EndpointBuilder{
+		PluginSpec: pluginruntime.PluginSpec{
+			Transports: []types.Transport{types.TransportHTTP, types.TransportGRPC},
+		},
 		Scheme: scheme,
 	}
 }
 
 // MarshalJSON returns the accumulated endpoints during Register* calls.
+// For every capability, it reports the contract version negotiated with the versions the manager
+// advertises in types.ContractVersionsEnv, see SetContractVersions.
 func (c *EndpointBuilder) MarshalJSON() ([]byte, error) {
-	pluginSpec, err := pluginruntime.ConvertToSpec(&c.PluginSpec)
+	offered, err := types.ContractVersionsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	spec := c.PluginSpec
+	spec.Contracts = c.negotiateContracts(offered)
+	pluginSpec, err := pluginruntime.ConvertToSpec(&spec)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert to spec: %w", err)
 	}
@@ -52,6 +69,44 @@ func (c *EndpointBuilder) GetHandlers() []Handler {
 	return c.Handlers
 }
 
+// SetVersion sets the version the plugin advertises, which allows pinning the plugin
+// if multiple installed plugins serve the same capability.
+func (c *EndpointBuilder) SetVersion(version string) {
+	c.PluginSpec.Version = version
+}
+
+// SetContractVersions sets the versions of the contract of the capability the plugin supports, in the order of
+// preference. Capabilities without versions only support types.DefaultContractVersion.
+func (c *EndpointBuilder) SetContractVersions(capability types.PluginType, versions ...string) {
+	if c.contractVersions == nil {
+		c.contractVersions = types.ContractVersions{}
+	}
+	c.contractVersions[capability] = versions
+}
+
+// negotiateContracts chooses the contract version of every registered capability out of the offered versions.
+// Managers that offer no versions only support types.DefaultContractVersion.
+func (c *EndpointBuilder) negotiateContracts(offered types.ContractVersions) []types.Contract {
+	var contracts []types.Contract
+	for _, capability := range c.PluginSpec.CapabilitySpecs {
+		typ := types.PluginType(capability.GetType().Name)
+		if slices.ContainsFunc(contracts, func(contract types.Contract) bool { return contract.Capability == typ }) {
+			continue
+		}
+		supported, ok := c.contractVersions[typ]
+		if !ok {
+			supported = []string{types.DefaultContractVersion}
+		}
+		versions, ok := offered[typ]
+		if !ok {
+			versions = []string{types.DefaultContractVersion}
+		}
+		version, _ := types.Negotiate(versions, supported)
+		contracts = append(contracts, types.Contract{Capability: typ, Version: version, Supported: supported})
+	}
+	return contracts
+}
+
 // AddConfigType adds a configuration type to the list of supported config types.
 func (c *EndpointBuilder) AddConfigType(typ ...runtime.Type) {
 	c.PluginSpec.SupportedConfigTypes = append(c.PluginSpec.SupportedConfigTypes, typ...)
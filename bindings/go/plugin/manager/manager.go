@@ -2,23 +2,28 @@ package manager
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/credentials"
 	blobtransformerv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/blobtransformer/v1"
 	componentlisterv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/componentlister/v1"
 	credentialpluginv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialplugin/v1"
+	credentialrefreshv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialrefresh/v1"
 	credentialrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentials/v1"
 	digestprocessorv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/digestprocessor/v1"
 	inputv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/input/v1"
@@ -32,16 +37,18 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/credentialrepository"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/digestprocessor"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/input"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/resource"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/signinghandler"
 	mtypes "ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	pluginruntime "ocm.software/open-component-model/bindings/go/plugin/manager/types/runtime"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types/spec"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // ErrNoPluginsFound is returned when a register plugin call finds no plugins.
-var ErrNoPluginsFound = errors.New("no plugins found")
+var ErrNoPluginsFound = ocmerrors.NotFound(errors.New("no plugins found"))
 
 // PluginManager manages all connected plugins.
 type PluginManager struct {
@@ -59,6 +66,22 @@ type PluginManager struct {
 
 	mu sync.Mutex
 
+	// credentialRefresh serves refreshed credentials to plugins. It is started with the first registration.
+	credentialRefresh *plugins.CredentialRefreshServer
+	// credentialResolver is the resolver for refreshed credentials, see SetCredentialResolver.
+	credentialResolver credentials.Resolver
+
+	// diagnosticsMu guards diagnostics separately from mu, so that Diagnostics does not
+	// block while plugins are registered.
+	diagnosticsMu sync.Mutex
+	// diagnostics describes all registered external plugins by ID.
+	diagnostics map[string]*PluginDiagnostics
+
+	// usage records the usage of the capabilities of all plugins, see Usage.
+	usage usageStatistics
+	// metrics aggregates the metrics of all plugin processes, see MetricsCollector.
+	metrics pluginMetrics
+
 	// baseCtx is the context that is used for all plugins.
 	// This is a different context than the one used for fetching plugins because
 	// that context is done once fetching is done. The plugin context, however, must not
@@ -80,12 +103,73 @@ func NewPluginManager(ctx context.Context) *PluginManager {
 		BlobTransformerRegistry:            blobtransformer.NewBlobTransformerRegistry(ctx),
 		SigningRegistry:                    signinghandler.NewSigningRegistry(ctx),
 		baseCtx:                            ctx,
+		diagnostics:                        make(map[string]*PluginDiagnostics),
+	}
+}
+
+// PluginDiagnostics describes a registered external plugin.
+// It contains no configuration values, as they may contain secrets.
+type PluginDiagnostics struct {
+	ID             string                `json:"id"`
+	Path           string                `json:"path"`
+	Version        string                `json:"version,omitempty"`
+	ConnectionType mtypes.ConnectionType `json:"connectionType"`
+	// Transport is the transport the plugin serves its endpoints with, see WithGRPCTransport.
+	Transport   mtypes.Transport `json:"transport"`
+	IdleTimeout string           `json:"idleTimeout,omitempty"`
+	// Capabilities are the types of the capabilities the plugin registered.
+	Capabilities []string `json:"capabilities"`
+	// ConfigTypes are the types of the configurations passed to the plugin.
+	ConfigTypes  []string  `json:"configTypes,omitempty"`
+	RegisteredAt time.Time `json:"registeredAt"`
+	// PreWarmed is set if the plugin was started right after registration, see WithPreWarm.
+	PreWarmed bool `json:"preWarmed"`
+	// ContractVersions are the negotiated versions of the contracts of the capabilities of the plugin.
+	ContractVersions map[mtypes.PluginType]string `json:"contractVersions,omitempty"`
+	// Sandbox restricts the resources and the environment of the plugin, see WithSandbox.
+	Sandbox *mtypes.Sandbox `json:"sandbox,omitempty"`
+}
+
+// Diagnostics returns a description of all registered external plugins, sorted by ID.
+func (pm *PluginManager) Diagnostics() []PluginDiagnostics {
+	pm.diagnosticsMu.Lock()
+	defer pm.diagnosticsMu.Unlock()
+	result := make([]PluginDiagnostics, 0, len(pm.diagnostics))
+	for _, d := range pm.diagnostics {
+		d := *d
+		d.Capabilities = slices.Clone(d.Capabilities)
+		d.ConfigTypes = slices.Clone(d.ConfigTypes)
+		d.ContractVersions = maps.Clone(d.ContractVersions)
+		if d.Sandbox != nil {
+			sandbox := *d.Sandbox
+			sandbox.Environment = slices.Clone(sandbox.Environment)
+			d.Sandbox = &sandbox
+		}
+		result = append(result, d)
 	}
+	slices.SortFunc(result, func(a, b PluginDiagnostics) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result
 }
 
 type RegistrationOptions struct {
 	IdleTimeout time.Duration
 	Config      *genericv1.Config
+	// PreWarm contains the IDs of plugins that are started right after registration
+	// instead of on their first use.
+	PreWarm []string
+	// SharedMemoryBlobs lets plugins return blobs in shared memory locations, see WithSharedMemoryBlobs.
+	SharedMemoryBlobs bool
+	// Transport is the preferred transport for calling plugins, see WithGRPCTransport.
+	Transport mtypes.Transport
+	// SocketPolicy configures the unix domain sockets of plugins, see WithSocketPolicy.
+	SocketPolicy *mtypes.SocketPolicy
+	// Sandbox restricts the resources and the environment of all plugins, see WithSandbox.
+	Sandbox *mtypes.Sandbox
+	// PluginSandboxes restrict the resources and the environment of the plugins with the given IDs
+	// instead of Sandbox, see WithPluginSandbox.
+	PluginSandboxes map[string]mtypes.Sandbox
 }
 
 type RegistrationOptionFn func(*RegistrationOptions)
@@ -97,6 +181,61 @@ func WithIdleTimeout(d time.Duration) RegistrationOptionFn {
 	}
 }
 
+// WithPreWarm starts the plugins with the given IDs right after registration.
+// All other plugins are only started once one of their capabilities is used.
+func WithPreWarm(ids ...string) RegistrationOptionFn {
+	return func(o *RegistrationOptions) {
+		o.PreWarm = append(o.PreWarm, ids...)
+	}
+}
+
+// WithSharedMemoryBlobs lets plugins on the same host return blobs in memory-mapped files
+// (types.LocationTypeSharedMemory) instead of regular files, avoiding additional copies of large blobs.
+// Plugins that do not support it keep returning regular files.
+func WithSharedMemoryBlobs() RegistrationOptionFn {
+	return func(o *RegistrationOptions) {
+		o.SharedMemoryBlobs = true
+	}
+}
+
+// WithGRPCTransport calls the endpoints of plugins through a single multiplexed gRPC connection per plugin
+// (mtypes.TransportGRPC) instead of HTTP/1.1 connections, reducing the overhead of frequent calls.
+// Plugins that do not advertise the gRPC transport in their capabilities are called with HTTP.
+func WithGRPCTransport() RegistrationOptionFn {
+	return func(o *RegistrationOptions) {
+		o.Transport = mtypes.TransportGRPC
+	}
+}
+
+// WithSocketPolicy configures the directory, permissions, owner and SELinux label of the unix domain sockets
+// plugins serve their endpoints on. The policy is validated on registration, and every socket is validated
+// against it before the manager connects to the plugin. It has no effect if plugins are reached via TCP.
+func WithSocketPolicy(policy mtypes.SocketPolicy) RegistrationOptionFn {
+	return func(o *RegistrationOptions) {
+		o.SocketPolicy = &policy
+	}
+}
+
+// WithSandbox restricts the resources and the environment variables of all plugin processes, including the
+// processes reporting the capabilities of the plugins. The sandbox is validated on registration.
+// Plugins with a sandbox configured by WithPluginSandbox use that one instead.
+func WithSandbox(sandbox mtypes.Sandbox) RegistrationOptionFn {
+	return func(o *RegistrationOptions) {
+		o.Sandbox = &sandbox
+	}
+}
+
+// WithPluginSandbox restricts the resources and the environment variables of the processes of the plugin
+// with the given ID, instead of the sandbox configured by WithSandbox.
+func WithPluginSandbox(id string, sandbox mtypes.Sandbox) RegistrationOptionFn {
+	return func(o *RegistrationOptions) {
+		if o.PluginSandboxes == nil {
+			o.PluginSandboxes = make(map[string]mtypes.Sandbox)
+		}
+		o.PluginSandboxes[id] = sandbox
+	}
+}
+
 // WithConfiguration adds a configuration to the plugin.
 func WithConfiguration(c *genericv1.Config) RegistrationOptionFn {
 	return func(o *RegistrationOptions) {
@@ -104,6 +243,18 @@ func WithConfiguration(c *genericv1.Config) RegistrationOptionFn {
 	}
 }
 
+// SetCredentialResolver sets the resolver with which plugins can refresh credentials for a consumer
+// identity during long-running operations, for example the credential graph. Until it is set,
+// plugins requesting refreshed credentials receive an error.
+func (pm *PluginManager) SetCredentialResolver(resolver credentials.Resolver) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.credentialResolver = resolver
+	if pm.credentialRefresh != nil {
+		pm.credentialRefresh.SetResolver(resolver)
+	}
+}
+
 // RegisterPlugins walks through files in a folder and registers them
 // as plugins if connection points can be established. This function doesn't support
 // concurrent access.
@@ -119,8 +270,29 @@ func (pm *PluginManager) RegisterPlugins(ctx context.Context, dir string, opts .
 		opt(defaultOpts)
 	}
 
+	if policy := defaultOpts.SocketPolicy; policy != nil {
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("invalid socket policy: %w", err)
+		}
+		if err := plugins.ValidateSocketDirectory(policy); err != nil {
+			return fmt.Errorf("invalid socket policy: %w", err)
+		}
+	}
+
+	if err := plugins.ValidateSandbox(defaultOpts.Sandbox); err != nil {
+		return fmt.Errorf("invalid sandbox: %w", err)
+	}
+	for id, sandbox := range defaultOpts.PluginSandboxes {
+		if err := plugins.ValidateSandbox(&sandbox); err != nil {
+			return fmt.Errorf("invalid sandbox of plugin %s: %w", id, err)
+		}
+	}
+
 	conf := &mtypes.Config{
-		IdleTimeout: &defaultOpts.IdleTimeout,
+		IdleTimeout:       &defaultOpts.IdleTimeout,
+		SharedMemoryBlobs: defaultOpts.SharedMemoryBlobs,
+		Transport:         defaultOpts.Transport,
+		Socket:            defaultOpts.SocketPolicy,
 	}
 
 	t, err := determineConnectionType(ctx)
@@ -129,18 +301,42 @@ func (pm *PluginManager) RegisterPlugins(ctx context.Context, dir string, opts .
 	}
 	conf.Type = t
 
-	plugins, err := pm.fetchPlugins(ctx, conf, dir)
+	found, err := pm.fetchPlugins(ctx, conf, dir)
 	if err != nil {
 		return fmt.Errorf("could not fetch plugins: %w", err)
 	}
 
-	if len(plugins) == 0 {
+	if len(found) == 0 {
 		return ErrNoPluginsFound
 	}
 
-	for _, plugin := range plugins {
+	for id := range defaultOpts.PluginSandboxes {
+		if !slices.ContainsFunc(found, func(plugin *mtypes.Plugin) bool { return plugin.ID == id }) {
+			return fmt.Errorf("plugin %s to sandbox not found", id)
+		}
+	}
+
+	if pm.credentialRefresh == nil {
+		if pm.credentialRefresh, err = plugins.NewCredentialRefreshServer(pm.baseCtx, t); err != nil {
+			return fmt.Errorf("could not start credential refresh server: %w", err)
+		}
+		pm.credentialRefresh.SetResolver(pm.credentialResolver)
+	}
+	conf.CredentialRefreshLocation = pm.credentialRefresh.Location()
+
+	// the manager advertises the contract versions it supports to the plugins, which choose the versions they serve.
+	contractEnv, err := contractVersionsEnv()
+	if err != nil {
+		return err
+	}
+
+	for _, plugin := range found {
 		conf.ID = plugin.ID
 		plugin.Config = *conf
+		plugin.Sandbox = defaultOpts.Sandbox
+		if sandbox, ok := defaultOpts.PluginSandboxes[plugin.ID]; ok {
+			plugin.Sandbox = &sandbox
+		}
 
 		output := bytes.NewBuffer(nil)
 		// TODO(fabianburth): provide developer documentation on how to debug
@@ -150,11 +346,12 @@ func (pm *PluginManager) RegisterPlugins(ctx context.Context, dir string, opts .
 		// The execution will wait for a debugger to attach before proceeding.
 		// cmd := exec.CommandContext(ctx, "dlv", "exec", cleanPath(plugin.Path), "--headless=true", "--listen=:40000", "--api-version=2", "--accept-multiclient", "--log", "--log-dest=2", "--", "capabilities")
 		cmd := exec.CommandContext(ctx, cleanPath(plugin.Path), "capabilities") //nolint:gosec // G204 does not apply
+		cmd.Env = pluginEnvironment(plugin.Sandbox, contractEnv)
 		cmd.Stdout = output
 		cmd.Stderr = os.Stderr
 
 		// Use Wait so we get the capabilities and make sure that the command exists and returns the values we need.
-		if err := cmd.Run(); err != nil {
+		if err := plugins.RunSandboxed(plugin.ID, cmd, plugin.Sandbox); err != nil {
 			return fmt.Errorf("failed to start plugin %s: %w", plugin.ID, err)
 		}
 
@@ -163,14 +360,43 @@ func (pm *PluginManager) RegisterPlugins(ctx context.Context, dir string, opts .
 		}
 	}
 
+	for _, id := range defaultOpts.PreWarm {
+		if !slices.ContainsFunc(found, func(plugin *mtypes.Plugin) bool { return plugin.ID == id }) {
+			return fmt.Errorf("plugin %s to pre-warm not found", id)
+		}
+		if err := pm.startPlugin(ctx, id); err != nil {
+			return fmt.Errorf("failed to pre-warm plugin %s: %w", id, err)
+		}
+		pm.diagnosticsMu.Lock()
+		if d, ok := pm.diagnostics[id]; ok {
+			d.PreWarmed = true
+		}
+		pm.diagnosticsMu.Unlock()
+	}
+
 	return nil
 }
 
+// startPlugin starts the plugin with the given ID in all registries it is registered in.
+func (pm *PluginManager) startPlugin(ctx context.Context, id string) error {
+	return errors.Join(
+		pm.ComponentVersionRepositoryRegistry.StartPlugin(ctx, id),
+		pm.ComponentListerRegistry.StartPlugin(ctx, id),
+		pm.CredentialPluginRegistry.StartPlugin(ctx, id),
+		pm.CredentialRepositoryRegistry.StartPlugin(ctx, id),
+		pm.InputRegistry.StartPlugin(ctx, id),
+		pm.DigestProcessorRegistry.StartPlugin(ctx, id),
+		pm.ResourcePluginRegistry.StartPlugin(ctx, id),
+		pm.BlobTransformerRegistry.StartPlugin(ctx, id),
+		pm.SigningRegistry.StartPlugin(ctx, id),
+	)
+}
+
 func cleanPath(path string) string {
 	return strings.Trim(path, `,;:'"|&*!@#$`)
 }
 
-// Shutdown is called to terminate all plugins.
+// Shutdown is called to terminate all plugins. The usage statistics of the plugins are saved, see SetUsageFile.
 func (pm *PluginManager) Shutdown(ctx context.Context) error {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
@@ -187,6 +413,11 @@ func (pm *PluginManager) Shutdown(ctx context.Context) error {
 		pm.BlobTransformerRegistry.Shutdown(ctx),
 		pm.SigningRegistry.Shutdown(ctx),
 	)
+	if pm.credentialRefresh != nil {
+		errs = errors.Join(errs, pm.credentialRefresh.Close(ctx))
+		pm.credentialRefresh = nil
+	}
+	errs = errors.Join(errs, pm.SaveUsage())
 
 	return errs
 }
@@ -270,34 +501,49 @@ func (pm *PluginManager) addPlugin(ctx context.Context, ocmConfig *genericv1.Con
 		plugin.Config.ConfigTypes = append(plugin.Config.ConfigTypes, filtered.Configurations...)
 	}
 
-	serialized, err := json.Marshal(plugin.Config)
-	if err != nil {
-		return err
+	if plugin.Config.Transport != "" && !slices.Contains(pluginSpec.Transports, plugin.Config.Transport) {
+		// fall back to the default transport, which every plugin supports.
+		slog.DebugContext(ctx, "plugin does not support the preferred transport", "id", plugin.ID, "transport", plugin.Config.Transport)
+		plugin.Config.Transport = ""
 	}
 
-	// Create a command that can then be managed.
-	pluginCmd := exec.CommandContext(ctx, cleanPath(plugin.Path), "--config", string(serialized)) //nolint:gosec // G204 does not apply
-	pluginCmd.Cancel = func() error {
-		slog.InfoContext(ctx, "killing plugin process because the parent context is cancelled", "id", plugin.ID)
-		return pluginCmd.Process.Kill()
+	plugin.Version = rawPluginSpec.Version
+
+	if plugin.Config.ContractVersions, err = negotiatedContractVersions(plugin.ID, pluginSpec); err != nil {
+		return err
 	}
 
-	// Set up communication pipes.
-	plugin.Cmd = pluginCmd
-	sdtErr, err := pluginCmd.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	// every process of the plugin gets its own credential refresh token, unless the sandbox of the plugin
+	// does not allow passing the token in the environment.
+	if pm.credentialRefresh != nil && plugin.Sandbox.AllowsEnvironment(credentialrefreshv1.TokenEnv) {
+		plugin.CredentialRefresh = pm.credentialRefresh.Grant
+	} else {
+		plugin.Config.CredentialRefreshLocation = ""
 	}
-	plugin.Stderr = sdtErr
-	sdtOut, err := pluginCmd.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
+
+	// Plugins are started lazily by the registries on first use. Every start creates a new command, so a
+	// plugin that exited after reaching its idle timeout is started again on its next use.
+	plugin.NewCmd = func(config mtypes.Config) (*exec.Cmd, error) {
+		serialized, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		pluginCmd := exec.CommandContext(ctx, cleanPath(plugin.Path), "--config", string(serialized)) //nolint:gosec // G204 does not apply
+		pluginCmd.Env = pluginEnvironment(plugin.Sandbox)
+		pluginCmd.Cancel = func() error {
+			slog.InfoContext(ctx, "killing plugin process because the parent context is cancelled", "id", plugin.ID)
+			return pluginCmd.Process.Kill()
+		}
+		return pluginCmd, nil
 	}
-	plugin.Stdout = sdtOut
 
 	// TODO(fabianburth): all registries have a common interface now
 	//  we could refactor this to get rid of the switch case statement.
 	for _, capability := range pluginSpec.CapabilitySpecs {
+		// every capability records its usage separately, even if the plugin serves several.
+		plugin := plugin
+		plugin.WrapTransport = pm.usage.wrapTransport(plugin.ID, capability.GetType().String())
+		plugin.Launched = pm.metrics.launched(plugin.ID, capability.GetType().String(), plugin.Config.Type)
 		switch capability := capability.(type) {
 		case *ocmrepositoryv1.CapabilitySpec:
 			slog.DebugContext(ctx, "adding component version repository plugin", "id", plugin.ID)
@@ -349,9 +595,50 @@ func (pm *PluginManager) addPlugin(ctx context.Context, ocmConfig *genericv1.Con
 		}
 	}
 
+	pm.recordDiagnostics(plugin, pluginSpec)
+
 	return nil
 }
 
+func (pm *PluginManager) recordDiagnostics(plugin mtypes.Plugin, pluginSpec *pluginruntime.PluginSpec) {
+	diagnostics := &PluginDiagnostics{
+		ID:               plugin.ID,
+		Path:             plugin.Path,
+		Version:          plugin.Version,
+		ConnectionType:   plugin.Config.Type,
+		Transport:        cmp.Or(plugin.Config.Transport, mtypes.TransportHTTP),
+		RegisteredAt:     time.Now(),
+		ContractVersions: plugin.Config.ContractVersions,
+		Sandbox:          plugin.Sandbox,
+	}
+	if plugin.Config.IdleTimeout != nil {
+		diagnostics.IdleTimeout = plugin.Config.IdleTimeout.String()
+	}
+	for _, capability := range pluginSpec.CapabilitySpecs {
+		diagnostics.Capabilities = append(diagnostics.Capabilities, capability.GetType().String())
+	}
+	for _, config := range plugin.Config.ConfigTypes {
+		diagnostics.ConfigTypes = append(diagnostics.ConfigTypes, config.GetType().String())
+	}
+
+	pm.diagnosticsMu.Lock()
+	defer pm.diagnosticsMu.Unlock()
+	pm.diagnostics[plugin.ID] = diagnostics
+}
+
+// pluginEnvironment returns the environment of a plugin process: the environment of the host process
+// filtered by the sandbox, and the variables set by the manager. It returns nil to pass the environment
+// of the host process unchanged.
+func pluginEnvironment(sandbox *mtypes.Sandbox, env ...string) []string {
+	if sandbox == nil || sandbox.Environment == nil {
+		if len(env) == 0 {
+			return nil
+		}
+		return append(os.Environ(), env...)
+	}
+	return append(sandbox.FilterEnvironment(os.Environ()), env...)
+}
+
 func determineConnectionType(ctx context.Context) (mtypes.ConnectionType, error) {
 	// if we can't create a temp folder ( for example we are in a scratch container ) we default to TCP
 	tmp, err := os.MkdirTemp("", "")
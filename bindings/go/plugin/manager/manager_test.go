@@ -4,21 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
 	"ocm.software/open-component-model/bindings/go/plugin/internal/dummytype"
 	dummyv1 "ocm.software/open-component-model/bindings/go/plugin/internal/dummytype/v1"
 	ocmrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/componentversionrepository"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	pluginruntime "ocm.software/open-component-model/bindings/go/plugin/manager/types/runtime"
 	"ocm.software/open-component-model/bindings/go/runtime"
@@ -328,6 +334,252 @@ func TestPluginManagerShutdownWithoutWait(t *testing.T) {
 	}, 1*time.Second, 100*time.Millisecond)
 }
 
+func TestPluginManagerLazyStartup(t *testing.T) {
+	config := &genericv1.Config{
+		Type: runtime.Type{
+			Name:    "custom.config",
+			Version: "v1",
+		},
+		Configurations: []*runtime.Raw{
+			{
+				Type: runtime.Type{
+					Name:    "custom.config",
+					Version: "v1",
+				},
+				Data: []byte(`{}`),
+			},
+		},
+	}
+	ctx := t.Context()
+	pm := NewPluginManager(context.Background())
+	socket := "/tmp/test-plugin-component-version-plugin.socket"
+
+	require.EqualError(t, pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"), WithConfiguration(config), WithPreWarm("unknown")),
+		"plugin unknown to pre-warm not found")
+
+	pm = NewPluginManager(context.Background())
+	t.Cleanup(func() {
+		require.NoError(t, pm.Shutdown(context.Background()))
+	})
+	require.NoError(t, pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"),
+		WithConfiguration(config),
+		WithIdleTimeout(500*time.Millisecond),
+		WithPreWarm("test-plugin-component-version"),
+	))
+	pid := pluginPID(t, socket)
+	require.NotZero(t, pid, "pre-warmed plugin should be running after registration")
+
+	var diagnostics *PluginDiagnostics
+	for _, d := range pm.Diagnostics() {
+		if d.ID == "test-plugin-component-version" {
+			diagnostics = &d
+		}
+	}
+	require.NotNil(t, diagnostics)
+	require.True(t, diagnostics.PreWarmed)
+	require.Equal(t, "500ms", diagnostics.IdleTimeout)
+	require.Equal(t, []string{"custom.config/v1"}, diagnostics.ConfigTypes)
+	require.NotEmpty(t, diagnostics.Capabilities)
+
+	// the plugin exits once it reaches its idle timeout.
+	require.Eventually(t, func() bool {
+		return errors.Is(syscall.Kill(pid, 0), syscall.ESRCH)
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// the next use starts the plugin again.
+	proto := &dummyv1.Repository{
+		Type: runtime.Type{
+			Name:    "DummyRepository",
+			Version: "v1",
+		},
+	}
+	plugin, err := pm.ComponentVersionRepositoryRegistry.GetComponentVersionRepository(ctx, proto, nil)
+	require.NoError(t, err)
+	desc, err := plugin.GetComponentVersion(ctx, "test-component", "1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "test-component:1.0.0", desc.String())
+	require.NotEqual(t, pid, pluginPID(t, socket), "plugin should have been started again")
+}
+
+func TestPluginManagerGRPCTransport(t *testing.T) {
+	r := require.New(t)
+	config := &genericv1.Config{
+		Type: runtime.Type{
+			Name:    "custom.config",
+			Version: "v1",
+		},
+		Configurations: []*runtime.Raw{
+			{
+				Type: runtime.Type{
+					Name:    "custom.config",
+					Version: "v1",
+				},
+				Data: []byte(`{}`),
+			},
+		},
+	}
+	ctx := t.Context()
+	pm := NewPluginManager(context.Background())
+	t.Cleanup(func() {
+		r.NoError(pm.Shutdown(context.Background()))
+	})
+	r.NoError(pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"), WithConfiguration(config), WithGRPCTransport()))
+
+	for _, d := range pm.Diagnostics() {
+		r.Equal(types.TransportGRPC, d.Transport, "plugin %s built with the SDK supports the gRPC transport", d.ID)
+	}
+
+	proto := &dummyv1.Repository{
+		Type: runtime.Type{
+			Name:    "DummyRepository",
+			Version: "v1",
+		},
+	}
+	plugin, err := pm.ComponentVersionRepositoryRegistry.GetComponentVersionRepository(ctx, proto, nil)
+	r.NoError(err)
+	desc, err := plugin.GetComponentVersion(ctx, "test-component", "1.0.0")
+	r.NoError(err)
+	r.Equal("test-component:1.0.0", desc.String())
+
+	blob, _, err := plugin.GetLocalResource(ctx, "test-resource", "v0.0.1", nil)
+	r.NoError(err)
+	reader, err := blob.ReadCloser()
+	r.NoError(err)
+	content, err := io.ReadAll(reader)
+	r.NoError(err)
+	r.NoError(reader.Close())
+	r.Equal("test-resource", string(content))
+}
+
+func TestPluginManagerUsage(t *testing.T) {
+	r := require.New(t)
+	config := &genericv1.Config{
+		Type: runtime.Type{
+			Name:    "custom.config",
+			Version: "v1",
+		},
+		Configurations: []*runtime.Raw{
+			{
+				Type: runtime.Type{
+					Name:    "custom.config",
+					Version: "v1",
+				},
+				Data: []byte(`{}`),
+			},
+		},
+	}
+	ctx := t.Context()
+	usageFile := filepath.Join(t.TempDir(), "usage.json")
+
+	usageOf := func(pm *PluginManager, id string) PluginUsage {
+		for _, u := range pm.Usage() {
+			if u.ID == id {
+				return u
+			}
+		}
+		r.Failf("no usage recorded", "plugin %s", id)
+		return PluginUsage{}
+	}
+
+	pm := NewPluginManager(context.Background())
+	r.NoError(pm.SetUsageFile(usageFile))
+	r.NoError(pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"), WithConfiguration(config)))
+
+	usage := usageOf(pm, "test-plugin-component-version")
+	r.True(usage.Registered)
+	r.Len(usage.Capabilities, 1)
+	r.Zero(usage.Capabilities[0].Calls, "registered plugins are listed before their first use")
+	r.True(usage.LastUsed.IsZero())
+
+	proto := &dummyv1.Repository{
+		Type: runtime.Type{
+			Name:    "DummyRepository",
+			Version: "v1",
+		},
+	}
+	plugin, err := pm.ComponentVersionRepositoryRegistry.GetComponentVersionRepository(ctx, proto, nil)
+	r.NoError(err)
+	_, err = plugin.GetComponentVersion(ctx, "test-component", "1.0.0")
+	r.NoError(err)
+
+	usage = usageOf(pm, "test-plugin-component-version")
+	calls := usage.Capabilities[0].Calls
+	r.Positive(calls)
+	r.Positive(usage.Capabilities[0].BytesReceived)
+	r.False(usage.LastUsed.IsZero())
+	r.NoError(pm.Shutdown(context.Background()))
+
+	// the statistics are continued after a restart.
+	pm = NewPluginManager(context.Background())
+	r.NoError(pm.SetUsageFile(usageFile))
+	usage = usageOf(pm, "test-plugin-component-version")
+	r.False(usage.Registered)
+	r.Equal(calls, usage.Capabilities[0].Calls)
+
+	r.NoError(os.WriteFile(usageFile, []byte("invalid"), 0o600))
+	r.ErrorContains(NewPluginManager(context.Background()).SetUsageFile(usageFile), "failed to decode plugin usage file")
+}
+
+func TestPluginManagerSocketPolicy(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	dir := t.TempDir()
+	uid := os.Getuid()
+
+	pm := NewPluginManager(context.Background())
+	t.Cleanup(func() {
+		r.NoError(pm.Shutdown(context.Background()))
+	})
+	r.NoError(pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"), WithSocketPolicy(types.SocketPolicy{
+		Directory: dir,
+		Mode:      0o600,
+		UID:       &uid,
+	})))
+
+	proto := &dummyv1.Repository{
+		Type: runtime.Type{
+			Name:    "DummyRepository",
+			Version: "v1",
+		},
+	}
+	plugin, err := pm.ComponentVersionRepositoryRegistry.GetComponentVersionRepository(ctx, proto, nil)
+	r.NoError(err)
+	desc, err := plugin.GetComponentVersion(ctx, "test-component", "1.0.0")
+	r.NoError(err)
+	r.Equal("test-component:1.0.0", desc.String())
+
+	sockets, err := filepath.Glob(filepath.Join(dir, "*.socket"))
+	r.NoError(err)
+	r.NotEmpty(sockets, "the plugin creates its socket in the directory of the policy")
+	for _, socket := range sockets {
+		info, err := os.Stat(socket)
+		r.NoError(err)
+		r.Equal(os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	t.Run("rejects invalid policies", func(t *testing.T) {
+		r := require.New(t)
+		pm := NewPluginManager(t.Context())
+		err := pm.RegisterPlugins(t.Context(), filepath.Join("..", "tmp", "testdata"), WithSocketPolicy(types.SocketPolicy{Directory: "relative"}))
+		r.ErrorContains(err, "is not an absolute path")
+
+		shared := t.TempDir()
+		r.NoError(os.Chmod(shared, 0o777))
+		err = pm.RegisterPlugins(t.Context(), filepath.Join("..", "tmp", "testdata"), WithSocketPolicy(types.SocketPolicy{Directory: shared}))
+		r.ErrorIs(err, plugins.ErrSocketPolicyViolation)
+	})
+}
+
+// pluginPID returns the PID of the plugin process serving the given socket.
+func pluginPID(t *testing.T, socket string) int {
+	t.Helper()
+	content, err := os.ReadFile(socket + ".lock")
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	require.NoError(t, err)
+	return pid
+}
+
 func TestPluginManagerMultiplePluginsForSameType(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	ctx := t.Context()
@@ -379,7 +631,16 @@ func TestPluginManagerMultiplePluginsForSameType(t *testing.T) {
 	testPlugin.Path = "/tmp/test-other-plugin-plugin.socket"
 	testPlugin.Config.ID = "test-other"
 	testPlugin.Config.Type = "tcp"
-	require.ErrorContains(t, pm.addPlugin(ctx, config, testPlugin, bytes.NewBuffer(serialized)), "failed to register plugin test-other: plugin for type OCIRepository/v1 already registered with ID: test-id")
+	// the first plugin stays the default for the type, the other one serves it only if selected.
+	require.NoError(t, pm.addPlugin(ctx, config, testPlugin, bytes.NewBuffer(serialized)))
+	diagnostics := pm.Diagnostics()
+	require.Len(t, diagnostics, 2)
+	require.Equal(t, "/tmp/test-plugin-plugin.socket", diagnostics[0].Path)
+	_, err = pm.ComponentVersionRepositoryRegistry.Select(componentversionrepository.PluginSelection{ID: "test-other"})
+	require.NoError(t, err)
+	_, err = pm.ComponentVersionRepositoryRegistry.Select(componentversionrepository.PluginSelection{ID: "test-other", Version: "v1.0.0"})
+	require.ErrorIs(t, err, componentversionrepository.ErrPluginNotFound)
+	require.ErrorContains(t, pm.addPlugin(ctx, config, types.Plugin{ID: "test-id"}, bytes.NewBuffer(serialized)), "plugin with ID test-id already registered")
 }
 
 func TestPluginManagerWithNoPlugins(t *testing.T) {
@@ -387,6 +648,49 @@ func TestPluginManagerWithNoPlugins(t *testing.T) {
 	require.ErrorContains(t, pm.RegisterPlugins(context.Background(), filepath.Join(".")), "no plugins found")
 }
 
+type staticResolver map[string]string
+
+func (r staticResolver) Resolve(_ context.Context, _ runtime.Identity) (runtime.Typed, error) {
+	data, err := json.Marshal(map[string]any{"type": "Credentials/v1", "properties": map[string]string(r)})
+	if err != nil {
+		return nil, err
+	}
+	raw := &runtime.Raw{}
+	return raw, raw.UnmarshalJSON(data)
+}
+
+func TestPluginManagerCredentialRefresh(t *testing.T) {
+	r := require.New(t)
+	config := &genericv1.Config{
+		Type: runtime.Type{Name: "custom.config", Version: "v1"},
+		Configurations: []*runtime.Raw{
+			{
+				Type: runtime.Type{Name: "custom.config", Version: "v1"},
+				Data: []byte(`{}`),
+			},
+		},
+	}
+	pm := NewPluginManager(context.Background())
+	r.NoError(pm.RegisterPlugins(t.Context(), filepath.Join("..", "tmp", "testdata"), WithConfiguration(config)))
+	server := pm.credentialRefresh
+	r.NotNil(server, "registering plugins must start the credential refresh channel")
+
+	identity := runtime.Identity{"type": "OCIRegistry", "hostname": "ghcr.io"}
+	grant, err := server.Grant()
+	r.NoError(err)
+	grant.Allow(identity)
+	_, err = plugins.RefreshCredentials(t.Context(), types.Socket, server.Location(), grant.Token(), identity)
+	r.Error(err, "credentials cannot be refreshed before a resolver is set")
+
+	pm.SetCredentialResolver(staticResolver{"accessToken": "refreshed"})
+	creds, err := plugins.RefreshCredentials(t.Context(), types.Socket, server.Location(), grant.Token(), identity)
+	r.NoError(err)
+	r.JSONEq(`{"type":"Credentials/v1","properties":{"accessToken":"refreshed"}}`, string(creds.(*runtime.Raw).Data))
+
+	r.NoError(pm.Shutdown(t.Context()))
+	r.NoFileExists(server.Location())
+}
+
 func TestCredentialTypeRegistryPopulatedFromPlugin(t *testing.T) {
 	config := &genericv1.Config{
 		Type: runtime.Type{Name: "custom.config", Version: "v1"},
@@ -412,3 +716,153 @@ func TestCredentialTypeRegistryPopulatedFromPlugin(t *testing.T) {
 	scheme := pm.CredentialRepositoryRegistry.GetCredentialTypeScheme()
 	require.True(t, scheme.IsRegistered(runtime.NewVersionedType("DummyToken", "v1")))
 }
+
+func TestPluginManagerSandbox(t *testing.T) {
+	r := require.New(t)
+	config := &genericv1.Config{
+		Type: runtime.Type{
+			Name:    "custom.config",
+			Version: "v1",
+		},
+		Configurations: []*runtime.Raw{
+			{
+				Type: runtime.Type{
+					Name:    "custom.config",
+					Version: "v1",
+				},
+				Data: []byte(`{}`),
+			},
+		},
+	}
+	ctx := t.Context()
+	t.Setenv("OCM_SANDBOX_TEST_SECRET", "secret")
+
+	pm := NewPluginManager(context.Background())
+	t.Cleanup(func() {
+		r.NoError(pm.Shutdown(context.Background()))
+	})
+	r.NoError(pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"),
+		WithConfiguration(config),
+		WithSandbox(types.Sandbox{OpenFiles: 256, Environment: []string{"PATH", "HOME", "TMPDIR"}}),
+		WithPluginSandbox("test-plugin-component-version", types.Sandbox{OpenFiles: 128}),
+	))
+
+	for _, d := range pm.Diagnostics() {
+		r.NotNil(d.Sandbox, "plugin %s is not sandboxed", d.ID)
+		if d.ID == "test-plugin-component-version" {
+			r.Equal(uint64(128), d.Sandbox.OpenFiles)
+			r.Nil(d.Sandbox.Environment)
+		} else {
+			r.Equal(uint64(256), d.Sandbox.OpenFiles)
+		}
+	}
+
+	proto := &dummyv1.Repository{
+		Type: runtime.Type{
+			Name:    "DummyRepository",
+			Version: "v1",
+		},
+	}
+	plugin, err := pm.ComponentVersionRepositoryRegistry.GetComponentVersionRepository(ctx, proto, nil)
+	r.NoError(err)
+	_, err = plugin.GetComponentVersion(ctx, "test-component", "1.0.0")
+	r.NoError(err)
+
+	env := pluginEnvironment(&types.Sandbox{Environment: []string{"PATH"}}, "EXTRA=value")
+	r.NotContains(env, "OCM_SANDBOX_TEST_SECRET=secret")
+	r.Contains(env, "EXTRA=value")
+	r.Nil(pluginEnvironment(nil))
+}
+
+func TestPluginManagerInvalidSandbox(t *testing.T) {
+	ctx := t.Context()
+	dir := filepath.Join("..", "tmp", "testdata")
+
+	t.Run("invalid sandbox", func(t *testing.T) {
+		r := require.New(t)
+		pm := NewPluginManager(context.Background())
+		err := pm.RegisterPlugins(ctx, dir, WithSandbox(types.Sandbox{CPU: 1}))
+		r.ErrorContains(err, "invalid sandbox")
+	})
+
+	t.Run("sandbox of unknown plugin", func(t *testing.T) {
+		r := require.New(t)
+		pm := NewPluginManager(context.Background())
+		t.Cleanup(func() {
+			r.NoError(pm.Shutdown(context.Background()))
+		})
+		err := pm.RegisterPlugins(ctx, dir, WithPluginSandbox("unknown", types.Sandbox{OpenFiles: 64}))
+		r.ErrorContains(err, "plugin unknown to sandbox not found")
+	})
+}
+
+func TestPluginManagerMetrics(t *testing.T) {
+	r := require.New(t)
+	config := &genericv1.Config{
+		Type: runtime.Type{
+			Name:    "custom.config",
+			Version: "v1",
+		},
+		Configurations: []*runtime.Raw{
+			{
+				Type: runtime.Type{
+					Name:    "custom.config",
+					Version: "v1",
+				},
+				Data: []byte(`{}`),
+			},
+		},
+	}
+	ctx := t.Context()
+
+	pm := NewPluginManager(context.Background())
+	r.NoError(pm.RegisterPlugins(ctx, filepath.Join("..", "tmp", "testdata"), WithConfiguration(config)))
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(pm.MetricsCollector())
+
+	requests := func() float64 {
+		families, err := registry.Gather()
+		r.NoError(err)
+		var total float64
+		for _, family := range families {
+			if family.GetName() != "ocm_plugin_requests_total" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				labels := make(map[string]string)
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				r.Equal("test-plugin-component-version", labels["plugin"])
+				r.Equal(string(ocmrepositoryv1.ComponentVersionRepositoryPluginType), labels["capability"])
+				total += metric.GetCounter().GetValue()
+			}
+		}
+		return total
+	}
+	r.Zero(requests(), "plugins are not started before their first use")
+
+	proto := &dummyv1.Repository{
+		Type: runtime.Type{
+			Name:    "DummyRepository",
+			Version: "v1",
+		},
+	}
+	plugin, err := pm.ComponentVersionRepositoryRegistry.GetComponentVersionRepository(ctx, proto, nil)
+	r.NoError(err)
+	_, err = plugin.GetComponentVersion(ctx, "test-component", "1.0.0")
+	r.NoError(err)
+
+	total := requests()
+	r.Positive(total)
+
+	// the counters of exited plugins are kept.
+	r.NoError(pm.Shutdown(context.Background()))
+	r.Eventually(func() bool {
+		pm.metrics.mu.Lock()
+		running := len(pm.metrics.targets)
+		pm.metrics.mu.Unlock()
+		return requests() == total && running == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
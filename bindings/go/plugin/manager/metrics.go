@@ -0,0 +1,287 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+
+	"ocm.software/open-component-model/bindings/go/plugin/client/sdk"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
+	mtypes "ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+const (
+	// metricsScrapeTimeout is the maximum time to scrape the metrics of a single plugin process.
+	metricsScrapeTimeout = 5 * time.Second
+	// pluginLabel and capabilityLabel are added to the metrics of plugins to tell them apart.
+	pluginLabel     = "plugin"
+	capabilityLabel = "capability"
+)
+
+// MetricsCollector returns a collector of the metrics the external plugins expose on sdk.MetricsEndpoint,
+// to be registered with the metric registry of the host, for example:
+//
+//	prometheus.MustRegister(pm.MetricsCollector())
+//
+// On every collection, the metrics of all running plugin processes are scraped. The metrics are labeled with the
+// ID of the plugin and the type of the capability the process serves. Counters and histograms of processes that
+// exited, for example because they reached their idle timeout, are kept as of their last scrape and added to those
+// of the following processes, so that they do not reset when a plugin is restarted. Gauges of exited processes are
+// dropped. Plugins not built with the SDK, which do not expose metrics, are skipped.
+func (pm *PluginManager) MetricsCollector() prometheus.Collector {
+	return &pm.metrics
+}
+
+// pluginMetrics scrapes and aggregates the metrics of plugin processes.
+type pluginMetrics struct {
+	mu      sync.Mutex
+	targets map[*metricsTarget]struct{}
+	// retired are the counters and histograms of exited processes.
+	retired map[string]*series
+}
+
+var _ prometheus.Collector = (*pluginMetrics)(nil)
+
+// metricsTarget is a running plugin process whose metrics are scraped.
+type metricsTarget struct {
+	pluginID       string
+	capability     string
+	connectionType mtypes.ConnectionType
+	client         *http.Client
+	location       string
+	exited         <-chan struct{}
+	// last are the series scraped last from the process.
+	last map[string]*series
+}
+
+// series is a single aggregated time series.
+type series struct {
+	name   string
+	help   string
+	typ    dto.MetricType
+	labels map[string]string
+
+	value   float64
+	count   uint64
+	sum     float64
+	buckets map[float64]uint64
+}
+
+// launched returns the callback with which a plugin process is added as target once it was launched, see mtypes.Plugin.Launched.
+func (m *pluginMetrics) launched(pluginID, capability string, connectionType mtypes.ConnectionType) func(*http.Client, string, <-chan struct{}) {
+	return func(client *http.Client, location string, exited <-chan struct{}) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.targets == nil {
+			m.targets = make(map[*metricsTarget]struct{})
+		}
+		m.targets[&metricsTarget{
+			pluginID:       pluginID,
+			capability:     capability,
+			connectionType: connectionType,
+			client:         client,
+			location:       location,
+			exited:         exited,
+		}] = struct{}{}
+	}
+}
+
+// Describe sends no descriptions, as the metrics of plugins are only known once they were scraped.
+// This makes the collector an unchecked collector.
+func (m *pluginMetrics) Describe(chan<- *prometheus.Desc) {}
+
+func (m *pluginMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	targets := slices.Collect(maps.Keys(m.targets))
+	m.mu.Unlock()
+
+	scraped := make([]map[string]*series, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Go(func() {
+			result, err := target.scrape()
+			if err != nil {
+				slog.Debug("failed to scrape plugin metrics", "id", target.pluginID, "capability", target.capability, "error", err)
+				return
+			}
+			scraped[i] = result
+		})
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	aggregated := make(map[string]*series)
+	for key, s := range m.retired {
+		aggregated[key] = s.clone()
+	}
+	for i, target := range targets {
+		if scraped[i] != nil {
+			target.last = scraped[i]
+		}
+		select {
+		case <-target.exited:
+			// the process exited, its counters and histograms are kept as of its last scrape.
+			delete(m.targets, target)
+			if m.retired == nil {
+				m.retired = make(map[string]*series)
+			}
+			for key, s := range target.last {
+				if s.typ == dto.MetricType_COUNTER || s.typ == dto.MetricType_HISTOGRAM {
+					add(m.retired, key, s)
+					add(aggregated, key, s)
+				}
+			}
+		default:
+			for key, s := range target.last {
+				add(aggregated, key, s)
+			}
+		}
+	}
+
+	// all series of a metric need the same help text, so the first is used.
+	help := make(map[string]string)
+	for _, key := range slices.Sorted(maps.Keys(aggregated)) {
+		s := aggregated[key]
+		if _, ok := help[s.name]; !ok {
+			help[s.name] = s.help
+		}
+		metric, err := s.metric(help[s.name])
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(prometheus.NewDesc(s.name, help[s.name], nil, nil), err)
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// scrape scrapes the metrics of the process, labeled with the plugin and capability.
+func (t *metricsTarget) scrape() (map[string]*series, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsScrapeTimeout)
+	defer cancel()
+	resp, err := plugins.CallStream(ctx, t.client, t.connectionType, t.location, sdk.MetricsEndpoint, http.MethodGet)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	result := make(map[string]*series)
+	for name, family := range families {
+		for _, metric := range family.GetMetric() {
+			s := &series{
+				name:   name,
+				help:   family.GetHelp(),
+				typ:    family.GetType(),
+				labels: map[string]string{pluginLabel: t.pluginID, capabilityLabel: t.capability},
+			}
+			for _, label := range metric.GetLabel() {
+				if label.GetName() != pluginLabel && label.GetName() != capabilityLabel {
+					s.labels[label.GetName()] = label.GetValue()
+				}
+			}
+			switch s.typ {
+			case dto.MetricType_COUNTER:
+				s.value = metric.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				s.value = metric.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				s.value = metric.GetUntyped().GetValue()
+			case dto.MetricType_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				s.count = histogram.GetSampleCount()
+				s.sum = histogram.GetSampleSum()
+				s.buckets = make(map[float64]uint64, len(histogram.GetBucket()))
+				for _, bucket := range histogram.GetBucket() {
+					s.buckets[bucket.GetUpperBound()] = bucket.GetCumulativeCount()
+				}
+			default:
+				// summaries cannot be aggregated across processes.
+				continue
+			}
+			result[s.key()] = s
+		}
+	}
+	return result, nil
+}
+
+func (s *series) key() string {
+	var b strings.Builder
+	b.WriteString(s.name)
+	for _, name := range slices.Sorted(maps.Keys(s.labels)) {
+		b.WriteString("\xff")
+		b.WriteString(name)
+		b.WriteString("=")
+		b.WriteString(s.labels[name])
+	}
+	return b.String()
+}
+
+func (s *series) clone() *series {
+	c := *s
+	c.buckets = maps.Clone(s.buckets)
+	return &c
+}
+
+// add adds s to the series with the given key in aggregated.
+func add(aggregated map[string]*series, key string, s *series) {
+	existing, ok := aggregated[key]
+	if !ok {
+		aggregated[key] = s.clone()
+		return
+	}
+	existing.value += s.value
+	existing.count += s.count
+	existing.sum += s.sum
+	for bound, count := range s.buckets {
+		if existing.buckets == nil {
+			existing.buckets = make(map[float64]uint64)
+		}
+		existing.buckets[bound] += count
+	}
+}
+
+func (s *series) metric(help string) (prometheus.Metric, error) {
+	names := slices.Sorted(maps.Keys(s.labels))
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, s.labels[name])
+	}
+	desc := prometheus.NewDesc(s.name, help, names, nil)
+	switch s.typ {
+	case dto.MetricType_COUNTER:
+		return prometheus.NewConstMetric(desc, prometheus.CounterValue, s.value, values...)
+	case dto.MetricType_GAUGE:
+		return prometheus.NewConstMetric(desc, prometheus.GaugeValue, s.value, values...)
+	case dto.MetricType_HISTOGRAM:
+		// the +Inf bucket is implicit in constant histograms.
+		buckets := maps.Clone(s.buckets)
+		for bound := range buckets {
+			if math.IsInf(bound, +1) {
+				delete(buckets, bound)
+			}
+		}
+		return prometheus.NewConstHistogram(desc, s.count, s.sum, buckets, values...)
+	default:
+		return prometheus.NewConstMetric(desc, prometheus.UntypedValue, s.value, values...)
+	}
+}
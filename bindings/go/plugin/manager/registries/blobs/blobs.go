@@ -9,10 +9,13 @@ import (
 )
 
 // CreateBlobData creates a blob based on the location.
-func CreateBlobData(location types.Location) (b blob.Blob, err error) {
+// Shared memory locations are consumed: their file is removed once it is mapped.
+func CreateBlobData(location types.Location) (b blob.ReadOnlyBlob, err error) {
 	switch location.LocationType {
 	case types.LocationTypeLocalFile:
 		b, err = filesystem.GetBlobFromOSPath(location.Value)
+	case types.LocationTypeSharedMemory:
+		b, err = openSharedMemory(location)
 	default:
 		return nil, fmt.Errorf("unsupported location type: %s", location.LocationType)
 	}
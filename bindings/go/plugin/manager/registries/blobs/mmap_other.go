@@ -0,0 +1,17 @@
+//go:build !unix
+
+package blobs
+
+import (
+	"errors"
+	"os"
+)
+
+// mapFile is not supported on this platform, shared memory locations are read and written with regular file I/O.
+func mapFile(_ *os.File, _ int, _ bool) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func unmapFile(_ []byte) error {
+	return errors.ErrUnsupported
+}
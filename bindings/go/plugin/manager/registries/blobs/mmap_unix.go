@@ -0,0 +1,22 @@
+//go:build unix
+
+package blobs
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps size bytes of file into memory, writable or read-only.
+func mapFile(file *os.File, size int, writable bool) ([]byte, error) {
+	prot := syscall.PROT_READ
+	if writable {
+		prot |= syscall.PROT_WRITE
+	}
+	return syscall.Mmap(int(file.Fd()), 0, size, prot, syscall.MAP_SHARED)
+}
+
+// unmapFile unmaps memory mapped with mapFile.
+func unmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,206 @@
+package blobs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+// sharedMemoryDir is the tmpfs mount used for shared memory locations on Linux.
+const sharedMemoryDir = "/dev/shm"
+
+// SharedMemoryDir returns the directory to create files for shared memory locations in.
+// It is /dev/shm if the host has it, so that the content never touches a disk, and the
+// temporary directory otherwise.
+func SharedMemoryDir() string {
+	if info, err := os.Stat(sharedMemoryDir); err == nil && info.IsDir() {
+		return sharedMemoryDir
+	}
+	return os.TempDir()
+}
+
+// WriteSharedMemory writes the content of b into file and returns a shared memory location
+// advertising the file together with the digest and size of the content.
+//
+// If the size of b is known, the content is copied into a memory mapping of the file, so the
+// only copy of the content is the one into the page cache shared with the consumer.
+// The ownership of the file is handed off with the location: the consumer removes the file once
+// it mapped it, see CreateBlobData. The creator SHOULD only remove the file if the location is
+// never handed off or on shutdown, in which case a file that does not exist anymore is not an error.
+func WriteSharedMemory(file *os.File, b blob.ReadOnlyBlob) (_ types.Location, err error) {
+	rc, err := b.ReadCloser()
+	if err != nil {
+		return types.Location{}, fmt.Errorf("failed to read blob: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, rc.Close())
+	}()
+
+	digester := digest.Canonical.Digester()
+	content := io.TeeReader(rc, digester.Hash())
+
+	size := blob.SizeUnknown
+	if sizeAware, ok := b.(blob.SizeAware); ok {
+		size = sizeAware.Size()
+	}
+	var written int64
+	if size > 0 {
+		written, err = writeMapped(file, content, size)
+		if errors.Is(err, errors.ErrUnsupported) {
+			written, err = io.Copy(file, content)
+		}
+	} else {
+		written, err = io.Copy(file, content)
+	}
+	if err != nil {
+		return types.Location{}, fmt.Errorf("failed to write blob to shared memory: %w", err)
+	}
+
+	location := types.Location{
+		LocationType: types.LocationTypeSharedMemory,
+		Value:        file.Name(),
+		Digest:       digester.Digest().String(),
+		Size:         written,
+	}
+	if mediaTypeAware, ok := b.(blob.MediaTypeAware); ok {
+		if mediaType, known := mediaTypeAware.MediaType(); known {
+			location.MediaType = mediaType
+		}
+	}
+	return location, nil
+}
+
+// writeMapped copies exactly size bytes of content into a memory mapping of file.
+func writeMapped(file *os.File, content io.Reader, size int64) (_ int64, err error) {
+	if err := file.Truncate(size); err != nil {
+		return 0, err
+	}
+	data, err := mapFile(file, int(size), true)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, unmapFile(data))
+	}()
+
+	if _, err := io.ReadFull(content, data); err != nil {
+		return 0, fmt.Errorf("blob is smaller than its size %d: %w", size, err)
+	}
+	if n, _ := content.Read(make([]byte, 1)); n > 0 {
+		return 0, fmt.Errorf("blob is larger than its size %d", size)
+	}
+	return size, nil
+}
+
+// openSharedMemory maps the file of a shared memory location and takes over its ownership by
+// removing the file. The content stays available through the mapping until the returned blob
+// is garbage collected.
+func openSharedMemory(location types.Location) (_ blob.ReadOnlyBlob, err error) {
+	file, err := os.Open(location.Value)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+	if location.Size > 0 && info.Size() != location.Size {
+		return nil, errors.Join(fmt.Errorf("shared memory location %q has size %d, but %d was advertised", location.Value, info.Size(), location.Size), file.Close())
+	}
+
+	var data []byte
+	mapped := false
+	if info.Size() > 0 {
+		data, err = mapFile(file, int(info.Size()), false)
+		switch {
+		case err == nil:
+			mapped = true
+		case errors.Is(err, errors.ErrUnsupported):
+			data, err = io.ReadAll(file)
+		}
+	}
+	// the mapping stays valid once the file is closed and removed.
+	err = errors.Join(err, file.Close())
+	if err == nil {
+		err = os.Remove(location.Value)
+	}
+	if err != nil {
+		if mapped {
+			err = errors.Join(err, unmapFile(data))
+		}
+		return nil, fmt.Errorf("failed to consume shared memory location %q: %w", location.Value, err)
+	}
+
+	b := &sharedMemoryBlob{
+		data:      data,
+		digest:    location.Digest,
+		mediaType: location.MediaType,
+	}
+	if mapped {
+		runtime.AddCleanup(b, func(data []byte) {
+			_ = unmapFile(data)
+		}, data)
+	}
+	return b, nil
+}
+
+// sharedMemoryBlob is a blob backed by the memory mapping of a consumed shared memory location.
+type sharedMemoryBlob struct {
+	data   []byte
+	digest string
+
+	mu        sync.RWMutex
+	mediaType string
+}
+
+var (
+	_ blob.ReadOnlyBlob          = (*sharedMemoryBlob)(nil)
+	_ blob.SizeAware             = (*sharedMemoryBlob)(nil)
+	_ blob.DigestAware           = (*sharedMemoryBlob)(nil)
+	_ blob.MediaTypeAware        = (*sharedMemoryBlob)(nil)
+	_ blob.MediaTypeOverrideable = (*sharedMemoryBlob)(nil)
+)
+
+func (b *sharedMemoryBlob) ReadCloser() (io.ReadCloser, error) {
+	// the reader references the blob, so the mapping is not released while it is read.
+	return &sharedMemoryReader{Reader: bytes.NewReader(b.data), blob: b}, nil
+}
+
+func (b *sharedMemoryBlob) Size() int64 {
+	return int64(len(b.data))
+}
+
+func (b *sharedMemoryBlob) Digest() (string, bool) {
+	return b.digest, b.digest != ""
+}
+
+func (b *sharedMemoryBlob) MediaType() (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.mediaType, b.mediaType != ""
+}
+
+func (b *sharedMemoryBlob) SetMediaType(mediaType string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mediaType = mediaType
+}
+
+type sharedMemoryReader struct {
+	*bytes.Reader
+	blob *sharedMemoryBlob
+}
+
+func (r *sharedMemoryReader) Close() error {
+	r.Reader, r.blob = bytes.NewReader(nil), nil
+	return nil
+}
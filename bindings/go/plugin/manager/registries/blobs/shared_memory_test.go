@@ -0,0 +1,70 @@
+package blobs
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+func TestSharedMemory(t *testing.T) {
+	content := bytes.Repeat([]byte("shared memory "), 10000)
+
+	for name, b := range map[string]blob.ReadOnlyBlob{
+		"known size":   inmemory.New(bytes.NewReader(content), inmemory.WithMediaType("text/plain")),
+		"unknown size": blob.NewDirectReadOnlyBlob(bytes.NewReader(content)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			file, err := os.CreateTemp(t.TempDir(), "blob-*")
+			r.NoError(err)
+
+			location, err := WriteSharedMemory(file, b)
+			r.NoError(err)
+			r.NoError(file.Close())
+			r.Equal(types.LocationTypeSharedMemory, location.LocationType)
+			r.Equal(file.Name(), location.Value)
+			r.Equal(digest.FromBytes(content).String(), location.Digest)
+			r.Equal(int64(len(content)), location.Size)
+
+			consumed, err := CreateBlobData(location)
+			r.NoError(err)
+			r.NoFileExists(file.Name(), "the consumer takes over the ownership of the file")
+
+			rc, err := consumed.ReadCloser()
+			r.NoError(err)
+			data, err := io.ReadAll(rc)
+			r.NoError(err)
+			r.NoError(rc.Close())
+			r.Equal(content, data)
+
+			dgst, known := consumed.(blob.DigestAware).Digest()
+			r.True(known)
+			r.Equal(location.Digest, dgst)
+			r.Equal(int64(len(content)), consumed.(blob.SizeAware).Size())
+			mediaType, _ := consumed.(blob.MediaTypeAware).MediaType()
+			r.Equal(location.MediaType, mediaType)
+		})
+	}
+}
+
+func TestSharedMemory_SizeMismatch(t *testing.T) {
+	r := require.New(t)
+	file, err := os.CreateTemp(t.TempDir(), "blob-*")
+	r.NoError(err)
+	location, err := WriteSharedMemory(file, inmemory.New(bytes.NewReader([]byte("content"))))
+	r.NoError(err)
+	r.NoError(file.Close())
+
+	location.Size++
+	_, err = CreateBlobData(location)
+	r.ErrorContains(err, "was advertised")
+	r.FileExists(file.Name(), "a location that was not consumed is not removed")
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	"ocm.software/open-component-model/bindings/go/blob/transformer"
 	blobtransformerv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/blobtransformer/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/blobs"
@@ -21,13 +21,14 @@ type converter struct {
 }
 
 func (c *converter) TransformBlob(ctx context.Context, blob blob.ReadOnlyBlob, spec runtime.Typed, credentials runtime.Typed) (_ blob.ReadOnlyBlob, err error) {
-	tmp, err := os.CreateTemp("", "blob")
+	tmp, err := tempfile.CreateTemp(ctx, "", "blob")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temp file: %w", err)
 	}
 
+	// the plugin has consumed the file once it responded, so it is removed right away.
 	defer func() {
-		err = errors.Join(err, tmp.Close())
+		err = errors.Join(err, tmp.Close(), tempfile.Release(ctx, tmp.Name()))
 	}()
 
 	if err := filesystem.CopyBlobToOSPath(blob, tmp.Name()); err != nil {
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"sync"
 
 	"ocm.software/open-component-model/bindings/go/blob/transformer"
@@ -13,12 +11,13 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	mtypes "ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 type constructedPlugin struct {
 	Plugin blobtransformerv1.BlobTransformerPluginContract[runtime.Typed]
 
-	cmd *exec.Cmd
+	process *plugins.Process
 }
 
 // Registry holds all plugins that implement capabilities corresponding to RepositoryPlugin operations.
@@ -70,7 +69,7 @@ func (r *Registry) Shutdown(ctx context.Context) error {
 	for _, p := range r.constructedPlugins {
 		// The plugins should handle the Interrupt signal for shutdowns.
 		// TODO(Skarlso): Use context to wait for the plugin to actually shut down.
-		if perr := p.cmd.Process.Signal(os.Interrupt); perr != nil && !errors.Is(perr, os.ErrProcessDone) {
+		if perr := p.process.Interrupt(); perr != nil {
 			errs = errors.Join(errs, perr)
 		}
 	}
@@ -105,24 +104,31 @@ func (r *Registry) AddPlugin(plugin mtypes.Plugin, spec runtime.Typed) error {
 	return nil
 }
 
-func startAndReturnPlugin(ctx context.Context, r *Registry, plugin *mtypes.Plugin) (blobtransformerv1.BlobTransformerPluginContract[runtime.Typed], error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *Registry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *mtypes.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+func startAndReturnPlugin(ctx context.Context, r *Registry, plugin *mtypes.Plugin) (blobtransformerv1.BlobTransformerPluginContract[runtime.Typed], error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	// start log streaming once the plugin is up and running.
-	// use the baseCtx here from the manager here so the streaming isn't stopped when the request is stopped.
-	go plugins.StartLogStreamer(r.ctx, plugin)
-
-	repoPlugin := NewPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
+	repoPlugin := NewPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
 	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: repoPlugin,
-		cmd:    plugin.Cmd,
+		Plugin:  repoPlugin,
+		process: process,
 	}
 
 	// wrap the untyped internal plugin into a typed representation.
@@ -159,10 +165,10 @@ func (r *Registry) GetPlugin(ctx context.Context, spec runtime.Typed) (transform
 func (r *Registry) getPlugin(ctx context.Context, typ runtime.Type) (blobtransformerv1.BlobTransformerPluginContract[runtime.Typed], error) {
 	plugin, ok := r.registry[typ]
 	if !ok {
-		return nil, fmt.Errorf("failed to get plugin for typ %q", typ)
+		return nil, ocmerrors.Unsupported(fmt.Errorf("failed to get plugin for typ %q", typ))
 	}
 
-	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok && !existingPlugin.process.Exited() {
 		return existingPlugin.Plugin, nil
 	}
 
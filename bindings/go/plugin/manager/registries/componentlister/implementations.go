@@ -72,6 +72,7 @@ func (r *ComponentListerPlugin) GetIdentity(ctx context.Context, request *compon
 	if err := plugins.Call(ctx, r.client, r.config.Type, r.location, Identity, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(&identity)); err != nil {
 		return nil, fmt.Errorf("failed to get identity from plugin %q: %w", r.ID, err)
 	}
+	plugins.AllowCredentialRefresh(r.client, identity.Identity)
 
 	return &identity, nil
 }
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
@@ -15,12 +13,13 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // constructedPlugin only contains EXTERNAL plugins that have been started and need to be shut down.
 type constructedPlugin struct {
-	Plugin componentlisterv1.ComponentListerPluginContract[runtime.Typed]
-	cmd    *exec.Cmd
+	Plugin  componentlisterv1.ComponentListerPluginContract[runtime.Typed]
+	process *plugins.Process
 }
 
 // RegisterInternalComponentListerPlugin is called to register an internal implementation for a component lister plugin.
@@ -69,25 +68,15 @@ func (r *ComponentListerRegistry) Shutdown(ctx context.Context) error {
 	for _, p := range r.constructedPlugins {
 		eg.Go(func() error {
 			// The plugins should handle the Interrupt signal for shutdowns.
-			if err := p.cmd.Process.Signal(os.Interrupt); err != nil && !errors.Is(err, os.ErrProcessDone) {
-				return fmt.Errorf("failed to send interrupt signal to plugin: %w", errors.Join(err, p.cmd.Process.Kill()))
+			if err := p.process.Interrupt(); err != nil {
+				return fmt.Errorf("failed to send interrupt signal to plugin: %w", errors.Join(err, p.process.Kill()))
 			}
 
-			shutdownSig := make(chan error, 1)
-			defer func() {
-				close(shutdownSig)
-			}()
-			go func() {
-				_, err := p.cmd.Process.Wait()
-				shutdownSig <- err
-			}()
-
-			select {
-			case err := <-shutdownSig:
-				return err
-			case <-ctx.Done():
-				return errors.Join(ctx.Err(), p.cmd.Process.Kill())
+			if err := p.process.Wait(ctx); err != nil {
+				return errors.Join(err, p.process.Kill())
 			}
+
+			return nil
 		})
 	}
 
@@ -121,24 +110,31 @@ func (r *ComponentListerRegistry) AddPlugin(plugin types.Plugin, spec runtime.Ty
 	return nil
 }
 
-func startAndReturnPlugin(ctx context.Context, r *ComponentListerRegistry, plugin *types.Plugin) (componentlisterv1.ComponentListerPluginContract[runtime.Typed], error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *ComponentListerRegistry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *types.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+func startAndReturnPlugin(ctx context.Context, r *ComponentListerRegistry, plugin *types.Plugin) (componentlisterv1.ComponentListerPluginContract[runtime.Typed], error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	// start log streaming once the plugin is up and running.
-	// use the baseCtx here from the manager here so the streaming isn't stopped when the request is stopped.
-	go plugins.StartLogStreamer(r.ctx, plugin)
-
-	listerPlugin := NewComponentListerPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
+	listerPlugin := NewComponentListerPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
 	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: listerPlugin,
-		cmd:    plugin.Cmd,
+		Plugin:  listerPlugin,
+		process: process,
 	}
 
 	// wrap the untyped internal plugin into a typed representation.
@@ -228,10 +224,10 @@ func (r *ComponentListerRegistry) GetComponentLister(ctx context.Context,
 func (r *ComponentListerRegistry) getPlugin(ctx context.Context, typ runtime.Type) (componentlisterv1.ComponentListerPluginContract[runtime.Typed], error) {
 	plugin, ok := r.registry[typ]
 	if !ok {
-		return nil, fmt.Errorf("failed to get plugin for typ %q", typ)
+		return nil, ocmerrors.Unsupported(fmt.Errorf("failed to get plugin for typ %q", typ))
 	}
 
-	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok && !existingPlugin.process.Exited() {
 		return existingPlugin.Plugin, nil
 	}
 
@@ -0,0 +1,117 @@
+package componentversionrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	ocmrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+// ErrMissingCredentials is matched by MissingCredentialsError.
+var ErrMissingCredentials = errors.New("missing credentials")
+
+// MissingCredentialsError is returned by CheckComponentVersionRepositoryCredentials if no credentials are
+// configured for consumer identities an operation requires. It also matches credentials.ErrNotFound.
+type MissingCredentialsError struct {
+	// Operation is the checked operation.
+	Operation ocmrepositoryv1.RepositoryOperation
+	// Identities are the consumer identities without credentials.
+	Identities []runtime.Identity
+}
+
+func (e *MissingCredentialsError) Error() string {
+	identities := make([]string, 0, len(e.Identities))
+	for _, identity := range e.Identities {
+		identities = append(identities, identity.String())
+	}
+	return fmt.Sprintf("missing credentials for %s operation for consumer identities: %s", e.Operation, strings.Join(identities, "; "))
+}
+
+func (e *MissingCredentialsError) Is(target error) bool {
+	return target == ErrMissingCredentials || target == credentials.ErrNotFound
+}
+
+// ListComponentVersionRepositoryCredentialConsumerIdentities lists the consumer identities credentials may be
+// resolved for while working on the repository of the given specification.
+// Plugins that do not support listing them, as well as internal plugins, are only known to use the identity
+// returned by GetComponentVersionRepositoryCredentialConsumerIdentity. It is listed as optional for all
+// operations, as it is unknown whether the operations succeed without credentials.
+func (r *RepositoryRegistry) ListComponentVersionRepositoryCredentialConsumerIdentities(ctx context.Context, repositorySpecification runtime.Typed) ([]ocmrepositoryv1.ConsumerIdentity, error) {
+	r.mu.RLock()
+	_, _ = r.scheme.DefaultType(repositorySpecification)
+	typ := repositorySpecification.GetType()
+	internal := r.scheme.IsRegistered(typ)
+	var plugin ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[runtime.Typed]
+	var err error
+	if !internal {
+		plugin, err = r.getPlugin(ctx, typ)
+	}
+	r.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin for typ %q: %w", typ, err)
+	}
+
+	if lister, ok := plugin.(ocmrepositoryv1.ConsumerIdentityLister[runtime.Typed]); ok {
+		response, err := lister.ListConsumerIdentities(ctx, ocmrepositoryv1.ListConsumerIdentitiesRequest[runtime.Typed]{
+			Repository: repositorySpecification,
+		})
+		switch {
+		case err == nil:
+			return response.Identities, nil
+		case !errors.Is(err, ocmerrors.ErrUnsupported):
+			return nil, fmt.Errorf("failed to list consumer identities: %w", err)
+		}
+	}
+
+	identity, err := r.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, repositorySpecification)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		return nil, nil
+	}
+
+	return []ocmrepositoryv1.ConsumerIdentity{{Identity: identity, Optional: true}}, nil
+}
+
+// CheckComponentVersionRepositoryCredentials checks up front that the resolver has credentials for all
+// consumer identities the operation on the repository of the given specification requires.
+// It fails with a MissingCredentialsError listing all consumer identities without credentials.
+func (r *RepositoryRegistry) CheckComponentVersionRepositoryCredentials(ctx context.Context, repositorySpecification runtime.Typed, operation ocmrepositoryv1.RepositoryOperation, resolver credentials.Resolver) error {
+	identities, err := r.ListComponentVersionRepositoryCredentialConsumerIdentities(ctx, repositorySpecification)
+	if err != nil {
+		return err
+	}
+
+	return checkCredentials(ctx, identities, operation, resolver)
+}
+
+// checkCredentials resolves the credentials of all required consumer identities of the operation.
+func checkCredentials(ctx context.Context, identities []ocmrepositoryv1.ConsumerIdentity, operation ocmrepositoryv1.RepositoryOperation, resolver credentials.Resolver) error {
+	var missing []runtime.Identity
+	for _, identity := range identities {
+		if identity.Optional || !identity.UsedFor(operation) {
+			continue
+		}
+		if resolver == nil {
+			missing = append(missing, identity.Identity)
+			continue
+		}
+		if _, err := resolver.Resolve(ctx, identity.Identity); err != nil {
+			if !errors.Is(err, credentials.ErrNotFound) {
+				return fmt.Errorf("failed to resolve credentials for consumer identity %s: %w", identity.Identity, err)
+			}
+			missing = append(missing, identity.Identity)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingCredentialsError{Operation: operation, Identities: missing}
+	}
+
+	return nil
+}
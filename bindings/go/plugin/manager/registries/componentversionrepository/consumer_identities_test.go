@@ -0,0 +1,107 @@
+package componentversionrepository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	dummyv1 "ocm.software/open-component-model/bindings/go/plugin/internal/dummytype/v1"
+	v1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// identityResolver resolves credentials for the configured hostnames.
+type identityResolver map[string]error
+
+func (r identityResolver) Resolve(_ context.Context, identity runtime.Identity) (runtime.Typed, error) {
+	err, ok := r[identity["hostname"]]
+	if !ok {
+		return nil, credentials.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.Raw{Type: runtime.NewVersionedType("Credentials", "v1"), Data: []byte(`{"type":"Credentials/v1"}`)}, nil
+}
+
+func TestCheckCredentials(t *testing.T) {
+	registry := runtime.Identity{"type": "OCIRegistry", "hostname": "ghcr.io"}
+	mirror := runtime.Identity{"type": "OCIRegistry", "hostname": "mirror.ocm.software"}
+	anonymous := runtime.Identity{"type": "OCIRegistry", "hostname": "public.ocm.software"}
+	identities := []v1.ConsumerIdentity{
+		{Identity: registry},
+		{Identity: mirror, Operations: []v1.RepositoryOperation{v1.RepositoryOperationWrite}},
+		{Identity: anonymous, Operations: []v1.RepositoryOperation{v1.RepositoryOperationRead}, Optional: true},
+	}
+
+	tests := []struct {
+		name      string
+		operation v1.RepositoryOperation
+		resolver  credentials.Resolver
+		missing   []runtime.Identity
+		err       string
+	}{
+		{
+			name:      "read with credentials",
+			operation: v1.RepositoryOperationRead,
+			resolver:  identityResolver{"ghcr.io": nil},
+		},
+		{
+			name:      "write with missing credentials",
+			operation: v1.RepositoryOperationWrite,
+			resolver:  identityResolver{"ghcr.io": nil},
+			missing:   []runtime.Identity{mirror},
+		},
+		{
+			name:      "no resolver",
+			operation: v1.RepositoryOperationWrite,
+			missing:   []runtime.Identity{registry, mirror},
+		},
+		{
+			name:      "resolution failure",
+			operation: v1.RepositoryOperationRead,
+			resolver:  identityResolver{"ghcr.io": errors.New("credential plugin unavailable")},
+			err:       "credential plugin unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			err := checkCredentials(t.Context(), identities, tt.operation, tt.resolver)
+			switch {
+			case tt.err != "":
+				r.ErrorContains(err, tt.err)
+				r.NotErrorIs(err, ErrMissingCredentials)
+			case tt.missing != nil:
+				r.ErrorIs(err, ErrMissingCredentials)
+				r.ErrorIs(err, credentials.ErrNotFound)
+				var missingErr *MissingCredentialsError
+				r.ErrorAs(err, &missingErr)
+				r.Equal(tt.operation, missingErr.Operation)
+				r.Equal(tt.missing, missingErr.Identities)
+			default:
+				r.NoError(err)
+			}
+		})
+	}
+}
+
+func TestListConsumerIdentitiesOfInternalPlugin(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	registry := NewComponentVersionRepositoryRegistry(ctx)
+	r.NoError(registry.RegisterInternalComponentVersionRepositoryPlugin(&mockPluginProvider{mockPlugin: &mockedRepository{}}))
+
+	identities, err := registry.ListComponentVersionRepositoryCredentialConsumerIdentities(ctx, &dummyv1.Repository{})
+	r.NoError(err)
+	r.Equal([]v1.ConsumerIdentity{{Identity: runtime.Identity{"test": "identity"}, Optional: true}}, identities)
+
+	r.NoError(registry.CheckComponentVersionRepositoryCredentials(ctx, &dummyv1.Repository{}, v1.RepositoryOperationWrite, nil),
+		"identities of internal plugins are optional")
+}
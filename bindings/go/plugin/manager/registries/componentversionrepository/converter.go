@@ -4,10 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"io"
+	"sync"
 
 	"ocm.software/open-component-model/bindings/go/blob"
-	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	ocmrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
@@ -15,6 +16,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // componentVersionRepositoryWrapper wraps external plugins to implement ComponentVersionRepository interface
@@ -26,7 +28,13 @@ type componentVersionRepositoryWrapper struct {
 	scheme                  *runtime.Scheme
 }
 
-var _ repository.ComponentVersionRepository = (*componentVersionRepositoryWrapper)(nil)
+var (
+	_ repository.ComponentVersionRepository     = (*componentVersionRepositoryWrapper)(nil)
+	_ repository.GarbageCollector               = (*componentVersionRepositoryWrapper)(nil)
+	_ repository.IntegrityVerifier              = (*componentVersionRepositoryWrapper)(nil)
+	_ repository.StatsProvider                  = (*componentVersionRepositoryWrapper)(nil)
+	_ repository.VerifiedComponentVersionGetter = (*componentVersionRepositoryWrapper)(nil)
+)
 
 func (c *componentVersionRepositoryWrapper) AddComponentVersion(ctx context.Context, desc *descriptor.Descriptor) error {
 	convertedDesc, err := descriptor.ConvertToV2(c.scheme, desc)
@@ -52,6 +60,19 @@ func (c *componentVersionRepositoryWrapper) GetComponentVersion(ctx context.Cont
 	return c.externalPlugin.GetComponentVersion(ctx, request, c.credentials)
 }
 
+func (c *componentVersionRepositoryWrapper) GetVerifiedComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, *repository.Verification, error) {
+	verifier, ok := c.externalPlugin.(ocmrepositoryv1.VerifiedComponentVersionGetter[runtime.Typed])
+	if !ok {
+		return nil, nil, ocmerrors.Unsupported(fmt.Errorf("verification metadata is not provided by the plugin"))
+	}
+	request := ocmrepositoryv1.GetComponentVersionRequest[runtime.Typed]{
+		Repository: c.repositorySpecification,
+		Name:       component,
+		Version:    version,
+	}
+	return verifier.GetVerifiedComponentVersion(ctx, request, c.credentials)
+}
+
 func (c *componentVersionRepositoryWrapper) ListComponentVersions(ctx context.Context, component string) ([]string, error) {
 	request := ocmrepositoryv1.ListComponentVersionsRequest[runtime.Typed]{
 		Repository: c.repositorySpecification,
@@ -67,18 +88,30 @@ func (c *componentVersionRepositoryWrapper) AddLocalResource(ctx context.Context
 		return nil, fmt.Errorf("failed to convert resource: %w", err)
 	}
 
-	tmp, err := os.CreateTemp("", "resource")
+	if streaming, ok := c.externalPlugin.(ocmrepositoryv1.StreamingBlobTransfer[runtime.Typed]); ok {
+		request := ocmrepositoryv1.PostLocalResourceStreamRequest[runtime.Typed]{
+			Repository: c.repositorySpecification,
+			Name:       component,
+			Version:    version,
+			Resource:   &resources[0],
+		}
+		resource, err := streamContent(content, func(reader io.Reader) (*descriptor.Resource, error) {
+			return streaming.AddLocalResourceStream(ctx, request, reader, c.credentials)
+		})
+		if !errors.Is(err, ocmerrors.ErrUnsupported) {
+			return resource, err
+		}
+	}
+
+	tmp, err := writeTempBlob(ctx, content, "resource")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
+		return nil, err
 	}
+	// the plugin has consumed the file once it responded, so it is removed right away.
 	defer func() {
-		err = errors.Join(err, tmp.Close())
+		err = errors.Join(err, tempfile.Release(ctx, tmp))
 	}()
 
-	if err := filesystem.CopyBlobToOSPath(content, tmp.Name()); err != nil {
-		return nil, fmt.Errorf("failed to copy blob to OS path: %w", err)
-	}
-
 	request := ocmrepositoryv1.PostLocalResourceRequest[runtime.Typed]{
 		Repository: c.repositorySpecification,
 		Name:       component,
@@ -86,7 +119,7 @@ func (c *componentVersionRepositoryWrapper) AddLocalResource(ctx context.Context
 		Resource:   &resources[0],
 		ResourceLocation: types.Location{
 			LocationType: types.LocationTypeLocalFile,
-			Value:        tmp.Name(),
+			Value:        tmp,
 		},
 	}
 
@@ -101,6 +134,26 @@ func (c *componentVersionRepositoryWrapper) GetLocalResource(ctx context.Context
 		Identity:   identity,
 	}
 
+	if streaming, ok := c.externalPlugin.(ocmrepositoryv1.StreamingBlobTransfer[runtime.Typed]); ok {
+		download := func() (*ocmrepositoryv1.GetLocalResourceStreamResponse, error) {
+			return streaming.GetLocalResourceStream(ctx, request, c.credentials)
+		}
+		response, err := download()
+		if err == nil {
+			convert := descriptor.ConvertFromV2Resources([]descriptorv2.Resource{*response.Resource})
+			return newStreamedBlob(response.Content, response.MediaType, response.Size, func() (io.ReadCloser, error) {
+				response, err := download()
+				if err != nil {
+					return nil, err
+				}
+				return response.Content, nil
+			}), &convert[0], nil
+		}
+		if !errors.Is(err, ocmerrors.ErrUnsupported) {
+			return nil, nil, err
+		}
+	}
+
 	response, err := c.externalPlugin.GetLocalResource(ctx, request, c.credentials)
 	if err != nil {
 		return nil, nil, err
@@ -122,19 +175,30 @@ func (c *componentVersionRepositoryWrapper) AddLocalSource(ctx context.Context,
 		return nil, fmt.Errorf("failed to convert source: %w", err)
 	}
 
-	tmp, err := os.CreateTemp("", "source")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	if streaming, ok := c.externalPlugin.(ocmrepositoryv1.StreamingBlobTransfer[runtime.Typed]); ok {
+		request := ocmrepositoryv1.PostLocalSourceStreamRequest[runtime.Typed]{
+			Repository: c.repositorySpecification,
+			Name:       component,
+			Version:    version,
+			Source:     &sources[0],
+		}
+		source, err := streamContent(content, func(reader io.Reader) (*descriptor.Source, error) {
+			return streaming.AddLocalSourceStream(ctx, request, reader, c.credentials)
+		})
+		if !errors.Is(err, ocmerrors.ErrUnsupported) {
+			return source, err
+		}
 	}
 
+	tmp, err := writeTempBlob(ctx, content, "source")
+	if err != nil {
+		return nil, err
+	}
+	// the plugin has consumed the file once it responded, so it is removed right away.
 	defer func() {
-		err = errors.Join(err, tmp.Close())
+		err = errors.Join(err, tempfile.Release(ctx, tmp))
 	}()
 
-	if err := filesystem.CopyBlobToOSPath(content, tmp.Name()); err != nil {
-		return nil, fmt.Errorf("failed to copy blob to OS path: %w", err)
-	}
-
 	request := ocmrepositoryv1.PostLocalSourceRequest[runtime.Typed]{
 		Repository: c.repositorySpecification,
 		Name:       component,
@@ -142,7 +206,7 @@ func (c *componentVersionRepositoryWrapper) AddLocalSource(ctx context.Context,
 		Source:     &sources[0],
 		SourceLocation: types.Location{
 			LocationType: types.LocationTypeLocalFile,
-			Value:        tmp.Name(),
+			Value:        tmp,
 		},
 	}
 
@@ -157,6 +221,26 @@ func (c *componentVersionRepositoryWrapper) GetLocalSource(ctx context.Context,
 		Identity:   identity,
 	}
 
+	if streaming, ok := c.externalPlugin.(ocmrepositoryv1.StreamingBlobTransfer[runtime.Typed]); ok {
+		download := func() (*ocmrepositoryv1.GetLocalSourceStreamResponse, error) {
+			return streaming.GetLocalSourceStream(ctx, request, c.credentials)
+		}
+		response, err := download()
+		if err == nil {
+			convert := descriptor.ConvertFromV2Sources([]descriptorv2.Source{*response.Source})
+			return newStreamedBlob(response.Content, response.MediaType, response.Size, func() (io.ReadCloser, error) {
+				response, err := download()
+				if err != nil {
+					return nil, err
+				}
+				return response.Content, nil
+			}), &convert[0], nil
+		}
+		if !errors.Is(err, ocmerrors.ErrUnsupported) {
+			return nil, nil, err
+		}
+	}
+
 	response, err := c.externalPlugin.GetLocalSource(ctx, request, c.credentials)
 	if err != nil {
 		return nil, nil, err
@@ -172,6 +256,97 @@ func (c *componentVersionRepositoryWrapper) GetLocalSource(ctx context.Context,
 	return rBlob, &convert[0], nil
 }
 
+func (c *componentVersionRepositoryWrapper) GarbageCollect(ctx context.Context, opts repository.GarbageCollectOptions) (*repository.GarbageCollectResult, error) {
+	collector, ok := c.externalPlugin.(ocmrepositoryv1.GarbageCollector[runtime.Typed])
+	if !ok {
+		return nil, ocmerrors.Unsupported(fmt.Errorf("garbage collection is not supported by the plugin"))
+	}
+	request := ocmrepositoryv1.GarbageCollectRequest[runtime.Typed]{
+		Repository: c.repositorySpecification,
+		Options:    opts,
+	}
+	return collector.GarbageCollect(ctx, request, c.credentials)
+}
+
+func (c *componentVersionRepositoryWrapper) VerifyIntegrity(ctx context.Context, opts repository.VerifyIntegrityOptions) (*repository.IntegrityReport, error) {
+	verifier, ok := c.externalPlugin.(ocmrepositoryv1.IntegrityVerifier[runtime.Typed])
+	if !ok {
+		return nil, ocmerrors.Unsupported(fmt.Errorf("integrity verification is not supported by the plugin"))
+	}
+	request := ocmrepositoryv1.VerifyIntegrityRequest[runtime.Typed]{
+		Repository: c.repositorySpecification,
+		Options:    opts,
+	}
+	return verifier.VerifyIntegrity(ctx, request, c.credentials)
+}
+
+func (c *componentVersionRepositoryWrapper) Stats(ctx context.Context) (*repository.Stats, error) {
+	provider, ok := c.externalPlugin.(ocmrepositoryv1.StatsProvider[runtime.Typed])
+	if !ok {
+		return nil, ocmerrors.Unsupported(fmt.Errorf("statistics are not supported by the plugin"))
+	}
+	request := ocmrepositoryv1.GetStatsRequest[runtime.Typed]{
+		Repository: c.repositorySpecification,
+	}
+	return provider.GetStats(ctx, request, c.credentials)
+}
+
+// streamContent passes the content of b to upload as a stream.
+func streamContent[T any](b blob.ReadOnlyBlob, upload func(io.Reader) (T, error)) (_ T, err error) {
+	reader, err := b.ReadCloser()
+	if err != nil {
+		return *new(T), fmt.Errorf("failed to open blob: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+
+	return upload(reader)
+}
+
+// streamedBlob is a blob.ReadOnlyBlob with content streamed from a plugin. The first call to ReadCloser
+// returns the content of the initial download, later calls download the content again.
+type streamedBlob struct {
+	mu       sync.Mutex
+	initial  io.ReadCloser
+	download func() (io.ReadCloser, error)
+
+	mediaType string
+	size      int64
+}
+
+var (
+	_ blob.ReadOnlyBlob   = (*streamedBlob)(nil)
+	_ blob.SizeAware      = (*streamedBlob)(nil)
+	_ blob.MediaTypeAware = (*streamedBlob)(nil)
+)
+
+func newStreamedBlob(initial io.ReadCloser, mediaType string, size int64, download func() (io.ReadCloser, error)) *streamedBlob {
+	if size <= 0 {
+		size = blob.SizeUnknown
+	}
+	return &streamedBlob{initial: initial, download: download, mediaType: mediaType, size: size}
+}
+
+func (b *streamedBlob) ReadCloser() (io.ReadCloser, error) {
+	b.mu.Lock()
+	initial := b.initial
+	b.initial = nil
+	b.mu.Unlock()
+	if initial != nil {
+		return initial, nil
+	}
+	return b.download()
+}
+
+func (b *streamedBlob) Size() int64 {
+	return b.size
+}
+
+func (b *streamedBlob) MediaType() (string, bool) {
+	return b.mediaType, b.mediaType != ""
+}
+
 func (r *RepositoryRegistry) externalToComponentVersionRepository(plugin ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[runtime.Typed], scheme *runtime.Scheme, repositorySpecification runtime.Typed, credentials runtime.Typed) *componentVersionRepositoryWrapper {
 	return &componentVersionRepositoryWrapper{
 		externalPlugin:          plugin,
@@ -180,3 +355,20 @@ func (r *RepositoryRegistry) externalToComponentVersionRepository(plugin ocmrepo
 		scheme:                  scheme,
 	}
 }
+
+// writeTempBlob buffers content in a temporary file for plugins that do not support streaming.
+// The file is charged against the quota carried by ctx, see tempfile.WriteTemp.
+func writeTempBlob(ctx context.Context, content blob.ReadOnlyBlob, pattern string) (_ string, err error) {
+	data, err := content.ReadCloser()
+	if err != nil {
+		return "", fmt.Errorf("failed to get blob data: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, data.Close())
+	}()
+	path, err := tempfile.WriteTemp(ctx, "", pattern, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to buffer blob in temp file: %w", err)
+	}
+	return path, nil
+}
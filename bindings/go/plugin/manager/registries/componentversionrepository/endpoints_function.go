@@ -15,6 +15,9 @@ import (
 // used to construct the supported endpoint list to give back to the plugin manager. This information is stored
 // about the plugin and then used for later lookup. The type is also saved with the endpoint, meaning
 // during lookup the right endpoint + type is used.
+// If the handler implements the optional maintenance contracts (GarbageCollector, IntegrityVerifier, StatsProvider),
+// their endpoints are registered and advertised in the capability as well. The same applies to the optional
+// StreamingBlobTransfer, VerifiedComponentVersionGetter and ConsumerIdentityLister contracts.
 func RegisterComponentVersionRepository[T runtime.Typed](
 	proto T,
 	handler ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[T],
@@ -61,6 +64,71 @@ func RegisterComponentVersionRepository[T runtime.Typed](
 		},
 	)
 
+	// Setup handlers for the optional maintenance operations the handler implements.
+	var operations []ocmrepositoryv1.MaintenanceOperation
+	if gc, ok := handler.(ocmrepositoryv1.GarbageCollector[T]); ok {
+		c.Handlers = append(c.Handlers, endpoints.Handler{
+			Handler:  GarbageCollectHandlerFunc(gc.GarbageCollect),
+			Location: GarbageCollect,
+		})
+		operations = append(operations, ocmrepositoryv1.MaintenanceOperationGarbageCollect)
+	}
+	if verifier, ok := handler.(ocmrepositoryv1.IntegrityVerifier[T]); ok {
+		c.Handlers = append(c.Handlers, endpoints.Handler{
+			Handler:  VerifyIntegrityHandlerFunc(verifier.VerifyIntegrity),
+			Location: VerifyIntegrity,
+		})
+		operations = append(operations, ocmrepositoryv1.MaintenanceOperationVerifyIntegrity)
+	}
+	if stats, ok := handler.(ocmrepositoryv1.StatsProvider[T]); ok {
+		c.Handlers = append(c.Handlers, endpoints.Handler{
+			Handler:  GetStatsHandlerFunc(stats.GetStats),
+			Location: Stats,
+		})
+		operations = append(operations, ocmrepositoryv1.MaintenanceOperationStats)
+	}
+
+	// Setup handlers for streaming blob transfer if the handler implements it.
+	streaming, supportsStreaming := handler.(ocmrepositoryv1.StreamingBlobTransfer[T])
+	if supportsStreaming {
+		c.Handlers = append(c.Handlers,
+			endpoints.Handler{
+				Handler:  AddLocalResourceStreamHandlerFunc(streaming.AddLocalResourceStream, c.Scheme),
+				Location: UploadLocalResourceStream,
+			},
+			endpoints.Handler{
+				Handler:  GetLocalResourceStreamHandlerFunc(streaming.GetLocalResourceStream, proto),
+				Location: DownloadLocalResourceStream,
+			},
+			endpoints.Handler{
+				Handler:  AddLocalSourceStreamHandlerFunc(streaming.AddLocalSourceStream, c.Scheme),
+				Location: UploadLocalSourceStream,
+			},
+			endpoints.Handler{
+				Handler:  GetLocalSourceStreamHandlerFunc(streaming.GetLocalSourceStream, proto),
+				Location: DownloadLocalSourceStream,
+			},
+		)
+	}
+
+	// Setup the handler for verification metadata if the handler implements it.
+	verifier, providesVerification := handler.(ocmrepositoryv1.VerifiedComponentVersionGetter[T])
+	if providesVerification {
+		c.Handlers = append(c.Handlers, endpoints.Handler{
+			Handler:  GetVerifiedComponentVersionHandlerFunc(verifier.GetVerifiedComponentVersion, c.Scheme, proto),
+			Location: DownloadVerifiedComponentVersion,
+		})
+	}
+
+	// Setup the handler for listing consumer identities if the handler implements it.
+	lister, listsConsumerIdentities := handler.(ocmrepositoryv1.ConsumerIdentityLister[T])
+	if listsConsumerIdentities {
+		c.Handlers = append(c.Handlers, endpoints.Handler{
+			Handler:  ListConsumerIdentitiesHandlerFunc(lister.ListConsumerIdentities),
+			Location: ListConsumerIdentities,
+		})
+	}
+
 	schema, err := plugins.GenerateJSONSchemaForType(proto)
 	if err != nil {
 		return fmt.Errorf("failed to generate jsonschema for prototype %T: %w", proto, err)
@@ -75,6 +143,10 @@ func RegisterComponentVersionRepository[T runtime.Typed](
 				JSONSchema: schema,
 			},
 		},
+		MaintenanceOperations:   operations,
+		StreamingBlobTransfer:   supportsStreaming,
+		VerificationMetadata:    providesVerification,
+		ConsumerIdentityListing: listsConsumerIdentities,
 	})
 
 	return nil
@@ -17,6 +17,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/contracts"
 	repov1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/endpoints"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -137,7 +138,7 @@ func TestRegisterComponentVersionRepository(t *testing.T) {
 	// Validate registered types
 	content, err := json.Marshal(rawPluginSpec)
 	r.NoError(err)
-	r.Equal(`{"capabilities":[{"supportedRepositorySpecTypes":[{"aliases":null,"jsonSchema":"eyIkc2NoZW1hIjoiaHR0cHM6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQvMjAyMC0xMi9zY2hlbWEiLCIkaWQiOiJodHRwczovL29jbS5zb2Z0d2FyZS9vcGVuLWNvbXBvbmVudC1tb2RlbC9iaW5kaW5ncy9nby9wbHVnaW4vaW50ZXJuYWwvZHVtbXl0eXBlL3YxL3JlcG9zaXRvcnkiLCIkcmVmIjoiIy8kZGVmcy9SZXBvc2l0b3J5IiwiJGRlZnMiOnsiUmVwb3NpdG9yeSI6eyJwcm9wZXJ0aWVzIjp7InR5cGUiOnsidHlwZSI6InN0cmluZyIsInBhdHRlcm4iOiJeKFthLXpBLVowLTldW2EtekEtWjAtOS5dKikoPzovKHZbMC05XSsoPzphbHBoYVswLTldK3xiZXRhWzAtOV0rKT8pKT8ifSwiYmFzZVVybCI6eyJ0eXBlIjoic3RyaW5nIn19LCJhZGRpdGlvbmFsUHJvcGVydGllcyI6ZmFsc2UsInR5cGUiOiJvYmplY3QiLCJyZXF1aXJlZCI6WyJ0eXBlIiwiYmFzZVVybCJdfX19","type":"DummyRepository/v1"}],"type":"componentVersionRepository"}]}`, string(content))
+	r.Equal(`{"capabilities":[{"supportedRepositorySpecTypes":[{"aliases":null,"jsonSchema":"eyIkc2NoZW1hIjoiaHR0cHM6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQvMjAyMC0xMi9zY2hlbWEiLCIkaWQiOiJodHRwczovL29jbS5zb2Z0d2FyZS9vcGVuLWNvbXBvbmVudC1tb2RlbC9iaW5kaW5ncy9nby9wbHVnaW4vaW50ZXJuYWwvZHVtbXl0eXBlL3YxL3JlcG9zaXRvcnkiLCIkcmVmIjoiIy8kZGVmcy9SZXBvc2l0b3J5IiwiJGRlZnMiOnsiUmVwb3NpdG9yeSI6eyJwcm9wZXJ0aWVzIjp7InR5cGUiOnsidHlwZSI6InN0cmluZyIsInBhdHRlcm4iOiJeKFthLXpBLVowLTldW2EtekEtWjAtOS5dKikoPzovKHZbMC05XSsoPzphbHBoYVswLTldK3xiZXRhWzAtOV0rKT8pKT8ifSwiYmFzZVVybCI6eyJ0eXBlIjoic3RyaW5nIn19LCJhZGRpdGlvbmFsUHJvcGVydGllcyI6ZmFsc2UsInR5cGUiOiJvYmplY3QiLCJyZXF1aXJlZCI6WyJ0eXBlIiwiYmFzZVVybCJdfX19","type":"DummyRepository/v1"}],"type":"componentVersionRepository"}],"transports":["http","grpc"]}`, string(content))
 
 	handlers := builder.GetHandlers()
 	r.Len(handlers, 8)
@@ -159,3 +160,36 @@ func TestRegisterComponentVersionRepository(t *testing.T) {
 	r.Equal(UploadLocalSource, handler6.Location)
 	r.Equal(DownloadLocalSource, handler7.Location)
 }
+
+type mockMaintenancePlugin struct {
+	mockPlugin
+}
+
+func (m *mockMaintenancePlugin) GarbageCollect(_ context.Context, _ repov1.GarbageCollectRequest[*dummyv1.Repository], _ runtime.Typed) (*repository.GarbageCollectResult, error) {
+	return &repository.GarbageCollectResult{}, nil
+}
+
+func (m *mockMaintenancePlugin) GetStats(_ context.Context, _ repov1.GetStatsRequest[*dummyv1.Repository], _ runtime.Typed) (*repository.Stats, error) {
+	return &repository.Stats{}, nil
+}
+
+func TestRegisterComponentVersionRepositoryWithMaintenance(t *testing.T) {
+	r := require.New(t)
+
+	scheme := runtime.NewScheme()
+	dummytype.MustAddToScheme(scheme)
+	builder := endpoints.NewEndpoints(scheme)
+	r.NoError(RegisterComponentVersionRepository(&dummyv1.Repository{}, &mockMaintenancePlugin{}, builder))
+
+	r.Len(builder.PluginSpec.CapabilitySpecs, 1)
+	capability, ok := builder.PluginSpec.CapabilitySpecs[0].(*repov1.CapabilitySpec)
+	r.True(ok)
+	r.Equal([]repov1.MaintenanceOperation{repov1.MaintenanceOperationGarbageCollect, repov1.MaintenanceOperationStats}, capability.MaintenanceOperations)
+	r.True(capability.Supports(repov1.MaintenanceOperationStats))
+	r.False(capability.Supports(repov1.MaintenanceOperationVerifyIntegrity))
+
+	handlers := builder.GetHandlers()
+	r.Len(handlers, 10)
+	r.Equal(GarbageCollect, handlers[8].Location)
+	r.Equal(Stats, handlers[9].Location)
+}
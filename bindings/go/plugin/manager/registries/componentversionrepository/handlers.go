@@ -6,12 +6,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -54,6 +57,41 @@ func GetComponentVersionHandlerFunc[T runtime.Typed](f func(ctx context.Context,
 	}
 }
 
+// GetVerifiedComponentVersionHandlerFunc creates an HTTP handler for retrieving component versions together with
+// the metadata of their verification.
+func GetVerifiedComponentVersionHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.GetComponentVersionRequest[T], credentials runtime.Typed) (*descriptor.Descriptor, *repository.Verification, error), scheme *runtime.Scheme, typ T) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+		rawCredentials := []byte(request.Header.Get("Authorization"))
+		credentials := &runtime.Raw{}
+		if err := json.Unmarshal(rawCredentials, credentials); err != nil {
+			plugins.NewError(fmt.Errorf("incorrect authentication header format: %w", err), http.StatusUnauthorized).Write(writer)
+			return
+		}
+
+		desc, verification, err := f(request.Context(), v1.GetComponentVersionRequest[T]{
+			Repository: typ,
+			Name:       query.Get("name"),
+			Version:    query.Get("version"),
+		}, credentials)
+		if err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+
+		descV2, err := descriptor.ConvertToV2(scheme, desc)
+		if err != nil {
+			plugins.NewError(fmt.Errorf("failed to convert to v2 descriptor: %w", err), http.StatusInternalServerError).Write(writer)
+			return
+		}
+
+		if err := json.NewEncoder(writer).Encode(v1.GetVerifiedComponentVersionResponse{Descriptor: descV2, Verification: verification}); err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+	}
+}
+
 // ListComponentVersionsHandlerFunc is a wrapper around calling the interface method ListComponentVersions for the plugin.
 // This is a convenience wrapper containing header and query parameter parsing logic that is not important to know for
 // the plugin implementor.
@@ -219,6 +257,29 @@ func GetIdentityHandlerFunc[T runtime.Typed](f func(ctx context.Context, typ *v1
 	}
 }
 
+// ListConsumerIdentitiesHandlerFunc creates an HTTP handler for listing the consumer identities the plugin may
+// require credentials for. Listing them does not require credentials.
+func ListConsumerIdentitiesHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.ListConsumerIdentitiesRequest[T]) (*v1.ListConsumerIdentitiesResponse, error)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		body, err := plugins.DecodeJSONRequestBody[v1.ListConsumerIdentitiesRequest[T]](writer, request)
+		if err != nil {
+			slog.Error("failed to decode request body", "error", err)
+			return
+		}
+
+		response, err := f(request.Context(), *body)
+		if err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+
+		if err := json.NewEncoder(writer).Encode(response); err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+	}
+}
+
 // AddLocalResourceHandlerFunc creates an HTTP handler for adding local resources.
 // It handles authentication, request body parsing, and resource conversion for the plugin implementation.
 func AddLocalResourceHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.PostLocalResourceRequest[T], credentials runtime.Typed) (*descriptor.Resource, error), scheme *runtime.Scheme) http.HandlerFunc {
@@ -302,3 +363,249 @@ func AddLocalSourceHandlerFunc[T runtime.Typed](f func(ctx context.Context, requ
 		}
 	}
 }
+
+// GarbageCollectHandlerFunc creates an HTTP handler for garbage collection in the repository.
+func GarbageCollectHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.GarbageCollectRequest[T], credentials runtime.Typed) (*repository.GarbageCollectResult, error)) http.HandlerFunc {
+	return maintenanceHandlerFunc(f)
+}
+
+// VerifyIntegrityHandlerFunc creates an HTTP handler for verifying the integrity of the repository.
+func VerifyIntegrityHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.VerifyIntegrityRequest[T], credentials runtime.Typed) (*repository.IntegrityReport, error)) http.HandlerFunc {
+	return maintenanceHandlerFunc(f)
+}
+
+// GetStatsHandlerFunc creates an HTTP handler for getting statistics of the repository.
+func GetStatsHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.GetStatsRequest[T], credentials runtime.Typed) (*repository.Stats, error)) http.HandlerFunc {
+	return maintenanceHandlerFunc(f)
+}
+
+// maintenanceHandlerFunc handles authentication, request body parsing and response encoding of maintenance operations.
+func maintenanceHandlerFunc[Request, Response any](f func(ctx context.Context, request Request, credentials runtime.Typed) (Response, error)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		rawCredentials := []byte(request.Header.Get("Authorization"))
+		credentials := &runtime.Raw{}
+		if err := json.Unmarshal(rawCredentials, credentials); err != nil {
+			plugins.NewError(fmt.Errorf("incorrect authentication header format: %w", err), http.StatusUnauthorized).Write(writer)
+			return
+		}
+
+		body, err := plugins.DecodeJSONRequestBody[Request](writer, request)
+		if err != nil {
+			slog.Error("failed to decode request body", "error", err)
+			return
+		}
+
+		response, err := f(request.Context(), *body, credentials)
+		if err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+
+		if err := json.NewEncoder(writer).Encode(response); err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+	}
+}
+
+// StreamMetadataHeader carries the base64 encoded JSON request of a streaming upload and the JSON response
+// of a streaming download (without the content), as the body of these calls is the content itself.
+const StreamMetadataHeader = "Ocm-Stream-Metadata"
+
+// AddLocalResourceStreamHandlerFunc creates an HTTP handler for adding local resources with their content
+// streamed in the request body.
+func AddLocalResourceStreamHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.PostLocalResourceStreamRequest[T], content io.Reader, credentials runtime.Typed) (*descriptor.Resource, error), scheme *runtime.Scheme) http.HandlerFunc {
+	return uploadStreamHandlerFunc(f, func(resource *descriptor.Resource) (any, error) {
+		resourceV2, err := descriptor.ConvertToV2Resources(scheme, []descriptor.Resource{*resource})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert to v2 resource: %w", err)
+		}
+		if len(resourceV2) == 0 {
+			return nil, errors.New("no resources returned during conversion")
+		}
+		return resourceV2[0], nil
+	})
+}
+
+// AddLocalSourceStreamHandlerFunc creates an HTTP handler for adding local sources with their content
+// streamed in the request body.
+func AddLocalSourceStreamHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.PostLocalSourceStreamRequest[T], content io.Reader, credentials runtime.Typed) (*descriptor.Source, error), scheme *runtime.Scheme) http.HandlerFunc {
+	return uploadStreamHandlerFunc(f, func(source *descriptor.Source) (any, error) {
+		sourceV2, err := descriptor.ConvertToV2Sources(scheme, []descriptor.Source{*source})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert to v2 source: %w", err)
+		}
+		if len(sourceV2) == 0 {
+			return nil, errors.New("no sources returned during conversion")
+		}
+		return sourceV2[0], nil
+	})
+}
+
+// GetLocalResourceStreamHandlerFunc creates an HTTP handler for retrieving local resources with their content
+// streamed in the response body.
+func GetLocalResourceStreamHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.GetLocalResourceRequest[T], credentials runtime.Typed) (*v1.GetLocalResourceStreamResponse, error), proto T) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		credentials, name, version, identity, ok := parseDownloadStreamRequest(writer, request)
+		if !ok {
+			return
+		}
+
+		response, err := f(request.Context(), v1.GetLocalResourceRequest[T]{
+			Repository: proto,
+			Name:       name,
+			Version:    version,
+			Identity:   identity,
+		}, credentials)
+		if err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+
+		writeStream(request.Context(), writer, response, response.MediaType, response.Size, response.Content)
+	}
+}
+
+// GetLocalSourceStreamHandlerFunc creates an HTTP handler for retrieving local sources with their content
+// streamed in the response body.
+func GetLocalSourceStreamHandlerFunc[T runtime.Typed](f func(ctx context.Context, request v1.GetLocalSourceRequest[T], credentials runtime.Typed) (*v1.GetLocalSourceStreamResponse, error), proto T) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		credentials, name, version, identity, ok := parseDownloadStreamRequest(writer, request)
+		if !ok {
+			return
+		}
+
+		response, err := f(request.Context(), v1.GetLocalSourceRequest[T]{
+			Repository: proto,
+			Name:       name,
+			Version:    version,
+			Identity:   identity,
+		}, credentials)
+		if err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+
+		writeStream(request.Context(), writer, response, response.MediaType, response.Size, response.Content)
+	}
+}
+
+// uploadStreamHandlerFunc handles authentication and request decoding of streaming uploads
+// and encodes the element returned by f with convert.
+func uploadStreamHandlerFunc[Request, Element any](f func(ctx context.Context, request Request, content io.Reader, credentials runtime.Typed) (Element, error), convert func(Element) (any, error)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		rawCredentials := []byte(request.Header.Get("Authorization"))
+		credentials := &runtime.Raw{}
+		if err := json.Unmarshal(rawCredentials, credentials); err != nil {
+			plugins.NewError(fmt.Errorf("incorrect authentication header format: %w", err), http.StatusUnauthorized).Write(writer)
+			return
+		}
+
+		var body Request
+		if err := decodeStreamMetadata(request.Header.Get(StreamMetadataHeader), &body); err != nil {
+			plugins.NewError(err, http.StatusBadRequest).Write(writer)
+			return
+		}
+
+		element, err := f(request.Context(), body, request.Body, credentials)
+		if err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+
+		response, err := convert(element)
+		if err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+
+		if err := json.NewEncoder(writer).Encode(response); err != nil {
+			plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+			return
+		}
+	}
+}
+
+// parseDownloadStreamRequest parses the credentials and query parameters of a streaming download.
+// It writes the error response and returns false if the request is invalid.
+func parseDownloadStreamRequest(writer http.ResponseWriter, request *http.Request) (_ runtime.Typed, name, version string, identity map[string]string, _ bool) {
+	rawCredentials := []byte(request.Header.Get("Authorization"))
+	credentials := &runtime.Raw{}
+	if err := json.Unmarshal(rawCredentials, credentials); err != nil {
+		plugins.NewError(fmt.Errorf("incorrect authentication header format: %w", err), http.StatusUnauthorized).Write(writer)
+		return nil, "", "", nil, false
+	}
+
+	query := request.URL.Query()
+	identity = map[string]string{}
+	if identityQuery := query.Get("identity"); identityQuery != "" {
+		decodedIdentity, err := base64.StdEncoding.DecodeString(identityQuery)
+		if err != nil {
+			plugins.NewError(err, http.StatusBadRequest).Write(writer)
+			return nil, "", "", nil, false
+		}
+		if err := json.Unmarshal(decodedIdentity, &identity); err != nil {
+			plugins.NewError(err, http.StatusBadRequest).Write(writer)
+			return nil, "", "", nil, false
+		}
+	}
+
+	return credentials, query.Get("name"), query.Get("version"), identity, true
+}
+
+// writeStream writes metadata to the StreamMetadataHeader and copies content into the response body.
+// As the status is already sent once copying started, a failed copy aborts the response,
+// so that the caller sees an incomplete body instead of truncated content.
+func writeStream(ctx context.Context, writer http.ResponseWriter, metadata any, mediaType string, size int64, content io.ReadCloser) {
+	if content == nil {
+		plugins.NewError(errors.New("plugin returned no content"), http.StatusInternalServerError).Write(writer)
+		return
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.ErrorContext(ctx, "failed to close streamed content", "error", err)
+		}
+	}()
+
+	encoded, err := encodeStreamMetadata(metadata)
+	if err != nil {
+		plugins.NewError(err, http.StatusInternalServerError).Write(writer)
+		return
+	}
+	writer.Header().Set(StreamMetadataHeader, encoded)
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+	writer.Header().Set("Content-Type", mediaType)
+	if size > 0 {
+		writer.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	writer.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(writer, content); err != nil {
+		slog.ErrorContext(ctx, "failed to stream content", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func encodeStreamMetadata(metadata any) (string, error) {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode stream metadata: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func decodeStreamMetadata(header string, metadata any) error {
+	if header == "" {
+		return fmt.Errorf("missing %s header", StreamMetadataHeader)
+	}
+	data, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return fmt.Errorf("failed to decode stream metadata: %w", err)
+	}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return fmt.Errorf("failed to decode stream metadata: %w", err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -14,7 +15,9 @@ import (
 	ocmrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // Endpoints
@@ -31,12 +34,30 @@ const (
 	UploadComponentVersion = "/component-version/upload"
 	// DownloadComponentVersion defines the endpoint to download component versions.
 	DownloadComponentVersion = "/component-version/download"
+	// DownloadVerifiedComponentVersion defines the endpoint to download component versions with their verification metadata.
+	DownloadVerifiedComponentVersion = "/component-version/download-verified"
 	// ListComponentVersions defines the endpoint to list component versions.
 	ListComponentVersions = "/component-versions"
+	// ListConsumerIdentities defines the endpoint to list the consumer identities the plugin may require credentials for.
+	ListConsumerIdentities = "/consumer-identities"
 	// Identity defines the endpoint to retrieve credential consumer identity.
 	Identity = "/identity"
 	// CheckHealth defines the endpoint to check the health of a component version repository.
 	CheckHealth = "/component-version/check-health"
+	// GarbageCollect defines the endpoint to collect garbage in a component version repository.
+	GarbageCollect = "/maintenance/garbage-collect"
+	// VerifyIntegrity defines the endpoint to verify the integrity of a component version repository.
+	VerifyIntegrity = "/maintenance/verify-integrity"
+	// Stats defines the endpoint to get statistics of a component version repository.
+	Stats = "/maintenance/stats"
+	// UploadLocalResourceStream defines the endpoint to upload a local resource with its content in the request body.
+	UploadLocalResourceStream = "/local-resource/upload-stream"
+	// DownloadLocalResourceStream defines the endpoint to download a local resource with its content in the response body.
+	DownloadLocalResourceStream = "/local-resource/download-stream"
+	// UploadLocalSourceStream defines the endpoint to upload a local source with its content in the request body.
+	UploadLocalSourceStream = "/local-source/upload-stream"
+	// DownloadLocalSourceStream defines the endpoint to download a local source with its content in the response body.
+	DownloadLocalSourceStream = "/local-source/download-stream"
 )
 
 // RepositoryPlugin implements the ReadWriteOCMRepositoryPluginContract for external plugin communication.
@@ -58,6 +79,12 @@ type RepositoryPlugin struct {
 // This plugin implements all the given contracts.
 var (
 	_ ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[runtime.Typed] = &RepositoryPlugin{}
+	_ ocmrepositoryv1.GarbageCollector[runtime.Typed]                     = &RepositoryPlugin{}
+	_ ocmrepositoryv1.IntegrityVerifier[runtime.Typed]                    = &RepositoryPlugin{}
+	_ ocmrepositoryv1.StatsProvider[runtime.Typed]                        = &RepositoryPlugin{}
+	_ ocmrepositoryv1.StreamingBlobTransfer[runtime.Typed]                = &RepositoryPlugin{}
+	_ ocmrepositoryv1.VerifiedComponentVersionGetter[runtime.Typed]       = &RepositoryPlugin{}
+	_ ocmrepositoryv1.ConsumerIdentityLister[runtime.Typed]               = &RepositoryPlugin{}
 )
 
 // NewComponentVersionRepositoryPlugin creates a new component version repository plugin instance with the provided configuration.
@@ -132,6 +159,44 @@ func (r *RepositoryPlugin) GetComponentVersion(ctx context.Context, request ocmr
 	return desc, nil
 }
 
+// GetVerifiedComponentVersion gets a component version with its verification metadata from the plugin.
+// It fails with an error matching errors.ErrUnsupported if the plugin does not provide verification metadata.
+func (r *RepositoryPlugin) GetVerifiedComponentVersion(ctx context.Context, request ocmrepositoryv1.GetComponentVersionRequest[runtime.Typed], credentials runtime.Typed) (*descriptor.Descriptor, *repository.Verification, error) {
+	if !r.capability.VerificationMetadata {
+		return nil, nil, ocmerrors.Unsupported(fmt.Errorf("plugin %q does not provide verification metadata", r.ID))
+	}
+
+	params := []plugins.KV{
+		{Key: "name", Value: request.Name},
+		{Key: "version", Value: request.Version},
+	}
+
+	credHeader, err := toCredentials(credentials)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// We know we only have this single schema for all endpoints which require validation.
+	if err := r.validateEndpoint(request.Repository); err != nil {
+		return nil, nil, err
+	}
+
+	response := &ocmrepositoryv1.GetVerifiedComponentVersionResponse{}
+	if err := plugins.Call(ctx, r.client, r.config.Type, r.location, DownloadVerifiedComponentVersion, http.MethodGet, plugins.WithResult(response), plugins.WithQueryParams(params), plugins.WithHeader(credHeader)); err != nil {
+		return nil, nil, fmt.Errorf("failed to get verified component version %s:%s from %s: %w", request.Name, request.Version, r.ID, err)
+	}
+	if response.Descriptor == nil {
+		return nil, nil, fmt.Errorf("plugin %q returned no descriptor for component version %s:%s", r.ID, request.Name, request.Version)
+	}
+
+	desc, err := descriptor.ConvertFromV2(response.Descriptor)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert component version descriptor: %w", err)
+	}
+
+	return desc, response.Verification, nil
+}
+
 func (r *RepositoryPlugin) ListComponentVersions(ctx context.Context, request ocmrepositoryv1.ListComponentVersionsRequest[runtime.Typed], credentials runtime.Typed) ([]string, error) {
 	var params []plugins.KV
 	addParam := func(k, v string) {
@@ -278,10 +343,31 @@ func (r *RepositoryPlugin) GetIdentity(ctx context.Context, request *ocmreposito
 	if err := plugins.Call(ctx, r.client, r.config.Type, r.location, Identity, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(&identity)); err != nil {
 		return nil, fmt.Errorf("failed to get identity from plugin %q: %w", r.ID, err)
 	}
+	plugins.AllowCredentialRefresh(r.client, identity.Identity)
 
 	return &identity, nil
 }
 
+// ListConsumerIdentities lists the consumer identities the plugin may require credentials for.
+// It fails with an error matching errors.ErrUnsupported if the plugin does not support listing them.
+func (r *RepositoryPlugin) ListConsumerIdentities(ctx context.Context, request ocmrepositoryv1.ListConsumerIdentitiesRequest[runtime.Typed]) (*ocmrepositoryv1.ListConsumerIdentitiesResponse, error) {
+	if !r.capability.ConsumerIdentityListing {
+		return nil, ocmerrors.Unsupported(fmt.Errorf("plugin %q does not support listing consumer identities", r.ID))
+	}
+
+	// We know we only have this single schema for all endpoints which require validation.
+	if err := r.validateEndpoint(request.Repository); err != nil {
+		return nil, err
+	}
+
+	response := &ocmrepositoryv1.ListConsumerIdentitiesResponse{}
+	if err := plugins.Call(ctx, r.client, r.config.Type, r.location, ListConsumerIdentities, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(response)); err != nil {
+		return nil, fmt.Errorf("failed to list consumer identities with plugin %q: %w", r.ID, err)
+	}
+
+	return response, nil
+}
+
 func (r *RepositoryPlugin) CheckHealth(ctx context.Context, request ocmrepositoryv1.PostCheckHealthRequest[runtime.Typed], credentials runtime.Typed) error {
 	credHeader, err := toCredentials(credentials)
 	if err != nil {
@@ -300,6 +386,183 @@ func (r *RepositoryPlugin) CheckHealth(ctx context.Context, request ocmrepositor
 	return nil
 }
 
+// GarbageCollect collects garbage with the plugin. It fails with an error matching errors.ErrUnsupported
+// if the plugin does not advertise the operation.
+func (r *RepositoryPlugin) GarbageCollect(ctx context.Context, request ocmrepositoryv1.GarbageCollectRequest[runtime.Typed], credentials runtime.Typed) (*repository.GarbageCollectResult, error) {
+	result := &repository.GarbageCollectResult{}
+	if err := r.callMaintenance(ctx, ocmrepositoryv1.MaintenanceOperationGarbageCollect, GarbageCollect, request.Repository, request, credentials, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// VerifyIntegrity verifies the integrity of the repository with the plugin. It fails with an error matching
+// errors.ErrUnsupported if the plugin does not advertise the operation.
+func (r *RepositoryPlugin) VerifyIntegrity(ctx context.Context, request ocmrepositoryv1.VerifyIntegrityRequest[runtime.Typed], credentials runtime.Typed) (*repository.IntegrityReport, error) {
+	report := &repository.IntegrityReport{}
+	if err := r.callMaintenance(ctx, ocmrepositoryv1.MaintenanceOperationVerifyIntegrity, VerifyIntegrity, request.Repository, request, credentials, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetStats gets statistics of the repository from the plugin. It fails with an error matching
+// errors.ErrUnsupported if the plugin does not advertise the operation.
+func (r *RepositoryPlugin) GetStats(ctx context.Context, request ocmrepositoryv1.GetStatsRequest[runtime.Typed], credentials runtime.Typed) (*repository.Stats, error) {
+	stats := &repository.Stats{}
+	if err := r.callMaintenance(ctx, ocmrepositoryv1.MaintenanceOperationStats, Stats, request.Repository, request, credentials, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *RepositoryPlugin) callMaintenance(ctx context.Context, operation ocmrepositoryv1.MaintenanceOperation, endpoint string, repositorySpecification runtime.Typed, request any, credentials runtime.Typed, result any) error {
+	if !r.capability.Supports(operation) {
+		return ocmerrors.Unsupported(fmt.Errorf("plugin %q does not support maintenance operation %q", r.ID, operation))
+	}
+
+	credHeader, err := toCredentials(credentials)
+	if err != nil {
+		return err
+	}
+
+	// We know we only have this single schema for all endpoints which require validation.
+	if err := r.validateEndpoint(repositorySpecification); err != nil {
+		return err
+	}
+
+	if err := plugins.Call(ctx, r.client, r.config.Type, r.location, endpoint, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(result), plugins.WithHeader(credHeader)); err != nil {
+		return fmt.Errorf("failed to run maintenance operation %q with plugin %q: %w", operation, r.ID, err)
+	}
+
+	return nil
+}
+
+// AddLocalResourceStream adds a local resource with the plugin, streaming the content in the request body.
+// It fails with an error matching errors.ErrUnsupported if the plugin does not support streaming blob transfer.
+func (r *RepositoryPlugin) AddLocalResourceStream(ctx context.Context, request ocmrepositoryv1.PostLocalResourceStreamRequest[runtime.Typed], content io.Reader, credentials runtime.Typed) (*descriptor.Resource, error) {
+	resourceV2 := &v2.Resource{}
+	if err := r.uploadStream(ctx, UploadLocalResourceStream, request.Repository, request, content, credentials, resourceV2); err != nil {
+		return nil, fmt.Errorf("failed to add local resource %s: %w", r.ID, err)
+	}
+
+	resources := descriptor.ConvertFromV2Resources([]v2.Resource{*resourceV2})
+	if len(resources) == 0 {
+		return nil, errors.New("number of converted resources is zero")
+	}
+
+	return &resources[0], nil
+}
+
+// GetLocalResourceStream gets a local resource from the plugin, streaming the content in the response body.
+// It fails with an error matching errors.ErrUnsupported if the plugin does not support streaming blob transfer.
+func (r *RepositoryPlugin) GetLocalResourceStream(ctx context.Context, request ocmrepositoryv1.GetLocalResourceRequest[runtime.Typed], credentials runtime.Typed) (*ocmrepositoryv1.GetLocalResourceStreamResponse, error) {
+	response := &ocmrepositoryv1.GetLocalResourceStreamResponse{}
+	content, size, err := r.downloadStream(ctx, DownloadLocalResourceStream, request.Repository, request.Name, request.Version, request.Identity, credentials, response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local resource %s:%s from %s: %w", request.Name, request.Version, r.ID, err)
+	}
+	response.Content, response.Size = content, size
+
+	return response, nil
+}
+
+// AddLocalSourceStream adds a local source with the plugin, streaming the content in the request body.
+// It fails with an error matching errors.ErrUnsupported if the plugin does not support streaming blob transfer.
+func (r *RepositoryPlugin) AddLocalSourceStream(ctx context.Context, request ocmrepositoryv1.PostLocalSourceStreamRequest[runtime.Typed], content io.Reader, credentials runtime.Typed) (*descriptor.Source, error) {
+	sourceV2 := &v2.Source{}
+	if err := r.uploadStream(ctx, UploadLocalSourceStream, request.Repository, request, content, credentials, sourceV2); err != nil {
+		return nil, fmt.Errorf("failed to add local source %s: %w", r.ID, err)
+	}
+
+	sources := descriptor.ConvertFromV2Sources([]v2.Source{*sourceV2})
+	if len(sources) == 0 {
+		return nil, errors.New("number of converted sources is zero")
+	}
+
+	return &sources[0], nil
+}
+
+// GetLocalSourceStream gets a local source from the plugin, streaming the content in the response body.
+// It fails with an error matching errors.ErrUnsupported if the plugin does not support streaming blob transfer.
+func (r *RepositoryPlugin) GetLocalSourceStream(ctx context.Context, request ocmrepositoryv1.GetLocalSourceRequest[runtime.Typed], credentials runtime.Typed) (*ocmrepositoryv1.GetLocalSourceStreamResponse, error) {
+	response := &ocmrepositoryv1.GetLocalSourceStreamResponse{}
+	content, size, err := r.downloadStream(ctx, DownloadLocalSourceStream, request.Repository, request.Name, request.Version, request.Identity, credentials, response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local source %s:%s from %s: %w", request.Name, request.Version, r.ID, err)
+	}
+	response.Content, response.Size = content, size
+
+	return response, nil
+}
+
+// uploadStream sends request in the StreamMetadataHeader and content as the body of the call.
+func (r *RepositoryPlugin) uploadStream(ctx context.Context, endpoint string, repositorySpecification runtime.Typed, request any, content io.Reader, credentials runtime.Typed, result any) error {
+	if !r.capability.StreamingBlobTransfer {
+		return ocmerrors.Unsupported(fmt.Errorf("plugin %q does not support streaming blob transfer", r.ID))
+	}
+
+	credHeader, err := toCredentials(credentials)
+	if err != nil {
+		return err
+	}
+
+	// We know we only have this single schema for all endpoints which require validation.
+	if err := r.validateEndpoint(repositorySpecification); err != nil {
+		return err
+	}
+
+	metadata, err := encodeStreamMetadata(request)
+	if err != nil {
+		return err
+	}
+
+	return plugins.Call(ctx, r.client, r.config.Type, r.location, endpoint, http.MethodPost,
+		plugins.WithBody(content),
+		plugins.WithResult(result),
+		plugins.WithHeader(credHeader),
+		plugins.WithHeader(plugins.KV{Key: StreamMetadataHeader, Value: metadata}),
+	)
+}
+
+// downloadStream decodes the StreamMetadataHeader of the response into metadata and returns its body
+// and its size, which is -1 if unknown.
+func (r *RepositoryPlugin) downloadStream(ctx context.Context, endpoint string, repositorySpecification runtime.Typed, name, version string, identity map[string]string, credentials runtime.Typed, metadata any) (io.ReadCloser, int64, error) {
+	if !r.capability.StreamingBlobTransfer {
+		return nil, 0, ocmerrors.Unsupported(fmt.Errorf("plugin %q does not support streaming blob transfer", r.ID))
+	}
+
+	identityEncoded, err := json.Marshal(identity)
+	if err != nil {
+		return nil, 0, err
+	}
+	params := []plugins.KV{
+		{Key: "name", Value: name},
+		{Key: "version", Value: version},
+		{Key: "identity", Value: base64.StdEncoding.EncodeToString(identityEncoded)},
+	}
+
+	credHeader, err := toCredentials(credentials)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// We know we only have this single schema for all endpoints which require validation.
+	if err := r.validateEndpoint(repositorySpecification); err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := plugins.CallStream(ctx, r.client, r.config.Type, r.location, endpoint, http.MethodGet, plugins.WithQueryParams(params), plugins.WithHeader(credHeader))
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := decodeStreamMetadata(resp.Header.Get(StreamMetadataHeader), metadata); err != nil {
+		return nil, 0, errors.Join(err, resp.Body.Close())
+	}
+
+	return resp.Body, resp.ContentLength, nil
+}
+
 // validateEndpoint uses the provided JSON schema and the runtime.Typed and, using the JSON schema, validates that the
 // underlying runtime.Type conforms to the provided schema.
 // TODO(fabianburth): this method looks essentially the same for all plugin make it reusable!
@@ -1,22 +1,32 @@
 package componentversionrepository
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/invopop/jsonschema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	dummyv1 "ocm.software/open-component-model/bindings/go/plugin/internal/dummytype/v1"
 
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	repov1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 func TestPing(t *testing.T) {
@@ -378,3 +388,212 @@ func defaultDescriptor() *v2.Descriptor {
 		},
 	}
 }
+
+func TestGarbageCollect(t *testing.T) {
+	r := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == GarbageCollect && req.Method == http.MethodPost {
+			request := repov1.GarbageCollectRequest[*runtime.Raw]{}
+			r.NoError(json.NewDecoder(req.Body).Decode(&request))
+			r.True(request.Options.DryRun)
+			r.NoError(json.NewEncoder(w).Encode(repository.GarbageCollectResult{Removed: []string{"sha256:abc"}, ReclaimedBytes: 42}))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	config := types.Config{
+		ID:         "test-plugin",
+		Type:       types.TCP,
+		PluginType: repov1.ComponentVersionRepositoryPluginType,
+	}
+	request := repov1.GarbageCollectRequest[runtime.Typed]{
+		Repository: &runtime.Raw{Type: dummyType, Data: []byte(`{"baseUrl":"ocm.software"}`)},
+		Options:    repository.GarbageCollectOptions{DryRun: true},
+	}
+
+	t.Run("supported", func(t *testing.T) {
+		capability := dummyCapability([]byte(`{}`))
+		capability.MaintenanceOperations = []repov1.MaintenanceOperation{repov1.MaintenanceOperationGarbageCollect}
+		plugin := NewComponentVersionRepositoryPlugin(server.Client(), "test-plugin", server.URL, config, server.URL, capability)
+
+		result, err := plugin.GarbageCollect(t.Context(), request, nil)
+		require.NoError(t, err)
+		require.Equal(t, &repository.GarbageCollectResult{Removed: []string{"sha256:abc"}, ReclaimedBytes: 42}, result)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		plugin := NewComponentVersionRepositoryPlugin(server.Client(), "test-plugin", server.URL, config, server.URL, dummyCapability([]byte(`{}`)))
+
+		_, err := plugin.GarbageCollect(t.Context(), request, nil)
+		require.ErrorIs(t, err, ocmerrors.ErrUnsupported)
+	})
+}
+
+func TestStreamingBlobTransfer(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	stored := map[string][]byte{}
+	mux := http.NewServeMux()
+	mux.Handle(UploadLocalResourceStream, AddLocalResourceStreamHandlerFunc(func(_ context.Context, request repov1.PostLocalResourceStreamRequest[*dummyv1.Repository], content io.Reader, _ runtime.Typed) (*descriptor.Resource, error) {
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return nil, err
+		}
+		stored[request.Resource.Name] = data
+		resources := descriptor.ConvertFromV2Resources([]v2.Resource{*request.Resource})
+		return &resources[0], nil
+	}, runtime.NewScheme()))
+	mux.Handle(DownloadLocalResourceStream, GetLocalResourceStreamHandlerFunc(func(_ context.Context, request repov1.GetLocalResourceRequest[*dummyv1.Repository], _ runtime.Typed) (*repov1.GetLocalResourceStreamResponse, error) {
+		data, ok := stored[request.Identity["name"]]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return &repov1.GetLocalResourceStreamResponse{
+			Resource:  &v2.Resource{ElementMeta: v2.ElementMeta{ObjectMeta: v2.ObjectMeta{Name: request.Identity["name"], Version: "v1.0.0"}}, Type: "blob", Relation: "local", Access: &runtime.Raw{Type: runtime.NewVersionedType("localBlob", "v1"), Data: []byte(`{"type":"localBlob/v1"}`)}},
+			MediaType: "application/octet-stream",
+			Size:      int64(len(data)),
+			Content:   io.NopCloser(bytes.NewReader(data)),
+		}, nil
+	}, &dummyv1.Repository{}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := types.Config{
+		ID:         "test-plugin",
+		Type:       types.TCP,
+		PluginType: repov1.ComponentVersionRepositoryPluginType,
+	}
+	capability := dummyCapability([]byte(`{}`))
+	capability.StreamingBlobTransfer = true
+	plugin := NewComponentVersionRepositoryPlugin(server.Client(), "test-plugin", server.URL, config, server.URL, capability)
+	repo := (&RepositoryRegistry{}).externalToComponentVersionRepository(plugin, runtime.NewScheme(), &runtime.Raw{Type: dummyType, Data: []byte(`{}`)}, nil)
+
+	resource := &descriptor.Resource{
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "large", Version: "v1.0.0"}},
+		Type:        "blob",
+		Relation:    descriptor.LocalRelation,
+		Access:      &runtime.Raw{Type: runtime.NewVersionedType("localBlob", "v1"), Data: []byte(`{"type":"localBlob/v1","localReference":"sha256:abc","mediaType":"application/octet-stream"}`)},
+	}
+	added, err := repo.AddLocalResource(ctx, "ocm.software/test", "v1.0.0", resource, inmemory.New(strings.NewReader("streamed content")))
+	r.NoError(err)
+	r.Equal("large", added.Name)
+	r.Equal("streamed content", string(stored["large"]))
+
+	b, res, err := repo.GetLocalResource(ctx, "ocm.software/test", "v1.0.0", runtime.Identity{"name": "large"})
+	r.NoError(err)
+	r.Equal("large", res.Name)
+	r.EqualValues(len("streamed content"), b.(blob.SizeAware).Size())
+	// the blob can be read more than once, later reads download the content again.
+	for range 2 {
+		rc, err := b.ReadCloser()
+		r.NoError(err)
+		data, err := io.ReadAll(rc)
+		r.NoError(err)
+		r.NoError(rc.Close())
+		r.Equal("streamed content", string(data))
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		plugin := NewComponentVersionRepositoryPlugin(server.Client(), "test-plugin", server.URL, config, server.URL, dummyCapability([]byte(`{}`)))
+		_, err := plugin.GetLocalResourceStream(t.Context(), repov1.GetLocalResourceRequest[runtime.Typed]{
+			Repository: &runtime.Raw{Type: dummyType, Data: []byte(`{}`)},
+		}, nil)
+		require.ErrorIs(t, err, ocmerrors.ErrUnsupported)
+	})
+}
+
+func TestGetVerifiedComponentVersion(t *testing.T) {
+	r := require.New(t)
+	verifiedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	verification := &repository.Verification{
+		Signatures: []repository.SignatureVerification{{
+			Name:      "signature",
+			Algorithm: "RSASSA-PSS",
+			Issuer:    "CN=ocm.software",
+			Digest:    v2.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v4alpha1", Value: "abc"},
+		}},
+		VerifiedAt: verifiedAt,
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(DownloadVerifiedComponentVersion, GetVerifiedComponentVersionHandlerFunc(func(_ context.Context, request repov1.GetComponentVersionRequest[*dummyv1.Repository], _ runtime.Typed) (*descriptor.Descriptor, *repository.Verification, error) {
+		return &descriptor.Descriptor{
+			Component: descriptor.Component{
+				ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: request.Name, Version: request.Version}},
+				Provider:      descriptor.Provider{Name: "ocm.software"},
+			},
+		}, verification, nil
+	}, runtime.NewScheme(), &dummyv1.Repository{}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := types.Config{
+		ID:         "test-plugin",
+		Type:       types.TCP,
+		PluginType: repov1.ComponentVersionRepositoryPluginType,
+	}
+	capability := dummyCapability([]byte(`{}`))
+	capability.VerificationMetadata = true
+	plugin := NewComponentVersionRepositoryPlugin(server.Client(), "test-plugin", server.URL, config, server.URL, capability)
+	repo := (&RepositoryRegistry{}).externalToComponentVersionRepository(plugin, runtime.NewScheme(), &runtime.Raw{Type: dummyType, Data: []byte(`{}`)}, nil)
+
+	desc, result, err := repo.GetVerifiedComponentVersion(t.Context(), "ocm.software/test", "v1.0.0")
+	r.NoError(err)
+	r.Equal("ocm.software/test", desc.Component.Name)
+	r.Equal("v1.0.0", desc.Component.Version)
+	r.Equal(verification, result)
+
+	t.Run("unsupported", func(t *testing.T) {
+		plugin := NewComponentVersionRepositoryPlugin(server.Client(), "test-plugin", server.URL, config, server.URL, dummyCapability([]byte(`{}`)))
+		_, _, err := plugin.GetVerifiedComponentVersion(t.Context(), repov1.GetComponentVersionRequest[runtime.Typed]{
+			Repository: &runtime.Raw{Type: dummyType, Data: []byte(`{}`)},
+		}, nil)
+		require.ErrorIs(t, err, ocmerrors.ErrUnsupported)
+	})
+}
+
+func TestListConsumerIdentities(t *testing.T) {
+	r := require.New(t)
+	identities := []repov1.ConsumerIdentity{
+		{Identity: runtime.Identity{"type": "OCIRegistry", "hostname": "ocm.software"}},
+		{Identity: runtime.Identity{"type": "OCIRegistry", "hostname": "mirror.ocm.software"}, Operations: []repov1.RepositoryOperation{repov1.RepositoryOperationWrite}},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(ListConsumerIdentities, ListConsumerIdentitiesHandlerFunc(func(_ context.Context, request repov1.ListConsumerIdentitiesRequest[*dummyv1.Repository]) (*repov1.ListConsumerIdentitiesResponse, error) {
+		if request.Repository.BaseUrl != "ocm.software" {
+			return nil, errors.New("unexpected repository")
+		}
+		return &repov1.ListConsumerIdentitiesResponse{Identities: identities}, nil
+	}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := types.Config{
+		ID:         "test-plugin",
+		Type:       types.TCP,
+		PluginType: repov1.ComponentVersionRepositoryPluginType,
+	}
+	request := repov1.ListConsumerIdentitiesRequest[runtime.Typed]{
+		Repository: &runtime.Raw{Type: dummyType, Data: []byte(`{"type":"DummyRepository/v1","baseUrl":"ocm.software"}`)},
+	}
+	capability := dummyCapability([]byte(`{}`))
+	capability.ConsumerIdentityListing = true
+	plugin := NewComponentVersionRepositoryPlugin(server.Client(), "test-plugin", server.URL, config, server.URL, capability)
+
+	response, err := plugin.ListConsumerIdentities(t.Context(), request)
+	r.NoError(err)
+	r.Equal(identities, response.Identities)
+	r.True(response.Identities[0].UsedFor(repov1.RepositoryOperationRead))
+	r.False(response.Identities[1].UsedFor(repov1.RepositoryOperationRead))
+
+	t.Run("unsupported", func(t *testing.T) {
+		plugin := NewComponentVersionRepositoryPlugin(server.Client(), "test-plugin", server.URL, config, server.URL, dummyCapability([]byte(`{}`)))
+		_, err := plugin.ListConsumerIdentities(t.Context(), request)
+		require.ErrorIs(t, err, ocmerrors.ErrUnsupported)
+	})
+}
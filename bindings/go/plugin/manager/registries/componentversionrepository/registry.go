@@ -4,8 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
+	"log/slog"
 	"sync"
 
 	ocmrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
@@ -13,12 +12,13 @@ import (
 	mtypes "ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 type constructedPlugin struct {
 	Plugin ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[runtime.Typed]
 
-	cmd *exec.Cmd
+	process *plugins.Process
 }
 
 // RepositoryRegistry holds all plugins that implement capabilities corresponding to RepositoryPlugin operations.
@@ -28,9 +28,15 @@ type RepositoryRegistry struct {
 	mu  sync.RWMutex
 	// capabilities maps plugin ID to its capability spec.
 	// TODO(fabianburth): refactor to make capability information part of RepositoryPlugin
-	capabilities       map[string]ocmrepositoryv1.CapabilitySpec
-	registry           map[runtime.Type]mtypes.Plugin // Have this as a single plugin for read/write
-	constructedPlugins map[string]*constructedPlugin  // running plugins
+	capabilities map[string]ocmrepositoryv1.CapabilitySpec
+	// registry maps a type to the plugin serving it by default, which is the first plugin registered for it.
+	registry map[runtime.Type]mtypes.Plugin // Have this as a single plugin for read/write
+	// plugins contains all registered plugins by ID, including those that only serve types through a PluginSelection.
+	plugins            map[string]mtypes.Plugin
+	constructedPlugins map[string]*constructedPlugin // running plugins by ID, or instance ID for isolated plugins
+	// isolatedInstances maps a plugin ID and isolation key to the instance ID of the isolated process
+	// started last for them, so that the process is stopped once the configuration of the isolation changes.
+	isolatedInstances map[isolation]string
 
 	// internalComponentVersionRepositoryPlugins contains all plugins that have been registered using internally import statement.
 	internalComponentVersionRepositoryPlugins map[runtime.Type]repository.ComponentVersionRepositoryProvider
@@ -78,6 +84,14 @@ func (r *RepositoryRegistry) RegisterInternalComponentVersionRepositoryPlugin(
 	return nil
 }
 
+// InternalPlugin returns the internal plugin registered for the given type or one of its aliases.
+func (r *RepositoryRegistry) InternalPlugin(typ runtime.Type) (repository.ComponentVersionRepositoryProvider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.internalComponentVersionRepositoryPlugins[typ]
+	return p, ok
+}
+
 func (r *RepositoryRegistry) GetComponentVersionRepositoryScheme() *runtime.Scheme {
 	return r.scheme
 }
@@ -95,7 +109,7 @@ func (r *RepositoryRegistry) Shutdown(ctx context.Context) error {
 	for _, p := range r.constructedPlugins {
 		// The plugins should handle the Interrupt signal for shutdowns.
 		// TODO(Skarlso): Use context to wait for the plugin to actually shut down.
-		if perr := p.cmd.Process.Signal(os.Interrupt); perr != nil && !errors.Is(perr, os.ErrProcessDone) {
+		if perr := p.process.Interrupt(); perr != nil {
 			errs = errors.Join(errs, perr)
 		}
 	}
@@ -104,6 +118,8 @@ func (r *RepositoryRegistry) Shutdown(ctx context.Context) error {
 }
 
 // AddPlugin takes a plugin discovered by the manager and adds it to the stored plugin registry.
+// If another plugin already serves one of the types of the plugin, that plugin stays the default for the type,
+// and the added plugin only serves it through Select.
 func (r *RepositoryRegistry) AddPlugin(plugin mtypes.Plugin, spec runtime.Typed) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -116,10 +132,14 @@ func (r *RepositoryRegistry) AddPlugin(plugin mtypes.Plugin, spec runtime.Typed)
 		return fmt.Errorf("plugin with ID %s already registered", plugin.ID)
 	}
 	r.capabilities[plugin.ID] = capability
+	r.plugins[plugin.ID] = plugin
 
 	for _, typ := range capability.SupportedRepositorySpecTypes {
 		if v, ok := r.registry[typ.Type]; ok {
-			return fmt.Errorf("plugin for type %v already registered with ID: %s", typ.Type, v.ID)
+			// the type is served by the first registered plugin, others can only be used with a PluginSelection.
+			slog.DebugContext(r.ctx, "plugin for type already registered, plugin can only serve type if selected",
+				"type", typ.Type, "default", v.ID, "id", plugin.ID)
+			continue
 		}
 		// Note: No need to be more intricate because we know the endpoints, and we have a specific plugin here.
 		r.registry[typ.Type] = plugin
@@ -128,24 +148,32 @@ func (r *RepositoryRegistry) AddPlugin(plugin mtypes.Plugin, spec runtime.Typed)
 	return nil
 }
 
-func startAndReturnPlugin(ctx context.Context, r *RepositoryRegistry, plugin *mtypes.Plugin) (ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[runtime.Typed], error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *RepositoryRegistry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *mtypes.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin.ID, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+// startAndReturnPlugin launches the plugin and stores the running plugin under the given key.
+func startAndReturnPlugin(ctx context.Context, r *RepositoryRegistry, key string, plugin *mtypes.Plugin) (ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[runtime.Typed], error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	// start log streaming once the plugin is up and running.
-	// use the baseCtx here from the manager here so the streaming isn't stopped when the request is stopped.
-	go plugins.StartLogStreamer(r.ctx, plugin)
-
-	repoPlugin := NewComponentVersionRepositoryPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
-	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: repoPlugin,
-		cmd:    plugin.Cmd,
+	repoPlugin := NewComponentVersionRepositoryPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
+	r.constructedPlugins[key] = &constructedPlugin{
+		Plugin:  repoPlugin,
+		process: process,
 	}
 
 	// wrap the untyped internal plugin into a typed representation.
@@ -230,14 +258,14 @@ func (r *RepositoryRegistry) GetComponentVersionRepository(ctx context.Context,
 func (r *RepositoryRegistry) getPlugin(ctx context.Context, typ runtime.Type) (ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[runtime.Typed], error) {
 	plugin, ok := r.registry[typ]
 	if !ok {
-		return nil, fmt.Errorf("failed to get plugin for typ %q", typ)
+		return nil, ocmerrors.Unsupported(fmt.Errorf("failed to get plugin for typ %q", typ))
 	}
 
-	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok && !existingPlugin.process.Exited() {
 		return existingPlugin.Plugin, nil
 	}
 
-	return startAndReturnPlugin(ctx, r, &plugin)
+	return startAndReturnPlugin(ctx, r, plugin.ID, &plugin)
 }
 
 // NewComponentVersionRepositoryRegistry creates a new registry and initializes maps.
@@ -246,7 +274,9 @@ func NewComponentVersionRepositoryRegistry(ctx context.Context) *RepositoryRegis
 		ctx:                ctx,
 		capabilities:       make(map[string]ocmrepositoryv1.CapabilitySpec),
 		registry:           make(map[runtime.Type]mtypes.Plugin),
+		plugins:            make(map[string]mtypes.Plugin),
 		constructedPlugins: make(map[string]*constructedPlugin),
+		isolatedInstances:  make(map[isolation]string),
 		scheme:             runtime.NewScheme(runtime.WithAllowUnknown()),
 		internalComponentVersionRepositoryPlugins: make(map[runtime.Type]repository.ComponentVersionRepositoryProvider),
 	}
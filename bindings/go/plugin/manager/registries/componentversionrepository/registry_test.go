@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -142,6 +143,12 @@ func TestInternalPluginRegistry(t *testing.T) {
 	}
 	r.NoError(registry.RegisterInternalComponentVersionRepositoryPlugin(repositoryProvider))
 
+	internal, ok := registry.InternalPlugin(runtime.NewVersionedType(dummyv1.ShortType, dummyv1.Version))
+	r.True(ok)
+	r.Same(repositoryProvider, internal)
+	_, ok = registry.InternalPlugin(runtime.NewVersionedType("NonExistingType", "v1"))
+	r.False(ok)
+
 	tests := []struct {
 		name           string
 		repositorySpec runtime.Typed
@@ -242,3 +249,92 @@ var (
 	_ runtime.Typed                         = (*mockedRepository)(nil)
 	_ repository.ComponentVersionRepository = (*mockedRepository)(nil)
 )
+
+func TestPluginSelection(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "tmp", "testdata", "test-plugin-component-version")
+	_, err := os.Stat(path)
+	require.NoError(t, err, "test plugin not found, please build the plugin under tmp/testdata/test-plugin-component-version first")
+
+	ctx := t.Context()
+	r := require.New(t)
+	registry := NewComponentVersionRepositoryRegistry(ctx)
+	t.Cleanup(func() {
+		r.NoError(registry.Shutdown(context.Background()))
+	})
+
+	newPlugin := func(id, version string) mtypes.Plugin {
+		return mtypes.Plugin{
+			ID:      id,
+			Path:    path,
+			Version: version,
+			Config: mtypes.Config{
+				ID:         id,
+				Type:       mtypes.Socket,
+				PluginType: v1.ComponentVersionRepositoryPluginType,
+			},
+			NewCmd: func(config mtypes.Config) (*exec.Cmd, error) {
+				serialized, err := json.Marshal(config)
+				if err != nil {
+					return nil, err
+				}
+				return exec.CommandContext(ctx, path, "--config", string(serialized)), nil
+			},
+		}
+	}
+	capability := dummyCapability([]byte(`{}`))
+	r.NoError(registry.AddPlugin(newPlugin("test-plugin-platform", "v1.0.0"), &capability))
+	r.NoError(registry.AddPlugin(newPlugin("test-plugin-tenant", "v2.0.0"), &capability))
+
+	_, err = registry.Select(PluginSelection{ID: "test-plugin-tenant", Version: "v1.0.0"})
+	r.ErrorIs(err, ErrPluginNotFound)
+	_, err = registry.Select(PluginSelection{ID: "unknown"})
+	r.ErrorIs(err, ErrPluginNotFound)
+
+	provider, err := registry.Select(PluginSelection{ID: "test-plugin-tenant", Version: "v2.0.0", Isolation: "tenant-a"})
+	r.NoError(err)
+	spec := &dummyv1.Repository{
+		Type:    dummyType,
+		BaseUrl: "ghcr.io/open-component/test-component-version-repository",
+	}
+	repo, err := provider.GetComponentVersionRepository(ctx, spec, nil)
+	r.NoError(err)
+	desc, err := repo.GetComponentVersion(ctx, "test-component", "1.0.0")
+	r.NoError(err)
+	r.Equal("test-component:1.0.0", desc.String())
+
+	r.Len(registry.constructedPlugins, 1)
+	r.NotContains(registry.constructedPlugins, "test-plugin-tenant", "the isolated process is not shared")
+	for key, plugin := range registry.constructedPlugins {
+		r.Contains(key, "test-plugin-tenant-")
+		r.Equal(key, plugin.Plugin.(*RepositoryPlugin).config.ID)
+		r.Empty(plugin.Plugin.(*RepositoryPlugin).config.CredentialRefreshLocation)
+	}
+
+	// types not served by the selection and unselected lookups use the default plugin.
+	repo, err = registry.GetComponentVersionRepository(ctx, spec, nil)
+	r.NoError(err)
+	_, err = repo.GetComponentVersion(ctx, "test-component", "1.0.0")
+	r.NoError(err)
+	r.Contains(registry.constructedPlugins, "test-plugin-platform")
+
+	// a changed configuration of the isolation starts a new process and stops the previous one.
+	var previous *constructedPlugin
+	for key, plugin := range registry.constructedPlugins {
+		if key != "test-plugin-platform" {
+			previous = plugin
+		}
+	}
+	config := &runtime.Raw{}
+	r.NoError(config.UnmarshalJSON([]byte(`{"type":"test.config/v1"}`)))
+	provider, err = registry.Select(PluginSelection{ID: "test-plugin-tenant", Isolation: "tenant-a", ConfigTypes: []*runtime.Raw{config}})
+	r.NoError(err)
+	repo, err = provider.GetComponentVersionRepository(ctx, spec, nil)
+	r.NoError(err)
+	_, err = repo.GetComponentVersion(ctx, "test-component", "1.0.0")
+	r.NoError(err)
+	r.Len(registry.constructedPlugins, 2)
+	r.NotContains(registry.constructedPlugins, previous.Plugin.(*RepositoryPlugin).config.ID)
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	r.NoError(previous.process.Wait(waitCtx))
+}
@@ -0,0 +1,192 @@
+package componentversionrepository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	ocmrepositoryv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/ocmrepository/v1"
+	mtypes "ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+// ErrPluginNotFound is returned by Select if no plugin matches the PluginSelection.
+var ErrPluginNotFound = ocmerrors.NotFound(errors.New("plugin not found"))
+
+// PluginSelection pins the external plugin that serves the repository types it supports.
+// It is used if multiple installed plugins serve the same type, or to isolate the invocations
+// of a plugin from those of other users of the registry.
+type PluginSelection struct {
+	// ID of the plugin, which is the file name of the plugin binary.
+	ID string
+	// Version the plugin must advertise. If empty, any version is accepted.
+	Version string
+	// Isolation runs the plugin in a dedicated process for the key instead of the shared process.
+	// The process is configured with ConfigTypes instead of the configuration the plugin was registered with,
+	// and cannot refresh credentials from the manager, so that it only receives the credentials passed to
+	// the repositories it serves. If empty, the shared process is used and ConfigTypes is ignored.
+	// Once the process for the key is started with other ConfigTypes, the process started with the
+	// previous ConfigTypes is stopped.
+	Isolation string
+	// ConfigTypes are the configurations passed to the isolated process.
+	ConfigTypes []*runtime.Raw
+}
+
+// Select returns a provider that serves the types supported by the selected plugin with that plugin,
+// and all other types like the registry.
+// It returns ErrPluginNotFound if no plugin with the ID and version of the selection is registered.
+func (r *RepositoryRegistry) Select(selection PluginSelection) (repository.ComponentVersionRepositoryProvider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plugin, ok := r.plugins[selection.ID]
+	if !ok {
+		return nil, fmt.Errorf("%w: no component version repository plugin with ID %q registered", ErrPluginNotFound, selection.ID)
+	}
+	if selection.Version != "" && plugin.Version != selection.Version {
+		return nil, fmt.Errorf("%w: plugin %q has version %q, but %q was selected", ErrPluginNotFound, selection.ID, plugin.Version, selection.Version)
+	}
+	if selection.Isolation != "" && plugin.NewCmd == nil {
+		return nil, fmt.Errorf("plugin %q cannot be isolated because it cannot be launched again", selection.ID)
+	}
+
+	instance := plugin
+	key := plugin.ID
+	if selection.Isolation != "" {
+		var err error
+		if key, err = isolatedInstanceID(plugin.ID, selection); err != nil {
+			return nil, err
+		}
+		// the instance ID also determines the socket of the plugin, so the processes do not collide.
+		instance.Config.ID = key
+		instance.Config.ConfigTypes = selection.ConfigTypes
+		instance.Config.CredentialRefreshLocation = ""
+	}
+
+	return &selectedRegistry{
+		RepositoryRegistry: r,
+		plugin:             instance,
+		key:                key,
+		isolation:          selection.Isolation,
+	}, nil
+}
+
+// isolation identifies the isolated processes of a plugin for an isolation key.
+type isolation struct {
+	id, key string
+}
+
+// isolatedInstanceID derives the ID of the isolated process of a plugin from the isolation key and
+// configuration, so that a changed configuration starts a new process.
+func isolatedInstanceID(id string, selection PluginSelection) (string, error) {
+	config, err := json.Marshal(selection.ConfigTypes)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal configuration of isolated plugin %q: %w", id, err)
+	}
+	hash := sha256.New()
+	hash.Write([]byte(selection.Isolation))
+	hash.Write([]byte{0})
+	hash.Write(config)
+	return id + "-" + hex.EncodeToString(hash.Sum(nil))[:16], nil
+}
+
+// selectedRegistry is the provider returned by RepositoryRegistry.Select.
+type selectedRegistry struct {
+	*RepositoryRegistry
+	plugin mtypes.Plugin
+	// key identifies the running process of plugin in the constructed plugins of the registry.
+	key string
+	// isolation is the isolation key of the selection, empty if the shared process is used.
+	isolation string
+}
+
+var _ repository.ComponentVersionRepositoryProvider = (*selectedRegistry)(nil)
+
+func (s *selectedRegistry) GetComponentVersionRepositoryCredentialConsumerIdentity(ctx context.Context, repositorySpecification runtime.Typed) (runtime.Identity, error) {
+	if !s.serves(repositorySpecification.GetType()) {
+		return s.RepositoryRegistry.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, repositorySpecification)
+	}
+
+	plugin, err := s.getPlugin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin %q: %w", s.plugin.ID, err)
+	}
+
+	result, err := plugin.GetIdentity(ctx, &ocmrepositoryv1.GetIdentityRequest[runtime.Typed]{
+		Typ: repositorySpecification,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	return result.Identity, nil
+}
+
+func (s *selectedRegistry) GetComponentVersionRepository(ctx context.Context, repositorySpecification runtime.Typed, credentials runtime.Typed) (repository.ComponentVersionRepository, error) {
+	if !s.serves(repositorySpecification.GetType()) {
+		return s.RepositoryRegistry.GetComponentVersionRepository(ctx, repositorySpecification, credentials)
+	}
+
+	plugin, err := s.getPlugin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin %q: %w", s.plugin.ID, err)
+	}
+
+	return s.externalToComponentVersionRepository(plugin, s.scheme, repositorySpecification, credentials), nil
+}
+
+// serves reports whether the selected plugin supports the type.
+func (s *selectedRegistry) serves(typ runtime.Type) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.ContainsFunc(s.capabilities[s.plugin.ID].SupportedRepositorySpecTypes, func(t mtypes.Type) bool {
+		return t.Type == typ
+	})
+}
+
+func (s *selectedRegistry) getPlugin(ctx context.Context) (ocmrepositoryv1.ReadWriteOCMRepositoryPluginContract[runtime.Typed], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existingPlugin, ok := s.constructedPlugins[s.key]; ok && !existingPlugin.process.Exited() {
+		return existingPlugin.Plugin, nil
+	}
+
+	plugin := s.plugin
+	started, err := startAndReturnPlugin(ctx, s.RepositoryRegistry, s.key, &plugin)
+	if err != nil {
+		return nil, err
+	}
+	if s.isolation != "" {
+		s.replaceIsolatedInstance(ctx)
+	}
+	return started, nil
+}
+
+// replaceIsolatedInstance records the started process as the isolated process of the plugin for the isolation key,
+// and stops the process started for the key before with another configuration, which is not selected anymore.
+// The caller has to hold the lock of the registry.
+func (s *selectedRegistry) replaceIsolatedInstance(ctx context.Context) {
+	isolated := isolation{id: s.plugin.ID, key: s.isolation}
+	previous, ok := s.isolatedInstances[isolated]
+	s.isolatedInstances[isolated] = s.key
+	if !ok || previous == s.key {
+		return
+	}
+	replaced, ok := s.constructedPlugins[previous]
+	if !ok {
+		return
+	}
+	delete(s.constructedPlugins, previous)
+	if err := replaced.process.Interrupt(); err != nil {
+		slog.WarnContext(ctx, "failed to stop isolated plugin process with outdated configuration",
+			"id", s.plugin.ID, "instance", previous, "error", err)
+	}
+}
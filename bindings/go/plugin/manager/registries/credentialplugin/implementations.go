@@ -73,6 +73,7 @@ func (p *CredentialPlugin) GetConsumerIdentity(ctx context.Context, request cred
 	if err := plugins.Call(ctx, p.client, p.config.Type, p.location, GetConsumerIdentityEndpoint, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(&identity)); err != nil {
 		return nil, fmt.Errorf("failed to get consumer identity from plugin %q: %w", p.ID, err)
 	}
+	plugins.AllowCredentialRefresh(p.client, identity)
 
 	return identity, nil
 }
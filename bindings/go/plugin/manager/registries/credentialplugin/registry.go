@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"sync"
 
 	"ocm.software/open-component-model/bindings/go/credentials"
@@ -30,8 +28,8 @@ type Registry struct {
 }
 
 type constructedPlugin struct {
-	Plugin credentialpluginv1.CredentialPluginContract[runtime.Typed]
-	cmd    *exec.Cmd
+	Plugin  credentialpluginv1.CredentialPluginContract[runtime.Typed]
+	process *plugins.Process
 }
 
 // NewRegistry creates a new credential plugin registry.
@@ -119,7 +117,7 @@ func (r *Registry) GetCredentialPlugin(ctx context.Context, typed runtime.Typed)
 		return nil, fmt.Errorf("no credential plugin registered for type %s", typ)
 	}
 
-	if existing, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existing, ok := r.constructedPlugins[plugin.ID]; ok && !existing.process.Exited() {
 		return NewCredentialPluginConverter(existing.Plugin), nil
 	}
 
@@ -130,26 +128,31 @@ func (r *Registry) GetCredentialPlugin(ctx context.Context, typed runtime.Typed)
 	return NewCredentialPluginConverter(external), nil
 }
 
-func startAndReturnPlugin(ctx context.Context, r *Registry, plugin *mtypes.Plugin) (credentialpluginv1.CredentialPluginContract[runtime.Typed], error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *Registry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *mtypes.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+func startAndReturnPlugin(ctx context.Context, r *Registry, plugin *mtypes.Plugin) (credentialpluginv1.CredentialPluginContract[runtime.Typed], error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		// Kill the orphaned subprocess to prevent resource leak
-		_ = plugin.Cmd.Process.Kill()
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	// start log streaming once the plugin is up and running.
-	// use the baseCtx here from the manager here so the streaming isn't stopped when the request is stopped.
-	go plugins.StartLogStreamer(r.ctx, plugin)
-
-	instance := NewCredentialPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
+	instance := NewCredentialPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
 	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: instance,
-		cmd:    plugin.Cmd,
+		Plugin:  instance,
+		process: process,
 	}
 
 	return instance, nil
@@ -161,7 +164,7 @@ func (r *Registry) Shutdown(ctx context.Context) error {
 	defer r.mu.Unlock()
 	var errs error
 	for _, p := range r.constructedPlugins {
-		if perr := p.cmd.Process.Signal(os.Interrupt); perr != nil && !errors.Is(perr, os.ErrProcessDone) {
+		if perr := p.process.Interrupt(); perr != nil {
 			errs = errors.Join(errs, perr)
 		}
 	}
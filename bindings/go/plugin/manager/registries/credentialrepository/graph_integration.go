@@ -3,27 +3,75 @@ package credentialrepository
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"ocm.software/open-component-model/bindings/go/credentials"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 var _ credentials.RepositoryPluginProvider = &RepositoryRegistry{}
 
-func (r *RepositoryRegistry) GetRepositoryPlugin(ctx context.Context, consumer runtime.Typed) (credentials.RepositoryPlugin, error) {
-	typ, ok := r.consumerTypeRegistrations[consumer.GetType()]
+// GetRepositoryPlugin returns the repository plugin for the consumer identity type.
+// If several plugins were registered for the consumer type, each repository configuration is passed to the
+// plugin registered for the type of the configuration, so that e.g. DockerConfig and HashiCorpVault
+// repositories can both serve OCI registry credentials.
+func (r *RepositoryRegistry) GetRepositoryPlugin(_ context.Context, consumer runtime.Typed) (credentials.RepositoryPlugin, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	types, ok := r.consumerTypeRegistrations[consumer.GetType()]
 	if !ok {
 		return nil, fmt.Errorf("no plugin registered for consumer identity type %q", consumer.GetType())
 	}
+	return &consumerRepositoryPlugin{registry: r, consumerType: consumer.GetType(), repositoryTypes: types}, nil
+}
+
+// consumerRepositoryPlugin dispatches the repository configurations of a consumer identity type
+// to the plugins registered for their types.
+type consumerRepositoryPlugin struct {
+	registry        *RepositoryRegistry
+	consumerType    runtime.Type
+	repositoryTypes []runtime.Type
+}
+
+var _ credentials.RepositoryPlugin = (*consumerRepositoryPlugin)(nil)
+
+func (p *consumerRepositoryPlugin) ConsumerIdentityForConfig(ctx context.Context, config runtime.Typed) (runtime.Identity, error) {
+	plugin, err := p.pluginFor(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ConsumerIdentityForConfig(ctx, config)
+}
+
+func (p *consumerRepositoryPlugin) Resolve(ctx context.Context, cfg runtime.Typed, identity runtime.Identity, credentials runtime.Typed) (runtime.Typed, error) {
+	plugin, err := p.pluginFor(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.Resolve(ctx, cfg, identity, credentials)
+}
+
+// pluginFor returns the plugin registered for the type of the repository configuration,
+// starting it if it is an external plugin that is not running yet.
+func (p *consumerRepositoryPlugin) pluginFor(ctx context.Context, cfg runtime.Typed) (credentials.RepositoryPlugin, error) {
+	typ := cfg.GetType()
+	if !slices.Contains(p.repositoryTypes, typ) {
+		return nil, ocmerrors.Unsupported(fmt.Errorf("no plugin for repository type %q registered for consumer identity type %q", typ, p.consumerType))
+	}
+
+	r := p.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	base, ok := r.internalCredentialRepositoryPlugins[typ]
-	if ok {
+	if base, ok := r.internalCredentialRepositoryPlugins[typ]; ok {
 		return base, nil
 	}
 
 	plugin, ok := r.registry[typ]
 	if !ok {
-		return nil, fmt.Errorf("failed to get plugin for typ %q", typ)
+		return nil, ocmerrors.Unsupported(fmt.Errorf("failed to get plugin for typ %q", typ))
 	}
 
 	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
@@ -0,0 +1,75 @@
+package credentialrepository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/credentialrepository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// fakeRepository is a built-in credential repository that resolves the name of its repository type.
+type fakeRepository struct {
+	scheme *runtime.Scheme
+	typ    runtime.Type
+}
+
+func newFakeRepository(typ runtime.Type, aliases ...runtime.Type) *fakeRepository {
+	scheme := runtime.NewScheme()
+	prototype := &runtime.Raw{}
+	prototype.SetType(typ)
+	scheme.MustRegisterWithAlias(prototype, append([]runtime.Type{typ}, aliases...)...)
+	return &fakeRepository{scheme: scheme, typ: typ}
+}
+
+func (f *fakeRepository) GetCredentialRepositoryScheme() *runtime.Scheme {
+	return f.scheme
+}
+
+func (f *fakeRepository) ConsumerIdentityForConfig(context.Context, runtime.Typed) (runtime.Identity, error) {
+	return runtime.Identity{"repository": f.typ.String()}, nil
+}
+
+func (f *fakeRepository) Resolve(context.Context, runtime.Typed, runtime.Identity, runtime.Typed) (runtime.Typed, error) {
+	return &runtime.Raw{Type: f.typ}, nil
+}
+
+func TestGetRepositoryPlugin_SeveralRepositoriesForConsumerType(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	reg := credentialrepository.NewCredentialRepositoryRegistry(ctx)
+
+	consumerType := runtime.NewVersionedType("OCIRegistry", "v1")
+	dockerType := runtime.NewVersionedType("DockerConfig", "v1")
+	vaultType := runtime.NewVersionedType("HashiCorpVault", "v1alpha1")
+	r.NoError(reg.RegisterInternalCredentialRepositoryPlugin(newFakeRepository(dockerType, runtime.NewUnversionedType("DockerConfig")), []runtime.Type{consumerType}))
+	r.NoError(reg.RegisterInternalCredentialRepositoryPlugin(newFakeRepository(vaultType), []runtime.Type{consumerType}))
+
+	plugin, err := reg.GetRepositoryPlugin(ctx, &runtime.Raw{Type: consumerType})
+	r.NoError(err)
+
+	for _, tc := range []struct {
+		config   runtime.Type
+		resolved runtime.Type
+	}{
+		{config: dockerType, resolved: dockerType},
+		{config: runtime.NewUnversionedType("DockerConfig"), resolved: dockerType},
+		{config: vaultType, resolved: vaultType},
+	} {
+		resolved, err := plugin.Resolve(ctx, &runtime.Raw{Type: tc.config}, runtime.Identity{}, nil)
+		r.NoError(err, "config of type %s", tc.config)
+		r.Equal(tc.resolved, resolved.GetType())
+
+		identity, err := plugin.ConsumerIdentityForConfig(ctx, &runtime.Raw{Type: tc.config})
+		r.NoError(err)
+		r.Equal(tc.resolved.String(), identity["repository"])
+	}
+
+	_, err = plugin.Resolve(ctx, &runtime.Raw{Type: runtime.NewVersionedType("Unknown", "v1")}, runtime.Identity{}, nil)
+	r.Error(err, "configs of types not registered for the consumer type must not be resolved")
+
+	_, err = reg.GetRepositoryPlugin(ctx, &runtime.Raw{Type: runtime.NewVersionedType("HelmChartRepository", "v1")})
+	r.Error(err)
+}
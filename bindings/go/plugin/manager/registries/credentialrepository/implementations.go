@@ -74,6 +74,7 @@ func (r *RepositoryPlugin) ConsumerIdentityForConfig(ctx context.Context, cfg cr
 	if err := plugins.Call(ctx, r.client, r.config.Type, r.location, ConsumerIdentityForConfig, http.MethodPost, plugins.WithPayload(cfg), plugins.WithResult(&identity)); err != nil {
 		return nil, fmt.Errorf("failed to get consumer identity from plugin %q: %w", r.ID, err)
 	}
+	plugins.AllowCredentialRefresh(r.client, identity)
 
 	return identity, nil
 }
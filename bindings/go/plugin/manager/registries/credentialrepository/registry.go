@@ -5,8 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"os"
-	"os/exec"
+	"slices"
 	"sync"
 
 	"ocm.software/open-component-model/bindings/go/credentials"
@@ -14,6 +13,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	mtypes "ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // NewCredentialRepositoryRegistry creates a new registry and initializes maps.
@@ -23,7 +23,7 @@ func NewCredentialRepositoryRegistry(ctx context.Context) *RepositoryRegistry {
 		capabilities:                        make(map[string]credentialsv1.CapabilitySpec),
 		registry:                            make(map[runtime.Type]mtypes.Plugin),
 		constructedPlugins:                  make(map[string]*constructedPlugin), // running plugins
-		consumerTypeRegistrations:           make(map[runtime.Type]runtime.Type),
+		consumerTypeRegistrations:           make(map[runtime.Type][]runtime.Type),
 		internalCredentialRepositoryPlugins: make(map[runtime.Type]credentials.RepositoryPlugin),
 		scheme:                              runtime.NewScheme(),
 		credentialTypeScheme:                runtime.NewScheme(),
@@ -39,8 +39,10 @@ type RepositoryRegistry struct {
 	scheme               *runtime.Scheme
 	credentialTypeScheme *runtime.Scheme
 
-	constructedPlugins        map[string]*constructedPlugin // running plugins
-	consumerTypeRegistrations map[runtime.Type]runtime.Type
+	constructedPlugins map[string]*constructedPlugin // running plugins
+	// consumerTypeRegistrations maps consumer identity types to the repository types, including their aliases,
+	// of all internal plugins that were registered for them.
+	consumerTypeRegistrations map[runtime.Type][]runtime.Type
 	// internalCredentialRepositoryPlugins contains all plugins that have been registered using internally import statement.
 	internalCredentialRepositoryPlugins map[runtime.Type]credentials.RepositoryPlugin
 }
@@ -142,10 +144,10 @@ func (r *RepositoryRegistry) GetPlugin(ctx context.Context, spec runtime.Typed)
 
 	plugin, ok := r.registry[typ]
 	if !ok {
-		return nil, fmt.Errorf("failed to get plugin for typ %q", typ)
+		return nil, ocmerrors.Unsupported(fmt.Errorf("failed to get plugin for typ %q", typ))
 	}
 
-	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok && !existingPlugin.process.Exited() {
 		// Convert the external plugin to internal interface using the converter
 		return NewCredentialRepositoryPluginConverter(existingPlugin.Plugin), nil
 	}
@@ -160,6 +162,8 @@ func (r *RepositoryRegistry) GetPlugin(ctx context.Context, spec runtime.Typed)
 
 // RegisterInternalCredentialRepositoryPlugin can be called by actual implementations in the source.
 // It will register any implementations directly for a given type and capability.
+// Several plugins can be registered for the same consumer type, the repository configurations
+// are then passed to the plugin registered for their type, see GetRepositoryPlugin.
 func (r *RepositoryRegistry) RegisterInternalCredentialRepositoryPlugin(
 	plugin BuiltinCredentialRepositoryPlugin,
 	consumerTypes []runtime.Type,
@@ -178,7 +182,11 @@ func (r *RepositoryRegistry) RegisterInternalCredentialRepositoryPlugin(
 		}
 
 		for _, consumerType := range consumerTypes {
-			r.consumerTypeRegistrations[consumerType] = providerType
+			for _, typ := range append([]runtime.Type{providerType}, providerTypeAliases...) {
+				if !slices.Contains(r.consumerTypeRegistrations[consumerType], typ) {
+					r.consumerTypeRegistrations[consumerType] = append(r.consumerTypeRegistrations[consumerType], typ)
+				}
+			}
 		}
 	}
 
@@ -186,8 +194,8 @@ func (r *RepositoryRegistry) RegisterInternalCredentialRepositoryPlugin(
 }
 
 type constructedPlugin struct {
-	Plugin credentialsv1.CredentialRepositoryPluginContract[runtime.Typed]
-	cmd    *exec.Cmd
+	Plugin  credentialsv1.CredentialRepositoryPluginContract[runtime.Typed]
+	process *plugins.Process
 }
 
 // Shutdown will loop through all _STARTED_ plugins and will send an Interrupt signal to them.
@@ -200,7 +208,7 @@ func (r *RepositoryRegistry) Shutdown(ctx context.Context) error {
 	for _, p := range r.constructedPlugins {
 		// The plugins should handle the Interrupt signal for shutdowns.
 		// TODO(Skarlso): Use context to wait for the plugin to actually shut down.
-		if perr := p.cmd.Process.Signal(os.Interrupt); perr != nil && !errors.Is(perr, os.ErrProcessDone) {
+		if perr := p.process.Interrupt(); perr != nil {
 			errs = errors.Join(errs, perr)
 		}
 	}
@@ -208,24 +216,31 @@ func (r *RepositoryRegistry) Shutdown(ctx context.Context) error {
 	return errs
 }
 
-func startAndReturnPlugin(ctx context.Context, r *RepositoryRegistry, plugin *mtypes.Plugin) (credentialsv1.CredentialRepositoryPluginContract[runtime.Typed], error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *RepositoryRegistry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *mtypes.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+func startAndReturnPlugin(ctx context.Context, r *RepositoryRegistry, plugin *mtypes.Plugin) (credentialsv1.CredentialRepositoryPluginContract[runtime.Typed], error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	// start log streaming once the plugin is up and running.
-	// use the baseCtx here from the manager here so the streaming isn't stopped when the request is stopped.
-	go plugins.StartLogStreamer(r.ctx, plugin)
-
-	repoPlugin := NewCredentialRepositoryPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
+	repoPlugin := NewCredentialRepositoryPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
 	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: repoPlugin,
-		cmd:    plugin.Cmd,
+		Plugin:  repoPlugin,
+		process: process,
 	}
 
 	// wrap the untyped internal plugin into a typed representation.
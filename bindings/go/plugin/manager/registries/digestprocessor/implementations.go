@@ -65,6 +65,7 @@ func (p *RepositoryPlugin) GetIdentity(ctx context.Context, request *digestproce
 	if err := plugins.Call(ctx, p.client, p.config.Type, p.location, Identity, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(&identity)); err != nil {
 		return nil, fmt.Errorf("failed to get identity from plugin %q: %w", p.ID, err)
 	}
+	plugins.AllowCredentialRefresh(p.client, identity.Identity)
 
 	return &identity, nil
 }
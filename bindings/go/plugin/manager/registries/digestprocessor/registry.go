@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"sync"
 
 	"ocm.software/open-component-model/bindings/go/constructor"
@@ -13,11 +11,12 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	mtypes "ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 type constructedPlugin struct {
-	Plugin digestprocessorv1.ResourceDigestProcessorContract
-	cmd    *exec.Cmd
+	Plugin  digestprocessorv1.ResourceDigestProcessorContract
+	process *plugins.Process
 }
 
 // NewDigestProcessorRegistry creates a new registry and initializes maps.
@@ -71,7 +70,7 @@ func (r *RepositoryRegistry) Shutdown(ctx context.Context) error {
 	defer r.mu.Unlock()
 	var errs error
 	for _, p := range r.constructedPlugins {
-		if perr := p.cmd.Process.Signal(os.Interrupt); perr != nil && !errors.Is(perr, os.ErrProcessDone) {
+		if perr := p.process.Interrupt(); perr != nil {
 			errs = errors.Join(errs, perr)
 		}
 	}
@@ -105,22 +104,31 @@ func (r *RepositoryRegistry) AddPlugin(plugin mtypes.Plugin, spec runtime.Typed)
 	return nil
 }
 
-func startAndReturnPlugin(ctx context.Context, r *RepositoryRegistry, plugin *mtypes.Plugin) (digestprocessorv1.ResourceDigestProcessorContract, error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *RepositoryRegistry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *mtypes.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+func startAndReturnPlugin(ctx context.Context, r *RepositoryRegistry, plugin *mtypes.Plugin) (digestprocessorv1.ResourceDigestProcessorContract, error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	go plugins.StartLogStreamer(r.ctx, plugin)
-
-	digestPlugin := NewDigestProcessorPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
+	digestPlugin := NewDigestProcessorPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
 	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: digestPlugin,
-		cmd:    plugin.Cmd,
+		Plugin:  digestPlugin,
+		process: process,
 	}
 
 	return digestPlugin, nil
@@ -154,10 +162,10 @@ func (r *RepositoryRegistry) GetPlugin(ctx context.Context, spec runtime.Typed)
 func (r *RepositoryRegistry) getPlugin(ctx context.Context, typ runtime.Type) (digestprocessorv1.ResourceDigestProcessorContract, error) {
 	plugin, ok := r.registry[typ]
 	if !ok {
-		return nil, fmt.Errorf("failed to get plugin for typ %q", typ)
+		return nil, ocmerrors.Unsupported(fmt.Errorf("failed to get plugin for typ %q", typ))
 	}
 
-	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok && !existingPlugin.process.Exited() {
 		return existingPlugin.Plugin, nil
 	}
 
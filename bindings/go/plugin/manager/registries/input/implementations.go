@@ -64,6 +64,7 @@ func (r *RepositoryPlugin) GetIdentity(ctx context.Context, request *inputv1.Get
 	if err := plugins.Call(ctx, r.client, r.config.Type, r.location, Identity, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(&identity)); err != nil {
 		return nil, fmt.Errorf("failed to get identity from plugin %q: %w", r.ID, err)
 	}
+	plugins.AllowCredentialRefresh(r.client, identity.Identity)
 
 	return &identity, nil
 }
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"slices"
 	"sync"
 
@@ -16,6 +14,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // NewInputRepositoryRegistry creates a new registry and initializes maps.
@@ -138,10 +137,10 @@ func (r *RepositoryRegistry) getPlugin(ctx context.Context, spec runtime.Typed)
 
 	plugin, ok := r.registry[typ]
 	if !ok {
-		return nil, fmt.Errorf("failed to get plugin for typ %q", typ)
+		return nil, ocmerrors.Unsupported(fmt.Errorf("failed to get plugin for typ %q", typ))
 	}
 
-	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok && !existingPlugin.process.Exited() {
 		return existingPlugin.Plugin, nil
 	}
 
@@ -234,8 +233,8 @@ func (r *RepositoryRegistry) RegisterInternalSourceInputPlugin(
 
 // constructedPlugin only contains EXTERNAL plugins that have been started and need to be shut down.
 type constructedPlugin struct {
-	Plugin inputv1.InputPluginContract
-	cmd    *exec.Cmd
+	Plugin  inputv1.InputPluginContract
+	process *plugins.Process
 }
 
 // Shutdown will loop through all _STARTED_ plugins and will send an Interrupt signal to them.
@@ -248,48 +247,46 @@ func (r *RepositoryRegistry) Shutdown(ctx context.Context) error {
 	for _, p := range r.constructedPlugins {
 		eg.Go(func() error {
 			// The plugins should handle the Interrupt signal for shutdowns.
-			if err := p.cmd.Process.Signal(os.Interrupt); err != nil && !errors.Is(err, os.ErrProcessDone) {
-				return fmt.Errorf("failed to send interrupt signal to plugin: %w", errors.Join(err, p.cmd.Process.Kill()))
+			if err := p.process.Interrupt(); err != nil {
+				return fmt.Errorf("failed to send interrupt signal to plugin: %w", errors.Join(err, p.process.Kill()))
 			}
 
-			shutdownSig := make(chan error, 1)
-			defer func() {
-				close(shutdownSig)
-			}()
-			go func() {
-				_, err := p.cmd.Process.Wait()
-				shutdownSig <- err
-			}()
-
-			select {
-			case err := <-shutdownSig:
-				return err
-			case <-ctx.Done():
-				return errors.Join(ctx.Err(), p.cmd.Process.Kill())
+			if err := p.process.Wait(ctx); err != nil {
+				return errors.Join(err, p.process.Kill())
 			}
+
+			return nil
 		})
 	}
 
 	return eg.Wait()
 }
 
-func startAndReturnPlugin(ctx context.Context, r *RepositoryRegistry, plugin *types.Plugin) (inputv1.InputPluginContract, error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *RepositoryRegistry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *types.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+func startAndReturnPlugin(ctx context.Context, r *RepositoryRegistry, plugin *types.Plugin) (inputv1.InputPluginContract, error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	// start log streaming once the plugin is up and running.
-	go plugins.StartLogStreamer(context.TODO(), plugin) //nolint:gosec // G118 - using context.TODO() because plugin baseCtx is not yet exposed.
-
-	repoPlugin := NewConstructionRepositoryPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
+	repoPlugin := NewConstructionRepositoryPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
 	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: repoPlugin,
-		cmd:    plugin.Cmd,
+		Plugin:  repoPlugin,
+		process: process,
 	}
 
 	// wrap the untyped internal plugin into a typed representation.
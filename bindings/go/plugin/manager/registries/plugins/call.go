@@ -11,11 +11,13 @@ import (
 	"strings"
 
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // CallOptions contains options for calling a plugin endpoint.
 type CallOptions struct {
 	Payload     any
+	Body        io.Reader
 	Result      any
 	Headers     []KV
 	QueryParams []KV
@@ -31,6 +33,14 @@ func WithPayload(payload any) CallOptionFn {
 	}
 }
 
+// WithBody streams body as the raw request content instead of a JSON payload.
+// The content is sent with chunked transfer encoding, so it does not need to be materialized.
+func WithBody(body io.Reader) CallOptionFn {
+	return func(opt *CallOptions) {
+		opt.Body = body
+	}
+}
+
 // WithResult sets up a result that the call will marshal into.
 func WithResult(result any) CallOptionFn {
 	return func(opt *CallOptions) {
@@ -67,14 +77,59 @@ func Call(ctx context.Context, client *http.Client, locationType types.Connectio
 		opt(options)
 	}
 
-	var body io.Reader
+	resp, err := do(ctx, client, locationType, location, endpoint, method, options)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+
+	if options.Result == nil {
+		// Discard the body content otherwise some gibberish might remain in it
+		// that messes up further connections.
+		_, err = io.Copy(io.Discard, resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		return nil
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	if err := decoder.Decode(&options.Result); err != nil {
+		return fmt.Errorf("failed to decode response from plugin: %w", err)
+	}
+
+	return nil
+}
+
+// CallStream makes a call to the specified endpoint like Call but returns the response of a successful call
+// instead of decoding it, so that its body can be streamed. The caller must close the body of the response.
+// The Result option is ignored.
+func CallStream(ctx context.Context, client *http.Client, locationType types.ConnectionType, location, endpoint, method string, opts ...CallOptionFn) (*http.Response, error) {
+	options := &CallOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return do(ctx, client, locationType, location, endpoint, method, options)
+}
+
+// do sends the request described by options and returns the response if the plugin responded with http.StatusOK.
+func do(ctx context.Context, client *http.Client, locationType types.ConnectionType, location, endpoint, method string, options *CallOptions) (*http.Response, error) {
+	body := options.Body
+	contentType := "application/octet-stream"
 	if options.Payload != nil {
 		content, err := json.Marshal(options.Payload)
 		if err != nil {
-			return fmt.Errorf("failed to marshal payload: %w", err)
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
 		}
 
 		body = bytes.NewReader(content)
+		contentType = "application/json"
 	}
 
 	base := "http://unix"
@@ -86,7 +141,7 @@ func Call(ctx context.Context, client *http.Client, locationType types.Connectio
 	endpoint = strings.TrimPrefix(endpoint, "/")
 	request, err := http.NewRequestWithContext(ctx, method, base+"/"+endpoint, body)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	if len(options.QueryParams) > 0 {
 		query := request.URL.Query()
@@ -103,43 +158,26 @@ func Call(ctx context.Context, client *http.Client, locationType types.Connectio
 		}
 		request.Header.Add(v.Key, v.Value)
 	}
-	request.Header.Set("Content-Type", "application/json")
+	if body == nil {
+		contentType = "application/json"
+	}
+	request.Header.Set("Content-Type", contentType)
 	request.Header.Set("Accept", "application/json")
 
 	resp, err := client.Do(request)
 	if err != nil {
-		return fmt.Errorf("failed to send request to plugin: %w", err)
+		return nil, fmt.Errorf("failed to send request to plugin: %w", err)
 	}
-	defer func() {
-		if cerr := resp.Body.Close(); cerr != nil {
-			err = errors.Join(err, cerr)
-		}
-	}()
 
 	if resp.StatusCode != http.StatusOK {
 		data, err := io.ReadAll(resp.Body)
+		err = errors.Join(err, resp.Body.Close())
 		if err == nil && len(data) > 0 {
-			return fmt.Errorf("plugin returned status code %d: additional information: %s", resp.StatusCode, data)
-		}
-
-		return fmt.Errorf("plugin returned status code: %d (no details were given)", resp.StatusCode)
-	}
-
-	if options.Result == nil {
-		// Discard the body content otherwise some gibberish might remain in it
-		// that messes up further connections.
-		_, err = io.Copy(io.Discard, resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return nil, ocmerrors.FromHTTPStatus(resp.StatusCode, fmt.Errorf("plugin returned status code %d: additional information: %s", resp.StatusCode, data))
 		}
 
-		return nil
+		return nil, ocmerrors.FromHTTPStatus(resp.StatusCode, fmt.Errorf("plugin returned status code: %d (no details were given)", resp.StatusCode))
 	}
 
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&options.Result); err != nil {
-		return fmt.Errorf("failed to decode response from plugin: %w", err)
-	}
-
-	return nil
+	return resp, nil
 }
@@ -3,6 +3,7 @@ package plugins
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // TestStructs defines sample structs for testing
@@ -196,6 +198,25 @@ func TestCall(t *testing.T) {
 		assert.Contains(t, err.Error(), "Invalid request")
 	})
 
+	t.Run("classify non-200 response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			NewError(ocmerrors.NotFound(errors.New("component version not found")), http.StatusInternalServerError).Write(w)
+		}))
+		defer server.Close()
+
+		err := Call(
+			context.Background(),
+			server.Client(),
+			types.TCP,
+			server.URL,
+			"test-endpoint",
+			http.MethodGet,
+		)
+		require.ErrorIs(t, err, ocmerrors.ErrNotFound)
+		assert.Contains(t, err.Error(), "plugin returned status code 404")
+		assert.Contains(t, err.Error(), "component version not found")
+	})
+
 	t.Run("handle non-200 response with empty body", func(t *testing.T) {
 		// Setup test server
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,89 @@
+//go:build linux
+
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+// memoryRequiresCgroup is set because on Linux memory is only limited with a cgroup, which unlike resource limits
+// accounts the memory actually used by the process.
+const memoryRequiresCgroup = true
+
+const (
+	// cpuPeriod is the period of the cpu.max quota in microseconds.
+	cpuPeriod = 100_000
+	// cgroup2SuperMagic is the file system type of the cgroup v2 hierarchy.
+	cgroup2SuperMagic = 0x63677270
+)
+
+// validateCgroupParent checks that the directory is a cgroup v2 directory.
+func validateCgroupParent(parent string) error {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(parent, &stat); err != nil {
+		return err
+	}
+	if stat.Type != cgroup2SuperMagic {
+		return fmt.Errorf("%q is not a cgroup v2 directory", parent)
+	}
+	return nil
+}
+
+// joinCgroup creates a cgroup for the command below the cgroup parent of the sandbox, limits its memory and
+// cpu, and lets the command start in it. The returned release function removes the cgroup, which is only
+// possible once the process exited.
+func joinCgroup(id string, cmd *exec.Cmd, sandbox *types.Sandbox) (func(), error) {
+	dir, err := os.MkdirTemp(sandbox.CgroupParent, id+"-")
+	if err != nil {
+		return nil, err
+	}
+	cleanup := func() { _ = os.Remove(dir) }
+
+	if sandbox.Memory > 0 {
+		if err := writeCgroupFile(dir, "memory.max", strconv.FormatInt(sandbox.Memory, 10)); err != nil {
+			cleanup()
+			return nil, err
+		}
+	}
+	if sandbox.CPU > 0 {
+		quota := max(int64(sandbox.CPU*cpuPeriod), 1000)
+		if err := writeCgroupFile(dir, "cpu.max", fmt.Sprintf("%d %d", quota, cpuPeriod)); err != nil {
+			cleanup()
+			return nil, err
+		}
+	}
+
+	fd, err := os.Open(dir)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(fd.Fd())
+
+	return func() {
+		_ = fd.Close()
+		cleanup()
+	}, nil
+}
+
+func writeCgroupFile(dir, name, value string) error {
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("controller of %s is not enabled in cgroup %q: %w", name, filepath.Dir(dir), err)
+		}
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package plugins
+
+import (
+	"errors"
+	"os/exec"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+// memoryRequiresCgroup is not set, memory is limited with resource limits on this platform.
+const memoryRequiresCgroup = false
+
+// validateCgroupParent is not supported on this platform.
+func validateCgroupParent(_ string) error {
+	return errors.ErrUnsupported
+}
+
+// joinCgroup is not supported on this platform.
+func joinCgroup(_ string, _ *exec.Cmd, _ *types.Sandbox) (func(), error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,251 @@
+package plugins
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	credentialrefreshv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialrefresh/v1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// CredentialRefreshServer serves the credential refresh channel of the plugin manager, through which
+// plugins request refreshed credentials during long-running operations.
+// Every plugin process authenticates with the token of its own grant, see Grant, and only refreshes
+// the credentials of identities allowed by the grant.
+// Credentials are resolved with the resolver set by SetResolver. Until a resolver is set, requests fail.
+type CredentialRefreshServer struct {
+	server   *http.Server
+	location string
+	dir      string
+
+	mu       sync.RWMutex
+	resolver credentials.Resolver
+	// grants are keyed by the SHA-256 hash of their token, so looking up a token does not leak its value through timing.
+	grants map[[sha256.Size]byte]*credentialRefreshGrant
+}
+
+// credentialRefreshGrant is the grant of a single plugin process.
+type credentialRefreshGrant struct {
+	server *CredentialRefreshServer
+	token  string
+
+	mu         sync.RWMutex
+	identities []runtime.Identity
+}
+
+var _ types.CredentialRefreshGrant = (*credentialRefreshGrant)(nil)
+
+func (g *credentialRefreshGrant) Token() string {
+	return g.token
+}
+
+func (g *credentialRefreshGrant) Allow(identity runtime.Identity) {
+	if len(identity) == 0 {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !slices.ContainsFunc(g.identities, identity.Equal) {
+		g.identities = append(g.identities, identity.Clone())
+	}
+}
+
+func (g *credentialRefreshGrant) Revoke() {
+	g.server.mu.Lock()
+	defer g.server.mu.Unlock()
+	delete(g.server.grants, sha256.Sum256([]byte(g.token)))
+}
+
+func (g *credentialRefreshGrant) allowed(identity runtime.Identity) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.ContainsFunc(g.identities, identity.Equal)
+}
+
+// NewCredentialRefreshServer starts a credential refresh server reachable with the given connection type.
+// Sockets are created in a new temporary directory that is only accessible by the current user,
+// TCP servers only listen on the loopback interface.
+func NewCredentialRefreshServer(ctx context.Context, typ types.ConnectionType) (_ *CredentialRefreshServer, err error) {
+	s := &CredentialRefreshServer{grants: make(map[[sha256.Size]byte]*credentialRefreshGrant)}
+
+	var lc net.ListenConfig
+	var listener net.Listener
+	switch typ {
+	case types.Socket:
+		if s.dir, err = os.MkdirTemp("", "ocm-credential-refresh-"); err != nil {
+			return nil, fmt.Errorf("failed to create credential refresh socket directory: %w", err)
+		}
+		s.location = filepath.Join(s.dir, "credentials.sock")
+		if listener, err = lc.Listen(ctx, "unix", s.location); err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to listen for credential refresh requests: %w", err), os.RemoveAll(s.dir))
+		}
+	case types.TCP:
+		if listener, err = lc.Listen(ctx, "tcp", "127.0.0.1:0"); err != nil {
+			return nil, fmt.Errorf("failed to listen for credential refresh requests: %w", err)
+		}
+		s.location = "http://" + listener.Addr().String()
+	default:
+		return nil, fmt.Errorf("invalid connection type: %s", typ)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(credentialrefreshv1.Endpoint, s.handleRefresh)
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.ErrorContext(ctx, "credential refresh server stopped", "error", err)
+		}
+	}()
+
+	return s, nil
+}
+
+// Location is the location plugins reach the server at.
+func (s *CredentialRefreshServer) Location() string {
+	return s.location
+}
+
+// Grant issues a grant with a new token for a single plugin process. The grant does not allow refreshing
+// any credentials until the identities whose credentials are passed to the process are allowed with Allow.
+// The grant has to be revoked once the process exited.
+func (s *CredentialRefreshServer) Grant() (types.CredentialRefreshGrant, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate credential refresh token: %w", err)
+	}
+	grant := &credentialRefreshGrant{server: s, token: hex.EncodeToString(token)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.grants[sha256.Sum256([]byte(grant.token))] = grant
+	return grant, nil
+}
+
+// SetResolver sets the resolver used to resolve refreshed credentials.
+func (s *CredentialRefreshServer) SetResolver(resolver credentials.Resolver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resolver = resolver
+}
+
+// Close stops the server and removes its socket.
+func (s *CredentialRefreshServer) Close(ctx context.Context) error {
+	err := s.server.Shutdown(ctx)
+	if s.dir != "" {
+		err = errors.Join(err, os.RemoveAll(s.dir))
+	}
+	return err
+}
+
+func (s *CredentialRefreshServer) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		NewError(errors.New("this endpoint may only be called with the POST method"), http.StatusMethodNotAllowed).Write(w)
+		return
+	}
+	s.mu.RLock()
+	grant, ok := s.grants[sha256.Sum256([]byte(r.Header.Get(credentialrefreshv1.TokenHeader)))]
+	resolver := s.resolver
+	s.mu.RUnlock()
+	if !ok {
+		NewError(errors.New("invalid credential refresh token"), http.StatusUnauthorized).Write(w)
+		return
+	}
+	if resolver == nil {
+		NewError(credentialrefreshv1.ErrRefreshUnavailable, http.StatusServiceUnavailable).Write(w)
+		return
+	}
+
+	request, err := DecodeJSONRequestBody[credentialrefreshv1.RefreshRequest](w, r)
+	if err != nil {
+		NewError(err, http.StatusBadRequest).Write(w)
+		return
+	}
+	if !grant.allowed(request.Identity) {
+		NewError(fmt.Errorf("credentials for identity %q were not passed to the plugin", request.Identity.String()), http.StatusForbidden).Write(w)
+		return
+	}
+
+	creds, err := resolver.Resolve(r.Context(), request.Identity)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, credentials.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		NewError(err, status).Write(w)
+		return
+	}
+
+	rawCreds, err := json.Marshal(creds)
+	if err != nil {
+		NewError(fmt.Errorf("failed to marshal credentials: %w", err), http.StatusInternalServerError).Write(w)
+		return
+	}
+	raw := &runtime.Raw{}
+	if err := raw.UnmarshalJSON(rawCreds); err != nil {
+		NewError(fmt.Errorf("failed to convert credentials: %w", err), http.StatusInternalServerError).Write(w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(credentialrefreshv1.RefreshResponse{Credentials: raw}); err != nil {
+		NewError(err, http.StatusInternalServerError).Write(w)
+	}
+}
+
+// credentialRefreshTransport carries the credential refresh grant of a plugin process with the client connected to it.
+type credentialRefreshTransport struct {
+	http.RoundTripper
+	grant types.CredentialRefreshGrant
+}
+
+// AllowCredentialRefresh permits the plugin process the client is connected to to refresh the credentials
+// of the identity. Registries call it with the identities whose credentials are passed to the plugin.
+// It does nothing if the process has no credential refresh grant.
+func AllowCredentialRefresh(client *http.Client, identity runtime.Identity) {
+	if transport, ok := client.Transport.(*credentialRefreshTransport); ok {
+		transport.grant.Allow(identity)
+	}
+}
+
+// RefreshCredentials requests refreshed credentials for the identity from the credential refresh
+// channel at the location. It returns credentialrefreshv1.ErrRefreshUnavailable if no location or token is given.
+func RefreshCredentials(ctx context.Context, typ types.ConnectionType, location, token string, identity runtime.Identity) (runtime.Typed, error) {
+	if location == "" || token == "" {
+		return nil, credentialrefreshv1.ErrRefreshUnavailable
+	}
+	client, err := connect(ctx, "credential-refresh", location, typ, types.TransportHTTP)
+	if err != nil {
+		return nil, err
+	}
+	defer client.CloseIdleConnections()
+
+	var response credentialrefreshv1.RefreshResponse
+	if err := Call(ctx, client, typ, location, credentialrefreshv1.Endpoint, http.MethodPost,
+		WithPayload(credentialrefreshv1.RefreshRequest{Identity: identity}),
+		WithResult(&response),
+		WithHeader(KV{Key: credentialrefreshv1.TokenHeader, Value: token}),
+	); err != nil {
+		return nil, fmt.Errorf("failed to refresh credentials for identity %q: %w", identity.String(), err)
+	}
+	if response.Credentials == nil {
+		return nil, fmt.Errorf("no credentials returned for identity %q", identity.String())
+	}
+	return response.Credentials, nil
+}
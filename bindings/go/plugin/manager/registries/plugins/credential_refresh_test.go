@@ -0,0 +1,109 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	credentialrefreshv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialrefresh/v1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// tokenResolver issues a new token for every resolution, like a credential plugin for short-lived tokens.
+type tokenResolver struct {
+	resolutions atomic.Int64
+}
+
+func (r *tokenResolver) Resolve(_ context.Context, identity runtime.Identity) (runtime.Typed, error) {
+	if identity["hostname"] != "ghcr.io" {
+		return nil, credentials.ErrNotFound
+	}
+	raw := &runtime.Raw{}
+	if err := raw.UnmarshalJSON(fmt.Appendf(nil, `{"type":"Credentials/v1","properties":{"accessToken":"token-%d"}}`, r.resolutions.Add(1))); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func TestCredentialRefresh(t *testing.T) {
+	identity := runtime.Identity{"type": "OCIRegistry", "hostname": "ghcr.io"}
+
+	for _, typ := range []types.ConnectionType{types.Socket, types.TCP} {
+		t.Run(string(typ), func(t *testing.T) {
+			r := require.New(t)
+			server, err := NewCredentialRefreshServer(t.Context(), typ)
+			r.NoError(err)
+			t.Cleanup(func() {
+				r.NoError(server.Close(context.Background()))
+			})
+			grant, err := server.Grant()
+			r.NoError(err)
+			grant.Allow(identity)
+
+			_, err = RefreshCredentials(t.Context(), typ, server.Location(), grant.Token(), identity)
+			r.ErrorContains(err, credentialrefreshv1.ErrRefreshUnavailable.Error(), "refresh must fail until a resolver is set")
+
+			server.SetResolver(&tokenResolver{})
+			for i := 1; i <= 2; i++ {
+				creds, err := RefreshCredentials(t.Context(), typ, server.Location(), grant.Token(), identity)
+				r.NoError(err)
+				r.Equal("Credentials/v1", creds.GetType().String())
+				r.JSONEq(fmt.Sprintf(`{"type":"Credentials/v1","properties":{"accessToken":"token-%d"}}`, i), string(creds.(*runtime.Raw).Data))
+			}
+
+			_, err = RefreshCredentials(t.Context(), typ, server.Location(), "invalid", identity)
+			r.ErrorContains(err, "status code 401")
+
+			other := runtime.Identity{"type": "OCIRegistry", "hostname": "quay.io"}
+			_, err = RefreshCredentials(t.Context(), typ, server.Location(), grant.Token(), other)
+			r.ErrorContains(err, "status code 403", "identities not passed to the plugin must not be refreshed")
+			grant.Allow(other)
+			_, err = RefreshCredentials(t.Context(), typ, server.Location(), grant.Token(), other)
+			r.ErrorContains(err, "status code 404")
+
+			grant.Revoke()
+			_, err = RefreshCredentials(t.Context(), typ, server.Location(), grant.Token(), identity)
+			r.ErrorContains(err, "status code 401", "revoked tokens must be rejected")
+		})
+	}
+}
+
+func TestCredentialRefresh_GrantsArePerProcess(t *testing.T) {
+	r := require.New(t)
+	server, err := NewCredentialRefreshServer(t.Context(), types.TCP)
+	r.NoError(err)
+	t.Cleanup(func() {
+		r.NoError(server.Close(context.Background()))
+	})
+	server.SetResolver(&tokenResolver{})
+
+	identity := runtime.Identity{"type": "OCIRegistry", "hostname": "ghcr.io"}
+	first, err := server.Grant()
+	r.NoError(err)
+	second, err := server.Grant()
+	r.NoError(err)
+	r.NotEqual(first.Token(), second.Token())
+	first.Allow(identity)
+
+	_, err = RefreshCredentials(t.Context(), types.TCP, server.Location(), first.Token(), identity)
+	r.NoError(err)
+	_, err = RefreshCredentials(t.Context(), types.TCP, server.Location(), second.Token(), identity)
+	r.ErrorContains(err, "status code 403", "a grant must not allow the identities of another grant")
+
+	client := &http.Client{Transport: &credentialRefreshTransport{RoundTripper: http.DefaultTransport, grant: second}}
+	AllowCredentialRefresh(client, identity)
+	_, err = RefreshCredentials(t.Context(), types.TCP, server.Location(), second.Token(), identity)
+	r.NoError(err)
+	r.NotPanics(func() { AllowCredentialRefresh(http.DefaultClient, identity) }, "clients of processes without a grant are ignored")
+}
+
+func TestRefreshCredentials_Unavailable(t *testing.T) {
+	_, err := RefreshCredentials(t.Context(), types.Socket, "", "", runtime.Identity{"type": "OCIRegistry"})
+	require.ErrorIs(t, err, credentialrefreshv1.ErrRefreshUnavailable)
+}
@@ -9,6 +9,15 @@
 //   - **Call**: Makes HTTP requests to plugin endpoints with optional payloads, headers, and query parameters.
 //   - **Error**: A custom error type for handling plugin-related errors in HTTP responses.
 //   - **ValidatePlugin**: Validates an incoming raw type against a given JSON schema.
+//   - **Launch**: Starts a plugin binary, waits until it is ready and tracks whether its process is still running,
+//     so registries can start plugins again that exited after reaching their idle timeout.
 //   - **WaitForPlugin**: Waits for a plugin to become ready by making periodic health checks. Once the plugin is ready
 //     it sets up a client which can then be used to interact with said plugin.
+//   - **NewGRPCServer**: Serves the endpoints of a plugin with the gRPC transport, which tunnels the requests of the
+//     client set up by WaitForPlugin through streams of a single multiplexed connection.
+//   - **ApplySocketPolicy** and **ValidateSocket**: Apply the socket policy of a plugin to its unix domain socket,
+//     and validate the socket against the policy before WaitForPlugin connects to it.
+//   - **CredentialRefreshServer**: Serves the credential refresh channel through which plugins request refreshed
+//     credentials during long-running operations. Plugins use **RefreshCredentials** to call it. Every plugin
+//     process gets its own grant, which registries extend with **AllowCredentialRefresh**.
 package plugins
@@ -1,6 +1,10 @@
 package plugins
 
-import "net/http"
+import (
+	"net/http"
+
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
 
 type Error struct {
 	Err    error `json:"error"`
@@ -12,6 +16,15 @@ func NewError(err error, status int) *Error {
 	return &Error{Err: err, Status: status}
 }
 
+// Write writes the error to the response. Internal server errors that are classified with a
+// reason of the shared error taxonomy are written with the status code of the reason instead,
+// so that Call classifies them with the same reason on the side of the plugin manager.
 func (e *Error) Write(w http.ResponseWriter) {
-	http.Error(w, e.Err.Error(), e.Status)
+	status := e.Status
+	if status == http.StatusInternalServerError {
+		if classified, ok := ocmerrors.HTTPStatus(e.Err); ok {
+			status = classified
+		}
+	}
+	http.Error(w, e.Err.Error(), status)
 }
@@ -0,0 +1,414 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The gRPC transport (types.TransportGRPC) tunnels the HTTP requests to the endpoints of a plugin through
+// bidirectional RoundTrip streams. This keeps the handlers and the payloads of both transports identical,
+// while all requests share a single multiplexed HTTP/2 connection.
+//
+// The client sends a frame with the method, path and header of the request, followed by frames with the
+// content of the body, and closes its side of the stream. The plugin responds with a frame with the status
+// and header of the response, followed by frames with the content of the body.
+const (
+	grpcServiceName     = "ocm.plugin.v1.Transport"
+	grpcRoundTripStream = "RoundTrip"
+	grpcRoundTripMethod = "/" + grpcServiceName + "/" + grpcRoundTripStream
+
+	// grpcChunkSize is the maximum size of the body content sent in a single frame.
+	grpcChunkSize = 32 * 1024
+)
+
+var grpcRoundTripStreamDesc = grpc.StreamDesc{
+	StreamName:    grpcRoundTripStream,
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// NewGRPCServer returns a gRPC server serving the requests sent with the gRPC transport with handler.
+// baseContext derives the context of a request from the context of its stream.
+func NewGRPCServer(handler http.Handler, baseContext func(context.Context) context.Context) *grpc.Server {
+	server := grpc.NewServer(grpc.ForceServerCodec(frameCodec{}))
+	desc := grpcRoundTripStreamDesc
+	desc.Handler = func(_ any, stream grpc.ServerStream) error {
+		return serveRoundTrip(stream, handler, baseContext)
+	}
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: grpcServiceName,
+		HandlerType: (*any)(nil),
+		Streams:     []grpc.StreamDesc{desc},
+	}, nil)
+	return server
+}
+
+func serveRoundTrip(stream grpc.ServerStream, handler http.Handler, baseContext func(context.Context) context.Context) error {
+	head := &frame{}
+	if err := stream.RecvMsg(head); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(baseContext(stream.Context()), head.method, head.path, &frameReader{recv: stream.RecvMsg})
+	if err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	req.RequestURI = head.path
+	req.Header = head.header
+	req.ContentLength = -1
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2.0", 2, 0
+
+	w := &grpcResponseWriter{stream: stream, header: http.Header{}}
+	handler.ServeHTTP(w, req)
+	w.WriteHeader(http.StatusOK)
+
+	return w.err
+}
+
+// grpcResponseWriter is an http.ResponseWriter sending the response as frames of a RoundTrip stream.
+type grpcResponseWriter struct {
+	stream grpc.ServerStream
+	header http.Header
+	status int
+	err    error
+}
+
+var _ http.Flusher = (*grpcResponseWriter)(nil)
+
+func (w *grpcResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *grpcResponseWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+	w.err = w.stream.SendMsg(&frame{status: status, header: w.header.Clone()})
+}
+
+func (w *grpcResponseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	written := 0
+	for w.err == nil && written < len(p) {
+		chunk := p[written:min(written+grpcChunkSize, len(p))]
+		if w.err = w.stream.SendMsg(&frame{data: chunk}); w.err == nil {
+			written += len(chunk)
+		}
+	}
+	return written, w.err
+}
+
+// Flush sends the header if it was not sent yet. Written content is sent right away.
+func (w *grpcResponseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+}
+
+// connectGRPC creates a client that sends its requests to the plugin at address with the gRPC transport.
+func connectGRPC(id, network, address string) (*http.Client, error) {
+	dialer := net.Dialer{
+		Timeout: 30 * time.Second,
+	}
+
+	conn, err := grpc.NewClient("passthrough:///"+id,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(frameCodec{})),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, network, address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to plugin %s: %w", id, err)
+			}
+
+			return conn, nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for plugin %s: %w", id, err)
+	}
+
+	return &http.Client{Transport: &grpcRoundTripper{conn: conn}}, nil
+}
+
+// grpcRoundTripper is an http.RoundTripper sending requests with the gRPC transport.
+type grpcRoundTripper struct {
+	conn *grpc.ClientConn
+}
+
+var _ io.Closer = (*grpcRoundTripper)(nil)
+
+func (t *grpcRoundTripper) RoundTrip(req *http.Request) (_ *http.Response, err error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	stream, err := t.conn.NewStream(ctx, &grpcRoundTripStreamDesc, grpcRoundTripMethod)
+	if err != nil {
+		closeRequestBody(req)
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+
+	head := &frame{method: req.Method, path: req.URL.RequestURI(), header: req.Header}
+	if err := stream.SendMsg(head); err != nil && !errors.Is(err, io.EOF) {
+		// io.EOF signals that the stream was closed, its cause is returned by RecvMsg.
+		closeRequestBody(req)
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	// the body is sent concurrently, so that the plugin can respond while it is still reading it.
+	go sendRequestBody(stream, req, cancel)
+
+	resp := &frame{}
+	if err := stream.RecvMsg(resp); err != nil {
+		return nil, fmt.Errorf("failed to receive response: %w", err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.status, http.StatusText(resp.status)),
+		StatusCode:    resp.status,
+		Proto:         "HTTP/2.0",
+		ProtoMajor:    2,
+		Header:        resp.header,
+		Body:          &responseBody{frameReader: frameReader{recv: stream.RecvMsg}, cancel: cancel},
+		ContentLength: -1,
+		Request:       req,
+	}, nil
+}
+
+// Close closes the connection to the plugin.
+func (t *grpcRoundTripper) Close() error {
+	return t.conn.Close()
+}
+
+// sendRequestBody sends the content of the request body and closes the sending side of the stream.
+// If the body cannot be read, the stream is canceled.
+func sendRequestBody(stream grpc.ClientStream, req *http.Request, cancel context.CancelFunc) {
+	if req.Body == nil || req.Body == http.NoBody {
+		_ = stream.CloseSend()
+		return
+	}
+	defer closeRequestBody(req)
+
+	buf := make([]byte, grpcChunkSize)
+	for {
+		n, err := req.Body.Read(buf)
+		if n > 0 {
+			if err := stream.SendMsg(&frame{data: buf[:n]}); err != nil {
+				// the stream was closed, for example because the plugin responded without reading the body.
+				return
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			_ = stream.CloseSend()
+			return
+		}
+		if err != nil {
+			cancel()
+			return
+		}
+	}
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// responseBody is the body of a response received with the gRPC transport.
+// Closing it cancels the stream.
+type responseBody struct {
+	frameReader
+	cancel context.CancelFunc
+}
+
+func (b *responseBody) Close() error {
+	b.cancel()
+	return nil
+}
+
+// frameReader reads the body content of the frames received from a stream.
+type frameReader struct {
+	recv func(any) error
+	data []byte
+	err  error
+}
+
+func (r *frameReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		f := &frame{}
+		if err := r.recv(f); err != nil {
+			r.err = err
+			continue
+		}
+		r.data = f.data
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// frame is a message of a RoundTrip stream. The first frame in each direction carries the method, path
+// and header of the request or the status and header of the response. All other frames carry body content.
+type frame struct {
+	method string
+	path   string
+	header http.Header
+	status int
+	data   []byte
+}
+
+// Field numbers of the protobuf encoding of frames.
+const (
+	frameMethod protowire.Number = iota + 1
+	framePath
+	frameHeader
+	frameStatus
+	frameData
+)
+
+// Field numbers of the header entries of frames.
+const (
+	headerKey protowire.Number = iota + 1
+	headerValue
+)
+
+// frameCodec encodes frames in the protobuf wire format, so that the stream is a valid gRPC stream
+// without requiring generated code.
+type frameCodec struct{}
+
+func (frameCodec) Name() string {
+	return "proto"
+}
+
+func (frameCodec) Marshal(v any) ([]byte, error) {
+	f, ok := v.(*frame)
+	if !ok {
+		return nil, fmt.Errorf("cannot marshal %T", v)
+	}
+
+	var b []byte
+	if f.method != "" {
+		b = protowire.AppendTag(b, frameMethod, protowire.BytesType)
+		b = protowire.AppendString(b, f.method)
+	}
+	if f.path != "" {
+		b = protowire.AppendTag(b, framePath, protowire.BytesType)
+		b = protowire.AppendString(b, f.path)
+	}
+	for key, values := range f.header {
+		for _, value := range values {
+			var entry []byte
+			entry = protowire.AppendTag(entry, headerKey, protowire.BytesType)
+			entry = protowire.AppendString(entry, key)
+			entry = protowire.AppendTag(entry, headerValue, protowire.BytesType)
+			entry = protowire.AppendString(entry, value)
+			b = protowire.AppendTag(b, frameHeader, protowire.BytesType)
+			b = protowire.AppendBytes(b, entry)
+		}
+	}
+	if f.status != 0 {
+		b = protowire.AppendTag(b, frameStatus, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(f.status))
+	}
+	if len(f.data) > 0 {
+		b = protowire.AppendTag(b, frameData, protowire.BytesType)
+		b = protowire.AppendBytes(b, f.data)
+	}
+	return b, nil
+}
+
+func (frameCodec) Unmarshal(data []byte, v any) error {
+	f, ok := v.(*frame)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal into %T", v)
+	}
+
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		switch {
+		case num == frameStatus && typ == protowire.VarintType:
+			var status uint64
+			status, n = protowire.ConsumeVarint(data)
+			f.status = int(status)
+		case typ == protowire.BytesType:
+			var value []byte
+			value, n = protowire.ConsumeBytes(data)
+			if n < 0 {
+				break
+			}
+			switch num {
+			case frameMethod:
+				f.method = string(value)
+			case framePath:
+				f.path = string(value)
+			case frameHeader:
+				key, val, err := unmarshalHeaderEntry(value)
+				if err != nil {
+					return err
+				}
+				if f.header == nil {
+					f.header = http.Header{}
+				}
+				f.header[key] = append(f.header[key], val)
+			case frameData:
+				// the received message may be reused once it is unmarshalled.
+				f.data = bytes.Clone(value)
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+func unmarshalHeaderEntry(data []byte) (key, value string, err error) {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return "", "", protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		} else {
+			var v string
+			v, n = protowire.ConsumeString(data)
+			switch num {
+			case headerKey:
+				key = v
+			case headerValue:
+				value = v
+			}
+		}
+		if n < 0 {
+			return "", "", protowire.ParseError(n)
+		}
+		data = data[n:]
+	}
+	return key, value, nil
+}
@@ -0,0 +1,128 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+type contextKey struct{}
+
+func TestGRPCTransport(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Query", r.URL.Query().Get("q"))
+		w.Header().Set("X-Header", r.Header.Get("X-Header"))
+		w.Header().Set("X-Context", r.Context().Value(contextKey{}).(string))
+		_, _ = io.Copy(w, r.Body)
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			NewError(err, http.StatusBadRequest).Write(w)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"greeting": "hello " + payload["name"]})
+	})
+
+	server := NewGRPCServer(mux, func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, contextKey{}, "plugin")
+	})
+	socket := filepath.Join(t.TempDir(), "grpc.sock")
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "unix", socket)
+	r.NoError(err)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	plugin := &types.Plugin{
+		ID: "test-grpc-plugin",
+		Config: types.Config{
+			Type:      types.Socket,
+			Transport: types.TransportGRPC,
+		},
+		Stdout: io.NopCloser(bytes.NewBufferString("grpc+unix://" + socket + "\n")),
+	}
+	client, location, err := WaitForPlugin(ctx, plugin)
+	r.NoError(err)
+	r.Equal(socket, location)
+	t.Cleanup(func() {
+		r.NoError(client.Transport.(io.Closer).Close())
+	})
+
+	t.Run("payload and result", func(t *testing.T) {
+		r := require.New(t)
+		var result map[string]string
+		r.NoError(Call(t.Context(), client, types.Socket, location, "json", http.MethodPost,
+			WithPayload(map[string]string{"name": "ocm"}), WithResult(&result)))
+		r.Equal("hello ocm", result["greeting"])
+	})
+
+	t.Run("streamed body larger than a frame", func(t *testing.T) {
+		r := require.New(t)
+		content := strings.Repeat("ocm", grpcChunkSize)
+		resp, err := CallStream(t.Context(), client, types.Socket, location, "echo", http.MethodPost,
+			WithBody(strings.NewReader(content)),
+			WithHeader(KV{Key: "X-Header", Value: "header"}),
+			WithQueryParams([]KV{{Key: "q", Value: "query"}}))
+		r.NoError(err)
+		t.Cleanup(func() {
+			r.NoError(resp.Body.Close())
+		})
+
+		data, err := io.ReadAll(resp.Body)
+		r.NoError(err)
+		r.Equal(content, string(data))
+		r.Equal("query", resp.Header.Get("X-Query"))
+		r.Equal("header", resp.Header.Get("X-Header"))
+		r.Equal("plugin", resp.Header.Get("X-Context"))
+	})
+
+	t.Run("errors of the plugin", func(t *testing.T) {
+		r := require.New(t)
+		err := Call(t.Context(), client, types.Socket, location, "json", http.MethodPost, WithBody(strings.NewReader("invalid")))
+		r.ErrorContains(err, "plugin returned status code 400")
+
+		err = Call(t.Context(), client, types.Socket, location, "unknown", http.MethodGet)
+		r.ErrorContains(err, "plugin returned status code 404")
+	})
+}
+
+func TestFrameCodec(t *testing.T) {
+	r := require.New(t)
+	codec := frameCodec{}
+
+	in := &frame{
+		method: http.MethodPost,
+		path:   "/endpoint?q=1",
+		header: http.Header{"Accept": {"application/json"}, "X-Multi": {"a", "b"}},
+		status: http.StatusCreated,
+		data:   []byte("content"),
+	}
+	data, err := codec.Marshal(in)
+	r.NoError(err)
+
+	out := &frame{}
+	r.NoError(codec.Unmarshal(data, out))
+	r.Equal(in, out)
+
+	r.Error(codec.Unmarshal([]byte{0xff}, &frame{}))
+}
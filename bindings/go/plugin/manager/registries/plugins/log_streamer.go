@@ -56,9 +56,14 @@ func StartLogStreamer(ctx context.Context, plugin *types.Plugin) {
 	}()
 
 	// start streaming log messages to the debug context log
+	errs := (<-chan error)(errChan)
 	for {
 		select {
-		case line := <-lineChan:
+		case line, ok := <-lineChan:
+			if !ok {
+				// the plugin closed its output, which happens when the process exits.
+				return
+			}
 			parsed, err := parseLine(line)
 			if err != nil {
 				// we don't log this one, otherwise the output gets very crowded during shutdown
@@ -83,10 +88,13 @@ func StartLogStreamer(ctx context.Context, plugin *types.Plugin) {
 			}
 
 			log(ctx, parsed.msg, parsed.args...)
-		case err, ok := <-errChan:
-			if ok {
-				slog.ErrorContext(ctx, "streaming logs from plugin failed", "error", err)
+		case err, ok := <-errs:
+			if !ok {
+				// stop selecting the closed channel.
+				errs = nil
+				continue
 			}
+			slog.ErrorContext(ctx, "streaming logs from plugin failed", "error", err)
 		case <-ctx.Done():
 			// context is done, we stop streaming logs
 			slog.DebugContext(ctx, "stopping log streamer, context is done")
@@ -0,0 +1,179 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	credentialrefreshv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/credentialrefresh/v1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+// Process is a running plugin binary launched by Launch.
+type Process struct {
+	// Client is the HTTP client connected to the plugin.
+	Client *http.Client
+	// Location is the address the plugin serves its endpoints on.
+	Location string
+
+	process *os.Process
+	exited  chan struct{}
+}
+
+// Launch starts the plugin binary and waits until the plugin serves requests.
+// The logs of the plugin are streamed with logCtx until the process exits.
+//
+// If plugin.NewCmd is set, a new command is created for every launch. This allows
+// launching a plugin again on demand after its previous process exited, for example
+// because it reached its idle timeout. Otherwise, plugin.Cmd is started, which can only be done once.
+// If plugin.Sandbox is set, the resources of the process are restricted as configured.
+// If plugin.CredentialRefresh is set, the process gets its own credential refresh grant, see AllowCredentialRefresh.
+func Launch(ctx, logCtx context.Context, plugin *types.Plugin) (*Process, error) {
+	if plugin.NewCmd != nil {
+		if err := prepareCmd(plugin); err != nil {
+			return nil, fmt.Errorf("failed to prepare plugin %s: %w", plugin.ID, err)
+		}
+	}
+
+	var grant types.CredentialRefreshGrant
+	if plugin.CredentialRefresh != nil && plugin.Config.CredentialRefreshLocation != "" {
+		var err error
+		if grant, err = plugin.CredentialRefresh(); err != nil {
+			return nil, fmt.Errorf("failed to grant credential refresh to plugin %s: %w", plugin.ID, err)
+		}
+		// the token is passed in the environment, which unlike the arguments is not visible to other users.
+		plugin.Cmd.Env = append(plugin.Cmd.Environ(), credentialrefreshv1.TokenEnv+"="+grant.Token())
+	}
+	revoke := func() {
+		if grant != nil {
+			grant.Revoke()
+		}
+	}
+
+	release, err := applySandbox(plugin.ID, plugin.Cmd, plugin.Sandbox)
+	if err != nil {
+		revoke()
+		return nil, fmt.Errorf("failed to sandbox plugin %s: %w", plugin.ID, err)
+	}
+	if err := plugin.Cmd.Start(); err != nil {
+		release()
+		revoke()
+		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+	}
+
+	p := &Process{
+		process: plugin.Cmd.Process,
+		exited:  make(chan struct{}),
+	}
+	go func() {
+		// os.Process.Wait is used instead of exec.Cmd.Wait because the latter closes
+		// the stderr pipe, which might still be read by the log streamer.
+		_, _ = p.process.Wait()
+		release()
+		revoke()
+		close(p.exited)
+	}()
+
+	client, loc, err := WaitForPlugin(ctx, plugin)
+	if err != nil {
+		// Kill the orphaned subprocess to prevent resource leak
+		_ = p.Kill()
+		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+	}
+	p.Client = client
+	p.Location = loc
+	if closer, ok := client.Transport.(io.Closer); ok {
+		// the connection of the gRPC transport is not closed on its own once the plugin exited.
+		go func() {
+			<-p.exited
+			_ = closer.Close()
+		}()
+	}
+	if plugin.Launched != nil {
+		plugin.Launched(client, loc, p.exited)
+	}
+	if plugin.WrapTransport != nil {
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		wrapped := *client
+		wrapped.Transport = plugin.WrapTransport(transport)
+		p.Client = &wrapped
+	}
+	if grant != nil {
+		transport := p.Client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		wrapped := *p.Client
+		wrapped.Transport = &credentialRefreshTransport{RoundTripper: transport, grant: grant}
+		p.Client = &wrapped
+	}
+
+	// start log streaming once the plugin is up and running.
+	go StartLogStreamer(logCtx, plugin)
+
+	return p, nil
+}
+
+// Exited reports whether the process has exited, for example because the plugin reached its idle timeout.
+func (p *Process) Exited() bool {
+	select {
+	case <-p.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// Interrupt sends an interrupt signal to the plugin. All plugins should handle interrupt
+// signals gracefully. For Go, this is done automatically by the plugin SDK.
+// Interrupting a process that already exited is not an error.
+func (p *Process) Interrupt() error {
+	if err := p.process.Signal(os.Interrupt); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return nil
+}
+
+// Kill kills the plugin immediately. Killing a process that already exited is not an error.
+func (p *Process) Kill() error {
+	if err := p.process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return nil
+}
+
+// Wait waits until the process exited or the context is done.
+func (p *Process) Wait(ctx context.Context) error {
+	select {
+	case <-p.exited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// prepareCmd replaces the command and the communication pipes of the plugin with new ones.
+func prepareCmd(plugin *types.Plugin) error {
+	cmd, err := plugin.NewCmd(plugin.Config)
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	plugin.Cmd = cmd
+	plugin.Stderr = stderr
+	plugin.Stdout = stdout
+	return nil
+}
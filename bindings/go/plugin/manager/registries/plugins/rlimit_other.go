@@ -0,0 +1,15 @@
+//go:build !unix
+
+package plugins
+
+import (
+	"errors"
+	"os/exec"
+)
+
+const rlimitsSupported = false
+
+// setRlimits is not supported on this platform.
+func setRlimits(_ *exec.Cmd, _ []rlimit) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,31 @@
+//go:build unix
+
+package plugins
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const rlimitsSupported = true
+
+// setRlimits sets the resource limits of the command by starting it through a shell, which sets the limits
+// with its ulimit builtin and replaces itself with the command. The limits are thus in place before the
+// command runs, and the process started is the command itself.
+func setRlimits(cmd *exec.Cmd, limits []rlimit) error {
+	var script strings.Builder
+	for _, limit := range limits {
+		fmt.Fprintf(&script, "ulimit %s %d && ", limit.option, limit.value)
+	}
+	script.WriteString(`exec "$0" "$@"`)
+
+	shell, err := exec.LookPath("/bin/sh")
+	if err != nil {
+		return err
+	}
+	// $0 is the path of the command, $@ are its arguments.
+	cmd.Args = append([]string{shell, "-c", script.String(), cmd.Path}, cmd.Args[1:]...)
+	cmd.Path = shell
+	return nil
+}
@@ -0,0 +1,86 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+// ValidateSandbox checks that the sandbox is valid and can be applied on this platform.
+func ValidateSandbox(sandbox *types.Sandbox) error {
+	if sandbox == nil {
+		return nil
+	}
+	if err := sandbox.Validate(); err != nil {
+		return err
+	}
+	if sandbox.CgroupParent != "" {
+		if err := validateCgroupParent(sandbox.CgroupParent); err != nil {
+			return fmt.Errorf("invalid sandbox cgroup parent: %w", err)
+		}
+	}
+	if sandbox.Memory > 0 && sandbox.CgroupParent == "" && memoryRequiresCgroup {
+		return errors.New("sandbox memory can only be limited with a cgroup parent on this platform")
+	}
+	if rlimits(sandbox) != nil && !rlimitsSupported {
+		return fmt.Errorf("sandbox resource limits: %w", errors.ErrUnsupported)
+	}
+	return nil
+}
+
+// applySandbox restricts the resources of the command before it is started. The returned release function
+// frees the resources of the sandbox and must be called once the process exited or failed to start.
+// The environment of the command is not changed, it is filtered by the creator of the command.
+func applySandbox(id string, cmd *exec.Cmd, sandbox *types.Sandbox) (release func(), err error) {
+	release = func() {}
+	if sandbox == nil {
+		return release, nil
+	}
+	if sandbox.CgroupParent != "" {
+		if release, err = joinCgroup(id, cmd, sandbox); err != nil {
+			return nil, fmt.Errorf("failed to create cgroup: %w", err)
+		}
+	}
+	if limits := rlimits(sandbox); limits != nil {
+		if err := setRlimits(cmd, limits); err != nil {
+			release()
+			return nil, fmt.Errorf("failed to set resource limits: %w", err)
+		}
+	}
+	return release, nil
+}
+
+// rlimit is a resource limit of a process, named after the option of the ulimit shell builtin setting it.
+type rlimit struct {
+	option string
+	value  uint64
+}
+
+// rlimits returns the resource limits of the sandbox that are not enforced by a cgroup.
+func rlimits(sandbox *types.Sandbox) []rlimit {
+	var limits []rlimit
+	if sandbox.Memory > 0 && sandbox.CgroupParent == "" {
+		// ulimit -d is in KiB.
+		limits = append(limits, rlimit{option: "-d", value: uint64(sandbox.Memory+1023) / 1024})
+	}
+	if sandbox.CPUTime > 0 {
+		// ulimit -t is in seconds, and the process should at least get the configured time.
+		limits = append(limits, rlimit{option: "-t", value: uint64((sandbox.CPUTime + 999_999_999) / 1_000_000_000)})
+	}
+	if sandbox.OpenFiles > 0 {
+		limits = append(limits, rlimit{option: "-n", value: sandbox.OpenFiles})
+	}
+	return limits
+}
+
+// RunSandboxed runs the command to completion with the resources restricted by the sandbox, see exec.Cmd.Run.
+func RunSandboxed(id string, cmd *exec.Cmd, sandbox *types.Sandbox) error {
+	release, err := applySandbox(id, cmd, sandbox)
+	if err != nil {
+		return fmt.Errorf("failed to sandbox plugin %s: %w", id, err)
+	}
+	defer release()
+	return cmd.Run()
+}
@@ -0,0 +1,113 @@
+//go:build unix
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	blobtransformerv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/blobtransformer/v1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+func TestRunSandboxed(t *testing.T) {
+	r := require.New(t)
+	sandbox := &types.Sandbox{
+		Memory:    1 << 30,
+		CPUTime:   1500 * time.Millisecond,
+		OpenFiles: 64,
+	}
+
+	output := bytes.NewBuffer(nil)
+	cmd := exec.CommandContext(t.Context(), "/bin/sh", "-c", `ulimit -d; ulimit -t; ulimit -n; echo "$1"`, "sh", "argument with spaces")
+	cmd.Stdout = output
+	r.NoError(RunSandboxed("test", cmd, sandbox))
+	// memory is limited in KiB and the cpu time is rounded up to seconds, the arguments are passed unchanged.
+	r.Equal([]string{"1048576", "2", "64", "argument with spaces"}, strings.Split(strings.TrimSpace(output.String()), "\n"))
+}
+
+func TestValidateSandbox(t *testing.T) {
+	r := require.New(t)
+	r.NoError(ValidateSandbox(nil))
+	r.Error(ValidateSandbox(&types.Sandbox{Memory: -1}))
+	r.ErrorContains(ValidateSandbox(&types.Sandbox{CPU: 0.5}), "cgroup parent")
+	r.ErrorContains(ValidateSandbox(&types.Sandbox{CgroupParent: "relative"}), "absolute")
+	r.Error(ValidateSandbox(&types.Sandbox{CgroupParent: t.TempDir()}))
+
+	err := ValidateSandbox(&types.Sandbox{Memory: 1 << 30})
+	if goruntime.GOOS == "linux" {
+		r.ErrorContains(err, "cgroup parent", "memory is only limited with a cgroup on linux")
+	} else {
+		r.NoError(err)
+	}
+}
+
+func TestLaunch_GoPluginUnderMemoryLimit(t *testing.T) {
+	r := require.New(t)
+	path := filepath.Join("..", "..", "..", "tmp", "testdata", "test-plugin-blobtransformer")
+	_, err := os.Stat(path)
+	r.NoError(err, "test plugin not found, please build the plugin under tmp/testdata/test-plugin-blobtransformer first")
+
+	ctx := t.Context()
+	id := "test-plugin-memory-limit"
+	plugin := &types.Plugin{
+		ID:   id,
+		Path: path,
+		Config: types.Config{
+			ID:         id,
+			Type:       types.Socket,
+			PluginType: blobtransformerv1.BlobTransformerPluginType,
+		},
+		NewCmd: func(config types.Config) (*exec.Cmd, error) {
+			serialized, err := json.Marshal(config)
+			if err != nil {
+				return nil, err
+			}
+			return exec.CommandContext(ctx, path, "--config", string(serialized)), nil
+		},
+		// the Go runtime reserves more address space than this, so the plugin would not start with an address space limit.
+		Sandbox: &types.Sandbox{Memory: 256 << 20},
+	}
+
+	process, err := Launch(ctx, ctx, plugin)
+	r.NoError(err, "a Go plugin must serve requests under the memory limit")
+	t.Cleanup(func() {
+		r.NoError(process.Kill())
+		r.NoError(process.Wait(context.Background()))
+		for _, file := range []string{process.Location, process.Location + ".lock"} {
+			if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+				t.Error(err)
+			}
+		}
+	})
+	r.False(process.Exited())
+
+	if goruntime.GOOS == "linux" {
+		limits, err := os.ReadFile(fmt.Sprintf("/proc/%d/limits", process.process.Pid))
+		r.NoError(err)
+		r.Regexp(`Max data size\s+268435456\s+268435456\s+bytes`, string(limits))
+	}
+}
+
+func TestFilterEnvironment(t *testing.T) {
+	r := require.New(t)
+	env := []string{"PATH=/bin", "HOME=/root", "OCM_TOKEN=secret", "OCM_CONFIG=ocm.yaml", "SECRET=value"}
+
+	r.Equal(env, (&types.Sandbox{}).FilterEnvironment(env))
+	r.Equal([]string{"PATH=/bin", "OCM_TOKEN=secret", "OCM_CONFIG=ocm.yaml"},
+		(&types.Sandbox{Environment: []string{"PATH", "OCM_*"}}).FilterEnvironment(env))
+	r.Empty((&types.Sandbox{Environment: []string{}}).FilterEnvironment(env))
+	r.NotNil((&types.Sandbox{Environment: []string{}}).FilterEnvironment(env))
+}
@@ -0,0 +1,45 @@
+//go:build linux
+
+package plugins
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// selinuxAttribute is the extended attribute holding the SELinux security context of a file.
+const selinuxAttribute = "security.selinux"
+
+// setSELinuxLabel sets the SELinux security context of the file at path.
+// It returns errors.ErrUnsupported if the file system does not support SELinux labels.
+func setSELinuxLabel(path, label string) error {
+	if err := syscall.Setxattr(path, selinuxAttribute, []byte(label), 0); err != nil {
+		if errors.Is(err, syscall.ENOTSUP) {
+			return fmt.Errorf("%w: %w", errors.ErrUnsupported, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// getSELinuxLabel returns the SELinux security context of the file at path.
+// It returns errors.ErrUnsupported if the file has no SELinux label, e.g. because SELinux is disabled.
+func getSELinuxLabel(path string) (string, error) {
+	buf := make([]byte, 256)
+	for {
+		n, err := syscall.Getxattr(path, selinuxAttribute, buf)
+		switch {
+		case errors.Is(err, syscall.ERANGE):
+			buf = make([]byte, 2*len(buf))
+			continue
+		case errors.Is(err, syscall.ENOTSUP), errors.Is(err, syscall.ENODATA):
+			return "", fmt.Errorf("%w: %w", errors.ErrUnsupported, err)
+		case err != nil:
+			return "", err
+		}
+		// the label is terminated with a null byte.
+		return string(bytes.TrimRight(buf[:n], "\x00")), nil
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package plugins
+
+import "errors"
+
+// setSELinuxLabel is not supported on this platform.
+func setSELinuxLabel(_, _ string) error {
+	return errors.ErrUnsupported
+}
+
+// getSELinuxLabel is not supported on this platform.
+func getSELinuxLabel(_ string) (string, error) {
+	return "", errors.ErrUnsupported
+}
@@ -0,0 +1,116 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+// ErrSocketPolicyViolation is returned if the socket of a plugin or its directory does not match the socket policy.
+var ErrSocketPolicyViolation = errors.New("socket policy violation")
+
+// ValidateSocketDirectory checks that the directory of the socket policy exists and is not writable by others,
+// so that other users can neither replace the socket of a plugin nor create their own.
+func ValidateSocketDirectory(policy *types.SocketPolicy) error {
+	if policy == nil || policy.Directory == "" {
+		return nil
+	}
+	info, err := os.Stat(policy.Directory)
+	if err != nil {
+		return fmt.Errorf("failed to access socket directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%w: socket directory %q is not a directory", ErrSocketPolicyViolation, policy.Directory)
+	}
+	if info.Mode().Perm()&0o002 != 0 {
+		return fmt.Errorf("%w: socket directory %q is writable by others", ErrSocketPolicyViolation, policy.Directory)
+	}
+	return nil
+}
+
+// ApplySocketPolicy sets the permissions, the owner and the SELinux label of the socket at path as configured
+// by the policy. The SELinux label is skipped on hosts without SELinux support.
+func ApplySocketPolicy(ctx context.Context, path string, policy *types.SocketPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.Mode != 0 {
+		if err := os.Chmod(path, policy.Mode); err != nil {
+			return fmt.Errorf("failed to set socket mode: %w", err)
+		}
+	}
+	if policy.UID != nil || policy.GID != nil {
+		uid, gid := -1, -1
+		if policy.UID != nil {
+			uid = *policy.UID
+		}
+		if policy.GID != nil {
+			gid = *policy.GID
+		}
+		if err := os.Lchown(path, uid, gid); err != nil {
+			return fmt.Errorf("failed to set socket owner: %w", err)
+		}
+	}
+	if policy.SELinuxLabel != "" {
+		if err := setSELinuxLabel(path, policy.SELinuxLabel); err != nil {
+			if !errors.Is(err, errors.ErrUnsupported) {
+				return fmt.Errorf("failed to set socket SELinux label: %w", err)
+			}
+			slog.DebugContext(ctx, "skipping SELinux label of socket, SELinux is not supported", "path", path)
+		}
+	}
+	return nil
+}
+
+// ValidateSocket checks that the socket at path matches the policy before the manager connects to it.
+// The SELinux label is only validated on hosts with SELinux support.
+func ValidateSocket(ctx context.Context, path string, policy *types.SocketPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.Directory != "" && filepath.Dir(filepath.Clean(path)) != filepath.Clean(policy.Directory) {
+		return fmt.Errorf("%w: socket %q is not located in directory %q", ErrSocketPolicyViolation, path, policy.Directory)
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return fmt.Errorf("failed to access socket: %w", err)
+	}
+	if info.Mode().Type() != os.ModeSocket {
+		return fmt.Errorf("%w: %q is not a socket", ErrSocketPolicyViolation, path)
+	}
+	if policy.Mode != 0 && info.Mode().Perm() != policy.Mode {
+		return fmt.Errorf("%w: socket %q has mode %#o instead of %#o", ErrSocketPolicyViolation, path, uint32(info.Mode().Perm()), uint32(policy.Mode))
+	}
+
+	if policy.UID != nil || policy.GID != nil {
+		uid, gid, err := fileOwner(info)
+		if err != nil {
+			return fmt.Errorf("failed to determine socket owner: %w", err)
+		}
+		if policy.UID != nil && uid != *policy.UID {
+			return fmt.Errorf("%w: socket %q is owned by uid %d instead of %d", ErrSocketPolicyViolation, path, uid, *policy.UID)
+		}
+		if policy.GID != nil && gid != *policy.GID {
+			return fmt.Errorf("%w: socket %q is owned by gid %d instead of %d", ErrSocketPolicyViolation, path, gid, *policy.GID)
+		}
+	}
+
+	if policy.SELinuxLabel != "" {
+		label, err := getSELinuxLabel(path)
+		switch {
+		case errors.Is(err, errors.ErrUnsupported):
+			slog.DebugContext(ctx, "skipping validation of socket SELinux label, SELinux is not supported", "path", path)
+		case err != nil:
+			return fmt.Errorf("failed to read socket SELinux label: %w", err)
+		case label != policy.SELinuxLabel:
+			return fmt.Errorf("%w: socket %q has SELinux label %q instead of %q", ErrSocketPolicyViolation, path, label, policy.SELinuxLabel)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package plugins
+
+import (
+	"errors"
+	"os"
+)
+
+// fileOwner is not supported on this platform.
+func fileOwner(_ os.FileInfo) (uid, gid int, err error) {
+	return 0, 0, errors.ErrUnsupported
+}
@@ -0,0 +1,70 @@
+package plugins
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+func TestSocketPolicy(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "plugin.socket")
+
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "unix", socket)
+	r.NoError(err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	uid, gid := os.Getuid(), os.Getgid()
+	policy := &types.SocketPolicy{
+		Directory: dir,
+		Mode:      0o600,
+		UID:       &uid,
+		GID:       &gid,
+	}
+	r.NoError(policy.Validate())
+	r.NoError(ValidateSocketDirectory(policy))
+	r.NoError(ApplySocketPolicy(ctx, socket, policy))
+	r.NoError(ValidateSocket(ctx, socket, policy))
+
+	info, err := os.Stat(socket)
+	r.NoError(err)
+	r.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	t.Run("rejects sockets not matching the policy", func(t *testing.T) {
+		r := require.New(t)
+		otherUID := uid + 1
+		for name, violating := range map[string]*types.SocketPolicy{
+			"directory": {Directory: t.TempDir()},
+			"mode":      {Mode: 0o660},
+			"owner":     {UID: &otherUID},
+		} {
+			r.ErrorIs(ValidateSocket(ctx, socket, violating), ErrSocketPolicyViolation, name)
+		}
+
+		file := filepath.Join(dir, "file")
+		r.NoError(os.WriteFile(file, nil, 0o600))
+		r.ErrorIs(ValidateSocket(ctx, file, &types.SocketPolicy{}), ErrSocketPolicyViolation, "regular files are no sockets")
+	})
+
+	t.Run("rejects invalid policies", func(t *testing.T) {
+		r := require.New(t)
+		negative := -1
+		r.Error((&types.SocketPolicy{Directory: "relative"}).Validate())
+		r.Error((&types.SocketPolicy{Mode: os.ModeSetuid | 0o600}).Validate())
+		r.Error((&types.SocketPolicy{UID: &negative}).Validate())
+
+		shared := t.TempDir()
+		r.NoError(os.Chmod(shared, 0o777))
+		r.ErrorIs(ValidateSocketDirectory(&types.SocketPolicy{Directory: shared}), ErrSocketPolicyViolation)
+	})
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package plugins
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// fileOwner returns the ids of the user and the group owning the file.
+func fileOwner(info os.FileInfo) (uid, gid int, err error) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, errors.ErrUnsupported
+	}
+	return int(stat.Uid), int(stat.Gid), nil
+}
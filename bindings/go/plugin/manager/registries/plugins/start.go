@@ -0,0 +1,26 @@
+package plugins
+
+import (
+	"context"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+)
+
+// StartPlugin launches the plugin with the given ID ahead of its first use, so that the first call to the
+// plugin does not have to wait for its startup. It implements StartPlugin of the plugin registries, which
+// hold their lock while calling it.
+//
+// running is the process the registry started for the plugin before, if any. StartPlugin does nothing if
+// that process is still running or if the plugin is not among the registered plugins. Otherwise, start
+// launches the plugin and records it as running plugin of the registry.
+func StartPlugin[K comparable](ctx context.Context, id string, running *Process, registered map[K]types.Plugin, start func(ctx context.Context, plugin *types.Plugin) error) error {
+	if running != nil && !running.Exited() {
+		return nil
+	}
+	for _, plugin := range registered {
+		if plugin.ID == id {
+			return start(ctx, &plugin)
+		}
+	}
+	return nil
+}
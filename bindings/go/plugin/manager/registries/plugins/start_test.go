@@ -0,0 +1,35 @@
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func TestStartPlugin(t *testing.T) {
+	registered := map[runtime.Type]types.Plugin{
+		runtime.NewVersionedType("Test", "v1"): {ID: "test-plugin"},
+	}
+
+	t.Run("starts a registered plugin", func(t *testing.T) {
+		r := require.New(t)
+		var started []string
+		r.NoError(StartPlugin(t.Context(), "test-plugin", nil, registered, func(_ context.Context, plugin *types.Plugin) error {
+			started = append(started, plugin.ID)
+			return nil
+		}))
+		r.Equal([]string{"test-plugin"}, started)
+	})
+
+	t.Run("ignores plugins that are not registered", func(t *testing.T) {
+		r := require.New(t)
+		r.NoError(StartPlugin(t.Context(), "other-plugin", nil, registered, func(context.Context, *types.Plugin) error {
+			r.Fail("unregistered plugin must not be started")
+			return nil
+		}))
+	})
+}
@@ -24,6 +24,10 @@ type KV struct {
 
 // WaitForPlugin sets up the HTTP client for the plugin and waits for it to become available.
 // It returns the configured HTTP client, the plugin location, and any error encountered.
+// If the plugin serves its endpoints with types.TransportGRPC, the requests of the client are sent
+// with the gRPC transport. The client then implements io.Closer to close the connection to the plugin.
+// If the plugin serves its endpoints on a unix domain socket, the socket is validated against the socket
+// policy of the plugin before connecting, see ValidateSocket.
 func WaitForPlugin(ctx context.Context, plugin *types.Plugin) (*http.Client, string, error) {
 	interval := 100 * time.Millisecond
 	timer := time.NewTicker(interval)
@@ -36,7 +40,13 @@ func WaitForPlugin(ctx context.Context, plugin *types.Plugin) (*http.Client, str
 
 	slog.DebugContext(ctx, "got plugin location", "location", location)
 
-	client, err := connect(ctx, plugin.ID, location, plugin.Config.Type)
+	if plugin.Config.Type == types.Socket {
+		if err := ValidateSocket(ctx, location, plugin.Config.Socket); err != nil {
+			return nil, "", fmt.Errorf("refusing to connect to plugin %s: %w", plugin.ID, err)
+		}
+	}
+
+	client, err := connect(ctx, plugin.ID, location, plugin.Config.Type, plugin.Config.Transport)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to connect to plugin %s: %w", plugin.ID, err)
 	}
@@ -110,6 +120,17 @@ func getPluginLocation(ctx context.Context, plugin *types.Plugin) (string, error
 			case strings.HasPrefix(line, "http+unix://"):
 				location <- strings.TrimPrefix(line, "http+unix://")
 
+				// stop the go routine, we have what we came for
+				return
+			case strings.HasPrefix(line, "grpc://"):
+				// the location is the base of the tunneled requests, see Call.
+				location <- "http://" + strings.TrimPrefix(line, "grpc://")
+
+				// stop the go routine, we have what we came for
+				return
+			case strings.HasPrefix(line, "grpc+unix://"):
+				location <- strings.TrimPrefix(line, "grpc+unix://")
+
 				// stop the go routine, we have what we came for
 				return
 			default:
@@ -136,7 +157,7 @@ func getPluginLocation(ctx context.Context, plugin *types.Plugin) (string, error
 // connect will create a client that sets up connection based on the plugin's connection type.
 // That is either a Unix socket or a TCP based connection. It does this by setting the `DialContext` using
 // the right network location.
-func connect(_ context.Context, id, location string, typ types.ConnectionType) (*http.Client, error) {
+func connect(_ context.Context, id, location string, typ types.ConnectionType, transport types.Transport) (*http.Client, error) {
 	var network string
 	switch typ {
 	case types.Socket:
@@ -148,6 +169,14 @@ func connect(_ context.Context, id, location string, typ types.ConnectionType) (
 		return nil, fmt.Errorf("invalid connection type: %s", typ)
 	}
 
+	switch transport {
+	case "", types.TransportHTTP:
+	case types.TransportGRPC:
+		return connectGRPC(id, network, location)
+	default:
+		return nil, fmt.Errorf("invalid transport: %s", transport)
+	}
+
 	dialer := net.Dialer{
 		Timeout: 30 * time.Second,
 	}
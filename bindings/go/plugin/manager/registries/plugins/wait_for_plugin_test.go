@@ -140,7 +140,7 @@ func TestWaitForPlugin(t *testing.T) {
 func TestConnect(t *testing.T) {
 	t.Run("socket connection", func(t *testing.T) {
 		ctx := context.Background()
-		client, err := connect(ctx, "test-socket", "/path/to/socket", types.Socket)
+		client, err := connect(ctx, "test-socket", "/path/to/socket", types.Socket, types.TransportHTTP)
 
 		require.NoError(t, err)
 		require.NotNil(t, client)
@@ -155,7 +155,7 @@ func TestConnect(t *testing.T) {
 
 	t.Run("TCP connection", func(t *testing.T) {
 		ctx := context.Background()
-		client, err := connect(ctx, "test-tcp", "localhost:8080", types.TCP)
+		client, err := connect(ctx, "test-tcp", "localhost:8080", types.TCP, types.TransportHTTP)
 
 		require.NoError(t, err)
 		require.NotNil(t, client)
@@ -171,7 +171,7 @@ func TestConnect(t *testing.T) {
 	t.Run("connection attempt with socket type", func(t *testing.T) {
 		// Don't expect this to succeed, but verify it attempts to connect with unix network
 		ctx := context.Background()
-		client, err := connect(ctx, "test-socket", "/non/existent/socket", types.Socket)
+		client, err := connect(ctx, "test-socket", "/non/existent/socket", types.Socket, types.TransportHTTP)
 
 		require.NoError(t, err)
 		require.NotNil(t, client)
@@ -190,7 +190,7 @@ func TestConnect(t *testing.T) {
 	t.Run("connection attempt with TCP type", func(t *testing.T) {
 		// Don't expect this to succeed, but verify it attempts to connect with tcp network
 		ctx := context.Background()
-		client, err := connect(ctx, "test-tcp", "localhost:12345", types.TCP)
+		client, err := connect(ctx, "test-tcp", "localhost:12345", types.TCP, types.TransportHTTP)
 
 		require.NoError(t, err)
 		require.NotNil(t, client)
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"sync"
 
 	"ocm.software/open-component-model/bindings/go/blob"
@@ -14,6 +12,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/plugins"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // NewResourceRegistry creates a new registry and initializes maps.
@@ -102,10 +101,10 @@ func (r *ResourceRegistry) GetResourcePlugin(ctx context.Context, spec runtime.T
 func (r *ResourceRegistry) getPlugin(ctx context.Context, spec runtime.Type) (resourcev1.ReadWriteResourcePluginContract, error) {
 	plugin, ok := r.registry[spec]
 	if !ok {
-		return nil, fmt.Errorf("failed to get plugin for typ %q", spec)
+		return nil, ocmerrors.Unsupported(fmt.Errorf("failed to get plugin for typ %q", spec))
 	}
 
-	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok && !existingPlugin.process.Exited() {
 		return existingPlugin.Plugin, nil
 	}
 
@@ -132,8 +131,8 @@ func (r *ResourceRegistry) RegisterInternalResourcePlugin(plugin BuiltinResource
 }
 
 type constructedPlugin struct {
-	Plugin resourcev1.ReadWriteResourcePluginContract
-	cmd    *exec.Cmd
+	Plugin  resourcev1.ReadWriteResourcePluginContract
+	process *plugins.Process
 }
 
 // Shutdown will loop through all _STARTED_ plugins and will send an Interrupt signal to them.
@@ -145,7 +144,7 @@ func (r *ResourceRegistry) Shutdown(ctx context.Context) error {
 	var errs error
 	for _, p := range r.constructedPlugins {
 		// The plugins should handle the Interrupt signal for shutdowns.
-		if perr := p.cmd.Process.Signal(os.Interrupt); perr != nil && !errors.Is(perr, os.ErrProcessDone) {
+		if perr := p.process.Interrupt(); perr != nil {
 			errs = errors.Join(errs, perr)
 		}
 	}
@@ -153,23 +152,31 @@ func (r *ResourceRegistry) Shutdown(ctx context.Context) error {
 	return errs
 }
 
-func startAndReturnPlugin(ctx context.Context, r *ResourceRegistry, plugin *types.Plugin) (resourcev1.ReadWriteResourcePluginContract, error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *ResourceRegistry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *types.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+func startAndReturnPlugin(ctx context.Context, r *ResourceRegistry, plugin *types.Plugin) (resourcev1.ReadWriteResourcePluginContract, error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	// start log streaming once the plugin is up and running.
-	go plugins.StartLogStreamer(r.ctx, plugin)
-
-	resourcePlugin := NewResourceRepositoryPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
+	resourcePlugin := NewResourceRepositoryPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
 	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: resourcePlugin,
-		cmd:    plugin.Cmd,
+		Plugin:  resourcePlugin,
+		process: process,
 	}
 
 	return resourcePlugin, nil
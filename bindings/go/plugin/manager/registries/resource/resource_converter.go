@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/contracts/resource/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/blobs"
@@ -61,12 +61,13 @@ func (r *resourcePluginConverter) UploadResource(ctx context.Context, resource *
 		return nil, fmt.Errorf("failed to convert resource: %w", err)
 	}
 
-	tmp, err := os.CreateTemp("", "resource")
+	tmp, err := tempfile.CreateTemp(ctx, "", "resource")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temp file: %w", err)
 	}
+	// the plugin has consumed the file once it responded, so it is removed right away.
 	defer func() {
-		err = errors.Join(err, tmp.Close())
+		err = errors.Join(err, tmp.Close(), tempfile.Release(ctx, tmp.Name()))
 	}()
 
 	if err := filesystem.CopyBlobToOSPath(content, tmp.Name()); err != nil {
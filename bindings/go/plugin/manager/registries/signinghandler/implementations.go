@@ -68,6 +68,7 @@ func (p *SigningHandlerPlugin) GetSignerIdentity(ctx context.Context, request *s
 	if err := plugins.Call(ctx, p.client, p.config.Type, p.location, GetSignerIdentity, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(&identity)); err != nil {
 		return nil, fmt.Errorf("failed to get identity from plugin %q: %w", p.ID, err)
 	}
+	plugins.AllowCredentialRefresh(p.client, identity.Identity)
 
 	return &identity, nil
 }
@@ -81,6 +82,7 @@ func (p *SigningHandlerPlugin) GetVerifierIdentity(ctx context.Context, request
 	if err := plugins.Call(ctx, p.client, p.config.Type, p.location, GetVerifierIdentity, http.MethodPost, plugins.WithPayload(request), plugins.WithResult(&identity)); err != nil {
 		return nil, fmt.Errorf("failed to get identity from plugin %q: %w", p.ID, err)
 	}
+	plugins.AllowCredentialRefresh(p.client, identity.Identity)
 
 	return &identity, nil
 }
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"sync"
 
 	signingv1 "ocm.software/open-component-model/bindings/go/plugin/manager/contracts/signing/v1"
@@ -108,7 +106,7 @@ func (r *SigningRegistry) getPlugin(ctx context.Context, spec runtime.Type) (sig
 		return nil, fmt.Errorf("no signing handler plugin registered for type %q", spec)
 	}
 
-	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok {
+	if existingPlugin, ok := r.constructedPlugins[plugin.ID]; ok && !existingPlugin.process.Exited() {
 		return existingPlugin.Plugin, nil
 	}
 
@@ -137,8 +135,8 @@ func (r *SigningRegistry) RegisterInternalComponentSignatureHandler(
 }
 
 type constructedPlugin struct {
-	Plugin signingv1.SignatureHandlerContract[runtime.Typed]
-	cmd    *exec.Cmd
+	Plugin  signingv1.SignatureHandlerContract[runtime.Typed]
+	process *plugins.Process
 }
 
 // Shutdown will loop through all _STARTED_ plugins and will send an Interrupt signal to them.
@@ -150,7 +148,7 @@ func (r *SigningRegistry) Shutdown(ctx context.Context) error {
 	var errs error
 	for _, p := range r.constructedPlugins {
 		// The plugins should handle the Interrupt signal for shutdowns.
-		if perr := p.cmd.Process.Signal(os.Interrupt); perr != nil && !errors.Is(perr, os.ErrProcessDone) {
+		if perr := p.process.Interrupt(); perr != nil {
 			errs = errors.Join(errs, perr)
 		}
 	}
@@ -158,23 +156,31 @@ func (r *SigningRegistry) Shutdown(ctx context.Context) error {
 	return errs
 }
 
-func startAndReturnPlugin(ctx context.Context, r *SigningRegistry, plugin *types.Plugin) (signingv1.SignatureHandlerContract[runtime.Typed], error) {
-	if err := plugin.Cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start plugin: %s, %w", plugin.ID, err)
+// StartPlugin launches the plugin with the given ID ahead of its first use, see plugins.StartPlugin.
+func (r *SigningRegistry) StartPlugin(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var running *plugins.Process
+	if p := r.constructedPlugins[id]; p != nil {
+		running = p.process
 	}
+	return plugins.StartPlugin(ctx, id, running, r.registry, func(ctx context.Context, plugin *types.Plugin) error {
+		_, err := startAndReturnPlugin(ctx, r, plugin)
+		return err
+	})
+}
 
-	client, loc, err := plugins.WaitForPlugin(ctx, plugin)
+func startAndReturnPlugin(ctx context.Context, r *SigningRegistry, plugin *types.Plugin) (signingv1.SignatureHandlerContract[runtime.Typed], error) {
+	process, err := plugins.Launch(ctx, r.ctx, plugin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for plugin to start: %w", err)
+		return nil, err
 	}
 
-	// start log streaming once the plugin is up and running.
-	go plugins.StartLogStreamer(r.ctx, plugin)
-
-	instance := NewSigningHandlerPlugin(client, plugin.ID, plugin.Path, plugin.Config, loc, r.capabilities[plugin.ID])
+	instance := NewSigningHandlerPlugin(process.Client, plugin.ID, plugin.Path, plugin.Config, process.Location, r.capabilities[plugin.ID])
 	r.constructedPlugins[plugin.ID] = &constructedPlugin{
-		Plugin: instance,
-		cmd:    plugin.Cmd,
+		Plugin:  instance,
+		process: process,
 	}
 
 	return instance, nil
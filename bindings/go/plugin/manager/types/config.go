@@ -1,6 +1,10 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"ocm.software/open-component-model/bindings/go/runtime"
@@ -13,6 +17,18 @@ const (
 	TCP    ConnectionType = "tcp"
 )
 
+// Transport is the protocol with which the manager calls the endpoints of a plugin.
+type Transport string
+
+const (
+	// TransportHTTP serves the endpoints with HTTP/1.1. All plugins support it.
+	TransportHTTP Transport = "http"
+	// TransportGRPC tunnels the requests to the endpoints through streams of a single multiplexed
+	// HTTP/2 connection with binary framing, instead of a connection per concurrent request.
+	// The payloads of the endpoints are the same as with TransportHTTP.
+	TransportGRPC Transport = "grpc"
+)
+
 // Config defines information about the plugin. It contains what type of plugin we are dealing with,
 // the id of the plugin and the connection type. The connection type is either unix ( preferred) or
 // tcp based. The plugin will perform certain actions based on the connection type such as create the
@@ -29,4 +45,55 @@ type Config struct {
 	IdleTimeout *time.Duration `json:"idleTimeout,omitempty"`
 	// ConfigTypes defines the configurations that are sent to the plugin.
 	ConfigTypes []*runtime.Raw `json:"configTypes,omitempty"`
+	// CredentialRefreshLocation is the location of the credential refresh channel of the manager, if it offers one.
+	// It is reached with the same connection type as the plugin. See the credentialrefresh contract for details.
+	CredentialRefreshLocation string `json:"credentialRefreshLocation,omitempty"`
+	// SharedMemoryBlobs is set if the manager accepts blobs returned in LocationTypeSharedMemory locations.
+	SharedMemoryBlobs bool `json:"sharedMemoryBlobs,omitempty"`
+	// Transport is the transport the plugin serves its endpoints with. If empty, TransportHTTP is used.
+	// The manager only selects a transport the plugin advertised in its capabilities.
+	Transport Transport `json:"transport,omitempty"`
+	// Socket configures the unix domain socket the plugin serves its endpoints on. It is only used with the
+	// Socket connection type. If nil, the socket is created in /tmp with the permissions of the plugin process.
+	Socket *SocketPolicy `json:"socket,omitempty"`
+	// ContractVersions are the negotiated versions of the contracts the plugin serves its capabilities with.
+	// Capabilities without a version are served with DefaultContractVersion.
+	ContractVersions map[PluginType]string `json:"contractVersions,omitempty"`
+}
+
+// SocketPolicy configures where a plugin creates its unix domain socket and who may connect to it, for hosts
+// that restrict the location and permissions of sockets. The plugin applies the policy right after creating
+// the socket, and the manager validates the socket against it before connecting.
+type SocketPolicy struct {
+	// Directory is the absolute path of the existing directory the socket is created in.
+	// It must not be writable by others. If empty, the socket is created in /tmp.
+	Directory string `json:"directory,omitempty"`
+	// Mode contains the permission bits of the socket, e.g. 0600 to only let the owner connect.
+	// If zero, the permissions result from the umask of the plugin process.
+	Mode os.FileMode `json:"mode,omitempty"`
+	// UID is the id of the user owning the socket. If nil, the socket is owned by the user of the plugin process.
+	UID *int `json:"uid,omitempty"`
+	// GID is the id of the group owning the socket. If nil, the socket is owned by the group of the plugin process.
+	GID *int `json:"gid,omitempty"`
+	// SELinuxLabel is the SELinux security context of the socket, e.g. system_u:object_r:container_file_t:s0.
+	// It is a hint: it is only applied and validated on hosts with SELinux support.
+	SELinuxLabel string `json:"seLinuxLabel,omitempty"`
+}
+
+// Validate checks that the policy can be applied. It does not access the file system.
+func (p *SocketPolicy) Validate() error {
+	var errs []error
+	if p.Directory != "" && !filepath.IsAbs(p.Directory) {
+		errs = append(errs, fmt.Errorf("socket directory %q is not an absolute path", p.Directory))
+	}
+	if p.Mode&^os.ModePerm != 0 {
+		errs = append(errs, fmt.Errorf("socket mode %#o contains more than permission bits", uint32(p.Mode)))
+	}
+	if p.UID != nil && *p.UID < 0 {
+		errs = append(errs, fmt.Errorf("socket uid %d is negative", *p.UID))
+	}
+	if p.GID != nil && *p.GID < 0 {
+		errs = append(errs, fmt.Errorf("socket gid %d is negative", *p.GID))
+	}
+	return errors.Join(errs...)
 }
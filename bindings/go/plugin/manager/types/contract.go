@@ -0,0 +1,58 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// ContractVersionsEnv is the environment variable in which the manager advertises the contract versions it
+// supports to the capabilities command of a plugin, encoded as JSON ContractVersions.
+const ContractVersionsEnv = "OCM_PLUGIN_CONTRACT_VERSIONS"
+
+// DefaultContractVersion is the version of the contract of every capability that existed before contract
+// versions were negotiated. Plugins that do not negotiate a version serve their capabilities with it.
+const DefaultContractVersion = "v1"
+
+// ContractVersions contains the versions of the contracts per capability type,
+// in the order of preference, e.g. {"componentVersionRepository": ["v2", "v1"]}.
+type ContractVersions map[PluginType][]string
+
+// Negotiate returns the first of the offered versions of the capability that is also supported, or
+// false if there is no version both sides support. The offered versions are in the order of preference.
+func Negotiate(offered, supported []string) (string, bool) {
+	for _, version := range offered {
+		if slices.Contains(supported, version) {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// ContractVersionsFromEnv returns the contract versions advertised by the manager in ContractVersionsEnv.
+// It returns nil if the manager did not advertise any versions, which is the case for managers
+// that do not negotiate versions and only support DefaultContractVersion.
+func ContractVersionsFromEnv() (ContractVersions, error) {
+	value, ok := os.LookupEnv(ContractVersionsEnv)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	versions := ContractVersions{}
+	if err := json.Unmarshal([]byte(value), &versions); err != nil {
+		return nil, fmt.Errorf("failed to decode contract versions in %s: %w", ContractVersionsEnv, err)
+	}
+	return versions, nil
+}
+
+// Contract is the result of the negotiation of the contract version of a capability, reported by the plugin
+// in its capabilities.
+type Contract struct {
+	// Capability is the type of the capability.
+	Capability PluginType `json:"capability"`
+	// Version is the version the plugin chose out of the versions offered by the manager.
+	// It is empty if the plugin supports none of them.
+	Version string `json:"version,omitempty"`
+	// Supported are the versions of the contract the plugin supports.
+	Supported []string `json:"supported"`
+}
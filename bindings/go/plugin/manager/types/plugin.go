@@ -2,16 +2,51 @@ package types
 
 import (
 	"io"
+	"net/http"
 	"os/exec"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
+// CredentialRefreshGrant grants a single plugin process access to the credential refresh channel of the manager.
+type CredentialRefreshGrant interface {
+	// Token is the token the process authenticates its refresh requests with.
+	Token() string
+	// Allow permits the process to refresh the credentials of the identity.
+	Allow(identity runtime.Identity)
+	// Revoke invalidates the token. It is called once the process exited.
+	Revoke()
+}
+
 // Plugin has information about the given plugin backed by the constructed CMD. This command will be called
 // during the fetch operation to actually start plugin.
 type Plugin struct {
-	ID     string
-	Path   string
-	Config Config
-	Cmd    *exec.Cmd
+	ID   string
+	Path string
+	// Version is the version the plugin advertised in its capabilities. It is empty if the plugin
+	// did not advertise a version.
+	Version string
+	Config  Config
+	Cmd     *exec.Cmd
+	// NewCmd creates a new command for the plugin binary that passes config to the plugin. If set, it is
+	// used instead of Cmd for every launch of the plugin, so the plugin can be launched again after its
+	// previous process exited, e.g. because it reached its idle timeout, or with a different configuration.
+	NewCmd func(config Config) (*exec.Cmd, error)
+	// WrapTransport, if set, wraps the transport of the client that is connected to the plugin on every launch,
+	// for example to record usage statistics of the plugin. Requests that check the health of the plugin are
+	// not sent through the wrapped transport.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+	// Launched, if set, is called on every launch of the plugin with the client connected to the plugin, the location
+	// the plugin serves its endpoints on and a channel that is closed once the process exited, for example to scrape
+	// the metrics of the plugin. Requests sent with the client are not sent through the wrapped transport.
+	Launched func(client *http.Client, location string, exited <-chan struct{})
+	// CredentialRefresh, if set, issues a credential refresh grant for every launch of the plugin.
+	// The token of the grant is passed to the process in the environment and revoked once the process exited.
+	// It is only used if Config.CredentialRefreshLocation is set.
+	CredentialRefresh func() (CredentialRefreshGrant, error)
+	// Sandbox, if set, restricts the resources of the plugin process on every launch.
+	// The environment of the command has to be filtered by the creator of the command.
+	Sandbox *Sandbox
 	// Stderr pipe will contain a link to the commands stderr output to stream back
 	// potential more information to the manager or the runtime.
 	Stderr io.ReadCloser
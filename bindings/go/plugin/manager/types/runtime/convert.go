@@ -13,6 +13,9 @@ func ConvertToSpec(pluginSpec *PluginSpec) (*spec.PluginSpec, error) {
 	plugin := &spec.PluginSpec{
 		CapabilitySpecs:      make([]*runtime.Raw, len(pluginSpec.CapabilitySpecs)),
 		SupportedConfigTypes: pluginSpec.SupportedConfigTypes,
+		Transports:           pluginSpec.Transports,
+		Version:              pluginSpec.Version,
+		Contracts:            pluginSpec.Contracts,
 	}
 
 	for index, capability := range pluginSpec.CapabilitySpecs {
@@ -29,14 +32,14 @@ func ConvertFromSpec(scheme *runtime.Scheme, pluginSpec *spec.PluginSpec) (*Plug
 	plugin := &PluginSpec{
 		CapabilitySpecs:      make([]runtime.Typed, len(pluginSpec.CapabilitySpecs)),
 		SupportedConfigTypes: pluginSpec.SupportedConfigTypes,
+		Transports:           pluginSpec.Transports,
+		Version:              pluginSpec.Version,
+		Contracts:            pluginSpec.Contracts,
 	}
 
 	for index, raw := range pluginSpec.CapabilitySpecs {
-		obj, err := scheme.NewObject(raw.Type)
+		obj, err := raw.Decode(scheme)
 		if err != nil {
-			return nil, fmt.Errorf("error creating new object for type %s: %w", raw.Type.String(), err)
-		}
-		if err := scheme.Convert(raw, obj); err != nil {
 			return nil, fmt.Errorf("error converting raw to type %s: %w", raw.Type.String(), err)
 		}
 		plugin.CapabilitySpecs[index] = obj
@@ -3,6 +3,7 @@ package runtime
 import (
 	"errors"
 
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -15,6 +16,9 @@ import (
 type PluginSpec struct {
 	CapabilitySpecs      []runtime.Typed
 	SupportedConfigTypes []runtime.Type
+	Transports           []types.Transport
+	Version              string
+	Contracts            []types.Contract
 }
 
 func (spec *PluginSpec) MarshalJSON() ([]byte, error) {
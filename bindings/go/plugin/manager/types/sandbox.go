@@ -0,0 +1,88 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Sandbox restricts the resources and the environment of a plugin process, to protect the host process
+// from runaway plugins. Zero values leave the respective resource unrestricted.
+//
+// On Linux, Memory and CPU are enforced with a cgroup created below CgroupParent for every plugin process,
+// and require a CgroupParent. On other unix platforms, Memory is enforced with the data segment size limit
+// (RLIMIT_DATA) of the process instead, and CPU cannot be enforced. The address space limit (RLIMIT_AS) is not
+// used, because the Go runtime reserves far more address space than it uses and Go plugins fail to start below
+// about 1 GiB.
+type Sandbox struct {
+	// Memory is the maximum memory of the plugin process in bytes.
+	Memory int64 `json:"memory,omitempty"`
+	// CPU is the maximum CPU bandwidth of the plugin process in CPUs, e.g. 0.5 for half a CPU.
+	// It requires a CgroupParent.
+	CPU float64 `json:"cpu,omitempty"`
+	// CPUTime is the maximum CPU time the plugin process may consume before it is killed (RLIMIT_CPU).
+	CPUTime time.Duration `json:"cpuTime,omitempty"`
+	// OpenFiles is the maximum number of files the plugin process may open (RLIMIT_NOFILE).
+	OpenFiles uint64 `json:"openFiles,omitempty"`
+	// CgroupParent is the absolute path of a cgroup v2 directory, e.g. /sys/fs/cgroup/ocm-plugins, that is
+	// delegated to the user of the host process and has the memory and cpu controllers enabled for its children.
+	// It is only supported on Linux.
+	CgroupParent string `json:"cgroupParent,omitempty"`
+	// Environment is the allowlist of the names of the environment variables passed to the plugin process.
+	// A name ending with "*" allows all variables with the preceding prefix. If nil, all environment variables
+	// of the host process are passed. Variables the manager sets for the plugin are always passed, except for
+	// the credential refresh token (see credentialrefresh/v1.TokenEnv), which is only passed if it is allowed.
+	Environment []string `json:"environment,omitempty"`
+}
+
+// Validate checks that the sandbox can be applied. It does not access the file system.
+func (s *Sandbox) Validate() error {
+	var errs []error
+	if s.Memory < 0 {
+		errs = append(errs, fmt.Errorf("sandbox memory %d is negative", s.Memory))
+	}
+	if s.CPU < 0 {
+		errs = append(errs, fmt.Errorf("sandbox cpu %g is negative", s.CPU))
+	}
+	if s.CPU > 0 && s.CgroupParent == "" {
+		errs = append(errs, errors.New("sandbox cpu can only be limited with a cgroup parent"))
+	}
+	if s.CPUTime < 0 {
+		errs = append(errs, fmt.Errorf("sandbox cpu time %s is negative", s.CPUTime))
+	}
+	if s.CgroupParent != "" && !filepath.IsAbs(s.CgroupParent) {
+		errs = append(errs, fmt.Errorf("sandbox cgroup parent %q is not an absolute path", s.CgroupParent))
+	}
+	return errors.Join(errs...)
+}
+
+// FilterEnvironment returns the variables of env ("NAME=value") that are allowed by Environment.
+// If Environment is nil, env is returned unchanged.
+func (s *Sandbox) FilterEnvironment(env []string) []string {
+	if s.Environment == nil {
+		return env
+	}
+	filtered := make([]string, 0, len(s.Environment))
+	for _, variable := range env {
+		if name, _, _ := strings.Cut(variable, "="); s.AllowsEnvironment(name) {
+			filtered = append(filtered, variable)
+		}
+	}
+	return filtered
+}
+
+// AllowsEnvironment reports whether the environment variable with the given name is allowed by Environment.
+// All variables are allowed if the sandbox or Environment is nil.
+func (s *Sandbox) AllowsEnvironment(name string) bool {
+	if s == nil || s.Environment == nil {
+		return true
+	}
+	for _, allowed := range s.Environment {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok && strings.HasPrefix(name, prefix) || name == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,9 @@
 package spec
 
-import "ocm.software/open-component-model/bindings/go/runtime"
+import (
+	"ocm.software/open-component-model/bindings/go/plugin/manager/types"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
 
 // PluginSpec is the list of plugin capabilities a plugin supports.
 // To determine into what type of plugin we have to unmarshal, we unmarshal
@@ -11,4 +14,13 @@ import "ocm.software/open-component-model/bindings/go/runtime"
 type PluginSpec struct {
 	CapabilitySpecs      []*runtime.Raw `json:"capabilities"`
 	SupportedConfigTypes []runtime.Type `json:"supportedConfigTypes,omitempty"`
+	// Transports are the transports the plugin can serve its endpoints with.
+	// Plugins that do not advertise any transport only support types.TransportHTTP.
+	Transports []types.Transport `json:"transports,omitempty"`
+	// Version is the version of the plugin. It allows selecting a specific plugin
+	// if multiple plugins serve the same capability.
+	Version string `json:"version,omitempty"`
+	// Contracts are the contract versions the plugin negotiated for its capabilities.
+	// Plugins that do not advertise a contract version for a capability serve it with types.DefaultContractVersion.
+	Contracts []types.Contract `json:"contracts,omitempty"`
 }
@@ -11,6 +11,12 @@ type Location struct {
 	LocationType `json:"type"`
 	Value        string `json:"value"`
 	MediaType    string `json:"mediaType,omitempty"`
+	// Digest is the digest of the content at the location, if known.
+	// It is REQUIRED for LocationTypeSharedMemory.
+	Digest string `json:"digest,omitempty"`
+	// Size is the size of the content at the location in bytes, if known.
+	// It is REQUIRED for LocationTypeSharedMemory.
+	Size int64 `json:"size,omitempty"`
 }
 
 type LocationType string
@@ -26,6 +32,13 @@ const (
 	// LocationTypeLocalFile is a local file present on the filesystem available to the orchestrator and plugin.
 	// It MUST be an absolute path.
 	LocationTypeLocalFile LocationType = "localFile"
+	// LocationTypeSharedMemory is a memory-mappable file on the host of the orchestrator and plugin, advertised
+	// together with the Digest and Size of its content. It MUST be an absolute path.
+	// The ownership of the file is handed off with the location: the consumer maps the file and removes it to confirm
+	// the consumption, the content stays available to it through the mapping. This avoids copying large blobs
+	// through HTTP bodies or additional files.
+	// Plugins MUST only return it if the orchestrator enabled it with Config.SharedMemoryBlobs.
+	LocationTypeSharedMemory LocationType = "sharedMemory"
 )
 
 // Type defines an endpoint's type and the scheme of the type.
@@ -0,0 +1,278 @@
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PluginUsage contains the usage statistics of a plugin. Together with the diagnostics of the registered
+// plugins, it helps to identify plugins that are not used and can be removed.
+type PluginUsage struct {
+	ID string `json:"id"`
+	// Registered is set if the plugin is registered with the manager. Statistics of plugins that are
+	// no longer registered are kept in the usage file, see SetUsageFile.
+	Registered bool `json:"registered"`
+	// Capabilities contains the usage of each capability of the plugin, sorted by type.
+	Capabilities []CapabilityUsage `json:"capabilities"`
+	// LastUsed is the time of the last call to any capability of the plugin. It is zero if the plugin was never used.
+	LastUsed time.Time `json:"lastUsed,omitzero"`
+}
+
+// CapabilityUsage contains the usage statistics of a single capability of a plugin.
+type CapabilityUsage struct {
+	// Type is the type of the capability, e.g. componentVersionRepository/v1.
+	Type string `json:"type"`
+	// Calls is the number of calls to endpoints of the capability.
+	Calls int64 `json:"calls"`
+	// Failures is the number of calls that failed to reach the plugin or that the plugin answered with an error.
+	Failures int64 `json:"failures"`
+	// BytesSent is the number of bytes sent to the plugin in request bodies.
+	BytesSent int64 `json:"bytesSent"`
+	// BytesReceived is the number of bytes received from the plugin in response bodies.
+	BytesReceived int64 `json:"bytesReceived"`
+	// LastUsed is the time of the last call to the capability. It is zero if the capability was never used.
+	LastUsed time.Time `json:"lastUsed,omitzero"`
+}
+
+// SetUsageFile persists the usage statistics of plugins in the file at path, so that they are kept across
+// restarts. Statistics already stored in the file are loaded and continued. The file is written by
+// SaveUsage and on Shutdown.
+func (pm *PluginManager) SetUsageFile(path string) error {
+	usage, err := readUsageFile(path)
+	if err != nil {
+		return err
+	}
+	pm.usage.mu.Lock()
+	defer pm.usage.mu.Unlock()
+	for _, plugin := range usage {
+		for _, capability := range plugin.Capabilities {
+			pm.usage.counters(plugin.ID, capability.Type).add(capability)
+		}
+	}
+	pm.usage.path = path
+	return nil
+}
+
+// Usage returns the usage statistics of all registered plugins and all plugins stored in the usage file,
+// sorted by ID. Registered plugins that were never used are included with zero counters.
+func (pm *PluginManager) Usage() []PluginUsage {
+	pm.diagnosticsMu.Lock()
+	registered := make(map[string][]string, len(pm.diagnostics))
+	for id, d := range pm.diagnostics {
+		registered[id] = d.Capabilities
+	}
+	pm.diagnosticsMu.Unlock()
+
+	pm.usage.mu.Lock()
+	defer pm.usage.mu.Unlock()
+	for id, capabilities := range registered {
+		for _, typ := range capabilities {
+			pm.usage.counters(id, typ)
+		}
+	}
+	result := pm.usage.snapshot()
+	for i := range result {
+		_, result[i].Registered = registered[result[i].ID]
+	}
+	return result
+}
+
+// SaveUsage writes the usage statistics to the file set with SetUsageFile.
+// It does nothing if no usage file is set.
+func (pm *PluginManager) SaveUsage() error {
+	pm.usage.mu.Lock()
+	defer pm.usage.mu.Unlock()
+	if pm.usage.path == "" {
+		return nil
+	}
+	return writeUsageFile(pm.usage.path, pm.usage.snapshot())
+}
+
+// usageStatistics records the usage of the capabilities of plugins by plugin ID and capability type.
+type usageStatistics struct {
+	mu      sync.Mutex
+	path    string
+	plugins map[string]map[string]*usageCounters
+}
+
+// counters returns the counters of the capability of the plugin, creating them if necessary.
+// The caller has to hold mu.
+func (u *usageStatistics) counters(id, capability string) *usageCounters {
+	if u.plugins == nil {
+		u.plugins = make(map[string]map[string]*usageCounters)
+	}
+	capabilities, ok := u.plugins[id]
+	if !ok {
+		capabilities = make(map[string]*usageCounters)
+		u.plugins[id] = capabilities
+	}
+	c, ok := capabilities[capability]
+	if !ok {
+		c = &usageCounters{}
+		capabilities[capability] = c
+	}
+	return c
+}
+
+// snapshot returns the current statistics sorted by plugin ID and capability type. The caller has to hold mu.
+func (u *usageStatistics) snapshot() []PluginUsage {
+	result := make([]PluginUsage, 0, len(u.plugins))
+	for id, capabilities := range u.plugins {
+		plugin := PluginUsage{ID: id, Capabilities: make([]CapabilityUsage, 0, len(capabilities))}
+		for typ, c := range capabilities {
+			usage := c.usage(typ)
+			if usage.LastUsed.After(plugin.LastUsed) {
+				plugin.LastUsed = usage.LastUsed
+			}
+			plugin.Capabilities = append(plugin.Capabilities, usage)
+		}
+		slices.SortFunc(plugin.Capabilities, func(a, b CapabilityUsage) int {
+			return strings.Compare(a.Type, b.Type)
+		})
+		result = append(result, plugin)
+	}
+	slices.SortFunc(result, func(a, b PluginUsage) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result
+}
+
+// wrapTransport returns a function that wraps the transport of a plugin to record the usage of the capability
+// of the plugin. See types.Plugin.WrapTransport.
+func (u *usageStatistics) wrapTransport(id, capability string) func(http.RoundTripper) http.RoundTripper {
+	return func(base http.RoundTripper) http.RoundTripper {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		return &usageTransport{base: base, counters: u.counters(id, capability)}
+	}
+}
+
+// usageCounters are the counters of a single capability of a plugin.
+// They are updated concurrently by the calls to the plugin.
+type usageCounters struct {
+	calls         atomic.Int64
+	failures      atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+	// lastUsed is the time of the last call in nanoseconds since the unix epoch.
+	lastUsed atomic.Int64
+}
+
+func (c *usageCounters) add(usage CapabilityUsage) {
+	c.calls.Add(usage.Calls)
+	c.failures.Add(usage.Failures)
+	c.bytesSent.Add(usage.BytesSent)
+	c.bytesReceived.Add(usage.BytesReceived)
+	if !usage.LastUsed.IsZero() && usage.LastUsed.UnixNano() > c.lastUsed.Load() {
+		c.lastUsed.Store(usage.LastUsed.UnixNano())
+	}
+}
+
+func (c *usageCounters) usage(typ string) CapabilityUsage {
+	usage := CapabilityUsage{
+		Type:          typ,
+		Calls:         c.calls.Load(),
+		Failures:      c.failures.Load(),
+		BytesSent:     c.bytesSent.Load(),
+		BytesReceived: c.bytesReceived.Load(),
+	}
+	if lastUsed := c.lastUsed.Load(); lastUsed != 0 {
+		usage.LastUsed = time.Unix(0, lastUsed).UTC()
+	}
+	return usage
+}
+
+// usageTransport records calls to a plugin and the data sent and received with them.
+type usageTransport struct {
+	base     http.RoundTripper
+	counters *usageCounters
+}
+
+func (t *usageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := t.counters
+	c.calls.Add(1)
+	c.lastUsed.Store(time.Now().UnixNano())
+
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(req.Context())
+		req.Body = &countingReadCloser{ReadCloser: req.Body, count: &c.bytesSent}
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		c.failures.Add(1)
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		c.failures.Add(1)
+	}
+	resp.Body = &countingReadCloser{ReadCloser: resp.Body, count: &c.bytesReceived}
+	return resp, nil
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	count *atomic.Int64
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count.Add(int64(n))
+	return n, err
+}
+
+func readUsageFile(path string) ([]PluginUsage, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin usage file: %w", err)
+	}
+	var usage []PluginUsage
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return nil, fmt.Errorf("failed to decode plugin usage file %s: %w", path, err)
+	}
+	return usage, nil
+}
+
+// writeUsageFile replaces the usage file atomically, so that a crash while writing does not lose the statistics.
+func writeUsageFile(path string, usage []PluginUsage) (err error) {
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plugin usage: %w", err)
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory of plugin usage file: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write plugin usage file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write plugin usage file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write plugin usage file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write plugin usage file: %w", err)
+	}
+	return nil
+}
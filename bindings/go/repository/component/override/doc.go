@@ -0,0 +1,16 @@
+// Package override remaps component references at consumption time, as configured by the
+// typed configuration "overrides.config.ocm.software", e.g. to substitute a patched child
+// component without rebuilding the component versions referencing it.
+//
+// Overrides are applied to the references of a resolved component descriptor:
+//
+//	overrides, err := override.New(cfg)
+//	overridden, deviations, err := overrides.Apply(desc)
+//
+// The overridden references point to the substitute component versions and no longer pin a digest.
+// The original references are recorded in the non-signing label [LabelName] of the component
+// version, so that the deviations stay visible in the provenance of the component version and can
+// be reported by verifications, see [Report]. As references are signing relevant, the signatures of
+// the component version verify against the descriptor reconstructed by [Original], not against the
+// overridden descriptor.
+package override
@@ -0,0 +1,247 @@
+package override
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	overridespec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+)
+
+const (
+	// LabelName is the name of the component label recording the overridden references of a component version.
+	LabelName = "ocm.software/overrides"
+	// LabelVersion is the version of the value of the overrides label.
+	LabelVersion = "v1"
+)
+
+// Overrides remaps component references to substitute component versions.
+// A nil *Overrides does not override any reference.
+type Overrides struct {
+	overrides []overridespec.Override
+}
+
+// New creates Overrides from the given configuration. A nil configuration overrides nothing.
+func New(cfg *overridespec.Config) (*Overrides, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid override config: %w", err)
+	}
+	if cfg == nil {
+		return &Overrides{}, nil
+	}
+	return &Overrides{overrides: slices.Clone(cfg.Overrides)}, nil
+}
+
+// Match returns the override for references to the component version, if any.
+// An override for the specific version takes precedence over an override for all versions of the component.
+func (o *Overrides) Match(component, version string) (overridespec.Override, bool) {
+	if o == nil {
+		return overridespec.Override{}, false
+	}
+	var match *overridespec.Override
+	for i, override := range o.overrides {
+		if override.Component != component {
+			continue
+		}
+		if override.Version == version {
+			return override, true
+		}
+		if override.Version == "" && match == nil {
+			match = &o.overrides[i]
+		}
+	}
+	if match == nil {
+		return overridespec.Override{}, false
+	}
+	return *match, true
+}
+
+// Substitute returns the component version used instead of the given one.
+// If no override matches, the component version is returned unchanged.
+func (o *Overrides) Substitute(component, version string) (string, string) {
+	override, ok := o.Match(component, version)
+	if !ok {
+		return component, version
+	}
+	return substitute(override, component, version)
+}
+
+func substitute(override overridespec.Override, component, version string) (string, string) {
+	if override.Substitute.Component != "" {
+		component = override.Substitute.Component
+	}
+	if override.Substitute.Version != "" {
+		version = override.Substitute.Version
+	}
+	return component, version
+}
+
+// Apply overrides the matching references of desc and records them in the label [LabelName].
+// It returns the overridden descriptor and the deviations of the references overridden by this call.
+// desc is not modified; if no reference is overridden, it is returned as is.
+//
+// Overridden references point to the substitute component version and pin no digest, as the
+// digest of the substitute is not known before it is resolved. References that were overridden
+// before keep their original component version in the label, so applying overrides again does not
+// lose the choice of the producer.
+func (o *Overrides) Apply(desc *descriptor.Descriptor) (*descriptor.Descriptor, []repository.Deviation, error) {
+	recorded, err := Deviations(&desc.Component)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// only the references and labels are modified, so they are cloned to keep desc intact.
+	overridden := *desc
+	overridden.Component.References = slices.Clone(desc.Component.References)
+	overridden.Component.Labels = slices.Clone(desc.Component.Labels)
+
+	var applied []repository.Deviation
+	for i := range overridden.Component.References {
+		ref := &overridden.Component.References[i]
+		override, ok := o.Match(ref.Component, ref.Version)
+		if !ok {
+			continue
+		}
+		component, version := substitute(override, ref.Component, ref.Version)
+		if component == ref.Component && version == ref.Version {
+			continue
+		}
+
+		deviation := repository.Deviation{
+			Reference:           ref.ToIdentity(),
+			Component:           ref.Component,
+			Version:             ref.Version,
+			SubstituteComponent: component,
+			SubstituteVersion:   version,
+			Reason:              override.Reason,
+		}
+		if ref.Digest.Value != "" {
+			deviation.Digest = descriptor.ConvertToV2Digest(&ref.Digest)
+		}
+		if idx := indexOfDeviation(recorded, ref); idx >= 0 {
+			// keep the original component version chosen by the producer.
+			original := recorded[idx]
+			deviation.Reference, deviation.Component, deviation.Version, deviation.Digest = original.Reference, original.Component, original.Version, original.Digest
+			recorded[idx] = deviation
+		} else {
+			recorded = append(recorded, deviation)
+		}
+		applied = append(applied, deviation)
+
+		ref.Component = component
+		ref.Version = version
+		ref.Digest = descriptor.Digest{}
+	}
+
+	if len(applied) == 0 {
+		return desc, nil, nil
+	}
+	if err := setDeviations(&overridden.Component, recorded); err != nil {
+		return nil, nil, err
+	}
+	return &overridden, applied, nil
+}
+
+// Deviations returns the overridden references recorded in the label [LabelName] of the component.
+func Deviations(component *descriptor.Component) ([]repository.Deviation, error) {
+	for _, label := range component.Labels {
+		if label.Name != LabelName {
+			continue
+		}
+		var deviations []repository.Deviation
+		if err := json.Unmarshal(label.Value, &deviations); err != nil {
+			return nil, fmt.Errorf("failed to decode label %s: %w", LabelName, err)
+		}
+		return deviations, nil
+	}
+	return nil, nil
+}
+
+// Original reconstructs the descriptor before its references were overridden, which the signatures
+// of the component version verify against. The descriptor is returned as is if no reference was overridden.
+func Original(desc *descriptor.Descriptor) (*descriptor.Descriptor, error) {
+	deviations, err := Deviations(&desc.Component)
+	if err != nil {
+		return nil, err
+	}
+	if deviations == nil {
+		return desc, nil
+	}
+
+	original := *desc
+	original.Component.Labels = slices.DeleteFunc(slices.Clone(desc.Component.Labels), func(l descriptor.Label) bool {
+		return l.Name == LabelName
+	})
+	if len(original.Component.Labels) == 0 {
+		// Apply added the labels, so the original descriptor had none.
+		original.Component.Labels = nil
+	}
+	original.Component.References = slices.Clone(desc.Component.References)
+	for _, deviation := range deviations {
+		idx := slices.IndexFunc(original.Component.References, func(ref descriptor.Reference) bool {
+			return isDeviationOf(deviation, &ref)
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("overridden reference %s not found in component version %s", deviation.Reference, desc.Component.ToIdentity())
+		}
+		ref := &original.Component.References[idx]
+		ref.Component = deviation.Component
+		ref.Version = deviation.Version
+		ref.Digest = descriptor.Digest{}
+		if deviation.Digest != nil {
+			ref.Digest = *descriptor.ConvertFromV2Digest(deviation.Digest)
+		}
+	}
+	return &original, nil
+}
+
+// Report records the overridden references of the component version in the verification,
+// so that they are reported as explicit deviations from the verified component version.
+// A nil verification is left as is.
+func Report(verification *repository.Verification, desc *descriptor.Descriptor) error {
+	if verification == nil {
+		return nil
+	}
+	deviations, err := Deviations(&desc.Component)
+	if err != nil {
+		return err
+	}
+	verification.Deviations = deviations
+	return nil
+}
+
+func indexOfDeviation(deviations []repository.Deviation, ref *descriptor.Reference) int {
+	return slices.IndexFunc(deviations, func(deviation repository.Deviation) bool {
+		return isDeviationOf(deviation, ref)
+	})
+}
+
+// isDeviationOf reports whether the deviation describes the currently overridden reference ref.
+// The identity of the reference contains the referenced version, so it is compared with the original version.
+func isDeviationOf(deviation repository.Deviation, ref *descriptor.Reference) bool {
+	if ref.Component != deviation.SubstituteComponent || ref.Version != deviation.SubstituteVersion {
+		return false
+	}
+	identity := ref.ToIdentity()
+	identity[descriptor.IdentityAttributeVersion] = deviation.Version
+	return identity.Equal(deviation.Reference)
+}
+
+func setDeviations(component *descriptor.Component, deviations []repository.Deviation) error {
+	value, err := json.Marshal(deviations)
+	if err != nil {
+		return fmt.Errorf("failed to encode label %s: %w", LabelName, err)
+	}
+
+	label := descriptor.Label{Name: LabelName, Value: value, Version: LabelVersion}
+	for i := range component.Labels {
+		if component.Labels[i].Name == LabelName {
+			component.Labels[i] = label
+			return nil
+		}
+	}
+	component.Labels = append(component.Labels, label)
+	return nil
+}
@@ -0,0 +1,107 @@
+package override_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	overridespec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/component/override"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func reference(name, component, version, digest string) descriptor.Reference {
+	ref := descriptor.Reference{
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: name, Version: version}},
+		Component:   component,
+	}
+	if digest != "" {
+		ref.Digest = descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v4alpha1", Value: digest}
+	}
+	return ref
+}
+
+func TestOverrides(t *testing.T) {
+	r := require.New(t)
+
+	overrides, err := override.New(&overridespec.Config{Overrides: []overridespec.Override{
+		{Component: "ocm.software/base", Substitute: overridespec.Substitute{Component: "acme.org/base"}},
+		{Component: "ocm.software/base", Version: "1.0.0", Substitute: overridespec.Substitute{Version: "1.0.1-patched"}, Reason: "CVE-2026-0001"},
+	}})
+	r.NoError(err)
+
+	r.Equal([]string{"ocm.software/base", "1.0.1-patched"}, pair(overrides.Substitute("ocm.software/base", "1.0.0")))
+	r.Equal([]string{"acme.org/base", "2.0.0"}, pair(overrides.Substitute("ocm.software/base", "2.0.0")))
+	r.Equal([]string{"ocm.software/other", "1.0.0"}, pair(overrides.Substitute("ocm.software/other", "1.0.0")))
+
+	original := newDescriptor()
+
+	desc, deviations, err := overrides.Apply(original)
+	r.NoError(err)
+	expected := []repository.Deviation{{
+		Reference:           runtime.Identity{descriptor.IdentityAttributeName: "base", descriptor.IdentityAttributeVersion: "1.0.0"},
+		Component:           "ocm.software/base",
+		Version:             "1.0.0",
+		Digest:              &v2.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v4alpha1", Value: "abc"},
+		SubstituteComponent: "ocm.software/base",
+		SubstituteVersion:   "1.0.1-patched",
+		Reason:              "CVE-2026-0001",
+	}}
+	r.Equal(expected, deviations)
+	r.Equal("1.0.1-patched", desc.Component.References[0].Version)
+	r.Empty(desc.Component.References[0].Digest.Value)
+	r.Equal(original.Component.References[1], desc.Component.References[1])
+	r.Equal(newDescriptor(), original, "the descriptor passed to Apply must not be modified")
+
+	recorded, err := override.Deviations(&desc.Component)
+	r.NoError(err)
+	r.Equal(expected, recorded)
+
+	t.Run("applying again keeps the original reference", func(t *testing.T) {
+		r := require.New(t)
+		again, err := override.New(&overridespec.Config{Overrides: []overridespec.Override{
+			{Component: "ocm.software/base", Version: "1.0.1-patched", Substitute: overridespec.Substitute{Version: "1.0.2"}},
+		}})
+		r.NoError(err)
+		reapplied, deviations, err := again.Apply(desc)
+		r.NoError(err)
+		r.Len(deviations, 1)
+		r.Equal("1.0.0", deviations[0].Version)
+		r.Equal("1.0.2", deviations[0].SubstituteVersion)
+
+		restored, err := override.Original(reapplied)
+		r.NoError(err)
+		r.Equal(original, restored)
+	})
+
+	t.Run("original", func(t *testing.T) {
+		r := require.New(t)
+		restored, err := override.Original(desc)
+		r.NoError(err)
+		r.Equal(original, restored)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		r := require.New(t)
+		verification := &repository.Verification{}
+		r.NoError(override.Report(verification, desc))
+		r.Equal(expected, verification.Deviations)
+	})
+}
+
+func newDescriptor() *descriptor.Descriptor {
+	return &descriptor.Descriptor{Component: descriptor.Component{
+		ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: "ocm.software/app", Version: "1.0.0"}},
+		References: []descriptor.Reference{
+			reference("base", "ocm.software/base", "1.0.0", "abc"),
+			reference("other", "ocm.software/other", "1.0.0", "def"),
+		},
+	}}
+}
+
+func pair(component, version string) []string {
+	return []string{component, version}
+}
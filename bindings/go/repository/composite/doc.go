@@ -0,0 +1,23 @@
+// Package composite combines multiple component version repository providers into a single
+// repository.ComponentVersionRepositoryProvider, so that embedders can mix in-process providers, e.g. for
+// OCI and CTF repositories, with plugin-backed providers behind one interface.
+//
+// A [Provider] dispatches to the first of its [Route]s that matches a request. A route matches
+// repository specifications by type, and component versions by a glob pattern on the component name
+// (see github.com/gobwas/glob), or all of them if it has no types or no pattern respectively:
+//
+//	provider, err := composite.NewProvider(
+//		composite.Route{Name: "internal", ComponentNamePattern: "acme.org/internal/*", Provider: pluginProvider},
+//		composite.Route{Name: "oci", RepositoryTypes: []runtime.Type{runtime.NewUnversionedType("OCIRepository")}, Provider: ociProvider},
+//		composite.Route{Name: "ctf", RepositoryTypes: []runtime.Type{runtime.NewUnversionedType("CommonTransportFormat")}, Provider: ctfProvider},
+//	)
+//
+// Repository specifications only carry a type, so routing by component name happens in the repository
+// returned by GetComponentVersionRepository: every operation is served by a repository of the provider of
+// the first route matching both the specification and the component, which is created on first use.
+// All providers of a specification share the credential consumer identity, and thus the credentials,
+// of the first route matching the specification.
+//
+// Repositories returned for component name routes only implement repository.ComponentVersionRepository,
+// optional interfaces of the repositories of the routed providers are not exposed.
+package composite
@@ -0,0 +1,246 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gobwas/glob"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ErrNoRoute is matched (with [errors.Is]) by a [*NoRouteError], returned if no route of a Provider matches.
+var ErrNoRoute = errors.New("no matching route")
+
+// NoRouteError is returned if no route of a Provider matches a repository specification,
+// or no route matching the repository specification matches a component.
+// In the latter case it also matches repository.ErrNotFound, as the component versions of the
+// component cannot be found in the repository.
+type NoRouteError struct {
+	// RepositoryType is the type of the repository specification.
+	RepositoryType runtime.Type
+	// Component is the name of the component, empty if no route matches the repository specification.
+	Component string
+}
+
+func (e *NoRouteError) Error() string {
+	if e.Component == "" {
+		return fmt.Sprintf("no route for repository type %s", e.RepositoryType)
+	}
+	return fmt.Sprintf("no route for component %s in repository of type %s", e.Component, e.RepositoryType)
+}
+
+func (e *NoRouteError) Is(target error) bool {
+	return target == ErrNoRoute || (e.Component != "" && target == repository.ErrNotFound)
+}
+
+// Route routes requests to a provider.
+type Route struct {
+	// Name identifies the route in errors.
+	Name string
+	// RepositoryTypes are the types of repository specifications served by the route. Unversioned types
+	// match all versions of the type. If empty, the route serves all repository specifications.
+	RepositoryTypes []runtime.Type
+	// ComponentNamePattern is a glob pattern for the names of the components served by the route,
+	// e.g. "acme.org/*". If empty, the route serves all components.
+	ComponentNamePattern string
+	// Provider serves the requests matching the route.
+	Provider repository.ComponentVersionRepositoryProvider
+}
+
+type compiledRoute struct {
+	Route
+	// componentNamePattern is nil if the route serves all components.
+	componentNamePattern glob.Glob
+}
+
+func (r *compiledRoute) matchesType(typ runtime.Type) bool {
+	if len(r.RepositoryTypes) == 0 {
+		return true
+	}
+	for _, t := range r.RepositoryTypes {
+		if t.Name == typ.Name && (!t.HasVersion() || t.Version == typ.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *compiledRoute) matchesComponent(component string) bool {
+	return r.componentNamePattern == nil || r.componentNamePattern.Match(component)
+}
+
+// Provider is a repository.ComponentVersionRepositoryProvider that routes requests to the provider
+// of the first matching route.
+type Provider struct {
+	routes []*compiledRoute
+}
+
+var _ repository.ComponentVersionRepositoryProvider = (*Provider)(nil)
+
+// NewProvider creates a provider routing to the given routes, in order.
+// It returns an error if a route has no provider or an invalid component name pattern.
+func NewProvider(routes ...Route) (*Provider, error) {
+	compiled := make([]*compiledRoute, 0, len(routes))
+	for i, route := range routes {
+		if route.Provider == nil {
+			return nil, fmt.Errorf("route %q at index %d has no provider", route.Name, i)
+		}
+		r := &compiledRoute{Route: route}
+		if route.ComponentNamePattern != "" {
+			g, err := glob.Compile(route.ComponentNamePattern)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile component name pattern %q of route %q at index %d: %w",
+					route.ComponentNamePattern, route.Name, i, err)
+			}
+			r.componentNamePattern = g
+		}
+		compiled = append(compiled, r)
+	}
+	return &Provider{routes: compiled}, nil
+}
+
+// GetComponentVersionRepositoryCredentialConsumerIdentity returns the consumer identity of the provider
+// of the first route matching the type of the repository specification.
+func (p *Provider) GetComponentVersionRepositoryCredentialConsumerIdentity(ctx context.Context, repositorySpecification runtime.Typed) (runtime.Identity, error) {
+	routes, err := p.routesFor(repositorySpecification.GetType())
+	if err != nil {
+		return nil, err
+	}
+	return routes[0].Provider.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, repositorySpecification)
+}
+
+// GetComponentVersionRepository returns the repository for the repository specification.
+// If the first route matching the type of the specification serves all components, its provider
+// creates the repository. Otherwise, the returned repository routes every operation by component name.
+func (p *Provider) GetComponentVersionRepository(ctx context.Context, repositorySpecification runtime.Typed, credentials runtime.Typed) (repository.ComponentVersionRepository, error) {
+	routes, err := p.routesFor(repositorySpecification.GetType())
+	if err != nil {
+		return nil, err
+	}
+	if routes[0].componentNamePattern == nil {
+		return routes[0].Provider.GetComponentVersionRepository(ctx, repositorySpecification, credentials)
+	}
+	return &routingRepository{
+		specification: repositorySpecification,
+		credentials:   credentials,
+		routes:        routes,
+		repositories:  make([]repository.ComponentVersionRepository, len(routes)),
+	}, nil
+}
+
+// GetJSONSchemaForRepositorySpecification returns the schema of the provider of the first route matching the type.
+func (p *Provider) GetJSONSchemaForRepositorySpecification(typ runtime.Type) ([]byte, error) {
+	routes, err := p.routesFor(typ)
+	if err != nil {
+		return nil, err
+	}
+	return routes[0].Provider.GetJSONSchemaForRepositorySpecification(typ)
+}
+
+// routesFor returns the routes matching the type of a repository specification, in order.
+func (p *Provider) routesFor(typ runtime.Type) ([]*compiledRoute, error) {
+	var routes []*compiledRoute
+	for _, r := range p.routes {
+		if r.matchesType(typ) {
+			routes = append(routes, r)
+		}
+	}
+	if len(routes) == 0 {
+		return nil, &NoRouteError{RepositoryType: typ}
+	}
+	return routes, nil
+}
+
+// routingRepository serves each operation by the repository of the first route matching the component.
+type routingRepository struct {
+	specification runtime.Typed
+	credentials   runtime.Typed
+	routes        []*compiledRoute
+
+	mu sync.Mutex
+	// repositories are created on first use, by index of the route.
+	repositories []repository.ComponentVersionRepository
+}
+
+var _ repository.ComponentVersionRepository = (*routingRepository)(nil)
+
+func (r *routingRepository) repositoryFor(ctx context.Context, component string) (repository.ComponentVersionRepository, error) {
+	for i, route := range r.routes {
+		if !route.matchesComponent(component) {
+			continue
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.repositories[i] == nil {
+			repo, err := route.Provider.GetComponentVersionRepository(ctx, r.specification, r.credentials)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get component version repository of route %q: %w", route.Name, err)
+			}
+			r.repositories[i] = repo
+		}
+		return r.repositories[i], nil
+	}
+	return nil, &NoRouteError{RepositoryType: r.specification.GetType(), Component: component}
+}
+
+func (r *routingRepository) AddComponentVersion(ctx context.Context, desc *descriptor.Descriptor) error {
+	repo, err := r.repositoryFor(ctx, desc.Component.Name)
+	if err != nil {
+		return err
+	}
+	return repo.AddComponentVersion(ctx, desc)
+}
+
+func (r *routingRepository) GetComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, error) {
+	repo, err := r.repositoryFor(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetComponentVersion(ctx, component, version)
+}
+
+func (r *routingRepository) ListComponentVersions(ctx context.Context, component string) ([]string, error) {
+	repo, err := r.repositoryFor(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListComponentVersions(ctx, component)
+}
+
+func (r *routingRepository) AddLocalResource(ctx context.Context, component, version string, res *descriptor.Resource, content blob.ReadOnlyBlob) (*descriptor.Resource, error) {
+	repo, err := r.repositoryFor(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+	return repo.AddLocalResource(ctx, component, version, res, content)
+}
+
+func (r *routingRepository) GetLocalResource(ctx context.Context, component, version string, identity runtime.Identity) (blob.ReadOnlyBlob, *descriptor.Resource, error) {
+	repo, err := r.repositoryFor(ctx, component)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo.GetLocalResource(ctx, component, version, identity)
+}
+
+func (r *routingRepository) AddLocalSource(ctx context.Context, component, version string, src *descriptor.Source, content blob.ReadOnlyBlob) (*descriptor.Source, error) {
+	repo, err := r.repositoryFor(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+	return repo.AddLocalSource(ctx, component, version, src, content)
+}
+
+func (r *routingRepository) GetLocalSource(ctx context.Context, component, version string, identity runtime.Identity) (blob.ReadOnlyBlob, *descriptor.Source, error) {
+	repo, err := r.repositoryFor(ctx, component)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo.GetLocalSource(ctx, component, version, identity)
+}
@@ -0,0 +1,143 @@
+package composite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/composite"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// fakeProvider creates repositories that return descriptors provided by the provider's name.
+type fakeProvider struct {
+	name    string
+	created int
+}
+
+func (f *fakeProvider) GetComponentVersionRepositoryCredentialConsumerIdentity(_ context.Context, _ runtime.Typed) (runtime.Identity, error) {
+	return runtime.Identity{"provider": f.name}, nil
+}
+
+func (f *fakeProvider) GetComponentVersionRepository(_ context.Context, _ runtime.Typed, _ runtime.Typed) (repository.ComponentVersionRepository, error) {
+	f.created++
+	return &fakeRepository{provider: f.name}, nil
+}
+
+func (f *fakeProvider) GetJSONSchemaForRepositorySpecification(_ runtime.Type) ([]byte, error) {
+	return []byte(f.name), nil
+}
+
+type fakeRepository struct {
+	repository.ComponentVersionRepository
+	provider string
+}
+
+func (f *fakeRepository) GetComponentVersion(_ context.Context, component, version string) (*descriptor.Descriptor, error) {
+	return &descriptor.Descriptor{Component: descriptor.Component{
+		ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: component, Version: version}},
+		Provider:      descriptor.Provider{Name: f.provider},
+	}}, nil
+}
+
+func spec(typ string) runtime.Typed {
+	t, err := runtime.TypeFromString(typ)
+	if err != nil {
+		panic(err)
+	}
+	return &runtime.Raw{Type: t, Data: []byte(`{"type":"` + typ + `"}`)}
+}
+
+func TestProvider(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	plugin := &fakeProvider{name: "plugin"}
+	oci := &fakeProvider{name: "oci"}
+	ctf := &fakeProvider{name: "ctf"}
+	provider, err := composite.NewProvider(
+		composite.Route{Name: "internal", RepositoryTypes: []runtime.Type{runtime.NewUnversionedType("OCIRepository")}, ComponentNamePattern: "acme.org/internal/*", Provider: plugin},
+		composite.Route{Name: "oci", RepositoryTypes: []runtime.Type{runtime.NewUnversionedType("OCIRepository")}, Provider: oci},
+		composite.Route{Name: "ctf", RepositoryTypes: []runtime.Type{runtime.NewVersionedType("CommonTransportFormat", "v1")}, Provider: ctf},
+	)
+	r.NoError(err)
+
+	t.Run("route by repository type", func(t *testing.T) {
+		r := require.New(t)
+		identity, err := provider.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, spec("CommonTransportFormat/v1"))
+		r.NoError(err)
+		r.Equal(runtime.Identity{"provider": "ctf"}, identity)
+
+		schema, err := provider.GetJSONSchemaForRepositorySpecification(runtime.NewVersionedType("CommonTransportFormat", "v1"))
+		r.NoError(err)
+		r.Equal("ctf", string(schema))
+
+		repo, err := provider.GetComponentVersionRepository(ctx, spec("CommonTransportFormat/v1"), nil)
+		r.NoError(err)
+		desc, err := repo.GetComponentVersion(ctx, "acme.org/internal/app", "1.0.0")
+		r.NoError(err)
+		r.Equal("ctf", desc.Component.Provider.Name)
+	})
+
+	t.Run("route by component name", func(t *testing.T) {
+		r := require.New(t)
+		identity, err := provider.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, spec("OCIRepository/v1"))
+		r.NoError(err)
+		r.Equal(runtime.Identity{"provider": "plugin"}, identity)
+
+		repo, err := provider.GetComponentVersionRepository(ctx, spec("OCIRepository/v1"), nil)
+		r.NoError(err)
+		r.Zero(plugin.created, "repositories are created on first use")
+
+		for _, tc := range []struct{ component, provider string }{
+			{component: "acme.org/internal/app", provider: "plugin"},
+			{component: "acme.org/public/app", provider: "oci"},
+			{component: "acme.org/internal/db", provider: "plugin"},
+		} {
+			desc, err := repo.GetComponentVersion(ctx, tc.component, "1.0.0")
+			r.NoError(err)
+			r.Equal(tc.provider, desc.Component.Provider.Name, tc.component)
+		}
+		r.Equal(1, plugin.created)
+		r.Equal(1, oci.created)
+	})
+
+	t.Run("no route", func(t *testing.T) {
+		r := require.New(t)
+		_, err := provider.GetComponentVersionRepository(ctx, spec("CommonTransportFormat/v2"), nil)
+		r.ErrorIs(err, composite.ErrNoRoute)
+		r.NotErrorIs(err, repository.ErrNotFound)
+		_, err = provider.GetJSONSchemaForRepositorySpecification(runtime.NewUnversionedType("Unknown"))
+		r.ErrorIs(err, composite.ErrNoRoute)
+	})
+}
+
+func TestProvider_NoComponentRoute(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	provider, err := composite.NewProvider(
+		composite.Route{Name: "internal", ComponentNamePattern: "acme.org/internal/*", Provider: &fakeProvider{name: "plugin"}},
+	)
+	r.NoError(err)
+
+	repo, err := provider.GetComponentVersionRepository(ctx, spec("OCIRepository/v1"), nil)
+	r.NoError(err)
+	_, err = repo.GetComponentVersion(ctx, "acme.org/public/app", "1.0.0")
+	r.ErrorIs(err, composite.ErrNoRoute)
+	r.ErrorIs(err, repository.ErrNotFound)
+	r.EqualError(err, "no route for component acme.org/public/app in repository of type OCIRepository/v1")
+}
+
+func TestNewProvider_InvalidRoute(t *testing.T) {
+	r := require.New(t)
+
+	_, err := composite.NewProvider(composite.Route{Name: "missing"})
+	r.ErrorContains(err, `route "missing" at index 0 has no provider`)
+
+	_, err = composite.NewProvider(composite.Route{Name: "invalid", ComponentNamePattern: "acme.org/[", Provider: &fakeProvider{}})
+	r.ErrorContains(err, "failed to compile component name pattern")
+}
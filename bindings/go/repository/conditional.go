@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+// ErrConflict is returned (wrapped in a [*ConflictError]) by AddComponentVersionIf
+// if the component version was changed by someone else.
+var ErrConflict = errors.New("component version conflict")
+
+// ConditionalComponentVersionRepository is an optional interface that can be implemented by a component version
+// repository to support optimistic concurrency control when publishing component versions.
+//
+// A publisher reads the digest of the component version, and passes it as precondition when writing
+// the component version, so that concurrent publishers cannot overwrite each other unnoticed:
+//
+//	dig, err := repo.GetComponentVersionDigest(ctx, component, version)
+//	// ... modify the descriptor
+//	err = repo.AddComponentVersionIf(ctx, desc, repository.AddComponentVersionPrecondition{ExpectedDigest: dig})
+//	if errors.Is(err, repository.ErrConflict) {
+//		// re-read and retry
+//	}
+type ConditionalComponentVersionRepository interface {
+	// GetComponentVersionDigest returns the digest of the stored component version. It changes whenever
+	// the component version is written with a different descriptor.
+	// It fails with ErrNotFound if the component version does not exist.
+	GetComponentVersionDigest(ctx context.Context, component, version string) (string, error)
+	// AddComponentVersionIf adds the component version like ComponentVersionRepository.AddComponentVersion
+	// if the precondition holds, and fails with a [*ConflictError] otherwise.
+	AddComponentVersionIf(ctx context.Context, descriptor *descriptor.Descriptor, precondition AddComponentVersionPrecondition) error
+}
+
+// AddComponentVersionPrecondition is the state of the component version expected by AddComponentVersionIf.
+// The zero value has no precondition.
+type AddComponentVersionPrecondition struct {
+	// MustNotExist requires that the component version does not exist yet.
+	MustNotExist bool
+	// ExpectedDigest requires that the component version exists with the digest returned by GetComponentVersionDigest.
+	ExpectedDigest string
+}
+
+// Check returns a [*ConflictError] if the current digest of the component version does not satisfy the precondition.
+// An empty current digest means that the component version does not exist.
+func (p AddComponentVersionPrecondition) Check(component, version, current string) error {
+	switch {
+	case p.MustNotExist && current != "":
+	case p.ExpectedDigest != "" && p.ExpectedDigest != current:
+	default:
+		return nil
+	}
+	return &ConflictError{
+		Component: component,
+		Version:   version,
+		Expected:  p.ExpectedDigest,
+		Actual:    current,
+	}
+}
+
+// ConflictError is returned by AddComponentVersionIf if the precondition does not hold.
+// It matches ErrConflict and ocmerrors.ErrPreconditionFailed with [errors.Is].
+type ConflictError struct {
+	// Component and Version identify the component version.
+	Component, Version string
+	// Expected is the expected digest, empty if the component version was expected to not exist.
+	Expected string
+	// Actual is the current digest, empty if the component version does not exist.
+	Actual string
+}
+
+func (e *ConflictError) Error() string {
+	switch {
+	case e.Expected == "":
+		return fmt.Sprintf("component version %s/%s already exists with digest %s", e.Component, e.Version, e.Actual)
+	case e.Actual == "":
+		return fmt.Sprintf("component version %s/%s was expected with digest %s but does not exist", e.Component, e.Version, e.Expected)
+	default:
+		return fmt.Sprintf("component version %s/%s was expected with digest %s but has digest %s", e.Component, e.Version, e.Expected, e.Actual)
+	}
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict || target == ocmerrors.ErrPreconditionFailed
+}
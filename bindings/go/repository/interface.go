@@ -7,13 +7,14 @@ import (
 	"ocm.software/open-component-model/bindings/go/blob"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
 )
 
 // ErrNotFound is an error type that indicates a requested component version
 // was not found. NotFoundError is independent of the underlying repository implementation.
 // It is supposed to be joined with the original technology-specific error to provide a
 // technology-agnostic API to check for not found errors.
-var ErrNotFound = errors.New("component version not found")
+var ErrNotFound = ocmerrors.NotFound(errors.New("component version not found"))
 
 // ComponentVersionRepositoryProvider defines the contract for providers that can retrieve
 // and manage component version repositories. It supports different types of repository
@@ -161,6 +162,16 @@ type ResourceDigestProcessor interface {
 	ProcessResourceDigest(ctx context.Context, res *descriptor.Resource, credentials runtime.Typed) (*descriptor.Resource, error)
 }
 
+// ComponentVersionDeleter is an optional interface that can be implemented by a component version
+// repository to remove component versions, e.g. to prune obsolete versions.
+type ComponentVersionDeleter interface {
+	// DeleteComponentVersion removes a component version from the repository, so that it is neither
+	// listed nor retrievable anymore. Content that was only referenced by the component version
+	// (e.g. local blobs) may be kept until the storage is garbage collected.
+	// Returns ErrNotFound if the component version does not exist.
+	DeleteComponentVersion(ctx context.Context, component, version string) error
+}
+
 // HealthCheckable is an optional interface that can be implemented by a
 // component version repository.
 type HealthCheckable interface {
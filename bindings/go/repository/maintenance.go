@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+)
+
+// The interfaces below are optional housekeeping capabilities of a component version repository.
+// Implementations that can only support an operation for some backends return an error matching
+// errors.ErrUnsupported of the runtime/errors package for the others.
+
+// GarbageCollector is an optional interface that can be implemented by a component version
+// repository to remove content that is no longer referenced by any component version.
+type GarbageCollector interface {
+	// GarbageCollect removes unreferenced content. With GarbageCollectOptions.DryRun, the content
+	// is only reported but not removed.
+	GarbageCollect(ctx context.Context, opts GarbageCollectOptions) (*GarbageCollectResult, error)
+}
+
+// IntegrityVerifier is an optional interface that can be implemented by a component version
+// repository to check that its stored content is consistent.
+type IntegrityVerifier interface {
+	// VerifyIntegrity checks the stored component versions and their local blobs, e.g. that all
+	// referenced blobs exist and match their digests. Problems found are reported in the result,
+	// an error is only returned if the verification itself failed.
+	VerifyIntegrity(ctx context.Context, opts VerifyIntegrityOptions) (*IntegrityReport, error)
+}
+
+// StatsProvider is an optional interface that can be implemented by a component version
+// repository to report statistics about its content.
+type StatsProvider interface {
+	// Stats returns statistics about the content of the repository.
+	Stats(ctx context.Context) (*Stats, error)
+}
+
+// GarbageCollectOptions configure GarbageCollector.GarbageCollect.
+type GarbageCollectOptions struct {
+	// DryRun only reports the content that would be removed.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// GarbageCollectResult is the result of GarbageCollector.GarbageCollect.
+type GarbageCollectResult struct {
+	// Removed identifies the removed content, e.g. by digest. With DryRun, it is the content that would be removed.
+	Removed []string `json:"removed,omitempty"`
+	// ReclaimedBytes is the size of the removed content, if known.
+	ReclaimedBytes int64 `json:"reclaimedBytes,omitempty"`
+}
+
+// VerifyIntegrityOptions configure IntegrityVerifier.VerifyIntegrity.
+type VerifyIntegrityOptions struct {
+	// Component restricts the verification to the versions of a single component. All components are verified if empty.
+	Component string `json:"component,omitempty"`
+}
+
+// IntegrityReport is the result of IntegrityVerifier.VerifyIntegrity.
+type IntegrityReport struct {
+	// ComponentVersions is the number of verified component versions.
+	ComponentVersions int `json:"componentVersions"`
+	// Problems are the inconsistencies found. The repository is consistent if there are none.
+	Problems []IntegrityProblem `json:"problems,omitempty"`
+}
+
+// IntegrityProblem is an inconsistency found by IntegrityVerifier.VerifyIntegrity.
+type IntegrityProblem struct {
+	// Component and Version identify the affected component version, if any.
+	Component string `json:"component,omitempty"`
+	Version   string `json:"version,omitempty"`
+	// Object identifies the affected object within the repository, e.g. the digest of a blob.
+	Object string `json:"object,omitempty"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// Stats are statistics about the content of a repository, as returned by StatsProvider.Stats.
+// Fields a backend cannot determine are left empty.
+type Stats struct {
+	// Components is the number of components.
+	Components int64 `json:"components,omitempty"`
+	// ComponentVersions is the number of component versions.
+	ComponentVersions int64 `json:"componentVersions,omitempty"`
+	// Blobs is the number of stored blobs.
+	Blobs int64 `json:"blobs,omitempty"`
+	// SizeBytes is the total size of the stored content.
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+	// Details are additional backend specific statistics.
+	Details map[string]string `json:"details,omitempty"`
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+)
+
+// ComponentVersionMetadataRepository is an optional interface that can be implemented by a component version
+// repository to attach metadata to component versions that were already published, e.g. scan results or
+// deployment approvals.
+//
+// The metadata is stored next to the component version and never changes the component descriptor,
+// so it does not affect its digest or signatures. It is append-only: entries cannot be changed or removed,
+// a newer entry with the same name supersedes older ones in views, see MergeComponentVersionMetadata.
+type ComponentVersionMetadataRepository interface {
+	// AppendComponentVersionMetadata attaches metadata to an existing component version.
+	// It fails with ErrNotFound if the component version does not exist.
+	AppendComponentVersionMetadata(ctx context.Context, component, version string, metadata ComponentVersionMetadata) error
+	// ListComponentVersionMetadata returns the metadata attached to a component version, ordered by creation time.
+	// It fails with ErrNotFound if the component version does not exist.
+	ListComponentVersionMetadata(ctx context.Context, component, version string) ([]ComponentVersionMetadata, error)
+}
+
+// ComponentVersionMetadata is metadata attached to a published component version.
+// It is not part of the signed component descriptor.
+type ComponentVersionMetadata struct {
+	// Name identifies the kind of metadata, e.g. "ocm.software/scan-result".
+	// It is used as label name if the metadata is merged into a view of the component version.
+	Name string `json:"name"`
+	// Value is the json data of the metadata.
+	Value json.RawMessage `json:"value"`
+	// Version is the optional specification version of the value.
+	Version string `json:"version,omitempty"`
+	// CreatedAt is the time the metadata was appended. It is set by the repository if empty.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MergeComponentVersionMetadata returns a view of desc with the metadata merged as labels of the component.
+// The labels are not signing relevant. For each name, the most recent metadata is used. Labels of the
+// descriptor take precedence over metadata with the same name, because the signed content must not be
+// shadowed by unsigned metadata.
+//
+// desc is not modified. The view shares all content with desc except for the component labels.
+func MergeComponentVersionMetadata(desc *descriptor.Descriptor, metadata []ComponentVersionMetadata) *descriptor.Descriptor {
+	if len(metadata) == 0 {
+		return desc
+	}
+
+	latest := slices.Clone(metadata)
+	// stable, so that the append order decides between entries created at the same time.
+	slices.SortStableFunc(latest, func(a, b ComponentVersionMetadata) int {
+		return cmp.Compare(a.CreatedAt.UnixNano(), b.CreatedAt.UnixNano())
+	})
+
+	view := *desc
+	view.Component.Labels = slices.Clone(desc.Component.Labels)
+	merged := make(map[string]int)
+	for _, m := range latest {
+		if slices.ContainsFunc(desc.Component.Labels, func(l descriptor.Label) bool { return l.Name == m.Name }) {
+			continue
+		}
+		label := descriptor.Label{Name: m.Name, Value: m.Value, Version: m.Version}
+		if idx, ok := merged[m.Name]; ok {
+			view.Component.Labels[idx] = label
+			continue
+		}
+		merged[m.Name] = len(view.Component.Labels)
+		view.Component.Labels = append(view.Component.Labels, label)
+	}
+
+	return &view
+}
@@ -0,0 +1,18 @@
+// Package mirror publishes component versions to multiple target repositories at once, so that
+// highly available registry setups do not need a separate replication run after every publish.
+//
+// A [Repository] fans out every write, i.e. AddComponentVersion, AddLocalResource and AddLocalSource,
+// to all of its targets concurrently, while reads are served by the first target, the primary.
+// Every target is accessed with its own repository, so each target uses its own credentials.
+// [NewTargets] creates the targets from repository specifications, resolving the credentials
+// of each target separately.
+//
+// Publishing is all-or-report: if a write fails for some targets, a [PublishError] reports the
+// failed and the succeeded targets, so that only the failed targets have to be retried.
+// With [WithRollback], a component version is removed again from the targets it was added to if
+// any target failed, so that either all targets or none serve it. Targets that cannot delete component
+// versions (see repository.ComponentVersionDeleter) are reported as not rolled back.
+//
+// The content of local blobs is read once per target, concurrently. It must therefore be readable
+// repeatedly, as blobs from files, memory or other repositories are.
+package mirror
@@ -0,0 +1,263 @@
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/credentials"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ErrNoTargets is returned by NewRepository if no target is given.
+var ErrNoTargets = errors.New("no mirror targets given")
+
+// Target is a repository that component versions are published to.
+type Target struct {
+	// Name identifies the target in reports, e.g. the URL of the registry.
+	Name string
+	// Repository is the repository of the target, set up with the credentials of the target.
+	Repository repository.ComponentVersionRepository
+}
+
+// Repository is a component version repository that publishes to all of its targets.
+// Reads are served by the first target.
+type Repository struct {
+	repository.ComponentVersionRepository
+	targets  []Target
+	rollback bool
+}
+
+var _ repository.ComponentVersionRepository = (*Repository)(nil)
+
+// Option configures a Repository.
+type Option func(*Repository)
+
+// WithRollback removes a component version again from the targets it was added to if adding it failed for
+// any target, so that it is either available in all targets or in none.
+func WithRollback() Option {
+	return func(r *Repository) {
+		r.rollback = true
+	}
+}
+
+// NewRepository creates a repository publishing to all targets. The first target is the primary,
+// which serves all reads. Target names must be unique.
+func NewRepository(targets []Target, opts ...Option) (*Repository, error) {
+	if len(targets) == 0 {
+		return nil, ErrNoTargets
+	}
+	names := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		if target.Repository == nil {
+			return nil, fmt.Errorf("mirror target %q has no repository", target.Name)
+		}
+		if _, ok := names[target.Name]; ok {
+			return nil, fmt.Errorf("duplicate mirror target %q", target.Name)
+		}
+		names[target.Name] = struct{}{}
+	}
+
+	r := &Repository{
+		ComponentVersionRepository: targets[0].Repository,
+		targets:                    targets,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// Targets returns the targets of the repository.
+func (r *Repository) Targets() []Target {
+	return r.targets
+}
+
+// AddComponentVersion adds the component version to all targets. If it failed for any target, a
+// PublishError is returned. With WithRollback, the component version is removed from the succeeded
+// targets again in that case.
+func (r *Repository) AddComponentVersion(ctx context.Context, desc *descriptor.Descriptor) error {
+	component, version := desc.Component.Name, desc.Component.Version
+	results := fanOut(ctx, r.targets, func(ctx context.Context, repo repository.ComponentVersionRepository) (struct{}, error) {
+		return struct{}{}, repo.AddComponentVersion(ctx, desc)
+	})
+	publishErr := newPublishError("add component version "+component+":"+version, r.targets, results)
+	if publishErr == nil {
+		return nil
+	}
+
+	if r.rollback {
+		for i, target := range r.targets {
+			if results[i].err != nil {
+				continue
+			}
+			if err := deleteComponentVersion(ctx, target, component, version); err != nil {
+				slog.WarnContext(ctx, "failed to roll back component version from mirror target",
+					"target", target.Name, "component", component, "version", version, "error", err)
+				publishErr.RollbackFailed = append(publishErr.RollbackFailed, TargetError{Target: target.Name, Err: err})
+				continue
+			}
+			publishErr.RolledBack = append(publishErr.RolledBack, target.Name)
+		}
+	}
+	return publishErr
+}
+
+// AddLocalResource adds the local resource to all targets and returns the resource as added to the primary.
+// If it failed for any target, a PublishError is returned.
+func (r *Repository) AddLocalResource(ctx context.Context, component, version string, res *descriptor.Resource, content blob.ReadOnlyBlob) (*descriptor.Resource, error) {
+	results := fanOut(ctx, r.targets, func(ctx context.Context, repo repository.ComponentVersionRepository) (*descriptor.Resource, error) {
+		return repo.AddLocalResource(ctx, component, version, res.DeepCopy(), content)
+	})
+	if err := newPublishError("add local resource "+res.ToIdentity().String(), r.targets, results); err != nil {
+		return nil, err
+	}
+	return results[0].value, nil
+}
+
+// AddLocalSource adds the local source to all targets and returns the source as added to the primary.
+// If it failed for any target, a PublishError is returned.
+func (r *Repository) AddLocalSource(ctx context.Context, component, version string, src *descriptor.Source, content blob.ReadOnlyBlob) (*descriptor.Source, error) {
+	results := fanOut(ctx, r.targets, func(ctx context.Context, repo repository.ComponentVersionRepository) (*descriptor.Source, error) {
+		return repo.AddLocalSource(ctx, component, version, src.DeepCopy(), content)
+	})
+	if err := newPublishError("add local source "+src.ToIdentity().String(), r.targets, results); err != nil {
+		return nil, err
+	}
+	return results[0].value, nil
+}
+
+func deleteComponentVersion(ctx context.Context, target Target, component, version string) error {
+	deleter, ok := target.Repository.(repository.ComponentVersionDeleter)
+	if !ok {
+		return errors.New("repository does not support deleting component versions")
+	}
+	return deleter.DeleteComponentVersion(ctx, component, version)
+}
+
+type result[T any] struct {
+	value T
+	err   error
+}
+
+// fanOut calls fn for the repositories of all targets concurrently and returns the results in the order of the targets.
+func fanOut[T any](ctx context.Context, targets []Target, fn func(context.Context, repository.ComponentVersionRepository) (T, error)) []result[T] {
+	results := make([]result[T], len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Go(func() {
+			results[i].value, results[i].err = fn(ctx, target.Repository)
+		})
+	}
+	wg.Wait()
+	return results
+}
+
+// TargetError is the failure of an operation for a single target.
+type TargetError struct {
+	// Target is the name of the target.
+	Target string
+	// Err is the error of the operation.
+	Err error
+}
+
+func (e TargetError) Error() string {
+	return fmt.Sprintf("target %q: %v", e.Target, e.Err)
+}
+
+func (e TargetError) Unwrap() error {
+	return e.Err
+}
+
+// PublishError reports the targets a write failed for.
+// It matches the errors of all failed targets with errors.Is and errors.As.
+type PublishError struct {
+	// Operation describes the failed write.
+	Operation string
+	// Failed are the targets the write failed for.
+	Failed []TargetError
+	// Succeeded are the names of the targets the write succeeded for.
+	Succeeded []string
+	// RolledBack are the names of the succeeded targets the component version was removed from again, see WithRollback.
+	RolledBack []string
+	// RollbackFailed are the succeeded targets the component version could not be removed from, see WithRollback.
+	RollbackFailed []TargetError
+}
+
+func newPublishError[T any](operation string, targets []Target, results []result[T]) *PublishError {
+	e := &PublishError{Operation: operation}
+	for i, target := range targets {
+		if err := results[i].err; err != nil {
+			e.Failed = append(e.Failed, TargetError{Target: target.Name, Err: err})
+		} else {
+			e.Succeeded = append(e.Succeeded, target.Name)
+		}
+	}
+	if len(e.Failed) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *PublishError) Error() string {
+	failures := make([]string, 0, len(e.Failed))
+	for _, failed := range e.Failed {
+		failures = append(failures, failed.Error())
+	}
+	msg := fmt.Sprintf("failed to %s for %d of %d mirror targets: %s",
+		e.Operation, len(e.Failed), len(e.Failed)+len(e.Succeeded), strings.Join(failures, "; "))
+	if len(e.RollbackFailed) > 0 {
+		msg += fmt.Sprintf(" (rollback failed for %d targets)", len(e.RollbackFailed))
+	}
+	return msg
+}
+
+func (e *PublishError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, failed := range e.Failed {
+		errs = append(errs, failed)
+	}
+	return errs
+}
+
+// NewTargets creates a target for every repository specification with the provider. The credentials of
+// each target are resolved separately with the consumer identity of its specification, if resolver is set.
+// The targets are named after the JSON of their specifications.
+func NewTargets(ctx context.Context, provider repository.ComponentVersionRepositoryProvider, resolver credentials.Resolver, specifications ...runtime.Typed) ([]Target, error) {
+	targets := make([]Target, 0, len(specifications))
+	for _, specification := range specifications {
+		var creds runtime.Typed
+		consumerIdentity, err := provider.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, specification)
+		if err == nil {
+			if resolver != nil {
+				if creds, err = resolver.Resolve(ctx, consumerIdentity); err != nil {
+					if errors.Is(err, credentials.ErrNotFound) {
+						slog.DebugContext(ctx, fmt.Sprintf("resolving credentials for mirror target %q failed: %s", specification, err.Error()))
+					} else {
+						return nil, fmt.Errorf("resolving credentials for mirror target %q failed: %w", specification, err)
+					}
+				}
+			}
+		} else {
+			slog.DebugContext(ctx, "could not get credential consumer identity for mirror target", "repository", specification, "error", err)
+		}
+
+		repo, err := provider.GetComponentVersionRepository(ctx, specification, creds)
+		if err != nil {
+			return nil, fmt.Errorf("getting component version repository for mirror target %q failed: %w", specification, err)
+		}
+		name, err := json.Marshal(specification)
+		if err != nil {
+			return nil, fmt.Errorf("encoding mirror target %q failed: %w", specification, err)
+		}
+		targets = append(targets, Target{Name: string(name), Repository: repo})
+	}
+	return targets, nil
+}
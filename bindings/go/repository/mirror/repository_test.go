@@ -0,0 +1,206 @@
+package mirror_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/credentials"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/mirror"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+type fakeRepository struct {
+	repository.ComponentVersionRepository
+	mu        sync.Mutex
+	err       error
+	versions  map[string]*descriptor.Descriptor
+	resources map[string]string
+}
+
+func newFakeRepository(err error) *fakeRepository {
+	return &fakeRepository{err: err, versions: map[string]*descriptor.Descriptor{}, resources: map[string]string{}}
+}
+
+func (f *fakeRepository) AddComponentVersion(_ context.Context, desc *descriptor.Descriptor) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.versions[desc.Component.Name+":"+desc.Component.Version] = desc
+	return nil
+}
+
+func (f *fakeRepository) GetComponentVersion(_ context.Context, component, version string) (*descriptor.Descriptor, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	desc, ok := f.versions[component+":"+version]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return desc, nil
+}
+
+func (f *fakeRepository) AddLocalResource(_ context.Context, _, _ string, res *descriptor.Resource, content blob.ReadOnlyBlob) (*descriptor.Resource, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	reader, err := content.ReadCloser()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resources[res.Name] = string(data)
+	return res, nil
+}
+
+// deletingRepository is a fakeRepository that can delete component versions.
+type deletingRepository struct {
+	*fakeRepository
+}
+
+func (d *deletingRepository) DeleteComponentVersion(_ context.Context, component, version string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.versions, component+":"+version)
+	return nil
+}
+
+func testDescriptor() *descriptor.Descriptor {
+	return &descriptor.Descriptor{
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: "ocm.software/test", Version: "1.0.0"}},
+			Provider:      descriptor.Provider{Name: "ocm.software"},
+		},
+	}
+}
+
+func TestNewRepository(t *testing.T) {
+	r := require.New(t)
+	_, err := mirror.NewRepository(nil)
+	r.ErrorIs(err, mirror.ErrNoTargets)
+
+	repo := newFakeRepository(nil)
+	_, err = mirror.NewRepository([]mirror.Target{{Name: "a", Repository: repo}, {Name: "a", Repository: repo}})
+	r.ErrorContains(err, "duplicate mirror target")
+}
+
+func TestRepository_AddComponentVersion(t *testing.T) {
+	t.Run("publishes to all targets", func(t *testing.T) {
+		r := require.New(t)
+		primary, secondary := newFakeRepository(nil), newFakeRepository(nil)
+		repo, err := mirror.NewRepository([]mirror.Target{{Name: "primary", Repository: primary}, {Name: "secondary", Repository: secondary}})
+		r.NoError(err)
+
+		content := []byte("content")
+		res := &descriptor.Resource{ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "resource", Version: "1.0.0"}}}
+		added, err := repo.AddLocalResource(t.Context(), "ocm.software/test", "1.0.0", res, inmemory.New(bytes.NewReader(content)))
+		r.NoError(err)
+		r.Equal("resource", added.Name)
+		r.NoError(repo.AddComponentVersion(t.Context(), testDescriptor()))
+
+		for _, target := range []*fakeRepository{primary, secondary} {
+			r.Equal("content", target.resources["resource"])
+			r.Contains(target.versions, "ocm.software/test:1.0.0")
+		}
+		desc, err := repo.GetComponentVersion(t.Context(), "ocm.software/test", "1.0.0")
+		r.NoError(err)
+		r.Equal("ocm.software/test", desc.Component.Name)
+	})
+
+	t.Run("reports partial failures", func(t *testing.T) {
+		r := require.New(t)
+		failure := errors.New("registry unavailable")
+		healthy := newFakeRepository(nil)
+		repo, err := mirror.NewRepository([]mirror.Target{
+			{Name: "healthy", Repository: healthy},
+			{Name: "broken", Repository: newFakeRepository(failure)},
+		})
+		r.NoError(err)
+
+		err = repo.AddComponentVersion(t.Context(), testDescriptor())
+		r.ErrorIs(err, failure)
+		var publishErr *mirror.PublishError
+		r.ErrorAs(err, &publishErr)
+		r.Equal([]string{"healthy"}, publishErr.Succeeded)
+		r.Len(publishErr.Failed, 1)
+		r.Equal("broken", publishErr.Failed[0].Target)
+		r.Contains(healthy.versions, "ocm.software/test:1.0.0", "succeeded targets keep the component version")
+	})
+
+	t.Run("rolls back succeeded targets", func(t *testing.T) {
+		r := require.New(t)
+		deleting, nonDeleting := &deletingRepository{newFakeRepository(nil)}, newFakeRepository(nil)
+		repo, err := mirror.NewRepository([]mirror.Target{
+			{Name: "deleting", Repository: deleting},
+			{Name: "non-deleting", Repository: nonDeleting},
+			{Name: "broken", Repository: newFakeRepository(errors.New("registry unavailable"))},
+		}, mirror.WithRollback())
+		r.NoError(err)
+
+		var publishErr *mirror.PublishError
+		r.ErrorAs(repo.AddComponentVersion(t.Context(), testDescriptor()), &publishErr)
+		r.Equal([]string{"deleting"}, publishErr.RolledBack)
+		r.NotContains(deleting.versions, "ocm.software/test:1.0.0")
+		r.Len(publishErr.RollbackFailed, 1)
+		r.Equal("non-deleting", publishErr.RollbackFailed[0].Target)
+		r.ErrorContains(publishErr, "rollback failed for 1 targets")
+	})
+}
+
+type fakeProvider struct {
+	repository.ComponentVersionRepositoryProvider
+	credentials map[string]runtime.Typed
+}
+
+func (f *fakeProvider) GetComponentVersionRepositoryCredentialConsumerIdentity(_ context.Context, spec runtime.Typed) (runtime.Identity, error) {
+	return runtime.Identity{"hostname": string(spec.(*runtime.Raw).Data)}, nil
+}
+
+func (f *fakeProvider) GetComponentVersionRepository(_ context.Context, spec runtime.Typed, creds runtime.Typed) (repository.ComponentVersionRepository, error) {
+	f.credentials[string(spec.(*runtime.Raw).Data)] = creds
+	return newFakeRepository(nil), nil
+}
+
+type fakeResolver map[string]runtime.Typed
+
+func (f fakeResolver) Resolve(_ context.Context, identity runtime.Identity) (runtime.Typed, error) {
+	creds, ok := f[identity["hostname"]]
+	if !ok {
+		return nil, credentials.ErrNotFound
+	}
+	return creds, nil
+}
+
+func TestNewTargets(t *testing.T) {
+	r := require.New(t)
+	provider := &fakeProvider{credentials: map[string]runtime.Typed{}}
+	creds := &runtime.Raw{Type: runtime.NewVersionedType("Credentials", "v1"), Data: []byte(`{"username":"a"}`)}
+	resolver := fakeResolver{`"a.example.com"`: creds}
+
+	targets, err := mirror.NewTargets(t.Context(), provider, resolver,
+		&runtime.Raw{Type: runtime.NewVersionedType("OCIRepository", "v1"), Data: []byte(`"a.example.com"`)},
+		&runtime.Raw{Type: runtime.NewVersionedType("OCIRepository", "v1"), Data: []byte(`"b.example.com"`)},
+	)
+	r.NoError(err)
+	r.Len(targets, 2)
+	r.Equal(creds, provider.credentials[`"a.example.com"`], "each target is set up with its own credentials")
+	r.Nil(provider.credentials[`"b.example.com"`], "targets without credentials are set up without")
+	r.NotEqual(targets[0].Name, targets[1].Name)
+}
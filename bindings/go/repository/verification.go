@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// VerifiedComponentVersionGetter is an optional interface that can be implemented by a component version
+// repository that verifies component versions on retrieval, e.g. by checking their signatures.
+// It returns the verification a component version passed together with its descriptor, so that consumers
+// can display and enforce provenance without verifying the component version again.
+type VerifiedComponentVersionGetter interface {
+	// GetVerifiedComponentVersion retrieves a component version like
+	// ComponentVersionRepository.GetComponentVersion. The returned Verification is nil if the
+	// component version was not verified. A failed verification is returned as error.
+	GetVerifiedComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, *Verification, error)
+}
+
+// Verification describes the verification a component version passed on retrieval.
+type Verification struct {
+	// Signatures are the verified signatures.
+	Signatures []SignatureVerification `json:"signatures,omitempty"`
+	// Digest is the expected digest the component version was verified against, e.g. the digest
+	// of a component reference. It is nil if the component version was not verified against a digest.
+	Digest *v2.Digest `json:"digest,omitempty"`
+	// VerifiedAt is the time of the verification. Verifications are typically cached,
+	// so it can be older than the retrieval.
+	VerifiedAt time.Time `json:"verifiedAt"`
+	// Deviations are the component references of the component version that were overridden by the
+	// consumer. The signatures cover the original references, not the substitutes.
+	Deviations []Deviation `json:"deviations,omitempty"`
+}
+
+// Deviation describes a component reference that was overridden at consumption time to point to a
+// substitute component version instead of the component version chosen by the producer.
+type Deviation struct {
+	// Reference is the identity of the overridden reference within the component version.
+	Reference runtime.Identity `json:"reference"`
+	// Component and Version identify the originally referenced component version.
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	// Digest is the digest the original reference pinned, if any.
+	Digest *v2.Digest `json:"digest,omitempty"`
+	// SubstituteComponent and SubstituteVersion identify the component version used instead.
+	SubstituteComponent string `json:"substituteComponent"`
+	SubstituteVersion   string `json:"substituteVersion"`
+	// Reason documents why the reference was overridden.
+	Reason string `json:"reason,omitempty"`
+}
+
+// SignatureVerification describes a verified signature of a component version.
+type SignatureVerification struct {
+	// Name is the name of the signature in the component descriptor.
+	Name string `json:"name"`
+	// Algorithm is the signing algorithm of the signature.
+	Algorithm string `json:"algorithm"`
+	// MediaType is the media type of the signature value.
+	MediaType string `json:"mediaType,omitempty"`
+	// Issuer is the identity of the signer, if the signature states one.
+	Issuer string `json:"issuer,omitempty"`
+	// Digest is the digest of the normalised component descriptor covered by the signature.
+	Digest v2.Digest `json:"digest"`
+}
+
+// NewSignatureVerification describes the successful verification of signature.
+func NewSignatureVerification(signature descriptor.Signature) SignatureVerification {
+	return SignatureVerification{
+		Name:      signature.Name,
+		Algorithm: signature.Signature.Algorithm,
+		MediaType: signature.Signature.MediaType,
+		Issuer:    signature.Signature.Issuer,
+		Digest:    *descriptor.ConvertToV2Digest(&signature.Digest),
+	}
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// DefaultWatchInterval is the interval in which WatchComponentVersions polls the repository if none is given.
+const DefaultWatchInterval = time.Minute
+
+// ComponentVersionNotifier is an optional interface that can be implemented by a component version repository
+// whose backend pushes events when component versions are added, e.g. registries with event notifications.
+// WatchComponentVersions lists the versions of the component again on every notification instead of waiting
+// for the next poll, so new versions are discovered without delay.
+type ComponentVersionNotifier interface {
+	// NotifyComponentVersions returns a channel that receives a value whenever versions of the component
+	// may have been added. The channel is closed at the latest when ctx is done.
+	NotifyComponentVersions(ctx context.Context, component string) (<-chan struct{}, error)
+}
+
+// WatchOptions configure WatchComponentVersions.
+type WatchOptions struct {
+	// Interval is the interval in which the repository is polled. Defaults to DefaultWatchInterval.
+	Interval time.Duration
+	// SemverConstraint restricts the watched versions to the ones matching the constraint, e.g. ">= 1.0.0".
+	// Versions that are no semantic versions are ignored if a constraint is set.
+	SemverConstraint string
+	// Since is the cursor of the watch: only versions greater than Since are emitted.
+	// If empty, all versions of the component are emitted, starting with the versions already in the repository.
+	// Versions that are no semantic versions are ignored if Since is set.
+	Since string
+	// Notifier optionally triggers additional polls, see ComponentVersionNotifier.
+	// If nil, the repository is used if it implements ComponentVersionNotifier.
+	Notifier ComponentVersionNotifier
+}
+
+// ComponentVersionEvent is emitted by WatchComponentVersions.
+type ComponentVersionEvent struct {
+	// Component is the name of the watched component.
+	Component string
+	// Version is the version that appeared in the repository. It is empty if Err is set.
+	Version string
+	// Err is set if listing the versions failed. The watch continues with the next poll.
+	Err error
+}
+
+// WatchComponentVersions polls the repository for versions of the component and emits an event for every
+// version that appears in it, in ascending semantic version order per poll. Every version is emitted at most once.
+//
+// The returned channel is closed when ctx is done. Events have to be received, a poll blocks until all of its
+// events were received.
+func WatchComponentVersions(ctx context.Context, repo ComponentVersionRepository, component string, opts WatchOptions) (<-chan ComponentVersionEvent, error) {
+	if opts.Interval < 0 {
+		return nil, fmt.Errorf("invalid watch interval %s, must be zero or positive", opts.Interval)
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+
+	var constraint *semver.Constraints
+	if opts.SemverConstraint != "" {
+		c, err := semver.NewConstraint(opts.SemverConstraint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid semver constraint %q: %w", opts.SemverConstraint, err)
+		}
+		constraint = c
+	}
+	var since *semver.Version
+	if opts.Since != "" {
+		v, err := semver.NewVersion(opts.Since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since version %q: %w", opts.Since, err)
+		}
+		since = v
+	}
+
+	notifier := opts.Notifier
+	if notifier == nil {
+		notifier, _ = repo.(ComponentVersionNotifier)
+	}
+	var notifications <-chan struct{}
+	if notifier != nil {
+		n, err := notifier.NotifyComponentVersions(ctx, component)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to notifications for component %q: %w", component, err)
+		}
+		notifications = n
+	}
+
+	w := &watcher{
+		repo:       repo,
+		component:  component,
+		constraint: constraint,
+		since:      since,
+		seen:       make(map[string]struct{}),
+		events:     make(chan ComponentVersionEvent),
+	}
+	go w.run(ctx, opts.Interval, notifications)
+
+	return w.events, nil
+}
+
+type watcher struct {
+	repo       ComponentVersionRepository
+	component  string
+	constraint *semver.Constraints
+	since      *semver.Version
+
+	// seen are the versions already emitted or filtered out.
+	seen   map[string]struct{}
+	events chan ComponentVersionEvent
+}
+
+func (w *watcher) run(ctx context.Context, interval time.Duration, notifications <-chan struct{}) {
+	defer close(w.events)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if !w.poll(ctx) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case _, ok := <-notifications:
+			if !ok {
+				slog.DebugContext(ctx, "notifications for component versions closed, falling back to polling", "component", w.component)
+				notifications = nil
+			}
+		}
+	}
+}
+
+// poll lists the versions of the component and emits the new ones.
+// It returns false if ctx is done.
+func (w *watcher) poll(ctx context.Context) bool {
+	versions, err := w.repo.ListComponentVersions(ctx, w.component)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		return w.emit(ctx, ComponentVersionEvent{
+			Component: w.component,
+			Err:       fmt.Errorf("failed to list versions of component %q: %w", w.component, err),
+		})
+	}
+
+	type candidate struct {
+		version string
+		semver  *semver.Version
+	}
+	var candidates []candidate
+	for _, version := range versions {
+		if _, ok := w.seen[version]; ok {
+			continue
+		}
+		w.seen[version] = struct{}{}
+
+		// v is nil if the version is no semantic version.
+		v, _ := semver.NewVersion(version)
+		if w.constraint != nil && (v == nil || !w.constraint.Check(v)) {
+			continue
+		}
+		if w.since != nil && (v == nil || !v.GreaterThan(w.since)) {
+			continue
+		}
+		candidates = append(candidates, candidate{version: version, semver: v})
+	}
+
+	// semantic versions in ascending order first, all other versions in the order of the listing.
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.semver != nil && b.semver != nil:
+			return a.semver.Compare(b.semver)
+		case a.semver != nil:
+			return -1
+		case b.semver != nil:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, c := range candidates {
+		if !w.emit(ctx, ComponentVersionEvent{Component: w.component, Version: c.version}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *watcher) emit(ctx context.Context, event ComponentVersionEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case w.events <- event:
+		return true
+	}
+}
@@ -0,0 +1,145 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/repository"
+)
+
+// versionRepository lists versions that can be changed while it is watched.
+type versionRepository struct {
+	repository.ComponentVersionRepository
+
+	mu       sync.Mutex
+	versions []string
+	err      error
+}
+
+func (r *versionRepository) ListComponentVersions(_ context.Context, _ string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.versions), r.err
+}
+
+func (r *versionRepository) set(err error, versions ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.versions, r.err = versions, err
+}
+
+// channelNotifier notifies about changes whenever a value is sent to it.
+type channelNotifier chan struct{}
+
+func (n channelNotifier) NotifyComponentVersions(_ context.Context, _ string) (<-chan struct{}, error) {
+	return n, nil
+}
+
+func receive(t *testing.T, events <-chan repository.ComponentVersionEvent, n int) []repository.ComponentVersionEvent {
+	t.Helper()
+	var received []repository.ComponentVersionEvent
+	for range n {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %d", len(received)+1)
+		}
+	}
+	return received
+}
+
+func versions(events []repository.ComponentVersionEvent) []string {
+	out := make([]string, 0, len(events))
+	for _, event := range events {
+		out = append(out, event.Version)
+	}
+	return out
+}
+
+func TestWatchComponentVersions(t *testing.T) {
+	t.Run("emits new versions once in semver order", func(t *testing.T) {
+		r := require.New(t)
+		repo := &versionRepository{versions: []string{"1.1.0", "1.0.0", "latest"}}
+
+		events, err := repository.WatchComponentVersions(t.Context(), repo, "ocm.software/test", repository.WatchOptions{Interval: 10 * time.Millisecond})
+		r.NoError(err)
+		r.Equal([]string{"1.0.0", "1.1.0", "latest"}, versions(receive(t, events, 3)))
+
+		repo.set(nil, "1.1.0", "1.0.0", "latest", "1.0.1", "2.0.0")
+		r.Equal([]string{"1.0.1", "2.0.0"}, versions(receive(t, events, 2)))
+	})
+
+	t.Run("filters by constraint and since cursor", func(t *testing.T) {
+		r := require.New(t)
+		repo := &versionRepository{versions: []string{"0.9.0", "1.0.0", "1.1.0", "2.0.0", "latest"}}
+
+		events, err := repository.WatchComponentVersions(t.Context(), repo, "ocm.software/test", repository.WatchOptions{
+			Interval:         10 * time.Millisecond,
+			SemverConstraint: "< 2.0.0",
+			Since:            "1.0.0",
+		})
+		r.NoError(err)
+		r.Equal([]string{"1.1.0"}, versions(receive(t, events, 1)))
+
+		repo.set(nil, "1.2.0", "2.1.0")
+		r.Equal([]string{"1.2.0"}, versions(receive(t, events, 1)))
+	})
+
+	t.Run("reports list errors and continues", func(t *testing.T) {
+		r := require.New(t)
+		repo := &versionRepository{err: errors.New("registry unavailable")}
+
+		events, err := repository.WatchComponentVersions(t.Context(), repo, "ocm.software/test", repository.WatchOptions{Interval: 10 * time.Millisecond})
+		r.NoError(err)
+		r.ErrorContains(receive(t, events, 1)[0].Err, "registry unavailable")
+
+		repo.set(nil, "1.0.0")
+		for event := range events {
+			if event.Err == nil {
+				r.Equal("1.0.0", event.Version)
+				break
+			}
+		}
+	})
+
+	t.Run("polls on notification", func(t *testing.T) {
+		r := require.New(t)
+		repo := &versionRepository{}
+		notifier := make(channelNotifier, 1)
+
+		events, err := repository.WatchComponentVersions(t.Context(), repo, "ocm.software/test", repository.WatchOptions{
+			Interval: time.Hour,
+			Notifier: notifier,
+		})
+		r.NoError(err)
+
+		repo.set(nil, "1.0.0")
+		notifier <- struct{}{}
+		r.Equal([]string{"1.0.0"}, versions(receive(t, events, 1)))
+	})
+
+	t.Run("closes events when the context is done", func(t *testing.T) {
+		r := require.New(t)
+		ctx, cancel := context.WithCancel(t.Context())
+		events, err := repository.WatchComponentVersions(ctx, &versionRepository{}, "ocm.software/test", repository.WatchOptions{})
+		r.NoError(err)
+		cancel()
+		for range events {
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		r := require.New(t)
+		_, err := repository.WatchComponentVersions(t.Context(), &versionRepository{}, "ocm.software/test", repository.WatchOptions{SemverConstraint: "not a constraint"})
+		r.ErrorContains(err, "invalid semver constraint")
+		_, err = repository.WatchComponentVersions(t.Context(), &versionRepository{}, "ocm.software/test", repository.WatchOptions{Since: "latest"})
+		r.ErrorContains(err, "invalid since version")
+	})
+}
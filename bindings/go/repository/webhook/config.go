@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/repository/webhook/spec/v1alpha1"
+)
+
+// LookupEmitter creates an Emitter from the webhook configurations in the central configuration,
+// see v1alpha1.Config. It returns nil if no endpoints are configured.
+// The options are applied after the configuration, e.g. to set the HTTP client.
+func LookupEmitter(cfg *genericv1.Config, opts ...Option) (*Emitter, error) {
+	config, err := v1alpha1.LookupConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook configuration: %w", err)
+	}
+	return NewEmitterFromConfig(config, opts...)
+}
+
+// NewEmitterFromConfig creates an Emitter from the webhook configuration.
+// It returns nil if no endpoints are configured.
+func NewEmitterFromConfig(config *v1alpha1.Config, opts ...Option) (*Emitter, error) {
+	if config == nil || len(config.Endpoints) == 0 {
+		return nil, nil
+	}
+
+	var configured []Option
+	if config.Timeout != "" {
+		timeout, err := time.ParseDuration(config.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid webhook timeout %q, must be a positive duration like 10s", config.Timeout)
+		}
+		configured = append(configured, WithTimeout(timeout))
+	}
+	if config.MaxAttempts > 0 {
+		configured = append(configured, WithMaxAttempts(config.MaxAttempts))
+	}
+	if config.NormalisationAlgorithm != "" {
+		hash := DefaultHashAlgorithm
+		if config.HashAlgorithm != "" {
+			var err error
+			if hash, err = HashAlgorithm(config.HashAlgorithm); err != nil {
+				return nil, fmt.Errorf("invalid webhook digest: %w", err)
+			}
+		}
+		configured = append(configured, WithDigestAlgorithms(config.NormalisationAlgorithm, hash))
+	}
+
+	endpoints := make([]Endpoint, 0, len(config.Endpoints))
+	var errs []error
+	for _, endpoint := range config.Endpoints {
+		secret := []byte(endpoint.Secret)
+		if len(secret) == 0 && endpoint.SecretFile != "" {
+			var err error
+			if secret, err = os.ReadFile(endpoint.SecretFile); err != nil {
+				errs = append(errs, fmt.Errorf("failed to read secret of webhook endpoint %s: %w", redact(endpoint.URL), err))
+				continue
+			}
+			secret = bytes.TrimRight(secret, "\r\n")
+		}
+		endpoints = append(endpoints, Endpoint{URL: endpoint.URL, Secret: secret})
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return NewEmitter(endpoints, append(configured, opts...)...), nil
+}
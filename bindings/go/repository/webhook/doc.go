@@ -0,0 +1,17 @@
+// Package webhook emits events to webhook endpoints when component versions are published,
+// so that downstream systems such as catalogs or continuous delivery get push-based updates
+// instead of polling repositories.
+//
+// An [Emitter] POSTs a JSON [Event] to all configured endpoints. Deliveries are retried on
+// network errors, rate limiting and server errors, and signed with HMAC-SHA256 if the endpoint
+// has a secret, so receivers can authenticate events with [VerifySignature].
+//
+// [NewRepository] wraps a component version repository and emits an event after every
+// successful AddComponentVersion. [NewProvider] wraps a repository provider so that all
+// repositories it returns, including the targets of transfers, emit events. The events are
+// delivered in the background, so that publishing is not delayed by the endpoints; processes
+// call [Emitter.Wait] before they exit to deliver the pending events.
+//
+// The endpoints are configured with the configuration type webhook.config.ocm.software/v1alpha1,
+// see [LookupEmitter].
+package webhook
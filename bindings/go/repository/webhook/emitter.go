@@ -0,0 +1,250 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// EventTypeComponentVersionPublished is the type of events emitted after a component version was added to a repository.
+	EventTypeComponentVersionPublished = "ComponentVersionPublished"
+
+	// EventHeader carries the type of the delivered event.
+	EventHeader = "X-OCM-Event"
+	// DeliveryHeader carries the ID of the delivered event, which is the same for all attempts and endpoints.
+	DeliveryHeader = "X-OCM-Delivery"
+	// SignatureHeader carries the HMAC-SHA256 signature of the request body in the form sha256=<hex>.
+	SignatureHeader = "X-OCM-Signature-256"
+
+	signaturePrefix = "sha256="
+)
+
+// Event is the JSON payload POSTed to webhook endpoints.
+type Event struct {
+	// Type is the type of the event, e.g. EventTypeComponentVersionPublished.
+	Type string `json:"type"`
+	// ID uniquely identifies the event, so receivers can deduplicate retried deliveries.
+	ID string `json:"id"`
+	// Time is the time the event occurred.
+	Time time.Time `json:"time"`
+	// Component is the name of the component.
+	Component string `json:"component"`
+	// Version is the version of the component.
+	Version string `json:"version"`
+	// Digest is the digest of the normalised component descriptor, see [Digest].
+	Digest *v2.Digest `json:"digest,omitempty"`
+	// Repository is the specification of the repository the component version was published to.
+	Repository runtime.Typed `json:"repository,omitempty"`
+}
+
+// Endpoint is a webhook endpoint events are delivered to.
+type Endpoint struct {
+	// URL is the URL events are POSTed to.
+	URL string
+	// Secret is the key used to sign events with HMAC-SHA256. Events are not signed if it is empty.
+	Secret []byte
+}
+
+// Emitter delivers events to webhook endpoints.
+type Emitter struct {
+	endpoints   []Endpoint
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+
+	normalisationAlgorithm string
+	hash                   crypto.Hash
+
+	// pending tracks the deliveries started with EmitAsync.
+	pending sync.WaitGroup
+}
+
+// NewEmitter creates an Emitter delivering events to the endpoints.
+func NewEmitter(endpoints []Endpoint, opts ...Option) *Emitter {
+	options := &Options{
+		Timeout:     DefaultTimeout,
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	client := options.Client
+	if client == nil {
+		client = &http.Client{Timeout: options.Timeout}
+	}
+	return &Emitter{
+		endpoints:              endpoints,
+		client:                 client,
+		maxAttempts:            max(options.MaxAttempts, 1),
+		backoff:                options.Backoff,
+		normalisationAlgorithm: options.NormalisationAlgorithm,
+		hash:                   options.HashAlgorithm,
+	}
+}
+
+// Emit delivers the event to all endpoints concurrently and waits for the deliveries to finish.
+// An empty ID and Time of the event are filled in. It returns the errors of all failed deliveries.
+func (e *Emitter) Emit(ctx context.Context, event Event) error {
+	if len(e.endpoints) == 0 {
+		return nil
+	}
+	if event.ID == "" {
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			return fmt.Errorf("failed to generate event id: %w", err)
+		}
+		event.ID = hex.EncodeToString(id)
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	errs := make([]error, len(e.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range e.endpoints {
+		wg.Go(func() {
+			if err := e.deliver(ctx, endpoint, event, body); err != nil {
+				errs[i] = fmt.Errorf("failed to deliver event %s to %s: %w", event.ID, redact(endpoint.URL), err)
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// EmitAsync delivers the event to all endpoints in the background, so that publishing is not delayed
+// by slow or unavailable endpoints. Deliveries are not canceled with ctx, and failed deliveries are logged.
+// Use Wait to wait for the pending deliveries, e.g. before the process exits.
+func (e *Emitter) EmitAsync(ctx context.Context, event Event) {
+	if len(e.endpoints) == 0 {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	e.pending.Go(func() {
+		if err := e.Emit(ctx, event); err != nil {
+			slog.WarnContext(ctx, "failed to emit webhook event",
+				"type", event.Type, "component", event.Component, "version", event.Version, "error", err)
+		}
+	})
+}
+
+// Wait waits until the deliveries started with EmitAsync finished or ctx is done.
+func (e *Emitter) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		e.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("pending webhook deliveries did not finish: %w", ctx.Err())
+	}
+}
+
+// deliver POSTs the event to the endpoint, retrying with exponential backoff.
+func (e *Emitter) deliver(ctx context.Context, endpoint Endpoint, event Event, body []byte) error {
+	backoff := e.backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		if retry, err = e.post(ctx, endpoint, event, body); err == nil || !retry || attempt >= e.maxAttempts {
+			return err
+		}
+		slog.DebugContext(ctx, "retrying webhook delivery", "endpoint", redact(endpoint.URL), "event", event.ID, "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends a single delivery attempt and reports whether a failed attempt should be retried.
+func (e *Emitter) post(ctx context.Context, endpoint Endpoint, event Event, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.ID)
+	if len(endpoint.Secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode >= 500
+	return retry, fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+}
+
+// Sign returns the value of the SignatureHeader for the body signed with the secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is a valid value of the SignatureHeader for the body signed with the secret.
+// Receivers use it to authenticate events.
+func VerifySignature(secret, body []byte, signature string) bool {
+	encoded, ok := strings.CutPrefix(signature, signaturePrefix)
+	if !ok {
+		return false
+	}
+	sig, err := hex.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// redact strips credentials, query parameters and fragments, which often carry tokens, from endpoint URLs for errors and logs.
+func redact(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "<invalid url>"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
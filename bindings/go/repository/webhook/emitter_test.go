@@ -0,0 +1,162 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/repository/webhook"
+	"ocm.software/open-component-model/bindings/go/repository/webhook/spec/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// receiver records the deliveries of a webhook endpoint and fails the first failures of them with status.
+type receiver struct {
+	mu         sync.Mutex
+	bodies     [][]byte
+	headers    []http.Header
+	failures   int
+	failStatus int
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.bodies = append(rc.bodies, body)
+	rc.headers = append(rc.headers, r.Header.Clone())
+	if rc.failures > 0 {
+		rc.failures--
+		w.WriteHeader(rc.failStatus)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newReceiver(t *testing.T, failures, failStatus int) (*receiver, string) {
+	t.Helper()
+	rc := &receiver{failures: failures, failStatus: failStatus}
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+	return rc, server.URL
+}
+
+func TestEmitter_Emit(t *testing.T) {
+	r := require.New(t)
+	secret := []byte("secret")
+	signed, signedURL := newReceiver(t, 0, 0)
+	unsigned, unsignedURL := newReceiver(t, 0, 0)
+
+	emitter := webhook.NewEmitter([]webhook.Endpoint{
+		{URL: signedURL, Secret: secret},
+		{URL: unsignedURL},
+	})
+	r.NoError(emitter.Emit(t.Context(), webhook.Event{
+		Type:      webhook.EventTypeComponentVersionPublished,
+		Component: "ocm.software/test",
+		Version:   "1.0.0",
+	}))
+
+	r.Len(signed.bodies, 1)
+	r.Len(unsigned.bodies, 1)
+	r.Equal(signed.bodies[0], unsigned.bodies[0], "all endpoints receive the same event")
+
+	var event webhook.Event
+	r.NoError(json.Unmarshal(signed.bodies[0], &event))
+	r.Equal("ocm.software/test", event.Component)
+	r.Equal("1.0.0", event.Version)
+	r.NotEmpty(event.ID)
+	r.False(event.Time.IsZero())
+
+	r.Equal(webhook.EventTypeComponentVersionPublished, signed.headers[0].Get(webhook.EventHeader))
+	r.Equal(event.ID, signed.headers[0].Get(webhook.DeliveryHeader))
+	r.True(webhook.VerifySignature(secret, signed.bodies[0], signed.headers[0].Get(webhook.SignatureHeader)))
+	r.False(webhook.VerifySignature([]byte("other"), signed.bodies[0], signed.headers[0].Get(webhook.SignatureHeader)))
+	r.Empty(unsigned.headers[0].Get(webhook.SignatureHeader))
+}
+
+func TestEmitter_Retries(t *testing.T) {
+	t.Run("retries server errors", func(t *testing.T) {
+		r := require.New(t)
+		rc, url := newReceiver(t, 2, http.StatusServiceUnavailable)
+		emitter := webhook.NewEmitter([]webhook.Endpoint{{URL: url}}, webhook.WithBackoff(time.Millisecond))
+
+		r.NoError(emitter.Emit(t.Context(), webhook.Event{Type: webhook.EventTypeComponentVersionPublished}))
+		r.Len(rc.bodies, 3)
+		r.Equal(rc.headers[0].Get(webhook.DeliveryHeader), rc.headers[2].Get(webhook.DeliveryHeader),
+			"retries deliver the same event")
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		r := require.New(t)
+		rc, url := newReceiver(t, 5, http.StatusBadGateway)
+		emitter := webhook.NewEmitter([]webhook.Endpoint{{URL: url + "?token=secret"}},
+			webhook.WithBackoff(time.Millisecond), webhook.WithMaxAttempts(2))
+
+		err := emitter.Emit(t.Context(), webhook.Event{Type: webhook.EventTypeComponentVersionPublished})
+		r.ErrorContains(err, "status 502")
+		r.NotContains(err.Error(), "secret", "query parameters must be redacted")
+		r.Len(rc.bodies, 2)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		r := require.New(t)
+		rc, url := newReceiver(t, 1, http.StatusBadRequest)
+		emitter := webhook.NewEmitter([]webhook.Endpoint{{URL: url}}, webhook.WithBackoff(time.Millisecond))
+
+		r.ErrorContains(emitter.Emit(t.Context(), webhook.Event{Type: webhook.EventTypeComponentVersionPublished}), "status 400")
+		r.Len(rc.bodies, 1)
+	})
+}
+
+func TestLookupEmitter(t *testing.T) {
+	t.Run("no endpoints", func(t *testing.T) {
+		r := require.New(t)
+		emitter, err := webhook.LookupEmitter(nil)
+		r.NoError(err)
+		r.Nil(emitter)
+	})
+
+	t.Run("endpoints with secret files", func(t *testing.T) {
+		r := require.New(t)
+		rc, url := newReceiver(t, 0, 0)
+		secretFile := filepath.Join(t.TempDir(), "secret")
+		r.NoError(os.WriteFile(secretFile, []byte("secret\n"), 0o600))
+
+		data, err := json.Marshal(&v1alpha1.Config{
+			Type:      runtime.NewVersionedType(v1alpha1.ConfigType, v1alpha1.Version),
+			Timeout:   "5s",
+			Endpoints: []v1alpha1.Endpoint{{URL: url, SecretFile: secretFile}},
+		})
+		r.NoError(err)
+		cfg := &genericv1.Config{Configurations: []*runtime.Raw{{
+			Type: runtime.NewVersionedType(v1alpha1.ConfigType, v1alpha1.Version),
+			Data: data,
+		}}}
+		emitter, err := webhook.LookupEmitter(cfg)
+		r.NoError(err)
+		r.NotNil(emitter)
+
+		r.NoError(emitter.Emit(t.Context(), webhook.Event{Type: webhook.EventTypeComponentVersionPublished}))
+		r.Len(rc.bodies, 1)
+		r.True(webhook.VerifySignature([]byte("secret"), rc.bodies[0], rc.headers[0].Get(webhook.SignatureHeader)))
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		r := require.New(t)
+		_, err := webhook.NewEmitterFromConfig(&v1alpha1.Config{
+			Endpoints: []v1alpha1.Endpoint{{URL: "https://example.com"}},
+			Timeout:   "soon",
+		})
+		r.ErrorContains(err, "invalid webhook timeout")
+	})
+}
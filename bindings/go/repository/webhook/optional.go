@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+// The Repository implements the optional interfaces of the repository package, so that wrapping a repository
+// does not hide its capabilities. If the wrapped repository does not implement an interface, reads behave
+// like for a repository without the capability, and writes fail with an error classified as unsupported.
+var (
+	_ repository.ConditionalComponentVersionRepository = (*Repository)(nil)
+	_ repository.ComponentVersionDeleter               = (*Repository)(nil)
+	_ repository.ComponentVersionMetadataRepository    = (*Repository)(nil)
+	_ repository.VerifiedComponentVersionGetter        = (*Repository)(nil)
+	_ repository.ComponentVersionNotifier              = (*Repository)(nil)
+	_ repository.HealthCheckable                       = (*Repository)(nil)
+)
+
+// GetComponentVersionDigest returns the digest of the component version in the wrapped repository.
+func (r *Repository) GetComponentVersionDigest(ctx context.Context, component, version string) (string, error) {
+	conditional, ok := r.ComponentVersionRepository.(repository.ConditionalComponentVersionRepository)
+	if !ok {
+		return "", ocmerrors.Unsupported(errors.New("repository does not support conditional writes"))
+	}
+	return conditional.GetComponentVersionDigest(ctx, component, version)
+}
+
+// AddComponentVersionIf adds the component version to the wrapped repository if the precondition holds
+// and emits an event if it succeeded, see AddComponentVersion.
+func (r *Repository) AddComponentVersionIf(ctx context.Context, desc *descriptor.Descriptor, precondition repository.AddComponentVersionPrecondition) error {
+	conditional, ok := r.ComponentVersionRepository.(repository.ConditionalComponentVersionRepository)
+	if !ok {
+		return ocmerrors.Unsupported(errors.New("repository does not support conditional writes"))
+	}
+	if err := conditional.AddComponentVersionIf(ctx, desc, precondition); err != nil {
+		return err
+	}
+	r.published(ctx, desc)
+	return nil
+}
+
+// DeleteComponentVersion removes the component version from the wrapped repository.
+func (r *Repository) DeleteComponentVersion(ctx context.Context, component, version string) error {
+	deleter, ok := r.ComponentVersionRepository.(repository.ComponentVersionDeleter)
+	if !ok {
+		return ocmerrors.Unsupported(errors.New("repository does not support deleting component versions"))
+	}
+	return deleter.DeleteComponentVersion(ctx, component, version)
+}
+
+// AppendComponentVersionMetadata appends the metadata to the component version in the wrapped repository.
+func (r *Repository) AppendComponentVersionMetadata(ctx context.Context, component, version string, metadata repository.ComponentVersionMetadata) error {
+	metadataRepo, ok := r.ComponentVersionRepository.(repository.ComponentVersionMetadataRepository)
+	if !ok {
+		return ocmerrors.Unsupported(errors.New("repository does not support component version metadata"))
+	}
+	return metadataRepo.AppendComponentVersionMetadata(ctx, component, version, metadata)
+}
+
+// ListComponentVersionMetadata lists the metadata of the component version in the wrapped repository.
+// It returns no metadata if the wrapped repository does not support metadata.
+func (r *Repository) ListComponentVersionMetadata(ctx context.Context, component, version string) ([]repository.ComponentVersionMetadata, error) {
+	metadataRepo, ok := r.ComponentVersionRepository.(repository.ComponentVersionMetadataRepository)
+	if !ok {
+		return nil, nil
+	}
+	return metadataRepo.ListComponentVersionMetadata(ctx, component, version)
+}
+
+// GetVerifiedComponentVersion retrieves the component version and its verification from the wrapped repository.
+// The verification is nil if the wrapped repository does not verify component versions.
+func (r *Repository) GetVerifiedComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, *repository.Verification, error) {
+	getter, ok := r.ComponentVersionRepository.(repository.VerifiedComponentVersionGetter)
+	if !ok {
+		desc, err := r.GetComponentVersion(ctx, component, version)
+		return desc, nil, err
+	}
+	return getter.GetVerifiedComponentVersion(ctx, component, version)
+}
+
+// NotifyComponentVersions subscribes to the notifications of the wrapped repository.
+// The returned channel never receives if the wrapped repository does not push notifications.
+func (r *Repository) NotifyComponentVersions(ctx context.Context, component string) (<-chan struct{}, error) {
+	notifier, ok := r.ComponentVersionRepository.(repository.ComponentVersionNotifier)
+	if !ok {
+		return nil, nil
+	}
+	return notifier.NotifyComponentVersions(ctx, component)
+}
+
+// CheckHealth checks the health of the wrapped repository. Repositories that cannot be checked are healthy.
+func (r *Repository) CheckHealth(ctx context.Context) error {
+	checkable, ok := r.ComponentVersionRepository.(repository.HealthCheckable)
+	if !ok {
+		return nil
+	}
+	return checkable.CheckHealth(ctx)
+}
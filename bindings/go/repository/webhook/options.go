@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"crypto"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of delivery attempts per endpoint.
+	DefaultMaxAttempts = 3
+	// DefaultBackoff is the default delay before the first retry of a delivery. It doubles with every retry.
+	DefaultBackoff = time.Second
+	// DefaultTimeout is the default time limit of a single delivery attempt, including reading the response.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Options holds configuration options for the Emitter.
+type Options struct {
+	Client      *http.Client
+	Timeout     time.Duration
+	MaxAttempts int
+	Backoff     time.Duration
+
+	// NormalisationAlgorithm and HashAlgorithm are the algorithms of the digests in events, see [Digest].
+	// If the normalisation algorithm is empty, the algorithms of the first signature of the component version
+	// are used, so that receivers can compare the digest with the signed one. Unsigned component versions are
+	// digested with DefaultNormalisationAlgorithm and DefaultHashAlgorithm.
+	NormalisationAlgorithm string
+	HashAlgorithm          crypto.Hash
+}
+
+// Option is a function that configures Options.
+type Option func(*Options)
+
+// WithHTTPClient sets the HTTP client used to deliver events.
+// The timeout set with WithTimeout is not applied to it.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		o.Client = client
+	}
+}
+
+// WithTimeout sets the time limit of a single delivery attempt.
+// Defaults to DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = timeout
+	}
+}
+
+// WithMaxAttempts sets the number of delivery attempts per endpoint.
+// Defaults to DefaultMaxAttempts.
+func WithMaxAttempts(attempts int) Option {
+	return func(o *Options) {
+		o.MaxAttempts = attempts
+	}
+}
+
+// WithBackoff sets the delay before the first retry of a delivery, which doubles with every retry.
+// Defaults to DefaultBackoff.
+func WithBackoff(backoff time.Duration) Option {
+	return func(o *Options) {
+		o.Backoff = backoff
+	}
+}
+
+// WithDigestAlgorithms sets the normalisation and hash algorithm of the digests in events.
+// By default, the algorithms of the first signature of the component version are used.
+func WithDigestAlgorithms(normalisationAlgorithm string, hash crypto.Hash) Option {
+	return func(o *Options) {
+		o.NormalisationAlgorithm = normalisationAlgorithm
+		o.HashAlgorithm = hash
+	}
+}
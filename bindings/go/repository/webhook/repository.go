@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"context"
+	"crypto"
+	// registers the hash algorithms that can be used for digests.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	// registers jsonNormalisation/v5alpha1, so that digests can use it.
+	_ "ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v5alpha1"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// DefaultNormalisationAlgorithm is the normalisation algorithm of the digests of unsigned component versions.
+	DefaultNormalisationAlgorithm = v4alpha1.Algorithm
+	// DefaultHashAlgorithm is the hash algorithm of the digests of unsigned component versions.
+	DefaultHashAlgorithm = crypto.SHA256
+)
+
+// supportedHashes are the hash algorithms digests can be computed with, see [HashAlgorithm].
+var supportedHashes = []crypto.Hash{crypto.SHA256, crypto.SHA512}
+
+// Repository is a component version repository that emits an EventTypeComponentVersionPublished
+// event after every successful AddComponentVersion and AddComponentVersionIf.
+// All other operations are passed to the wrapped repository, including the ones of the optional
+// interfaces of the repository package that a repository is typically asserted for.
+type Repository struct {
+	repository.ComponentVersionRepository
+	specification runtime.Typed
+	emitter       *Emitter
+}
+
+var _ repository.ComponentVersionRepository = (*Repository)(nil)
+
+// NewRepository wraps the repository with the given specification, which is reported in events.
+func NewRepository(repo repository.ComponentVersionRepository, specification runtime.Typed, emitter *Emitter) *Repository {
+	return &Repository{
+		ComponentVersionRepository: repo,
+		specification:              specification,
+		emitter:                    emitter,
+	}
+}
+
+// Unwrap returns the wrapped repository.
+func (r *Repository) Unwrap() repository.ComponentVersionRepository {
+	return r.ComponentVersionRepository
+}
+
+// AddComponentVersion adds the component version to the wrapped repository and emits an event if it succeeded.
+// The event is delivered in the background, see Emitter.EmitAsync, so that publishing is neither delayed
+// nor failed by the endpoints.
+func (r *Repository) AddComponentVersion(ctx context.Context, desc *descriptor.Descriptor) error {
+	if err := r.ComponentVersionRepository.AddComponentVersion(ctx, desc); err != nil {
+		return err
+	}
+	r.published(ctx, desc)
+	return nil
+}
+
+// published emits the event for a component version that was added to the repository.
+func (r *Repository) published(ctx context.Context, desc *descriptor.Descriptor) {
+	component, version := desc.Component.Name, desc.Component.Version
+	var digest *v2.Digest
+	if d, err := r.digest(desc); err == nil {
+		digest = &v2.Digest{HashAlgorithm: d.HashAlgorithm, NormalisationAlgorithm: d.NormalisationAlgorithm, Value: d.Value}
+	} else {
+		slog.WarnContext(ctx, "failed to compute digest of published component version, emitting event without digest",
+			"component", component, "version", version, "error", err)
+	}
+	r.emitter.EmitAsync(ctx, Event{
+		Type:       EventTypeComponentVersionPublished,
+		Component:  component,
+		Version:    version,
+		Digest:     digest,
+		Repository: r.specification,
+	})
+}
+
+// digest computes the digest of the descriptor with the algorithms of the emitter, or of the first
+// signature with a digest of the descriptor itself if the emitter has none configured.
+func (r *Repository) digest(desc *descriptor.Descriptor) (*descriptor.Digest, error) {
+	if r.emitter.normalisationAlgorithm != "" {
+		return Digest(desc, r.emitter.normalisationAlgorithm, r.emitter.hash)
+	}
+	for _, signature := range desc.Signatures {
+		if normalisation.Normalisations.Get(signature.Digest.NormalisationAlgorithm) == nil {
+			// e.g. signatures over the artifact holding the descriptor.
+			continue
+		}
+		hash, err := HashAlgorithm(signature.Digest.HashAlgorithm)
+		if err != nil {
+			continue
+		}
+		return Digest(desc, signature.Digest.NormalisationAlgorithm, hash)
+	}
+	return Digest(desc, DefaultNormalisationAlgorithm, DefaultHashAlgorithm)
+}
+
+// Digest computes the digest of the descriptor as it is used in signatures,
+// by hashing the descriptor normalised with the normalisation algorithm with the hash algorithm.
+func Digest(desc *descriptor.Descriptor, normalisationAlgorithm string, hash crypto.Hash) (*descriptor.Digest, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("hash algorithm %s is not available", hash)
+	}
+	normalised, err := normalisation.Normalise(desc, normalisationAlgorithm)
+	if err != nil {
+		return nil, fmt.Errorf("normalising component version failed: %w", err)
+	}
+	h := hash.New()
+	h.Write(normalised)
+	return &descriptor.Digest{
+		HashAlgorithm:          hash.String(),
+		NormalisationAlgorithm: normalisationAlgorithm,
+		Value:                  hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// HashAlgorithm returns the hash algorithm with the name used in digests, e.g. SHA-256.
+func HashAlgorithm(name string) (crypto.Hash, error) {
+	for _, hash := range supportedHashes {
+		if strings.EqualFold(hash.String(), name) {
+			return hash, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported hash algorithm %q", name)
+}
+
+// Provider is a repository provider whose repositories emit events, see [Repository].
+type Provider struct {
+	repository.ComponentVersionRepositoryProvider
+	emitter *Emitter
+}
+
+var _ repository.ComponentVersionRepositoryProvider = (*Provider)(nil)
+
+// NewProvider wraps the provider so that all repositories it returns emit events with the emitter.
+func NewProvider(provider repository.ComponentVersionRepositoryProvider, emitter *Emitter) *Provider {
+	return &Provider{
+		ComponentVersionRepositoryProvider: provider,
+		emitter:                            emitter,
+	}
+}
+
+// GetComponentVersionRepository returns the repository of the wrapped provider wrapped in a [Repository].
+func (p *Provider) GetComponentVersionRepository(ctx context.Context, specification runtime.Typed, credentials runtime.Typed) (repository.ComponentVersionRepository, error) {
+	repo, err := p.ComponentVersionRepositoryProvider.GetComponentVersionRepository(ctx, specification, credentials)
+	if err != nil {
+		return nil, err
+	}
+	return NewRepository(repo, specification, p.emitter), nil
+}
@@ -0,0 +1,185 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/webhook"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+type fakeRepository struct {
+	repository.ComponentVersionRepository
+	err   error
+	added []*descriptor.Descriptor
+}
+
+func (f *fakeRepository) AddComponentVersion(_ context.Context, desc *descriptor.Descriptor) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.added = append(f.added, desc)
+	return nil
+}
+
+type fakeProvider struct {
+	repository.ComponentVersionRepositoryProvider
+	repo *fakeRepository
+}
+
+func (f *fakeProvider) GetComponentVersionRepository(context.Context, runtime.Typed, runtime.Typed) (repository.ComponentVersionRepository, error) {
+	return f.repo, nil
+}
+
+func testDescriptor() *descriptor.Descriptor {
+	return &descriptor.Descriptor{
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{ObjectMeta: descriptor.ObjectMeta{Name: "ocm.software/test", Version: "1.0.0"}},
+			Provider:      descriptor.Provider{Name: "ocm.software"},
+		},
+	}
+}
+
+func TestProvider_AddComponentVersion(t *testing.T) {
+	r := require.New(t)
+	rc, url := newReceiver(t, 0, 0)
+	spec := &runtime.Raw{Type: runtime.NewVersionedType("OCIRepository", "v1"), Data: []byte(`{"type":"OCIRepository/v1","baseUrl":"ghcr.io/open-component-model"}`)}
+	base := &fakeRepository{}
+	emitter := webhook.NewEmitter([]webhook.Endpoint{{URL: url}})
+	provider := webhook.NewProvider(&fakeProvider{repo: base}, emitter)
+
+	repo, err := provider.GetComponentVersionRepository(t.Context(), spec, nil)
+	r.NoError(err)
+	desc := testDescriptor()
+	r.NoError(repo.AddComponentVersion(t.Context(), desc))
+	r.Equal([]*descriptor.Descriptor{desc}, base.added)
+	r.NoError(emitter.Wait(t.Context()))
+
+	r.Len(rc.bodies, 1)
+	var event struct {
+		webhook.Event
+		Repository json.RawMessage `json:"repository"`
+	}
+	r.NoError(json.Unmarshal(rc.bodies[0], &event))
+	r.Equal(webhook.EventTypeComponentVersionPublished, event.Type)
+	r.Equal("ocm.software/test", event.Component)
+	r.Equal("1.0.0", event.Version)
+	r.JSONEq(`{"type":"OCIRepository/v1","baseUrl":"ghcr.io/open-component-model"}`, string(event.Repository))
+
+	digest, err := webhook.Digest(desc, "jsonNormalisation/v4alpha1", crypto.SHA256)
+	r.NoError(err)
+	r.Equal("SHA-256", event.Digest.HashAlgorithm)
+	r.Equal("jsonNormalisation/v4alpha1", event.Digest.NormalisationAlgorithm)
+	r.Equal(digest.Value, event.Digest.Value)
+}
+
+func TestRepository_AddComponentVersion(t *testing.T) {
+	t.Run("failed adds emit no events", func(t *testing.T) {
+		r := require.New(t)
+		rc, url := newReceiver(t, 0, 0)
+		errAdd := errors.New("add failed")
+		repo := webhook.NewRepository(&fakeRepository{err: errAdd}, nil, webhook.NewEmitter([]webhook.Endpoint{{URL: url}}))
+
+		r.ErrorIs(repo.AddComponentVersion(t.Context(), testDescriptor()), errAdd)
+		r.Empty(rc.bodies)
+	})
+
+	t.Run("failed deliveries do not fail the add", func(t *testing.T) {
+		r := require.New(t)
+		rc, url := newReceiver(t, 1, http.StatusBadRequest)
+		base := &fakeRepository{}
+		emitter := webhook.NewEmitter([]webhook.Endpoint{{URL: url}})
+		repo := webhook.NewRepository(base, nil, emitter)
+
+		r.NoError(repo.AddComponentVersion(t.Context(), testDescriptor()))
+		r.Len(base.added, 1)
+		r.NoError(emitter.Wait(t.Context()))
+		r.Len(rc.bodies, 1)
+	})
+
+	t.Run("slow endpoints do not delay the add", func(t *testing.T) {
+		r := require.New(t)
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			<-release
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(server.Close)
+		emitter := webhook.NewEmitter([]webhook.Endpoint{{URL: server.URL}})
+		repo := webhook.NewRepository(&fakeRepository{}, nil, emitter)
+
+		r.NoError(repo.AddComponentVersion(t.Context(), testDescriptor()))
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		r.ErrorIs(emitter.Wait(ctx), context.DeadlineExceeded, "the delivery should still be pending")
+		close(release)
+		r.NoError(emitter.Wait(t.Context()))
+	})
+
+	t.Run("digest uses the algorithms of the signature", func(t *testing.T) {
+		r := require.New(t)
+		rc, url := newReceiver(t, 0, 0)
+		emitter := webhook.NewEmitter([]webhook.Endpoint{{URL: url}})
+		repo := webhook.NewRepository(&fakeRepository{}, nil, emitter)
+		desc := testDescriptor()
+		desc.Signatures = []descriptor.Signature{{
+			Name:   "sig",
+			Digest: descriptor.Digest{HashAlgorithm: "SHA-512", NormalisationAlgorithm: "jsonNormalisation/v5alpha1", Value: "abc"},
+		}}
+
+		r.NoError(repo.AddComponentVersion(t.Context(), desc))
+		r.NoError(emitter.Wait(t.Context()))
+		r.Len(rc.bodies, 1)
+		var event webhook.Event
+		r.NoError(json.Unmarshal(rc.bodies[0], &event))
+		expected, err := webhook.Digest(desc, "jsonNormalisation/v5alpha1", crypto.SHA512)
+		r.NoError(err)
+		r.Equal("SHA-512", event.Digest.HashAlgorithm)
+		r.Equal("jsonNormalisation/v5alpha1", event.Digest.NormalisationAlgorithm)
+		r.Equal(expected.Value, event.Digest.Value)
+	})
+}
+
+type deletingRepository struct {
+	fakeRepository
+	deleted []string
+}
+
+func (d *deletingRepository) DeleteComponentVersion(_ context.Context, component, version string) error {
+	d.deleted = append(d.deleted, component+":"+version)
+	return nil
+}
+
+func TestRepository_OptionalInterfaces(t *testing.T) {
+	emitter := webhook.NewEmitter(nil)
+
+	t.Run("forwards to the wrapped repository", func(t *testing.T) {
+		r := require.New(t)
+		base := &deletingRepository{}
+		repo := webhook.NewRepository(base, nil, emitter)
+
+		r.NoError(repo.DeleteComponentVersion(t.Context(), "ocm.software/test", "1.0.0"))
+		r.Equal([]string{"ocm.software/test:1.0.0"}, base.deleted)
+		r.Same(base, repo.Unwrap())
+	})
+
+	t.Run("writes fail as unsupported without the capability", func(t *testing.T) {
+		r := require.New(t)
+		repo := webhook.NewRepository(&fakeRepository{}, nil, emitter)
+
+		r.True(ocmerrors.IsUnsupported(repo.DeleteComponentVersion(t.Context(), "ocm.software/test", "1.0.0")))
+		r.True(ocmerrors.IsUnsupported(repo.AddComponentVersionIf(t.Context(), testDescriptor(), repository.AddComponentVersionPrecondition{})))
+		r.NoError(repo.CheckHealth(t.Context()))
+	})
+}
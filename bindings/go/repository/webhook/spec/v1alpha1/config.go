@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// ConfigType defines the type identifier for webhook configurations.
+	ConfigType = "webhook.config.ocm.software"
+)
+
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&Config{},
+		runtime.NewVersionedType(ConfigType, Version),
+		runtime.NewUnversionedType(ConfigType),
+	)
+}
+
+// Config configures the webhook endpoints that are notified when component versions are published,
+// e.g. by adding or transferring them.
+//
+//	type: generic.config.ocm.software/v1
+//	configurations:
+//	  - type: webhook.config.ocm.software/v1alpha1
+//	    endpoints:
+//	      - url: https://catalog.example.com/hooks/ocm
+//	        secretFile: /etc/ocm/webhook-secret
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Config struct {
+	// +ocm:jsonschema-gen:enum=webhook.config.ocm.software/v1alpha1
+	// +ocm:jsonschema-gen:enum:deprecated=webhook.config.ocm.software
+	Type runtime.Type `json:"type"`
+
+	// Endpoints are the endpoints events are delivered to. No events are emitted if empty.
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+
+	// Timeout is the time limit of a single delivery attempt as a Go duration, e.g. "10s".
+	// If not defined, 10 seconds are used.
+	Timeout string `json:"timeout,omitempty"`
+
+	// MaxAttempts is the number of delivery attempts per endpoint. If not defined, 3 attempts are made.
+	// +ocm:jsonschema-gen:minimum=1
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+
+	// NormalisationAlgorithm is the normalisation algorithm of the component descriptor digests in events,
+	// e.g. jsonNormalisation/v4alpha1. If not defined, the algorithms of the first signature of the
+	// component version are used, and jsonNormalisation/v4alpha1 with SHA-256 for unsigned component versions.
+	NormalisationAlgorithm string `json:"normalisationAlgorithm,omitempty"`
+
+	// HashAlgorithm is the hash algorithm of the component descriptor digests in events, SHA-256 or SHA-512.
+	// It is only used together with NormalisationAlgorithm. If not defined, SHA-256 is used.
+	// +ocm:jsonschema-gen:enum=SHA-256,SHA-512
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+}
+
+// Endpoint is a webhook endpoint events are delivered to.
+//
+// +k8s:deepcopy-gen=true
+type Endpoint struct {
+	// URL is the URL events are POSTed to.
+	URL string `json:"url"`
+
+	// Secret is the key events are signed with using HMAC-SHA256.
+	// Configurations holding secrets should be stored in a Kubernetes Secret or a file only readable by the user.
+	Secret string `json:"secret,omitempty"`
+
+	// SecretFile is the path of a file holding the key events are signed with.
+	// It is used if Secret is not defined. Events are not signed if neither is defined.
+	SecretFile string `json:"secretFile,omitempty"`
+}
+
+// LookupConfig creates a webhook configuration from a central V1 config.
+// The endpoints of all webhook configurations are combined.
+func LookupConfig(cfg *genericv1.Config) (*Config, error) {
+	return genericv1.Lookup(Scheme, cfg, Merge)
+}
+
+// Merge merges the provided configs into a single config.
+// The endpoints are combined, all other fields are taken from the last config defining them.
+func Merge(configs ...*Config) *Config {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	merged := new(Config)
+	_, _ = Scheme.DefaultType(merged)
+
+	for _, config := range configs {
+		merged.Endpoints = append(merged.Endpoints, config.Endpoints...)
+		if config.Timeout != "" {
+			merged.Timeout = config.Timeout
+		}
+		if config.MaxAttempts != 0 {
+			merged.MaxAttempts = config.MaxAttempts
+		}
+		if config.NormalisationAlgorithm != "" {
+			merged.NormalisationAlgorithm = config.NormalisationAlgorithm
+		}
+		if config.HashAlgorithm != "" {
+			merged.HashAlgorithm = config.HashAlgorithm
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,5 @@
+// Package v1alpha1 defines the webhook configuration type webhook.config.ocm.software/v1alpha1.
+//
+// See the parent package ocm.software/open-component-model/bindings/go/repository/webhook
+// for the emitter that delivers events to the configured endpoints.
+package v1alpha1
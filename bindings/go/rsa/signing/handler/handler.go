@@ -28,6 +28,7 @@ import (
 	rsacredentialsv1 "ocm.software/open-component-model/bindings/go/rsa/spec/credentials/v1"
 	identityv1 "ocm.software/open-component-model/bindings/go/rsa/spec/identity/v1"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
 )
 
 // Common errors for callers to test.
@@ -179,7 +180,7 @@ func (h *Handler) Verify(
 
 	case v1alpha1.MediaTypePEM:
 		slog.WarnContext(ctx, "verifying signatures with PEM encoding is experimental")
-		return h.verifyPEMSignature(signed, hash, dig, rsaCreds)
+		return h.verifyPEMSignature(ctx, signed, hash, dig, rsaCreds)
 
 	default:
 		return fmt.Errorf("unsupported media type %q", signed.Signature.MediaType)
@@ -190,7 +191,10 @@ func (h *Handler) Verify(
 // embedded chain, classifies the credential chain into intermediates and an
 // optional root anchor, merges the two intermediate pools, validates the X.509
 // path and issuer constraint, and finally verifies the RSA signature bytes.
+// The path is validated at the time of signing.VerificationTime if ctx carries
+// one, e.g. the time of a verified RFC 3161 timestamp of the signature.
 func (h *Handler) verifyPEMSignature(
+	ctx context.Context,
 	signed descruntime.Signature,
 	hash crypto.Hash,
 	dig []byte,
@@ -219,7 +223,11 @@ func (h *Handler) verifyPEMSignature(
 	allIntermediates = append(allIntermediates, chain[1:]...)
 	allIntermediates = append(allIntermediates, credIntermediates...)
 
-	if err := verifyChainWithOptionalAnchor(leaf, allIntermediates, credAnchor, h.roots, h.now); err != nil {
+	now := h.now
+	if t, ok := signing.VerificationTime(ctx); ok {
+		now = func() time.Time { return t }
+	}
+	if err := verifyChainWithOptionalAnchor(leaf, allIntermediates, credAnchor, h.roots, now); err != nil {
 		return fmt.Errorf("certificate verification failed: %w", err)
 	}
 
@@ -24,6 +24,7 @@ import (
 	rsacredentialsv1 "ocm.software/open-component-model/bindings/go/rsa/spec/credentials/v1"
 	identityv1 "ocm.software/open-component-model/bindings/go/rsa/spec/identity/v1"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
 )
 
 func Test_RSA_Handler(t *testing.T) {
@@ -661,6 +662,40 @@ func Test_RSA_Credentials_Override_SystemRoots(t *testing.T) {
 	})
 }
 
+func Test_RSA_Verify_At_VerificationTime(t *testing.T) {
+	h, err := New(v1alpha1.Scheme, false)
+	require.NoError(t, err)
+
+	key := mustKey(t)
+	cert := mustSelfSigned(t, "expiring-signer", key)
+	dir := t.TempDir()
+	privPath, chainPath := writeKeyAndChain(t, dir, key, cert)
+
+	sum := sha256.Sum256([]byte("payload"))
+	d := descruntime.Digest{HashAlgorithm: crypto.SHA256.String(), Value: hex.EncodeToString(sum[:])}
+	si, err := h.Sign(t.Context(), d, &v1alpha1.Config{
+		SignatureAlgorithm:      v1alpha1.AlgorithmRSASSAPSS,
+		SignatureEncodingPolicy: v1alpha1.SignatureEncodingPolicyPEM,
+	}, &rsacredentialsv1.RSACredentials{
+		Type:              rsacredentialsv1.VersionedType,
+		PrivateKeyPEMFile: privPath,
+		PublicKeyPEMFile:  chainPath,
+	})
+	require.NoError(t, err)
+	sig := descruntime.Signature{Digest: d, Signature: si}
+	creds := &rsacredentialsv1.RSACredentials{Type: rsacredentialsv1.VersionedType, PublicKeyPEMFile: chainPath}
+
+	// verify after the certificate expired.
+	signedAt := time.Now()
+	h.now = func() time.Time { return cert.NotAfter.Add(time.Hour) }
+
+	err = h.Verify(t.Context(), sig, nil, creds)
+	require.ErrorContains(t, err, "certificate has expired")
+
+	// the time of signing, e.g. established by a timestamp, is within the validity of the certificate.
+	require.NoError(t, h.Verify(signing.WithVerificationTime(t.Context(), signedAt), sig, nil, creds))
+}
+
 func Test_RSA_Verify_ErrorPaths_BothAlgs(t *testing.T) {
 	for _, alg := range []v1alpha1.SignatureAlgorithm{v1alpha1.AlgorithmRSASSAPSS, v1alpha1.AlgorithmRSASSAPKCS1V15} {
 		t.Run(string(alg), func(t *testing.T) {
@@ -0,0 +1,183 @@
+package runtime
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ConversionFunc converts an object of one type into an object of another type of a Scheme.
+// from is an object of the prototype registered for the source type, into is a new object
+// of the prototype registered for the target type. If into has no type after the conversion,
+// the target type is set.
+type ConversionFunc func(from Typed, into Typed) error
+
+// RegisterConversion registers fn to convert objects of type from into objects of type to.
+// Both types have to be registered and are resolved to their default types, so a conversion
+// applies to all aliases of a type.
+//
+// Scheme.Convert uses the registered conversions whenever the prototypes of the source and the
+// target type differ. Conversions are chained, so that registering the conversions from and to a
+// hub version (e.g. v1 → hub, hub → v1, v2 → hub, hub → v2) is enough to convert between all versions.
+func (r *Scheme) RegisterConversion(from, to Type, fn ConversionFunc) error {
+	if fn == nil {
+		return fmt.Errorf("no conversion function provided to convert %q into %q", from, to)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fromDefault, ok := r.defaultTypeFor(from)
+	if !ok {
+		return fmt.Errorf("cannot register conversion from unregistered type %q", from)
+	}
+	toDefault, ok := r.defaultTypeFor(to)
+	if !ok {
+		return fmt.Errorf("cannot register conversion into unregistered type %q", to)
+	}
+	if fromDefault.Equal(toDefault) {
+		return fmt.Errorf("cannot register conversion of type %q into itself", from)
+	}
+	if _, exists := r.conversions[fromDefault][toDefault]; exists {
+		return fmt.Errorf("conversion from %q into %q is already registered", fromDefault, toDefault)
+	}
+	if r.conversions[fromDefault] == nil {
+		r.conversions[fromDefault] = map[Type]ConversionFunc{}
+	}
+	r.conversions[fromDefault][toDefault] = fn
+	return nil
+}
+
+// MustRegisterConversion registers a conversion like RegisterConversion and panics if this fails.
+func (r *Scheme) MustRegisterConversion(from, to Type, fn ConversionFunc) {
+	if err := r.RegisterConversion(from, to, fn); err != nil {
+		panic(err)
+	}
+}
+
+// HasConversion reports whether objects of type from can be converted into objects of type to with
+// registered conversions, directly or chained.
+func (r *Scheme) HasConversion(from, to Type) bool {
+	_, ok := r.conversionPath(from, to)
+	return ok
+}
+
+// defaultTypeFor returns the default type of a default or alias type.
+// The caller has to hold the lock of the scheme.
+func (r *Scheme) defaultTypeFor(typ Type) (Type, bool) {
+	if _, exists := r.defaults.GetLeft(typ); exists {
+		return typ, true
+	}
+	def, ok := r.aliases[typ]
+	return def, ok
+}
+
+// conversionPath returns the shortest chain of default types leading from one type to another,
+// including both, if the types are registered and connected by conversions.
+func (r *Scheme) conversionPath(from, to Type) ([]Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	start, ok := r.defaultTypeFor(from)
+	if !ok {
+		return nil, false
+	}
+	target, ok := r.defaultTypeFor(to)
+	if !ok {
+		return nil, false
+	}
+	if start.Equal(target) {
+		return []Type{start}, true
+	}
+
+	// breadth-first search for the shortest chain of conversions.
+	previous := map[Type]Type{start: start}
+	queue := []Type{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		// sorted, so that the chain is deterministic if there are several shortest chains.
+		for _, next := range slices.SortedFunc(maps.Keys(r.conversions[current]), CompareTypesLexicographically) {
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = current
+			if next.Equal(target) {
+				path := []Type{target}
+				for typ := target; !typ.Equal(start); {
+					typ = previous[typ]
+					path = append([]Type{typ}, path...)
+				}
+				return path, true
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil, false
+}
+
+// convertWithConversions converts from into into along the registered conversions, if the prototype registered for
+// the type of from differs from the type of into. It reports whether a conversion path was found.
+func (r *Scheme) convertWithConversions(from Typed, into Typed) (bool, error) {
+	if !r.hasConversions() {
+		return false, nil
+	}
+	target, err := r.TypeForPrototype(into)
+	if err != nil {
+		return false, nil
+	}
+	path, ok := r.conversionPath(from.GetType(), target)
+	if !ok || len(path) < 2 {
+		return false, nil
+	}
+
+	current, err := r.NewObject(from.GetType())
+	if err != nil {
+		return true, err
+	}
+	if err := r.Convert(from, current); err != nil {
+		return true, err
+	}
+	for i := 1; i < len(path); i++ {
+		next, err := r.NewObject(path[i])
+		if err != nil {
+			return true, err
+		}
+		r.mu.RLock()
+		fn := r.conversions[path[i-1]][path[i]]
+		r.mu.RUnlock()
+		if err := fn(current, next); err != nil {
+			return true, fmt.Errorf("failed to convert %q into %q: %w", path[i-1], path[i], err)
+		}
+		if next.GetType().IsEmpty() {
+			next.SetType(path[i])
+		}
+		current = next
+	}
+
+	currentVal := reflect.ValueOf(current).Elem()
+	intoElem := reflect.ValueOf(into).Elem()
+	if !currentVal.Type().AssignableTo(intoElem.Type()) {
+		return true, fmt.Errorf("cannot assign value of type %T to target of type %T", current, into)
+	}
+	intoElem.Set(currentVal)
+	return true, nil
+}
+
+// hasConversions reports whether any conversion is registered, to skip the search for conversions otherwise.
+func (r *Scheme) hasConversions() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.conversions) > 0
+}
+
+// cloneConversions returns a copy of the registered conversions.
+// The caller has to hold the lock of the scheme.
+func (r *Scheme) cloneConversions() map[Type]map[Type]ConversionFunc {
+	clone := make(map[Type]map[Type]ConversionFunc, len(r.conversions))
+	for from, conversions := range r.conversions {
+		clone[from] = maps.Clone(conversions)
+	}
+	return clone
+}
@@ -0,0 +1,135 @@
+package runtime_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// SpecV2 is a second version of TestType, which renamed Foo to Bar.
+type SpecV2 struct {
+	Type runtime.Type `json:"type"`
+	Bar  string       `json:"bar"`
+}
+
+func (t *SpecV2) GetType() runtime.Type        { return t.Type }
+func (t *SpecV2) SetType(typ runtime.Type)     { t.Type = typ }
+func (t *SpecV2) DeepCopyTyped() runtime.Typed { c := *t; return &c }
+
+// SpecHub is the hub version all versions are converted from and into.
+type SpecHub struct {
+	Type  runtime.Type `json:"type"`
+	Value string       `json:"value"`
+}
+
+func (t *SpecHub) GetType() runtime.Type        { return t.Type }
+func (t *SpecHub) SetType(typ runtime.Type)     { t.Type = typ }
+func (t *SpecHub) DeepCopyTyped() runtime.Typed { c := *t; return &c }
+
+var (
+	specV1  = runtime.NewVersionedType("Spec", "v1")
+	specV2  = runtime.NewVersionedType("Spec", "v2")
+	specHub = runtime.NewVersionedType("Spec", "hub")
+)
+
+func newConversionScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	r := require.New(t)
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterWithAlias(&TestType{}, specV1, runtime.NewUnversionedType("Spec"))
+	scheme.MustRegisterWithAlias(&SpecV2{}, specV2)
+	scheme.MustRegisterWithAlias(&SpecHub{}, specHub)
+
+	r.NoError(scheme.RegisterConversion(specV1, specHub, func(from, into runtime.Typed) error {
+		into.(*SpecHub).Value = from.(*TestType).Foo
+		return nil
+	}))
+	r.NoError(scheme.RegisterConversion(specHub, specV1, func(from, into runtime.Typed) error {
+		into.(*TestType).Foo = from.(*SpecHub).Value
+		return nil
+	}))
+	r.NoError(scheme.RegisterConversion(specV2, specHub, func(from, into runtime.Typed) error {
+		into.(*SpecHub).Value = from.(*SpecV2).Bar
+		return nil
+	}))
+	r.NoError(scheme.RegisterConversion(specHub, specV2, func(from, into runtime.Typed) error {
+		if from.(*SpecHub).Value == "" {
+			return errors.New("value is required")
+		}
+		into.(*SpecV2).Bar = from.(*SpecHub).Value
+		return nil
+	}))
+	return scheme
+}
+
+func TestScheme_Convert_WithConversions(t *testing.T) {
+	scheme := newConversionScheme(t)
+
+	t.Run("typed through hub", func(t *testing.T) {
+		r := require.New(t)
+		var v2 SpecV2
+		r.NoError(scheme.Convert(&TestType{Type: specV1, Foo: "bar"}, &v2))
+		r.Equal(SpecV2{Type: specV2, Bar: "bar"}, v2)
+
+		var v1 TestType
+		r.NoError(scheme.Convert(&v2, &v1))
+		r.Equal(TestType{Type: specV1, Foo: "bar"}, v1)
+	})
+
+	t.Run("raw through hub", func(t *testing.T) {
+		r := require.New(t)
+		raw := &runtime.Raw{Type: runtime.NewUnversionedType("Spec"), Data: []byte(`{"type":"Spec","foo":"bar"}`)}
+		var v2 SpecV2
+		r.NoError(scheme.Convert(raw, &v2))
+		r.Equal("bar", v2.Bar)
+	})
+
+	t.Run("raw of the same prototype", func(t *testing.T) {
+		r := require.New(t)
+		raw := &runtime.Raw{Type: specV2, Data: []byte(`{"type":"Spec/v2","bar":"baz"}`)}
+		var v2 SpecV2
+		r.NoError(scheme.Convert(raw, &v2))
+		r.Equal("baz", v2.Bar)
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+		r := require.New(t)
+		var v2 SpecV2
+		err := scheme.Convert(&TestType{Type: specV1}, &v2)
+		r.ErrorContains(err, `failed to convert "Spec/hub" into "Spec/v2": value is required`)
+	})
+
+	t.Run("no conversion", func(t *testing.T) {
+		r := require.New(t)
+		scheme := newConversionScheme(t)
+		scheme.MustRegister(&TestType2{}, "v1")
+		r.False(scheme.HasConversion(specV1, runtime.NewVersionedType("TestType2", "v1")))
+		err := scheme.Convert(&TestType{Type: specV1}, &TestType2{})
+		r.ErrorContains(err, "cannot assign value")
+	})
+
+	t.Run("clone and register scheme", func(t *testing.T) {
+		r := require.New(t)
+		r.True(scheme.Clone().HasConversion(specV1, specV2))
+
+		other := runtime.NewScheme()
+		r.NoError(other.RegisterScheme(scheme))
+		r.True(other.HasConversion(specV2, specV1))
+	})
+}
+
+func TestScheme_RegisterConversion(t *testing.T) {
+	r := require.New(t)
+	scheme := newConversionScheme(t)
+	noop := func(runtime.Typed, runtime.Typed) error { return nil }
+
+	r.ErrorContains(scheme.RegisterConversion(specV1, specHub, noop), "already registered")
+	r.ErrorContains(scheme.RegisterConversion(runtime.NewUnversionedType("Spec"), specHub, noop), "already registered", "aliases resolve to their default type")
+	r.ErrorContains(scheme.RegisterConversion(specV1, runtime.NewUnversionedType("Spec"), noop), "into itself")
+	r.ErrorContains(scheme.RegisterConversion(runtime.NewVersionedType("Unknown", "v1"), specHub, noop), "unregistered type")
+	r.ErrorContains(scheme.RegisterConversion(specV1, specV2, nil), "no conversion function")
+	r.Panics(func() { scheme.MustRegisterConversion(specV1, specHub, noop) })
+}
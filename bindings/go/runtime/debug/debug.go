@@ -0,0 +1,190 @@
+// Package debug provides an optional, read-only HTTP server that embedders of the bindings
+// (such as the CLI or the controller) can enable to inspect their state in the field.
+//
+// The server exposes named views. A view is a function returning a JSON serializable
+// snapshot, e.g. of the types registered in a scheme, the registered plugins, the shape of a
+// credential graph, cache statistics or the latest entries of an operation journal:
+//
+//	srv := debug.NewServer()
+//	_ = srv.Register("types", debug.SchemeView(scheme))
+//	_ = srv.Register("plugins", func(context.Context) (any, error) {
+//		return pluginManager.Diagnostics(), nil
+//	})
+//	go srv.ListenAndServe(ctx, "localhost:6061")
+//
+// GET / lists the names of all views, GET /<name> returns the snapshot of a view.
+// Views must not expose secrets, as the server does not authenticate requests. It should
+// only listen on a loopback address or be protected otherwise.
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// ErrViewExists is returned by Server.Register if a view with the same name is already registered.
+var ErrViewExists = errors.New("view already registered")
+
+// View returns a JSON serializable snapshot of the state it describes.
+type View func(ctx context.Context) (any, error)
+
+// viewNamePattern restricts view names to a single path segment.
+var viewNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Server serves registered views read-only over HTTP. It is safe for concurrent use.
+type Server struct {
+	mu    sync.RWMutex
+	views map[string]View
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// NewServer creates a server without views.
+func NewServer() *Server {
+	return &Server{views: make(map[string]View)}
+}
+
+// Register adds a view under the given name. Names consist of lower case letters,
+// digits and dashes.
+func (s *Server) Register(name string, view View) error {
+	if !viewNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid view name %q", name)
+	}
+	if view == nil {
+		return fmt.Errorf("view %q is nil", name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.views[name]; ok {
+		return fmt.Errorf("%w: %q", ErrViewExists, name)
+	}
+	s.views[name] = view
+	return nil
+}
+
+// Index is the response of GET /.
+type Index struct {
+	Views []string `json:"views"`
+}
+
+// ServeHTTP serves the index and the views. Only GET and HEAD requests are allowed.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "the debug server is read-only"})
+		return
+	}
+
+	name := strings.Trim(r.URL.Path, "/")
+	s.mu.RLock()
+	if name == "" {
+		index := Index{Views: slices.AppendSeq(make([]string, 0, len(s.views)), maps.Keys(s.views))}
+		slices.Sort(index.Views)
+		s.mu.RUnlock()
+		writeJSON(w, http.StatusOK, index)
+		return
+	}
+	view, ok := s.views[name]
+	s.mu.RUnlock()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: fmt.Sprintf("view %q not found", name)})
+		return
+	}
+
+	snapshot, err := view(r.Context())
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+// ListenAndServe serves the views on addr until ctx is done.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves the views on the listener until ctx is done.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		status = http.StatusInternalServerError
+		data, _ = json.Marshal(errorResponse{Error: fmt.Sprintf("failed to encode view: %v", err)})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(data, '\n'))
+}
+
+// SchemeType describes a type registered in a scheme.
+type SchemeType struct {
+	Type    string   `json:"type"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// SchemeView returns a view listing the types registered in the scheme with their aliases.
+func SchemeView(scheme *runtime.Scheme) View {
+	return func(context.Context) (any, error) {
+		types := scheme.GetTypes()
+		result := make([]SchemeType, 0, len(types))
+		for typ, aliases := range types {
+			st := SchemeType{Type: typ.String()}
+			for _, alias := range aliases {
+				st.Aliases = append(st.Aliases, alias.String())
+			}
+			result = append(result, st)
+		}
+		slices.SortFunc(result, func(a, b SchemeType) int {
+			return strings.Compare(a.Type, b.Type)
+		})
+		return result, nil
+	}
+}
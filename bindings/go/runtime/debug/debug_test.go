@@ -0,0 +1,84 @@
+package debug_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/debug"
+)
+
+func get(t *testing.T, handler http.Handler, method, path string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), method, path, nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestServer(t *testing.T) {
+	r := require.New(t)
+
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterWithAlias(&runtime.Raw{}, runtime.NewVersionedType("Example", "v1"), runtime.NewUnversionedType("Example"))
+
+	srv := debug.NewServer()
+	r.NoError(srv.Register("types", debug.SchemeView(scheme)))
+	r.NoError(srv.Register("failing", func(context.Context) (any, error) {
+		return nil, errors.New("view failed")
+	}))
+	r.ErrorIs(srv.Register("types", debug.SchemeView(scheme)), debug.ErrViewExists)
+	r.Error(srv.Register("with/slash", debug.SchemeView(scheme)))
+
+	code, body := get(t, srv, http.MethodGet, "/")
+	r.Equal(http.StatusOK, code)
+	r.JSONEq(`{"views": ["failing", "types"]}`, body)
+
+	code, body = get(t, srv, http.MethodGet, "/types")
+	r.Equal(http.StatusOK, code)
+	var types []debug.SchemeType
+	r.NoError(json.Unmarshal([]byte(body), &types))
+	r.Equal([]debug.SchemeType{{Type: "Example/v1", Aliases: []string{"Example"}}}, types)
+
+	code, body = get(t, srv, http.MethodGet, "/failing")
+	r.Equal(http.StatusInternalServerError, code)
+	r.JSONEq(`{"error": "view failed"}`, body)
+
+	code, _ = get(t, srv, http.MethodGet, "/unknown")
+	r.Equal(http.StatusNotFound, code)
+
+	code, _ = get(t, srv, http.MethodPost, "/types")
+	r.Equal(http.StatusMethodNotAllowed, code)
+}
+
+func TestServer_Serve(t *testing.T) {
+	r := require.New(t)
+	srv := debug.NewServer()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	r.NoError(err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve(ctx, listener)
+	}()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://"+listener.Addr().String()+"/", nil)
+	r.NoError(err)
+	resp, err := http.DefaultClient.Do(req)
+	r.NoError(err)
+	body, err := io.ReadAll(resp.Body)
+	r.NoError(err)
+	r.NoError(resp.Body.Close())
+	r.JSONEq(`{"views": []}`, string(body))
+
+	cancel()
+	r.NoError(<-done)
+}
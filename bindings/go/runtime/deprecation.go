@@ -0,0 +1,165 @@
+package runtime
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+)
+
+// Deprecation describes a deprecated type of a Scheme.
+//
+// A deprecated type can still be decoded, but every decode emits a warning through the
+// DeprecationHandler of the Scheme. Scheme.Migrate converts objects of a deprecated type
+// into its Replacement, so that legacy type names can be retired safely.
+type Deprecation struct {
+	// Replacement is the type that should be used instead of the deprecated type.
+	// It is OPTIONAL, a deprecation without replacement only emits warnings.
+	Replacement Type
+	// Message is an OPTIONAL human-readable hint on why the type is deprecated
+	// and how to migrate away from it.
+	Message string
+	// Migrate converts an object of the deprecated type into an object of the Replacement type.
+	// It receives a typed object created from the prototype registered for the deprecated type.
+	// Migrate is OPTIONAL if the Replacement is registered with the same prototype as the deprecated type
+	// (e.g. a legacy alias), in which case the migration only replaces the type.
+	Migrate func(deprecated Typed) (Typed, error)
+}
+
+// DeprecationHandler is called whenever an object of a deprecated type is decoded.
+type DeprecationHandler func(typ Type, deprecation Deprecation)
+
+// WithDeprecationHandler sets the handler that is called whenever an object of a deprecated
+// type is decoded. By default, a structured warning is logged with slog.
+func WithDeprecationHandler(handler DeprecationHandler) SchemeOption {
+	return func(registry *Scheme) {
+		registry.deprecationHandler = handler
+	}
+}
+
+// logDeprecation is the default DeprecationHandler.
+func logDeprecation(typ Type, deprecation Deprecation) {
+	attrs := []any{slog.String("type", typ.String())}
+	if !deprecation.Replacement.IsEmpty() {
+		attrs = append(attrs, slog.String("replacement", deprecation.Replacement.String()))
+	}
+	if deprecation.Message != "" {
+		attrs = append(attrs, slog.String("hint", deprecation.Message))
+	}
+	slog.Warn("decoded deprecated type", attrs...)
+}
+
+// Deprecate marks a registered type as deprecated.
+// The replacement of the deprecation has to be registered as well. If no Deprecation.Migrate
+// function is given, the replacement has to share the prototype of the deprecated type.
+func (r *Scheme) Deprecate(typ Type, deprecation Deprecation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rt, ok := r.reflectTypeFor(typ)
+	if !ok {
+		return fmt.Errorf("cannot deprecate unregistered type %q", typ)
+	}
+	if !deprecation.Replacement.IsEmpty() {
+		if deprecation.Replacement.Equal(typ) {
+			return fmt.Errorf("type %q cannot be deprecated in favor of itself", typ)
+		}
+		replacement, ok := r.reflectTypeFor(deprecation.Replacement)
+		if !ok {
+			return fmt.Errorf("replacement %q of deprecated type %q is not registered", deprecation.Replacement, typ)
+		}
+		if deprecation.Migrate == nil && replacement != rt {
+			return fmt.Errorf("replacement %q of deprecated type %q has a different prototype and requires a migration", deprecation.Replacement, typ)
+		}
+	}
+	r.deprecations[typ] = deprecation
+	return nil
+}
+
+// MustDeprecate marks a registered type as deprecated and panics if this fails.
+func (r *Scheme) MustDeprecate(typ Type, deprecation Deprecation) {
+	if err := r.Deprecate(typ, deprecation); err != nil {
+		panic(err)
+	}
+}
+
+// Deprecation returns the deprecation of the given type and whether the type is deprecated.
+func (r *Scheme) Deprecation(typ Type) (Deprecation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	deprecation, ok := r.deprecations[typ]
+	return deprecation, ok
+}
+
+// Deprecations returns all deprecated types of the scheme.
+func (r *Scheme) Deprecations() map[Type]Deprecation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return maps.Clone(r.deprecations)
+}
+
+// Migrate converts typed from a deprecated type into its replacement.
+// Replacements that are deprecated themselves are migrated as well, until a type without
+// (or without replacement in its) deprecation is reached. typed itself is never modified.
+// If typed is a Raw, it is decoded into the prototype of the deprecated type before it is migrated.
+//
+// Migrate reports whether a migration took place. If the type of typed is not deprecated,
+// typed is returned as is.
+func (r *Scheme) Migrate(typed Typed) (Typed, bool, error) {
+	migrated := false
+	visited := map[Type]struct{}{}
+	for {
+		typ := typed.GetType()
+		deprecation, ok := r.Deprecation(typ)
+		if !ok || deprecation.Replacement.IsEmpty() {
+			return typed, migrated, nil
+		}
+		if _, ok := visited[typ]; ok {
+			return nil, false, fmt.Errorf("migration of deprecated type %q does not terminate", typ)
+		}
+		visited[typ] = struct{}{}
+
+		next, err := r.migrate(typed, deprecation)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to migrate deprecated type %q to %q: %w", typ, deprecation.Replacement, err)
+		}
+		typed, migrated = next, true
+	}
+}
+
+// migrate performs a single migration step of typed based on deprecation.
+func (r *Scheme) migrate(typed Typed, deprecation Deprecation) (Typed, error) {
+	obj, err := r.NewObject(typed.GetType())
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Convert(typed, obj); err != nil {
+		return nil, err
+	}
+	if deprecation.Migrate == nil {
+		obj.SetType(deprecation.Replacement)
+		return obj, nil
+	}
+
+	migrated, err := deprecation.Migrate(obj)
+	if err != nil {
+		return nil, err
+	}
+	if migrated == nil {
+		return nil, fmt.Errorf("migration returned no object")
+	}
+	if migrated.GetType().IsEmpty() {
+		migrated.SetType(deprecation.Replacement)
+	}
+	return migrated, nil
+}
+
+// warnIfDeprecated calls the DeprecationHandler if typ is deprecated.
+func (r *Scheme) warnIfDeprecated(typ Type) {
+	r.mu.RLock()
+	deprecation, ok := r.deprecations[typ]
+	handler := r.deprecationHandler
+	r.mu.RUnlock()
+	if ok && handler != nil {
+		handler(typ, deprecation)
+	}
+}
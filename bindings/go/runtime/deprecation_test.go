@@ -0,0 +1,157 @@
+package runtime
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ReplacementTestType struct {
+	Type Type   `json:"type"`
+	Data string `json:"data"`
+}
+
+func (t *ReplacementTestType) GetType() Type {
+	return t.Type
+}
+
+func (t *ReplacementTestType) SetType(typ Type) {
+	t.Type = typ
+}
+
+func (t *ReplacementTestType) DeepCopyTyped() Typed {
+	return &ReplacementTestType{
+		Type: t.Type,
+		Data: t.Data,
+	}
+}
+
+func TestScheme_Deprecate(t *testing.T) {
+	current := NewVersionedType("test", "v2")
+	legacy := NewUnversionedType("legacyTest")
+	other := NewVersionedType("other", "v1")
+	unknown := NewVersionedType("unknown", "v1")
+
+	newScheme := func() *Scheme {
+		scheme := NewScheme()
+		scheme.MustRegisterWithAlias(&TestType{}, current, legacy)
+		scheme.MustRegisterWithAlias(&ReplacementTestType{}, other)
+		return scheme
+	}
+
+	t.Run("valid deprecations", func(t *testing.T) {
+		r := require.New(t)
+		scheme := newScheme()
+		r.NoError(scheme.Deprecate(legacy, Deprecation{Replacement: current, Message: "use test/v2"}))
+		r.NoError(scheme.Deprecate(current, Deprecation{}))
+
+		deprecation, ok := scheme.Deprecation(legacy)
+		r.True(ok)
+		r.Equal(current, deprecation.Replacement)
+		_, ok = scheme.Deprecation(other)
+		r.False(ok)
+		r.Len(scheme.Deprecations(), 2)
+	})
+
+	t.Run("invalid deprecations", func(t *testing.T) {
+		r := require.New(t)
+		scheme := newScheme()
+		r.ErrorContains(scheme.Deprecate(unknown, Deprecation{}), "unregistered type")
+		r.ErrorContains(scheme.Deprecate(legacy, Deprecation{Replacement: legacy}), "in favor of itself")
+		r.ErrorContains(scheme.Deprecate(legacy, Deprecation{Replacement: unknown}), "is not registered")
+		r.ErrorContains(scheme.Deprecate(legacy, Deprecation{Replacement: other}), "requires a migration")
+		r.Empty(scheme.Deprecations())
+	})
+
+	t.Run("deprecations are carried over", func(t *testing.T) {
+		r := require.New(t)
+		scheme := newScheme()
+		scheme.MustDeprecate(legacy, Deprecation{Replacement: current})
+
+		_, ok := scheme.Clone().Deprecation(legacy)
+		r.True(ok)
+
+		target := NewScheme()
+		r.NoError(target.RegisterScheme(scheme))
+		_, ok = target.Deprecation(legacy)
+		r.True(ok)
+	})
+}
+
+func TestScheme_DeprecationWarnings(t *testing.T) {
+	r := require.New(t)
+	current := NewVersionedType("test", "v2")
+	legacy := NewUnversionedType("legacyTest")
+
+	var warned []Type
+	scheme := NewScheme(WithDeprecationHandler(func(typ Type, deprecation Deprecation) {
+		r.Equal(current, deprecation.Replacement)
+		warned = append(warned, typ)
+	}))
+	scheme.MustRegisterWithAlias(&TestType{}, current, legacy)
+	scheme.MustDeprecate(legacy, Deprecation{Replacement: current})
+
+	r.NoError(scheme.Decode(bytes.NewReader([]byte(`{"type": "test/v2", "value": "foo"}`)), &TestType{}))
+	r.Empty(warned)
+
+	r.NoError(scheme.Decode(bytes.NewReader([]byte(`{"type": "legacyTest", "value": "foo"}`)), &TestType{}))
+	r.Equal([]Type{legacy}, warned)
+
+	raw := &Raw{Type: legacy, Data: []byte(`{"type":"legacyTest","value":"foo"}`)}
+	r.NoError(scheme.Convert(raw, &TestType{}))
+	r.Equal([]Type{legacy, legacy}, warned)
+}
+
+func TestScheme_Migrate(t *testing.T) {
+	oldest := NewUnversionedType("oldestTest")
+	legacy := NewUnversionedType("legacyTest")
+	current := NewVersionedType("test", "v2")
+	other := NewVersionedType("other", "v1")
+
+	scheme := NewScheme()
+	scheme.MustRegisterWithAlias(&TestType{}, current, legacy)
+	scheme.MustRegisterWithAlias(&ReplacementTestType{}, other, oldest)
+	scheme.MustDeprecate(legacy, Deprecation{Replacement: current})
+	scheme.MustDeprecate(oldest, Deprecation{Replacement: legacy, Migrate: func(deprecated Typed) (Typed, error) {
+		return &TestType{Value: deprecated.(*ReplacementTestType).Data}, nil
+	}})
+
+	t.Run("not deprecated", func(t *testing.T) {
+		r := require.New(t)
+		typed := &TestType{Type: current, Value: "foo"}
+		migrated, ok, err := scheme.Migrate(typed)
+		r.NoError(err)
+		r.False(ok)
+		r.Same(typed, migrated)
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		r := require.New(t)
+		typed := &TestType{Type: legacy, Value: "foo"}
+		migrated, ok, err := scheme.Migrate(typed)
+		r.NoError(err)
+		r.True(ok)
+		r.Equal(&TestType{Type: current, Value: "foo"}, migrated)
+		r.Equal(legacy, typed.Type, "the original object must not be modified")
+	})
+
+	t.Run("chained migration from raw", func(t *testing.T) {
+		r := require.New(t)
+		raw := &Raw{Type: oldest, Data: []byte(`{"type":"oldestTest","data":"foo"}`)}
+		migrated, ok, err := scheme.Migrate(raw)
+		r.NoError(err)
+		r.True(ok)
+		r.Equal(&TestType{Type: current, Value: "foo"}, migrated)
+	})
+
+	t.Run("migration loop", func(t *testing.T) {
+		r := require.New(t)
+		scheme := scheme.Clone()
+		scheme.MustDeprecate(current, Deprecation{Replacement: oldest, Migrate: func(deprecated Typed) (Typed, error) {
+			return &ReplacementTestType{Data: deprecated.(*TestType).Value}, nil
+		}})
+		_, _, err := scheme.Migrate(&TestType{Type: legacy})
+		r.ErrorContains(err, "does not terminate")
+	})
+}
@@ -0,0 +1,189 @@
+// Package errors provides the error taxonomy shared by all Open Component Model bindings.
+//
+// Bindings classify the errors they return with one of the reason sentinels below, so that
+// callers such as controllers setting conditions or the CLI choosing an exit code can react
+// to the kind of failure with [errors.Is] instead of matching error messages:
+//
+//	if errors.Is(err, ocmerrors.ErrNotFound) {
+//		// the requested object does not exist
+//	}
+//
+// Classification does not change the message of an error, and the original error chain
+// remains available to [errors.Is] and [errors.As].
+package errors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Reasons an operation can fail for.
+var (
+	// ErrNotFound indicates that the requested object does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists indicates that the object to create exists already.
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrUnauthorized indicates missing, invalid or insufficient credentials.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrPreconditionFailed indicates that the target is not in a state that allows the operation,
+	// e.g. a repository that does not accept writes.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrTemporary indicates a transient failure that may succeed when retried.
+	ErrTemporary = errors.New("temporary failure")
+	// ErrUnsupported indicates that an operation, type or format is not supported.
+	ErrUnsupported = errors.New("unsupported")
+)
+
+// reasons is the order in which Reason looks up the reason of an error.
+var reasons = []error{
+	ErrNotFound,
+	ErrAlreadyExists,
+	ErrUnauthorized,
+	ErrPreconditionFailed,
+	ErrTemporary,
+	ErrUnsupported,
+}
+
+// Error is an error classified with a reason.
+// Its message is the message of the wrapped error.
+type Error struct {
+	// Reason is one of the reason sentinels of this package.
+	Reason error
+	// Err is the classified error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the reason and the classified error, so that both match with [errors.Is].
+func (e *Error) Unwrap() []error {
+	return []error{e.Reason, e.Err}
+}
+
+// New classifies err with the reason. It returns nil if err is nil.
+func New(reason, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Reason: reason, Err: err}
+}
+
+// NotFound classifies err with ErrNotFound.
+func NotFound(err error) error {
+	return New(ErrNotFound, err)
+}
+
+// AlreadyExists classifies err with ErrAlreadyExists.
+func AlreadyExists(err error) error {
+	return New(ErrAlreadyExists, err)
+}
+
+// Unauthorized classifies err with ErrUnauthorized.
+func Unauthorized(err error) error {
+	return New(ErrUnauthorized, err)
+}
+
+// PreconditionFailed classifies err with ErrPreconditionFailed.
+func PreconditionFailed(err error) error {
+	return New(ErrPreconditionFailed, err)
+}
+
+// Temporary classifies err with ErrTemporary.
+func Temporary(err error) error {
+	return New(ErrTemporary, err)
+}
+
+// Unsupported classifies err with ErrUnsupported.
+func Unsupported(err error) error {
+	return New(ErrUnsupported, err)
+}
+
+// IsNotFound reports whether err is classified with ErrNotFound.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsAlreadyExists reports whether err is classified with ErrAlreadyExists.
+func IsAlreadyExists(err error) bool {
+	return errors.Is(err, ErrAlreadyExists)
+}
+
+// IsUnauthorized reports whether err is classified with ErrUnauthorized.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsPreconditionFailed reports whether err is classified with ErrPreconditionFailed.
+func IsPreconditionFailed(err error) bool {
+	return errors.Is(err, ErrPreconditionFailed)
+}
+
+// IsTemporary reports whether err is classified with ErrTemporary.
+func IsTemporary(err error) bool {
+	return errors.Is(err, ErrTemporary)
+}
+
+// IsUnsupported reports whether err is classified with ErrUnsupported.
+func IsUnsupported(err error) bool {
+	return errors.Is(err, ErrUnsupported)
+}
+
+// Reason returns the reason err is classified with, or nil if it is not classified.
+// If err carries multiple reasons, e.g. because it joins several errors,
+// the first reason in the order of declaration is returned.
+func Reason(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, reason := range reasons {
+		if errors.Is(err, reason) {
+			return reason
+		}
+	}
+	return nil
+}
+
+// FromHTTPStatus classifies err with the reason corresponding to the HTTP status code.
+// Errors with status codes without a corresponding reason are returned unchanged.
+func FromHTTPStatus(status int, err error) error {
+	switch status {
+	case http.StatusNotFound, http.StatusGone:
+		return NotFound(err)
+	case http.StatusConflict:
+		return AlreadyExists(err)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return Unauthorized(err)
+	case http.StatusPreconditionFailed:
+		return PreconditionFailed(err)
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return Temporary(err)
+	case http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		return Unsupported(err)
+	default:
+		return err
+	}
+}
+
+// HTTPStatus returns the HTTP status code corresponding to the reason of err.
+// It returns false if err is not classified.
+func HTTPStatus(err error) (int, bool) {
+	switch Reason(err) {
+	case ErrNotFound:
+		return http.StatusNotFound, true
+	case ErrAlreadyExists:
+		return http.StatusConflict, true
+	case ErrUnauthorized:
+		return http.StatusUnauthorized, true
+	case ErrPreconditionFailed:
+		return http.StatusPreconditionFailed, true
+	case ErrTemporary:
+		return http.StatusServiceUnavailable, true
+	case ErrUnsupported:
+		return http.StatusNotImplemented, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,68 @@
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+func TestNew(t *testing.T) {
+	r := require.New(t)
+	cause := errors.New("manifest unknown")
+
+	err := fmt.Errorf("failed to resolve component version: %w", ocmerrors.NotFound(cause))
+	r.Equal("failed to resolve component version: manifest unknown", err.Error(), "classification must not change the message")
+	r.ErrorIs(err, ocmerrors.ErrNotFound)
+	r.ErrorIs(err, cause)
+	r.True(ocmerrors.IsNotFound(err))
+	r.False(ocmerrors.IsTemporary(err))
+
+	var classified *ocmerrors.Error
+	r.ErrorAs(err, &classified)
+	r.Equal(ocmerrors.ErrNotFound, classified.Reason)
+
+	r.NoError(ocmerrors.Temporary(nil))
+}
+
+func TestReason(t *testing.T) {
+	r := require.New(t)
+	r.NoError(ocmerrors.Reason(nil))
+	r.NoError(ocmerrors.Reason(errors.New("unclassified")))
+	r.Equal(ocmerrors.ErrUnsupported, ocmerrors.Reason(ocmerrors.Unsupported(errors.New("unknown type"))))
+	r.Equal(ocmerrors.ErrNotFound, ocmerrors.Reason(errors.Join(
+		ocmerrors.Temporary(errors.New("connection reset")),
+		ocmerrors.NotFound(errors.New("blob unknown")),
+	)), "reasons are looked up in order of declaration")
+}
+
+func TestHTTPStatus(t *testing.T) {
+	for status, reason := range map[int]error{
+		http.StatusNotFound:            ocmerrors.ErrNotFound,
+		http.StatusConflict:            ocmerrors.ErrAlreadyExists,
+		http.StatusUnauthorized:        ocmerrors.ErrUnauthorized,
+		http.StatusForbidden:           ocmerrors.ErrUnauthorized,
+		http.StatusPreconditionFailed:  ocmerrors.ErrPreconditionFailed,
+		http.StatusTooManyRequests:     ocmerrors.ErrTemporary,
+		http.StatusServiceUnavailable:  ocmerrors.ErrTemporary,
+		http.StatusNotImplemented:      ocmerrors.ErrUnsupported,
+		http.StatusInternalServerError: nil,
+		http.StatusBadRequest:          nil,
+	} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			r := require.New(t)
+			err := ocmerrors.FromHTTPStatus(status, errors.New("request failed"))
+			r.Equal(reason, ocmerrors.Reason(err))
+
+			code, ok := ocmerrors.HTTPStatus(err)
+			r.Equal(reason != nil, ok)
+			if ok {
+				r.Equal(reason, ocmerrors.Reason(ocmerrors.FromHTTPStatus(code, err)), "status must map back to the same reason")
+			}
+		})
+	}
+}
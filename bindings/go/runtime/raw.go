@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	_ "embed"
@@ -22,6 +23,27 @@ type Raw struct {
 	Data []byte `json:"-"`
 }
 
+// ErrRawSizeLimitExceeded is returned (wrapped in a [*RawSizeLimitExceededError]) when
+// the data of a Raw exceeds the limit of the scheme it is decoded with, see WithMaxRawSize.
+var ErrRawSizeLimitExceeded = errors.New("raw size limit exceeded")
+
+// RawSizeLimitExceededError is returned when the data of a Raw exceeds the limit of the scheme it is decoded with.
+// It matches ErrRawSizeLimitExceeded with [errors.Is].
+type RawSizeLimitExceededError struct {
+	// Size is the size of the rejected data in bytes.
+	Size int64
+	// Limit is the configured maximum size in bytes.
+	Limit int64
+}
+
+func (e *RawSizeLimitExceededError) Error() string {
+	return fmt.Sprintf("raw data of %d bytes exceeds the maximum size of %d bytes", e.Size, e.Limit)
+}
+
+func (e *RawSizeLimitExceededError) Is(target error) bool {
+	return target == ErrRawSizeLimitExceeded
+}
+
 func (u *Raw) String() string {
 	return string(u.Data)
 }
@@ -63,6 +85,35 @@ func (u *Raw) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Decode returns the data decoded into a new object of the type registered for u.Type in scheme.
+// The decoded object is cached by the scheme, so repeated calls with the same scheme do not
+// parse the data again. The returned object is a deep copy of the cached one and may be modified.
+// Data exceeding the limit of the scheme, see WithMaxRawSize, is rejected with a [*RawSizeLimitExceededError].
+//
+// The cached object is validated against the type and data of the Raw on every call, so it is dropped when
+// the Raw is unmarshalled again, its type is changed with SetType or Data is replaced.
+// Modifying Data in place is not detected.
+// Decode is safe for concurrent use as long as the Raw is not modified.
+func (u *Raw) Decode(scheme *Scheme) (Typed, error) {
+	if scheme == nil {
+		return nil, fmt.Errorf("cannot decode raw of type %s without a scheme", u.Type)
+	}
+	if err := scheme.checkRawSize(u.Data); err != nil {
+		return nil, err
+	}
+	return scheme.rawCache.decode(u, func() (Typed, error) {
+		obj, err := scheme.NewObject(u.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode raw of type %s: %w", u.Type, err)
+		}
+		if err := json.Unmarshal(u.Data, obj); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal from raw: %w", err)
+		}
+		scheme.warnIfDeprecated(u.Type)
+		return obj, nil
+	})
+}
+
 //go:embed schemas/Raw.schema.json
 var schemaRaw []byte
 
@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	goruntime "runtime"
+	"sync"
+	"weak"
+)
+
+// rawCache holds the objects a scheme decoded with Raw.Decode. The entries are keyed by weak pointers to the
+// Raws, so the cache does not keep a Raw alive, and are removed once their Raw is garbage collected.
+type rawCache struct {
+	mu      sync.Mutex
+	entries map[weak.Pointer[Raw]]*rawCacheEntry
+}
+
+// rawCacheEntry holds the object decoded from a Raw. It remembers the type and data it was
+// decoded from, so that it can be invalidated when they are replaced.
+type rawCacheEntry struct {
+	mu      sync.Mutex
+	typ     Type
+	data    []byte
+	decoded Typed
+}
+
+// decode returns a deep copy of the object cached for u, decoding it with decode if there is none
+// or the type or data of u changed since it was decoded.
+func (c *rawCache) decode(u *Raw, decode func() (Typed, error)) (Typed, error) {
+	entry := c.entry(u)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.decoded == nil || !entry.typ.Equal(u.Type) || !sameBytes(entry.data, u.Data) {
+		obj, err := decode()
+		if err != nil {
+			return nil, err
+		}
+		entry.typ, entry.data, entry.decoded = u.Type, u.Data, obj
+	}
+	return entry.decoded.DeepCopyTyped(), nil
+}
+
+func (c *rawCache) entry(u *Raw) *rawCacheEntry {
+	key := weak.Make(u)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		return entry
+	}
+	if c.entries == nil {
+		c.entries = make(map[weak.Pointer[Raw]]*rawCacheEntry)
+	}
+	entry := &rawCacheEntry{}
+	c.entries[key] = entry
+	goruntime.AddCleanup(u, c.remove, key)
+	return entry
+}
+
+func (c *rawCache) remove(key weak.Pointer[Raw]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// len returns the number of cached entries.
+func (c *rawCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// sameBytes reports whether a and b are the same slice (not just equal content).
+func sameBytes(a, b []byte) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
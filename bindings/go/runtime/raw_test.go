@@ -2,8 +2,13 @@ package runtime
 
 import (
 	"encoding/json"
+	"reflect"
+	goruntime "runtime"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -58,3 +63,128 @@ func TestRaw_String(t *testing.T) {
 
 	require.Equal(t, "some raw data", raw.String())
 }
+
+func TestRaw_Decode(t *testing.T) {
+	r := require.New(t)
+	typ := NewVersionedType("test", "v1")
+	scheme := NewScheme()
+	scheme.MustRegisterWithAlias(&TestType{}, typ)
+
+	var raw Raw
+	r.NoError(json.Unmarshal([]byte(`{"type":"test/v1","value":"foo"}`), &raw))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			obj, err := raw.Decode(scheme)
+			assert.NoError(t, err)
+			assert.Equal(t, &TestType{Type: typ, Value: "foo"}, obj)
+		})
+	}
+	wg.Wait()
+
+	first, err := raw.Decode(scheme)
+	r.NoError(err)
+	r.Equal(1, scheme.rawCache.len())
+	cached := scheme.rawCache.entry(&raw).decoded
+
+	t.Run("modifying the result does not modify the cache", func(t *testing.T) {
+		first.(*TestType).Value = "bar"
+		second, err := raw.Decode(scheme)
+		require.NoError(t, err)
+		require.Equal(t, "foo", second.(*TestType).Value)
+		require.Same(t, cached, scheme.rawCache.entry(&raw).decoded)
+	})
+
+	t.Run("replacing data invalidates the cache", func(t *testing.T) {
+		raw.Data = []byte(`{"type":"test/v1","value":"baz"}`)
+		obj, err := raw.Decode(scheme)
+		require.NoError(t, err)
+		require.Equal(t, "baz", obj.(*TestType).Value)
+	})
+
+	t.Run("decoding does not change the raw", func(t *testing.T) {
+		cp := raw.DeepCopy()
+		_, err := raw.Decode(scheme)
+		require.NoError(t, err)
+		require.Equal(t, cp, &raw)
+		require.True(t, reflect.DeepEqual(cp, &raw))
+	})
+
+	t.Run("other schemes do not share the cache", func(t *testing.T) {
+		other := NewScheme()
+		other.MustRegisterWithAlias(&TestType{}, typ)
+		_, err := raw.Decode(other)
+		require.NoError(t, err)
+		require.NotSame(t, scheme.rawCache.entry(&raw), other.rawCache.entry(&raw))
+	})
+
+	t.Run("unregistered type", func(t *testing.T) {
+		raw.SetType(NewVersionedType("unknown", "v1"))
+		_, err := raw.Decode(scheme)
+		require.ErrorContains(t, err, "unsupported type: unknown/v1")
+	})
+
+	t.Run("new object from", func(t *testing.T) {
+		r := require.New(t)
+		raw := &Raw{}
+		r.NoError(json.Unmarshal([]byte(`{"type":"test/v1","value":"foo"}`), raw))
+		obj, err := scheme.NewObjectFrom(raw)
+		r.NoError(err)
+		r.Equal(&TestType{Type: typ, Value: "foo"}, obj)
+		r.NotNil(scheme.rawCache.entry(raw).decoded)
+
+		obj, err = scheme.NewObjectFrom(&TestType{Type: typ, Value: "bar"})
+		r.NoError(err)
+		r.Equal(&TestType{Type: typ, Value: "bar"}, obj)
+	})
+}
+
+func TestRaw_DecodeCacheDoesNotKeepRawsAlive(t *testing.T) {
+	scheme := NewScheme()
+	scheme.MustRegisterWithAlias(&TestType{}, NewVersionedType("test", "v1"))
+
+	raw := &Raw{}
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"test/v1","value":"foo"}`), raw))
+	_, err := raw.Decode(scheme)
+	require.NoError(t, err)
+	require.Equal(t, 1, scheme.rawCache.len())
+
+	raw = nil
+	require.Eventually(t, func() bool {
+		goruntime.GC()
+		return scheme.rawCache.len() == 0
+	}, 5*time.Second, 10*time.Millisecond, "the cache entry must be removed once the raw is garbage collected")
+}
+
+func TestRaw_MaxRawSize(t *testing.T) {
+	r := require.New(t)
+
+	input := []byte(`{"type":"example","foo":"bar"}`)
+	var raw Raw
+	r.NoError(json.Unmarshal(input, &raw))
+
+	limited := NewScheme(WithAllowUnknown(), WithMaxRawSize(int64(len(raw.Data)-1)))
+	_, err := raw.Decode(limited)
+	r.ErrorIs(err, ErrRawSizeLimitExceeded)
+	var sizeErr *RawSizeLimitExceededError
+	r.ErrorAs(err, &sizeErr)
+	r.Equal(int64(len(raw.Data)), sizeErr.Size)
+	r.Equal(int64(len(raw.Data)-1), sizeErr.Limit)
+
+	r.NoError(limited.Convert(&raw, &Raw{}), "raw to raw copies are not limited")
+	r.ErrorIs(limited.Clone().Convert(&raw, &TestType{}), ErrRawSizeLimitExceeded)
+
+	for _, scheme := range []*Scheme{
+		NewScheme(WithAllowUnknown()),
+		NewScheme(WithAllowUnknown(), WithMaxRawSize(int64(len(raw.Data)))),
+		NewScheme(WithAllowUnknown(), WithMaxRawSize(-1)),
+	} {
+		_, err := raw.Decode(scheme)
+		r.NoError(err)
+	}
+
+	r.NoError(CheckRawSize(raw.Data, 0))
+	r.NoError(CheckRawSize(raw.Data, DefaultMaxRawSize))
+	r.ErrorIs(CheckRawSize(make([]byte, DefaultMaxRawSize+1), DefaultMaxRawSize), ErrRawSizeLimitExceeded)
+}
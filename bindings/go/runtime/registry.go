@@ -34,6 +34,16 @@ type Scheme struct {
 	// this avoids the need to create new instances via reflection and allows
 	// passing pre-initialized default values if needed.
 	instances map[reflect.Type]Typed
+	// deprecations maps deprecated Types to their Deprecation.
+	deprecations map[Type]Deprecation
+	// deprecationHandler is called whenever a deprecated Type is decoded.
+	deprecationHandler DeprecationHandler
+	// conversions maps default Types to the conversions into other default Types.
+	conversions map[Type]map[Type]ConversionFunc
+	// maxRawSize is the maximum size of Raw data in bytes that is decoded, 0 means unlimited.
+	maxRawSize int64
+	// rawCache holds the objects decoded with Raw.Decode.
+	rawCache rawCache
 }
 
 // NewScheme creates a new registry.
@@ -42,6 +52,10 @@ func NewScheme(opts ...SchemeOption) *Scheme {
 		defaults:  bimap.New[Type, reflect.Type](),
 		aliases:   map[Type]Type{},
 		instances: make(map[reflect.Type]Typed),
+
+		deprecations:       map[Type]Deprecation{},
+		deprecationHandler: logDeprecation,
+		conversions:        map[Type]map[Type]ConversionFunc{},
 	}
 	for _, opt := range opts {
 		opt(reg)
@@ -112,13 +126,27 @@ func (r *Scheme) RegisterSchemeType(scheme *Scheme, typ Type) error {
 		}
 	}
 
+	types := append([]Type{typ}, slices.Collect(scheme.AliasesIter(typ))...)
+
 	// register the type in the new scheme
-	return r.RegisterWithAlias(
+	if err := r.RegisterWithAlias(
 		// first the default type
 		scheme.instances[rt],
 		// then the default type and all its aliases
-		append([]Type{typ}, slices.Collect(scheme.AliasesIter(typ))...)...,
-	)
+		types...,
+	); err != nil {
+		return err
+	}
+
+	// carry over the deprecations of the type and its aliases
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range types {
+		if deprecation, ok := scheme.deprecations[t]; ok {
+			r.deprecations[t] = deprecation
+		}
+	}
+	return nil
 }
 
 type SchemeOption func(*Scheme)
@@ -130,6 +158,36 @@ func WithAllowUnknown() SchemeOption {
 	}
 }
 
+// DefaultMaxRawSize is the limit for specifications embedded in documents that are not under the control of the
+// user, such as the access specifications of descriptors and the configurations of the OCM configuration.
+// It is far larger than any hand-written specification.
+const DefaultMaxRawSize = 1 << 20
+
+// WithMaxRawSize sets the maximum size in bytes of the data of a Raw that is decoded with the scheme,
+// see Raw.Decode and Convert. Decoding a Raw with more data fails with a [*RawSizeLimitExceededError].
+// This protects against huge embedded specifications, e.g. in descriptors received from
+// untrusted registries, see DefaultMaxRawSize. A limit <= 0 disables the check, which is the default.
+func WithMaxRawSize(limit int64) SchemeOption {
+	return func(registry *Scheme) {
+		registry.maxRawSize = max(limit, 0)
+	}
+}
+
+// checkRawSize returns a [*RawSizeLimitExceededError] if data exceeds the limit set with WithMaxRawSize.
+func (r *Scheme) checkRawSize(data []byte) error {
+	return CheckRawSize(data, r.maxRawSize)
+}
+
+// CheckRawSize returns a [*RawSizeLimitExceededError] if data exceeds the limit in bytes.
+// A limit <= 0 disables the check. It allows rejecting the data of a Raw before it is parsed in
+// other ways than decoding it with a scheme, e.g. for validation against a JSON schema.
+func CheckRawSize(data []byte, limit int64) error {
+	if limit > 0 && int64(len(data)) > limit {
+		return &RawSizeLimitExceededError{Size: int64(len(data)), Limit: limit}
+	}
+	return nil
+}
+
 func (r *Scheme) Clone() *Scheme {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -138,6 +196,10 @@ func (r *Scheme) Clone() *Scheme {
 	clone.defaults = r.defaults.Clone()
 	maps.Copy(clone.aliases, r.aliases)
 	maps.Copy(clone.instances, r.instances)
+	maps.Copy(clone.deprecations, r.deprecations)
+	clone.deprecationHandler = r.deprecationHandler
+	clone.conversions = r.cloneConversions()
+	clone.maxRawSize = r.maxRawSize
 	return clone
 }
 
@@ -155,6 +217,7 @@ func (r *Scheme) RegisterSchemes(schemes ...*Scheme) error {
 }
 
 // RegisterScheme adds all types from the given scheme to the given scheme, and fails if any of the types already exist.
+// The conversions between the types of the given scheme are added as well.
 func (r *Scheme) RegisterScheme(scheme *Scheme) error {
 	if scheme == nil {
 		return nil
@@ -170,6 +233,14 @@ func (r *Scheme) RegisterScheme(scheme *Scheme) error {
 		}
 	}
 
+	for from, conversions := range scheme.conversions {
+		for to, fn := range conversions {
+			if err := r.RegisterConversion(from, to, fn); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -291,6 +362,18 @@ func (r *Scheme) ResolveCanonicalType(typ Type) (canonical Type, ok bool) {
 	return typ, false
 }
 
+// reflectTypeFor returns the reflect.Type registered for a default or alias type.
+// The caller has to hold the lock of the scheme.
+func (r *Scheme) reflectTypeFor(typ Type) (reflect.Type, bool) {
+	if rt, exists := r.defaults.GetLeft(typ); exists {
+		return rt, true
+	}
+	if def, isAlias := r.aliases[typ]; isAlias {
+		return r.defaults.GetLeft(def)
+	}
+	return nil, false
+}
+
 func (r *Scheme) MustRegisterWithAlias(prototype Typed, types ...Type) {
 	if err := r.RegisterWithAlias(prototype, types...); err != nil {
 		panic(err)
@@ -323,6 +406,22 @@ func (r *Scheme) NewObject(typ Type) (Typed, error) {
 	return nil, fmt.Errorf("unsupported type: %s", typ)
 }
 
+// NewObjectFrom creates a new instance of the type registered for the type of from and converts from into it.
+// A Raw is decoded with Raw.Decode, so that repeated calls with the same Raw decode its data only once.
+func (r *Scheme) NewObjectFrom(from Typed) (Typed, error) {
+	if raw, ok := from.(*Raw); ok {
+		return raw.Decode(r)
+	}
+	obj, err := r.NewObject(from.GetType())
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Convert(from, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
 func (r *Scheme) Decode(data io.Reader, into Typed) error {
 	if _, err := r.TypeForPrototype(into); err != nil {
 		if !r.allowUnknown {
@@ -343,6 +442,7 @@ func (r *Scheme) Decode(data io.Reader, into Typed) error {
 	if !oldType.IsEmpty() && !oldType.Equal(into.GetType()) {
 		return fmt.Errorf("expected type %q after decoding but got %q", oldType, into.GetType())
 	}
+	r.warnIfDeprecated(into.GetType())
 	return nil
 }
 
@@ -373,14 +473,20 @@ func (r *Scheme) DefaultType(typed Typed) (updated bool, err error) {
 // Special Cases:
 //   - Raw → Raw: performs a deep copy of the underlying []byte data.
 //   - Raw → Typed: unmarshals Raw.Data JSON via json.Unmarshal into the Typed object (if Typed.GetType is registered).
+//     Use Raw.Decode instead to decode the same Raw only once. Decoding a deprecated type calls the DeprecationHandler.
 //   - Typed → Raw: marshals the Typed with json.Marshal, applies canonicalization, and stores the result in Raw.Data.
 //     (See Raw.UnmarshalJSON for equivalent behavior)
 //   - Typed → Typed: performs a deep copy using Typed.DeepCopyTyped, with reflection-based assignment.
 //
+// If the prototypes registered for the type of 'from' and for 'into' differ, and conversions between them are
+// registered with RegisterConversion, 'from' is converted along the shortest chain of conversions instead,
+// for Raw → Typed as well as Typed → Typed.
+//
 // Errors are returned if:
 //   - Either argument is nil.
 //   - A type is not registered in the Scheme (for Raw conversions).
 //   - A reflection-based assignment fails due to type mismatch.
+//   - Raw.Data exceeds the limit configured with WithMaxRawSize.
 func (r *Scheme) Convert(from Typed, into Typed) error {
 	// Check for nil arguments.
 	if from == nil || into == nil {
@@ -407,13 +513,22 @@ func (r *Scheme) Convert(from Typed, into Typed) error {
 			return nil
 		}
 
+		// Raw → Typed: Convert between versions if the target has a different prototype.
+		if converted, err := r.convertWithConversions(rawFrom, into); converted {
+			return err
+		}
+
 		// Raw → Typed: Unmarshal the Raw.Data into the target.
 		if !r.IsRegistered(fromType) && !r.allowUnknown {
 			return fmt.Errorf("cannot decode from unregistered type: %s", fromType)
 		}
+		if err := r.checkRawSize(rawFrom.Data); err != nil {
+			return err
+		}
 		if err := json.Unmarshal(rawFrom.Data, into); err != nil {
 			return fmt.Errorf("failed to unmarshal from raw: %w", err)
 		}
+		r.warnIfDeprecated(fromType)
 		return nil
 	}
 
@@ -447,6 +562,9 @@ func (r *Scheme) Convert(from Typed, into Typed) error {
 	}
 	intoElem := intoVal.Elem()
 	if !copiedVal.Type().AssignableTo(intoElem.Type()) {
+		if converted, err := r.convertWithConversions(from, into); converted {
+			return err
+		}
 		return fmt.Errorf("cannot assign value of type %T to target of type %T", copied, into)
 	}
 	intoElem.Set(copiedVal)
@@ -0,0 +1,143 @@
+// Package status provides a machine-readable output mode for long-running operations
+// of the bindings (such as component construction, transfer or verification).
+//
+// Operations write one status record per line as JSON (NDJSON) to a Writer provided by
+// the caller. The records are meant for CI systems that need to follow an operation
+// without scraping human-readable logs. Their fields form a stable schema identified
+// by SchemaVersion:
+//
+//	{"schema":"status/v1","time":"2026-01-01T00:00:00Z","operation":"construct","step":"resource","item":"acme.org/app:1.0.0/name=image","result":"started"}
+//	{"schema":"status/v1","time":"2026-01-01T00:00:02Z","operation":"construct","step":"resource","item":"acme.org/app:1.0.0/name=image","result":"succeeded","durationMs":2000}
+//
+// A step is recorded when it starts and when it ends:
+//
+//	w := status.NewWriter(os.Stdout)
+//	end := w.Start("verify", "signature", "acme.org/app:1.0.0/release")
+//	err := verify(ctx)
+//	end(err)
+//
+// All methods of a nil *Writer are no-ops, so operations can record steps unconditionally.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// SchemaVersion identifies the schema of the records. It changes only if fields are
+// removed or change their meaning, new fields may be added at any time.
+const SchemaVersion = "status/v1"
+
+// Result is the outcome of a step at the time a record is written.
+type Result string
+
+const (
+	// ResultStarted is recorded when a step starts.
+	ResultStarted Result = "started"
+	// ResultSucceeded is recorded when a step ends without error.
+	ResultSucceeded Result = "succeeded"
+	// ResultFailed is recorded when a step ends with an error.
+	ResultFailed Result = "failed"
+	// ResultSkipped is recorded for steps that are not executed, e.g. because a previous run completed them.
+	ResultSkipped Result = "skipped"
+)
+
+// Record is a single status record.
+type Record struct {
+	// Schema is the SchemaVersion of the record. It is set by the Writer.
+	Schema string `json:"schema"`
+	// Time is the time the record was written. It is set by the Writer if empty.
+	Time time.Time `json:"time"`
+	// Operation is the long-running operation the step belongs to, e.g. "construct", "transfer" or "verify".
+	Operation string `json:"operation"`
+	// Step is the kind of the step within the operation, e.g. "component", "resource" or "transformation".
+	Step string `json:"step"`
+	// Item identifies the object the step processes. It is unique for the step within the operation.
+	Item string `json:"item"`
+	// Result is the outcome of the step.
+	Result Result `json:"result"`
+	// DurationMilliseconds is the duration of the step in milliseconds. It is only set for ended steps.
+	DurationMilliseconds *int64 `json:"durationMs,omitempty"`
+	// Error is the error message of failed steps.
+	Error string `json:"error,omitempty"`
+}
+
+// Writer writes status records as NDJSON. It is safe for concurrent use.
+type Writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+	now func() time.Time
+}
+
+// NewWriter creates a Writer writing status records to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// Write writes the record as a single line. Write errors do not fail the operation
+// recording the step, the first one is returned by Err.
+func (w *Writer) Write(record Record) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	record.Schema = SchemaVersion
+	if record.Time.IsZero() {
+		record.Time = w.now().UTC()
+	}
+	if err := w.enc.Encode(record); err != nil && w.err == nil {
+		w.err = err
+	}
+}
+
+// Start records the start of a step and returns a function that records its end.
+// The end function records the step as failed if err is not nil, and as succeeded otherwise.
+func (w *Writer) Start(operation, step, item string) (end func(err error)) {
+	if w == nil {
+		return func(error) {}
+	}
+	started := w.now()
+	w.Write(Record{Operation: operation, Step: step, Item: item, Result: ResultStarted})
+	return func(err error) {
+		duration := w.now().Sub(started).Milliseconds()
+		record := Record{Operation: operation, Step: step, Item: item, Result: ResultSucceeded, DurationMilliseconds: &duration}
+		if err != nil {
+			record.Result = ResultFailed
+			record.Error = err.Error()
+		}
+		w.Write(record)
+	}
+}
+
+// Skip records a step that is not executed.
+func (w *Writer) Skip(operation, step, item string) {
+	w.Write(Record{Operation: operation, Step: step, Item: item, Result: ResultSkipped})
+}
+
+// Err returns the first error that occurred while writing records.
+func (w *Writer) Err() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+type contextKey struct{}
+
+// WithWriter returns a context that carries w, for operations that do not take the Writer as option.
+func WithWriter(ctx context.Context, w *Writer) context.Context {
+	return context.WithValue(ctx, contextKey{}, w)
+}
+
+// FromContext returns the Writer carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Writer {
+	w, _ := ctx.Value(contextKey{}).(*Writer)
+	return w
+}
@@ -0,0 +1,60 @@
+package status_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/runtime/status"
+)
+
+func TestWriter(t *testing.T) {
+	r := require.New(t)
+	var buf bytes.Buffer
+	w := status.NewWriter(&buf)
+
+	end := w.Start("construct", "resource", "acme.org/app:1.0.0/name=image")
+	end(nil)
+	w.Start("construct", "resource", "acme.org/app:1.0.0/name=chart")(errors.New("boom"))
+	w.Skip("construct", "component", "acme.org/app:1.0.0")
+	r.NoError(w.Err())
+
+	var records []status.Record
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record status.Record
+		r.NoError(json.Unmarshal(scanner.Bytes(), &record))
+		r.Equal(status.SchemaVersion, record.Schema)
+		r.False(record.Time.IsZero())
+		records = append(records, record)
+	}
+	r.Len(records, 5)
+
+	r.Equal(status.ResultStarted, records[0].Result)
+	r.Nil(records[0].DurationMilliseconds)
+	r.Equal(status.ResultSucceeded, records[1].Result)
+	r.NotNil(records[1].DurationMilliseconds)
+	r.Equal("acme.org/app:1.0.0/name=image", records[1].Item)
+	r.Equal(status.ResultFailed, records[3].Result)
+	r.Equal("boom", records[3].Error)
+	r.Equal(status.Record{
+		Schema: status.SchemaVersion, Time: records[4].Time,
+		Operation: "construct", Step: "component", Item: "acme.org/app:1.0.0", Result: status.ResultSkipped,
+	}, records[4])
+}
+
+func TestNilWriter(t *testing.T) {
+	r := require.New(t)
+	var w *status.Writer
+	w.Start("verify", "signature", "release")(nil)
+	w.Skip("verify", "signature", "release")
+	r.NoError(w.Err())
+	r.Nil(status.FromContext(t.Context()))
+
+	w = status.NewWriter(&bytes.Buffer{})
+	r.Same(w, status.FromContext(status.WithWriter(t.Context(), w)))
+}
@@ -11,7 +11,10 @@ import (
 
 	"ocm.software/open-component-model/bindings/go/descriptor/normalisation"
 	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	// registers jsonNormalisation/v5alpha1, so that signatures can use it.
+	_ "ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v5alpha1"
 	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
 )
 
 const (
@@ -22,8 +25,38 @@ const (
 	// AccessTypeNone is the access type for resources without access.
 	// It is used to prevent meaningless digest claims.
 	AccessTypeNone = "None"
+
+	// StatusOperation is the operation of the status records written during verification.
+	StatusOperation = "verify"
+	// StatusStepDigest is the step of the status records of VerifyDigestMatchesDescriptor.
+	StatusStepDigest = "digest"
+	// StatusStepTimestamp is the step of the status records of the verification of RFC 3161 timestamps,
+	// see the tsa package.
+	StatusStepTimestamp = "timestamp"
+
+	// ArtifactDigestNormalisation is the normalisation algorithm of signatures over the artifact holding a
+	// descriptor instead of the descriptor itself, e.g. cosign signatures of the OCI manifest of a component
+	// version. Their digest is verified against the artifact digest recorded with WithArtifactDigest.
+	ArtifactDigestNormalisation = "ociArtifactDigest/v1"
 )
 
+type artifactDigestKey struct{}
+
+// WithArtifactDigest returns a context recording the digest of the artifact the descriptor to verify was read from,
+// e.g. the OCI manifest of a component version. The caller is responsible for the descriptor being held by
+// this artifact, e.g. by reading it from the artifact fetched by digest.
+//
+// VerifyDigestMatchesDescriptor verifies signatures with the normalisation algorithm ArtifactDigestNormalisation
+// against this digest.
+func WithArtifactDigest(ctx context.Context, digest descruntime.Digest) context.Context {
+	return context.WithValue(ctx, artifactDigestKey{}, digest)
+}
+
+func artifactDigestFromContext(ctx context.Context) (descruntime.Digest, bool) {
+	digest, ok := ctx.Value(artifactDigestKey{}).(descruntime.Digest)
+	return digest, ok
+}
+
 // VerifyDigestMatchesDescriptor ensures that a descriptor matches a digest
 // provided by a signature. This validates descriptor integrity against the
 // signature’s claimed digest.
@@ -35,12 +68,25 @@ const (
 //  4. Hash the normalised descriptor.
 //  5. Decode the digest value from the signature.
 //  6. Compare the freshly computed digest against the signature digest.
+//
+// Signatures with the normalisation algorithm ArtifactDigestNormalisation are compared against
+// the artifact digest recorded in ctx with WithArtifactDigest instead.
+//
+// If ctx carries a status.Writer, the verification is recorded as step StatusStepDigest
+// of StatusOperation, with the component version and signature name as item.
 func VerifyDigestMatchesDescriptor(
 	ctx context.Context,
 	desc *descruntime.Descriptor,
 	signature descruntime.Signature,
 	logger *slog.Logger,
-) error {
+) (err error) {
+	end := status.FromContext(ctx).Start(StatusOperation, StatusStepDigest, desc.Component.ToIdentity().String()+"/"+signature.Name)
+	defer func() { end(err) }()
+
+	if signature.Digest.NormalisationAlgorithm == ArtifactDigestNormalisation {
+		return verifyArtifactDigest(ctx, signature)
+	}
+
 	signature.Digest.NormalisationAlgorithm = ensureNormalisationAlgo(ctx, signature.Digest.NormalisationAlgorithm, logger)
 
 	normalised, err := normalisation.Normalise(desc, signature.Digest.NormalisationAlgorithm)
@@ -70,6 +116,23 @@ func VerifyDigestMatchesDescriptor(
 	return nil
 }
 
+// verifyArtifactDigest ensures that the digest of a signature over an artifact is the digest
+// recorded with WithArtifactDigest.
+func verifyArtifactDigest(ctx context.Context, signature descruntime.Signature) error {
+	artifact, ok := artifactDigestFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("no artifact digest known to verify signature with normalisation algorithm %s", ArtifactDigestNormalisation)
+	}
+	if _, err := getSupportedHash(signature.Digest.HashAlgorithm); err != nil {
+		return err
+	}
+	if !strings.EqualFold(signature.Digest.HashAlgorithm, artifact.HashAlgorithm) || !strings.EqualFold(signature.Digest.Value, artifact.Value) {
+		return fmt.Errorf("digest mismatch: artifact %s:%s vs signature %s:%s",
+			artifact.HashAlgorithm, artifact.Value, signature.Digest.HashAlgorithm, signature.Digest.Value)
+	}
+	return nil
+}
+
 // GenerateDigest computes a new digest for a descriptor with the given
 // normalisation and hashing algorithms.
 //
@@ -118,6 +181,31 @@ func GenerateDigest(
 	}, nil
 }
 
+// VerifyReferenceDigest ensures that a descriptor matches the digest of the
+// component reference pointing to it, with the normalisation and hash
+// algorithms of the reference digest.
+//
+// It is used as descriptor runtime.DigestVerifier when resolving the
+// references of a component version with runtime.ResolveReferences.
+func VerifyReferenceDigest(
+	ctx context.Context,
+	ref *descruntime.Reference,
+	desc *descruntime.Descriptor,
+	logger *slog.Logger,
+) error {
+	if ref.Digest.Value == "" {
+		return fmt.Errorf("missing digest in componentReference for %s:%s", ref.Name, ref.Version)
+	}
+	fresh, err := GenerateDigest(ctx, desc, logger, ref.Digest.NormalisationAlgorithm, ref.Digest.HashAlgorithm)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(fresh.Value, ref.Digest.Value) {
+		return fmt.Errorf("digest mismatch: descriptor %s vs reference %s", fresh.Value, ref.Digest.Value)
+	}
+	return nil
+}
+
 // IsSafelyDigestible validates that a component’s references and resources
 // contain consistent digests according to OCM rules:
 //
@@ -1,9 +1,11 @@
 package signing
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"encoding/hex"
+	"encoding/json"
 	"io"
 	"log/slog"
 	"testing"
@@ -13,6 +15,7 @@ import (
 
 	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
 	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
 )
 
 func TestGetSupportedHash(t *testing.T) {
@@ -164,6 +167,27 @@ func TestVerifyDigestMatchesDescriptor_Mismatch(t *testing.T) {
 	assert.Contains(t, err.Error(), "digest mismatch")
 }
 
+func TestVerifyDigestMatchesDescriptor_Status(t *testing.T) {
+	r := require.New(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	var buf bytes.Buffer
+	ctx := status.WithWriter(t.Context(), status.NewWriter(&buf))
+
+	d := &descruntime.Descriptor{Component: descruntime.Component{ComponentMeta: descruntime.ComponentMeta{ObjectMeta: descruntime.ObjectMeta{Name: "a", Version: "v1"}}, Provider: descruntime.Provider{Name: "p"}}}
+	r.Error(VerifyDigestMatchesDescriptor(ctx, d, descruntime.Signature{Name: "s1", Digest: descruntime.Digest{Value: "zz"}}, logger))
+
+	dec := json.NewDecoder(&buf)
+	var started, ended status.Record
+	r.NoError(dec.Decode(&started))
+	r.NoError(dec.Decode(&ended))
+	r.Equal(StatusOperation, ended.Operation)
+	r.Equal(StatusStepDigest, ended.Step)
+	r.Equal(d.Component.ToIdentity().String()+"/s1", ended.Item)
+	r.Equal(status.ResultStarted, started.Result)
+	r.Equal(status.ResultFailed, ended.Result)
+	r.NotEmpty(ended.Error)
+}
+
 func TestVerifyDigestMatchesDescriptor_InvalidHex(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	ctx := context.Background()
@@ -210,3 +234,26 @@ func TestVerifyDigestMatchesDescriptor_LegacyNormalisation(t *testing.T) {
 
 	require.NoError(t, VerifyDigestMatchesDescriptor(ctx, d, sig, logger))
 }
+
+func TestVerifyReferenceDigest(t *testing.T) {
+	r := require.New(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := t.Context()
+
+	d := &descruntime.Descriptor{Component: descruntime.Component{ComponentMeta: descruntime.ComponentMeta{ObjectMeta: descruntime.ObjectMeta{Name: "ref", Version: "v1"}}, Provider: descruntime.Provider{Name: "p"}}}
+	dg, err := GenerateDigest(ctx, d, logger, v4alpha1.Algorithm, crypto.SHA256.String())
+	r.NoError(err)
+
+	ref := &descruntime.Reference{
+		ElementMeta: descruntime.ElementMeta{ObjectMeta: descruntime.ObjectMeta{Name: "ref", Version: "v1"}},
+		Component:   "ref",
+		Digest:      *dg,
+	}
+	r.NoError(VerifyReferenceDigest(ctx, ref, d, logger))
+
+	d.Component.Provider.Name = "tampered"
+	r.ErrorContains(VerifyReferenceDigest(ctx, ref, d, logger), "digest mismatch")
+
+	ref.Digest = descruntime.Digest{}
+	r.ErrorContains(VerifyReferenceDigest(ctx, ref, d, logger), "missing digest")
+}
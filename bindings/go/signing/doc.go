@@ -5,4 +5,11 @@
 // canonical JSON form and hashing the result with a supported algorithm.
 // These functions are used to guarantee integrity, support signature checks,
 // and validate component graph consistency.
+//
+// VerifyWithPolicy verifies the signatures of a descriptor against a Policy, which declares the signers a
+// component version requires, e.g. "at least 2 of these 3 signers" or "signer X for components matching a glob".
+//
+// Signatures over the artifact holding a descriptor instead of the descriptor itself, e.g. cosign signatures of
+// the OCI manifest of a component version, use the normalisation algorithm ArtifactDigestNormalisation. They are
+// verified against the digest of the artifact the descriptor was read from, recorded with WithArtifactDigest.
 package signing
@@ -52,6 +52,7 @@ type Signer interface {
 // - Return an error if any selected signature or required digest check fails.
 //
 // Implementations SHOULD:
+// - Verify certificates at the time returned by VerificationTime, if the context carries one.
 // - Use a well-known registered default configuration derived from configuration and specification and be modifiable in their behavior, assuming sane defaults.
 // - Offer versioned, stable verification implementations differentiated by the config type.
 // - Reject verification specifications if there is no credential available that is required for the handler to verify the signature.
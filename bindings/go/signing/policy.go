@@ -0,0 +1,276 @@
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
+)
+
+// StatusStepPolicy is the step of the status records of the evaluation of a rule by VerifyWithPolicy.
+const StatusStepPolicy = "policy"
+
+var (
+	// ErrPolicyNotSatisfied is matched by the error returned from VerifyWithPolicy if a rule of the policy
+	// is not satisfied by the signatures of the component version.
+	ErrPolicyNotSatisfied = errors.New("signature verification policy not satisfied")
+	// ErrSignatureNotFound is returned for signers whose signature is not present in the descriptor.
+	ErrSignatureNotFound = errors.New("signature not found")
+)
+
+// Policy declares the signatures a component version must carry to be trusted,
+// e.g. "at least 2 of these 3 signers" or "signer X is required for all components matching ocm.software/*".
+//
+// Every rule applying to a component version must be satisfied. A component version no rule applies to is
+// refused, add a rule without signers to accept such component versions without verification.
+type Policy struct {
+	Rules []Rule
+}
+
+// Rule requires a number of signers to have signed the component versions it applies to.
+type Rule struct {
+	// Name identifies the rule in errors and status records.
+	Name string
+	// Components are glob patterns matched with path.Match against the component name.
+	// If empty, the rule applies to all components.
+	Components []string
+	// Signers are the signers whose signatures are verified.
+	Signers []PolicySigner
+	// Threshold is the number of Signers that must have signed the component version.
+	// If zero, all Signers are required.
+	Threshold int
+}
+
+// PolicySigner is a signer of a Rule, identified by the name of its signature in the descriptor.
+type PolicySigner struct {
+	// Signature is the name of the signature created by the signer. It may be a glob pattern matched
+	// with path.Match against the signature names, e.g. "cosign-*" for cosign signatures, which are named
+	// after their content. The signer is then verified if one of the matching signatures is verified.
+	Signature string
+	// Verifier verifies the signature cryptographically. It is required, as a signature with a digest
+	// matching the descriptor can be attached by anyone and does not prove that it was created by the signer.
+	Verifier Verifier
+	// Config is passed to the Verifier.
+	Config runtime.Typed
+	// Credentials are passed to the Verifier, e.g. the public key of the signer.
+	Credentials runtime.Typed
+}
+
+// Validate checks that the rules of the policy are well-formed.
+func (p Policy) Validate() error {
+	var errs []error
+	for i, rule := range p.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		for _, pattern := range rule.Components {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs = append(errs, fmt.Errorf("rule %s: invalid component pattern %q: %w", name, pattern, err))
+			}
+		}
+		if rule.Threshold < 0 || rule.Threshold > len(rule.Signers) {
+			errs = append(errs, fmt.Errorf("rule %s: threshold %d is not between 0 and the number of signers %d", name, rule.Threshold, len(rule.Signers)))
+		}
+		seen := make(map[string]bool, len(rule.Signers))
+		for _, signer := range rule.Signers {
+			switch {
+			case signer.Signature == "":
+				errs = append(errs, fmt.Errorf("rule %s: signer without signature name", name))
+				continue
+			case !validPattern(signer.Signature):
+				errs = append(errs, fmt.Errorf("rule %s: invalid signature pattern %q", name, signer.Signature))
+			case seen[signer.Signature]:
+				// a signature listed twice would count twice towards the threshold.
+				errs = append(errs, fmt.Errorf("rule %s: signature %q is listed more than once", name, signer.Signature))
+			}
+			seen[signer.Signature] = true
+			if signer.Verifier == nil {
+				errs = append(errs, fmt.Errorf("rule %s: signature %q has no verifier", name, signer.Signature))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Applies returns true if the rule applies to the component with the given name.
+func (r Rule) Applies(component string) bool {
+	if len(r.Components) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(r.Components, func(pattern string) bool {
+		matched, err := path.Match(pattern, component)
+		return err == nil && matched
+	})
+}
+
+// PolicyError describes a rule that is not satisfied by the signatures of a component version.
+// It matches [ErrPolicyNotSatisfied] with [errors.Is] and unwraps to the failures of the signers.
+type PolicyError struct {
+	// Component is the component version the rule was evaluated for, as "name:version".
+	Component string
+	// Rule is the name of the rule, empty if no rule applies to the component version.
+	Rule string
+	// Required is the number of signers the rule requires.
+	Required int
+	// Verified are the names of the signatures that were verified successfully.
+	Verified []string
+	// Errs are the reasons the other signers failed.
+	Errs []error
+}
+
+func (e *PolicyError) Error() string {
+	if e.Rule == "" && e.Required == 0 {
+		return fmt.Sprintf("no signature verification rule applies to %s", e.Component)
+	}
+	msg := fmt.Sprintf("rule %q requires %d verified signatures for %s, got %d", e.Rule, e.Required, e.Component, len(e.Verified))
+	if len(e.Errs) > 0 {
+		failures := make([]string, 0, len(e.Errs))
+		for _, err := range e.Errs {
+			failures = append(failures, err.Error())
+		}
+		msg += ": " + strings.Join(failures, "; ")
+	}
+	return msg
+}
+
+func (e *PolicyError) Unwrap() []error {
+	return e.Errs
+}
+
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPolicyNotSatisfied
+}
+
+// VerifyWithPolicy verifies the signatures of desc against the rules of policy that apply to its component.
+// The signature of a signer is verified if its digest matches the descriptor (see VerifyDigestMatchesDescriptor)
+// and its Verifier accepts it, both are required. A rule is satisfied if at least Threshold of its signers were verified.
+//
+// It returns the verified signatures in the order of the descriptor, or a [*PolicyError] for the first rule that
+// is not satisfied. If ctx carries a status.Writer, the evaluation of each rule is recorded as step
+// StatusStepPolicy of StatusOperation, with the component version and rule name as item.
+func VerifyWithPolicy(ctx context.Context, desc *descruntime.Descriptor, policy Policy, logger *slog.Logger) ([]descruntime.Signature, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid signature verification policy: %w", err)
+	}
+	component := desc.Component.Name + ":" + desc.Component.Version
+
+	applied := false
+	verified := make(map[string]bool)
+	// digests caches the digest checks, which do not depend on the signer.
+	digests := make(map[string]error)
+	for _, rule := range policy.Rules {
+		if !rule.Applies(desc.Component.Name) {
+			continue
+		}
+		applied = true
+		if err := evaluateRule(ctx, desc, rule, component, verified, digests, logger); err != nil {
+			return nil, err
+		}
+	}
+	if !applied {
+		return nil, &PolicyError{Component: component}
+	}
+
+	var signatures []descruntime.Signature
+	for _, signature := range desc.Signatures {
+		if verified[signature.Name] {
+			signatures = append(signatures, signature)
+		}
+	}
+	return signatures, nil
+}
+
+func evaluateRule(
+	ctx context.Context,
+	desc *descruntime.Descriptor,
+	rule Rule,
+	component string,
+	verified map[string]bool,
+	digests map[string]error,
+	logger *slog.Logger,
+) (err error) {
+	end := status.FromContext(ctx).Start(StatusOperation, StatusStepPolicy, desc.Component.ToIdentity().String()+"/"+rule.Name)
+	defer func() { end(err) }()
+
+	required := rule.Threshold
+	if required == 0 {
+		required = len(rule.Signers)
+	}
+	result := &PolicyError{Component: component, Rule: rule.Name, Required: required}
+	for _, signer := range rule.Signers {
+		name, err := verifySigner(ctx, desc, signer, result.Verified, digests, logger)
+		if err != nil {
+			result.Errs = append(result.Errs, fmt.Errorf("signature %q: %w", signer.Signature, err))
+			continue
+		}
+		verified[name] = true
+		result.Verified = append(result.Verified, name)
+	}
+	if len(result.Verified) < required {
+		return result
+	}
+	return nil
+}
+
+// verifySigner verifies the signature of the signer and returns its name. If the signer names its signature
+// with a pattern, the first of the matching signatures that is verified is returned. Signatures in taken
+// were verified for other signers of the rule and are not considered, so that a signature counts only once.
+func verifySigner(
+	ctx context.Context,
+	desc *descruntime.Descriptor,
+	signer PolicySigner,
+	taken []string,
+	digests map[string]error,
+	logger *slog.Logger,
+) (string, error) {
+	var errs []error
+	for _, signature := range desc.Signatures {
+		if slices.Contains(taken, signature.Name) {
+			continue
+		}
+		if signature.Name == signer.Signature {
+			if err := verifySignature(ctx, desc, signature, signer, digests, logger); err != nil {
+				return "", err
+			}
+			return signature.Name, nil
+		}
+		if matched, _ := path.Match(signer.Signature, signature.Name); !matched {
+			continue
+		}
+		if err := verifySignature(ctx, desc, signature, signer, digests, logger); err != nil {
+			errs = append(errs, fmt.Errorf("matching signature %q: %w", signature.Name, err))
+			continue
+		}
+		return signature.Name, nil
+	}
+	if len(errs) == 0 {
+		return "", ErrSignatureNotFound
+	}
+	return "", errors.Join(errs...)
+}
+
+func verifySignature(ctx context.Context, desc *descruntime.Descriptor, signature descruntime.Signature, signer PolicySigner, digests map[string]error, logger *slog.Logger) error {
+	err, checked := digests[signature.Name]
+	if !checked {
+		err = VerifyDigestMatchesDescriptor(ctx, desc, signature, logger)
+		digests[signature.Name] = err
+	}
+	if err != nil {
+		return err
+	}
+	return signer.Verifier.Verify(ctx, signature, signer.Config, signer.Credentials)
+}
+
+// validPattern reports whether the signature name of a signer is a valid path.Match pattern.
+func validPattern(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
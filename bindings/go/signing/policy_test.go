@@ -0,0 +1,262 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
+)
+
+// keyVerifier accepts signatures whose value equals the key passed as credentials.
+type keyVerifier struct{}
+
+func (keyVerifier) GetVerifyingCredentialConsumerIdentity(context.Context, descruntime.Signature, runtime.Typed) (runtime.Identity, error) {
+	return nil, errors.New("no credentials")
+}
+
+func (keyVerifier) Verify(_ context.Context, signed descruntime.Signature, _ runtime.Typed, credentials runtime.Typed) error {
+	raw, ok := credentials.(*runtime.Raw)
+	if !ok || string(raw.Data) != signed.Signature.Value {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func signedDescriptor(t *testing.T, name string, signers ...string) *descruntime.Descriptor {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	desc := &descruntime.Descriptor{
+		Component: descruntime.Component{
+			ComponentMeta: descruntime.ComponentMeta{ObjectMeta: descruntime.ObjectMeta{Name: name, Version: "v1"}},
+			Provider:      descruntime.Provider{Name: "acme"},
+		},
+	}
+	dig, err := GenerateDigest(t.Context(), desc, logger, v4alpha1.Algorithm, crypto.SHA256.String())
+	require.NoError(t, err)
+	for _, signer := range signers {
+		desc.Signatures = append(desc.Signatures, descruntime.Signature{
+			Name:      signer,
+			Digest:    *dig,
+			Signature: descruntime.SignatureInfo{Algorithm: "test", Value: "key-" + signer, MediaType: "text/plain"},
+		})
+	}
+	return desc
+}
+
+func signer(name string) PolicySigner {
+	return PolicySigner{
+		Signature:   name,
+		Verifier:    keyVerifier{},
+		Credentials: &runtime.Raw{Data: []byte("key-" + name)},
+	}
+}
+
+func TestVerifyWithPolicy(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	threeSigners := []PolicySigner{signer("alice"), signer("bob"), signer("carol")}
+
+	tests := []struct {
+		name     string
+		desc     *descruntime.Descriptor
+		policy   Policy
+		verified []string
+		err      string
+	}{
+		{
+			name:     "threshold reached",
+			desc:     signedDescriptor(t, "ocm.software/app", "alice", "carol"),
+			policy:   Policy{Rules: []Rule{{Name: "two of three", Signers: threeSigners, Threshold: 2}}},
+			verified: []string{"alice", "carol"},
+		},
+		{
+			name:   "threshold not reached",
+			desc:   signedDescriptor(t, "ocm.software/app", "alice"),
+			policy: Policy{Rules: []Rule{{Name: "two of three", Signers: threeSigners, Threshold: 2}}},
+			err:    `rule "two of three" requires 2 verified signatures for ocm.software/app:v1, got 1`,
+		},
+		{
+			name: "wrong key does not count",
+			desc: signedDescriptor(t, "ocm.software/app", "alice", "bob"),
+			policy: Policy{Rules: []Rule{{Name: "two of three", Threshold: 2, Signers: []PolicySigner{
+				signer("alice"),
+				{Signature: "bob", Verifier: keyVerifier{}, Credentials: &runtime.Raw{Data: []byte("key-mallory")}},
+			}}}},
+			err: `signature "bob": invalid signature`,
+		},
+		{
+			name: "required signer for matching components",
+			desc: signedDescriptor(t, "ocm.software/app", "alice"),
+			policy: Policy{Rules: []Rule{
+				{Name: "any", Signers: threeSigners, Threshold: 1},
+				{Name: "release", Components: []string{"ocm.software/*"}, Signers: []PolicySigner{signer("bob")}},
+			}},
+			err: `signature "bob": signature not found`,
+		},
+		{
+			name: "required signer for other components",
+			desc: signedDescriptor(t, "acme.org/app", "alice"),
+			policy: Policy{Rules: []Rule{
+				{Name: "any", Signers: threeSigners, Threshold: 1},
+				{Name: "release", Components: []string{"ocm.software/*"}, Signers: []PolicySigner{signer("bob")}},
+			}},
+			verified: []string{"alice"},
+		},
+		{
+			name:   "no applicable rule",
+			desc:   signedDescriptor(t, "acme.org/app", "alice"),
+			policy: Policy{Rules: []Rule{{Name: "release", Components: []string{"ocm.software/*"}, Signers: []PolicySigner{signer("alice")}}}},
+			err:    "no signature verification rule applies to acme.org/app:v1",
+		},
+		{
+			name: "signer with pattern",
+			desc: signedDescriptor(t, "ocm.software/app", "alice", "cosign-1", "cosign-2"),
+			policy: Policy{Rules: []Rule{{Name: "cosign", Signers: []PolicySigner{
+				{Signature: "cosign-*", Verifier: keyVerifier{}, Credentials: &runtime.Raw{Data: []byte("key-cosign-2")}},
+			}}}},
+			verified: []string{"cosign-2"},
+		},
+		{
+			name: "signature matching several patterns counts once",
+			desc: signedDescriptor(t, "ocm.software/app", "cosign-1"),
+			policy: Policy{Rules: []Rule{{Name: "cosign", Signers: []PolicySigner{
+				{Signature: "cosign-*", Verifier: keyVerifier{}, Credentials: &runtime.Raw{Data: []byte("key-cosign-1")}},
+				{Signature: "cosign-?", Verifier: keyVerifier{}, Credentials: &runtime.Raw{Data: []byte("key-cosign-1")}},
+			}}}},
+			err: `signature "cosign-?": signature not found`,
+		},
+		{
+			name: "no signature matching pattern verified",
+			desc: signedDescriptor(t, "ocm.software/app", "cosign-1"),
+			policy: Policy{Rules: []Rule{{Name: "cosign", Signers: []PolicySigner{
+				{Signature: "cosign-*", Verifier: keyVerifier{}, Credentials: &runtime.Raw{Data: []byte("key-mallory")}},
+			}}}},
+			err: `signature "cosign-*": matching signature "cosign-1": invalid signature`,
+		},
+		{
+			name:   "rule without signers",
+			desc:   signedDescriptor(t, "acme.org/app"),
+			policy: Policy{Rules: []Rule{{Name: "unsigned"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			signatures, err := VerifyWithPolicy(t.Context(), tt.desc, tt.policy, logger)
+			if tt.err != "" {
+				r.ErrorIs(err, ErrPolicyNotSatisfied)
+				r.ErrorContains(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			var names []string
+			for _, signature := range signatures {
+				names = append(names, signature.Name)
+			}
+			r.Equal(tt.verified, names)
+		})
+	}
+}
+
+func TestVerifyWithPolicy_DigestMismatch(t *testing.T) {
+	r := require.New(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	desc := signedDescriptor(t, "ocm.software/app", "alice")
+	desc.Component.Provider.Name = "mallory"
+
+	_, err := VerifyWithPolicy(t.Context(), desc, Policy{Rules: []Rule{{Name: "alice", Signers: []PolicySigner{signer("alice")}}}}, logger)
+	r.ErrorIs(err, ErrPolicyNotSatisfied)
+	r.ErrorContains(err, "digest mismatch")
+
+	var policyErr *PolicyError
+	r.ErrorAs(err, &policyErr)
+	r.Equal(1, policyErr.Required)
+	r.Empty(policyErr.Verified)
+}
+
+func TestVerifyWithPolicy_ArtifactDigest(t *testing.T) {
+	r := require.New(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	manifest := descruntime.Digest{HashAlgorithm: crypto.SHA256.String(), NormalisationAlgorithm: ArtifactDigestNormalisation, Value: strings.Repeat("ab", 32)}
+	desc := signedDescriptor(t, "ocm.software/app")
+	desc.Signatures = append(desc.Signatures, descruntime.Signature{
+		Name:      "cosign-1",
+		Digest:    manifest,
+		Signature: descruntime.SignatureInfo{Algorithm: "test", Value: "key-cosign-1", MediaType: "text/plain"},
+	})
+	policy := Policy{Rules: []Rule{{Name: "cosign", Signers: []PolicySigner{signer("cosign-1")}}}}
+
+	_, err := VerifyWithPolicy(t.Context(), desc, policy, logger)
+	r.ErrorIs(err, ErrPolicyNotSatisfied)
+	r.ErrorContains(err, "no artifact digest known")
+
+	other := manifest
+	other.Value = strings.Repeat("cd", 32)
+	_, err = VerifyWithPolicy(WithArtifactDigest(t.Context(), other), desc, policy, logger)
+	r.ErrorIs(err, ErrPolicyNotSatisfied)
+	r.ErrorContains(err, "digest mismatch")
+
+	signatures, err := VerifyWithPolicy(WithArtifactDigest(t.Context(), manifest), desc, policy, logger)
+	r.NoError(err)
+	r.Len(signatures, 1)
+	r.Equal("cosign-1", signatures[0].Name)
+}
+
+func TestVerifyWithPolicy_InvalidPolicy(t *testing.T) {
+	r := require.New(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	desc := signedDescriptor(t, "ocm.software/app", "alice")
+
+	_, err := VerifyWithPolicy(t.Context(), desc, Policy{Rules: []Rule{{Name: "too many", Signers: []PolicySigner{signer("alice")}, Threshold: 2}}}, logger)
+	r.ErrorContains(err, "threshold 2 is not between 0 and the number of signers 1")
+	r.NotErrorIs(err, ErrPolicyNotSatisfied)
+
+	_, err = VerifyWithPolicy(t.Context(), desc, Policy{Rules: []Rule{{Name: "pattern", Components: []string{"["}, Signers: []PolicySigner{signer("alice")}}}}, logger)
+	r.ErrorContains(err, `invalid component pattern "["`)
+
+	_, err = VerifyWithPolicy(t.Context(), desc, Policy{Rules: []Rule{{Name: "twice", Signers: []PolicySigner{signer("alice"), signer("alice")}, Threshold: 2}}}, logger)
+	r.ErrorContains(err, `rule twice: signature "alice" is listed more than once`)
+
+	_, err = VerifyWithPolicy(t.Context(), desc, Policy{Rules: []Rule{{Name: "signer pattern", Signers: []PolicySigner{{Signature: "[", Verifier: keyVerifier{}}}}}}, logger)
+	r.ErrorContains(err, `rule signer pattern: invalid signature pattern "["`)
+
+	_, err = VerifyWithPolicy(t.Context(), desc, Policy{Rules: []Rule{{Name: "digest only", Signers: []PolicySigner{{Signature: "alice"}}}}}, logger)
+	r.ErrorContains(err, `rule digest only: signature "alice" has no verifier`)
+	r.NotErrorIs(err, ErrPolicyNotSatisfied)
+}
+
+func TestVerifyWithPolicy_Status(t *testing.T) {
+	r := require.New(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	var buf bytes.Buffer
+	ctx := status.WithWriter(t.Context(), status.NewWriter(&buf))
+	desc := signedDescriptor(t, "ocm.software/app", "alice")
+
+	_, err := VerifyWithPolicy(ctx, desc, Policy{Rules: []Rule{{Name: "alice", Signers: []PolicySigner{signer("alice")}}}}, logger)
+	r.NoError(err)
+
+	var records []status.Record
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record status.Record
+		r.NoError(dec.Decode(&record))
+		records = append(records, record)
+	}
+	r.NotEmpty(records)
+	last := records[len(records)-1]
+	r.Equal(StatusOperation, last.Operation)
+	r.Equal(StatusStepPolicy, last.Step)
+	r.Equal(desc.Component.ToIdentity().String()+"/alice", last.Item)
+	r.Equal(status.ResultSucceeded, last.Result)
+}
@@ -0,0 +1,34 @@
+package tsa
+
+import (
+	"crypto"
+	"net/http"
+)
+
+// DefaultHash is the default hash algorithm of the message imprint of timestamp requests.
+const DefaultHash = crypto.SHA256
+
+// Options holds configuration options for the Client.
+type Options struct {
+	Client *http.Client
+	Hash   crypto.Hash
+}
+
+// Option is a function that configures Options.
+type Option func(*Options)
+
+// WithHTTPClient sets the HTTP client used to request timestamps.
+// Defaults to http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		o.Client = client
+	}
+}
+
+// WithHash sets the hash algorithm of the message imprint of timestamp requests.
+// Defaults to DefaultHash.
+func WithHash(hash crypto.Hash) Option {
+	return func(o *Options) {
+		o.Hash = hash
+	}
+}
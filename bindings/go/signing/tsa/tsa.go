@@ -0,0 +1,151 @@
+// Package tsa countersigns signatures of component descriptors with RFC 3161 timestamps and verifies them.
+//
+// A timestamp is issued by a time-stamp authority (TSA) for the hash of the value of a signature, as it is stored in
+// the descriptor, and proves that the signature existed at the time stated by the TSA. Verifiers establish this
+// time with Verify and pass it to the signing handler with signing.WithVerificationTime, so that the certificates
+// of the signature are verified at the time of signing. Signatures thereby remain verifiable after their
+// certificates expired.
+//
+// See RFC 3161:
+//   - https://datatracker.ietf.org/doc/html/rfc3161
+package tsa
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/digitorus/timestamp"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+)
+
+const (
+	// MediaTypeTimestampQuery is the media type of timestamp requests.
+	MediaTypeTimestampQuery = "application/timestamp-query"
+	// MediaTypeTimestampReply is the media type of timestamp responses.
+	MediaTypeTimestampReply = "application/timestamp-reply"
+
+	// maxResponseSize limits the size of timestamp responses, which contain the token and the certificates of the TSA.
+	maxResponseSize = 1 << 20
+)
+
+// Client requests timestamps from a time-stamp authority.
+type Client struct {
+	url  string
+	opts Options
+}
+
+// NewClient returns a Client requesting timestamps from the time-stamp authority at url.
+func NewClient(url string, opts ...Option) *Client {
+	options := Options{
+		Client: http.DefaultClient,
+		Hash:   DefaultHash,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &Client{url: url, opts: options}
+}
+
+// Countersign requests a timestamp of the signature and attaches it to the signature.
+// It is called after the signature was created, before it is added to the descriptor.
+func (c *Client) Countersign(ctx context.Context, signature *descruntime.Signature) error {
+	ts, err := c.Timestamp(ctx, signature.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to timestamp signature %s: %w", signature.Name, err)
+	}
+	signature.Timestamp = ts
+	return nil
+}
+
+// Timestamp requests a timestamp of the signature value from the time-stamp authority.
+// The token of the response is checked against the request, including its signature, but its certificates are
+// not verified against trusted roots, which is up to Verify.
+func (c *Client) Timestamp(ctx context.Context, signature descruntime.SignatureInfo) (*descruntime.TimestampSpec, error) {
+	if signature.Value == "" {
+		return nil, errors.New("signature has no value")
+	}
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	query, err := timestamp.CreateRequest(strings.NewReader(signature.Value), &timestamp.RequestOptions{
+		Hash:         c.opts.Hash,
+		Certificates: true,
+		Nonce:        nonce,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create timestamp request: %w", err)
+	}
+
+	reply, err := c.post(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := timestamp.ParseResponse(reply)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+	if ts.Nonce == nil || ts.Nonce.Cmp(nonce) != 0 {
+		return nil, fmt.Errorf("%w: nonce does not match the request", ErrInvalidTimestamp)
+	}
+	if err := checkImprint(ts, signature); err != nil {
+		return nil, err
+	}
+
+	return &descruntime.TimestampSpec{
+		Value: base64.StdEncoding.EncodeToString(ts.RawToken),
+		Time:  ts.Time.UTC(),
+	}, nil
+}
+
+func (c *Client) post(ctx context.Context, query []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(query))
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", MediaTypeTimestampQuery)
+	req.Header.Set("Accept", MediaTypeTimestampReply)
+
+	resp, err := c.opts.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request timestamp: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("time-stamp authority returned status %d", resp.StatusCode)
+	}
+
+	reply, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timestamp response: %w", err)
+	}
+	if len(reply) > maxResponseSize {
+		return nil, fmt.Errorf("timestamp response exceeds %d bytes", maxResponseSize)
+	}
+	return reply, nil
+}
+
+// checkImprint checks that the timestamp was issued for the value of the signature.
+func checkImprint(ts *timestamp.Timestamp, signature descruntime.SignatureInfo) error {
+	if !ts.HashAlgorithm.Available() {
+		return fmt.Errorf("%w: unsupported hash algorithm %s", ErrInvalidTimestamp, ts.HashAlgorithm)
+	}
+	h := ts.HashAlgorithm.New()
+	h.Write([]byte(signature.Value))
+	if !bytes.Equal(h.Sum(nil), ts.HashedMessage) {
+		return fmt.Errorf("%w: message imprint does not match the signature", ErrInvalidTimestamp)
+	}
+	return nil
+}
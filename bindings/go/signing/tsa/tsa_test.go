@@ -0,0 +1,165 @@
+package tsa_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/digitorus/timestamp"
+	"github.com/stretchr/testify/require"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+)
+
+type authority struct {
+	roots *x509.CertPool
+	cert  *x509.Certificate
+	key   crypto.Signer
+	// now is the time the authority states in its timestamps.
+	now time.Time
+}
+
+// newAuthority returns a time-stamp authority with a certificate issued by a root, which is valid from notBefore
+// to notAfter.
+func newAuthority(t *testing.T, notBefore, notAfter time.Time) *authority {
+	t.Helper()
+	r := require.New(t)
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(err)
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test root"},
+		NotBefore:             notBefore.Add(-time.Hour),
+		NotAfter:              notAfter.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	r.NoError(err)
+	root, err := x509.ParseCertificate(rootDER)
+	r.NoError(err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test tsa"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, root, key.Public(), rootKey)
+	r.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	r.NoError(err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	return &authority{roots: roots, cert: cert, key: key, now: notBefore.Add(time.Minute)}
+}
+
+func (a *authority) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil || req.Header.Get("Content-Type") != tsa.MediaTypeTimestampQuery {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	query, err := timestamp.ParseRequest(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	ts := &timestamp.Timestamp{
+		HashAlgorithm:     query.HashAlgorithm,
+		HashedMessage:     query.HashedMessage,
+		Time:              a.now,
+		Nonce:             query.Nonce,
+		Policy:            asn1.ObjectIdentifier{1, 2, 3, 4, 1},
+		AddTSACertificate: query.Certificates,
+	}
+	reply, err := ts.CreateResponseWithOpts(a.cert, a.key, crypto.SHA256)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", tsa.MediaTypeTimestampReply)
+	_, _ = io.Copy(w, bytes.NewReader(reply))
+}
+
+func testSignature() descruntime.Signature {
+	return descruntime.Signature{
+		Name: "test",
+		Signature: descruntime.SignatureInfo{
+			Algorithm: "RSASSA-PSS",
+			Value:     "0123456789abcdef",
+			MediaType: "application/vnd.ocm.signature.rsa",
+		},
+	}
+}
+
+func TestCountersignAndVerify(t *testing.T) {
+	r := require.New(t)
+	now := time.Now().Truncate(time.Second)
+	authority := newAuthority(t, now.Add(-time.Hour), now.Add(time.Hour))
+	server := httptest.NewServer(authority)
+	t.Cleanup(server.Close)
+
+	signature := testSignature()
+	r.NoError(tsa.NewClient(server.URL, tsa.WithHTTPClient(server.Client())).Countersign(t.Context(), &signature))
+	r.NotNil(signature.Timestamp)
+	r.True(authority.now.Equal(signature.Timestamp.Time))
+
+	verified, err := tsa.Verify(t.Context(), signature, tsa.VerifyOptions{Roots: authority.roots})
+	r.NoError(err)
+	r.True(authority.now.Equal(verified))
+
+	t.Run("signature changed", func(t *testing.T) {
+		r := require.New(t)
+		changed := *signature.DeepCopy()
+		changed.Signature.Value = "fedcba9876543210"
+		_, err := tsa.Verify(t.Context(), changed, tsa.VerifyOptions{Roots: authority.roots})
+		r.ErrorIs(err, tsa.ErrInvalidTimestamp)
+		r.ErrorContains(err, "message imprint")
+	})
+
+	t.Run("untrusted authority", func(t *testing.T) {
+		r := require.New(t)
+		other := newAuthority(t, now.Add(-time.Hour), now.Add(time.Hour))
+		_, err := tsa.Verify(t.Context(), signature, tsa.VerifyOptions{Roots: other.roots})
+		r.ErrorIs(err, tsa.ErrInvalidTimestamp)
+	})
+
+	t.Run("missing timestamp", func(t *testing.T) {
+		r := require.New(t)
+		_, err := tsa.Verify(t.Context(), testSignature(), tsa.VerifyOptions{Roots: authority.roots})
+		r.ErrorIs(err, tsa.ErrMissingTimestamp)
+	})
+}
+
+func TestTimestampRejectedByAuthority(t *testing.T) {
+	r := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	signature := testSignature()
+	err := tsa.NewClient(server.URL, tsa.WithHTTPClient(server.Client())).Countersign(t.Context(), &signature)
+	r.ErrorContains(err, "status 503")
+	r.Nil(signature.Timestamp)
+}
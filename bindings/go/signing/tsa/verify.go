@@ -0,0 +1,90 @@
+package tsa
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/digitorus/pkcs7"
+	"github.com/digitorus/timestamp"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
+	"ocm.software/open-component-model/bindings/go/signing"
+)
+
+var (
+	// ErrMissingTimestamp is returned by Verify if the signature has no timestamp.
+	ErrMissingTimestamp = errors.New("signature has no timestamp")
+	// ErrInvalidTimestamp is returned if a timestamp is malformed, not issued for the signature, or not
+	// issued by a trusted time-stamp authority.
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+)
+
+// VerifyOptions configures the verification of timestamps.
+type VerifyOptions struct {
+	// Roots are the trusted root certificates of time-stamp authorities. Required.
+	Roots *x509.CertPool
+	// Intermediates are intermediate certificates of time-stamp authorities that are not embedded in the tokens.
+	Intermediates []*x509.Certificate
+}
+
+// Verify verifies the timestamp of the signature and returns the time stated by the time-stamp authority.
+// The timestamp is valid if it was issued for the value of the signature and signed by a certificate with the
+// time stamping extended key usage, which chains to one of the roots and was valid at the time of the timestamp.
+//
+// Pass the returned time to the verification of the signature with signing.WithVerificationTime.
+// If ctx carries a status.Writer, the verification is recorded as step signing.StatusStepTimestamp of
+// signing.StatusOperation, with the signature name as item.
+func Verify(ctx context.Context, signature descruntime.Signature, opts VerifyOptions) (_ time.Time, err error) {
+	end := status.FromContext(ctx).Start(signing.StatusOperation, signing.StatusStepTimestamp, signature.Name)
+	defer func() { end(err) }()
+
+	if signature.Timestamp == nil {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrMissingTimestamp, signature.Name)
+	}
+	if opts.Roots == nil {
+		return time.Time{}, errors.New("no trusted roots for time-stamp authorities")
+	}
+	token, err := base64.StdEncoding.DecodeString(signature.Timestamp.Value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: failed to decode token: %w", ErrInvalidTimestamp, err)
+	}
+
+	// Parse verifies the signature of the token with its embedded certificate.
+	ts, err := timestamp.Parse(token)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+	if err := checkImprint(ts, signature.Signature); err != nil {
+		return time.Time{}, err
+	}
+
+	p7, err := pkcs7.Parse(token)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+	if len(p7.Certificates) == 0 {
+		return time.Time{}, fmt.Errorf("%w: token does not contain the certificate of the time-stamp authority", ErrInvalidTimestamp)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range p7.Certificates {
+		intermediates.AddCert(cert)
+	}
+	for _, cert := range opts.Intermediates {
+		intermediates.AddCert(cert)
+	}
+	if err := p7.VerifyWithOpts(x509.VerifyOptions{
+		Roots:         opts.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   ts.Time,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}); err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+
+	return ts.Time.UTC(), nil
+}
@@ -0,0 +1,22 @@
+package signing
+
+import (
+	"context"
+	"time"
+)
+
+type verificationTimeKey struct{}
+
+// WithVerificationTime returns a context that instructs Verifier implementations to verify the certificates of
+// a signature at t instead of the current time. It is set after the time of a signature was established by a
+// trusted source, e.g. an RFC 3161 timestamp verified with the tsa package, so that signatures remain verifiable
+// after their certificates expired.
+func WithVerificationTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, verificationTimeKey{}, t)
+}
+
+// VerificationTime returns the time set with WithVerificationTime, if any.
+func VerificationTime(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(verificationTimeKey{}).(time.Time)
+	return t, ok
+}
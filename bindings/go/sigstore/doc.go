@@ -30,6 +30,19 @@
 // on verify under signing/v1alpha1.AlgorithmSigstoreLegacy as an alias for
 // AlgorithmSigstoreV1Alpha1; new signatures always emit AlgorithmSigstoreV1Alpha1.
 //
+// # Cosign Signatures of Container Images
+//
+// Component versions stored in OCI registries can also be signed with standard container tooling,
+// e.g. "cosign sign --registry-referrers-mode=oci-1-1" on the component version manifest. Such
+// signatures are Sigstore bundles with a DSSE envelope holding an in-toto statement whose subject is
+// the manifest digest; the OCI bindings return them as signatures whose digest is this manifest digest
+// (normalisation algorithm ociArtifactDigest/v1).
+//
+// Verify detects DSSE bundles and only accepts them for signatures with normalisation algorithm
+// ociArtifactDigest/v1. It checks that the statement is a cosign signature (predicate type
+// https://sigstore.dev/cosign/sign/v1) with a subject with the digest of the signature, and verifies
+// the bundle with cosign verify-blob-attestation and the same identity constraints as other signatures.
+//
 // # Endpoint Discovery
 //
 // Signing endpoints (Fulcio, Rekor, TSA) are configured via a signing config
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+)
+
+const (
+	// inTotoPayloadType is the DSSE payload type of in-toto statements.
+	inTotoPayloadType = "application/vnd.in-toto+json"
+	// cosignSignPredicateType is the predicate type of the in-toto statements cosign creates when signing
+	// container images. Statements with other predicate types are attestations of other claims about the
+	// subject, e.g. SBOMs or provenance, and are not accepted as signatures.
+	cosignSignPredicateType = "https://sigstore.dev/cosign/sign/v1"
+)
+
+// dsseBundle is the part of a Sigstore bundle that distinguishes DSSE envelopes, as produced by
+// cosign when signing container images, from message signatures, as produced by cosign sign-blob.
+type dsseBundle struct {
+	DSSEEnvelope *struct {
+		Payload     string `json:"payload"`
+		PayloadType string `json:"payloadType"`
+	} `json:"dsseEnvelope"`
+}
+
+type inTotoStatement struct {
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	PredicateType string `json:"predicateType"`
+}
+
+// attestedSubject inspects the Sigstore bundle of a signature. Bundles created by signing container images,
+// e.g. the cosign signatures attached to a component version manifest as OCI referrers, carry a DSSE envelope
+// with an in-toto statement about the signed manifest instead of a signature of the digest itself.
+//
+// Such bundles are only accepted for signatures over the artifact digest (signing.ArtifactDigestNormalisation),
+// a DSSE bundle never signs a normalised descriptor. The statement must be a cosign signature
+// (cosignSignPredicateType) and have a subject with the digest of the signature. The in-toto digest algorithm
+// is returned for verification with cosign verify-blob-attestation. attested is false for message signature bundles.
+func attestedSubject(bundleJSON []byte, dig descruntime.Digest) (algorithm string, attested bool, err error) {
+	var bundle dsseBundle
+	if err := json.Unmarshal(bundleJSON, &bundle); err != nil {
+		return "", false, fmt.Errorf("unmarshal bundle JSON: %w", err)
+	}
+	if bundle.DSSEEnvelope == nil {
+		return "", false, nil
+	}
+	if dig.NormalisationAlgorithm != signing.ArtifactDigestNormalisation {
+		return "", true, fmt.Errorf("DSSE bundles are only accepted for signatures with normalisation algorithm %s, not %q",
+			signing.ArtifactDigestNormalisation, dig.NormalisationAlgorithm)
+	}
+	if bundle.DSSEEnvelope.PayloadType != inTotoPayloadType {
+		return "", true, fmt.Errorf("unsupported DSSE payload type %q, expected %q", bundle.DSSEEnvelope.PayloadType, inTotoPayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(bundle.DSSEEnvelope.Payload)
+	if err != nil {
+		return "", true, fmt.Errorf("decode DSSE payload base64: %w", err)
+	}
+	var statement inTotoStatement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return "", true, fmt.Errorf("unmarshal in-toto statement: %w", err)
+	}
+	if statement.PredicateType != cosignSignPredicateType {
+		return "", true, fmt.Errorf("unsupported in-toto predicate type %q, expected %q", statement.PredicateType, cosignSignPredicateType)
+	}
+
+	// OCM hash algorithms (SHA-256) are named like crypto.Hash, in-toto digests like OCI digests (sha256).
+	algorithm = strings.ToLower(strings.ReplaceAll(dig.HashAlgorithm, "-", ""))
+	for _, subject := range statement.Subject {
+		if value, ok := subject.Digest[algorithm]; ok && strings.EqualFold(value, dig.Value) {
+			return algorithm, true, nil
+		}
+	}
+	return "", true, fmt.Errorf("in-toto statement has no subject with digest %s:%s", algorithm, dig.Value)
+}
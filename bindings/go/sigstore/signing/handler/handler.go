@@ -162,6 +162,10 @@ func (h *Handler) Sign(
 // Verify checks a Sigstore bundle via cosign verify-blob: decodes the bundle and digest,
 // validates the Fulcio certificate chain and Rekor inclusion proof, and confirms the
 // signed content matches the digest using the configured identity/issuer constraints.
+//
+// Bundles with a DSSE envelope, as cosign creates them when signing container images, are verified
+// via cosign verify-blob-attestation instead: their in-toto statement must have a subject with the digest,
+// e.g. the manifest digest of a component version for signatures attached to it as OCI referrers.
 func (h *Handler) Verify(
 	ctx context.Context,
 	signed descruntime.Signature,
@@ -218,6 +222,11 @@ func (h *Handler) Verify(
 		return fmt.Errorf("digest value must not be empty")
 	}
 
+	digestAlgorithm, attested, err := attestedSubject(bundleJSON, signed.Digest)
+	if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+
 	tmpDir, err := os.MkdirTemp(h.tempDir, "cosign-verify-*")
 	if err != nil {
 		return fmt.Errorf("create temp dir for verify: %w", err)
@@ -233,11 +242,6 @@ func (h *Handler) Verify(
 		return fmt.Errorf("resolve trusted root: %w", err)
 	}
 
-	dataPath, err := writeTemp(tmpDir, "data-*", bytes.NewReader(digestBytes))
-	if err != nil {
-		return fmt.Errorf("write verify data to temp file: %w", err)
-	}
-
 	bundlePath, err := writeTemp(tmpDir, "bundle-*.json", bytes.NewReader(bundleJSON))
 	if err != nil {
 		return fmt.Errorf("write bundle to temp file: %w", err)
@@ -274,6 +278,17 @@ func (h *Handler) Verify(
 		"trusted_root", trustedRootPath,
 	)
 
+	if attested {
+		// the bundle attests the subject with the digest, e.g. the manifest of a component version
+		// signed by cosign, so there is no signed blob to verify.
+		return h.runner.VerifyAttestation(ctx, bundlePath, digestAlgorithm, signed.Digest.Value, cosignSignPredicateType, extraArgs, os.Environ())
+	}
+
+	dataPath, err := writeTemp(tmpDir, "data-*", bytes.NewReader(digestBytes))
+	if err != nil {
+		return fmt.Errorf("write verify data to temp file: %w", err)
+	}
+
 	if err := h.runner.Verify(ctx, dataPath, bundlePath, extraArgs, os.Environ()); err != nil {
 		return err
 	}
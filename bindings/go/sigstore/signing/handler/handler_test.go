@@ -31,13 +31,14 @@ import (
 
 // execRecorder captures args and env from ExecCosign calls and optionally writes a bundle file.
 type execRecorder struct {
-	lastSignArgs   []string
-	lastSignEnv    []string
-	lastVerifyArgs []string
-	lastVerifyEnv  []string
-	signErr        error
-	verifyErr      error
-	bundleJSON     []byte
+	lastSignArgs      []string
+	lastSignEnv       []string
+	lastVerifyArgs    []string
+	lastVerifyCommand string
+	lastVerifyEnv     []string
+	signErr           error
+	verifyErr         error
+	bundleJSON        []byte
 }
 
 func (m *execRecorder) exec(_ context.Context, _ string, args, env []string) error {
@@ -57,7 +58,8 @@ func (m *execRecorder) exec(_ context.Context, _ string, args, env []string) err
 			}
 		}
 		return m.signErr
-	case "verify-blob":
+	case "verify-blob", "verify-blob-attestation":
+		m.lastVerifyCommand = args[0]
 		m.lastVerifyArgs = args[1:]
 		m.lastVerifyEnv = env
 		return m.verifyErr
@@ -711,6 +713,116 @@ func TestHandler_Verify(t *testing.T) {
 	}
 }
 
+func TestVerify_Attestation(t *testing.T) {
+	t.Parallel()
+
+	dig := descruntime.Digest{
+		HashAlgorithm:          "SHA-256",
+		NormalisationAlgorithm: "ociArtifactDigest/v1",
+		Value:                  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}
+	attestationBundle := func(t *testing.T, payloadType, predicateType, subjectDigest string) string {
+		t.Helper()
+		statement, err := json.Marshal(map[string]any{
+			"_type": "https://in-toto.io/Statement/v1",
+			"subject": []map[string]any{
+				{"name": "ghcr.io/acme/component-descriptors/ocm.software/test", "digest": map[string]string{"sha256": subjectDigest}},
+			},
+			"predicateType": predicateType,
+			"predicate":     map[string]any{},
+		})
+		require.NoError(t, err)
+		bundle, err := json.Marshal(map[string]any{
+			"mediaType": v1alpha1.MediaTypeSigstoreBundle,
+			"dsseEnvelope": map[string]any{
+				"payload":     base64.StdEncoding.EncodeToString(statement),
+				"payloadType": payloadType,
+			},
+		})
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(bundle)
+	}
+
+	const cosignSign = "https://sigstore.dev/cosign/sign/v1"
+
+	tests := []struct {
+		name          string
+		bundle        func(t *testing.T) string
+		normalisation string
+		wantErr       string
+	}{
+		{
+			name: "subject matches digest",
+			bundle: func(t *testing.T) string {
+				return attestationBundle(t, "application/vnd.in-toto+json", cosignSign, dig.Value)
+			},
+		},
+		{
+			name: "subject does not match digest",
+			bundle: func(t *testing.T) string {
+				return attestationBundle(t, "application/vnd.in-toto+json", cosignSign, testDigest().Value)
+			},
+			wantErr: "in-toto statement has no subject with digest sha256:" + dig.Value,
+		},
+		{
+			name:    "unsupported payload type",
+			bundle:  func(t *testing.T) string { return attestationBundle(t, "text/plain", cosignSign, dig.Value) },
+			wantErr: "unsupported DSSE payload type",
+		},
+		{
+			name: "attestation of another predicate type",
+			bundle: func(t *testing.T) string {
+				return attestationBundle(t, "application/vnd.in-toto+json", "https://spdx.dev/Document", dig.Value)
+			},
+			wantErr: `unsupported in-toto predicate type "https://spdx.dev/Document"`,
+		},
+		{
+			name: "signature of the normalised descriptor",
+			bundle: func(t *testing.T) string {
+				return attestationBundle(t, "application/vnd.in-toto+json", cosignSign, dig.Value)
+			},
+			normalisation: "jsonNormalisation/v4alpha1",
+			wantErr:       "DSSE bundles are only accepted for signatures with normalisation algorithm ociArtifactDigest/v1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r := require.New(t)
+
+			mock := &execRecorder{}
+			h := newWithRunner(mock)
+			digest := dig
+			if tc.normalisation != "" {
+				digest.NormalisationAlgorithm = tc.normalisation
+			}
+			signed := descruntime.Signature{
+				Name:   "cosign-0123456789ab",
+				Digest: digest,
+				Signature: descruntime.SignatureInfo{
+					Algorithm: string(v1alpha1.AlgorithmSigstoreV1Alpha1),
+					MediaType: v1alpha1.MediaTypeSigstoreBundle,
+					Value:     tc.bundle(t),
+				},
+			}
+
+			err := h.Verify(t.Context(), signed, testVerifyConfig(), nil)
+			if tc.wantErr != "" {
+				r.ErrorContains(err, tc.wantErr)
+				r.Nil(mock.lastVerifyArgs, "cosign must not be invoked")
+				return
+			}
+			r.NoError(err)
+			r.Equal("verify-blob-attestation", mock.lastVerifyCommand)
+			r.Equal(dig.Value, flagValue(mock.lastVerifyArgs, "--digest"))
+			r.Equal("sha256", flagValue(mock.lastVerifyArgs, "--digestAlg"))
+			r.Equal(cosignSign, flagValue(mock.lastVerifyArgs, "--type"))
+			r.Equal("user@example.com", flagValue(mock.lastVerifyArgs, "--certificate-identity"))
+			r.NotEmpty(flagValue(mock.lastVerifyArgs, "--bundle"))
+		})
+	}
+}
+
 func TestVerify_MissingIdentity(t *testing.T) {
 	t.Parallel()
 	r := require.New(t)
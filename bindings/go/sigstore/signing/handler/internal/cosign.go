@@ -71,6 +71,21 @@ func (b *CosignBinary) Verify(ctx context.Context, dataPath, bundlePath string,
 	return b.ExecCosign(ctx, path, args, env)
 }
 
+// VerifyAttestation invokes "cosign verify-blob-attestation" with the Sigstore bundle at bundlePath for
+// the subject with the given digest, as cosign attests it when signing container images.
+// Identity and issuer constraints are passed via extraArgs by the caller.
+func (b *CosignBinary) VerifyAttestation(ctx context.Context, bundlePath, digestAlgorithm, digest, predicateType string, extraArgs, env []string) error {
+	path, err := b.resolveBinary(ctx)
+	if err != nil {
+		return fmt.Errorf("resolve cosign binary: %w", err)
+	}
+	args := make([]string, 0, 9+len(extraArgs))
+	args = append(args, "verify-blob-attestation", "--bundle", bundlePath,
+		"--digest", digest, "--digestAlg", digestAlgorithm, "--type", predicateType)
+	args = append(args, extraArgs...)
+	return b.ExecCosign(ctx, path, args, env)
+}
+
 func (b *CosignBinary) resolveBinary(ctx context.Context) (string, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -104,7 +119,7 @@ func (b *CosignBinary) execCosign(ctx context.Context, binaryPath string, args,
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// args[0] is the subcommand (sign-blob/verify-blob); the remaining args are file paths and
+	// args[0] is the subcommand (sign-blob/verify-blob/verify-blob-attestation); the remaining args are file paths and
 	// configuration flags such as --certificate-identity. None of these contain secrets — the OIDC
 	// token is passed through env (SIGSTORE_ID_TOKEN), never argv.
 	slog.DebugContext(ctx, "cosign: invoking subcommand", "subcommand", args[0], "args", args[1:])
@@ -7,6 +7,14 @@ import (
 	"ocm.software/open-component-model/bindings/go/transform/graph/builder"
 )
 
+// StatusOperation is the operation of the status records of transfers.
+// Pass it to builder.Builder.WithStatus to emit machine-readable status records
+// for every transformation of a transfer:
+//
+//	b := transfer.NewDefaultBuilder(repoProvider, resourceRepo, credentialProvider).
+//		WithStatus(status.NewWriter(os.Stdout), transfer.StatusOperation)
+const StatusOperation = "transfer"
+
 // NewDefaultBuilder creates a builder.Builder pre-configured with all standard OCI, CTF, and Helm transformers.
 // It accepts the repository provider, resource repository, and credential resolver interfaces
 // that are needed by the transformers to interact with repositories.
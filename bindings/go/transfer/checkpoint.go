@@ -0,0 +1,21 @@
+package transfer
+
+import (
+	"ocm.software/open-component-model/bindings/go/transfer/internal"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+)
+
+// CheckpointEntries returns the journal entries of the transformations of the graph definition that
+// completed writing their content to the target repository, e.g. added local blobs, OCI artifacts and
+// component versions.
+//
+// Unlike a complete journal, these entries remain valid if the transfer is resumed in another process:
+// they do not refer to data buffered by the failed run. A journal created from them resumes the transfer
+// without copying that content again, and without downloading it from the source again:
+//
+//	j := journal.New(w, transfer.CheckpointEntries(tgd, previous)...)
+//	graph, err := b.WithJournal(j).BuildAndCheck(tgd)
+func CheckpointEntries(tgd *transformv1alpha1.TransformationGraphDefinition, entries []journal.Entry) []journal.Entry {
+	return internal.CheckpointEntries(tgd, entries)
+}
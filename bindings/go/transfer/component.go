@@ -52,6 +52,11 @@ type Mapping struct {
 
 	// Resolver resolves component versions from the source repository.
 	Resolver resolvers.ComponentVersionRepositoryResolver
+
+	// TargetRepository is the repository opened from Target. It is required for delta
+	// transfers to look up the component versions already present in the target, and
+	// ignored otherwise.
+	TargetRepository repository.ComponentVersionRepository
 }
 
 // NewRepositoryResolver wraps a single [repository.ComponentVersionRepository] and its
@@ -0,0 +1,32 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"ocm.software/open-component-model/bindings/go/transfer/internal"
+	graphruntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
+)
+
+// DeltaSummary summarizes a delta transfer: the component versions that are skipped because
+// they are already present in their target, and the ones that are copied, with the known
+// sizes of their resources.
+type DeltaSummary = internal.DeltaSummary
+
+// DeltaSummaryFromEvent returns the summary of a delta transfer if the progress event belongs
+// to the summary transformation of the graph. It returns nil for all other events.
+func DeltaSummaryFromEvent(event graphruntime.ProgressEvent) (*DeltaSummary, error) {
+	t := event.Transformation
+	if t == nil || t.Type != internal.DeltaSummaryVersionedType || t.Spec == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(t.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal transfer delta summary: %w", err)
+	}
+	var summary DeltaSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal transfer delta summary: %w", err)
+	}
+	return &summary, nil
+}
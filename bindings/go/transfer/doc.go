@@ -5,7 +5,7 @@
 // versions (and optionally their resources) from source repositories to target
 // repositories. The graph is then executed using the transform/graph/runtime package.
 //
-// Transfer settings (recursion, copy mode, upload type) are carried by the wire
+// Transfer settings (recursion, copy mode, upload type, resource filter, transfer mode) are carried by the wire
 // format [transferv1alpha1.Config], typically extracted from the central generic
 // configuration with [transferv1alpha1.LookupConfig]. Mappings route source
 // components to target repositories and carry the runtime objects (resolvers,
@@ -35,6 +35,56 @@
 //	    return err
 //	}
 //
+// [Process] combines both steps and delivers the progress of every transformation to a
+// callback. The number of transformations processed in parallel is limited with
+// WithConcurrency on the builder:
+//
+//	err := transfer.Process(ctx, b.WithConcurrency(4), tgd, func(event graphruntime.ProgressEvent) {
+//	    // report progress
+//	})
+//
+// Long-running transfers can record every transformation in a journal with
+// WithJournal on the builder. Re-running the same graph definition with the
+// journal of a failed run skips all transformations that already completed:
+//
+//	j, err := journal.Open("transfer.journal")
+//	if err != nil {
+//	    return err
+//	}
+//	defer j.Close()
+//	graph, err := b.WithJournal(j).BuildAndCheck(tgd)
+//
+// The journal of a failed run refers to blobs buffered in temporary files by that run.
+// Transformations whose buffered blobs were removed since are executed again, so that
+// their consumers can still read the blobs. To resume a transfer in another process, e.g.
+// after a restart, keeping only the checkpoint of the content already written to the target
+// avoids these checks, see [CheckpointEntries].
+//
+// Repeated transfers into the same target can be reduced to the delta with
+// [transferv1alpha1.TransferModeDelta]. The target repository of each mapping is then
+// checked for the component versions before the graph is built, and component versions
+// whose resource and reference digests match the ones in the target are skipped. The
+// savings are reported by a summary transformation, see [DeltaSummaryFromEvent]:
+//
+//	cfg := &transferv1alpha1.Config{Mode: transferv1alpha1.TransferModeDelta}
+//	tgd, err := transfer.BuildGraphDefinition(ctx, cfg,
+//	    transfer.Mapping{
+//	        Components:       components,
+//	        Target:           targetSpec,
+//	        TargetRepository: targetRepo,
+//	        Resolver:         transfer.NewRepositoryResolver(sourceRepo, sourceSpec),
+//	    },
+//	)
+//
+// Component references can be overridden with [transferv1alpha1.Config.Overrides], e.g. to ship a
+// patched child component with unchanged parents. The substitutes are transferred instead of the
+// referenced component versions, and the transferred parents record the original references as
+// deviations in their provenance metadata.
+//
+// Staged delivery of single component versions between repositories, guarded by
+// gates such as signature, label and SBOM checks, is provided by the promotion
+// sub-package.
+//
 // A nil config uses the defaults (no recursion, local blob resources only).
 // Multiple mappings enable N:M routing where different components come from
 // different sources and go to different targets.
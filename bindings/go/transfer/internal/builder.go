@@ -108,6 +108,12 @@ func NewDefaultBuilder(
 		Scheme: transformerScheme,
 	}
 
+	// Transfer delta summary transformer
+	transformerScheme.MustRegisterWithAlias(&DeltaSummaryTransformation{}, DeltaSummaryVersionedType)
+	deltaSummary := &DeltaSummaryReporter{
+		Scheme: transformerScheme,
+	}
+
 	return builder.NewBuilder(transformerScheme).
 		WithTransformer(&ociv1alpha1.OCIGetComponentVersion{}, ociGet).
 		WithTransformer(&ociv1alpha1.OCIAddComponentVersion{}, ociAdd).
@@ -123,5 +129,6 @@ func NewDefaultBuilder(
 		WithTransformer(&helmv1alpha1.GetHelmChart{}, getHelmChart).
 		WithTransformer(&helmv1alpha1.ConvertHelmToOCI{}, convertHelmToOCI).
 		WithTransformer(&wgetv1alpha1.DownloadWgetResource{}, downloadWget).
-		WithTransformer(&FileCleanupTransformation{}, fileCleanup)
+		WithTransformer(&FileCleanupTransformation{}, fileCleanup).
+		WithTransformer(&DeltaSummaryTransformation{}, deltaSummary)
 }
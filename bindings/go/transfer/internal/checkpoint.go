@@ -0,0 +1,47 @@
+package internal
+
+import (
+	ociv1alpha1 "ocm.software/open-component-model/bindings/go/oci/spec/transformation/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+	graphruntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+)
+
+// checkpointTypes are the types of transformations that write content to the target repository
+// (or clean up after that). Their outputs only refer to the target, so they stay valid when a
+// transfer is resumed in another process. The outputs of all other transformations, e.g. of Get
+// transformations buffering blobs to temporary files, do not.
+var checkpointTypes = map[string]struct{}{
+	ociv1alpha1.OCIAddComponentVersionV1alpha1.String(): {},
+	ociv1alpha1.CTFAddComponentVersionV1alpha1.String(): {},
+	ociv1alpha1.OCIAddLocalResourceV1alpha1.String():    {},
+	ociv1alpha1.CTFAddLocalResourceV1alpha1.String():    {},
+	ociv1alpha1.AddOCIArtifactV1alpha1.String():         {},
+	ociv1alpha1.TransferOCIArtifactV1alpha1.String():    {},
+	FileCleanupVersionedType.String():                   {},
+}
+
+// CheckpointEntries returns the journal entries of the transformations of the graph definition that
+// completed writing their content to the target repository.
+func CheckpointEntries(tgd *transformv1alpha1.TransformationGraphDefinition, entries []journal.Entry) []journal.Entry {
+	types := make(map[string]runtime.Type, len(tgd.Transformations))
+	for _, transformation := range tgd.Transformations {
+		types[transformation.ID] = transformation.Type
+	}
+
+	var checkpoint []journal.Entry
+	for _, entry := range entries {
+		if entry.Kind != graphruntime.JournalKindTransformation || entry.Outcome != journal.OutcomeSucceeded || len(entry.Output) == 0 {
+			continue
+		}
+		typ, ok := types[entry.ID]
+		if !ok {
+			continue
+		}
+		if _, ok := checkpointTypes[typ.String()]; ok {
+			checkpoint = append(checkpoint, entry)
+		}
+	}
+	return checkpoint
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+	graphruntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
+)
+
+func TestCheckpointEntries(t *testing.T) {
+	r := require.New(t)
+	sourceRepo := testOCIRepo("ghcr.io/source")
+	targetRepo := testOCIRepo("ghcr.io/target")
+	desc := testDescriptor("ocm.software/test", "1.0.0",
+		[]descriptor.Resource{localBlobResource("my-resource", "1.0.0")}, nil)
+	resolver := testResolverFor("ocm.software/test", "1.0.0", sourceRepo, desc)
+	roots := testTransferRoots("ocm.software/test", "1.0.0", targetRepo, resolver)
+
+	tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{CopyMode: transferv1alpha1.CopyModeLocalBlobResources, UploadType: transferv1alpha1.UploadAsDefault})
+	r.NoError(err)
+	r.Len(tgd.Transformations, 4, "get, add, upload and cleanup")
+	getID, addID, uploadID, cleanupID := tgd.Transformations[0].ID, tgd.Transformations[1].ID, tgd.Transformations[2].ID, tgd.Transformations[3].ID
+
+	output := json.RawMessage(`{"output":{}}`)
+	entry := func(id string, outcome journal.Outcome) journal.Entry {
+		return journal.Entry{Kind: graphruntime.JournalKindTransformation, ID: id, Outcome: outcome, Output: output}
+	}
+	entries := []journal.Entry{
+		entry(getID, journal.OutcomeSucceeded),
+		entry(addID, journal.OutcomeStarted),
+		entry(addID, journal.OutcomeSucceeded),
+		entry(uploadID, journal.OutcomeFailed),
+		entry(cleanupID, journal.OutcomeSucceeded),
+		entry("unknown", journal.OutcomeSucceeded),
+		{Kind: "component", ID: addID, Outcome: journal.OutcomeSucceeded, Output: output},
+		{Kind: graphruntime.JournalKindTransformation, ID: uploadID, Outcome: journal.OutcomeSucceeded},
+	}
+
+	checkpoint := CheckpointEntries(tgd, entries)
+	r.Equal([]journal.Entry{entries[2], entries[4]}, checkpoint,
+		"only succeeded transformations writing to the target with an output are kept")
+}
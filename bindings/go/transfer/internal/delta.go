@@ -0,0 +1,207 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// TargetRepository pairs a target repository specification with the repository opened from it.
+// It is used by delta transfers to look up the content already present in the target.
+type TargetRepository struct {
+	// Spec is the target repository specification, as used in TransferRoot.Targets.
+	Spec runtime.Typed
+	// Repository is the component version repository opened from Spec.
+	Repository repository.ComponentVersionRepository
+}
+
+// deltaChecker decides which component versions of a delta transfer are already present in their
+// target repositories. The versions of a component present in a target are listed once per
+// (component, target) pair and cached, so that checking many versions of the same component is
+// a single request. Only the descriptors of component versions that are listed are fetched to
+// compare their digest manifests.
+type deltaChecker struct {
+	targets     []TargetRepository
+	resolverMap map[string]resolvers.ComponentVersionRepositoryResolver
+	// versions caches the listed versions per target index and component name.
+	versions map[int]map[string]map[string]struct{}
+	summary  DeltaSummary
+}
+
+func newDeltaChecker(targets []TargetRepository, resolverMap map[string]resolvers.ComponentVersionRepositoryResolver) *deltaChecker {
+	return &deltaChecker{
+		targets:     targets,
+		resolverMap: resolverMap,
+		versions:    make(map[int]map[string]map[string]struct{}),
+	}
+}
+
+// present reports whether the component version with the given key is present in the target with
+// the same digest manifest as in the source, so that transferring it again can be skipped.
+// The result is recorded in the summary.
+func (c *deltaChecker) present(ctx context.Context, key string, desc *descruntime.Descriptor, target runtime.Typed) (bool, error) {
+	present, err := c.check(ctx, desc, target)
+	if err != nil {
+		return false, err
+	}
+
+	size, err := c.size(ctx, key, desc)
+	if err != nil {
+		return false, err
+	}
+
+	if present {
+		c.summary.SkippedComponentVersions++
+		c.summary.SkippedBytes += size
+		c.summary.Skipped = append(c.summary.Skipped, key)
+	} else {
+		c.summary.CopiedComponentVersions++
+		c.summary.CopiedBytes += size
+	}
+	return present, nil
+}
+
+func (c *deltaChecker) check(ctx context.Context, desc *descruntime.Descriptor, target runtime.Typed) (bool, error) {
+	component := desc.Component.Name
+	version := desc.Component.Version
+
+	idx := slices.IndexFunc(c.targets, func(t TargetRepository) bool {
+		return RepositoryEqual(t.Spec, target)
+	})
+	if idx < 0 {
+		return false, fmt.Errorf("delta transfer of %s:%s requires the repository of target %T", component, version, target)
+	}
+	repo := c.targets[idx].Repository
+
+	versions, err := c.listVersions(ctx, idx, component)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := versions[version]; !ok {
+		return false, nil
+	}
+
+	existing, err := repo.GetComponentVersion(ctx, component, version)
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot get component version %s:%s from target: %w", component, version, err)
+	}
+
+	want, complete := digestManifest(desc)
+	if !complete {
+		slog.DebugContext(ctx, "component version has resources or references without digest, transferring it again",
+			"component", component, "version", version)
+		return false, nil
+	}
+	got, _ := digestManifest(existing)
+	if !maps.Equal(want, got) {
+		slog.DebugContext(ctx, "component version in target differs from source, transferring it again",
+			"component", component, "version", version)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (c *deltaChecker) listVersions(ctx context.Context, idx int, component string) (map[string]struct{}, error) {
+	byComponent, ok := c.versions[idx]
+	if !ok {
+		byComponent = make(map[string]map[string]struct{})
+		c.versions[idx] = byComponent
+	}
+	if versions, ok := byComponent[component]; ok {
+		return versions, nil
+	}
+
+	listed, err := c.targets[idx].Repository.ListComponentVersions(ctx, component)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("cannot list versions of component %s in target: %w", component, err)
+	}
+	versions := make(map[string]struct{}, len(listed))
+	for _, v := range listed {
+		versions[v] = struct{}{}
+	}
+	byComponent[component] = versions
+	return versions, nil
+}
+
+// size returns the size of the local blob resources of the component version as known from the
+// source repository. Resources without a known size are not counted.
+func (c *deltaChecker) size(ctx context.Context, key string, desc *descruntime.Descriptor) (int64, error) {
+	component := desc.Component.Name
+	version := desc.Component.Version
+
+	var local []runtime.Identity
+	for _, resource := range desc.Component.Resources {
+		if !descriptorv2.IsLocalBlob(resource.Access) {
+			continue
+		}
+		local = append(local, resource.ToIdentity())
+	}
+	if len(local) == 0 {
+		return 0, nil
+	}
+
+	resolver, ok := c.resolverMap[key]
+	if !ok {
+		return 0, nil
+	}
+	repo, err := resolver.GetComponentVersionRepositoryForComponent(ctx, component, version)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get source repository for %s: %w", key, err)
+	}
+
+	var total int64
+	for _, identity := range local {
+		b, _, err := repo.GetLocalResource(ctx, component, version, identity)
+		if err != nil {
+			slog.DebugContext(ctx, "cannot determine size of local resource",
+				"component", component, "version", version, "resource", identity.String(), "error", err)
+			continue
+		}
+		if sizeAware, ok := b.(blob.SizeAware); ok {
+			if size := sizeAware.Size(); size != blob.SizeUnknown {
+				total += size
+			}
+		}
+	}
+	return total, nil
+}
+
+// digestManifest maps the identities of the resources and component references of a component
+// version to their digests. complete is false if any resource or reference has no digest, in which
+// case the content of the component version cannot be compared.
+func digestManifest(desc *descruntime.Descriptor) (manifest map[string]string, complete bool) {
+	manifest = make(map[string]string, len(desc.Component.Resources)+len(desc.Component.References))
+	complete = true
+	for _, resource := range desc.Component.Resources {
+		if resource.Digest == nil || resource.Digest.Value == "" {
+			complete = false
+			continue
+		}
+		manifest["resource:"+resource.ToIdentity().String()] = digestString(*resource.Digest)
+	}
+	for _, ref := range desc.Component.References {
+		if ref.Digest.Value == "" {
+			complete = false
+			continue
+		}
+		manifest["reference:"+ref.ToIdentity().String()] = digestString(ref.Digest)
+	}
+	return manifest, complete
+}
+
+func digestString(d descruntime.Digest) string {
+	return d.HashAlgorithm + "/" + d.NormalisationAlgorithm + "/" + d.Value
+}
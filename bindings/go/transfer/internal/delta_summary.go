@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1/meta"
+)
+
+const (
+	DeltaSummaryType    = "TransferDeltaSummary"
+	deltaSummaryVersion = "v1alpha1"
+	// DeltaSummaryID is the ID of the TransferDeltaSummary transformation in the graph.
+	DeltaSummaryID = "transferDeltaSummary"
+)
+
+// DeltaSummaryVersionedType is the versioned type identifier for TransferDeltaSummary transformations.
+var DeltaSummaryVersionedType = runtime.NewVersionedType(DeltaSummaryType, deltaSummaryVersion)
+
+// DeltaSummary summarizes a delta transfer: the component versions that were already present
+// in their target repository and are skipped, and the ones that are copied.
+// Byte counts only include resources whose size is known from the source repository.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type DeltaSummary struct {
+	// CopiedComponentVersions is the number of (component version, target) pairs that are copied.
+	CopiedComponentVersions int `json:"copiedComponentVersions"`
+	// SkippedComponentVersions is the number of (component version, target) pairs that are skipped,
+	// because the target already contains the component version with the same content.
+	SkippedComponentVersions int `json:"skippedComponentVersions"`
+	// CopiedBytes is the size of the resources of the copied component versions.
+	CopiedBytes int64 `json:"copiedBytes"`
+	// SkippedBytes is the size of the resources of the skipped component versions.
+	SkippedBytes int64 `json:"skippedBytes"`
+	// Skipped lists the skipped component versions in the form "component:version".
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// DeltaSummaryTransformation is a transformation specification that reports the summary of a
+// delta transfer. The summary is computed while building the graph and is carried in the spec,
+// the transformation only echoes it as its output, so that it is surfaced in progress events,
+// status records and journals of the transfer.
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type DeltaSummaryTransformation struct {
+	// +ocm:jsonschema-gen:enum=TransferDeltaSummary/v1alpha1
+	Type   runtime.Type  `json:"type"`
+	ID     string        `json:"id"`
+	Spec   *DeltaSummary `json:"spec"`
+	Output *DeltaSummary `json:"output,omitempty"`
+}
+
+// DeltaSummaryReporter is a transformer that reports the summary of a delta transfer.
+type DeltaSummaryReporter struct {
+	Scheme *runtime.Scheme
+}
+
+func (t *DeltaSummaryReporter) Transform(ctx context.Context, step runtime.Typed) (runtime.Typed, error) {
+	var transformation DeltaSummaryTransformation
+	if err := t.Scheme.Convert(step, &transformation); err != nil {
+		return nil, fmt.Errorf("failed converting generic transformation to transfer delta summary: %w", err)
+	}
+
+	if transformation.Spec == nil {
+		return nil, fmt.Errorf("spec is required for transfer delta summary transformation")
+	}
+	transformation.Output = transformation.Spec.DeepCopy()
+
+	slog.InfoContext(ctx, "delta transfer skips component versions already present in the target",
+		"copiedComponentVersions", transformation.Spec.CopiedComponentVersions,
+		"skippedComponentVersions", transformation.Spec.SkippedComponentVersions,
+		"copiedBytes", transformation.Spec.CopiedBytes,
+		"skippedBytes", transformation.Spec.SkippedBytes)
+
+	return &transformation, nil
+}
+
+// addDeltaSummaryTransformation appends a TransferDeltaSummary transformation to the graph.
+// The transformation has no dependencies, so it is reported as soon as the graph is processed.
+func addDeltaSummaryTransformation(tgd *transformv1alpha1.TransformationGraphDefinition, summary *DeltaSummary) error {
+	rawSummary, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("cannot marshal transfer delta summary: %w", err)
+	}
+	spec := make(map[string]any)
+	if err := json.Unmarshal(rawSummary, &spec); err != nil {
+		return fmt.Errorf("cannot unmarshal transfer delta summary: %w", err)
+	}
+
+	tgd.Transformations = append(tgd.Transformations, transformv1alpha1.GenericTransformation{
+		TransformationMeta: meta.TransformationMeta{
+			Type: DeltaSummaryVersionedType,
+			ID:   DeltaSummaryID,
+		},
+		Spec: &runtime.Unstructured{Data: spec},
+	})
+	return nil
+}
@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+)
+
+// deltaSourceRepo serves local resources with a known size.
+type deltaSourceRepo struct {
+	mockCVRepo
+	content string
+}
+
+func (m *deltaSourceRepo) GetLocalResource(_ context.Context, _, _ string, _ runtime.Identity) (blob.ReadOnlyBlob, *descriptor.Resource, error) {
+	return inmemory.New(strings.NewReader(m.content)), nil, nil
+}
+
+// deltaTargetRepo records the requests of delta checks.
+type deltaTargetRepo struct {
+	mockCVRepo
+	listed map[string][]string
+	lists  int
+	gets   int
+}
+
+func (m *deltaTargetRepo) ListComponentVersions(_ context.Context, component string) ([]string, error) {
+	m.lists++
+	return m.listed[component], nil
+}
+
+func (m *deltaTargetRepo) GetComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, error) {
+	m.gets++
+	return m.mockCVRepo.GetComponentVersion(ctx, component, version)
+}
+
+func digestedLocalBlobResource(name, digest string) descriptor.Resource {
+	res := localBlobResource(name, "1.0.0")
+	res.Digest = &descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "genericBlobDigest/v1", Value: digest}
+	return res
+}
+
+func summaryOf(t *testing.T, tgd *transformv1alpha1.TransformationGraphDefinition) DeltaSummary {
+	t.Helper()
+	r := require.New(t)
+	for _, tr := range tgd.Transformations {
+		if tr.Type != DeltaSummaryVersionedType {
+			continue
+		}
+		r.Equal(DeltaSummaryID, tr.ID)
+		data, err := json.Marshal(tr.Spec)
+		r.NoError(err)
+		var summary DeltaSummary
+		r.NoError(json.Unmarshal(data, &summary))
+		return summary
+	}
+	r.Fail("no delta summary transformation in graph")
+	return DeltaSummary{}
+}
+
+func TestBuildGraphDefinition_Delta(t *testing.T) {
+	sourceSpec := testOCIRepo("ghcr.io/source")
+	targetSpec := testOCIRepo("ghcr.io/target")
+
+	source := func(descs ...*descriptor.Descriptor) *mockCVRepoResolver {
+		repo := &deltaSourceRepo{mockCVRepo: mockCVRepo{descriptors: map[string]*descriptor.Descriptor{}}, content: "hello"}
+		resolver := &mockCVRepoResolver{specs: map[string]runtime.Typed{}, repos: map[string]repository.ComponentVersionRepository{}}
+		for _, d := range descs {
+			key := d.Component.Name + ":" + d.Component.Version
+			repo.descriptors[key] = d
+			resolver.specs[key] = sourceSpec
+			resolver.repos[key] = repo
+		}
+		return resolver
+	}
+	target := func(descs ...*descriptor.Descriptor) *deltaTargetRepo {
+		repo := &deltaTargetRepo{mockCVRepo: mockCVRepo{descriptors: map[string]*descriptor.Descriptor{}}, listed: map[string][]string{}}
+		for _, d := range descs {
+			repo.descriptors[d.Component.Name+":"+d.Component.Version] = d
+			repo.listed[d.Component.Name] = append(repo.listed[d.Component.Name], d.Component.Version)
+		}
+		return repo
+	}
+	roots := func(resolver *mockCVRepoResolver, targetRepo repository.ComponentVersionRepository, keys ...string) map[string]TransferRoot {
+		roots := make(map[string]TransferRoot, len(keys))
+		for _, key := range keys {
+			roots[key] = TransferRoot{
+				RootComponentKey:   key,
+				Targets:            []runtime.Typed{targetSpec},
+				SourceResolver:     resolver,
+				TargetRepositories: []TargetRepository{{Spec: targetSpec, Repository: targetRepo}},
+			}
+		}
+		return roots
+	}
+	cfg := transferv1alpha1.Config{
+		CopyMode:   transferv1alpha1.CopyModeLocalBlobResources,
+		UploadType: transferv1alpha1.UploadAsDefault,
+		Mode:       transferv1alpha1.TransferModeDelta,
+	}
+
+	t.Run("identical component version is skipped", func(t *testing.T) {
+		r := require.New(t)
+		desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{digestedLocalBlobResource("data", "abc")}, nil)
+		targetRepo := target(desc)
+
+		tgd, err := BuildGraphDefinition(t.Context(), roots(source(desc), targetRepo, "ocm.software/test:1.0.0"), cfg)
+		r.NoError(err)
+		r.Len(tgd.Transformations, 1, "only the summary is left")
+		r.Equal(DeltaSummary{
+			SkippedComponentVersions: 1,
+			SkippedBytes:             int64(len("hello")),
+			Skipped:                  []string{"ocm.software/test:1.0.0"},
+		}, summaryOf(t, tgd))
+	})
+
+	t.Run("changed component version is copied", func(t *testing.T) {
+		r := require.New(t)
+		desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{digestedLocalBlobResource("data", "abc")}, nil)
+		changed := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{digestedLocalBlobResource("data", "def")}, nil)
+
+		tgd, err := BuildGraphDefinition(t.Context(), roots(source(desc), target(changed), "ocm.software/test:1.0.0"), cfg)
+		r.NoError(err)
+		r.Len(tgd.Transformations, 5, "get, add, upload, cleanup and summary")
+		r.Equal(DeltaSummary{CopiedComponentVersions: 1, CopiedBytes: int64(len("hello"))}, summaryOf(t, tgd))
+	})
+
+	t.Run("resources without digest are copied", func(t *testing.T) {
+		r := require.New(t)
+		desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{localBlobResource("data", "1.0.0")}, nil)
+
+		tgd, err := BuildGraphDefinition(t.Context(), roots(source(desc), target(desc), "ocm.software/test:1.0.0"), cfg)
+		r.NoError(err)
+		r.Equal(1, summaryOf(t, tgd).CopiedComponentVersions)
+	})
+
+	t.Run("versions are listed once per component", func(t *testing.T) {
+		r := require.New(t)
+		v1 := testDescriptor("ocm.software/test", "1.0.0", nil, nil)
+		v2 := testDescriptor("ocm.software/test", "2.0.0", nil, nil)
+		v3 := testDescriptor("ocm.software/test", "3.0.0", nil, nil)
+		targetRepo := target(v1)
+
+		tgd, err := BuildGraphDefinition(t.Context(),
+			roots(source(v1, v2, v3), targetRepo, "ocm.software/test:1.0.0", "ocm.software/test:2.0.0", "ocm.software/test:3.0.0"), cfg)
+		r.NoError(err)
+		r.Equal(1, targetRepo.lists)
+		r.Equal(1, targetRepo.gets, "only listed versions are fetched")
+		summary := summaryOf(t, tgd)
+		r.Equal(2, summary.CopiedComponentVersions)
+		r.Equal([]string{"ocm.software/test:1.0.0"}, summary.Skipped)
+	})
+
+	t.Run("missing target repository", func(t *testing.T) {
+		r := require.New(t)
+		desc := testDescriptor("ocm.software/test", "1.0.0", nil, nil)
+		roots := testTransferRoots("ocm.software/test", "1.0.0", targetSpec, source(desc))
+
+		_, err := BuildGraphDefinition(t.Context(), roots, cfg)
+		r.ErrorContains(err, "requires the repository of target")
+	})
+
+	t.Run("full mode has no summary", func(t *testing.T) {
+		r := require.New(t)
+		desc := testDescriptor("ocm.software/test", "1.0.0", nil, nil)
+		targetRepo := target(desc)
+		full := cfg
+		full.Mode = transferv1alpha1.TransferModeFull
+
+		tgd, err := BuildGraphDefinition(t.Context(), roots(source(desc), targetRepo, "ocm.software/test:1.0.0"), full)
+		r.NoError(err)
+		r.Len(tgd.Transformations, 1)
+		r.NotEqual(DeltaSummaryVersionedType, tgd.Transformations[0].Type)
+		r.Zero(targetRepo.lists)
+	})
+}
+
+func TestDeltaSummaryReporter(t *testing.T) {
+	r := require.New(t)
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterWithAlias(&DeltaSummaryTransformation{}, DeltaSummaryVersionedType)
+	reporter := &DeltaSummaryReporter{Scheme: scheme}
+
+	summary := &DeltaSummary{CopiedComponentVersions: 1, SkippedComponentVersions: 2, CopiedBytes: 10, SkippedBytes: 20, Skipped: []string{"a:1", "b:1"}}
+	out, err := reporter.Transform(t.Context(), &DeltaSummaryTransformation{
+		Type: DeltaSummaryVersionedType,
+		ID:   DeltaSummaryID,
+		Spec: summary,
+	})
+	r.NoError(err)
+	r.Equal(summary, out.(*DeltaSummaryTransformation).Output)
+
+	_, err = reporter.Transform(t.Context(), &DeltaSummaryTransformation{Type: DeltaSummaryVersionedType, ID: DeltaSummaryID})
+	r.ErrorContains(err, "spec is required")
+}
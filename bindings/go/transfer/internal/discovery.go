@@ -11,6 +11,7 @@ import (
 
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/component/override"
 	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/signing"
@@ -35,6 +36,8 @@ type multiResolver struct {
 	mu             *sync.Mutex // shared with discoverer to protect resolverMap
 	resolverMap    map[string]resolvers.ComponentVersionRepositoryResolver
 	expectedDigest func(id runtime.Identity) *descriptor.Digest
+	// overrides remap the references of resolved descriptors before their children are discovered.
+	overrides *override.Overrides
 }
 
 // Resolve fetches the component descriptor for the given key from the appropriate resolver.
@@ -45,7 +48,9 @@ type multiResolver struct {
 //     it falls back to GetComponentVersionRepositoryForComponent.
 //
 // After fetching the descriptor, it optionally verifies the digest against the expected value
-// recorded during recursive discovery of parent component references.
+// recorded during recursive discovery of parent component references. Afterwards, the configured
+// overrides are applied to the references of the descriptor, so that the substitutes are discovered
+// and transferred instead of the originally referenced component versions.
 func (r *multiResolver) Resolve(ctx context.Context, key string) (*discoveryValue, error) {
 	parts := strings.SplitN(key, ":", 2)
 	if len(parts) != 2 {
@@ -109,6 +114,19 @@ func (r *multiResolver) Resolve(ctx context.Context, key string) (*discoveryValu
 		}
 	}
 
+	desc, deviations, err := r.overrides.Apply(desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed overriding references of component version %s:%s: %w", component, version, err)
+	}
+	for _, deviation := range deviations {
+		slog.InfoContext(ctx, "overriding component reference",
+			"component", component, "version", version,
+			"reference", deviation.Reference.String(),
+			"original", deviation.Component+":"+deviation.Version,
+			"substitute", deviation.SubstituteComponent+":"+deviation.SubstituteVersion,
+			"reason", deviation.Reason)
+	}
+
 	return &discoveryValue{
 		Descriptor:       desc,
 		SourceRepository: repoSpec,
@@ -9,9 +9,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	overridespec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
 	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/component/override"
 	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
@@ -432,3 +434,55 @@ func TestMultiResolver_NilRepoSpec_FallsBackToDirectLookup(t *testing.T) {
 	assert.Equal(t, desc, val.Descriptor)
 	assert.Nil(t, val.SourceRepository, "SourceRepository should be nil when repo spec is nil")
 }
+
+func TestMultiResolver_AppliesOverrides(t *testing.T) {
+	desc := &descriptor.Descriptor{
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{
+				ObjectMeta: descriptor.ObjectMeta{Name: "ocm.software/parent", Version: "1.0.0"},
+			},
+			References: []descriptor.Reference{{
+				ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "child", Version: "1.0.0"}},
+				Component:   "ocm.software/child",
+				Digest:      descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v4alpha1", Value: "abc"},
+			}},
+		},
+	}
+	mockRes := &mockCVRepoResolver{
+		specs: map[string]runtime.Typed{"ocm.software/parent:1.0.0": nil},
+		repos: map[string]repository.ComponentVersionRepository{
+			"ocm.software/parent:1.0.0": &mockCVRepo{descriptors: map[string]*descriptor.Descriptor{"ocm.software/parent:1.0.0": desc}},
+		},
+	}
+	overrides, err := override.New(&overridespec.Config{Overrides: []overridespec.Override{
+		{Component: "ocm.software/child", Substitute: overridespec.Substitute{Version: "1.0.1"}, Reason: "patched"},
+	}})
+	require.NoError(t, err)
+	r := &multiResolver{
+		mu: &sync.Mutex{},
+		resolverMap: map[string]resolvers.ComponentVersionRepositoryResolver{
+			"ocm.software/parent:1.0.0": mockRes,
+		},
+		expectedDigest: func(_ runtime.Identity) *descriptor.Digest { return nil },
+		overrides:      overrides,
+	}
+
+	val, err := r.Resolve(t.Context(), "ocm.software/parent:1.0.0")
+	require.NoError(t, err)
+	ref := val.Descriptor.Component.References[0]
+	assert.Equal(t, "1.0.1", ref.Version)
+	assert.Empty(t, ref.Digest.Value, "the digest of the original reference must not be pinned for the substitute")
+	assert.Equal(t, "1.0.0", desc.Component.References[0].Version, "the resolved descriptor must not be modified")
+
+	disc := &discoverer{recursive: -1, discoveredDigests: map[string]descriptor.Digest{}}
+	children, err := disc.Discover(t.Context(), val)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ocm.software/child:1.0.1"}, children)
+	assert.Empty(t, disc.discoveredDigests)
+
+	deviations, err := override.Deviations(&val.Descriptor.Component)
+	require.NoError(t, err)
+	require.Len(t, deviations, 1)
+	assert.Equal(t, "1.0.0", deviations[0].Version)
+	assert.Equal(t, "patched", deviations[0].Reason)
+}
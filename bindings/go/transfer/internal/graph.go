@@ -5,14 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
 
+	overridespec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
 	"ocm.software/open-component-model/bindings/go/dag"
 	dagsync "ocm.software/open-component-model/bindings/go/dag/sync"
 	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime/selector"
 	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	helmv1 "ocm.software/open-component-model/bindings/go/helm/spec/access/v1"
 	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
 	"ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
+	"ocm.software/open-component-model/bindings/go/repository/component/override"
 	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
@@ -47,6 +51,10 @@ type TransferRoot struct {
 	// SourceResolver resolves this root's component version from its source repository.
 	// One resolver per component — see the struct doc for the design rationale.
 	SourceResolver resolvers.ComponentVersionRepositoryResolver
+	// TargetRepositories are the opened repositories of Targets. They are only needed for
+	// delta transfers, which look up the content already present in the targets. Recursively
+	// discovered children use the repositories of all roots, matched by their spec.
+	TargetRepositories []TargetRepository
 }
 
 // BuildGraphDefinition constructs a [transformv1alpha1.TransformationGraphDefinition] for
@@ -80,10 +88,12 @@ func BuildGraphDefinition(
 	targetMap := make(map[string][]runtime.Typed)
 	resolverMap := make(map[string]resolvers.ComponentVersionRepositoryResolver)
 	dagRoots := make([]string, 0, len(roots))
+	var targetRepos []TargetRepository
 	for key, root := range roots {
 		dagRoots = append(dagRoots, key)
 		targetMap[key] = AppendUniqueRepositories(targetMap[key], root.Targets)
 		resolverMap[key] = root.SourceResolver
+		targetRepos = append(targetRepos, root.TargetRepositories...)
 	}
 
 	disc := &discoverer{
@@ -92,6 +102,13 @@ func BuildGraphDefinition(
 		targetMap:         targetMap,
 		resolverMap:       resolverMap,
 	}
+
+	// The overrides remap references of the discovered components to substitute component versions.
+	overrides, err := override.New(&overridespec.Config{Overrides: cfg.Overrides})
+	if err != nil {
+		return nil, err
+	}
+
 	// The multiResolver delegates to per-component resolvers from resolverMap.
 	// The expectedDigest closure checks whether a recursively discovered child has
 	// a pinned digest from its parent's reference, enabling integrity verification.
@@ -110,6 +127,7 @@ func BuildGraphDefinition(
 			}
 			return &dig
 		},
+		overrides: overrides,
 	}
 
 	slog.DebugContext(ctx, "starting component discovery",
@@ -133,15 +151,28 @@ func BuildGraphDefinition(
 		},
 	}
 
+	// In delta mode, the content already present in the targets is checked while walking the DAG.
+	var delta *deltaChecker
+	if cfg.Mode == transferv1alpha1.TransferModeDelta {
+		delta = newDeltaChecker(targetRepos, resolverMap)
+	}
+
 	// Phase 2: walk the discovered DAG and generate transformation nodes per (component, target) pair.
 	g := dr.Graph()
-	err := g.WithReadLock(func(d *dag.DirectedAcyclicGraph[string]) error {
-		return fillGraphDefinitionWithPrefetchedComponents(ctx, d, targetMap, tgd, cfg.CopyMode, cfg.UploadType)
+	err = g.WithReadLock(func(d *dag.DirectedAcyclicGraph[string]) error {
+		return fillGraphDefinitionWithPrefetchedComponents(ctx, d, targetMap, resolverMap, tgd, cfg.CopyMode, cfg.UploadType, cfg.Resources, cfg.DigestPinning, delta)
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if delta != nil {
+		slices.Sort(delta.summary.Skipped)
+		if err := addDeltaSummaryTransformation(tgd, &delta.summary); err != nil {
+			return nil, err
+		}
+	}
+
 	return tgd, nil
 }
 
@@ -157,14 +188,19 @@ func BuildGraphDefinition(
 //
 // When a component has multiple targets, transformation IDs are suffixed (e.g., "T0", "T1")
 // to ensure uniqueness in the DAG. The environment descriptor is shared across targets
-// since it's source-side data.
+// since it's source-side data. If delta is set, targets that already contain the component
+// version with the same content are skipped.
 func fillGraphDefinitionWithPrefetchedComponents(
 	ctx context.Context,
 	d *dag.DirectedAcyclicGraph[string],
 	targetMap map[string][]runtime.Typed,
+	resolverMap map[string]resolvers.ComponentVersionRepositoryResolver,
 	tgd *transformv1alpha1.TransformationGraphDefinition,
 	copyMode transferv1alpha1.CopyMode,
 	uploadType transferv1alpha1.UploadType,
+	filter *selector.ResourceFilter,
+	digestPinning transferv1alpha1.DigestPinning,
+	delta *deltaChecker,
 ) error {
 	slog.DebugContext(ctx, "building transformations for discovered components",
 		"components", len(d.Vertices))
@@ -186,6 +222,14 @@ func fillGraphDefinitionWithPrefetchedComponents(
 			return fmt.Errorf("cannot convert to v2: %w", err)
 		}
 
+		if err := migrateResourceAccesses(ctx, v2desc); err != nil {
+			return err
+		}
+
+		if err := pinResourceAccesses(ctx, v2desc, digestPinning, sourceDigestResolver(resolverMap[key], component, version)); err != nil {
+			return err
+		}
+
 		if err := addDescriptorToEnvironment(v2desc, baseID, tgd); err != nil {
 			return err
 		}
@@ -202,12 +246,25 @@ func fillGraphDefinitionWithPrefetchedComponents(
 				id = fmt.Sprintf("%sT%d", baseID, targetIdx)
 			}
 
+			if delta != nil {
+				present, err := delta.present(ctx, key, val.Descriptor, target)
+				if err != nil {
+					return fmt.Errorf("cannot check target for component version %s: %w", key, err)
+				}
+				if present {
+					slog.DebugContext(ctx, "skipping component version already present in target",
+						"component", component, "version", version,
+						"targetIndex", targetIdx, "targetType", fmt.Sprintf("%T", target))
+					continue
+				}
+			}
+
 			slog.DebugContext(ctx, "generating transformations for target",
 				"component", component, "version", version,
 				"targetIndex", targetIdx, "targetType", fmt.Sprintf("%T", target),
 				"transformID", id)
 
-			resourceTransformIDs, fileRefs, err := processResources(ctx, v2desc, id, val, tgd, target, copyMode, uploadType)
+			resourceTransformIDs, fileRefs, err := processResources(ctx, v2desc, id, val, tgd, target, copyMode, uploadType, filter, digestPinning != "")
 			if err != nil {
 				return err
 			}
@@ -226,6 +283,7 @@ func fillGraphDefinitionWithPrefetchedComponents(
 
 // processResources iterates over resources in a v2 descriptor and creates the appropriate
 // get/add transformation pairs based on access type, copy mode, and upload type.
+// Resources that are not local blobs are only copied if they are selected by the filter.
 // It returns CEL spec-field expressions for all Get transformations that buffer content to disk.
 func processResources(
 	ctx context.Context,
@@ -236,6 +294,8 @@ func processResources(
 	toSpec runtime.Typed,
 	copyMode transferv1alpha1.CopyMode,
 	uploadType transferv1alpha1.UploadType,
+	filter *selector.ResourceFilter,
+	pinDigests bool,
 ) (map[int]string, []string, error) {
 	component := val.Descriptor.Component.Name
 	version := val.Descriptor.Component.Version
@@ -243,12 +303,9 @@ func processResources(
 	var fileExpressions []string
 
 	for i, resource := range v2desc.Component.Resources {
-		access, err := scheme.NewObject(resource.Access.Type)
+		access, err := scheme.NewObjectFrom(resource.Access)
 		if err != nil {
-			return nil, nil, fmt.Errorf("cannot create new object for resource access type %q: %w", resource.Access.Type.String(), err)
-		}
-		if err := scheme.Convert(resource.Access, access); err != nil {
-			return nil, nil, fmt.Errorf("cannot convert resource access to typed object: %w", err)
+			return nil, nil, fmt.Errorf("cannot convert resource access of type %q to typed object: %w", resource.Access.Type.String(), err)
 		}
 
 		if copyMode == transferv1alpha1.CopyModeLocalBlobResources && !descriptorv2.IsLocalBlob(access) {
@@ -256,7 +313,23 @@ func processResources(
 			continue
 		}
 
-		exprs, err := processResource(resource, access, id, val, tgd, toSpec, resourceTransformIDs, i, uploadType)
+		// local blobs are always copied, their content is stored with the component version.
+		if filter != nil && !descriptorv2.IsLocalBlob(access) {
+			selected, err := filter.Matches(ctx, descruntime.ConvertFromV2Resource(&resource), nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot evaluate resource filter for resource %q: %w", resource.ToIdentity().String(), err)
+			}
+			if !selected {
+				slog.DebugContext(ctx, "Skipping copy of resource since it is not selected by the resource filter.",
+					"component", component,
+					"version", version,
+					"resource", resource.ToIdentity().String(),
+					"accessType", resource.Access.Type.String())
+				continue
+			}
+		}
+
+		exprs, err := processResource(resource, access, id, val, tgd, toSpec, resourceTransformIDs, i, uploadType, pinDigests)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -265,6 +338,34 @@ func processResources(
 	return resourceTransformIDs, fileExpressions, nil
 }
 
+// migrateResourceAccesses replaces resource accesses of deprecated types in the descriptor with their
+// replacement, so that both the transformations and the transferred descriptor use the replacement.
+// Accesses are not part of the normalized descriptor, so the migration does not invalidate signatures.
+func migrateResourceAccesses(ctx context.Context, v2desc *descriptorv2.Descriptor) error {
+	for i, resource := range v2desc.Component.Resources {
+		if resource.Access == nil {
+			continue
+		}
+		migrated, ok, err := scheme.Migrate(resource.Access)
+		if err != nil {
+			return fmt.Errorf("cannot migrate access of resource %q: %w", resource.ToIdentity().String(), err)
+		}
+		if !ok {
+			continue
+		}
+		var raw runtime.Raw
+		if err := scheme.Convert(migrated, &raw); err != nil {
+			return fmt.Errorf("cannot convert migrated access of resource %q: %w", resource.ToIdentity().String(), err)
+		}
+		slog.DebugContext(ctx, "migrated deprecated resource access type",
+			"component", v2desc.Component.Name, "version", v2desc.Component.Version,
+			"resource", resource.ToIdentity().String(),
+			"from", resource.Access.Type.String(), "to", raw.Type.String())
+		v2desc.Component.Resources[i].Access = &raw
+	}
+	return nil
+}
+
 func logSkippedResource(ctx context.Context, component, version string, resource descriptorv2.Resource, copyMode transferv1alpha1.CopyMode, uploadType transferv1alpha1.UploadType) {
 	logLevel := slog.LevelDebug
 	if uploadType == transferv1alpha1.UploadAsOciArtifact {
@@ -286,7 +387,7 @@ func logSkippedResource(ctx context.Context, component, version string, resource
 // target repository. wget resources are always downloaded and embedded as local blobs.
 // It returns CEL spec-field expressions for the file buffers produced, referencing consumer spec
 // fields (not producer outputs) so the DAG edge points from consumer to the cleanup node.
-func processResource(resource descriptorv2.Resource, access runtime.Typed, id string, val *discoveryValue, tgd *transformv1alpha1.TransformationGraphDefinition, toSpec runtime.Typed, resourceTransformIDs map[int]string, i int, uploadType transferv1alpha1.UploadType) ([]string, error) {
+func processResource(resource descriptorv2.Resource, access runtime.Typed, id string, val *discoveryValue, tgd *transformv1alpha1.TransformationGraphDefinition, toSpec runtime.Typed, resourceTransformIDs map[int]string, i int, uploadType transferv1alpha1.UploadType, pinDigests bool) ([]string, error) {
 	_, isOCITarget := toSpec.(*oci.Repository)
 	uploadAsArtifact := isOCITarget && uploadType == transferv1alpha1.UploadAsOciArtifact
 
@@ -302,7 +403,7 @@ func processResource(resource descriptorv2.Resource, access runtime.Typed, id st
 		}
 		return []string{fmt.Sprintf("${%s.spec.file}", addResourceID)}, nil
 	case *ociv1.OCIImage:
-		if err := processOCIArtifact(resource, id, val, tgd, toSpec, resourceTransformIDs, i, uploadAsArtifact); err != nil {
+		if err := processOCIArtifact(resource, id, val, tgd, toSpec, resourceTransformIDs, i, uploadAsArtifact, pinDigests); err != nil {
 			return nil, fmt.Errorf("cannot process OCI artifact resource: %w", err)
 		}
 		// Streaming path (TransferOCIArtifact) produces no temp file — skip cleanup.
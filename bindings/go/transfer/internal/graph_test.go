@@ -1,13 +1,17 @@
 package internal
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime/selector"
 	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	helmv1 "ocm.software/open-component-model/bindings/go/helm/spec/access/v1"
 	helmv1alpha1 "ocm.software/open-component-model/bindings/go/helm/transformation/spec/v1alpha1"
@@ -226,6 +230,75 @@ func TestBuildGraphDefinition_OCIImageUploadAsOCIArtifact(t *testing.T) {
 	assert.Nil(t, findCleanupTransformation(tgd), "streaming OCI path should produce no FileCleanup node")
 }
 
+// resolvingCVRepo is a source repository that resolves the tags of OCI artifact accesses.
+type resolvingCVRepo struct {
+	*mockCVRepo
+	digests map[string]digest.Digest
+}
+
+func (m *resolvingCVRepo) ResolveDigest(_ context.Context, imageReference string) (digest.Digest, error) {
+	dig, ok := m.digests[imageReference]
+	if !ok {
+		return "", fmt.Errorf("tag %s not found", imageReference)
+	}
+	return dig, nil
+}
+
+func TestBuildGraphDefinition_OCIImageDigestPinning(t *testing.T) {
+	const dig digest.Digest = "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	withResourceDigest := ociImageResource("by-tag", "1.0.0", "ghcr.io/org/image:v1")
+	withResourceDigest.Digest = &descriptor.Digest{
+		HashAlgorithm:          "SHA-256",
+		NormalisationAlgorithm: "genericBlobDigest/v1",
+		Value:                  dig.Encoded(),
+	}
+
+	tests := []struct {
+		name          string
+		resource      descriptor.Resource
+		digestPinning transferv1alpha1.DigestPinning
+		resolves      bool
+		want          string
+		err           string
+	}{
+		{"digest of the image reference", ociImageResource("by-reference", "1.0.0", "ghcr.io/org/image:v1@"+dig.String()), transferv1alpha1.DigestPinningEnforce, false, "ghcr.io/target/org/image:v1@" + dig.String(), ""},
+		{"tag resolved in the source registry", withResourceDigest, transferv1alpha1.DigestPinningResolve, true, "ghcr.io/target/org/image:v1@" + dig.String(), ""},
+		{"source repository cannot resolve tags", withResourceDigest, transferv1alpha1.DigestPinningResolve, false, "", "cannot resolve tags"},
+		{"no pinning", withResourceDigest, "", false, "ghcr.io/target/org/image:v1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{tt.resource}, nil)
+			resolver := testResolverFor("ocm.software/test", "1.0.0", testOCIRepo("ghcr.io/source"), desc)
+			if tt.resolves {
+				for key, repo := range resolver.repos {
+					resolver.repos[key] = &resolvingCVRepo{mockCVRepo: repo.(*mockCVRepo), digests: map[string]digest.Digest{"ghcr.io/org/image:v1": dig}}
+				}
+			}
+			roots := testTransferRoots("ocm.software/test", "1.0.0", testOCIRepo("ghcr.io/target"), resolver)
+
+			tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+				CopyMode:      transferv1alpha1.CopyModeAllResources,
+				UploadType:    transferv1alpha1.UploadAsOciArtifact,
+				DigestPinning: tt.digestPinning,
+			})
+			if tt.err != "" {
+				r.ErrorContains(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(ociv1alpha1.TransferOCIArtifactV1alpha1, tgd.Transformations[0].Type)
+
+			targetResource, ok := tgd.Transformations[0].Spec.Data["targetResource"].(map[string]any)
+			r.True(ok)
+			access, ok := targetResource["access"].(map[string]any)
+			r.True(ok)
+			r.Equal(tt.want, access["imageReference"])
+		})
+	}
+}
+
 func TestBuildGraphDefinition_HelmResource(t *testing.T) {
 	sourceRepo := testOCIRepo("ghcr.io/source")
 	targetRepo := testOCIRepo("ghcr.io/target")
@@ -242,6 +315,46 @@ func TestBuildGraphDefinition_HelmResource(t *testing.T) {
 	assert.Equal(t, helmv1alpha1.ConvertHelmToOCIV1alpha1, tgd.Transformations[1].Type)
 }
 
+func TestBuildGraphDefinition_ResourceFilter(t *testing.T) {
+	sourceRepo := testOCIRepo("ghcr.io/source")
+	targetRepo := testOCIRepo("ghcr.io/target")
+	desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		localBlobResource("my-resource", "1.0.0"),
+		ociImageResource("my-image", "1.0.0", "oci://ghcr.io/org/image:v1"),
+		helmResource("my-chart", "1.0.0", "https://charts.example.com", "my-chart"),
+	}, nil)
+	resolver := testResolverFor("ocm.software/test", "1.0.0", sourceRepo, desc)
+	roots := testTransferRoots("ocm.software/test", "1.0.0", targetRepo, resolver)
+
+	tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+		CopyMode:   transferv1alpha1.CopyModeAllResources,
+		UploadType: transferv1alpha1.UploadAsDefault,
+		Resources: &selector.ResourceFilter{
+			Include: []selector.ResourceSelector{{Types: []string{"helmChart"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	// the local blob is copied although it is not selected, the OCI image is skipped.
+	types := make([]runtime.Type, 0, len(tgd.Transformations))
+	for _, transformation := range tgd.Transformations {
+		types = append(types, transformation.Type)
+	}
+	assert.Len(t, types, 7)
+	assert.Contains(t, types, ociv1alpha1.OCIGetLocalResourceV1alpha1)
+	assert.Contains(t, types, helmv1alpha1.GetHelmChartV1alpha1)
+	assert.NotContains(t, types, ociv1alpha1.GetOCIArtifactV1alpha1)
+
+	_, err = BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+		CopyMode:   transferv1alpha1.CopyModeAllResources,
+		UploadType: transferv1alpha1.UploadAsDefault,
+		Resources: &selector.ResourceFilter{
+			Exclude: []selector.ResourceSelector{{MinSize: new(int64)}},
+		},
+	})
+	require.ErrorIs(t, err, selector.ErrUnknownSize)
+}
+
 func TestBuildGraphDefinition_CTFTarget(t *testing.T) {
 	sourceRepo := testOCIRepo("ghcr.io/source")
 	targetRepo := testCTFRepo("/tmp/target-archive")
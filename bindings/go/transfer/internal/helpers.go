@@ -39,11 +39,8 @@ func convertToConcreteRepo(repo runtime.Typed) (runtime.Typed, error) {
 	case *oci.Repository, *ctfv1.Repository:
 		return repo, nil
 	case *runtime.Raw:
-		obj, err := scheme.NewObject(r.Type)
+		obj, err := r.Decode(scheme)
 		if err != nil {
-			return nil, fmt.Errorf("cannot create object for type %s: %w", r.Type, err)
-		}
-		if err := scheme.Convert(r, obj); err != nil {
 			return nil, fmt.Errorf("cannot convert raw to concrete type: %w", err)
 		}
 		return obj, nil
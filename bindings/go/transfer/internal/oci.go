@@ -1,23 +1,31 @@
 package internal
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 
+	"github.com/opencontainers/go-digest"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
 	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
 	ocirepo "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
 	ociv1alpha1 "ocm.software/open-component-model/bindings/go/oci/spec/transformation/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
 	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1/meta"
 )
 
-func processOCIArtifact(resource descriptorv2.Resource, id string, val *discoveryValue, tgd *transformv1alpha1.TransformationGraphDefinition, toSpec runtime.Typed, resourceTransformIDs map[int]string, i int, uploadAsOCIArtifact bool) error {
+func processOCIArtifact(resource descriptorv2.Resource, id string, val *discoveryValue, tgd *transformv1alpha1.TransformationGraphDefinition, toSpec runtime.Typed, resourceTransformIDs map[int]string, i int, uploadAsOCIArtifact, pinDigests bool) error {
 	if uploadAsOCIArtifact {
 		var ociTarget ocirepo.Repository
 		if err := scheme.Convert(toSpec, &ociTarget); err == nil {
-			return processOCIArtifactStreaming(resource, id, tgd, toSpec, resourceTransformIDs, i)
+			return processOCIArtifactStreaming(resource, id, tgd, toSpec, resourceTransformIDs, i, pinDigests)
 		}
 		// toSpec is not an OCI repository — fall through to the legacy Get+Add path.
 	}
@@ -102,7 +110,8 @@ func imageReferenceFromAccess(id string) referenceNameOption {
 
 // processOCIArtifactStreaming emits a single TransferOCIArtifact node that streams
 // the OCI artifact directly from source to target without tar materialization.
-func processOCIArtifactStreaming(resource descriptorv2.Resource, id string, tgd *transformv1alpha1.TransformationGraphDefinition, toSpec runtime.Typed, resourceTransformIDs map[int]string, i int) error {
+// If pinDigests is set, the target image reference is pinned to the digest of the artifact if it is known.
+func processOCIArtifactStreaming(resource descriptorv2.Resource, id string, tgd *transformv1alpha1.TransformationGraphDefinition, toSpec runtime.Typed, resourceTransformIDs map[int]string, i int, pinDigests bool) error {
 	resourceIdentity := resource.ToIdentity()
 	resourceID := identityToTransformationID(resourceIdentity)
 	transferID := fmt.Sprintf("%sTransfer%s", id, resourceID)
@@ -126,6 +135,16 @@ func processOCIArtifactStreaming(resource descriptorv2.Resource, id string, tgd
 		targetRepoBaseURL = targetRepoBaseURL + "/" + ociSpec.SubPath
 	}
 	targetImageReference := staticReferenceName(referenceName)(targetRepoBaseURL)
+	if pinDigests {
+		pinned, err := pinning.Digest(ociAccess.ImageReference)
+		if err != nil {
+			return err
+		}
+		// the source accesses are pinned before, unless they cannot be resolved to a digest.
+		if pinned != "" {
+			targetImageReference += "@" + pinned.String()
+		}
+	}
 
 	targetResource := map[string]any{
 		"name":     resource.Name,
@@ -172,6 +191,71 @@ func processOCIArtifactStreaming(resource descriptorv2.Resource, id string, tgd
 	return nil
 }
 
+// pinResourceAccesses applies the digest pinning policy to the OCI artifact accesses of the resources in the
+// descriptor, so that both the transformations and the transferred descriptor use the pinned accesses.
+// With [transferv1alpha1.DigestPinningEnforce], an access referencing a tag without a digest fails the transfer.
+// With [transferv1alpha1.DigestPinningResolve], its tag is resolved with resolver, and the access is pinned to
+// the resolved digest if it matches the digest of the resource. The original reference is recorded in the label
+// [pinning.LabelName]. Neither accesses nor non-signing labels are part of the normalised descriptor, so pinning
+// does not invalidate signatures.
+func pinResourceAccesses(ctx context.Context, v2desc *descriptorv2.Descriptor, digestPinning transferv1alpha1.DigestPinning, resolver pinning.Resolver) error {
+	var policy pinning.Policy
+	switch digestPinning {
+	case "":
+		return nil
+	case transferv1alpha1.DigestPinningEnforce:
+		policy = pinning.PolicyEnforce
+	case transferv1alpha1.DigestPinningResolve:
+		policy = pinning.PolicyResolve
+	default:
+		return fmt.Errorf("unknown digest pinning %q", digestPinning)
+	}
+
+	for i, resource := range v2desc.Component.Resources {
+		if resource.Access == nil {
+			continue
+		}
+		res := descruntime.ConvertFromV2Resource(&resource)
+		pinned, err := pinning.Apply(ctx, res, policy, resolver)
+		if err != nil {
+			return fmt.Errorf("cannot transfer resource %q of component version %s:%s: %w",
+				resource.ToIdentity().String(), v2desc.Component.Name, v2desc.Component.Version, err)
+		}
+		if pinned == res {
+			continue
+		}
+		converted, err := descruntime.ConvertToV2Resource(scheme, pinned)
+		if err != nil {
+			return fmt.Errorf("cannot convert pinned resource %q: %w", resource.ToIdentity().String(), err)
+		}
+		slog.DebugContext(ctx, "pinned OCI artifact access to digest",
+			"component", v2desc.Component.Name, "version", v2desc.Component.Version,
+			"resource", resource.ToIdentity().String())
+		v2desc.Component.Resources[i] = *converted
+	}
+	return nil
+}
+
+// sourceDigestResolver returns a resolver for the tags of OCI artifact accesses that uses the source repository
+// of the component version, if it can resolve them, e.g. an OCI registry. The source repository is only opened
+// if a tag has to be resolved.
+func sourceDigestResolver(resolver resolvers.ComponentVersionRepositoryResolver, component, version string) pinning.Resolver {
+	return pinning.ResolverFunc(func(ctx context.Context, imageReference string) (digest.Digest, error) {
+		if resolver == nil {
+			return "", fmt.Errorf("no source repository known for component version %s:%s", component, version)
+		}
+		repo, err := resolver.GetComponentVersionRepositoryForComponent(ctx, component, version)
+		if err != nil {
+			return "", fmt.Errorf("cannot get source repository for component version %s:%s: %w", component, version, err)
+		}
+		digestResolver, ok := repo.(pinning.Resolver)
+		if !ok {
+			return "", fmt.Errorf("source repository of component version %s:%s cannot resolve tags", component, version)
+		}
+		return digestResolver.ResolveDigest(ctx, imageReference)
+	})
+}
+
 func ociUploadAsArtifact(toSpec runtime.Typed, addResourceID string, getResourceID string, referenceName referenceNameOption) (transformv1alpha1.GenericTransformation, error) {
 	var ociSpec ocirepo.Repository
 	if err := scheme.Convert(toSpec, &ociSpec); err != nil {
@@ -1,13 +1,21 @@
 package internal
 
 import (
+	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
+	godigest "github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
+	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
 	"ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
 )
 
 func TestStaticReferenceName_EmptyBaseURL(t *testing.T) {
@@ -62,3 +70,77 @@ func TestOciUploadAsArtifact_NoSubPath(t *testing.T) {
 	access := resource["access"].(map[string]interface{})
 	assert.Equal(t, "ghcr.io/my/image:v1", access["imageReference"])
 }
+
+func TestPinResourceAccesses(t *testing.T) {
+	const digest = "0f3f5b9a2d7c4e1b8a6f0c3d2e1b4a5f6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f"
+	newDescriptor := func(withDigest bool) *descriptorv2.Descriptor {
+		resource := descriptorv2.Resource{
+			ElementMeta: descriptorv2.ElementMeta{ObjectMeta: descriptorv2.ObjectMeta{Name: "image", Version: "1.0.0"}},
+			Type:        "ociImage",
+			Relation:    descriptorv2.ExternalRelation,
+			Access: &runtime.Raw{
+				Type: runtime.NewVersionedType(ociv1.LegacyType, ociv1.LegacyTypeVersion),
+				Data: []byte(`{"type":"ociArtifact/v1","imageReference":"ghcr.io/acme/app:1.0.0"}`),
+			},
+		}
+		if withDigest {
+			resource.Digest = &descriptorv2.Digest{
+				HashAlgorithm:          "SHA-256",
+				NormalisationAlgorithm: "genericBlobDigest/v1",
+				Value:                  digest,
+			}
+		}
+		desc := &descriptorv2.Descriptor{}
+		desc.Component.Name = "ocm.software/app"
+		desc.Component.Version = "1.0.0"
+		desc.Component.Resources = []descriptorv2.Resource{resource}
+		return desc
+	}
+
+	t.Run("transfer as is by default", func(t *testing.T) {
+		r := require.New(t)
+		desc := newDescriptor(false)
+		r.NoError(pinResourceAccesses(t.Context(), desc, "", nil))
+		r.Equal(newDescriptor(false), desc)
+	})
+
+	t.Run("enforce refuses tags", func(t *testing.T) {
+		r := require.New(t)
+		err := pinResourceAccesses(t.Context(), newDescriptor(true), transferv1alpha1.DigestPinningEnforce, nil)
+		r.ErrorIs(err, pinning.ErrUnpinned)
+		r.ErrorContains(err, "ocm.software/app:1.0.0")
+	})
+
+	resolver := func(resolved string) pinning.Resolver {
+		return pinning.ResolverFunc(func(_ context.Context, imageReference string) (godigest.Digest, error) {
+			return godigest.NewDigestFromEncoded(godigest.SHA256, resolved), nil
+		})
+	}
+
+	t.Run("resolve pins to the digest of the tag", func(t *testing.T) {
+		r := require.New(t)
+		desc := newDescriptor(true)
+		r.NoError(pinResourceAccesses(t.Context(), desc, transferv1alpha1.DigestPinningResolve, resolver(digest)))
+
+		var access ociv1.OCIImage
+		r.NoError(json.Unmarshal(desc.Component.Resources[0].Access.Data, &access))
+		r.Equal("ghcr.io/acme/app:1.0.0@sha256:"+digest, access.ImageReference)
+
+		r.Len(desc.Component.Resources[0].Labels, 1)
+		label := desc.Component.Resources[0].Labels[0]
+		r.Equal(pinning.LabelName, label.Name)
+		r.False(label.Signing)
+		r.JSONEq(`{"imageReference":"ghcr.io/acme/app:1.0.0","digest":"sha256:`+digest+`"}`, string(label.Value))
+
+		// pinned accesses are left alone.
+		pinned := desc.DeepCopy()
+		r.NoError(pinResourceAccesses(t.Context(), pinned, transferv1alpha1.DigestPinningEnforce, nil))
+		r.Equal(desc, pinned)
+	})
+
+	t.Run("resolve refuses moved tags", func(t *testing.T) {
+		r := require.New(t)
+		err := pinResourceAccesses(t.Context(), newDescriptor(true), transferv1alpha1.DigestPinningResolve, resolver(strings.Repeat("0", 64)))
+		r.ErrorIs(err, pinning.ErrUnpinned)
+	})
+}
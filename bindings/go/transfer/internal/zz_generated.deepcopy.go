@@ -10,6 +10,62 @@ import (
 	runtime "ocm.software/open-component-model/bindings/go/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeltaSummary) DeepCopyInto(out *DeltaSummary) {
+	*out = *in
+	if in.Skipped != nil {
+		in, out := &in.Skipped, &out.Skipped
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeltaSummary.
+func (in *DeltaSummary) DeepCopy() *DeltaSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(DeltaSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeltaSummaryTransformation) DeepCopyInto(out *DeltaSummaryTransformation) {
+	*out = *in
+	out.Type = in.Type
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(DeltaSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(DeltaSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeltaSummaryTransformation.
+func (in *DeltaSummaryTransformation) DeepCopy() *DeltaSummaryTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(DeltaSummaryTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyTyped is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Typed.
+func (in *DeltaSummaryTransformation) DeepCopyTyped() runtime.Typed {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileCleanupOutput) DeepCopyInto(out *FileCleanupOutput) {
 	*out = *in
@@ -9,6 +9,12 @@ import (
 	_ "embed"
 )
 
+//go:embed schemas/DeltaSummary.schema.json
+var schemaDeltaSummary []byte
+
+//go:embed schemas/DeltaSummaryTransformation.schema.json
+var schemaDeltaSummaryTransformation []byte
+
 //go:embed schemas/FileCleanupOutput.schema.json
 var schemaFileCleanupOutput []byte
 
@@ -18,6 +24,16 @@ var schemaFileCleanupSpec []byte
 //go:embed schemas/FileCleanupTransformation.schema.json
 var schemaFileCleanupTransformation []byte
 
+// JSONSchema returns the JSON Schema for DeltaSummary.
+func (DeltaSummary) JSONSchema() []byte {
+	return schemaDeltaSummary
+}
+
+// JSONSchema returns the JSON Schema for DeltaSummaryTransformation.
+func (DeltaSummaryTransformation) JSONSchema() []byte {
+	return schemaDeltaSummaryTransformation
+}
+
 // JSONSchema returns the JSON Schema for FileCleanupOutput.
 func (FileCleanupOutput) JSONSchema() []byte {
 	return schemaFileCleanupOutput
@@ -7,6 +7,16 @@ package internal
 
 import "ocm.software/open-component-model/bindings/go/runtime"
 
+// SetType is an autogenerated setter function, useful for type inference and defaulting.
+func (t *DeltaSummaryTransformation) SetType(typ runtime.Type) {
+	t.Type = typ
+}
+
+// GetType is an autogenerated getter function, useful for type inference and defaulting.
+func (t *DeltaSummaryTransformation) GetType() runtime.Type {
+	return t.Type
+}
+
 // SetType is an autogenerated setter function, useful for type inference and defaulting.
 func (t *FileCleanupTransformation) SetType(typ runtime.Type) {
 	t.Type = typ
@@ -0,0 +1,57 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"ocm.software/open-component-model/bindings/go/transform/graph/builder"
+	graphruntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+)
+
+// ProgressFunc is called for every state change of a transformation while a transfer is processed.
+// Calls are sequential, so implementations do not have to synchronize state between calls.
+type ProgressFunc func(event graphruntime.ProgressEvent)
+
+// Process builds the graph of a transfer graph definition with the builder and processes it,
+// calling progress for every progress event. Concurrency limits, journals and transformers are
+// configured on the builder, see [NewDefaultBuilder]. The builder must not have an events channel,
+// as Process uses its own to deliver the events to progress, which may be nil.
+//
+// Process returns after the graph is processed and all events are delivered to progress:
+//
+//	b := transfer.NewDefaultBuilder(repoProvider, resourceRepo, credentialProvider).WithConcurrency(4)
+//	err := transfer.Process(ctx, b, tgd, func(event graphruntime.ProgressEvent) {
+//	    slog.Info("transfer progress", "transformation", event.Transformation.ID, "state", event.State)
+//	})
+func Process(ctx context.Context, b *builder.Builder, tgd *transformv1alpha1.TransformationGraphDefinition, progress ProgressFunc) error {
+	var events chan graphruntime.ProgressEvent
+	if progress != nil {
+		events = make(chan graphruntime.ProgressEvent)
+		b = b.WithEvents(events)
+	}
+
+	graph, err := b.BuildAndCheck(tgd)
+	if err != nil {
+		return fmt.Errorf("failed to build transformation graph: %w", err)
+	}
+
+	delivered := make(chan struct{})
+	if events != nil {
+		go func() {
+			defer close(delivered)
+			for event := range events {
+				progress(event)
+			}
+		}()
+	} else {
+		close(delivered)
+	}
+
+	err = graph.Process(ctx)
+	<-delivered
+	if err != nil {
+		return fmt.Errorf("failed to process transformation graph: %w", err)
+	}
+	return nil
+}
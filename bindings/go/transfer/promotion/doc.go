@@ -0,0 +1,27 @@
+// Package promotion provides staged delivery of component versions between repositories.
+//
+// Promote copies a component version from a source to a target repository (for example
+// from a "staging" to a "production" registry) only if all configured gates pass:
+//
+//	desc, err := promotion.Promote(ctx, staging, production, "ocm.software/app", "1.0.0",
+//	    []promotion.Gate{
+//	        promotion.SignatureGate(promotion.SignaturePolicy{
+//	            Names:       []string{"release"},
+//	            Verifier:    rsaHandler,
+//	            Credentials: releaseKey,
+//	        }),
+//	        promotion.LabelGate("ocm.software/tested"),
+//	        promotion.SBOMGate(),
+//	    },
+//	    promotion.WithEnvironment("production"),
+//	)
+//	if errors.Is(err, promotion.ErrPromotionDenied) {
+//	    // at least one gate failed, nothing was written to production
+//	}
+//
+// If writing to the target repository fails, the partially promoted component version is removed again,
+// provided the target repository supports deleting component versions.
+//
+// Every promotion is recorded in the non-signing label [LabelName] of the promoted
+// component version, so the promotion history travels with the component version.
+package promotion
@@ -0,0 +1,176 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+)
+
+// ErrPromotionDenied is matched by the error returned from Promote if at least one gate failed.
+var ErrPromotionDenied = errors.New("promotion denied")
+
+// GateError describes the failure of a single gate.
+// It matches [ErrPromotionDenied] with [errors.Is].
+type GateError struct {
+	// Gate is the name of the failed gate.
+	Gate string
+	// Err is the reason the gate failed.
+	Err error
+}
+
+func (e *GateError) Error() string {
+	return fmt.Sprintf("gate %q failed: %v", e.Gate, e.Err)
+}
+
+func (e *GateError) Unwrap() error {
+	return e.Err
+}
+
+func (e *GateError) Is(target error) bool {
+	return target == ErrPromotionDenied
+}
+
+// Gate is a condition a component version has to fulfill to be promoted.
+type Gate interface {
+	// Name identifies the gate in errors and in the promotion record.
+	Name() string
+	// Check returns an error describing why the component version must not be promoted, or nil.
+	Check(ctx context.Context, desc *descriptor.Descriptor) error
+}
+
+// NewGate creates a Gate from a check function.
+func NewGate(name string, check func(ctx context.Context, desc *descriptor.Descriptor) error) Gate {
+	return &gate{name: name, check: check}
+}
+
+type gate struct {
+	name  string
+	check func(ctx context.Context, desc *descriptor.Descriptor) error
+}
+
+func (g *gate) Name() string {
+	return g.name
+}
+
+func (g *gate) Check(ctx context.Context, desc *descriptor.Descriptor) error {
+	return g.check(ctx, desc)
+}
+
+// SignaturePolicy describes the signatures a component version needs to carry to be promoted.
+type SignaturePolicy struct {
+	// Names are the names of the signatures that must be present.
+	// If empty and no Rules are given, at least one signature must be present and all signatures are checked.
+	Names []string
+	// Verifier is used to verify the signatures in Names cryptographically.
+	// It is required unless the policy only consists of Rules, as a signature with a digest matching
+	// the descriptor can be attached by anyone.
+	Verifier signing.Verifier
+	// Config is passed to the Verifier.
+	Config runtime.Typed
+	// Credentials are passed to the Verifier.
+	Credentials runtime.Typed
+	// Rules are additional verification rules, e.g. "at least 2 of these 3 signers" or
+	// "signer X is required for components matching ocm.software/*", see signing.VerifyWithPolicy.
+	Rules []signing.Rule
+}
+
+// SignatureGate creates a Gate enforcing the given signature policy with signing.VerifyWithPolicy.
+// Every required signature must have a digest matching the descriptor and must be verified
+// successfully by the verifier of the policy.
+func SignatureGate(policy SignaturePolicy) Gate {
+	return NewGate("signature", func(ctx context.Context, desc *descriptor.Descriptor) error {
+		names := policy.Names
+		if len(names) == 0 && len(policy.Rules) == 0 {
+			if len(desc.Signatures) == 0 {
+				return errors.New("component version is not signed")
+			}
+			for _, signature := range desc.Signatures {
+				names = append(names, signature.Name)
+			}
+		}
+
+		var rules []signing.Rule
+		if len(names) > 0 {
+			if policy.Verifier == nil {
+				return errors.New("no verifier configured to verify the signatures")
+			}
+			rule := signing.Rule{Name: "signatures"}
+			for _, name := range names {
+				rule.Signers = append(rule.Signers, signing.PolicySigner{
+					Signature:   name,
+					Verifier:    policy.Verifier,
+					Config:      policy.Config,
+					Credentials: policy.Credentials,
+				})
+			}
+			rules = append(rules, rule)
+		}
+		rules = append(rules, policy.Rules...)
+
+		_, err := signing.VerifyWithPolicy(ctx, desc, signing.Policy{Rules: rules}, slog.Default())
+		return err
+	})
+}
+
+// LabelGate creates a Gate requiring the component to carry all labels with the given names.
+func LabelGate(names ...string) Gate {
+	return NewGate("labels", func(_ context.Context, desc *descriptor.Descriptor) error {
+		var missing []string
+		for _, name := range names {
+			if !slices.ContainsFunc(desc.Component.Labels, func(l descriptor.Label) bool { return l.Name == name }) {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing labels %v", missing)
+		}
+		return nil
+	})
+}
+
+// DefaultSBOMResourceTypes are the resource types SBOMGate accepts if no types are given.
+var DefaultSBOMResourceTypes = []string{"sbom"}
+
+// SBOMGate creates a Gate requiring the component to contain a resource of one of the
+// given types. If no types are given, [DefaultSBOMResourceTypes] are used.
+func SBOMGate(types ...string) Gate {
+	if len(types) == 0 {
+		types = DefaultSBOMResourceTypes
+	}
+	return NewGate("sbom", func(_ context.Context, desc *descriptor.Descriptor) error {
+		for _, resource := range desc.Component.Resources {
+			if slices.Contains(types, resource.Type) {
+				return nil
+			}
+		}
+		return fmt.Errorf("no resource of type %v found", types)
+	})
+}
+
+// PolicyRule is a single named rule evaluated by the PolicyGate.
+type PolicyRule struct {
+	// Name identifies the rule in errors.
+	Name string
+	// Check returns an error if the rule is violated.
+	Check func(ctx context.Context, desc *descriptor.Descriptor) error
+}
+
+// PolicyGate creates a Gate that fails if any of the given rules fails.
+// All rules are evaluated and all violations are reported.
+func PolicyGate(rules ...PolicyRule) Gate {
+	return NewGate("policy", func(ctx context.Context, desc *descriptor.Descriptor) error {
+		var errs []error
+		for _, rule := range rules {
+			if err := rule.Check(ctx, desc); err != nil {
+				errs = append(errs, fmt.Errorf("rule %q: %w", rule.Name, err))
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
@@ -0,0 +1,230 @@
+package promotion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// LabelName is the name of the component label recording the promotions of a component version.
+	LabelName = "ocm.software/promotions"
+	// LabelVersion is the version of the value of the promotion label.
+	LabelVersion = "v1"
+)
+
+// ErrAlreadyPromoted is returned by Promote if the component version already exists in the target repository.
+var ErrAlreadyPromoted = errors.New("component version already exists in target repository")
+
+// Record describes a single promotion of a component version.
+// The records of all promotions are kept as a list in the label [LabelName].
+type Record struct {
+	// Environment is the environment the component version was promoted to.
+	Environment string `json:"environment,omitempty"`
+	// PromotedAt is the time of the promotion.
+	PromotedAt time.Time `json:"promotedAt"`
+	// Gates are the names of the gates that passed for the promotion.
+	Gates []string `json:"gates,omitempty"`
+}
+
+// Option configures a promotion.
+type Option func(*options)
+
+type options struct {
+	environment string
+	now         func() time.Time
+}
+
+// WithEnvironment sets the name of the environment the component version is promoted to.
+// It is recorded in the promotion label.
+func WithEnvironment(environment string) Option {
+	return func(o *options) {
+		o.environment = environment
+	}
+}
+
+// WithClock sets the function used to determine the time of the promotion. Defaults to [time.Now].
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// Promote copies a component version from src to dst if all gates pass.
+//
+// All gates are evaluated against the descriptor in src before anything is written to dst,
+// and all failing gates are reported together in an error matching [ErrPromotionDenied].
+// On success, the local resources and sources of the component version are copied to dst
+// and the promotion is appended to the [LabelName] label of the component. The label is not
+// signing relevant, so existing signatures stay valid.
+// Resources and sources with global access are not copied, only their access is kept.
+//
+// If the component version already exists in dst, [ErrAlreadyPromoted] is returned.
+// If the copy fails after the component version was written to dst, it is removed from dst again,
+// provided dst implements [repository.ComponentVersionDeleter]. Blobs copied before the failure are
+// left to the garbage collection of dst.
+// The promoted descriptor is returned.
+func Promote(ctx context.Context, src, dst repository.ComponentVersionRepository, component, version string, gates []Gate, opts ...Option) (*descriptor.Descriptor, error) {
+	o := &options{now: time.Now}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	original, err := src.GetComponentVersion(ctx, component, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component version %s:%s from source repository: %w", component, version, err)
+	}
+	// only the resources, sources and labels are modified, so they are cloned to keep the source descriptor intact.
+	desc := *original
+	desc.Component.Resources = slices.Clone(original.Component.Resources)
+	desc.Component.Sources = slices.Clone(original.Component.Sources)
+	desc.Component.Labels = slices.Clone(original.Component.Labels)
+
+	if _, err := dst.GetComponentVersion(ctx, component, version); err == nil {
+		return nil, fmt.Errorf("failed to promote component version %s:%s: %w", component, version, ErrAlreadyPromoted)
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check for component version %s:%s in target repository: %w", component, version, err)
+	}
+
+	passed := make([]string, 0, len(gates))
+	var failures []error
+	for _, gate := range gates {
+		if err := gate.Check(ctx, &desc); err != nil {
+			failures = append(failures, &GateError{Gate: gate.Name(), Err: err})
+			continue
+		}
+		passed = append(passed, gate.Name())
+	}
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("promotion of component version %s:%s denied: %w", component, version, errors.Join(failures...))
+	}
+
+	if err := copyLocalBlobs(ctx, src, dst, &desc); err != nil {
+		return nil, rollback(ctx, dst, component, version, err)
+	}
+
+	if err := appendRecord(&desc.Component, Record{
+		Environment: o.environment,
+		PromotedAt:  o.now().UTC(),
+		Gates:       passed,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := dst.AddComponentVersion(ctx, &desc); err != nil {
+		return nil, rollback(ctx, dst, component, version,
+			fmt.Errorf("failed to add component version %s:%s to target repository: %w", component, version, err))
+	}
+
+	return &desc, nil
+}
+
+// rollback removes the component version from dst after the promotion failed with err, in case it was
+// written partially. It returns err, joined with the reason if the component version could not be removed.
+// The rollback is not canceled with ctx, so that a canceled promotion is cleaned up as well.
+func rollback(ctx context.Context, dst repository.ComponentVersionRepository, component, version string, err error) error {
+	ctx = context.WithoutCancel(ctx)
+	if _, getErr := dst.GetComponentVersion(ctx, component, version); errors.Is(getErr, repository.ErrNotFound) {
+		return err
+	}
+
+	deleter, ok := dst.(repository.ComponentVersionDeleter)
+	if !ok {
+		return errors.Join(err, fmt.Errorf("target repository does not support deletion, component version %s:%s may be partially promoted", component, version))
+	}
+	if deleteErr := deleter.DeleteComponentVersion(ctx, component, version); deleteErr != nil && !errors.Is(deleteErr, repository.ErrNotFound) {
+		return errors.Join(err, fmt.Errorf("failed to roll back component version %s:%s in target repository: %w", component, version, deleteErr))
+	}
+	return err
+}
+
+// Records returns the promotions recorded in the label [LabelName] of the component.
+func Records(component *descriptor.Component) ([]Record, error) {
+	for _, label := range component.Labels {
+		if label.Name != LabelName {
+			continue
+		}
+		var records []Record
+		if err := json.Unmarshal(label.Value, &records); err != nil {
+			return nil, fmt.Errorf("failed to decode label %s: %w", LabelName, err)
+		}
+		return records, nil
+	}
+	return nil, nil
+}
+
+func appendRecord(component *descriptor.Component, record Record) error {
+	records, err := Records(component)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(append(records, record))
+	if err != nil {
+		return fmt.Errorf("failed to encode label %s: %w", LabelName, err)
+	}
+
+	label := descriptor.Label{Name: LabelName, Value: value, Version: LabelVersion}
+	for i := range component.Labels {
+		if component.Labels[i].Name == LabelName {
+			component.Labels[i] = label
+			return nil
+		}
+	}
+	component.Labels = append(component.Labels, label)
+	return nil
+}
+
+func copyLocalBlobs(ctx context.Context, src, dst repository.ComponentVersionRepository, desc *descriptor.Descriptor) error {
+	name, version := desc.Component.Name, desc.Component.Version
+
+	for i, resource := range desc.Component.Resources {
+		if !isLocalBlob(resource.Access) {
+			continue
+		}
+		content, _, err := src.GetLocalResource(ctx, name, version, resource.ToIdentity())
+		if err != nil {
+			return fmt.Errorf("failed to get local resource %s from source repository: %w", resource.ToIdentity(), err)
+		}
+		added, err := dst.AddLocalResource(ctx, name, version, &resource, content)
+		if err != nil {
+			return fmt.Errorf("failed to add local resource %s to target repository: %w", resource.ToIdentity(), err)
+		}
+		desc.Component.Resources[i] = *added
+	}
+
+	for i, source := range desc.Component.Sources {
+		if !isLocalBlob(source.Access) {
+			continue
+		}
+		content, _, err := src.GetLocalSource(ctx, name, version, source.ToIdentity())
+		if err != nil {
+			return fmt.Errorf("failed to get local source %s from source repository: %w", source.ToIdentity(), err)
+		}
+		added, err := dst.AddLocalSource(ctx, name, version, &source, content)
+		if err != nil {
+			return fmt.Errorf("failed to add local source %s to target repository: %w", source.ToIdentity(), err)
+		}
+		desc.Component.Sources[i] = *added
+	}
+
+	return nil
+}
+
+func isLocalBlob(access runtime.Typed) bool {
+	if access == nil {
+		return false
+	}
+	switch access.GetType().Name {
+	case descriptor.LocalBlobAccessType, descriptor.LegacyLocalBlobAccessType:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,269 @@
+package promotion_test
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/direct"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+	"ocm.software/open-component-model/bindings/go/transfer/promotion"
+)
+
+type memoryRepository struct {
+	repository.ComponentVersionRepository
+	descriptors map[string]*descriptor.Descriptor
+	blobs       map[string][]byte
+	// addErr is returned by AddComponentVersion after the descriptor was stored, simulating a partial write.
+	addErr error
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		descriptors: map[string]*descriptor.Descriptor{},
+		blobs:       map[string][]byte{},
+	}
+}
+
+func (m *memoryRepository) AddComponentVersion(_ context.Context, desc *descriptor.Descriptor) error {
+	m.descriptors[desc.Component.Name+":"+desc.Component.Version] = desc
+	return m.addErr
+}
+
+func (m *memoryRepository) DeleteComponentVersion(_ context.Context, component, version string) error {
+	if _, ok := m.descriptors[component+":"+version]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.descriptors, component+":"+version)
+	return nil
+}
+
+func (m *memoryRepository) GetComponentVersion(_ context.Context, component, version string) (*descriptor.Descriptor, error) {
+	desc, ok := m.descriptors[component+":"+version]
+	if !ok {
+		return nil, fmt.Errorf("component version %s:%s: %w", component, version, repository.ErrNotFound)
+	}
+	return desc, nil
+}
+
+func (m *memoryRepository) AddLocalResource(_ context.Context, component, version string, res *descriptor.Resource, content blob.ReadOnlyBlob) (*descriptor.Resource, error) {
+	rc, err := content.ReadCloser()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	m.blobs[component+":"+version+":"+res.Name] = data
+	return res, nil
+}
+
+func (m *memoryRepository) GetLocalResource(_ context.Context, component, version string, identity runtime.Identity) (blob.ReadOnlyBlob, *descriptor.Resource, error) {
+	data, ok := m.blobs[component+":"+version+":"+identity["name"]]
+	if !ok {
+		return nil, nil, errors.New("local resource not found")
+	}
+	return direct.NewFromBytes(data), nil, nil
+}
+
+func testDescriptor() *descriptor.Descriptor {
+	resource := func(name, typ string) descriptor.Resource {
+		return descriptor.Resource{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: name, Version: "1.0.0"}},
+			Type:        typ,
+			Relation:    descriptor.LocalRelation,
+			Access: &descriptor.LocalBlob{
+				Type:           descriptor.GetLocalBlobAccessType(),
+				LocalReference: "sha256:" + name,
+				MediaType:      "application/octet-stream",
+			},
+		}
+	}
+	return &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{
+				ObjectMeta: descriptor.ObjectMeta{
+					Name:    "ocm.software/app",
+					Version: "1.0.0",
+					Labels:  []descriptor.Label{{Name: "ocm.software/tested", Value: []byte(`true`)}},
+				},
+			},
+			Provider:  descriptor.Provider{Name: "ocm.software"},
+			Resources: []descriptor.Resource{resource("app", "ociImage"), resource("bom", "sbom")},
+		},
+	}
+}
+
+func TestPromote(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	dev, staging, production := newMemoryRepository(), newMemoryRepository(), newMemoryRepository()
+	r.NoError(dev.AddComponentVersion(ctx, testDescriptor()))
+	dev.blobs["ocm.software/app:1.0.0:app"] = []byte("image")
+	dev.blobs["ocm.software/app:1.0.0:bom"] = []byte("bom")
+
+	gates := []promotion.Gate{promotion.LabelGate("ocm.software/tested"), promotion.SBOMGate()}
+	promoted, err := promotion.Promote(ctx, dev, staging, "ocm.software/app", "1.0.0", gates,
+		promotion.WithEnvironment("staging"), promotion.WithClock(func() time.Time { return now }))
+	r.NoError(err)
+	r.Equal([]byte("image"), staging.blobs["ocm.software/app:1.0.0:app"])
+	r.Equal([]byte("bom"), staging.blobs["ocm.software/app:1.0.0:bom"])
+
+	records, err := promotion.Records(&promoted.Component)
+	r.NoError(err)
+	r.Equal([]promotion.Record{{Environment: "staging", PromotedAt: now, Gates: []string{"labels", "sbom"}}}, records)
+	sourceRecords, err := promotion.Records(&dev.descriptors["ocm.software/app:1.0.0"].Component)
+	r.NoError(err)
+	r.Empty(sourceRecords, "the source descriptor must not be modified")
+
+	_, err = promotion.Promote(ctx, staging, production, "ocm.software/app", "1.0.0", nil,
+		promotion.WithEnvironment("production"), promotion.WithClock(func() time.Time { return now.Add(time.Hour) }))
+	r.NoError(err)
+	records, err = promotion.Records(&production.descriptors["ocm.software/app:1.0.0"].Component)
+	r.NoError(err)
+	r.Len(records, 2)
+	r.Equal("production", records[1].Environment)
+
+	_, err = promotion.Promote(ctx, staging, production, "ocm.software/app", "1.0.0", nil)
+	r.ErrorIs(err, promotion.ErrAlreadyPromoted)
+}
+
+func TestPromote_Denied(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	src, dst := newMemoryRepository(), newMemoryRepository()
+	desc := testDescriptor()
+	desc.Component.Resources = desc.Component.Resources[:1]
+	r.NoError(src.AddComponentVersion(ctx, desc))
+
+	_, err := promotion.Promote(ctx, src, dst, "ocm.software/app", "1.0.0", []promotion.Gate{
+		promotion.LabelGate("ocm.software/tested", "ocm.software/approved"),
+		promotion.SBOMGate(),
+		promotion.SignatureGate(promotion.SignaturePolicy{}),
+		promotion.PolicyGate(
+			promotion.PolicyRule{Name: "provider", Check: func(_ context.Context, desc *descriptor.Descriptor) error {
+				if desc.Component.Provider.Name != "ocm.software" {
+					return errors.New("unknown provider")
+				}
+				return nil
+			}},
+			promotion.PolicyRule{Name: "no-latest", Check: func(_ context.Context, _ *descriptor.Descriptor) error {
+				return errors.New("rule violated")
+			}},
+		),
+	})
+	r.ErrorIs(err, promotion.ErrPromotionDenied)
+
+	var gateErr *promotion.GateError
+	r.ErrorAs(err, &gateErr)
+	r.ErrorContains(err, `gate "labels" failed: missing labels [ocm.software/approved]`)
+	r.ErrorContains(err, `gate "sbom" failed`)
+	r.ErrorContains(err, `gate "signature" failed: component version is not signed`)
+	r.ErrorContains(err, `rule "no-latest": rule violated`)
+	r.NotContains(err.Error(), `rule "provider"`)
+	r.Empty(dst.descriptors)
+	r.Empty(dst.blobs)
+}
+
+func TestPromote_Rollback(t *testing.T) {
+	ctx := t.Context()
+	src := newMemoryRepository()
+	require.NoError(t, src.AddComponentVersion(ctx, testDescriptor()))
+	src.blobs["ocm.software/app:1.0.0:app"] = []byte("image")
+	src.blobs["ocm.software/app:1.0.0:bom"] = []byte("bom")
+
+	t.Run("deletes the partially promoted component version", func(t *testing.T) {
+		r := require.New(t)
+		dst := newMemoryRepository()
+		dst.addErr = errors.New("index update failed")
+		_, err := promotion.Promote(ctx, src, dst, "ocm.software/app", "1.0.0", nil)
+		r.ErrorContains(err, "index update failed")
+		r.Empty(dst.descriptors)
+	})
+
+	t.Run("reports targets that cannot delete", func(t *testing.T) {
+		r := require.New(t)
+		dst := newMemoryRepository()
+		dst.addErr = errors.New("index update failed")
+		_, err := promotion.Promote(ctx, src, struct {
+			repository.ComponentVersionRepository
+		}{dst}, "ocm.software/app", "1.0.0", nil)
+		r.ErrorContains(err, "index update failed")
+		r.ErrorContains(err, "may be partially promoted")
+	})
+}
+
+// keyVerifier accepts signatures whose value equals the key passed as credentials.
+type keyVerifier struct{}
+
+func (keyVerifier) GetVerifyingCredentialConsumerIdentity(context.Context, descriptor.Signature, runtime.Typed) (runtime.Identity, error) {
+	return nil, nil
+}
+
+func (keyVerifier) Verify(_ context.Context, signed descriptor.Signature, _ runtime.Typed, credentials runtime.Typed) error {
+	if raw, ok := credentials.(*runtime.Raw); !ok || string(raw.Data) != signed.Signature.Value {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func TestSignatureGate(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	desc := testDescriptor()
+
+	digest, err := signing.GenerateDigest(ctx, desc, slog.Default(), v4alpha1.Algorithm, crypto.SHA256.String())
+	r.NoError(err)
+	desc.Signatures = []descriptor.Signature{{Name: "release", Digest: *digest, Signature: descriptor.SignatureInfo{Value: "release-key"}}}
+	policy := func(names ...string) promotion.SignaturePolicy {
+		return promotion.SignaturePolicy{Names: names, Verifier: keyVerifier{}, Credentials: &runtime.Raw{Data: []byte("release-key")}}
+	}
+
+	r.NoError(promotion.SignatureGate(policy("release")).Check(ctx, desc))
+	r.ErrorIs(promotion.SignatureGate(policy("audit")).Check(ctx, desc), signing.ErrSignatureNotFound)
+	r.ErrorContains(promotion.SignatureGate(promotion.SignaturePolicy{Names: []string{"release"}}).Check(ctx, desc), "no verifier configured")
+
+	wrongKey := policy("release")
+	wrongKey.Credentials = &runtime.Raw{Data: []byte("other-key")}
+	r.ErrorContains(promotion.SignatureGate(wrongKey).Check(ctx, desc), "invalid signature")
+
+	t.Run("rules", func(t *testing.T) {
+		r := require.New(t)
+		audit := signing.Rule{
+			Name:       "audit",
+			Components: []string{"ocm.software/*"},
+			Signers: []signing.PolicySigner{
+				{Signature: "release", Verifier: keyVerifier{}, Credentials: &runtime.Raw{Data: []byte("release-key")}},
+				{Signature: "audit", Verifier: keyVerifier{}, Credentials: &runtime.Raw{Data: []byte("audit-key")}},
+			},
+			Threshold: 1,
+		}
+		r.NoError(promotion.SignatureGate(promotion.SignaturePolicy{Rules: []signing.Rule{audit}}).Check(ctx, desc))
+
+		audit.Threshold = 0
+		err := promotion.SignatureGate(promotion.SignaturePolicy{Rules: []signing.Rule{audit}}).Check(ctx, desc)
+		r.ErrorIs(err, signing.ErrPolicyNotSatisfied)
+		r.ErrorContains(err, `signature "audit": signature not found`)
+	})
+
+	desc.Component.Provider.Name = "tampered"
+	r.ErrorContains(promotion.SignatureGate(policy("release")).Check(ctx, desc), "digest mismatch")
+}
@@ -0,0 +1,26 @@
+// Package provenance reconstructs the lifecycle of a component version from the provenance
+// metadata it carries.
+//
+// Query returns the timeline of a component version in a repository:
+//
+//	timeline, err := provenance.Query(ctx, production, "ocm.software/app", "1.0.0",
+//	    provenance.WithCreator(creator),
+//	)
+//	for _, event := range timeline.Events {
+//	    fmt.Println(event.Stage, event.Component, event.Time)
+//	}
+//
+// The timeline follows the chain built → signed → promoted → replicated. It is assembled from
+//   - the creation time of the component version, its creator and its build provenance resources,
+//   - the signatures of the component version,
+//   - the promotions recorded by the promotion package in the label [promotion.LabelName],
+//   - the origin recorded by the relocation package in the label [relocation.LabelName].
+//
+// Metadata appended to the component version after publication (see
+// repository.ComponentVersionMetadataRepository) is taken into account as well, so promotions
+// recorded as metadata are part of the timeline. Component references overridden by a consumer
+// (see the override package of the repository module) are listed as deviations of the timeline,
+// and OCI artifact accesses pinned to a digest (see the pinning package of the OCI module) are
+// listed with the image reference they were published with.
+// The Timeline is json encodable for audit tooling.
+package provenance
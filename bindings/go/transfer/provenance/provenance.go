@@ -0,0 +1,313 @@
+package provenance
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/component/override"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/transfer/promotion"
+	"ocm.software/open-component-model/bindings/go/transfer/relocation"
+)
+
+// Stage is a stage in the lifecycle of a component version.
+type Stage string
+
+const (
+	// StageBuilt is the creation of the component version.
+	StageBuilt Stage = "built"
+	// StageSigned is the signing of the component version.
+	StageSigned Stage = "signed"
+	// StagePromoted is the promotion of the component version to an environment.
+	StagePromoted Stage = "promoted"
+	// StageReplicated is the relocation of the component version into a different component namespace.
+	StageReplicated Stage = "replicated"
+)
+
+// DefaultBuildProvenanceResourceTypes are the resource types considered build provenance if no types are given.
+var DefaultBuildProvenanceResourceTypes = []string{"provenance"}
+
+// Timeline is the reconstructed lifecycle of a component version.
+type Timeline struct {
+	// Component is the name of the component.
+	Component string `json:"component"`
+	// Version is the version of the component.
+	Version string `json:"version"`
+	// Events are the lifecycle events in the order of the chain built → signed → promoted → replicated.
+	Events []Event `json:"events"`
+	// Deviations are the component references overridden by a consumer, see the override package
+	// of the repository module. The signatures of the component version cover the original references.
+	Deviations []repository.Deviation `json:"deviations,omitempty"`
+	// PinnedAccesses are the OCI artifact accesses that were pinned to a digest, see the pinning
+	// package of the OCI module. They list the image references the resources were published with.
+	PinnedAccesses []PinnedAccess `json:"pinnedAccesses,omitempty"`
+}
+
+// PinnedAccess describes an OCI artifact access of a resource that was pinned to a digest.
+type PinnedAccess struct {
+	// Resource is the identity of the resource within the component version.
+	Resource runtime.Identity `json:"resource"`
+	// ImageReference is the image reference of the access before it was pinned.
+	ImageReference string `json:"imageReference"`
+	// Digest is the digest the access was pinned to.
+	Digest string `json:"digest"`
+}
+
+// Event is a single stage in the lifecycle of a component version.
+// Only the field matching the Stage is set.
+type Event struct {
+	// Stage is the lifecycle stage of the event.
+	Stage Stage `json:"stage"`
+	// Component is the name of the component at the time of the event.
+	// It differs from the name in the timeline for events before a relocation.
+	Component string `json:"component"`
+	// Time is the time of the event, or nil if it was not recorded.
+	Time *time.Time `json:"time,omitempty"`
+
+	// Build describes the creation of the component version for StageBuilt.
+	Build *Build `json:"build,omitempty"`
+	// Signature describes the signature for StageSigned.
+	Signature *Signature `json:"signature,omitempty"`
+	// Promotion is the promotion record for StagePromoted.
+	Promotion *promotion.Record `json:"promotion,omitempty"`
+	// Relocation describes the relocation for StageReplicated.
+	Relocation *Relocation `json:"relocation,omitempty"`
+}
+
+// Build describes the creation of a component version.
+type Build struct {
+	// Creator is the creator of the component version, if known. See WithCreator.
+	Creator string `json:"creator,omitempty"`
+	// Provenance are the identities of the build provenance resources of the component version.
+	Provenance []runtime.Identity `json:"provenance,omitempty"`
+}
+
+// Signature describes a signature of a component version.
+type Signature struct {
+	// Name is the name of the signature.
+	Name string `json:"name"`
+	// Algorithm is the signing algorithm of the signature.
+	Algorithm string `json:"algorithm"`
+	// Issuer is the identity of the signer, if the signature states one.
+	Issuer string `json:"issuer,omitempty"`
+	// Digest is the digest of the normalised component descriptor covered by the signature.
+	Digest v2.Digest `json:"digest"`
+	// Verified is true if the signature was verified. See WithVerification.
+	Verified bool `json:"verified"`
+}
+
+// Relocation describes the relocation of a component version.
+type Relocation struct {
+	// From is the original name of the component.
+	From string `json:"from"`
+	// To is the relocated name of the component.
+	To string `json:"to"`
+}
+
+// Option configures the reconstruction of a timeline.
+type Option func(*options)
+
+type options struct {
+	creator         string
+	verification    *repository.Verification
+	provenanceTypes []string
+}
+
+// WithCreator sets the creator of the component version, which is not part of the component
+// descriptor, e.g. the value of the OCI annotation "software.ocm.creator".
+func WithCreator(creator string) Option {
+	return func(o *options) {
+		o.creator = creator
+	}
+}
+
+// WithVerification marks the signatures contained in the verification as verified.
+// Query uses the verification of a repository.VerifiedComponentVersionGetter if none is given.
+func WithVerification(verification *repository.Verification) Option {
+	return func(o *options) {
+		o.verification = verification
+	}
+}
+
+// WithBuildProvenanceTypes sets the resource types considered build provenance.
+// Defaults to [DefaultBuildProvenanceResourceTypes].
+func WithBuildProvenanceTypes(types ...string) Option {
+	return func(o *options) {
+		o.provenanceTypes = types
+	}
+}
+
+// Query reconstructs the timeline of a component version in repo.
+//
+// If repo implements repository.VerifiedComponentVersionGetter, the component version is retrieved
+// verified and its verified signatures are marked in the timeline. If repo implements
+// repository.ComponentVersionMetadataRepository, the metadata of the component version is merged
+// into the descriptor, see repository.MergeComponentVersionMetadata, so that provenance recorded
+// after publication is part of the timeline.
+func Query(ctx context.Context, repo repository.ComponentVersionRepository, component, version string, opts ...Option) (*Timeline, error) {
+	var (
+		desc         *descriptor.Descriptor
+		verification *repository.Verification
+		err          error
+	)
+	if getter, ok := repo.(repository.VerifiedComponentVersionGetter); ok {
+		desc, verification, err = getter.GetVerifiedComponentVersion(ctx, component, version)
+	} else {
+		desc, err = repo.GetComponentVersion(ctx, component, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component version %s:%s: %w", component, version, err)
+	}
+
+	if metadataRepo, ok := repo.(repository.ComponentVersionMetadataRepository); ok {
+		metadata, err := metadataRepo.ListComponentVersionMetadata(ctx, component, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list metadata of component version %s:%s: %w", component, version, err)
+		}
+		desc = repository.MergeComponentVersionMetadata(desc, metadata)
+	}
+
+	if verification != nil {
+		// a verification given as option takes precedence.
+		opts = append([]Option{WithVerification(verification)}, opts...)
+	}
+	return Reconstruct(desc, opts...)
+}
+
+// Reconstruct reconstructs the timeline of the component version described by desc.
+//
+// The events are ordered along the chain built → signed → promoted → replicated.
+// Promotions are ordered by their time. For a relocated component version, the signatures
+// of the original component version precede the promotions, and signatures added after the
+// relocation follow the replication. Relocations do not record a time, so the replication is
+// placed after all promotions, which are carried along by the relocation.
+func Reconstruct(desc *descriptor.Descriptor, opts ...Option) (*Timeline, error) {
+	o := &options{provenanceTypes: DefaultBuildProvenanceResourceTypes}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	origin, err := relocation.GetOrigin(&desc.Component)
+	if err != nil {
+		return nil, err
+	}
+	records, err := promotion.Records(&desc.Component)
+	if err != nil {
+		return nil, err
+	}
+	deviations, err := override.Deviations(&desc.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	name := desc.Component.Name
+	originalName := name
+	if origin != nil {
+		originalName = origin.Component
+	}
+
+	timeline := &Timeline{Component: name, Version: desc.Component.Version, Deviations: deviations}
+	for _, resource := range desc.Component.Resources {
+		record, err := pinning.GetRecord(resource.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pinned access of resource %s: %w", resource.ToIdentity(), err)
+		}
+		if record != nil {
+			timeline.PinnedAccesses = append(timeline.PinnedAccesses, PinnedAccess{
+				Resource:       resource.ToIdentity(),
+				ImageReference: record.ImageReference,
+				Digest:         record.Digest,
+			})
+		}
+	}
+
+	built, err := o.buildEvent(desc, originalName)
+	if err != nil {
+		return nil, err
+	}
+	timeline.Events = append(timeline.Events, built)
+
+	if origin != nil {
+		// the original signatures are verified against the original descriptor, see relocation.Original.
+		timeline.Events = append(timeline.Events, o.signatureEvents(originalName, descriptor.ConvertFromV2Signatures(origin.Signatures), false)...)
+	} else {
+		timeline.Events = append(timeline.Events, o.signatureEvents(name, desc.Signatures, true)...)
+	}
+
+	slices.SortStableFunc(records, func(a, b promotion.Record) int {
+		return cmp.Compare(a.PromotedAt.UnixNano(), b.PromotedAt.UnixNano())
+	})
+	for _, record := range records {
+		promotedAt := record.PromotedAt
+		timeline.Events = append(timeline.Events, Event{
+			Stage:     StagePromoted,
+			Component: name,
+			Time:      &promotedAt,
+			Promotion: &record,
+		})
+	}
+
+	if origin != nil {
+		timeline.Events = append(timeline.Events, Event{
+			Stage:      StageReplicated,
+			Component:  name,
+			Relocation: &Relocation{From: originalName, To: name},
+		})
+		timeline.Events = append(timeline.Events, o.signatureEvents(name, desc.Signatures, true)...)
+	}
+
+	return timeline, nil
+}
+
+func (o *options) buildEvent(desc *descriptor.Descriptor, component string) (Event, error) {
+	event := Event{
+		Stage:     StageBuilt,
+		Component: component,
+		Build:     &Build{Creator: o.creator},
+	}
+	if creationTime := desc.Component.CreationTime; creationTime != "" {
+		created, err := time.Parse(time.RFC3339, creationTime)
+		if err != nil {
+			return Event{}, fmt.Errorf("invalid creation time of component version %s: %w", desc.Component.ToIdentity(), err)
+		}
+		event.Time = &created
+	}
+	for _, resource := range desc.Component.Resources {
+		if slices.Contains(o.provenanceTypes, resource.Type) {
+			event.Build.Provenance = append(event.Build.Provenance, resource.ToIdentity())
+		}
+	}
+	return event, nil
+}
+
+// signatureEvents returns an event per signature. Signatures are only marked as verified if
+// verifiable is set, as the verification refers to the descriptor it was retrieved with.
+func (o *options) signatureEvents(component string, signatures []descriptor.Signature, verifiable bool) []Event {
+	events := make([]Event, 0, len(signatures))
+	for _, signature := range signatures {
+		event := Event{
+			Stage:     StageSigned,
+			Component: component,
+			Signature: &Signature{
+				Name:      signature.Name,
+				Algorithm: signature.Signature.Algorithm,
+				Issuer:    signature.Signature.Issuer,
+				Digest:    *descriptor.ConvertToV2Digest(&signature.Digest),
+			},
+		}
+		if verifiable && o.verification != nil {
+			event.Signature.Verified = slices.ContainsFunc(o.verification.Signatures, func(v repository.SignatureVerification) bool {
+				return v.Name == signature.Name && v.Digest.Value == signature.Digest.Value
+			})
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,249 @@
+package provenance_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	overridespec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/component/override"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/transfer/promotion"
+	"ocm.software/open-component-model/bindings/go/transfer/provenance"
+	"ocm.software/open-component-model/bindings/go/transfer/relocation"
+)
+
+type memoryRepository struct {
+	repository.ComponentVersionRepository
+	descriptors map[string]*descriptor.Descriptor
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{descriptors: map[string]*descriptor.Descriptor{}}
+}
+
+func (m *memoryRepository) AddComponentVersion(_ context.Context, desc *descriptor.Descriptor) error {
+	m.descriptors[desc.Component.Name+":"+desc.Component.Version] = desc
+	return nil
+}
+
+func (m *memoryRepository) GetComponentVersion(_ context.Context, component, version string) (*descriptor.Descriptor, error) {
+	desc, ok := m.descriptors[component+":"+version]
+	if !ok {
+		return nil, fmt.Errorf("component version %s:%s: %w", component, version, repository.ErrNotFound)
+	}
+	return desc, nil
+}
+
+// metadataRepository is a memoryRepository that verifies component versions and keeps metadata.
+type metadataRepository struct {
+	*memoryRepository
+	metadata     []repository.ComponentVersionMetadata
+	verification *repository.Verification
+}
+
+func (m *metadataRepository) GetVerifiedComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, *repository.Verification, error) {
+	desc, err := m.GetComponentVersion(ctx, component, version)
+	return desc, m.verification, err
+}
+
+func (m *metadataRepository) AppendComponentVersionMetadata(_ context.Context, _, _ string, metadata repository.ComponentVersionMetadata) error {
+	m.metadata = append(m.metadata, metadata)
+	return nil
+}
+
+func (m *metadataRepository) ListComponentVersionMetadata(_ context.Context, _, _ string) ([]repository.ComponentVersionMetadata, error) {
+	return m.metadata, nil
+}
+
+var created = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func testDescriptor() *descriptor.Descriptor {
+	return &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{
+				ObjectMeta:   descriptor.ObjectMeta{Name: "github.com/acme/app", Version: "1.0.0"},
+				CreationTime: created.Format(time.RFC3339),
+			},
+			Provider: descriptor.Provider{Name: "acme"},
+			Resources: []descriptor.Resource{
+				{
+					ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "image", Version: "1.0.0"}},
+					Type:        "ociImage",
+					Relation:    descriptor.ExternalRelation,
+				},
+				{
+					ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "slsa", Version: "1.0.0"}},
+					Type:        "provenance",
+					Relation:    descriptor.LocalRelation,
+				},
+			},
+		},
+		Signatures: []descriptor.Signature{{
+			Name:      "release",
+			Digest:    descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v4alpha1", Value: "abc"},
+			Signature: descriptor.SignatureInfo{Algorithm: "RSASSA-PSS", Issuer: "CN=acme"},
+		}},
+	}
+}
+
+func stages(timeline *provenance.Timeline) []provenance.Stage {
+	var stages []provenance.Stage
+	for _, event := range timeline.Events {
+		stages = append(stages, event.Stage)
+	}
+	return stages
+}
+
+func TestQuery_Lifecycle(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	staging, production, vendored := newMemoryRepository(), newMemoryRepository(), newMemoryRepository()
+	r.NoError(staging.AddComponentVersion(ctx, testDescriptor()))
+	promotedAt := created.Add(time.Hour)
+	_, err := promotion.Promote(ctx, staging, production, "github.com/acme/app", "1.0.0", nil,
+		promotion.WithEnvironment("production"),
+		promotion.WithClock(func() time.Time { return promotedAt }),
+	)
+	r.NoError(err)
+	_, err = relocation.Relocate(ctx, production, vendored, "github.com/acme/app", "1.0.0",
+		relocation.PrefixRename("github.com/acme", "ocm.example.org/vendor/acme"))
+	r.NoError(err)
+
+	timeline, err := provenance.Query(ctx, vendored, "ocm.example.org/vendor/acme/app", "1.0.0", provenance.WithCreator("ci"))
+	r.NoError(err)
+	r.Equal("ocm.example.org/vendor/acme/app", timeline.Component)
+	r.Equal([]provenance.Stage{provenance.StageBuilt, provenance.StageSigned, provenance.StagePromoted, provenance.StageReplicated}, stages(timeline))
+
+	built := timeline.Events[0]
+	r.Equal("github.com/acme/app", built.Component)
+	r.True(created.Equal(*built.Time))
+	r.Equal("ci", built.Build.Creator)
+	r.Equal([]runtime.Identity{{"name": "slsa", "version": "1.0.0"}}, built.Build.Provenance)
+
+	signed := timeline.Events[1]
+	r.Equal("github.com/acme/app", signed.Component, "the original signature belongs to the original component")
+	r.Equal("release", signed.Signature.Name)
+	r.Equal("CN=acme", signed.Signature.Issuer)
+	r.False(signed.Signature.Verified)
+
+	promoted := timeline.Events[2]
+	r.Equal("production", promoted.Promotion.Environment)
+	r.True(promotedAt.Equal(*promoted.Time))
+
+	replicated := timeline.Events[3]
+	r.Equal(&provenance.Relocation{From: "github.com/acme/app", To: "ocm.example.org/vendor/acme/app"}, replicated.Relocation)
+	r.Nil(replicated.Time)
+
+	_, err = json.Marshal(timeline)
+	r.NoError(err)
+}
+
+func TestQuery_MetadataAndVerification(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	repo := &metadataRepository{memoryRepository: newMemoryRepository()}
+	desc := testDescriptor()
+	r.NoError(repo.AddComponentVersion(ctx, desc))
+	repo.verification = &repository.Verification{Signatures: []repository.SignatureVerification{repository.NewSignatureVerification(desc.Signatures[0])}}
+
+	later, earlier := created.Add(2*time.Hour), created.Add(time.Hour)
+	records, err := json.Marshal([]promotion.Record{
+		{Environment: "production", PromotedAt: later},
+		{Environment: "staging", PromotedAt: earlier},
+	})
+	r.NoError(err)
+	r.NoError(repo.AppendComponentVersionMetadata(ctx, "github.com/acme/app", "1.0.0", repository.ComponentVersionMetadata{
+		Name:  promotion.LabelName,
+		Value: records,
+	}))
+
+	timeline, err := provenance.Query(ctx, repo, "github.com/acme/app", "1.0.0")
+	r.NoError(err)
+	r.Equal([]provenance.Stage{provenance.StageBuilt, provenance.StageSigned, provenance.StagePromoted, provenance.StagePromoted}, stages(timeline))
+	r.True(timeline.Events[1].Signature.Verified)
+	r.Equal("staging", timeline.Events[2].Promotion.Environment, "promotions are ordered by time")
+	r.Equal("production", timeline.Events[3].Promotion.Environment)
+}
+
+func TestReconstruct(t *testing.T) {
+	t.Run("signatures after relocation follow the replication", func(t *testing.T) {
+		r := require.New(t)
+		ctx := t.Context()
+		upstream, vendored := newMemoryRepository(), newMemoryRepository()
+		r.NoError(upstream.AddComponentVersion(ctx, testDescriptor()))
+		desc, err := relocation.Relocate(ctx, upstream, vendored, "github.com/acme/app", "1.0.0",
+			relocation.PrefixRename("github.com/acme", "ocm.example.org/vendor/acme"))
+		r.NoError(err)
+		desc.Signatures = []descriptor.Signature{{Name: "vendor", Digest: descriptor.Digest{Value: "def"}}}
+
+		timeline, err := provenance.Reconstruct(desc)
+		r.NoError(err)
+		r.Equal([]provenance.Stage{provenance.StageBuilt, provenance.StageSigned, provenance.StageReplicated, provenance.StageSigned}, stages(timeline))
+		r.Equal("release", timeline.Events[1].Signature.Name)
+		r.Equal("vendor", timeline.Events[3].Signature.Name)
+		r.Equal("ocm.example.org/vendor/acme/app", timeline.Events[3].Component)
+	})
+
+	t.Run("custom build provenance types", func(t *testing.T) {
+		r := require.New(t)
+		timeline, err := provenance.Reconstruct(testDescriptor(), provenance.WithBuildProvenanceTypes("ociImage"))
+		r.NoError(err)
+		r.Equal([]runtime.Identity{{"name": "image", "version": "1.0.0"}}, timeline.Events[0].Build.Provenance)
+	})
+
+	t.Run("overridden references are deviations", func(t *testing.T) {
+		r := require.New(t)
+		desc := testDescriptor()
+		desc.Component.References = []descriptor.Reference{{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "base", Version: "1.0.0"}},
+			Component:   "github.com/acme/base",
+		}}
+		overrides, err := override.New(&overridespec.Config{Overrides: []overridespec.Override{
+			{Component: "github.com/acme/base", Substitute: overridespec.Substitute{Version: "1.0.1"}, Reason: "CVE-2026-0001"},
+		}})
+		r.NoError(err)
+		desc, _, err = overrides.Apply(desc)
+		r.NoError(err)
+
+		timeline, err := provenance.Reconstruct(desc)
+		r.NoError(err)
+		r.Len(timeline.Deviations, 1)
+		r.Equal("1.0.0", timeline.Deviations[0].Version)
+		r.Equal("1.0.1", timeline.Deviations[0].SubstituteVersion)
+		r.Equal("CVE-2026-0001", timeline.Deviations[0].Reason)
+	})
+
+	t.Run("pinned accesses", func(t *testing.T) {
+		r := require.New(t)
+		desc := testDescriptor()
+		label, err := pinning.Record{ImageReference: "ghcr.io/acme/app:1.0.0", Digest: "sha256:abc"}.Label()
+		r.NoError(err)
+		desc.Component.Resources[0].Labels = append(desc.Component.Resources[0].Labels, label)
+
+		timeline, err := provenance.Reconstruct(desc)
+		r.NoError(err)
+		r.Equal([]provenance.PinnedAccess{{
+			Resource:       runtime.Identity{"name": "image", "version": "1.0.0"},
+			ImageReference: "ghcr.io/acme/app:1.0.0",
+			Digest:         "sha256:abc",
+		}}, timeline.PinnedAccesses)
+	})
+
+	t.Run("invalid creation time", func(t *testing.T) {
+		desc := testDescriptor()
+		desc.Component.CreationTime = "yesterday"
+		_, err := provenance.Reconstruct(desc)
+		require.ErrorContains(t, err, "invalid creation time")
+	})
+}
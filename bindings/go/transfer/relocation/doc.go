@@ -0,0 +1,21 @@
+// Package relocation vendors component versions into a different component namespace.
+//
+// Relocate copies a component version, and optionally its reference closure, to a target
+// repository under a new component name:
+//
+//	desc, err := relocation.Relocate(ctx, upstream, vendored, "github.com/acme/app", "1.0.0",
+//	    relocation.PrefixRename("github.com/acme", "ocm.example.org/vendor/acme"),
+//	    relocation.WithReferenceClosure(),
+//	)
+//
+// The original identity is recorded in the non-signing label [LabelName] of the relocated
+// component version, together with the original signatures and the original component names
+// and digests of relocated references. As the component name is part of the normalised
+// descriptor, the original signatures do not verify against the relocated descriptor, but
+// against the descriptor reconstructed by [Original]:
+//
+//	original, err := relocation.Original(desc)
+//	for _, signature := range original.Signatures {
+//	    err := signing.VerifyDigestMatchesDescriptor(ctx, original, signature, logger)
+//	}
+package relocation
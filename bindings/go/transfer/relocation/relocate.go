@@ -0,0 +1,325 @@
+package relocation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+)
+
+const (
+	// LabelName is the name of the component label recording the origin of a relocated component version.
+	LabelName = "ocm.software/relocation"
+	// LabelVersion is the version of the value of the relocation label.
+	LabelVersion = "v1"
+)
+
+var (
+	// ErrAlreadyRelocated is returned by Relocate if a relocated component version already exists in the target repository.
+	ErrAlreadyRelocated = errors.New("component version already exists in target repository")
+	// ErrNotRelocated is returned by Original if the descriptor has no relocation label.
+	ErrNotRelocated = errors.New("component version is not relocated")
+)
+
+// Origin is the relocation metadata kept in the label [LabelName].
+type Origin struct {
+	// Component is the original name of the component.
+	Component string `json:"component"`
+	// Signatures are the signatures of the original component version.
+	Signatures []v2.Signature `json:"signatures,omitempty"`
+	// References are the original targets of the relocated references.
+	References []ReferenceOrigin `json:"references,omitempty"`
+}
+
+// ReferenceOrigin is the original target of a relocated component reference.
+type ReferenceOrigin struct {
+	// Identity is the identity of the reference within the component version.
+	Identity runtime.Identity `json:"identity"`
+	// Component is the original name of the referenced component.
+	Component string `json:"component"`
+	// Digest is the original digest of the referenced component version.
+	Digest v2.Digest `json:"digest"`
+}
+
+// Rename returns the relocated name of a component. If ok is false, the component is not relocated.
+type Rename func(component string) (relocated string, ok bool)
+
+// PrefixRename creates a Rename replacing the name prefix from with to. Only whole path segments
+// are matched, so a prefix "github.com/acme" relocates "github.com/acme/app" but not "github.com/acme-corp/app".
+// If from is empty, every component is relocated below to.
+func PrefixRename(from, to string) Rename {
+	from, to = strings.TrimSuffix(from, "/"), strings.TrimSuffix(to, "/")
+	return func(component string) (string, bool) {
+		if from == "" {
+			return to + "/" + component, true
+		}
+		rest, ok := strings.CutPrefix(component, from)
+		if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+			return "", false
+		}
+		return to + rest, true
+	}
+}
+
+// Option configures a relocation.
+type Option func(*options)
+
+type options struct {
+	referenceClosure bool
+}
+
+// WithReferenceClosure relocates all component versions referenced directly or transitively
+// by the relocated component version, as long as the Rename relocates their component.
+// References to relocated component versions are rewritten to the relocated names and digests.
+func WithReferenceClosure() Option {
+	return func(o *options) {
+		o.referenceClosure = true
+	}
+}
+
+type relocator struct {
+	src, dst repository.ComponentVersionRepository
+	rename   Rename
+	options
+	// relocated are the relocated descriptors by original component identity, nil while in progress.
+	relocated map[string]*descriptor.Descriptor
+}
+
+// Relocate copies a component version from src to dst under the name returned by rename.
+//
+// The original component name and signatures are recorded in the [LabelName] label of the
+// relocated component version, which carries no signatures itself, as the component name is
+// signing relevant. Use [Original] to verify the original signatures.
+// Local resources and sources are copied to dst, resources and sources with global access keep their access.
+// References are only relocated with [WithReferenceClosure], otherwise they keep pointing to the
+// original component names.
+//
+// If a relocated component version already exists in dst, [ErrAlreadyRelocated] is returned.
+// The relocated descriptor is returned.
+func Relocate(ctx context.Context, src, dst repository.ComponentVersionRepository, component, version string, rename Rename, opts ...Option) (*descriptor.Descriptor, error) {
+	r := &relocator{src: src, dst: dst, rename: rename, relocated: make(map[string]*descriptor.Descriptor)}
+	for _, opt := range opts {
+		opt(&r.options)
+	}
+	return r.relocate(ctx, component, version)
+}
+
+func (r *relocator) relocate(ctx context.Context, component, version string) (*descriptor.Descriptor, error) {
+	key := component + ":" + version
+	if desc, ok := r.relocated[key]; ok {
+		if desc == nil {
+			return nil, fmt.Errorf("component version %s is part of a reference cycle", key)
+		}
+		return desc, nil
+	}
+	r.relocated[key] = nil
+
+	name, ok := r.rename(component)
+	if !ok {
+		return nil, fmt.Errorf("component %s is not relocated by the given rename", component)
+	}
+
+	original, err := r.src.GetComponentVersion(ctx, component, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component version %s from source repository: %w", key, err)
+	}
+	if _, err := r.dst.GetComponentVersion(ctx, name, version); err == nil {
+		return nil, fmt.Errorf("failed to relocate component version %s to %s: %w", key, name, ErrAlreadyRelocated)
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check for component version %s:%s in target repository: %w", name, version, err)
+	}
+
+	origin, err := GetOrigin(&original.Component)
+	if err != nil {
+		return nil, err
+	}
+	if origin == nil {
+		// the first relocation records the original identity, later relocations keep it.
+		origin = &Origin{Component: component, Signatures: descriptor.ConvertToV2Signatures(original.Signatures)}
+	}
+
+	// only the name, references, resources, sources and labels are modified, so they are cloned to keep the source descriptor intact.
+	desc := *original
+	desc.Component.Name = name
+	desc.Component.References = slices.Clone(original.Component.References)
+	desc.Component.Resources = slices.Clone(original.Component.Resources)
+	desc.Component.Sources = slices.Clone(original.Component.Sources)
+	desc.Component.Labels = slices.Clone(original.Component.Labels)
+	desc.Signatures = nil
+
+	if r.referenceClosure {
+		if err := r.relocateReferences(ctx, &desc, origin); err != nil {
+			return nil, fmt.Errorf("failed to relocate references of component version %s: %w", key, err)
+		}
+	}
+	if err := setOrigin(&desc.Component, origin); err != nil {
+		return nil, err
+	}
+	if err := copyLocalBlobs(ctx, r.src, r.dst, component, &desc); err != nil {
+		return nil, err
+	}
+	if err := r.dst.AddComponentVersion(ctx, &desc); err != nil {
+		return nil, fmt.Errorf("failed to add component version %s:%s to target repository: %w", name, version, err)
+	}
+
+	slog.DebugContext(ctx, "relocated component version", slog.String("component", component), slog.String("relocated", name), slog.String("version", version))
+	r.relocated[key] = &desc
+	return &desc, nil
+}
+
+// relocateReferences relocates the referenced component versions and rewrites the references to them.
+func (r *relocator) relocateReferences(ctx context.Context, desc *descriptor.Descriptor, origin *Origin) error {
+	for i := range desc.Component.References {
+		reference := &desc.Component.References[i]
+		if _, ok := r.rename(reference.Component); !ok {
+			continue
+		}
+		relocated, err := r.relocate(ctx, reference.Component, reference.Version)
+		if err != nil {
+			return err
+		}
+
+		identity := reference.ToIdentity()
+		if !slices.ContainsFunc(origin.References, func(ref ReferenceOrigin) bool { return ref.Identity.Equal(identity) }) {
+			origin.References = append(origin.References, ReferenceOrigin{
+				Identity:  identity,
+				Component: reference.Component,
+				Digest:    *descriptor.ConvertToV2Digest(&reference.Digest),
+			})
+		}
+
+		reference.Component = relocated.Component.Name
+		if reference.Digest.Value == "" {
+			continue
+		}
+		digest, err := signing.GenerateDigest(ctx, relocated, slog.Default(), reference.Digest.NormalisationAlgorithm, reference.Digest.HashAlgorithm)
+		if err != nil {
+			return fmt.Errorf("failed to calculate digest of relocated reference %s: %w", identity, err)
+		}
+		reference.Digest = *digest
+	}
+	return nil
+}
+
+// Original reconstructs the descriptor of the original component version from a relocated descriptor.
+// It restores the original component name, the original targets of relocated references and the
+// original signatures, which can then be verified against it, e.g. with signing.VerifyDigestMatchesDescriptor.
+// If the descriptor was not relocated, [ErrNotRelocated] is returned.
+func Original(desc *descriptor.Descriptor) (*descriptor.Descriptor, error) {
+	origin, err := GetOrigin(&desc.Component)
+	if err != nil {
+		return nil, err
+	}
+	if origin == nil {
+		return nil, fmt.Errorf("%s: %w", desc.Component.ToIdentity(), ErrNotRelocated)
+	}
+
+	original := *desc
+	original.Component.Name = origin.Component
+	original.Component.Labels = slices.DeleteFunc(slices.Clone(desc.Component.Labels), func(l descriptor.Label) bool {
+		return l.Name == LabelName
+	})
+	original.Component.References = slices.Clone(desc.Component.References)
+	for _, ref := range origin.References {
+		idx := slices.IndexFunc(original.Component.References, func(r descriptor.Reference) bool {
+			return r.ToIdentity().Equal(ref.Identity)
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("relocated reference %s not found in component version %s", ref.Identity, desc.Component.ToIdentity())
+		}
+		original.Component.References[idx].Component = ref.Component
+		original.Component.References[idx].Digest = *descriptor.ConvertFromV2Digest(&ref.Digest)
+	}
+	original.Signatures = descriptor.ConvertFromV2Signatures(origin.Signatures)
+	return &original, nil
+}
+
+// GetOrigin returns the origin recorded in the label [LabelName] of the component, or nil if it was not relocated.
+func GetOrigin(component *descriptor.Component) (*Origin, error) {
+	for _, label := range component.Labels {
+		if label.Name != LabelName {
+			continue
+		}
+		var origin Origin
+		if err := json.Unmarshal(label.Value, &origin); err != nil {
+			return nil, fmt.Errorf("failed to decode label %s: %w", LabelName, err)
+		}
+		return &origin, nil
+	}
+	return nil, nil
+}
+
+func setOrigin(component *descriptor.Component, origin *Origin) error {
+	value, err := json.Marshal(origin)
+	if err != nil {
+		return fmt.Errorf("failed to encode label %s: %w", LabelName, err)
+	}
+
+	label := descriptor.Label{Name: LabelName, Value: value, Version: LabelVersion}
+	for i := range component.Labels {
+		if component.Labels[i].Name == LabelName {
+			component.Labels[i] = label
+			return nil
+		}
+	}
+	component.Labels = append(component.Labels, label)
+	return nil
+}
+
+// copyLocalBlobs copies the local resources and sources of the original component to the relocated component in dst.
+func copyLocalBlobs(ctx context.Context, src, dst repository.ComponentVersionRepository, component string, desc *descriptor.Descriptor) error {
+	name, version := desc.Component.Name, desc.Component.Version
+
+	for i, resource := range desc.Component.Resources {
+		if !isLocalBlob(resource.Access) {
+			continue
+		}
+		content, _, err := src.GetLocalResource(ctx, component, version, resource.ToIdentity())
+		if err != nil {
+			return fmt.Errorf("failed to get local resource %s from source repository: %w", resource.ToIdentity(), err)
+		}
+		added, err := dst.AddLocalResource(ctx, name, version, &resource, content)
+		if err != nil {
+			return fmt.Errorf("failed to add local resource %s to target repository: %w", resource.ToIdentity(), err)
+		}
+		desc.Component.Resources[i] = *added
+	}
+
+	for i, source := range desc.Component.Sources {
+		if !isLocalBlob(source.Access) {
+			continue
+		}
+		content, _, err := src.GetLocalSource(ctx, component, version, source.ToIdentity())
+		if err != nil {
+			return fmt.Errorf("failed to get local source %s from source repository: %w", source.ToIdentity(), err)
+		}
+		added, err := dst.AddLocalSource(ctx, name, version, &source, content)
+		if err != nil {
+			return fmt.Errorf("failed to add local source %s to target repository: %w", source.ToIdentity(), err)
+		}
+		desc.Component.Sources[i] = *added
+	}
+
+	return nil
+}
+
+func isLocalBlob(access runtime.Typed) bool {
+	if access == nil {
+		return false
+	}
+	switch access.GetType().Name {
+	case descriptor.LocalBlobAccessType, descriptor.LegacyLocalBlobAccessType:
+		return true
+	default:
+		return false
+	}
+}
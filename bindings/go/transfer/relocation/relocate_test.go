@@ -0,0 +1,197 @@
+package relocation_test
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/direct"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+	"ocm.software/open-component-model/bindings/go/transfer/relocation"
+)
+
+type memoryRepository struct {
+	repository.ComponentVersionRepository
+	descriptors map[string]*descriptor.Descriptor
+	blobs       map[string][]byte
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		descriptors: map[string]*descriptor.Descriptor{},
+		blobs:       map[string][]byte{},
+	}
+}
+
+func (m *memoryRepository) AddComponentVersion(_ context.Context, desc *descriptor.Descriptor) error {
+	m.descriptors[desc.Component.Name+":"+desc.Component.Version] = desc
+	return nil
+}
+
+func (m *memoryRepository) GetComponentVersion(_ context.Context, component, version string) (*descriptor.Descriptor, error) {
+	desc, ok := m.descriptors[component+":"+version]
+	if !ok {
+		return nil, fmt.Errorf("component version %s:%s: %w", component, version, repository.ErrNotFound)
+	}
+	return desc, nil
+}
+
+func (m *memoryRepository) AddLocalResource(_ context.Context, component, version string, res *descriptor.Resource, content blob.ReadOnlyBlob) (*descriptor.Resource, error) {
+	rc, err := content.ReadCloser()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	m.blobs[component+":"+version+":"+res.Name] = data
+	return res, nil
+}
+
+func (m *memoryRepository) GetLocalResource(_ context.Context, component, version string, identity runtime.Identity) (blob.ReadOnlyBlob, *descriptor.Resource, error) {
+	data, ok := m.blobs[component+":"+version+":"+identity["name"]]
+	if !ok {
+		return nil, nil, errors.New("local resource not found")
+	}
+	return direct.NewFromBytes(data), nil, nil
+}
+
+func newDescriptor(name string, references ...descriptor.Reference) *descriptor.Descriptor {
+	return &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{
+				ObjectMeta: descriptor.ObjectMeta{Name: name, Version: "1.0.0"},
+			},
+			Provider: descriptor.Provider{Name: "acme"},
+			Resources: []descriptor.Resource{{
+				ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "data", Version: "1.0.0"}},
+				Type:        "blob",
+				Relation:    descriptor.LocalRelation,
+				Access: &descriptor.LocalBlob{
+					Type:           descriptor.GetLocalBlobAccessType(),
+					LocalReference: "sha256:data",
+					MediaType:      "application/octet-stream",
+				},
+				Digest: &descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "genericBlobDigest/v1", Value: "abc"},
+			}},
+			References: references,
+		},
+	}
+}
+
+func digest(t *testing.T, desc *descriptor.Descriptor) *descriptor.Digest {
+	t.Helper()
+	dig, err := signing.GenerateDigest(t.Context(), desc, slog.Default(), v4alpha1.Algorithm, crypto.SHA256.String())
+	require.NoError(t, err)
+	return dig
+}
+
+func TestRelocate(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	upstream, vendored := newMemoryRepository(), newMemoryRepository()
+	lib := newDescriptor("github.com/acme/lib")
+	app := newDescriptor("github.com/acme/app",
+		descriptor.Reference{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "lib", Version: "1.0.0"}},
+			Component:   lib.Component.Name,
+			Digest:      *digest(t, lib),
+		},
+		descriptor.Reference{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "external", Version: "2.0.0"}},
+			Component:   "ocm.software/external",
+		},
+	)
+	app.Signatures = []descriptor.Signature{{Name: "acme", Digest: *digest(t, app)}}
+	for _, desc := range []*descriptor.Descriptor{lib, app} {
+		r.NoError(upstream.AddComponentVersion(ctx, desc))
+		upstream.blobs[desc.Component.Name+":1.0.0:data"] = []byte(desc.Component.Name)
+	}
+
+	rename := relocation.PrefixRename("github.com/acme", "ocm.example.org/vendor/acme")
+	relocated, err := relocation.Relocate(ctx, upstream, vendored, app.Component.Name, "1.0.0", rename, relocation.WithReferenceClosure())
+	r.NoError(err)
+
+	r.Equal("ocm.example.org/vendor/acme/app", relocated.Component.Name)
+	r.Empty(relocated.Signatures)
+	r.Equal([]byte("github.com/acme/app"), vendored.blobs["ocm.example.org/vendor/acme/app:1.0.0:data"])
+	r.Equal([]byte("github.com/acme/lib"), vendored.blobs["ocm.example.org/vendor/acme/lib:1.0.0:data"])
+	r.Equal("github.com/acme/app", upstream.descriptors["github.com/acme/app:1.0.0"].Component.Name, "the source descriptor must not be modified")
+
+	relocatedLib, err := vendored.GetComponentVersion(ctx, "ocm.example.org/vendor/acme/lib", "1.0.0")
+	r.NoError(err)
+	r.Equal("ocm.example.org/vendor/acme/lib", relocated.Component.References[0].Component)
+	r.Equal(*digest(t, relocatedLib), relocated.Component.References[0].Digest, "references point to the relocated closure")
+	r.Equal("ocm.software/external", relocated.Component.References[1].Component)
+
+	origin, err := relocation.GetOrigin(&relocated.Component)
+	r.NoError(err)
+	r.Equal("github.com/acme/app", origin.Component)
+	r.Len(origin.References, 1)
+
+	// the original signatures verify against the reconstructed original descriptor.
+	original, err := relocation.Original(relocated)
+	r.NoError(err)
+	r.Equal(app.Component.Name, original.Component.Name)
+	r.Equal(app.Component.References, original.Component.References)
+	r.Len(original.Signatures, 1)
+	r.NoError(signing.VerifyDigestMatchesDescriptor(ctx, original, original.Signatures[0], slog.Default()))
+	r.Error(signing.VerifyDigestMatchesDescriptor(ctx, relocated, original.Signatures[0], slog.Default()))
+
+	originalLib, err := relocation.Original(relocatedLib)
+	r.NoError(err)
+	r.Equal(app.Component.References[0].Digest, *digest(t, originalLib))
+
+	// relocating again keeps the first origin.
+	mirror := newMemoryRepository()
+	mirrored, err := relocation.Relocate(ctx, vendored, mirror, relocated.Component.Name, "1.0.0",
+		relocation.PrefixRename("ocm.example.org/vendor", "mirror.example.org"), relocation.WithReferenceClosure())
+	r.NoError(err)
+	r.Equal("mirror.example.org/acme/app", mirrored.Component.Name)
+	original, err = relocation.Original(mirrored)
+	r.NoError(err)
+	r.NoError(signing.VerifyDigestMatchesDescriptor(ctx, original, original.Signatures[0], slog.Default()))
+
+	_, err = relocation.Relocate(ctx, upstream, vendored, app.Component.Name, "1.0.0", rename)
+	r.ErrorIs(err, relocation.ErrAlreadyRelocated)
+	_, err = relocation.Original(app)
+	r.ErrorIs(err, relocation.ErrNotRelocated)
+	_, err = relocation.Relocate(ctx, upstream, newMemoryRepository(), app.Component.Name, "1.0.0", relocation.PrefixRename("github.com/other", "ocm.example.org"))
+	r.ErrorContains(err, "is not relocated")
+}
+
+func TestPrefixRename(t *testing.T) {
+	tests := []struct {
+		from, to, component, want string
+		ok                        bool
+	}{
+		{"github.com/acme", "ocm.example.org/acme", "github.com/acme/app", "ocm.example.org/acme/app", true},
+		{"github.com/acme/", "ocm.example.org/acme/", "github.com/acme/app", "ocm.example.org/acme/app", true},
+		{"github.com/acme", "ocm.example.org/acme", "github.com/acme", "ocm.example.org/acme", true},
+		{"github.com/acme", "ocm.example.org/acme", "github.com/acme-corp/app", "", false},
+		{"", "ocm.example.org/vendor", "github.com/acme/app", "ocm.example.org/vendor/github.com/acme/app", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.component, func(t *testing.T) {
+			r := require.New(t)
+			got, ok := relocation.PrefixRename(tt.from, tt.to)(tt.component)
+			r.Equal(tt.ok, ok)
+			r.Equal(tt.want, got)
+		})
+	}
+}
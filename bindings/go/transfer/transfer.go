@@ -23,6 +23,11 @@ import (
 // Each [Mapping] pairs source components with a target repository and a
 // resolver, enabling N:M routing where different sources feed different
 // targets.
+//
+// With [transferv1alpha1.TransferModeDelta], every mapping must carry its
+// [Mapping.TargetRepository]. Component versions already present in their
+// target are skipped, and the graph reports the savings in a summary
+// transformation, see [DeltaSummaryFromEvent].
 func BuildGraphDefinition(
 	ctx context.Context,
 	cfg *transferv1alpha1.Config,
@@ -42,6 +47,17 @@ func BuildGraphDefinition(
 	if resolved.UploadType == "" {
 		resolved.UploadType = transferv1alpha1.UploadAsDefault
 	}
+	if resolved.Mode == "" {
+		resolved.Mode = transferv1alpha1.TransferModeFull
+	}
+
+	if resolved.Mode == transferv1alpha1.TransferModeDelta {
+		for i, m := range mappings {
+			if m.TargetRepository == nil {
+				return nil, fmt.Errorf("mapping %d has no target repository, which is required for delta transfers", i)
+			}
+		}
+	}
 
 	roots, err := collectTransferRoots(ctx, mappings)
 	if err != nil {
@@ -52,7 +68,8 @@ func BuildGraphDefinition(
 		"roots", len(roots),
 		"recursive", resolved.Recursive,
 		"copyMode", resolved.CopyMode,
-		"uploadType", resolved.UploadType)
+		"uploadType", resolved.UploadType,
+		"mode", resolved.Mode)
 
 	return internal.BuildGraphDefinition(ctx, roots, resolved)
 }
@@ -63,8 +80,9 @@ func collectTransferRoots(ctx context.Context, mappings []Mapping) (map[string]i
 	}
 
 	type rootData struct {
-		targets  []runtime.Typed
-		resolver resolvers.ComponentVersionRepositoryResolver
+		targets     []runtime.Typed
+		targetRepos []internal.TargetRepository
+		resolver    resolvers.ComponentVersionRepositoryResolver
 	}
 
 	byKey := make(map[string]*rootData)
@@ -97,15 +115,19 @@ func collectTransferRoots(ctx context.Context, mappings []Mapping) (map[string]i
 				return nil, fmt.Errorf("conflicting resolvers for component %s: each component must use the same resolver across all mappings", key)
 			}
 			rd.targets = internal.AppendUniqueRepositories(rd.targets, []runtime.Typed{m.Target})
+			if m.TargetRepository != nil {
+				rd.targetRepos = append(rd.targetRepos, internal.TargetRepository{Spec: m.Target, Repository: m.TargetRepository})
+			}
 		}
 	}
 
 	roots := make(map[string]internal.TransferRoot, len(byKey))
 	for key, rd := range byKey {
 		roots[key] = internal.TransferRoot{
-			RootComponentKey: key,
-			Targets:          rd.targets,
-			SourceResolver:   rd.resolver,
+			RootComponentKey:   key,
+			Targets:            rd.targets,
+			SourceResolver:     rd.resolver,
+			TargetRepositories: rd.targetRepos,
 		}
 	}
 	return roots, nil
@@ -14,7 +14,13 @@ import (
 	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/transfer/internal"
 	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/transform/graph"
+	"ocm.software/open-component-model/bindings/go/transform/graph/builder"
+	graphruntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1/meta"
 )
 
 // --- mock types ---
@@ -649,3 +655,106 @@ func TestResolveMapping_Conflict(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "cannot combine")
 }
+
+type listingCVRepo struct {
+	mockCVRepo
+}
+
+func (m *listingCVRepo) ListComponentVersions(_ context.Context, component string) ([]string, error) {
+	var versions []string
+	for _, d := range m.descriptors {
+		if d.Component.Name == component {
+			versions = append(versions, d.Component.Version)
+		}
+	}
+	return versions, nil
+}
+
+func TestBuildGraphDefinition_Delta(t *testing.T) {
+	r := require.New(t)
+	sourceRepo := testOCITarget("ghcr.io/source")
+	targetRepo := testOCITarget("ghcr.io/target")
+	descA := testDescriptor("ocm.software/a", "1.0.0", nil)
+	descB := testDescriptor("ocm.software/b", "1.0.0", nil)
+	resolver := testMultiComponentResolver(map[string]struct {
+		spec runtime.Typed
+		desc *descriptor.Descriptor
+	}{
+		"ocm.software/a:1.0.0": {spec: sourceRepo, desc: descA},
+		"ocm.software/b:1.0.0": {spec: sourceRepo, desc: descB},
+	})
+	target := &listingCVRepo{mockCVRepo{descriptors: map[string]*descriptor.Descriptor{"ocm.software/a:1.0.0": descA}}}
+	mapping := Mapping{
+		Components: []ComponentID{
+			{Component: "ocm.software/a", Version: "1.0.0"},
+			{Component: "ocm.software/b", Version: "1.0.0"},
+		},
+		Target:   targetRepo,
+		Resolver: resolver,
+	}
+	cfg := &transferv1alpha1.Config{Mode: transferv1alpha1.TransferModeDelta}
+
+	_, err := BuildGraphDefinition(t.Context(), cfg, mapping)
+	r.ErrorContains(err, "no target repository")
+
+	mapping.TargetRepository = target
+	tgd, err := BuildGraphDefinition(t.Context(), cfg, mapping)
+	r.NoError(err)
+
+	var summary *DeltaSummary
+	for _, tr := range tgd.Transformations {
+		s, err := DeltaSummaryFromEvent(graphruntime.ProgressEvent{
+			Transformation: &graph.Transformation{GenericTransformation: tr},
+			State:          graphruntime.Completed,
+		})
+		r.NoError(err)
+		if s != nil {
+			summary = s
+		} else {
+			r.NotContains(tr.ID, "OcmSoftwareA", "component a is already present in the target")
+		}
+	}
+	r.NotNil(summary)
+	r.Equal(1, summary.CopiedComponentVersions)
+	r.Equal(1, summary.SkippedComponentVersions)
+	r.Equal([]string{"ocm.software/a:1.0.0"}, summary.Skipped)
+}
+
+func TestProcess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterWithAlias(&internal.DeltaSummaryTransformation{}, internal.DeltaSummaryVersionedType)
+	newBuilder := func() *builder.Builder {
+		return builder.NewBuilder(scheme).WithTransformer(&internal.DeltaSummaryTransformation{}, &internal.DeltaSummaryReporter{Scheme: scheme})
+	}
+	tgd := &transformv1alpha1.TransformationGraphDefinition{Transformations: []transformv1alpha1.GenericTransformation{{
+		TransformationMeta: meta.TransformationMeta{Type: internal.DeltaSummaryVersionedType, ID: internal.DeltaSummaryID},
+		Spec:               &runtime.Unstructured{Data: map[string]any{"copiedComponentVersions": 1, "skippedComponentVersions": 0, "copiedBytes": 0, "skippedBytes": 0}},
+	}}}
+
+	t.Run("progress receives all events", func(t *testing.T) {
+		r := require.New(t)
+		var states []graphruntime.State
+		r.NoError(Process(t.Context(), newBuilder(), tgd, func(event graphruntime.ProgressEvent) {
+			r.Equal(internal.DeltaSummaryID, event.Transformation.ID)
+			states = append(states, event.State)
+		}))
+		r.Equal([]graphruntime.State{graphruntime.Running, graphruntime.Completed}, states)
+	})
+
+	t.Run("without progress", func(t *testing.T) {
+		r := require.New(t)
+		r.NoError(Process(t.Context(), newBuilder(), tgd, nil))
+	})
+
+	t.Run("missing transformer", func(t *testing.T) {
+		r := require.New(t)
+		var failed int
+		err := Process(t.Context(), builder.NewBuilder(scheme), tgd, func(event graphruntime.ProgressEvent) {
+			if event.State == graphruntime.Failed {
+				failed++
+			}
+		})
+		r.ErrorContains(err, "failed to process transformation graph")
+		r.Equal(1, failed)
+	})
+}
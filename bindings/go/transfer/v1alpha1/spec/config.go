@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	overridespec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime/selector"
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -58,13 +60,36 @@ type Config struct {
 	// embedded as local blobs within the component descriptor or uploaded as separate OCI artifacts
 	// with their own repository references.
 	UploadType UploadType `json:"uploadType,omitempty"`
+
+	// Resources narrows down the resources whose content is copied when [CopyModeAllResources] is set.
+	// Resources that are not selected are transferred by reference and keep their original access.
+	// Local blob resources are always copied, since their content is stored with the component version.
+	// Size thresholds cannot be used, because the size of a resource is only known after fetching it.
+	Resources *selector.ResourceFilter `json:"resources,omitempty"`
+
+	// Mode determines whether component versions already present in the target repository are
+	// transferred again. With [TransferModeDelta], only the component versions whose content is
+	// missing in the target are transferred. See [TransferMode].
+	Mode TransferMode `json:"mode,omitempty"`
+
+	// Overrides remap component references to substitute component versions while transferring,
+	// e.g. to ship a patched child component with unchanged parents. The references of the transferred
+	// component versions are rewritten, and the original references are recorded as deviations.
+	// See the configuration type overrides.config.ocm.software for the semantics of the entries.
+	Overrides []overridespec.Override `json:"overrides,omitempty"`
+
+	// DigestPinning determines how OCI artifact accesses that reference a tag without a digest are
+	// treated, and pins the image references of OCI artifacts copied by value into an OCI target with
+	// [UploadAsOciArtifact] to the digest of the artifact. By default, accesses are transferred as they are.
+	// See [DigestPinning].
+	DigestPinning DigestPinning `json:"digestPinning,omitempty"`
 }
 
 // Validate rejects a non-matching [Config.Type] and unknown enum values.
 // An empty Type is allowed so callers constructing a Config programmatically
 // (without going through [Scheme.Decode]) do not need to set it explicitly.
 // Empty enum fields are allowed; consumers resolve them to their defaults
-// ([CopyModeLocalBlobResources], [UploadAsDefault]) at the point of use.
+// ([CopyModeLocalBlobResources], [UploadAsDefault], [TransferModeFull]) at the point of use.
 func (cfg *Config) Validate() error {
 	if cfg == nil {
 		return nil
@@ -96,6 +121,24 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid uploadType %q (must be one of %q, %q, %q)",
 			cfg.UploadType, UploadAsDefault, UploadAsLocalBlob, UploadAsOciArtifact)
 	}
+	switch cfg.Mode {
+	case "", TransferModeFull, TransferModeDelta:
+	default:
+		return fmt.Errorf("invalid mode %q (must be one of %q, %q)",
+			cfg.Mode, TransferModeFull, TransferModeDelta)
+	}
+	switch cfg.DigestPinning {
+	case "", DigestPinningEnforce, DigestPinningResolve:
+	default:
+		return fmt.Errorf("invalid digestPinning %q (must be one of %q, %q)",
+			cfg.DigestPinning, DigestPinningEnforce, DigestPinningResolve)
+	}
+	if err := cfg.Resources.Validate(); err != nil {
+		return fmt.Errorf("invalid resources filter: %w", err)
+	}
+	if err := (&overridespec.Config{Overrides: cfg.Overrides}).Validate(); err != nil {
+		return fmt.Errorf("invalid overrides: %w", err)
+	}
 	return nil
 }
 
@@ -127,8 +170,8 @@ func LookupConfig(cfg *genericv1.Config) (*Config, error) {
 }
 
 // Merge merges the provided configs into a single config. Later entries win:
-// a non-empty CopyMode or UploadType and a non-zero Recursive override
-// whatever earlier entries set. An explicit "recursive: 0" cannot be
+// a non-empty CopyMode, UploadType, Mode or DigestPinning, a non-nil Resources filter and a non-zero
+// Recursive override whatever earlier entries set. Overrides of all entries are appended. An explicit "recursive: 0" cannot be
 // distinguished from an omitted field; both leave the default of no recursion.
 func Merge(configs ...*Config) *Config {
 	if len(configs) == 0 {
@@ -150,6 +193,16 @@ func Merge(configs ...*Config) *Config {
 		if cfg.UploadType != "" {
 			merged.UploadType = cfg.UploadType
 		}
+		if cfg.Mode != "" {
+			merged.Mode = cfg.Mode
+		}
+		if cfg.Resources != nil {
+			merged.Resources = cfg.Resources.DeepCopy()
+		}
+		if cfg.DigestPinning != "" {
+			merged.DigestPinning = cfg.DigestPinning
+		}
+		merged.Overrides = append(merged.Overrides, cfg.Overrides...)
 	}
 	return merged
 }
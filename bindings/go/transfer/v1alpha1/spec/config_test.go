@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	overridespec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime/selector"
 	"ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
 )
 
@@ -88,8 +90,15 @@ func TestConfig_Validate(t *testing.T) {
 		{"valid uploadType localBlob", spec.Config{UploadType: spec.UploadAsLocalBlob}, ""},
 		{"invalid copyMode", spec.Config{CopyMode: "garbage"}, "invalid copyMode"},
 		{"invalid uploadType", spec.Config{UploadType: "garbage"}, "invalid uploadType"},
+		{"valid mode delta", spec.Config{Mode: spec.TransferModeDelta}, ""},
+		{"invalid mode", spec.Config{Mode: "garbage"}, "invalid mode"},
+		{"valid digestPinning resolve", spec.Config{DigestPinning: spec.DigestPinningResolve}, ""},
+		{"invalid digestPinning", spec.Config{DigestPinning: "garbage"}, "invalid digestPinning"},
 		{"recursive depth not implemented", spec.Config{Recursive: 3}, "not implemented"},
 		{"invalid recursive below -1", spec.Config{Recursive: -5}, "invalid recursive"},
+		{"valid resources filter", spec.Config{Resources: &selector.ResourceFilter{Include: []selector.ResourceSelector{{Types: []string{"ociImage"}}}}}, ""},
+		{"invalid resources filter", spec.Config{Resources: &selector.ResourceFilter{Exclude: []selector.ResourceSelector{{AccessTypes: []string{"a/b/c"}}}}}, "invalid resources filter"},
+		{"invalid override", spec.Config{Overrides: []overridespec.Override{{Component: "ocm.software/a"}}}, "invalid overrides"},
 	}
 
 	for _, tc := range tests {
@@ -110,14 +119,32 @@ func TestMerge(t *testing.T) {
 	})
 
 	t.Run("later non-empty fields win", func(t *testing.T) {
-		a := &spec.Config{Recursive: spec.RecursiveInfinite, CopyMode: spec.CopyModeLocalBlobResources, UploadType: spec.UploadAsLocalBlob}
-		b := &spec.Config{CopyMode: spec.CopyModeAllResources}
+		a := &spec.Config{Recursive: spec.RecursiveInfinite, CopyMode: spec.CopyModeLocalBlobResources, UploadType: spec.UploadAsLocalBlob, Mode: spec.TransferModeDelta, DigestPinning: spec.DigestPinningEnforce}
+		b := &spec.Config{CopyMode: spec.CopyModeAllResources, DigestPinning: spec.DigestPinningResolve}
 
 		merged := spec.Merge(a, b)
 
 		assert.Equal(t, spec.RecursiveInfinite, merged.Recursive)
 		assert.Equal(t, spec.CopyModeAllResources, merged.CopyMode)
 		assert.Equal(t, spec.UploadAsLocalBlob, merged.UploadType)
+		assert.Equal(t, spec.TransferModeDelta, merged.Mode)
+		assert.Equal(t, spec.DigestPinningResolve, merged.DigestPinning)
+		assert.Equal(t, spec.TransferModeFull, spec.Merge(a, &spec.Config{Mode: spec.TransferModeFull}).Mode)
+	})
+
+	t.Run("later resources filter wins", func(t *testing.T) {
+		a := &spec.Config{Resources: &selector.ResourceFilter{Include: []selector.ResourceSelector{{Types: []string{"ociImage"}}}}}
+		b := &spec.Config{Resources: &selector.ResourceFilter{Include: []selector.ResourceSelector{{Types: []string{"helmChart"}}}}}
+
+		assert.Equal(t, b.Resources, spec.Merge(a, b).Resources)
+		assert.Equal(t, a.Resources, spec.Merge(a, &spec.Config{}).Resources)
+	})
+
+	t.Run("overrides are appended", func(t *testing.T) {
+		a := &spec.Config{Overrides: []overridespec.Override{{Component: "ocm.software/a", Substitute: overridespec.Substitute{Version: "2.0.0"}}}}
+		b := &spec.Config{Overrides: []overridespec.Override{{Component: "ocm.software/b", Substitute: overridespec.Substitute{Version: "3.0.0"}}}}
+
+		assert.Equal(t, append(a.Overrides, b.Overrides...), spec.Merge(a, b).Overrides)
 	})
 
 	t.Run("nil element is skipped", func(t *testing.T) {
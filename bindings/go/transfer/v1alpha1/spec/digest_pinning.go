@@ -0,0 +1,22 @@
+package spec
+
+// DigestPinning determines how resources are transferred whose OCI artifact access references
+// a mutable tag without a digest, e.g. "ghcr.io/acme/app:1.0.0". With both values, the image
+// references of OCI artifacts that are copied by value into an OCI target are pinned to the digest
+// of the copied artifact, e.g. "ghcr.io/target/app:1.0.0@sha256:...", so that consumers of the target
+// get exactly the artifact that was signed with the component version.
+// +ocm:jsonschema-gen:enum=enforce,resolve
+type DigestPinning string
+
+const (
+	// DigestPinningEnforce refuses to transfer component versions with OCI artifact accesses that
+	// are not pinned to a digest, regardless of whether the resources are copied or transferred by reference.
+	DigestPinningEnforce DigestPinning = "enforce"
+
+	// DigestPinningResolve resolves the tags of OCI artifact accesses that are not pinned to a digest
+	// against the registry and pins the accesses to the resolved digest. The tag has to reference the
+	// artifact described by the resource digest, which is covered by the signatures of the component
+	// version. The transferred component version carries the pinned access, and the original image
+	// reference is recorded in the non-signing resource label "ocm.software/oci/pinned-digest".
+	DigestPinningResolve DigestPinning = "resolve"
+)
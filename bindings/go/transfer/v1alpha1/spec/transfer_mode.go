@@ -0,0 +1,19 @@
+package spec
+
+// TransferMode determines whether component versions that are already present in the target
+// repository are transferred again.
+// +ocm:jsonschema-gen:enum=full,delta
+type TransferMode string
+
+const (
+	// TransferModeFull is the default transfer mode. Every component version is transferred,
+	// regardless of the content already present in the target repository.
+	TransferModeFull TransferMode = "full"
+
+	// TransferModeDelta transfers only the component versions whose content is not yet present
+	// in the target repository. Before the transfer, the target is checked for the component
+	// versions, and a component version is skipped if the digests of its resources and component
+	// references match the ones already present in the target. The savings are reported in a
+	// summary transformation of the graph.
+	TransferModeDelta TransferMode = "delta"
+)
@@ -6,6 +6,8 @@
 package spec
 
 import (
+	overridesv1alpha1spec "ocm.software/open-component-model/bindings/go/configuration/overrides/v1alpha1/spec"
+	selector "ocm.software/open-component-model/bindings/go/descriptor/runtime/selector"
 	runtime "ocm.software/open-component-model/bindings/go/runtime"
 )
 
@@ -13,6 +15,16 @@ import (
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
 	out.Type = in.Type
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(selector.ResourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]overridesv1alpha1spec.Override, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -15,9 +15,15 @@ var schemaConfig []byte
 //go:embed schemas/CopyMode.schema.json
 var schemaCopyMode []byte
 
+//go:embed schemas/DigestPinning.schema.json
+var schemaDigestPinning []byte
+
 //go:embed schemas/Recursive.schema.json
 var schemaRecursive []byte
 
+//go:embed schemas/TransferMode.schema.json
+var schemaTransferMode []byte
+
 //go:embed schemas/UploadType.schema.json
 var schemaUploadType []byte
 
@@ -31,11 +37,21 @@ func (CopyMode) JSONSchema() []byte {
 	return schemaCopyMode
 }
 
+// JSONSchema returns the JSON Schema for DigestPinning.
+func (DigestPinning) JSONSchema() []byte {
+	return schemaDigestPinning
+}
+
 // JSONSchema returns the JSON Schema for Recursive.
 func (Recursive) JSONSchema() []byte {
 	return schemaRecursive
 }
 
+// JSONSchema returns the JSON Schema for TransferMode.
+func (TransferMode) JSONSchema() []byte {
+	return schemaTransferMode
+}
+
 // JSONSchema returns the JSON Schema for UploadType.
 func (UploadType) JSONSchema() []byte {
 	return schemaUploadType
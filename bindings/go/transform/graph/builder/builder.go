@@ -9,9 +9,11 @@ import (
 	"ocm.software/open-component-model/bindings/go/dag"
 	syncdag "ocm.software/open-component-model/bindings/go/dag/sync"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
 	"ocm.software/open-component-model/bindings/go/transform/graph"
 	"ocm.software/open-component-model/bindings/go/transform/graph/analysis"
 	graphEnv "ocm.software/open-component-model/bindings/go/transform/graph/env"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
 	graphRuntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
 	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
 )
@@ -20,6 +22,10 @@ type Builder struct {
 	scheme       *runtime.Scheme
 	transformers map[runtime.Type]graphRuntime.Transformer
 	events       chan graphRuntime.ProgressEvent
+	journal      *journal.Journal
+	status       *status.Writer
+	operation    string
+	concurrency  int
 }
 
 func NewBuilder(scheme *runtime.Scheme) *Builder {
@@ -83,6 +89,10 @@ func (b *Builder) BuildAndCheck(original *v1alpha1.TransformationGraphDefinition
 		checked:      g,
 		transformers: b.transformers,
 		events:       b.events,
+		journal:      b.journal,
+		status:       b.status,
+		operation:    b.operation,
+		concurrency:  max(b.concurrency, 1),
 	}, nil
 }
 
@@ -110,9 +120,21 @@ type Graph struct {
 	checked      *dag.DirectedAcyclicGraph[string]
 	transformers map[runtime.Type]graphRuntime.Transformer
 	events       chan graphRuntime.ProgressEvent
+	journal      *journal.Journal
+	status       *status.Writer
+	operation    string
+	concurrency  int
 }
 
 func (g *Graph) Process(ctx context.Context) error {
+	unneeded, err := unneededTransformations(g.checked, g.journal)
+	if err != nil {
+		if g.events != nil {
+			close(g.events)
+		}
+		return err
+	}
+
 	synced := syncdag.ToSyncedGraph(g.checked)
 	runtimeEvaluationProcessor := syncdag.NewGraphProcessor(synced, &syncdag.GraphProcessorOptions[string, graph.Transformation]{
 		Processor: &graphRuntime.Runtime{
@@ -121,11 +143,15 @@ func (g *Graph) Process(ctx context.Context) error {
 			EvaluatedExpressionCache: make(map[string]any),
 			EvaluatedTransformations: make(map[string]any),
 			Events:                   g.events,
+			Journal:                  g.journal,
+			Unneeded:                 unneeded,
+			Status:                   g.status,
+			StatusOperation:          g.operation,
 		},
-		Concurrency: 1,
+		Concurrency: g.concurrency,
 	})
 
-	err := runtimeEvaluationProcessor.Process(ctx)
+	err = runtimeEvaluationProcessor.Process(ctx)
 	if g.events != nil {
 		close(g.events)
 	}
@@ -140,6 +166,34 @@ func (b *Builder) WithEvents(events chan graphRuntime.ProgressEvent) *Builder {
 	return b
 }
 
+// WithJournal sets the journal in which every transformation is recorded during Process().
+// Transformations recorded as completed in a previous run are not executed again, which allows
+// resuming a failed run. Transformations whose outputs are only consumed by such transformations
+// are not executed again either. Outputs referring to temporary data (such as buffered blobs) are only valid
+// as long as that data is kept between runs.
+// This is optional - if not set, no journal is recorded.
+func (b *Builder) WithJournal(j *journal.Journal) *Builder {
+	b.journal = j
+	return b
+}
+
+// WithStatus sets the writer that receives a machine-readable status record for the start and end of every
+// transformation during Process(), with the given operation (e.g. "transfer") as operation of the records.
+// This is optional - if not set, no status records are written.
+func (b *Builder) WithStatus(w *status.Writer, operation string) *Builder {
+	b.status = w
+	b.operation = operation
+	return b
+}
+
+// WithConcurrency sets the number of transformations that are processed in parallel during Process().
+// Transformations are only processed in parallel if they do not depend on each other.
+// This is optional - if not set, transformations are processed one after another.
+func (b *Builder) WithConcurrency(concurrency int) *Builder {
+	b.concurrency = concurrency
+	return b
+}
+
 // Events returns the channel where progress events are sent during Process().
 func (g *Graph) Events() <-chan graphRuntime.ProgressEvent {
 	return g.events
@@ -149,3 +203,36 @@ func (g *Graph) Events() <-chan graphRuntime.ProgressEvent {
 func (g *Graph) NodeCount() int {
 	return len(g.checked.Vertices)
 }
+
+// unneededTransformations returns the transformations that do not have to be executed, because
+// the journal records all transformations consuming their output as completed in a previous run.
+// Transformations without consumers are always needed, unless they are completed themselves.
+func unneededTransformations(g *dag.DirectedAcyclicGraph[string], j *journal.Journal) (map[string]struct{}, error) {
+	if j == nil {
+		return nil, nil
+	}
+	// the topological order lists consumers before the transformations they consume.
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return nil, err
+	}
+	needed := make(map[string]bool, len(order))
+	unneeded := make(map[string]struct{})
+	for _, id := range order {
+		if _, completed := graphRuntime.Restorable(j, id); completed {
+			continue
+		}
+		consumers := g.Vertices[id].Edges
+		needed[id] = len(consumers) == 0
+		for consumer := range consumers {
+			if needed[consumer] {
+				needed[id] = true
+				break
+			}
+		}
+		if !needed[id] {
+			unneeded[id] = struct{}{}
+		}
+	}
+	return unneeded, nil
+}
@@ -1,11 +1,18 @@
 package builder
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
 	"ocm.software/open-component-model/bindings/go/transform/graph/internal/testutils"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
 	graphRuntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
 	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
 	"sigs.k8s.io/yaml"
@@ -445,3 +452,179 @@ transformations:
 		require.NoError(t, graph.Process(t.Context()))
 	})
 }
+
+func TestBuilder_WithJournal(t *testing.T) {
+	r := require.New(t)
+	path := filepath.Join(t.TempDir(), "transfer.journal")
+
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(`
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "test"
+    version: "1.0.0"
+- id: add1
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get1.output.object}
+`), tgd))
+
+	var statusOutput bytes.Buffer
+	statusWriter := status.NewWriter(&statusOutput)
+
+	j, err := journal.Open(path)
+	r.NoError(err)
+	graph, err := newTestBuilder(t).WithJournal(j).WithStatus(statusWriter, "transfer").BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(graph.Process(t.Context()))
+	r.NoError(j.Close())
+
+	j, err = journal.Open(path)
+	r.NoError(err)
+	t.Cleanup(func() { r.NoError(j.Close()) })
+
+	for _, id := range []string{"get1", "add1"} {
+		entry, ok := j.Completed(graphRuntime.JournalKindTransformation, id)
+		r.True(ok, "transformation %s should be recorded as completed", id)
+		r.NotEmpty(entry.Output)
+	}
+
+	// A builder without any transformers can only process the graph
+	// if all transformations are resumed from the journal.
+	resumed, err := NewBuilder(newTestBuilder(t).scheme).WithJournal(j).WithStatus(statusWriter, "transfer").BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(resumed.Process(t.Context()))
+	r.Len(j.Query(journal.Filter{Outcome: journal.OutcomeSkipped}), 2)
+
+	results := map[string][]status.Result{}
+	scanner := bufio.NewScanner(&statusOutput)
+	for scanner.Scan() {
+		var record status.Record
+		r.NoError(json.Unmarshal(scanner.Bytes(), &record))
+		r.Equal("transfer", record.Operation)
+		r.Equal(graphRuntime.JournalKindTransformation, record.Step)
+		results[record.Item] = append(results[record.Item], record.Result)
+	}
+	for _, id := range []string{"get1", "add1"} {
+		r.Equal([]status.Result{status.ResultStarted, status.ResultSucceeded, status.ResultStarted, status.ResultSkipped}, results[id])
+	}
+}
+
+func TestBuilder_WithJournal_SkipsUnneeded(t *testing.T) {
+	r := require.New(t)
+
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(`
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "test"
+    version: "1.0.0"
+- id: add1
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get1.output.object}
+`), tgd))
+
+	var recorded bytes.Buffer
+	graph, err := newTestBuilder(t).WithJournal(journal.New(&recorded)).BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(graph.Process(t.Context()))
+
+	entries, err := journal.Read(&recorded)
+	r.NoError(err)
+	var previous []journal.Entry
+	for _, entry := range entries {
+		if entry.ID == "add1" {
+			previous = append(previous, entry)
+		}
+	}
+
+	// get1 is only consumed by the completed add1, so a builder without any
+	// transformers can process the graph without executing get1 again.
+	j := journal.New(io.Discard, previous...)
+	resumed, err := NewBuilder(newTestBuilder(t).scheme).WithJournal(j).BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(resumed.Process(t.Context()))
+	r.Len(j.Query(journal.Filter{Outcome: journal.OutcomeSkipped, ID: "add1"}), 1)
+	r.Empty(j.Query(journal.Filter{ID: "get1"}))
+}
+
+func TestBuilder_WithJournal_ReexecutesMissingFiles(t *testing.T) {
+	r := require.New(t)
+
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(`
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "test"
+    version: "1.0.0"
+- id: add1
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get1.output.object}
+`), tgd))
+
+	// get1 completed in a previous run that buffered its output in a file that was removed since.
+	missing := filepath.Join(t.TempDir(), "removed")
+	previous := journal.New(io.Discard)
+	step, err := previous.Start(graphRuntime.JournalKindTransformation, "get1")
+	r.NoError(err)
+	r.NoError(step.End(nil, journal.Result{Output: json.RawMessage(`{"output":{"file":"` + missing + `"}}`)}))
+	_, restorable := graphRuntime.Restorable(previous, "get1")
+	r.False(restorable)
+
+	j := journal.New(io.Discard, previous.Query(journal.Filter{})...)
+	graph, err := newTestBuilder(t).WithJournal(j).BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(graph.Process(t.Context()))
+	r.Empty(j.Query(journal.Filter{Outcome: journal.OutcomeSkipped}))
+	r.Len(j.Query(journal.Filter{Outcome: journal.OutcomeSucceeded, ID: "get1"}), 2)
+}
+
+func TestBuilder_WithConcurrency(t *testing.T) {
+	r := require.New(t)
+
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(`
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "test1"
+    version: "1.0.0"
+- id: get2
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "test2"
+    version: "1.0.0"
+- id: add1
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get1.output.object}
+- id: add2
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get2.output.object}
+`), tgd))
+
+	events := make(chan graphRuntime.ProgressEvent, 16)
+	graph, err := newTestBuilder(t).WithEvents(events).WithConcurrency(4).BuildAndCheck(tgd)
+	r.NoError(err)
+	r.Equal(4, graph.concurrency)
+	r.NoError(graph.Process(t.Context()))
+
+	completed := 0
+	for event := range events {
+		r.NoError(event.Err)
+		if event.State == graphRuntime.Completed {
+			completed++
+		}
+	}
+	r.Equal(4, completed)
+}
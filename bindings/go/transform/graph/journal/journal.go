@@ -0,0 +1,312 @@
+// Package journal records the steps of long-running graph processing runs
+// (such as component construction or transfer) as a structured, append-only
+// file of JSON lines.
+//
+// Every step is identified by a kind (e.g. "component", "resource" or "transformation")
+// and an ID that is unique within that kind. A step is recorded when it starts and
+// when it ends, together with its attempt number, duration, digest, error and output.
+//
+// A journal that is opened on an existing file keeps all previous entries. Processors
+// can use Completed to look up steps that succeeded in a previous run and resume
+// a failed run from there instead of starting from scratch:
+//
+//	j, err := journal.Open("construct.journal")
+//	if err != nil {
+//	    return err
+//	}
+//	defer j.Close()
+//
+//	if entry, ok := j.Completed("component", id); ok {
+//	    // reuse entry.Digest / entry.Output
+//	}
+//	step, err := j.Start("component", id)
+//	if err != nil {
+//	    return err
+//	}
+//	err = construct(ctx)
+//	return errors.Join(err, step.End(err))
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Outcome describes the state of a step recorded in an Entry.
+type Outcome string
+
+const (
+	// OutcomeStarted is recorded when a step starts.
+	OutcomeStarted Outcome = "started"
+	// OutcomeSucceeded is recorded when a step ends without error.
+	OutcomeSucceeded Outcome = "succeeded"
+	// OutcomeFailed is recorded when a step ends with an error.
+	OutcomeFailed Outcome = "failed"
+	// OutcomeSkipped is recorded when a step is not executed because it
+	// already succeeded in a previous run.
+	OutcomeSkipped Outcome = "skipped"
+)
+
+// Entry is a single record in the journal.
+type Entry struct {
+	// Time is the time at which the entry was recorded.
+	Time time.Time `json:"time"`
+	// Kind is the kind of the step, e.g. "component" or "transformation".
+	Kind string `json:"kind"`
+	// ID identifies the step within its Kind.
+	ID string `json:"id"`
+	// Outcome is the state of the step.
+	Outcome Outcome `json:"outcome"`
+	// Attempt counts how often the step was started, including previous runs.
+	Attempt int `json:"attempt"`
+	// Duration is the time the step took. It is only set for finished steps.
+	Duration time.Duration `json:"duration,omitempty"`
+	// Digest is the digest of the content produced by the step, if any.
+	Digest string `json:"digest,omitempty"`
+	// Error is the error message of a failed step.
+	Error string `json:"error,omitempty"`
+	// Output is the result of the step that is needed to resume a run
+	// without executing the step again.
+	Output json.RawMessage `json:"output,omitempty"`
+}
+
+// Filter selects entries in Query. Empty fields match all entries.
+type Filter struct {
+	Kind    string
+	ID      string
+	Outcome Outcome
+}
+
+func (f Filter) matches(e Entry) bool {
+	return (f.Kind == "" || f.Kind == e.Kind) &&
+		(f.ID == "" || f.ID == e.ID) &&
+		(f.Outcome == "" || f.Outcome == e.Outcome)
+}
+
+type key struct {
+	kind, id string
+}
+
+// Journal records entries to an underlying writer.
+// It is safe for concurrent use.
+type Journal struct {
+	mu        sync.Mutex
+	w         io.Writer
+	closer    io.Closer
+	entries   []Entry
+	attempts  map[key]int
+	completed map[key]Entry
+
+	// now is used to determine entry times and durations.
+	now func() time.Time
+}
+
+// New creates a Journal that writes new entries to w.
+// previous are entries of earlier runs (see Read) that are taken into account
+// for Completed, Query and attempt counting, but are not written to w again.
+func New(w io.Writer, previous ...Entry) *Journal {
+	j := &Journal{
+		w:         w,
+		attempts:  make(map[key]int),
+		completed: make(map[key]Entry),
+		now:       time.Now,
+	}
+	for _, e := range previous {
+		j.track(e)
+	}
+	return j
+}
+
+// Open opens the journal file at path for appending, creating it if it does not exist.
+// All entries already present in the file are loaded so that a failed run can be resumed.
+func Open(path string) (_ *Journal, err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open journal %q: %w", path, err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, f.Close())
+		}
+	}()
+
+	previous, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read journal %q: %w", path, err)
+	}
+
+	j := New(f, previous...)
+	j.closer = f
+	return j, nil
+}
+
+// Read reads all entries from a journal.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("invalid journal entry at line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Start records the start of a step and returns a Step to record its end.
+func (j *Journal) Start(kind, id string) (*Step, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	e := Entry{
+		Time:    now,
+		Kind:    kind,
+		ID:      id,
+		Outcome: OutcomeStarted,
+		Attempt: j.attempts[key{kind, id}] + 1,
+	}
+	if err := j.record(e); err != nil {
+		return nil, err
+	}
+	return &Step{journal: j, kind: kind, id: id, attempt: e.Attempt, start: now}, nil
+}
+
+// Skip records that a step was not executed because it already completed in a previous run.
+func (j *Journal) Skip(kind, id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.record(Entry{
+		Time:    j.now(),
+		Kind:    kind,
+		ID:      id,
+		Outcome: OutcomeSkipped,
+		Attempt: j.attempts[key{kind, id}],
+	})
+}
+
+// Completed returns the latest successful entry of a step, if the step succeeded
+// in this or any previous run.
+func (j *Journal) Completed(kind, id string) (Entry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	e, ok := j.completed[key{kind, id}]
+	return e, ok
+}
+
+// Query returns all entries matching the filter in the order in which they were recorded.
+func (j *Journal) Query(filter Filter) []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var result []Entry
+	for _, e := range j.entries {
+		if filter.matches(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Recent returns the latest n entries in the order in which they were recorded.
+// Entries of previous runs are included if this run recorded fewer than n entries.
+func (j *Journal) Recent(n int) []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if n <= 0 {
+		return nil
+	}
+	return slices.Clone(j.entries[max(len(j.entries)-n, 0):])
+}
+
+// Close closes the underlying file if the journal was created with Open.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closer == nil {
+		return nil
+	}
+	return j.closer.Close()
+}
+
+// record writes the entry and tracks it. j.mu must be held.
+func (j *Journal) record(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("unable to encode journal entry for %s %q: %w", e.Kind, e.ID, err)
+	}
+	if _, err := j.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("unable to write journal entry for %s %q: %w", e.Kind, e.ID, err)
+	}
+	j.track(e)
+	return nil
+}
+
+func (j *Journal) track(e Entry) {
+	k := key{e.Kind, e.ID}
+	j.entries = append(j.entries, e)
+	switch e.Outcome {
+	case OutcomeStarted:
+		j.attempts[k] = max(j.attempts[k], e.Attempt)
+	case OutcomeSucceeded:
+		j.completed[k] = e
+	}
+}
+
+// Step is a started step of a Journal.
+type Step struct {
+	journal *Journal
+	kind    string
+	id      string
+	attempt int
+	start   time.Time
+}
+
+// Result contains optional information about a successfully finished step.
+type Result struct {
+	// Digest is the digest of the content produced by the step.
+	Digest string
+	// Output is the data needed to resume a run without executing the step again.
+	Output json.RawMessage
+}
+
+// End records the end of the step. If err is nil, the step is recorded as succeeded
+// with the optional result, otherwise it is recorded as failed.
+func (s *Step) End(err error, result ...Result) error {
+	s.journal.mu.Lock()
+	defer s.journal.mu.Unlock()
+
+	now := s.journal.now()
+	e := Entry{
+		Time:     now,
+		Kind:     s.kind,
+		ID:       s.id,
+		Outcome:  OutcomeSucceeded,
+		Attempt:  s.attempt,
+		Duration: now.Sub(s.start),
+	}
+	if err != nil {
+		e.Outcome = OutcomeFailed
+		e.Error = err.Error()
+	} else {
+		for _, r := range result {
+			e.Digest = r.Digest
+			e.Output = r.Output
+		}
+	}
+	return s.journal.record(e)
+}
@@ -0,0 +1,110 @@
+package journal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+)
+
+func TestJournal_RecordAndQuery(t *testing.T) {
+	r := require.New(t)
+
+	var buf bytes.Buffer
+	j := journal.New(&buf)
+
+	step, err := j.Start("resource", "a")
+	r.NoError(err)
+	r.NoError(step.End(nil, journal.Result{Digest: "sha256:abc"}))
+
+	step, err = j.Start("resource", "b")
+	r.NoError(err)
+	r.NoError(step.End(errors.New("boom")))
+
+	entry, ok := j.Completed("resource", "a")
+	r.True(ok)
+	r.Equal("sha256:abc", entry.Digest)
+	r.Equal(1, entry.Attempt)
+
+	_, ok = j.Completed("resource", "b")
+	r.False(ok)
+
+	failed := j.Query(journal.Filter{Outcome: journal.OutcomeFailed})
+	r.Len(failed, 1)
+	r.Equal("b", failed[0].ID)
+	r.Equal("boom", failed[0].Error)
+
+	r.Len(j.Query(journal.Filter{Kind: "resource"}), 4)
+
+	recent := j.Recent(2)
+	r.Len(recent, 2)
+	r.Equal("b", recent[0].ID)
+	r.Equal(journal.OutcomeStarted, recent[0].Outcome)
+	r.Equal(journal.OutcomeFailed, recent[1].Outcome)
+	r.Len(j.Recent(10), 4)
+	r.Empty(j.Recent(0))
+
+	written, err := journal.Read(&buf)
+	r.NoError(err)
+	r.Len(written, 4)
+	for i, e := range j.Query(journal.Filter{}) {
+		r.Equal(e.ID, written[i].ID)
+		r.Equal(e.Outcome, written[i].Outcome)
+		r.Equal(e.Duration, written[i].Duration)
+		r.True(e.Time.Equal(written[i].Time))
+	}
+}
+
+func TestJournal_Resume(t *testing.T) {
+	r := require.New(t)
+	path := filepath.Join(t.TempDir(), "run.journal")
+
+	j, err := journal.Open(path)
+	r.NoError(err)
+	step, err := j.Start("transformation", "download")
+	r.NoError(err)
+	r.NoError(step.End(nil, journal.Result{Output: json.RawMessage(`{"file":"data.tar"}`)}))
+	step, err = j.Start("transformation", "upload")
+	r.NoError(err)
+	r.NoError(step.End(errors.New("connection reset")))
+	r.NoError(j.Close())
+
+	j, err = journal.Open(path)
+	r.NoError(err)
+	t.Cleanup(func() { r.NoError(j.Close()) })
+
+	entry, ok := j.Completed("transformation", "download")
+	r.True(ok)
+	r.JSONEq(`{"file":"data.tar"}`, string(entry.Output))
+	r.NoError(j.Skip("transformation", "download"))
+
+	_, ok = j.Completed("transformation", "upload")
+	r.False(ok)
+	step, err = j.Start("transformation", "upload")
+	r.NoError(err)
+	r.NoError(step.End(nil))
+
+	uploads := j.Query(journal.Filter{ID: "upload", Outcome: journal.OutcomeSucceeded})
+	r.Len(uploads, 1)
+	r.Equal(2, uploads[0].Attempt)
+
+	f, err := os.Open(path)
+	r.NoError(err)
+	defer f.Close()
+	entries, err := journal.Read(f)
+	r.NoError(err)
+	r.Len(entries, 7)
+	r.Equal(journal.OutcomeSkipped, entries[4].Outcome)
+}
+
+func TestRead_InvalidEntry(t *testing.T) {
+	r := require.New(t)
+	_, err := journal.Read(bytes.NewBufferString("{\"kind\":\"component\"}\nnot-json\n"))
+	r.ErrorContains(err, "invalid journal entry at line 2")
+}
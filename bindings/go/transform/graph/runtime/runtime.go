@@ -2,15 +2,20 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"sync"
 
 	"github.com/google/cel-go/cel"
 	"github.com/santhosh-tekuri/jsonschema/v6"
 
 	stv6jsonschema "ocm.software/open-component-model/bindings/go/cel/jsonschema/santhosh-tekuri/v6"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/status"
 	"ocm.software/open-component-model/bindings/go/transform/graph"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
 	"ocm.software/open-component-model/bindings/go/transform/graph/runtime/resolver"
 	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
 )
@@ -54,6 +59,9 @@ type ProgressEvent struct {
 	Err            error
 }
 
+// JournalKindTransformation is the kind of the steps recorded in Runtime.Journal.
+const JournalKindTransformation = "transformation"
+
 type Runtime struct {
 	Environment              *cel.Env
 	EvaluatedExpressionCache map[string]any
@@ -61,6 +69,25 @@ type Runtime struct {
 
 	Transformers map[runtime.Type]Transformer
 	Events       chan<- ProgressEvent
+
+	// Journal optionally records every processed transformation together with its evaluated output.
+	// Transformations that the journal records as completed in a previous run are not executed again,
+	// their recorded output is reused instead.
+	Journal *journal.Journal
+
+	// Unneeded are the IDs of transformations that are not executed, because all transformations
+	// consuming their output are restored from the Journal. They are recorded as skipped.
+	Unneeded map[string]struct{}
+
+	// Status optionally receives a status record for the start and end of every processed transformation,
+	// with StatusOperation as operation, JournalKindTransformation as step and the transformation ID as item.
+	// Transformations restored from the journal are recorded as skipped.
+	Status          *status.Writer
+	StatusOperation string
+
+	// mu guards EvaluatedExpressionCache and EvaluatedTransformations,
+	// so that independent transformations can be processed concurrently.
+	mu sync.Mutex
 }
 
 func (b *Runtime) ProcessValue(ctx context.Context, transformation graph.Transformation) error {
@@ -68,7 +95,14 @@ func (b *Runtime) ProcessValue(ctx context.Context, transformation graph.Transfo
 	if b.Events != nil {
 		b.Events <- ProgressEvent{Transformation: t, State: Running}
 	}
-	if err := b.processTransformation(ctx, transformation); err != nil {
+	endStatus := b.Status.Start(b.StatusOperation, JournalKindTransformation, transformation.ID)
+	skipped, err := b.processJournaledTransformation(ctx, transformation)
+	if skipped && err == nil {
+		b.Status.Skip(b.StatusOperation, JournalKindTransformation, transformation.ID)
+	} else {
+		endStatus(err)
+	}
+	if err != nil {
 		if b.Events != nil {
 			b.Events <- ProgressEvent{Transformation: t, State: Failed, Err: err}
 		}
@@ -81,32 +115,71 @@ func (b *Runtime) ProcessValue(ctx context.Context, transformation graph.Transfo
 	return nil
 }
 
-func (b *Runtime) processTransformation(ctx context.Context, transformation graph.Transformation) error {
-	for _, fieldDescriptor := range transformation.FieldDescriptors {
-		for _, expression := range fieldDescriptor.Expressions {
-			if _, found := b.EvaluatedExpressionCache[expression.String()]; found {
-				continue
-			}
-			program, err := b.Environment.Program(expression.AST)
-			if err != nil {
-				return fmt.Errorf("failed to create program for expression %q: %w", expression.String(), err)
-			}
-			result, _, err := program.Eval(b.EvaluatedTransformations)
-			if err != nil {
-				return fmt.Errorf("failed to evaluate expression %q: %w", expression.String(), err)
-			}
+// processJournaledTransformation processes the transformation and records it in the journal if one is configured.
+// If the journal records the transformation as completed in a previous run, its output is restored from the journal
+// and skipped is true. Unneeded transformations are skipped without output.
+func (b *Runtime) processJournaledTransformation(ctx context.Context, transformation graph.Transformation) (skipped bool, _ error) {
+	if _, ok := b.Unneeded[transformation.ID]; ok {
+		return true, nil
+	}
+	if b.Journal == nil {
+		return false, b.processTransformation(ctx, transformation)
+	}
 
-			val, err := GoNativeValue(result)
-			if err != nil {
-				return fmt.Errorf("failed to convert result of expression %q to go native type: %w", expression.String(), err)
-			}
-			b.EvaluatedExpressionCache[expression.String()] = val
+	if entry, ok := Restorable(b.Journal, transformation.ID); ok {
+		var evaluated map[string]any
+		if err := json.Unmarshal(entry.Output, &evaluated); err != nil {
+			return false, fmt.Errorf("failed to restore output of transformation %q from journal: %w", transformation.ID, err)
 		}
+		b.mu.Lock()
+		b.EvaluatedTransformations[transformation.ID] = evaluated
+		b.mu.Unlock()
+		return true, b.Journal.Skip(JournalKindTransformation, transformation.ID)
 	}
-	res := resolver.NewResolver(transformation.Spec.Data, b.EvaluatedExpressionCache, specSubSchema(transformation.Schema))
-	summary := res.Resolve(transformation.FieldDescriptors)
-	if len(summary.Errors) > 0 {
-		return fmt.Errorf("failed to resolve transformation %q: %w", transformation.ID, errors.Join(summary.Errors...))
+
+	step, err := b.Journal.Start(JournalKindTransformation, transformation.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := b.processTransformation(ctx, transformation); err != nil {
+		return false, errors.Join(err, step.End(err))
+	}
+	b.mu.Lock()
+	output, err := json.Marshal(b.EvaluatedTransformations[transformation.ID])
+	b.mu.Unlock()
+	if err != nil {
+		err = fmt.Errorf("failed to encode output of transformation %q for journal: %w", transformation.ID, err)
+		return false, errors.Join(err, step.End(err))
+	}
+	return false, step.End(nil, journal.Result{Output: output})
+}
+
+// Restorable returns the journal entry of a transformation that completed in a previous run
+// with an output that can be restored instead of executing the transformation again.
+// Outputs referring to a file that no longer exists cannot be restored, e.g. blobs buffered in
+// temporary files by a previous run that were removed when it ended. Such transformations are
+// executed again.
+func Restorable(j *journal.Journal, id string) (journal.Entry, bool) {
+	entry, ok := j.Completed(JournalKindTransformation, id)
+	if !ok || len(entry.Output) == 0 {
+		return entry, false
+	}
+	var evaluated struct {
+		Output struct {
+			File string `json:"file"`
+		} `json:"output"`
+	}
+	if err := json.Unmarshal(entry.Output, &evaluated); err == nil && evaluated.Output.File != "" {
+		if _, err := os.Stat(evaluated.Output.File); err != nil {
+			return entry, false
+		}
+	}
+	return entry, true
+}
+
+func (b *Runtime) processTransformation(ctx context.Context, transformation graph.Transformation) error {
+	if err := b.resolveExpressions(transformation); err != nil {
+		return err
 	}
 
 	unstructuredTransformationData := transformation.GenericTransformation.AsUnstructured().Data
@@ -152,7 +225,44 @@ func (b *Runtime) processTransformation(ctx context.Context, transformation grap
 		return fmt.Errorf("transformation %q has unresolved fields after evaluation", transformation.ID)
 	}
 
+	b.mu.Lock()
 	b.EvaluatedTransformations[transformation.ID] = evaluatedTransformation
+	b.mu.Unlock()
+	return nil
+}
+
+// resolveExpressions evaluates the expressions of the transformation against the outputs of the
+// transformations processed so far and resolves them in the transformation.
+func (b *Runtime) resolveExpressions(transformation graph.Transformation) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, fieldDescriptor := range transformation.FieldDescriptors {
+		for _, expression := range fieldDescriptor.Expressions {
+			if _, found := b.EvaluatedExpressionCache[expression.String()]; found {
+				continue
+			}
+			program, err := b.Environment.Program(expression.AST)
+			if err != nil {
+				return fmt.Errorf("failed to create program for expression %q: %w", expression.String(), err)
+			}
+			result, _, err := program.Eval(b.EvaluatedTransformations)
+			if err != nil {
+				return fmt.Errorf("failed to evaluate expression %q: %w", expression.String(), err)
+			}
+
+			val, err := GoNativeValue(result)
+			if err != nil {
+				return fmt.Errorf("failed to convert result of expression %q to go native type: %w", expression.String(), err)
+			}
+			b.EvaluatedExpressionCache[expression.String()] = val
+		}
+	}
+	res := resolver.NewResolver(transformation.Spec.Data, b.EvaluatedExpressionCache, specSubSchema(transformation.Schema))
+	summary := res.Resolve(transformation.FieldDescriptors)
+	if len(summary.Errors) > 0 {
+		return fmt.Errorf("failed to resolve transformation %q: %w", transformation.ID, errors.Join(summary.Errors...))
+	}
 	return nil
 }
 
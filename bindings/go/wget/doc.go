@@ -43,6 +43,51 @@
 // access type, so both behave identically for a given URL and credentials, and
 // both derive the credential consumer identity from the url.
 //
+// For artifacts that must be ingested reproducibly, such as vendor binaries, the
+// "urlFile" constructor input method in
+// [ocm.software/open-component-model/bindings/go/wget/input.URLFileInputMethod]
+// downloads a single file described by a
+// [ocm.software/open-component-model/bindings/go/wget/spec/input/v1.URLFile] input
+// spec. The file is only accepted if it matches the required checksum and, if
+// configured, a detached PGP or cosign signature. Redirects are only followed to the
+// host of the url and an explicit allowlist of hosts. The media type is taken from the
+// spec, the response, or the file extension, and the resource is labeled with the
+// origin and checksum of the file:
+//
+//	resources:
+//	- name: vendor-tool
+//	  type: executable
+//	  input:
+//	    type: urlFile
+//	    url: https://downloads.example.com/tool-1.2.3-linux-amd64
+//	    checksum: sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+//	    allowedRedirectHosts: [cdn.example.com]
+//	    signature:
+//	      type: pgp
+//	      publicKey: |
+//	        -----BEGIN PGP PUBLIC KEY BLOCK-----
+//	        ...
+//
+// Java libraries and other artifacts published to a Maven repository are ingested with
+// the "maven" constructor input method in
+// [ocm.software/open-component-model/bindings/go/wget/input.MavenInputMethod]. A
+// [ocm.software/open-component-model/bindings/go/wget/spec/input/v1.Maven] input spec
+// addresses the artifact by its GAV coordinates, an optional classifier and extension
+// (defaulting to jar) in a repository. The artifact is only accepted if it matches the
+// strongest sha512, sha256 or sha1 checksum the repository publishes next to it.
+// Credentials are resolved for the repository URL with the MavenRepository consumer
+// type and applied like the credentials of the access type:
+//
+//	resources:
+//	- name: commons-lang3
+//	  type: jar
+//	  input:
+//	    type: maven/v1
+//	    repoUrl: https://repo1.maven.org/maven2
+//	    groupId: org.apache.commons
+//	    artifactId: commons-lang3
+//	    version: 3.14.0
+//
 // The wire types are each registered in their package scheme for typed
 // conversion. Both the versioned (wget/v1) and unversioned (wget) type names are
 // registered, and legacy upper-case access specs remain parsable because JSON
@@ -0,0 +1,223 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // sha1 checksums are only used when no stronger checksum is published
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"mime"
+	nethttp "net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/constructor"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/wget/internal/download"
+	"ocm.software/open-component-model/bindings/go/wget/spec/input"
+	v1 "ocm.software/open-component-model/bindings/go/wget/spec/input/v1"
+)
+
+// mavenChecksum is a checksum file a Maven repository publishes next to an artifact.
+type mavenChecksum struct {
+	extension string
+	hash      func() hash.Hash
+}
+
+// mavenChecksums are the checksum files looked up for an artifact, strongest first.
+// MD5 checksums are not accepted.
+var mavenChecksums = []mavenChecksum{
+	{extension: "sha512", hash: sha512.New},
+	{extension: "sha256", hash: sha256.New},
+	{extension: "sha1", hash: sha1.New},
+}
+
+var _ constructor.ResourceInputMethod = (*MavenInputMethod)(nil)
+
+// MavenInputMethod implements the [constructor.ResourceInputMethod] interface for maven inputs.
+// It resolves an artifact by its GAV coordinates from a Maven repository and only accepts it if it
+// matches the strongest checksum published for it in the repository.
+// The artifact is returned as a local blob.
+type MavenInputMethod struct {
+	// HTTPConfig configures the HTTP client (timeouts, retries, TLS, routing) used for
+	// downloads. When nil, a default client is used.
+	HTTPConfig *httpv1alpha1.Config
+	// MaxDownloadSize limits the number of bytes read from a response body. When zero,
+	// the download package default [download.DefaultMaxDownloadSize] is used. A negative value disables the limit.
+	MaxDownloadSize int64
+}
+
+func (i *MavenInputMethod) GetInputMethodScheme() *runtime.Scheme {
+	return input.MavenScheme
+}
+
+// GetResourceCredentialConsumerIdentity resolves the credential consumer identity for a
+// maven input from its repository URL, using the MavenRepository consumer type so that
+// credentials are configured once per repository instead of once per artifact.
+func (i *MavenInputMethod) GetResourceCredentialConsumerIdentity(_ context.Context, resource *constructorruntime.Resource) (runtime.Identity, error) {
+	spec, err := i.convert(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := runtime.ParseURLToIdentity(spec.RepoURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing maven repository URL to identity: %w", err)
+	}
+
+	identity.SetType(runtime.NewUnversionedType(input.MavenRepositoryConsumerType))
+
+	return identity, nil
+}
+
+// ProcessResource resolves the artifact described by the maven input specification, verifies
+// it against the checksum published in the repository and returns it as local blob data to be
+// stored in the component version.
+func (i *MavenInputMethod) ProcessResource(ctx context.Context, resource *constructorruntime.Resource, credentials runtime.Typed) (*constructor.ResourceInputMethodResult, error) {
+	spec, err := i.convert(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	artifactURL, err := MavenArtifactURL(spec)
+	if err != nil {
+		return nil, err
+	}
+	safeURL := sanitizeURL(artifactURL)
+
+	data, _, err := downloadData(ctx, i.HTTPConfig, i.MaxDownloadSize, download.Request{URL: artifactURL.String()}, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading maven artifact %s from %q: %w", spec, safeURL, err)
+	}
+
+	if err := i.verifyChecksum(ctx, artifactURL, data, credentials); err != nil {
+		return nil, fmt.Errorf("error verifying maven artifact %s: %w", spec, err)
+	}
+
+	mediaType := spec.MediaType
+	if mediaType == "" {
+		mediaType = mavenMediaType(path.Ext(artifactURL.Path))
+	}
+
+	return &constructor.ResourceInputMethodResult{
+		ProcessedBlobData: inmemory.New(bytes.NewReader(data),
+			inmemory.WithMediaType(mediaType),
+			inmemory.WithSize(int64(len(data))),
+			inmemory.WithDigest(digest.FromBytes(data).String()),
+		),
+	}, nil
+}
+
+// verifyChecksum compares the data with the strongest checksum file the repository publishes
+// for the artifact. Missing checksum files are skipped, but at least one must exist.
+func (i *MavenInputMethod) verifyChecksum(ctx context.Context, artifactURL *url.URL, data []byte, credentials runtime.Typed) error {
+	for _, checksum := range mavenChecksums {
+		checksumURL := *artifactURL
+		checksumURL.Path += "." + checksum.extension
+		checksumURL.RawPath = ""
+
+		content, _, err := downloadData(ctx, i.HTTPConfig, i.MaxDownloadSize, download.Request{URL: checksumURL.String()}, credentials)
+		if err != nil {
+			var statusErr *download.StatusError
+			if errors.As(err, &statusErr) && statusErr.StatusCode == nethttp.StatusNotFound {
+				continue
+			}
+			return fmt.Errorf("error downloading %s checksum from %q: %w", checksum.extension, sanitizeURL(&checksumURL), err)
+		}
+
+		// checksum files contain the hex encoded checksum, optionally followed by the file name.
+		fields := strings.Fields(string(content))
+		if len(fields) == 0 {
+			return fmt.Errorf("%s checksum file %q is empty", checksum.extension, sanitizeURL(&checksumURL))
+		}
+		expected := strings.ToLower(fields[0])
+
+		h := checksum.hash()
+		h.Write(data)
+		if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+			return fmt.Errorf("%s checksum mismatch for %q: expected %s, got %s", checksum.extension, sanitizeURL(artifactURL), expected, actual)
+		}
+		return nil
+	}
+	return fmt.Errorf("no sha512, sha256 or sha1 checksum published for %q", sanitizeURL(artifactURL))
+}
+
+func (i *MavenInputMethod) convert(resource *constructorruntime.Resource) (*v1.Maven, error) {
+	spec := v1.Maven{}
+	if err := i.GetInputMethodScheme().Convert(resource.Input, &spec); err != nil {
+		return nil, fmt.Errorf("error converting resource input spec: %w", err)
+	}
+	if spec.RepoURL == "" {
+		return nil, fmt.Errorf("repoUrl is required in maven input spec")
+	}
+	return &spec, nil
+}
+
+// MavenArtifactURL returns the URL of the artifact described by the maven input specification,
+// following the Maven repository layout
+// <repoUrl>/<groupId with dots as slashes>/<artifactId>/<version>/<artifactId>-<version>[-<classifier>].<extension>.
+func MavenArtifactURL(spec *v1.Maven) (*url.URL, error) {
+	coordinates := []struct {
+		name, value string
+		required    bool
+	}{
+		{"groupId", spec.GroupID, true},
+		{"artifactId", spec.ArtifactID, true},
+		{"version", spec.Version, true},
+		{"classifier", spec.Classifier, false},
+		{"extension", spec.Extension, false},
+	}
+	for _, c := range coordinates {
+		if c.required && c.value == "" {
+			return nil, fmt.Errorf("%s is required in maven input spec", c.name)
+		}
+		if strings.ContainsAny(c.value, "/\\:") || strings.HasPrefix(c.value, ".") || strings.HasSuffix(c.value, ".") || strings.Contains(c.value, "..") {
+			return nil, fmt.Errorf("invalid %s %q in maven input spec", c.name, c.value)
+		}
+	}
+
+	repoURL, err := url.Parse(spec.RepoURL)
+	if err != nil {
+		return nil, fmt.Errorf("maven repoUrl is not a valid url: %w", err)
+	}
+	if repoURL.Scheme != "http" && repoURL.Scheme != "https" {
+		return nil, fmt.Errorf("maven repoUrl must use http or https scheme, got %q", repoURL.Scheme)
+	}
+
+	extension := spec.Extension
+	if extension == "" {
+		extension = v1.DefaultMavenExtension
+	}
+	file := spec.ArtifactID + "-" + spec.Version
+	if spec.Classifier != "" {
+		file += "-" + spec.Classifier
+	}
+	file += "." + extension
+
+	elements := append(strings.Split(spec.GroupID, "."), spec.ArtifactID, spec.Version, file)
+	return repoURL.JoinPath(elements...), nil
+}
+
+// mavenMediaType returns the media type of a Maven artifact with the given file extension.
+func mavenMediaType(extension string) string {
+	switch extension {
+	case ".jar", ".war", ".ear":
+		return "application/java-archive"
+	case ".pom":
+		return "application/xml"
+	}
+	if mediaType := mime.TypeByExtension(extension); mediaType != "" {
+		return mediaType
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,179 @@
+package input_test
+
+import (
+	"crypto/sha1" //nolint:gosec // maven repositories publish sha1 checksums
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/wget/input"
+	v1 "ocm.software/open-component-model/bindings/go/wget/spec/input/v1"
+)
+
+func mavenInputResource(t *testing.T, spec map[string]any) *constructorruntime.Resource {
+	t.Helper()
+	raw, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	r := &constructorruntime.Resource{}
+	r.Name = "library"
+	r.Version = "1.0.0"
+	r.Type = "jar"
+	r.Input = &runtime.Raw{
+		Type: runtime.NewVersionedType(v1.MavenType, v1.Version),
+		Data: raw,
+	}
+	return r
+}
+
+func mavenSpec(repoURL string) map[string]any {
+	return map[string]any{
+		"type":       "maven/v1",
+		"repoUrl":    repoURL,
+		"groupId":    "software.ocm",
+		"artifactId": "library",
+		"version":    "1.0.0",
+	}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestMavenProcessResource(t *testing.T) {
+	t.Parallel()
+	content := []byte("jar content")
+	const jarPath = "/maven2/software/ocm/library/1.0.0/library-1.0.0.jar"
+
+	t.Run("resolves artifact with matching checksum", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newArtifactServer(t, map[string][]byte{
+			jarPath:             content,
+			jarPath + ".sha256": []byte(sha256Hex(content) + "  library-1.0.0.jar\n"),
+		})
+
+		result, err := (&input.MavenInputMethod{}).ProcessResource(t.Context(), mavenInputResource(t, mavenSpec(server.URL+"/maven2")), nil)
+		r.NoError(err)
+		r.Equal(content, readBlob(t, result.ProcessedBlobData))
+		mediaType, _ := result.ProcessedBlobData.(blob.MediaTypeAware).MediaType()
+		r.Equal("application/java-archive", mediaType)
+		dig, _ := result.ProcessedBlobData.(blob.DigestAware).Digest()
+		r.Equal(digest.FromBytes(content).String(), dig)
+	})
+
+	t.Run("resolves artifact with classifier and extension", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		sum := sha1.Sum(content) //nolint:gosec // maven repositories publish sha1 checksums
+		server := newArtifactServer(t, map[string][]byte{
+			"/software/ocm/library/1.0.0/library-1.0.0-linux.tar.gz":      content,
+			"/software/ocm/library/1.0.0/library-1.0.0-linux.tar.gz.sha1": []byte(hex.EncodeToString(sum[:])),
+		})
+
+		spec := mavenSpec(server.URL)
+		spec["classifier"] = "linux"
+		spec["extension"] = "tar.gz"
+		spec["mediaType"] = "application/gzip"
+		result, err := (&input.MavenInputMethod{}).ProcessResource(t.Context(), mavenInputResource(t, spec), nil)
+		r.NoError(err)
+		r.Equal(content, readBlob(t, result.ProcessedBlobData))
+		mediaType, _ := result.ProcessedBlobData.(blob.MediaTypeAware).MediaType()
+		r.Equal("application/gzip", mediaType)
+	})
+
+	t.Run("rejects artifact with mismatching checksum", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newArtifactServer(t, map[string][]byte{
+			jarPath:             content,
+			jarPath + ".sha512": []byte("0000"),
+			jarPath + ".sha256": []byte(sha256Hex(content)),
+		})
+
+		_, err := (&input.MavenInputMethod{}).ProcessResource(t.Context(), mavenInputResource(t, mavenSpec(server.URL+"/maven2")), nil)
+		r.ErrorContains(err, "sha512 checksum mismatch")
+	})
+
+	t.Run("rejects artifact without checksum", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newArtifactServer(t, map[string][]byte{
+			jarPath:          content,
+			jarPath + ".md5": []byte("0000"),
+		})
+
+		_, err := (&input.MavenInputMethod{}).ProcessResource(t.Context(), mavenInputResource(t, mavenSpec(server.URL+"/maven2")), nil)
+		r.ErrorContains(err, "no sha512, sha256 or sha1 checksum published")
+	})
+
+	t.Run("sends credentials for artifact and checksum", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		files := map[string][]byte{
+			jarPath:             content,
+			jarPath + ".sha256": []byte(sha256Hex(content)),
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if user, pass, ok := req.BasicAuth(); !ok || user != "user" || pass != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			data, ok := files[req.URL.Path]
+			if !ok {
+				http.NotFound(w, req)
+				return
+			}
+			_, _ = w.Write(data)
+		}))
+		t.Cleanup(server.Close)
+
+		method := &input.MavenInputMethod{}
+		resource := mavenInputResource(t, mavenSpec(server.URL+"/maven2"))
+		_, err := method.ProcessResource(t.Context(), resource, nil)
+		r.ErrorContains(err, "status 401")
+
+		creds := &runtime.Raw{
+			Type: runtime.NewVersionedType("Credentials", "v1"),
+			Data: []byte(`{"type":"Credentials/v1","properties":{"username":"user","password":"pass"}}`),
+		}
+		result, err := method.ProcessResource(t.Context(), resource, creds)
+		r.NoError(err)
+		r.Equal(content, readBlob(t, result.ProcessedBlobData))
+	})
+
+	t.Run("rejects invalid coordinates", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		spec := mavenSpec("https://repo.example.com/maven2")
+		spec["artifactId"] = "../../secret"
+		_, err := (&input.MavenInputMethod{}).ProcessResource(t.Context(), mavenInputResource(t, spec), nil)
+		r.ErrorContains(err, "invalid artifactId")
+
+		spec = mavenSpec("https://repo.example.com/maven2")
+		delete(spec, "version")
+		_, err = (&input.MavenInputMethod{}).ProcessResource(t.Context(), mavenInputResource(t, spec), nil)
+		r.ErrorContains(err, "version is required")
+	})
+}
+
+func TestMavenGetResourceCredentialConsumerIdentity(t *testing.T) {
+	r := require.New(t)
+	identity, err := (&input.MavenInputMethod{}).GetResourceCredentialConsumerIdentity(t.Context(),
+		mavenInputResource(t, mavenSpec("https://repo.example.com:8443/maven2")))
+	r.NoError(err)
+	r.Equal("MavenRepository", identity[runtime.IdentityAttributeType])
+	r.Equal("repo.example.com", identity[runtime.IdentityAttributeHostname])
+	r.Equal("8443", identity[runtime.IdentityAttributePort])
+	r.Equal("maven2", identity[runtime.IdentityAttributePath])
+}
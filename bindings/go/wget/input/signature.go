@@ -0,0 +1,99 @@
+package input
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+
+	v1 "ocm.software/open-component-model/bindings/go/wget/spec/input/v1"
+)
+
+// signatureVerifier verifies a detached signature over the downloaded artifact.
+type signatureVerifier interface {
+	Verify(data, signature []byte) error
+}
+
+// newSignatureVerifier returns the verifier for the signature type with the configured public key.
+func newSignatureVerifier(signature *v1.URLFileSignature) (signatureVerifier, error) {
+	if signature.PublicKey == "" {
+		return nil, fmt.Errorf("publicKey is required for %s signature verification", signature.Type)
+	}
+	switch signature.Type {
+	case v1.SignatureTypePGP:
+		keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(signature.PublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("error reading pgp public key: %w", err)
+		}
+		return pgpVerifier{keyring: keyring}, nil
+	case v1.SignatureTypeCosign:
+		block, _ := pem.Decode([]byte(signature.PublicKey))
+		if block == nil {
+			return nil, errors.New("cosign public key is not PEM encoded")
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cosign public key: %w", err)
+		}
+		return cosignVerifier{key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature type %q, expected %q or %q", signature.Type, v1.SignatureTypePGP, v1.SignatureTypeCosign)
+	}
+}
+
+// pgpVerifier verifies OpenPGP detached signatures, armored or binary.
+type pgpVerifier struct {
+	keyring openpgp.EntityList
+}
+
+func (v pgpVerifier) Verify(data, signature []byte) error {
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN")) {
+		_, err = openpgp.CheckArmoredDetachedSignature(v.keyring, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	} else {
+		_, err = openpgp.CheckDetachedSignature(v.keyring, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	}
+	return err
+}
+
+// cosignVerifier verifies signatures created with "cosign sign-blob" and a key pair.
+// The signature is the base64 encoded signature over the SHA-256 digest of the artifact
+// for ECDSA and RSA (PKCS #1 v1.5) keys, and over the artifact itself for Ed25519 keys.
+// Keyless signatures with certificates issued by Fulcio are not supported.
+type cosignVerifier struct {
+	key crypto.PublicKey
+}
+
+func (v cosignVerifier) Verify(data, signature []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil {
+		return fmt.Errorf("cosign signature is not base64 encoded: %w", err)
+	}
+	hash := sha256.Sum256(data)
+	switch key := v.key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hash[:], sig) {
+			return errors.New("invalid ecdsa signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, sig) {
+			return errors.New("invalid ed25519 signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported cosign public key type %T", v.key)
+	}
+}
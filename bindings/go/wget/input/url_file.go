@@ -0,0 +1,285 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	nethttp "net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/constructor"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	httpclient "ocm.software/open-component-model/bindings/go/http"
+	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/wget/internal/download"
+	"ocm.software/open-component-model/bindings/go/wget/spec/input"
+	v1 "ocm.software/open-component-model/bindings/go/wget/spec/input/v1"
+)
+
+// URLFileLabelName is the name of the label the URLFileInputMethod sets on a resource to record
+// where the artifact was downloaded from and how it was verified.
+const URLFileLabelName = "ocm.software/urlFile"
+
+// URLFileLabel is the value of the label with the name [URLFileLabelName].
+type URLFileLabel struct {
+	// URL is the URL the artifact was downloaded from, without credentials and query parameters.
+	URL string `json:"url"`
+	// Checksum is the verified digest of the artifact.
+	Checksum string `json:"checksum"`
+	// Signature is the type of the verified detached signature, if any.
+	Signature string `json:"signature,omitempty"`
+}
+
+var _ constructor.ResourceInputMethod = (*URLFileInputMethod)(nil)
+
+// URLFileInputMethod implements the [constructor.ResourceInputMethod] interface for urlFile inputs.
+// It downloads a single artifact from an HTTP/S URL and only accepts it if it matches the
+// checksum and the optional detached signature declared in the input specification.
+// The artifact is returned as a local blob, and the resource is labeled with its origin.
+type URLFileInputMethod struct {
+	// HTTPConfig configures the HTTP client (timeouts, retries, TLS, routing) used for
+	// downloads. When nil, a default client is used.
+	HTTPConfig *httpv1alpha1.Config
+	// MaxDownloadSize limits the number of bytes read from a response body. When zero,
+	// the download package default [download.DefaultMaxDownloadSize] is used. A negative value disables the limit.
+	MaxDownloadSize int64
+}
+
+func (i *URLFileInputMethod) GetInputMethodScheme() *runtime.Scheme {
+	return input.URLFileScheme
+}
+
+// GetResourceCredentialConsumerIdentity resolves the credential consumer identity for an
+// urlFile input from its URL, using the wget consumer type so that credentials configured
+// for a host resolve for both inputs.
+func (i *URLFileInputMethod) GetResourceCredentialConsumerIdentity(_ context.Context, resource *constructorruntime.Resource) (runtime.Identity, error) {
+	spec, err := i.convert(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := runtime.ParseURLToIdentity(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing urlFile URL to identity: %w", err)
+	}
+
+	identity.SetType(runtime.NewUnversionedType(input.WgetConsumerType))
+
+	return identity, nil
+}
+
+// ProcessResource downloads the artifact described by the urlFile input specification,
+// verifies its checksum and signature and returns it as local blob data to be stored in
+// the component version.
+func (i *URLFileInputMethod) ProcessResource(ctx context.Context, resource *constructorruntime.Resource, credentials runtime.Typed) (*constructor.ResourceInputMethodResult, error) {
+	spec, err := i.convert(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := digest.Parse(spec.Checksum)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum %q in urlFile input spec: %w", spec.Checksum, err)
+	}
+
+	var verifier signatureVerifier
+	if spec.Signature != nil {
+		if verifier, err = newSignatureVerifier(spec.Signature); err != nil {
+			return nil, err
+		}
+	}
+
+	artifactURL, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("urlFile url is not a valid url: %w", err)
+	}
+	safeURL := sanitizeURL(artifactURL)
+
+	data, mediaType, err := i.download(ctx, download.Request{
+		URL:                  spec.URL,
+		MediaType:            spec.MediaType,
+		RestrictRedirects:    true,
+		AllowedRedirectHosts: spec.AllowedRedirectHosts,
+	}, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading urlFile input from %q: %w", safeURL, err)
+	}
+
+	if actual := expected.Algorithm().FromBytes(data); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch for %q: expected %s, got %s", safeURL, expected, actual)
+	}
+
+	label := URLFileLabel{
+		URL:      safeURL,
+		Checksum: expected.String(),
+	}
+
+	if verifier != nil {
+		signatureURL, err := signatureLocation(artifactURL, spec.Signature)
+		if err != nil {
+			return nil, err
+		}
+		// credentials are only sent along if the signature is served by the same host as the artifact.
+		var signatureCredentials runtime.Typed
+		if strings.EqualFold(signatureURL.Host, artifactURL.Host) {
+			signatureCredentials = credentials
+		}
+		signature, _, err := i.download(ctx, download.Request{
+			URL:                  signatureURL.String(),
+			RestrictRedirects:    true,
+			AllowedRedirectHosts: spec.AllowedRedirectHosts,
+		}, signatureCredentials)
+		if err != nil {
+			return nil, fmt.Errorf("error downloading %s signature from %q: %w", spec.Signature.Type, sanitizeURL(signatureURL), err)
+		}
+		if err := verifier.Verify(data, signature); err != nil {
+			return nil, fmt.Errorf("%s signature verification failed for %q: %w", spec.Signature.Type, safeURL, err)
+		}
+		label.Signature = spec.Signature.Type
+	}
+
+	if spec.MediaType == "" && isGenericMediaType(mediaType) {
+		if byExtension := mime.TypeByExtension(path.Ext(artifactURL.Path)); byExtension != "" {
+			mediaType = byExtension
+		}
+	}
+
+	if err := setLabel(resource, URLFileLabelName, label); err != nil {
+		return nil, err
+	}
+
+	return &constructor.ResourceInputMethodResult{
+		ProcessedBlobData: inmemory.New(bytes.NewReader(data),
+			inmemory.WithMediaType(mediaType),
+			inmemory.WithSize(int64(len(data))),
+			inmemory.WithDigest(expected.String()),
+		),
+	}, nil
+}
+
+func (i *URLFileInputMethod) convert(resource *constructorruntime.Resource) (*v1.URLFile, error) {
+	spec := v1.URLFile{}
+	if err := i.GetInputMethodScheme().Convert(resource.Input, &spec); err != nil {
+		return nil, fmt.Errorf("error converting resource input spec: %w", err)
+	}
+	if spec.URL == "" {
+		return nil, fmt.Errorf("url is required in urlFile input spec")
+	}
+	if spec.Checksum == "" {
+		return nil, fmt.Errorf("checksum is required in urlFile input spec")
+	}
+	return &spec, nil
+}
+
+// download downloads the given request fully into memory and returns the data together with
+// the media type of the resulting blob.
+func (i *URLFileInputMethod) download(ctx context.Context, req download.Request, credentials runtime.Typed) ([]byte, string, error) {
+	return downloadData(ctx, i.HTTPConfig, i.MaxDownloadSize, req, credentials)
+}
+
+// downloadData downloads the given request fully into memory with a client configured by httpConfig
+// and returns the data together with the media type of the resulting blob.
+func downloadData(ctx context.Context, httpConfig *httpv1alpha1.Config, maxDownloadSize int64, req download.Request, credentials runtime.Typed) ([]byte, string, error) {
+	var client *nethttp.Client
+	if httpConfig != nil {
+		client = httpclient.New(httpclient.WithConfig(httpConfig))
+	}
+
+	opts := []download.Option{
+		download.WithClient(client),
+		download.WithCredentials(credentials),
+	}
+
+	if maxDownloadSize != 0 {
+		opts = append(opts, download.WithMaxDownloadSize(maxDownloadSize))
+	}
+
+	b, err := download.Download(ctx, req, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rc, err := b.ReadCloser()
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = rc.Close() }()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var mediaType string
+	if mediaTypeAware, ok := b.(blob.MediaTypeAware); ok {
+		mediaType, _ = mediaTypeAware.MediaType()
+	}
+	return data, mediaType, nil
+}
+
+// signatureLocation returns the URL of the detached signature, defaulting to the artifact URL
+// with the conventional suffix of the signature type.
+func signatureLocation(artifactURL *url.URL, signature *v1.URLFileSignature) (*url.URL, error) {
+	if signature.URL != "" {
+		u, err := url.Parse(signature.URL)
+		if err != nil {
+			return nil, fmt.Errorf("signature url is not a valid url: %w", err)
+		}
+		return u, nil
+	}
+	u := *artifactURL
+	switch signature.Type {
+	case v1.SignatureTypePGP:
+		u.Path += ".asc"
+	case v1.SignatureTypeCosign:
+		u.Path += ".sig"
+	}
+	u.RawPath = ""
+	return &u, nil
+}
+
+// isGenericMediaType reports whether the media type carries no information about the content.
+func isGenericMediaType(mediaType string) bool {
+	switch mediaType {
+	case "", "application/octet-stream", "binary/octet-stream":
+		return true
+	default:
+		return false
+	}
+}
+
+// sanitizeURL strips userinfo and query params so presigned URLs and credentials
+// are never leaked into error messages or labels.
+func sanitizeURL(u *url.URL) string {
+	safe := *u
+	safe.User = nil
+	safe.RawQuery = ""
+	safe.Fragment = ""
+	return safe.String()
+}
+
+// setLabel sets a label that is not signing relevant on the resource, replacing an existing label with the same name.
+func setLabel(resource *constructorruntime.Resource, name string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error marshalling label %q: %w", name, err)
+	}
+	label := constructorruntime.Label{Name: name, Value: raw}
+	for idx := range resource.Labels {
+		if resource.Labels[idx].Name == name {
+			resource.Labels[idx] = label
+			return nil
+		}
+	}
+	resource.Labels = append(resource.Labels, label)
+	return nil
+}
@@ -0,0 +1,240 @@
+package input_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/wget/input"
+	v1 "ocm.software/open-component-model/bindings/go/wget/spec/input/v1"
+)
+
+func urlFileInputResource(t *testing.T, spec map[string]any) *constructorruntime.Resource {
+	t.Helper()
+	raw, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	r := &constructorruntime.Resource{}
+	r.Name = "vendor-binary"
+	r.Version = "1.0.0"
+	r.Type = "executable"
+	r.Input = &runtime.Raw{
+		Type: runtime.NewVersionedType(v1.URLFileType, v1.Version),
+		Data: raw,
+	}
+	return r
+}
+
+// newArtifactServer serves the given files by path.
+func newArtifactServer(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestURLFileProcessResource(t *testing.T) {
+	t.Parallel()
+	content := []byte(`{"tool":"vendor"}`)
+	checksum := digest.FromBytes(content).String()
+
+	t.Run("accepts artifact with matching checksum", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newArtifactServer(t, map[string][]byte{"/tool.json": content})
+
+		resource := urlFileInputResource(t, map[string]any{
+			"type":     "urlFile/v1",
+			"url":      server.URL + "/tool.json?token=secret",
+			"checksum": checksum,
+		})
+		result, err := (&input.URLFileInputMethod{}).ProcessResource(t.Context(), resource, nil)
+		r.NoError(err)
+		r.Equal(content, readBlob(t, result.ProcessedBlobData))
+
+		mediaType, _ := result.ProcessedBlobData.(blob.MediaTypeAware).MediaType()
+		r.Equal("application/json", mediaType, "generic content type must be refined by the file extension")
+
+		r.Len(resource.Labels, 1)
+		r.Equal(input.URLFileLabelName, resource.Labels[0].Name)
+		r.False(resource.Labels[0].Signing)
+		var label input.URLFileLabel
+		r.NoError(json.Unmarshal(resource.Labels[0].Value, &label))
+		r.Equal(input.URLFileLabel{URL: server.URL + "/tool.json", Checksum: checksum}, label)
+	})
+
+	t.Run("rejects artifact with checksum mismatch", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		server := newArtifactServer(t, map[string][]byte{"/tool": []byte("tampered")})
+
+		resource := urlFileInputResource(t, map[string]any{
+			"type":     "urlFile",
+			"url":      server.URL + "/tool",
+			"checksum": checksum,
+		})
+		_, err := (&input.URLFileInputMethod{}).ProcessResource(t.Context(), resource, nil)
+		r.ErrorContains(err, "checksum mismatch")
+		r.Empty(resource.Labels)
+	})
+
+	t.Run("requires a valid checksum", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+
+		_, err := (&input.URLFileInputMethod{}).ProcessResource(t.Context(), urlFileInputResource(t, map[string]any{
+			"type": "urlFile/v1",
+			"url":  "https://example.com/tool",
+		}), nil)
+		r.ErrorContains(err, "checksum is required")
+
+		_, err = (&input.URLFileInputMethod{}).ProcessResource(t.Context(), urlFileInputResource(t, map[string]any{
+			"type":     "urlFile/v1",
+			"url":      "https://example.com/tool",
+			"checksum": "md5:abc",
+		}), nil)
+		r.ErrorContains(err, "invalid checksum")
+	})
+
+	t.Run("rejects redirects to hosts not on the allowlist", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+		mirror := newArtifactServer(t, map[string][]byte{"/tool": content})
+		mirrorURL, err := url.Parse(mirror.URL)
+		r.NoError(err)
+		server := httptest.NewServer(http.RedirectHandler("http://localhost:"+mirrorURL.Port()+"/tool", http.StatusFound))
+		t.Cleanup(server.Close)
+
+		spec := map[string]any{
+			"type":                 "urlFile/v1",
+			"url":                  server.URL + "/tool",
+			"checksum":             checksum,
+			"allowedRedirectHosts": []string{"downloads.example.com"},
+		}
+		_, err = (&input.URLFileInputMethod{}).ProcessResource(t.Context(), urlFileInputResource(t, spec), nil)
+		r.ErrorContains(err, "is not allowed")
+
+		spec["allowedRedirectHosts"] = []string{"localhost"}
+		result, err := (&input.URLFileInputMethod{}).ProcessResource(t.Context(), urlFileInputResource(t, spec), nil)
+		r.NoError(err)
+		r.Equal(content, readBlob(t, result.ProcessedBlobData))
+	})
+}
+
+func TestURLFileProcessResource_Signature(t *testing.T) {
+	t.Parallel()
+	content := []byte("vendor binary")
+	checksum := digest.FromBytes(content).String()
+
+	t.Run("pgp", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+
+		entity, err := openpgp.NewEntity("vendor", "", "vendor@example.com", nil)
+		r.NoError(err)
+		var publicKey bytes.Buffer
+		w, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+		r.NoError(err)
+		r.NoError(entity.Serialize(w))
+		r.NoError(w.Close())
+		var signature bytes.Buffer
+		r.NoError(openpgp.ArmoredDetachSign(&signature, entity, bytes.NewReader(content), nil))
+
+		server := newArtifactServer(t, map[string][]byte{
+			"/tool":       content,
+			"/tool.asc":   signature.Bytes(),
+			"/other.tool": []byte("other binary"),
+			"/other.sig":  signature.Bytes(),
+		})
+
+		resource := urlFileInputResource(t, map[string]any{
+			"type":      "urlFile/v1",
+			"url":       server.URL + "/tool",
+			"checksum":  checksum,
+			"signature": map[string]any{"type": "pgp", "publicKey": publicKey.String()},
+		})
+		_, err = (&input.URLFileInputMethod{}).ProcessResource(t.Context(), resource, nil)
+		r.NoError(err)
+		var label input.URLFileLabel
+		r.NoError(json.Unmarshal(resource.Labels[0].Value, &label))
+		r.Equal(v1.SignatureTypePGP, label.Signature)
+
+		_, err = (&input.URLFileInputMethod{}).ProcessResource(t.Context(), urlFileInputResource(t, map[string]any{
+			"type":      "urlFile/v1",
+			"url":       server.URL + "/other.tool",
+			"checksum":  digest.FromString("other binary").String(),
+			"signature": map[string]any{"type": "pgp", "url": server.URL + "/other.sig", "publicKey": publicKey.String()},
+		}), nil)
+		r.ErrorContains(err, "pgp signature verification failed")
+	})
+
+	t.Run("cosign", func(t *testing.T) {
+		t.Parallel()
+		r := require.New(t)
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		r.NoError(err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		r.NoError(err)
+		publicKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+		hash := sha256.Sum256(content)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		r.NoError(err)
+
+		server := newArtifactServer(t, map[string][]byte{
+			"/tool":     content,
+			"/tool.sig": []byte(base64.StdEncoding.EncodeToString(sig)),
+			"/bad.sig":  []byte(base64.StdEncoding.EncodeToString([]byte("not a signature"))),
+		})
+
+		_, err = (&input.URLFileInputMethod{}).ProcessResource(t.Context(), urlFileInputResource(t, map[string]any{
+			"type":      "urlFile/v1",
+			"url":       server.URL + "/tool",
+			"checksum":  checksum,
+			"signature": map[string]any{"type": "cosign", "publicKey": string(publicKey)},
+		}), nil)
+		r.NoError(err)
+
+		_, err = (&input.URLFileInputMethod{}).ProcessResource(t.Context(), urlFileInputResource(t, map[string]any{
+			"type":      "urlFile/v1",
+			"url":       server.URL + "/tool",
+			"checksum":  checksum,
+			"signature": map[string]any{"type": "cosign", "url": server.URL + "/bad.sig", "publicKey": string(publicKey)},
+		}), nil)
+		r.ErrorContains(err, "cosign signature verification failed")
+
+		_, err = (&input.URLFileInputMethod{}).ProcessResource(t.Context(), urlFileInputResource(t, map[string]any{
+			"type":      "urlFile/v1",
+			"url":       server.URL + "/tool",
+			"checksum":  checksum,
+			"signature": map[string]any{"type": "minisign", "publicKey": string(publicKey)},
+		}), nil)
+		r.ErrorContains(err, `unsupported signature type "minisign"`)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/blob/inmemory"
@@ -36,6 +37,24 @@ type Request struct {
 	Body []byte
 	// NoRedirect disables following HTTP redirects when set.
 	NoRedirect bool
+	// RestrictRedirects only follows redirects to the host of URL and the hosts in
+	// AllowedRedirectHosts, and never from https to http.
+	RestrictRedirects bool
+	// AllowedRedirectHosts lists additional hosts redirects may lead to when
+	// RestrictRedirects is set.
+	AllowedRedirectHosts []string
+}
+
+// StatusError is returned by [Download] if the server responds with a non-2xx status code.
+type StatusError struct {
+	// URL is the requested URL, without credentials and query parameters.
+	URL string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP request to %s returned status %d", e.URL, e.StatusCode)
 }
 
 // Download performs the HTTP request described by req and returns the response
@@ -94,6 +113,8 @@ func Download(ctx context.Context, req Request, opts ...Option) (blob.ReadOnlyBl
 
 	if req.NoRedirect {
 		client = cloneClientWithNoRedirect(client)
+	} else if req.RestrictRedirects {
+		client = cloneClientWithRedirectAllowlist(client, parsedURL, req.AllowedRedirectHosts)
 	}
 
 	if err := applyCredentials(ctx, httpReq, &client, o.Credentials); err != nil {
@@ -111,7 +132,7 @@ func Download(ctx context.Context, req Request, opts ...Option) (blob.ReadOnlyBl
 	}()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP request to %s returned status %d", safeURL.String(), resp.StatusCode)
+		return nil, &StatusError{URL: safeURL.String(), StatusCode: resp.StatusCode}
 	}
 
 	// A nil option means "use the default"; a zero or negative value disables the limit.
@@ -249,3 +270,38 @@ func cloneClientWithNoRedirect(original *http.Client) *http.Client {
 	}
 	return &c
 }
+
+// maxRedirects is the number of redirects followed by a client with a redirect allowlist,
+// matching the default of net/http.
+const maxRedirects = 10
+
+// cloneClientWithRedirectAllowlist returns a copy of the client that only follows
+// redirects to the host of the original URL or one of the allowed hosts, and never
+// from https to http.
+func cloneClientWithRedirectAllowlist(original *http.Client, origin *url.URL, allowedHosts []string) *http.Client {
+	allowed := map[string]struct{}{
+		strings.ToLower(origin.Host):       {},
+		strings.ToLower(origin.Hostname()): {},
+	}
+	for _, host := range allowedHosts {
+		allowed[strings.ToLower(host)] = struct{}{}
+	}
+	c := *original
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		_, hostAllowed := allowed[strings.ToLower(req.URL.Host)]
+		if !hostAllowed {
+			_, hostAllowed = allowed[strings.ToLower(req.URL.Hostname())]
+		}
+		if !hostAllowed {
+			return fmt.Errorf("redirect to host %q is not allowed", req.URL.Host)
+		}
+		if origin.Scheme == "https" && req.URL.Scheme != "https" {
+			return fmt.Errorf("redirect from https to %s is not allowed", req.URL.Scheme)
+		}
+		return nil
+	}
+	return &c
+}
@@ -13,6 +13,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -155,6 +156,40 @@ func TestDownload_Redirect(t *testing.T) {
 			download.WithClient(srv.Client()))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "status 302")
+		var statusErr *download.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusFound, statusErr.StatusCode)
+	})
+
+	t.Run("RestrictRedirects only follows redirects to allowed hosts", func(t *testing.T) {
+		t.Parallel()
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("mirror"))
+		}))
+		t.Cleanup(target.Close)
+		targetURL, err := url.Parse(target.URL)
+		require.NoError(t, err)
+		// the origin is served on 127.0.0.1, so redirecting to localhost changes the host.
+		mirror := "http://localhost:" + targetURL.Port() + "/artifact"
+		srv := httptest.NewServer(http.RedirectHandler(mirror, http.StatusFound))
+		t.Cleanup(srv.Close)
+
+		_, err = download.Download(t.Context(), download.Request{URL: srv.URL, RestrictRedirects: true, AllowedRedirectHosts: []string{"example.com"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `redirect to host "localhost:`+targetURL.Port()+`" is not allowed`)
+
+		b, err := download.Download(t.Context(), download.Request{URL: srv.URL, RestrictRedirects: true, AllowedRedirectHosts: []string{"localhost"}})
+		require.NoError(t, err)
+		assert.Equal(t, "mirror", string(readBlob(t, b)))
+	})
+
+	t.Run("RestrictRedirects always allows the origin host", func(t *testing.T) {
+		t.Parallel()
+		srv := newRedirectServer(t)
+		b, err := download.Download(t.Context(), download.Request{URL: srv.URL + "/redirect", RestrictRedirects: true},
+			download.WithClient(srv.Client()))
+		require.NoError(t, err)
+		assert.Equal(t, "final", string(readBlob(t, b)))
 	})
 }
 
@@ -9,14 +9,31 @@ import (
 
 const (
 	WgetConsumerType = "Wget"
+	// MavenRepositoryConsumerType is the credential consumer type of Maven repositories.
+	MavenRepositoryConsumerType = "MavenRepository"
 )
 
 var V1VersionedType = runtime.NewVersionedType(WgetConsumerType, v1.Version)
 
+// URLFileV1VersionedType is the versioned type of the urlFile input.
+var URLFileV1VersionedType = runtime.NewVersionedType(v1.URLFileType, v1.Version)
+
+// MavenV1VersionedType is the versioned type of the maven input.
+var MavenV1VersionedType = runtime.NewVersionedType(v1.MavenType, v1.Version)
+
 var Scheme = runtime.NewScheme()
 
+// URLFileScheme contains the urlFile input type. It is kept separate from Scheme because
+// the urlFile input is processed by its own input method.
+var URLFileScheme = runtime.NewScheme()
+
+// MavenScheme contains the maven input type, which is processed by its own input method.
+var MavenScheme = runtime.NewScheme()
+
 func init() {
 	MustAddToScheme(Scheme)
+	MustAddURLFileToScheme(URLFileScheme)
+	MustAddMavenToScheme(MavenScheme)
 }
 
 func MustAddToScheme(scheme *runtime.Scheme) {
@@ -30,3 +47,19 @@ func MustAddToScheme(scheme *runtime.Scheme) {
 		runtime.NewUnversionedType(lowerCaseConsumerType),
 	)
 }
+
+// MustAddURLFileToScheme registers the urlFile input type with its versioned and unversioned name.
+func MustAddURLFileToScheme(scheme *runtime.Scheme) {
+	scheme.MustRegisterWithAlias(&v1.URLFile{},
+		URLFileV1VersionedType,
+		runtime.NewUnversionedType(v1.URLFileType),
+	)
+}
+
+// MustAddMavenToScheme registers the maven input type with its versioned and unversioned name.
+func MustAddMavenToScheme(scheme *runtime.Scheme) {
+	scheme.MustRegisterWithAlias(&v1.Maven{},
+		MavenV1VersionedType,
+		runtime.NewUnversionedType(v1.MavenType),
+	)
+}
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	MavenType = "maven"
+)
+
+// DefaultMavenExtension is the extension of a Maven artifact if none is specified.
+const DefaultMavenExtension = "jar"
+
+// Maven describes an input sourced by resolving a single artifact by its GAV coordinates
+// (groupId, artifactId, version) from a Maven repository during component construction.
+// The artifact is only accepted if it matches the checksum published next to it in the
+// repository, and is stored as a local blob in the component version.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Maven struct {
+	// +ocm:jsonschema-gen:enum=maven/v1
+	// +ocm:jsonschema-gen:enum:deprecated=maven
+	Type runtime.Type `json:"type"`
+
+	// RepoURL is the base URL of the Maven repository, for example https://repo1.maven.org/maven2.
+	RepoURL string `json:"repoUrl"`
+
+	// GroupID is the group of the artifact, for example org.apache.commons.
+	GroupID string `json:"groupId"`
+
+	// ArtifactID is the name of the artifact within its group, for example commons-lang3.
+	ArtifactID string `json:"artifactId"`
+
+	// Version is the version of the artifact, for example 3.14.0.
+	Version string `json:"version"`
+
+	// Classifier optionally distinguishes artifacts built from the same version,
+	// for example sources or javadoc.
+	Classifier string `json:"classifier,omitempty"`
+
+	// Extension is the file extension of the artifact. Defaults to jar.
+	Extension string `json:"extension,omitempty"`
+
+	// MediaType is the media type of the artifact. When empty, it is derived from
+	// the extension of the artifact.
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+func (t *Maven) String() string {
+	gav := t.GroupID + ":" + t.ArtifactID + ":" + t.Version
+	if t.Classifier != "" {
+		gav += ":" + t.Classifier
+	}
+	if t.Extension != "" {
+		gav += "@" + t.Extension
+	}
+	return gav
+}
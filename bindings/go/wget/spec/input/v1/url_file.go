@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	URLFileType = "urlFile"
+)
+
+// Signature types supported for detached signatures of an URLFile input.
+const (
+	// SignatureTypePGP verifies an OpenPGP detached signature (armored or binary)
+	// against an armored public key.
+	SignatureTypePGP = "pgp"
+	// SignatureTypeCosign verifies a cosign blob signature (base64 encoded, as written by
+	// "cosign sign-blob --output-signature") against a PEM encoded public key.
+	SignatureTypeCosign = "cosign"
+)
+
+// URLFile describes an input sourced by downloading a single artifact, such as a vendor
+// binary, from an HTTP/S URL during component construction. In contrast to Wget, the
+// downloaded content is only accepted if it matches the required checksum and, if
+// configured, a detached signature, so that the constructed component version is
+// reproducible.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type URLFile struct {
+	// +ocm:jsonschema-gen:enum=urlFile/v1
+	// +ocm:jsonschema-gen:enum:deprecated=urlFile
+	Type runtime.Type `json:"type"`
+
+	// URL is the HTTP endpoint to download the artifact from.
+	URL string `json:"url"`
+
+	// Checksum is the expected digest of the artifact in the form <algorithm>:<hex>,
+	// for example sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824.
+	// The artifact is rejected if its digest does not match.
+	Checksum string `json:"checksum"`
+
+	// MediaType is the media type of the artifact. When empty, the Content-Type of the
+	// response is used, or the media type derived from the file extension of the URL
+	// if the server only reports a generic type.
+	MediaType string `json:"mediaType,omitempty"`
+
+	// AllowedRedirectHosts lists the hosts the download may be redirected to, in addition
+	// to the host of the URL. Redirects to any other host are rejected.
+	AllowedRedirectHosts []string `json:"allowedRedirectHosts,omitempty"`
+
+	// Signature optionally configures a detached signature that must be valid for the
+	// artifact before it is accepted.
+	Signature *URLFileSignature `json:"signature,omitempty"`
+}
+
+// URLFileSignature describes a detached signature of an URLFile artifact.
+//
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type URLFileSignature struct {
+	// Type is the signature format, either pgp or cosign.
+	// +ocm:jsonschema-gen:enum=pgp,cosign
+	Type string `json:"type"`
+
+	// URL is the HTTP endpoint to download the signature from. Defaults to the URL of
+	// the artifact with the suffix .asc for pgp and .sig for cosign signatures.
+	URL string `json:"url,omitempty"`
+
+	// PublicKey is the public key the signature is verified with, armored for pgp and
+	// PEM encoded for cosign signatures.
+	PublicKey string `json:"publicKey"`
+}
+
+func (t *URLFile) String() string {
+	return t.URL
+}
@@ -9,6 +9,82 @@ import (
 	runtime "ocm.software/open-component-model/bindings/go/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Maven) DeepCopyInto(out *Maven) {
+	*out = *in
+	out.Type = in.Type
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Maven.
+func (in *Maven) DeepCopy() *Maven {
+	if in == nil {
+		return nil
+	}
+	out := new(Maven)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyTyped is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Typed.
+func (in *Maven) DeepCopyTyped() runtime.Typed {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLFile) DeepCopyInto(out *URLFile) {
+	*out = *in
+	out.Type = in.Type
+	if in.AllowedRedirectHosts != nil {
+		in, out := &in.AllowedRedirectHosts, &out.AllowedRedirectHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(URLFileSignature)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLFile.
+func (in *URLFile) DeepCopy() *URLFile {
+	if in == nil {
+		return nil
+	}
+	out := new(URLFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyTyped is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Typed.
+func (in *URLFile) DeepCopyTyped() runtime.Typed {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLFileSignature) DeepCopyInto(out *URLFileSignature) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLFileSignature.
+func (in *URLFileSignature) DeepCopy() *URLFileSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(URLFileSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Wget) DeepCopyInto(out *Wget) {
 	*out = *in
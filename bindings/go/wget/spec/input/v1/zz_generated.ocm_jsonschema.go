@@ -9,9 +9,33 @@ import (
 	_ "embed"
 )
 
+//go:embed schemas/Maven.schema.json
+var schemaMaven []byte
+
+//go:embed schemas/URLFile.schema.json
+var schemaURLFile []byte
+
+//go:embed schemas/URLFileSignature.schema.json
+var schemaURLFileSignature []byte
+
 //go:embed schemas/Wget.schema.json
 var schemaWget []byte
 
+// JSONSchema returns the JSON Schema for Maven.
+func (Maven) JSONSchema() []byte {
+	return schemaMaven
+}
+
+// JSONSchema returns the JSON Schema for URLFile.
+func (URLFile) JSONSchema() []byte {
+	return schemaURLFile
+}
+
+// JSONSchema returns the JSON Schema for URLFileSignature.
+func (URLFileSignature) JSONSchema() []byte {
+	return schemaURLFileSignature
+}
+
 // JSONSchema returns the JSON Schema for Wget.
 func (Wget) JSONSchema() []byte {
 	return schemaWget
@@ -7,6 +7,26 @@ package v1
 
 import "ocm.software/open-component-model/bindings/go/runtime"
 
+// SetType is an autogenerated setter function, useful for type inference and defaulting.
+func (t *Maven) SetType(typ runtime.Type) {
+	t.Type = typ
+}
+
+// GetType is an autogenerated getter function, useful for type inference and defaulting.
+func (t *Maven) GetType() runtime.Type {
+	return t.Type
+}
+
+// SetType is an autogenerated setter function, useful for type inference and defaulting.
+func (t *URLFile) SetType(typ runtime.Type) {
+	t.Type = typ
+}
+
+// GetType is an autogenerated getter function, useful for type inference and defaulting.
+func (t *URLFile) GetType() runtime.Type {
+	return t.Type
+}
+
 // SetType is an autogenerated setter function, useful for type inference and defaulting.
 func (t *Wget) SetType(typ runtime.Type) {
 	t.Type = typ
@@ -29,6 +29,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/resource"
 	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
+	"ocm.software/open-component-model/bindings/go/repository/webhook"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/cli/cmd/setup/hooks"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
@@ -50,6 +51,9 @@ const (
 	FlagComponentVersionConflictPolicy     = "component-version-conflict-policy"
 	FlagExternalComponentVersionCopyPolicy = "external-component-version-copy-policy"
 	FlagSkipReferenceDigestProcessing      = "skip-reference-digest-processing"
+	FlagValidateReferences                 = "validate-references"
+	FlagLockfile                           = "lockfile"
+	FlagLocked                             = "locked"
 	FlagOutput                             = "output"
 	FlagDisplayMode                        = "display-mode"
 
@@ -225,6 +229,9 @@ add component-version --%[1]s ./archive --%[2]s %[3]s.yaml
 	enum.Var(cmd.Flags(), FlagComponentVersionConflictPolicy, ComponentVersionConflictPolicies(), "policy to apply when a component version already exists in the repository")
 	enum.Var(cmd.Flags(), FlagExternalComponentVersionCopyPolicy, ExternalComponentVersionCopyPolicies(), "policy to apply when a component reference to a component version outside of the constructor or target repository is encountered")
 	cmd.Flags().Bool(FlagSkipReferenceDigestProcessing, false, "skip digest processing for resources and sources. Any resource referenced via access type will not have their digest updated.")
+	cmd.Flags().Bool(FlagValidateReferences, false, "resolve all component references outside of the constructor against the target repository before construction, verify their existence and digests and report all broken references at once.")
+	cmd.Flags().String(FlagLockfile, "", "path to a lockfile recording the resolution of all resources, sources and references (digests, accesses and input content). It is written after a successful construction.")
+	cmd.Flags().Bool(FlagLocked, false, "fail if the resolution of a resource, source or reference differs from the lockfile given with --lockfile. The lockfile is not modified.")
 	enum.VarP(cmd.Flags(), FlagOutput, "o", []string{render.OutputFormatTable.String(), render.OutputFormatYAML.String(), render.OutputFormatJSON.String(), render.OutputFormatNDJSON.String(), render.OutputFormatTree.String()}, "output format of the component descriptors")
 	enum.VarP(cmd.Flags(), FlagDisplayMode, "", []string{render.StaticRenderMode, render.LiveRenderMode}, `static: print the output once the complete component graph is discovered
   live (experimental): continuously updates the output to represent the current construction state of the component graph`)
@@ -286,6 +293,11 @@ func AddComponentVersion(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("getting skip-reference-digest-processing flag failed: %w", err)
 	}
 
+	validateReferences, err := cmd.Flags().GetBool(FlagValidateReferences)
+	if err != nil {
+		return fmt.Errorf("getting validate-references flag failed: %w", err)
+	}
+
 	cvConflictPolicy, err := enum.Get(cmd.Flags(), FlagComponentVersionConflictPolicy)
 	if err != nil {
 		return fmt.Errorf("getting component-version-conflict-policy flag failed: %w", err)
@@ -346,12 +358,19 @@ func AddComponentVersion(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("could not initialize ocm repository: %w", err)
 	}
 
+	emitter, waitForWebhooks, err := ocm.NewWebhookEmitter(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer waitForWebhooks()
+
 	instance := &constructorProvider{
 		cache:              cacheDir,
 		targetRepoSpec:     repoSpec,
 		repositoryResolver: repoResolver,
 		pluginManager:      pluginManager,
 		graph:              credentialGraph,
+		emitter:            emitter,
 	}
 
 	opts := constructor.Options{
@@ -364,15 +383,69 @@ func AddComponentVersion(cmd *cobra.Command, _ []string) error {
 		ConcurrencyLimit:                    concurrencyLimit,
 		ComponentVersionConflictPolicy:      ComponentVersionConflictPolicy(cvConflictPolicy).ToConstructorConflictPolicy(),
 		ExternalComponentVersionCopyPolicy:  ExternalComponentVersionCopyPolicy(evCopyPolicy).ToConstructorPolicy(),
+		ValidateReferenceClosure:            validateReferences,
 	}
 	if !skipReferenceDigestProcessing {
 		opts.ResourceDigestProcessorProvider = instance
 	}
 
+	lockfilePath, err := cmd.Flags().GetString(FlagLockfile)
+	if err != nil {
+		return fmt.Errorf("getting lockfile flag failed: %w", err)
+	}
+	locked, err := cmd.Flags().GetBool(FlagLocked)
+	if err != nil {
+		return fmt.Errorf("getting locked flag failed: %w", err)
+	}
+	if opts.Lockfile, err = getLockfile(lockfilePath, locked); err != nil {
+		return err
+	}
+	opts.Locked = locked
+
 	constr := constructor.NewDefaultConstructor(constructorSpec, opts)
 	if err := renderComponents(cmd, constr, output, displayMode); err != nil {
 		return fmt.Errorf("failed to render components recursively: %w", err)
 	}
+
+	if opts.Lockfile != nil && !locked {
+		if err := writeLockfile(lockfilePath, opts.Lockfile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getLockfile returns the lockfile to record the construction in, or for a locked construction,
+// the lockfile read from path to verify the construction against.
+func getLockfile(path string, locked bool) (*constructor.Lockfile, error) {
+	if path == "" {
+		if locked {
+			return nil, fmt.Errorf("--%s requires --%s", FlagLocked, FlagLockfile)
+		}
+		return nil, nil
+	}
+	if !locked {
+		return &constructor.Lockfile{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading lockfile failed: %w", err)
+	}
+	lockfile := &constructor.Lockfile{}
+	if err := yaml.Unmarshal(data, lockfile); err != nil {
+		return nil, fmt.Errorf("parsing lockfile %q failed: %w", path, err)
+	}
+	return lockfile, nil
+}
+
+func writeLockfile(path string, lockfile *constructor.Lockfile) error {
+	data, err := yaml.Marshal(lockfile)
+	if err != nil {
+		return fmt.Errorf("encoding lockfile failed: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing lockfile failed: %w", err)
+	}
 	return nil
 }
 
@@ -453,6 +526,8 @@ type constructorProvider struct {
 	repositoryResolver resolvers.ComponentVersionRepositoryResolver
 	pluginManager      *manager.PluginManager
 	graph              credentials.Resolver
+	// emitter announces the added component versions to the configured webhook endpoints, if set.
+	emitter *webhook.Emitter
 }
 
 func (prov *constructorProvider) GetExternalRepository(ctx context.Context, name, version string) (repository.ComponentVersionRepository, error) {
@@ -523,7 +598,11 @@ func (prov *constructorProvider) GetTargetRepository(ctx context.Context, _ *con
 		slog.DebugContext(ctx, "could not get credential consumer identity for component version repository", "repository", prov.targetRepoSpec, "error", err)
 	}
 
-	return prov.pluginManager.ComponentVersionRepositoryRegistry.GetComponentVersionRepository(ctx, prov.targetRepoSpec, creds)
+	repo, err := prov.pluginManager.ComponentVersionRepositoryRegistry.GetComponentVersionRepository(ctx, prov.targetRepoSpec, creds)
+	if err != nil || prov.emitter == nil {
+		return repo, err
+	}
+	return webhook.NewRepository(repo, prov.targetRepoSpec, prov.emitter), nil
 }
 
 func renderComponents(cmd *cobra.Command, constr constructor.Constructor, format string, mode string) error {
@@ -26,10 +26,10 @@ var pluginDirectoryDefault = filepath.Join("$HOME", ".config", "ocm", "plugins")
 
 // Execute adds all child commands to the Cmd command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the Cmd.
+// On failure, the process exits with the code returned by ExitCode.
 func Execute() {
-	err := New().Execute()
-	if err != nil {
-		os.Exit(1)
+	if err := New().Execute(); err != nil {
+		os.Exit(ExitCode(err))
 	}
 }
 
@@ -55,6 +55,7 @@ func New() *cobra.Command {
 		`Timeout for plugin shutdown. If a plugin does not shut down within this time, it is forcefully killed`)
 	cmd.PersistentFlags().String(ocmcmd.PluginDirectoryFlag, pluginDirectoryDefault, `default directory path for ocm plugins.`)
 	cmd.PersistentFlags().String(ocmcmd.WorkingDirectoryFlag, "", `Specify a custom working directory path to load resources from.`)
+	cmd.PersistentFlags().String(ocmcmd.DebugAddressFlag, "", `Serve read-only debug views (registered types, plugins, credential graph shape) as JSON on the given address, e.g. localhost:6061.`)
 	log.RegisterLoggingFlags(cmd.PersistentFlags())
 	cmd.AddCommand(generate.New())
 	cmd.AddCommand(get.New())
@@ -1,7 +1,7 @@
 package configuration
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/pflag"
 
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/configuration/sops"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
 )
 
@@ -51,7 +52,8 @@ By default (without specifying custom locations with this flag), the file will b
 - $EXE_DIR/.ocmconfig
 If multiple configuration files are found, they will be merged in the order they are discovered.
 Later entries have higher priority.
-Using the option, the specified configuration file(s) will be used instead of the lookup above.`)
+Using the option, the specified configuration file(s) will be used instead of the lookup above.
+Configuration files encrypted with sops are decrypted with the age identities from $SOPS_AGE_KEY, $SOPS_AGE_KEY_FILE or $XDG_CONFIG_HOME/sops/age/keys.txt, or with AWS KMS and GCP KMS using the credentials of the environment.`)
 }
 
 func GetFlattenedOCMConfigForCommand(cmd *cobra.Command) (*genericv1.Config, error) {
@@ -123,23 +125,36 @@ func loadAndMergeConfigs(paths []string, strict bool) (*genericv1.Config, error)
 // Parameters:
 //   - path (string): The file path of the configuration file.
 //
+// If the file is encrypted with sops, it is decrypted with the age identities configured
+// in the environment, or with AWS KMS and GCP KMS using the credentials of the environment,
+// see sops.KeySourcesFromEnvironment.
+//
 // Returns:
 //   - *v1.Config: The decoded configuration struct.
-//   - error: An error if the file cannot be opened or decoded.
-func GetConfigFromPath(path string) (_ *genericv1.Config, err error) {
-	file, err := os.Open(path)
+//   - error: An error if the file cannot be opened, decrypted or decoded.
+func GetConfigFromPath(path string) (*genericv1.Config, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = errors.Join(err, file.Close())
-	}()
 
-	var instance genericv1.Config
-	if err := genericv1.Scheme.Decode(file, &instance); err != nil {
+	if sops.IsEncrypted(data) {
+		if data, err = decryptConfig(data); err != nil {
+			return nil, fmt.Errorf("failed to decrypt ocm config %q: %w", path, err)
+		}
+	}
+
+	// the file and line of every configuration are recorded, so that invalid configurations can be located.
+	return genericv1.Decode(data, path)
+}
+
+// decryptConfig decrypts a configuration file encrypted with sops.
+func decryptConfig(data []byte) ([]byte, error) {
+	sources, err := sops.KeySourcesFromEnvironment(os.Getenv, os.UserConfigDir)
+	if err != nil {
 		return nil, err
 	}
-	return &instance, nil
+	return sops.NewDecryptor(sources...).Decrypt(context.Background(), data)
 }
 
 // GetOCMConfigPaths searches for the OCM configuration file in the following locations (in order):
@@ -164,3 +164,21 @@ func TestGetFlattenedGetConfigFromPath(t *testing.T) {
 		})
 	}
 }
+
+func TestGetConfigFromPath_SOPSEncrypted(t *testing.T) {
+	r := require.New(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("SOPS_AGE_KEY", "")
+
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+	_, err := GetConfigFromPath("testdata/.ocmconfig-sops")
+	r.ErrorContains(err, "failed to decrypt ocm config")
+
+	t.Setenv("SOPS_AGE_KEY_FILE", "testdata/sops-age-keys.txt")
+	cfg, err := GetConfigFromPath("testdata/.ocmconfig-sops")
+	r.NoError(err)
+	r.Equal(runtime.NewVersionedType(genericv1.ConfigType, genericv1.Version), cfg.Type)
+	r.Len(cfg.Configurations, 1)
+	r.JSONEq(`{"type":"credentials.config.ocm.software","consumers":[{"identity":{"type":"HelmChartRepository","hostname":"charts.example.com"},"credentials":[{"type":"Credentials/v1","properties":{"username":"username","password":"password"}}]}]}`,
+		string(cfg.Configurations[0].Data))
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	ocmerrors "ocm.software/open-component-model/bindings/go/runtime/errors"
+)
+
+// Exit codes of the CLI. Failures that are classified with a reason of the shared error
+// taxonomy exit with the code of the reason, all other failures exit with ExitCodeFailure.
+const (
+	ExitCodeFailure            = 1
+	ExitCodeNotFound           = 3
+	ExitCodeAlreadyExists      = 4
+	ExitCodeUnauthorized       = 5
+	ExitCodePreconditionFailed = 6
+	ExitCodeTemporary          = 7
+	ExitCodeUnsupported        = 8
+)
+
+// ExitCode returns the exit code of the CLI for err, or 0 if err is nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	switch ocmerrors.Reason(err) {
+	case ocmerrors.ErrNotFound:
+		return ExitCodeNotFound
+	case ocmerrors.ErrAlreadyExists:
+		return ExitCodeAlreadyExists
+	case ocmerrors.ErrUnauthorized:
+		return ExitCodeUnauthorized
+	case ocmerrors.ErrPreconditionFailed:
+		return ExitCodePreconditionFailed
+	case ocmerrors.ErrTemporary:
+		return ExitCodeTemporary
+	case ocmerrors.ErrUnsupported:
+		return ExitCodeUnsupported
+	default:
+		return ExitCodeFailure
+	}
+}
@@ -0,0 +1,19 @@
+package cmd_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/cli/cmd"
+)
+
+func Test_ExitCode(t *testing.T) {
+	r := require.New(t)
+	r.Equal(0, cmd.ExitCode(nil))
+	r.Equal(cmd.ExitCodeFailure, cmd.ExitCode(errors.New("invalid flag")))
+	r.Equal(cmd.ExitCodeNotFound, cmd.ExitCode(fmt.Errorf("getting component version failed: %w", repository.ErrNotFound)))
+}
@@ -7,7 +7,9 @@ import (
 	"io"
 	"log/slog"
 	"strings"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 
@@ -21,6 +23,7 @@ import (
 	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
 	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
@@ -38,6 +41,8 @@ const (
 	FlagConcurrencyLimit = "concurrency-limit"
 	FlagLatest           = "latest"
 	FlagRecursive        = "recursive"
+	FlagWatch            = "watch"
+	FlagWatchInterval    = "watch-interval"
 )
 
 func New() *cobra.Command {
@@ -80,6 +85,11 @@ Specifying types and schemes:
 
 get cv ctf::github.com/locally-checked-out-repo//ocm.software/ocmcli:0.23.0
 get cvs oci::http://localhost:8080//ocm.software/ocmcli
+
+Watching for new component versions:
+
+get cvs ghcr.io/open-component-model/ocm//ocm.software/ocmcli --watch
+get cvs ghcr.io/open-component-model/ocm//ocm.software/ocmcli --watch --watch-interval 10s --semver-constraint ">= 1.0.0"
 `),
 		RunE:              GetComponentVersion,
 		DisableAutoGenTag: true,
@@ -95,6 +105,8 @@ get cvs oci::http://localhost:8080//ocm.software/ocmcli
 	cmd.Flags().Bool(FlagLatest, false, "if set, only the latest version of the component is returned")
 	cmd.Flags().Int(FlagRecursive, 0, "depth of recursion for resolving referenced component versions (0=none, -1=unlimited, >0=levels (not implemented yet))")
 	cmd.Flags().Lookup(FlagRecursive).NoOptDefVal = "-1"
+	cmd.Flags().Bool(FlagWatch, false, "after the output, keep polling the repository and output every new component version matching the semver constraint until interrupted (only for component references)")
+	cmd.Flags().Duration(FlagWatchInterval, repository.DefaultWatchInterval, "interval in which the repository is polled with --watch")
 
 	return cmd
 }
@@ -149,6 +161,14 @@ func GetComponentVersion(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("getting recursive flag failed: %w", err)
 	}
+	watch, err := cmd.Flags().GetBool(FlagWatch)
+	if err != nil {
+		return fmt.Errorf("getting watch flag failed: %w", err)
+	}
+	watchInterval, err := cmd.Flags().GetDuration(FlagWatchInterval)
+	if err != nil {
+		return fmt.Errorf("getting watch-interval flag failed: %w", err)
+	}
 
 	config := ocmctx.FromContext(cmd.Context()).Configuration()
 
@@ -158,6 +178,8 @@ func GetComponentVersion(cmd *cobra.Command, args []string) error {
 		constraint:  constraint,
 		latestOnly:  latestOnly,
 		recursive:   recursive,
+		watch:       watch,
+		interval:    watchInterval,
 	}
 
 	reference := args[0]
@@ -174,6 +196,9 @@ func GetComponentVersion(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("first position argument %q must be either a component reference or repository reference: %w", reference, errors.Join(compErr, repoErr))
 		}
 		slog.DebugContext(cmd.Context(), "parsed repository reference", "reference", reference, "parsed", repository)
+		if watch {
+			return fmt.Errorf("--%s is only supported for component references", FlagWatch)
+		}
 
 		return processRepositoryReference(cmd, pluginManager, credentialGraph, config, params, repository)
 	}
@@ -228,6 +253,59 @@ func processComponentReference(cmd *cobra.Command,
 		return fmt.Errorf("failed to render components: %w", err)
 	}
 
+	if params.watch {
+		return watchComponentVersions(cmd, repoProvider, repo, ref.Component, descs, params)
+	}
+
+	return nil
+}
+
+// watchComponentVersions renders every component version that appears in the repository after the
+// already rendered ones until the command is interrupted.
+func watchComponentVersions(cmd *cobra.Command,
+	repoProvider resolvers.ComponentVersionRepositoryResolver,
+	repo repository.ComponentVersionRepository,
+	component string,
+	rendered []*descruntime.Descriptor,
+	params Params,
+) error {
+	ctx := cmd.Context()
+
+	// the latest rendered version is the cursor of the watch.
+	var since *semver.Version
+	for _, desc := range rendered {
+		if v, err := semver.NewVersion(desc.Component.Version); err == nil && (since == nil || v.GreaterThan(since)) {
+			since = v
+		}
+	}
+	opts := repository.WatchOptions{
+		Interval:         params.interval,
+		SemverConstraint: params.constraint,
+	}
+	if since != nil {
+		opts.Since = since.Original()
+	}
+
+	events, err := repository.WatchComponentVersions(ctx, repo, component, opts)
+	if err != nil {
+		return fmt.Errorf("watching component versions failed: %w", err)
+	}
+	slog.InfoContext(ctx, "watching for new component versions", "component", component, "since", opts.Since, "interval", opts.Interval)
+
+	for event := range events {
+		if event.Err != nil {
+			slog.WarnContext(ctx, "polling for new component versions failed, retrying with next poll", "error", event.Err)
+			continue
+		}
+		root := runtime.Identity{
+			descruntime.IdentityAttributeName:    event.Component,
+			descruntime.IdentityAttributeVersion: event.Version,
+		}.String()
+		if err := renderComponents(cmd, repoProvider, []string{root}, params.output, render.StaticRenderMode, params.recursive); err != nil {
+			return fmt.Errorf("failed to render component version %s: %w", root, err)
+		}
+	}
+
 	return nil
 }
 
@@ -401,6 +479,8 @@ type Params struct {
 	constraint  string
 	latestOnly  bool
 	recursive   int
+	watch       bool
+	interval    time.Duration
 }
 
 // processRepositoryReference implements the logic for the `get cv <ref>` command, where <ref> is a repository reference.
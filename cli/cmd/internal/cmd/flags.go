@@ -15,4 +15,6 @@ const (
 	PluginShutdownTimeoutDefault = 10 * time.Second
 	// PluginDirectoryFlag Flag to specify the default directory path for OCM plugins.
 	PluginDirectoryFlag = "plugin-directory"
+	// DebugAddressFlag Flag to specify the address of the read-only debug endpoint. The endpoint is disabled if empty.
+	DebugAddressFlag = "debug-address"
 )
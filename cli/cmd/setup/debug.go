@@ -0,0 +1,82 @@
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	"ocm.software/open-component-model/bindings/go/oci/repository/provider"
+	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/componentversionrepository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/debug"
+	ocmcmd "ocm.software/open-component-model/cli/cmd/internal/cmd"
+	ocmctx "ocm.software/open-component-model/cli/internal/context"
+)
+
+// DebugServer starts the read-only debug server if an address is set with the debug address flag.
+// It serves the types of the component version repositories, the registered plugins, the shape
+// of the credential graph and the usage of the repository caches, and is stopped once the command finishes.
+func DebugServer(cmd *cobra.Command) error {
+	addr, err := cmd.Flags().GetString(ocmcmd.DebugAddressFlag)
+	if err != nil || addr == "" {
+		return nil
+	}
+
+	ocmContext := ocmctx.FromContext(cmd.Context())
+	pluginManager := ocmContext.PluginManager()
+	if pluginManager == nil {
+		return fmt.Errorf("could not get plugin manager to initialize debug server")
+	}
+
+	server := debug.NewServer()
+	errs := []error{
+		server.Register("repository-types", debug.SchemeView(pluginManager.ComponentVersionRepositoryRegistry.GetComponentVersionRepositoryScheme())),
+		server.Register("plugins", func(context.Context) (any, error) {
+			return pluginManager.Diagnostics(), nil
+		}),
+	}
+	if stores, ok := repositoryStores(pluginManager.ComponentVersionRepositoryRegistry); ok {
+		errs = append(errs, server.Register("caches", func(context.Context) (any, error) {
+			return map[string]any{"repositoryStores": stores.Stats()}, nil
+		}))
+	}
+	if graph, ok := ocmContext.CredentialGraph().(*credentials.Graph); ok {
+		errs = append(errs, server.Register("credentials", func(context.Context) (any, error) {
+			return graph.Shape(), nil
+		}))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("could not register debug views: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(cmd.Context()))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		slog.InfoContext(ctx, "serving debug views", slog.String("address", addr))
+		if err := server.ListenAndServe(ctx, addr); err != nil {
+			slog.ErrorContext(ctx, "debug server failed", slog.String("error", err.Error()))
+		}
+	}()
+	cobra.OnFinalize(func() {
+		cancel()
+		<-done
+	})
+
+	return nil
+}
+
+// repositoryStores returns the builtin provider of CTF repositories, which caches their opened stores.
+func repositoryStores(registry *componentversionrepository.RepositoryRegistry) (*provider.CachingComponentVersionRepositoryProvider, bool) {
+	p, ok := registry.InternalPlugin(runtime.NewVersionedType(ctfv1.Type, ctfv1.Version))
+	if !ok {
+		return nil, false
+	}
+	stores, ok := p.(*provider.CachingComponentVersionRepositoryProvider)
+	return stores, ok
+}
@@ -42,9 +42,14 @@ func PreRunEWithConfig(cmd *cobra.Command, cfg Config) error {
 	if err := setup.PluginManager(cmd); err != nil {
 		return fmt.Errorf("setup plugin manager: %w", err)
 	}
+	// after the plugin manager, so that temporary files are removed after the plugins were shut down.
+	setup.TempFiles(cmd)
 	if err := setup.CredentialGraph(cmd); err != nil {
 		return fmt.Errorf("setup credential graph: %w", err)
 	}
+	if err := setup.DebugServer(cmd); err != nil {
+		return fmt.Errorf("setup debug server: %w", err)
+	}
 	ocmctx.Register(cmd)
 
 	// Inherit output streams from parent command if available.
@@ -11,10 +11,13 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
 	"ocm.software/open-component-model/bindings/go/credentials"
 	credentialsRuntime "ocm.software/open-component-model/bindings/go/credentials/spec/config/runtime"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	encryptionv1alpha1 "ocm.software/open-component-model/bindings/go/oci/encryption/spec/v1alpha1"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
 	"ocm.software/open-component-model/cli/cmd/configuration"
 	ocmcmd "ocm.software/open-component-model/cli/cmd/internal/cmd"
@@ -90,7 +93,15 @@ func PluginManager(cmd *cobra.Command) error {
 		slog.String("tlsHandshakeTimeout", timeoutString(httpConfig.TLSHandshakeTimeout)),
 		slog.Any("hosts", httpConfig.Hosts),
 	)
-	if err := builtin.Register(pluginManager, filesystemConfig, httpConfig, slog.Default()); err != nil {
+	encryptionConfig, err := encryptionv1alpha1.LookupConfig(ocmContext.Configuration())
+	if err != nil {
+		return fmt.Errorf("could not get local blob encryption configuration: %w", err)
+	}
+	registryConfig, err := registriesv1alpha1.LookupConfig(ocmContext.Configuration())
+	if err != nil {
+		return fmt.Errorf("could not get registry configuration: %w", err)
+	}
+	if err := builtin.Register(pluginManager, filesystemConfig, httpConfig, encryptionConfig, registryConfig, slog.Default()); err != nil {
 		return fmt.Errorf("could not register builtin plugins: %w", err)
 	}
 
@@ -111,6 +122,31 @@ func PluginManager(cmd *cobra.Command) error {
 	return nil
 }
 
+// TempFiles sets up the manager of the temporary files of the command in the configured temp folder.
+// Temporary files left behind by previous runs that crashed or were killed are removed first,
+// the temporary files of the command are removed once the command finished.
+func TempFiles(cmd *cobra.Command) {
+	var opts []tempfile.Option
+	if fsCfg := ocmctx.FromContext(cmd.Context()).FilesystemConfig(); fsCfg != nil && fsCfg.TempFolder != "" {
+		opts = append(opts, tempfile.WithDir(fsCfg.TempFolder))
+	}
+	tempFiles := tempfile.NewManager(opts...)
+
+	if removed, err := tempFiles.SweepOrphans(); err != nil {
+		slog.DebugContext(cmd.Context(), "could not remove orphaned temporary files", slog.String("error", err.Error()))
+	} else if len(removed) > 0 {
+		slog.DebugContext(cmd.Context(), "removed orphaned temporary files", slog.Int("count", len(removed)))
+	}
+
+	cmd.SetContext(tempfile.WithManager(cmd.Context(), tempFiles))
+
+	cobra.OnFinalize(func() {
+		if err := tempFiles.Cleanup(); err != nil {
+			slog.Error("failed to remove temporary files", slog.String("error", err.Error()))
+		}
+	})
+}
+
 func CredentialGraph(cmd *cobra.Command) error {
 	pluginManager := ocmctx.FromContext(cmd.Context()).PluginManager()
 	if pluginManager == nil {
@@ -140,6 +176,8 @@ func CredentialGraph(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("could not create credential graph: %w", err)
 	}
+	// plugins refresh expiring credentials of long-running operations through the graph.
+	pluginManager.SetCredentialResolver(graph)
 
 	cmd.SetContext(ocmctx.WithCredentialGraph(cmd.Context(), graph))
 
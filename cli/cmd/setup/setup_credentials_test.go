@@ -2,7 +2,11 @@ package setup_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,6 +15,8 @@ import (
 	"ocm.software/open-component-model/bindings/go/credentials"
 	credconfigruntime "ocm.software/open-component-model/bindings/go/credentials/spec/config/runtime"
 	credv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	vaultv1alpha1 "ocm.software/open-component-model/bindings/go/credentials/spec/vault/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/credentials/vault"
 	gpgcredsv1alpha1 "ocm.software/open-component-model/bindings/go/gpg/spec/credentials/v1alpha1"
 	gpgidentityv1alpha1 "ocm.software/open-component-model/bindings/go/gpg/spec/identity/v1alpha1"
 	helmcredsv1 "ocm.software/open-component-model/bindings/go/helm/spec/credentials/v1"
@@ -34,7 +40,7 @@ import (
 // the typed consumer credential structs declared by each built-in binding
 func TestCredentialTypeSchemePopulatedByBuiltinRegister(t *testing.T) {
 	pm := manager.NewPluginManager(context.Background())
-	require.NoError(t, builtin.Register(pm, &filesystemv1alpha1.Config{}, &httpv1alpha1.Config{}, slog.Default()))
+	require.NoError(t, builtin.Register(pm, &filesystemv1alpha1.Config{}, &httpv1alpha1.Config{}, nil, nil, slog.Default()))
 
 	scheme := pm.CredentialRepositoryRegistry.GetCredentialTypeScheme()
 	require.NotNil(t, scheme)
@@ -59,6 +65,93 @@ func TestCredentialTypeSchemePopulatedByBuiltinRegister(t *testing.T) {
 	}
 }
 
+// TestCredentialRepositoriesRegisteredByBuiltinRegister verifies that calling builtin.Register
+// registers the built-in credential repositories, so that they can be configured.
+func TestCredentialRepositoriesRegisteredByBuiltinRegister(t *testing.T) {
+	r := require.New(t)
+	pm := manager.NewPluginManager(t.Context())
+	r.NoError(builtin.Register(pm, &filesystemv1alpha1.Config{}, &httpv1alpha1.Config{}, nil, nil, slog.Default()))
+
+	scheme := pm.CredentialRepositoryRegistry.RepositoryScheme()
+	r.True(scheme.IsRegistered(vaultv1alpha1.RepositoryVersionedType), "the vault credential repository must be registered")
+	r.True(scheme.IsRegistered(runtime.NewUnversionedType(vaultv1alpha1.RepositoryType)))
+}
+
+// TestCredentialGraphResolvesDockerConfigAndVaultRepositories verifies that the DockerConfig and
+// HashiCorpVault credential repositories registered by builtin.Register both serve OCI registry
+// credentials when they are configured side by side.
+func TestCredentialGraphResolvesDockerConfigAndVaultRepositories(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	pm := manager.NewPluginManager(ctx)
+	r.NoError(builtin.Register(pm, &filesystemv1alpha1.Config{}, &httpv1alpha1.Config{}, nil, nil, slog.Default()))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch req.URL.Path {
+		case "/v1/secret/metadata/ocm":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"keys": []string{"registry"}}})
+		case "/v1/secret/data/ocm/registry":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": map[string]any{
+				"consumerId": `{"type":"OCIRegistry","hostname":"vault.example.com"}`,
+				"username":   "vault-user",
+				"password":   "vault-password",
+			}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	vaultRepository := &vaultv1alpha1.Repository{
+		Type:      vaultv1alpha1.RepositoryVersionedType,
+		ServerURL: srv.URL,
+		Path:      "ocm",
+	}
+	vaultIdentity, err := (&vault.CredentialRepository{}).ConsumerIdentityForConfig(ctx, vaultRepository)
+	r.NoError(err)
+
+	cfg := &credconfigruntime.Config{
+		Consumers: []credconfigruntime.Consumer{{
+			Identities: []runtime.Identity{vaultIdentity},
+			Credentials: []runtime.Typed{&credv1.DirectCredentials{
+				Type:       runtime.NewVersionedType(credv1.CredentialsType, credv1.Version),
+				Properties: map[string]string{vault.CredentialKeyToken: "vault-token"},
+			}},
+		}},
+		Repositories: []credconfigruntime.RepositoryConfigEntry{
+			{Repository: &ocicredsv1.DockerConfig{
+				Type:         runtime.NewVersionedType(ocicredsv1.DockerConfigType, ocicredsv1.Version),
+				DockerConfig: `{"auths":{"docker.example.com":{"username":"docker-user","password":"docker-password"}}}`,
+			}},
+			{Repository: vaultRepository},
+		},
+	}
+	graph, err := credentials.ToGraph(ctx, cfg, credentials.Options{
+		RepositoryPluginProvider:       pm.CredentialRepositoryRegistry,
+		CredentialPluginProvider:       pm.CredentialPluginRegistry,
+		CredentialRepositoryTypeScheme: pm.CredentialRepositoryRegistry.RepositoryScheme(),
+		CredentialTypeSchemeProvider:   pm.CredentialRepositoryRegistry,
+	})
+	r.NoError(err)
+
+	for host, username := range map[string]string{
+		"docker.example.com": "docker-user",
+		"vault.example.com":  "vault-user",
+	} {
+		resolved, err := graph.Resolve(ctx, runtime.Identity{
+			"type":     credidentityv1.Type.String(),
+			"hostname": host,
+		})
+		r.NoError(err, "credentials of %s", host)
+		r.Contains(fmt.Sprintf("%+v", resolved), username)
+	}
+}
+
 // TestCredentialGraphResolvesTypedCredentials verifies that a credential graph built with
 // PluginManager.CredentialRepositoryRegistry as the scheme provider resolves each built-in
 // typed credential to its concrete Go type.
@@ -66,7 +159,7 @@ func TestCredentialGraphResolvesTypedCredentials(t *testing.T) {
 	ctx := t.Context()
 
 	pm := manager.NewPluginManager(ctx)
-	require.NoError(t, builtin.Register(pm, &filesystemv1alpha1.Config{}, &httpv1alpha1.Config{}, slog.Default()))
+	require.NoError(t, builtin.Register(pm, &filesystemv1alpha1.Config{}, &httpv1alpha1.Config{}, nil, nil, slog.Default()))
 
 	tests := []struct {
 		name       string
@@ -17,6 +17,8 @@ import (
 	"ocm.software/open-component-model/bindings/go/oci/compref"
 	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/webhook"
 	"ocm.software/open-component-model/bindings/go/transfer"
 	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
 	graphPkg "ocm.software/open-component-model/bindings/go/transform/graph"
@@ -36,10 +38,14 @@ const (
 	FlagRecursive        = "recursive"
 	FlagCopyResources    = "copy-resources"
 	FlagUploadAs         = "upload-as"
+	FlagDigestPinning    = "digest-pinning"
 	FlagTransferSpec     = "transfer-spec"
 	FlagSemverConstraint = "semver-constraint"
 	FlagLatest           = "latest"
 
+	// digestPinningNone is the value of --digest-pinning that transfers accesses as they are.
+	digestPinningNone = "none"
+
 	// Each node emits 2 events (Running + Completed/Failed) and since the tracker consumes
 	// them faster than the transfer produces, 16 is enough to avoid blocking with room to grow.
 	eventBufferSize = 16
@@ -64,12 +70,18 @@ OCI and CTF repositories are supported as transfer targets, while Helm repositor
 
 By default, only the component version itself is transferred. Use --copy-resources to also
 copy (and, when needed, transform) the resources it references. --upload-as controls whether
-those resources land as OCI artifacts or as local blobs in the target. --recursive walks the
-component's references and transfers them too.
+those resources land as OCI artifacts or as local blobs in the target. --digest-pinning refuses
+(enforce) or pins (resolve) OCI artifact accesses that reference a mutable tag without a digest,
+whether the resources are copied or not. With resolve, the tag is resolved against the registry and
+has to reference the artifact described by the signed resource digest. With both values, the image
+references of OCI artifacts copied into an OCI target are pinned to their digest, so consumers of
+the target get exactly the signed artifacts. --recursive walks the component's references and
+transfers them too.
 
 Driving defaults from the OCM configuration:
   A transfer.config.ocm.software/v1alpha1 entry inside the central OCM configuration
-  (passed via --config) sets defaults for --recursive, --copy-resources, and --upload-as.
+  (passed via --config) sets defaults for --recursive, --copy-resources, --upload-as,
+  and --digest-pinning.
   Explicit command-line flags always override the values from the configuration.
 
 Two-step workflow (generate, review, replay):
@@ -78,7 +90,7 @@ Two-step workflow (generate, review, replay):
   from a file (or stdin with "-"):
     1. Generate the spec:  transfer cv --dry-run -o yaml --copy-resources -r {reference} {target} > spec.yaml
     2. Review/edit spec.yaml, then execute: transfer cv --transfer-spec spec.yaml
-  All graph-shaping flags (--recursive, --copy-resources, --upload-as) and any transfer
+  All graph-shaping flags (--recursive, --copy-resources, --upload-as, --digest-pinning) and any transfer
   configuration entry are baked into the spec during step 1 and are therefore ignored in
   step 2 - the spec is the full graph definition. Only --dry-run and --output remain
   meaningful when replaying a spec.
@@ -115,6 +127,12 @@ transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.
 # Transfer a component version containing Helm charts (access-type: helm/v1) as an OCI artifact
 transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm --copy-resources --upload-as ociArtifact
 
+# Copy external OCI images into the target and pin their new image references to the digest
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm --copy-resources --upload-as ociArtifact --digest-pinning resolve
+
+# Refuse to transfer OCI artifact accesses that reference a tag without a digest
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm --digest-pinning enforce
+
 # Transfer including all resources (e.g. OCI artifacts)
 transfer component-version ctf::./my-archive//ocm.software/mycomponent:1.0.0 ghcr.io/my-org/ocm --copy-resources
 
@@ -152,6 +170,10 @@ transfer component-version --transfer-spec spec.yaml
 	}
 	enum.VarP(cmd.Flags(), FlagUploadAs, "u", uploadAsValues,
 		"Define whether copied resources should be uploaded as OCI artifacts (instead of local blob resources). This option is only relevant if --copy-resources is set.")
+	enum.Var(cmd.Flags(), FlagDigestPinning, []string{digestPinningNone, string(transferv1alpha1.DigestPinningEnforce), string(transferv1alpha1.DigestPinningResolve)},
+		"refuse (enforce) or pin to the digest their tag resolves to in the registry (resolve) OCI artifact accesses that reference a tag without a digest. "+
+			"Pinned accesses record the original image reference in a resource label. "+
+			"With both values, the image references of OCI artifacts copied into an OCI target are pinned to their digest.")
 	cmd.Flags().String(FlagTransferSpec, "", "path to a transfer specification file (use \"-\" for stdin)")
 	cmd.Flags().String(FlagSemverConstraint, "", "semantic version constraint restricting which versions to transfer (e.g. \">= 1.0.0, < 2.0.0\"); only used when no version is specified in the reference")
 	cmd.Flags().Bool(FlagLatest, false, "if set, only the latest version of the component is transferred; only used when no version is specified in the reference")
@@ -169,7 +191,7 @@ func transferArgs(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
 			return fmt.Errorf("positional arguments are not allowed when --%s is set", FlagTransferSpec)
 		}
-		ignoredFlags := []string{FlagRecursive, FlagCopyResources, FlagUploadAs}
+		ignoredFlags := []string{FlagRecursive, FlagCopyResources, FlagUploadAs, FlagDigestPinning}
 		for _, name := range ignoredFlags {
 			if cmd.Flags().Changed(name) {
 				slog.Warn(fmt.Sprintf("--%s has no effect when --%s is set", name, FlagTransferSpec))
@@ -236,8 +258,19 @@ func TransferComponentVersion(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Component versions written to the targets are announced to the configured webhook endpoints.
+	var repoProvider repository.ComponentVersionRepositoryProvider = pm.ComponentVersionRepositoryRegistry
+	emitter, waitForWebhooks, err := ocm.NewWebhookEmitter(ctx, octx.Configuration())
+	if err != nil {
+		return err
+	}
+	defer waitForWebhooks()
+	if emitter != nil {
+		repoProvider = webhook.NewProvider(repoProvider, emitter)
+	}
+
 	// Build transformation graph
-	b := transfer.NewDefaultBuilder(pm.ComponentVersionRepositoryRegistry, pm.ResourcePluginRegistry, credGraph)
+	b := transfer.NewDefaultBuilder(repoProvider, pm.ResourcePluginRegistry, credGraph)
 	graph, err := b.
 		WithEvents(make(chan graphRuntime.ProgressEvent, eventBufferSize)).
 		BuildAndCheck(tgd)
@@ -390,6 +423,16 @@ func buildGraphDefinitionFromArgs(
 		}
 		transferCfg.UploadType = transferv1alpha1.UploadType(uploadAs)
 	}
+	if cmd.Flags().Changed(FlagDigestPinning) {
+		digestPinning, err := enum.Get(cmd.Flags(), FlagDigestPinning)
+		if err != nil {
+			return nil, fmt.Errorf("getting digest-pinning flag failed: %w", err)
+		}
+		if digestPinning == digestPinningNone {
+			digestPinning = ""
+		}
+		transferCfg.DigestPinning = transferv1alpha1.DigestPinning(digestPinning)
+	}
 
 	constraint, err := cmd.Flags().GetString(FlagSemverConstraint)
 	if err != nil {
@@ -437,13 +480,19 @@ func buildGraphDefinitionFromArgs(
 		}
 	}
 
-	tgd, err := transfer.BuildGraphDefinition(ctx, transferCfg,
-		transfer.Mapping{
-			Components: componentIDs,
-			Target:     toSpec,
-			Resolver:   repoProvider,
-		},
-	)
+	mapping := transfer.Mapping{
+		Components: componentIDs,
+		Target:     toSpec,
+		Resolver:   repoProvider,
+	}
+	if transferCfg.Mode == transferv1alpha1.TransferModeDelta {
+		// delta transfers look up the component versions already present in the target.
+		if mapping.TargetRepository, err = repoProvider.GetComponentVersionRepositoryForSpecification(ctx, toSpec); err != nil {
+			return nil, fmt.Errorf("could not access target repository: %w", err)
+		}
+	}
+
+	tgd, err := transfer.BuildGraphDefinition(ctx, transferCfg, mapping)
 	if err != nil {
 		return nil, fmt.Errorf("building graph definition failed: %w", err)
 	}
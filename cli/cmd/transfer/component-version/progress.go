@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"ocm.software/open-component-model/bindings/go/transfer"
 	graphPkg "ocm.software/open-component-model/bindings/go/transform/graph"
 	graphRuntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
 	"ocm.software/open-component-model/cli/internal/render/progress"
@@ -53,6 +54,13 @@ func formatError(t *graphPkg.Transformation, err error) string {
 }
 
 // formatTransformationName returns a display name as "ID [Type]".
+// The summary of a delta transfer additionally shows the skipped and copied component versions.
 func formatTransformationName(t *graphPkg.Transformation) string {
-	return fmt.Sprintf("%s [%s]", t.ID, t.Type.Name)
+	name := fmt.Sprintf("%s [%s]", t.ID, t.Type.Name)
+	if summary, err := transfer.DeltaSummaryFromEvent(graphRuntime.ProgressEvent{Transformation: t}); err == nil && summary != nil {
+		name += fmt.Sprintf(" %d skipped (%d bytes), %d copied (%d bytes)",
+			summary.SkippedComponentVersions, summary.SkippedBytes,
+			summary.CopiedComponentVersions, summary.CopiedBytes)
+	}
+	return name
 }
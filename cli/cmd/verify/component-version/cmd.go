@@ -2,9 +2,11 @@ package componentversion
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"strings"
 	"time"
 
@@ -13,13 +15,16 @@ import (
 
 	"ocm.software/open-component-model/bindings/go/credentials"
 	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci"
 	"ocm.software/open-component-model/bindings/go/oci/compref"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
 	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
 	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
 	"ocm.software/open-component-model/bindings/go/rsa/signing/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/signing"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
+	"ocm.software/open-component-model/cli/internal/flags/enum"
 	"ocm.software/open-component-model/cli/internal/flags/log"
 	"ocm.software/open-component-model/cli/internal/repository/ocm"
 )
@@ -28,6 +33,12 @@ const (
 	FlagConcurrencyLimit = "concurrency-limit"
 	FlagSignature        = "signature"
 	FlagVerifierSpec     = "verifier-spec"
+	FlagDigestPinning    = "digest-pinning"
+
+	// digestPinningNone is the value of --digest-pinning that accepts OCI artifact accesses referencing a tag.
+	digestPinningNone = "none"
+	// digestPinningEnforce is the value of --digest-pinning that refuses OCI artifact accesses referencing a tag.
+	digestPinningEnforce = "enforce"
 )
 
 func New() *cobra.Command {
@@ -53,13 +64,16 @@ func New() *cobra.Command {
 - Normalise descriptor (algorithm from signature)  
 - Recompute hash and compare with signature digest  
 - Verify signature (--verifier-spec, default RSASSA-PSS verifier)  
+- Check that OCI artifact accesses pinned to a digest reference the signed resource digest  
 
 ## Behavior
 
 - --signature selects a single signature by name; without it, every signature on the descriptor is verified
+- --signature also accepts a glob pattern, e.g. 'cosign-*' selects the cosign signatures attached to the component version manifest in OCI registries; their digest is the manifest digest
 - Signatures are verified concurrently (--concurrency-limit); the command exits non-zero on the first failure
 - Default verifier: RSASSA-PSS, resolves the public key from credentials in .ocmconfig
 - For Sigstore keyless verification, pass --verifier-spec with a SigstoreVerificationConfiguration/v1alpha1 config
+- OCI artifact accesses pinned to a digest must be pinned to the signed resource digest; --digest-pinning enforce also refuses accesses that only reference a mutable tag
 
 Use to validate component versions before promotion, deployment, or further usage to ensure integrity and provenance.`,
 			compref.DefaultPrefix,
@@ -164,16 +178,27 @@ verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0
 # Verify a specific signature
 verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --signature my-signature
 
+# Verify the signatures created with "cosign sign" on the component version manifest
+verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --signature 'cosign-*' --verifier-spec ./sigstore-verify.yaml
+
 # Use a verifier specification file
 verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --verifier-spec ./rsassa-pss.yaml
+
+# Refuse OCI artifact accesses that reference a tag without a digest
+verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --digest-pinning enforce
 `),
 		RunE:              VerifyComponentVersion,
 		DisableAutoGenTag: true,
 	}
 
 	cmd.Flags().Int(FlagConcurrencyLimit, 4, "maximum amount of parallel requests to the repository for resolving component versions")
-	cmd.Flags().String(FlagSignature, "", "name of the signature to verify. If not set, all signatures are verified.")
+	cmd.Flags().String(FlagSignature, "", "name of the signature to verify, or a glob pattern matching the names of the signatures to verify, "+
+		"e.g. 'cosign-*' for the cosign signatures attached to the component version manifest. "+
+		"If not set, all signatures of the component descriptor are verified.")
 	cmd.Flags().String(FlagVerifierSpec, "", "path to a verifier specification file. If empty, defaults to RSASSA-PSS.")
+	enum.Var(cmd.Flags(), FlagDigestPinning, []string{digestPinningNone, digestPinningEnforce},
+		"refuse (enforce) OCI artifact accesses that reference a tag without a digest. "+
+			"Accesses pinned to a digest are always checked against the resource digest.")
 
 	return cmd
 }
@@ -225,6 +250,11 @@ func VerifyComponentVersion(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting verifier-spec flag failed: %w", err)
 	}
 
+	digestPinning, err := enum.Get(cmd.Flags(), FlagDigestPinning)
+	if err != nil {
+		return fmt.Errorf("getting digest-pinning flag failed: %w", err)
+	}
+
 	reference := args[0]
 
 	config := ocmContext.Configuration()
@@ -242,21 +272,31 @@ func VerifyComponentVersion(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not access ocm repository: %w", err)
 	}
 
-	desc, err := repo.GetComponentVersion(ctx, ref.Component, ref.Version)
-	if err != nil {
+	var desc *descruntime.Descriptor
+	if cosignRepo, ok := repo.(oci.CosignSignatureRepository); ok {
+		cosign, err := cosignRepo.GetComponentVersionCosignSignatures(ctx, ref.Component, ref.Version)
+		if err != nil {
+			return fmt.Errorf("getting component version and cosign signatures failed: %w", err)
+		}
+		// the cosign signatures sign the manifest holding the descriptor, their digest is verified against it.
+		desc = oci.MergeSignatures(cosign.Descriptor, cosign.Signatures)
+		ctx = signing.WithArtifactDigest(ctx, cosign.ManifestDigest)
+	} else if desc, err = repo.GetComponentVersion(ctx, ref.Component, ref.Version); err != nil {
 		return fmt.Errorf("getting component reference and versions failed: %w", err)
 	}
 
 	var sigs []descruntime.Signature
-	if signatureName != "" {
-		for _, sig := range desc.Signatures {
-			if sig.Name == signatureName {
+	for _, sig := range desc.Signatures {
+		if signatureName == "" {
+			// cosign signatures are only verified on request, they require a Sigstore verifier spec.
+			if sig.Digest.NormalisationAlgorithm != signing.ArtifactDigestNormalisation {
 				sigs = append(sigs, sig)
-				break
 			}
+			continue
+		}
+		if matched, _ := path.Match(signatureName, sig.Name); matched || sig.Name == signatureName {
+			sigs = append(sigs, sig)
 		}
-	} else {
-		sigs = desc.Signatures
 	}
 
 	if len(sigs) == 0 {
@@ -327,5 +367,30 @@ func VerifyComponentVersion(cmd *cobra.Command, args []string) error {
 	}
 
 	logger.InfoContext(ctx, "SIGNATURE VERIFICATION SUCCESSFUL")
+
+	// the resource digests are covered by the verified signatures, the accesses are not.
+	if err := verifyPinnedReferences(ctx, desc, digestPinning == digestPinningEnforce); err != nil {
+		return fmt.Errorf("PINNED REFERENCE VERIFICATION FAILED: %w", err)
+	}
 	return nil
 }
+
+// verifyPinnedReferences checks that the OCI artifact accesses of the resources that are pinned to a digest
+// reference the artifacts described by the resource digests. If enforce is set, accesses that only reference
+// a tag are refused.
+func verifyPinnedReferences(ctx context.Context, desc *descruntime.Descriptor, enforce bool) error {
+	var errs []error
+	for i := range desc.Component.Resources {
+		res := &desc.Component.Resources[i]
+		if enforce {
+			if _, err := pinning.Apply(ctx, res, pinning.PolicyEnforce, nil); err != nil {
+				errs = append(errs, fmt.Errorf("resource %s: %w", res.ToIdentity(), err))
+			}
+			continue
+		}
+		if err := pinning.Verify(res); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -8,8 +8,11 @@ import (
 	helmdigest "ocm.software/open-component-model/bindings/go/helm/digest"
 	helmresource "ocm.software/open-component-model/bindings/go/helm/repository/resource"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	encryptionv1alpha1 "ocm.software/open-component-model/bindings/go/oci/encryption/spec/v1alpha1"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
 	ocicredentialplugin "ocm.software/open-component-model/cli/internal/plugin/builtin/credentials/oci"
+	vaultcredentialplugin "ocm.software/open-component-model/cli/internal/plugin/builtin/credentials/vault"
 	"ocm.software/open-component-model/cli/internal/plugin/builtin/gpg"
 	"ocm.software/open-component-model/cli/internal/plugin/builtin/input/dir"
 	"ocm.software/open-component-model/cli/internal/plugin/builtin/input/file"
@@ -21,10 +24,20 @@ import (
 	"ocm.software/open-component-model/cli/internal/plugin/builtin/wget"
 )
 
-func Register(manager *manager.PluginManager, filesystemConfig *filesystemv1alpha1.Config, httpConfig *httpv1alpha1.Config, logger *slog.Logger) error {
+func Register(
+	manager *manager.PluginManager,
+	filesystemConfig *filesystemv1alpha1.Config,
+	httpConfig *httpv1alpha1.Config,
+	encryptionConfig *encryptionv1alpha1.Config,
+	registryConfig *registriesv1alpha1.Config,
+	logger *slog.Logger,
+) error {
 	if err := ocicredentialplugin.Register(manager.CredentialRepositoryRegistry); err != nil {
 		return fmt.Errorf("could not register OCI inbuilt credential plugin: %w", err)
 	}
+	if err := vaultcredentialplugin.Register(manager.CredentialRepositoryRegistry); err != nil {
+		return fmt.Errorf("could not register vault inbuilt credential plugin: %w", err)
+	}
 
 	if err := ociplugin.Register(
 		manager.ComponentVersionRepositoryRegistry,
@@ -34,6 +47,8 @@ func Register(manager *manager.PluginManager, filesystemConfig *filesystemv1alph
 		manager.ComponentListerRegistry,
 		filesystemConfig,
 		httpConfig,
+		encryptionConfig,
+		registryConfig,
 		logger,
 	); err != nil {
 		return fmt.Errorf("could not register OCI inbuilt plugin: %w", err)
@@ -12,7 +12,7 @@ func Register(registry *credentialrepository.RepositoryRegistry) error {
 	registry.Register(ocicredentialsspec.CredentialTypeScheme)
 
 	return registry.RegisterInternalCredentialRepositoryPlugin(
-		&ocicredentials.OCICredentialRepository{},
+		&ocicredentials.OCICredentialRepository{SecretProvider: kubeconfigSecretProvider{}},
 		[]runtime.Type{ociidentity.Type},
 	)
 }
@@ -0,0 +1,48 @@
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	ocicredentials "ocm.software/open-component-model/bindings/go/oci/credentials"
+	credentialsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
+)
+
+// kubeconfigSecretProvider reads secrets of KubernetesSecrets credential repositories with the
+// kubeconfig of the configuration. Without a kubeconfig, the default loading rules of kubectl apply,
+// i.e. $KUBECONFIG or ~/.kube/config, and the namespace defaults to the one of the current context.
+type kubeconfigSecretProvider struct{}
+
+var _ ocicredentials.SecretProvider = kubeconfigSecretProvider{}
+
+func (kubeconfigSecretProvider) GetSecretData(ctx context.Context, cfg *credentialsv1.KubernetesSecrets, name string) (map[string][]byte, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = cfg.Kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	namespace := cfg.Namespace
+	if namespace == "" {
+		if namespace, _, err = clientConfig.Namespace(); err != nil {
+			return nil, fmt.Errorf("failed to determine namespace from kubeconfig: %w", err)
+		}
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+
+	return secret.Data, nil
+}
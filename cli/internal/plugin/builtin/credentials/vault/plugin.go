@@ -0,0 +1,17 @@
+package vault
+
+import (
+	"ocm.software/open-component-model/bindings/go/credentials/vault"
+	ociidentity "ocm.software/open-component-model/bindings/go/oci/spec/identity/v1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/credentialrepository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Register registers the HashiCorp Vault credential repository, which resolves credentials from
+// the secrets of a KV v2 secrets engine configured as HashiCorpVault repository.
+func Register(registry *credentialrepository.RepositoryRegistry) error {
+	return registry.RegisterInternalCredentialRepositoryPlugin(
+		&vault.CredentialRepository{},
+		[]runtime.Type{ociidentity.Type},
+	)
+}
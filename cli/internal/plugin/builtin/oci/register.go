@@ -6,8 +6,10 @@ import (
 
 	filesystemv1alpha1 "ocm.software/open-component-model/bindings/go/configuration/filesystem/v1alpha1/spec"
 	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	encryptionv1alpha1 "ocm.software/open-component-model/bindings/go/oci/encryption/spec/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/oci/repository/provider"
 	ocires "ocm.software/open-component-model/bindings/go/oci/repository/resource"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/oci/transformer"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/blobtransformer"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/componentlister"
@@ -26,15 +28,22 @@ func Register(
 	compListRegistry *componentlister.ComponentListerRegistry,
 	filesystemConfig *filesystemv1alpha1.Config,
 	httpConfig *httpv1alpha1.Config,
+	encryptionConfig *encryptionv1alpha1.Config,
+	registryConfig *registriesv1alpha1.Config,
 	logger *slog.Logger,
 ) error {
 	CachingComponentVersionRepositoryProvider := provider.NewComponentVersionRepositoryProvider(
 		provider.WithTempDir(filesystemConfig.TempFolder),
 		provider.WithUserAgent(creator),
 		provider.WithHTTPConfig(httpConfig),
+		provider.WithEncryptionConfig(encryptionConfig),
+		provider.WithRegistryConfig(registryConfig),
 	)
 
-	resourceRepoPlugin := ocires.NewResourceRepository(filesystemConfig, ocires.WithUserAgent(creator))
+	resourceRepoPlugin := ocires.NewResourceRepository(filesystemConfig,
+		ocires.WithUserAgent(creator),
+		ocires.WithRegistryConfig(registryConfig),
+	)
 	ociBlobTransformerPlugin := transformer.New(logger)
 
 	return errors.Join(
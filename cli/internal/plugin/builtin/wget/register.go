@@ -14,7 +14,7 @@ import (
 	wgetcreds "ocm.software/open-component-model/bindings/go/wget/spec/credentials"
 )
 
-// Register wires the wget input method and its credential scheme into the CLI plugin registries.
+// Register wires the wget and urlFile input methods and their credential scheme into the CLI plugin registries.
 func Register(inputRegistry *input.RepositoryRegistry,
 	resourcePluginRegistry *resource.ResourceRegistry,
 	digestProcessorRegistry *digestprocessor.RepositoryRegistry,
@@ -31,6 +31,13 @@ func Register(inputRegistry *input.RepositoryRegistry,
 		return fmt.Errorf("could not register wget resource input method: %w", err)
 	}
 
+	urlFileMethod := &wgetinput.URLFileInputMethod{
+		HTTPConfig: httpConfig,
+	}
+	if err := inputRegistry.RegisterInternalResourceInputPlugin(urlFileMethod); err != nil {
+		return fmt.Errorf("could not register urlFile resource input method: %w", err)
+	}
+
 	wgetResourceRepository := wgetrepository.NewResourceRepository(
 		wgetrepository.WithHTTPClient(httpclient.New(httpclient.WithConfig(httpConfig))),
 	)
@@ -0,0 +1,27 @@
+package ocm
+
+import (
+	"context"
+	"log/slog"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/repository/webhook"
+)
+
+// NewWebhookEmitter creates the emitter of the webhook endpoints configured with
+// webhook.config.ocm.software/v1alpha1, see webhook.LookupEmitter. The emitter is nil if no endpoints
+// are configured.
+//
+// Events are delivered in the background. wait blocks until the pending events are delivered or ctx is done,
+// and has to be called before the command returns.
+func NewWebhookEmitter(ctx context.Context, cfg *genericv1.Config) (emitter *webhook.Emitter, wait func(), err error) {
+	emitter, err = webhook.LookupEmitter(cfg)
+	if err != nil || emitter == nil {
+		return nil, func() {}, err
+	}
+	return emitter, func() {
+		if err := emitter.Wait(ctx); err != nil {
+			slog.WarnContext(ctx, "not all webhook events were delivered", "error", err)
+		}
+	}, nil
+}
@@ -54,6 +54,25 @@ type OCMConfiguration struct {
 	Policy ConfigurationPolicy `json:"policy,omitempty"`
 }
 
+// PluginSelection pins the plugin that serves the type of an ocm repository if multiple
+// installed plugins serve the same type.
+type PluginSelection struct {
+	// Name of the plugin, which is the file name of the plugin binary in the plugin directory
+	// of the controller.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+	// Version the plugin must advertise. If empty, any version of the plugin is accepted.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Isolate runs the plugin in a process dedicated to the namespace of the object and its
+	// effective ocm configuration, instead of the process shared with the platform defaults.
+	// The isolated process does not get the configuration of the controller and can only
+	// access the credentials resolved from the effective ocm configuration.
+	// +optional
+	Isolate bool `json:"isolate,omitempty"`
+}
+
 type ObjectKey struct {
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
@@ -78,6 +97,31 @@ type Verification struct {
 	// Value defines a PEM/base64 encoded public key value.
 	// +optional
 	Value string `json:"value,omitempty"`
+	// Sigstore verifies the signature as Sigstore bundle with the given identity constraints instead of
+	// a public key. It is used for keyless signatures, e.g. the signatures created by "cosign sign" on the
+	// manifest of a component version in an OCI registry, which are named cosign-<digest>. Signature may be
+	// a glob pattern, e.g. "cosign-*", to accept any of the matching signatures.
+	// +optional
+	Sigstore *SigstoreVerification `json:"sigstore,omitempty"`
+}
+
+// SigstoreVerification defines the identity constraints a keyless Sigstore signature must satisfy.
+// An issuer constraint (certificateOIDCIssuer or certificateOIDCIssuerRegexp) and an identity constraint
+// (certificateIdentity or certificateIdentityRegexp) are required.
+type SigstoreVerification struct {
+	// CertificateOIDCIssuer is the exact OIDC issuer URL the signing certificate must have been issued for.
+	// +optional
+	CertificateOIDCIssuer string `json:"certificateOIDCIssuer,omitempty"`
+	// CertificateOIDCIssuerRegexp is a regular expression matched against the OIDC issuer URL.
+	// +optional
+	CertificateOIDCIssuerRegexp string `json:"certificateOIDCIssuerRegexp,omitempty"`
+	// CertificateIdentity is the exact Subject Alternative Name the signing certificate must carry,
+	// typically the signer's email or CI workflow URI.
+	// +optional
+	CertificateIdentity string `json:"certificateIdentity,omitempty"`
+	// CertificateIdentityRegexp is a regular expression matched against the certificate Subject Alternative Name.
+	// +optional
+	CertificateIdentityRegexp string `json:"certificateIdentityRegexp,omitempty"`
 }
 
 // ResourceID defines the configuration of the repository.
@@ -105,6 +149,9 @@ type ComponentInfo struct {
 	// Digest information of the Component, if available as per OCM specification.
 	// +optional
 	Digest *v2.Digest `json:"digest,omitempty"`
+	// Plugin is the plugin selected to serve the type of the RepositorySpec, if any.
+	// +optional
+	Plugin *PluginSelection `json:"plugin,omitempty"`
 }
 
 type ResourceInfo struct {
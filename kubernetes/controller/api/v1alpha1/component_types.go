@@ -51,11 +51,23 @@ type ComponentSpec struct {
 	// +optional
 	Verify []Verification `json:"verify,omitempty"`
 
+	// VerifyThreshold is the number of signatures in Verify that must be verified
+	// successfully, e.g. 2 to accept component versions signed by any 2 of 3
+	// listed signers. If unset, all signatures in Verify must be verified.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	VerifyThreshold int32 `json:"verifyThreshold,omitempty"`
+
 	// OCMConfig defines references to secrets, config maps or ocm api
 	// objects providing configuration data including credentials.
 	// +optional
 	OCMConfig []OCMConfiguration `json:"ocmConfig,omitempty"`
 
+	// Plugin pins the plugin that serves the type of the referenced
+	// Repository. It takes precedence over the plugin of the Repository.
+	// +optional
+	Plugin *PluginSelection `json:"plugin,omitempty"`
+
 	// Interval at which the repository will be checked for new component
 	// versions.
 	// +required
@@ -10,6 +10,8 @@ const (
 	StalledCondition = "Stalled"
 	// TransferInProgressCondition indicates a Replication transfer is in flight.
 	TransferInProgressCondition = "TransferInProgress"
+	// DriftedCondition indicates whether objects deployed by a Deployer drifted from the rendered objects.
+	DriftedCondition = "Drifted"
 )
 
 // Generic condition reasons.
@@ -56,9 +58,40 @@ const (
 	// ApplyFailed is used when we fail to create or update a resource.
 	ApplyFailed = "ApplyFailed"
 
+	// HelmReleaseFailedReason is used when the Flux objects deploying a Helm chart cannot be generated.
+	HelmReleaseFailedReason = "HelmReleaseFailed"
+
+	// KustomizeFailedReason is used when a resource cannot be rendered with kustomize.
+	KustomizeFailedReason = "KustomizeFailed"
+
+	// DriftDetectedReason is used when deployed objects drifted and the drift is only reported.
+	DriftDetectedReason = "DriftDetected"
+
+	// DriftCorrectedReason is used when deployed objects drifted and were re-applied.
+	DriftCorrectedReason = "DriftCorrected"
+
+	// NoDriftReason is used when no deployed object drifted.
+	NoDriftReason = "NoDrift"
+
+	// DriftDetectionFailedReason is used when the deployed objects cannot be checked for drift.
+	DriftDetectionFailedReason = "DriftDetectionFailed"
+
+	// CRDUpgradeUnsafeReason is used when CustomResourceDefinitions are not applied because they change unsafely.
+	CRDUpgradeUnsafeReason = "CRDUpgradeUnsafe"
+
+	// CRDUpgradeCheckFailedReason is used when the changes of CustomResourceDefinitions cannot be checked.
+	CRDUpgradeCheckFailedReason = "CRDUpgradeCheckFailed"
+
+	// LocalizationFailedReason is used when the localizations of a Deployer cannot be substituted.
+	LocalizationFailedReason = "LocalizationFailed"
+
 	// GetReferenceFailedReason is used when we fail to get a reference.
 	GetReferenceFailedReason = "GetReferenceFailed"
 
+	// UnpinnedAccessReason is used when the access of a resource is not pinned to a digest as required
+	// by the digest pinning policy.
+	UnpinnedAccessReason = "UnpinnedAccess"
+
 	// GetResourceFailedReason is used when we fail to get the resource.
 	GetResourceFailedReason = "GetResourceFailed"
 
@@ -74,6 +107,9 @@ const (
 	// ResolutionInProgress is used when resolution is still in progress.
 	ResolutionInProgress = "ResolutionInProgress"
 
+	// ResolutionQuotaExceededReason is used when a resolution is rejected because the namespace exceeded its resolution quota.
+	ResolutionQuotaExceededReason = "ResolutionQuotaExceeded"
+
 	// ComponentDriftResolutionInProgress the component and the deployer are catching up.
 	ComponentDriftResolutionInProgress = "ComponentDriftResolutionInProgress"
 
@@ -82,4 +118,13 @@ const (
 
 	// TransferCompleteReason is used when no Replication transfer is done.
 	TransferCompleteReason = "TransferComplete"
+
+	// ComponentNotReadyReason is used when the Component managed by a ProductDeployment is not Ready yet.
+	ComponentNotReadyReason = "ComponentNotReady"
+
+	// DeploymentNotReadyReason is used when a Resource or Deployer managed by a ProductDeployment is not Ready yet.
+	DeploymentNotReadyReason = "DeploymentNotReady"
+
+	// InvalidDeploymentReason is used when a ProductDeployment deploys outside of its namespace or cluster.
+	InvalidDeploymentReason = "InvalidDeployment"
 )
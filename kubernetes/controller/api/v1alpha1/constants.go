@@ -27,4 +27,7 @@ const (
 	RepositoryFinalizer = "finalizers.ocm.software/repository"
 	// ReplicationFinalizer makes sure that an in-flight transfer is drained before the Replication is removed.
 	ReplicationFinalizer = "finalizers.ocm.software/replication"
+	// ProductDeploymentFinalizer makes sure that the cluster-scoped Deployers managed by a ProductDeployment are
+	// removed before the ProductDeployment is deleted.
+	ProductDeploymentFinalizer = "finalizers.ocm.software/product-deployment"
 )
@@ -3,12 +3,75 @@ package v1alpha1
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 const KindDeployer = "Deployer"
 
+// RecreateOnImmutableChangeAnnotation is the annotation deployed objects carry to opt in to (value "true") or
+// out of (value "false") being recreated when a change of an immutable field cannot be applied.
+// See RecreatePolicy for details.
+const RecreateOnImmutableChangeAnnotation = "delivery.ocm.software/recreate-on-immutable-change"
+
+// RecreatePolicy defines whether the Deployer deletes and recreates deployed objects whose immutable fields
+// changed, e.g. the template of a Job or the clusterIP of a Service, which server-side apply cannot update.
+// +kubebuilder:validation:Enum=Never;IfAnnotated;Always
+type RecreatePolicy string
+
+const (
+	// RecreatePolicyNever never recreates objects, so that applying a change of an immutable field fails.
+	RecreatePolicyNever RecreatePolicy = "Never"
+	// RecreatePolicyIfAnnotated recreates objects annotated with RecreateOnImmutableChangeAnnotation set to "true".
+	RecreatePolicyIfAnnotated RecreatePolicy = "IfAnnotated"
+	// RecreatePolicyAlways recreates all objects, except those annotated with RecreateOnImmutableChangeAnnotation
+	// set to "false".
+	RecreatePolicyAlways RecreatePolicy = "Always"
+)
+
+// CRDUpgradePolicy defines how the Deployer handles unsafe changes of CustomResourceDefinitions that already
+// exist in the cluster, e.g. when an older version of a component downgrades them.
+// +kubebuilder:validation:Enum=Fail;Warn;Allow
+type CRDUpgradePolicy string
+
+const (
+	// CRDUpgradePolicyFail does not apply any object if a CustomResourceDefinition changes unsafely.
+	CRDUpgradePolicyFail CRDUpgradePolicy = "Fail"
+	// CRDUpgradePolicyWarn applies the objects, but reports unsafe changes of CustomResourceDefinitions through
+	// a warning event.
+	CRDUpgradePolicyWarn CRDUpgradePolicy = "Warn"
+	// CRDUpgradePolicyAllow applies the objects without checking the changes of CustomResourceDefinitions.
+	CRDUpgradePolicyAllow CRDUpgradePolicy = "Allow"
+)
+
+// CRDUpgradeFindingType is the kind of an unsafe change of a CustomResourceDefinition.
+type CRDUpgradeFindingType string
+
+const (
+	// CRDUpgradeVersionRemoved is found if a version of the existing CustomResourceDefinition is removed.
+	// Objects of this version can no longer be read or written, and objects stored in it can no longer be
+	// read at all.
+	CRDUpgradeVersionRemoved CRDUpgradeFindingType = "VersionRemoved"
+	// CRDUpgradeStorageVersionChanged is found if the storage version of the CustomResourceDefinition changes.
+	// Existing objects stay stored in the previous version until they are migrated.
+	CRDUpgradeStorageVersionChanged CRDUpgradeFindingType = "StorageVersionChanged"
+	// CRDUpgradeScopeChanged is found if the scope of the CustomResourceDefinition changes between Namespaced
+	// and Cluster, which orphans the existing objects.
+	CRDUpgradeScopeChanged CRDUpgradeFindingType = "ScopeChanged"
+)
+
+// CRDUpgradeFinding is an unsafe change of a CustomResourceDefinition found before it was applied.
+type CRDUpgradeFinding struct {
+	// Name of the CustomResourceDefinition.
+	Name string `json:"name"`
+	// Type of the change.
+	Type CRDUpgradeFindingType `json:"type"`
+	// Message describes the change.
+	Message string `json:"message"`
+}
+
 // DeployerSpec defines the desired state of Deployer.
 type DeployerSpec struct {
 	// ResourceRef is the k8s resource name of an OCM resource containing the ResourceGroupDefinition.
@@ -20,10 +83,199 @@ type DeployerSpec struct {
 	// +optional
 	OCMConfig []OCMConfiguration `json:"ocmConfig,omitempty"`
 
+	// TargetNamespace is the namespace namespaced objects are deployed to
+	// if they do not specify a namespace themselves. Defaults to "default".
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// RestrictToTargetNamespace rejects cluster-scoped objects and objects specifying a namespace
+	// other than TargetNamespace. It is set on the Deployers of ProductDeployments, which must not
+	// deploy outside of the namespace of the ProductDeployment.
+	// +optional
+	RestrictToTargetNamespace bool `json:"restrictToTargetNamespace,omitempty"`
+
 	// Suspend tells the controller to suspend the reconciliation of this
 	// Resource.
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
+
+	// RecreatePolicy defines whether deployed objects are deleted and recreated if a change of
+	// an immutable field cannot be applied. Objects not managed by this Deployer as well as
+	// Namespaces, CustomResourceDefinitions, PersistentVolumes and PersistentVolumeClaims are
+	// never recreated. Defaults to Never.
+	// +kubebuilder:default=Never
+	// +optional
+	RecreatePolicy RecreatePolicy `json:"recreatePolicy,omitempty"`
+
+	// CRDUpgradePolicy defines how unsafe changes of CustomResourceDefinitions that already exist in the
+	// cluster are handled. A change is unsafe if it removes a version, changes the storage version or
+	// changes the scope of the CustomResourceDefinition. Defaults to Fail.
+	// +kubebuilder:default=Fail
+	// +optional
+	CRDUpgradePolicy CRDUpgradePolicy `json:"crdUpgradePolicy,omitempty"`
+
+	// Localizations substitute values derived from the component descriptor, e.g. the image references
+	// of the resources of the component, into the deployed objects before they are applied.
+	// +optional
+	Localizations []Localization `json:"localizations,omitempty"`
+
+	// Helm configures the deployment of resources of type helmChart.
+	// +optional
+	Helm *HelmDeployment `json:"helm,omitempty"`
+
+	// Kustomize renders the resource with kustomize before it is applied.
+	// +optional
+	Kustomize *Kustomization `json:"kustomize,omitempty"`
+
+	// DriftDetection periodically compares the deployed objects with the objects rendered from the resource,
+	// and corrects or reports objects that were changed or deleted outside of the Deployer. If not set, the
+	// objects are only applied when a change of the Deployer, the Resource or a deployed object triggers a
+	// reconciliation.
+	// +optional
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
+}
+
+// DriftDetectionMode defines how the Deployer handles drifted objects.
+// +kubebuilder:validation:Enum=Correct;Report
+type DriftDetectionMode string
+
+const (
+	// DriftDetectionModeCorrect re-applies the objects, which corrects their drift.
+	DriftDetectionModeCorrect DriftDetectionMode = "Correct"
+	// DriftDetectionModeReport only reports drift through the Drifted condition. The objects are applied
+	// only if the objects rendered from the resource or the Deployer changed.
+	DriftDetectionModeReport DriftDetectionMode = "Report"
+)
+
+// DriftDetection configures the periodic detection of drift of the deployed objects. An object drifted if a
+// server-side apply dry-run of the rendered object differs from the live object, or if the live object does
+// not exist anymore. The result is reported by the Drifted condition of the Deployer.
+type DriftDetection struct {
+	// Interval at which the deployed objects are checked for drift.
+	// +required
+	Interval metav1.Duration `json:"interval"`
+
+	// Mode defines whether drift is corrected or only reported. Defaults to Correct.
+	// +kubebuilder:default=Correct
+	// +optional
+	Mode DriftDetectionMode `json:"mode,omitempty"`
+}
+
+// Kustomization renders a resource containing a directory tree, e.g. of type directoryTree, or a tar archive
+// with kustomize. If the directory of the kustomization has no kustomization file, one is generated that
+// includes all YAML and JSON files of the directory, but not of its subdirectories.
+// Plugins, Helm chart inflation and remote resources are not supported.
+type Kustomization struct {
+	// Path of the directory of the kustomization within the resource. Defaults to its root directory.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Patches are applied to the rendered objects, as patches of a kustomization file.
+	// +optional
+	Patches []KustomizePatch `json:"patches,omitempty"`
+}
+
+// KustomizePatch is a strategic merge patch or a JSON 6902 patch applied to the rendered objects.
+type KustomizePatch struct {
+	// Patch is the patch, as YAML or JSON. A JSON 6902 patch requires a Target.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+
+	// Target selects the objects the patch is applied to. If empty, the patch is applied to the object
+	// with the group, version, kind and name of the strategic merge patch.
+	// +optional
+	Target *KustomizeSelector `json:"target,omitempty"`
+}
+
+// KustomizeSelector selects the objects a patch is applied to. All fields are regular expressions,
+// except for the label and annotation selectors.
+type KustomizeSelector struct {
+	// +optional
+	Group string `json:"group,omitempty"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// LabelSelector selects objects by their labels, as label selector of the Kubernetes API.
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// AnnotationSelector selects objects by their annotations, like a label selector.
+	// +optional
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
+}
+
+// HelmDeployment configures the deployment of resources of type helmChart. Instead of applying the content
+// of such a resource, the Deployer applies a Flux OCIRepository referencing the chart and a Flux HelmRelease
+// installing it, which requires the source and helm controllers of Flux in the cluster. The chart must be
+// stored in an OCI registry, i.e. accessed by an OCI image access or as local blob of an OCI repository.
+type HelmDeployment struct {
+	// ReleaseName is the name of the Helm release. Defaults to the name of the Deployer.
+	// +optional
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// Interval at which Flux reconciles the chart and the release. Defaults to 10m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Values of the Helm release. Use Localizations with the path /spec/values/... of the HelmRelease to
+	// substitute values from the component descriptor, e.g. image references.
+	// +optional
+	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+
+	// SecretRef references a Secret of type kubernetes.io/dockerconfigjson in the namespace of the Deployer,
+	// which Flux uses to pull the chart.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// Localization substitutes the result of a CEL expression into a field of the deployed objects.
+//
+// The expression is evaluated against the following variables:
+//   - component: the component of the component descriptor, as in the v2 descriptor format.
+//   - resources: the resources of the component by name. Resources with an extra identity share their name,
+//     select them from component.resources instead.
+//   - resource: the resource of the component the Deployer deploys.
+//
+// The toOCI() function splits the access of a resource into registry, repository, tag and digest, e.g.
+// resources["image"].access.toOCI().repository, also for local blobs of OCI repositories.
+type Localization struct {
+	// Target selects the deployed objects the value is substituted in. It must match at least one object.
+	// +required
+	Target LocalizationTarget `json:"target"`
+
+	// Path is the JSON pointer (RFC 6901) of the field the value is substituted in, e.g.
+	// /spec/template/spec/containers/0/image or /data/image for a ConfigMap. Missing objects along the
+	// path are created, list elements must exist. Values of Secrets are substituted base64 encoded into
+	// /data, or as plain string into /stringData.
+	// +required
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+
+	// Value is the CEL expression whose result is substituted.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Value string `json:"value"`
+}
+
+// LocalizationTarget selects deployed objects by kind and name.
+type LocalizationTarget struct {
+	// APIVersion of the objects. If empty, objects of all API versions are selected.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Kind of the objects.
+	// +required
+	Kind string `json:"kind"`
+	// Name of the objects.
+	// +required
+	Name string `json:"name"`
+	// Namespace of the objects. If empty, objects of all namespaces are selected.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // DeployerStatus defines the observed state of Deployer.
@@ -46,6 +298,30 @@ type DeployerStatus struct {
 	// Deployed contains references to the objects that have been deployed by the Deployer through
 	// the Resource.
 	Deployed []DeployedObjectReference `json:"deployed,omitempty"`
+
+	// Recreated contains references to the objects that were deleted and recreated because a change
+	// of an immutable field could not be applied, as of the last apply that recreated objects.
+	// +optional
+	Recreated []RecreatedObjectReference `json:"recreated,omitempty"`
+
+	// CRDUpgradeFindings are the unsafe changes of CustomResourceDefinitions found by the last check before
+	// the objects were applied. See CRDUpgradePolicy.
+	// +optional
+	CRDUpgradeFindings []CRDUpgradeFinding `json:"crdUpgradeFindings,omitempty"`
+
+	// LastAppliedDigest is the digest of the objects of the last successful apply, including the ownership
+	// metadata and defaults added by the Deployer.
+	// +optional
+	LastAppliedDigest string `json:"lastAppliedDigest,omitempty"`
+}
+
+// RecreatedObjectReference is a reference to an object that has been recreated by the Deployer.
+type RecreatedObjectReference struct {
+	DeployedObjectReference `json:",inline"`
+	// RecreatedAt is the time the object was recreated.
+	RecreatedAt metav1.Time `json:"recreatedAt"`
+	// Reason is the error that prevented the change from being applied to the previous object.
+	Reason string `json:"reason"`
 }
 
 // DeployedObjectReference is a reference to an object that has been deployed by the Deployer.
@@ -0,0 +1,203 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const KindProductDeployment = "ProductDeployment"
+
+// Labels set on all objects managed by a ProductDeployment.
+// Deployers are cluster-scoped and cannot be owned by a namespaced ProductDeployment,
+// so the labels are used to find and clean up the managed objects.
+const (
+	// ProductDeploymentNameLabel contains the name of the managing ProductDeployment.
+	ProductDeploymentNameLabel = "delivery.ocm.software/product-deployment-name"
+	// ProductDeploymentNamespaceLabel contains the namespace of the managing ProductDeployment.
+	ProductDeploymentNamespaceLabel = "delivery.ocm.software/product-deployment-namespace"
+	// ProductDeploymentItemLabel contains the name of the deployment item an object was created for.
+	ProductDeploymentItemLabel = "delivery.ocm.software/product-deployment-item"
+)
+
+// ProductDeploymentSpec defines the desired state of ProductDeployment.
+type ProductDeploymentSpec struct {
+	// RepositoryRef is a reference to the Repository containing the root component.
+	// +required
+	RepositoryRef corev1.LocalObjectReference `json:"repositoryRef"`
+
+	// Component is the name of the root ocm component of the product.
+	// +required
+	Component string `json:"component"`
+
+	// Semver defines the constraint of the root component version. '>=v0.1'.
+	// +required
+	Semver string `json:"semver"`
+
+	// SemverFilter is a regex pattern to filter the versions within the Semver
+	// range.
+	// +optional
+	SemverFilter string `json:"semverFilter,omitempty"`
+
+	// DowngradePolicy specifies whether the root component may be downgraded.
+	// +kubebuilder:validation:Enum:=Allow;Deny
+	// +kubebuilder:default:=Deny
+	// +optional
+	DowngradePolicy DowngradePolicy `json:"downgradePolicy,omitempty"`
+
+	// Verify contains the signatures of the root component to be verified.
+	// +optional
+	Verify []Verification `json:"verify,omitempty"`
+
+	// Deployments maps the resources of the root component and its referenced
+	// components to the place they are deployed to.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	// +required
+	Deployments []ProductDeploymentItem `json:"deployments"`
+
+	// OCMConfig defines references to secrets, config maps or ocm api
+	// objects providing configuration data including credentials.
+	// It is propagated to all managed objects. The referenced objects must be
+	// in the namespace of the ProductDeployment.
+	// +optional
+	OCMConfig []OCMConfiguration `json:"ocmConfig,omitempty"`
+
+	// Interval at which the repository will be checked for new versions of
+	// the root component. It is passed on to the managed Component.
+	// +required
+	Interval metav1.Duration `json:"interval"`
+
+	// Suspend tells the controller to suspend the reconciliation of this
+	// ProductDeployment. The managed objects are not suspended.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// ProductDeploymentItem describes the deployment of a single resource of the product.
+type ProductDeploymentItem struct {
+	// Name identifies the deployment within the ProductDeployment.
+	// It is used to derive the names of the managed Resource and Deployer.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	Name string `json:"name"`
+
+	// Resource identifies the resource containing the ResourceGroupDefinition to deploy.
+	// Resources of referenced components are selected with the reference path,
+	// relative to the root component.
+	// +required
+	Resource ResourceID `json:"resource"`
+
+	// TargetNamespace is the namespace the objects of the resource are deployed to.
+	// It defaults to the namespace of the ProductDeployment, other namespaces are rejected.
+	// Objects of the resource that are cluster-scoped or specify another namespace are not deployed.
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+}
+
+// ProductDeploymentStatus defines the observed state of ProductDeployment.
+type ProductDeploymentStatus struct {
+	// ObservedGeneration is the last observed generation of the ProductDeployment
+	// object.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions holds the conditions for the ProductDeployment.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Component specifies the concrete version of the root component that is
+	// currently deployed.
+	// +optional
+	Component *ComponentInfo `json:"component,omitempty"`
+
+	// Deployments holds the status of every deployment of the product.
+	// +optional
+	Deployments []ProductDeploymentItemStatus `json:"deployments,omitempty"`
+}
+
+// ProductDeploymentItemStatus describes the observed state of a single deployment.
+type ProductDeploymentItemStatus struct {
+	// Name of the deployment item.
+	// +required
+	Name string `json:"name"`
+
+	// ResourceRef references the Resource managed for the deployment.
+	// +optional
+	ResourceRef *ObjectKey `json:"resourceRef,omitempty"`
+
+	// DeployerRef references the Deployer managed for the deployment.
+	// +optional
+	DeployerRef *ObjectKey `json:"deployerRef,omitempty"`
+
+	// Ready indicates whether the Resource and Deployer of the deployment are ready.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// Message describes why the deployment is not ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+func (in *ProductDeployment) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *ProductDeployment) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *ProductDeployment) GetVID() map[string]string {
+	vid := fmt.Sprintf("%s:%s", in.GetNamespace(), in.GetName())
+	metadata := make(map[string]string)
+	metadata[GroupVersion.Group+"/product_deployment"] = vid
+
+	return metadata
+}
+
+func (in *ProductDeployment) SetObservedGeneration(v int64) {
+	in.Status.ObservedGeneration = v
+}
+
+func (in *ProductDeployment) GetObjectMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+func (in *ProductDeployment) GetKind() string {
+	return KindProductDeployment
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Component",type=string,JSONPath=`.spec.component`,description="The root component of the product"
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.component.version`,description="The deployed version of the root component"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].message`,description="Indicates if the ProductDeployment is Ready",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Displays the Age of the ProductDeployment"
+
+// ProductDeployment is the Schema for the productdeployments API.
+// It deploys a whole product described by a root component version: it creates and
+// manages a Component for the root component and a Resource and Deployer for every
+// configured deployment, and aggregates their status.
+type ProductDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProductDeploymentSpec   `json:"spec"`
+	Status ProductDeploymentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProductDeploymentList contains a list of ProductDeployment.
+type ProductDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProductDeployment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProductDeployment{}, &ProductDeploymentList{})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,6 +29,74 @@ type ReplicationSpec struct {
 	// Replication.
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
+
+	// Transfer tunes how transfers of this Replication are executed.
+	// +optional
+	Transfer *ReplicationTransferSettings `json:"transfer,omitempty"`
+
+	// Resources selects the resources whose content is replicated into the target repository.
+	// Resources that are not selected keep their original access and are transferred by reference.
+	// Local blob resources are always replicated, since their content is stored with the component version.
+	// If set, the content of all selected resources is copied, regardless of the copy mode of the
+	// transfer configuration. Changes apply to the next transferred component version.
+	// +optional
+	Resources *ResourceFilter `json:"resources,omitempty"`
+}
+
+// ResourceFilter selects the resources matching any of the Include selectors, except
+// those matching any of the Exclude selectors. Without Include selectors, all resources
+// not excluded are selected.
+type ResourceFilter struct {
+	// Include are the selectors of the resources to select.
+	// +optional
+	Include []ResourceSelector `json:"include,omitempty"`
+
+	// Exclude are the selectors of the resources not to select, even if they are included.
+	// +optional
+	Exclude []ResourceSelector `json:"exclude,omitempty"`
+}
+
+// ResourceSelector selects resources by their attributes. All configured criteria must be
+// satisfied, while the entries of a list criterion are alternatives.
+type ResourceSelector struct {
+	// Types are the resource types to select, e.g. ociImage or helmChart.
+	// +optional
+	Types []string `json:"types,omitempty"`
+
+	// MediaTypes are the media types of the resource content to select, taken from the
+	// access of the resource. They are compared case-insensitively and without parameters.
+	// +optional
+	MediaTypes []string `json:"mediaTypes,omitempty"`
+
+	// ExtraIdentity is a map of extra identity attributes the resource must have.
+	// +optional
+	ExtraIdentity map[string]string `json:"extraIdentity,omitempty"`
+
+	// MatchLabels is a map of {name,value} pairs of labels the resource must have.
+	// Label values that are not strings are compared by their compact JSON representation.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// ReplicationTransferSettings tunes the execution of a transfer.
+type ReplicationTransferSettings struct {
+	// Parallelism is the maximum number of transformations, such as copies of component
+	// versions or resources, that are executed in parallel. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Parallelism int `json:"parallelism,omitempty"`
+
+	// BandwidthLimit limits the bytes per second a transfer sends and receives, e.g. "10Mi".
+	// Parallel transformations of a transfer share the limit. If not set, the bandwidth is not limited.
+	// +optional
+	BandwidthLimit *resource.Quantity `json:"bandwidthLimit,omitempty"`
+
+	// BatchSize is the number of transferred component versions after which the progress
+	// is reported in the status and as an event. Larger batches reduce the load on the
+	// API server for transfers of many component versions. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
 }
 
 // ReplicationStatus defines the observed state of Replication.
@@ -63,6 +132,29 @@ type ReplicationStatus struct {
 	// in the order the configuration data was applied.
 	// +optional
 	EffectiveOCMConfig []OCMConfiguration `json:"effectiveOCMConfig,omitempty"`
+
+	// Progress reports the progress of the current or last transfer.
+	// +optional
+	Progress *TransferProgress `json:"progress,omitempty"`
+}
+
+// TransferProgress reports the progress of a transfer.
+type TransferProgress struct {
+	// ComponentVersions is the number of component versions of the transfer.
+	// +required
+	ComponentVersions int `json:"componentVersions"`
+
+	// TransferredComponentVersions is the number of component versions transferred so far.
+	// +required
+	TransferredComponentVersions int `json:"transferredComponentVersions"`
+
+	// TransferredBytes is the number of bytes sent and received by the transfer so far.
+	// +optional
+	TransferredBytes int64 `json:"transferredBytes,omitempty"`
+
+	// CurrentItem is the transformation that was started last, in the form "ID [Type]".
+	// +optional
+	CurrentItem string `json:"currentItem,omitempty"`
 }
 
 // TransferEvent captures a single failed transformation observed during a
@@ -24,6 +24,12 @@ type RepositorySpec struct {
 	// +optional
 	OCMConfig []OCMConfiguration `json:"ocmConfig,omitempty"`
 
+	// Plugin pins the plugin that serves the type of the RepositorySpec if
+	// multiple installed plugins serve it. If not set, the type is served by the
+	// plugin the controller uses by default.
+	// +optional
+	Plugin *PluginSelection `json:"plugin,omitempty"`
+
 	// Interval at which the ocm repository specified by the RepositorySpec
 	// validated.
 	// +required
@@ -20,6 +20,18 @@ const (
 	VerificationPolicyNever VerificationPolicy = "Never"
 )
 
+type DigestPinningPolicy string
+
+const (
+	// DigestPinningPolicyNone uses OCI artifact accesses as they are.
+	DigestPinningPolicyNone DigestPinningPolicy = "None"
+	// DigestPinningPolicyEnforce refuses resources whose OCI artifact access references a tag without a digest.
+	DigestPinningPolicyEnforce DigestPinningPolicy = "Enforce"
+	// DigestPinningPolicyResolve pins OCI artifact accesses that reference a tag without a digest
+	// to the digest the tag resolves to in the registry, which has to match the digest of the resource.
+	DigestPinningPolicyResolve DigestPinningPolicy = "Resolve"
+)
+
 // ResourceSpec defines the desired state of Resource.
 type ResourceSpec struct {
 	// ComponentRef is a reference to a Component.
@@ -43,6 +55,17 @@ type ResourceSpec struct {
 	// +optional
 	VerificationPolicy VerificationPolicy `json:"verificationPolicy,omitempty"`
 
+	// DigestPinningPolicy controls how an OCI artifact access that references a mutable tag without a digest is treated.
+	// None (default): use the access as it is.
+	// Enforce: refuse the resource.
+	// Resolve: resolve the tag against the registry, pin the access to the resolved digest and record the original
+	// image reference in the resource label "ocm.software/oci/pinned-digest". The resource is refused if the tag
+	// does not reference the artifact described by the resource digest.
+	// +kubebuilder:validation:Enum:="None";"Enforce";"Resolve"
+	// +kubebuilder:default:="None"
+	// +optional
+	DigestPinningPolicy DigestPinningPolicy `json:"digestPinningPolicy,omitempty"`
+
 	// Suspend tells the controller to suspend the reconciliation of this
 	// Resource.
 	// +optional
@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
@@ -12,6 +13,21 @@ import (
 	"ocm.software/open-component-model/bindings/go/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDUpgradeFinding) DeepCopyInto(out *CRDUpgradeFinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDUpgradeFinding.
+func (in *CRDUpgradeFinding) DeepCopy() *CRDUpgradeFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDUpgradeFinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Component) DeepCopyInto(out *Component) {
 	*out = *in
@@ -52,6 +68,11 @@ func (in *ComponentInfo) DeepCopyInto(out *ComponentInfo) {
 		*out = new(v2.Digest)
 		**out = **in
 	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginSelection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentInfo.
@@ -103,13 +124,20 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 	if in.Verify != nil {
 		in, out := &in.Verify, &out.Verify
 		*out = make([]Verification, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.OCMConfig != nil {
 		in, out := &in.OCMConfig, &out.OCMConfig
 		*out = make([]OCMConfiguration, len(*in))
 		copy(*out, *in)
 	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginSelection)
+		**out = **in
+	}
 	out.Interval = in.Interval
 }
 
@@ -234,6 +262,26 @@ func (in *DeployerSpec) DeepCopyInto(out *DeployerSpec) {
 		*out = make([]OCMConfiguration, len(*in))
 		copy(*out, *in)
 	}
+	if in.Localizations != nil {
+		in, out := &in.Localizations, &out.Localizations
+		*out = make([]Localization, len(*in))
+		copy(*out, *in)
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kustomize != nil {
+		in, out := &in.Kustomize, &out.Kustomize
+		*out = new(Kustomization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployerSpec.
@@ -266,6 +314,18 @@ func (in *DeployerStatus) DeepCopyInto(out *DeployerStatus) {
 		*out = make([]DeployedObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Recreated != nil {
+		in, out := &in.Recreated, &out.Recreated
+		*out = make([]RecreatedObjectReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CRDUpgradeFindings != nil {
+		in, out := &in.CRDUpgradeFindings, &out.CRDUpgradeFindings
+		*out = make([]CRDUpgradeFinding, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployerStatus.
@@ -278,6 +338,109 @@ func (in *DeployerStatus) DeepCopy() *DeployerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetection) DeepCopyInto(out *DriftDetection) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetection.
+func (in *DriftDetection) DeepCopy() *DriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmDeployment) DeepCopyInto(out *HelmDeployment) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmDeployment.
+func (in *HelmDeployment) DeepCopy() *HelmDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kustomization) DeepCopyInto(out *Kustomization) {
+	*out = *in
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]KustomizePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kustomization.
+func (in *Kustomization) DeepCopy() *Kustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(Kustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizePatch) DeepCopyInto(out *KustomizePatch) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(KustomizeSelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizePatch.
+func (in *KustomizePatch) DeepCopy() *KustomizePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizeSelector) DeepCopyInto(out *KustomizeSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizeSelector.
+func (in *KustomizeSelector) DeepCopy() *KustomizeSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizeSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Label) DeepCopyInto(out *Label) {
 	*out = *in
@@ -299,6 +462,37 @@ func (in *Label) DeepCopy() *Label {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Localization) DeepCopyInto(out *Localization) {
+	*out = *in
+	out.Target = in.Target
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Localization.
+func (in *Localization) DeepCopy() *Localization {
+	if in == nil {
+		return nil
+	}
+	out := new(Localization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalizationTarget) DeepCopyInto(out *LocalizationTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalizationTarget.
+func (in *LocalizationTarget) DeepCopy() *LocalizationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalizationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MergeAlgorithmSpecification) DeepCopyInto(out *MergeAlgorithmSpecification) {
 	*out = *in
@@ -361,6 +555,208 @@ func (in *ObjectKey) DeepCopy() *ObjectKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSelection) DeepCopyInto(out *PluginSelection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSelection.
+func (in *PluginSelection) DeepCopy() *PluginSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductDeployment) DeepCopyInto(out *ProductDeployment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeployment.
+func (in *ProductDeployment) DeepCopy() *ProductDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProductDeployment) DeepCopyObject() pkgruntime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductDeploymentItem) DeepCopyInto(out *ProductDeploymentItem) {
+	*out = *in
+	in.Resource.DeepCopyInto(&out.Resource)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeploymentItem.
+func (in *ProductDeploymentItem) DeepCopy() *ProductDeploymentItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductDeploymentItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductDeploymentItemStatus) DeepCopyInto(out *ProductDeploymentItemStatus) {
+	*out = *in
+	if in.ResourceRef != nil {
+		in, out := &in.ResourceRef, &out.ResourceRef
+		*out = new(ObjectKey)
+		**out = **in
+	}
+	if in.DeployerRef != nil {
+		in, out := &in.DeployerRef, &out.DeployerRef
+		*out = new(ObjectKey)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeploymentItemStatus.
+func (in *ProductDeploymentItemStatus) DeepCopy() *ProductDeploymentItemStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductDeploymentItemStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductDeploymentList) DeepCopyInto(out *ProductDeploymentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProductDeployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeploymentList.
+func (in *ProductDeploymentList) DeepCopy() *ProductDeploymentList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductDeploymentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProductDeploymentList) DeepCopyObject() pkgruntime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductDeploymentSpec) DeepCopyInto(out *ProductDeploymentSpec) {
+	*out = *in
+	out.RepositoryRef = in.RepositoryRef
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = make([]Verification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make([]ProductDeploymentItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OCMConfig != nil {
+		in, out := &in.OCMConfig, &out.OCMConfig
+		*out = make([]OCMConfiguration, len(*in))
+		copy(*out, *in)
+	}
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeploymentSpec.
+func (in *ProductDeploymentSpec) DeepCopy() *ProductDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductDeploymentStatus) DeepCopyInto(out *ProductDeploymentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Component != nil {
+		in, out := &in.Component, &out.Component
+		*out = new(ComponentInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make([]ProductDeploymentItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeploymentStatus.
+func (in *ProductDeploymentStatus) DeepCopy() *ProductDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecreatedObjectReference) DeepCopyInto(out *RecreatedObjectReference) {
+	*out = *in
+	out.DeployedObjectReference = in.DeployedObjectReference
+	in.RecreatedAt.DeepCopyInto(&out.RecreatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecreatedObjectReference.
+func (in *RecreatedObjectReference) DeepCopy() *RecreatedObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RecreatedObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Replication) DeepCopyInto(out *Replication) {
 	*out = *in
@@ -430,6 +826,16 @@ func (in *ReplicationSpec) DeepCopyInto(out *ReplicationSpec) {
 		*out = make([]OCMConfiguration, len(*in))
 		copy(*out, *in)
 	}
+	if in.Transfer != nil {
+		in, out := &in.Transfer, &out.Transfer
+		*out = new(ReplicationTransferSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSpec.
@@ -467,6 +873,11 @@ func (in *ReplicationStatus) DeepCopyInto(out *ReplicationStatus) {
 		*out = make([]OCMConfiguration, len(*in))
 		copy(*out, *in)
 	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(TransferProgress)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationStatus.
@@ -479,6 +890,26 @@ func (in *ReplicationStatus) DeepCopy() *ReplicationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationTransferSettings) DeepCopyInto(out *ReplicationTransferSettings) {
+	*out = *in
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationTransferSettings.
+func (in *ReplicationTransferSettings) DeepCopy() *ReplicationTransferSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationTransferSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Repository) DeepCopyInto(out *Repository) {
 	*out = *in
@@ -551,6 +982,11 @@ func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 		*out = make([]OCMConfiguration, len(*in))
 		copy(*out, *in)
 	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginSelection)
+		**out = **in
+	}
 	out.Interval = in.Interval
 }
 
@@ -618,6 +1054,35 @@ func (in *Resource) DeepCopyObject() pkgruntime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFilter) DeepCopyInto(out *ResourceFilter) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]ResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]ResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFilter.
+func (in *ResourceFilter) DeepCopy() *ResourceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceID) DeepCopyInto(out *ResourceID) {
 	*out = *in
@@ -736,6 +1201,45 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MediaTypes != nil {
+		in, out := &in.MediaTypes, &out.MediaTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraIdentity != nil {
+		in, out := &in.ExtraIdentity, &out.ExtraIdentity
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
+func (in *ResourceSelector) DeepCopy() *ResourceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSpec) DeepCopyInto(out *ResourceSpec) {
 	*out = *in
@@ -805,6 +1309,21 @@ func (in *ResourceStatus) DeepCopy() *ResourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SigstoreVerification) DeepCopyInto(out *SigstoreVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SigstoreVerification.
+func (in *SigstoreVerification) DeepCopy() *SigstoreVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(SigstoreVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransferEvent) DeepCopyInto(out *TransferEvent) {
 	*out = *in
@@ -820,10 +1339,30 @@ func (in *TransferEvent) DeepCopy() *TransferEvent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferProgress) DeepCopyInto(out *TransferProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferProgress.
+func (in *TransferProgress) DeepCopy() *TransferProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(TransferProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Verification) DeepCopyInto(out *Verification) {
 	*out = *in
 	out.SecretRef = in.SecretRef
+	if in.Sigstore != nil {
+		in, out := &in.Sigstore, &out.Sigstore
+		*out = new(SigstoreVerification)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Verification.
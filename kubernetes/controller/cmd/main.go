@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -26,12 +29,17 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"ocm.software/open-component-model/bindings/go/blob/tempfile"
 	filesystemv1alpha1 "ocm.software/open-component-model/bindings/go/configuration/filesystem/v1alpha1/spec"
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/configuration/sops"
+	"ocm.software/open-component-model/bindings/go/credentials/vault"
 	helmdigest "ocm.software/open-component-model/bindings/go/helm/digest"
 	helmcredspec "ocm.software/open-component-model/bindings/go/helm/spec/credentials"
 	ocicredentials "ocm.software/open-component-model/bindings/go/oci/credentials"
 	"ocm.software/open-component-model/bindings/go/oci/repository/provider"
 	ocires "ocm.software/open-component-model/bindings/go/oci/repository/resource"
+	registriesv1alpha1 "ocm.software/open-component-model/bindings/go/oci/resolver/url/spec/v1alpha1"
 	ocicredspec "ocm.software/open-component-model/bindings/go/oci/spec/credentials"
 	v1 "ocm.software/open-component-model/bindings/go/oci/spec/identity/v1"
 	ocirepository "ocm.software/open-component-model/bindings/go/oci/spec/repository"
@@ -41,17 +49,25 @@ import (
 	signingv1alpha1 "ocm.software/open-component-model/bindings/go/rsa/signing/v1alpha1"
 	rsacredspec "ocm.software/open-component-model/bindings/go/rsa/spec/credentials"
 	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime/debug"
+	sigstorehandler "ocm.software/open-component-model/bindings/go/sigstore/signing/handler"
+	trustedroot "ocm.software/open-component-model/bindings/go/sigstore/spec/credentials/trustedroot"
 	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	ocmcel "ocm.software/open-component-model/kubernetes/controller/internal/cel"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/component"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/cache"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/dynamic"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/productdeployment"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/replication"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/repository"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/resource"
 	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/preflight"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
+	"ocm.software/open-component-model/kubernetes/controller/internal/setup"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
 )
 
 const (
@@ -61,6 +77,12 @@ const (
 	// etcd's default --max-request-bytes is 1.5 MiB (https://etcd.io/docs/v3.5/dev-guide/limit/),
 	// so a multi-document manifest written to the cluster will always stay well under 2 MiB.
 	defaultMaxResourceSize = "2Mi"
+
+	// defaultDownloadCacheMemoryLimit bounds the memory used by decoded deployer objects.
+	defaultDownloadCacheMemoryLimit = "256Mi"
+
+	// recentJournalEntries is the number of journal entries of every Replication served by the debug endpoint.
+	recentJournalEntries = 100
 )
 
 var (
@@ -88,12 +110,27 @@ func main() {
 		enableHTTP2               bool
 		deployerDownloadCacheSize int
 		deployerMaxResourceSize   string
+		deployerCacheMemoryLimit  string
+		artifactCacheDir          string
+		artifactCacheSizeLimit    string
+		resumableDownloadDir      string
 		resourceConcurrency       int
 		replicationConcurrency    int
 		resolverWorkerCount       int
 		resolverWorkerQueueLength int
 		resolverSubscriberBuffer  int
+		resolverNamespaceQuota    workerpool.NamespaceQuota
 		resolverCacheTTL          int
+		resolverInFlightFile      string
+		resolverRecoveryWindow    time.Duration
+		tracingOpts               tracing.Options
+		configDecryptionKeyFile   string
+		configDecryptionGCPFile   string
+		registryConfigFile        string
+		debugAddr                 string
+		requiredRepositoryTypes   string
+		preflightRetryInterval    time.Duration
+		celLimits                 ocmcel.Limits
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metric endpoint binds to. "+
@@ -110,6 +147,18 @@ func main() {
 		"The maximum size of the deployer download object LRU cache.")
 	flag.StringVar(&deployerMaxResourceSize, "deployer-download-max-resource-size", defaultMaxResourceSize,
 		"Maximum size of a single downloadable resource as a Kubernetes resource.Quantity (e.g. \"2Mi\", \"512Ki\"). \"0\" disables the limit.")
+	flag.StringVar(&deployerCacheMemoryLimit, "deployer-download-cache-memory-limit", defaultDownloadCacheMemoryLimit,
+		"Maximum accumulated size of the decoded objects in the deployer download cache as a Kubernetes resource.Quantity. "+
+			"Least recently used objects are evicted beyond it. \"0\" disables the limit.")
+	flag.StringVar(&artifactCacheDir, "artifact-cache-dir", "",
+		"The directory downloaded resource blobs are cached in, ideally a dedicated volume. "+
+			"The cache owns the directory and removes its content on start. If not set, blobs are cached in memory.")
+	flag.StringVar(&artifactCacheSizeLimit, "artifact-cache-size-limit", "0",
+		"Maximum accumulated size of the cached resource blobs as a Kubernetes resource.Quantity (e.g. \"1Gi\"). "+
+			"Least recently used blobs are evicted beyond it. \"0\" disables the artifact cache.")
+	flag.StringVar(&resumableDownloadDir, "resumable-download-dir", "",
+		"The directory downloads of OCI artifacts keep their partial state in, so that interrupted downloads "+
+			"are resumed instead of restarted. State that is not modified for a day is removed. If not set, downloads are not resumable.")
 	flag.IntVar(&resourceConcurrency, "resource-controller-concurrency", 4, //nolint:mnd // no magic number
 		"The resource controller concurrency. This is the number of active resource controller workers that can be kept alive.")
 	flag.IntVar(&replicationConcurrency, "replication-controller-concurrency", 4, //nolint:mnd // no magic number
@@ -121,8 +170,60 @@ func main() {
 	flag.IntVar(&resolverSubscriberBuffer, "resolver-subscriber-buffer-size", 100, //nolint:mnd // no magic number
 		"The buffer size for each subscriber's event channel. A larger buffer reduces the probability of dropped resolution events under load. "+
 			"Tune upward if the resolver_event_channel_drops_total metric is non-zero.")
+	flag.IntVar(&resolverNamespaceQuota.MaxConcurrent, "resolver-namespace-max-concurrent", 0,
+		"The maximum number of component versions resolved at the same time for a single namespace. "+
+			"Further resolutions of the namespace wait without occupying a resolver worker. 0 disables the limit.")
+	flag.IntVar(&resolverNamespaceQuota.MaxQueued, "resolver-namespace-max-queued", 0,
+		"The maximum number of component versions waiting to be resolved for a single namespace. "+
+			"Further resolutions of the namespace are rejected and retried with backoff. 0 disables the limit.")
 	flag.IntVar(&resolverCacheTTL, "resolver-cache-ttl", 30, //nolint:mnd // no magic number
 		"The time-to-live (TTL) for the resolver cache entries in minutes. Setting TTL to less than 30 minutes is discouraged in productive use as it can lead to unintended performance issues.")
+	flag.StringVar(&resolverInFlightFile, "resolver-inflight-file", "",
+		"The file the resolutions in progress are persisted to, so that their requesters are re-triggered after a restart "+
+			"of the controller instead of waiting for their next reconciliation. The file must be on a volume outliving the container. "+
+			"Disabled if empty.")
+	flag.DurationVar(&resolverRecoveryWindow, "resolver-recovery-window", workerpool.DefaultRecoveryWindow,
+		"The maximum age of persisted resolutions whose requesters are re-triggered after a restart. Older resolutions are discarded.")
+
+	flag.StringVar(&tracingOpts.Endpoint, "otlp-endpoint", "",
+		"The host:port of the OTLP/gRPC collector that traces of reconciles, resolutions and plugin calls are exported to. "+
+			"Tracing is disabled if not set.")
+	flag.BoolVar(&tracingOpts.Insecure, "otlp-insecure", false,
+		"If set, the connection to the OTLP collector does not use TLS.")
+	flag.Float64Var(&tracingOpts.SampleRatio, "trace-sample-ratio", 1,
+		"The fraction of reconciles that are traced, between 0 and 1.")
+	flag.StringVar(&tracingOpts.ServiceName, "trace-service-name", tracing.DefaultServiceName,
+		"The service name reported with all exported spans.")
+
+	flag.StringVar(&configDecryptionKeyFile, "config-decryption-key-file", "",
+		"The path of an age identity file used to decrypt OCM configurations encrypted with sops. "+
+			"Identities from the SOPS_AGE_KEY and SOPS_AGE_KEY_FILE environment variables are used as well. "+
+			"Data keys encrypted with AWS KMS or GCP KMS are decrypted with the credentials of the environment, "+
+			"e.g. the role of the service account.")
+	flag.StringVar(&configDecryptionGCPFile, "config-decryption-gcp-credentials-file", "",
+		"The path of a GCP service account credentials file used to decrypt OCM configurations encrypted with sops "+
+			"and GCP KMS. If not set, the application default credentials are used.")
+
+	flag.StringVar(&registryConfigFile, "registry-config-file", "",
+		"The path of an OCM configuration file with a registries.config.ocm.software configuration. "+
+			"It configures the registry hosts accessed over plain HTTP or without TLS verification, "+
+			"which have to be allowed explicitly with allowInsecureRegistries.")
+
+	flag.StringVar(&debugAddr, "debug-bind-address", "",
+		"The address the read-only debug endpoint binds to. It serves the registered repository types, "+
+			"plugin diagnostics, cache statistics and the recent transfer journal entries of Replications as JSON. Leave empty to disable the debug endpoint.")
+
+	flag.StringVar(&requiredRepositoryTypes, "preflight-required-repository-types", "OCIRepository/v1",
+		"Comma-separated types of repository specifications that must be served by a plugin. "+
+			"The controller does not become ready until they are.")
+	flag.DurationVar(&preflightRetryInterval, "preflight-retry-interval", preflight.DefaultRetryInterval,
+		"The interval at which failed preflight checks are retried. The controller does not become ready until all passed.")
+
+	flag.Uint64Var(&celLimits.CostLimit, "cel-cost-limit", ocmcel.DefaultCostLimit,
+		"The maximum cost of a single evaluation of a user provided CEL expression, e.g. of additional status fields "+
+			"or localizations. Evaluations exceeding it fail and are counted in the cel_evaluation_failures metric.")
+	flag.DurationVar(&celLimits.Timeout, "cel-evaluation-timeout", ocmcel.DefaultTimeout,
+		"The maximum duration of a single evaluation of a user provided CEL expression.")
 
 	opts := zap.Options{
 		Development: true,
@@ -130,16 +231,9 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	maxResourceQuantity, err := apiresource.ParseQuantity(deployerMaxResourceSize)
-	if err != nil {
-		setupLog.Error(err, "invalid flag value", "flag", "deployer-download-max-resource-size", "value", deployerMaxResourceSize)
-		os.Exit(1)
-	}
-	maxResourceSizeBytes := maxResourceQuantity.Value()
-	if maxResourceSizeBytes < 0 {
-		setupLog.Error(nil, "invalid flag value", "flag", "deployer-download-max-resource-size", "value", deployerMaxResourceSize, "reason", "must be >= 0")
-		os.Exit(1)
-	}
+	maxResourceSizeBytes := mustParseSizeFlag("deployer-download-max-resource-size", deployerMaxResourceSize)
+	cacheMemoryLimitBytes := mustParseSizeFlag("deployer-download-cache-memory-limit", deployerCacheMemoryLimit)
+	artifactCacheSizeLimitBytes := mustParseSizeFlag("artifact-cache-size-limit", artifactCacheSizeLimit)
 
 	if resolverSubscriberBuffer <= 0 {
 		setupLog.Error(nil, "invalid flag value", "flag", "resolver-subscriber-buffer-size",
@@ -154,16 +248,65 @@ func main() {
 		os.Exit(1)
 	}
 
+	requiredTypes, err := parseRepositoryTypes(requiredRepositoryTypes)
+	if err != nil {
+		setupLog.Error(err, "invalid flag value", "flag", "preflight-required-repository-types", "value", requiredRepositoryTypes)
+		os.Exit(1)
+	}
+
 	if resolverCacheTTL <= 0 {
 		setupLog.Error(nil, "invalid flag value", "flag", "resolver-cache-ttl",
 			"value", resolverCacheTTL, "reason", "must be > 0")
 		os.Exit(1)
 	}
 
+	if resolverRecoveryWindow <= 0 {
+		setupLog.Error(nil, "invalid flag value", "flag", "resolver-recovery-window",
+			"value", resolverRecoveryWindow, "reason", "must be > 0")
+		os.Exit(1)
+	}
+
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 	ctx := context.Background()
 
+	// temporary files left behind by a previous instance of the controller that crashed or was killed.
+	if removed, err := tempfile.Default().SweepOrphans(); err != nil {
+		setupLog.Error(err, "unable to remove orphaned temporary files")
+	} else if len(removed) > 0 {
+		setupLog.Info("removed orphaned temporary files", "count", len(removed))
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	configKeySources, err := sops.KeySourcesFromEnvironment(os.Getenv, os.UserConfigDir)
+	if err != nil {
+		setupLog.Error(err, "unable to load configuration decryption keys")
+		os.Exit(1)
+	}
+	if configDecryptionKeyFile != "" {
+		keySource, err := sops.AgeKeySourceFromFile(configDecryptionKeyFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load configuration decryption keys", "flag", "config-decryption-key-file")
+			os.Exit(1)
+		}
+		configKeySources = append(configKeySources, keySource)
+	}
+	if configDecryptionGCPFile != "" {
+		credentials, err := os.ReadFile(configDecryptionGCPFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load configuration decryption keys", "flag", "config-decryption-gcp-credentials-file")
+			os.Exit(1)
+		}
+		// the configured credentials take precedence over the application default credentials of the environment.
+		configKeySources = append([]sops.KeySource{sops.NewGCPKMSKeySource(credentials)}, configKeySources...)
+	}
+	configDecryptor := sops.NewDecryptor(configKeySources...)
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -214,9 +357,20 @@ func main() {
 	}
 
 	pm := manager.NewPluginManager(ctx)
+	// the metrics of external plugins are served together with the metrics of the controller.
+	metrics.Registry.MustRegister(pm.MetricsCollector())
+
+	registryConfig, err := loadRegistryConfig(registryConfigFile)
+	if err != nil {
+		setupLog.Error(err, "unable to load registry configuration", "flag", "registry-config-file")
+		os.Exit(1)
+	}
 
 	ocirepository.MustAddLegacyToScheme(ocirepository.Scheme)
-	repositoryProvider := provider.NewComponentVersionRepositoryProvider(provider.WithScheme(ocirepository.Scheme))
+	repositoryProvider := provider.NewComponentVersionRepositoryProvider(
+		provider.WithScheme(ocirepository.Scheme),
+		provider.WithRegistryConfig(registryConfig),
+	)
 	if err := pm.ComponentVersionRepositoryRegistry.RegisterInternalComponentVersionRepositoryPlugin(repositoryProvider); err != nil {
 		setupLog.Error(err, "failed to register internal component version repository plugin")
 		os.Exit(1)
@@ -234,8 +388,17 @@ func main() {
 	}
 	pm.CredentialRepositoryRegistry.Register(rsacredspec.Scheme)
 
+	// the Sigstore signing handler verifies keyless signatures, e.g. cosign signatures of component version manifests.
+	if err := pm.SigningRegistry.RegisterInternalComponentSignatureHandler(sigstorehandler.New()); err != nil {
+		setupLog.Error(err, "failed to register internal sigstore signing plugin")
+		os.Exit(1)
+	}
+	pm.CredentialRepositoryRegistry.Register(trustedroot.Scheme)
+
 	if err := pm.CredentialRepositoryRegistry.RegisterInternalCredentialRepositoryPlugin(
-		&ocicredentials.OCICredentialRepository{},
+		&ocicredentials.OCICredentialRepository{
+			SecretProvider: &setup.SecretProvider{Reader: mgr.GetAPIReader()},
+		},
 		[]ocmruntime.Type{v1.Type},
 	); err != nil {
 		setupLog.Error(err, "failed to register internal credential repository plugin")
@@ -243,7 +406,20 @@ func main() {
 	}
 	pm.CredentialRepositoryRegistry.Register(ocicredspec.Scheme)
 
-	ociResourceRepoPlugin := ocires.NewResourceRepository(&filesystemv1alpha1.Config{}, ocires.WithUserAgent(creator))
+	// the HashiCorp Vault credential repository resolves credentials from the secrets of a KV v2 secrets engine.
+	if err := pm.CredentialRepositoryRegistry.RegisterInternalCredentialRepositoryPlugin(
+		&vault.CredentialRepository{},
+		[]ocmruntime.Type{v1.Type},
+	); err != nil {
+		setupLog.Error(err, "failed to register internal vault credential repository plugin")
+		os.Exit(1)
+	}
+
+	ociResourceRepoPlugin := ocires.NewResourceRepository(&filesystemv1alpha1.Config{},
+		ocires.WithUserAgent(creator),
+		ocires.WithResumableDownloadDir(resumableDownloadDir),
+		ocires.WithRegistryConfig(registryConfig),
+	)
 	if err := pm.ResourcePluginRegistry.RegisterInternalResourcePlugin(ociResourceRepoPlugin); err != nil {
 		setupLog.Error(err, "failed to register internal resource repository plugin")
 		os.Exit(1)
@@ -272,28 +448,62 @@ func main() {
 	resolverCache := expirable.NewLRU[string, *workerpool.Result](unlimited, nil, ttl)
 
 	// Create worker pool with its own dependencies
-	workerPool := workerpool.NewWorkerPool(workerpool.PoolOptions{
+	poolOpts := workerpool.PoolOptions{
 		WorkerCount:          resolverWorkerCount,
 		QueueSize:            resolverWorkerQueueLength,
 		SubscriberBufferSize: resolverSubscriberBuffer,
+		NamespaceQuota:       resolverNamespaceQuota,
 		Logger:               &setupLog,
 		Client:               mgr.GetClient(),
 		Cache:                resolverCache,
-	})
+		RecoveryWindow:       resolverRecoveryWindow,
+	}
+	if resolverInFlightFile != "" {
+		poolOpts.InFlightStore = &workerpool.FileInFlightStore{Path: resolverInFlightFile}
+	}
+	workerPool := workerpool.NewWorkerPool(poolOpts)
 	if err := mgr.Add(workerPool); err != nil {
 		setupLog.Error(err, "unable to add worker pool")
 		os.Exit(1)
 	}
 
+	replicationJournals := &replication.Journals{}
+	if debugAddr != "" {
+		debugServer := debug.NewServer()
+		if err := errors.Join(
+			debugServer.Register("repository-types", debug.SchemeView(ocirepository.Scheme)),
+			debugServer.Register("plugins", func(context.Context) (any, error) {
+				return pm.Diagnostics(), nil
+			}),
+			debugServer.Register("caches", func(context.Context) (any, error) {
+				return map[string]any{
+					"repositoryStores": repositoryProvider.Stats(),
+					"resolutions":      map[string]int{"entries": resolverCache.Len()},
+				}, nil
+			}),
+			debugServer.Register("journal", func(context.Context) (any, error) {
+				return replicationJournals.Recent(recentJournalEntries), nil
+			}),
+		); err != nil {
+			setupLog.Error(err, "unable to register debug views")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&debugServerRunnable{server: debugServer, addr: debugAddr}); err != nil {
+			setupLog.Error(err, "unable to add debug server")
+			os.Exit(1)
+		}
+	}
+
 	// TODO: migrate to mgr.GetEventRecorder() once BaseReconciler uses events.EventRecorder
 	eventsRecorder := mgr.GetEventRecorderFor("ocm-k8s-toolkit") //nolint:staticcheck,nolintlint
 
 	resolver := resolution.NewResolver(&setupLog, workerPool, pm)
 	if err = (&repository.Reconciler{
 		BaseReconciler: &ocm.BaseReconciler{
-			Client:        mgr.GetClient(),
-			Scheme:        mgr.GetScheme(),
-			EventRecorder: eventsRecorder,
+			Client:          mgr.GetClient(),
+			Scheme:          mgr.GetScheme(),
+			EventRecorder:   eventsRecorder,
+			ConfigDecryptor: configDecryptor,
 		},
 		Resolver: resolver,
 	}).SetupWithManager(ctx, mgr); err != nil {
@@ -302,9 +512,10 @@ func main() {
 	}
 	if err = (&component.Reconciler{
 		BaseReconciler: &ocm.BaseReconciler{
-			Client:        mgr.GetClient(),
-			Scheme:        mgr.GetScheme(),
-			EventRecorder: eventsRecorder,
+			Client:          mgr.GetClient(),
+			Scheme:          mgr.GetScheme(),
+			EventRecorder:   eventsRecorder,
+			ConfigDecryptor: configDecryptor,
 		},
 		Resolver:      resolver,
 		PluginManager: pm,
@@ -315,12 +526,14 @@ func main() {
 
 	if err = (&resource.Reconciler{
 		BaseReconciler: &ocm.BaseReconciler{
-			Client:        mgr.GetClient(),
-			Scheme:        mgr.GetScheme(),
-			EventRecorder: eventsRecorder,
+			Client:          mgr.GetClient(),
+			Scheme:          mgr.GetScheme(),
+			EventRecorder:   eventsRecorder,
+			ConfigDecryptor: configDecryptor,
 		},
 		Resolver:      resolver,
 		PluginManager: pm,
+		CELLimits:     celLimits,
 	}).SetupWithManager(ctx, mgr, resourceConcurrency); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Resource")
 		os.Exit(1)
@@ -328,34 +541,59 @@ func main() {
 
 	if err = (&replication.Reconciler{
 		BaseReconciler: &ocm.BaseReconciler{
-			Client:        mgr.GetClient(),
-			Scheme:        mgr.GetScheme(),
-			EventRecorder: eventsRecorder,
+			Client:          mgr.GetClient(),
+			Scheme:          mgr.GetScheme(),
+			EventRecorder:   eventsRecorder,
+			ConfigDecryptor: configDecryptor,
 		},
 		Resolver:         resolver,
 		PluginManager:    pm,
 		RepositoryScheme: ocirepository.Scheme,
+		Journals:         replicationJournals,
 	}).SetupWithManager(ctx, mgr, replicationConcurrency); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Replication")
 		os.Exit(1)
 	}
 
+	var artifactCache *cache.BlobCache
+	if artifactCacheSizeLimitBytes > 0 {
+		if artifactCache, err = cache.NewBlobCache("deployer_artifact_cache", artifactCacheDir, artifactCacheSizeLimitBytes); err != nil {
+			setupLog.Error(err, "unable to create artifact cache")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&deployer.Reconciler{
 		BaseReconciler: &ocm.BaseReconciler{
-			Client:        mgr.GetClient(),
-			Scheme:        mgr.GetScheme(),
-			EventRecorder: eventsRecorder,
+			Client:          mgr.GetClient(),
+			Scheme:          mgr.GetScheme(),
+			EventRecorder:   eventsRecorder,
+			ConfigDecryptor: configDecryptor,
 		},
-		DownloadCache: cache.NewMemoryDigestObjectCache[string, []*unstructured.Unstructured]("deployer_download_cache", deployerDownloadCacheSize, func(k string, v []*unstructured.Unstructured) {
-			setupLog.Info("evicting deployment objects from cache", "key", k, "count", len(v))
-		}),
+		DownloadCache: cache.NewSizedDigestObjectCache[string, []*unstructured.Unstructured]("deployer_download_cache",
+			deployerDownloadCacheSize, cacheMemoryLimitBytes, deployer.ObjectsSize, func(k string, v []*unstructured.Unstructured) {
+				setupLog.Info("evicting deployment objects from cache", "key", k, "count", len(v))
+			}),
+		BlobCache:            artifactCache,
 		Resolver:             resolver,
 		PluginManager:        pm,
 		MaxResourceSizeBytes: maxResourceSizeBytes,
+		CELLimits:            celLimits,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Deployer")
 		os.Exit(1)
 	}
+	if err = (&productdeployment.Reconciler{
+		BaseReconciler: &ocm.BaseReconciler{
+			Client:          mgr.GetClient(),
+			Scheme:          mgr.GetScheme(),
+			EventRecorder:   eventsRecorder,
+			ConfigDecryptor: configDecryptor,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ProductDeployment")
+		os.Exit(1)
+	}
 	if err = (&v1alpha1.Component{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Component")
 		os.Exit(1)
@@ -383,6 +621,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	preflightChecks := preflight.New([]preflight.Check{
+		preflight.Plugins(func() []string {
+			var ids []string
+			for _, plugin := range pm.Diagnostics() {
+				ids = append(ids, plugin.ID)
+			}
+			return ids
+		},
+			pm.ComponentVersionRepositoryRegistry,
+			pm.ComponentListerRegistry,
+			pm.CredentialPluginRegistry,
+			pm.CredentialRepositoryRegistry,
+			pm.InputRegistry,
+			pm.DigestProcessorRegistry,
+			pm.ResourcePluginRegistry,
+			pm.BlobTransformerRegistry,
+			pm.SigningRegistry,
+		),
+		preflight.RepositoryTypes(pm.ComponentVersionRepositoryRegistry, requiredTypes...),
+	}, preflight.WithRetryInterval(preflightRetryInterval), preflight.WithLogger(setupLog.WithName("preflight")))
+	if err := mgr.Add(preflightChecks); err != nil {
+		setupLog.Error(err, "unable to add preflight checks")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("preflight", preflightChecks.ReadyzCheck); err != nil {
+		setupLog.Error(err, "unable to set up preflight ready check")
+		os.Exit(1)
+	}
+
 	go func() {
 		// Block until our controller manager is elected leader. We presume our
 		// entire process will terminate if we lose leadership, so we don't need
@@ -391,8 +658,85 @@ func main() {
 	}()
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	// flush the spans of the last reconciles before exiting.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second) //nolint:mnd // no magic number
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "failed to flush traces")
+	}
+	cancel()
+
+	if err := tempfile.Default().Cleanup(); err != nil {
+		setupLog.Error(err, "failed to remove temporary files")
+	}
+
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// debugServerRunnable serves the debug views on all replicas, independent of leader election.
+type debugServerRunnable struct {
+	server *debug.Server
+	addr   string
+}
+
+func (r *debugServerRunnable) Start(ctx context.Context) error {
+	return r.server.ListenAndServe(ctx, r.addr)
+}
+
+func (r *debugServerRunnable) NeedLeaderElection() bool {
+	return false
+}
+
+// parseRepositoryTypes parses the comma-separated types of repository specifications.
+func parseRepositoryTypes(value string) ([]ocmruntime.Type, error) {
+	var types []ocmruntime.Type
+	for _, typ := range strings.Split(value, ",") {
+		if typ = strings.TrimSpace(typ); typ == "" {
+			continue
+		}
+		t, err := ocmruntime.TypeFromString(typ)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+
+	return types, nil
+}
+
+// loadRegistryConfig loads the registry configuration from the OCM configuration file at path.
+// It returns nil if path is empty.
+func loadRegistryConfig(path string) (*registriesv1alpha1.Config, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+	cfg, err := genericv1.Decode(data, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode configuration file %q: %w", path, err)
+	}
+	return registriesv1alpha1.LookupConfig(cfg)
+}
+
+// mustParseSizeFlag parses the value of a size flag given as Kubernetes resource.Quantity and exits if it is invalid.
+func mustParseSizeFlag(name, value string) int64 {
+	quantity, err := apiresource.ParseQuantity(value)
+	if err != nil {
+		setupLog.Error(err, "invalid flag value", "flag", name, "value", value)
+		os.Exit(1)
+	}
+	size := quantity.Value()
+	if size < 0 {
+		setupLog.Error(nil, "invalid flag value", "flag", name, "value", value, "reason", "must be >= 0")
+		os.Exit(1)
+	}
+
+	return size
+}
@@ -0,0 +1,128 @@
+package cel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultCostLimit is the default limit of the actual cost of a single expression evaluation.
+	// It is generous for expressions accessing and formatting descriptor data, but stops expressions
+	// that e.g. build huge lists or strings in nested comprehensions.
+	DefaultCostLimit uint64 = 1_000_000
+	// DefaultTimeout is the default timeout of a single expression evaluation.
+	DefaultTimeout = time.Second
+
+	// interruptCheckFrequency is the number of comprehension iterations after which
+	// the evaluation checks whether it timed out.
+	interruptCheckFrequency = 100
+)
+
+const (
+	// KindAdditionalStatusField is the kind of the expressions of Resource.spec.additionalStatusFields.
+	KindAdditionalStatusField = "additional_status_field"
+	// KindLocalization is the kind of the expressions of Deployer.spec.localizations.
+	KindLocalization = "localization"
+)
+
+const (
+	// ReasonCompile is the reason of failed evaluations of expressions that cannot be compiled.
+	ReasonCompile = "compile"
+	// ReasonCostLimitExceeded is the reason of failed evaluations that exceeded the cost limit.
+	ReasonCostLimitExceeded = "cost_limit_exceeded"
+	// ReasonTimeout is the reason of failed evaluations that exceeded the timeout.
+	ReasonTimeout = "timeout"
+	// ReasonEvaluation is the reason of evaluations that failed otherwise, e.g. due to missing fields.
+	ReasonEvaluation = "evaluation"
+)
+
+var (
+	// ErrCostLimitExceeded is returned if an evaluation exceeded its cost limit.
+	ErrCostLimitExceeded = errors.New("CEL expression exceeded its cost limit")
+	// ErrTimeout is returned if an evaluation exceeded its timeout.
+	ErrTimeout = errors.New("CEL expression exceeded its evaluation timeout")
+)
+
+// Limits restrict the evaluation of user provided CEL expressions, so that a pathological
+// expression cannot stall a reconciliation. Zero values use the defaults.
+type Limits struct {
+	// CostLimit is the maximum actual cost of a single evaluation. Defaults to DefaultCostLimit.
+	CostLimit uint64
+	// Timeout is the maximum duration of a single evaluation. Defaults to DefaultTimeout.
+	// It is checked between the iterations of comprehensions, so the cost limit remains the
+	// primary bound of expressions nesting many comprehensions.
+	Timeout time.Duration
+}
+
+// WithDefaults returns a copy of the limits in which zero values are replaced by the defaults.
+func (l Limits) WithDefaults() Limits {
+	if l.CostLimit == 0 {
+		l.CostLimit = DefaultCostLimit
+	}
+	if l.Timeout == 0 {
+		l.Timeout = DefaultTimeout
+	}
+	return l
+}
+
+// Evaluate compiles and evaluates the expression against the variables within the limits.
+// The duration and cost of the evaluation are recorded in the metrics of the kind of the expression.
+// Evaluations exceeding the limits fail with ErrCostLimitExceeded or ErrTimeout.
+func Evaluate(ctx context.Context, env *cel.Env, kind, expr string, vars map[string]any, limits Limits) (_ ref.Val, err error) {
+	limits = limits.WithDefaults()
+	start := time.Now()
+	reason := ""
+	defer func() {
+		EvaluationDurationHistogram.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+		if err != nil {
+			EvaluationFailuresTotal.WithLabelValues(kind, reason).Inc()
+		}
+	}()
+
+	ast, issues := env.Compile(expr)
+	if issues.Err() != nil {
+		reason = ReasonCompile
+		return nil, fmt.Errorf("failed to compile CEL expression: %w", issues.Err())
+	}
+	prog, err := env.Program(ast,
+		cel.CostLimit(limits.CostLimit),
+		cel.InterruptCheckFrequency(interruptCheckFrequency),
+	)
+	if err != nil {
+		reason = ReasonCompile
+		return nil, fmt.Errorf("failed to build CEL program: %w", err)
+	}
+
+	evalCtx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+
+	val, details, err := prog.ContextEval(evalCtx, vars)
+	if details != nil && details.ActualCost() != nil {
+		cost := *details.ActualCost()
+		EvaluationCostHistogram.WithLabelValues(kind).Observe(float64(cost))
+		log.FromContext(ctx).V(1).Info("evaluated CEL expression", "kind", kind, "cost", cost, "duration", time.Since(start))
+	}
+	if err != nil {
+		var cancelled interpreter.EvalCancelledError
+		switch {
+		case errors.As(err, &cancelled) && cancelled.Cause == interpreter.CostLimitExceeded:
+			reason = ReasonCostLimitExceeded
+			return nil, fmt.Errorf("%w (limit %d)", ErrCostLimitExceeded, limits.CostLimit)
+		case ctx.Err() == nil && errors.Is(evalCtx.Err(), context.DeadlineExceeded):
+			reason = ReasonTimeout
+			return nil, fmt.Errorf("%w (timeout %s)", ErrTimeout, limits.Timeout)
+		default:
+			reason = ReasonEvaluation
+			return nil, fmt.Errorf("failed to evaluate CEL expression: %w", err)
+		}
+	}
+
+	return val, nil
+}
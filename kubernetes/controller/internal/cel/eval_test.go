@@ -0,0 +1,66 @@
+package cel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/stretchr/testify/require"
+
+	ocmcel "ocm.software/open-component-model/kubernetes/controller/internal/cel"
+)
+
+func newEnv(t *testing.T) *cel.Env {
+	t.Helper()
+	env, err := cel.NewEnv(ext.Strings(), cel.Variable("resource", cel.DynType))
+	require.NoError(t, err)
+	return env
+}
+
+func TestEvaluate(t *testing.T) {
+	// a single comprehension over many items is interrupted as soon as the timeout is exceeded.
+	items := make([]int, 1_000_000)
+	vars := map[string]any{"resource": map[string]any{"name": "my-resource", "items": items}}
+	// builds a list of 10^6 elements in nested comprehensions.
+	const expensive = `[1,2,3,4,5,6,7,8,9,10].map(a, [1,2,3,4,5,6,7,8,9,10].map(b, [1,2,3,4,5,6,7,8,9,10].map(c, ` +
+		`[1,2,3,4,5,6,7,8,9,10].map(d, [1,2,3,4,5,6,7,8,9,10].map(e, [1,2,3,4,5,6,7,8,9,10].map(f, a + b + c + d + e + f))))))`
+
+	t.Run("within limits", func(t *testing.T) {
+		r := require.New(t)
+		val, err := ocmcel.Evaluate(t.Context(), newEnv(t), ocmcel.KindAdditionalStatusField, `resource.name + "-suffix"`, vars, ocmcel.Limits{})
+		r.NoError(err)
+		r.Equal("my-resource-suffix", val.Value())
+	})
+
+	t.Run("cost limit exceeded", func(t *testing.T) {
+		r := require.New(t)
+		_, err := ocmcel.Evaluate(t.Context(), newEnv(t), ocmcel.KindAdditionalStatusField, expensive, vars, ocmcel.Limits{CostLimit: 1000})
+		r.ErrorIs(err, ocmcel.ErrCostLimitExceeded)
+	})
+
+	t.Run("timeout exceeded", func(t *testing.T) {
+		r := require.New(t)
+		_, err := ocmcel.Evaluate(t.Context(), newEnv(t), ocmcel.KindLocalization, `resource.items.map(i, [i, i, i, i])`, vars, ocmcel.Limits{
+			CostLimit: 1 << 62,
+			Timeout:   time.Millisecond,
+		})
+		r.ErrorIs(err, ocmcel.ErrTimeout)
+	})
+
+	t.Run("cancelled context is no timeout", func(t *testing.T) {
+		r := require.New(t)
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := ocmcel.Evaluate(ctx, newEnv(t), ocmcel.KindLocalization, `resource.items.map(i, [i, i, i, i])`, vars, ocmcel.Limits{CostLimit: 1 << 62})
+		r.Error(err)
+		r.NotErrorIs(err, ocmcel.ErrTimeout)
+	})
+
+	t.Run("compile error", func(t *testing.T) {
+		r := require.New(t)
+		_, err := ocmcel.Evaluate(t.Context(), newEnv(t), ocmcel.KindAdditionalStatusField, "invalid.!!!", vars, ocmcel.Limits{})
+		r.ErrorContains(err, "failed to compile CEL expression")
+	})
+}
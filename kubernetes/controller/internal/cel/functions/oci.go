@@ -172,6 +172,16 @@ func extractImageReference(m map[string]any, component *v1alpha1.ComponentInfo)
 	return "", fmt.Errorf("expected map with OCIImage access (imageReference) or localBlob access (localReference) or imageReference field")
 }
 
+// AccessImageReference returns the OCI image reference of the access of a resource, like toOCI does
+// for accesses of type OCIImage and localBlob.
+func AccessImageReference(access runtime.Typed, component *v1alpha1.ComponentInfo) (string, error) {
+	var raw runtime.Raw
+	if err := runtime.NewScheme(runtime.WithAllowUnknown()).Convert(access, &raw); err != nil {
+		return "", fmt.Errorf("converting access to raw failed: %w", err)
+	}
+	return getAccessReference(&raw, component)
+}
+
 // getAccessReference extracts an OCI image reference from a typed access spec.
 // For OCIImage access, it returns the imageReference field directly.
 // For localBlob access, it decodes the component's repository spec to obtain the
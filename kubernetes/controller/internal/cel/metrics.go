@@ -0,0 +1,67 @@
+package cel
+
+import (
+	kmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"ocm.software/open-component-model/kubernetes/controller/internal/metrics"
+)
+
+func init() {
+	kmetrics.Registry.MustRegister(
+		EvaluationDurationHistogram,
+		EvaluationCostHistogram,
+		EvaluationFailuresTotal,
+	)
+}
+
+const (
+	// EvaluationDurationHistogramLabel tracks the duration of CEL expression evaluations.
+	EvaluationDurationHistogramLabel = "cel_evaluation_duration_seconds"
+	// EvaluationCostHistogramLabel tracks the cost of CEL expression evaluations.
+	EvaluationCostHistogramLabel = "cel_evaluation_cost"
+	// EvaluationFailuresLabel tracks the number of failed CEL expression evaluations.
+	EvaluationFailuresLabel = "cel_evaluation_failures"
+	// MetricsNamespace defines the namespace of all the CEL metrics.
+	MetricsNamespace = "ocm_system"
+	// OcmComponent is the name of the component registering for these metrics.
+	OcmComponent = "ocm_k8s_toolkit"
+)
+
+const (
+	// KindLabel is the name of the label for the kind of the evaluated expression, e.g. KindAdditionalStatusField.
+	KindLabel = "kind"
+	// ReasonLabel is the name of the label for the reason of a failed evaluation, e.g. ReasonCostLimitExceeded.
+	ReasonLabel = "reason"
+)
+
+// EvaluationDurationHistogram tracks the duration of CEL expression evaluations, including their compilation.
+// [kind].
+var EvaluationDurationHistogram = metrics.MustRegisterHistogramVec(
+	MetricsNamespace,
+	OcmComponent,
+	EvaluationDurationHistogramLabel,
+	"Duration of CEL expression evaluations in seconds.",
+	[]float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5},
+	KindLabel,
+)
+
+// EvaluationCostHistogram tracks the actual cost of CEL expression evaluations.
+// [kind].
+var EvaluationCostHistogram = metrics.MustRegisterHistogramVec(
+	MetricsNamespace,
+	OcmComponent,
+	EvaluationCostHistogramLabel,
+	"Actual cost of CEL expression evaluations.",
+	[]float64{10, 100, 1_000, 10_000, 100_000, 1_000_000},
+	KindLabel,
+)
+
+// EvaluationFailuresTotal counts the failed CEL expression evaluations.
+// [kind, reason].
+var EvaluationFailuresTotal = metrics.MustRegisterCounterVec(
+	MetricsNamespace,
+	OcmComponent,
+	EvaluationFailuresLabel,
+	"Number of failed CEL expression evaluations.",
+	KindLabel, ReasonLabel,
+)
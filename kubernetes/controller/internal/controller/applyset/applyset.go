@@ -68,6 +68,13 @@ type Resource struct {
 // ApplyMode controls Apply behavior.
 type ApplyMode struct {
 	Concurrency int // 0 = len(resources)
+	// Recreate decides whether a resource whose apply failed because an immutable field changed
+	// is deleted and recreated. It is called with the desired object. If nil, no resource is recreated.
+	// Resources not managed by the ApplySet and protected kinds such as Namespaces are never recreated.
+	Recreate func(desired *unstructured.Unstructured) bool
+	// DryRun applies the resources with a server-side dry-run, so that ApplyResultItem.Observed is the state the
+	// resources would have after applying them, without persisting it. Resources are never recreated in dry-run mode.
+	DryRun bool
 }
 
 // PruneOptions controls Prune behavior.
@@ -280,6 +287,9 @@ func (a *ApplySet) Apply(ctx context.Context, resources []Resource, mode ApplyMo
 		FieldManager: FieldManager,
 		Force:        true,
 	}
+	if mode.DryRun {
+		applyOptions.DryRun = []string{metav1.DryRunAll}
+	}
 
 	for _, entry := range toApply {
 		eg.Go(func() error {
@@ -319,6 +329,9 @@ func (a *ApplySet) Apply(ctx context.Context, resources []Resource, mode ApplyMo
 			}
 
 			item := a.applyResource(egCtx, entry.resource, entry.mapping, applyOptions)
+			if item.Error != nil && !mode.DryRun && mode.Recreate != nil && IsImmutableFieldError(item.Error) && mode.Recreate(entry.resource.Object) {
+				item = a.recreate(egCtx, entry.resource, entry.mapping, applyOptions, item.Error)
+			}
 			mu.Lock()
 			result.Applied = append(result.Applied, item)
 			mu.Unlock()
@@ -400,6 +413,9 @@ func (a *ApplySet) applyResource(
 		client.ForceOwnership,
 		client.FieldOwner(options.FieldManager),
 	}
+	if len(options.DryRun) > 0 {
+		applyOptions = append(applyOptions, client.DryRunAll)
+	}
 
 	err := a.client.Apply(ctx, client.ApplyConfigurationFromUnstructured(r.Object), applyOptions...)
 	if err != nil {
@@ -37,6 +37,14 @@
 // resources get cleaned up: they were applied before, now they're skipped,
 // and the parent annotation provides prune scope from prior reconciles.
 //
+// # Recreation
+//
+// SSA cannot change immutable fields, e.g. the template of a Job. If ApplyMode.Recreate
+// approves it, a resource whose apply fails for that reason is deleted and applied again.
+// Only members of the ApplySet are recreated, never protected kinds such as Namespaces or
+// PersistentVolumeClaims. Resources that still exist after the delete, e.g. because of
+// finalizers, fail with ErrRecreatePending until a later Apply finds them gone.
+//
 // # ApplySet ID
 //
 // Computed from parent GKNN: applyset-<base64(sha256(name.namespace.kind.group))>-v1
@@ -0,0 +1,113 @@
+package applyset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrRecreatePending is returned for resources that were deleted to be recreated but still exist,
+// e.g. because finalizers delay their deletion. The resource is recreated by a later Apply.
+var ErrRecreatePending = errors.New("resource is being deleted to be recreated")
+
+// protectedGroupKinds are never recreated, because deleting them also deletes the resources
+// or data they contain.
+var protectedGroupKinds = sets.New(
+	schema.GroupKind{Kind: "Namespace"},
+	schema.GroupKind{Kind: "PersistentVolume"},
+	schema.GroupKind{Kind: "PersistentVolumeClaim"},
+	schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"},
+)
+
+// IsImmutableFieldError reports whether err rejects a change of an immutable field,
+// e.g. of the template of a Job or the clusterIP of a Service.
+func IsImmutableFieldError(err error) bool {
+	if !apierrors.IsInvalid(err) {
+		return false
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		if details := status.Status().Details; details != nil {
+			for _, cause := range details.Causes {
+				if isImmutableFieldMessage(cause.Message) {
+					return true
+				}
+			}
+		}
+	}
+	return isImmutableFieldMessage(err.Error())
+}
+
+func isImmutableFieldMessage(msg string) bool {
+	return strings.Contains(msg, "field is immutable") || strings.Contains(msg, "may not change once set")
+}
+
+// recreate deletes a resource whose apply failed with applyErr because an immutable field changed
+// and applies it again. For safety, only resources that are members of this ApplySet and not of
+// a protected kind are recreated, and the delete is preconditioned on the UID of the existing
+// resource so that a resource replaced in the meantime is not deleted.
+// If the resource is not recreated, the returned item carries applyErr and the reason.
+func (a *ApplySet) recreate(
+	ctx context.Context,
+	r Resource,
+	mapping *meta.RESTMapping,
+	options metav1.ApplyOptions,
+	applyErr error,
+) ApplyResultItem {
+	notRecreated := func(reason string) ApplyResultItem {
+		return ApplyResultItem{ID: r.ID, Error: fmt.Errorf("%w (not recreated: %s)", applyErr, reason)}
+	}
+
+	if protectedGroupKinds.Has(mapping.GroupVersionKind.GroupKind()) {
+		return notRecreated(fmt.Sprintf("%s resources are never recreated", mapping.GroupVersionKind.Kind))
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(r.Object.GroupVersionKind())
+	if err := a.client.Get(ctx, client.ObjectKeyFromObject(r.Object), current); err != nil {
+		return notRecreated(fmt.Sprintf("failed to get existing resource: %v", err))
+	}
+	if current.GetLabels()[ApplysetPartOfLabel] != a.applySetID {
+		return notRecreated("existing resource is not managed by this ApplySet")
+	}
+
+	uid := current.GetUID()
+	if current.GetDeletionTimestamp() == nil {
+		a.log.Info("recreating resource because an immutable field changed",
+			"id", r.ID,
+			"gvr", mapping.Resource.String(),
+			"namespace", current.GetNamespace(),
+			"name", current.GetName(),
+			"uid", uid,
+			"error", applyErr,
+		)
+		err := a.client.Delete(ctx, current,
+			client.Preconditions{UID: &uid},
+			client.PropagationPolicy(metav1.DeletePropagationBackground),
+		)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return ApplyResultItem{ID: r.ID, Error: fmt.Errorf("failed to delete %s/%s to recreate it: %w", current.GetNamespace(), current.GetName(), err)}
+		}
+	}
+
+	if err := a.client.Get(ctx, client.ObjectKeyFromObject(r.Object), current); err == nil {
+		return ApplyResultItem{ID: r.ID, Error: fmt.Errorf("%w: %s/%s", ErrRecreatePending, current.GetNamespace(), current.GetName())}
+	} else if !apierrors.IsNotFound(err) {
+		return ApplyResultItem{ID: r.ID, Error: fmt.Errorf("failed to verify deletion of %s/%s: %w", current.GetNamespace(), current.GetName(), err)}
+	}
+
+	// the resource is created from scratch, so a revision of the deleted resource cannot match.
+	r.CurrentRevision = ""
+	item := a.applyResource(ctx, r, mapping, options)
+	item.Recreated = &Recreation{PreviousUID: uid, Reason: applyErr.Error()}
+	return item
+}
@@ -0,0 +1,176 @@
+package applyset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newImmutableFieldError(obj client.Object) error {
+	return apierrors.NewInvalid(obj.GetObjectKind().GroupVersionKind().GroupKind(), obj.GetName(), field.ErrorList{
+		field.Invalid(field.NewPath("spec", "template"), "changed", "field is immutable"),
+	})
+}
+
+func TestIsImmutableFieldError(t *testing.T) {
+	cm := newConfigMap("cm1", "default")
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"immutable field":     {err: newImmutableFieldError(cm), want: true},
+		"may not change once": {err: apierrors.NewInvalid(schema.GroupKind{Kind: "Service"}, "svc", field.ErrorList{field.Invalid(field.NewPath("spec", "clusterIPs").Index(0), "10.0.0.1", "may not change once set")}), want: true},
+		"other invalid":       {err: apierrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "cm1", field.ErrorList{field.Required(field.NewPath("data"), "")}), want: false},
+		"not invalid":         {err: errors.New("field is immutable"), want: false},
+		"nil":                 {err: nil, want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsImmutableFieldError(tt.err); got != tt.want {
+				t.Errorf("IsImmutableFieldError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply_Recreate(t *testing.T) {
+	ctx := context.Background()
+	mapper := newTestRESTMapper()
+	parent := newTestParent(schema.GroupVersionKind{
+		Group: "delivery.ocm.software", Version: "v1alpha1", Kind: "TestKind",
+	})
+	applySetID := ID(parent)
+	always := func(*unstructured.Unstructured) bool { return true }
+
+	tests := map[string]struct {
+		existing      *unstructured.Unstructured
+		desired       *unstructured.Unstructured
+		recreate      func(*unstructured.Unstructured) bool
+		wantRecreated bool
+		wantErr       string
+	}{
+		"managed resource is recreated": {
+			existing: func() *unstructured.Unstructured {
+				cm := newConfigMap("cm1", "default")
+				cm.SetLabels(map[string]string{ApplysetPartOfLabel: applySetID})
+				return cm
+			}(),
+			desired:       newConfigMap("cm1", "default"),
+			recreate:      always,
+			wantRecreated: true,
+		},
+		"recreation not approved": {
+			existing: func() *unstructured.Unstructured {
+				cm := newConfigMap("cm1", "default")
+				cm.SetLabels(map[string]string{ApplysetPartOfLabel: applySetID})
+				return cm
+			}(),
+			desired:  newConfigMap("cm1", "default"),
+			recreate: func(*unstructured.Unstructured) bool { return false },
+			wantErr:  "field is immutable",
+		},
+		"unmanaged resource is not recreated": {
+			existing: newConfigMap("cm1", "default"),
+			desired:  newConfigMap("cm1", "default"),
+			recreate: always,
+			wantErr:  "not managed by this ApplySet",
+		},
+		"protected kind is not recreated": {
+			existing: func() *unstructured.Unstructured {
+				ns := newNamespace("ns1")
+				ns.SetLabels(map[string]string{ApplysetPartOfLabel: applySetID})
+				return ns
+			}(),
+			desired:  newNamespace("ns1"),
+			recreate: always,
+			wantErr:  "Namespace resources are never recreated",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.existing.SetUID(types.UID("existing-uid"))
+
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			var rejected, deleted atomic.Int32
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.existing).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+						// the existing resource rejects the change, a recreated one accepts it.
+						if rejected.Add(1) == 1 {
+							return newImmutableFieldError(tt.desired)
+						}
+						return c.Apply(ctx, obj, opts...)
+					},
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						deleted.Add(1)
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			applier := New(Config{
+				Client:          fakeClient,
+				RESTMapper:      mapper,
+				Log:             logr.Discard(),
+				ParentNamespace: "default",
+			}, parent)
+
+			result, err := applier.Apply(ctx, []Resource{{ID: "res", Object: tt.desired}}, ApplyMode{Recreate: tt.recreate})
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if len(result.Applied) != 1 {
+				t.Fatalf("Apply() applied %d resources, want 1", len(result.Applied))
+			}
+			item := result.Applied[0]
+
+			if tt.wantErr != "" {
+				if item.Error == nil || !strings.Contains(item.Error.Error(), tt.wantErr) {
+					t.Errorf("Apply() item error = %v, want error containing %q", item.Error, tt.wantErr)
+				}
+				if !IsImmutableFieldError(item.Error) {
+					t.Errorf("Apply() item error = %v, want the immutable field error to be preserved", item.Error)
+				}
+			} else if item.Error != nil {
+				t.Errorf("Apply() item error = %v, want nil", item.Error)
+			}
+
+			if !tt.wantRecreated {
+				if item.Recreated != nil || deleted.Load() != 0 {
+					t.Errorf("Apply() recreated %v with %d deletes, want no recreation", item.Recreated, deleted.Load())
+				}
+				return
+			}
+			if deleted.Load() != 1 {
+				t.Errorf("Apply() deleted %d times, want 1", deleted.Load())
+			}
+			if item.Recreated == nil || item.Recreated.PreviousUID != "existing-uid" {
+				t.Fatalf("Apply() recreation = %v, want previous UID existing-uid", item.Recreated)
+			}
+			if !strings.Contains(item.Recreated.Reason, "field is immutable") {
+				t.Errorf("Apply() recreation reason = %q, want the apply error", item.Recreated.Reason)
+			}
+			if got := result.Recreated(); len(got) != 1 {
+				t.Errorf("Recreated() returned %d items, want 1", len(got))
+			}
+		})
+	}
+}
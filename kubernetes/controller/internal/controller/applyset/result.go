@@ -31,6 +31,16 @@ type ApplyResultItem struct {
 	Observed *unstructured.Unstructured // cluster state after apply (nil if error)
 	Changed  bool                       // resourceVersion changed
 	Error    error
+	// Recreated is set if the resource was deleted and recreated because an immutable field changed.
+	Recreated *Recreation
+}
+
+// Recreation describes the recreation of a resource whose immutable fields changed.
+type Recreation struct {
+	// PreviousUID is the UID of the deleted resource.
+	PreviousUID types.UID
+	// Reason is the apply error that required the recreation.
+	Reason string
 }
 
 // PruneResultItem is a successfully pruned resource.
@@ -47,6 +57,17 @@ func (r *ApplyResult) ByID() map[string]ApplyResultItem {
 	return m
 }
 
+// Recreated returns the items of all resources that were recreated.
+func (r *ApplyResult) Recreated() []ApplyResultItem {
+	var recreated []ApplyResultItem
+	for _, item := range r.Applied {
+		if item.Recreated != nil {
+			recreated = append(recreated, item)
+		}
+	}
+	return recreated
+}
+
 // ObservedUIDs returns the UIDs of all successfully applied resources.
 func (r *ApplyResult) ObservedUIDs() sets.Set[types.UID] {
 	uids := sets.New[types.UID]()
@@ -38,6 +38,7 @@ import (
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
 	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
 	"ocm.software/open-component-model/kubernetes/controller/internal/util"
 	"ocm.software/open-component-model/kubernetes/controller/internal/verification"
 	"ocm.software/open-component-model/kubernetes/controller/pkg/configuration"
@@ -174,6 +175,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) err
 
 //nolint:funlen,cyclop // we do not want to cut the function at arbitrary points
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := tracing.StartReconcile(ctx, "Component", req)
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 	logger.Info("starting reconciliation")
 
@@ -260,18 +264,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to get verifications: %w", err)
 	}
 
-	cfg, err := configuration.LoadConfigurations(ctx, r.Client, component.GetNamespace(), configs)
+	cfg, err := configuration.LoadConfigurations(ctx, r.Client, component.GetNamespace(), configs, configuration.WithDecryptor(r.ConfigDecryptor))
 	if err != nil {
 		status.MarkNotReady(r.EventRecorder, component, v1alpha1.GetComponentVersionFailedReason, err.Error())
 
 		return ctrl.Result{}, fmt.Errorf("failed to load configurations: %w", err)
 	}
 
+	// the plugin pinned by the component takes precedence over the one of the repository.
+	plugin := component.Spec.Plugin
+	if plugin == nil {
+		plugin = repo.Spec.Plugin
+	}
+
 	cacheBackedRepo, err := r.Resolver.NewCacheBackedRepository(ctx, &resolution.RepositoryOptions{
-		RepositorySpec:  repoSpec,
-		Configuration:   cfg,
-		SigningRegistry: r.PluginManager.SigningRegistry,
-		Verifications:   verifications,
+		RepositorySpec:        repoSpec,
+		Configuration:         cfg,
+		SigningRegistry:       r.PluginManager.SigningRegistry,
+		Verifications:         verifications,
+		VerificationThreshold: int(component.Spec.VerifyThreshold),
+		Plugin:                resolution.NewPluginSelection(component.GetNamespace(), plugin, cfg),
 		RequesterFunc: func() workerpool.RequesterInfo {
 			return workerpool.RequesterInfo{
 				NamespacedName: types.NamespacedName{
@@ -304,6 +316,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 			"version", version)
 
 		return ctrl.Result{}, nil
+	case errors.Is(err, workerpool.ErrQuotaExceeded):
+		status.MarkResolutionQuotaExceeded(r.EventRecorder, component, err)
+
+		return ctrl.Result{}, err
 	case errors.Is(err, workerpool.ErrNotSafelyDigestible):
 		// Ignore error, but log event
 		event.New(r.EventRecorder, component, nil, v1alpha1.EventSeverityError, "%s", err.Error())
@@ -332,6 +348,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 			NormalisationAlgorithm: digestSpec.NormalisationAlgorithm,
 			Value:                  digestSpec.Value,
 		},
+		Plugin: plugin.DeepCopy(),
 	}
 
 	status.MarkReady(r.EventRecorder, component, "Applied version %s", version)
@@ -0,0 +1,229 @@
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// BlobCache caches downloaded blobs, e.g. resource manifests, up to a byte budget and evicts the least
+// recently used blobs once the budget is exceeded.
+//
+// If the cache is backed by a directory, the blobs are stored as files in it, so that the memory usage of
+// the controller does not grow with the cached content. The directory is expected to be a volume dedicated
+// to the cache (e.g. an emptyDir with a size limit): the cache owns its content and removes stale files
+// on creation. Without a directory, the blobs are kept in memory.
+type BlobCache struct {
+	name     string
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	bytes   int64
+}
+
+type blobEntry struct {
+	key  string
+	data []byte
+	path string
+	size int64
+}
+
+// NewBlobCache creates a BlobCache with a budget of maxBytes, which must be positive.
+// If dir is empty, the blobs are kept in memory, otherwise they are stored in dir, which is created if needed.
+func NewBlobCache(name, dir string, maxBytes int64) (*BlobCache, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("blob cache %s requires a positive size limit, got %d", name, maxBytes)
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("creating blob cache directory: %w", err)
+		}
+		// the index is kept in memory, blobs cached by previous runs are unknown and would leak space.
+		stale, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("reading blob cache directory: %w", err)
+		}
+		for _, entry := range stale {
+			if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				return nil, fmt.Errorf("removing stale blob cache entry: %w", err)
+			}
+		}
+	}
+
+	return &BlobCache{
+		name:     name,
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}, nil
+}
+
+// Load returns a reader for the blob cached under key. On a miss, the blob is read from fallback and cached.
+// Blobs bigger than the budget are returned but not cached. The returned reader stays valid if the blob
+// is evicted while it is read.
+func (c *BlobCache) Load(key string, fallback func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	if rc, ok := c.get(key); ok {
+		hitCount.WithLabelValues(c.name).Inc()
+
+		return rc, nil
+	}
+	missCount.WithLabelValues(c.name).Inc()
+
+	source, err := fallback()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.dir == "" {
+		data, err := readAllAndClose(source)
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) <= c.maxBytes {
+			c.add(&blobEntry{key: key, data: data, size: int64(len(data))})
+		}
+
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	return c.store(key, source)
+}
+
+// Bytes returns the accumulated size of the cached blobs.
+func (c *BlobCache) Bytes() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.bytes
+}
+
+func (c *BlobCache) get(key string) (io.ReadCloser, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	//nolint:forcetypeassert // we know the type is correct because we are the only ones setting it
+	entry := elem.Value.(*blobEntry)
+	if entry.path == "" {
+		c.order.MoveToFront(elem)
+
+		return io.NopCloser(bytes.NewReader(entry.data)), true
+	}
+	file, err := os.Open(entry.path)
+	if err != nil {
+		// the file is gone, e.g. because the volume was cleaned up, treat it as a miss.
+		c.remove(elem, false)
+		c.updateMetrics()
+
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+
+	return file, true
+}
+
+// store copies source into the cache directory and returns a reader for the stored file.
+func (c *BlobCache) store(key string, source io.ReadCloser) (_ io.ReadCloser, err error) {
+	tmp, err := os.CreateTemp(c.dir, ".download-*")
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("creating blob cache file: %w", err), source.Close())
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(tmp.Name()))
+		}
+	}()
+
+	size, err := io.Copy(tmp, source)
+	err = errors.Join(err, source.Close(), tmp.Close())
+	if err != nil {
+		return nil, fmt.Errorf("storing blob in cache: %w", err)
+	}
+
+	if size > c.maxBytes {
+		file, err := os.Open(tmp.Name())
+		if err != nil {
+			return nil, fmt.Errorf("opening blob cache file: %w", err)
+		}
+		// the open file stays readable after it was removed.
+		if err := os.Remove(tmp.Name()); err != nil {
+			return nil, errors.Join(fmt.Errorf("removing uncached blob: %w", err), file.Close())
+		}
+
+		return file, nil
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	path := filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("storing blob in cache: %w", err)
+	}
+	// opened before it is added, so it cannot be evicted before it is read.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening blob cache file: %w", err)
+	}
+	c.add(&blobEntry{key: key, path: path, size: size})
+
+	return file, nil
+}
+
+func (c *BlobCache) add(entry *blobEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[entry.key]; ok {
+		// stored concurrently, the file was replaced by the rename already.
+		c.remove(elem, false)
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	c.bytes += entry.size
+	for c.bytes > c.maxBytes {
+		//nolint:forcetypeassert // we know the type is correct because we are the only ones setting it
+		evicted := c.order.Back().Value.(*blobEntry)
+		c.remove(c.order.Back(), true)
+		evictCount.WithLabelValues(c.name).Inc()
+		evictedBytes.WithLabelValues(c.name).Add(float64(evicted.size))
+	}
+	c.updateMetrics()
+}
+
+func (c *BlobCache) remove(elem *list.Element, deleteFile bool) {
+	//nolint:forcetypeassert // we know the type is correct because we are the only ones setting it
+	entry := elem.Value.(*blobEntry)
+	c.order.Remove(elem)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+	if deleteFile && entry.path != "" {
+		// a failed removal leaks space on the volume but does not affect the cache itself.
+		_ = os.Remove(entry.path)
+	}
+}
+
+func (c *BlobCache) updateMetrics() {
+	objectCacheSize.WithLabelValues(c.name).Set(float64(c.order.Len()))
+	cacheBytes.WithLabelValues(c.name).Set(float64(c.bytes))
+}
+
+func readAllAndClose(rc io.ReadCloser) ([]byte, error) {
+	data, err := io.ReadAll(rc)
+	if err = errors.Join(err, rc.Close()); err != nil {
+		return nil, fmt.Errorf("reading blob: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizedDigestObjectCache(t *testing.T) {
+	r := require.New(t)
+
+	var evicted []string
+	c := NewSizedDigestObjectCache[string, string]("test_sized", 0, 10, func(v string) int64 {
+		return int64(len(v))
+	}, func(k string, _ string) {
+		evicted = append(evicted, k)
+	})
+	load := func(key, value string) string {
+		v, err := c.Load(key, func() (string, error) { return value, nil })
+		r.NoError(err)
+		return v
+	}
+
+	r.Equal("aaaa", load("a", "aaaa"))
+	r.Equal("bbbb", load("b", "bbbb"))
+	// a hit returns the cached value and marks it as recently used.
+	r.Equal("aaaa", load("a", "other"))
+	r.Equal("cccc", load("c", "cccc"))
+	r.Equal([]string{"b"}, evicted)
+	r.EqualValues(8, c.Bytes())
+
+	// objects above the budget are returned but not cached.
+	r.Equal("too big for the cache", load("d", "too big for the cache"))
+	r.Equal(2, c.Len())
+
+	_, err := c.Load("e", func() (string, error) { return "", errors.New("failed") })
+	r.ErrorContains(err, "failed")
+	r.Equal(2, c.Len())
+}
+
+func TestBlobCache(t *testing.T) {
+	for name, dir := range map[string]string{
+		"memory": "",
+		"disk":   filepath.Join(t.TempDir(), "blobs"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+
+			c, err := NewBlobCache("test_blob_"+name, dir, 10)
+			r.NoError(err)
+
+			downloads := 0
+			load := func(key, content string) string {
+				rc, err := c.Load(key, func() (io.ReadCloser, error) {
+					downloads++
+					return io.NopCloser(strings.NewReader(content)), nil
+				})
+				r.NoError(err)
+				data, err := io.ReadAll(rc)
+				r.NoError(err)
+				r.NoError(rc.Close())
+				return string(data)
+			}
+
+			r.Equal("aaaa", load("a", "aaaa"))
+			r.Equal("aaaa", load("a", "aaaa"))
+			r.Equal(1, downloads)
+
+			r.Equal("bbbbbb", load("b", "bbbbbb"))
+			r.Equal("cccc", load("c", "cccc"))
+			r.EqualValues(10, c.Bytes())
+			// a was evicted for c.
+			r.Equal("aaaa", load("a", "aaaa"))
+			r.Equal(4, downloads)
+
+			r.Equal("too big for the cache", load("d", "too big for the cache"))
+			r.EqualValues(8, c.Bytes())
+
+			if dir != "" {
+				files, err := os.ReadDir(dir)
+				r.NoError(err)
+				r.Len(files, 2)
+			}
+		})
+	}
+}
+
+func TestBlobCacheRemovesStaleFiles(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	r.NoError(os.WriteFile(filepath.Join(dir, "stale"), []byte("stale"), 0o600))
+
+	_, err := NewBlobCache("test_blob_stale", dir, 10)
+	r.NoError(err)
+	files, err := os.ReadDir(dir)
+	r.NoError(err)
+	r.Empty(files)
+
+	_, err = NewBlobCache("test_blob_invalid", dir, 0)
+	r.Error(err)
+}
@@ -12,6 +12,8 @@ var (
 	missCount       *prometheus.CounterVec
 	hitCount        *prometheus.CounterVec
 	evictCount      *prometheus.CounterVec
+	cacheBytes      *prometheus.GaugeVec
+	evictedBytes    *prometheus.CounterVec
 )
 
 func MustRegisterMetrics(registerer prometheus.Registerer) {
@@ -26,6 +28,8 @@ func RegisterMetrics(registerer prometheus.Registerer) error {
 		registerer.Register(missCount),
 		registerer.Register(hitCount),
 		registerer.Register(evictCount),
+		registerer.Register(cacheBytes),
+		registerer.Register(evictedBytes),
 	)
 }
 
@@ -46,6 +50,14 @@ func init() {
 		Name: "cache_evict_total",
 		Help: "number of cache evictions",
 	}, []string{"name"})
+	cacheBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cache_bytes",
+		Help: "accumulated size of the objects in cache in bytes",
+	}, []string{"name"})
+	evictedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_evicted_bytes_total",
+		Help: "accumulated size of the evicted objects in bytes",
+	}, []string{"name"})
 }
 
 const DefaultMemoryDigestObjectCacheSize = 1000
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// SizedDigestObjectCache is a DigestObjectCache that evicts the least recently used objects once
+// the accumulated size of the cached objects exceeds a byte budget. Unlike MemoryDigestObjectCache,
+// which only limits the number of objects, it keeps the memory usage bounded even if single entries
+// (e.g. large manifests decoded into many objects) are big.
+type SizedDigestObjectCache[K comparable, V any] struct {
+	name       string
+	maxEntries int
+	maxBytes   int64
+	sizeOf     func(V) int64
+	onEvict    func(k K, v V)
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	order   *list.List
+	bytes   int64
+}
+
+type sizedEntry[K comparable, V any] struct {
+	key   K
+	value V
+	size  int64
+}
+
+// NewSizedDigestObjectCache creates a SizedDigestObjectCache that holds at most maxEntries objects
+// with an accumulated size of at most maxBytes, as determined by sizeOf. A limit of 0 disables it.
+// Objects bigger than maxBytes are returned but not cached.
+func NewSizedDigestObjectCache[K comparable, V any](name string, maxEntries int, maxBytes int64, sizeOf func(V) int64, onEvict func(k K, v V)) *SizedDigestObjectCache[K, V] {
+	return &SizedDigestObjectCache[K, V]{
+		name:       name,
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		sizeOf:     sizeOf,
+		onEvict:    onEvict,
+		entries:    make(map[K]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (m *SizedDigestObjectCache[K, V]) Load(key K, fallback func() (V, error)) (V, error) {
+	m.mu.Lock()
+	if elem, ok := m.entries[key]; ok {
+		m.order.MoveToFront(elem)
+		m.mu.Unlock()
+		hitCount.WithLabelValues(m.name).Inc()
+
+		//nolint:forcetypeassert // we know the type is correct because we are the only ones setting it
+		return elem.Value.(*sizedEntry[K, V]).value, nil
+	}
+	m.mu.Unlock()
+	missCount.WithLabelValues(m.name).Inc()
+
+	v, err := fallback()
+	if err != nil {
+		return *new(V), err
+	}
+
+	var size int64
+	if m.sizeOf != nil {
+		size = m.sizeOf(v)
+	}
+	if m.maxBytes > 0 && size > m.maxBytes {
+		return v, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[key]; ok {
+		// loaded concurrently, replace the entry with the most recent value.
+		m.remove(elem)
+	}
+	m.entries[key] = m.order.PushFront(&sizedEntry[K, V]{key: key, value: v, size: size})
+	m.bytes += size
+	for (m.maxEntries > 0 && m.order.Len() > m.maxEntries) || (m.maxBytes > 0 && m.bytes > m.maxBytes) {
+		//nolint:forcetypeassert // we know the type is correct because we are the only ones setting it
+		evicted := m.order.Back().Value.(*sizedEntry[K, V])
+		m.remove(m.order.Back())
+		evictCount.WithLabelValues(m.name).Inc()
+		evictedBytes.WithLabelValues(m.name).Add(float64(evicted.size))
+		if m.onEvict != nil {
+			m.onEvict(evicted.key, evicted.value)
+		}
+	}
+	objectCacheSize.WithLabelValues(m.name).Set(float64(m.order.Len()))
+	cacheBytes.WithLabelValues(m.name).Set(float64(m.bytes))
+
+	return v, nil
+}
+
+// Bytes returns the accumulated size of the cached objects.
+func (m *SizedDigestObjectCache[K, V]) Bytes() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.bytes
+}
+
+// Len returns the number of cached objects.
+func (m *SizedDigestObjectCache[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+func (m *SizedDigestObjectCache[K, V]) remove(elem *list.Element) {
+	//nolint:forcetypeassert // we know the type is correct because we are the only ones setting it
+	entry := elem.Value.(*sizedEntry[K, V])
+	m.order.Remove(elem)
+	delete(m.entries, entry.key)
+	m.bytes -= entry.size
+}
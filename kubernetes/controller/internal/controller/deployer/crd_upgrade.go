@@ -0,0 +1,138 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/applyset"
+	"ocm.software/open-component-model/kubernetes/controller/internal/event"
+)
+
+// errUnsafeCRDUpgrade is returned by checkCRDUpgrades if the objects must not be applied.
+var errUnsafeCRDUpgrade = errors.New("unsafe upgrade of custom resource definitions")
+
+var crdGVK = apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition")
+
+// checkCRDUpgrades compares the CustomResourceDefinitions among the resources with the ones that exist in the
+// cluster, records the unsafe changes in the status of the Deployer and handles them according to its
+// CRDUpgradePolicy. It returns an error wrapping errUnsafeCRDUpgrade if the objects must not be applied.
+func (r *Reconciler) checkCRDUpgrades(ctx context.Context, deployer *deliveryv1alpha1.Deployer, resources []applyset.Resource) error {
+	policy := deployer.Spec.CRDUpgradePolicy
+	if policy == deliveryv1alpha1.CRDUpgradePolicyAllow {
+		deployer.Status.CRDUpgradeFindings = nil
+
+		return nil
+	}
+
+	var findings []deliveryv1alpha1.CRDUpgradeFinding
+	for _, res := range resources {
+		if res.Object.GroupVersionKind() != crdGVK {
+			continue
+		}
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(crdGVK)
+		if err := r.Get(ctx, client.ObjectKey{Name: res.Object.GetName()}, live); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return fmt.Errorf("failed to get custom resource definition %s: %w", res.Object.GetName(), err)
+		}
+		existing, err := toCRD(live)
+		if err != nil {
+			return err
+		}
+		incoming, err := toCRD(res.Object)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, compareCRDs(existing, incoming)...)
+	}
+	deployer.Status.CRDUpgradeFindings = findings
+	if len(findings) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		messages = append(messages, finding.Message)
+	}
+	message := strings.Join(messages, "; ")
+
+	if policy == deliveryv1alpha1.CRDUpgradePolicyWarn {
+		log.FromContext(ctx).Info("applying unsafe changes of custom resource definitions", "findings", len(findings))
+		event.New(r.EventRecorder, deployer, nil, deliveryv1alpha1.EventSeverityError,
+			"applying unsafe changes of custom resource definitions: %s", message)
+
+		return nil
+	}
+
+	// Fail as well as the empty policy of Deployers that predate the field do not apply the objects.
+	return fmt.Errorf("%w: %s", errUnsafeCRDUpgrade, message)
+}
+
+// compareCRDs returns the unsafe changes from the existing to the incoming CustomResourceDefinition.
+func compareCRDs(existing, incoming *apiextensionsv1.CustomResourceDefinition) []deliveryv1alpha1.CRDUpgradeFinding {
+	var findings []deliveryv1alpha1.CRDUpgradeFinding
+	add := func(typ deliveryv1alpha1.CRDUpgradeFindingType, format string, args ...any) {
+		findings = append(findings, deliveryv1alpha1.CRDUpgradeFinding{
+			Name:    existing.Name,
+			Type:    typ,
+			Message: existing.Name + ": " + fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, version := range existing.Spec.Versions {
+		if slices.ContainsFunc(incoming.Spec.Versions, func(v apiextensionsv1.CustomResourceDefinitionVersion) bool {
+			return v.Name == version.Name
+		}) {
+			continue
+		}
+		if slices.Contains(existing.Status.StoredVersions, version.Name) {
+			add(deliveryv1alpha1.CRDUpgradeVersionRemoved, "version %s is removed, but objects are stored in it", version.Name)
+		} else {
+			add(deliveryv1alpha1.CRDUpgradeVersionRemoved, "version %s is removed", version.Name)
+		}
+	}
+
+	if from, to := storageVersion(existing), storageVersion(incoming); from != to {
+		add(deliveryv1alpha1.CRDUpgradeStorageVersionChanged, "storage version changes from %s to %s", from, to)
+	}
+
+	if existing.Spec.Scope != incoming.Spec.Scope {
+		add(deliveryv1alpha1.CRDUpgradeScopeChanged, "scope changes from %s to %s", existing.Spec.Scope, incoming.Spec.Scope)
+	}
+
+	return findings
+}
+
+// storageVersion returns the name of the version the objects of the CustomResourceDefinition are stored in.
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+
+	return ""
+}
+
+func toCRD(obj *unstructured.Unstructured) (*apiextensionsv1.CustomResourceDefinition, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+		return nil, fmt.Errorf("failed to convert custom resource definition %s: %w", obj.GetName(), err)
+	}
+
+	return crd, nil
+}
@@ -0,0 +1,151 @@
+package deployer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/applyset"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+)
+
+func testCRD(scope apiextensionsv1.ResourceScope, storage string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition"},
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget"},
+			Scope: scope,
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{storage}},
+	}
+	for _, version := range versions {
+		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+			Name:    version,
+			Served:  true,
+			Storage: version == storage,
+		})
+	}
+
+	return crd
+}
+
+func TestCompareCRDs(t *testing.T) {
+	existing := testCRD(apiextensionsv1.NamespaceScoped, "v1", "v1alpha1", "v1")
+
+	tests := []struct {
+		name     string
+		incoming *apiextensionsv1.CustomResourceDefinition
+		types    []deliveryv1alpha1.CRDUpgradeFindingType
+		messages []string
+	}{
+		{
+			name:     "unchanged",
+			incoming: testCRD(apiextensionsv1.NamespaceScoped, "v1", "v1alpha1", "v1"),
+		},
+		{
+			name:     "version added",
+			incoming: testCRD(apiextensionsv1.NamespaceScoped, "v1", "v1alpha1", "v1", "v2"),
+		},
+		{
+			name:     "unstored version removed",
+			incoming: testCRD(apiextensionsv1.NamespaceScoped, "v1", "v1"),
+			types:    []deliveryv1alpha1.CRDUpgradeFindingType{deliveryv1alpha1.CRDUpgradeVersionRemoved},
+			messages: []string{"widgets.example.com: version v1alpha1 is removed"},
+		},
+		{
+			name:     "downgrade",
+			incoming: testCRD(apiextensionsv1.NamespaceScoped, "v1alpha1", "v1alpha1"),
+			types: []deliveryv1alpha1.CRDUpgradeFindingType{
+				deliveryv1alpha1.CRDUpgradeVersionRemoved,
+				deliveryv1alpha1.CRDUpgradeStorageVersionChanged,
+			},
+			messages: []string{
+				"widgets.example.com: version v1 is removed, but objects are stored in it",
+				"widgets.example.com: storage version changes from v1 to v1alpha1",
+			},
+		},
+		{
+			name:     "scope changed",
+			incoming: testCRD(apiextensionsv1.ClusterScoped, "v1", "v1alpha1", "v1"),
+			types:    []deliveryv1alpha1.CRDUpgradeFindingType{deliveryv1alpha1.CRDUpgradeScopeChanged},
+			messages: []string{"widgets.example.com: scope changes from Namespaced to Cluster"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			findings := compareCRDs(existing, tt.incoming)
+
+			r.Len(findings, len(tt.types))
+			for i, finding := range findings {
+				r.Equal(existing.Name, finding.Name)
+				r.Equal(tt.types[i], finding.Type)
+				r.Equal(tt.messages[i], finding.Message)
+			}
+		})
+	}
+}
+
+func TestCheckCRDUpgrades(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	toResource := func(t *testing.T, crd *apiextensionsv1.CustomResourceDefinition) applyset.Resource {
+		content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(crd)
+		require.NoError(t, err)
+
+		return applyset.Resource{ID: crd.Name, Object: &unstructured.Unstructured{Object: content}}
+	}
+
+	tests := []struct {
+		name     string
+		policy   deliveryv1alpha1.CRDUpgradePolicy
+		existing bool
+		findings int
+		unsafe   bool
+		events   int
+	}{
+		{name: "new definition", policy: deliveryv1alpha1.CRDUpgradePolicyFail},
+		{name: "fail", policy: deliveryv1alpha1.CRDUpgradePolicyFail, existing: true, findings: 2, unsafe: true},
+		{name: "empty policy fails", existing: true, findings: 2, unsafe: true},
+		{name: "warn", policy: deliveryv1alpha1.CRDUpgradePolicyWarn, existing: true, findings: 2, events: 1},
+		{name: "allow", policy: deliveryv1alpha1.CRDUpgradePolicyAllow, existing: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.existing {
+				builder = builder.WithObjects(testCRD(apiextensionsv1.NamespaceScoped, "v1", "v1alpha1", "v1"))
+			}
+			recorder := record.NewFakeRecorder(8)
+			reconciler := &Reconciler{BaseReconciler: &ocm.BaseReconciler{Client: builder.Build(), EventRecorder: recorder}}
+			deployer := &deliveryv1alpha1.Deployer{Spec: deliveryv1alpha1.DeployerSpec{CRDUpgradePolicy: tt.policy}}
+			resources := []applyset.Resource{
+				configMapResource("unrelated", "1"),
+				toResource(t, testCRD(apiextensionsv1.NamespaceScoped, "v1alpha1", "v1alpha1")),
+			}
+
+			err := reconciler.checkCRDUpgrades(t.Context(), deployer, resources)
+			if tt.unsafe {
+				r.ErrorIs(err, errUnsafeCRDUpgrade)
+			} else {
+				r.NoError(err)
+			}
+			r.Len(deployer.Status.CRDUpgradeFindings, tt.findings)
+			r.Len(recorder.Events, tt.events)
+		})
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"io"
 	"runtime"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -39,6 +41,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
 	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
 	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	ocmcel "ocm.software/open-component-model/kubernetes/controller/internal/cel"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/applyset"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/cache"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/dynamic"
@@ -48,6 +51,7 @@ import (
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
 	"ocm.software/open-component-model/kubernetes/controller/internal/setup"
 	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
 	"ocm.software/open-component-model/kubernetes/controller/internal/util"
 	"ocm.software/open-component-model/kubernetes/controller/internal/verification"
 	"ocm.software/open-component-model/kubernetes/controller/pkg/configuration"
@@ -89,12 +93,18 @@ type Reconciler struct {
 	resourceRESTMapper meta.RESTMapper
 
 	DownloadCache cache.DigestObjectCache[string, []*unstructured.Unstructured]
+	// BlobCache caches the downloaded resource blobs before they are decoded, so that an eviction from the
+	// DownloadCache does not require downloading the resource again. Blobs are not cached if it is nil.
+	BlobCache     *cache.BlobCache
 	Resolver      *resolution.Resolver
 	PluginManager *manager.PluginManager
 
 	// MaxResourceSizeBytes is the maximum size in bytes a downloaded resource blob may contain.
 	// 0 disables the limit.
 	MaxResourceSizeBytes int64
+
+	// CELLimits restrict the evaluation of the CEL expressions of localizations.
+	CELLimits ocmcel.Limits
 }
 
 var _ ocm.Reconciler = (*Reconciler)(nil)
@@ -290,6 +300,9 @@ func (r *Reconciler) pruneWithApplySet(ctx context.Context, deployer *deliveryv1
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := tracing.StartReconcile(ctx, "Deployer", req)
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 	logger.Info("starting reconciliation")
 
@@ -332,7 +345,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 }
 
 // reconcileDeployment orchestrates the main deployment pipeline: resolve the referenced resource,
-// load configuration, download the OCM resource, apply it, and track the deployed objects.
+// load configuration, download the OCM resource (or generate the Flux objects of a Helm chart), localize it,
+// detect drift of the deployed objects if configured, check the upgrades of custom resource definitions, apply it,
+// and track the deployed objects.
 func (r *Reconciler) reconcileDeployment(ctx context.Context, deployer *deliveryv1alpha1.Deployer) (ctrl.Result, error) {
 	resource, err := r.resolveResource(ctx, deployer)
 	if resource == nil || err != nil {
@@ -354,21 +369,94 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, deployer *delivery
 		return ctrl.Result{}, err
 	}
 
-	key := buildResourceCacheKey(matchedResource, componentDescriptor, cfg, resource.Spec.Resource.ByReference.Resource.String())
+	var objs []*unstructured.Unstructured
+	if isHelmChart(matchedResource) {
+		// Helm charts are not applied, but deployed through Flux, which pulls the chart itself.
+		if objs, err = helmReleaseObjects(deployer, matchedResource, resource.Status.Component); err != nil {
+			status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.HelmReleaseFailedReason, err.Error())
 
-	objs, err := r.DownloadCache.Load(key, func() ([]*unstructured.Unstructured, error) {
-		return r.DownloadResourceWithOCM(ctx, cacheBackedRepo, componentDescriptor, matchedResource, cfg)
-	})
+			return ctrl.Result{}, fmt.Errorf("failed to generate helm release: %w", err)
+		}
+	} else {
+		key := buildResourceCacheKey(matchedResource, componentDescriptor, cfg, resource.Spec.Resource.ByReference.Resource.String())
+
+		// the downloaded manifest is cached under key, the objects rendered from it also depend on the kustomization.
+		objsKey := key
+		if kustomization := deployer.Spec.Kustomize; kustomization != nil {
+			hash, err := kustomizationHash(kustomization)
+			if err != nil {
+				status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.KustomizeFailedReason, err.Error())
+
+				return ctrl.Result{}, err
+			}
+			objsKey += "/kustomize:" + hash
+		}
+
+		objs, err = r.DownloadCache.Load(objsKey, func() ([]*unstructured.Unstructured, error) {
+			return r.DownloadResourceWithOCM(ctx, key, cacheBackedRepo, componentDescriptor, matchedResource, cfg, deployer.Spec.Kustomize)
+		})
+		if err != nil {
+			reason := deliveryv1alpha1.GetOCMResourceFailedReason
+			if errors.Is(err, errKustomizeFailed) {
+				reason = deliveryv1alpha1.KustomizeFailedReason
+			}
+			status.MarkNotReady(r.EventRecorder, deployer, reason, err.Error())
+
+			return ctrl.Result{}, fmt.Errorf("failed to download resource from OCM or retrieve it from the cache: %w", err)
+		}
+	}
+
+	objs, err = localize(ctx, deployer.Spec.Localizations, componentDescriptor, matchedResource, resource.Status.Component, objs, r.CELLimits)
 	if err != nil {
-		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.GetOCMResourceFailedReason, err.Error())
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.LocalizationFailedReason, err.Error())
 
-		return ctrl.Result{}, fmt.Errorf("failed to download resource from OCM or retrieve it from the cache: %w", err)
+		return ctrl.Result{}, fmt.Errorf("failed to localize resources: %w", err)
 	}
 
-	if err = r.applyWithApplySet(ctx, resource, deployer, objs); err != nil {
+	resources, err := r.prepareApplySetResources(ctx, resource, deployer, objs)
+	if err != nil {
 		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.ApplyFailed, err.Error())
 
-		return ctrl.Result{}, fmt.Errorf("failed to apply resources: %w", err)
+		return ctrl.Result{}, fmt.Errorf("failed to prepare resources for apply: %w", err)
+	}
+	digest, err := objectsDigest(resources)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.MarshalFailedReason, err.Error())
+
+		return ctrl.Result{}, err
+	}
+
+	// drift is only detected if the objects did not change since the last apply, as otherwise the deployed
+	// objects are expected to differ from them.
+	var drifted []deliveryv1alpha1.DeployedObjectReference
+	driftDetection := deployer.Spec.DriftDetection
+	unchanged := deployer.Status.ObservedGeneration == deployer.GetGeneration() && deployer.Status.LastAppliedDigest == digest
+	if driftDetection != nil && unchanged {
+		if drifted, err = r.detectDrift(ctx, deployer, resources); err != nil {
+			status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.DriftDetectionFailedReason, err.Error())
+
+			return ctrl.Result{}, fmt.Errorf("failed to detect drift: %w", err)
+		}
+	}
+
+	// in report mode, drift is not corrected, so that unchanged objects are not applied.
+	reportOnly := driftDetection != nil && unchanged && driftDetection.Mode == deliveryv1alpha1.DriftDetectionModeReport
+	if !reportOnly {
+		if err = r.checkCRDUpgrades(ctx, deployer, resources); err != nil {
+			reason := deliveryv1alpha1.CRDUpgradeCheckFailedReason
+			if errors.Is(err, errUnsafeCRDUpgrade) {
+				reason = deliveryv1alpha1.CRDUpgradeUnsafeReason
+			}
+			status.MarkNotReady(r.EventRecorder, deployer, reason, err.Error())
+
+			return ctrl.Result{}, fmt.Errorf("failed to check upgrades of custom resource definitions: %w", err)
+		}
+		if err = r.applyWithApplySet(ctx, deployer, resources); err != nil {
+			status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.ApplyFailed, err.Error())
+
+			return ctrl.Result{}, fmt.Errorf("failed to apply resources: %w", err)
+		}
+		deployer.Status.LastAppliedDigest = digest
 	}
 
 	// Track the applied objects for the dynamic informer manager
@@ -380,10 +468,18 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, deployer *delivery
 
 	updateDeployedObjectStatusReferences(objs, deployer)
 
+	var interval time.Duration
+	if driftDetection != nil {
+		r.setDriftCondition(deployer, drifted, !reportOnly)
+		interval = driftDetection.Interval.Duration
+	} else {
+		status.RemoveCondition(deployer, deliveryv1alpha1.DriftedCondition)
+	}
+
 	status.MarkReady(r.EventRecorder, deployer, "Applied %s:%s, resource %s",
 		componentDescriptor.Component.Name, componentDescriptor.Component.Version, matchedResource.Name)
 
-	return ctrl.Result{}, nil
+	return status.RequeueResult(deployer, interval), nil
 }
 
 // resolveResource fetches the Resource referenced by the Deployer and validates that it is ready.
@@ -442,7 +538,7 @@ func (r *Reconciler) resolveConfiguration(
 	}
 	deployer.Status.EffectiveOCMConfig = configs
 
-	cfg, err := configuration.LoadConfigurations(ctx, r.Client, deployer.GetNamespace(), configs)
+	cfg, err := configuration.LoadConfigurations(ctx, r.Client, deployer.GetNamespace(), configs, configuration.WithDecryptor(r.ConfigDecryptor))
 	if err != nil {
 		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.GetConfigurationFailedReason, err.Error())
 
@@ -470,6 +566,7 @@ func (r *Reconciler) createCacheBackedRepository(
 		RepositorySpec:  repoSpec,
 		Configuration:   cfg,
 		SigningRegistry: r.PluginManager.SigningRegistry,
+		Plugin:          resolution.NewPluginSelection(deployer.GetNamespace(), resource.Status.Component.Plugin, cfg),
 		RequesterFunc: func() workerpool.RequesterInfo {
 			return workerpool.RequesterInfo{
 				NamespacedName: k8stypes.NamespacedName{
@@ -503,6 +600,10 @@ func (r *Reconciler) resolveComponentAndMatchResource(
 		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.ResolutionInProgress, err.Error())
 
 		return nil, nil, nil
+	case errors.Is(err, workerpool.ErrQuotaExceeded):
+		status.MarkResolutionQuotaExceeded(r.EventRecorder, deployer, err)
+
+		return nil, nil, err
 	case errors.Is(err, ErrComponentVersionDrift):
 		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.ComponentDriftResolutionInProgress, err.Error())
 
@@ -596,13 +697,51 @@ func (r *Reconciler) reconcileDeletionTimestamp(ctx context.Context, deployer *d
 	return ctrl.Result{}, nil, false
 }
 
+// DownloadResourceWithOCM downloads the resource and decodes the objects of its manifest. If a kustomization is
+// given, the objects are rendered from the resource with kustomize instead. If a BlobCache is configured, the
+// downloaded manifest is cached under key.
 func (r *Reconciler) DownloadResourceWithOCM(
 	ctx context.Context,
+	key string,
 	cacheBackedRepo *resolution.CacheBackedRepository,
 	componentDescriptor *descriptor.Descriptor,
 	resource *descriptor.Resource,
 	cfg *configuration.Configuration,
+	kustomization *deliveryv1alpha1.Kustomization,
 ) (objs []*unstructured.Unstructured, err error) {
+	open := func() (io.ReadCloser, error) {
+		return r.openResourceManifest(ctx, cacheBackedRepo, componentDescriptor, resource, cfg)
+	}
+
+	var manifest io.ReadCloser
+	if r.BlobCache != nil {
+		manifest, err = r.BlobCache.Load(key, open)
+	} else {
+		manifest, err = open()
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, manifest.Close())
+	}()
+
+	if kustomization != nil {
+		return renderKustomization(manifest, kustomization)
+	}
+
+	return decodeObjectsFromManifest(manifest)
+}
+
+// openResourceManifest downloads the resource and returns a reader for its content that enforces
+// MaxResourceSizeBytes.
+func (r *Reconciler) openResourceManifest(
+	ctx context.Context,
+	cacheBackedRepo *resolution.CacheBackedRepository,
+	componentDescriptor *descriptor.Descriptor,
+	resource *descriptor.Resource,
+	cfg *configuration.Configuration,
+) (io.ReadCloser, error) {
 	resourceBlob, err := r.downloadResourceBlob(ctx, cacheBackedRepo, componentDescriptor, resource, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download resource: %w", err)
@@ -612,9 +751,6 @@ func (r *Reconciler) DownloadResourceWithOCM(
 	if err != nil {
 		return nil, fmt.Errorf("getting reader for resource blob: %w", err)
 	}
-	defer func() {
-		err = errors.Join(err, limitedReader.Close())
-	}()
 
 	// Enforce resource size limit: opportunistic pre-check using declared size,
 	// then wrap the reader to cap reads at the limit.
@@ -628,14 +764,14 @@ func (r *Reconciler) DownloadResourceWithOCM(
 					apiresource.NewQuantity(r.MaxResourceSizeBytes, apiresource.BinarySI),
 				)
 
-				return nil, err
+				return nil, errors.Join(err, limitedReader.Close())
 			}
 		}
 
 		limitedReader = &limitedReadCloser{Closer: limitedReader, limited: &io.LimitedReader{R: limitedReader, N: r.MaxResourceSizeBytes}}
 	}
 
-	return decodeObjectsFromManifest(limitedReader)
+	return limitedReader, nil
 }
 
 func decodeObjectsFromManifest(manifest io.ReadCloser) (_ []*unstructured.Unstructured, err error) {
@@ -661,6 +797,21 @@ func decodeObjectsFromManifest(manifest io.ReadCloser) (_ []*unstructured.Unstru
 	return objs, nil
 }
 
+// ObjectsSize estimates the memory used by decoded objects by the size of their JSON encoding.
+// It is used as the size of DownloadCache entries.
+func ObjectsSize(objs []*unstructured.Unstructured) int64 {
+	var size int64
+	for _, obj := range objs {
+		data, err := obj.MarshalJSON()
+		if err != nil {
+			continue
+		}
+		size += int64(len(data))
+	}
+
+	return size
+}
+
 // downloadResourceBlob downloads a resource blob using either the repository (for local blobs)
 // or the plugin manager (for external access types like OCI images).
 func (r *Reconciler) downloadResourceBlob(
@@ -699,7 +850,15 @@ func (r *Reconciler) downloadResourceBlob(
 		return nil, fmt.Errorf("failed to resolve credentials: %w", err)
 	}
 
-	return resourcePlugin.DownloadResource(ctx, resource, creds)
+	ctx, span := tracing.Start(ctx, "ResourceRepository.DownloadResource",
+		tracing.ComponentKey.String(componentDescriptor.Component.Name),
+		tracing.VersionKey.String(componentDescriptor.Component.Version),
+		tracing.ResourceKey.String(resource.Name),
+	)
+	resourceBlob, err := resourcePlugin.DownloadResource(ctx, resource, creds)
+	tracing.End(span, err)
+
+	return resourceBlob, err
 }
 
 // resolveResourceCredentials resolves credentials for accessing a resource.
@@ -727,6 +886,7 @@ func resolveResourceCredentials(
 	credGraph, err := setup.NewCredentialGraph(ctx, cfg.Config, setup.CredentialGraphOptions{
 		PluginManager: pm,
 		Logger:        &logger,
+		Namespace:     cfg.Namespace,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create credential graph: %w", err)
@@ -774,21 +934,11 @@ func (r *Reconciler) createApplySet(deployer *deliveryv1alpha1.Deployer, logger
 	return applyset.New(cfg, deployer)
 }
 
-// applyWithApplySet applies the resource objects using ApplySet for proper tracking and pruning.
-// This method uses the ApplySet specification (KEP-3659) to manage sets of resources with automatic
-// pruning of orphaned resources.
-//
-// The deployer object itself is used as the ApplySet parent, which means:
-// - All deployed resources are labeled with applyset.k8s.io/part-of=<applyset-id>
-// - The deployer carries annotations tracking the GroupKinds and namespaces of managed resources
-// - Pruning automatically removes resources that were previously deployed but are no longer in the manifest
-func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1alpha1.Resource, deployer *deliveryv1alpha1.Deployer, objs []*unstructured.Unstructured) error {
+// prepareApplySetResources returns the ApplySet resources of the objects, which are copies of the objects with the
+// ownership metadata and controller reference of the Deployer and defaulted namespace and apiVersion.
+func (r *Reconciler) prepareApplySetResources(ctx context.Context, resource *deliveryv1alpha1.Resource, deployer *deliveryv1alpha1.Deployer, objs []*unstructured.Unstructured) ([]applyset.Resource, error) {
 	logger := log.FromContext(ctx).WithValues("deployer", deployer.Name, "namespace", deployer.Namespace)
 
-	// Use the deployer as the ApplySet parent
-	// This allows us to track all resources deployed by this deployer
-	set := r.createApplySet(deployer, logger)
-
 	logger.Info("adding objects to ApplySet", "count", len(objs))
 
 	resourcesToAdd := make([]applyset.Resource, 0, len(objs))
@@ -805,12 +955,12 @@ func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1
 
 		// Set controller reference
 		if err := controllerutil.SetControllerReference(deployer, obj, r.Scheme); err != nil {
-			return fmt.Errorf("failed to set controller reference on object %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+			return nil, fmt.Errorf("failed to set controller reference on object %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
 		}
 
 		// Default namespace and apiVersion if needed
-		if err := r.defaultObj(ctx, resource, obj); err != nil {
-			return fmt.Errorf("failed to default object %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		if err := r.defaultObj(ctx, deployer, obj); err != nil {
+			return nil, fmt.Errorf("failed to default object %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
 		}
 
 		resourcesToAdd = append(resourcesToAdd, applyset.Resource{
@@ -820,6 +970,27 @@ func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1
 		})
 	}
 
+	return resourcesToAdd, nil
+}
+
+// applyWithApplySet applies the resources using ApplySet for proper tracking and pruning.
+// This method uses the ApplySet specification (KEP-3659) to manage sets of resources with automatic
+// pruning of orphaned resources.
+//
+// The deployer object itself is used as the ApplySet parent, which means:
+// - All deployed resources are labeled with applyset.k8s.io/part-of=<applyset-id>
+// - The deployer carries annotations tracking the GroupKinds and namespaces of managed resources
+// - Pruning automatically removes resources that were previously deployed but are no longer in the manifest
+func (r *Reconciler) applyWithApplySet(ctx context.Context, deployer *deliveryv1alpha1.Deployer, resourcesToAdd []applyset.Resource) (err error) {
+	ctx, span := tracing.Start(ctx, "Deployer.Apply", attribute.Int("ocm.deployer.objects", len(resourcesToAdd)))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx).WithValues("deployer", deployer.Name, "namespace", deployer.Namespace)
+
+	// Use the deployer as the ApplySet parent
+	// This allows us to track all resources deployed by this deployer
+	set := r.createApplySet(deployer, logger)
+
 	logger.Info("projecting ApplySet and set deployer metadata")
 	metadata, err := set.Project(resourcesToAdd)
 	if err != nil {
@@ -831,11 +1002,25 @@ func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1
 	}
 
 	logger.Info("applying ApplySet")
-	applyResult, err := set.Apply(ctx, resourcesToAdd, applyset.ApplyMode{Concurrency: runtime.NumCPU()})
+	applyResult, err := set.Apply(ctx, resourcesToAdd, applyset.ApplyMode{
+		Concurrency: runtime.NumCPU(),
+		Recreate:    recreateDecision(deployer.Spec.RecreatePolicy),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to apply ApplySet: %w", err)
 	}
 
+	if recreated := applyResult.Recreated(); len(recreated) > 0 {
+		names := make([]string, 0, len(recreated))
+		for _, item := range recreated {
+			names = append(names, item.ID)
+		}
+		logger.Info("recreated objects because immutable fields changed", "objects", names)
+		event.New(r.EventRecorder, deployer, nil, deliveryv1alpha1.EventSeverityInfo,
+			"recreated %d object(s) because immutable fields changed: %s", len(names), strings.Join(names, ", "))
+		updateRecreatedObjectStatusReferences(recreated, deployer)
+	}
+
 	if applyResult.Errors() != nil {
 		return fmt.Errorf("errors occurred during ApplySet apply: %w", applyResult.Errors())
 	}
@@ -863,9 +1048,12 @@ func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1
 //
 // Behavior:
 //  1. Determines the GroupVersionKind (GVK) using the RESTMapper that is dynamically filled.
-//  2. If the object is namespaced but lacks a namespace, it defaults to "default" and logs the action.
-//  3. If the object's apiVersion is missing but the RESTMapper provides one, it applies that version.
-func (r *Reconciler) defaultObj(ctx context.Context, resource *deliveryv1alpha1.Resource, obj *unstructured.Unstructured) error {
+//  2. If the object is namespaced but lacks a namespace, it defaults to the target namespace of the deployer
+//     (or "default" if none is set) and logs the action.
+//  3. If the deployer is restricted to its target namespace, it rejects cluster-scoped objects and objects
+//     of other namespaces, see checkTargetNamespace.
+//  4. If the object's apiVersion is missing but the RESTMapper provides one, it applies that version.
+func (r *Reconciler) defaultObj(ctx context.Context, deployer *deliveryv1alpha1.Deployer, obj *unstructured.Unstructured) error {
 	logger := log.FromContext(ctx).WithValues(
 		"operation", "apply",
 		"gvk", obj.GetObjectKind().GroupVersionKind().String())
@@ -876,10 +1064,19 @@ func (r *Reconciler) defaultObj(ctx context.Context, resource *deliveryv1alpha1.
 	if err != nil {
 		return fmt.Errorf("failed to determine resource mapping: %w", err)
 	}
-	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && obj.GetNamespace() == "" {
-		// TODO(jakobmoellerdev) we can think of adding more namespacing options down the line
-		logger.Info("namespace will be defaulted", "defaultNamespace", resource.GetNamespace())
-		obj.SetNamespace(metav1.NamespaceDefault)
+	namespace := deployer.Spec.TargetNamespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	if namespaced && obj.GetNamespace() == "" {
+		logger.Info("namespace will be defaulted", "defaultNamespace", namespace)
+		obj.SetNamespace(namespace)
+	}
+	if deployer.Spec.RestrictToTargetNamespace {
+		if err := checkTargetNamespace(obj, namespaced, namespace); err != nil {
+			return err
+		}
 	}
 	if gvk.Version == "" && mapping.GroupVersionKind.Version != "" {
 		logger.Info("apiVersion will be defaulted to match discovered rest mapping", "defaultAPIVersion", mapping.GroupVersionKind.Version)
@@ -889,6 +1086,22 @@ func (r *Reconciler) defaultObj(ctx context.Context, resource *deliveryv1alpha1.
 	return nil
 }
 
+// checkTargetNamespace rejects objects a deployer restricted to its target namespace must not apply:
+// cluster-scoped objects, e.g. ClusterRoleBindings or CustomResourceDefinitions, and objects of other namespaces.
+// Otherwise, the restricted deployer would apply them with the permissions of the controller.
+func checkTargetNamespace(obj *unstructured.Unstructured, namespaced bool, targetNamespace string) error {
+	if !namespaced {
+		return fmt.Errorf("cluster-scoped %s %s is not allowed, the deployer is restricted to namespace %s",
+			obj.GetKind(), obj.GetName(), targetNamespace)
+	}
+	if obj.GetNamespace() != targetNamespace {
+		return fmt.Errorf("%s %s/%s is not allowed, the deployer is restricted to namespace %s",
+			obj.GetKind(), obj.GetNamespace(), obj.GetName(), targetNamespace)
+	}
+
+	return nil
+}
+
 // trackConcurrently tracks the objects for the deployer concurrently.
 //
 // See track for more details on how the objects are tracked.
@@ -979,12 +1192,16 @@ func (r *Reconciler) getEffectiveComponentDescriptor(
 		}
 	}
 
+	plugin := resolution.NewPluginSelection(deployer.GetNamespace(), component.Status.Component.Plugin, cfg)
+
 	verifiedOpts := resolution.RepositoryOptions{
-		RepositorySpec:  repoSpecComponent,
-		Configuration:   cfg,
-		SigningRegistry: r.PluginManager.SigningRegistry,
-		Verifications:   verifications,
-		RequesterFunc:   requesterFunc,
+		RepositorySpec:        repoSpecComponent,
+		Configuration:         cfg,
+		SigningRegistry:       r.PluginManager.SigningRegistry,
+		Verifications:         verifications,
+		VerificationThreshold: int(component.Spec.VerifyThreshold),
+		RequesterFunc:         requesterFunc,
+		Plugin:                plugin,
 	}
 
 	refPathOpts := resolution.RepositoryOptions{
@@ -992,6 +1209,7 @@ func (r *Reconciler) getEffectiveComponentDescriptor(
 		Configuration:   cfg,
 		SigningRegistry: r.PluginManager.SigningRegistry,
 		RequesterFunc:   requesterFunc,
+		Plugin:          plugin,
 	}
 
 	repoComponent, err := r.Resolver.NewCacheBackedRepository(ctx, &verifiedOpts)
@@ -1047,6 +1265,52 @@ func (r *Reconciler) getEffectiveComponentDescriptor(
 	return resourceDescriptor, err
 }
 
+// recreateDecision returns the decision whether to recreate an object whose immutable fields changed
+// according to the recreate policy and the RecreateOnImmutableChangeAnnotation of the object.
+func recreateDecision(policy deliveryv1alpha1.RecreatePolicy) func(obj *unstructured.Unstructured) bool {
+	return func(obj *unstructured.Unstructured) bool {
+		annotation := obj.GetAnnotations()[deliveryv1alpha1.RecreateOnImmutableChangeAnnotation]
+		switch policy {
+		case deliveryv1alpha1.RecreatePolicyAlways:
+			return annotation != "false"
+		case deliveryv1alpha1.RecreatePolicyIfAnnotated:
+			return annotation == "true"
+		default:
+			return false
+		}
+	}
+}
+
+// updateRecreatedObjectStatusReferences replaces the recreated objects in the status with the recreated items
+// of the last apply and removes the references to the deleted objects from the deployed objects.
+func updateRecreatedObjectStatusReferences(items []applyset.ApplyResultItem, deployer *deliveryv1alpha1.Deployer) {
+	now := metav1.Now()
+	recreated := make([]deliveryv1alpha1.RecreatedObjectReference, 0, len(items))
+	for _, item := range items {
+		deployer.Status.Deployed = slices.DeleteFunc(deployer.Status.Deployed, func(reference deliveryv1alpha1.DeployedObjectReference) bool {
+			return reference.UID == item.Recreated.PreviousUID
+		})
+
+		obj := item.Observed
+		if obj == nil {
+			obj = item.Desired
+		}
+		apiVersion, kind := obj.GroupVersionKind().ToAPIVersionAndKind()
+		recreated = append(recreated, deliveryv1alpha1.RecreatedObjectReference{
+			DeployedObjectReference: deliveryv1alpha1.DeployedObjectReference{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Name:       obj.GetName(),
+				Namespace:  obj.GetNamespace(),
+				UID:        obj.GetUID(),
+			},
+			RecreatedAt: now,
+			Reason:      item.Recreated.Reason,
+		})
+	}
+	deployer.Status.Recreated = recreated
+}
+
 func updateDeployedObjectStatusReferences[T client.Object](objs []T, deployer *deliveryv1alpha1.Deployer) {
 	for _, obj := range objs {
 		apiVersion, kind := obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
@@ -0,0 +1,175 @@
+package deployer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/applyset"
+	"ocm.software/open-component-model/kubernetes/controller/internal/event"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+)
+
+// maxDriftedObjectsInMessage limits the number of drifted objects listed in the Drifted condition.
+const maxDriftedObjectsInMessage = 10
+
+// objectsDigest returns a digest of the objects of the resources, which changes if any of the objects changes.
+func objectsDigest(resources []applyset.Resource) (string, error) {
+	hash := sha256.New()
+	for _, res := range resources {
+		data, err := json.Marshal(res.Object.Object)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal object %s/%s: %w", res.Object.GetNamespace(), res.Object.GetName(), err)
+		}
+		hash.Write(data)
+	}
+
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
+
+// detectDrift returns references to the deployed objects that drifted from the objects of the resources. The
+// resources are applied with a server-side dry-run, which yields the state the objects would have after applying
+// them. An object drifted if this state differs from its live state, or if it does not exist.
+func (r *Reconciler) detectDrift(ctx context.Context, deployer *deliveryv1alpha1.Deployer, resources []applyset.Resource) ([]deliveryv1alpha1.DeployedObjectReference, error) {
+	logger := log.FromContext(ctx)
+
+	live := make([]*unstructured.Unstructured, len(resources))
+	dryRun := make([]applyset.Resource, len(resources))
+	for i, res := range resources {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(res.Object.GroupVersionKind())
+		if err := r.Get(ctx, client.ObjectKeyFromObject(res.Object), obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get object %s/%s: %w", res.Object.GetNamespace(), res.Object.GetName(), err)
+			}
+			obj = nil
+		}
+		live[i] = obj
+		// the IDs of the resources are the names of the objects, which are not unique across kinds.
+		dryRun[i] = applyset.Resource{ID: strconv.Itoa(i), Object: res.Object.DeepCopy()}
+	}
+
+	result, err := r.createApplySet(deployer, logger).Apply(ctx, dryRun, applyset.ApplyMode{
+		Concurrency: runtime.NumCPU(),
+		DryRun:      true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply objects with dry-run: %w", err)
+	}
+
+	var errs []error
+	var driftedIndices []int
+	for _, item := range result.Applied {
+		if item.Error != nil {
+			errs = append(errs, item.Error)
+			continue
+		}
+		i, err := strconv.Atoi(item.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected dry-run result %q: %w", item.ID, err)
+		}
+		if live[i] == nil || !equality.Semantic.DeepEqual(driftRelevantFields(live[i]), driftRelevantFields(item.Observed)) {
+			driftedIndices = append(driftedIndices, i)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("failed to apply objects with dry-run: %w", err)
+	}
+
+	// the dry-run applies the objects concurrently, report drifted objects in the order of the resources.
+	slices.Sort(driftedIndices)
+	drifted := make([]deliveryv1alpha1.DeployedObjectReference, 0, len(driftedIndices))
+	for _, i := range driftedIndices {
+		obj := resources[i].Object
+		apiVersion, kind := obj.GroupVersionKind().ToAPIVersionAndKind()
+		ref := deliveryv1alpha1.DeployedObjectReference{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Name:       obj.GetName(),
+			Namespace:  obj.GetNamespace(),
+		}
+		if live[i] != nil {
+			ref.UID = live[i].GetUID()
+		}
+		drifted = append(drifted, ref)
+	}
+	logger.Info("detected drift of deployed objects", "drifted", len(drifted), "objects", len(resources))
+
+	return drifted, nil
+}
+
+// driftRelevantFields returns the content of the object without the fields that change whenever the object is
+// written or that are not set by the Deployer.
+func driftRelevantFields(obj *unstructured.Unstructured) map[string]any {
+	content := obj.DeepCopy().Object
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	unstructured.RemoveNestedField(content, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(content, "metadata", "generation")
+	unstructured.RemoveNestedField(content, "status")
+
+	return content
+}
+
+// setDriftCondition sets the Drifted condition of the Deployer for the drifted objects, which were re-applied
+// if corrected is true.
+func (r *Reconciler) setDriftCondition(deployer *deliveryv1alpha1.Deployer, drifted []deliveryv1alpha1.DeployedObjectReference, corrected bool) {
+	if len(drifted) == 0 {
+		status.SetCondition(deployer, metav1.Condition{
+			Type:    deliveryv1alpha1.DriftedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  deliveryv1alpha1.NoDriftReason,
+			Message: "deployed objects match the rendered objects",
+		})
+
+		return
+	}
+
+	var names []string
+	for _, ref := range drifted[:min(len(drifted), maxDriftedObjectsInMessage)] {
+		name := ref.Name
+		if ref.Namespace != "" {
+			name = ref.Namespace + "/" + name
+		}
+		names = append(names, ref.Kind+" "+name)
+	}
+	objects := strings.Join(names, ", ")
+	if len(drifted) > maxDriftedObjectsInMessage {
+		objects += fmt.Sprintf(" and %d more", len(drifted)-maxDriftedObjectsInMessage)
+	}
+
+	if corrected {
+		status.SetCondition(deployer, metav1.Condition{
+			Type:    deliveryv1alpha1.DriftedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  deliveryv1alpha1.DriftCorrectedReason,
+			Message: fmt.Sprintf("corrected drift of %d object(s): %s", len(drifted), objects),
+		})
+		event.New(r.EventRecorder, deployer, nil, deliveryv1alpha1.EventSeverityInfo,
+			"corrected drift of %d object(s): %s", len(drifted), objects)
+
+		return
+	}
+
+	status.SetCondition(deployer, metav1.Condition{
+		Type:    deliveryv1alpha1.DriftedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  deliveryv1alpha1.DriftDetectedReason,
+		Message: fmt.Sprintf("%d object(s) drifted: %s", len(drifted), objects),
+	})
+	event.New(r.EventRecorder, deployer, nil, deliveryv1alpha1.EventSeverityInfo,
+		"%d object(s) drifted: %s", len(drifted), objects)
+}
@@ -0,0 +1,124 @@
+package deployer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/applyset"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+)
+
+func configMapResource(name, value string) applyset.Resource {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": name, "namespace": "default"},
+		"data":       map[string]any{"key": value},
+	}}
+
+	return applyset.Resource{ID: name, Object: obj}
+}
+
+func TestObjectsDigest(t *testing.T) {
+	r := require.New(t)
+
+	digest, err := objectsDigest([]applyset.Resource{configMapResource("a", "1"), configMapResource("b", "1")})
+	r.NoError(err)
+	same, err := objectsDigest([]applyset.Resource{configMapResource("a", "1"), configMapResource("b", "1")})
+	r.NoError(err)
+	changed, err := objectsDigest([]applyset.Resource{configMapResource("a", "1"), configMapResource("b", "2")})
+	r.NoError(err)
+
+	r.Equal(digest, same)
+	r.NotEqual(digest, changed)
+}
+
+func TestDriftRelevantFields(t *testing.T) {
+	r := require.New(t)
+
+	live := configMapResource("a", "1").Object
+	live.SetResourceVersion("1")
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: applyset.FieldManager}})
+	r.NoError(unstructured.SetNestedField(live.Object, "Bound", "status", "phase"))
+	dryRun := configMapResource("a", "1").Object
+	dryRun.SetResourceVersion("2")
+
+	r.Equal(driftRelevantFields(live), driftRelevantFields(dryRun))
+	r.Equal("1", live.GetResourceVersion(), "the object is not modified")
+
+	r.NoError(unstructured.SetNestedField(dryRun.Object, "2", "data", "key"))
+	r.NotEqual(driftRelevantFields(live), driftRelevantFields(dryRun))
+}
+
+func TestSetDriftCondition(t *testing.T) {
+	drifted := []deliveryv1alpha1.DeployedObjectReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", Namespace: "apps"},
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "app"},
+	}
+	var many []deliveryv1alpha1.DeployedObjectReference
+	for i := range maxDriftedObjectsInMessage + 2 {
+		many = append(many, deliveryv1alpha1.DeployedObjectReference{Kind: "ConfigMap", Name: fmt.Sprintf("cm-%d", i)})
+	}
+
+	tests := []struct {
+		name      string
+		drifted   []deliveryv1alpha1.DeployedObjectReference
+		corrected bool
+		status    metav1.ConditionStatus
+		reason    string
+		message   string
+	}{
+		{
+			name:    "no drift",
+			status:  metav1.ConditionFalse,
+			reason:  deliveryv1alpha1.NoDriftReason,
+			message: "deployed objects match the rendered objects",
+		},
+		{
+			name:      "corrected",
+			drifted:   drifted,
+			corrected: true,
+			status:    metav1.ConditionFalse,
+			reason:    deliveryv1alpha1.DriftCorrectedReason,
+			message:   "corrected drift of 2 object(s): Deployment apps/app, ClusterRole app",
+		},
+		{
+			name:    "reported",
+			drifted: drifted,
+			status:  metav1.ConditionTrue,
+			reason:  deliveryv1alpha1.DriftDetectedReason,
+			message: "2 object(s) drifted: Deployment apps/app, ClusterRole app",
+		},
+		{
+			name:    "truncated",
+			drifted: many,
+			status:  metav1.ConditionTrue,
+			reason:  deliveryv1alpha1.DriftDetectedReason,
+			message: "12 object(s) drifted: ConfigMap cm-0, ConfigMap cm-1, ConfigMap cm-2, ConfigMap cm-3, " +
+				"ConfigMap cm-4, ConfigMap cm-5, ConfigMap cm-6, ConfigMap cm-7, ConfigMap cm-8, ConfigMap cm-9 and 2 more",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			reconciler := &Reconciler{BaseReconciler: &ocm.BaseReconciler{EventRecorder: record.NewFakeRecorder(8)}}
+			deployer := &deliveryv1alpha1.Deployer{}
+
+			reconciler.setDriftCondition(deployer, tt.drifted, tt.corrected)
+
+			condition := status.FindCondition(deployer, deliveryv1alpha1.DriftedCondition)
+			r.NotNil(condition)
+			r.Equal(tt.status, condition.Status)
+			r.Equal(tt.reason, condition.Reason)
+			r.Equal(tt.message, condition.Message)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/looseref"
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	ocmfunctions "ocm.software/open-component-model/kubernetes/controller/internal/cel/functions"
+)
+
+const (
+	// helmChartResourceType is the type of resources containing a Helm chart.
+	helmChartResourceType = "helmChart"
+	// helmChartContentMediaType is the media type of the layer of OCI artifacts that contains the Helm chart.
+	helmChartContentMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+
+	fluxOCIRepositoryAPIVersion = "source.toolkit.fluxcd.io/v1"
+	fluxOCIRepositoryKind       = "OCIRepository"
+	fluxHelmReleaseAPIVersion   = "helm.toolkit.fluxcd.io/v2"
+	fluxHelmReleaseKind         = "HelmRelease"
+
+	defaultHelmInterval = 10 * time.Minute
+)
+
+// isHelmChart returns true if the resource is deployed as Helm chart through Flux instead of applying its content.
+func isHelmChart(resource *descriptor.Resource) bool {
+	return resource.Type == helmChartResourceType
+}
+
+// helmReleaseObjects returns a Flux OCIRepository referencing the Helm chart of the resource and a Flux HelmRelease
+// installing it. Both are named like the Deployer and created in the namespace of the Deployer, the chart is
+// installed into the target namespace of the Deployer.
+func helmReleaseObjects(
+	deployer *deliveryv1alpha1.Deployer,
+	resource *descriptor.Resource,
+	component *deliveryv1alpha1.ComponentInfo,
+) ([]*unstructured.Unstructured, error) {
+	if resource.Access == nil {
+		return nil, fmt.Errorf("helm chart resource %s has no access", resource.Name)
+	}
+	imageReference, err := ocmfunctions.AccessImageReference(resource.Access, component)
+	if err != nil {
+		return nil, fmt.Errorf("helm chart resource %s is not stored in an OCI registry: %w", resource.Name, err)
+	}
+	ref, err := looseref.ParseReference(imageReference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OCI reference %q of helm chart resource %s: %w", imageReference, resource.Name, err)
+	}
+
+	helm := deployer.Spec.Helm
+	if helm == nil {
+		helm = &deliveryv1alpha1.HelmDeployment{}
+	}
+	interval := metav1.Duration{Duration: defaultHelmInterval}
+	if helm.Interval != nil {
+		interval = *helm.Interval
+	}
+	releaseName := helm.ReleaseName
+	if releaseName == "" {
+		releaseName = deployer.GetName()
+	}
+
+	// prefer the digest over the tag, so that Flux deploys exactly the chart of the component version.
+	chartRef := map[string]any{}
+	if dig, err := ref.Digest(); err == nil {
+		chartRef["digest"] = dig.String()
+	} else if ref.Tag != "" {
+		chartRef["tag"] = ref.Tag
+	} else {
+		return nil, fmt.Errorf("OCI reference %q of helm chart resource %s has neither a tag nor a digest", imageReference, resource.Name)
+	}
+
+	ociRepositorySpec := map[string]any{
+		"interval": interval.Duration.String(),
+		"url":      "oci://" + ref.Host() + "/" + strings.TrimLeft(ref.Repository, "/"),
+		"ref":      chartRef,
+		"layerSelector": map[string]any{
+			"mediaType": helmChartContentMediaType,
+			"operation": "copy",
+		},
+	}
+	if ref.Scheme == "http" {
+		ociRepositorySpec["insecure"] = true
+	}
+	if helm.SecretRef != nil {
+		ociRepositorySpec["secretRef"] = map[string]any{"name": helm.SecretRef.Name}
+	}
+
+	helmReleaseSpec := map[string]any{
+		"interval":    interval.Duration.String(),
+		"releaseName": releaseName,
+		"chartRef": map[string]any{
+			"kind":      fluxOCIRepositoryKind,
+			"name":      deployer.GetName(),
+			"namespace": deployer.GetNamespace(),
+		},
+	}
+	if deployer.Spec.TargetNamespace != "" {
+		helmReleaseSpec["targetNamespace"] = deployer.Spec.TargetNamespace
+	}
+	if helm.Values != nil && len(helm.Values.Raw) > 0 {
+		var values map[string]any
+		if err := json.Unmarshal(helm.Values.Raw, &values); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal helm values: %w", err)
+		}
+		helmReleaseSpec["values"] = values
+	}
+
+	return []*unstructured.Unstructured{
+		fluxObject(fluxOCIRepositoryAPIVersion, fluxOCIRepositoryKind, deployer, ociRepositorySpec),
+		fluxObject(fluxHelmReleaseAPIVersion, fluxHelmReleaseKind, deployer, helmReleaseSpec),
+	}, nil
+}
+
+func fluxObject(apiVersion, kind string, deployer *deliveryv1alpha1.Deployer, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(deployer.GetName())
+	obj.SetNamespace(deployer.GetNamespace())
+
+	return obj
+}
@@ -0,0 +1,136 @@
+package deployer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+func helmChartResource(access string) *descriptor.Resource {
+	return &descriptor.Resource{
+		ElementMeta: descriptor.ElementMeta{
+			ObjectMeta: descriptor.ObjectMeta{Name: "chart", Version: "1.0.0"},
+		},
+		Type:     helmChartResourceType,
+		Relation: descriptor.ExternalRelation,
+		Access:   &ocmruntime.Raw{Type: ocmruntime.NewVersionedType("ociArtifact", "v1"), Data: []byte(access)},
+	}
+}
+
+func TestHelmReleaseObjects(t *testing.T) {
+	const digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	component := &deliveryv1alpha1.ComponentInfo{
+		RepositorySpec: &apiextensionsv1.JSON{Raw: []byte(`{"type":"OCIRepository/v1","baseUrl":"https://ghcr.io","subPath":"acme"}`)},
+		Component:      "acme.org/app",
+		Version:        "1.0.0",
+	}
+
+	tests := []struct {
+		name                  string
+		resource              *descriptor.Resource
+		helm                  *deliveryv1alpha1.HelmDeployment
+		expectedOCIRepository map[string]any
+		expectedHelmRelease   map[string]any
+		err                   string
+	}{
+		{
+			name:     "defaults",
+			resource: helmChartResource(`{"type":"ociArtifact/v1","imageReference":"ghcr.io/acme/charts/app:1.0.0"}`),
+			expectedOCIRepository: map[string]any{
+				"interval":      "10m0s",
+				"url":           "oci://ghcr.io/acme/charts/app",
+				"ref":           map[string]any{"tag": "1.0.0"},
+				"layerSelector": map[string]any{"mediaType": helmChartContentMediaType, "operation": "copy"},
+			},
+			expectedHelmRelease: map[string]any{
+				"interval":        "10m0s",
+				"releaseName":     "app",
+				"targetNamespace": "apps",
+				"chartRef":        map[string]any{"kind": "OCIRepository", "name": "app", "namespace": "default"},
+			},
+		},
+		{
+			name:     "configured release with digest",
+			resource: helmChartResource(`{"type":"ociArtifact/v1","imageReference":"ghcr.io/acme/charts/app:1.0.0@` + digest + `"}`),
+			helm: &deliveryv1alpha1.HelmDeployment{
+				ReleaseName: "release",
+				Interval:    &metav1.Duration{Duration: time.Minute},
+				Values:      &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2,"image":{"repository":"ghcr.io/acme/app"}}`)},
+				SecretRef:   &corev1.LocalObjectReference{Name: "pull-secret"},
+			},
+			expectedOCIRepository: map[string]any{
+				"interval":      "1m0s",
+				"url":           "oci://ghcr.io/acme/charts/app",
+				"ref":           map[string]any{"digest": digest},
+				"layerSelector": map[string]any{"mediaType": helmChartContentMediaType, "operation": "copy"},
+				"secretRef":     map[string]any{"name": "pull-secret"},
+			},
+			expectedHelmRelease: map[string]any{
+				"interval":        "1m0s",
+				"releaseName":     "release",
+				"targetNamespace": "apps",
+				"chartRef":        map[string]any{"kind": "OCIRepository", "name": "app", "namespace": "default"},
+				"values": map[string]any{
+					"replicas": int64(2),
+					"image":    map[string]any{"repository": "ghcr.io/acme/app"},
+				},
+			},
+		},
+		{
+			name:     "neither tag nor digest",
+			resource: helmChartResource(`{"type":"ociArtifact/v1","imageReference":"ghcr.io/acme/charts/app"}`),
+			err:      "has neither a tag nor a digest",
+		},
+		{
+			name: "not stored in an OCI registry",
+			resource: &descriptor.Resource{
+				ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "chart", Version: "1.0.0"}},
+				Type:        helmChartResourceType,
+				Access: &ocmruntime.Raw{
+					Type: ocmruntime.NewVersionedType("helm", "v1"),
+					Data: []byte(`{"type":"helm/v1","helmRepository":"https://charts.acme.org","helmChart":"app:1.0.0"}`),
+				},
+			},
+			err: "is not stored in an OCI registry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			deployer := &deliveryv1alpha1.Deployer{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec:       deliveryv1alpha1.DeployerSpec{TargetNamespace: "apps", Helm: tt.helm},
+			}
+			r.True(isHelmChart(tt.resource))
+
+			objs, err := helmReleaseObjects(deployer, tt.resource, component)
+			if tt.err != "" {
+				r.ErrorContains(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			r.Len(objs, 2)
+
+			r.Equal(fluxOCIRepositoryAPIVersion, objs[0].GetAPIVersion())
+			r.Equal(fluxOCIRepositoryKind, objs[0].GetKind())
+			r.Equal("app", objs[0].GetName())
+			r.Equal("default", objs[0].GetNamespace())
+			r.Equal(tt.expectedOCIRepository, objs[0].Object["spec"])
+
+			r.Equal(fluxHelmReleaseAPIVersion, objs[1].GetAPIVersion())
+			r.Equal(fluxHelmReleaseKind, objs[1].GetKind())
+			r.Equal("app", objs[1].GetName())
+			r.Equal("default", objs[1].GetNamespace())
+			r.Equal(tt.expectedHelmRelease, objs[1].Object["spec"])
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package deployer
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+const (
+	// kustomizeResourceDir is the directory of the in-memory file system the resource is extracted to.
+	kustomizeResourceDir = "/resource"
+	// kustomizeOverlayDir is the directory of the in-memory file system of the generated kustomization
+	// containing the patches of the Deployer.
+	kustomizeOverlayDir = "/overlay"
+)
+
+// kustomizationFileNames are the names of the files kustomize recognizes as kustomization.
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// errKustomizeFailed marks errors of rendering a resource with kustomize, so that they can be told apart from
+// errors of downloading the resource.
+var errKustomizeFailed = errors.New("failed to render resource with kustomize")
+
+// kustomizationHash returns a hash of the kustomization, which is part of the cache key of the rendered objects.
+func kustomizationHash(kustomization *deliveryv1alpha1.Kustomization) (string, error) {
+	data, err := json.Marshal(kustomization)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal kustomization: %w", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// renderKustomization extracts the resource, a tar archive that is optionally gzip compressed, into an in-memory
+// file system and runs a kustomize build on the directory of the kustomization. If the directory has no
+// kustomization file, one is generated that includes the YAML and JSON files of the directory. The patches of the
+// kustomization are applied through an overlay on top of it.
+func renderKustomization(manifest io.Reader, kustomization *deliveryv1alpha1.Kustomization) ([]*unstructured.Unstructured, error) {
+	objs, err := kustomize(manifest, kustomization)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errKustomizeFailed, err)
+	}
+
+	return objs, nil
+}
+
+func kustomize(manifest io.Reader, kustomization *deliveryv1alpha1.Kustomization) ([]*unstructured.Unstructured, error) {
+	if hasParentReference(kustomization.Path) {
+		return nil, fmt.Errorf("path %q must not reference parent directories", kustomization.Path)
+	}
+
+	fSys := filesys.MakeFsInMemory()
+	if err := extractTar(manifest, fSys, kustomizeResourceDir); err != nil {
+		return nil, err
+	}
+
+	dir := path.Join(kustomizeResourceDir, kustomization.Path)
+	if err := ensureKustomization(fSys, dir); err != nil {
+		return nil, err
+	}
+
+	if len(kustomization.Patches) > 0 {
+		if err := writeKustomization(fSys, kustomizeOverlayDir, map[string]any{
+			"resources": []string{".." + dir},
+			"patches":   kustomization.Patches,
+		}); err != nil {
+			return nil, err
+		}
+		dir = kustomizeOverlayDir
+	}
+
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build failed: %w", err)
+	}
+	rendered, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rendered objects: %w", err)
+	}
+
+	return decodeObjectsFromManifest(io.NopCloser(bytes.NewReader(rendered)))
+}
+
+// extractTar extracts the directories and regular files of the tar archive into dir. Other entries, e.g. symbolic
+// links, are skipped.
+func extractTar(r io.Reader, fSys filesys.FileSystem, dir string) error {
+	buffered := bufio.NewReader(r)
+	r = buffered
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return fmt.Errorf("failed to decompress resource: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	if err := fSys.MkdirAll(dir); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read resource as tar archive: %w", err)
+		}
+		if hasParentReference(header.Name) {
+			return fmt.Errorf("tar entry %q must not reference parent directories", header.Name)
+		}
+
+		target := path.Join(dir, header.Name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := fSys.MkdirAll(target); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", header.Name, err)
+			}
+		case tar.TypeReg:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("failed to read tar entry %s: %w", header.Name, err)
+			}
+			if err := fSys.MkdirAll(path.Dir(target)); err != nil {
+				return fmt.Errorf("failed to create directory of %s: %w", header.Name, err)
+			}
+			if err := fSys.WriteFile(target, data); err != nil {
+				return fmt.Errorf("failed to write %s: %w", header.Name, err)
+			}
+		}
+	}
+}
+
+// ensureKustomization generates a kustomization file including all YAML and JSON files of dir,
+// unless dir already contains one.
+func ensureKustomization(fSys filesys.FileSystem, dir string) error {
+	for _, name := range kustomizationFileNames {
+		if fSys.Exists(path.Join(dir, name)) {
+			return nil
+		}
+	}
+	if !fSys.IsDir(dir) {
+		return fmt.Errorf("path %s does not exist in the resource", strings.TrimPrefix(dir, kustomizeResourceDir))
+	}
+
+	entries, err := fSys.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	var resources []string
+	for _, entry := range entries {
+		if fSys.IsDir(path.Join(dir, entry)) {
+			continue
+		}
+		switch path.Ext(entry) {
+		case ".yaml", ".yml", ".json":
+			resources = append(resources, entry)
+		}
+	}
+	if len(resources) == 0 {
+		return fmt.Errorf("no kustomization and no manifests found in path %s of the resource",
+			strings.TrimPrefix(dir, kustomizeResourceDir))
+	}
+	slices.Sort(resources)
+
+	return writeKustomization(fSys, dir, map[string]any{"resources": resources})
+}
+
+// writeKustomization writes the kustomization as JSON, which is valid YAML, into dir.
+func writeKustomization(fSys filesys.FileSystem, dir string, kustomization map[string]any) error {
+	kustomization["apiVersion"] = "kustomize.config.k8s.io/v1beta1"
+	kustomization["kind"] = "Kustomization"
+	data, err := json.Marshal(kustomization)
+	if err != nil {
+		return fmt.Errorf("failed to marshal kustomization: %w", err)
+	}
+	if err := fSys.MkdirAll(dir); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	return fSys.WriteFile(path.Join(dir, kustomizationFileNames[0]), data)
+}
+
+func hasParentReference(p string) bool {
+	return slices.Contains(strings.Split(p, "/"), "..")
+}
@@ -0,0 +1,201 @@
+package deployer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+const (
+	kustomizeTestDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: ghcr.io/acme/app:1.0.0
+`
+	kustomizeTestConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  level: info
+`
+)
+
+type tarEntry struct {
+	name, content string
+}
+
+func tarball(t *testing.T, compress bool, entries ...tarEntry) []byte {
+	t.Helper()
+	r := require.New(t)
+
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	for _, entry := range entries {
+		r.NoError(tw.WriteHeader(&tar.Header{Name: entry.name, Mode: 0o644, Size: int64(len(entry.content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(entry.content))
+		r.NoError(err)
+	}
+	r.NoError(tw.Close())
+	if gz != nil {
+		r.NoError(gz.Close())
+	}
+
+	return buf.Bytes()
+}
+
+func objectByKind(t *testing.T, objs []*unstructured.Unstructured, kind string) *unstructured.Unstructured {
+	t.Helper()
+	for _, obj := range objs {
+		if obj.GetKind() == kind {
+			return obj
+		}
+	}
+	require.Failf(t, "object not found", "no object of kind %s rendered", kind)
+
+	return nil
+}
+
+func TestRenderKustomization(t *testing.T) {
+	tests := []struct {
+		name          string
+		archive       []byte
+		kustomization *deliveryv1alpha1.Kustomization
+		assert        func(t *testing.T, objs []*unstructured.Unstructured)
+		err           string
+	}{
+		{
+			name: "generated kustomization",
+			archive: tarball(t, false,
+				tarEntry{"deployment.yaml", kustomizeTestDeployment},
+				tarEntry{"configmap.yml", kustomizeTestConfigMap},
+				tarEntry{"README.md", "# App"},
+				tarEntry{"nested/ignored.yaml", kustomizeTestConfigMap},
+			),
+			kustomization: &deliveryv1alpha1.Kustomization{},
+			assert: func(t *testing.T, objs []*unstructured.Unstructured) {
+				require.Len(t, objs, 2)
+			},
+		},
+		{
+			name: "kustomization in path with patches",
+			archive: tarball(t, true,
+				tarEntry{"deploy/base/deployment.yaml", kustomizeTestDeployment},
+				tarEntry{"deploy/base/configmap.yaml", kustomizeTestConfigMap},
+				tarEntry{"deploy/base/kustomization.yaml", `resources:
+- deployment.yaml
+- configmap.yaml
+commonAnnotations:
+  team: acme
+`},
+			),
+			kustomization: &deliveryv1alpha1.Kustomization{
+				Path: "deploy/base",
+				Patches: []deliveryv1alpha1.KustomizePatch{
+					{Patch: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 3
+`},
+					{
+						Patch:  `[{"op": "replace", "path": "/data/level", "value": "debug"}]`,
+						Target: &deliveryv1alpha1.KustomizeSelector{Kind: "ConfigMap", Name: "app-.*"},
+					},
+				},
+			},
+			assert: func(t *testing.T, objs []*unstructured.Unstructured) {
+				r := require.New(t)
+				r.Len(objs, 2)
+
+				deployment := objectByKind(t, objs, "Deployment")
+				replicas, _, err := unstructured.NestedInt64(deployment.Object, "spec", "replicas")
+				r.NoError(err)
+				r.EqualValues(3, replicas)
+				r.Equal("acme", deployment.GetAnnotations()["team"])
+
+				configMap := objectByKind(t, objs, "ConfigMap")
+				level, _, err := unstructured.NestedString(configMap.Object, "data", "level")
+				r.NoError(err)
+				r.Equal("debug", level)
+			},
+		},
+		{
+			name:          "path traversal in archive",
+			archive:       tarball(t, false, tarEntry{"../deployment.yaml", kustomizeTestDeployment}),
+			kustomization: &deliveryv1alpha1.Kustomization{},
+			err:           `tar entry "../deployment.yaml" must not reference parent directories`,
+		},
+		{
+			name:          "path traversal in path",
+			archive:       tarball(t, false, tarEntry{"deployment.yaml", kustomizeTestDeployment}),
+			kustomization: &deliveryv1alpha1.Kustomization{Path: "deploy/../.."},
+			err:           `path "deploy/../.." must not reference parent directories`,
+		},
+		{
+			name:          "missing path",
+			archive:       tarball(t, false, tarEntry{"deployment.yaml", kustomizeTestDeployment}),
+			kustomization: &deliveryv1alpha1.Kustomization{Path: "deploy"},
+			err:           "path /deploy does not exist in the resource",
+		},
+		{
+			name:          "no manifests",
+			archive:       tarball(t, false, tarEntry{"README.md", "# App"}),
+			kustomization: &deliveryv1alpha1.Kustomization{},
+			err:           "no kustomization and no manifests found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			objs, err := renderKustomization(bytes.NewReader(tt.archive), tt.kustomization)
+			if tt.err != "" {
+				r.ErrorIs(err, errKustomizeFailed)
+				r.ErrorContains(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			tt.assert(t, objs)
+		})
+	}
+}
+
+func TestKustomizationHash(t *testing.T) {
+	r := require.New(t)
+
+	base, err := kustomizationHash(&deliveryv1alpha1.Kustomization{Path: "deploy"})
+	r.NoError(err)
+	same, err := kustomizationHash(&deliveryv1alpha1.Kustomization{Path: "deploy"})
+	r.NoError(err)
+	patched, err := kustomizationHash(&deliveryv1alpha1.Kustomization{
+		Path:    "deploy",
+		Patches: []deliveryv1alpha1.KustomizePatch{{Patch: `{"kind":"Deployment"}`}},
+	})
+	r.NoError(err)
+
+	r.Equal(base, same)
+	r.NotEqual(base, patched)
+}
@@ -0,0 +1,231 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	ocmcel "ocm.software/open-component-model/kubernetes/controller/internal/cel"
+	celconv "ocm.software/open-component-model/kubernetes/controller/internal/controller/resource/conversion"
+)
+
+// localize substitutes the values of the localizations into the objects before they are applied.
+// The objects are shared through the download cache, so the localized objects are deep copies.
+// If there are no localizations, the objects are returned as they are.
+// Every localization value is evaluated within the given limits.
+func localize(
+	ctx context.Context,
+	localizations []deliveryv1alpha1.Localization,
+	desc *descriptor.Descriptor,
+	res *descriptor.Resource,
+	component *deliveryv1alpha1.ComponentInfo,
+	objs []*unstructured.Unstructured,
+	limits ocmcel.Limits,
+) ([]*unstructured.Unstructured, error) {
+	if len(localizations) == 0 {
+		return objs, nil
+	}
+
+	env, vars, err := localizationEnv(desc, res, component)
+	if err != nil {
+		return nil, err
+	}
+
+	localized := make([]*unstructured.Unstructured, len(objs))
+	for i, obj := range objs {
+		localized[i] = obj.DeepCopy()
+	}
+
+	for i, localization := range localizations {
+		value, err := evalLocalization(ctx, env, vars, localization.Value, limits)
+		if err != nil {
+			return nil, fmt.Errorf("localization %d: %w", i, err)
+		}
+
+		matched := false
+		for _, obj := range localized {
+			if !matchesLocalizationTarget(localization.Target, obj) {
+				continue
+			}
+			matched = true
+			// every object gets its own copy of the value, as values are modified in place by later localizations.
+			if err := setJSONPointer(obj.Object, localization.Path, k8sruntime.DeepCopyJSONValue(value)); err != nil {
+				return nil, fmt.Errorf("localization %d: failed to set %s in %s %s: %w",
+					i, localization.Path, obj.GetKind(), obj.GetName(), err)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("localization %d: no deployed object matches %s %s",
+				i, localization.Target.Kind, localization.Target.Name)
+		}
+	}
+
+	return localized, nil
+}
+
+// localizationEnv returns the CEL environment and the variables localizations are evaluated against.
+func localizationEnv(
+	desc *descriptor.Descriptor,
+	res *descriptor.Resource,
+	component *deliveryv1alpha1.ComponentInfo,
+) (*cel.Env, map[string]any, error) {
+	env, err := ocmcel.ComponentInfoEnv(component)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base CEL env: %w", err)
+	}
+	env, err = env.Extend(
+		cel.Variable("component", cel.DynType),
+		cel.Variable("resources", cel.DynType),
+		cel.Variable("resource", cel.DynType),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to extend CEL env: %w", err)
+	}
+
+	scheme := ocmruntime.NewScheme(ocmruntime.WithAllowUnknown())
+	descV2, err := descriptor.ConvertToV2(scheme, desc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert component descriptor to v2: %w", err)
+	}
+	resV2, err := descriptor.ConvertToV2Resource(scheme, res)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert resource to v2: %w", err)
+	}
+
+	var componentMap, resourceMap map[string]any
+	if err := convertViaJSON(descV2.Component, &componentMap); err != nil {
+		return nil, nil, fmt.Errorf("failed to prepare CEL variable component: %w", err)
+	}
+	if err := convertViaJSON(resV2, &resourceMap); err != nil {
+		return nil, nil, fmt.Errorf("failed to prepare CEL variable resource: %w", err)
+	}
+
+	resources := make(map[string]any, len(descV2.Component.Resources))
+	for _, r := range descV2.Component.Resources {
+		if _, ok := resources[r.Name]; ok {
+			// resources that only differ in their extra identity share their name, the first one wins.
+			continue
+		}
+		var m map[string]any
+		if err := convertViaJSON(r, &m); err != nil {
+			return nil, nil, fmt.Errorf("failed to prepare CEL variable resources: %w", err)
+		}
+		resources[r.Name] = m
+	}
+
+	return env, map[string]any{
+		"component": componentMap,
+		"resources": resources,
+		"resource":  resourceMap,
+	}, nil
+}
+
+// evalLocalization compiles and evaluates the CEL expression of a localization.
+// The result is converted into a value that can be stored in unstructured objects.
+func evalLocalization(ctx context.Context, env *cel.Env, vars map[string]any, expr string, limits ocmcel.Limits) (any, error) {
+	val, err := ocmcel.Evaluate(ctx, env, ocmcel.KindLocalization, expr, vars, limits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate CEL expression %q: %w", expr, err)
+	}
+	native, err := celconv.GoNativeType(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert result of CEL expression %q: %w", expr, err)
+	}
+
+	// unstructured objects only hold JSON compatible values, e.g. int64 instead of uint64
+	// and base64 encoded strings instead of bytes.
+	var value any
+	if err := convertViaJSON(native, &value); err != nil {
+		return nil, fmt.Errorf("failed to convert result of CEL expression %q: %w", expr, err)
+	}
+
+	return value, nil
+}
+
+func matchesLocalizationTarget(target deliveryv1alpha1.LocalizationTarget, obj *unstructured.Unstructured) bool {
+	return obj.GetKind() == target.Kind &&
+		obj.GetName() == target.Name &&
+		(target.APIVersion == "" || obj.GetAPIVersion() == target.APIVersion) &&
+		(target.Namespace == "" || obj.GetNamespace() == target.Namespace)
+}
+
+// setJSONPointer sets the value at the JSON pointer (RFC 6901) in obj.
+// Missing objects along the pointer are created, list elements must exist.
+func setJSONPointer(obj map[string]any, pointer string, value any) error {
+	if !strings.HasPrefix(pointer, "/") {
+		return fmt.Errorf("JSON pointer %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	var current any = obj
+	for i, token := range tokens {
+		last := i == len(tokens)-1
+		switch node := current.(type) {
+		case map[string]any:
+			if last {
+				node[token] = value
+
+				return nil
+			}
+			next, ok := node[token]
+			if !ok || next == nil {
+				next = map[string]any{}
+				node[token] = next
+			}
+			current = next
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return fmt.Errorf("index %q at %s is not an element of the list", token, joinJSONPointer(tokens[:i]))
+			}
+			if last {
+				node[index] = value
+
+				return nil
+			}
+			current = node[index]
+		default:
+			return fmt.Errorf("%s is neither an object nor a list", joinJSONPointer(tokens[:i]))
+		}
+	}
+
+	return nil
+}
+
+func joinJSONPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+
+	return b.String()
+}
+
+// convertViaJSON converts v into out through its JSON representation.
+func convertViaJSON(v any, out any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package deployer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	ocmcel "ocm.software/open-component-model/kubernetes/controller/internal/cel"
+)
+
+func localizationTestDescriptor() *descriptor.Descriptor {
+	return &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{
+				ObjectMeta: descriptor.ObjectMeta{Name: "acme.org/app", Version: "1.0.0"},
+			},
+			Provider: descriptor.Provider{Name: "acme.org"},
+			Resources: []descriptor.Resource{
+				{
+					ElementMeta: descriptor.ElementMeta{
+						ObjectMeta: descriptor.ObjectMeta{Name: "manifests", Version: "1.0.0"},
+					},
+					Type:     "blob",
+					Relation: descriptor.LocalRelation,
+					Access: &ocmruntime.Raw{
+						Type: ocmruntime.NewVersionedType("localBlob", "v1"),
+						Data: []byte(`{"type":"localBlob/v1","localReference":"sha256:abc","mediaType":"application/x-yaml"}`),
+					},
+				},
+				{
+					ElementMeta: descriptor.ElementMeta{
+						ObjectMeta: descriptor.ObjectMeta{Name: "image", Version: "1.0.0"},
+					},
+					Type:     "ociImage",
+					Relation: descriptor.ExternalRelation,
+					Access: &ocmruntime.Raw{
+						Type: ocmruntime.NewVersionedType("ociArtifact", "v1"),
+						Data: []byte(`{"type":"ociArtifact/v1","imageReference":"ghcr.io/acme/app:1.0.0"}`),
+					},
+				},
+			},
+		},
+	}
+}
+
+func localizationTestObjects() []*unstructured.Unstructured {
+	return []*unstructured.Unstructured{
+		{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": "app", "namespace": "default"},
+			"spec": map[string]any{
+				"template": map[string]any{
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{"name": "app", "image": "placeholder"},
+						},
+					},
+				},
+			},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "app-config", "namespace": "default"},
+		}},
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	desc := localizationTestDescriptor()
+	component := &deliveryv1alpha1.ComponentInfo{
+		RepositorySpec: &apiextensionsv1.JSON{Raw: []byte(`{"type":"OCIRepository/v1","baseUrl":"https://ghcr.io","subPath":"acme"}`)},
+		Component:      "acme.org/app",
+		Version:        "1.0.0",
+	}
+
+	tests := []struct {
+		name          string
+		localizations []deliveryv1alpha1.Localization
+		path          []string
+		expected      any
+		err           string
+	}{
+		{
+			name: "image reference of a resource",
+			localizations: []deliveryv1alpha1.Localization{{
+				Target: deliveryv1alpha1.LocalizationTarget{Kind: "Deployment", Name: "app"},
+				Path:   "/spec/template/spec/containers/0/image",
+				Value:  `resources["image"].access.imageReference`,
+			}},
+			path:     []string{"spec", "template", "spec", "containers"},
+			expected: []any{map[string]any{"name": "app", "image": "ghcr.io/acme/app:1.0.0"}},
+		},
+		{
+			name: "tag of an OCI access",
+			localizations: []deliveryv1alpha1.Localization{{
+				Target: deliveryv1alpha1.LocalizationTarget{APIVersion: "v1", Kind: "ConfigMap", Name: "app-config"},
+				Path:   "/data/tag",
+				Value:  `resources["image"].access.toOCI().tag`,
+			}},
+			path:     []string{"data", "tag"},
+			expected: "1.0.0",
+		},
+		{
+			name: "escaped keys and structured values",
+			localizations: []deliveryv1alpha1.Localization{{
+				Target: deliveryv1alpha1.LocalizationTarget{Kind: "ConfigMap", Name: "app-config", Namespace: "default"},
+				Path:   "/metadata/annotations/acme.org~1component",
+				Value:  `{"name": component.name, "resource": resource.name, "replicas": 1 + 2}`,
+			}},
+			path:     []string{"metadata", "annotations", "acme.org/component"},
+			expected: map[string]any{"name": "acme.org/app", "resource": "manifests", "replicas": int64(3)},
+		},
+		{
+			name: "no matching object",
+			localizations: []deliveryv1alpha1.Localization{{
+				Target: deliveryv1alpha1.LocalizationTarget{Kind: "ConfigMap", Name: "app-config", Namespace: "other"},
+				Path:   "/data/tag",
+				Value:  `"1.0.0"`,
+			}},
+			err: "no deployed object matches ConfigMap app-config",
+		},
+		{
+			name: "list element does not exist",
+			localizations: []deliveryv1alpha1.Localization{{
+				Target: deliveryv1alpha1.LocalizationTarget{Kind: "Deployment", Name: "app"},
+				Path:   "/spec/template/spec/containers/1/image",
+				Value:  `"image"`,
+			}},
+			err: `index "1" at /spec/template/spec/containers is not an element of the list`,
+		},
+		{
+			name: "invalid expression",
+			localizations: []deliveryv1alpha1.Localization{{
+				Target: deliveryv1alpha1.LocalizationTarget{Kind: "Deployment", Name: "app"},
+				Path:   "/spec/replicas",
+				Value:  `resources["image"].unknown`,
+			}},
+			err: "failed to evaluate CEL expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			objs := localizationTestObjects()
+
+			localized, err := localize(t.Context(), tt.localizations, desc, &desc.Component.Resources[0], component, objs, ocmcel.Limits{})
+			if tt.err != "" {
+				r.ErrorContains(err, tt.err)
+				return
+			}
+			r.NoError(err)
+			r.Len(localized, len(objs))
+
+			var actual any
+			for _, obj := range localized {
+				if obj.GetKind() != tt.localizations[0].Target.Kind {
+					continue
+				}
+				value, found, err := unstructured.NestedFieldNoCopy(obj.Object, tt.path...)
+				r.NoError(err)
+				r.True(found)
+				actual = value
+			}
+			r.Equal(tt.expected, actual)
+
+			// the objects passed in are shared through the download cache and must not be modified.
+			r.Equal(localizationTestObjects(), objs)
+		})
+	}
+}
+
+func TestLocalize_WithoutLocalizations(t *testing.T) {
+	r := require.New(t)
+	objs := localizationTestObjects()
+
+	localized, err := localize(t.Context(), nil, nil, nil, nil, objs, ocmcel.Limits{})
+	r.NoError(err)
+	r.Equal(objs, localized)
+}
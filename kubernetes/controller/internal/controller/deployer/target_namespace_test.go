@@ -0,0 +1,35 @@
+package deployer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCheckTargetNamespace(t *testing.T) {
+	object := func(kind, namespace string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetKind(kind)
+		obj.SetName("object")
+		obj.SetNamespace(namespace)
+
+		return obj
+	}
+
+	t.Run("objects of the target namespace are allowed", func(t *testing.T) {
+		require.NoError(t, checkTargetNamespace(object("ConfigMap", "tenant"), true, "tenant"))
+	})
+
+	t.Run("objects of other namespaces are rejected", func(t *testing.T) {
+		r := require.New(t)
+		err := checkTargetNamespace(object("ConfigMap", "kube-system"), true, "tenant")
+		r.ErrorContains(err, "ConfigMap kube-system/object is not allowed")
+	})
+
+	t.Run("cluster-scoped objects are rejected", func(t *testing.T) {
+		r := require.New(t)
+		err := checkTargetNamespace(object("ClusterRoleBinding", ""), false, "tenant")
+		r.ErrorContains(err, "cluster-scoped ClusterRoleBinding object is not allowed")
+	})
+}
@@ -0,0 +1,27 @@
+package productdeployment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+func TestDeployerName(t *testing.T) {
+	r := require.New(t)
+	productDeployment := &v1alpha1.ProductDeployment{ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "tenant"}}
+	backend := v1alpha1.ProductDeploymentItem{Name: "backend"}
+
+	r.Equal("tenant.shop.backend", deployerName(productDeployment, backend))
+
+	productDeployment.Name = strings.Repeat("p", validation.DNS1123SubdomainMaxLength)
+	name := deployerName(productDeployment, backend)
+	r.Len(name, validation.DNS1123SubdomainMaxLength)
+	r.Empty(validation.IsDNS1123Subdomain(name))
+	r.NotEqual(name, deployerName(productDeployment, v1alpha1.ProductDeploymentItem{Name: "frontend"}),
+		"shortened names of different items must differ")
+}
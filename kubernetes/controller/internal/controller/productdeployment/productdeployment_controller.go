@@ -0,0 +1,430 @@
+package productdeployment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
+)
+
+// Reconciler reconciles ProductDeployments. For every ProductDeployment it manages
+//   - a Component for the root component version (owned by the ProductDeployment),
+//   - a Resource per deployment item, referencing the managed Component (owned by the ProductDeployment),
+//   - a Deployer per deployment item, referencing the managed Resource.
+//
+// Deployers are cluster-scoped and can therefore not be owned by the namespaced ProductDeployment.
+// All managed objects carry the ProductDeployment labels, which are used to map events back to the
+// ProductDeployment and to clean up Deployers on deletion.
+type Reconciler struct {
+	*ocm.BaseReconciler
+}
+
+var _ ocm.Reconciler = (*Reconciler)(nil)
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ProductDeployment{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&v1alpha1.Component{}).
+		Owns(&v1alpha1.Resource{}).
+		Watches(&v1alpha1.Deployer{}, handler.EnqueueRequestsFromMapFunc(productDeploymentForManagedObject)).
+		Complete(r)
+}
+
+// productDeploymentForManagedObject enqueues the ProductDeployment recorded in the labels of a managed object.
+func productDeploymentForManagedObject(_ context.Context, obj client.Object) []reconcile.Request {
+	lbls := obj.GetLabels()
+	name, namespace := lbls[v1alpha1.ProductDeploymentNameLabel], lbls[v1alpha1.ProductDeploymentNamespaceLabel]
+	if name == "" || namespace == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: k8stypes.NamespacedName{Namespace: namespace, Name: name}}}
+}
+
+// +kubebuilder:rbac:groups=delivery.ocm.software,resources=productdeployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=delivery.ocm.software,resources=productdeployments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=delivery.ocm.software,resources=productdeployments/finalizers,verbs=update
+// +kubebuilder:rbac:groups=delivery.ocm.software,resources=components;resources;deployers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := tracing.StartReconcile(ctx, "ProductDeployment", req)
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("starting reconciliation")
+
+	productDeployment := &v1alpha1.ProductDeployment{}
+	if err := r.Get(ctx, req.NamespacedName, productDeployment); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	old := productDeployment.DeepCopy()
+	defer func(ctx context.Context) {
+		status.UpdateBeforePatch(productDeployment, r.EventRecorder, 0, err)
+		if !equality.Semantic.DeepEqual(productDeployment.Status, old.Status) {
+			err = errors.Join(err, r.GetClient().Status().Patch(ctx, productDeployment, client.MergeFrom(old)))
+		}
+	}(ctx)
+
+	if !productDeployment.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.reconcileDelete(ctx, productDeployment)
+	}
+
+	if productDeployment.Spec.Suspend {
+		return ctrl.Result{}, nil
+	}
+
+	if updated := controllerutil.AddFinalizer(productDeployment, v1alpha1.ProductDeploymentFinalizer); updated {
+		if err := r.Update(ctx, productDeployment); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	return ctrl.Result{}, r.reconcile(ctx, productDeployment)
+}
+
+// reconcileDelete deletes the managed Deployers and removes the finalizer once they are gone.
+// The managed Component and Resources are garbage collected through their owner references.
+func (r *Reconciler) reconcileDelete(ctx context.Context, productDeployment *v1alpha1.ProductDeployment) error {
+	deployers := &v1alpha1.DeployerList{}
+	if err := r.List(ctx, deployers, client.MatchingLabels(managedLabels(productDeployment))); err != nil {
+		return fmt.Errorf("failed to list managed deployers: %w", err)
+	}
+
+	for i := range deployers.Items {
+		if err := client.IgnoreNotFound(r.Delete(ctx, &deployers.Items[i])); err != nil {
+			status.MarkNotReady(r.EventRecorder, productDeployment, v1alpha1.DeletionFailedReason, err.Error())
+
+			return fmt.Errorf("failed to delete deployer %s: %w", deployers.Items[i].GetName(), err)
+		}
+	}
+
+	// Deployers prune their deployed objects before they are gone. Keep the ProductDeployment
+	// (and with it the Resources the Deployers reference) until that has happened.
+	// The removal of the Deployers triggers the next reconciliation.
+	if len(deployers.Items) > 0 {
+		log.FromContext(ctx).Info("waiting for managed deployers to be deleted", "count", len(deployers.Items))
+
+		return nil
+	}
+
+	if updated := controllerutil.RemoveFinalizer(productDeployment, v1alpha1.ProductDeploymentFinalizer); updated {
+		if err := r.Update(ctx, productDeployment); err != nil {
+			status.MarkNotReady(r.EventRecorder, productDeployment, v1alpha1.DeletionFailedReason, err.Error())
+
+			return fmt.Errorf("failed to remove finalizer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// reconcile applies all managed objects, prunes the ones of removed deployment items
+// and aggregates the status of the managed objects.
+func (r *Reconciler) reconcile(ctx context.Context, productDeployment *v1alpha1.ProductDeployment) error {
+	if err := validate(productDeployment); err != nil {
+		status.MarkNotReady(r.EventRecorder, productDeployment, v1alpha1.InvalidDeploymentReason, err.Error())
+
+		return reconcile.TerminalError(err)
+	}
+
+	component, err := r.applyComponent(ctx, productDeployment)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, productDeployment, v1alpha1.ApplyFailed, err.Error())
+
+		return err
+	}
+
+	deployments := make([]v1alpha1.ProductDeploymentItemStatus, 0, len(productDeployment.Spec.Deployments))
+	for _, item := range productDeployment.Spec.Deployments {
+		itemStatus, err := r.applyDeployment(ctx, productDeployment, component, item)
+		if err != nil {
+			status.MarkNotReady(r.EventRecorder, productDeployment, v1alpha1.ApplyFailed, err.Error())
+
+			return err
+		}
+		deployments = append(deployments, itemStatus)
+	}
+	productDeployment.Status.Deployments = deployments
+
+	if err := r.prune(ctx, productDeployment); err != nil {
+		status.MarkNotReady(r.EventRecorder, productDeployment, v1alpha1.DeletionFailedReason, err.Error())
+
+		return err
+	}
+
+	if !status.IsReady(component) {
+		productDeployment.Status.Component = nil
+		status.MarkNotReady(r.EventRecorder, productDeployment, v1alpha1.ComponentNotReadyReason,
+			notReadyMessage(component, v1alpha1.KindComponent))
+
+		return nil
+	}
+	productDeployment.Status.Component = component.Status.Component.DeepCopy()
+
+	var pending []string
+	for _, deployment := range deployments {
+		if !deployment.Ready {
+			pending = append(pending, fmt.Sprintf("%s: %s", deployment.Name, deployment.Message))
+		}
+	}
+	if len(pending) > 0 {
+		status.MarkNotReady(r.EventRecorder, productDeployment, v1alpha1.DeploymentNotReadyReason,
+			fmt.Sprintf("%d of %d deployments not ready: %s", len(pending), len(deployments), strings.Join(pending, "; ")))
+
+		return nil
+	}
+
+	status.MarkReady(r.EventRecorder, productDeployment, "Deployed %d resources of %s:%s",
+		len(deployments), component.Status.Component.Component, component.Status.Component.Version)
+
+	return nil
+}
+
+func (r *Reconciler) applyComponent(ctx context.Context, productDeployment *v1alpha1.ProductDeployment) (*v1alpha1.Component, error) {
+	component := &v1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      productDeployment.GetName(),
+			Namespace: productDeployment.GetNamespace(),
+		},
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, component, func() error {
+		setManagedLabels(component, productDeployment, "")
+		component.Spec.RepositoryRef = productDeployment.Spec.RepositoryRef
+		component.Spec.Component = productDeployment.Spec.Component
+		component.Spec.Semver = productDeployment.Spec.Semver
+		component.Spec.SemverFilter = productDeployment.Spec.SemverFilter
+		if productDeployment.Spec.DowngradePolicy != "" {
+			component.Spec.DowngradePolicy = productDeployment.Spec.DowngradePolicy
+		}
+		component.Spec.Verify = productDeployment.Spec.Verify
+		component.Spec.OCMConfig = productDeployment.Spec.OCMConfig
+		component.Spec.Interval = productDeployment.Spec.Interval
+
+		return controllerutil.SetControllerReference(productDeployment, component, r.Scheme)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to apply component %s: %w", component.GetName(), err)
+	}
+
+	return component, nil
+}
+
+// applyDeployment applies the Resource and Deployer of a single deployment item and returns its status.
+func (r *Reconciler) applyDeployment(
+	ctx context.Context,
+	productDeployment *v1alpha1.ProductDeployment,
+	component *v1alpha1.Component,
+	item v1alpha1.ProductDeploymentItem,
+) (v1alpha1.ProductDeploymentItemStatus, error) {
+	itemStatus := v1alpha1.ProductDeploymentItemStatus{Name: item.Name}
+
+	resource := &v1alpha1.Resource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName(productDeployment, item),
+			Namespace: productDeployment.GetNamespace(),
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, resource, func() error {
+		setManagedLabels(resource, productDeployment, item.Name)
+		resource.Spec.ComponentRef.Name = component.GetName()
+		resource.Spec.Resource = *item.Resource.DeepCopy()
+		resource.Spec.OCMConfig = productDeployment.Spec.OCMConfig
+
+		return controllerutil.SetControllerReference(productDeployment, resource, r.Scheme)
+	}); err != nil {
+		return itemStatus, fmt.Errorf("failed to apply resource %s for deployment %s: %w", resource.GetName(), item.Name, err)
+	}
+	itemStatus.ResourceRef = &v1alpha1.ObjectKey{Namespace: resource.GetNamespace(), Name: resource.GetName()}
+
+	deployer := &v1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: deployerName(productDeployment, item),
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployer, func() error {
+		setManagedLabels(deployer, productDeployment, item.Name)
+		deployer.Spec.ResourceRef = v1alpha1.ObjectKey{Namespace: resource.GetNamespace(), Name: resource.GetName()}
+		deployer.Spec.OCMConfig = clusterScopedOCMConfig(productDeployment)
+		deployer.Spec.TargetNamespace = productDeployment.GetNamespace()
+		deployer.Spec.RestrictToTargetNamespace = true
+
+		return nil
+	}); err != nil {
+		return itemStatus, fmt.Errorf("failed to apply deployer %s for deployment %s: %w", deployer.GetName(), item.Name, err)
+	}
+	itemStatus.DeployerRef = &v1alpha1.ObjectKey{Name: deployer.GetName()}
+
+	switch {
+	case !status.IsReady(resource):
+		itemStatus.Message = notReadyMessage(resource, v1alpha1.KindResource)
+	case !status.IsReady(deployer):
+		itemStatus.Message = notReadyMessage(deployer, v1alpha1.KindDeployer)
+	default:
+		itemStatus.Ready = true
+	}
+
+	return itemStatus, nil
+}
+
+// prune deletes the Resources and Deployers of deployment items that were removed from the ProductDeployment.
+func (r *Reconciler) prune(ctx context.Context, productDeployment *v1alpha1.ProductDeployment) error {
+	logger := log.FromContext(ctx)
+
+	items := make([]string, 0, len(productDeployment.Spec.Deployments))
+	for _, item := range productDeployment.Spec.Deployments {
+		items = append(items, item.Name)
+	}
+	stale := func(obj client.Object) bool {
+		return !slices.Contains(items, obj.GetLabels()[v1alpha1.ProductDeploymentItemLabel])
+	}
+
+	deployers := &v1alpha1.DeployerList{}
+	if err := r.List(ctx, deployers, client.MatchingLabels(managedLabels(productDeployment))); err != nil {
+		return fmt.Errorf("failed to list managed deployers: %w", err)
+	}
+	for i := range deployers.Items {
+		if deployer := &deployers.Items[i]; stale(deployer) {
+			logger.Info("deleting deployer of removed deployment", "deployer", deployer.GetName())
+			if err := client.IgnoreNotFound(r.Delete(ctx, deployer)); err != nil {
+				return fmt.Errorf("failed to delete deployer %s: %w", deployer.GetName(), err)
+			}
+		}
+	}
+
+	resources := &v1alpha1.ResourceList{}
+	if err := r.List(ctx, resources,
+		client.InNamespace(productDeployment.GetNamespace()),
+		client.MatchingLabels(managedLabels(productDeployment)),
+	); err != nil {
+		return fmt.Errorf("failed to list managed resources: %w", err)
+	}
+	for i := range resources.Items {
+		if resource := &resources.Items[i]; stale(resource) {
+			logger.Info("deleting resource of removed deployment", "resource", resource.GetName())
+			if err := client.IgnoreNotFound(r.Delete(ctx, resource)); err != nil {
+				return fmt.Errorf("failed to delete resource %s: %w", resource.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate rejects ProductDeployments acting outside of their namespace. The managed Deployers are cluster-scoped
+// and deploy with the permissions of the controller, so a ProductDeployment must neither deploy to other namespaces
+// nor reference configurations, including credentials, of other namespaces. The Deployers are restricted to the
+// namespace as well, so that the objects of the deployed resources cannot leave it.
+func validate(productDeployment *v1alpha1.ProductDeployment) error {
+	namespace := productDeployment.GetNamespace()
+
+	var errs []error
+	for _, config := range productDeployment.Spec.OCMConfig {
+		if config.Namespace != "" && config.Namespace != namespace {
+			errs = append(errs, fmt.Errorf("ocm config %s %s/%s is not in namespace %s of the product deployment",
+				config.Kind, config.Namespace, config.Name, namespace))
+		}
+	}
+	for _, item := range productDeployment.Spec.Deployments {
+		if item.TargetNamespace != "" && item.TargetNamespace != namespace {
+			errs = append(errs, fmt.Errorf("deployment %s: target namespace %s is not namespace %s of the product deployment",
+				item.Name, item.TargetNamespace, namespace))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// managedLabels returns the labels identifying the objects managed by the ProductDeployment.
+func managedLabels(productDeployment *v1alpha1.ProductDeployment) map[string]string {
+	return map[string]string{
+		v1alpha1.ProductDeploymentNameLabel:      productDeployment.GetName(),
+		v1alpha1.ProductDeploymentNamespaceLabel: productDeployment.GetNamespace(),
+	}
+}
+
+func setManagedLabels(obj client.Object, productDeployment *v1alpha1.ProductDeployment, item string) {
+	lbls := obj.GetLabels()
+	if lbls == nil {
+		lbls = make(map[string]string)
+	}
+	for k, v := range managedLabels(productDeployment) {
+		lbls[k] = v
+	}
+	if item != "" {
+		lbls[v1alpha1.ProductDeploymentItemLabel] = item
+	}
+	obj.SetLabels(lbls)
+}
+
+// deployerNameHashLength is the number of hex digits of the hash suffixing shortened Deployer names.
+const deployerNameHashLength = 16
+
+// resourceName returns the name of the Resource managed for a deployment item.
+func resourceName(productDeployment *v1alpha1.ProductDeployment, item v1alpha1.ProductDeploymentItem) string {
+	return productDeployment.GetName() + "-" + item.Name
+}
+
+// deployerName returns the name of the cluster-scoped Deployer managed for a deployment item.
+// Namespaces and item names cannot contain dots, so the name is unique across all ProductDeployments.
+// Names longer than the object name limit are shortened and suffixed with a hash of the full name.
+func deployerName(productDeployment *v1alpha1.ProductDeployment, item v1alpha1.ProductDeploymentItem) string {
+	name := productDeployment.GetNamespace() + "." + productDeployment.GetName() + "." + item.Name
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	suffix := "-" + hex.EncodeToString(hash[:])[:deployerNameHashLength]
+	prefix := strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(suffix)], ".-")
+
+	return prefix + suffix
+}
+
+// clusterScopedOCMConfig returns the OCM configuration of the ProductDeployment with all local
+// references resolved to the namespace of the ProductDeployment, so it can be used by cluster-scoped Deployers.
+func clusterScopedOCMConfig(productDeployment *v1alpha1.ProductDeployment) []v1alpha1.OCMConfiguration {
+	if productDeployment.Spec.OCMConfig == nil {
+		return nil
+	}
+	configs := make([]v1alpha1.OCMConfiguration, len(productDeployment.Spec.OCMConfig))
+	for i, config := range productDeployment.Spec.OCMConfig {
+		if config.Namespace == "" {
+			config.Namespace = productDeployment.GetNamespace()
+		}
+		configs[i] = config
+	}
+
+	return configs
+}
+
+func notReadyMessage(obj status.ConditionObject, kind string) string {
+	if ready := status.FindCondition(obj, v1alpha1.ReadyCondition); ready != nil && ready.Message != "" {
+		return fmt.Sprintf("%s is not ready: %s", kind, ready.Message)
+	}
+
+	return kind + " is not ready"
+}
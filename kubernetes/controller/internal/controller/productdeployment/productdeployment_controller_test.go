@@ -0,0 +1,220 @@
+package productdeployment
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/test"
+)
+
+var _ = Describe("ProductDeployment Controller", func() {
+	const (
+		timeout  = 10 * time.Second
+		interval = 250 * time.Millisecond
+	)
+
+	It("manages and aggregates the component, resources and deployers of a product", func(ctx SpecContext) {
+		namespace := test.NamespaceForTest(ctx)
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+		productDeployment := &v1alpha1.ProductDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: namespace.GetName(),
+			},
+			Spec: v1alpha1.ProductDeploymentSpec{
+				RepositoryRef: corev1.LocalObjectReference{Name: "repository"},
+				Component:     "ocm.software/shop",
+				Semver:        ">=1.0.0",
+				Interval:      metav1.Duration{Duration: time.Minute},
+				OCMConfig: []v1alpha1.OCMConfiguration{{
+					NamespacedObjectKindReference: v1alpha1.NamespacedObjectKindReference{
+						APIVersion: "v1",
+						Kind:       "Secret",
+						Name:       "credentials",
+					},
+					Policy: v1alpha1.ConfigurationPolicyPropagate,
+				}},
+				Deployments: []v1alpha1.ProductDeploymentItem{
+					{
+						Name: "backend",
+						Resource: v1alpha1.ResourceID{ByReference: v1alpha1.ResourceReference{
+							Resource:      ocmruntime.Identity{"name": "backend-rgd"},
+							ReferencePath: []ocmruntime.Identity{{"name": "backend"}},
+						}},
+						TargetNamespace: namespace.GetName(),
+					},
+					{
+						Name: "frontend",
+						Resource: v1alpha1.ResourceID{ByReference: v1alpha1.ResourceReference{
+							Resource:      ocmruntime.Identity{"name": "frontend-rgd"},
+							ReferencePath: []ocmruntime.Identity{{"name": "frontend"}},
+						}},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, productDeployment)).To(Succeed())
+
+		By("creating the managed component")
+		component := &v1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: namespace.GetName()}}
+		Eventually(komega.Object(component), timeout, interval).Should(And(
+			HaveField("Spec.Component", "ocm.software/shop"),
+			HaveField("Spec.Semver", ">=1.0.0"),
+			HaveField("Spec.RepositoryRef.Name", "repository"),
+			HaveField("OwnerReferences", ContainElement(HaveField("Name", "shop"))),
+		))
+		test.WaitForNotReadyObject(ctx, k8sClient, productDeployment, v1alpha1.ComponentNotReadyReason)
+
+		By("creating a resource and deployer per deployment")
+		resources := map[string]*v1alpha1.Resource{}
+		deployers := map[string]*v1alpha1.Deployer{}
+		for _, item := range []string{"backend", "frontend"} {
+			resources[item] = &v1alpha1.Resource{ObjectMeta: metav1.ObjectMeta{Name: "shop-" + item, Namespace: namespace.GetName()}}
+			Eventually(komega.Object(resources[item]), timeout, interval).Should(And(
+				HaveField("Spec.ComponentRef.Name", "shop"),
+				HaveField("Spec.Resource.ByReference.Resource", ocmruntime.Identity{"name": item + "-rgd"}),
+				HaveField("Labels", HaveKeyWithValue(v1alpha1.ProductDeploymentItemLabel, item)),
+			))
+
+			deployers[item] = &v1alpha1.Deployer{ObjectMeta: metav1.ObjectMeta{Name: namespace.GetName() + ".shop." + item}}
+			Eventually(komega.Object(deployers[item]), timeout, interval).Should(And(
+				HaveField("Spec.ResourceRef", v1alpha1.ObjectKey{Namespace: namespace.GetName(), Name: "shop-" + item}),
+				HaveField("Spec.OCMConfig", ContainElement(HaveField("Namespace", namespace.GetName()))),
+				HaveField("Spec.RestrictToTargetNamespace", true),
+			))
+		}
+		Expect(deployers["backend"].Spec.TargetNamespace).To(Equal(namespace.GetName()))
+		Expect(deployers["frontend"].Spec.TargetNamespace).To(Equal(namespace.GetName()))
+
+		By("aggregating the status of the managed objects")
+		component.Status.Component = v1alpha1.ComponentInfo{
+			RepositorySpec: &apiextensionsv1.JSON{Raw: []byte(`{"type":"CommonTransportFormat/v1","filePath":"/tmp"}`)},
+			Component:      "ocm.software/shop",
+			Version:        "1.0.0",
+		}
+		markReady(ctx, component)
+		test.WaitForNotReadyObject(ctx, k8sClient, productDeployment, v1alpha1.DeploymentNotReadyReason)
+
+		markReady(ctx, resources["backend"])
+		markReady(ctx, deployers["backend"])
+		markReady(ctx, resources["frontend"])
+		Eventually(komega.Object(productDeployment), timeout, interval).Should(
+			HaveField("Status.Deployments", ConsistOf(
+				HaveField("Ready", true),
+				And(HaveField("Ready", false), HaveField("Message", ContainSubstring("Deployer is not ready"))),
+			)),
+		)
+
+		markReady(ctx, deployers["frontend"])
+		test.WaitForReadyObject(ctx, k8sClient, productDeployment, map[string]any{
+			"Status.Component.Version": "1.0.0",
+		})
+
+		By("pruning the objects of removed deployments")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(productDeployment), productDeployment)).To(Succeed())
+		productDeployment.Spec.Deployments = productDeployment.Spec.Deployments[:1]
+		Expect(k8sClient.Update(ctx, productDeployment)).To(Succeed())
+		Eventually(func(ctx context.Context) bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployers["frontend"]), &v1alpha1.Deployer{}))
+		}).WithTimeout(timeout).WithContext(ctx).Should(BeTrue())
+		Eventually(func(ctx context.Context) bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(resources["frontend"]), &v1alpha1.Resource{}))
+		}).WithTimeout(timeout).WithContext(ctx).Should(BeTrue())
+
+		By("deleting the managed deployers with the product deployment")
+		test.DeleteObject(ctx, k8sClient, productDeployment)
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployers["backend"]), &v1alpha1.Deployer{}))).To(BeTrue())
+	})
+
+	It("rejects deployments outside of its namespace", func(ctx SpecContext) {
+		namespace := test.NamespaceForTest(ctx)
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+		productDeployment := &v1alpha1.ProductDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: namespace.GetName(),
+			},
+			Spec: v1alpha1.ProductDeploymentSpec{
+				RepositoryRef: corev1.LocalObjectReference{Name: "repository"},
+				Component:     "ocm.software/shop",
+				Semver:        ">=1.0.0",
+				Interval:      metav1.Duration{Duration: time.Minute},
+				OCMConfig: []v1alpha1.OCMConfiguration{{
+					NamespacedObjectKindReference: v1alpha1.NamespacedObjectKindReference{
+						APIVersion: "v1",
+						Kind:       "Secret",
+						Name:       "credentials",
+						Namespace:  "other-tenant",
+					},
+					Policy: v1alpha1.ConfigurationPolicyPropagate,
+				}},
+				Deployments: []v1alpha1.ProductDeploymentItem{
+					{
+						Name:            "backend",
+						Resource:        v1alpha1.ResourceID{ByReference: v1alpha1.ResourceReference{Resource: ocmruntime.Identity{"name": "backend-rgd"}}},
+						TargetNamespace: "kube-system",
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, productDeployment)).To(Succeed())
+
+		test.WaitForNotReadyObject(ctx, k8sClient, productDeployment, v1alpha1.InvalidDeploymentReason)
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(productDeployment), productDeployment)).To(Succeed())
+		ready := meta.FindStatusCondition(productDeployment.Status.Conditions, v1alpha1.ReadyCondition)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Message).To(And(
+			ContainSubstring("ocm config Secret other-tenant/credentials"),
+			ContainSubstring("deployment backend: target namespace kube-system"),
+		))
+
+		Consistently(func(ctx context.Context) bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(productDeployment), &v1alpha1.Component{}))
+		}).WithTimeout(time.Second).WithContext(ctx).Should(BeTrue())
+
+		test.DeleteObject(ctx, k8sClient, productDeployment)
+	})
+})
+
+type readyObject interface {
+	client.Object
+	GetConditions() []metav1.Condition
+	SetConditions([]metav1.Condition)
+}
+
+// markReady simulates the controller of a managed object by setting its Ready condition.
+func markReady(ctx context.Context, obj readyObject) {
+	GinkgoHelper()
+
+	Eventually(func(ctx context.Context) error {
+		current := obj.DeepCopyObject().(readyObject)
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+			return err
+		}
+		obj.SetResourceVersion(current.GetResourceVersion())
+		obj.SetConditions([]metav1.Condition{{
+			Type:               v1alpha1.ReadyCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             v1alpha1.SucceededReason,
+			Message:            "ready",
+			LastTransitionTime: metav1.Now(),
+		}})
+
+		return k8sClient.Status().Update(ctx, obj)
+	}).WithTimeout(10 * time.Second).WithContext(ctx).Should(Succeed())
+}
@@ -0,0 +1,119 @@
+package productdeployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+)
+
+// +kubebuilder:scaffold:imports
+
+var (
+	cfg        *rest.Config
+	k8sClient  client.Client
+	k8sManager ctrl.Manager
+	testEnv    *envtest.Environment
+)
+
+func TestControllers(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "ProductDeployment Controller Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	By("bootstrapping test environment")
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		BinaryAssetsDirectory: filepath.Join("..", "..", "..", "bin", "k8s",
+			fmt.Sprintf("%s-%s-%s", os.Getenv("ENVTEST_K8S_VERSION"), runtime.GOOS, runtime.GOARCH)),
+	}
+
+	var err error
+	cfg, err = testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cfg).NotTo(BeNil())
+
+	Expect(v1alpha1.AddToScheme(scheme.Scheme)).Should(Succeed())
+
+	// +kubebuilder:scaffold:scheme
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sClient).NotTo(BeNil())
+
+	komega.SetClient(k8sClient)
+
+	gracefulTimeout := 5 * time.Second
+	k8sManager, err = ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:                  scheme.Scheme,
+		GracefulShutdownTimeout: &gracefulTimeout,
+		Metrics: metricserver.Options{
+			BindAddress: "0",
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events := make(chan string)
+	recorder := &record.FakeRecorder{
+		Events:        events,
+		IncludeObject: true,
+	}
+
+	go func() {
+		for {
+			select {
+			case event := <-events:
+				GinkgoLogr.Info("Event received", "event", event)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	Expect((&Reconciler{
+		BaseReconciler: &ocm.BaseReconciler{
+			Client:        k8sManager.GetClient(),
+			Scheme:        testEnv.Scheme,
+			EventRecorder: recorder,
+		},
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	mgrDone := make(chan struct{})
+	go func() {
+		defer GinkgoRecover()
+		defer close(mgrDone)
+		Expect(k8sManager.Start(ctx)).To(Or(Succeed(), MatchError(ContainSubstring("grace period"))))
+	}()
+
+	DeferCleanup(func() {
+		cancel()
+		<-mgrDone
+		Expect(testEnv.Stop()).To(Succeed())
+	})
+})
@@ -0,0 +1,179 @@
+package replication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"ocm.software/open-component-model/bindings/go/transfer"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+const (
+	// checkpointSourceDigestKey holds the digest of the source component version the checkpoint belongs to.
+	checkpointSourceDigestKey = "sourceDigest"
+	// checkpointTargetKey holds the target repository specification the checkpoint belongs to.
+	checkpointTargetKey = "target"
+	// checkpointJournalKey holds the journal entries of the transformations that completed writing to the target.
+	checkpointJournalKey = "journal"
+)
+
+// checkpointName returns the name of the ConfigMap holding the transfer checkpoint of a Replication.
+func checkpointName(replication *v1alpha1.Replication) string {
+	return replication.GetName() + "-transfer-checkpoint"
+}
+
+// checkpoint persists the progress of a transfer in a ConfigMap owned by the Replication, so that a failed
+// or interrupted transfer (e.g. by a restart of the controller) resumes instead of copying the whole
+// component version again. Only the transformations that completed writing their content to the target
+// are recorded, see transfer.CheckpointEntries.
+//
+// A checkpoint belongs to a source digest and a target repository. It is discarded as soon as either changes.
+type checkpoint struct {
+	client       client.Client
+	logger       logr.Logger
+	replication  *v1alpha1.Replication
+	sourceDigest string
+	target       string
+	tgd          *transformv1alpha1.TransformationGraphDefinition
+
+	// ctx is the context the checkpoint is persisted with while the journal writes to it.
+	ctx context.Context
+
+	mu      sync.Mutex
+	entries []journal.Entry
+}
+
+func newCheckpoint(
+	ctx context.Context,
+	c client.Client,
+	logger logr.Logger,
+	replication *v1alpha1.Replication,
+	sourceDigest string,
+	target []byte,
+	tgd *transformv1alpha1.TransformationGraphDefinition,
+) *checkpoint {
+	return &checkpoint{
+		client:       c,
+		logger:       logger,
+		replication:  replication,
+		sourceDigest: sourceDigest,
+		target:       string(target),
+		tgd:          tgd,
+		ctx:          ctx,
+	}
+}
+
+// load returns the checkpointed journal entries of a previous transfer of the same source digest
+// into the same target. It returns no entries if there is no such checkpoint.
+func (c *checkpoint) load(ctx context.Context) ([]journal.Entry, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.client.Get(ctx, client.ObjectKey{Namespace: c.replication.GetNamespace(), Name: checkpointName(c.replication)}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer checkpoint: %w", err)
+	}
+
+	if cm.Data[checkpointSourceDigestKey] != c.sourceDigest || cm.Data[checkpointTargetKey] != c.target {
+		c.logger.Info("discarding transfer checkpoint of a different source digest or target",
+			"checkpointSourceDigest", cm.Data[checkpointSourceDigestKey])
+
+		return nil, nil
+	}
+
+	previous, err := journal.Read(strings.NewReader(cm.Data[checkpointJournalKey]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transfer checkpoint: %w", err)
+	}
+	entries := transfer.CheckpointEntries(c.tgd, previous)
+
+	c.mu.Lock()
+	c.entries = entries
+	c.mu.Unlock()
+
+	return entries, nil
+}
+
+// Write implements io.Writer for the journal of the transfer. Every entry of a transformation that
+// completed writing to the target is added to the checkpoint, which is persisted right away.
+// Failures to persist the checkpoint do not fail the transfer, they are only logged.
+func (c *checkpoint) Write(p []byte) (int, error) {
+	var entry journal.Entry
+	if err := json.Unmarshal(bytes.TrimSpace(p), &entry); err != nil {
+		c.logger.Error(err, "failed to decode journal entry for transfer checkpoint")
+
+		return len(p), nil
+	}
+	if len(transfer.CheckpointEntries(c.tgd, []journal.Entry{entry})) == 0 {
+		return len(p), nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, entry)
+	if err := c.persist(c.ctx); err != nil {
+		c.logger.Error(err, "failed to persist transfer checkpoint", "transformation", entry.ID)
+	}
+
+	return len(p), nil
+}
+
+// persist creates or updates the ConfigMap of the checkpoint. c.mu must be held.
+func (c *checkpoint) persist(ctx context.Context) error {
+	var data strings.Builder
+	for _, entry := range c.entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode journal entry %q: %w", entry.ID, err)
+		}
+		data.Write(line)
+		data.WriteByte('\n')
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.replication.GetNamespace(),
+			Name:      checkpointName(c.replication),
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, c.client, cm, func() error {
+		cm.Data = map[string]string{
+			checkpointSourceDigestKey: c.sourceDigest,
+			checkpointTargetKey:       c.target,
+			checkpointJournalKey:      data.String(),
+		}
+
+		return controllerutil.SetControllerReference(c.replication, cm, c.client.Scheme())
+	})
+
+	return err
+}
+
+// delete removes the checkpoint after the transfer completed.
+func (c *checkpoint) delete(ctx context.Context) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.replication.GetNamespace(),
+			Name:      checkpointName(c.replication),
+		},
+	}
+	if err := c.client.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete transfer checkpoint: %w", err)
+	}
+
+	return nil
+}
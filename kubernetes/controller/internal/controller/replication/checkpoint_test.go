@@ -0,0 +1,83 @@
+package replication
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ociv1alpha1 "ocm.software/open-component-model/bindings/go/oci/spec/transformation/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+	graphRuntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1/meta"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+func TestCheckpoint(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	scheme := k8sruntime.NewScheme()
+	r.NoError(corev1.AddToScheme(scheme))
+	r.NoError(v1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	replication := &v1alpha1.Replication{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "replication", UID: "uid"}}
+	tgd := &transformv1alpha1.TransformationGraphDefinition{Transformations: []transformv1alpha1.GenericTransformation{
+		{TransformationMeta: meta.TransformationMeta{ID: "get", Type: ociv1alpha1.OCIGetLocalResourceV1alpha1}},
+		{TransformationMeta: meta.TransformationMeta{ID: "add", Type: ociv1alpha1.OCIAddLocalResourceV1alpha1}},
+	}}
+	target := []byte(`{"type":"OCIRepository/v1","baseUrl":"ghcr.io/target"}`)
+
+	// record both transformations as completed, only the add transformation wrote to the target.
+	cp := newCheckpoint(ctx, c, logr.Discard(), replication, "sha256:source", target, tgd)
+	resumed, err := cp.load(ctx)
+	r.NoError(err)
+	r.Empty(resumed)
+	j := journal.New(cp, resumed...)
+	for _, id := range []string{"get", "add"} {
+		step, err := j.Start(graphRuntime.JournalKindTransformation, id)
+		r.NoError(err)
+		r.NoError(step.End(nil, journal.Result{Output: []byte(`{"output":{}}`)}))
+	}
+
+	cm := &corev1.ConfigMap{}
+	r.NoError(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "replication-transfer-checkpoint"}, cm))
+	r.Equal("sha256:source", cm.Data[checkpointSourceDigestKey])
+	r.Len(cm.OwnerReferences, 1)
+
+	t.Run("resumes the same source digest and target", func(t *testing.T) {
+		r := require.New(t)
+		resumed, err := newCheckpoint(ctx, c, logr.Discard(), replication, "sha256:source", target, tgd).load(ctx)
+		r.NoError(err)
+		r.Len(resumed, 1)
+		r.Equal("add", resumed[0].ID)
+	})
+
+	t.Run("discards a different source digest", func(t *testing.T) {
+		r := require.New(t)
+		resumed, err := newCheckpoint(ctx, c, logr.Discard(), replication, "sha256:other", target, tgd).load(ctx)
+		r.NoError(err)
+		r.Empty(resumed)
+	})
+
+	t.Run("discards a different target", func(t *testing.T) {
+		r := require.New(t)
+		other := []byte(`{"type":"OCIRepository/v1","baseUrl":"ghcr.io/other"}`)
+		resumed, err := newCheckpoint(ctx, c, logr.Discard(), replication, "sha256:source", other, tgd).load(ctx)
+		r.NoError(err)
+		r.Empty(resumed)
+	})
+
+	r.NoError(cp.delete(ctx))
+	err = c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "replication-transfer-checkpoint"}, cm)
+	r.True(apierrors.IsNotFound(err))
+	r.NoError(cp.delete(ctx), "deleting a missing checkpoint succeeds")
+}
@@ -0,0 +1,55 @@
+package replication
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+)
+
+// Journals keeps the journal of the latest transfer of every Replication so that
+// its recent entries can be inspected while debugging the controller.
+// The zero value is ready to use, a nil Journals does not keep any journals.
+type Journals struct {
+	mu       sync.Mutex
+	journals map[types.NamespacedName]*journal.Journal
+}
+
+// Recent returns the latest n entries of the journal of every Replication, keyed by
+// the namespaced name of the Replication.
+func (j *Journals) Recent(n int) map[string][]journal.Entry {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	recent := make(map[string][]journal.Entry, len(j.journals))
+	for key, jr := range j.journals {
+		recent[key.String()] = jr.Recent(n)
+	}
+	return recent
+}
+
+// track replaces the journal kept for the Replication with the journal of its current transfer.
+func (j *Journals) track(key types.NamespacedName, jr *journal.Journal) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.journals == nil {
+		j.journals = make(map[types.NamespacedName]*journal.Journal)
+	}
+	j.journals[key] = jr
+}
+
+// forget drops the journal kept for a deleted Replication.
+func (j *Journals) forget(key types.NamespacedName) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.journals, key)
+}
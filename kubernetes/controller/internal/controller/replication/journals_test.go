@@ -0,0 +1,39 @@
+package replication
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
+)
+
+func TestJournals(t *testing.T) {
+	r := require.New(t)
+
+	var disabled *Journals
+	disabled.track(types.NamespacedName{Namespace: "ns", Name: "a"}, journal.New(io.Discard))
+	r.Nil(disabled.Recent(10))
+
+	journals := &Journals{}
+	key := types.NamespacedName{Namespace: "ns", Name: "a"}
+
+	first := journal.New(io.Discard)
+	r.NoError(first.Skip("transformation", "old"))
+	journals.track(key, first)
+
+	current := journal.New(io.Discard)
+	r.NoError(current.Skip("transformation", "one"))
+	r.NoError(current.Skip("transformation", "two"))
+	journals.track(key, current)
+
+	recent := journals.Recent(1)
+	r.Len(recent, 1)
+	r.Len(recent["ns/a"], 1)
+	r.Equal("two", recent["ns/a"][0].ID)
+
+	journals.forget(key)
+	r.Empty(journals.Recent(1))
+}
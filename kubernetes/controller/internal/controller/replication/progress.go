@@ -0,0 +1,109 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
+	ocitransformation "ocm.software/open-component-model/bindings/go/oci/spec/transformation/v1alpha1"
+	graphRuntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/event"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+)
+
+// progressReporter tracks the progress of a transfer from the progress events of the transformation graph.
+// Every batchSize transferred component versions, the progress is patched into the status of the
+// Replication and recorded as an event, so that long-running transfers are observable before they end.
+type progressReporter struct {
+	client    client.Client
+	recorder  record.EventRecorder
+	logger    logr.Logger
+	bandwidth *ocmhttp.Bandwidth
+	batchSize int
+
+	replication *v1alpha1.Replication
+	progress    v1alpha1.TransferProgress
+	unreported  int
+}
+
+// newProgressReporter creates a progressReporter for the transfer of tgd and resets the progress in the status.
+func newProgressReporter(c client.Client, recorder record.EventRecorder, logger logr.Logger, replication *v1alpha1.Replication,
+	tgd *transformv1alpha1.TransformationGraphDefinition, bandwidth *ocmhttp.Bandwidth,
+) *progressReporter {
+	p := &progressReporter{
+		client:      c,
+		recorder:    recorder,
+		logger:      logger,
+		bandwidth:   bandwidth,
+		batchSize:   1,
+		replication: replication,
+	}
+	if settings := replication.Spec.Transfer; settings != nil && settings.BatchSize > 0 {
+		p.batchSize = settings.BatchSize
+	}
+	for _, transformation := range tgd.Transformations {
+		if isComponentVersionUpload(transformation.Type.Name) {
+			p.progress.ComponentVersions++
+		}
+	}
+	replication.Status.Progress = p.progress.DeepCopy()
+
+	return p
+}
+
+// observe records a progress event and reports the progress once a batch of component versions is transferred.
+func (p *progressReporter) observe(ctx context.Context, e graphRuntime.ProgressEvent) {
+	t := e.Transformation
+	switch {
+	case e.State == graphRuntime.Running:
+		p.progress.CurrentItem = fmt.Sprintf("%s [%s]", t.ID, t.Type.Name)
+	case e.State == graphRuntime.Completed && isComponentVersionUpload(t.Type.Name):
+		p.progress.TransferredComponentVersions++
+		p.unreported++
+		if p.unreported >= p.batchSize {
+			p.report(ctx)
+		}
+	}
+}
+
+// finish records the final progress in the status without patching it, which is left to the reconciliation.
+func (p *progressReporter) finish() {
+	p.progress.TransferredBytes = p.bandwidth.Transferred()
+	p.replication.Status.Progress = p.progress.DeepCopy()
+}
+
+// report patches the current progress into the status of the Replication and records it as an event.
+// Failing to report the progress does not fail the transfer.
+func (p *progressReporter) report(ctx context.Context) {
+	p.unreported = 0
+	p.progress.TransferredBytes = p.bandwidth.Transferred()
+
+	base := p.replication.DeepCopy()
+	p.replication.Status.Progress = p.progress.DeepCopy()
+	msg := fmt.Sprintf("transferred %d of %d component versions (%d bytes)",
+		p.progress.TransferredComponentVersions, p.progress.ComponentVersions, p.progress.TransferredBytes)
+	status.SetCondition(p.replication, metav1.Condition{
+		Type:    v1alpha1.TransferInProgressCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.TransferInProgressReason,
+		Message: msg,
+	})
+	event.New(p.recorder, p.replication, nil, v1alpha1.EventSeverityInfo, "%s", msg)
+
+	if err := p.client.Status().Patch(ctx, p.replication, client.MergeFrom(base)); err != nil {
+		p.logger.Error(err, "failed to report transfer progress")
+	}
+}
+
+// isComponentVersionUpload reports whether transformations of the given type upload a component version,
+// which is the last transformation of every component version of a transfer.
+func isComponentVersionUpload(typ string) bool {
+	return typ == ocitransformation.OCIAddComponentVersionType || typ == ocitransformation.CTFAddComponentVersionType
+}
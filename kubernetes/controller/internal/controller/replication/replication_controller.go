@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -25,10 +24,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"ocm.software/open-component-model/bindings/go/credentials"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/repository/webhook"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/transfer"
 	transferspec "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/transform/graph/journal"
 	graphRuntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
 	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
 	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
@@ -37,6 +40,7 @@ import (
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
 	"ocm.software/open-component-model/kubernetes/controller/internal/setup"
 	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
 	"ocm.software/open-component-model/kubernetes/controller/internal/util"
 	"ocm.software/open-component-model/kubernetes/controller/pkg/configuration"
 )
@@ -60,6 +64,9 @@ type Reconciler struct {
 	// built with, so Replication accepts exactly the spec types Component
 	// resolution accepts.
 	RepositoryScheme *runtime.Scheme
+
+	// Journals keeps the journal of the latest transfer of every Replication, if set.
+	Journals *Journals
 }
 
 var _ ocm.Reconciler = (*Reconciler)(nil)
@@ -139,8 +146,12 @@ func (r *Reconciler) replicationsForIndex(index string) handler.MapFunc {
 // +kubebuilder:rbac:groups=delivery.ocm.software,resources=replications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=delivery.ocm.software,resources=replications/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=delivery.ocm.software,resources=replications/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := tracing.StartReconcile(ctx, "Replication", req)
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 	logger.Info("starting reconciliation")
 
@@ -162,6 +173,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		// TODO(skarlso): per ADR 0020 deletion semantics should cancel in-flight transfer, right now
 		// since the transfer is sequential, cancel is when the reconcile context is cancelled.
 		// https://github.com/open-component-model/ocm-project/issues/1148
+		r.Journals.forget(req.NamespacedName)
 		if updated := controllerutil.RemoveFinalizer(replication, v1alpha1.ReplicationFinalizer); updated {
 			if err := r.Update(ctx, replication); err != nil {
 				status.MarkNotReady(r.EventRecorder, replication, v1alpha1.DeletionFailedReason, err.Error())
@@ -286,9 +298,9 @@ func (r *Reconciler) reconcile(ctx context.Context, replication *v1alpha1.Replic
 		return ctrl.Result{}, fmt.Errorf("failed to decode target repository spec: %w", err)
 	}
 
-	cfg, err := configuration.LoadConfigurations(ctx, r.Client, replication.GetNamespace(), configs)
+	cfg, err := configuration.LoadConfigurations(ctx, r.Client, replication.GetNamespace(), configs, configuration.WithDecryptor(r.ConfigDecryptor))
 	if err != nil {
-		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetConfigurationFailedReason, err.Error())
+		status.MarkNotReady(r.GetEventRecorder(), replication, v1alpha1.GetConfigurationFailedReason, err.Error())
 
 		return ctrl.Result{}, fmt.Errorf("failed to load configurations: %w", err)
 	}
@@ -297,6 +309,7 @@ func (r *Reconciler) reconcile(ctx context.Context, replication *v1alpha1.Replic
 		RepositorySpec:  sourceSpec,
 		Configuration:   cfg,
 		SigningRegistry: r.PluginManager.SigningRegistry,
+		Plugin:          resolution.NewPluginSelection(replication.GetNamespace(), component.Status.Component.Plugin, cfg),
 		RequesterFunc: func() workerpool.RequesterInfo {
 			return workerpool.RequesterInfo{
 				NamespacedName: k8stypes.NamespacedName{
@@ -317,11 +330,14 @@ func (r *Reconciler) reconcile(ctx context.Context, replication *v1alpha1.Replic
 	if cfg != nil {
 		transferCfg, err = transferspec.LookupConfig(cfg.Config)
 		if err != nil {
-			status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetConfigurationFailedReason, err.Error())
+			status.MarkNotReady(r.GetEventRecorder(), replication, v1alpha1.GetConfigurationFailedReason, err.Error())
 
 			return ctrl.Result{}, fmt.Errorf("failed to load transfer config: %w", err)
 		}
 	}
+	if replication.Spec.Resources != nil {
+		transferCfg = withResourceFilter(transferCfg, replication.Spec.Resources)
+	}
 
 	// Descriptor fetches for discovery go through the resolution service. Uncached component version
 	// aborts the walk with `ErrResolutionInProgress` and the event from the resolution service will retrigger
@@ -346,6 +362,10 @@ func (r *Reconciler) reconcile(ctx context.Context, replication *v1alpha1.Replic
 			"version", component.Status.Component.Version)
 
 		return ctrl.Result{}, nil
+	case errors.Is(err, workerpool.ErrQuotaExceeded):
+		status.MarkResolutionQuotaExceeded(r.EventRecorder, replication, err)
+
+		return ctrl.Result{}, err
 	case err != nil:
 		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.ReplicationFailedReason, err.Error())
 
@@ -367,7 +387,7 @@ func (r *Reconciler) reconcile(ctx context.Context, replication *v1alpha1.Replic
 		Message: fmt.Sprintf("transferring component version %s", component.Status.Component.Version),
 	})
 
-	if err := r.transfer(ctx, logger, replication, cfg, tgd, component, sourceDigest); err != nil {
+	if err := r.transfer(ctx, logger, replication, cfg, tgd, component, sourceDigest, targetRepository.Spec.RepositorySpec.Raw); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -376,63 +396,93 @@ func (r *Reconciler) reconcile(ctx context.Context, replication *v1alpha1.Replic
 	return ctrl.Result{}, nil
 }
 
-func (r *Reconciler) transfer(ctx context.Context, logger logr.Logger, replication *v1alpha1.Replication, cfg *configuration.Configuration, tgd *transformv1alpha1.TransformationGraphDefinition, component *v1alpha1.Component, sourceDigest string) error {
+// transfer executes the transfer graph. Transformations that completed writing to the target in a
+// previous, failed attempt of the same source digest and target are resumed from the checkpoint of the
+// Replication instead of being executed again.
+func (r *Reconciler) transfer(ctx context.Context, logger logr.Logger, replication *v1alpha1.Replication, cfg *configuration.Configuration, tgd *transformv1alpha1.TransformationGraphDefinition, component *v1alpha1.Component, sourceDigest string, target []byte) error {
 	var (
-		credGraph credentials.Resolver
-		err       error
+		credGraph    credentials.Resolver
+		repoProvider repository.ComponentVersionRepositoryProvider = r.PluginManager.ComponentVersionRepositoryRegistry
+		err          error
 	)
 	if cfg != nil {
 		credGraph, err = setup.NewCredentialGraph(ctx, cfg.Config, setup.CredentialGraphOptions{
 			PluginManager: r.PluginManager,
 			Logger:        &logger,
+			Namespace:     cfg.Namespace,
 		})
 		if err != nil {
-			status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetConfigurationFailedReason, err.Error())
+			status.MarkNotReady(r.GetEventRecorder(), replication, v1alpha1.GetConfigurationFailedReason, err.Error())
 
 			return fmt.Errorf("failed to create credential graph: %w", err)
 		}
-	}
 
-	events := make(chan graphRuntime.ProgressEvent)
+		// Component versions written to the target are announced to the webhook endpoints of the configuration.
+		// The events are delivered in the background and neither delay nor fail the replication.
+		emitter, err := webhook.LookupEmitter(cfg.Config)
+		if err != nil {
+			status.MarkNotReady(r.GetEventRecorder(), replication, v1alpha1.GetConfigurationFailedReason, err.Error())
+
+			return fmt.Errorf("failed to create webhook emitter: %w", err)
+		}
+		if emitter != nil {
+			repoProvider = webhook.NewProvider(repoProvider, emitter)
+		}
+	}
 
-	transferGraph, err := transfer.NewDefaultBuilder(
-		r.PluginManager.ComponentVersionRepositoryRegistry,
-		r.PluginManager.ResourcePluginRegistry,
-		credGraph,
-	).WithEvents(events).BuildAndCheck(tgd)
+	checkpoint := newCheckpoint(ctx, r.GetClient(), logger, replication, sourceDigest, target, tgd)
+	resumed, err := checkpoint.load(ctx)
 	if err != nil {
 		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.ReplicationFailedReason, err.Error())
 
-		return fmt.Errorf("failed to build transformation graph: %w", err)
+		return err
 	}
 
-	var failed []v1alpha1.TransferEvent
-
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		for e := range events {
-			if e.Err != nil {
-				failed = append(failed, toFailedTransferEvent(e))
-			}
+	parallelism, bandwidth := 1, ocmhttp.NewBandwidth(0)
+	if settings := replication.Spec.Transfer; settings != nil {
+		parallelism = max(settings.Parallelism, 1)
+		if settings.BandwidthLimit != nil {
+			bandwidth = ocmhttp.NewBandwidth(settings.BandwidthLimit.Value())
 		}
-	}()
+	}
+
+	transferJournal := journal.New(checkpoint, resumed...)
+	r.Journals.track(client.ObjectKeyFromObject(replication), transferJournal)
+
+	b := transfer.NewDefaultBuilder(
+		repoProvider,
+		r.PluginManager.ResourcePluginRegistry,
+		credGraph,
+	).WithConcurrency(parallelism).WithJournal(transferJournal)
+
+	var failed []v1alpha1.TransferEvent
+	progress := newProgressReporter(r.GetClient(), r.EventRecorder, logger, replication, tgd, bandwidth)
 
 	logger.Info("executing transfer",
 		"component", component.Status.Component.Component,
 		"version", component.Status.Component.Version,
 		"sourceDigest", sourceDigest,
-		"transformations", len(tgd.Transformations))
+		"transformations", len(tgd.Transformations),
+		"resumedTransformations", len(resumed),
+		"parallelism", parallelism)
 
-	processErr := transferGraph.Process(ctx)
-	wg.Wait()
+	err = transfer.Process(ocmhttp.ContextWithBandwidth(ctx, bandwidth), b, tgd, func(e graphRuntime.ProgressEvent) {
+		if e.Err != nil {
+			failed = append(failed, toFailedTransferEvent(e))
+		}
+		progress.observe(ctx, e)
+	})
+	progress.finish()
 
-	if processErr != nil {
-		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.ReplicationFailedReason, processErr.Error())
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.ReplicationFailedReason, err.Error())
 		replication.Status.LastFailedTransferEvents = failed
 
-		return fmt.Errorf("failed to process transformation graph: %w", processErr)
+		return err
+	}
+
+	if err := checkpoint.delete(ctx); err != nil {
+		logger.Error(err, "failed to delete checkpoint of completed transfer")
 	}
 
 	replication.Status.LastTransferredVersion = component.Status.Component.Version
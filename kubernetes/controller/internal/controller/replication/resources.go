@@ -0,0 +1,40 @@
+package replication
+
+import (
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime/selector"
+	transferspec "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+// withResourceFilter returns a copy of the transfer configuration that copies the content of the
+// resources selected by the filter. The configuration is not modified.
+func withResourceFilter(cfg *transferspec.Config, filter *v1alpha1.ResourceFilter) *transferspec.Config {
+	merged := &transferspec.Config{}
+	if cfg != nil {
+		merged = cfg.DeepCopy()
+	}
+	merged.CopyMode = transferspec.CopyModeAllResources
+	merged.Resources = &selector.ResourceFilter{
+		Include: toResourceSelectors(filter.Include),
+		Exclude: toResourceSelectors(filter.Exclude),
+	}
+
+	return merged
+}
+
+func toResourceSelectors(selectors []v1alpha1.ResourceSelector) []selector.ResourceSelector {
+	if len(selectors) == 0 {
+		return nil
+	}
+	converted := make([]selector.ResourceSelector, 0, len(selectors))
+	for _, s := range selectors {
+		converted = append(converted, selector.ResourceSelector{
+			Types:         s.Types,
+			MediaTypes:    s.MediaTypes,
+			ExtraIdentity: s.ExtraIdentity,
+			MatchLabels:   s.MatchLabels,
+		})
+	}
+
+	return converted
+}
@@ -0,0 +1,42 @@
+package replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/descriptor/runtime/selector"
+	transferspec "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+func TestWithResourceFilter(t *testing.T) {
+	r := require.New(t)
+
+	filter := &v1alpha1.ResourceFilter{
+		Include: []v1alpha1.ResourceSelector{
+			{Types: []string{"ociImage"}, MatchLabels: map[string]string{"env": "prod"}},
+			{MediaTypes: []string{"application/vnd.cncf.helm.chart.content.v1.tar+gzip"}},
+		},
+		Exclude: []v1alpha1.ResourceSelector{{ExtraIdentity: map[string]string{"architecture": "arm64"}}},
+	}
+	cfg := &transferspec.Config{Recursive: transferspec.RecursiveInfinite, CopyMode: transferspec.CopyModeLocalBlobResources}
+
+	merged := withResourceFilter(cfg, filter)
+	r.Equal(transferspec.RecursiveInfinite, merged.Recursive)
+	r.Equal(transferspec.CopyModeAllResources, merged.CopyMode)
+	r.Equal(&selector.ResourceFilter{
+		Include: []selector.ResourceSelector{
+			{Types: []string{"ociImage"}, MatchLabels: map[string]string{"env": "prod"}},
+			{MediaTypes: []string{"application/vnd.cncf.helm.chart.content.v1.tar+gzip"}},
+		},
+		Exclude: []selector.ResourceSelector{{ExtraIdentity: map[string]string{"architecture": "arm64"}}},
+	}, merged.Resources)
+	r.NoError(merged.Validate())
+	r.Equal(transferspec.CopyModeLocalBlobResources, cfg.CopyMode, "the configuration is not modified")
+	r.Nil(cfg.Resources)
+
+	merged = withResourceFilter(nil, &v1alpha1.ResourceFilter{})
+	r.Equal(transferspec.CopyModeAllResources, merged.CopyMode)
+	r.Equal(&selector.ResourceFilter{}, merged.Resources)
+}
@@ -28,6 +28,7 @@ import (
 	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
 	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
 	"ocm.software/open-component-model/kubernetes/controller/pkg/configuration"
 )
 
@@ -106,6 +107,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) err
 // +kubebuilder:rbac:groups=delivery.ocm.software,resources=repositories/finalizers,verbs=update
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := tracing.StartReconcile(ctx, "Repository", req)
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 	logger.Info("starting reconciliation")
 
@@ -187,7 +191,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 }
 
 func (r *Reconciler) validate(ctx context.Context, repoSpec runtime.Typed, configs []v1alpha1.OCMConfiguration, ocmRepo *v1alpha1.Repository) error {
-	cfg, err := configuration.LoadConfigurations(ctx, r.Client, ocmRepo.GetNamespace(), configs)
+	cfg, err := configuration.LoadConfigurations(ctx, r.Client, ocmRepo.GetNamespace(), configs, configuration.WithDecryptor(r.ConfigDecryptor))
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
@@ -195,6 +199,7 @@ func (r *Reconciler) validate(ctx context.Context, repoSpec runtime.Typed, confi
 	cacheBackedRepo, err := r.Resolver.NewCacheBackedRepository(ctx, &resolution.RepositoryOptions{
 		RepositorySpec: repoSpec,
 		Configuration:  cfg,
+		Plugin:         resolution.NewPluginSelection(ocmRepo.GetNamespace(), ocmRepo.Spec.Plugin, cfg),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create repository: %w", err)
@@ -16,11 +16,13 @@ import (
 )
 
 // ComputeAdditionalStatusFields compiles and evaluates CEL expressions for additional fields.
+// Every expression is evaluated within the given limits.
 func ComputeAdditionalStatusFields(
 	ctx context.Context,
 	res *descriptor.Resource,
 	resource *v1alpha1.Resource,
 	component *v1alpha1.ComponentInfo,
+	limits ocmcel.Limits,
 ) error {
 	if resource.Spec.AdditionalStatusFields == nil || len(resource.Spec.AdditionalStatusFields.Raw) == 0 {
 		return nil
@@ -52,7 +54,7 @@ func ComputeAdditionalStatusFields(
 		return fmt.Errorf("failed to prepare CEL variables: %w", err)
 	}
 
-	result, err := processAdditionalFields(ctx, env, resourceMap, fields)
+	result, err := processAdditionalFields(ctx, env, resourceMap, fields, limits)
 	if err != nil {
 		return fmt.Errorf("failed to process additional status fields: %w", err)
 	}
@@ -85,19 +87,20 @@ func processAdditionalFields(
 	env *cel.Env,
 	resourceMap map[string]any,
 	fields map[string]any,
+	limits ocmcel.Limits,
 ) (map[string]any, error) {
 	result := make(map[string]any, len(fields))
 
 	for name, val := range fields {
 		switch v := val.(type) {
 		case string:
-			resolved, err := evalCEL(ctx, env, resourceMap, name, v)
+			resolved, err := evalCEL(ctx, env, resourceMap, name, v, limits)
 			if err != nil {
 				return nil, fmt.Errorf("failed to process field %s: %w", name, err)
 			}
 			result[name] = resolved
 		case map[string]any:
-			nested, err := processAdditionalFields(ctx, env, resourceMap, v)
+			nested, err := processAdditionalFields(ctx, env, resourceMap, v, limits)
 			if err != nil {
 				return nil, fmt.Errorf("failed to process field %s: %w", name, err)
 			}
@@ -110,23 +113,16 @@ func processAdditionalFields(
 	return result, nil
 }
 
-// evalCEL compiles and evaluates a single CEL expression against the resource data.
+// evalCEL compiles and evaluates a single CEL expression against the resource data within the limits.
 func evalCEL(
 	ctx context.Context,
 	env *cel.Env,
 	resourceMap map[string]any,
 	name string,
 	expr string,
+	limits ocmcel.Limits,
 ) (any, error) {
-	ast, issues := env.Compile(expr)
-	if issues.Err() != nil {
-		return nil, fmt.Errorf("failed to compile CEL expression %q: %w", name, issues.Err())
-	}
-	prog, err := env.Program(ast)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build CEL program %q: %w", name, err)
-	}
-	val, _, err := prog.ContextEval(ctx, map[string]any{"resource": resourceMap})
+	val, err := ocmcel.Evaluate(ctx, env, ocmcel.KindAdditionalStatusField, expr, map[string]any{"resource": resourceMap}, limits)
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate CEL expression %q: %w", name, err)
 	}
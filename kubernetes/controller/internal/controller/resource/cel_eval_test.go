@@ -9,6 +9,8 @@ import (
 	"github.com/google/cel-go/ext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	ocmcel "ocm.software/open-component-model/kubernetes/controller/internal/cel"
 )
 
 func newTestEnv(t *testing.T) *cel.Env {
@@ -52,7 +54,7 @@ func TestEvalCEL(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result, err := evalCEL(ctx, env, resourceMap, tt.name, tt.expr)
+			result, err := evalCEL(ctx, env, resourceMap, tt.name, tt.expr, ocmcel.Limits{})
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -79,7 +81,7 @@ func TestProcessAdditionalFields(t *testing.T) {
 
 	t.Run("empty fields", func(t *testing.T) {
 		t.Parallel()
-		result, err := processAdditionalFields(ctx, env, resourceMap, map[string]any{})
+		result, err := processAdditionalFields(ctx, env, resourceMap, map[string]any{}, ocmcel.Limits{})
 		require.NoError(t, err)
 		assert.Empty(t, result)
 	})
@@ -89,7 +91,7 @@ func TestProcessAdditionalFields(t *testing.T) {
 		result, err := processAdditionalFields(ctx, env, resourceMap, map[string]any{
 			"name":    "resource.name",
 			"version": "resource.version",
-		})
+		}, ocmcel.Limits{})
 		require.NoError(t, err)
 		assert.Equal(t, "my-resource", result["name"])
 		assert.Equal(t, "3.0.0", result["version"])
@@ -102,7 +104,7 @@ func TestProcessAdditionalFields(t *testing.T) {
 				"name": "resource.name",
 				"ref":  "resource.access.imageReference",
 			},
-		})
+		}, ocmcel.Limits{})
 		require.NoError(t, err)
 
 		info, ok := result["info"].(map[string]any)
@@ -115,7 +117,7 @@ func TestProcessAdditionalFields(t *testing.T) {
 		t.Parallel()
 		_, err := processAdditionalFields(ctx, env, resourceMap, map[string]any{
 			"bad": 42,
-		})
+		}, ocmcel.Limits{})
 		require.Error(t, err)
 	})
 
@@ -123,7 +125,7 @@ func TestProcessAdditionalFields(t *testing.T) {
 		t.Parallel()
 		_, err := processAdditionalFields(ctx, env, resourceMap, map[string]any{
 			"bad": "resource.nonexistent.field",
-		})
+		}, ocmcel.Limits{})
 		require.Error(t, err)
 	})
 }
@@ -150,7 +152,7 @@ func TestProcessAdditionalFields_MultipleResourcesNestedMap(t *testing.T) {
 			"frontend": "resource.components.frontend.image",
 		},
 		"summary": `resource.components.backend.image + " " + resource.components.frontend.image`,
-	})
+	}, ocmcel.Limits{})
 	require.NoError(t, err)
 
 	assert.Equal(t, "ghcr.io/org/api-server:1.5.0 ghcr.io/org/web-ui:3.2.1", result["summary"])
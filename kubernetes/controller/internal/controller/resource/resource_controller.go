@@ -26,14 +26,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	ocmcel "ocm.software/open-component-model/kubernetes/controller/internal/cel"
 	"ocm.software/open-component-model/kubernetes/controller/internal/event"
 	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
 	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
 	"ocm.software/open-component-model/kubernetes/controller/internal/util"
 	"ocm.software/open-component-model/kubernetes/controller/internal/verification"
 	"ocm.software/open-component-model/kubernetes/controller/pkg/configuration"
@@ -49,6 +52,9 @@ type Reconciler struct {
 	// PluginManager manages plugins for resource operations.
 	// It enables dynamic loading and execution of plugins required for resource access.
 	PluginManager *manager.PluginManager
+
+	// CELLimits restrict the evaluation of the CEL expressions of additional status fields.
+	CELLimits ocmcel.Limits
 }
 
 var _ ocm.Reconciler = (*Reconciler)(nil)
@@ -173,6 +179,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, con
 
 //nolint:cyclop,funlen,gocognit,maintidx // we do not want to cut the function at arbitrary points
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := tracing.StartReconcile(ctx, "Resource", req)
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 	logger.Info("starting reconciliation")
 
@@ -308,7 +317,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to get verifications: %w", err)
 	}
 
-	cfg, err := configuration.LoadConfigurations(ctx, r.Client, resource.GetNamespace(), configs)
+	cfg, err := configuration.LoadConfigurations(ctx, r.Client, resource.GetNamespace(), configs, configuration.WithDecryptor(r.ConfigDecryptor))
 	if err != nil {
 		status.MarkNotReady(r.EventRecorder, resource, v1alpha1.GetComponentVersionFailedReason, err.Error())
 
@@ -316,10 +325,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	}
 
 	cacheBackedRepo, err := r.Resolver.NewCacheBackedRepository(ctx, &resolution.RepositoryOptions{
-		RepositorySpec:  repoSpec,
-		Configuration:   cfg,
-		SigningRegistry: r.PluginManager.SigningRegistry,
-		Verifications:   verifications,
+		RepositorySpec:        repoSpec,
+		Configuration:         cfg,
+		SigningRegistry:       r.PluginManager.SigningRegistry,
+		Verifications:         verifications,
+		VerificationThreshold: int(component.Spec.VerifyThreshold),
+		Plugin:                resolution.NewPluginSelection(resource.GetNamespace(), component.Status.Component.Plugin, cfg),
 		RequesterFunc: func() workerpool.RequesterInfo {
 			return workerpool.RequesterInfo{
 				NamespacedName: k8stypes.NamespacedName{
@@ -347,6 +358,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 			"version", component.Status.Component.Version)
 
 		return ctrl.Result{}, nil
+	case errors.Is(err, workerpool.ErrQuotaExceeded):
+		status.MarkResolutionQuotaExceeded(r.EventRecorder, resource, err)
+
+		return ctrl.Result{}, err
 	case errors.Is(err, workerpool.ErrNotSafelyDigestible):
 		// Ignore error, but log event
 		event.New(r.EventRecorder, resource, nil, v1alpha1.EventSeverityError, "%s", err.Error())
@@ -369,6 +384,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 			RepositorySpec:  repoSpec,
 			Configuration:   cfg,
 			SigningRegistry: r.PluginManager.SigningRegistry,
+			Plugin:          resolution.NewPluginSelection(resource.GetNamespace(), component.Status.Component.Plugin, cfg),
 			RequesterFunc: func() workerpool.RequesterInfo {
 				return workerpool.RequesterInfo{
 					NamespacedName: k8stypes.NamespacedName{
@@ -386,6 +402,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		logger.Info("reference path resolution in progress, waiting for event notification")
 
 		return ctrl.Result{}, nil
+	case errors.Is(err, workerpool.ErrQuotaExceeded):
+		status.MarkResolutionQuotaExceeded(r.EventRecorder, resource, err)
+
+		return ctrl.Result{}, err
 	case errors.Is(err, workerpool.ErrNotSafelyDigestible):
 		// Ignore error, but log event
 		event.New(r.EventRecorder, resource, nil, v1alpha1.EventSeverityError, "%s", err.Error())
@@ -415,6 +435,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// the access is pinned before the verification, so that the pinned artifact is verified.
+	matchedResource, err = pinning.Apply(ctx, matchedResource, pinningPolicy(resource.Spec.DigestPinningPolicy), ocm.DigestResolver(r.PluginManager, cfg))
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, resource, v1alpha1.UnpinnedAccessReason, err.Error())
+
+		if errors.Is(err, pinning.ErrUnpinned) {
+			// the access only changes with a new component version, which triggers a new reconciliation.
+			return ctrl.Result{}, reconcile.TerminalError(err)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed to apply digest pinning policy: %w", err)
+	}
+
 	if resource.Spec.VerificationPolicy != v1alpha1.VerificationPolicyNever {
 		logger.V(1).Info("verifying resource")
 
@@ -448,7 +481,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		RepositorySpec: &apiextensionsv1.JSON{Raw: resourceRepoSpecData},
 		Component:      resourceDescriptor.Component.Name,
 		Version:        resourceDescriptor.Component.Version,
-	}); err != nil {
+		Plugin:         component.Status.Component.Plugin.DeepCopy(),
+	}, r.CELLimits); err != nil {
 		status.MarkNotReady(r.EventRecorder, resource, v1alpha1.StatusSetFailedReason, err.Error())
 
 		return ctrl.Result{}, fmt.Errorf("failed to set resource status: %w", err)
@@ -466,6 +500,7 @@ func setResourceStatus(
 	resource *v1alpha1.Resource,
 	res *descriptor.Resource,
 	component *v1alpha1.ComponentInfo,
+	celLimits ocmcel.Limits,
 ) error {
 	log.FromContext(ctx).V(1).Info("updating resource status")
 
@@ -475,7 +510,7 @@ func setResourceStatus(
 	}
 	resource.Status.Resource = info
 
-	if err := ComputeAdditionalStatusFields(ctx, res, resource, component); err != nil {
+	if err := ComputeAdditionalStatusFields(ctx, res, resource, component, celLimits); err != nil {
 		return fmt.Errorf("evaluating additional status fields: %w", err)
 	}
 
@@ -485,6 +520,18 @@ func setResourceStatus(
 	return nil
 }
 
+// pinningPolicy returns the policy of the pinning package for the digest pinning policy of a Resource.
+func pinningPolicy(policy v1alpha1.DigestPinningPolicy) pinning.Policy {
+	switch policy {
+	case v1alpha1.DigestPinningPolicyEnforce:
+		return pinning.PolicyEnforce
+	case v1alpha1.DigestPinningPolicyResolve:
+		return pinning.PolicyResolve
+	default:
+		return pinning.PolicyNone
+	}
+}
+
 // buildResourceInfo constructs a ResourceInfo from a descriptor resource.
 func buildResourceInfo(res *descriptor.Resource) (*v1alpha1.ResourceInfo, error) {
 	raw, err := json.Marshal(res.Access)
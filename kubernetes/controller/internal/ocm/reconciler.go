@@ -4,6 +4,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"ocm.software/open-component-model/bindings/go/configuration/sops"
 )
 
 type Reconciler interface {
@@ -16,6 +18,8 @@ type BaseReconciler struct {
 	ctrl.Client
 	Scheme *runtime.Scheme
 	record.EventRecorder
+	// ConfigDecryptor decrypts referenced OCM configurations that are encrypted with sops.
+	ConfigDecryptor *sops.Decryptor
 }
 
 func (r *BaseReconciler) GetClient() ctrl.Client {
@@ -5,16 +5,20 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/opencontainers/go-digest"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"ocm.software/open-component-model/bindings/go/credentials"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci/pinning"
+	ociaccessv1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
 	"ocm.software/open-component-model/kubernetes/controller/internal/setup"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
 	"ocm.software/open-component-model/kubernetes/controller/pkg/configuration"
 )
 
@@ -43,6 +47,7 @@ func VerifyResource(ctx context.Context, pm *manager.PluginManager, resource *de
 		credGraph, err := setup.NewCredentialGraph(ctx, cfg.Config, setup.CredentialGraphOptions{
 			PluginManager: pm,
 			Logger:        &logger,
+			Namespace:     cfg.Namespace,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed creating credential graph: %w", err)
@@ -55,7 +60,9 @@ func VerifyResource(ctx context.Context, pm *manager.PluginManager, resource *de
 	}
 
 	// Process resource digest will also verify the digest if already present
-	digestResource, err := digestProcessor.ProcessResourceDigest(ctx, resource, creds)
+	digestCtx, span := tracing.Start(ctx, "DigestProcessor.ProcessResourceDigest", tracing.ResourceKey.String(resource.Name))
+	digestResource, err := digestProcessor.ProcessResourceDigest(digestCtx, resource, creds)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed processing resource digest: %w", err)
 	}
@@ -63,6 +70,28 @@ func VerifyResource(ctx context.Context, pm *manager.PluginManager, resource *de
 	return digestResource, nil
 }
 
+// DigestResolver returns a resolver for the tags of OCI artifact accesses. The tags are resolved against the
+// registry by the digest processor of the plugin manager, with the credentials of the configuration.
+func DigestResolver(pm *manager.PluginManager, cfg *configuration.Configuration) pinning.Resolver {
+	return pinning.ResolverFunc(func(ctx context.Context, imageReference string) (digest.Digest, error) {
+		resource := &descriptor.Resource{
+			Access: &ociaccessv1.OCIImage{
+				Type:           runtime.NewVersionedType(ociaccessv1.LegacyType, ociaccessv1.LegacyTypeVersion),
+				ImageReference: imageReference,
+			},
+		}
+		processed, err := VerifyResource(ctx, pm, resource, cfg)
+		if err != nil {
+			return "", err
+		}
+		dig := pinning.ResourceDigest(processed)
+		if dig == "" {
+			return "", fmt.Errorf("digest processor returned no digest for %q", imageReference)
+		}
+		return dig, nil
+	})
+}
+
 // ResolveReferencePath walks a reference path from a parent component version to a final component version.
 // It returns the final descriptor and repository spec.
 // The baseOpts are used as a template for each resolution step; only the Digest field is overridden per reference.
@@ -0,0 +1,209 @@
+// Package preflight validates the setup of the controller when it starts, and gates its readiness on the result.
+//
+// Misconfigurations such as a plugin that does not start or a repository type without a plugin otherwise only
+// surface once a reconciler needs them, as errors on arbitrary objects. Instead, a [Preflight] runs its checks
+// once the manager starts and reports every failed check with an actionable message through its readiness check,
+// so that the controller does not become ready. Failed checks are retried until they pass, as some failures,
+// e.g. an unreachable registry, resolve on their own.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// DefaultRetryInterval is the interval at which failed checks are retried.
+	DefaultRetryInterval = 30 * time.Second
+	// DefaultTimeout is the timeout of a single run of a check.
+	DefaultTimeout = time.Minute
+)
+
+// ErrPending is returned by the readiness check until all checks ran once.
+var ErrPending = errors.New("preflight checks have not completed yet")
+
+// Check is a single preflight check.
+type Check struct {
+	// Name identifies the check in logs and readiness messages.
+	Name string
+	// Run performs the check. Its error should tell how to fix the failure.
+	Run func(ctx context.Context) error
+}
+
+// Preflight runs its checks when it is started, e.g. as runnable of the manager, and retries failed checks
+// until all of them passed. Use ReadyzCheck as readiness check of the manager.
+type Preflight struct {
+	checks        []Check
+	retryInterval time.Duration
+	timeout       time.Duration
+	logger        logr.Logger
+
+	mu sync.Mutex
+	// failures are the errors of the failed checks by name, nil until all checks ran once.
+	failures map[string]error
+}
+
+// Option configures a Preflight.
+type Option func(*Preflight)
+
+// WithRetryInterval sets the interval at which failed checks are retried.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(p *Preflight) {
+		p.retryInterval = interval
+	}
+}
+
+// WithTimeout sets the timeout of a single run of a check.
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Preflight) {
+		p.timeout = timeout
+	}
+}
+
+// WithLogger sets the logger failed and passed checks are logged with.
+func WithLogger(logger logr.Logger) Option {
+	return func(p *Preflight) {
+		p.logger = logger
+	}
+}
+
+// New creates a Preflight running the given checks.
+func New(checks []Check, opts ...Option) *Preflight {
+	p := &Preflight{
+		checks:        checks,
+		retryInterval: DefaultRetryInterval,
+		timeout:       DefaultTimeout,
+		logger:        logr.Discard(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Start runs the checks and retries the failed ones until all passed or ctx is done.
+func (p *Preflight) Start(ctx context.Context) error {
+	pending := p.checks
+	for {
+		failures := make(map[string]error)
+		var failed []Check
+		for _, check := range pending {
+			if err := p.run(ctx, check); err != nil {
+				p.logger.Error(err, "preflight check failed", "check", check.Name, "retryIn", p.retryInterval)
+				failures[check.Name] = err
+				failed = append(failed, check)
+				continue
+			}
+			p.logger.Info("preflight check passed", "check", check.Name)
+		}
+
+		p.mu.Lock()
+		p.failures = failures
+		p.mu.Unlock()
+
+		if len(failed) == 0 {
+			return nil
+		}
+		pending = failed
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(p.retryInterval):
+		}
+	}
+}
+
+func (p *Preflight) run(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	return check.Run(ctx)
+}
+
+// NeedLeaderElection returns false, as every replica has to be ready.
+func (p *Preflight) NeedLeaderElection() bool {
+	return false
+}
+
+// ReadyzCheck fails with ErrPending until the checks ran once, and with the errors of all failed checks
+// afterwards, until they passed.
+func (p *Preflight) ReadyzCheck(_ *http.Request) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures == nil {
+		return ErrPending
+	}
+	var errs []error
+	// report in the order of the checks, so that the message is stable.
+	for _, check := range p.checks {
+		if err, ok := p.failures[check.Name]; ok {
+			errs = append(errs, fmt.Errorf("preflight check %s failed: %w", check.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RepositoryTypeRegistry knows the types of repository specifications served by internal and external plugins,
+// like the component version repository registry of the plugin manager.
+type RepositoryTypeRegistry interface {
+	// GetComponentVersionRepositoryScheme returns the scheme of the types served by internal plugins.
+	GetComponentVersionRepositoryScheme() *runtime.Scheme
+	// GetJSONSchemaForRepositorySpecification fails for types not served by external plugins.
+	GetJSONSchemaForRepositorySpecification(typ runtime.Type) ([]byte, error)
+}
+
+// RepositoryTypes checks that component version repositories of all the given types are served by a plugin.
+func RepositoryTypes(registry RepositoryTypeRegistry, types ...runtime.Type) Check {
+	return Check{
+		Name: "repository-types",
+		Run: func(context.Context) error {
+			var errs []error
+			for _, typ := range types {
+				if registry.GetComponentVersionRepositoryScheme().IsRegistered(typ) {
+					continue
+				}
+				if _, err := registry.GetJSONSchemaForRepositorySpecification(typ); err == nil {
+					continue
+				}
+				errs = append(errs, fmt.Errorf("no plugin serves repositories of type %s, "+
+					"register a plugin for the type or remove it from the required repository types", typ))
+			}
+			return errors.Join(errs...)
+		},
+	}
+}
+
+// PluginStarter starts registered external plugins, like the registries of the plugin manager.
+// Starting a plugin waits until it serves requests, and does nothing if the plugin already runs or
+// is not registered with the starter.
+type PluginStarter interface {
+	StartPlugin(ctx context.Context, id string) error
+}
+
+// Plugins checks that all external plugins, whose IDs are returned by ids, start and respond.
+// Every plugin is started with all starters, as a plugin can be registered in multiple registries.
+func Plugins(ids func() []string, starters ...PluginStarter) Check {
+	return Check{
+		Name: "plugins",
+		Run: func(ctx context.Context) error {
+			var errs []error
+			for _, id := range ids() {
+				for _, starter := range starters {
+					if err := starter.StartPlugin(ctx, id); err != nil {
+						errs = append(errs, fmt.Errorf("plugin %s does not respond, check its binary and logs: %w", id, err))
+						break
+					}
+				}
+			}
+			return errors.Join(errs...)
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package preflight_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/kubernetes/controller/internal/preflight"
+)
+
+func TestPreflight(t *testing.T) {
+	r := require.New(t)
+
+	var attempts atomic.Int32
+	p := preflight.New([]preflight.Check{
+		{Name: "ok", Run: func(context.Context) error { return nil }},
+		{Name: "flaky", Run: func(context.Context) error {
+			if attempts.Add(1) < 3 {
+				return errors.New("registry not reachable")
+			}
+			return nil
+		}},
+	}, preflight.WithRetryInterval(time.Millisecond))
+
+	r.ErrorIs(p.ReadyzCheck(&http.Request{}), preflight.ErrPending)
+	r.False(p.NeedLeaderElection())
+
+	r.NoError(p.Start(t.Context()))
+	r.EqualValues(3, attempts.Load(), "only the failed check is retried until it passes")
+	r.NoError(p.ReadyzCheck(&http.Request{}))
+}
+
+func TestPreflight_Failing(t *testing.T) {
+	r := require.New(t)
+
+	p := preflight.New([]preflight.Check{
+		{Name: "first", Run: func(context.Context) error { return errors.New("first failure") }},
+		{Name: "ok", Run: func(context.Context) error { return nil }},
+		{Name: "second", Run: func(context.Context) error { return errors.New("second failure") }},
+	}, preflight.WithRetryInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() {
+		done <- p.Start(ctx)
+	}()
+
+	r.Eventually(func() bool {
+		return !errors.Is(p.ReadyzCheck(&http.Request{}), preflight.ErrPending)
+	}, time.Second, time.Millisecond)
+	r.EqualError(p.ReadyzCheck(&http.Request{}),
+		"preflight check first failed: first failure\npreflight check second failed: second failure")
+
+	cancel()
+	r.NoError(<-done)
+}
+
+type fakeRegistry struct {
+	scheme   *runtime.Scheme
+	external []runtime.Type
+}
+
+func (f *fakeRegistry) GetComponentVersionRepositoryScheme() *runtime.Scheme {
+	return f.scheme
+}
+
+func (f *fakeRegistry) GetJSONSchemaForRepositorySpecification(typ runtime.Type) ([]byte, error) {
+	for _, t := range f.external {
+		if t == typ {
+			return []byte(`{}`), nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+type fakeSpec struct {
+	Type runtime.Type `json:"type"`
+}
+
+func (f *fakeSpec) GetType() runtime.Type        { return f.Type }
+func (f *fakeSpec) SetType(typ runtime.Type)     { f.Type = typ }
+func (f *fakeSpec) DeepCopyTyped() runtime.Typed { c := *f; return &c }
+
+func TestRepositoryTypes(t *testing.T) {
+	r := require.New(t)
+
+	internal := runtime.NewVersionedType("OCIRepository", "v1")
+	external := runtime.NewVersionedType("S3Repository", "v1")
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterWithAlias(&fakeSpec{}, internal)
+	registry := &fakeRegistry{scheme: scheme, external: []runtime.Type{external}}
+
+	r.NoError(preflight.RepositoryTypes(registry, internal, external).Run(t.Context()))
+
+	missing := runtime.NewVersionedType("CommonTransportFormat", "v1")
+	err := preflight.RepositoryTypes(registry, internal, missing).Run(t.Context())
+	r.ErrorContains(err, "no plugin serves repositories of type CommonTransportFormat/v1")
+}
+
+type fakeStarter struct {
+	failing map[string]error
+	started []string
+}
+
+func (f *fakeStarter) StartPlugin(_ context.Context, id string) error {
+	f.started = append(f.started, id)
+	return f.failing[id]
+}
+
+func TestPlugins(t *testing.T) {
+	r := require.New(t)
+
+	ids := func() []string { return []string{"good", "bad"} }
+	repositories := &fakeStarter{failing: map[string]error{"bad": errors.New("timed out waiting for plugin")}}
+	credentials := &fakeStarter{}
+
+	err := preflight.Plugins(ids, repositories, credentials).Run(t.Context())
+	r.EqualError(err, "plugin bad does not respond, check its binary and logs: timed out waiting for plugin")
+	r.Equal([]string{"good", "bad"}, repositories.started)
+	r.Equal([]string{"good"}, credentials.started, "failed plugins are not started with further starters")
+}
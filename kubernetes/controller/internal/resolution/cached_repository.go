@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"sort"
+	"strconv"
 
 	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
 	"github.com/go-logr/logr"
@@ -32,6 +33,8 @@ type CacheBackedRepository struct {
 	cfg      *configuration.Configuration
 	// verifications are used to verify against component version signatures and used as a cache key.
 	verifications []verification.Verification
+	// threshold is the number of verifications that must be verified, all if zero, and used as part of the cache key.
+	threshold int
 	// digest is used to verify the integrity of a referenced component version and is used as part of the cache key.
 	digest *v2.Digest
 	// signingRegistry holds all plugins that implement capabilities to verify signatures and is used during resolution
@@ -46,7 +49,10 @@ type CacheBackedRepository struct {
 	baseRepoSpec  runtime.Typed
 }
 
-var _ repository.ComponentVersionRepository = (*CacheBackedRepository)(nil)
+var (
+	_ repository.ComponentVersionRepository     = (*CacheBackedRepository)(nil)
+	_ repository.VerifiedComponentVersionGetter = (*CacheBackedRepository)(nil)
+)
 
 // AddComponentVersion adds a component version to the underlying repository.
 func (c *CacheBackedRepository) AddComponentVersion(ctx context.Context, desc *descriptor.Descriptor) error {
@@ -61,6 +67,14 @@ func (c *CacheBackedRepository) AddComponentVersion(ctx context.Context, desc *d
 // This function is async. First call to this function will return a resolution.ErrResolutionInProgress error.
 // Second call, once the resolution succeeds, will return a cached result with a default TTL.
 func (c *CacheBackedRepository) GetComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, error) {
+	desc, _, err := c.GetVerifiedComponentVersion(ctx, component, version)
+	return desc, err
+}
+
+// GetVerifiedComponentVersion retrieves a component version like GetComponentVersion together with the
+// verification it passed against the configured verifications or digest. The verification is nil if neither
+// are configured.
+func (c *CacheBackedRepository) GetVerifiedComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, *repository.Verification, error) {
 	var configHash []byte
 	if c.cfg != nil {
 		configHash = c.cfg.Hash
@@ -73,35 +87,36 @@ func (c *CacheBackedRepository) GetComponentVersion(ctx context.Context, compone
 		// the base repository.
 		// The verifications and digests are part of the cache-key to ensure that verified or integrity-checked
 		// component versions are cached under another cache-key than non-verified ones.
-		return buildCacheKey(configHash, c.baseRepoSpec, component, version, c.verifications, c.digest)
+		return buildCacheKey(configHash, c.baseRepoSpec, component, version, c.verifications, c.threshold, c.digest)
 	}
 
 	repo, err := c.resolver.GetComponentVersionRepositoryForComponent(ctx, component, version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repository for component %s:%s: %w", component, version, err)
+		return nil, nil, fmt.Errorf("failed to get repository for component %s:%s: %w", component, version, err)
 	}
 
 	wpOpts := workerpool.ResolveOptions{
-		Component:       component,
-		Version:         version,
-		Verifications:   c.verifications,
-		Digest:          c.digest,
-		SigningRegistry: c.signingRegistry,
-		Repository:      repo,
-		KeyFunc:         keyFunc,
-		Requester:       c.requesterFunc(),
+		Component:             component,
+		Version:               version,
+		Verifications:         c.verifications,
+		VerificationThreshold: c.threshold,
+		Digest:                c.digest,
+		SigningRegistry:       c.signingRegistry,
+		Repository:            repo,
+		KeyFunc:               keyFunc,
+		Requester:             c.requesterFunc(),
 	}
 
-	desc, err := c.workerPool.GetComponentVersion(ctx, wpOpts)
+	cv, err := c.workerPool.GetVerifiedComponentVersion(ctx, wpOpts)
 	if err != nil {
-		if errors.Is(err, workerpool.ErrNotSafelyDigestible) {
-			return desc, err
+		if errors.Is(err, workerpool.ErrNotSafelyDigestible) && cv != nil {
+			return cv.Descriptor, nil, err
 		}
 
-		return nil, err
+		return nil, nil, err
 	}
 
-	return desc, nil
+	return cv.Descriptor, cv.Verification, nil
 }
 
 // ListComponentVersions lists all versions of a component.
@@ -168,12 +183,12 @@ func (c *CacheBackedRepository) CheckHealth(ctx context.Context) error {
 }
 
 // buildCacheKey generates a cache key from the configuration hash, repository spec, component, version, verifications,
-// and a digest spec.
-// The verifications and digest spec are included in the cache-key to ensure that different cache entries are created
+// their threshold, and a digest spec.
+// The verifications, threshold, and digest spec are included in the cache-key to ensure that different cache entries are created
 // for verified and unverified component versions of the same kind.
 // It canonicalizes the repository spec, verifications, and digest spec using JCS (RFC 8785) before hashing to ensure
 // consistent keys regardless of field ordering in the JSON representation.
-func buildCacheKey(configHash []byte, repoSpec runtime.Typed, component, version string, verifications []verification.Verification, digestSpec *v2.Digest) (string, error) {
+func buildCacheKey(configHash []byte, repoSpec runtime.Typed, component, version string, verifications []verification.Verification, threshold int, digestSpec *v2.Digest) (string, error) {
 	repoJSON, err := json.Marshal(repoSpec)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal repository spec: %w", err)
@@ -229,6 +244,8 @@ func buildCacheKey(configHash []byte, repoSpec runtime.Typed, component, version
 	_, _ = hasher.Write(sep)
 	_, _ = hasher.Write(canonicalVerificationsJSON)
 	_, _ = hasher.Write(sep)
+	_, _ = hasher.Write([]byte(strconv.Itoa(threshold)))
+	_, _ = hasher.Write(sep)
 	_, _ = hasher.Write(canonicalDigestJSON)
 
 	return fmt.Sprintf("%016x", hasher.Sum64()), nil
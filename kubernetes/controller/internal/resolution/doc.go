@@ -31,6 +31,10 @@
 // no need to requeue the object because the workpool will enqueue reconcile events for the given
 // objects using RequesterFunc function.
 //
+// If the worker pool is configured with a [workerpool.InFlightStore], resolutions in progress survive a
+// restart of the controller: on start, the requesters of resolutions enqueued within the recovery window
+// are re-triggered, so that they request the resolution again right away.
+//
 // # Cache Keys and Verification Separation
 //
 // Cache keys are an FNV-1a hash of: configHash | repoSpec | component | version | verifications | digest.
@@ -45,11 +49,13 @@
 //
 // # Error Handling
 //
-// Controllers must handle two sentinel errors from GetComponentVersion:
+// Controllers must handle three sentinel errors from GetComponentVersion:
 //
 //   - [ErrResolutionInProgress]: resolution is queued and waiting to be done.
 //   - [workerpool.ErrNotSafelyDigestible]: the component was resolved but cannot be digested.
 //     The descriptor is still returned alongside the error.
+//   - [workerpool.ErrQuotaExceeded]: the namespace of the requester has too many resolutions waiting
+//     (see [workerpool.NamespaceQuota]). The object must be requeued, as no event will be sent for it.
 //
 // All other errors indicate resolution failure (network, verification mismatch, etc.).
 //
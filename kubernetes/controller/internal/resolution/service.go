@@ -2,7 +2,10 @@ package resolution
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
 	"k8s.io/utils/lru"
@@ -10,9 +13,11 @@ import (
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	ocirepository "ocm.software/open-component-model/bindings/go/oci/spec/repository"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/componentversionrepository"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/signinghandler"
 	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
 	"ocm.software/open-component-model/kubernetes/controller/internal/setup"
 	"ocm.software/open-component-model/kubernetes/controller/internal/verification"
@@ -56,9 +61,34 @@ type RepositoryOptions struct {
 	RequesterFunc  func() workerpool.RequesterInfo
 	// Verifications are used to verify against component version signatures and used a cache key.
 	Verifications []verification.Verification
+	// VerificationThreshold is the number of Verifications that must be verified, all if zero.
+	// It is used as part of the cache key.
+	VerificationThreshold int
 	// Digest is used to verify the integrity of a referenced component version and is used as part of the cache key.
 	Digest          *v2.Digest
 	SigningRegistry *signinghandler.SigningRegistry
+	// Plugin pins the plugin serving the repository types it supports and is used as part of the cache key.
+	// See NewPluginSelection.
+	Plugin *componentversionrepository.PluginSelection
+}
+
+// NewPluginSelection translates the plugin pinned by an object in namespace into a selection of the plugin manager.
+// Isolated plugins run in a process per namespace and effective configuration cfg. It returns nil if plugin is nil.
+func NewPluginSelection(namespace string, plugin *v1alpha1.PluginSelection, cfg *configuration.Configuration) *componentversionrepository.PluginSelection {
+	if plugin == nil {
+		return nil
+	}
+	selection := &componentversionrepository.PluginSelection{
+		ID:      plugin.Name,
+		Version: plugin.Version,
+	}
+	if plugin.Isolate {
+		selection.Isolation = namespace
+		if cfg != nil && cfg.Config != nil {
+			selection.ConfigTypes = cfg.Config.Configurations
+		}
+	}
+	return selection
 }
 
 // NewCacheBackedRepository creates a new cache-backed repository wrapper.
@@ -78,6 +108,14 @@ func (r *Resolver) NewCacheBackedRepository(ctx context.Context, opts *Repositor
 	if baseRepoSpec == nil {
 		return nil, fmt.Errorf("base repository spec is required")
 	}
+	if opts.Plugin != nil {
+		// the plugin serving the repository is part of the configuration hash, and
+		// therefore of all cache keys, so that resolutions of different plugins are not shared.
+		var err error
+		if cfg, err = withPluginSelection(cfg, opts.Plugin); err != nil {
+			return nil, err
+		}
+	}
 	var configHash []byte
 	if cfg != nil {
 		configHash = cfg.Hash
@@ -90,7 +128,7 @@ func (r *Resolver) NewCacheBackedRepository(ctx context.Context, opts *Repositor
 	if cached, ok := r.repoCache.Get(cacheKey); ok {
 		provider = cached.(resolvers.ComponentVersionRepositoryResolver)
 	} else {
-		provider, err = r.createResolver(ctx, opts.RepositorySpec, cfg)
+		provider, err = r.createResolver(ctx, opts.RepositorySpec, cfg, opts.Plugin)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create provider: %w", err)
 		}
@@ -105,6 +143,7 @@ func (r *Resolver) NewCacheBackedRepository(ctx context.Context, opts *Repositor
 		requesterFunc:   requesterFunc,
 		baseRepoSpec:    baseRepoSpec,
 		verifications:   opts.Verifications,
+		threshold:       opts.VerificationThreshold,
 		digest:          opts.Digest,
 		signingRegistry: opts.SigningRegistry,
 	}, nil
@@ -112,7 +151,7 @@ func (r *Resolver) NewCacheBackedRepository(ctx context.Context, opts *Repositor
 
 // createResolver creates a resolver based on the configuration.
 // The resolver handles resolving the appropriate repository for each component.
-func (r *Resolver) createResolver(ctx context.Context, spec runtime.Typed, cfg *configuration.Configuration) (resolvers.ComponentVersionRepositoryResolver, error) {
+func (r *Resolver) createResolver(ctx context.Context, spec runtime.Typed, cfg *configuration.Configuration, plugin *componentversionrepository.PluginSelection) (resolvers.ComponentVersionRepositoryResolver, error) {
 	if spec == nil {
 		return nil, fmt.Errorf("repository spec is required")
 	}
@@ -120,11 +159,19 @@ func (r *Resolver) createResolver(ctx context.Context, spec runtime.Typed, cfg *
 	opts := resolvers.Options{
 		RepoProvider: r.pluginManager.ComponentVersionRepositoryRegistry,
 	}
+	if plugin != nil {
+		provider, err := r.pluginManager.ComponentVersionRepositoryRegistry.Select(*plugin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select plugin %q: %w", plugin.ID, err)
+		}
+		opts.RepoProvider = provider
+	}
 
-	if cfg != nil {
+	if cfg != nil && cfg.Config != nil {
 		credGraph, err := setup.NewCredentialGraph(ctx, cfg.Config, setup.CredentialGraphOptions{
 			PluginManager: r.pluginManager,
 			Logger:        r.logger,
+			Namespace:     cfg.Namespace,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create credential graph: %w", err)
@@ -142,3 +189,20 @@ func (r *Resolver) createResolver(ctx context.Context, spec runtime.Typed, cfg *
 
 	return resolvers.New(ctx, opts, spec)
 }
+
+// withPluginSelection returns a copy of cfg whose hash also covers the plugin selection.
+func withPluginSelection(cfg *configuration.Configuration, plugin *componentversionrepository.PluginSelection) (*configuration.Configuration, error) {
+	selection, err := json.Marshal(plugin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin selection: %w", err)
+	}
+	result := &configuration.Configuration{}
+	if cfg != nil {
+		result.Config = cfg.Config
+		result.Hash = cfg.Hash
+		result.Namespace = cfg.Namespace
+	}
+	hash := sha256.Sum256(append(slices.Clone(result.Hash), selection...))
+	result.Hash = hash[:]
+	return result, nil
+}
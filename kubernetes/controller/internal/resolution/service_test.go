@@ -18,10 +18,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	ocirepository "ocm.software/open-component-model/bindings/go/oci/spec/repository"
 	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/componentversionrepository"
 	"ocm.software/open-component-model/bindings/go/repository"
 	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
@@ -334,6 +336,39 @@ func TestResolveComponentVersion_MissingConfig(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNewPluginSelection(t *testing.T) {
+	r := require.New(t)
+	cfg := &configuration.Configuration{
+		Config: &genericv1.Config{
+			Configurations: []*ocmruntime.Raw{{Type: ocmruntime.NewVersionedType("credentials.config.ocm.software", "v1")}},
+		},
+	}
+
+	r.Nil(resolution.NewPluginSelection("tenant", nil, cfg))
+	r.Equal(&componentversionrepository.PluginSelection{ID: "oci", Version: "v1.0.0"},
+		resolution.NewPluginSelection("tenant", &v1alpha1.PluginSelection{Name: "oci", Version: "v1.0.0"}, cfg))
+	r.Equal(&componentversionrepository.PluginSelection{ID: "oci", Isolation: "tenant", ConfigTypes: cfg.Config.Configurations},
+		resolution.NewPluginSelection("tenant", &v1alpha1.PluginSelection{Name: "oci", Isolate: true}, cfg))
+}
+
+func TestNewCacheBackedRepository_UnknownPlugin(t *testing.T) {
+	ctx := t.Context()
+	logger := logr.Discard()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	env := setupTestEnvironment(t, fake.NewClientBuilder().WithScheme(scheme).Build(), &logger)
+	t.Cleanup(func() {
+		require.NoError(t, env.Close(context.Background()))
+	})
+
+	_, err := env.Resolver.NewCacheBackedRepository(ctx, &resolution.RepositoryOptions{
+		RepositorySpec: &ociv1.Repository{BaseUrl: "localhost:5000/test"},
+		Plugin:         resolution.NewPluginSelection("default", &v1alpha1.PluginSelection{Name: "unknown"}, nil),
+	})
+	require.ErrorIs(t, err, componentversionrepository.ErrPluginNotFound)
+}
+
 func TestResolveComponentVersionDeduplication(t *testing.T) {
 	synctest.Test(t, func(t *testing.T) {
 		ctx := context.Background()
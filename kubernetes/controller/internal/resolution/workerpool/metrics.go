@@ -14,6 +14,8 @@ func init() {
 		InProgressGauge,
 		ResolutionDurationHistogram,
 		EventChannelDropsTotal,
+		QuotaRejectionsTotal,
+		RecoveredResolutionsTotal,
 	)
 }
 
@@ -30,6 +32,10 @@ const (
 	ResolutionDurationHistogramLabel = "resolution_duration_seconds"
 	// EventChannelDropsLabel tracks the number of times events could not be emitted due to channel overflow.
 	EventChannelDropsLabel = "event_channel_drops"
+	// QuotaRejectionsLabel tracks the number of resolutions rejected because a namespace exceeded its quota.
+	QuotaRejectionsLabel = "quota_rejections"
+	// RecoveredResolutionsLabel tracks the number of in-flight resolutions recovered after a restart.
+	RecoveredResolutionsLabel = "recovered_resolutions"
 	// MetricsNamespace defines the namespace of all the resolution metrics.
 	MetricsNamespace = "ocm_system"
 	// OcmComponent is the name of the component registering for these metrics.
@@ -43,6 +49,17 @@ const (
 	VersionLabel = "version"
 	// VerificationStateLabel is the name of the label for the verification state of a resolved component version.
 	VerificationStateLabel = "verification_state"
+	// NamespaceLabel is the name of the label for the namespace of the requester of a resolution.
+	NamespaceLabel = "namespace"
+	// RecoveryOutcomeLabel is the name of the label for the outcome of the recovery of an in-flight resolution.
+	RecoveryOutcomeLabel = "outcome"
+)
+
+const (
+	// RecoveryOutcomeResumed is the outcome of a recovered resolution whose requesters were re-triggered.
+	RecoveryOutcomeResumed = "resumed"
+	// RecoveryOutcomeInvalidated is the outcome of a recovered resolution outside of the recovery window.
+	RecoveryOutcomeInvalidated = "invalidated"
 )
 
 // CacheMissCounterTotal counts the number of times a cache miss occurred.
@@ -101,3 +118,23 @@ var EventChannelDropsTotal = metrics.MustRegisterCounterVec(
 	"Number of times resolution events could not be emitted due to channel overflow.",
 	ComponentLabel, VersionLabel, VerificationStateLabel,
 )
+
+// QuotaRejectionsTotal counts the number of resolutions rejected because a namespace exceeded its quota.
+// [namespace].
+var QuotaRejectionsTotal = metrics.MustRegisterCounterVec(
+	MetricsNamespace,
+	OcmComponent,
+	QuotaRejectionsLabel,
+	"Number of resolutions rejected because the namespace of the requester exceeded its quota.",
+	NamespaceLabel,
+)
+
+// RecoveredResolutionsTotal counts the in-flight resolutions recovered after a restart.
+// [outcome].
+var RecoveredResolutionsTotal = metrics.MustRegisterCounterVec(
+	MetricsNamespace,
+	OcmComponent,
+	RecoveredResolutionsLabel,
+	"Number of in-flight resolutions recovered after a restart of the controller.",
+	RecoveryOutcomeLabel,
+)
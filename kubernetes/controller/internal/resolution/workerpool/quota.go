@@ -0,0 +1,133 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrQuotaExceeded is returned when a resolution is rejected because the namespace of its requester
+// exceeded its queue quota.
+var ErrQuotaExceeded = errors.New("namespace resolution quota exceeded")
+
+// NamespaceQuota limits the share of the worker pool a single namespace can use, so that a
+// misbehaving tenant cannot occupy all workers and registry bandwidth in a shared cluster.
+// A resolution is accounted to the namespace of the requester that enqueued it.
+// Zero values disable the respective limit.
+type NamespaceQuota struct {
+	// MaxConcurrent is the maximum number of resolutions of a namespace processed at the same time.
+	// Further resolutions of the namespace are deferred without occupying a worker until one of
+	// the running resolutions of the namespace completes.
+	MaxConcurrent int
+	// MaxQueued is the maximum number of resolutions of a namespace waiting to be processed.
+	// Further resolutions of the namespace are rejected with ErrQuotaExceeded.
+	MaxQueued int
+}
+
+// namespaceUsage is the usage of the quota of a single namespace.
+type namespaceUsage struct {
+	// queued is the number of resolutions waiting to be processed, including deferred ones.
+	queued int
+	// running is the number of resolutions currently processed by workers.
+	running int
+	// deferred are the resolutions picked up by workers while the namespace was at its concurrency limit.
+	deferred []*WorkItem
+}
+
+// quotas tracks the usage of the namespace quotas of the worker pool.
+type quotas struct {
+	NamespaceQuota
+	mu    sync.Mutex
+	usage map[string]*namespaceUsage
+}
+
+func newQuotas(quota NamespaceQuota) *quotas {
+	return &quotas{
+		NamespaceQuota: quota,
+		usage:          make(map[string]*namespaceUsage),
+	}
+}
+
+func namespaceOf(item *WorkItem) string {
+	return item.Opts.Requester.NamespacedName.Namespace
+}
+
+// reserve accounts a resolution about to be enqueued to the namespace.
+// It returns ErrQuotaExceeded if the namespace has no queue quota left.
+func (q *quotas) reserve(namespace string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	usage := q.get(namespace)
+	if q.MaxQueued > 0 && usage.queued >= q.MaxQueued {
+		return fmt.Errorf("%w: namespace %q has %d resolutions waiting (limit %d)",
+			ErrQuotaExceeded, namespace, usage.queued, q.MaxQueued)
+	}
+	usage.queued++
+
+	return nil
+}
+
+// unreserve releases a reservation of a resolution that could not be enqueued.
+func (q *quotas) unreserve(namespace string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.get(namespace).queued--
+	q.cleanup(namespace)
+}
+
+// start is called by a worker that picked up the item. It returns false if the namespace of the item
+// is at its concurrency limit, in which case the item is deferred until a running resolution of the
+// namespace finishes.
+func (q *quotas) start(item *WorkItem) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	usage := q.get(namespaceOf(item))
+	if q.MaxConcurrent > 0 && usage.running >= q.MaxConcurrent {
+		usage.deferred = append(usage.deferred, item)
+		return false
+	}
+	usage.queued--
+	usage.running++
+
+	return true
+}
+
+// finish is called by a worker that processed the item. It returns the next deferred item of the
+// namespace, which the worker must process next, or nil if there is none.
+func (q *quotas) finish(item *WorkItem) *WorkItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	namespace := namespaceOf(item)
+	usage := q.get(namespace)
+	if len(usage.deferred) > 0 {
+		next := usage.deferred[0]
+		usage.deferred = usage.deferred[1:]
+		usage.queued--
+
+		return next
+	}
+	usage.running--
+	q.cleanup(namespace)
+
+	return nil
+}
+
+func (q *quotas) get(namespace string) *namespaceUsage {
+	usage, ok := q.usage[namespace]
+	if !ok {
+		usage = &namespaceUsage{}
+		q.usage[namespace] = usage
+	}
+
+	return usage
+}
+
+func (q *quotas) cleanup(namespace string) {
+	if usage := q.usage[namespace]; usage.queued == 0 && usage.running == 0 {
+		delete(q.usage, namespace)
+	}
+}
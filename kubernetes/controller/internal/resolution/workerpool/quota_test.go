@@ -0,0 +1,46 @@
+package workerpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestQuotas(t *testing.T) {
+	r := require.New(t)
+	q := newQuotas(NamespaceQuota{MaxConcurrent: 1, MaxQueued: 2})
+	item := func(namespace, name string) *WorkItem {
+		return &WorkItem{key: name, Opts: ResolveOptions{Requester: RequesterInfo{
+			NamespacedName: types.NamespacedName{Namespace: namespace, Name: name},
+		}}}
+	}
+	first, second, third := item("tenant", "first"), item("tenant", "second"), item("tenant", "third")
+
+	r.NoError(q.reserve("tenant"))
+	r.True(q.start(first))
+
+	r.NoError(q.reserve("tenant"))
+	r.NoError(q.reserve("tenant"))
+	r.False(q.start(second), "second must be deferred while first is running")
+	r.False(q.start(third), "third must be deferred while first is running")
+	r.ErrorIs(q.reserve("tenant"), ErrQuotaExceeded)
+	r.NoError(q.reserve("other"), "quotas are per namespace")
+	q.unreserve("other")
+
+	r.Same(second, q.finish(first))
+	r.NoError(q.reserve("tenant"), "starting a deferred item releases its queue quota")
+	q.unreserve("tenant")
+	r.Same(third, q.finish(second))
+	r.Nil(q.finish(third))
+	r.Empty(q.usage, "usage of idle namespaces must be released")
+}
+
+func TestQuotas_Unlimited(t *testing.T) {
+	r := require.New(t)
+	q := newQuotas(NamespaceQuota{})
+	for range 100 {
+		r.NoError(q.reserve(""))
+		r.True(q.start(&WorkItem{}))
+	}
+}
@@ -0,0 +1,200 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultRecoveryWindow is the default age up to which in-flight resolutions are recovered after a restart.
+const DefaultRecoveryWindow = 5 * time.Minute
+
+// InFlightResolution is a resolution that was in progress, persisted by an InFlightStore so that its
+// requesters can be re-triggered after a restart of the controller.
+type InFlightResolution struct {
+	// Key is the cache key of the resolution.
+	Key string `json:"key"`
+	// Component is the name of the resolved component.
+	Component string `json:"component"`
+	// Version is the resolved version of the component.
+	Version string `json:"version"`
+	// Requesters are the objects waiting for the resolution.
+	Requesters []types.NamespacedName `json:"requesters"`
+	// Enqueued is the time the resolution was enqueued.
+	Enqueued time.Time `json:"enqueued"`
+}
+
+// InFlightStore persists the in-flight resolutions of a worker pool across restarts.
+type InFlightStore interface {
+	// Save replaces the persisted in-flight resolutions.
+	Save(ctx context.Context, resolutions []InFlightResolution) error
+	// Load returns the persisted in-flight resolutions. It returns no resolutions if nothing was persisted yet.
+	Load(ctx context.Context) ([]InFlightResolution, error)
+}
+
+// FileInFlightStore is an InFlightStore keeping the in-flight resolutions as json in a file.
+// The file is replaced atomically, so that it is never read partially written.
+// To survive a restart of the controller container, the file must be located on a volume outliving
+// the container, e.g. an emptyDir volume of the pod.
+type FileInFlightStore struct {
+	// Path is the path of the file.
+	Path string
+}
+
+var _ InFlightStore = (*FileInFlightStore)(nil)
+
+func (s *FileInFlightStore) Save(_ context.Context, resolutions []InFlightResolution) (err error) {
+	data, err := json.Marshal(resolutions)
+	if err != nil {
+		return fmt.Errorf("failed to encode in-flight resolutions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for in-flight resolutions: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create file for in-flight resolutions: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(tmp.Name()))
+		}
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		return errors.Join(fmt.Errorf("failed to write in-flight resolutions: %w", err), tmp.Close())
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write in-flight resolutions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return fmt.Errorf("failed to replace in-flight resolutions: %w", err)
+	}
+	return nil
+}
+
+func (s *FileInFlightStore) Load(_ context.Context) ([]InFlightResolution, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read in-flight resolutions: %w", err)
+	}
+	var resolutions []InFlightResolution
+	if err := json.Unmarshal(data, &resolutions); err != nil {
+		return nil, fmt.Errorf("failed to decode in-flight resolutions: %w", err)
+	}
+	return resolutions, nil
+}
+
+// recoverInFlight loads the resolutions that were in flight when the controller stopped. The requesters
+// of resolutions enqueued within the recovery window are re-triggered, so that they request the resolution
+// again instead of waiting for their next reconciliation. Older resolutions are invalidated, as their
+// requesters have been reconciled in the meantime.
+// The persisted resolutions are replaced by the resolutions currently in flight afterward.
+// Recovery is best effort, failures are logged and do not prevent the worker pool from starting.
+func (wp *WorkerPool) recoverInFlight(ctx context.Context) {
+	logger := wp.Logger.WithValues("recoveryWindow", wp.RecoveryWindow)
+
+	resolutions, err := wp.InFlightStore.Load(ctx)
+	if err != nil {
+		logger.Error(err, "failed to load in-flight resolutions, skipping recovery")
+		return
+	}
+
+	var requesters []RequesterInfo
+	for _, resolution := range resolutions {
+		if age := time.Since(resolution.Enqueued); age > wp.RecoveryWindow {
+			RecoveredResolutionsTotal.WithLabelValues(RecoveryOutcomeInvalidated).Inc()
+			logger.V(1).Info("invalidated in-flight resolution outside of recovery window",
+				"component", resolution.Component,
+				"version", resolution.Version,
+				"age", age)
+			continue
+		}
+		RecoveredResolutionsTotal.WithLabelValues(RecoveryOutcomeResumed).Inc()
+		for _, requester := range resolution.Requesters {
+			info := RequesterInfo{NamespacedName: requester}
+			if !slices.Contains(requesters, info) {
+				requesters = append(requesters, info)
+			}
+		}
+	}
+	if len(resolutions) > 0 {
+		logger.Info("recovered in-flight resolutions", "resolutions", len(resolutions), "requesters", len(requesters))
+	}
+
+	if len(requesters) > 0 {
+		wp.subscribersMu.RLock()
+		for _, ch := range wp.subscribers {
+			select {
+			case ch <- requesters:
+			default:
+				logger.Info("dropped recovery event, subscriber buffer full", "requesterCount", len(requesters))
+			}
+		}
+		wp.subscribersMu.RUnlock()
+	}
+
+	if err := wp.InFlightStore.Save(ctx, wp.inFlight()); err != nil {
+		logger.Error(err, "failed to persist in-flight resolutions")
+	}
+}
+
+// persistInFlight saves the in-flight resolutions whenever they change until ctx is canceled.
+func (wp *WorkerPool) persistInFlight(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-wp.inFlightChanged:
+			if err := wp.InFlightStore.Save(ctx, wp.inFlight()); err != nil && ctx.Err() == nil {
+				wp.Logger.Error(err, "failed to persist in-flight resolutions")
+			}
+		}
+	}
+}
+
+// markInFlightChanged signals persistInFlight that the in-flight resolutions changed.
+// Changes signaled while a save is running are coalesced into a single save.
+func (wp *WorkerPool) markInFlightChanged() {
+	if wp.InFlightStore == nil {
+		return
+	}
+	select {
+	case wp.inFlightChanged <- struct{}{}:
+	default:
+	}
+}
+
+// inFlight returns a snapshot of the resolutions in progress.
+func (wp *WorkerPool) inFlight() []InFlightResolution {
+	wp.inProgressMu.Lock()
+	defer wp.inProgressMu.Unlock()
+
+	resolutions := make([]InFlightResolution, 0, len(wp.inProgress))
+	for key, requesters := range wp.inProgress {
+		resolution := InFlightResolution{Key: key}
+		if item, ok := wp.inProgressItems[key]; ok {
+			resolution.Component = item.Opts.Component
+			resolution.Version = item.Opts.Version
+			resolution.Enqueued = item.enqueued
+		}
+		for _, requester := range requesters {
+			resolution.Requesters = append(resolution.Requesters, requester.NamespacedName)
+		}
+		resolutions = append(resolutions, resolution)
+	}
+	slices.SortFunc(resolutions, func(a, b InFlightResolution) int {
+		return a.Enqueued.Compare(b.Enqueued)
+	})
+	return resolutions
+}
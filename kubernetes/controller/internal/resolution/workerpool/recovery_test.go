@@ -0,0 +1,159 @@
+package workerpool_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
+	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
+)
+
+func TestFileInFlightStore(t *testing.T) {
+	r := require.New(t)
+	store := &workerpool.FileInFlightStore{Path: filepath.Join(t.TempDir(), "state", "inflight.json")}
+
+	resolutions, err := store.Load(t.Context())
+	r.NoError(err)
+	r.Empty(resolutions)
+
+	saved := []workerpool.InFlightResolution{{
+		Key:        "key",
+		Component:  "ocm.software/test",
+		Version:    "v1.0.0",
+		Requesters: []types.NamespacedName{{Namespace: "default", Name: "component"}},
+		Enqueued:   time.Now().UTC().Truncate(time.Second),
+	}}
+	r.NoError(store.Save(t.Context(), saved))
+	resolutions, err = store.Load(t.Context())
+	r.NoError(err)
+	r.Equal(saved, resolutions)
+}
+
+func TestWorkerPool_RecoverInFlight(t *testing.T) {
+	r := require.New(t)
+	logger := logr.Discard()
+	store := &workerpool.FileInFlightStore{Path: filepath.Join(t.TempDir(), "inflight.json")}
+
+	fresh := []types.NamespacedName{{Namespace: "default", Name: "a"}, {Namespace: "default", Name: "b"}}
+	r.NoError(store.Save(t.Context(), []workerpool.InFlightResolution{
+		{Key: "fresh", Requesters: fresh, Enqueued: time.Now().Add(-time.Minute)},
+		{Key: "stale", Requesters: []types.NamespacedName{{Namespace: "default", Name: "c"}}, Enqueued: time.Now().Add(-time.Hour)},
+	}))
+
+	wp := workerpool.NewWorkerPool(workerpool.PoolOptions{
+		Logger:         &logger,
+		Cache:          expirable.NewLRU[string, *workerpool.Result](0, nil, 0),
+		InFlightStore:  store,
+		RecoveryWindow: 10 * time.Minute,
+	})
+	events := wp.Subscribe()
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	go func() {
+		_ = wp.Start(ctx)
+	}()
+
+	select {
+	case requesters := <-events:
+		r.ElementsMatch([]workerpool.RequesterInfo{{NamespacedName: fresh[0]}, {NamespacedName: fresh[1]}}, requesters,
+			"only requesters of resolutions within the recovery window are re-triggered")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for recovery event")
+	}
+
+	r.Eventually(func() bool {
+		resolutions, err := store.Load(t.Context())
+		return err == nil && len(resolutions) == 0
+	}, 5*time.Second, 10*time.Millisecond, "recovered resolutions are removed from the store")
+}
+
+func TestWorkerPool_PersistInFlight(t *testing.T) {
+	logger := logr.Discard()
+	requester := workerpool.RequesterInfo{NamespacedName: types.NamespacedName{Namespace: "default", Name: "requester"}}
+
+	setup := func(t *testing.T, release <-chan struct{}) (*workerpool.WorkerPool, *workerpool.FileInFlightStore, workerpool.ResolveOptions) {
+		t.Helper()
+		store := &workerpool.FileInFlightStore{Path: filepath.Join(t.TempDir(), "inflight.json")}
+		wp := workerpool.NewWorkerPool(workerpool.PoolOptions{
+			Logger:        &logger,
+			Cache:         expirable.NewLRU[string, *workerpool.Result](0, nil, 0),
+			InFlightStore: store,
+		})
+		opts := workerpool.ResolveOptions{
+			Component: "ocm.software/test",
+			Version:   "v1.0.0",
+			KeyFunc:   func() (string, error) { return "key", nil },
+			Repository: &mockRepository{
+				GetComponentVersionFn: func(ctx context.Context, _, _ string) (*descriptor.Descriptor, error) {
+					select {
+					case <-release:
+						return &descriptor.Descriptor{}, nil
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					}
+				},
+			},
+			Requester: requester,
+		}
+		return wp, store, opts
+	}
+
+	inFlight := func(t *testing.T, store *workerpool.FileInFlightStore) []workerpool.InFlightResolution {
+		t.Helper()
+		resolutions, err := store.Load(t.Context())
+		require.NoError(t, err)
+		return resolutions
+	}
+
+	t.Run("resolutions are persisted while in progress", func(t *testing.T) {
+		r := require.New(t)
+		release := make(chan struct{})
+		wp, store, opts := setup(t, release)
+		ctx, cancel := context.WithCancel(t.Context())
+		t.Cleanup(cancel)
+		go func() {
+			_ = wp.Start(ctx)
+		}()
+
+		_, err := wp.GetComponentVersion(t.Context(), opts)
+		r.ErrorIs(err, resolution.ErrResolutionInProgress)
+		r.Eventually(func() bool {
+			resolutions := inFlight(t, store)
+			return len(resolutions) == 1 &&
+				resolutions[0].Component == opts.Component &&
+				resolutions[0].Requesters[0] == requester.NamespacedName
+		}, 5*time.Second, 10*time.Millisecond)
+
+		close(release)
+		r.Eventually(func() bool {
+			return len(inFlight(t, store)) == 0
+		}, 5*time.Second, 10*time.Millisecond, "finished resolutions are removed from the store")
+	})
+
+	t.Run("interrupted resolutions are persisted on shutdown", func(t *testing.T) {
+		r := require.New(t)
+		wp, store, opts := setup(t, make(chan struct{}))
+		ctx, cancel := context.WithCancel(t.Context())
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- wp.Start(ctx)
+		}()
+
+		_, err := wp.GetComponentVersion(t.Context(), opts)
+		r.ErrorIs(err, resolution.ErrResolutionInProgress)
+		cancel()
+		r.NoError(<-stopped)
+
+		resolutions := inFlight(t, store)
+		r.Len(resolutions, 1)
+		r.Equal("key", resolutions[0].Key)
+	})
+}
@@ -11,18 +11,21 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/golang-lru/v2/expirable"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/signinghandler"
 	"ocm.software/open-component-model/bindings/go/repository"
 	signingv1alpha1 "ocm.software/open-component-model/bindings/go/rsa/signing/v1alpha1"
 	rsacredentialsv1 "ocm.software/open-component-model/bindings/go/rsa/spec/credentials/v1"
-	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/signing"
+	"ocm.software/open-component-model/kubernetes/controller/internal/tracing"
 	"ocm.software/open-component-model/kubernetes/controller/internal/verification"
 )
 
@@ -65,6 +68,8 @@ type ResolveOptions struct {
 	Repository repository.ComponentVersionRepository
 	// Verifications are used to verify against component version signatures and used a cache key.
 	Verifications []verification.Verification
+	// VerificationThreshold is the number of Verifications that must be verified, all if zero.
+	VerificationThreshold int
 	// Digest is used to verify the integrity of a referenced component version and is used as part of the cache key.
 	Digest          *v2.Digest
 	SigningRegistry *signinghandler.SigningRegistry
@@ -89,6 +94,10 @@ type WorkItem struct {
 	// key is the calculated key that is passed in from the top to avoid
 	// the error handling from the key function later.
 	key string
+	// parent is the span of the reconcile that enqueued the work item. The resolution is traced as its child.
+	parent trace.SpanContext
+	// enqueued is the time the work item was enqueued.
+	enqueued time.Time
 }
 
 // PoolOptions configures the worker pool.
@@ -100,12 +109,20 @@ type PoolOptions struct {
 	// SubscriberBufferSize is the buffer size for each subscriber's event channel.
 	// A larger buffer reduces the probability of dropped events under load.
 	SubscriberBufferSize int
+	// NamespaceQuota limits the resolutions of a single namespace.
+	NamespaceQuota NamespaceQuota
 	// Logger for the worker pool.
 	Logger *logr.Logger
 	// Client for Kubernetes API access.
 	Client client.Reader
 	// Cache for caching.
 	Cache *expirable.LRU[string, *Result]
+	// InFlightStore persists the resolutions in progress, so that their requesters are re-triggered
+	// after a restart instead of waiting for their next reconciliation. Disabled if nil.
+	InFlightStore InFlightStore
+	// RecoveryWindow is the maximum age of persisted resolutions whose requesters are re-triggered on start.
+	// Older resolutions are invalidated. Defaults to DefaultRecoveryWindow.
+	RecoveryWindow time.Duration
 }
 
 // WorkerPool manages a pool of workers that process work items concurrently.
@@ -117,8 +134,14 @@ type WorkerPool struct {
 	subscribers   []chan []RequesterInfo
 	// tracks all requesters per resolution key to make sure that all objects who request this item will
 	// be notified of any change.
-	inProgress  map[string][]RequesterInfo
-	workersDone sync.WaitGroup
+	inProgress map[string][]RequesterInfo
+	// inProgressItems are the work items of the resolutions in progress by key, to persist them.
+	inProgressItems map[string]*WorkItem
+	// inFlightChanged signals that the resolutions in progress need to be persisted.
+	inFlightChanged chan struct{}
+	quotas          *quotas
+	workersDone     sync.WaitGroup
+	persisterDone   sync.WaitGroup
 }
 
 // ErrResolutionInProgress is returned when a component version is being resolved in the background.
@@ -138,11 +161,18 @@ func NewWorkerPool(opts PoolOptions) *WorkerPool {
 		opts.SubscriberBufferSize = 100
 	}
 
+	if opts.RecoveryWindow <= 0 {
+		opts.RecoveryWindow = DefaultRecoveryWindow
+	}
+
 	return &WorkerPool{
-		PoolOptions: opts,
-		workQueue:   make(chan *WorkItem, opts.QueueSize),
-		inProgress:  make(map[string][]RequesterInfo),
-		subscribers: make([]chan []RequesterInfo, 0),
+		PoolOptions:     opts,
+		workQueue:       make(chan *WorkItem, opts.QueueSize),
+		inProgress:      make(map[string][]RequesterInfo),
+		inProgressItems: make(map[string]*WorkItem),
+		inFlightChanged: make(chan struct{}, 1),
+		quotas:          newQuotas(opts.NamespaceQuota),
+		subscribers:     make([]chan []RequesterInfo, 0),
 	}
 }
 
@@ -162,8 +192,20 @@ func (wp *WorkerPool) Subscribe() <-chan []RequesterInfo {
 
 // Start begins the worker pool.
 // This method blocks until the context is canceled to implement graceful shutdown.
+// If an InFlightStore is configured, the requesters of resolutions that were in flight when the worker
+// pool stopped are re-triggered first, and the resolutions in flight are persisted on shutdown.
 func (wp *WorkerPool) Start(ctx context.Context) error {
-	wp.Logger.Info("starting worker pool", "workers", wp.WorkerCount, "queueSize", wp.QueueSize, "subscriberBufferSize", wp.SubscriberBufferSize)
+	wp.Logger.Info("starting worker pool", "workers", wp.WorkerCount, "queueSize", wp.QueueSize, "subscriberBufferSize", wp.SubscriberBufferSize,
+		"namespaceMaxConcurrent", wp.NamespaceQuota.MaxConcurrent, "namespaceMaxQueued", wp.NamespaceQuota.MaxQueued)
+
+	if wp.InFlightStore != nil {
+		wp.recoverInFlight(ctx)
+		wp.persisterDone.Add(1)
+		go func() {
+			defer wp.persisterDone.Done()
+			wp.persistInFlight(ctx)
+		}()
+	}
 
 	for i := range wp.WorkerCount {
 		wp.workersDone.Add(1)
@@ -179,6 +221,14 @@ func (wp *WorkerPool) Start(ctx context.Context) error {
 	go func() {
 		wp.workersDone.Wait()
 
+		// persist the resolutions that did not finish, including the ones still queued.
+		if wp.InFlightStore != nil {
+			wp.persisterDone.Wait()
+			if err := wp.InFlightStore.Save(context.WithoutCancel(ctx), wp.inFlight()); err != nil {
+				wp.Logger.Error(err, "failed to persist in-flight resolutions on shutdown")
+			}
+		}
+
 		// now it's safe to close the channels
 		close(wp.workQueue)
 
@@ -202,9 +252,26 @@ func (wp *WorkerPool) Start(ctx context.Context) error {
 	}
 }
 
+// ComponentVersion is a resolved component version together with the verification it passed.
+type ComponentVersion struct {
+	Descriptor *descriptor.Descriptor
+	// Verification is nil if neither verifications nor a digest were provided for the resolution.
+	Verification *repository.Verification
+}
+
 // GetComponentVersion retrieves a component version using the worker pool and cache.
 func (wp *WorkerPool) GetComponentVersion(ctx context.Context, opts ResolveOptions) (*descriptor.Descriptor, error) {
-	return resolveWorkRequest[*descriptor.Descriptor](ctx, wp, opts, wp.getComponentVersion)
+	cv, err := wp.GetVerifiedComponentVersion(ctx, opts)
+	if cv == nil {
+		return nil, err
+	}
+	return cv.Descriptor, err
+}
+
+// GetVerifiedComponentVersion retrieves a component version with the verification it passed using the worker pool
+// and cache.
+func (wp *WorkerPool) GetVerifiedComponentVersion(ctx context.Context, opts ResolveOptions) (*ComponentVersion, error) {
+	return resolveWorkRequest[*ComponentVersion](ctx, wp, opts, wp.getComponentVersion)
 }
 
 // resolveWorkRequest is an abstraction in front of the worker queue and resolution logic. It is meant to be called by
@@ -229,7 +296,14 @@ func resolveWorkRequest[T any](ctx context.Context, wp *WorkerPool, opts Resolve
 	// is already in the cache.
 	// With this, it returns, releases in-progress mutex, defer in handleWorkItem continues and removes the
 	// InProgress key.
+	span := trace.SpanFromContext(ctx)
+	resolutionAttrs := trace.WithAttributes(
+		tracing.ComponentKey.String(opts.Component),
+		tracing.VersionKey.String(opts.Version),
+	)
+
 	if cached, ok := wp.Cache.Get(key); ok {
+		span.AddEvent("component version resolution cache hit", resolutionAttrs)
 		CacheHitCounterTotal.WithLabelValues(opts.Component, opts.Version, verificationState(opts.Verifications, opts.Digest)).Inc()
 		// In case of an error of type ErrNotSafelyDigestible we return the cached error and value because we want
 		// to pass through the information that this component version is not safely digestible to the controller
@@ -261,6 +335,7 @@ func resolveWorkRequest[T any](ctx context.Context, wp *WorkerPool, opts Resolve
 
 	// check if already/still in progress
 	if requesters, exists := wp.inProgress[key]; exists {
+		span.AddEvent("component version resolution in progress", resolutionAttrs)
 		// add this requester to the list if not already present (deduplicate)
 		alreadyRequested := false
 		for _, r := range requesters {
@@ -271,6 +346,7 @@ func resolveWorkRequest[T any](ctx context.Context, wp *WorkerPool, opts Resolve
 		}
 		if !alreadyRequested {
 			wp.inProgress[key] = append(requesters, opts.Requester)
+			wp.markInFlightChanged()
 			wp.Logger.V(1).Info("resolution still in progress, added requester",
 				"component", opts.Component,
 				"version", opts.Version,
@@ -291,22 +367,40 @@ func resolveWorkRequest[T any](ctx context.Context, wp *WorkerPool, opts Resolve
 	default:
 	}
 
+	namespace := opts.Requester.NamespacedName.Namespace
+	if err := wp.quotas.reserve(namespace); err != nil {
+		QuotaRejectionsTotal.WithLabelValues(namespace).Inc()
+		span.AddEvent("component version resolution rejected", resolutionAttrs)
+		wp.Logger.V(1).Info("rejected request, namespace quota exceeded",
+			"component", opts.Component,
+			"version", opts.Version,
+			"requester", opts.Requester.NamespacedName)
+
+		return result, fmt.Errorf("cannot resolve %s:%s: %w", opts.Component, opts.Version, err)
+	}
+
 	workItem := &WorkItem{
-		Fn:   fn,
-		Opts: opts,
-		key:  key,
+		Fn:       fn,
+		Opts:     opts,
+		key:      key,
+		parent:   span.SpanContext(),
+		enqueued: time.Now(),
 	}
 
 	select {
 	case wp.workQueue <- workItem:
 		// first requester
 		wp.inProgress[key] = []RequesterInfo{opts.Requester}
+		wp.inProgressItems[key] = workItem
+		wp.markInFlightChanged()
 		InProgressGauge.Set(float64(len(wp.inProgress)))
 		QueueSizeGauge.Set(float64(len(wp.workQueue)))
 		wp.Logger.V(1).Info("enqueued request", "component", opts.Component, "requester", opts.Requester.NamespacedName)
+		span.AddEvent("component version resolution enqueued", resolutionAttrs)
 
 		return result, ErrResolutionInProgress
 	default:
+		wp.quotas.unreserve(namespace)
 		if len(wp.workQueue) == wp.QueueSize {
 			return result, fmt.Errorf("work queue is full; cannot resolve requests for %s", opts.Component)
 		}
@@ -328,7 +422,18 @@ func (wp *WorkerPool) worker(ctx context.Context, id int) {
 			return
 		case item := <-wp.workQueue:
 			QueueSizeGauge.Set(float64(len(wp.workQueue)))
-			wp.handleWorkItem(ctx, &logger, item)
+			if !wp.quotas.start(item) {
+				logger.V(1).Info("deferred work item, namespace at concurrency limit",
+					"key", item.key,
+					"namespace", namespaceOf(item))
+				continue
+			}
+			// process the deferred items of the namespace in this worker as long as there are any,
+			// so that they keep the concurrency slot of the namespace instead of competing for a worker.
+			for item != nil && ctx.Err() == nil {
+				wp.handleWorkItem(ctx, &logger, item)
+				item = wp.quotas.finish(item)
+			}
 		}
 	}
 }
@@ -339,9 +444,24 @@ type workFunc func(ctx context.Context, item ResolveOptions) (any, error)
 func (wp *WorkerPool) handleWorkItem(ctx context.Context, logger *logr.Logger, item *WorkItem) {
 	logger.V(1).Info("processing work item", "key", item.key)
 
+	// the resolution is traced as part of the reconcile that enqueued it, even though that reconcile
+	// has usually finished by the time the work item is picked up.
+	spanCtx, span := tracing.Start(trace.ContextWithSpanContext(ctx, item.parent), "WorkerPool.Resolve",
+		tracing.ComponentKey.String(item.Opts.Component),
+		tracing.VersionKey.String(item.Opts.Version),
+		attribute.String("ocm.verification", verificationState(item.Opts.Verifications, item.Opts.Digest)),
+		attribute.Float64("ocm.resolution.queue_wait_seconds", time.Since(item.enqueued).Seconds()),
+	)
 	start := time.Now()
-	result, err := item.Fn(ctx, item.Opts)
+	result, err := item.Fn(spanCtx, item.Opts)
 	duration := time.Since(start).Seconds()
+	tracing.End(span, err)
+
+	if err != nil && ctx.Err() != nil {
+		// the resolution was interrupted by the shutdown, so it stays in flight to be persisted and recovered.
+		logger.V(1).Info("work item interrupted by shutdown", "key", item.key)
+		return
+	}
 
 	// Track metrics
 	ResolutionDurationHistogram.WithLabelValues(item.Opts.Component, item.Opts.Version, verificationState(item.Opts.Verifications, item.Opts.Digest)).Observe(duration)
@@ -400,6 +520,8 @@ func (wp *WorkerPool) setResult(key string, result any, err error) []RequesterIn
 
 	requesters := slices.Clone(wp.inProgress[key])
 	delete(wp.inProgress, key)
+	delete(wp.inProgressItems, key)
+	wp.markInFlightChanged()
 	InProgressGauge.Set(float64(len(wp.inProgress)))
 	return requesters
 }
@@ -409,10 +531,18 @@ func (wp *WorkerPool) setResult(key string, result any, err error) []RequesterIn
 func (wp *WorkerPool) getComponentVersion(ctx context.Context, opts ResolveOptions) (any, error) {
 	logger := log.FromContext(ctx)
 
-	desc, err := opts.Repository.GetComponentVersion(ctx, opts.Component, opts.Version)
+	repoCtx, span := tracing.Start(ctx, "ComponentVersionRepository.GetComponentVersion",
+		tracing.ComponentKey.String(opts.Component),
+		tracing.VersionKey.String(opts.Version),
+	)
+	desc, manifestDigest, err := getComponentVersionForVerification(repoCtx, opts)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get component version %s:%s: %w", opts.Component, opts.Version, err)
 	}
+	if manifestDigest != nil {
+		ctx = signing.WithArtifactDigest(ctx, *manifestDigest)
+	}
 
 	if opts.Digest != nil && len(opts.Verifications) > 0 {
 		return nil, fmt.Errorf(
@@ -428,24 +558,42 @@ func (wp *WorkerPool) getComponentVersion(ctx context.Context, opts ResolveOptio
 		// If verifications are requested, we need to verify that the component version is safely digestible.
 		// Anything that comes after this will, in case of an error, always be skipped until cache TTL expires
 		if err := signing.IsSafelyDigestible(&desc.Component); err != nil {
-			return desc, fmt.Errorf("%w: %w", ErrNotSafelyDigestible, err)
+			return &ComponentVersion{Descriptor: desc}, fmt.Errorf("%w: %w", ErrNotSafelyDigestible, err)
 		}
 
 		if opts.SigningRegistry == nil {
 			return nil, fmt.Errorf("signing registry is required when verifications are configured")
 		}
 
-		return verifySignatures(ctx, desc, opts.Verifications, opts.SigningRegistry)
+		return verifySignatures(ctx, desc, opts.Verifications, opts.VerificationThreshold, opts.SigningRegistry)
 	default:
 		logger.Info("no digest or verifications provided, skipping integrity and signature verification",
 			"component", opts.Component, "version", opts.Version)
-		return desc, nil
+		return &ComponentVersion{Descriptor: desc}, nil
 	}
 }
 
+// getComponentVersionForVerification gets the component version from the repository. If Sigstore verifications
+// are requested and the repository holds cosign signatures of its manifests, e.g. an OCI registry, the cosign
+// signatures are merged into the descriptor and the digest of the manifest they sign is returned, which the
+// signatures are verified against, see signing.WithArtifactDigest.
+func getComponentVersionForVerification(ctx context.Context, opts ResolveOptions) (*descriptor.Descriptor, *descriptor.Digest, error) {
+	cosignRepo, ok := opts.Repository.(oci.CosignSignatureRepository)
+	if !ok || !slices.ContainsFunc(opts.Verifications, func(v verification.Verification) bool { return v.Sigstore != nil }) {
+		desc, err := opts.Repository.GetComponentVersion(ctx, opts.Component, opts.Version)
+		return desc, nil, err
+	}
+	cosign, err := cosignRepo.GetComponentVersionCosignSignatures(ctx, opts.Component, opts.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return oci.MergeSignatures(cosign.Descriptor, cosign.Signatures), &cosign.ManifestDigest, nil
+}
+
 // verifySignatures performs signature verification for the provided component version descriptor and the list of
-// verifications.
-func verifySignatures(ctx context.Context, desc *descriptor.Descriptor, verifications []verification.Verification, signingRegistry *signinghandler.SigningRegistry) (*descriptor.Descriptor, error) {
+// verifications with signing.VerifyWithPolicy. At least threshold verifications, or all if threshold is zero, must be
+// verified. The returned component version records the verified signatures.
+func verifySignatures(ctx context.Context, desc *descriptor.Descriptor, verifications []verification.Verification, threshold int, signingRegistry *signinghandler.SigningRegistry) (*ComponentVersion, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("verifying signature", "component", desc.Component.Name, "version", desc.Component.Version)
 
@@ -454,47 +602,62 @@ func verifySignatures(ctx context.Context, desc *descriptor.Descriptor, verifica
 		return nil, fmt.Errorf("failed to get signing handler plugin: %w", err)
 	}
 
+	rule := signing.Rule{Name: "verify", Threshold: threshold}
 	for _, v := range verifications {
-		var descSig *descriptor.Signature
-		for i := range desc.Signatures {
-			if desc.Signatures[i].Name == v.Signature {
-				descSig = &desc.Signatures[i]
-				break
+		if cfg := v.SigstoreConfig(); cfg != nil {
+			sigstoreHandler, err := signingRegistry.GetPlugin(ctx, cfg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get sigstore signing handler plugin: %w", err)
 			}
+			rule.Signers = append(rule.Signers, signing.PolicySigner{
+				Signature: v.Signature,
+				Verifier:  sigstoreHandler,
+				Config:    cfg,
+			})
+			continue
 		}
-
-		if descSig == nil {
-			return nil, fmt.Errorf("signature %s not found in component %s", v.Signature, desc.Component.Name)
-		}
-
-		if err := signing.VerifyDigestMatchesDescriptor(ctx, desc, *descSig, slog.New(logr.ToSlogHandler(logger))); err != nil {
-			return nil, fmt.Errorf("digest verification failed for signature %q: %w", descSig.Name, err)
+		signer := signing.PolicySigner{
+			Signature: v.Signature,
+			Verifier:  signingHandler,
+			Config:    &signingv1alpha1.Config{},
 		}
-
-		// TODO: We need to derive the expected credential key from the signature algorithm. This does not look that
-		//       reliable currently. This will probably change, when typed credentials are supported.
-		var credentials runtime.Typed
-		switch signingv1alpha1.SignatureAlgorithm(descSig.Signature.Algorithm) {
-		case signingv1alpha1.AlgorithmRSASSAPSS, signingv1alpha1.AlgorithmRSASSAPKCS1V15:
-			credentials = &rsacredentialsv1.RSACredentials{
-				Type:         rsacredentialsv1.VersionedType,
-				PublicKeyPEM: string(v.PublicKey),
+		idx := slices.IndexFunc(desc.Signatures, func(s descriptor.Signature) bool { return s.Name == v.Signature })
+		if idx >= 0 {
+			// TODO: We need to derive the expected credential key from the signature algorithm. This does not look that
+			//       reliable currently. This will probably change, when typed credentials are supported.
+			descSig := desc.Signatures[idx]
+			switch signingv1alpha1.SignatureAlgorithm(descSig.Signature.Algorithm) {
+			case signingv1alpha1.AlgorithmRSASSAPSS, signingv1alpha1.AlgorithmRSASSAPKCS1V15:
+				signer.Credentials = &rsacredentialsv1.RSACredentials{
+					Type:         rsacredentialsv1.VersionedType,
+					PublicKeyPEM: string(v.PublicKey),
+				}
+			default:
+				return nil, fmt.Errorf("unsupported signature algorithm: %q", descSig.Signature.Algorithm)
 			}
-		default:
-			return nil, fmt.Errorf("unsupported signature algorithm: %q", descSig.Signature.Algorithm)
 		}
+		// signatures missing in the descriptor are reported by the policy, they may not be required.
+		rule.Signers = append(rule.Signers, signer)
+	}
 
-		if err := signingHandler.Verify(ctx, *descSig, &signingv1alpha1.Config{}, credentials); err != nil {
-			return nil, fmt.Errorf("signature verification failed for signature %s: %w", v.Signature, err)
-		}
+	verifyCtx, span := tracing.Start(ctx, "signing.VerifyWithPolicy", attribute.Int("ocm.signature.threshold", threshold))
+	signatures, err := signing.VerifyWithPolicy(verifyCtx, desc, signing.Policy{Rules: []signing.Rule{rule}}, slog.New(logr.ToSlogHandler(logger)))
+	tracing.End(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("signature verification failed for component %s: %w", desc.Component.Name, err)
+	}
+
+	result := &repository.Verification{VerifiedAt: time.Now()}
+	for _, signature := range signatures {
+		result.Signatures = append(result.Signatures, repository.NewSignatureVerification(signature))
 	}
 
-	return desc, nil
+	return &ComponentVersion{Descriptor: desc, Verification: result}, nil
 }
 
 // compareDigest performs integrity verification using the provided digest against a fresh calculated digest of
-// the passed descriptor.
-func compareDigest(ctx context.Context, desc *descriptor.Descriptor, digest *v2.Digest) (*descriptor.Descriptor, error) {
+// the passed descriptor. The returned component version records the verified digest.
+func compareDigest(ctx context.Context, desc *descriptor.Descriptor, digest *v2.Digest) (*ComponentVersion, error) {
 	logger := log.FromContext(ctx)
 
 	logger.Info("verifying integrity with provided digest",
@@ -513,7 +676,10 @@ func compareDigest(ctx context.Context, desc *descriptor.Descriptor, digest *v2.
 			digest.Value, digestDesc.Value)
 	}
 
-	return desc, nil
+	return &ComponentVersion{
+		Descriptor:   desc,
+		Verification: &repository.Verification{Digest: digest, VerifiedAt: time.Now()},
+	}, nil
 }
 
 func verificationState(verifications []verification.Verification, digest *v2.Digest) string {
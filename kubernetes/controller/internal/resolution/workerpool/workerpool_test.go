@@ -19,6 +19,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -395,6 +396,90 @@ func TestWorkerPool_QueueFull(t *testing.T) {
 	assert.GreaterOrEqual(t, queueFullCount.Load(), int32(7), "expected at least 7 requests to fail due to full queue (got %d)", queueFullCount.Load())
 }
 
+func TestWorkerPool_NamespaceQuota(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		r := require.New(t)
+		ctx := t.Context()
+		logger := logr.Discard()
+
+		cache := expirable.NewLRU[string, *workerpool.Result](0, nil, 0)
+		wp := workerpool.NewWorkerPool(workerpool.PoolOptions{
+			WorkerCount: 4,
+			NamespaceQuota: workerpool.NamespaceQuota{
+				MaxConcurrent: 1,
+				MaxQueued:     2,
+			},
+			Logger: &logger,
+			Client: fake.NewClientBuilder().Build(),
+			Cache:  cache,
+		})
+		wpCtx, wpCancel := context.WithCancel(ctx)
+		t.Cleanup(wpCancel)
+		go func() { _ = wp.Start(wpCtx) }()
+
+		release := make(chan struct{})
+		var mu sync.Mutex
+		running := map[string]int{}
+		maxRunning := map[string]int{}
+
+		resolve := func(namespace, component string) error {
+			_, err := wp.GetComponentVersion(ctx, workerpool.ResolveOptions{
+				Component: component,
+				Version:   "v1.0.0",
+				KeyFunc:   func() (string, error) { return namespace + "/" + component, nil },
+				Repository: &mockRepository{
+					GetComponentVersionFn: func(ctx context.Context, component, version string) (*descriptor.Descriptor, error) {
+						mu.Lock()
+						running[namespace]++
+						maxRunning[namespace] = max(maxRunning[namespace], running[namespace])
+						mu.Unlock()
+						<-release
+						mu.Lock()
+						running[namespace]--
+						mu.Unlock()
+
+						return &descriptor.Descriptor{}, nil
+					},
+				},
+				Requester: workerpool.RequesterInfo{
+					NamespacedName: types.NamespacedName{Namespace: namespace, Name: component},
+				},
+			})
+
+			return err
+		}
+
+		r.ErrorIs(resolve("tenant-a", "component-1"), workerpool.ErrResolutionInProgress)
+		synctest.Wait()
+
+		// the running resolution does not count against the queue quota, the deferred ones do
+		r.ErrorIs(resolve("tenant-a", "component-2"), workerpool.ErrResolutionInProgress)
+		r.ErrorIs(resolve("tenant-a", "component-3"), workerpool.ErrResolutionInProgress)
+		synctest.Wait()
+		r.ErrorIs(resolve("tenant-a", "component-4"), workerpool.ErrQuotaExceeded)
+
+		// other namespaces are not affected by the quota of tenant-a
+		r.ErrorIs(resolve("tenant-b", "component-1"), workerpool.ErrResolutionInProgress)
+		synctest.Wait()
+		mu.Lock()
+		r.Equal(map[string]int{"tenant-a": 1, "tenant-b": 1}, running)
+		mu.Unlock()
+
+		close(release)
+		synctest.Wait()
+
+		for _, component := range []string{"component-1", "component-2", "component-3"} {
+			r.NoError(resolve("tenant-a", component), "deferred resolutions must complete")
+		}
+		r.Equal(map[string]int{"tenant-a": 1, "tenant-b": 1}, maxRunning)
+
+		// the quota is released once the resolutions of the namespace completed
+		r.ErrorIs(resolve("tenant-a", "component-4"), workerpool.ErrResolutionInProgress)
+		synctest.Wait()
+		wpCancel()
+	})
+}
+
 func TestWorkerPool_ContextCancellation(t *testing.T) {
 	synctest.Test(t, func(t *testing.T) {
 		ctx, cancel := context.WithCancel(t.Context())
@@ -17,6 +17,9 @@ import (
 type CredentialGraphOptions struct {
 	PluginManager *manager.PluginManager
 	Logger        *logr.Logger
+	// Namespace is the namespace of the reconciled object. Credential repositories backed by
+	// Kubernetes Secrets may only read secrets from this namespace.
+	Namespace string
 }
 
 // NewCredentialGraph creates a credential graph from the given configuration.
@@ -33,6 +36,9 @@ func NewCredentialGraph(ctx context.Context, config *genericv1.Config, opts Cred
 	if credCfg == nil {
 		credCfg = &credentialsConfig.Config{}
 	}
+	if err := restrictKubernetesSecrets(credCfg, opts.Namespace); err != nil {
+		return nil, err
+	}
 
 	credOpts := credentials.Options{
 		RepositoryPluginProvider: opts.PluginManager.CredentialRepositoryRegistry,
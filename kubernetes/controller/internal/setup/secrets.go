@@ -0,0 +1,83 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	credentialsConfig "ocm.software/open-component-model/bindings/go/credentials/spec/config/runtime"
+	ocicredentials "ocm.software/open-component-model/bindings/go/oci/credentials"
+	ocicredentialspec "ocm.software/open-component-model/bindings/go/oci/spec/credentials"
+	credentialsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
+)
+
+// SecretProvider reads the secrets of KubernetesSecrets credential repositories from the cluster
+// the controller runs in. Configurations referencing a kubeconfig are rejected, so that tenants
+// cannot make the controller read files from its filesystem or access other clusters.
+//
+// The provider reads secrets with the permissions of the controller. The namespace of a configuration
+// must therefore be pinned to the namespace of the reconciled object before, see NewCredentialGraph.
+type SecretProvider struct {
+	// Reader reads secrets from the cluster of the controller. It should not be backed by the
+	// cache of the manager, so that the controller does not watch all secrets of the cluster.
+	Reader client.Reader
+}
+
+var _ ocicredentials.SecretProvider = (*SecretProvider)(nil)
+
+// GetSecretData returns the data of the secret with the given name in the namespace of cfg.
+func (p *SecretProvider) GetSecretData(ctx context.Context, cfg *credentialsv1.KubernetesSecrets, name string) (map[string][]byte, error) {
+	if cfg.Kubeconfig != "" {
+		return nil, fmt.Errorf("kubeconfig is not supported by the controller, secrets are read from the cluster of the controller")
+	}
+	if cfg.Namespace == "" {
+		return nil, fmt.Errorf("namespace of secret %q is required", name)
+	}
+
+	var secret corev1.Secret
+	if err := p.Reader.Get(ctx, client.ObjectKey{Namespace: cfg.Namespace, Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", cfg.Namespace, name, err)
+	}
+
+	return secret.Data, nil
+}
+
+// restrictKubernetesSecrets pins the KubernetesSecrets credential repositories of config to namespace, the
+// namespace of the reconciled object. Repositories without a namespace default to it, repositories of other
+// namespaces or with a kubeconfig are rejected, so that a tenant cannot read the secrets of other tenants.
+func restrictKubernetesSecrets(config *credentialsConfig.Config, namespace string) error {
+	for i, entry := range config.Repositories {
+		if entry.Repository == nil || !ocicredentialspec.Scheme.IsRegistered(entry.Repository.GetType()) {
+			continue
+		}
+		obj, err := ocicredentialspec.Scheme.NewObject(entry.Repository.GetType())
+		if err != nil {
+			return fmt.Errorf("failed to create credential repository of type %q: %w", entry.Repository.GetType(), err)
+		}
+		secrets, ok := obj.(*credentialsv1.KubernetesSecrets)
+		if !ok {
+			continue
+		}
+		if err := ocicredentialspec.Scheme.Convert(entry.Repository, secrets); err != nil {
+			return fmt.Errorf("failed to convert credential repository of type %q: %w", entry.Repository.GetType(), err)
+		}
+
+		if secrets.Kubeconfig != "" {
+			return fmt.Errorf("credential repository %s: kubeconfig is not supported by the controller", secrets)
+		}
+		if namespace == "" {
+			return fmt.Errorf("credential repository %s: secrets can only be read for namespaced objects", secrets)
+		}
+		if secrets.Namespace == "" {
+			secrets.Namespace = namespace
+		}
+		if secrets.Namespace != namespace {
+			return fmt.Errorf("credential repository %s: secrets can only be read from namespace %q of the object", secrets, namespace)
+		}
+		config.Repositories[i].Repository = secrets
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package setup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	credentialsConfig "ocm.software/open-component-model/bindings/go/credentials/spec/config/runtime"
+	credentialsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func kubernetesSecretsConfig(t *testing.T, data string) *credentialsConfig.Config {
+	t.Helper()
+	raw := &runtime.Raw{}
+	require.NoError(t, raw.UnmarshalJSON([]byte(data)))
+	return &credentialsConfig.Config{
+		Repositories: []credentialsConfig.RepositoryConfigEntry{{Repository: raw}},
+	}
+}
+
+func TestRestrictKubernetesSecrets(t *testing.T) {
+	t.Run("defaults to the namespace of the object", func(t *testing.T) {
+		r := require.New(t)
+		cfg := kubernetesSecretsConfig(t, `{"type":"KubernetesSecrets/v1","secretNames":["registry"]}`)
+		r.NoError(restrictKubernetesSecrets(cfg, "tenant-a"))
+		secrets, ok := cfg.Repositories[0].Repository.(*credentialsv1.KubernetesSecrets)
+		r.True(ok)
+		r.Equal("tenant-a", secrets.Namespace)
+	})
+
+	t.Run("same namespace", func(t *testing.T) {
+		r := require.New(t)
+		cfg := kubernetesSecretsConfig(t, `{"type":"KubernetesSecrets/v1","namespace":"tenant-a","secretNames":["registry"]}`)
+		r.NoError(restrictKubernetesSecrets(cfg, "tenant-a"))
+	})
+
+	t.Run("other namespace", func(t *testing.T) {
+		r := require.New(t)
+		cfg := kubernetesSecretsConfig(t, `{"type":"KubernetesSecrets/v1","namespace":"tenant-b","secretNames":["registry"]}`)
+		r.ErrorContains(restrictKubernetesSecrets(cfg, "tenant-a"), `only be read from namespace "tenant-a"`)
+	})
+
+	t.Run("kubeconfig", func(t *testing.T) {
+		r := require.New(t)
+		cfg := kubernetesSecretsConfig(t, `{"type":"KubernetesSecrets/v1","kubeconfig":"/etc/kubeconfig","secretNames":["registry"]}`)
+		r.ErrorContains(restrictKubernetesSecrets(cfg, "tenant-a"), "kubeconfig is not supported")
+	})
+
+	t.Run("without namespace of the object", func(t *testing.T) {
+		r := require.New(t)
+		cfg := kubernetesSecretsConfig(t, `{"type":"KubernetesSecrets/v1","secretNames":["registry"]}`)
+		r.ErrorContains(restrictKubernetesSecrets(cfg, ""), "namespaced objects")
+	})
+
+	t.Run("other repositories", func(t *testing.T) {
+		r := require.New(t)
+		cfg := kubernetesSecretsConfig(t, `{"type":"DockerConfig/v1","dockerConfigFile":"~/.docker/config.json"}`)
+		r.NoError(restrictKubernetesSecrets(cfg, ""))
+		r.IsType(&runtime.Raw{}, cfg.Repositories[0].Repository)
+	})
+}
+
+func TestSecretProvider_GetSecretData(t *testing.T) {
+	ctx := t.Context()
+	provider := &SecretProvider{Reader: fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "tenant-a"},
+		Data:       map[string][]byte{".dockerconfigjson": []byte("{}")},
+	}).Build()}
+
+	t.Run("secret", func(t *testing.T) {
+		r := require.New(t)
+		data, err := provider.GetSecretData(ctx, &credentialsv1.KubernetesSecrets{Namespace: "tenant-a"}, "registry")
+		r.NoError(err)
+		r.Equal([]byte("{}"), data[".dockerconfigjson"])
+	})
+
+	t.Run("kubeconfig", func(t *testing.T) {
+		r := require.New(t)
+		_, err := provider.GetSecretData(ctx, &credentialsv1.KubernetesSecrets{Namespace: "tenant-a", Kubeconfig: "/etc/kubeconfig"}, "registry")
+		r.ErrorContains(err, "kubeconfig is not supported")
+	})
+
+	t.Run("without namespace", func(t *testing.T) {
+		r := require.New(t)
+		_, err := provider.GetSecretData(ctx, &credentialsv1.KubernetesSecrets{}, "registry")
+		r.ErrorContains(err, "namespace")
+	})
+}
@@ -51,3 +51,10 @@ func MarkReady(recorder kuberecorder.EventRecorder, obj IdentifiableClientObject
 	})
 	event.New(recorder, obj, nil, v1alpha1.EventSeverityInfo, msg, messageArgs...)
 }
+
+// MarkResolutionQuotaExceeded sets the condition status of an Object to `Not Ready` because the namespace
+// of the Object exhausted its resolution quota. The error is expected to be returned from the reconciliation,
+// so that it is retried with backoff until resolutions of the namespace completed.
+func MarkResolutionQuotaExceeded(recorder kuberecorder.EventRecorder, obj IdentifiableClientObject, err error) {
+	MarkNotReady(recorder, obj, v1alpha1.ResolutionQuotaExceededReason, err.Error())
+}
@@ -0,0 +1,127 @@
+// Package tracing wires OpenTelemetry tracing into the controller.
+//
+// Every reconcile starts a root span carrying the identifiers of the reconciled object, see StartReconcile.
+// Work derived from a reconcile, such as component version resolutions in the worker pool and calls into plugins,
+// is recorded as child spans, so a slow resolution or deployment can be followed across components.
+//
+// Spans are exported via OTLP/gRPC once Setup was called with an endpoint. Without it, the global no-op tracer
+// provider of OpenTelemetry is used and all spans are discarded.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	// instrumentationName is the name of the tracer used by the controller.
+	instrumentationName = "ocm.software/open-component-model/kubernetes/controller"
+
+	// DefaultServiceName is the service name reported with all spans if no other name is configured.
+	DefaultServiceName = "ocm-k8s-toolkit"
+)
+
+// Attribute keys set on the spans of the controller.
+const (
+	ControllerKey  = attribute.Key("ocm.controller")
+	NamespaceKey   = attribute.Key("k8s.namespace.name")
+	NameKey        = attribute.Key("ocm.object.name")
+	ReconcileIDKey = attribute.Key("ocm.reconcile.id")
+	ComponentKey   = attribute.Key("ocm.component.name")
+	VersionKey     = attribute.Key("ocm.component.version")
+	ResourceKey    = attribute.Key("ocm.resource.name")
+)
+
+// Options configures the export of spans.
+type Options struct {
+	// Endpoint is the host and port of the OTLP/gRPC collector. Tracing is disabled if empty.
+	Endpoint string
+	// Insecure disables transport security for the connection to the collector.
+	Insecure bool
+	// SampleRatio is the fraction of traces that are sampled, between 0 and 1.
+	// Spans with a sampled parent are always sampled.
+	SampleRatio float64
+	// ServiceName is reported as service.name with all spans. Defaults to DefaultServiceName.
+	ServiceName string
+}
+
+// Setup installs a global tracer provider exporting spans to the configured OTLP endpoint and a W3C trace context
+// propagator. The returned function flushes pending spans and shuts down the provider.
+// If no endpoint is configured, Setup does nothing and returns a no-op shutdown function.
+func Setup(ctx context.Context, opts Options) (shutdown func(context.Context) error, err error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if opts.SampleRatio < 0 || opts.SampleRatio > 1 {
+		return nil, fmt.Errorf("trace sample ratio must be between 0 and 1, got %v", opts.SampleRatio)
+	}
+	if opts.ServiceName == "" {
+		opts.ServiceName = DefaultServiceName
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", opts.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span with the given name and attributes as child of the span in the context.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartReconcile starts the root span of a reconcile of the given controller.
+// The span carries the namespace and name of the reconciled object and the reconcile ID assigned by
+// controller-runtime, so it can be correlated with the logs of the reconcile.
+func StartReconcile(ctx context.Context, controllerName string, req ctrl.Request) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, controllerName+".Reconcile",
+		trace.WithNewRoot(),
+		trace.WithAttributes(
+			ControllerKey.String(controllerName),
+			NamespaceKey.String(req.Namespace),
+			NameKey.String(req.Name),
+			ReconcileIDKey.String(string(controller.ReconcileIDFromContext(ctx))),
+		),
+	)
+}
+
+// End records the error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestSetup(t *testing.T) {
+	r := require.New(t)
+
+	shutdown, err := Setup(t.Context(), Options{})
+	r.NoError(err)
+	r.NoError(shutdown(t.Context()))
+
+	_, err = Setup(t.Context(), Options{Endpoint: "localhost:4317", SampleRatio: 1.5})
+	r.ErrorContains(err, "trace sample ratio must be between 0 and 1")
+}
+
+func TestReconcileSpans(t *testing.T) {
+	r := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, reconcile := StartReconcile(t.Context(), "Resource", ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-resource"},
+	})
+	_, child := Start(ctx, "WorkerPool.Resolve", ComponentKey.String("ocm.software/test"))
+	End(child, errors.New("resolution failed"))
+	End(reconcile, nil)
+
+	spans := exporter.GetSpans()
+	r.Len(spans, 2)
+
+	resolve, root := spans[0], spans[1]
+	r.Equal("Resource.Reconcile", root.Name)
+	r.Contains(root.Attributes, ControllerKey.String("Resource"))
+	r.Contains(root.Attributes, NamespaceKey.String("default"))
+	r.Contains(root.Attributes, NameKey.String("my-resource"))
+	r.Equal(codes.Unset, root.Status.Code)
+
+	r.Equal("WorkerPool.Resolve", resolve.Name)
+	r.Equal(root.SpanContext.TraceID(), resolve.SpanContext.TraceID())
+	r.Equal(root.SpanContext.SpanID(), resolve.Parent.SpanID())
+	r.Contains(resolve.Attributes, ComponentKey.String("ocm.software/test"))
+	r.Equal(codes.Error, resolve.Status.Code)
+	r.Equal("resolution failed", resolve.Status.Description)
+	r.Len(resolve.Events, 1, "the error must be recorded as span event")
+}
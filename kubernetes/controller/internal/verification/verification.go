@@ -9,14 +9,17 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"ocm.software/open-component-model/bindings/go/runtime"
+	sigstorev1alpha1 "ocm.software/open-component-model/bindings/go/sigstore/signing/v1alpha1"
 	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
 )
 
 // Verification is an internal representation of v1alpha1.Verification where the public key is already extracted from
-// the value or secret.
+// the value or secret. Keyless Sigstore verifications carry their identity constraints instead of a public key.
 type Verification struct {
-	Signature string `json:"signature"`
-	PublicKey []byte `json:"publicKey"`
+	Signature string                         `json:"signature"`
+	PublicKey []byte                         `json:"publicKey"`
+	Sigstore  *v1alpha1.SigstoreVerification `json:"sigstore,omitempty"`
 }
 
 func GetVerifications(ctx context.Context, client ctrl.Reader,
@@ -31,6 +34,17 @@ func GetVerifications(ctx context.Context, client ctrl.Reader,
 		internal := Verification{
 			Signature: verification.Signature,
 		}
+		if verification.Sigstore != nil {
+			if verification.Value != "" || verification.SecretRef.Name != "" {
+				return nil, reconcile.TerminalError(fmt.Errorf("sigstore cannot be set together with value or secret ref for signature: %s", verification.Signature))
+			}
+			internal.Sigstore = verification.Sigstore.DeepCopy()
+			if err := internal.SigstoreConfig().Validate(); err != nil {
+				return nil, reconcile.TerminalError(fmt.Errorf("invalid sigstore verification for signature %q: %w", verification.Signature, err))
+			}
+			v = append(v, internal)
+			continue
+		}
 		if verification.Value == "" && verification.SecretRef.Name == "" {
 			return nil, reconcile.TerminalError(fmt.Errorf("value and secret ref cannot both be empty for signature: %s", verification.Signature))
 		}
@@ -60,3 +74,18 @@ func GetVerifications(ctx context.Context, client ctrl.Reader,
 
 	return v, nil
 }
+
+// SigstoreConfig returns the configuration of the Sigstore signing handler verifying the signature with the
+// identity constraints of the verification, or nil if the signature is verified with a public key.
+func (v Verification) SigstoreConfig() *sigstorev1alpha1.VerifyConfig {
+	if v.Sigstore == nil {
+		return nil
+	}
+	return &sigstorev1alpha1.VerifyConfig{
+		Type:                        runtime.NewVersionedType(sigstorev1alpha1.VerifyConfigType, sigstorev1alpha1.Version),
+		CertificateOIDCIssuer:       v.Sigstore.CertificateOIDCIssuer,
+		CertificateOIDCIssuerRegexp: v.Sigstore.CertificateOIDCIssuerRegexp,
+		CertificateIdentity:         v.Sigstore.CertificateIdentity,
+		CertificateIdentityRegexp:   v.Sigstore.CertificateIdentityRegexp,
+	}
+}
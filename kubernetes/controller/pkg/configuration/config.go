@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"slices"
-	"strings"
 	"sync"
 
 	"github.com/docker/cli/cli/config/configfile"
@@ -20,6 +19,7 @@ import (
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
 	ocmconfigv1spec "ocm.software/open-component-model/bindings/go/configuration/ocm/v1/spec"
 	resolversv1alpha1spec "ocm.software/open-component-model/bindings/go/configuration/resolvers/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/configuration/sops"
 	credentialsv1spec "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
 	ocicredentials "ocm.software/open-component-model/bindings/go/oci/spec/credentials"
 	ocicredentialsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
@@ -97,21 +97,64 @@ func filterAllowedConfigTypes(ctx context.Context, cfg *genericv1.Config) (*gene
 	return filtered, nil
 }
 
+// LoadOptions configures how OCM configuration data is loaded.
+type LoadOptions struct {
+	// Decryptor decrypts OCM configuration data encrypted with sops.
+	// Without a decryptor, encrypted configuration data is rejected.
+	Decryptor *sops.Decryptor
+}
+
+// LoadOption configures how OCM configuration data is loaded.
+type LoadOption func(*LoadOptions)
+
+// WithDecryptor sets the decryptor for OCM configuration data encrypted with sops.
+func WithDecryptor(decryptor *sops.Decryptor) LoadOption {
+	return func(o *LoadOptions) {
+		o.Decryptor = decryptor
+	}
+}
+
+// decodeConfig decodes OCM configuration data, decrypting it first if it is encrypted with sops.
+func decodeConfig(ctx context.Context, data []byte, opts []LoadOption) (*genericv1.Config, error) {
+	options := &LoadOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if sops.IsEncrypted(data) {
+		if options.Decryptor == nil {
+			return nil, errors.New("ocm config is encrypted with sops, but decryption is not configured")
+		}
+		decrypted, err := options.Decryptor.Decrypt(ctx, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt ocm config: %w", err)
+		}
+		data = decrypted
+	}
+
+	var cfg genericv1.Config
+	if err := genericv1.Scheme.Decode(bytes.NewReader(data), &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
 // GetConfigFromSecret extracts and decodes OCM configuration from a Kubernetes Secret.
-// It looks for configuration data under the OCMConfigKey.
-func GetConfigFromSecret(secret *corev1.Secret) (*genericv1.Config, error) {
+// It looks for configuration data under the OCMConfigKey, which may be encrypted with sops.
+func GetConfigFromSecret(ctx context.Context, secret *corev1.Secret, opts ...LoadOption) (*genericv1.Config, error) {
 	if data, ok := secret.Data[v1alpha1.OCMConfigKey]; ok {
 		if len(data) == 0 {
 			return nil, errors.New("no OCM config data found in secret")
 		}
 
-		var cfg genericv1.Config
-		if err := genericv1.Scheme.Decode(bytes.NewReader(data), &cfg); err != nil {
+		cfg, err := decodeConfig(ctx, data, opts)
+		if err != nil {
 			return nil, fmt.Errorf("failed to decode ocm config from secret %s/%s: %w",
 				secret.Namespace, secret.Name, err)
 		}
 
-		return &cfg, nil
+		return cfg, nil
 	}
 
 	if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
@@ -167,29 +210,29 @@ func createConfigFromDockerConfig(data []byte) (*genericv1.Config, error) {
 }
 
 // GetConfigFromConfigMap extracts and decodes OCM configuration from a Kubernetes ConfigMap.
-// It looks for configuration data under the OCMConfigKey.
-func GetConfigFromConfigMap(configMap *corev1.ConfigMap) (*genericv1.Config, error) {
+// It looks for configuration data under the OCMConfigKey, which may be encrypted with sops.
+func GetConfigFromConfigMap(ctx context.Context, configMap *corev1.ConfigMap, opts ...LoadOption) (*genericv1.Config, error) {
 	data, ok := configMap.Data[v1alpha1.OCMConfigKey]
 	if !ok || len(data) == 0 {
 		return nil, errors.New("no ocm config found in configmap")
 	}
 
-	var cfg genericv1.Config
-	if err := genericv1.Scheme.Decode(strings.NewReader(data), &cfg); err != nil {
+	cfg, err := decodeConfig(ctx, []byte(data), opts)
+	if err != nil {
 		return nil, fmt.Errorf("failed to decode ocm config from configmap %s/%s: %w",
 			configMap.Namespace, configMap.Name, err)
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
 
 // GetConfigFromObject extracts configuration from either a Secret or ConfigMap.
-func GetConfigFromObject(obj client.Object) (*genericv1.Config, error) {
+func GetConfigFromObject(ctx context.Context, obj client.Object, opts ...LoadOption) (*genericv1.Config, error) {
 	switch o := obj.(type) {
 	case *corev1.Secret:
-		return GetConfigFromSecret(o)
+		return GetConfigFromSecret(ctx, o, opts...)
 	case *corev1.ConfigMap:
-		return GetConfigFromConfigMap(o)
+		return GetConfigFromConfigMap(ctx, o, opts...)
 	default:
 		return nil, fmt.Errorf("unsupported configuration object type: %T", obj)
 	}
@@ -200,13 +243,18 @@ func GetConfigFromObject(obj client.Object) (*genericv1.Config, error) {
 type Configuration struct {
 	Hash   []byte
 	Config *genericv1.Config
+	// Namespace is the namespace of the object the configuration was loaded for. Configurations
+	// accessing the cluster, such as credentials stored in Kubernetes Secrets, are restricted to it.
+	// It is part of the hash, so that equal configurations of different namespaces are not shared.
+	Namespace string
 }
 
 // LoadConfigurations loads OCM configurations from a list of OCMConfiguration references.
 // It fetches the referenced Secrets/ConfigMaps from the cluster and extracts their configuration into a flat map and
 // calculates the hash of the configuration data. The object fetching happens concurrently, but Spec declaration order
 // is preserved. Meaning, in whatever order the original object declared the configuration, that order is preserved.
-func LoadConfigurations(ctx context.Context, k8sClient client.Reader, namespace string, ocmConfigs []v1alpha1.OCMConfiguration) (*Configuration, error) {
+// Configuration data encrypted with sops is decrypted with the decryptor configured by WithDecryptor.
+func LoadConfigurations(ctx context.Context, k8sClient client.Reader, namespace string, ocmConfigs []v1alpha1.OCMConfiguration, opts ...LoadOption) (*Configuration, error) {
 	if len(ocmConfigs) == 0 {
 		return nil, nil
 	}
@@ -218,7 +266,7 @@ func LoadConfigurations(ctx context.Context, k8sClient client.Reader, namespace
 
 	var configs []*genericv1.Config
 	for _, obj := range objects {
-		cfg, err := GetConfigFromObject(obj)
+		cfg, err := GetConfigFromObject(ctx, obj, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -247,11 +295,13 @@ func LoadConfigurations(ctx context.Context, k8sClient client.Reader, namespace
 
 	hasher := sha256.New()
 	hasher.Write(content)
+	hasher.Write([]byte(namespace))
 	hash := hasher.Sum(nil)
 
 	result := Configuration{
-		Config: flattenedFiltered,
-		Hash:   hash,
+		Config:    flattenedFiltered,
+		Hash:      hash,
+		Namespace: namespace,
 	}
 
 	return &result, nil
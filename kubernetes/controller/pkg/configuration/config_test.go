@@ -2,6 +2,7 @@ package configuration
 
 import (
 	"context"
+	"os"
 	"strings"
 	"testing"
 
@@ -17,6 +18,7 @@ import (
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
 	ocmconfigv1spec "ocm.software/open-component-model/bindings/go/configuration/ocm/v1/spec"
 	resolversv1alpha1spec "ocm.software/open-component-model/bindings/go/configuration/resolvers/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/configuration/sops"
 	credentialsv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
 	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
@@ -187,7 +189,7 @@ func TestGetConfigFromSecret(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg, err := GetConfigFromSecret(tt.secret)
+			cfg, err := GetConfigFromSecret(t.Context(), tt.secret)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -238,7 +240,7 @@ func TestGetConfigFromConfigMap(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg, err := GetConfigFromConfigMap(tt.configMap)
+			cfg, err := GetConfigFromConfigMap(t.Context(), tt.configMap)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -253,6 +255,31 @@ func TestGetConfigFromConfigMap(t *testing.T) {
 	}
 }
 
+func TestGetConfigFromSecret_SOPSEncrypted(t *testing.T) {
+	r := require.New(t)
+	data, err := os.ReadFile("testdata/ocmconfig.sops.yaml")
+	r.NoError(err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "encrypted", Namespace: "default"},
+		Data:       map[string][]byte{v1alpha1.OCMConfigKey: data},
+	}
+
+	_, err = GetConfigFromSecret(t.Context(), secret)
+	r.ErrorContains(err, "ocm config is encrypted with sops, but decryption is not configured")
+
+	keys, err := sops.AgeKeySourceFromFile("testdata/sops-age-keys.txt")
+	r.NoError(err)
+	cfg, err := GetConfigFromSecret(t.Context(), secret, WithDecryptor(sops.NewDecryptor(keys)))
+	r.NoError(err)
+	r.Len(cfg.Configurations, 1)
+	r.JSONEq(`{"type":"credentials.config.ocm.software","consumers":[{"identity":{"type":"HelmChartRepository","hostname":"charts.example.com"},"credentials":[{"type":"Credentials/v1","properties":{"username":"username","password":"password"}}]}]}`,
+		string(cfg.Configurations[0].Data))
+
+	secret.Data[v1alpha1.OCMConfigKey] = []byte(strings.Replace(string(data), "2026-01-02T03:04:05Z", "2026-01-02T03:04:06Z", 1))
+	_, err = GetConfigFromSecret(t.Context(), secret, WithDecryptor(sops.NewDecryptor(keys)))
+	r.ErrorContains(err, "failed to decrypt ocm config")
+}
+
 func TestLoadConfigurations(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, corev1.AddToScheme(scheme))
@@ -3,6 +3,7 @@
 //
 // It supports native OCM config entries as well as docker-style registry
 // credentials and
+//   - decrypts configuration data encrypted with sops, see [WithDecryptor],
 //   - flattens multiple configuration sources into a single [Configuration],
 //   - calculates a content-addressable hash, and
 //   - enforces a hardcoded strict allowlist of accepted config types (config